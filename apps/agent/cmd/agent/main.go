@@ -2,7 +2,9 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
+	"io"
 	"log/slog"
 	"os"
 	"os/signal"
@@ -10,8 +12,13 @@ import (
 	"time"
 
 	"github.com/paasdeploy/agent/internal/agent"
+	"github.com/paasdeploy/agent/internal/agentlog"
 	"github.com/paasdeploy/agent/internal/cleanup"
 	"github.com/paasdeploy/agent/internal/grpcserver"
+	"github.com/paasdeploy/agent/internal/prepull"
+	"github.com/paasdeploy/agent/internal/selfupdate"
+	"github.com/paasdeploy/shared/pkg/tracing"
+	"golang.org/x/sys/unix"
 )
 
 func main() {
@@ -21,9 +28,55 @@ func main() {
 	cert := flag.String("cert", "", "path to this agent's PEM TLS certificate")
 	key := flag.String("key", "", "path to this agent's PEM TLS private key")
 	agentPort := flag.Int("agent-port", 50052, "TCP port for the agent gRPC API server")
+	updateGrace := flag.Duration("update-grace", 90*time.Second, "time a freshly updated binary has to become healthy before rolling back")
+	logFilePath := flag.String("log-file", "", "optional path to also write agent logs to, rotated by size")
+	logMaxSizeMB := flag.Int("log-max-size", 10, "size in MB after which the log file is rotated")
+	logMaxBackups := flag.Int("log-max-backups", 5, "number of rotated log files to keep")
+	maxBuilds := flag.Int("max-builds", 0, "maximum image builds running at once (0 sizes it from the server's memory, one per 2 GiB)")
+	prepullInterval := flag.Duration("prepull-interval", time.Hour, "how often to pre-pull the base images of recently deployed apps while idle (0 disables)")
 	flag.Parse()
 
-	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{}))
+	var logOutput io.Writer = os.Stdout
+	var logFile *agentlog.RotatingFile
+	if *logFilePath != "" {
+		rf, err := agentlog.OpenRotatingFile(*logFilePath, int64(*logMaxSizeMB)*1024*1024, *logMaxBackups)
+		if err != nil {
+			slog.Error("failed to open log file", "path", *logFilePath, "error", err)
+			os.Exit(1)
+		}
+		defer rf.Close()
+		logFile = rf
+		logOutput = io.MultiWriter(os.Stdout, rf)
+	}
+
+	logger := slog.New(tracing.NewHandler(slog.NewTextHandler(logOutput, &slog.HandlerOptions{})))
+
+	execPath, err := os.Executable()
+	if err != nil {
+		logger.Error("failed to resolve executable path", "error", err)
+		os.Exit(1)
+	}
+
+	updatePending, err := selfupdate.BeginBoot(execPath)
+	if errors.Is(err, selfupdate.ErrRolledBack) {
+		reexec(execPath, logger)
+	}
+	if err != nil {
+		logger.Error("failed to check pending update", "error", err)
+	}
+
+	grpcSrv, err := grpcserver.New(grpcserver.Config{
+		Port:      *agentPort,
+		CertPath:  *cert,
+		KeyPath:   *key,
+		CAPath:    *caCert,
+		LogFile:   logFile,
+		MaxBuilds: *maxBuilds,
+	}, logger)
+	if err != nil {
+		logger.Error("failed to initialize grpc server", "error", err)
+		os.Exit(1)
+	}
 
 	cfg := agent.Config{
 		ServerAddr: *serverAddr,
@@ -31,6 +84,7 @@ func main() {
 		CACertPath: *caCert,
 		CertPath:   *cert,
 		KeyPath:    *key,
+		Commands:   grpcSrv,
 	}
 
 	a, err := agent.New(cfg, logger)
@@ -39,19 +93,13 @@ func main() {
 		os.Exit(1)
 	}
 
-	grpcSrv, err := grpcserver.New(grpcserver.Config{
-		Port:     *agentPort,
-		CertPath: *cert,
-		KeyPath:  *key,
-		CAPath:   *caCert,
-	}, logger)
-	if err != nil {
-		logger.Error("failed to initialize grpc server", "error", err)
-		os.Exit(1)
-	}
-
 	cleanupScheduler := cleanup.NewScheduler(grpcSrv.Docker(), logger)
 
+	var imageWarmer *prepull.Warmer
+	if *prepullInterval > 0 {
+		imageWarmer = prepull.NewWarmer(grpcSrv.Docker(), grpcSrv.DeployExecutor(), *prepullInterval, logger)
+	}
+
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
@@ -69,14 +117,44 @@ func main() {
 		}
 	}()
 
+	if updatePending {
+		go func() {
+			gateErr := selfupdate.RunHealthGate(ctx, execPath, *updateGrace, logger,
+				selfupdate.Check{Name: "grpc", Run: grpcSrv.CheckServing},
+				selfupdate.Check{Name: "backend", Run: a.CheckRegistered},
+			)
+			if errors.Is(gateErr, selfupdate.ErrRolledBack) {
+				grpcSrv.Stop()
+				reexec(execPath, logger)
+			}
+			if gateErr != nil && ctx.Err() == nil {
+				logger.Error("update health gate error", "error", gateErr)
+			}
+		}()
+	}
+
 	go cleanupScheduler.RunOnce(ctx)
 	cleanupScheduler.Start(ctx)
+	if imageWarmer != nil {
+		imageWarmer.Start(ctx)
+	}
 
 	waitForShutdown(ctx, cancel)
 	cleanupScheduler.Stop()
+	if imageWarmer != nil {
+		imageWarmer.Stop()
+	}
 	grpcSrv.Stop()
 }
 
+func reexec(execPath string, logger *slog.Logger) {
+	logger.Warn("restarting previous agent binary", "path", execPath)
+	if err := unix.Exec(execPath, os.Args, os.Environ()); err != nil {
+		logger.Error("exec failed", "error", err)
+		os.Exit(1)
+	}
+}
+
 func waitForShutdown(ctx context.Context, cancel context.CancelFunc) {
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
@@ -7,12 +7,15 @@ import (
 	"io"
 	"log/slog"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
+	"runtime"
 	"sync"
 	"time"
 
 	"github.com/paasdeploy/agent/internal/grpcclient"
+	"github.com/paasdeploy/agent/internal/selfupdate"
 	pb "github.com/paasdeploy/backend/gen/go/flowdeploy/v1"
 	"golang.org/x/sys/unix"
 )
@@ -22,6 +25,10 @@ var Version = "dev"
 const (
 	selfUpdateTimeout = 2 * time.Minute
 	tempBinaryName    = "agent.new"
+
+	// commandQueueSize bounds the queued commands waiting to run. Commands
+	// arriving while it is full are reported as failed.
+	commandQueueSize = 32
 )
 
 type Config struct {
@@ -30,14 +37,24 @@ type Config struct {
 	CACertPath string
 	CertPath   string
 	KeyPath    string
+	Commands   CommandExecutor
+}
+
+type CommandExecutor interface {
+	ExecuteCommand(ctx context.Context, cmd *pb.AgentCommand) *pb.AgentCommandResult
 }
 
 type Agent struct {
-	cfg         Config
-	client      *grpcclient.Client
-	logger      *slog.Logger
-	updateErrMu sync.Mutex
-	updateError string
+	cfg          Config
+	client       *grpcclient.Client
+	logger       *slog.Logger
+	updateErrMu  sync.Mutex
+	updateError  string
+	registered   chan struct{}
+	registerOnce sync.Once
+	resultsMu    sync.Mutex
+	results      []*pb.AgentCommandResult
+	commands     chan *pb.AgentCommand
 }
 
 func New(cfg Config, logger *slog.Logger) (*Agent, error) {
@@ -50,11 +67,32 @@ func New(cfg Config, logger *slog.Logger) (*Agent, error) {
 		return nil, err
 	}
 
-	return &Agent{
-		cfg:    cfg,
-		client: client,
-		logger: logger.With("component", "agent"),
-	}, nil
+	a := &Agent{
+		cfg:        cfg,
+		client:     client,
+		logger:     logger.With("component", "agent"),
+		registered: make(chan struct{}),
+		commands:   make(chan *pb.AgentCommand, commandQueueSize),
+	}
+
+	if execPath, err := os.Executable(); err == nil {
+		if reason := selfupdate.ConsumeFailure(execPath); reason != "" {
+			a.logger.Warn("previous update was rolled back", "reason", reason)
+			a.setUpdateError(reason)
+		}
+	}
+
+	return a, nil
+}
+
+// CheckRegistered succeeds once the agent has registered with the backend.
+func (a *Agent) CheckRegistered(ctx context.Context) error {
+	select {
+	case <-a.registered:
+		return nil
+	default:
+		return errors.New("not registered with backend yet")
+	}
 }
 
 func (a *Agent) Run(ctx context.Context) error {
@@ -62,6 +100,8 @@ func (a *Agent) Run(ctx context.Context) error {
 		return err
 	}
 
+	go a.runCommands(ctx)
+
 	ticker := time.NewTicker(30 * time.Second)
 	defer ticker.Stop()
 
@@ -86,6 +126,7 @@ func (a *Agent) registerWithRetry(ctx context.Context) error {
 		err := a.register(ctx)
 		if err == nil {
 			a.logger.Info("registered with backend")
+			a.registerOnce.Do(func() { close(a.registered) })
 			return nil
 		}
 
@@ -131,15 +172,19 @@ func (a *Agent) heartbeat(ctx context.Context) error {
 	if updateErr := a.consumeUpdateError(); updateErr != "" {
 		req.UpdateError = &updateErr
 	}
+	req.CommandResults = a.takeCommandResults()
 
 	resp, err := a.client.Heartbeat(ctx, req)
 	if err != nil {
+		a.requeueCommandResults(req.CommandResults)
 		return err
 	}
 	a.processCommands(resp.GetCommands())
 	return nil
 }
 
+// processCommands hands commands to runCommands so a slow command never
+// delays the next heartbeat.
 func (a *Agent) processCommands(commands []*pb.AgentCommand) {
 	for _, cmd := range commands {
 		if cmd == nil {
@@ -147,20 +192,96 @@ func (a *Agent) processCommands(commands []*pb.AgentCommand) {
 		}
 		switch cmd.Type {
 		case pb.AgentCommandType_AGENT_COMMAND_UPDATE_AGENT:
-			a.handleUpdateAgent(cmd.GetPayload())
+			a.handleUpdateAgent(cmd)
+		case pb.AgentCommandType_AGENT_COMMAND_START_CONTAINER,
+			pb.AgentCommandType_AGENT_COMMAND_STOP_CONTAINER,
+			pb.AgentCommandType_AGENT_COMMAND_RESTART_CONTAINER,
+			pb.AgentCommandType_AGENT_COMMAND_REMOVE_CONTAINER,
+			pb.AgentCommandType_AGENT_COMMAND_UPDATE_DOMAINS:
+			a.queueCommand(cmd)
 		default:
 			a.logger.Debug("ignoring unknown command", "type", cmd.Type)
 		}
 	}
 }
 
-func (a *Agent) handleUpdateAgent(payload string) {
+func (a *Agent) queueCommand(cmd *pb.AgentCommand) {
+	select {
+	case a.commands <- cmd:
+	default:
+		a.logger.Warn("command queue full, rejecting command", "commandId", cmd.GetId(), "type", cmd.GetType())
+		a.addCommandResult(&pb.AgentCommandResult{
+			CommandId: cmd.GetId(),
+			Message:   "agent command queue is full",
+		})
+	}
+}
+
+// runCommands executes queued commands one at a time, in delivery order, and
+// keeps their results for the next heartbeat.
+func (a *Agent) runCommands(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case cmd := <-a.commands:
+			a.executeQueuedCommand(ctx, cmd)
+		}
+	}
+}
+
+func (a *Agent) executeQueuedCommand(ctx context.Context, cmd *pb.AgentCommand) {
+	if a.cfg.Commands == nil {
+		a.addCommandResult(&pb.AgentCommandResult{
+			CommandId: cmd.GetId(),
+			Message:   "agent cannot execute queued commands",
+		})
+		return
+	}
+	a.addCommandResult(a.cfg.Commands.ExecuteCommand(ctx, cmd))
+}
+
+func (a *Agent) addCommandResult(result *pb.AgentCommandResult) {
+	if result == nil || result.GetCommandId() == "" {
+		return
+	}
+	a.resultsMu.Lock()
+	a.results = append(a.results, result)
+	a.resultsMu.Unlock()
+}
+
+func (a *Agent) takeCommandResults() []*pb.AgentCommandResult {
+	a.resultsMu.Lock()
+	defer a.resultsMu.Unlock()
+	results := a.results
+	a.results = nil
+	return results
+}
+
+func (a *Agent) requeueCommandResults(results []*pb.AgentCommandResult) {
+	if len(results) == 0 {
+		return
+	}
+	a.resultsMu.Lock()
+	a.results = append(results, a.results...)
+	a.resultsMu.Unlock()
+}
+
+// handleUpdateAgent starts a self-update. Failures are reported as the
+// command result; on success the agent restarts into the new binary and the
+// backend completes the command when the new version registers.
+func (a *Agent) handleUpdateAgent(cmd *pb.AgentCommand) {
+	payload := cmd.GetPayload()
 	if payload == "" {
 		a.logger.Info("update agent requested but no download URL")
+		a.addCommandResult(&pb.AgentCommandResult{
+			CommandId: cmd.GetId(),
+			Message:   "update requested without a download URL",
+		})
 		return
 	}
 	a.logger.Info("update agent requested", "url", payload)
-	go a.runSelfUpdate(payload)
+	go a.runSelfUpdate(cmd.GetId(), payload)
 }
 
 func (a *Agent) setUpdateError(msg string) {
@@ -177,25 +298,33 @@ func (a *Agent) consumeUpdateError() string {
 	return err
 }
 
-func (a *Agent) runSelfUpdate(downloadURL string) {
+func (a *Agent) updateFailed(commandID string, err error) {
+	a.setUpdateError(err.Error())
+	a.addCommandResult(&pb.AgentCommandResult{
+		CommandId: commandID,
+		Message:   err.Error(),
+	})
+}
+
+func (a *Agent) runSelfUpdate(commandID, downloadURL string) {
 	a.logger.Info("starting self-update", "url", downloadURL)
 	execPath, err := os.Executable()
 	if err != nil {
 		a.logger.Error("failed to get executable path", "error", err)
-		a.setUpdateError(err.Error())
+		a.updateFailed(commandID, err)
 		return
 	}
 	a.logger.Info("current binary path", "path", execPath)
 
 	if err := a.downloadAndReplace(downloadURL, execPath); err != nil {
 		a.logger.Error("self-update failed", "error", err)
-		a.setUpdateError(err.Error())
+		a.updateFailed(commandID, err)
 		return
 	}
 	a.logger.Info("self-update complete, restarting", "path", execPath)
 	if err := unix.Exec(execPath, os.Args, os.Environ()); err != nil {
 		a.logger.Error("exec failed", "error", err)
-		a.setUpdateError(err.Error())
+		a.updateFailed(commandID, err)
 	}
 }
 
@@ -203,10 +332,30 @@ func (a *Agent) downloadAndReplace(downloadURL, execPath string) error {
 	dir := filepath.Dir(execPath)
 	newPath := filepath.Join(dir, tempBinaryName)
 
-	if err := a.downloadToFile(downloadURL, newPath); err != nil {
+	if err := a.downloadToFile(withArch(downloadURL), newPath); err != nil {
 		return err
 	}
-	return a.replaceBinary(execPath, newPath)
+	if err := selfupdate.Install(execPath, newPath, ""); err != nil {
+		os.Remove(newPath)
+		return err
+	}
+	return nil
+}
+
+// withArch asks the backend for the binary matching this agent's architecture
+// unless the download URL already names one.
+func withArch(downloadURL string) string {
+	u, err := url.Parse(downloadURL)
+	if err != nil {
+		return downloadURL
+	}
+	q := u.Query()
+	if q.Get("arch") != "" {
+		return downloadURL
+	}
+	q.Set("arch", runtime.GOARCH)
+	u.RawQuery = q.Encode()
+	return u.String()
 }
 
 func (a *Agent) downloadToFile(downloadURL, destPath string) error {
@@ -268,14 +417,3 @@ func (a *Agent) tryDownload(downloadURL, destPath string) error {
 	}
 	return nil
 }
-
-func (a *Agent) replaceBinary(execPath, newPath string) error {
-	if err := os.Remove(execPath); err != nil {
-		os.Remove(newPath)
-		return fmt.Errorf("remove current binary: %w", err)
-	}
-	if err := os.Rename(newPath, execPath); err != nil {
-		return fmt.Errorf("rename new binary: %w", err)
-	}
-	return nil
-}
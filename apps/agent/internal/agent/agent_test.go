@@ -0,0 +1,101 @@
+package agent
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	pb "github.com/paasdeploy/backend/gen/go/flowdeploy/v1"
+)
+
+type blockingExecutor struct {
+	release chan struct{}
+	started chan string
+}
+
+func (e *blockingExecutor) ExecuteCommand(ctx context.Context, cmd *pb.AgentCommand) *pb.AgentCommandResult {
+	e.started <- cmd.GetId()
+	<-e.release
+	return &pb.AgentCommandResult{CommandId: cmd.GetId(), Success: true}
+}
+
+func newTestAgent(commands CommandExecutor, queueSize int) *Agent {
+	return &Agent{
+		cfg:      Config{Commands: commands},
+		logger:   slog.New(slog.NewTextHandler(io.Discard, nil)),
+		commands: make(chan *pb.AgentCommand, queueSize),
+	}
+}
+
+func restartCommand(id string) *pb.AgentCommand {
+	return &pb.AgentCommand{Id: id, Type: pb.AgentCommandType_AGENT_COMMAND_RESTART_CONTAINER}
+}
+
+func TestProcessCommandsDoesNotWaitForExecution(t *testing.T) {
+	exec := &blockingExecutor{release: make(chan struct{}), started: make(chan string, 2)}
+	a := newTestAgent(exec, commandQueueSize)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go a.runCommands(ctx)
+
+	done := make(chan struct{})
+	go func() {
+		a.processCommands([]*pb.AgentCommand{restartCommand("cmd-1"), restartCommand("cmd-2")})
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("processCommands blocked on a running command")
+	}
+
+	if id := <-exec.started; id != "cmd-1" {
+		t.Fatalf("first command = %s, want cmd-1", id)
+	}
+	if results := a.takeCommandResults(); len(results) != 0 {
+		t.Fatalf("results before completion = %v", results)
+	}
+	exec.release <- struct{}{}
+	if id := <-exec.started; id != "cmd-2" {
+		t.Fatalf("second command = %s, want cmd-2", id)
+	}
+	exec.release <- struct{}{}
+
+	deadline := time.After(time.Second)
+	var results []*pb.AgentCommandResult
+	for len(results) < 2 {
+		select {
+		case <-deadline:
+			t.Fatalf("results = %v, want two", results)
+		case <-time.After(10 * time.Millisecond):
+			results = append(results, a.takeCommandResults()...)
+		}
+	}
+	if results[0].GetCommandId() != "cmd-1" || results[1].GetCommandId() != "cmd-2" {
+		t.Errorf("results = %v, want cmd-1 then cmd-2", results)
+	}
+}
+
+func TestProcessCommandsReportsFullQueue(t *testing.T) {
+	a := newTestAgent(nil, 1)
+
+	a.processCommands([]*pb.AgentCommand{restartCommand("cmd-1"), restartCommand("cmd-2")})
+
+	results := a.takeCommandResults()
+	if len(results) != 1 || results[0].GetCommandId() != "cmd-2" || results[0].GetSuccess() {
+		t.Fatalf("results = %v, want a failure for cmd-2", results)
+	}
+}
+
+func TestUpdateAgentWithoutURLReportsFailure(t *testing.T) {
+	a := newTestAgent(nil, 1)
+
+	a.processCommands([]*pb.AgentCommand{{Id: "update-1", Type: pb.AgentCommandType_AGENT_COMMAND_UPDATE_AGENT}})
+
+	results := a.takeCommandResults()
+	if len(results) != 1 || results[0].GetCommandId() != "update-1" || results[0].GetSuccess() {
+		t.Fatalf("results = %v, want a failure for update-1", results)
+	}
+}
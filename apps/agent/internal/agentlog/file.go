@@ -0,0 +1,117 @@
+package agentlog
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+const rotatedTimeFormat = "20060102-150405.000000"
+
+// RotatingFile is an io.Writer that appends to a log file and moves it aside
+// once it grows past maxSize, keeping at most maxBackups rotated files.
+type RotatingFile struct {
+	mu         sync.Mutex
+	path       string
+	maxSize    int64
+	maxBackups int
+	file       *os.File
+	size       int64
+}
+
+func OpenRotatingFile(path string, maxSize int64, maxBackups int) (*RotatingFile, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("create log dir: %w", err)
+	}
+
+	r := &RotatingFile{path: path, maxSize: maxSize, maxBackups: maxBackups}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *RotatingFile) Path() string {
+	return r.path
+}
+
+func (r *RotatingFile) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.maxSize > 0 && r.size+int64(len(p)) > r.maxSize && r.size > 0 {
+		if _, err := r.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := r.file.Write(p)
+	r.size += int64(n)
+	return n, err
+}
+
+// Rotate moves the current file aside and starts a new one. It returns the
+// path of the rotated file.
+func (r *RotatingFile) Rotate() (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rotate()
+}
+
+func (r *RotatingFile) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.file.Close()
+}
+
+func (r *RotatingFile) open() error {
+	f, err := os.OpenFile(r.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o640)
+	if err != nil {
+		return fmt.Errorf("open log file: %w", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("stat log file: %w", err)
+	}
+	r.file = f
+	r.size = info.Size()
+	return nil
+}
+
+func (r *RotatingFile) rotate() (string, error) {
+	if err := r.file.Close(); err != nil {
+		return "", fmt.Errorf("close log file: %w", err)
+	}
+
+	rotated := fmt.Sprintf("%s.%s", r.path, time.Now().Format(rotatedTimeFormat))
+	if err := os.Rename(r.path, rotated); err != nil {
+		if openErr := r.open(); openErr != nil {
+			return "", fmt.Errorf("rotate log file: %w (reopen failed: %v)", err, openErr)
+		}
+		return "", fmt.Errorf("rotate log file: %w", err)
+	}
+
+	if err := r.open(); err != nil {
+		return "", err
+	}
+	r.pruneBackups()
+	return rotated, nil
+}
+
+func (r *RotatingFile) pruneBackups() {
+	if r.maxBackups <= 0 {
+		return
+	}
+	matches, err := filepath.Glob(r.path + ".*")
+	if err != nil || len(matches) <= r.maxBackups {
+		return
+	}
+	sort.Strings(matches)
+	for _, old := range matches[:len(matches)-r.maxBackups] {
+		_ = os.Remove(old)
+	}
+}
@@ -0,0 +1,66 @@
+package agentlog
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+)
+
+func TestRotatingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "agent.log")
+	rf, err := OpenRotatingFile(path, 64, 2)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer rf.Close()
+
+	for i := 0; i < 5; i++ {
+		if _, err := fmt.Fprintf(rf, "line %d ............................\n", i); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+	}
+
+	backups, _ := filepath.Glob(path + ".*")
+	if len(backups) != 2 {
+		t.Fatalf("expected 2 backups after size rotation, got %d", len(backups))
+	}
+
+	lines, err := TailFile(path, 10)
+	if err != nil {
+		t.Fatalf("tail: %v", err)
+	}
+	if len(lines) != 1 || lines[0] != "line 4 ............................" {
+		t.Fatalf("unexpected current file contents: %q", lines)
+	}
+
+	rotated, err := rf.Rotate()
+	if err != nil {
+		t.Fatalf("rotate: %v", err)
+	}
+	if lines, _ := TailFile(rotated, 10); len(lines) != 1 {
+		t.Fatalf("expected rotated file to keep the last line, got %q", lines)
+	}
+	if lines, _ := TailFile(path, 10); len(lines) != 0 {
+		t.Fatalf("expected empty file after rotate, got %q", lines)
+	}
+}
+
+func TestTailFileKeepsLastLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "agent.log")
+	rf, err := OpenRotatingFile(path, 0, 0)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	for i := 0; i < 10; i++ {
+		fmt.Fprintf(rf, "%d\n", i)
+	}
+	rf.Close()
+
+	lines, err := TailFile(path, 3)
+	if err != nil {
+		t.Fatalf("tail: %v", err)
+	}
+	if fmt.Sprint(lines) != "[7 8 9]" {
+		t.Fatalf("unexpected tail: %v", lines)
+	}
+}
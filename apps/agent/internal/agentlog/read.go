@@ -0,0 +1,88 @@
+package agentlog
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	SystemdUnit  = "paasdeploy-agent.service"
+	DefaultLines = 200
+	MaxLines     = 5000
+
+	SourceJournal = "journal"
+	SourceFile    = "file"
+)
+
+var ErrNoLogSource = errors.New("no agent log source available")
+
+func clampLines(lines int) int {
+	if lines <= 0 {
+		return DefaultLines
+	}
+	if lines > MaxLines {
+		return MaxLines
+	}
+	return lines
+}
+
+// ReadJournal returns the agent unit's journal from the user session it runs in.
+func ReadJournal(ctx context.Context, lines int, since time.Duration) ([]string, error) {
+	if _, err := exec.LookPath("journalctl"); err != nil {
+		return nil, err
+	}
+
+	args := []string{"--user", "-u", SystemdUnit, "--no-pager", "-o", "short-iso", "-n", strconv.Itoa(clampLines(lines))}
+	if since > 0 {
+		args = append(args, fmt.Sprintf("--since=-%ds", int64(since.Seconds())))
+	}
+
+	out, err := exec.CommandContext(ctx, "journalctl", args...).Output()
+	if err != nil {
+		return nil, fmt.Errorf("journalctl: %w", err)
+	}
+
+	result := splitLines(string(out))
+	if len(result) == 1 && strings.HasPrefix(result[0], "-- No entries --") {
+		return nil, nil
+	}
+	return result, nil
+}
+
+// TailFile returns the last lines of path. Lines are kept in a ring so large
+// files are read only once without being held in memory.
+func TailFile(path string, lines int) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	limit := clampLines(lines)
+	ring := make([]string, 0, limit)
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if len(ring) == limit {
+			ring = append(ring[1:], scanner.Text())
+			continue
+		}
+		ring = append(ring, scanner.Text())
+	}
+	return ring, scanner.Err()
+}
+
+func splitLines(out string) []string {
+	out = strings.TrimRight(out, "\n")
+	if out == "" {
+		return nil
+	}
+	return strings.Split(out, "\n")
+}
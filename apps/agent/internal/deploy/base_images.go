@@ -0,0 +1,76 @@
+package deploy
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/paasdeploy/shared/pkg/compose"
+	"github.com/paasdeploy/shared/pkg/docker"
+)
+
+// Busy reports whether a deployment is running.
+func (e *Executor) Busy() bool {
+	return e.active.Load() > 0
+}
+
+// RecentBaseImages returns the base images of the apps deployed on this
+// server within window, the ones shared by the most apps first, at most
+// limit of them.
+func (e *Executor) RecentBaseImages(window time.Duration, limit int) []string {
+	entries, err := os.ReadDir(e.dataDir)
+	if err != nil {
+		return nil
+	}
+
+	cutoff := time.Now().Add(-window)
+	counts := make(map[string]int)
+	var images []string
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		repoDir := filepath.Join(e.dataDir, entry.Name())
+		// The metadata file is rewritten by every deploy of the app.
+		info, err := os.Stat(filepath.Join(repoDir, metadataFileName))
+		if err != nil || info.ModTime().Before(cutoff) {
+			continue
+		}
+		for _, image := range e.appBaseImages(repoDir) {
+			if counts[image] == 0 {
+				images = append(images, image)
+			}
+			counts[image]++
+		}
+	}
+
+	sort.SliceStable(images, func(i, j int) bool {
+		return counts[images[i]] > counts[images[j]]
+	})
+	if len(images) > limit {
+		images = images[:limit]
+	}
+	return images
+}
+
+func (e *Executor) appBaseImages(repoDir string) []string {
+	appDir := repoDir
+	if meta := e.loadMetadata(repoDir); meta != nil {
+		appDir = e.resolveAppDir(repoDir, meta.Workdir)
+	}
+
+	dockerfile := "./Dockerfile"
+	if cfg, err := compose.LoadConfig(appDir); err == nil && cfg.Build.Dockerfile != "" {
+		dockerfile = cfg.Build.Dockerfile
+	}
+	path, err := compose.SafeJoin(appDir, dockerfile)
+	if err != nil {
+		return nil
+	}
+	images, err := docker.BaseImages(path)
+	if err != nil {
+		return nil
+	}
+	return images
+}
@@ -0,0 +1,45 @@
+package deploy
+
+import (
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func writeApp(t *testing.T, dataDir, appID, dockerfile string, deployedAt time.Time) {
+	t.Helper()
+	repoDir := filepath.Join(dataDir, appID)
+	if err := os.MkdirAll(repoDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(repoDir, "Dockerfile"), []byte(dockerfile), 0644); err != nil {
+		t.Fatal(err)
+	}
+	meta := filepath.Join(repoDir, metadataFileName)
+	if err := os.WriteFile(meta, []byte(`{}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(meta, deployedAt, deployedAt); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRecentBaseImagesRanksSharedImagesFirst(t *testing.T) {
+	dataDir := t.TempDir()
+	now := time.Now()
+	writeApp(t, dataDir, "a", "FROM node:20-alpine\n", now)
+	writeApp(t, dataDir, "b", "FROM golang:1.24-alpine AS build\nFROM alpine:3.20\n", now)
+	writeApp(t, dataDir, "c", "FROM golang:1.24-alpine\n", now)
+	writeApp(t, dataDir, "old", "FROM python:3.9\n", now.Add(-30*24*time.Hour))
+
+	e := &Executor{dataDir: dataDir, logger: slog.New(slog.NewTextHandler(io.Discard, nil))}
+	got := e.RecentBaseImages(7*24*time.Hour, 2)
+	want := []string{"golang:1.24-alpine", "node:20-alpine"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("RecentBaseImages = %v, want %v", got, want)
+	}
+}
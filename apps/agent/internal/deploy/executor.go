@@ -9,24 +9,27 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync/atomic"
 	"time"
 
+	"github.com/paasdeploy/agent/internal/sysinfo"
 	pb "github.com/paasdeploy/backend/gen/go/flowdeploy/v1"
 	"github.com/paasdeploy/shared/pkg/compose"
 	"github.com/paasdeploy/shared/pkg/docker"
 	"github.com/paasdeploy/shared/pkg/git"
 	"github.com/paasdeploy/shared/pkg/health"
 	"github.com/paasdeploy/shared/pkg/paths"
+	"github.com/paasdeploy/shared/pkg/sbom"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 const (
-	defaultHealthTimeout  = 2 * time.Minute
-	defaultHealthRetries  = 10
-	defaultHealthInterval = 5 * time.Second
-	healthCheckStartDelay = 15 * time.Second
-	logChannelBuffer      = 256
-	metadataFileName      = ".paasdeploy-meta.json"
+	defaultHealthTimeout   = 2 * time.Minute
+	defaultHealthRetries   = 10
+	defaultHealthInterval  = 5 * time.Second
+	healthCheckStartPeriod = 15 * time.Second
+	logChannelBuffer       = 256
+	metadataFileName       = ".paasdeploy-meta.json"
 )
 
 type appMetadata struct {
@@ -40,24 +43,39 @@ type Executor struct {
 	git     *git.Client
 	docker  *docker.Client
 	health  *health.Checker
+	builds  *docker.BuildLimiter
 	logger  *slog.Logger
+
+	active atomic.Int32
 }
 
-func NewExecutor(logger *slog.Logger) *Executor {
+// NewExecutor returns an executor running at most maxBuilds image builds at
+// once, or as many as the server's memory allows when maxBuilds is zero.
+func NewExecutor(maxBuilds int, logger *slog.Logger) *Executor {
 	dataDir := paths.ResolveDataDir()
 
 	registry := os.Getenv("DOCKER_REGISTRY")
 
+	if maxBuilds <= 0 {
+		maxBuilds = docker.BuildSlotsForMemory(sysinfo.MemoryTotal())
+	}
+	logger = logger.With("component", "deploy-executor")
+	logger.Info("Build concurrency limit", "maxBuilds", maxBuilds)
+
 	return &Executor{
 		dataDir: dataDir,
 		git:     git.NewClient(dataDir, logger),
 		docker:  docker.NewClient(dataDir, registry, logger),
 		health:  health.NewChecker(defaultHealthTimeout, defaultHealthRetries, defaultHealthInterval, logger),
-		logger:  logger.With("component", "deploy-executor"),
+		builds:  docker.NewBuildLimiter(maxBuilds),
+		logger:  logger,
 	}
 }
 
 func (e *Executor) Execute(ctx context.Context, req *pb.DeployRequest, logFn LogFunc) *pb.DeployResponse {
+	e.active.Add(1)
+	defer e.active.Add(-1)
+
 	startedAt := time.Now()
 	e.logger.Info("Starting deployment",
 		"deploymentId", req.DeploymentId,
@@ -66,51 +84,102 @@ func (e *Executor) Execute(ctx context.Context, req *pb.DeployRequest, logFn Log
 	)
 
 	emit := e.emitter(logFn)
+	timer := newStageTimer(startedAt)
 
 	cfg := e.buildConfig(req)
 	repoDir := filepath.Join(e.dataDir, req.AppId)
 	appDir := e.resolveAppDir(repoDir, req.Git.GetWorkdir())
+	imageTag := e.docker.GetImageTag(req.AppName, req.Git.GetCommitSha())
+
+	prepareCtx, cancelPrepare := context.WithCancel(ctx)
+	prepared := e.prepare(prepareCtx, req, appDir, imageTag, emit, timer)
+	var pushed <-chan string
+	defer func() {
+		cancelPrepare()
+		<-prepared.done
+		if pushed != nil {
+			<-pushed
+		}
+	}()
 
 	emit(pb.DeployStage_DEPLOY_STAGE_GIT_SYNC, pb.DeployLogLevel_DEPLOY_LOG_LEVEL_INFO, "Syncing repository...")
-	if err := e.syncGit(ctx, req, repoDir); err != nil {
+	stopGitSync := timer.track(pb.DeployStage_DEPLOY_STAGE_GIT_SYNC)
+	err := e.syncGit(ctx, req, repoDir)
+	stopGitSync()
+	if err != nil {
 		emit(pb.DeployStage_DEPLOY_STAGE_GIT_SYNC, pb.DeployLogLevel_DEPLOY_LOG_LEVEL_ERROR, err.Error())
-		return e.failResponse(req, pb.DeployErrorCode_DEPLOY_ERROR_GIT_CLONE_FAILED, "git_sync", err, startedAt)
+		return e.failResponse(req, pb.DeployErrorCode_DEPLOY_ERROR_GIT_CLONE_FAILED, "git_sync", err, timer)
 	}
 	emit(pb.DeployStage_DEPLOY_STAGE_GIT_SYNC, pb.DeployLogLevel_DEPLOY_LOG_LEVEL_INFO, "Repository synced successfully")
 
 	e.saveMetadata(repoDir, req.Git.GetWorkdir())
-	e.mergeLocalConfig(cfg, req, appDir)
+	if missing := e.mergeLocalConfig(cfg, req, appDir); len(missing) > 0 {
+		emit(pb.DeployStage_DEPLOY_STAGE_BUILD, pb.DeployLogLevel_DEPLOY_LOG_LEVEL_WARN,
+			fmt.Sprintf("paasdeploy config references unset variable(s): %s", strings.Join(missing, ", ")))
+	}
+	if cfg.PortSource != "" {
+		emit(pb.DeployStage_DEPLOY_STAGE_BUILD, pb.DeployLogLevel_DEPLOY_LOG_LEVEL_INFO,
+			fmt.Sprintf("Port not set in paasdeploy config, using %d (%s)", cfg.Port, cfg.PortSource))
+	}
 
 	appVersion := detectAppVersion(cfg.Runtime, appDir)
 	if appVersion != "" {
 		e.logger.Info("Detected app version", "version", appVersion, "runtime", cfg.Runtime)
 	}
 
-	imageTag := e.docker.GetImageTag(req.AppName, req.Git.GetCommitSha())
+	// Base images are pulled by the build anyway; waiting for the prepare
+	// step keeps it from pulling them a second time.
+	<-prepared.done
+
+	if prepared.imageReady {
+		emit(pb.DeployStage_DEPLOY_STAGE_BUILD, pb.DeployLogLevel_DEPLOY_LOG_LEVEL_INFO,
+			fmt.Sprintf("Image %s already exists, skipping build", imageTag))
+	} else {
+		emit(pb.DeployStage_DEPLOY_STAGE_BUILD, pb.DeployLogLevel_DEPLOY_LOG_LEVEL_INFO, fmt.Sprintf("Building image %s", imageTag))
+		stopBuild := timer.track(pb.DeployStage_DEPLOY_STAGE_BUILD)
+		err = e.buildWithSlot(ctx, req, repoDir, appDir, imageTag, logFn)
+		stopBuild()
+		if err != nil {
+			emit(pb.DeployStage_DEPLOY_STAGE_BUILD, pb.DeployLogLevel_DEPLOY_LOG_LEVEL_ERROR, err.Error())
+			return e.failResponse(req, pb.DeployErrorCode_DEPLOY_ERROR_BUILD_FAILED, "build", err, timer)
+		}
+		emit(pb.DeployStage_DEPLOY_STAGE_BUILD, pb.DeployLogLevel_DEPLOY_LOG_LEVEL_INFO, "Image built successfully")
 
-	emit(pb.DeployStage_DEPLOY_STAGE_BUILD, pb.DeployLogLevel_DEPLOY_LOG_LEVEL_INFO, fmt.Sprintf("Building image %s", imageTag))
-	if err := e.buildImage(ctx, req, repoDir, appDir, imageTag, logFn); err != nil {
-		emit(pb.DeployStage_DEPLOY_STAGE_BUILD, pb.DeployLogLevel_DEPLOY_LOG_LEVEL_ERROR, err.Error())
-		return e.failResponse(req, pb.DeployErrorCode_DEPLOY_ERROR_BUILD_FAILED, "build", err, startedAt)
+		if e.docker.HasRegistry() {
+			pushed = e.push(ctx, imageTag, emit, timer)
+		}
 	}
-	emit(pb.DeployStage_DEPLOY_STAGE_BUILD, pb.DeployLogLevel_DEPLOY_LOG_LEVEL_INFO, "Image built successfully")
+	sbomDoc := e.generateSBOM(ctx, imageTag, emit)
 
 	emit(pb.DeployStage_DEPLOY_STAGE_DEPLOY, pb.DeployLogLevel_DEPLOY_LOG_LEVEL_INFO, "Deploying container...")
-	if err := e.deployContainer(ctx, req, cfg, appDir, imageTag, logFn); err != nil {
+	stopDeploy := timer.track(pb.DeployStage_DEPLOY_STAGE_DEPLOY)
+	err = e.deployContainer(ctx, req, cfg, appDir, imageTag, logFn)
+	stopDeploy()
+	if err != nil {
 		emit(pb.DeployStage_DEPLOY_STAGE_DEPLOY, pb.DeployLogLevel_DEPLOY_LOG_LEVEL_ERROR, err.Error())
-		return e.failResponse(req, pb.DeployErrorCode_DEPLOY_ERROR_CONTAINER_START_FAILED, "deploy", err, startedAt)
+		return e.failResponse(req, pb.DeployErrorCode_DEPLOY_ERROR_CONTAINER_START_FAILED, "deploy", err, timer)
 	}
 	emit(pb.DeployStage_DEPLOY_STAGE_DEPLOY, pb.DeployLogLevel_DEPLOY_LOG_LEVEL_INFO, "Container deployed successfully")
 
 	emit(pb.DeployStage_DEPLOY_STAGE_HEALTH_CHECK, pb.DeployLogLevel_DEPLOY_LOG_LEVEL_INFO,
 		fmt.Sprintf("Running health check on port %d path %s ...", cfg.Port, cfg.Healthcheck.Path))
-	if err := e.checkHealth(ctx, req, cfg); err != nil {
+	stopHealth := timer.track(pb.DeployStage_DEPLOY_STAGE_HEALTH_CHECK)
+	err = e.checkHealth(ctx, req, cfg)
+	stopHealth()
+	if err != nil {
 		emit(pb.DeployStage_DEPLOY_STAGE_HEALTH_CHECK, pb.DeployLogLevel_DEPLOY_LOG_LEVEL_ERROR, err.Error())
 		e.rollback(ctx, req, cfg, appDir)
-		return e.failResponse(req, pb.DeployErrorCode_DEPLOY_ERROR_HEALTH_CHECK_FAILED, "health_check", err, startedAt)
+		return e.failResponse(req, pb.DeployErrorCode_DEPLOY_ERROR_HEALTH_CHECK_FAILED, "health_check", err, timer)
 	}
 	emit(pb.DeployStage_DEPLOY_STAGE_HEALTH_CHECK, pb.DeployLogLevel_DEPLOY_LOG_LEVEL_INFO, "Health check passed")
 
+	var imageDigest string
+	if pushed != nil {
+		imageDigest = <-pushed
+	}
+	sbomData := <-sbomDoc
+	imageSize, configJSON := e.deployedState(ctx, imageTag, cfg)
+
 	go e.cleanupOldImages(imageTag)
 
 	completedAt := time.Now()
@@ -121,16 +190,156 @@ func (e *Executor) Execute(ctx context.Context, req *pb.DeployRequest, logFn Log
 		Success: true,
 		Message: "deployment completed successfully",
 		Result: &pb.DeployResult{
-			ImageTag:    imageTag,
-			StartedAt:   timestamppb.New(startedAt),
-			CompletedAt: timestamppb.New(completedAt),
-			ExposedPort: int32(cfg.Port),
-			AppVersion:  appVersion,
-			Runtime:     cfg.Runtime,
+			ImageTag:         imageTag,
+			StartedAt:        timestamppb.New(startedAt),
+			CompletedAt:      timestamppb.New(completedAt),
+			BuildDurationMs:  timer.durationMs(pb.DeployStage_DEPLOY_STAGE_BUILD),
+			DeployDurationMs: timer.durationMs(pb.DeployStage_DEPLOY_STAGE_DEPLOY),
+			ExposedPort:      int32(cfg.Port),
+			AppVersion:       appVersion,
+			Runtime:          cfg.Runtime,
+			StageTimings:     timer.list(),
+			Sbom:             sbomData,
+			ImageDigest:      imageDigest,
+			ImageSize:        imageSize,
+			Config:           configJSON,
 		},
 	}
 }
 
+// deployedState returns the size of the deployed image and the effective
+// configuration as JSON, which the backend keeps to compare deployments.
+// Either is left empty when it cannot be read.
+func (e *Executor) deployedState(ctx context.Context, imageTag string, cfg *compose.Config) (int64, []byte) {
+	size, err := e.docker.ImageSize(ctx, imageTag)
+	if err != nil {
+		e.logger.Warn("Failed to read image size", "imageTag", imageTag, "error", err)
+	}
+	configJSON, err := json.Marshal(cfg)
+	if err != nil {
+		e.logger.Warn("Failed to encode deploy config", "error", err)
+	}
+	return size, configJSON
+}
+
+// generateSBOM lists the packages of the image while the container is
+// deployed. The channel yields the compressed document, or nothing when it
+// could not be generated, which does not fail the deploy.
+func (e *Executor) generateSBOM(ctx context.Context, imageTag string, emit func(pb.DeployStage, pb.DeployLogLevel, string)) <-chan []byte {
+	result := make(chan []byte, 1)
+	go func() {
+		defer close(result)
+
+		document, err := e.docker.GenerateSBOM(ctx, imageTag)
+		if err == nil {
+			document, err = sbom.Compress(document)
+		}
+		if err != nil {
+			emit(pb.DeployStage_DEPLOY_STAGE_BUILD, pb.DeployLogLevel_DEPLOY_LOG_LEVEL_WARN,
+				fmt.Sprintf("Failed to generate SBOM for %s: %v", imageTag, err))
+			return
+		}
+		result <- document
+	}()
+	return result
+}
+
+// preparation is the outcome of prepare, to be read once done is closed.
+type preparation struct {
+	done chan struct{}
+	// imageReady is set when the image of the commit already exists,
+	// locally or in the registry, so the build can be skipped.
+	imageReady bool
+}
+
+// prepare readies Docker while the repository syncs: it ensures the app
+// network exists and looks for an existing image of the commit, as left by
+// a retry or by the same commit rolled out to another server. Without one
+// it pulls the base images of the cached checkout, which usually match
+// those of the new commit. Network and pulls are best effort since the
+// deploy and build steps still cover a miss.
+func (e *Executor) prepare(ctx context.Context, req *pb.DeployRequest, appDir, imageTag string, emit func(pb.DeployStage, pb.DeployLogLevel, string), timer *stageTimer) *preparation {
+	// Read before the sync starts, which moves the checkout and may update
+	// req.Build.
+	images := e.cachedBaseImages(req, appDir)
+
+	p := &preparation{done: make(chan struct{})}
+	go func() {
+		defer close(p.done)
+		defer timer.track(pb.DeployStage_DEPLOY_STAGE_INITIALIZING)()
+
+		if err := e.docker.EnsureNetwork(ctx, docker.DefaultNetworkName); err != nil {
+			e.logger.Warn("Failed to ensure network ahead of deploy", "error", err)
+		}
+
+		ready, err := e.docker.ImageAvailable(ctx, imageTag, req.GetImageSigningPublicKey())
+		if err != nil {
+			emit(pb.DeployStage_DEPLOY_STAGE_INITIALIZING, pb.DeployLogLevel_DEPLOY_LOG_LEVEL_WARN,
+				fmt.Sprintf("Discarded image %s pulled from the registry, building from source instead: %v", imageTag, err))
+		}
+		if ready {
+			p.imageReady = true
+			return
+		}
+
+		if len(images) == 0 {
+			return
+		}
+		emit(pb.DeployStage_DEPLOY_STAGE_INITIALIZING, pb.DeployLogLevel_DEPLOY_LOG_LEVEL_INFO,
+			fmt.Sprintf("Preparing base images: %s", strings.Join(images, ", ")))
+		if err := e.docker.EnsureImages(ctx, images); err != nil {
+			e.logger.Warn("Failed to pull base images ahead of build", "error", err)
+		}
+	}()
+	return p
+}
+
+// push publishes a freshly built image to the registry while the container
+// is deployed, so other servers rolling out the same commit can skip their
+// build. A failed push only costs them that build. The channel yields the
+// digest reference of the pushed image, which the backend signs, or nothing
+// when the push failed.
+func (e *Executor) push(ctx context.Context, imageTag string, emit func(pb.DeployStage, pb.DeployLogLevel, string), timer *stageTimer) <-chan string {
+	done := make(chan string, 1)
+	go func() {
+		defer close(done)
+		defer timer.track(pb.DeployStage_DEPLOY_STAGE_PUSH)()
+
+		if err := e.docker.Push(ctx, imageTag); err != nil {
+			emit(pb.DeployStage_DEPLOY_STAGE_PUSH, pb.DeployLogLevel_DEPLOY_LOG_LEVEL_WARN,
+				fmt.Sprintf("Failed to push image %s: %v", imageTag, err))
+			return
+		}
+		emit(pb.DeployStage_DEPLOY_STAGE_PUSH, pb.DeployLogLevel_DEPLOY_LOG_LEVEL_INFO, fmt.Sprintf("Pushed image %s", imageTag))
+
+		digest, err := e.docker.RepoDigest(ctx, imageTag)
+		if err != nil {
+			e.logger.Warn("Failed to read pushed image digest", "image", imageTag, "error", err)
+			return
+		}
+		done <- digest
+	}()
+	return done
+}
+
+// cachedBaseImages returns the base images of the Dockerfile left by the
+// previous deploy, or nil on a first deploy.
+func (e *Executor) cachedBaseImages(req *pb.DeployRequest, appDir string) []string {
+	dockerfile := req.GetBuild().GetDockerfile()
+	if dockerfile == "" {
+		dockerfile = "./Dockerfile"
+	}
+	path, err := compose.SafeJoin(appDir, dockerfile)
+	if err != nil {
+		return nil
+	}
+	images, err := docker.BaseImages(path)
+	if err != nil {
+		return nil
+	}
+	return images
+}
+
 func (e *Executor) emitter(logFn LogFunc) func(pb.DeployStage, pb.DeployLogLevel, string) {
 	return func(stage pb.DeployStage, level pb.DeployLogLevel, msg string) {
 		if logFn != nil {
@@ -159,7 +368,6 @@ func (e *Executor) buildConfig(req *pb.DeployRequest) *compose.Config {
 			cfg.Resources.Memory = req.Runtime.Resources.Memory
 			cfg.Resources.CPU = req.Runtime.Resources.Cpu
 		}
-		cfg.Domains = req.Runtime.Domains
 		for _, v := range req.Runtime.Volumes {
 			cfg.Volumes = append(cfg.Volumes, compose.VolumeConfig{
 				Name:     v.Name,
@@ -183,14 +391,18 @@ func (e *Executor) buildConfig(req *pb.DeployRequest) *compose.Config {
 	return cfg
 }
 
-func (e *Executor) mergeLocalConfig(cfg *compose.Config, req *pb.DeployRequest, appDir string) {
+// mergeLocalConfig applies the paasdeploy config of the repository, with
+// its variable references resolved against the app's env vars, and returns
+// the variables that could not be resolved.
+func (e *Executor) mergeLocalConfig(cfg *compose.Config, req *pb.DeployRequest, appDir string) []string {
 	localCfg, err := compose.LoadConfig(appDir)
 	if err != nil {
-		e.logger.Debug("No local paasdeploy.json to merge", "appDir", appDir, "error", err)
-		return
+		e.logger.Debug("No local paasdeploy config to merge", "appDir", appDir, "error", err)
+		return nil
 	}
+	missing := localCfg.Interpolate(req.EnvVars)
 
-	e.logger.Info("Merging local paasdeploy.json",
+	e.logger.Info("Merging local paasdeploy config",
 		"appDir", appDir,
 		"localRuntime", localCfg.Runtime,
 		"localPort", localCfg.Port,
@@ -200,6 +412,7 @@ func (e *Executor) mergeLocalConfig(cfg *compose.Config, req *pb.DeployRequest,
 
 	e.mergeRuntimeConfig(cfg, localCfg)
 	e.mergeBuildConfig(req, localCfg)
+	return missing
 }
 
 func (e *Executor) mergeRuntimeConfig(cfg *compose.Config, localCfg *compose.Config) {
@@ -207,8 +420,11 @@ func (e *Executor) mergeRuntimeConfig(cfg *compose.Config, localCfg *compose.Con
 		cfg.Runtime = localCfg.Runtime
 	}
 
-	if localCfg.Port > 0 {
+	// A port the file neither sets nor lets us detect leaves the one from
+	// the request, which honors the app's PORT variable.
+	if localCfg.Port > 0 && localCfg.PortSource != compose.PortSourceDefault {
 		cfg.Port = localCfg.Port
+		cfg.PortSource = localCfg.PortSource
 	}
 
 	if localCfg.HostPort > 0 {
@@ -233,6 +449,18 @@ func (e *Executor) mergeRuntimeConfig(cfg *compose.Config, localCfg *compose.Con
 	if localCfg.Healthcheck.TLS {
 		cfg.Healthcheck.TLS = true
 	}
+	if localCfg.Compress {
+		cfg.Compress = true
+	}
+	if localCfg.Sticky != nil {
+		cfg.Sticky = localCfg.Sticky
+	}
+	if len(localCfg.Domains) > 0 {
+		cfg.Domains = localCfg.Domains
+	}
+	if len(localCfg.Ports) > 0 {
+		cfg.Ports = localCfg.Ports
+	}
 
 	if localCfg.Resources.Memory != "" {
 		cfg.Resources.Memory = localCfg.Resources.Memory
@@ -327,7 +555,7 @@ func (e *Executor) scanForConfig(repoDir string) *compose.Config {
 		if walkErr != nil {
 			return filepath.SkipDir
 		}
-		if d.IsDir() || d.Name() != "paasdeploy.json" {
+		if d.IsDir() || !compose.IsConfigFile(d.Name()) {
 			return nil
 		}
 		dir := filepath.Dir(path)
@@ -341,7 +569,7 @@ func (e *Executor) scanForConfig(repoDir string) *compose.Config {
 		}
 		if subCfg == nil {
 			rel, _ := filepath.Rel(repoDir, dir)
-			e.logger.Debug("Found paasdeploy.json in subdirectory", "subdir", rel)
+			e.logger.Debug("Found paasdeploy config in subdirectory", "subdir", rel)
 			subCfg = localCfg
 		}
 		return nil
@@ -407,6 +635,23 @@ func (e *Executor) cloneRepo(ctx context.Context, repoURL, repoDir, token string
 	return nil
 }
 
+// buildWithSlot runs the build once a build slot is free, queueing behind
+// the builds already running on the server.
+func (e *Executor) buildWithSlot(ctx context.Context, req *pb.DeployRequest, repoDir, appDir, imageTag string, logFn LogFunc) error {
+	if !e.builds.TryAcquire() {
+		if logFn != nil {
+			logFn(pb.DeployStage_DEPLOY_STAGE_BUILD, pb.DeployLogLevel_DEPLOY_LOG_LEVEL_INFO,
+				fmt.Sprintf("Waiting for a build slot (all %d build slots on this server are busy)", e.builds.Limit()))
+		}
+		if err := e.builds.Acquire(ctx); err != nil {
+			return fmt.Errorf("waiting for a build slot: %w", err)
+		}
+	}
+	defer e.builds.Release()
+
+	return e.buildImage(ctx, req, repoDir, appDir, imageTag, logFn)
+}
+
 func (e *Executor) buildImage(ctx context.Context, req *pb.DeployRequest, repoDir, appDir, imageTag string, logFn LogFunc) error {
 	dockerfile := "./Dockerfile"
 	buildContext := "."
@@ -459,17 +704,19 @@ func (e *Executor) deployContainer(ctx context.Context, req *pb.DeployRequest, c
 		e.logger.Warn("Failed to remove existing container", "appName", req.AppName, "error", err)
 	}
 
-	var domainRoutes []compose.DomainRoute
-	for _, d := range cfg.Domains {
-		domainRoutes = append(domainRoutes, compose.DomainRoute{Domain: d})
-	}
+	domainRoutes, redirects := deployDomainRoutes(req, cfg)
 
 	if err := compose.WriteComposeFile(appDir, compose.GenerateParams{
-		AppName:  req.AppName,
-		ImageTag: imageTag,
-		Config:   cfg,
-		Domains:  domainRoutes,
-		EnvVars:  req.EnvVars,
+		AppName:   req.AppName,
+		ImageTag:  imageTag,
+		Config:    cfg,
+		Domains:   domainRoutes,
+		EnvVars:   req.EnvVars,
+		RateLimit: toComposeRateLimit(req.GetRuntime().GetRateLimit()),
+		Redirects: redirects,
+		Headers:   toComposeSecurityHeaders(req.GetRuntime().GetSecurityHeaders()),
+		Internal:  req.GetRuntime().GetInternal(),
+		MeshIP:    meshIP(),
 	}); err != nil {
 		return fmt.Errorf("failed to write docker-compose.yml: %w", err)
 	}
@@ -489,20 +736,13 @@ func (e *Executor) deployContainer(ctx context.Context, req *pb.DeployRequest, c
 }
 
 func (e *Executor) checkHealth(ctx context.Context, req *pb.DeployRequest, cfg *compose.Config) error {
-	startDelay := healthCheckStartDelay
+	startPeriod := healthCheckStartPeriod
 	if cfg.Healthcheck.StartPeriod != "" {
-		if parsed, err := time.ParseDuration(cfg.Healthcheck.StartPeriod); err == nil && parsed > startDelay {
-			startDelay = parsed
+		if parsed, err := time.ParseDuration(cfg.Healthcheck.StartPeriod); err == nil && parsed > startPeriod {
+			startPeriod = parsed
 		}
 	}
 
-	e.logger.Info("Waiting for container startup", "delay", startDelay)
-	select {
-	case <-time.After(startDelay):
-	case <-ctx.Done():
-		return ctx.Err()
-	}
-
 	containerIP, err := e.docker.GetContainerIP(ctx, req.AppName, docker.DefaultNetworkName)
 	if err != nil {
 		return fmt.Errorf("failed to get container IP: %w", err)
@@ -513,9 +753,80 @@ func (e *Executor) checkHealth(ctx context.Context, req *pb.DeployRequest, cfg *
 		scheme = "https"
 	}
 	healthURL := fmt.Sprintf("%s://%s:%d%s", scheme, containerIP, cfg.Port, cfg.Healthcheck.Path)
-	e.logger.Info("Running health check", "url", healthURL)
+	e.logger.Info("Running health check", "url", healthURL, "startPeriod", startPeriod)
+
+	return e.health.WaitReady(ctx, healthURL, startPeriod)
+}
+
+// deployDomainRoutes merges the domains of the paasdeploy config with the
+// custom domains sent by the backend. The full routes, which carry path
+// prefixes and middleware settings, are preferred over the plain domain list.
+func deployDomainRoutes(req *pb.DeployRequest, cfg *compose.Config) ([]compose.DomainRoute, []compose.Redirect) {
+	customRoutes := toComposeRoutes(req.GetRuntime().GetDomainRoutes())
+	if len(customRoutes) == 0 {
+		for _, d := range req.GetRuntime().GetDomains() {
+			customRoutes = append(customRoutes, compose.DomainRoute{Domain: d})
+		}
+	}
+	return mergeDomainRoutes(cfg, customRoutes, req.GetRuntime().GetRedirects())
+}
+
+func mergeDomainRoutes(cfg *compose.Config, customRoutes []compose.DomainRoute, redirects []*pb.RedirectConfig) ([]compose.DomainRoute, []compose.Redirect) {
+	configRoutes, configRedirects := cfg.DomainRoutes()
+	return compose.MergeDomainRoutes(configRoutes, customRoutes), append(configRedirects, toComposeRedirects(redirects)...)
+}
+
+func toComposeRoutes(routes []*pb.DomainRouteConfig) []compose.DomainRoute {
+	var domainRoutes []compose.DomainRoute
+	for _, d := range routes {
+		domainRoutes = append(domainRoutes, compose.DomainRoute{
+			Domain:            d.Domain,
+			PathPrefix:        d.PathPrefix,
+			BasicAuthUsers:    d.BasicAuthUsers,
+			IPAllowlist:       d.IpAllowlist,
+			CustomCertificate: d.CustomCertificate,
+		})
+	}
+	return domainRoutes
+}
+
+func toComposeRateLimit(rl *pb.RateLimitConfig) *compose.RateLimit {
+	if rl == nil {
+		return nil
+	}
+	return &compose.RateLimit{
+		Average: int(rl.Average),
+		Burst:   int(rl.Burst),
+		Source:  rl.Source,
+		Header:  rl.Header,
+	}
+}
 
-	return e.health.CheckWithBackoff(ctx, healthURL)
+func toComposeSecurityHeaders(h *pb.SecurityHeadersConfig) *compose.SecurityHeaders {
+	if h == nil {
+		return nil
+	}
+	return &compose.SecurityHeaders{
+		HSTSMaxAge:            int(h.HstsMaxAge),
+		HSTSIncludeSubdomains: h.HstsIncludeSubdomains,
+		HSTSPreload:           h.HstsPreload,
+		FrameOptions:          h.FrameOptions,
+		ContentSecurityPolicy: h.ContentSecurityPolicy,
+		ReferrerPolicy:        h.ReferrerPolicy,
+	}
+}
+
+func toComposeRedirects(redirects []*pb.RedirectConfig) []compose.Redirect {
+	result := make([]compose.Redirect, 0, len(redirects))
+	for _, r := range redirects {
+		result = append(result, compose.Redirect{
+			SourceHost: r.SourceHost,
+			SourcePath: r.SourcePath,
+			Target:     r.Target,
+			Permanent:  r.Permanent,
+		})
+	}
+	return result
 }
 
 func (e *Executor) rollback(ctx context.Context, req *pb.DeployRequest, cfg *compose.Config, appDir string) {
@@ -526,17 +837,19 @@ func (e *Executor) rollback(ctx context.Context, req *pb.DeployRequest, cfg *com
 
 	e.logger.Info("Rolling back deployment", "appName", req.AppName, "rollbackImage", *req.RollbackImage)
 
-	var domainRoutes []compose.DomainRoute
-	for _, d := range cfg.Domains {
-		domainRoutes = append(domainRoutes, compose.DomainRoute{Domain: d})
-	}
+	domainRoutes, redirects := deployDomainRoutes(req, cfg)
 
 	if err := compose.WriteComposeFile(appDir, compose.GenerateParams{
-		AppName:  req.AppName,
-		ImageTag: *req.RollbackImage,
-		Config:   cfg,
-		Domains:  domainRoutes,
-		EnvVars:  req.EnvVars,
+		AppName:   req.AppName,
+		ImageTag:  *req.RollbackImage,
+		Config:    cfg,
+		Domains:   domainRoutes,
+		EnvVars:   req.EnvVars,
+		RateLimit: toComposeRateLimit(req.GetRuntime().GetRateLimit()),
+		Redirects: redirects,
+		Headers:   toComposeSecurityHeaders(req.GetRuntime().GetSecurityHeaders()),
+		Internal:  req.GetRuntime().GetInternal(),
+		MeshIP:    meshIP(),
 	}); err != nil {
 		e.logger.Error("Rollback compose generation failed", "error", err)
 		return
@@ -601,14 +914,6 @@ func (e *Executor) UpdateDomains(ctx context.Context, req *pb.UpdateDomainsReque
 		return nil, fmt.Errorf("container %s not found", req.AppName)
 	}
 
-	var domainRoutes []compose.DomainRoute
-	for _, d := range req.Domains {
-		domainRoutes = append(domainRoutes, compose.DomainRoute{
-			Domain:     d.Domain,
-			PathPrefix: d.PathPrefix,
-		})
-	}
-
 	cfg := &compose.Config{}
 	compose.ApplyDefaults(cfg)
 	if req.Port > 0 {
@@ -616,15 +921,22 @@ func (e *Executor) UpdateDomains(ctx context.Context, req *pb.UpdateDomainsReque
 	}
 
 	if localCfg := e.findLocalConfig(appDir); localCfg != nil {
+		localCfg.Interpolate(req.EnvVars)
 		e.mergeRuntimeConfig(cfg, localCfg)
 	}
+	domainRoutes, redirects := mergeDomainRoutes(cfg, toComposeRoutes(req.Domains), req.Redirects)
 
 	content := compose.GenerateContent(compose.GenerateParams{
-		AppName:  req.AppName,
-		ImageTag: containerHealth.Image,
-		Config:   cfg,
-		Domains:  domainRoutes,
-		EnvVars:  req.EnvVars,
+		AppName:   req.AppName,
+		ImageTag:  containerHealth.Image,
+		Config:    cfg,
+		Domains:   domainRoutes,
+		EnvVars:   req.EnvVars,
+		RateLimit: toComposeRateLimit(req.RateLimit),
+		Redirects: redirects,
+		Headers:   toComposeSecurityHeaders(req.SecurityHeaders),
+		Internal:  req.Internal,
+		MeshIP:    meshIP(),
 	})
 
 	composePath := filepath.Join(appDir, "docker-compose.yml")
@@ -648,7 +960,7 @@ func (e *Executor) UpdateDomains(ctx context.Context, req *pb.UpdateDomainsReque
 	}, nil
 }
 
-func (e *Executor) failResponse(req *pb.DeployRequest, code pb.DeployErrorCode, stage string, err error, startedAt time.Time) *pb.DeployResponse {
+func (e *Executor) failResponse(req *pb.DeployRequest, code pb.DeployErrorCode, stage string, err error, timer *stageTimer) *pb.DeployResponse {
 	e.logger.Error("Deployment failed",
 		"deploymentId", req.DeploymentId,
 		"appName", req.AppName,
@@ -665,8 +977,9 @@ func (e *Executor) failResponse(req *pb.DeployRequest, code pb.DeployErrorCode,
 			Stage:   stage,
 		},
 		Result: &pb.DeployResult{
-			StartedAt:   timestamppb.New(startedAt),
-			CompletedAt: timestamppb.New(time.Now()),
+			StartedAt:    timestamppb.New(timer.start),
+			CompletedAt:  timestamppb.New(time.Now()),
+			StageTimings: timer.list(),
 		},
 	}
 }
@@ -0,0 +1,26 @@
+package deploy
+
+import "net"
+
+// meshInterface is the WireGuard interface the provisioner creates when the
+// server joins the private mesh.
+const meshInterface = "wg-paasdeploy"
+
+// meshIP returns the server's mesh address, or "" when the server has not
+// joined the mesh.
+func meshIP() string {
+	iface, err := net.InterfaceByName(meshInterface)
+	if err != nil {
+		return ""
+	}
+	addrs, err := iface.Addrs()
+	if err != nil {
+		return ""
+	}
+	for _, addr := range addrs {
+		if ipNet, ok := addr.(*net.IPNet); ok && ipNet.IP.To4() != nil {
+			return ipNet.IP.String()
+		}
+	}
+	return ""
+}
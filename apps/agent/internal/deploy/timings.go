@@ -0,0 +1,61 @@
+package deploy
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	pb "github.com/paasdeploy/backend/gen/go/flowdeploy/v1"
+)
+
+// stageTimer records when each stage of a deployment ran. Stages may run
+// concurrently, so it is safe for use from several goroutines.
+type stageTimer struct {
+	start time.Time
+
+	mu      sync.Mutex
+	timings []*pb.StageTiming
+}
+
+func newStageTimer(start time.Time) *stageTimer {
+	return &stageTimer{start: start}
+}
+
+// track starts timing stage and returns the function that stops it.
+func (t *stageTimer) track(stage pb.DeployStage) func() {
+	begin := time.Now()
+	return func() {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		t.timings = append(t.timings, &pb.StageTiming{
+			Stage:         stage,
+			StartOffsetMs: begin.Sub(t.start).Milliseconds(),
+			DurationMs:    time.Since(begin).Milliseconds(),
+		})
+	}
+}
+
+// durationMs returns the total time spent in stage.
+func (t *stageTimer) durationMs(stage pb.DeployStage) int64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var total int64
+	for _, timing := range t.timings {
+		if timing.Stage == stage {
+			total += timing.DurationMs
+		}
+	}
+	return total
+}
+
+// list returns the recorded timings ordered by when the stages started.
+func (t *stageTimer) list() []*pb.StageTiming {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	timings := make([]*pb.StageTiming, len(t.timings))
+	copy(timings, t.timings)
+	sort.SliceStable(timings, func(i, j int) bool {
+		return timings[i].StartOffsetMs < timings[j].StartOffsetMs
+	})
+	return timings
+}
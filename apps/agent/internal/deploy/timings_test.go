@@ -0,0 +1,30 @@
+package deploy
+
+import (
+	"testing"
+	"time"
+
+	pb "github.com/paasdeploy/backend/gen/go/flowdeploy/v1"
+)
+
+func TestStageTimerRecordsOverlappingStages(t *testing.T) {
+	timer := newStageTimer(time.Now())
+
+	stopPrepare := timer.track(pb.DeployStage_DEPLOY_STAGE_INITIALIZING)
+	time.Sleep(2 * time.Millisecond)
+	stopSync := timer.track(pb.DeployStage_DEPLOY_STAGE_GIT_SYNC)
+	time.Sleep(5 * time.Millisecond)
+	stopSync()
+	stopPrepare()
+
+	timings := timer.list()
+	if len(timings) != 2 {
+		t.Fatalf("got %d timings, want 2", len(timings))
+	}
+	if timings[0].Stage != pb.DeployStage_DEPLOY_STAGE_INITIALIZING {
+		t.Errorf("first stage = %v, want the one started first", timings[0].Stage)
+	}
+	if got := timer.durationMs(pb.DeployStage_DEPLOY_STAGE_GIT_SYNC); got < 5 {
+		t.Errorf("git sync duration = %dms, want at least 5ms", got)
+	}
+}
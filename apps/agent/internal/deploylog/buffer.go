@@ -0,0 +1,249 @@
+package deploylog
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	pb "github.com/paasdeploy/backend/gen/go/flowdeploy/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+const recordHeaderSize = 4
+
+// Buffer keeps the log entries of one deployment on disk, so subscribers
+// that connect late or reconnect still receive them. Entries are written to
+// numbered segment files; once there are more than maxSegments, the oldest is
+// deleted, which bounds the buffer to about maxSegments * segmentSize bytes.
+type Buffer struct {
+	dir         string
+	segmentSize int64
+	maxSegments int
+
+	mu       sync.Mutex
+	first    int
+	current  int
+	file     *os.File
+	size     int64
+	sequence uint64
+	closed   bool
+	changed  chan struct{}
+}
+
+func Open(dir string, segmentSize int64, maxSegments int) (*Buffer, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("create deploy log dir: %w", err)
+	}
+	b := &Buffer{
+		dir:         dir,
+		segmentSize: segmentSize,
+		maxSegments: maxSegments,
+		first:       1,
+		current:     1,
+		changed:     make(chan struct{}),
+	}
+	file, err := os.OpenFile(b.segmentPath(1), os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o640)
+	if err != nil {
+		return nil, fmt.Errorf("open deploy log segment: %w", err)
+	}
+	b.file = file
+	return b, nil
+}
+
+// Append assigns entry the next sequence number and writes it.
+func (b *Buffer) Append(entry *pb.DeployLogEntry) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return errors.New("deploy log buffer is closed")
+	}
+
+	b.sequence++
+	entry.Sequence = b.sequence
+	data, err := proto.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("encode deploy log entry: %w", err)
+	}
+
+	if b.size > 0 && b.size+int64(recordHeaderSize+len(data)) > b.segmentSize {
+		if err := b.rotate(); err != nil {
+			return err
+		}
+	}
+
+	record := make([]byte, recordHeaderSize+len(data))
+	binary.BigEndian.PutUint32(record, uint32(len(data)))
+	copy(record[recordHeaderSize:], data)
+	n, err := b.file.Write(record)
+	b.size += int64(n)
+	if err != nil {
+		return fmt.Errorf("write deploy log entry: %w", err)
+	}
+
+	b.notify()
+	return nil
+}
+
+// Close marks the deployment finished. Readers get io.EOF once they have
+// read every entry.
+func (b *Buffer) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return nil
+	}
+	b.closed = true
+	b.notify()
+	return b.file.Close()
+}
+
+// Remove closes the buffer and deletes its files.
+func (b *Buffer) Remove() error {
+	if err := b.Close(); err != nil {
+		return err
+	}
+	return os.RemoveAll(b.dir)
+}
+
+// Reader returns a reader of the entries with a sequence above after.
+func (b *Buffer) Reader(after uint64) *Reader {
+	return &Reader{buffer: b, after: after}
+}
+
+func (b *Buffer) rotate() error {
+	if err := b.file.Close(); err != nil {
+		return fmt.Errorf("close deploy log segment: %w", err)
+	}
+	file, err := os.OpenFile(b.segmentPath(b.current+1), os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o640)
+	if err != nil {
+		return fmt.Errorf("open deploy log segment: %w", err)
+	}
+	b.current++
+	b.file = file
+	b.size = 0
+
+	for b.current-b.first >= b.maxSegments {
+		_ = os.Remove(b.segmentPath(b.first))
+		b.first++
+	}
+	return nil
+}
+
+func (b *Buffer) notify() {
+	close(b.changed)
+	b.changed = make(chan struct{})
+}
+
+func (b *Buffer) segmentPath(n int) string {
+	return filepath.Join(b.dir, fmt.Sprintf("%06d.seg", n))
+}
+
+type bufferState struct {
+	first, current int
+	size           int64
+	closed         bool
+	changed        <-chan struct{}
+}
+
+func (b *Buffer) state() bufferState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return bufferState{first: b.first, current: b.current, size: b.size, closed: b.closed, changed: b.changed}
+}
+
+// Reader reads a Buffer in order, following it as entries are appended.
+// Entries in segments deleted before the reader got to them are skipped.
+type Reader struct {
+	buffer  *Buffer
+	after   uint64
+	segment int
+	offset  int64
+}
+
+// Next returns the entries available after the last call, waiting for new
+// ones when there are none. It returns io.EOF once the buffer is closed and
+// fully read.
+func (r *Reader) Next(ctx context.Context) ([]*pb.DeployLogEntry, error) {
+	for {
+		st := r.buffer.state()
+		if r.segment < st.first {
+			r.segment, r.offset = st.first, 0
+		}
+
+		limit := int64(-1)
+		if r.segment == st.current {
+			limit = st.size
+		}
+		entries, err := r.readSegment(limit)
+		if err != nil {
+			return nil, err
+		}
+		if len(entries) > 0 {
+			return entries, nil
+		}
+
+		if r.segment < st.current {
+			r.segment, r.offset = r.segment+1, 0
+			continue
+		}
+		if st.closed {
+			return nil, io.EOF
+		}
+		select {
+		case <-st.changed:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// readSegment reads the records of the current segment from r.offset up to
+// limit bytes, or to its end when limit is negative.
+func (r *Reader) readSegment(limit int64) ([]*pb.DeployLogEntry, error) {
+	file, err := os.Open(r.buffer.segmentPath(r.segment))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("open deploy log segment: %w", err)
+	}
+	defer file.Close()
+
+	var src io.Reader = io.NewSectionReader(file, r.offset, limit-r.offset)
+	if limit < 0 {
+		if _, err := file.Seek(r.offset, io.SeekStart); err != nil {
+			return nil, fmt.Errorf("seek deploy log segment: %w", err)
+		}
+		src = file
+	}
+	data, err := io.ReadAll(src)
+	if err != nil {
+		return nil, fmt.Errorf("read deploy log segment: %w", err)
+	}
+
+	var entries []*pb.DeployLogEntry
+	for pos := 0; pos+recordHeaderSize <= len(data); {
+		end := pos + recordHeaderSize + int(binary.BigEndian.Uint32(data[pos:]))
+		if end > len(data) {
+			break
+		}
+		entry := &pb.DeployLogEntry{}
+		if err := proto.Unmarshal(data[pos+recordHeaderSize:end], entry); err != nil {
+			return nil, fmt.Errorf("decode deploy log entry: %w", err)
+		}
+		r.offset += int64(end - pos)
+		pos = end
+		if entry.Sequence > r.after {
+			entries = append(entries, entry)
+			r.after = entry.Sequence
+		}
+	}
+	return entries, nil
+}
@@ -0,0 +1,97 @@
+package deploylog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	pb "github.com/paasdeploy/backend/gen/go/flowdeploy/v1"
+)
+
+func appendLines(t *testing.T, b *Buffer, from, to int) {
+	t.Helper()
+	for i := from; i < to; i++ {
+		if err := b.Append(&pb.DeployLogEntry{Message: fmt.Sprintf("line %d", i)}); err != nil {
+			t.Fatalf("append: %v", err)
+		}
+	}
+}
+
+func readAll(t *testing.T, r *Reader) []*pb.DeployLogEntry {
+	t.Helper()
+	var all []*pb.DeployLogEntry
+	for {
+		entries, err := r.Next(context.Background())
+		if errors.Is(err, io.EOF) {
+			return all
+		}
+		if err != nil {
+			t.Fatalf("next: %v", err)
+		}
+		all = append(all, entries...)
+	}
+}
+
+func TestBufferReplaysEntriesToLateReaders(t *testing.T) {
+	b, err := Open(t.TempDir(), 1024, 4)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	appendLines(t, b, 0, 10)
+	b.Close()
+
+	entries := readAll(t, b.Reader(0))
+	if len(entries) != 10 || entries[0].Message != "line 0" || entries[9].Sequence != 10 {
+		t.Fatalf("unexpected entries: %d, first %v", len(entries), entries[0])
+	}
+
+	resumed := readAll(t, b.Reader(7))
+	if len(resumed) != 3 || resumed[0].Sequence != 8 {
+		t.Fatalf("resume after 7 returned %d entries starting at %d", len(resumed), resumed[0].Sequence)
+	}
+}
+
+func TestBufferDropsOldestSegments(t *testing.T) {
+	b, err := Open(t.TempDir(), 64, 2)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	appendLines(t, b, 0, 50)
+	b.Close()
+
+	entries := readAll(t, b.Reader(0))
+	if len(entries) == 0 || len(entries) >= 50 {
+		t.Fatalf("expected the oldest entries to be dropped, got %d", len(entries))
+	}
+	if last := entries[len(entries)-1]; last.Message != "line 49" {
+		t.Fatalf("last entry = %q, want line 49", last.Message)
+	}
+}
+
+func TestReaderFollowsAppends(t *testing.T) {
+	b, err := Open(t.TempDir(), 1024, 4)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	r := b.Reader(0)
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		_ = b.Append(&pb.DeployLogEntry{Message: "line 0"})
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	entries, err := r.Next(ctx)
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("next = %d entries, %v", len(entries), err)
+	}
+
+	b.Close()
+	if _, err := r.Next(ctx); !errors.Is(err, io.EOF) {
+		t.Fatalf("next after close = %v, want EOF", err)
+	}
+}
@@ -0,0 +1,83 @@
+package grpcserver
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/paasdeploy/backend/gen/go/flowdeploy/v1"
+)
+
+const commandTimeout = 5 * time.Minute
+
+type commandResponse interface {
+	GetSuccess() bool
+	GetMessage() string
+}
+
+// ExecuteCommand runs a command queued by the backend while the agent was
+// unreachable. Payloads are the JSON encoding of the matching RPC request.
+func (s *Server) ExecuteCommand(ctx context.Context, cmd *pb.AgentCommand) *pb.AgentCommandResult {
+	ctx, cancel := context.WithTimeout(ctx, commandTimeout)
+	defer cancel()
+
+	resp, err := s.dispatchCommand(ctx, cmd)
+	result := &pb.AgentCommandResult{CommandId: cmd.GetId()}
+	switch {
+	case err != nil:
+		result.Message = err.Error()
+	case resp != nil:
+		result.Success = resp.GetSuccess()
+		result.Message = resp.GetMessage()
+	}
+
+	s.logger.Info("queued command executed", "commandId", cmd.GetId(), "type", cmd.GetType(), "success", result.Success)
+	return result
+}
+
+func (s *Server) dispatchCommand(ctx context.Context, cmd *pb.AgentCommand) (commandResponse, error) {
+	switch cmd.GetType() {
+	case pb.AgentCommandType_AGENT_COMMAND_START_CONTAINER:
+		req := &pb.StartContainerRequest{}
+		if err := decodeCommandPayload(cmd, req); err != nil {
+			return nil, err
+		}
+		return s.service.StartContainer(ctx, req)
+	case pb.AgentCommandType_AGENT_COMMAND_STOP_CONTAINER:
+		req := &pb.StopContainerRequest{}
+		if err := decodeCommandPayload(cmd, req); err != nil {
+			return nil, err
+		}
+		return s.service.StopContainer(ctx, req)
+	case pb.AgentCommandType_AGENT_COMMAND_RESTART_CONTAINER:
+		req := &pb.RestartContainerRequest{}
+		if err := decodeCommandPayload(cmd, req); err != nil {
+			return nil, err
+		}
+		return s.service.RestartContainer(ctx, req)
+	case pb.AgentCommandType_AGENT_COMMAND_REMOVE_CONTAINER:
+		req := &pb.RemoveContainerRequest{}
+		if err := decodeCommandPayload(cmd, req); err != nil {
+			return nil, err
+		}
+		return s.service.RemoveContainer(ctx, req)
+	case pb.AgentCommandType_AGENT_COMMAND_UPDATE_DOMAINS:
+		req := &pb.UpdateDomainsRequest{}
+		if err := decodeCommandPayload(cmd, req); err != nil {
+			return nil, err
+		}
+		return s.service.UpdateDomains(ctx, req)
+	default:
+		return nil, fmt.Errorf("unsupported command type %s", cmd.GetType())
+	}
+}
+
+func decodeCommandPayload(cmd *pb.AgentCommand, msg proto.Message) error {
+	if err := protojson.Unmarshal([]byte(cmd.GetPayload()), msg); err != nil {
+		return fmt.Errorf("decode %s payload: %w", cmd.GetType(), err)
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package grpcserver
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	pb "github.com/paasdeploy/backend/gen/go/flowdeploy/v1"
+	"github.com/paasdeploy/shared/pkg/traefik"
+)
+
+const (
+	// traefikAccessLogPath is where the provisioner points Traefik's JSON
+	// access log inside the container.
+	traefikAccessLogPath = "/var/log/traefik/access.log"
+	// accessLogTailLines bounds how much of the log is read per request.
+	accessLogTailLines = 50000
+)
+
+// GetAccessLogStats aggregates the tail of Traefik's access log into
+// per-domain request counts, status codes and p95 latency.
+func (s *AgentService) GetAccessLogStats(ctx context.Context, req *pb.GetAccessLogStatsRequest) (*pb.GetAccessLogStatsResponse, error) {
+	out, err := s.executor.RunQuietWithTimeout(ctx, acmeCommandTimeout, "docker", "exec", traefikContainerName,
+		"tail", "-n", fmt.Sprintf("%d", accessLogTailLines), traefikAccessLogPath)
+	if err != nil {
+		s.logger.Warn("Failed to read Traefik access log", "error", err)
+		return &pb.GetAccessLogStatsResponse{}, nil
+	}
+
+	stats, err := traefik.AggregateAccessLog(strings.NewReader(out.Stdout), req.GetDomains(), time.Unix(req.GetSince(), 0))
+	if err != nil {
+		return nil, fmt.Errorf("parse access log: %w", err)
+	}
+
+	result := make([]*pb.DomainAccessStats, 0, len(stats))
+	for _, st := range stats {
+		codes := make(map[int32]int64, len(st.StatusCodes))
+		for code, n := range st.StatusCodes {
+			codes[int32(code)] = n
+		}
+		result = append(result, &pb.DomainAccessStats{
+			Domain:       st.Domain,
+			Requests:     st.Requests,
+			StatusCodes:  codes,
+			P95LatencyMs: float64(st.P95Latency) / float64(time.Millisecond),
+		})
+	}
+	return &pb.GetAccessLogStatsResponse{Domains: result}, nil
+}
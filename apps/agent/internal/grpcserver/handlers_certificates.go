@@ -0,0 +1,219 @@
+package grpcserver
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	pb "github.com/paasdeploy/backend/gen/go/flowdeploy/v1"
+	"github.com/paasdeploy/shared/pkg/traefik"
+)
+
+// traefikDynamicMountPath is where the provisioner mounts the dynamic config
+// directory inside the Traefik container.
+const traefikDynamicMountPath = "/etc/traefik/dynamic"
+
+const (
+	traefikContainerName = "traefik"
+	acmeCommandTimeout   = 30 * time.Second
+)
+
+// acmeStorages are the ACME storage files inside the Traefik container. The
+// provisioner switches to the staging file when Let's Encrypt staging is on.
+var acmeStorages = []struct {
+	path    string
+	staging bool
+}{
+	{"/letsencrypt/acme.json", false},
+	{"/letsencrypt/acme-staging.json", true},
+}
+
+var certificateDomainRe = regexp.MustCompile(`^(\*\.)?[a-z0-9]([a-z0-9.-]*[a-z0-9])?$`)
+
+func certificateFileName(domain string) (string, error) {
+	domain = strings.ToLower(strings.TrimSpace(domain))
+	if !certificateDomainRe.MatchString(domain) {
+		return "", fmt.Errorf("invalid domain: %q", domain)
+	}
+	return strings.Replace(domain, "*", "_wildcard", 1), nil
+}
+
+// InstallCertificate writes an uploaded certificate and key next to a Traefik
+// file provider config that references them. The config file is written last
+// so Traefik only reloads once both PEM files are in place.
+func (s *AgentService) InstallCertificate(_ context.Context, req *pb.InstallCertificateRequest) (*pb.InstallCertificateResponse, error) {
+	name, err := certificateFileName(req.GetDomain())
+	if err != nil {
+		return &pb.InstallCertificateResponse{Success: false, Message: err.Error()}, nil
+	}
+	if _, err := tls.X509KeyPair([]byte(req.GetCertificatePem()), []byte(req.GetPrivateKeyPem())); err != nil {
+		return &pb.InstallCertificateResponse{Success: false, Message: fmt.Sprintf("invalid certificate or key: %v", err)}, nil
+	}
+
+	certsDir := filepath.Join(s.traefikDynamicDir, "certs")
+	if err := os.MkdirAll(certsDir, 0o755); err != nil {
+		return &pb.InstallCertificateResponse{Success: false, Message: fmt.Sprintf("create certs dir: %v", err)}, nil
+	}
+
+	files := []struct {
+		path string
+		data string
+		mode os.FileMode
+	}{
+		{filepath.Join(certsDir, name+".crt"), req.GetCertificatePem(), 0o644},
+		{filepath.Join(certsDir, name+".key"), req.GetPrivateKeyPem(), 0o600},
+		{filepath.Join(s.traefikDynamicDir, "cert-"+name+".yml"), certificateDynamicConfig(name), 0o644},
+	}
+	for _, f := range files {
+		if err := writeFileAtomic(f.path, []byte(f.data), f.mode); err != nil {
+			s.logger.Error("Failed to write certificate file", "domain", req.GetDomain(), "path", f.path, "error", err)
+			return &pb.InstallCertificateResponse{Success: false, Message: err.Error()}, nil
+		}
+	}
+
+	s.logger.Info("Custom certificate installed", "domain", req.GetDomain())
+	return &pb.InstallCertificateResponse{Success: true, Message: "certificate installed"}, nil
+}
+
+func (s *AgentService) RemoveCertificate(_ context.Context, req *pb.RemoveCertificateRequest) (*pb.RemoveCertificateResponse, error) {
+	name, err := certificateFileName(req.GetDomain())
+	if err != nil {
+		return &pb.RemoveCertificateResponse{Success: false, Message: err.Error()}, nil
+	}
+
+	for _, path := range []string{
+		filepath.Join(s.traefikDynamicDir, "cert-"+name+".yml"),
+		filepath.Join(s.traefikDynamicDir, "certs", name+".crt"),
+		filepath.Join(s.traefikDynamicDir, "certs", name+".key"),
+	} {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return &pb.RemoveCertificateResponse{Success: false, Message: err.Error()}, nil
+		}
+	}
+
+	s.logger.Info("Custom certificate removed", "domain", req.GetDomain())
+	return &pb.RemoveCertificateResponse{Success: true, Message: "certificate removed"}, nil
+}
+
+func certificateDynamicConfig(name string) string {
+	return fmt.Sprintf(`tls:
+  certificates:
+    - certFile: %[1]s/certs/%[2]s.crt
+      keyFile: %[1]s/certs/%[2]s.key
+`, traefikDynamicMountPath, name)
+}
+
+func writeFileAtomic(path string, data []byte, mode os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), mode); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func (s *AgentService) ListAcmeCertificates(ctx context.Context, _ *pb.ListAcmeCertificatesRequest) (*pb.ListAcmeCertificatesResponse, error) {
+	var result []*pb.AcmeCertificate
+	for _, storage := range acmeStorages {
+		out, err := s.executor.RunQuietWithTimeout(ctx, acmeCommandTimeout, "docker", "exec", traefikContainerName, "cat", storage.path)
+		if err != nil {
+			continue
+		}
+		certs, err := traefik.ParseAcmeStore([]byte(out.Stdout))
+		if err != nil {
+			s.logger.Warn("Failed to parse ACME storage", "path", storage.path, "error", err)
+			continue
+		}
+		for _, c := range certs {
+			cert := &pb.AcmeCertificate{
+				Resolver: c.Resolver,
+				Domain:   c.Domain,
+				Sans:     c.Sans,
+				Issuer:   c.Issuer,
+				Staging:  storage.staging,
+			}
+			if !c.NotAfter.IsZero() {
+				cert.NotBefore = c.NotBefore.Unix()
+				cert.NotAfter = c.NotAfter.Unix()
+			}
+			result = append(result, cert)
+		}
+	}
+	return &pb.ListAcmeCertificatesResponse{Certificates: result}, nil
+}
+
+// DeleteAcmeCertificates removes certificates from Traefik's ACME storage.
+// Traefik keeps the storage in memory and rewrites it, so it is stopped while
+// the files are edited; on start it requests new certificates for any domain
+// that is still routed, which is how a renewal is forced.
+func (s *AgentService) DeleteAcmeCertificates(ctx context.Context, req *pb.DeleteAcmeCertificatesRequest) (*pb.DeleteAcmeCertificatesResponse, error) {
+	if len(req.GetDomains()) == 0 {
+		return &pb.DeleteAcmeCertificatesResponse{Success: true, Message: "nothing to delete"}, nil
+	}
+
+	tmpDir, err := os.MkdirTemp("", "acme-")
+	if err != nil {
+		return &pb.DeleteAcmeCertificatesResponse{Success: false, Message: err.Error()}, nil
+	}
+	defer os.RemoveAll(tmpDir)
+
+	if _, err := s.executor.RunWithTimeout(ctx, acmeCommandTimeout, "docker", "stop", traefikContainerName); err != nil {
+		return &pb.DeleteAcmeCertificatesResponse{Success: false, Message: fmt.Sprintf("failed to stop traefik: %v", err)}, nil
+	}
+	defer func() {
+		if _, err := s.executor.RunWithTimeout(context.Background(), acmeCommandTimeout, "docker", "start", traefikContainerName); err != nil {
+			s.logger.Error("Failed to start traefik after editing ACME storage", "error", err)
+		}
+	}()
+
+	removed := 0
+	for i, storage := range acmeStorages {
+		local := filepath.Join(tmpDir, fmt.Sprintf("acme-%d.json", i))
+		if _, err := s.executor.RunQuietWithTimeout(ctx, acmeCommandTimeout, "docker", "cp", traefikContainerName+":"+storage.path, local); err != nil {
+			continue
+		}
+		data, err := os.ReadFile(local)
+		if err != nil {
+			return &pb.DeleteAcmeCertificatesResponse{Success: false, Message: err.Error()}, nil
+		}
+
+		updated, n, err := traefik.RemoveAcmeCertificates(data, req.GetDomains())
+		if err != nil {
+			return &pb.DeleteAcmeCertificatesResponse{Success: false, Message: err.Error()}, nil
+		}
+		if n == 0 {
+			continue
+		}
+		if err := os.WriteFile(local, updated, 0o600); err != nil {
+			return &pb.DeleteAcmeCertificatesResponse{Success: false, Message: err.Error()}, nil
+		}
+		if _, err := s.executor.RunWithTimeout(ctx, acmeCommandTimeout, "docker", "cp", local, traefikContainerName+":"+storage.path); err != nil {
+			return &pb.DeleteAcmeCertificatesResponse{Success: false, Message: fmt.Sprintf("failed to write %s: %v", storage.path, err)}, nil
+		}
+		removed += n
+	}
+
+	s.logger.Info("ACME certificates deleted", "domains", req.GetDomains(), "removed", removed)
+	return &pb.DeleteAcmeCertificatesResponse{
+		Success: true,
+		Message: fmt.Sprintf("removed %d certificate(s)", removed),
+		Removed: int32(removed),
+	}, nil
+}
@@ -0,0 +1,56 @@
+package grpcserver
+
+import (
+	"context"
+	"path/filepath"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/paasdeploy/backend/gen/go/flowdeploy/v1"
+	"github.com/paasdeploy/shared/pkg/compose"
+)
+
+const composeConfigTimeout = 30 * time.Second
+
+// ReadComposeProject resolves the compose project in the given directory
+// with `docker compose config` so the backend can adopt its services as
+// managed apps. Interpolation and env files are applied by Compose itself.
+func (s *AgentService) ReadComposeProject(ctx context.Context, req *pb.ReadComposeProjectRequest) (*pb.ReadComposeProjectResponse, error) {
+	dir := filepath.Clean(req.GetPath())
+	if !filepath.IsAbs(dir) {
+		return nil, status.Error(codes.InvalidArgument, "path must be absolute")
+	}
+
+	out, err := s.executor.RunQuietWithTimeout(ctx, composeConfigTimeout, "docker", "compose",
+		"--project-directory", dir, "config", "--format", "json")
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "read compose project: %v", err)
+	}
+
+	name, services, err := compose.ParseProject([]byte(out.Stdout), dir)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &pb.ReadComposeProjectResponse{ProjectName: name}
+	for _, svc := range services {
+		volumes := make([]*pb.ComposeVolume, 0, len(svc.Volumes))
+		for _, v := range svc.Volumes {
+			volumes = append(volumes, &pb.ComposeVolume{Source: v.Source, Target: v.Target, ReadOnly: v.ReadOnly})
+		}
+		resp.Services = append(resp.Services, &pb.ComposeService{
+			Name:         svc.Name,
+			Image:        svc.Image,
+			BuildContext: svc.BuildContext,
+			Dockerfile:   svc.Dockerfile,
+			Environment:  svc.Environment,
+			Port:         int32(svc.Port),
+			Volumes:      volumes,
+			Domains:      svc.Domains,
+		})
+	}
+	s.logger.Info("Compose project read", "path", dir, "services", len(resp.Services))
+	return resp, nil
+}
@@ -8,6 +8,8 @@ import (
 
 	pb "github.com/paasdeploy/backend/gen/go/flowdeploy/v1"
 	"github.com/paasdeploy/shared/pkg/docker"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -190,6 +192,89 @@ func (s *AgentService) GetContainerStats(req *pb.ContainerStatsRequest, stream p
 	}
 }
 
+func (s *AgentService) GetContainerTop(ctx context.Context, req *pb.GetContainerTopRequest) (*pb.GetContainerTopResponse, error) {
+	processes, err := s.docker.ContainerTop(ctx, req.ContainerId)
+	if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	pbProcesses := make([]*pb.ContainerProcess, 0, len(processes))
+	for _, p := range processes {
+		pbProcesses = append(pbProcesses, &pb.ContainerProcess{
+			Pid:        int32(p.PID),
+			Ppid:       int32(p.PPID),
+			User:       p.User,
+			CpuPercent: p.CPUPercent,
+			MemPercent: p.MemPercent,
+			Elapsed:    p.Elapsed,
+			Command:    p.Command,
+		})
+	}
+	return &pb.GetContainerTopResponse{Processes: pbProcesses}, nil
+}
+
+func (s *AgentService) InspectContainer(ctx context.Context, req *pb.InspectContainerRequest) (*pb.InspectContainerResponse, error) {
+	inspect, err := s.docker.InspectContainerFull(ctx, req.ContainerId)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	resp := &pb.InspectContainerResponse{
+		Id:            inspect.ID,
+		Name:          inspect.Name,
+		Image:         inspect.Image,
+		ImageId:       inspect.ImageID,
+		Created:       inspect.Created,
+		RestartCount:  int32(inspect.RestartCount),
+		RestartPolicy: inspect.RestartPolicy,
+		State: &pb.ContainerInspectState{
+			Status:     inspect.State.Status,
+			Running:    inspect.State.Running,
+			Paused:     inspect.State.Paused,
+			Restarting: inspect.State.Restarting,
+			OomKilled:  inspect.State.OOMKilled,
+			Dead:       inspect.State.Dead,
+			ExitCode:   int32(inspect.State.ExitCode),
+			Error:      inspect.State.Error,
+			StartedAt:  inspect.State.StartedAt,
+			FinishedAt: inspect.State.FinishedAt,
+		},
+		EnvNames: inspect.EnvNames,
+		Labels:   inspect.Labels,
+	}
+	for _, m := range inspect.Mounts {
+		resp.Mounts = append(resp.Mounts, &pb.ContainerMount{
+			Type:        m.Type,
+			Source:      m.Source,
+			Destination: m.Destination,
+			ReadOnly:    m.ReadOnly,
+		})
+	}
+	for _, n := range inspect.Networks {
+		resp.Networks = append(resp.Networks, &pb.ContainerNetwork{
+			Name:       n.Name,
+			IpAddress:  n.IPAddress,
+			Gateway:    n.Gateway,
+			MacAddress: n.MacAddress,
+		})
+	}
+	if inspect.Health != nil {
+		resp.Health = &pb.ContainerHealthLog{
+			Status:        inspect.Health.Status,
+			FailingStreak: int32(inspect.Health.FailingStreak),
+		}
+		for _, check := range inspect.Health.Log {
+			resp.Health.Log = append(resp.Health.Log, &pb.ContainerHealthCheck{
+				Start:    check.Start,
+				End:      check.End,
+				ExitCode: int32(check.ExitCode),
+				Output:   check.Output,
+			})
+		}
+	}
+	return resp, nil
+}
+
 func (s *AgentService) RestartContainer(ctx context.Context, req *pb.RestartContainerRequest) (*pb.RestartContainerResponse, error) {
 	if err := s.docker.RestartContainer(ctx, req.ContainerId); err != nil {
 		return &pb.RestartContainerResponse{Success: false, Message: err.Error()}, nil
@@ -218,6 +303,20 @@ func (s *AgentService) RemoveContainer(ctx context.Context, req *pb.RemoveContai
 	return &pb.RemoveContainerResponse{Success: true, Message: "Container removed"}, nil
 }
 
+func (s *AgentService) CommitContainer(ctx context.Context, req *pb.CommitContainerRequest) (*pb.CommitContainerResponse, error) {
+	imageID, err := s.docker.CommitContainer(ctx, req.ContainerId, docker.CommitOptions{
+		Repository: req.Repository,
+		Tag:        req.Tag,
+		Message:    req.Message,
+		Author:     req.Author,
+		Pause:      req.Pause,
+	})
+	if err != nil {
+		return &pb.CommitContainerResponse{Success: false, Message: err.Error()}, nil
+	}
+	return &pb.CommitContainerResponse{Success: true, Message: "Container committed", ImageId: imageID}, nil
+}
+
 func (s *AgentService) CreateContainerFromTemplate(ctx context.Context, req *pb.CreateContainerFromTemplateRequest) (*pb.CreateContainerFromTemplateResponse, error) {
 	opts := docker.CreateContainerOptions{
 		Name:          req.Name,
@@ -2,12 +2,30 @@ package grpcserver
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
 	"sync"
+	"time"
 
+	"github.com/paasdeploy/agent/internal/deploylog"
 	pb "github.com/paasdeploy/backend/gen/go/flowdeploy/v1"
+	"github.com/paasdeploy/shared/pkg/buildlog"
+	"google.golang.org/grpc"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+const (
+	logBatchMaxEntries    = 50
+	logBatchFlushInterval = 100 * time.Millisecond
+
+	logBufferSegmentSize = 1 << 20
+	logBufferSegments    = 8
+	logBufferRetention   = 10 * time.Minute
+)
+
 func (s *AgentService) getAppDeployLock(appName string) *sync.Mutex {
 	val, _ := s.deployLocks.LoadOrStore(appName, &sync.Mutex{})
 	return val.(*sync.Mutex)
@@ -18,69 +36,179 @@ func (s *AgentService) ExecuteDeploy(ctx context.Context, req *pb.DeployRequest)
 	mu.Lock()
 	defer mu.Unlock()
 
-	s.getOrCreateLogStream(req.DeploymentId)
-	logFn := s.buildLogFunc(req.DeploymentId)
+	buffer, err := s.logBuffer(req.DeploymentId)
+	if err != nil {
+		s.logger.Warn("Deploy logs will not be streamed", "deploymentId", req.DeploymentId, "error", err)
+	}
+	logFn := s.buildLogFunc(req.DeploymentId, buffer)
 	resp := s.deployExecutor.Execute(ctx, req, logFn)
-	s.closeLogStream(req.DeploymentId)
+	s.finishLogBuffer(req.DeploymentId, buffer)
 	return resp, nil
 }
 
 func (s *AgentService) StreamDeployLogs(sub *pb.DeployLogSubscription, stream pb.AgentService_StreamDeployLogsServer) error {
-	ch := s.getOrCreateLogStream(sub.DeploymentId)
-	defer s.logStreams.Delete(sub.DeploymentId)
+	buffer, err := s.logBuffer(sub.DeploymentId)
+	if err != nil {
+		return fmt.Errorf("failed to open deploy logs: %w", err)
+	}
+	reader := buffer.Reader(sub.AfterSequence)
 
-	s.logger.Info("Deploy log stream opened", "deploymentId", sub.DeploymentId)
+	s.logger.Info("Deploy log stream opened", "deploymentId", sub.DeploymentId, "afterSequence", sub.AfterSequence)
 
 	for {
-		select {
-		case entry, ok := <-ch:
-			if !ok {
-				s.logger.Info("Deploy log stream closed", "deploymentId", sub.DeploymentId)
+		entries, err := reader.Next(stream.Context())
+		if errors.Is(err, io.EOF) {
+			s.logger.Info("Deploy log stream closed", "deploymentId", sub.DeploymentId)
+			return nil
+		}
+		if err != nil {
+			if stream.Context().Err() != nil {
+				s.logger.Info("Deploy log stream context cancelled", "deploymentId", sub.DeploymentId)
 				return nil
 			}
+			return err
+		}
+		for _, entry := range entries {
 			if err := stream.Send(entry); err != nil {
 				s.logger.Warn("Failed to send deploy log entry", "deploymentId", sub.DeploymentId, "error", err)
 				return err
 			}
+		}
+	}
+}
+
+// StreamDeployLogBatches streams the same entries as StreamDeployLogs, gzip
+// compressed and grouped into batches of up to logBatchMaxEntries, sent at
+// most every logBatchFlushInterval unless a backlog is being caught up.
+func (s *AgentService) StreamDeployLogBatches(sub *pb.DeployLogSubscription, stream pb.AgentService_StreamDeployLogBatchesServer) error {
+	buffer, err := s.logBuffer(sub.DeploymentId)
+	if err != nil {
+		return fmt.Errorf("failed to open deploy logs: %w", err)
+	}
+	reader := buffer.Reader(sub.AfterSequence)
+
+	if err := grpc.SetSendCompressor(stream.Context(), "gzip"); err != nil {
+		s.logger.Debug("Deploy log batches sent uncompressed", "deploymentId", sub.DeploymentId, "error", err)
+	}
+
+	s.logger.Info("Deploy log batch stream opened", "deploymentId", sub.DeploymentId, "afterSequence", sub.AfterSequence)
+
+	for {
+		entries, err := reader.Next(stream.Context())
+		if errors.Is(err, io.EOF) {
+			s.logger.Info("Deploy log batch stream closed", "deploymentId", sub.DeploymentId)
+			return nil
+		}
+		if err != nil {
+			if stream.Context().Err() != nil {
+				s.logger.Info("Deploy log batch stream context cancelled", "deploymentId", sub.DeploymentId)
+				return nil
+			}
+			return err
+		}
+
+		for len(entries) > 0 {
+			n := min(len(entries), logBatchMaxEntries)
+			if err := stream.Send(&pb.DeployLogBatch{Entries: entries[:n]}); err != nil {
+				s.logger.Warn("Failed to send deploy log batch", "deploymentId", sub.DeploymentId, "error", err)
+				return err
+			}
+			entries = entries[n:]
+		}
+
+		select {
+		case <-time.After(logBatchFlushInterval):
 		case <-stream.Context().Done():
-			s.logger.Info("Deploy log stream context cancelled", "deploymentId", sub.DeploymentId)
 			return nil
 		}
 	}
 }
 
-func (s *AgentService) getOrCreateLogStream(deploymentID string) chan *pb.DeployLogEntry {
-	ch := make(chan *pb.DeployLogEntry, logStreamBuffer)
-	actual, _ := s.logStreams.LoadOrStore(deploymentID, ch)
-	return actual.(chan *pb.DeployLogEntry)
+// logBuffer returns the on-disk log buffer of a deployment, creating it on
+// first use by either the deploy or a subscriber, whichever comes first.
+func (s *AgentService) logBuffer(deploymentID string) (*deploylog.Buffer, error) {
+	if deploymentID == "" || filepath.Base(deploymentID) != deploymentID || strings.HasPrefix(deploymentID, ".") {
+		return nil, fmt.Errorf("invalid deployment id %q", deploymentID)
+	}
+
+	s.logBuffersMu.Lock()
+	defer s.logBuffersMu.Unlock()
+
+	if buffer, ok := s.logBuffers[deploymentID]; ok {
+		return buffer, nil
+	}
+	buffer, err := deploylog.Open(filepath.Join(s.logBufferDir, deploymentID), logBufferSegmentSize, logBufferSegments)
+	if err != nil {
+		return nil, err
+	}
+	s.logBuffers[deploymentID] = buffer
+	return buffer, nil
 }
 
-func (s *AgentService) buildLogFunc(deploymentID string) func(pb.DeployStage, pb.DeployLogLevel, string) {
+func (s *AgentService) buildLogFunc(deploymentID string, buffer *deploylog.Buffer) func(pb.DeployStage, pb.DeployLogLevel, string) {
 	return func(stage pb.DeployStage, level pb.DeployLogLevel, message string) {
-		val, ok := s.logStreams.Load(deploymentID)
-		if !ok {
+		if buffer == nil {
 			return
 		}
-		ch := val.(chan *pb.DeployLogEntry)
+		message, kind := buildlog.Parse(message)
 		entry := &pb.DeployLogEntry{
 			DeploymentId: deploymentID,
 			Timestamp:    timestamppb.Now(),
-			Level:        level,
+			Level:        logLevelOf(level, kind),
 			Stage:        stage,
 			Message:      message,
+			Kind:         logKindToProto(kind),
 		}
-		select {
-		case ch <- entry:
-		default:
-			s.logger.Debug("Deploy log channel full, dropping entry", "deploymentId", deploymentID)
+		if err := buffer.Append(entry); err != nil {
+			s.logger.Debug("Failed to buffer deploy log entry", "deploymentId", deploymentID, "error", err)
 		}
 	}
 }
 
-func (s *AgentService) closeLogStream(deploymentID string) {
-	val, loaded := s.logStreams.LoadAndDelete(deploymentID)
-	if !loaded {
+// logLevelOf raises the level of builder output that reads as a warning or
+// an error, which the executor forwards at info level.
+func logLevelOf(level pb.DeployLogLevel, kind buildlog.Kind) pb.DeployLogLevel {
+	if level != pb.DeployLogLevel_DEPLOY_LOG_LEVEL_INFO {
+		return level
+	}
+	switch kind {
+	case buildlog.KindWarning:
+		return pb.DeployLogLevel_DEPLOY_LOG_LEVEL_WARN
+	case buildlog.KindError:
+		return pb.DeployLogLevel_DEPLOY_LOG_LEVEL_ERROR
+	}
+	return level
+}
+
+func logKindToProto(kind buildlog.Kind) pb.DeployLogKind {
+	switch kind {
+	case buildlog.KindStep:
+		return pb.DeployLogKind_DEPLOY_LOG_KIND_STEP
+	case buildlog.KindWarning:
+		return pb.DeployLogKind_DEPLOY_LOG_KIND_WARNING
+	case buildlog.KindError:
+		return pb.DeployLogKind_DEPLOY_LOG_KIND_ERROR
+	}
+	return pb.DeployLogKind_DEPLOY_LOG_KIND_UNSPECIFIED
+}
+
+// finishLogBuffer ends the deployment's log stream and keeps the buffer for
+// logBufferRetention so a subscriber that reconnects can still catch up.
+func (s *AgentService) finishLogBuffer(deploymentID string, buffer *deploylog.Buffer) {
+	if buffer == nil {
 		return
 	}
-	close(val.(chan *pb.DeployLogEntry))
+	if err := buffer.Close(); err != nil {
+		s.logger.Warn("Failed to close deploy log buffer", "deploymentId", deploymentID, "error", err)
+	}
+	time.AfterFunc(logBufferRetention, func() {
+		s.logBuffersMu.Lock()
+		if s.logBuffers[deploymentID] == buffer {
+			delete(s.logBuffers, deploymentID)
+		}
+		s.logBuffersMu.Unlock()
+		if err := buffer.Remove(); err != nil {
+			s.logger.Warn("Failed to remove deploy log buffer", "deploymentId", deploymentID, "error", err)
+		}
+	})
 }
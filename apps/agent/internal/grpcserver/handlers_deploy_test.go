@@ -0,0 +1,121 @@
+package grpcserver
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"testing"
+
+	"google.golang.org/grpc"
+
+	"github.com/paasdeploy/agent/internal/deploylog"
+	pb "github.com/paasdeploy/backend/gen/go/flowdeploy/v1"
+)
+
+type fakeLogBatchStream struct {
+	grpc.ServerStream
+	ctx     context.Context
+	batches []*pb.DeployLogBatch
+}
+
+func (s *fakeLogBatchStream) Context() context.Context { return s.ctx }
+
+func (s *fakeLogBatchStream) Send(batch *pb.DeployLogBatch) error {
+	s.batches = append(s.batches, batch)
+	return nil
+}
+
+func newTestLogService(t *testing.T) *AgentService {
+	return &AgentService{
+		logBufferDir: t.TempDir(),
+		logBuffers:   make(map[string]*deploylog.Buffer),
+		logger:       slog.New(slog.NewTextHandler(io.Discard, nil)),
+	}
+}
+
+func writeDeployLogs(t *testing.T, s *AgentService, deploymentID string, n int) {
+	t.Helper()
+	buffer, err := s.logBuffer(deploymentID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < n; i++ {
+		if err := buffer.Append(&pb.DeployLogEntry{Message: fmt.Sprintf("line %d", i)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := buffer.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestStreamDeployLogBatchesSplitsLargeBatches(t *testing.T) {
+	s := newTestLogService(t)
+	writeDeployLogs(t, s, "dep-1", 2*logBatchMaxEntries+20)
+	stream := &fakeLogBatchStream{ctx: context.Background()}
+
+	if err := s.StreamDeployLogBatches(&pb.DeployLogSubscription{DeploymentId: "dep-1"}, stream); err != nil {
+		t.Fatal(err)
+	}
+
+	var sizes []int
+	var sequence uint64
+	for _, batch := range stream.batches {
+		sizes = append(sizes, len(batch.Entries))
+		for _, entry := range batch.Entries {
+			sequence++
+			if entry.Sequence != sequence {
+				t.Fatalf("entry %d has sequence %d", sequence, entry.Sequence)
+			}
+		}
+	}
+	if sequence != 2*logBatchMaxEntries+20 {
+		t.Errorf("streamed %d entries, want %d", sequence, 2*logBatchMaxEntries+20)
+	}
+	if len(sizes) < 3 {
+		t.Errorf("batch sizes %v, want at least 3 batches", sizes)
+	}
+	for _, size := range sizes {
+		if size > logBatchMaxEntries {
+			t.Errorf("batch sizes %v exceed %d", sizes, logBatchMaxEntries)
+			break
+		}
+	}
+}
+
+func TestStreamDeployLogBatchesResumesAfterSequence(t *testing.T) {
+	s := newTestLogService(t)
+	writeDeployLogs(t, s, "dep-1", 10)
+	stream := &fakeLogBatchStream{ctx: context.Background()}
+
+	if err := s.StreamDeployLogBatches(&pb.DeployLogSubscription{DeploymentId: "dep-1", AfterSequence: 7}, stream); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(stream.batches) != 1 || len(stream.batches[0].Entries) != 3 || stream.batches[0].Entries[0].Sequence != 8 {
+		t.Errorf("batches = %v, want entries 8 to 10", stream.batches)
+	}
+}
+
+func TestStreamDeployLogBatchesStopsWhenClientLeaves(t *testing.T) {
+	s := newTestLogService(t)
+	if _, err := s.logBuffer("dep-1"); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := s.StreamDeployLogBatches(&pb.DeployLogSubscription{DeploymentId: "dep-1"}, &fakeLogBatchStream{ctx: ctx}); err != nil {
+		t.Errorf("StreamDeployLogBatches = %v after the client left, want nil", err)
+	}
+}
+
+func TestStreamDeployLogBatchesRejectsInvalidDeploymentID(t *testing.T) {
+	s := newTestLogService(t)
+
+	err := s.StreamDeployLogBatches(&pb.DeployLogSubscription{DeploymentId: "../etc"}, &fakeLogBatchStream{ctx: context.Background()})
+	if err == nil {
+		t.Error("StreamDeployLogBatches accepted a path as deployment ID")
+	}
+}
@@ -0,0 +1,158 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/paasdeploy/backend/gen/go/flowdeploy/v1"
+	"github.com/paasdeploy/shared/pkg/docker"
+)
+
+const containerFileChunkSize = 1 << 20
+
+func (s *AgentService) ListContainerFiles(ctx context.Context, req *pb.ListContainerFilesRequest) (*pb.ListContainerFilesResponse, error) {
+	if req.ContainerId == "" {
+		return nil, status.Error(codes.InvalidArgument, "container id is required")
+	}
+
+	files, err := s.docker.ListContainerFiles(ctx, req.ContainerId, req.Path)
+	if err != nil {
+		return nil, containerFileError(err)
+	}
+
+	return &pb.ListContainerFilesResponse{Entries: containerFileEntries(files)}, nil
+}
+
+func (s *AgentService) DownloadContainerFile(req *pb.DownloadContainerFileRequest, stream grpc.ServerStreamingServer[pb.ContainerFileChunk]) error {
+	if req.ContainerId == "" {
+		return status.Error(codes.InvalidArgument, "container id is required")
+	}
+
+	name, data, err := s.docker.CopyFileFromContainer(stream.Context(), req.ContainerId, req.Path, docker.MaxContainerFileBytes)
+	if err != nil {
+		return containerFileError(err)
+	}
+
+	s.logger.Info("container file downloaded", "container", req.ContainerId, "path", req.Path, "size", len(data))
+	return sendFileChunks(stream, &pb.ContainerFileChunk{
+		ContainerId: req.ContainerId,
+		Path:        req.Path,
+		Name:        name,
+		TotalSize:   int64(len(data)),
+	}, data)
+}
+
+func (s *AgentService) UploadContainerFile(stream grpc.ClientStreamingServer[pb.ContainerFileChunk, pb.UploadContainerFileResponse]) error {
+	var header *pb.ContainerFileChunk
+	var data []byte
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if header == nil {
+			header = chunk
+		}
+		data = append(data, chunk.Data...)
+		if int64(len(data)) > docker.MaxContainerFileBytes {
+			return containerFileError(docker.ErrContainerFileTooLarge)
+		}
+	}
+
+	if header == nil || header.ContainerId == "" {
+		return status.Error(codes.InvalidArgument, "container id is required")
+	}
+	if header.TotalSize != int64(len(data)) {
+		return status.Errorf(codes.DataLoss, "received %d of %d bytes", len(data), header.TotalSize)
+	}
+
+	if err := s.docker.CopyFileToContainer(stream.Context(), header.ContainerId, header.Path, header.Name, data); err != nil {
+		return containerFileError(err)
+	}
+
+	s.logger.Info("container file uploaded", "container", header.ContainerId, "path", header.Path, "name", header.Name, "size", len(data))
+	return stream.SendAndClose(&pb.UploadContainerFileResponse{Size: int64(len(data))})
+}
+
+func (s *AgentService) ListVolumeFiles(ctx context.Context, req *pb.ListVolumeFilesRequest) (*pb.ListVolumeFilesResponse, error) {
+	files, err := s.docker.ListVolumeFiles(ctx, req.VolumeName, req.Path)
+	if err != nil {
+		return nil, containerFileError(err)
+	}
+	return &pb.ListVolumeFilesResponse{Entries: containerFileEntries(files)}, nil
+}
+
+func (s *AgentService) StatVolumeFile(ctx context.Context, req *pb.StatVolumeFileRequest) (*pb.StatVolumeFileResponse, error) {
+	file, err := s.docker.StatVolumeFile(ctx, req.VolumeName, req.Path)
+	if err != nil {
+		return nil, containerFileError(err)
+	}
+	return &pb.StatVolumeFileResponse{Entry: containerFileEntries([]docker.ContainerFile{*file})[0]}, nil
+}
+
+func (s *AgentService) DownloadVolumeFile(req *pb.DownloadVolumeFileRequest, stream grpc.ServerStreamingServer[pb.ContainerFileChunk]) error {
+	name, data, err := s.docker.CopyFileFromVolume(stream.Context(), req.VolumeName, req.Path, docker.MaxContainerFileBytes)
+	if err != nil {
+		return containerFileError(err)
+	}
+
+	s.logger.Info("volume file downloaded", "volume", req.VolumeName, "path", req.Path, "size", len(data))
+	return sendFileChunks(stream, &pb.ContainerFileChunk{
+		Path:      req.Path,
+		Name:      name,
+		TotalSize: int64(len(data)),
+	}, data)
+}
+
+// sendFileChunks streams data in containerFileChunkSize pieces. The header
+// fields of first are only sent once, and an empty file still yields one
+// chunk.
+func sendFileChunks(stream grpc.ServerStreamingServer[pb.ContainerFileChunk], first *pb.ContainerFileChunk, data []byte) error {
+	for offset := 0; offset < len(data) || first != nil; offset += containerFileChunkSize {
+		chunk := first
+		if chunk == nil {
+			chunk = &pb.ContainerFileChunk{}
+		}
+		first = nil
+		chunk.Data = data[offset:min(offset+containerFileChunkSize, len(data))]
+		if err := stream.Send(chunk); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func containerFileEntries(files []docker.ContainerFile) []*pb.ContainerFileEntry {
+	entries := make([]*pb.ContainerFileEntry, 0, len(files))
+	for _, f := range files {
+		entries = append(entries, &pb.ContainerFileEntry{
+			Name:       f.Name,
+			Type:       f.Type,
+			Size:       f.Size,
+			Mode:       f.Mode,
+			Owner:      f.Owner,
+			Modified:   f.Modified,
+			LinkTarget: f.LinkTarget,
+		})
+	}
+	return entries
+}
+
+func containerFileError(err error) error {
+	switch {
+	case errors.Is(err, docker.ErrInvalidContainerPath), errors.Is(err, docker.ErrNotRegularFile), errors.Is(err, docker.ErrInvalidVolumeName):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, docker.ErrContainerFileTooLarge):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	default:
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+}
@@ -0,0 +1,57 @@
+package grpcserver
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/paasdeploy/agent/internal/agentlog"
+	pb "github.com/paasdeploy/backend/gen/go/flowdeploy/v1"
+)
+
+const agentLogsTimeout = 15 * time.Second
+
+func (s *AgentService) GetAgentLogs(ctx context.Context, req *pb.GetAgentLogsRequest) (*pb.GetAgentLogsResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, agentLogsTimeout)
+	defer cancel()
+
+	since := time.Duration(req.GetSinceSeconds()) * time.Second
+	lines, journalErr := agentlog.ReadJournal(ctx, int(req.GetLines()), since)
+	if journalErr == nil && len(lines) > 0 {
+		return &pb.GetAgentLogsResponse{Lines: lines, Source: agentlog.SourceJournal}, nil
+	}
+
+	if s.logFile != nil {
+		lines, err := agentlog.TailFile(s.logFile.Path(), int(req.GetLines()))
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "read log file: %v", err)
+		}
+		return &pb.GetAgentLogsResponse{Lines: lines, Source: agentlog.SourceFile}, nil
+	}
+
+	if journalErr != nil {
+		s.logger.Warn("Failed to read agent journal", "error", journalErr)
+		return nil, status.Errorf(codes.FailedPrecondition, "%v: %v", agentlog.ErrNoLogSource, journalErr)
+	}
+	return &pb.GetAgentLogsResponse{Source: agentlog.SourceJournal}, nil
+}
+
+func (s *AgentService) RotateAgentLogs(_ context.Context, _ *pb.RotateAgentLogsRequest) (*pb.RotateAgentLogsResponse, error) {
+	if s.logFile == nil {
+		return &pb.RotateAgentLogsResponse{
+			Success: false,
+			Message: "agent is not logging to a file; journald handles rotation",
+		}, nil
+	}
+
+	rotated, err := s.logFile.Rotate()
+	if err != nil {
+		s.logger.Error("Failed to rotate agent log file", "error", err)
+		return &pb.RotateAgentLogsResponse{Success: false, Message: err.Error()}, nil
+	}
+
+	s.logger.Info("Agent log file rotated", "rotatedFile", rotated)
+	return &pb.RotateAgentLogsResponse{Success: true, Message: "log file rotated", RotatedFile: rotated}, nil
+}
@@ -0,0 +1,98 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	pb "github.com/paasdeploy/backend/gen/go/flowdeploy/v1"
+)
+
+// maintenanceRouterPriority is above the app routers and the redirect
+// routers, so the maintenance router takes every request for the domains.
+const maintenanceRouterPriority = 10000
+
+var maintenanceAppNameRe = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// SetMaintenance routes an app's domains to the maintenance page served by
+// the platform, through a Traefik file provider config that outranks the
+// app's own routers. Disabling removes the config, which restores routing
+// to the container that kept running meanwhile.
+func (s *AgentService) SetMaintenance(_ context.Context, req *pb.SetMaintenanceRequest) (*pb.SetMaintenanceResponse, error) {
+	appName := req.GetAppName()
+	if !maintenanceAppNameRe.MatchString(appName) {
+		return &pb.SetMaintenanceResponse{Success: false, Message: fmt.Sprintf("invalid app name: %q", appName)}, nil
+	}
+	path := filepath.Join(s.traefikDynamicDir, "maintenance-"+appName+".yml")
+
+	if !req.GetEnabled() {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return &pb.SetMaintenanceResponse{Success: false, Message: err.Error()}, nil
+		}
+		s.logger.Info("Maintenance mode disabled", "app", appName)
+		return &pb.SetMaintenanceResponse{Success: true, Message: "maintenance mode disabled"}, nil
+	}
+
+	config, err := maintenanceDynamicConfig(appName, req.GetDomains(), req.GetPageUrl())
+	if err != nil {
+		return &pb.SetMaintenanceResponse{Success: false, Message: err.Error()}, nil
+	}
+	if err := os.MkdirAll(s.traefikDynamicDir, 0o755); err != nil {
+		return &pb.SetMaintenanceResponse{Success: false, Message: fmt.Sprintf("create dynamic config dir: %v", err)}, nil
+	}
+	if err := writeFileAtomic(path, []byte(config), 0o644); err != nil {
+		s.logger.Error("Failed to write maintenance config", "app", appName, "path", path, "error", err)
+		return &pb.SetMaintenanceResponse{Success: false, Message: err.Error()}, nil
+	}
+
+	s.logger.Info("Maintenance mode enabled", "app", appName, "domains", req.GetDomains())
+	return &pb.SetMaintenanceResponse{Success: true, Message: "maintenance mode enabled"}, nil
+}
+
+func maintenanceDynamicConfig(appName string, domains []string, pageURL string) (string, error) {
+	if len(domains) == 0 {
+		return "", errors.New("app has no domains to put in maintenance")
+	}
+	rules := make([]string, 0, len(domains))
+	for _, domain := range domains {
+		domain = strings.ToLower(strings.TrimSpace(domain))
+		if strings.HasPrefix(domain, "*") || !certificateDomainRe.MatchString(domain) {
+			return "", fmt.Errorf("invalid domain: %q", domain)
+		}
+		rules = append(rules, fmt.Sprintf("Host(`%s`)", domain))
+	}
+
+	page, err := url.Parse(pageURL)
+	if err != nil || (page.Scheme != "http" && page.Scheme != "https") || page.Host == "" {
+		return "", fmt.Errorf("invalid maintenance page URL: %q", pageURL)
+	}
+
+	name := "maintenance-" + appName
+	return fmt.Sprintf(`http:
+  routers:
+    %[1]s:
+      rule: %[2]q
+      priority: %[3]d
+      entryPoints:
+        - websecure
+      tls: {}
+      middlewares:
+        - %[1]s
+      service: %[1]s
+  middlewares:
+    %[1]s:
+      replacePath:
+        path: %[4]q
+  services:
+    %[1]s:
+      loadBalancer:
+        passHostHeader: false
+        servers:
+          - url: %[5]q
+`, name, strings.Join(rules, " || "), maintenanceRouterPriority, page.EscapedPath(), page.Scheme+"://"+page.Host), nil
+}
@@ -0,0 +1,293 @@
+package grpcserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/paasdeploy/backend/gen/go/flowdeploy/v1"
+)
+
+const (
+	migrationCommandTimeout = 30 * time.Second
+	migrationBackupTimeout  = 5 * time.Minute
+	migrationBackupBasePath = "/var/backups/flowdeploy"
+	letsencryptLivePath     = "/etc/letsencrypt/live"
+	letsencryptRenewalPath  = "/etc/letsencrypt/renewal"
+)
+
+var nginxConfigDirs = []string{"/etc/nginx/sites-enabled", "/etc/nginx/conf.d"}
+
+// GetMigrationSnapshot returns the raw nginx, certificate and container state
+// of the host. Parsing and planning happen on the backend so local and remote
+// migrations share the same analysis.
+func (s *AgentService) GetMigrationSnapshot(ctx context.Context, _ *pb.GetMigrationSnapshotRequest) (*pb.GetMigrationSnapshotResponse, error) {
+	resp := &pb.GetMigrationSnapshotResponse{ProxyType: "none"}
+
+	if s.processRunning(ctx, "nginx") {
+		resp.ProxyType, resp.ProxyRunning = "nginx", true
+		resp.ProxyVersion = s.nginxVersion(ctx)
+	} else if s.processRunning(ctx, "apache2") || s.processRunning(ctx, "httpd") {
+		resp.ProxyType, resp.ProxyRunning = "apache", true
+	}
+
+	out, err := s.executor.RunQuietWithTimeout(ctx, migrationCommandTimeout, "docker", "ps", "--filter", "name=traefik", "--format", "{{.Names}}")
+	resp.TraefikRunning = err == nil && strings.TrimSpace(out.Stdout) != ""
+
+	resp.NginxConfigs = readNginxConfigs()
+	resp.Certificates = readLetsEncryptCertificates()
+
+	containers, err := s.migrationContainers(ctx)
+	if err != nil {
+		s.logger.Warn("Failed to list containers for migration", "error", err)
+	}
+	resp.Containers = containers
+
+	return resp, nil
+}
+
+func (s *AgentService) processRunning(ctx context.Context, name string) bool {
+	_, err := s.executor.RunQuietWithTimeout(ctx, migrationCommandTimeout, "pgrep", "-x", name)
+	return err == nil
+}
+
+func (s *AgentService) nginxVersion(ctx context.Context) string {
+	out, err := s.executor.RunQuietWithTimeout(ctx, migrationCommandTimeout, "nginx", "-v")
+	if err != nil {
+		return ""
+	}
+	// nginx prints "nginx version: nginx/1.24.0 (Ubuntu)" to stderr.
+	_, version, ok := strings.Cut(out.Stderr, "/")
+	fields := strings.Fields(version)
+	if !ok || len(fields) == 0 {
+		return ""
+	}
+	return fields[0]
+}
+
+func readNginxConfigs() []*pb.MigrationConfigFile {
+	var files []*pb.MigrationConfigFile
+	for _, dir := range nginxConfigDirs {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			if entry.IsDir() || strings.HasPrefix(entry.Name(), "default") {
+				continue
+			}
+			path := filepath.Join(dir, entry.Name())
+			content, err := os.ReadFile(path)
+			if err != nil {
+				continue
+			}
+			files = append(files, &pb.MigrationConfigFile{Path: path, Content: string(content)})
+		}
+	}
+	return files
+}
+
+func readLetsEncryptCertificates() []*pb.MigrationCertificate {
+	entries, err := os.ReadDir(letsencryptLivePath)
+	if err != nil {
+		return nil
+	}
+
+	var certs []*pb.MigrationCertificate
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		domain := entry.Name()
+		certPEM, err := os.ReadFile(filepath.Join(letsencryptLivePath, domain, "cert.pem"))
+		if err != nil {
+			continue
+		}
+		cert := &pb.MigrationCertificate{Domain: domain, CertPem: certPEM}
+		renewal := filepath.Join(letsencryptRenewalPath, domain+".conf")
+		if _, err := os.Stat(renewal); err == nil {
+			cert.RenewalConfig = renewal
+		}
+		certs = append(certs, cert)
+	}
+	return certs
+}
+
+// migrationContainers lists the host's containers except Traefik and the
+// FlowDeploy components, which are never migrated.
+func (s *AgentService) migrationContainers(ctx context.Context) ([]*pb.MigrationContainer, error) {
+	out, err := s.executor.RunQuietWithTimeout(ctx, migrationCommandTimeout, "docker", "ps", "-a", "--format",
+		"{{.ID}}\t{{.Names}}\t{{.Image}}\t{{.Status}}\t{{.State}}\t{{.Ports}}\t{{.CreatedAt}}")
+	if err != nil {
+		return nil, err
+	}
+
+	var containers []*pb.MigrationContainer
+	for _, line := range strings.Split(strings.TrimSpace(out.Stdout), "\n") {
+		parts := strings.Split(line, "\t")
+		if len(parts) < 7 {
+			continue
+		}
+		if strings.Contains(parts[1], "traefik") || strings.Contains(parts[1], "flowdeploy") {
+			continue
+		}
+		c := &pb.MigrationContainer{
+			Id:      parts[0],
+			Name:    parts[1],
+			Image:   parts[2],
+			Status:  parts[3],
+			State:   parts[4],
+			Created: parts[6],
+		}
+		if parts[5] != "" {
+			c.Ports = strings.Split(parts[5], ", ")
+		}
+		containers = append(containers, c)
+	}
+	return containers, nil
+}
+
+// CreateMigrationBackup copies the nginx and Let's Encrypt configuration and
+// the container list to a timestamped directory before anything is changed.
+func (s *AgentService) CreateMigrationBackup(ctx context.Context, _ *pb.CreateMigrationBackupRequest) (*pb.CreateMigrationBackupResponse, error) {
+	now := time.Now()
+	backupPath := filepath.Join(migrationBackupBasePath, "migration-"+now.Format("2006-01-02-150405"))
+	if err := os.MkdirAll(backupPath, 0o755); err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "create backup directory: %v", err)
+	}
+
+	resp := &pb.CreateMigrationBackupResponse{Path: backupPath, CreatedAt: now.Unix()}
+	for _, src := range []string{"/etc/nginx", "/etc/letsencrypt"} {
+		if _, err := os.Stat(src); err != nil {
+			continue
+		}
+		dst := filepath.Join(backupPath, filepath.Base(src))
+		if _, err := s.executor.RunQuietWithTimeout(ctx, migrationBackupTimeout, "cp", "-r", src, dst); err != nil {
+			s.logger.Warn("Failed to back up directory", "path", src, "error", err)
+			continue
+		}
+		resp.Files = append(resp.Files, filepath.Base(src)+"/")
+	}
+
+	out, err := s.executor.RunQuietWithTimeout(ctx, migrationCommandTimeout, "docker", "ps", "-a", "--format",
+		"table {{.Names}}\t{{.Image}}\t{{.Status}}\t{{.Ports}}")
+	if err == nil {
+		if err := os.WriteFile(filepath.Join(backupPath, "containers.txt"), []byte(out.Stdout), 0o644); err == nil {
+			resp.Files = append(resp.Files, "containers.txt")
+		}
+	}
+
+	filepath.Walk(backupPath, func(_ string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() {
+			resp.Size += info.Size()
+		}
+		return nil
+	})
+
+	s.logger.Info("Migration backup created", "path", backupPath, "files", len(resp.Files))
+	return resp, nil
+}
+
+type migrationInspect struct {
+	Name   string `json:"Name"`
+	Config struct {
+		Image string   `json:"Image"`
+		Env   []string `json:"Env"`
+	} `json:"Config"`
+	HostConfig struct {
+		Binds         []string `json:"Binds"`
+		RestartPolicy struct {
+			Name string `json:"Name"`
+		} `json:"RestartPolicy"`
+	} `json:"HostConfig"`
+	NetworkSettings struct {
+		Networks map[string]json.RawMessage `json:"Networks"`
+	} `json:"NetworkSettings"`
+}
+
+// MigrateContainer recreates a container with the given Traefik labels,
+// keeping its image, env, binds, networks and restart policy. The original
+// is renamed to <name>-backup and left stopped so it can be restored.
+func (s *AgentService) MigrateContainer(ctx context.Context, req *pb.MigrateContainerRequest) (*pb.MigrateContainerResponse, error) {
+	id := req.GetContainerId()
+	if id == "" {
+		return nil, status.Error(codes.InvalidArgument, "container_id is required")
+	}
+
+	out, err := s.executor.RunQuietWithTimeout(ctx, migrationCommandTimeout, "docker", "inspect", id)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "inspect container: %v", err)
+	}
+	var inspected []migrationInspect
+	if err := json.Unmarshal([]byte(out.Stdout), &inspected); err != nil || len(inspected) == 0 {
+		return nil, status.Error(codes.NotFound, "container not found")
+	}
+	info := inspected[0]
+	name := strings.TrimPrefix(info.Name, "/")
+	backupName := name + "-backup"
+
+	if _, err := s.executor.RunQuietWithTimeout(ctx, migrationCommandTimeout, "docker", "stop", id); err != nil {
+		return nil, status.Errorf(codes.Internal, "stop container: %v", err)
+	}
+	if _, err := s.executor.RunQuietWithTimeout(ctx, migrationCommandTimeout, "docker", "rename", name, backupName); err != nil {
+		s.executor.RunQuietWithTimeout(ctx, migrationCommandTimeout, "docker", "start", id)
+		return nil, status.Errorf(codes.Internal, "rename container: %v", err)
+	}
+
+	args := []string{"run", "-d", "--name", name}
+	if info.HostConfig.RestartPolicy.Name != "" {
+		args = append(args, "--restart", info.HostConfig.RestartPolicy.Name)
+	}
+	for network := range info.NetworkSettings.Networks {
+		if network != "bridge" {
+			args = append(args, "--network", network)
+		}
+	}
+	for _, bind := range info.HostConfig.Binds {
+		args = append(args, "-v", bind)
+	}
+	for _, env := range info.Config.Env {
+		if !strings.HasPrefix(env, "PATH=") && !strings.HasPrefix(env, "HOME=") {
+			args = append(args, "-e", env)
+		}
+	}
+	for _, label := range req.GetLabels() {
+		args = append(args, "--label", label)
+	}
+	args = append(args, info.Config.Image)
+
+	out, err = s.executor.RunQuietWithTimeout(ctx, migrationCommandTimeout, "docker", args...)
+	if err != nil {
+		s.logger.Error("Failed to recreate container, rolling back", "name", name, "error", err)
+		s.executor.RunQuietWithTimeout(ctx, migrationCommandTimeout, "docker", "rename", backupName, name)
+		s.executor.RunQuietWithTimeout(ctx, migrationCommandTimeout, "docker", "start", id)
+		return nil, status.Errorf(codes.Internal, "create container: %v", err)
+	}
+
+	newID := strings.TrimSpace(out.Stdout)
+	s.logger.Info("Container migrated to Traefik", "name", name, "newId", newID)
+	return &pb.MigrateContainerResponse{ContainerId: newID, ContainerName: name, BackupName: backupName}, nil
+}
+
+// StopNginx stops and disables nginx so Traefik can bind ports 80 and 443.
+func (s *AgentService) StopNginx(ctx context.Context, _ *pb.StopNginxRequest) (*pb.StopNginxResponse, error) {
+	_, err := s.executor.RunQuietWithTimeout(ctx, migrationCommandTimeout, "systemctl", "is-enabled", "--quiet", "nginx")
+	wasEnabled := err == nil
+
+	if _, err := s.executor.RunQuietWithTimeout(ctx, migrationCommandTimeout, "systemctl", "stop", "nginx"); err != nil {
+		if _, err := s.executor.RunQuietWithTimeout(ctx, migrationCommandTimeout, "service", "nginx", "stop"); err != nil {
+			return nil, status.Error(codes.FailedPrecondition, fmt.Sprintf("stop nginx: %v", err))
+		}
+	}
+	s.executor.RunQuietWithTimeout(ctx, migrationCommandTimeout, "systemctl", "disable", "nginx")
+
+	s.logger.Info("Nginx stopped for migration")
+	return &pb.StopNginxResponse{WasEnabled: wasEnabled}, nil
+}
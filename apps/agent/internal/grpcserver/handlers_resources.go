@@ -60,12 +60,25 @@ func (s *AgentService) ListNetworks(ctx context.Context, _ *pb.ListNetworksReque
 
 	pbNetworks := make([]*pb.NetworkInfo, 0, len(networks))
 	for _, n := range networks {
+		containers := make([]*pb.NetworkContainer, 0, len(n.Containers))
+		for _, c := range n.Containers {
+			containers = append(containers, &pb.NetworkContainer{
+				Id:          c.ID,
+				Name:        c.Name,
+				Ipv4Address: c.IPv4Address,
+				Ipv6Address: c.IPv6Address,
+				MacAddress:  c.MacAddress,
+			})
+		}
 		pbNetworks = append(pbNetworks, &pb.NetworkInfo{
-			Name:     n.Name,
-			Id:       n.ID,
-			Driver:   n.Driver,
-			Scope:    n.Scope,
-			Internal: n.Internal,
+			Name:       n.Name,
+			Id:         n.ID,
+			Driver:     n.Driver,
+			Scope:      n.Scope,
+			Internal:   n.Internal,
+			Subnet:     n.Subnet,
+			Gateway:    n.Gateway,
+			Containers: containers,
 		})
 	}
 
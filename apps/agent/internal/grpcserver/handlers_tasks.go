@@ -0,0 +1,114 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"sync"
+	"syscall"
+	"time"
+
+	pb "github.com/paasdeploy/backend/gen/go/flowdeploy/v1"
+)
+
+const (
+	defaultServerTaskTimeout = 10 * time.Minute
+	maxServerTaskTimeout     = time.Hour
+	// serverTaskOutputLimit is the tail of the combined output kept for a
+	// run, where the reason a script failed usually is.
+	serverTaskOutputLimit = 64 << 10
+	// serverTaskWaitDelay bounds how long a run waits for background
+	// processes that inherited the output pipes after the shell exited.
+	serverTaskWaitDelay = 5 * time.Second
+)
+
+// RunServerTask runs a scheduled task's command with sh on the host and
+// returns its exit code and the tail of its combined output. A timeout kills
+// the whole process group so commands started by the script stop too.
+func (s *AgentService) RunServerTask(ctx context.Context, req *pb.RunServerTaskRequest) (*pb.RunServerTaskResponse, error) {
+	if req.GetCommand() == "" {
+		return &pb.RunServerTaskResponse{ExitCode: -1, Error: "command is required"}, nil
+	}
+
+	timeout := time.Duration(req.GetTimeoutSeconds()) * time.Second
+	if timeout <= 0 {
+		timeout = defaultServerTaskTimeout
+	}
+	timeout = min(timeout, maxServerTaskTimeout)
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	output := &tailBuffer{limit: serverTaskOutputLimit}
+	cmd := exec.CommandContext(ctx, "sh", "-c", req.GetCommand())
+	cmd.Stdout = output
+	cmd.Stderr = output
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+	cmd.WaitDelay = serverTaskWaitDelay
+
+	s.logger.Info("Running server task", "taskId", req.GetTaskId(), "timeout", timeout)
+	start := time.Now()
+	err := cmd.Run()
+	duration := time.Since(start)
+
+	resp := &pb.RunServerTaskResponse{
+		ExitCode:   -1,
+		DurationMs: duration.Milliseconds(),
+	}
+	if cmd.ProcessState != nil {
+		resp.ExitCode = int32(cmd.ProcessState.ExitCode())
+	}
+	resp.Output, resp.Truncated = output.Bytes()
+
+	var exitErr *exec.ExitError
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		resp.TimedOut = true
+		resp.Error = "task timed out after " + timeout.String()
+	case err != nil && !errors.As(err, &exitErr):
+		resp.Error = err.Error()
+	}
+
+	s.logger.Info("Server task finished",
+		"taskId", req.GetTaskId(),
+		"exitCode", resp.ExitCode,
+		"timedOut", resp.TimedOut,
+		"duration", duration,
+	)
+	return resp, nil
+}
+
+// tailBuffer keeps the last limit bytes written to it.
+type tailBuffer struct {
+	mu        sync.Mutex
+	limit     int
+	buf       []byte
+	truncated bool
+}
+
+func (b *tailBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	n := len(p)
+	if len(p) >= b.limit {
+		b.buf = append(b.buf[:0], p[len(p)-b.limit:]...)
+		b.truncated = true
+		return n, nil
+	}
+	if over := len(b.buf) + len(p) - b.limit; over > 0 {
+		b.buf = append(b.buf[:0], b.buf[over:]...)
+		b.truncated = true
+	}
+	b.buf = append(b.buf, p...)
+	return n, nil
+}
+
+func (b *tailBuffer) Bytes() ([]byte, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]byte(nil), b.buf...), b.truncated
+}
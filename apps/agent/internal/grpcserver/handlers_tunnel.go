@@ -0,0 +1,72 @@
+package grpcserver
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	pb "github.com/paasdeploy/backend/gen/go/flowdeploy/v1"
+)
+
+const (
+	cloudflaredContainerName = "cloudflared"
+	cloudflaredImage         = "cloudflare/cloudflared:2024.12.2"
+	// cloudflaredNetwork is the Docker network Traefik runs on, so the tunnel
+	// can reach it by container name.
+	cloudflaredNetwork    = "paasdeploy"
+	tunnelCommandTimeout  = 3 * time.Minute
+	tunnelTeardownTimeout = 30 * time.Second
+)
+
+// ConfigureTunnel (re)starts cloudflared with the given connector token. The
+// token is handed over through an env file so it never shows up in process
+// arguments or command logs.
+func (s *AgentService) ConfigureTunnel(ctx context.Context, req *pb.ConfigureTunnelRequest) (*pb.ConfigureTunnelResponse, error) {
+	token := strings.TrimSpace(req.GetToken())
+	if token == "" {
+		return &pb.ConfigureTunnelResponse{Success: false, Message: "tunnel token is required"}, nil
+	}
+
+	envFile, err := os.CreateTemp("", "cloudflared-*.env")
+	if err != nil {
+		return &pb.ConfigureTunnelResponse{Success: false, Message: err.Error()}, nil
+	}
+	defer os.Remove(envFile.Name())
+	if _, err := fmt.Fprintf(envFile, "TUNNEL_TOKEN=%s\n", token); err != nil {
+		envFile.Close()
+		return &pb.ConfigureTunnelResponse{Success: false, Message: err.Error()}, nil
+	}
+	if err := envFile.Close(); err != nil {
+		return &pb.ConfigureTunnelResponse{Success: false, Message: err.Error()}, nil
+	}
+
+	_, _ = s.executor.RunQuietWithTimeout(ctx, tunnelTeardownTimeout, "docker", "rm", "-f", cloudflaredContainerName)
+
+	_, err = s.executor.RunWithTimeout(ctx, tunnelCommandTimeout, "docker", "run", "-d",
+		"--name", cloudflaredContainerName,
+		"--network", cloudflaredNetwork,
+		"--restart", "unless-stopped",
+		"--env-file", envFile.Name(),
+		cloudflaredImage,
+		"tunnel", "--no-autoupdate", "run",
+	)
+	if err != nil {
+		return &pb.ConfigureTunnelResponse{Success: false, Message: fmt.Sprintf("failed to start cloudflared: %v", err)}, nil
+	}
+
+	s.logger.Info("Cloudflare tunnel started")
+	return &pb.ConfigureTunnelResponse{Success: true, Message: "cloudflared started"}, nil
+}
+
+func (s *AgentService) RemoveTunnel(ctx context.Context, _ *pb.RemoveTunnelRequest) (*pb.RemoveTunnelResponse, error) {
+	if _, err := s.executor.RunQuietWithTimeout(ctx, tunnelTeardownTimeout, "docker", "rm", "-f", cloudflaredContainerName); err != nil {
+		if !strings.Contains(err.Error(), "No such container") {
+			return &pb.RemoveTunnelResponse{Success: false, Message: fmt.Sprintf("failed to remove cloudflared: %v", err)}, nil
+		}
+	}
+
+	s.logger.Info("Cloudflare tunnel removed")
+	return &pb.RemoveTunnelResponse{Success: true, Message: "cloudflared removed"}, nil
+}
@@ -10,6 +10,7 @@ import (
 	"golang.org/x/sys/unix"
 	"google.golang.org/grpc"
 
+	"github.com/paasdeploy/agent/internal/selfupdate"
 	pb "github.com/paasdeploy/backend/gen/go/flowdeploy/v1"
 )
 
@@ -61,16 +62,6 @@ func (s *AgentService) receiveBinaryChunks(stream grpc.ClientStreamingServer[pb.
 	return result, nil
 }
 
-func replaceBinaryFile(execPath, tmpPath string) error {
-	if err := os.Chmod(tmpPath, 0o755); err != nil {
-		return fmt.Errorf("chmod: %w", err)
-	}
-	if err := os.Rename(tmpPath, execPath); err != nil {
-		return fmt.Errorf("rename new binary: %w", err)
-	}
-	return nil
-}
-
 func (s *AgentService) PushUpdate(stream grpc.ClientStreamingServer[pb.UpdateBinaryChunk, pb.UpdateBinaryResponse]) error {
 	execPath, err := os.Executable()
 	if err != nil {
@@ -95,7 +86,7 @@ func (s *AgentService) PushUpdate(stream grpc.ClientStreamingServer[pb.UpdateBin
 		return fmt.Errorf("size mismatch: expected %d, got %d", result.expectedSize, result.totalSize)
 	}
 
-	if err := replaceBinaryFile(execPath, tmpPath); err != nil {
+	if err := selfupdate.Install(execPath, tmpPath, result.version); err != nil {
 		os.Remove(tmpPath)
 		return err
 	}
@@ -1,12 +1,14 @@
 package grpcserver
 
 import (
+	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"fmt"
 	"log/slog"
 	"net"
 	"os"
+	"path/filepath"
 	"sync"
 	"time"
 
@@ -17,7 +19,9 @@ import (
 	grpc_health_v1 "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/keepalive"
 
+	"github.com/paasdeploy/agent/internal/agentlog"
 	"github.com/paasdeploy/agent/internal/deploy"
+	"github.com/paasdeploy/agent/internal/deploylog"
 	pb "github.com/paasdeploy/backend/gen/go/flowdeploy/v1"
 	"github.com/paasdeploy/shared/pkg/docker"
 	"github.com/paasdeploy/shared/pkg/executor"
@@ -26,13 +30,15 @@ import (
 )
 
 const (
-	logStreamBuffer     = 512
-	defaultTraefikURL   = "http://127.0.0.1:8081"
-	maxConnectionIdle   = 5 * time.Minute
-	keepaliveTime       = 10 * time.Second
-	keepaliveTimeout    = 5 * time.Second
-	keepaliveMinTime    = 5 * time.Second
-	executorTimeout     = 2 * time.Minute
+	logStreamBuffer          = 512
+	defaultTraefikURL        = "http://127.0.0.1:8081"
+	defaultTraefikDynamicDir = "/opt/traefik/dynamic"
+	maxConnectionIdle        = 5 * time.Minute
+	keepaliveTime            = 10 * time.Second
+	keepaliveTimeout         = 5 * time.Second
+	keepaliveMinTime         = 5 * time.Second
+	executorTimeout          = 2 * time.Minute
+	selfCheckTimeout         = 5 * time.Second
 )
 
 type Config struct {
@@ -40,24 +46,33 @@ type Config struct {
 	Port     int
 	CertPath string
 	KeyPath  string
+	LogFile  *agentlog.RotatingFile
+	// MaxBuilds caps the image builds running at once; zero sizes the
+	// limit from the server's memory.
+	MaxBuilds int
 }
 
 type Server struct {
 	cfg        Config
 	grpcServer *grpc.Server
+	service    *AgentService
 	docker     *docker.Client
 	logger     *slog.Logger
 }
 
 type AgentService struct {
 	pb.UnimplementedAgentServiceServer
-	deployExecutor *deploy.Executor
-	docker         *docker.Client
-	executor       *executor.Executor
-	traefikClient  *traefik.Client
-	logStreams     sync.Map
-	deployLocks    sync.Map
-	logger         *slog.Logger
+	deployExecutor    *deploy.Executor
+	docker            *docker.Client
+	executor          *executor.Executor
+	traefikClient     *traefik.Client
+	traefikDynamicDir string
+	logFile           *agentlog.RotatingFile
+	logBufferDir      string
+	logBuffers        map[string]*deploylog.Buffer
+	logBuffersMu      sync.Mutex
+	deployLocks       sync.Map
+	logger            *slog.Logger
 }
 
 func buildTLSConfig(cfg Config, cert tls.Certificate, logger *slog.Logger) (*tls.Config, error) {
@@ -113,6 +128,8 @@ func New(cfg Config, logger *slog.Logger) (*Server, error) {
 			MinTime:             keepaliveMinTime,
 			PermitWithoutStream: true,
 		}),
+		grpc.UnaryInterceptor(traceUnaryInterceptor(logger)),
+		grpc.StreamInterceptor(traceStreamInterceptor(logger)),
 	)
 	healthServer := health.NewServer()
 	healthServer.SetServingStatus("", grpc_health_v1.HealthCheckResponse_SERVING)
@@ -126,18 +143,34 @@ func New(cfg Config, logger *slog.Logger) (*Server, error) {
 		traefikURL = defaultTraefikURL
 	}
 
+	traefikDynamicDir := os.Getenv("TRAEFIK_DYNAMIC_DIR")
+	if traefikDynamicDir == "" {
+		traefikDynamicDir = defaultTraefikDynamicDir
+	}
+
+	// Deploy log buffers only matter to the deploys of this process.
+	logBufferDir := filepath.Join(paths.ResolveDataDir(), ".deploy-logs")
+	if err := os.RemoveAll(logBufferDir); err != nil {
+		logger.Warn("Failed to clear stale deploy log buffers", "dir", logBufferDir, "error", err)
+	}
+
 	agentService := &AgentService{
-		deployExecutor: deploy.NewExecutor(logger),
-		docker:         dockerClient,
-		executor:       executor.New("", executorTimeout, logger),
-		traefikClient:  traefik.NewClient(traefikURL),
-		logger:         logger.With("component", "agent-service"),
+		deployExecutor:    deploy.NewExecutor(cfg.MaxBuilds, logger),
+		docker:            dockerClient,
+		executor:          executor.New("", executorTimeout, logger),
+		traefikClient:     traefik.NewClient(traefikURL),
+		traefikDynamicDir: traefikDynamicDir,
+		logFile:           cfg.LogFile,
+		logBufferDir:      logBufferDir,
+		logBuffers:        make(map[string]*deploylog.Buffer),
+		logger:            logger.With("component", "agent-service"),
 	}
 	pb.RegisterAgentServiceServer(grpcServer, agentService)
 
 	return &Server{
 		cfg:        cfg,
 		grpcServer: grpcServer,
+		service:    agentService,
 		docker:     dockerClient,
 		logger:     logger.With("component", "agent-grpc"),
 	}, nil
@@ -157,6 +190,57 @@ func (s *Server) Stop() {
 	s.grpcServer.GracefulStop()
 }
 
+// CheckServing dials the local gRPC endpoint with the agent's own certificate
+// and queries the health service.
+func (s *Server) CheckServing(ctx context.Context) error {
+	cert, err := tls.LoadX509KeyPair(s.cfg.CertPath, s.cfg.KeyPath)
+	if err != nil {
+		return err
+	}
+
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ServerName:   "localhost",
+		MinVersion:   tls.VersionTLS13,
+	}
+	if s.cfg.CAPath != "" {
+		caPEM, err := os.ReadFile(s.cfg.CAPath)
+		if err != nil {
+			return fmt.Errorf("failed to read CA cert: %w", err)
+		}
+		caPool := x509.NewCertPool()
+		if !caPool.AppendCertsFromPEM(caPEM) {
+			return fmt.Errorf("failed to parse CA cert")
+		}
+		tlsConfig.RootCAs = caPool
+	} else {
+		tlsConfig.InsecureSkipVerify = true
+	}
+
+	addr := net.JoinHostPort("127.0.0.1", fmt.Sprintf("%d", s.cfg.Port))
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	checkCtx, cancel := context.WithTimeout(ctx, selfCheckTimeout)
+	defer cancel()
+
+	resp, err := grpc_health_v1.NewHealthClient(conn).Check(checkCtx, &grpc_health_v1.HealthCheckRequest{})
+	if err != nil {
+		return err
+	}
+	if resp.GetStatus() != grpc_health_v1.HealthCheckResponse_SERVING {
+		return fmt.Errorf("health status %s", resp.GetStatus())
+	}
+	return nil
+}
+
 func (s *Server) Docker() *docker.Client {
 	return s.docker
 }
+
+func (s *Server) DeployExecutor() *deploy.Executor {
+	return s.service.deployExecutor
+}
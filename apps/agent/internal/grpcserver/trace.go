@@ -0,0 +1,59 @@
+package grpcserver
+
+import (
+	"context"
+	"log/slog"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/paasdeploy/shared/pkg/tracing"
+)
+
+// incomingTraceID returns the trace ID the backend sent with the call.
+func incomingTraceID(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	values := md.Get(tracing.MetadataKey)
+	if len(values) == 0 || !tracing.ValidID(values[0]) {
+		return ""
+	}
+	return values[0]
+}
+
+// traceUnaryInterceptor puts the backend's trace ID in the handler context
+// and logs failed calls with it.
+func traceUnaryInterceptor(logger *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx = tracing.WithID(ctx, incomingTraceID(ctx))
+		resp, err := handler(ctx, req)
+		if err != nil {
+			logger.WarnContext(ctx, "grpc call failed", "method", info.FullMethod, "error", err)
+		} else {
+			logger.DebugContext(ctx, "grpc call", "method", info.FullMethod)
+		}
+		return resp, err
+	}
+}
+
+type tracedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *tracedStream) Context() context.Context {
+	return s.ctx
+}
+
+func traceStreamInterceptor(logger *slog.Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx := tracing.WithID(ss.Context(), incomingTraceID(ss.Context()))
+		err := handler(srv, &tracedStream{ServerStream: ss, ctx: ctx})
+		if err != nil {
+			logger.WarnContext(ctx, "grpc stream failed", "method", info.FullMethod, "error", err)
+		}
+		return err
+	}
+}
@@ -0,0 +1,118 @@
+// Package prepull keeps the base images of recently deployed apps on the
+// server, so builds after an image prune don't start with cold pulls.
+package prepull
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+const (
+	// recentWindow is how far back deploys count towards the images kept.
+	recentWindow = 14 * 24 * time.Hour
+	maxImages    = 10
+)
+
+// Source lists the base images worth keeping and reports when a deploy is
+// running, which the warmer stays out of the way of.
+type Source interface {
+	RecentBaseImages(window time.Duration, limit int) []string
+	Busy() bool
+}
+
+type Puller interface {
+	ImageExists(ctx context.Context, tag string) (bool, error)
+	Pull(ctx context.Context, image string) error
+}
+
+type Warmer struct {
+	puller   Puller
+	source   Source
+	logger   *slog.Logger
+	interval time.Duration
+	stopCh   chan struct{}
+	wg       sync.WaitGroup
+}
+
+func NewWarmer(puller Puller, source Source, interval time.Duration, logger *slog.Logger) *Warmer {
+	return &Warmer{
+		puller:   puller,
+		source:   source,
+		logger:   logger.With("component", "image_warmer"),
+		interval: interval,
+		stopCh:   make(chan struct{}),
+	}
+}
+
+func (w *Warmer) Start(ctx context.Context) {
+	w.logger.Info("Starting base image warmer", "interval", w.interval)
+
+	w.wg.Add(1)
+	go w.run(ctx)
+}
+
+func (w *Warmer) Stop() {
+	w.logger.Info("Stopping base image warmer")
+	close(w.stopCh)
+	w.wg.Wait()
+	w.logger.Info("Base image warmer stopped")
+}
+
+func (w *Warmer) run(ctx context.Context) {
+	defer w.wg.Done()
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-w.stopCh:
+			return
+		case <-ticker.C:
+			w.Warm(ctx)
+		}
+	}
+}
+
+// Warm pulls the missing base images, one at a time and only while no
+// deploy is running. It returns how many images were pulled.
+func (w *Warmer) Warm(ctx context.Context) int {
+	if w.source.Busy() {
+		w.logger.Debug("Deploy in progress, skipping base image warm-up")
+		return 0
+	}
+
+	pulled := 0
+	for _, image := range w.source.RecentBaseImages(recentWindow, maxImages) {
+		if ctx.Err() != nil {
+			return pulled
+		}
+		if w.source.Busy() {
+			w.logger.Info("Deploy started, pausing base image warm-up", "pulled", pulled)
+			return pulled
+		}
+
+		exists, err := w.puller.ImageExists(ctx, image)
+		if err != nil {
+			w.logger.Warn("Failed to inspect base image", "image", image, "error", err)
+			continue
+		}
+		if exists {
+			continue
+		}
+		if err := w.puller.Pull(ctx, image); err != nil {
+			w.logger.Warn("Failed to pre-pull base image", "image", image, "error", err)
+			continue
+		}
+		pulled++
+	}
+
+	if pulled > 0 {
+		w.logger.Info("Pre-pulled base images", "count", pulled)
+	}
+	return pulled
+}
@@ -0,0 +1,68 @@
+package prepull
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+type fakeSource struct {
+	images []string
+	busy   func() bool
+}
+
+func (s *fakeSource) RecentBaseImages(time.Duration, int) []string { return s.images }
+func (s *fakeSource) Busy() bool                                   { return s.busy() }
+
+type fakePuller struct {
+	local  map[string]bool
+	pulled []string
+	onPull func()
+}
+
+func (p *fakePuller) ImageExists(_ context.Context, tag string) (bool, error) {
+	return p.local[tag], nil
+}
+
+func (p *fakePuller) Pull(_ context.Context, image string) error {
+	p.pulled = append(p.pulled, image)
+	p.local[image] = true
+	if p.onPull != nil {
+		p.onPull()
+	}
+	return nil
+}
+
+func newTestWarmer(puller *fakePuller, source *fakeSource) *Warmer {
+	return NewWarmer(puller, source, time.Hour, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func TestWarmPullsOnlyMissingImages(t *testing.T) {
+	puller := &fakePuller{local: map[string]bool{"node:20-alpine": true}}
+	source := &fakeSource{
+		images: []string{"node:20-alpine", "golang:1.24-alpine", "nginx:alpine"},
+		busy:   func() bool { return false },
+	}
+
+	if n := newTestWarmer(puller, source).Warm(context.Background()); n != 2 {
+		t.Fatalf("pulled %d images, want 2", n)
+	}
+	if len(puller.pulled) != 2 || puller.pulled[0] != "golang:1.24-alpine" {
+		t.Errorf("pulled %v", puller.pulled)
+	}
+}
+
+func TestWarmStopsWhenDeployStarts(t *testing.T) {
+	busy := false
+	puller := &fakePuller{local: map[string]bool{}, onPull: func() { busy = true }}
+	source := &fakeSource{
+		images: []string{"golang:1.24-alpine", "nginx:alpine"},
+		busy:   func() bool { return busy },
+	}
+
+	if n := newTestWarmer(puller, source).Warm(context.Background()); n != 1 {
+		t.Errorf("pulled %d images, want 1", n)
+	}
+}
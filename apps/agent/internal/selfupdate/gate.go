@@ -0,0 +1,61 @@
+package selfupdate
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+const gateCheckInterval = 2 * time.Second
+
+type Check struct {
+	Name string
+	Run  func(ctx context.Context) error
+}
+
+// RunHealthGate waits for every check to pass within the grace window and
+// confirms the update. Otherwise it restores agent.old and returns
+// ErrRolledBack; the caller is expected to re-exec the restored binary.
+func RunHealthGate(ctx context.Context, execPath string, grace time.Duration, logger *slog.Logger, checks ...Check) error {
+	gateCtx, cancel := context.WithTimeout(ctx, grace)
+	defer cancel()
+
+	for _, check := range checks {
+		if err := waitForCheck(gateCtx, check); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			reason := fmt.Sprintf("update health check %q failed: %v", check.Name, err)
+			logger.Error("update health gate failed, rolling back", "check", check.Name, "error", err)
+			if restoreErr := Restore(execPath, reason); restoreErr != nil {
+				return fmt.Errorf("rollback: %w", restoreErr)
+			}
+			return ErrRolledBack
+		}
+		logger.Info("update health check passed", "check", check.Name)
+	}
+
+	if err := Confirm(execPath); err != nil {
+		return fmt.Errorf("confirm update: %w", err)
+	}
+	logger.Info("update confirmed healthy")
+	return nil
+}
+
+func waitForCheck(ctx context.Context, check Check) error {
+	ticker := time.NewTicker(gateCheckInterval)
+	defer ticker.Stop()
+
+	var lastErr error
+	for {
+		if lastErr = check.Run(ctx); lastErr == nil {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return lastErr
+		case <-ticker.C:
+		}
+	}
+}
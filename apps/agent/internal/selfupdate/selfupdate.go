@@ -0,0 +1,134 @@
+package selfupdate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	BackupBinaryName  = "agent.old"
+	pendingMarkerName = "agent.update-pending"
+	failureNoteName   = "agent.update-failed"
+	maxBootAttempts   = 3
+)
+
+var ErrRolledBack = errors.New("update rolled back to previous binary")
+
+type pendingUpdate struct {
+	Version  string    `json:"version"`
+	StagedAt time.Time `json:"stagedAt"`
+	Attempts int       `json:"attempts"`
+}
+
+func sidecarPath(execPath, name string) string {
+	return filepath.Join(filepath.Dir(execPath), name)
+}
+
+// Install swaps newPath in as the running binary, keeping the current one as
+// agent.old and leaving a marker so the next start runs the health gate.
+func Install(execPath, newPath, version string) error {
+	if err := os.Chmod(newPath, 0o755); err != nil {
+		return fmt.Errorf("chmod: %w", err)
+	}
+
+	backupPath := sidecarPath(execPath, BackupBinaryName)
+	_ = os.Remove(backupPath)
+	if err := os.Rename(execPath, backupPath); err != nil {
+		return fmt.Errorf("backup current binary: %w", err)
+	}
+
+	if err := os.Rename(newPath, execPath); err != nil {
+		if restoreErr := os.Rename(backupPath, execPath); restoreErr != nil {
+			return fmt.Errorf("rename new binary: %w (restore failed: %v)", err, restoreErr)
+		}
+		return fmt.Errorf("rename new binary: %w", err)
+	}
+
+	return writePending(execPath, pendingUpdate{Version: version, StagedAt: time.Now()})
+}
+
+// BeginBoot is called on startup. It reports whether the running binary is an
+// update still waiting for the health gate. A binary that keeps crashing
+// before confirming is rolled back after maxBootAttempts starts, in which case
+// ErrRolledBack is returned and the caller must re-exec.
+func BeginBoot(execPath string) (bool, error) {
+	pending, err := readPending(execPath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	pending.Attempts++
+	if pending.Attempts > maxBootAttempts {
+		reason := fmt.Sprintf("update to %s did not become healthy after %d starts", pending.Version, maxBootAttempts)
+		if err := Restore(execPath, reason); err != nil {
+			return true, err
+		}
+		return true, ErrRolledBack
+	}
+
+	if err := writePending(execPath, *pending); err != nil {
+		return true, err
+	}
+	return true, nil
+}
+
+func Confirm(execPath string) error {
+	if err := os.Remove(sidecarPath(execPath, pendingMarkerName)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// Restore puts agent.old back in place and records why, so the restored
+// binary can report the failure to the backend.
+func Restore(execPath, reason string) error {
+	backupPath := sidecarPath(execPath, BackupBinaryName)
+	if _, err := os.Stat(backupPath); err != nil {
+		return fmt.Errorf("no previous binary to restore: %w", err)
+	}
+
+	if err := os.Rename(backupPath, execPath); err != nil {
+		return fmt.Errorf("restore previous binary: %w", err)
+	}
+
+	_ = os.WriteFile(sidecarPath(execPath, failureNoteName), []byte(reason), 0o600)
+	return Confirm(execPath)
+}
+
+// ConsumeFailure returns the reason of the last rollback, if any, and clears it.
+func ConsumeFailure(execPath string) string {
+	path := sidecarPath(execPath, failureNoteName)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	_ = os.Remove(path)
+	return string(data)
+}
+
+func readPending(execPath string) (*pendingUpdate, error) {
+	data, err := os.ReadFile(sidecarPath(execPath, pendingMarkerName))
+	if err != nil {
+		return nil, err
+	}
+	var pending pendingUpdate
+	if err := json.Unmarshal(data, &pending); err != nil {
+		return nil, fmt.Errorf("parse update marker: %w", err)
+	}
+	return &pending, nil
+}
+
+func writePending(execPath string, pending pendingUpdate) error {
+	data, err := json.Marshal(pending)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(sidecarPath(execPath, pendingMarkerName), data, 0o600)
+}
@@ -0,0 +1,113 @@
+package selfupdate
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeBinary(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o755); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+}
+
+func readBinary(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read %s: %v", path, err)
+	}
+	return string(data)
+}
+
+func stageUpdate(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	execPath := filepath.Join(dir, "agent")
+	newPath := filepath.Join(dir, "agent.new")
+	writeBinary(t, execPath, "old")
+	writeBinary(t, newPath, "new")
+
+	if err := Install(execPath, newPath, "v2"); err != nil {
+		t.Fatalf("install: %v", err)
+	}
+	return execPath
+}
+
+func TestInstallKeepsBackup(t *testing.T) {
+	execPath := stageUpdate(t)
+
+	if got := readBinary(t, execPath); got != "new" {
+		t.Fatalf("expected new binary, got %q", got)
+	}
+	if got := readBinary(t, filepath.Join(filepath.Dir(execPath), BackupBinaryName)); got != "old" {
+		t.Fatalf("expected backup of old binary, got %q", got)
+	}
+
+	pending, err := BeginBoot(execPath)
+	if err != nil || !pending {
+		t.Fatalf("expected pending update, got pending=%v err=%v", pending, err)
+	}
+}
+
+func TestBeginBootRollsBackAfterMaxAttempts(t *testing.T) {
+	execPath := stageUpdate(t)
+
+	for i := 0; i < maxBootAttempts; i++ {
+		if _, err := BeginBoot(execPath); err != nil {
+			t.Fatalf("boot %d: %v", i+1, err)
+		}
+	}
+
+	if _, err := BeginBoot(execPath); !errors.Is(err, ErrRolledBack) {
+		t.Fatalf("expected ErrRolledBack, got %v", err)
+	}
+	if got := readBinary(t, execPath); got != "old" {
+		t.Fatalf("expected old binary restored, got %q", got)
+	}
+	if reason := ConsumeFailure(execPath); reason == "" {
+		t.Fatalf("expected rollback reason")
+	}
+	if pending, err := BeginBoot(execPath); err != nil || pending {
+		t.Fatalf("expected no pending update after rollback, got pending=%v err=%v", pending, err)
+	}
+}
+
+func TestRunHealthGate(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	ok := Check{Name: "ok", Run: func(context.Context) error { return nil }}
+	failing := Check{Name: "failing", Run: func(context.Context) error { return errors.New("down") }}
+
+	t.Run("confirms healthy update", func(t *testing.T) {
+		execPath := stageUpdate(t)
+
+		if err := RunHealthGate(context.Background(), execPath, time.Second, logger, ok); err != nil {
+			t.Fatalf("gate: %v", err)
+		}
+		if pending, _ := BeginBoot(execPath); pending {
+			t.Fatalf("expected update to be confirmed")
+		}
+		if got := readBinary(t, execPath); got != "new" {
+			t.Fatalf("expected new binary kept, got %q", got)
+		}
+	})
+
+	t.Run("rolls back unhealthy update", func(t *testing.T) {
+		execPath := stageUpdate(t)
+
+		err := RunHealthGate(context.Background(), execPath, 100*time.Millisecond, logger, ok, failing)
+		if !errors.Is(err, ErrRolledBack) {
+			t.Fatalf("expected ErrRolledBack, got %v", err)
+		}
+		if got := readBinary(t, execPath); got != "old" {
+			t.Fatalf("expected old binary restored, got %q", got)
+		}
+	})
+}
@@ -66,6 +66,11 @@ func GetSystemMetrics() (*pb.SystemMetrics, error) {
 	}, nil
 }
 
+// MemoryTotal returns the RAM of the server in bytes, or 0 if unknown.
+func MemoryTotal() int64 {
+	return readMemTotal()
+}
+
 func readMemTotal() int64 {
 	return readMemInfoKey("MemTotal")
 }
@@ -2,25 +2,54 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/signal"
 	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
 	"syscall"
+	"text/tabwriter"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/joho/godotenv"
 
+	"github.com/paasdeploy/backend/internal/agentdownload"
+	"github.com/paasdeploy/backend/internal/backup"
+	"github.com/paasdeploy/backend/internal/config"
 	"github.com/paasdeploy/backend/internal/database"
 	"github.com/paasdeploy/backend/internal/di"
+	"github.com/paasdeploy/backend/internal/diagnostics"
 	"github.com/paasdeploy/backend/internal/engine"
+	"github.com/paasdeploy/backend/internal/gitops"
 	"github.com/paasdeploy/backend/internal/handler"
 	"github.com/paasdeploy/backend/internal/server"
 )
 
+// processEnv holds the variables set by the process environment, which
+// take precedence over the .env file, also when the config is reloaded.
+var processEnv = map[string]bool{}
+
 func main() {
+	for _, kv := range os.Environ() {
+		key, _, _ := strings.Cut(kv, "=")
+		processEnv[key] = true
+	}
 	_ = godotenv.Load()
 
+	if len(os.Args) > 1 {
+		if command, ok := commands[os.Args[1]]; ok {
+			if err := command(os.Args[2:]); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(1)
+			}
+			return
+		}
+	}
+
 	if err := runMigrationsFirst(); err != nil {
 		panic(err)
 	}
@@ -32,6 +61,7 @@ func main() {
 	defer cleanup()
 
 	app.Logger.Info("Starting FlowDeploy API", "version", di.Version)
+	logDiagnostics(app)
 	go handleEngineEvents(app)
 	startEngine(app)
 	startGrpcServer(app)
@@ -40,6 +70,7 @@ func main() {
 
 	ctx, cancel := context.WithCancel(context.Background())
 	monitors := startMonitors(ctx, app)
+	go watchReloadSignal(app)
 	startServer(app)
 	waitForShutdown(app, cancel, monitors)
 }
@@ -57,12 +88,171 @@ func runMigrationsFirst() error {
 	defer cleanup()
 
 	migrationsPath := getMigrationsPath()
+	if !cfg.Database.AutoMigrate {
+		return database.CheckSchema(db, migrationsPath)
+	}
 	if err := database.RunMigrations(db, migrationsPath, logger); err != nil {
 		return fmt.Errorf("failed to run migrations: %w", err)
 	}
 	return nil
 }
 
+var commands = map[string]func(args []string) error{
+	"migrate": runMigrateCommand,
+	"backup":  runBackupCommand,
+	"restore": runRestoreCommand,
+}
+
+const migrateUsage = "usage: api migrate up | down [steps] | status | version"
+
+// runMigrateCommand handles `api migrate`, which manages the schema without
+// starting the server.
+func runMigrateCommand(args []string) error {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+
+	cfg, err := di.ProvideConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	logger := di.ProvideLogger(cfg)
+	db, cleanup, err := di.ProvideDatabase(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer cleanup()
+
+	migrationsPath := getMigrationsPath()
+	switch args[0] {
+	case "up":
+		return database.RunMigrations(db, migrationsPath, logger)
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			if steps, err = strconv.Atoi(args[1]); err != nil {
+				return fmt.Errorf("invalid steps %q", args[1])
+			}
+		}
+		return database.MigrateDown(db, migrationsPath, steps, logger)
+	case "status":
+		status, err := database.MigrationsStatus(db, migrationsPath)
+		if err != nil {
+			return err
+		}
+		printMigrationStatus(status)
+		return nil
+	case "version":
+		status, err := database.MigrationsStatus(db, migrationsPath)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("version %d (latest %d, dirty %t)\n", status.Version, status.Latest, status.Dirty)
+		return nil
+	default:
+		return errors.New(migrateUsage)
+	}
+}
+
+const (
+	backupUsage          = "usage: BACKUP_PASSPHRASE=... api backup <file>"
+	restoreUsage         = "usage: BACKUP_PASSPHRASE=... api restore <file>"
+	backupPassphraseEnv  = "BACKUP_PASSPHRASE"
+	backupFilePermission = 0o600
+)
+
+// runBackupCommand handles `api backup`, which writes the same encrypted
+// archive as POST /api/system/backup.
+func runBackupCommand(args []string) error {
+	passphrase := os.Getenv(backupPassphraseEnv)
+	if len(args) != 1 || passphrase == "" {
+		return errors.New(backupUsage)
+	}
+
+	cfg, err := di.ProvideConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	db, cleanup, err := di.ProvideDatabase(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer cleanup()
+
+	data, summary, err := backup.NewManager(db).Create(context.Background(), passphrase)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(args[0], data, backupFilePermission); err != nil {
+		return fmt.Errorf("failed to write backup: %w", err)
+	}
+	fmt.Printf("backup of %d tables (%d rows) at schema version %d written to %s\n", summary.Tables, summary.Rows, summary.SchemaVersion, args[0])
+	return nil
+}
+
+// runRestoreCommand handles `api restore`, which replaces the database
+// content with a backup. Run it with the API stopped. A fresh database is
+// migrated to the backup's schema version first and to the latest version
+// after the restore.
+func runRestoreCommand(args []string) error {
+	passphrase := os.Getenv(backupPassphraseEnv)
+	if len(args) != 1 || passphrase == "" {
+		return errors.New(restoreUsage)
+	}
+
+	file, err := os.Open(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to open backup: %w", err)
+	}
+	defer file.Close()
+	archive, err := backup.Open(file, passphrase)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := di.ProvideConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	logger := di.ProvideLogger(cfg)
+	db, cleanup, err := di.ProvideDatabase(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer cleanup()
+
+	migrationsPath := getMigrationsPath()
+	if err := database.MigrateUpTo(db, migrationsPath, archive.SchemaVersion, logger); err != nil {
+		return err
+	}
+	summary, err := backup.NewManager(db).Restore(context.Background(), archive)
+	if err != nil {
+		return err
+	}
+	if err := database.RunMigrations(db, migrationsPath, logger); err != nil {
+		return err
+	}
+	fmt.Printf("restored %d tables (%d rows) from backup taken %s\n", summary.Tables, summary.Rows, summary.CreatedAt.Format(time.RFC3339))
+	return nil
+}
+
+func printMigrationStatus(status database.MigrationStatus) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tSTATUS")
+	for _, m := range status.Migrations {
+		state := "pending"
+		if m.Applied {
+			state = "applied"
+		}
+		if m.Version == status.Version && status.Dirty {
+			state = "dirty"
+		}
+		fmt.Fprintf(w, "%06d\t%s\t%s\n", m.Version, m.Name, state)
+	}
+	w.Flush()
+	fmt.Printf("\nversion %d of %d, %d pending\n", status.Version, status.Latest, status.Pending())
+}
+
 func handleEngineEvents(app *di.Application) {
 	for event := range app.Engine.Events() {
 		processEvent(app, event)
@@ -72,22 +262,27 @@ func handleEngineEvents(app *di.Application) {
 func processEvent(app *di.Application, event engine.DeployEvent) {
 	switch event.Type {
 	case engine.EventTypeRunning:
-		app.SSEHandler.EmitDeployRunning(event.DeployID, event.AppID)
+		app.SSEHandler.EmitDeployRunning(event.DeployID, event.AppID, event.TraceID)
 		app.NotificationService.NotifyDeployRunning(event.DeployID, event.AppID)
+		app.AppWebhookService.NotifyDeployStarted(event.DeployID, event.AppID)
 	case engine.EventTypeSuccess:
-		app.SSEHandler.EmitDeploySuccess(event.DeployID, event.AppID)
+		app.SSEHandler.EmitDeploySuccess(event.DeployID, event.AppID, event.TraceID)
 		app.NotificationService.NotifyDeploySuccess(event.DeployID, event.AppID)
+		app.AppWebhookService.NotifyDeploySuccess(event.DeployID, event.AppID)
 		app.SSEHandler.EmitInvalidate("containers")
 		app.SSEHandler.EmitInvalidate("images")
 		app.SSEHandler.EmitInvalidate("deployments")
 	case engine.EventTypeFailed:
-		app.SSEHandler.EmitDeployFailed(event.DeployID, event.AppID, event.Message)
+		app.SSEHandler.EmitDeployFailed(event.DeployID, event.AppID, event.TraceID, event.Message)
 		app.NotificationService.NotifyDeployFailed(event.DeployID, event.AppID, event.Message)
+		app.AppWebhookService.NotifyDeployFailed(event.DeployID, event.AppID, event.Message)
 		app.SSEHandler.EmitInvalidate("containers")
 		app.SSEHandler.EmitInvalidate("images")
 		app.SSEHandler.EmitInvalidate("deployments")
 	case engine.EventTypeLog:
-		app.SSEHandler.EmitLog(event.DeployID, event.AppID, event.Message)
+		app.SSEHandler.EmitLog(event.DeployID, event.AppID, event.Message, string(event.LogKind))
+	case engine.EventTypeProgress:
+		emitProgressEvent(app, event)
 	case engine.EventTypeHealth:
 		emitHealthEvent(app, event)
 		emitNotificationHealth(app, event)
@@ -113,6 +308,22 @@ func emitNotificationHealth(app *di.Application, event engine.DeployEvent) {
 		return
 	}
 	app.NotificationService.NotifyHealthChange(event.AppID, event.Health.Status, event.Health.Health)
+	app.AppWebhookService.NotifyHealthChange(event.AppID, event.Health.Status, event.Health.Health)
+}
+
+func emitProgressEvent(app *di.Application, event engine.DeployEvent) {
+	if event.Progress == nil {
+		return
+	}
+	app.SSEHandler.EmitProgress(event.DeployID, event.AppID, handler.SSEDeployProgress{
+		Stage:       event.Progress.Stage,
+		StageIndex:  event.Progress.StageIndex,
+		StageCount:  event.Progress.StageCount,
+		Percent:     event.Progress.Percent,
+		ElapsedMs:   event.Progress.ElapsedMs,
+		EtaMs:       event.Progress.EtaMs,
+		FromHistory: event.Progress.FromHistory,
+	})
 }
 
 func emitStatsEvent(app *di.Application, event engine.DeployEvent) {
@@ -127,6 +338,7 @@ func emitStatsEvent(app *di.Application, event engine.DeployEvent) {
 		NetworkRx:     event.Stats.NetworkRx,
 		NetworkTx:     event.Stats.NetworkTx,
 		PIDs:          event.Stats.PIDs,
+		Averages:      event.Stats.Averages,
 	})
 }
 
@@ -137,6 +349,23 @@ func startEngine(app *di.Application) {
 	}
 }
 
+// logDiagnostics validates the configuration at boot so missing or broken
+// settings show up in the logs instead of on the first request that needs them.
+func logDiagnostics(app *di.Application) {
+	report := app.Diagnostics.Run(context.Background())
+	for _, check := range report.Checks {
+		switch check.Status {
+		case diagnostics.StatusFailed:
+			app.Logger.Error("Configuration check failed", "check", check.Name, "message", check.Message)
+		case diagnostics.StatusDegraded:
+			app.Logger.Warn("Configuration check degraded", "check", check.Name, "message", check.Message)
+		case diagnostics.StatusDisabled:
+			app.Logger.Info("Feature disabled", "check", check.Name, "message", check.Message)
+		}
+	}
+	app.Logger.Info("Configuration checked", "status", report.Status)
+}
+
 func startGrpcServer(app *di.Application) {
 	if !app.Config.GRPC.Enabled || app.GrpcServer == nil {
 		return
@@ -152,6 +381,7 @@ func startGrpcServer(app *di.Application) {
 func registerHandlers(app *di.Application) {
 	app.HealthHandler.Register(app.Server.App())
 	app.SwaggerHandler.Register(app.Server.App())
+	app.ConfigHandler.Register(app.Server.App())
 
 	if app.AuthHandler != nil {
 		app.AuthHandler.Register(app.Server.App(), server.AuthRateLimiter())
@@ -165,11 +395,22 @@ func registerHandlers(app *di.Application) {
 		app.Server.App().Use(app.AuthMiddleware.Optional())
 	}
 
+	app.WebhookHandler.SetPushListener(app.GitOpsController)
+	app.AppService.SetAppRunner(app.AppAdminHandler)
+	app.SystemHandler.SetConfigReloader(func() ([]string, error) {
+		return reloadConfig(app)
+	})
 	app.WebhookHandler.Register(app.Server.App())
+	app.AppAdminHandler.RegisterPublic(app.Server.App())
 
 	if app.AgentDownloadHandler != nil {
 		app.Server.App().Get("/paas-deploy/v1/agent/binary", app.AgentDownloadHandler.ServeBinary)
 	}
+
+	if app.AgentBootstrapHandler != nil {
+		app.Server.App().Get(agentdownload.BootstrapBinaryPath, app.AgentBootstrapHandler.ServeBinary)
+		app.Server.App().Post(agentdownload.BootstrapCertsPath, app.AgentBootstrapHandler.ServeCerts)
+	}
 }
 
 func registerProtectedRoutes(app *di.Application) {
@@ -180,6 +421,7 @@ func registerProtectedRoutes(app *di.Application) {
 	authRequired := app.Server.App().Group("")
 	authRequired.Use(app.AuthMiddleware.Require())
 	app.AuthHandler.RegisterProtected(authRequired)
+	app.APITokenHandler.Register(authRequired)
 
 	registerOptionalProtectedHandler(app.GitHubHandler, authRequired)
 	registerOptionalProtectedHandler(app.CloudflareAuthHandler, authRequired)
@@ -189,11 +431,19 @@ func registerProtectedRoutes(app *di.Application) {
 	registerOptionalProtectedHandler(app.ServerHandler, authRequired)
 
 	app.AppHandler.Register(authRequired)
+	app.AppSpecHandler.Register(authRequired)
+	app.GitOpsHandler.Register(authRequired)
 	app.EnvVarHandler.Register(authRequired)
 	app.SSEHandler.Register(authRequired)
 	app.ContainerHealthHandler.Register(authRequired)
 	app.AppAdminHandler.Register(authRequired)
+	app.AppWebhookHandler.Register(authRequired)
+	app.DeploymentSBOMHandler.Register(authRequired)
+	app.ServerTaskHandler.Register(authRequired)
+	app.AppBulkHandler.Register(authRequired)
 	app.ContainerHandler.Register(authRequired)
+	app.SearchHandler.Register(authRequired)
+	app.QuotaHandler.Register(authRequired)
 	app.ContainerExecHandler.Register(authRequired)
 	app.TemplateHandler.Register(authRequired)
 
@@ -271,6 +521,12 @@ func (a *sseBroadcastAdapter) EmitInvalidateForServer(serverID, resource string)
 type monitorGroup struct {
 	systemStats *engine.SystemStatsMonitor
 	serverStats *engine.ServerStatsMonitor
+	heartbeats  *engine.HeartbeatMonitor
+	certExpiry  *engine.CertificateExpiryMonitor
+	serverTasks *engine.ServerTaskScheduler
+	gitOps      *gitops.Controller
+	trash       *engine.AppTrashPurger
+	usage       *engine.UsageMeter
 }
 
 func startMonitors(ctx context.Context, app *di.Application) *monitorGroup {
@@ -287,6 +543,57 @@ func startMonitors(ctx context.Context, app *di.Application) *monitorGroup {
 		mg.serverStats.Start(ctx)
 	}
 
+	if app.Config.GRPC.Enabled {
+		mg.heartbeats = engine.NewHeartbeatMonitor(
+			app.ServerRepo, app.ServerHeartbeatRepo, app.NotificationService, emitter, app.Logger,
+		)
+		mg.heartbeats.Start(ctx)
+	}
+
+	if app.CustomDomainRepo != nil && app.CertificateAlertRepo != nil {
+		mg.certExpiry = engine.NewCertificateExpiryMonitor(engine.CertificateExpiryMonitorParams{
+			DomainRepo:  app.CustomDomainRepo,
+			AlertRepo:   app.CertificateAlertRepo,
+			ServerRepo:  app.ServerRepo,
+			AppRepo:     app.AppRepo,
+			AgentClient: app.AgentClient,
+			AgentPort:   app.Config.GRPC.AgentPort,
+			Notifier:    app.NotificationService,
+			Logger:      app.Logger,
+		})
+		mg.certExpiry.Start(ctx)
+	}
+
+	if app.Config.GRPC.Enabled && app.ServerTaskScheduler != nil {
+		mg.serverTasks = app.ServerTaskScheduler
+		mg.serverTasks.Start(ctx)
+	}
+
+	if app.GitOpsController != nil {
+		mg.gitOps = app.GitOpsController
+		mg.gitOps.Start(ctx)
+	}
+
+	mg.trash = engine.NewAppTrashPurger(
+		app.AppRepo, app.AppService, app.AuditService, app.Config.Deploy.TrashRetention, app.Logger,
+	)
+	mg.trash.Start(ctx)
+
+	if app.UsageSink != nil {
+		mg.usage = engine.NewUsageMeter(engine.UsageMeterParams{
+			AppRepo:        app.AppRepo,
+			DeploymentRepo: app.DeploymentRepo,
+			ServerRepo:     app.ServerRepo,
+			Docker:         app.Engine.Docker(),
+			AgentClient:    app.AgentClient,
+			AgentPort:      app.Config.GRPC.AgentPort,
+			Sink:           app.UsageSink,
+			Interval:       app.Config.Metering.Interval,
+			Logger:         app.Logger,
+		})
+		mg.usage.Start(ctx)
+	}
+
 	return mg
 }
 
@@ -297,6 +604,81 @@ func (mg *monitorGroup) Stop() {
 	if mg.serverStats != nil {
 		mg.serverStats.Stop()
 	}
+	if mg.heartbeats != nil {
+		mg.heartbeats.Stop()
+	}
+	if mg.certExpiry != nil {
+		mg.certExpiry.Stop()
+	}
+	if mg.serverTasks != nil {
+		mg.serverTasks.Stop()
+	}
+	if mg.gitOps != nil {
+		mg.gitOps.Stop()
+	}
+	if mg.trash != nil {
+		mg.trash.Stop()
+	}
+	if mg.usage != nil {
+		mg.usage.Stop()
+	}
+}
+
+func watchReloadSignal(app *di.Application) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	for range hup {
+		if _, err := reloadConfig(app); err != nil {
+			app.Logger.Error("Config reload failed", "error", err)
+		}
+	}
+}
+
+var reloadMu sync.Mutex
+
+// reloadConfig re-reads the .env file and the environment and applies the
+// settings that can change at runtime: log level, CORS origins and deploy
+// workers. Connections, including SSE streams and exec sessions, are kept.
+func reloadConfig(app *di.Application) ([]string, error) {
+	reloadMu.Lock()
+	defer reloadMu.Unlock()
+
+	if vars, err := godotenv.Read(); err == nil {
+		for key, value := range vars {
+			if !processEnv[key] {
+				_ = os.Setenv(key, value)
+			}
+		}
+	}
+	next := config.Load()
+	current := app.Config
+
+	var changed []string
+	if next.Server.CorsOrigins != current.Server.CorsOrigins {
+		if err := app.Server.SetCorsOrigins(next.Server.CorsOrigins); err != nil {
+			return nil, err
+		}
+		current.Server.CorsOrigins = next.Server.CorsOrigins
+		changed = append(changed, "CORS_ORIGINS")
+	}
+	if next.Server.LogLevel != current.Server.LogLevel {
+		di.SetLogLevel(next.Server.LogLevel)
+		current.Server.LogLevel = next.Server.LogLevel
+		changed = append(changed, "LOG_LEVEL")
+	}
+	if next.Deploy.Workers != current.Deploy.Workers {
+		app.Engine.SetWorkers(next.Deploy.Workers)
+		current.Deploy.Workers = app.Engine.WorkerCount()
+		changed = append(changed, "DEPLOY_WORKERS")
+	}
+	if next.Deploy.MaxPerUser != current.Deploy.MaxPerUser {
+		app.Engine.SetMaxDeploysPerUser(next.Deploy.MaxPerUser)
+		current.Deploy.MaxPerUser = next.Deploy.MaxPerUser
+		changed = append(changed, "DEPLOY_MAX_PER_USER")
+	}
+
+	app.Logger.Info("Config reloaded", "changed", changed)
+	return changed, nil
 }
 
 func waitForShutdown(app *di.Application, cancel context.CancelFunc, monitors *monitorGroup) {
@@ -46,7 +46,7 @@ const docTemplate = `{
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/docs.ErrorInfo"
+                            "$ref": "#/definitions/docs.Problem"
                         }
                     }
                 }
@@ -84,13 +84,13 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/docs.ErrorInfo"
+                            "$ref": "#/definitions/docs.Problem"
                         }
                     },
                     "409": {
                         "description": "Conflict",
                         "schema": {
-                            "$ref": "#/definitions/docs.ErrorInfo"
+                            "$ref": "#/definitions/docs.Problem"
                         }
                     }
                 }
@@ -125,7 +125,7 @@ const docTemplate = `{
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/docs.ErrorInfo"
+                            "$ref": "#/definitions/docs.Problem"
                         }
                     }
                 }
@@ -152,7 +152,7 @@ const docTemplate = `{
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/docs.ErrorInfo"
+                            "$ref": "#/definitions/docs.Problem"
                         }
                     }
                 }
@@ -190,7 +190,7 @@ const docTemplate = `{
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/docs.ErrorInfo"
+                            "$ref": "#/definitions/docs.Problem"
                         }
                     }
                 }
@@ -236,13 +236,13 @@ const docTemplate = `{
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/docs.ErrorInfo"
+                            "$ref": "#/definitions/docs.Problem"
                         }
                     },
                     "409": {
                         "description": "Conflict",
                         "schema": {
-                            "$ref": "#/definitions/docs.ErrorInfo"
+                            "$ref": "#/definitions/docs.Problem"
                         }
                     }
                 }
@@ -277,7 +277,7 @@ const docTemplate = `{
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/docs.ErrorInfo"
+                            "$ref": "#/definitions/docs.Problem"
                         }
                     }
                 }
@@ -312,13 +312,13 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/docs.ErrorInfo"
+                            "$ref": "#/definitions/docs.Problem"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/docs.ErrorInfo"
+                            "$ref": "#/definitions/docs.Problem"
                         }
                     }
                 }
@@ -345,7 +345,7 @@ const docTemplate = `{
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/docs.ErrorInfo"
+                            "$ref": "#/definitions/docs.Problem"
                         }
                     }
                 }
@@ -380,7 +380,7 @@ const docTemplate = `{
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/docs.ErrorInfo"
+                            "$ref": "#/definitions/docs.Problem"
                         }
                     }
                 }
@@ -607,18 +607,38 @@ const docTemplate = `{
                 }
             }
         },
-        "docs.ErrorInfo": {
-            "description": "Detalhes do erro",
+        "docs.Problem": {
+            "description": "Erro no formato RFC 7807 (application/problem+json)",
             "type": "object",
             "properties": {
                 "code": {
                     "type": "string",
                     "example": "NOT_FOUND"
                 },
-                "details": {},
-                "message": {
+                "detail": {
+                    "type": "string",
+                    "example": "app not found"
+                },
+                "errors": {},
+                "instance": {
+                    "type": "string",
+                    "example": "/paas-deploy/v1/apps/123"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 404
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Not Found"
+                },
+                "traceId": {
+                    "type": "string",
+                    "example": "abc123"
+                },
+                "type": {
                     "type": "string",
-                    "example": "resource not found"
+                    "example": "urn:paasdeploy:error:not-found"
                 }
             }
         },
@@ -159,7 +159,7 @@ var file_flowdeploy_v1_agent_proto_rawDesc = []byte{
 	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73,
 	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73,
 	0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x32, 0xe1, 0x16, 0x0a, 0x0c, 0x41,
+	0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x32, 0xa8, 0x2b, 0x0a, 0x0c, 0x41,
 	0x67, 0x65, 0x6e, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x4b, 0x0a, 0x08, 0x52,
 	0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x12, 0x1e, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65,
 	0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72,
@@ -181,172 +181,336 @@ var file_flowdeploy_v1_agent_proto_rawDesc = []byte{
 	0x79, 0x4c, 0x6f, 0x67, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e,
 	0x1a, 0x1d, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31,
 	0x2e, 0x44, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x4c, 0x6f, 0x67, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x30,
-	0x01, 0x12, 0x5d, 0x0a, 0x0e, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e,
-	0x65, 0x72, 0x73, 0x12, 0x24, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79,
-	0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65,
-	0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e, 0x66, 0x6c, 0x6f, 0x77,
-	0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x6f,
-	0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x5b, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72,
-	0x4c, 0x6f, 0x67, 0x73, 0x12, 0x23, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f,
-	0x79, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x4c, 0x6f,
-	0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x66, 0x6c, 0x6f, 0x77,
+	0x01, 0x12, 0x5f, 0x0a, 0x16, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x44, 0x65, 0x70, 0x6c, 0x6f,
+	0x79, 0x4c, 0x6f, 0x67, 0x42, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x12, 0x24, 0x2e, 0x66, 0x6c,
+	0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x70, 0x6c,
+	0x6f, 0x79, 0x4c, 0x6f, 0x67, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x1a, 0x1d, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76,
+	0x31, 0x2e, 0x44, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x4c, 0x6f, 0x67, 0x42, 0x61, 0x74, 0x63, 0x68,
+	0x30, 0x01, 0x12, 0x5d, 0x0a, 0x0e, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69,
+	0x6e, 0x65, 0x72, 0x73, 0x12, 0x24, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f,
+	0x79, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e,
+	0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e, 0x66, 0x6c, 0x6f,
+	0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x43,
+	0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x5b, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65,
+	0x72, 0x4c, 0x6f, 0x67, 0x73, 0x12, 0x23, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c,
+	0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x4c,
+	0x6f, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x66, 0x6c, 0x6f,
+	0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x61,
+	0x69, 0x6e, 0x65, 0x72, 0x4c, 0x6f, 0x67, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x30, 0x01, 0x12, 0x5a,
+	0x0a, 0x11, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x53, 0x74,
+	0x61, 0x74, 0x73, 0x12, 0x24, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79,
+	0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x53, 0x74, 0x61,
+	0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x66, 0x6c, 0x6f, 0x77,
 	0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69,
-	0x6e, 0x65, 0x72, 0x4c, 0x6f, 0x67, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x30, 0x01, 0x12, 0x5a, 0x0a,
-	0x11, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x53, 0x74, 0x61,
-	0x74, 0x73, 0x12, 0x24, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e,
-	0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74,
-	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64,
-	0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e,
-	0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x73, 0x30, 0x01, 0x12, 0x63, 0x0a, 0x10, 0x52, 0x65, 0x73,
-	0x74, 0x61, 0x72, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x12, 0x26, 0x2e,
-	0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65,
-	0x73, 0x74, 0x61, 0x72, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c,
-	0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x43, 0x6f, 0x6e,
-	0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5a,
-	0x0a, 0x0d, 0x53, 0x74, 0x6f, 0x70, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x12,
+	0x6e, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x73, 0x30, 0x01, 0x12, 0x63, 0x0a, 0x10, 0x52, 0x65,
+	0x73, 0x74, 0x61, 0x72, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x12, 0x26,
+	0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x52,
+	0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70,
+	0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x43, 0x6f,
+	0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x5a, 0x0a, 0x0d, 0x53, 0x74, 0x6f, 0x70, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72,
+	0x12, 0x23, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31,
+	0x2e, 0x53, 0x74, 0x6f, 0x70, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c,
+	0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x6f, 0x70, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69,
+	0x6e, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x42, 0x0a, 0x0d, 0x47,
+	0x65, 0x74, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x16, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45,
+	0x6d, 0x70, 0x74, 0x79, 0x1a, 0x19, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f,
+	0x79, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x49, 0x6e, 0x66, 0x6f, 0x12,
+	0x48, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x4d, 0x65, 0x74, 0x72,
+	0x69, 0x63, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x1c, 0x2e, 0x66, 0x6c,
+	0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x79, 0x73, 0x74,
+	0x65, 0x6d, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x12, 0x42, 0x0a, 0x0d, 0x47, 0x65, 0x74,
+	0x44, 0x6f, 0x63, 0x6b, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70,
+	0x74, 0x79, 0x1a, 0x19, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e,
+	0x76, 0x31, 0x2e, 0x44, 0x6f, 0x63, 0x6b, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x5d, 0x0a,
+	0x0e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x12,
+	0x24, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e,
+	0x53, 0x74, 0x61, 0x72, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c,
+	0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x61,
+	0x69, 0x6e, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x51, 0x0a, 0x0a,
+	0x4c, 0x69, 0x73, 0x74, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x73, 0x12, 0x20, 0x2e, 0x66, 0x6c, 0x6f,
+	0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x49,
+	0x6d, 0x61, 0x67, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x66,
+	0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73,
+	0x74, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x54, 0x0a, 0x0b, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x21,
+	0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x52,
+	0x65, 0x6d, 0x6f, 0x76, 0x65, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x22, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76,
+	0x31, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x54, 0x0a, 0x0b, 0x50, 0x72, 0x75, 0x6e, 0x65, 0x49, 0x6d,
+	0x61, 0x67, 0x65, 0x73, 0x12, 0x21, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f,
+	0x79, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x75, 0x6e, 0x65, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65,
+	0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x75, 0x6e, 0x65, 0x49, 0x6d, 0x61,
+	0x67, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x57, 0x0a, 0x0c, 0x4c,
+	0x69, 0x73, 0x74, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x73, 0x12, 0x22, 0x2e, 0x66, 0x6c,
+	0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74,
+	0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
 	0x23, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e,
-	0x53, 0x74, 0x6f, 0x70, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f,
-	0x79, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x6f, 0x70, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e,
-	0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x42, 0x0a, 0x0d, 0x47, 0x65,
-	0x74, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x16, 0x2e, 0x67, 0x6f,
-	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d,
-	0x70, 0x74, 0x79, 0x1a, 0x19, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79,
-	0x2e, 0x76, 0x31, 0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x48,
-	0x0a, 0x10, 0x47, 0x65, 0x74, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x4d, 0x65, 0x74, 0x72, 0x69,
-	0x63, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x1c, 0x2e, 0x66, 0x6c, 0x6f,
-	0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x79, 0x73, 0x74, 0x65,
-	0x6d, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x12, 0x42, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x44,
-	0x6f, 0x63, 0x6b, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
-	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74,
-	0x79, 0x1a, 0x19, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76,
-	0x31, 0x2e, 0x44, 0x6f, 0x63, 0x6b, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x5d, 0x0a, 0x0e,
-	0x53, 0x74, 0x61, 0x72, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x12, 0x24,
-	0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x53,
-	0x74, 0x61, 0x72, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f,
-	0x79, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69,
-	0x6e, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x51, 0x0a, 0x0a, 0x4c,
-	0x69, 0x73, 0x74, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x73, 0x12, 0x20, 0x2e, 0x66, 0x6c, 0x6f, 0x77,
-	0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x49, 0x6d,
-	0x61, 0x67, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x66, 0x6c,
+	0x4c, 0x69, 0x73, 0x74, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5a, 0x0a, 0x0d, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4e, 0x65,
+	0x74, 0x77, 0x6f, 0x72, 0x6b, 0x12, 0x23, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c,
+	0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4e, 0x65, 0x74, 0x77,
+	0x6f, 0x72, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x66, 0x6c, 0x6f,
+	0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x5a, 0x0a, 0x0d, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72,
+	0x6b, 0x12, 0x23, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76,
+	0x31, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70,
+	0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x4e, 0x65, 0x74,
+	0x77, 0x6f, 0x72, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x54, 0x0a, 0x0b,
+	0x4c, 0x69, 0x73, 0x74, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x73, 0x12, 0x21, 0x2e, 0x66, 0x6c,
 	0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74,
-	0x49, 0x6d, 0x61, 0x67, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x54,
-	0x0a, 0x0b, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x21, 0x2e,
-	0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65,
-	0x6d, 0x6f, 0x76, 0x65, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x22, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31,
-	0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x54, 0x0a, 0x0b, 0x50, 0x72, 0x75, 0x6e, 0x65, 0x49, 0x6d, 0x61,
-	0x67, 0x65, 0x73, 0x12, 0x21, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79,
-	0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x75, 0x6e, 0x65, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x73, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70,
-	0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x75, 0x6e, 0x65, 0x49, 0x6d, 0x61, 0x67,
-	0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x57, 0x0a, 0x0c, 0x4c, 0x69,
-	0x73, 0x74, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x73, 0x12, 0x22, 0x2e, 0x66, 0x6c, 0x6f,
-	0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4e,
-	0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23,
+	0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22,
 	0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x4c,
-	0x69, 0x73, 0x74, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x5a, 0x0a, 0x0d, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4e, 0x65, 0x74,
-	0x77, 0x6f, 0x72, 0x6b, 0x12, 0x23, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f,
-	0x79, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4e, 0x65, 0x74, 0x77, 0x6f,
-	0x72, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x66, 0x6c, 0x6f, 0x77,
-	0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
-	0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x5a, 0x0a, 0x0d, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b,
-	0x12, 0x23, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31,
-	0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c,
-	0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x4e, 0x65, 0x74, 0x77,
-	0x6f, 0x72, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x54, 0x0a, 0x0b, 0x4c,
-	0x69, 0x73, 0x74, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x73, 0x12, 0x21, 0x2e, 0x66, 0x6c, 0x6f,
-	0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x56,
-	0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e,
-	0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69,
-	0x73, 0x74, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x57, 0x0a, 0x0c, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x56, 0x6f, 0x6c, 0x75, 0x6d,
-	0x65, 0x12, 0x22, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76,
-	0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c,
-	0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x56, 0x6f, 0x6c, 0x75,
-	0x6d, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x57, 0x0a, 0x0c, 0x52, 0x65,
-	0x6d, 0x6f, 0x76, 0x65, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x12, 0x22, 0x2e, 0x66, 0x6c, 0x6f,
-	0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76,
-	0x65, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23,
+	0x69, 0x73, 0x74, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x57, 0x0a, 0x0c, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x56, 0x6f, 0x6c, 0x75,
+	0x6d, 0x65, 0x12, 0x22, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e,
+	0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70,
+	0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x56, 0x6f, 0x6c,
+	0x75, 0x6d, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x57, 0x0a, 0x0c, 0x52,
+	0x65, 0x6d, 0x6f, 0x76, 0x65, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x12, 0x22, 0x2e, 0x66, 0x6c,
+	0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x6d, 0x6f,
+	0x76, 0x65, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x23, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e,
+	0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x60, 0x0a, 0x0f, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x43, 0x6f,
+	0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x12, 0x25, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65,
+	0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x43, 0x6f,
+	0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x26,
 	0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x52,
-	0x65, 0x6d, 0x6f, 0x76, 0x65, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x60, 0x0a, 0x0f, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x43, 0x6f, 0x6e,
-	0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x12, 0x25, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70,
-	0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x43, 0x6f, 0x6e,
-	0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x26, 0x2e,
-	0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65,
-	0x6d, 0x6f, 0x76, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5a, 0x0a, 0x0d, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x44,
-	0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x73, 0x12, 0x23, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70,
-	0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x44, 0x6f, 0x6d,
-	0x61, 0x69, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x66, 0x6c,
-	0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70, 0x64, 0x61,
-	0x74, 0x65, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x48, 0x0a, 0x0d, 0x45, 0x78, 0x65, 0x63, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e,
-	0x65, 0x72, 0x12, 0x18, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e,
-	0x76, 0x31, 0x2e, 0x45, 0x78, 0x65, 0x63, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x1a, 0x19, 0x2e, 0x66,
-	0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x65,
-	0x63, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x28, 0x01, 0x30, 0x01, 0x12, 0x55, 0x0a, 0x0a, 0x50,
-	0x75, 0x73, 0x68, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x20, 0x2e, 0x66, 0x6c, 0x6f, 0x77,
-	0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
-	0x42, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x1a, 0x23, 0x2e, 0x66, 0x6c,
-	0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70, 0x64, 0x61,
-	0x74, 0x65, 0x42, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x28, 0x01, 0x12, 0x60, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69,
-	0x63, 0x61, 0x74, 0x65, 0x73, 0x12, 0x25, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c,
-	0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69,
-	0x63, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x26, 0x2e, 0x66,
-	0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74,
+	0x65, 0x6d, 0x6f, 0x76, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5a, 0x0a, 0x0d, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x73, 0x12, 0x23, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65,
+	0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x44, 0x6f,
+	0x6d, 0x61, 0x69, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x66,
+	0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x48, 0x0a, 0x0d, 0x45, 0x78, 0x65, 0x63, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69,
+	0x6e, 0x65, 0x72, 0x12, 0x18, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79,
+	0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x65, 0x63, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x1a, 0x19, 0x2e,
+	0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78,
+	0x65, 0x63, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x28, 0x01, 0x30, 0x01, 0x12, 0x55, 0x0a, 0x0a,
+	0x50, 0x75, 0x73, 0x68, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x20, 0x2e, 0x66, 0x6c, 0x6f,
+	0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x42, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x1a, 0x23, 0x2e, 0x66,
+	0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x42, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x28, 0x01, 0x12, 0x60, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66,
+	0x69, 0x63, 0x61, 0x74, 0x65, 0x73, 0x12, 0x25, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70,
+	0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66,
+	0x69, 0x63, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x26, 0x2e,
+	0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65,
+	0x74, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x60, 0x0a, 0x0f, 0x50, 0x72, 0x75, 0x6e, 0x65, 0x43, 0x6f,
+	0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x73, 0x12, 0x25, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64,
+	0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x75, 0x6e, 0x65, 0x43, 0x6f,
+	0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x26, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e,
+	0x50, 0x72, 0x75, 0x6e, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x57, 0x0a, 0x0c, 0x50, 0x72, 0x75, 0x6e, 0x65,
+	0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x73, 0x12, 0x22, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65,
+	0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x75, 0x6e, 0x65, 0x56, 0x6f, 0x6c,
+	0x75, 0x6d, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x66, 0x6c,
+	0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x75, 0x6e,
+	0x65, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x84, 0x01, 0x0a, 0x1b, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x61,
+	0x69, 0x6e, 0x65, 0x72, 0x46, 0x72, 0x6f, 0x6d, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65,
+	0x12, 0x31, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31,
+	0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72,
+	0x46, 0x72, 0x6f, 0x6d, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x32, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79,
+	0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69,
+	0x6e, 0x65, 0x72, 0x46, 0x72, 0x6f, 0x6d, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x72, 0x0a, 0x15, 0x43, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x75, 0x72, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x53, 0x53, 0x4c,
+	0x12, 0x2b, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31,
+	0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69,
+	0x6e, 0x65, 0x72, 0x53, 0x53, 0x4c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2c, 0x2e,
+	0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72,
+	0x53, 0x53, 0x4c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x72, 0x0a, 0x15, 0x47,
+	0x65, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x53, 0x53, 0x4c, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x2b, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f,
+	0x79, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65,
+	0x72, 0x53, 0x53, 0x4c, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x2c, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76,
+	0x31, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x53, 0x53,
+	0x4c, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x57, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x4c, 0x6f, 0x67, 0x73, 0x12,
+	0x22, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e,
+	0x47, 0x65, 0x74, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x4c, 0x6f, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79,
+	0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x4c, 0x6f, 0x67, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x60, 0x0a, 0x0f, 0x52, 0x6f, 0x74, 0x61,
+	0x74, 0x65, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x4c, 0x6f, 0x67, 0x73, 0x12, 0x25, 0x2e, 0x66, 0x6c,
+	0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x6f, 0x74, 0x61,
+	0x74, 0x65, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x4c, 0x6f, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x26, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e,
+	0x76, 0x31, 0x2e, 0x52, 0x6f, 0x74, 0x61, 0x74, 0x65, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x4c, 0x6f,
+	0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x69, 0x0a, 0x12, 0x49, 0x6e,
+	0x73, 0x74, 0x61, 0x6c, 0x6c, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65,
+	0x12, 0x28, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31,
+	0x2e, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63,
+	0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x29, 0x2e, 0x66, 0x6c, 0x6f,
+	0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e, 0x73, 0x74, 0x61,
+	0x6c, 0x6c, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x66, 0x0a, 0x11, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x43,
+	0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x12, 0x27, 0x2e, 0x66, 0x6c, 0x6f,
+	0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76,
+	0x65, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x28, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79,
+	0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66,
+	0x69, 0x63, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5d, 0x0a,
+	0x0e, 0x53, 0x65, 0x74, 0x4d, 0x61, 0x69, 0x6e, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x12,
+	0x24, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e,
+	0x53, 0x65, 0x74, 0x4d, 0x61, 0x69, 0x6e, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c,
+	0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x74, 0x4d, 0x61, 0x69, 0x6e, 0x74, 0x65, 0x6e,
+	0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x6f, 0x0a, 0x14,
+	0x4c, 0x69, 0x73, 0x74, 0x41, 0x63, 0x6d, 0x65, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63,
+	0x61, 0x74, 0x65, 0x73, 0x12, 0x2a, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f,
+	0x79, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x63, 0x6d, 0x65, 0x43, 0x65, 0x72,
+	0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x2b, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31,
+	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x63, 0x6d, 0x65, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69,
+	0x63, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x75, 0x0a,
+	0x16, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x41, 0x63, 0x6d, 0x65, 0x43, 0x65, 0x72, 0x74, 0x69,
+	0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x73, 0x12, 0x2c, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65,
+	0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x41, 0x63,
+	0x6d, 0x65, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2d, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c,
+	0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x41, 0x63, 0x6d, 0x65,
 	0x43, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x60, 0x0a, 0x0f, 0x50, 0x72, 0x75, 0x6e, 0x65, 0x43, 0x6f, 0x6e,
-	0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x73, 0x12, 0x25, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65,
-	0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x75, 0x6e, 0x65, 0x43, 0x6f, 0x6e,
-	0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x26,
-	0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x50,
-	0x72, 0x75, 0x6e, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x73, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x57, 0x0a, 0x0c, 0x50, 0x72, 0x75, 0x6e, 0x65, 0x56,
-	0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x73, 0x12, 0x22, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70,
-	0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x75, 0x6e, 0x65, 0x56, 0x6f, 0x6c, 0x75,
-	0x6d, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x66, 0x6c, 0x6f,
-	0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x75, 0x6e, 0x65,
-	0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x84, 0x01, 0x0a, 0x1b, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69,
-	0x6e, 0x65, 0x72, 0x46, 0x72, 0x6f, 0x6d, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x12,
-	0x31, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e,
-	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x46,
-	0x72, 0x6f, 0x6d, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x32, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e,
-	0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e,
-	0x65, 0x72, 0x46, 0x72, 0x6f, 0x6d, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x72, 0x0a, 0x15, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
-	0x75, 0x72, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x53, 0x53, 0x4c, 0x12,
-	0x2b, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e,
-	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e,
-	0x65, 0x72, 0x53, 0x53, 0x4c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2c, 0x2e, 0x66,
-	0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e,
-	0x66, 0x69, 0x67, 0x75, 0x72, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x53,
-	0x53, 0x4c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x72, 0x0a, 0x15, 0x47, 0x65,
-	0x74, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x53, 0x53, 0x4c, 0x53, 0x74, 0x61,
-	0x74, 0x75, 0x73, 0x12, 0x2b, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79,
-	0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72,
-	0x53, 0x53, 0x4c, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x2c, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31,
-	0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x53, 0x53, 0x4c,
-	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x41,
-	0x5a, 0x3f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x70, 0x61, 0x61,
-	0x73, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2f, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x2f,
-	0x67, 0x65, 0x6e, 0x2f, 0x67, 0x6f, 0x2f, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f,
-	0x79, 0x2f, 0x76, 0x31, 0x3b, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x76,
-	0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x60, 0x0a, 0x0f, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72,
+	0x65, 0x54, 0x75, 0x6e, 0x6e, 0x65, 0x6c, 0x12, 0x25, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65,
+	0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72,
+	0x65, 0x54, 0x75, 0x6e, 0x6e, 0x65, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x26,
+	0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x43,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x65, 0x54, 0x75, 0x6e, 0x6e, 0x65, 0x6c, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x57, 0x0a, 0x0c, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65,
+	0x54, 0x75, 0x6e, 0x6e, 0x65, 0x6c, 0x12, 0x22, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70,
+	0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x54, 0x75, 0x6e,
+	0x6e, 0x65, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x66, 0x6c, 0x6f,
+	0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76,
+	0x65, 0x54, 0x75, 0x6e, 0x6e, 0x65, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x66, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x4c, 0x6f, 0x67, 0x53,
+	0x74, 0x61, 0x74, 0x73, 0x12, 0x27, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f,
+	0x79, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x4c, 0x6f,
+	0x67, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x28, 0x2e,
+	0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65,
+	0x74, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x4c, 0x6f, 0x67, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x69, 0x0a, 0x12, 0x52, 0x65, 0x61, 0x64, 0x43,
+	0x6f, 0x6d, 0x70, 0x6f, 0x73, 0x65, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x12, 0x28, 0x2e,
+	0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65,
+	0x61, 0x64, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x73, 0x65, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x29, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65,
+	0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x43, 0x6f, 0x6d, 0x70,
+	0x6f, 0x73, 0x65, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x6f, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x4d, 0x69, 0x67, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x2a, 0x2e, 0x66, 0x6c, 0x6f,
+	0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x4d, 0x69,
+	0x67, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2b, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70,
+	0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x4d, 0x69, 0x67, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x72, 0x0a, 0x15, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4d, 0x69, 0x67,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x12, 0x2b, 0x2e, 0x66,
+	0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x4d, 0x69, 0x67, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x61, 0x63, 0x6b,
+	0x75, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2c, 0x2e, 0x66, 0x6c, 0x6f, 0x77,
+	0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x4d, 0x69, 0x67, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x63, 0x0a, 0x10, 0x4d, 0x69, 0x67, 0x72, 0x61,
+	0x74, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x12, 0x26, 0x2e, 0x66, 0x6c,
+	0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x69, 0x67, 0x72,
+	0x61, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79,
+	0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x69, 0x67, 0x72, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x61,
+	0x69, 0x6e, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4e, 0x0a, 0x09,
+	0x53, 0x74, 0x6f, 0x70, 0x4e, 0x67, 0x69, 0x6e, 0x78, 0x12, 0x1f, 0x2e, 0x66, 0x6c, 0x6f, 0x77,
+	0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x6f, 0x70, 0x4e, 0x67,
+	0x69, 0x6e, 0x78, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x66, 0x6c, 0x6f,
+	0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x6f, 0x70, 0x4e,
+	0x67, 0x69, 0x6e, 0x78, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x69, 0x0a, 0x12,
+	0x4c, 0x69, 0x73, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x46, 0x69, 0x6c,
+	0x65, 0x73, 0x12, 0x28, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e,
+	0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72,
+	0x46, 0x69, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x29, 0x2e, 0x66,
+	0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73,
+	0x74, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x69, 0x0a, 0x15, 0x44, 0x6f, 0x77, 0x6e, 0x6c,
+	0x6f, 0x61, 0x64, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x46, 0x69, 0x6c, 0x65,
+	0x12, 0x2b, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31,
+	0x2e, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e,
+	0x65, 0x72, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e,
+	0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f,
+	0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x46, 0x69, 0x6c, 0x65, 0x43, 0x68, 0x75, 0x6e, 0x6b,
+	0x30, 0x01, 0x12, 0x66, 0x0a, 0x13, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x43, 0x6f, 0x6e, 0x74,
+	0x61, 0x69, 0x6e, 0x65, 0x72, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x21, 0x2e, 0x66, 0x6c, 0x6f, 0x77,
+	0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69,
+	0x6e, 0x65, 0x72, 0x46, 0x69, 0x6c, 0x65, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x1a, 0x2a, 0x2e, 0x66,
+	0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70, 0x6c,
+	0x6f, 0x61, 0x64, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x46, 0x69, 0x6c, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x28, 0x01, 0x12, 0x60, 0x0a, 0x0f, 0x47, 0x65,
+	0x74, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x54, 0x6f, 0x70, 0x12, 0x25, 0x2e,
+	0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65,
+	0x74, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x54, 0x6f, 0x70, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x26, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f,
+	0x79, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65,
+	0x72, 0x54, 0x6f, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x63, 0x0a, 0x10,
+	0x49, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72,
+	0x12, 0x26, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31,
+	0x2e, 0x49, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65,
+	0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64,
+	0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x74,
+	0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x60, 0x0a, 0x0f, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x61,
+	0x69, 0x6e, 0x65, 0x72, 0x12, 0x25, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f,
+	0x79, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x61,
+	0x69, 0x6e, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x26, 0x2e, 0x66, 0x6c,
+	0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x6d,
+	0x69, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x60, 0x0a, 0x0f, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x6f, 0x6c, 0x75, 0x6d,
+	0x65, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x12, 0x25, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70,
+	0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x6f, 0x6c, 0x75, 0x6d,
+	0x65, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x26, 0x2e,
+	0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69,
+	0x73, 0x74, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5d, 0x0a, 0x0e, 0x53, 0x74, 0x61, 0x74, 0x56, 0x6f, 0x6c,
+	0x75, 0x6d, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x24, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65,
+	0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x56, 0x6f, 0x6c, 0x75,
+	0x6d, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e,
+	0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74,
+	0x61, 0x74, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x63, 0x0a, 0x12, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64,
+	0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x28, 0x2e, 0x66, 0x6c, 0x6f,
+	0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x6f, 0x77, 0x6e, 0x6c,
+	0x6f, 0x61, 0x64, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f,
+	0x79, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x46, 0x69,
+	0x6c, 0x65, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x30, 0x01, 0x12, 0x5a, 0x0a, 0x0d, 0x52, 0x75, 0x6e,
+	0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x54, 0x61, 0x73, 0x6b, 0x12, 0x23, 0x2e, 0x66, 0x6c, 0x6f,
+	0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x75, 0x6e, 0x53, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x24, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e,
+	0x52, 0x75, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x41, 0x5a, 0x3f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
+	0x63, 0x6f, 0x6d, 0x2f, 0x70, 0x61, 0x61, 0x73, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2f, 0x62,
+	0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x67, 0x6f, 0x2f, 0x66, 0x6c,
+	0x6f, 0x77, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2f, 0x76, 0x31, 0x3b, 0x66, 0x6c, 0x6f, 0x77,
+	0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -394,108 +558,209 @@ var file_flowdeploy_v1_agent_proto_goTypes = []any{
 	(*CreateContainerFromTemplateRequest)(nil),  // 28: flowdeploy.v1.CreateContainerFromTemplateRequest
 	(*ConfigureContainerSSLRequest)(nil),        // 29: flowdeploy.v1.ConfigureContainerSSLRequest
 	(*GetContainerSSLStatusRequest)(nil),        // 30: flowdeploy.v1.GetContainerSSLStatusRequest
-	(*RegisterResponse)(nil),                    // 31: flowdeploy.v1.RegisterResponse
-	(*HeartbeatResponse)(nil),                   // 32: flowdeploy.v1.HeartbeatResponse
-	(*DeployResponse)(nil),                      // 33: flowdeploy.v1.DeployResponse
-	(*DeployLogEntry)(nil),                      // 34: flowdeploy.v1.DeployLogEntry
-	(*ListContainersResponse)(nil),              // 35: flowdeploy.v1.ListContainersResponse
-	(*ContainerLogEntry)(nil),                   // 36: flowdeploy.v1.ContainerLogEntry
-	(*ContainerStats)(nil),                      // 37: flowdeploy.v1.ContainerStats
-	(*RestartContainerResponse)(nil),            // 38: flowdeploy.v1.RestartContainerResponse
-	(*StopContainerResponse)(nil),               // 39: flowdeploy.v1.StopContainerResponse
-	(*SystemInfo)(nil),                          // 40: flowdeploy.v1.SystemInfo
-	(*SystemMetrics)(nil),                       // 41: flowdeploy.v1.SystemMetrics
-	(*DockerInfo)(nil),                          // 42: flowdeploy.v1.DockerInfo
-	(*StartContainerResponse)(nil),              // 43: flowdeploy.v1.StartContainerResponse
-	(*ListImagesResponse)(nil),                  // 44: flowdeploy.v1.ListImagesResponse
-	(*RemoveImageResponse)(nil),                 // 45: flowdeploy.v1.RemoveImageResponse
-	(*PruneImagesResponse)(nil),                 // 46: flowdeploy.v1.PruneImagesResponse
-	(*ListNetworksResponse)(nil),                // 47: flowdeploy.v1.ListNetworksResponse
-	(*CreateNetworkResponse)(nil),               // 48: flowdeploy.v1.CreateNetworkResponse
-	(*RemoveNetworkResponse)(nil),               // 49: flowdeploy.v1.RemoveNetworkResponse
-	(*ListVolumesResponse)(nil),                 // 50: flowdeploy.v1.ListVolumesResponse
-	(*CreateVolumeResponse)(nil),                // 51: flowdeploy.v1.CreateVolumeResponse
-	(*RemoveVolumeResponse)(nil),                // 52: flowdeploy.v1.RemoveVolumeResponse
-	(*RemoveContainerResponse)(nil),             // 53: flowdeploy.v1.RemoveContainerResponse
-	(*UpdateDomainsResponse)(nil),               // 54: flowdeploy.v1.UpdateDomainsResponse
-	(*ExecOutput)(nil),                          // 55: flowdeploy.v1.ExecOutput
-	(*GetCertificatesResponse)(nil),             // 56: flowdeploy.v1.GetCertificatesResponse
-	(*PruneContainersResponse)(nil),             // 57: flowdeploy.v1.PruneContainersResponse
-	(*PruneVolumesResponse)(nil),                // 58: flowdeploy.v1.PruneVolumesResponse
-	(*CreateContainerFromTemplateResponse)(nil), // 59: flowdeploy.v1.CreateContainerFromTemplateResponse
-	(*ConfigureContainerSSLResponse)(nil),       // 60: flowdeploy.v1.ConfigureContainerSSLResponse
-	(*GetContainerSSLStatusResponse)(nil),       // 61: flowdeploy.v1.GetContainerSSLStatusResponse
+	(*GetAgentLogsRequest)(nil),                 // 31: flowdeploy.v1.GetAgentLogsRequest
+	(*RotateAgentLogsRequest)(nil),              // 32: flowdeploy.v1.RotateAgentLogsRequest
+	(*InstallCertificateRequest)(nil),           // 33: flowdeploy.v1.InstallCertificateRequest
+	(*RemoveCertificateRequest)(nil),            // 34: flowdeploy.v1.RemoveCertificateRequest
+	(*SetMaintenanceRequest)(nil),               // 35: flowdeploy.v1.SetMaintenanceRequest
+	(*ListAcmeCertificatesRequest)(nil),         // 36: flowdeploy.v1.ListAcmeCertificatesRequest
+	(*DeleteAcmeCertificatesRequest)(nil),       // 37: flowdeploy.v1.DeleteAcmeCertificatesRequest
+	(*ConfigureTunnelRequest)(nil),              // 38: flowdeploy.v1.ConfigureTunnelRequest
+	(*RemoveTunnelRequest)(nil),                 // 39: flowdeploy.v1.RemoveTunnelRequest
+	(*GetAccessLogStatsRequest)(nil),            // 40: flowdeploy.v1.GetAccessLogStatsRequest
+	(*ReadComposeProjectRequest)(nil),           // 41: flowdeploy.v1.ReadComposeProjectRequest
+	(*GetMigrationSnapshotRequest)(nil),         // 42: flowdeploy.v1.GetMigrationSnapshotRequest
+	(*CreateMigrationBackupRequest)(nil),        // 43: flowdeploy.v1.CreateMigrationBackupRequest
+	(*MigrateContainerRequest)(nil),             // 44: flowdeploy.v1.MigrateContainerRequest
+	(*StopNginxRequest)(nil),                    // 45: flowdeploy.v1.StopNginxRequest
+	(*ListContainerFilesRequest)(nil),           // 46: flowdeploy.v1.ListContainerFilesRequest
+	(*DownloadContainerFileRequest)(nil),        // 47: flowdeploy.v1.DownloadContainerFileRequest
+	(*ContainerFileChunk)(nil),                  // 48: flowdeploy.v1.ContainerFileChunk
+	(*GetContainerTopRequest)(nil),              // 49: flowdeploy.v1.GetContainerTopRequest
+	(*InspectContainerRequest)(nil),             // 50: flowdeploy.v1.InspectContainerRequest
+	(*CommitContainerRequest)(nil),              // 51: flowdeploy.v1.CommitContainerRequest
+	(*ListVolumeFilesRequest)(nil),              // 52: flowdeploy.v1.ListVolumeFilesRequest
+	(*StatVolumeFileRequest)(nil),               // 53: flowdeploy.v1.StatVolumeFileRequest
+	(*DownloadVolumeFileRequest)(nil),           // 54: flowdeploy.v1.DownloadVolumeFileRequest
+	(*RunServerTaskRequest)(nil),                // 55: flowdeploy.v1.RunServerTaskRequest
+	(*RegisterResponse)(nil),                    // 56: flowdeploy.v1.RegisterResponse
+	(*HeartbeatResponse)(nil),                   // 57: flowdeploy.v1.HeartbeatResponse
+	(*DeployResponse)(nil),                      // 58: flowdeploy.v1.DeployResponse
+	(*DeployLogEntry)(nil),                      // 59: flowdeploy.v1.DeployLogEntry
+	(*DeployLogBatch)(nil),                      // 60: flowdeploy.v1.DeployLogBatch
+	(*ListContainersResponse)(nil),              // 61: flowdeploy.v1.ListContainersResponse
+	(*ContainerLogEntry)(nil),                   // 62: flowdeploy.v1.ContainerLogEntry
+	(*ContainerStats)(nil),                      // 63: flowdeploy.v1.ContainerStats
+	(*RestartContainerResponse)(nil),            // 64: flowdeploy.v1.RestartContainerResponse
+	(*StopContainerResponse)(nil),               // 65: flowdeploy.v1.StopContainerResponse
+	(*SystemInfo)(nil),                          // 66: flowdeploy.v1.SystemInfo
+	(*SystemMetrics)(nil),                       // 67: flowdeploy.v1.SystemMetrics
+	(*DockerInfo)(nil),                          // 68: flowdeploy.v1.DockerInfo
+	(*StartContainerResponse)(nil),              // 69: flowdeploy.v1.StartContainerResponse
+	(*ListImagesResponse)(nil),                  // 70: flowdeploy.v1.ListImagesResponse
+	(*RemoveImageResponse)(nil),                 // 71: flowdeploy.v1.RemoveImageResponse
+	(*PruneImagesResponse)(nil),                 // 72: flowdeploy.v1.PruneImagesResponse
+	(*ListNetworksResponse)(nil),                // 73: flowdeploy.v1.ListNetworksResponse
+	(*CreateNetworkResponse)(nil),               // 74: flowdeploy.v1.CreateNetworkResponse
+	(*RemoveNetworkResponse)(nil),               // 75: flowdeploy.v1.RemoveNetworkResponse
+	(*ListVolumesResponse)(nil),                 // 76: flowdeploy.v1.ListVolumesResponse
+	(*CreateVolumeResponse)(nil),                // 77: flowdeploy.v1.CreateVolumeResponse
+	(*RemoveVolumeResponse)(nil),                // 78: flowdeploy.v1.RemoveVolumeResponse
+	(*RemoveContainerResponse)(nil),             // 79: flowdeploy.v1.RemoveContainerResponse
+	(*UpdateDomainsResponse)(nil),               // 80: flowdeploy.v1.UpdateDomainsResponse
+	(*ExecOutput)(nil),                          // 81: flowdeploy.v1.ExecOutput
+	(*GetCertificatesResponse)(nil),             // 82: flowdeploy.v1.GetCertificatesResponse
+	(*PruneContainersResponse)(nil),             // 83: flowdeploy.v1.PruneContainersResponse
+	(*PruneVolumesResponse)(nil),                // 84: flowdeploy.v1.PruneVolumesResponse
+	(*CreateContainerFromTemplateResponse)(nil), // 85: flowdeploy.v1.CreateContainerFromTemplateResponse
+	(*ConfigureContainerSSLResponse)(nil),       // 86: flowdeploy.v1.ConfigureContainerSSLResponse
+	(*GetContainerSSLStatusResponse)(nil),       // 87: flowdeploy.v1.GetContainerSSLStatusResponse
+	(*GetAgentLogsResponse)(nil),                // 88: flowdeploy.v1.GetAgentLogsResponse
+	(*RotateAgentLogsResponse)(nil),             // 89: flowdeploy.v1.RotateAgentLogsResponse
+	(*InstallCertificateResponse)(nil),          // 90: flowdeploy.v1.InstallCertificateResponse
+	(*RemoveCertificateResponse)(nil),           // 91: flowdeploy.v1.RemoveCertificateResponse
+	(*SetMaintenanceResponse)(nil),              // 92: flowdeploy.v1.SetMaintenanceResponse
+	(*ListAcmeCertificatesResponse)(nil),        // 93: flowdeploy.v1.ListAcmeCertificatesResponse
+	(*DeleteAcmeCertificatesResponse)(nil),      // 94: flowdeploy.v1.DeleteAcmeCertificatesResponse
+	(*ConfigureTunnelResponse)(nil),             // 95: flowdeploy.v1.ConfigureTunnelResponse
+	(*RemoveTunnelResponse)(nil),                // 96: flowdeploy.v1.RemoveTunnelResponse
+	(*GetAccessLogStatsResponse)(nil),           // 97: flowdeploy.v1.GetAccessLogStatsResponse
+	(*ReadComposeProjectResponse)(nil),          // 98: flowdeploy.v1.ReadComposeProjectResponse
+	(*GetMigrationSnapshotResponse)(nil),        // 99: flowdeploy.v1.GetMigrationSnapshotResponse
+	(*CreateMigrationBackupResponse)(nil),       // 100: flowdeploy.v1.CreateMigrationBackupResponse
+	(*MigrateContainerResponse)(nil),            // 101: flowdeploy.v1.MigrateContainerResponse
+	(*StopNginxResponse)(nil),                   // 102: flowdeploy.v1.StopNginxResponse
+	(*ListContainerFilesResponse)(nil),          // 103: flowdeploy.v1.ListContainerFilesResponse
+	(*UploadContainerFileResponse)(nil),         // 104: flowdeploy.v1.UploadContainerFileResponse
+	(*GetContainerTopResponse)(nil),             // 105: flowdeploy.v1.GetContainerTopResponse
+	(*InspectContainerResponse)(nil),            // 106: flowdeploy.v1.InspectContainerResponse
+	(*CommitContainerResponse)(nil),             // 107: flowdeploy.v1.CommitContainerResponse
+	(*ListVolumeFilesResponse)(nil),             // 108: flowdeploy.v1.ListVolumeFilesResponse
+	(*StatVolumeFileResponse)(nil),              // 109: flowdeploy.v1.StatVolumeFileResponse
+	(*RunServerTaskResponse)(nil),               // 110: flowdeploy.v1.RunServerTaskResponse
 }
 var file_flowdeploy_v1_agent_proto_depIdxs = []int32{
-	2,  // 0: flowdeploy.v1.AgentService.Register:input_type -> flowdeploy.v1.RegisterRequest
-	3,  // 1: flowdeploy.v1.AgentService.Heartbeat:input_type -> flowdeploy.v1.HeartbeatRequest
-	4,  // 2: flowdeploy.v1.AgentService.ExecuteDeploy:input_type -> flowdeploy.v1.DeployRequest
-	5,  // 3: flowdeploy.v1.AgentService.StreamDeployLogs:input_type -> flowdeploy.v1.DeployLogSubscription
-	6,  // 4: flowdeploy.v1.AgentService.ListContainers:input_type -> flowdeploy.v1.ListContainersRequest
-	7,  // 5: flowdeploy.v1.AgentService.GetContainerLogs:input_type -> flowdeploy.v1.ContainerLogsRequest
-	8,  // 6: flowdeploy.v1.AgentService.GetContainerStats:input_type -> flowdeploy.v1.ContainerStatsRequest
-	9,  // 7: flowdeploy.v1.AgentService.RestartContainer:input_type -> flowdeploy.v1.RestartContainerRequest
-	10, // 8: flowdeploy.v1.AgentService.StopContainer:input_type -> flowdeploy.v1.StopContainerRequest
-	11, // 9: flowdeploy.v1.AgentService.GetSystemInfo:input_type -> google.protobuf.Empty
-	11, // 10: flowdeploy.v1.AgentService.GetSystemMetrics:input_type -> google.protobuf.Empty
-	11, // 11: flowdeploy.v1.AgentService.GetDockerInfo:input_type -> google.protobuf.Empty
-	12, // 12: flowdeploy.v1.AgentService.StartContainer:input_type -> flowdeploy.v1.StartContainerRequest
-	13, // 13: flowdeploy.v1.AgentService.ListImages:input_type -> flowdeploy.v1.ListImagesRequest
-	14, // 14: flowdeploy.v1.AgentService.RemoveImage:input_type -> flowdeploy.v1.RemoveImageRequest
-	15, // 15: flowdeploy.v1.AgentService.PruneImages:input_type -> flowdeploy.v1.PruneImagesRequest
-	16, // 16: flowdeploy.v1.AgentService.ListNetworks:input_type -> flowdeploy.v1.ListNetworksRequest
-	17, // 17: flowdeploy.v1.AgentService.CreateNetwork:input_type -> flowdeploy.v1.CreateNetworkRequest
-	18, // 18: flowdeploy.v1.AgentService.RemoveNetwork:input_type -> flowdeploy.v1.RemoveNetworkRequest
-	19, // 19: flowdeploy.v1.AgentService.ListVolumes:input_type -> flowdeploy.v1.ListVolumesRequest
-	20, // 20: flowdeploy.v1.AgentService.CreateVolume:input_type -> flowdeploy.v1.CreateVolumeRequest
-	21, // 21: flowdeploy.v1.AgentService.RemoveVolume:input_type -> flowdeploy.v1.RemoveVolumeRequest
-	22, // 22: flowdeploy.v1.AgentService.RemoveContainer:input_type -> flowdeploy.v1.RemoveContainerRequest
-	23, // 23: flowdeploy.v1.AgentService.UpdateDomains:input_type -> flowdeploy.v1.UpdateDomainsRequest
-	24, // 24: flowdeploy.v1.AgentService.ExecContainer:input_type -> flowdeploy.v1.ExecInput
-	0,  // 25: flowdeploy.v1.AgentService.PushUpdate:input_type -> flowdeploy.v1.UpdateBinaryChunk
-	25, // 26: flowdeploy.v1.AgentService.GetCertificates:input_type -> flowdeploy.v1.GetCertificatesRequest
-	26, // 27: flowdeploy.v1.AgentService.PruneContainers:input_type -> flowdeploy.v1.PruneContainersRequest
-	27, // 28: flowdeploy.v1.AgentService.PruneVolumes:input_type -> flowdeploy.v1.PruneVolumesRequest
-	28, // 29: flowdeploy.v1.AgentService.CreateContainerFromTemplate:input_type -> flowdeploy.v1.CreateContainerFromTemplateRequest
-	29, // 30: flowdeploy.v1.AgentService.ConfigureContainerSSL:input_type -> flowdeploy.v1.ConfigureContainerSSLRequest
-	30, // 31: flowdeploy.v1.AgentService.GetContainerSSLStatus:input_type -> flowdeploy.v1.GetContainerSSLStatusRequest
-	31, // 32: flowdeploy.v1.AgentService.Register:output_type -> flowdeploy.v1.RegisterResponse
-	32, // 33: flowdeploy.v1.AgentService.Heartbeat:output_type -> flowdeploy.v1.HeartbeatResponse
-	33, // 34: flowdeploy.v1.AgentService.ExecuteDeploy:output_type -> flowdeploy.v1.DeployResponse
-	34, // 35: flowdeploy.v1.AgentService.StreamDeployLogs:output_type -> flowdeploy.v1.DeployLogEntry
-	35, // 36: flowdeploy.v1.AgentService.ListContainers:output_type -> flowdeploy.v1.ListContainersResponse
-	36, // 37: flowdeploy.v1.AgentService.GetContainerLogs:output_type -> flowdeploy.v1.ContainerLogEntry
-	37, // 38: flowdeploy.v1.AgentService.GetContainerStats:output_type -> flowdeploy.v1.ContainerStats
-	38, // 39: flowdeploy.v1.AgentService.RestartContainer:output_type -> flowdeploy.v1.RestartContainerResponse
-	39, // 40: flowdeploy.v1.AgentService.StopContainer:output_type -> flowdeploy.v1.StopContainerResponse
-	40, // 41: flowdeploy.v1.AgentService.GetSystemInfo:output_type -> flowdeploy.v1.SystemInfo
-	41, // 42: flowdeploy.v1.AgentService.GetSystemMetrics:output_type -> flowdeploy.v1.SystemMetrics
-	42, // 43: flowdeploy.v1.AgentService.GetDockerInfo:output_type -> flowdeploy.v1.DockerInfo
-	43, // 44: flowdeploy.v1.AgentService.StartContainer:output_type -> flowdeploy.v1.StartContainerResponse
-	44, // 45: flowdeploy.v1.AgentService.ListImages:output_type -> flowdeploy.v1.ListImagesResponse
-	45, // 46: flowdeploy.v1.AgentService.RemoveImage:output_type -> flowdeploy.v1.RemoveImageResponse
-	46, // 47: flowdeploy.v1.AgentService.PruneImages:output_type -> flowdeploy.v1.PruneImagesResponse
-	47, // 48: flowdeploy.v1.AgentService.ListNetworks:output_type -> flowdeploy.v1.ListNetworksResponse
-	48, // 49: flowdeploy.v1.AgentService.CreateNetwork:output_type -> flowdeploy.v1.CreateNetworkResponse
-	49, // 50: flowdeploy.v1.AgentService.RemoveNetwork:output_type -> flowdeploy.v1.RemoveNetworkResponse
-	50, // 51: flowdeploy.v1.AgentService.ListVolumes:output_type -> flowdeploy.v1.ListVolumesResponse
-	51, // 52: flowdeploy.v1.AgentService.CreateVolume:output_type -> flowdeploy.v1.CreateVolumeResponse
-	52, // 53: flowdeploy.v1.AgentService.RemoveVolume:output_type -> flowdeploy.v1.RemoveVolumeResponse
-	53, // 54: flowdeploy.v1.AgentService.RemoveContainer:output_type -> flowdeploy.v1.RemoveContainerResponse
-	54, // 55: flowdeploy.v1.AgentService.UpdateDomains:output_type -> flowdeploy.v1.UpdateDomainsResponse
-	55, // 56: flowdeploy.v1.AgentService.ExecContainer:output_type -> flowdeploy.v1.ExecOutput
-	1,  // 57: flowdeploy.v1.AgentService.PushUpdate:output_type -> flowdeploy.v1.UpdateBinaryResponse
-	56, // 58: flowdeploy.v1.AgentService.GetCertificates:output_type -> flowdeploy.v1.GetCertificatesResponse
-	57, // 59: flowdeploy.v1.AgentService.PruneContainers:output_type -> flowdeploy.v1.PruneContainersResponse
-	58, // 60: flowdeploy.v1.AgentService.PruneVolumes:output_type -> flowdeploy.v1.PruneVolumesResponse
-	59, // 61: flowdeploy.v1.AgentService.CreateContainerFromTemplate:output_type -> flowdeploy.v1.CreateContainerFromTemplateResponse
-	60, // 62: flowdeploy.v1.AgentService.ConfigureContainerSSL:output_type -> flowdeploy.v1.ConfigureContainerSSLResponse
-	61, // 63: flowdeploy.v1.AgentService.GetContainerSSLStatus:output_type -> flowdeploy.v1.GetContainerSSLStatusResponse
-	32, // [32:64] is the sub-list for method output_type
-	0,  // [0:32] is the sub-list for method input_type
-	0,  // [0:0] is the sub-list for extension type_name
-	0,  // [0:0] is the sub-list for extension extendee
-	0,  // [0:0] is the sub-list for field type_name
+	2,   // 0: flowdeploy.v1.AgentService.Register:input_type -> flowdeploy.v1.RegisterRequest
+	3,   // 1: flowdeploy.v1.AgentService.Heartbeat:input_type -> flowdeploy.v1.HeartbeatRequest
+	4,   // 2: flowdeploy.v1.AgentService.ExecuteDeploy:input_type -> flowdeploy.v1.DeployRequest
+	5,   // 3: flowdeploy.v1.AgentService.StreamDeployLogs:input_type -> flowdeploy.v1.DeployLogSubscription
+	5,   // 4: flowdeploy.v1.AgentService.StreamDeployLogBatches:input_type -> flowdeploy.v1.DeployLogSubscription
+	6,   // 5: flowdeploy.v1.AgentService.ListContainers:input_type -> flowdeploy.v1.ListContainersRequest
+	7,   // 6: flowdeploy.v1.AgentService.GetContainerLogs:input_type -> flowdeploy.v1.ContainerLogsRequest
+	8,   // 7: flowdeploy.v1.AgentService.GetContainerStats:input_type -> flowdeploy.v1.ContainerStatsRequest
+	9,   // 8: flowdeploy.v1.AgentService.RestartContainer:input_type -> flowdeploy.v1.RestartContainerRequest
+	10,  // 9: flowdeploy.v1.AgentService.StopContainer:input_type -> flowdeploy.v1.StopContainerRequest
+	11,  // 10: flowdeploy.v1.AgentService.GetSystemInfo:input_type -> google.protobuf.Empty
+	11,  // 11: flowdeploy.v1.AgentService.GetSystemMetrics:input_type -> google.protobuf.Empty
+	11,  // 12: flowdeploy.v1.AgentService.GetDockerInfo:input_type -> google.protobuf.Empty
+	12,  // 13: flowdeploy.v1.AgentService.StartContainer:input_type -> flowdeploy.v1.StartContainerRequest
+	13,  // 14: flowdeploy.v1.AgentService.ListImages:input_type -> flowdeploy.v1.ListImagesRequest
+	14,  // 15: flowdeploy.v1.AgentService.RemoveImage:input_type -> flowdeploy.v1.RemoveImageRequest
+	15,  // 16: flowdeploy.v1.AgentService.PruneImages:input_type -> flowdeploy.v1.PruneImagesRequest
+	16,  // 17: flowdeploy.v1.AgentService.ListNetworks:input_type -> flowdeploy.v1.ListNetworksRequest
+	17,  // 18: flowdeploy.v1.AgentService.CreateNetwork:input_type -> flowdeploy.v1.CreateNetworkRequest
+	18,  // 19: flowdeploy.v1.AgentService.RemoveNetwork:input_type -> flowdeploy.v1.RemoveNetworkRequest
+	19,  // 20: flowdeploy.v1.AgentService.ListVolumes:input_type -> flowdeploy.v1.ListVolumesRequest
+	20,  // 21: flowdeploy.v1.AgentService.CreateVolume:input_type -> flowdeploy.v1.CreateVolumeRequest
+	21,  // 22: flowdeploy.v1.AgentService.RemoveVolume:input_type -> flowdeploy.v1.RemoveVolumeRequest
+	22,  // 23: flowdeploy.v1.AgentService.RemoveContainer:input_type -> flowdeploy.v1.RemoveContainerRequest
+	23,  // 24: flowdeploy.v1.AgentService.UpdateDomains:input_type -> flowdeploy.v1.UpdateDomainsRequest
+	24,  // 25: flowdeploy.v1.AgentService.ExecContainer:input_type -> flowdeploy.v1.ExecInput
+	0,   // 26: flowdeploy.v1.AgentService.PushUpdate:input_type -> flowdeploy.v1.UpdateBinaryChunk
+	25,  // 27: flowdeploy.v1.AgentService.GetCertificates:input_type -> flowdeploy.v1.GetCertificatesRequest
+	26,  // 28: flowdeploy.v1.AgentService.PruneContainers:input_type -> flowdeploy.v1.PruneContainersRequest
+	27,  // 29: flowdeploy.v1.AgentService.PruneVolumes:input_type -> flowdeploy.v1.PruneVolumesRequest
+	28,  // 30: flowdeploy.v1.AgentService.CreateContainerFromTemplate:input_type -> flowdeploy.v1.CreateContainerFromTemplateRequest
+	29,  // 31: flowdeploy.v1.AgentService.ConfigureContainerSSL:input_type -> flowdeploy.v1.ConfigureContainerSSLRequest
+	30,  // 32: flowdeploy.v1.AgentService.GetContainerSSLStatus:input_type -> flowdeploy.v1.GetContainerSSLStatusRequest
+	31,  // 33: flowdeploy.v1.AgentService.GetAgentLogs:input_type -> flowdeploy.v1.GetAgentLogsRequest
+	32,  // 34: flowdeploy.v1.AgentService.RotateAgentLogs:input_type -> flowdeploy.v1.RotateAgentLogsRequest
+	33,  // 35: flowdeploy.v1.AgentService.InstallCertificate:input_type -> flowdeploy.v1.InstallCertificateRequest
+	34,  // 36: flowdeploy.v1.AgentService.RemoveCertificate:input_type -> flowdeploy.v1.RemoveCertificateRequest
+	35,  // 37: flowdeploy.v1.AgentService.SetMaintenance:input_type -> flowdeploy.v1.SetMaintenanceRequest
+	36,  // 38: flowdeploy.v1.AgentService.ListAcmeCertificates:input_type -> flowdeploy.v1.ListAcmeCertificatesRequest
+	37,  // 39: flowdeploy.v1.AgentService.DeleteAcmeCertificates:input_type -> flowdeploy.v1.DeleteAcmeCertificatesRequest
+	38,  // 40: flowdeploy.v1.AgentService.ConfigureTunnel:input_type -> flowdeploy.v1.ConfigureTunnelRequest
+	39,  // 41: flowdeploy.v1.AgentService.RemoveTunnel:input_type -> flowdeploy.v1.RemoveTunnelRequest
+	40,  // 42: flowdeploy.v1.AgentService.GetAccessLogStats:input_type -> flowdeploy.v1.GetAccessLogStatsRequest
+	41,  // 43: flowdeploy.v1.AgentService.ReadComposeProject:input_type -> flowdeploy.v1.ReadComposeProjectRequest
+	42,  // 44: flowdeploy.v1.AgentService.GetMigrationSnapshot:input_type -> flowdeploy.v1.GetMigrationSnapshotRequest
+	43,  // 45: flowdeploy.v1.AgentService.CreateMigrationBackup:input_type -> flowdeploy.v1.CreateMigrationBackupRequest
+	44,  // 46: flowdeploy.v1.AgentService.MigrateContainer:input_type -> flowdeploy.v1.MigrateContainerRequest
+	45,  // 47: flowdeploy.v1.AgentService.StopNginx:input_type -> flowdeploy.v1.StopNginxRequest
+	46,  // 48: flowdeploy.v1.AgentService.ListContainerFiles:input_type -> flowdeploy.v1.ListContainerFilesRequest
+	47,  // 49: flowdeploy.v1.AgentService.DownloadContainerFile:input_type -> flowdeploy.v1.DownloadContainerFileRequest
+	48,  // 50: flowdeploy.v1.AgentService.UploadContainerFile:input_type -> flowdeploy.v1.ContainerFileChunk
+	49,  // 51: flowdeploy.v1.AgentService.GetContainerTop:input_type -> flowdeploy.v1.GetContainerTopRequest
+	50,  // 52: flowdeploy.v1.AgentService.InspectContainer:input_type -> flowdeploy.v1.InspectContainerRequest
+	51,  // 53: flowdeploy.v1.AgentService.CommitContainer:input_type -> flowdeploy.v1.CommitContainerRequest
+	52,  // 54: flowdeploy.v1.AgentService.ListVolumeFiles:input_type -> flowdeploy.v1.ListVolumeFilesRequest
+	53,  // 55: flowdeploy.v1.AgentService.StatVolumeFile:input_type -> flowdeploy.v1.StatVolumeFileRequest
+	54,  // 56: flowdeploy.v1.AgentService.DownloadVolumeFile:input_type -> flowdeploy.v1.DownloadVolumeFileRequest
+	55,  // 57: flowdeploy.v1.AgentService.RunServerTask:input_type -> flowdeploy.v1.RunServerTaskRequest
+	56,  // 58: flowdeploy.v1.AgentService.Register:output_type -> flowdeploy.v1.RegisterResponse
+	57,  // 59: flowdeploy.v1.AgentService.Heartbeat:output_type -> flowdeploy.v1.HeartbeatResponse
+	58,  // 60: flowdeploy.v1.AgentService.ExecuteDeploy:output_type -> flowdeploy.v1.DeployResponse
+	59,  // 61: flowdeploy.v1.AgentService.StreamDeployLogs:output_type -> flowdeploy.v1.DeployLogEntry
+	60,  // 62: flowdeploy.v1.AgentService.StreamDeployLogBatches:output_type -> flowdeploy.v1.DeployLogBatch
+	61,  // 63: flowdeploy.v1.AgentService.ListContainers:output_type -> flowdeploy.v1.ListContainersResponse
+	62,  // 64: flowdeploy.v1.AgentService.GetContainerLogs:output_type -> flowdeploy.v1.ContainerLogEntry
+	63,  // 65: flowdeploy.v1.AgentService.GetContainerStats:output_type -> flowdeploy.v1.ContainerStats
+	64,  // 66: flowdeploy.v1.AgentService.RestartContainer:output_type -> flowdeploy.v1.RestartContainerResponse
+	65,  // 67: flowdeploy.v1.AgentService.StopContainer:output_type -> flowdeploy.v1.StopContainerResponse
+	66,  // 68: flowdeploy.v1.AgentService.GetSystemInfo:output_type -> flowdeploy.v1.SystemInfo
+	67,  // 69: flowdeploy.v1.AgentService.GetSystemMetrics:output_type -> flowdeploy.v1.SystemMetrics
+	68,  // 70: flowdeploy.v1.AgentService.GetDockerInfo:output_type -> flowdeploy.v1.DockerInfo
+	69,  // 71: flowdeploy.v1.AgentService.StartContainer:output_type -> flowdeploy.v1.StartContainerResponse
+	70,  // 72: flowdeploy.v1.AgentService.ListImages:output_type -> flowdeploy.v1.ListImagesResponse
+	71,  // 73: flowdeploy.v1.AgentService.RemoveImage:output_type -> flowdeploy.v1.RemoveImageResponse
+	72,  // 74: flowdeploy.v1.AgentService.PruneImages:output_type -> flowdeploy.v1.PruneImagesResponse
+	73,  // 75: flowdeploy.v1.AgentService.ListNetworks:output_type -> flowdeploy.v1.ListNetworksResponse
+	74,  // 76: flowdeploy.v1.AgentService.CreateNetwork:output_type -> flowdeploy.v1.CreateNetworkResponse
+	75,  // 77: flowdeploy.v1.AgentService.RemoveNetwork:output_type -> flowdeploy.v1.RemoveNetworkResponse
+	76,  // 78: flowdeploy.v1.AgentService.ListVolumes:output_type -> flowdeploy.v1.ListVolumesResponse
+	77,  // 79: flowdeploy.v1.AgentService.CreateVolume:output_type -> flowdeploy.v1.CreateVolumeResponse
+	78,  // 80: flowdeploy.v1.AgentService.RemoveVolume:output_type -> flowdeploy.v1.RemoveVolumeResponse
+	79,  // 81: flowdeploy.v1.AgentService.RemoveContainer:output_type -> flowdeploy.v1.RemoveContainerResponse
+	80,  // 82: flowdeploy.v1.AgentService.UpdateDomains:output_type -> flowdeploy.v1.UpdateDomainsResponse
+	81,  // 83: flowdeploy.v1.AgentService.ExecContainer:output_type -> flowdeploy.v1.ExecOutput
+	1,   // 84: flowdeploy.v1.AgentService.PushUpdate:output_type -> flowdeploy.v1.UpdateBinaryResponse
+	82,  // 85: flowdeploy.v1.AgentService.GetCertificates:output_type -> flowdeploy.v1.GetCertificatesResponse
+	83,  // 86: flowdeploy.v1.AgentService.PruneContainers:output_type -> flowdeploy.v1.PruneContainersResponse
+	84,  // 87: flowdeploy.v1.AgentService.PruneVolumes:output_type -> flowdeploy.v1.PruneVolumesResponse
+	85,  // 88: flowdeploy.v1.AgentService.CreateContainerFromTemplate:output_type -> flowdeploy.v1.CreateContainerFromTemplateResponse
+	86,  // 89: flowdeploy.v1.AgentService.ConfigureContainerSSL:output_type -> flowdeploy.v1.ConfigureContainerSSLResponse
+	87,  // 90: flowdeploy.v1.AgentService.GetContainerSSLStatus:output_type -> flowdeploy.v1.GetContainerSSLStatusResponse
+	88,  // 91: flowdeploy.v1.AgentService.GetAgentLogs:output_type -> flowdeploy.v1.GetAgentLogsResponse
+	89,  // 92: flowdeploy.v1.AgentService.RotateAgentLogs:output_type -> flowdeploy.v1.RotateAgentLogsResponse
+	90,  // 93: flowdeploy.v1.AgentService.InstallCertificate:output_type -> flowdeploy.v1.InstallCertificateResponse
+	91,  // 94: flowdeploy.v1.AgentService.RemoveCertificate:output_type -> flowdeploy.v1.RemoveCertificateResponse
+	92,  // 95: flowdeploy.v1.AgentService.SetMaintenance:output_type -> flowdeploy.v1.SetMaintenanceResponse
+	93,  // 96: flowdeploy.v1.AgentService.ListAcmeCertificates:output_type -> flowdeploy.v1.ListAcmeCertificatesResponse
+	94,  // 97: flowdeploy.v1.AgentService.DeleteAcmeCertificates:output_type -> flowdeploy.v1.DeleteAcmeCertificatesResponse
+	95,  // 98: flowdeploy.v1.AgentService.ConfigureTunnel:output_type -> flowdeploy.v1.ConfigureTunnelResponse
+	96,  // 99: flowdeploy.v1.AgentService.RemoveTunnel:output_type -> flowdeploy.v1.RemoveTunnelResponse
+	97,  // 100: flowdeploy.v1.AgentService.GetAccessLogStats:output_type -> flowdeploy.v1.GetAccessLogStatsResponse
+	98,  // 101: flowdeploy.v1.AgentService.ReadComposeProject:output_type -> flowdeploy.v1.ReadComposeProjectResponse
+	99,  // 102: flowdeploy.v1.AgentService.GetMigrationSnapshot:output_type -> flowdeploy.v1.GetMigrationSnapshotResponse
+	100, // 103: flowdeploy.v1.AgentService.CreateMigrationBackup:output_type -> flowdeploy.v1.CreateMigrationBackupResponse
+	101, // 104: flowdeploy.v1.AgentService.MigrateContainer:output_type -> flowdeploy.v1.MigrateContainerResponse
+	102, // 105: flowdeploy.v1.AgentService.StopNginx:output_type -> flowdeploy.v1.StopNginxResponse
+	103, // 106: flowdeploy.v1.AgentService.ListContainerFiles:output_type -> flowdeploy.v1.ListContainerFilesResponse
+	48,  // 107: flowdeploy.v1.AgentService.DownloadContainerFile:output_type -> flowdeploy.v1.ContainerFileChunk
+	104, // 108: flowdeploy.v1.AgentService.UploadContainerFile:output_type -> flowdeploy.v1.UploadContainerFileResponse
+	105, // 109: flowdeploy.v1.AgentService.GetContainerTop:output_type -> flowdeploy.v1.GetContainerTopResponse
+	106, // 110: flowdeploy.v1.AgentService.InspectContainer:output_type -> flowdeploy.v1.InspectContainerResponse
+	107, // 111: flowdeploy.v1.AgentService.CommitContainer:output_type -> flowdeploy.v1.CommitContainerResponse
+	108, // 112: flowdeploy.v1.AgentService.ListVolumeFiles:output_type -> flowdeploy.v1.ListVolumeFilesResponse
+	109, // 113: flowdeploy.v1.AgentService.StatVolumeFile:output_type -> flowdeploy.v1.StatVolumeFileResponse
+	48,  // 114: flowdeploy.v1.AgentService.DownloadVolumeFile:output_type -> flowdeploy.v1.ContainerFileChunk
+	110, // 115: flowdeploy.v1.AgentService.RunServerTask:output_type -> flowdeploy.v1.RunServerTaskResponse
+	58,  // [58:116] is the sub-list for method output_type
+	0,   // [0:58] is the sub-list for method input_type
+	0,   // [0:0] is the sub-list for extension type_name
+	0,   // [0:0] is the sub-list for extension extendee
+	0,   // [0:0] is the sub-list for field type_name
 }
 
 func init() { file_flowdeploy_v1_agent_proto_init() }
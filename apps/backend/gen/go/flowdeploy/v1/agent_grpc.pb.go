@@ -24,6 +24,7 @@ const (
 	AgentService_Heartbeat_FullMethodName                   = "/flowdeploy.v1.AgentService/Heartbeat"
 	AgentService_ExecuteDeploy_FullMethodName               = "/flowdeploy.v1.AgentService/ExecuteDeploy"
 	AgentService_StreamDeployLogs_FullMethodName            = "/flowdeploy.v1.AgentService/StreamDeployLogs"
+	AgentService_StreamDeployLogBatches_FullMethodName      = "/flowdeploy.v1.AgentService/StreamDeployLogBatches"
 	AgentService_ListContainers_FullMethodName              = "/flowdeploy.v1.AgentService/ListContainers"
 	AgentService_GetContainerLogs_FullMethodName            = "/flowdeploy.v1.AgentService/GetContainerLogs"
 	AgentService_GetContainerStats_FullMethodName           = "/flowdeploy.v1.AgentService/GetContainerStats"
@@ -52,6 +53,31 @@ const (
 	AgentService_CreateContainerFromTemplate_FullMethodName = "/flowdeploy.v1.AgentService/CreateContainerFromTemplate"
 	AgentService_ConfigureContainerSSL_FullMethodName       = "/flowdeploy.v1.AgentService/ConfigureContainerSSL"
 	AgentService_GetContainerSSLStatus_FullMethodName       = "/flowdeploy.v1.AgentService/GetContainerSSLStatus"
+	AgentService_GetAgentLogs_FullMethodName                = "/flowdeploy.v1.AgentService/GetAgentLogs"
+	AgentService_RotateAgentLogs_FullMethodName             = "/flowdeploy.v1.AgentService/RotateAgentLogs"
+	AgentService_InstallCertificate_FullMethodName          = "/flowdeploy.v1.AgentService/InstallCertificate"
+	AgentService_RemoveCertificate_FullMethodName           = "/flowdeploy.v1.AgentService/RemoveCertificate"
+	AgentService_SetMaintenance_FullMethodName              = "/flowdeploy.v1.AgentService/SetMaintenance"
+	AgentService_ListAcmeCertificates_FullMethodName        = "/flowdeploy.v1.AgentService/ListAcmeCertificates"
+	AgentService_DeleteAcmeCertificates_FullMethodName      = "/flowdeploy.v1.AgentService/DeleteAcmeCertificates"
+	AgentService_ConfigureTunnel_FullMethodName             = "/flowdeploy.v1.AgentService/ConfigureTunnel"
+	AgentService_RemoveTunnel_FullMethodName                = "/flowdeploy.v1.AgentService/RemoveTunnel"
+	AgentService_GetAccessLogStats_FullMethodName           = "/flowdeploy.v1.AgentService/GetAccessLogStats"
+	AgentService_ReadComposeProject_FullMethodName          = "/flowdeploy.v1.AgentService/ReadComposeProject"
+	AgentService_GetMigrationSnapshot_FullMethodName        = "/flowdeploy.v1.AgentService/GetMigrationSnapshot"
+	AgentService_CreateMigrationBackup_FullMethodName       = "/flowdeploy.v1.AgentService/CreateMigrationBackup"
+	AgentService_MigrateContainer_FullMethodName            = "/flowdeploy.v1.AgentService/MigrateContainer"
+	AgentService_StopNginx_FullMethodName                   = "/flowdeploy.v1.AgentService/StopNginx"
+	AgentService_ListContainerFiles_FullMethodName          = "/flowdeploy.v1.AgentService/ListContainerFiles"
+	AgentService_DownloadContainerFile_FullMethodName       = "/flowdeploy.v1.AgentService/DownloadContainerFile"
+	AgentService_UploadContainerFile_FullMethodName         = "/flowdeploy.v1.AgentService/UploadContainerFile"
+	AgentService_GetContainerTop_FullMethodName             = "/flowdeploy.v1.AgentService/GetContainerTop"
+	AgentService_InspectContainer_FullMethodName            = "/flowdeploy.v1.AgentService/InspectContainer"
+	AgentService_CommitContainer_FullMethodName             = "/flowdeploy.v1.AgentService/CommitContainer"
+	AgentService_ListVolumeFiles_FullMethodName             = "/flowdeploy.v1.AgentService/ListVolumeFiles"
+	AgentService_StatVolumeFile_FullMethodName              = "/flowdeploy.v1.AgentService/StatVolumeFile"
+	AgentService_DownloadVolumeFile_FullMethodName          = "/flowdeploy.v1.AgentService/DownloadVolumeFile"
+	AgentService_RunServerTask_FullMethodName               = "/flowdeploy.v1.AgentService/RunServerTask"
 )
 
 // AgentServiceClient is the client API for AgentService service.
@@ -62,6 +88,7 @@ type AgentServiceClient interface {
 	Heartbeat(ctx context.Context, in *HeartbeatRequest, opts ...grpc.CallOption) (*HeartbeatResponse, error)
 	ExecuteDeploy(ctx context.Context, in *DeployRequest, opts ...grpc.CallOption) (*DeployResponse, error)
 	StreamDeployLogs(ctx context.Context, in *DeployLogSubscription, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DeployLogEntry], error)
+	StreamDeployLogBatches(ctx context.Context, in *DeployLogSubscription, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DeployLogBatch], error)
 	ListContainers(ctx context.Context, in *ListContainersRequest, opts ...grpc.CallOption) (*ListContainersResponse, error)
 	GetContainerLogs(ctx context.Context, in *ContainerLogsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ContainerLogEntry], error)
 	GetContainerStats(ctx context.Context, in *ContainerStatsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ContainerStats], error)
@@ -90,6 +117,31 @@ type AgentServiceClient interface {
 	CreateContainerFromTemplate(ctx context.Context, in *CreateContainerFromTemplateRequest, opts ...grpc.CallOption) (*CreateContainerFromTemplateResponse, error)
 	ConfigureContainerSSL(ctx context.Context, in *ConfigureContainerSSLRequest, opts ...grpc.CallOption) (*ConfigureContainerSSLResponse, error)
 	GetContainerSSLStatus(ctx context.Context, in *GetContainerSSLStatusRequest, opts ...grpc.CallOption) (*GetContainerSSLStatusResponse, error)
+	GetAgentLogs(ctx context.Context, in *GetAgentLogsRequest, opts ...grpc.CallOption) (*GetAgentLogsResponse, error)
+	RotateAgentLogs(ctx context.Context, in *RotateAgentLogsRequest, opts ...grpc.CallOption) (*RotateAgentLogsResponse, error)
+	InstallCertificate(ctx context.Context, in *InstallCertificateRequest, opts ...grpc.CallOption) (*InstallCertificateResponse, error)
+	RemoveCertificate(ctx context.Context, in *RemoveCertificateRequest, opts ...grpc.CallOption) (*RemoveCertificateResponse, error)
+	SetMaintenance(ctx context.Context, in *SetMaintenanceRequest, opts ...grpc.CallOption) (*SetMaintenanceResponse, error)
+	ListAcmeCertificates(ctx context.Context, in *ListAcmeCertificatesRequest, opts ...grpc.CallOption) (*ListAcmeCertificatesResponse, error)
+	DeleteAcmeCertificates(ctx context.Context, in *DeleteAcmeCertificatesRequest, opts ...grpc.CallOption) (*DeleteAcmeCertificatesResponse, error)
+	ConfigureTunnel(ctx context.Context, in *ConfigureTunnelRequest, opts ...grpc.CallOption) (*ConfigureTunnelResponse, error)
+	RemoveTunnel(ctx context.Context, in *RemoveTunnelRequest, opts ...grpc.CallOption) (*RemoveTunnelResponse, error)
+	GetAccessLogStats(ctx context.Context, in *GetAccessLogStatsRequest, opts ...grpc.CallOption) (*GetAccessLogStatsResponse, error)
+	ReadComposeProject(ctx context.Context, in *ReadComposeProjectRequest, opts ...grpc.CallOption) (*ReadComposeProjectResponse, error)
+	GetMigrationSnapshot(ctx context.Context, in *GetMigrationSnapshotRequest, opts ...grpc.CallOption) (*GetMigrationSnapshotResponse, error)
+	CreateMigrationBackup(ctx context.Context, in *CreateMigrationBackupRequest, opts ...grpc.CallOption) (*CreateMigrationBackupResponse, error)
+	MigrateContainer(ctx context.Context, in *MigrateContainerRequest, opts ...grpc.CallOption) (*MigrateContainerResponse, error)
+	StopNginx(ctx context.Context, in *StopNginxRequest, opts ...grpc.CallOption) (*StopNginxResponse, error)
+	ListContainerFiles(ctx context.Context, in *ListContainerFilesRequest, opts ...grpc.CallOption) (*ListContainerFilesResponse, error)
+	DownloadContainerFile(ctx context.Context, in *DownloadContainerFileRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ContainerFileChunk], error)
+	UploadContainerFile(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ContainerFileChunk, UploadContainerFileResponse], error)
+	GetContainerTop(ctx context.Context, in *GetContainerTopRequest, opts ...grpc.CallOption) (*GetContainerTopResponse, error)
+	InspectContainer(ctx context.Context, in *InspectContainerRequest, opts ...grpc.CallOption) (*InspectContainerResponse, error)
+	CommitContainer(ctx context.Context, in *CommitContainerRequest, opts ...grpc.CallOption) (*CommitContainerResponse, error)
+	ListVolumeFiles(ctx context.Context, in *ListVolumeFilesRequest, opts ...grpc.CallOption) (*ListVolumeFilesResponse, error)
+	StatVolumeFile(ctx context.Context, in *StatVolumeFileRequest, opts ...grpc.CallOption) (*StatVolumeFileResponse, error)
+	DownloadVolumeFile(ctx context.Context, in *DownloadVolumeFileRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ContainerFileChunk], error)
+	RunServerTask(ctx context.Context, in *RunServerTaskRequest, opts ...grpc.CallOption) (*RunServerTaskResponse, error)
 }
 
 type agentServiceClient struct {
@@ -149,6 +201,25 @@ func (c *agentServiceClient) StreamDeployLogs(ctx context.Context, in *DeployLog
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type AgentService_StreamDeployLogsClient = grpc.ServerStreamingClient[DeployLogEntry]
 
+func (c *agentServiceClient) StreamDeployLogBatches(ctx context.Context, in *DeployLogSubscription, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DeployLogBatch], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &AgentService_ServiceDesc.Streams[1], AgentService_StreamDeployLogBatches_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[DeployLogSubscription, DeployLogBatch]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AgentService_StreamDeployLogBatchesClient = grpc.ServerStreamingClient[DeployLogBatch]
+
 func (c *agentServiceClient) ListContainers(ctx context.Context, in *ListContainersRequest, opts ...grpc.CallOption) (*ListContainersResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListContainersResponse)
@@ -161,7 +232,7 @@ func (c *agentServiceClient) ListContainers(ctx context.Context, in *ListContain
 
 func (c *agentServiceClient) GetContainerLogs(ctx context.Context, in *ContainerLogsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ContainerLogEntry], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &AgentService_ServiceDesc.Streams[1], AgentService_GetContainerLogs_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &AgentService_ServiceDesc.Streams[2], AgentService_GetContainerLogs_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -180,7 +251,7 @@ type AgentService_GetContainerLogsClient = grpc.ServerStreamingClient[ContainerL
 
 func (c *agentServiceClient) GetContainerStats(ctx context.Context, in *ContainerStatsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ContainerStats], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &AgentService_ServiceDesc.Streams[2], AgentService_GetContainerStats_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &AgentService_ServiceDesc.Streams[3], AgentService_GetContainerStats_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -369,7 +440,7 @@ func (c *agentServiceClient) UpdateDomains(ctx context.Context, in *UpdateDomain
 
 func (c *agentServiceClient) ExecContainer(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[ExecInput, ExecOutput], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &AgentService_ServiceDesc.Streams[3], AgentService_ExecContainer_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &AgentService_ServiceDesc.Streams[4], AgentService_ExecContainer_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -382,7 +453,7 @@ type AgentService_ExecContainerClient = grpc.BidiStreamingClient[ExecInput, Exec
 
 func (c *agentServiceClient) PushUpdate(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UpdateBinaryChunk, UpdateBinaryResponse], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &AgentService_ServiceDesc.Streams[4], AgentService_PushUpdate_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &AgentService_ServiceDesc.Streams[5], AgentService_PushUpdate_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -453,6 +524,277 @@ func (c *agentServiceClient) GetContainerSSLStatus(ctx context.Context, in *GetC
 	return out, nil
 }
 
+func (c *agentServiceClient) GetAgentLogs(ctx context.Context, in *GetAgentLogsRequest, opts ...grpc.CallOption) (*GetAgentLogsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAgentLogsResponse)
+	err := c.cc.Invoke(ctx, AgentService_GetAgentLogs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentServiceClient) RotateAgentLogs(ctx context.Context, in *RotateAgentLogsRequest, opts ...grpc.CallOption) (*RotateAgentLogsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RotateAgentLogsResponse)
+	err := c.cc.Invoke(ctx, AgentService_RotateAgentLogs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentServiceClient) InstallCertificate(ctx context.Context, in *InstallCertificateRequest, opts ...grpc.CallOption) (*InstallCertificateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(InstallCertificateResponse)
+	err := c.cc.Invoke(ctx, AgentService_InstallCertificate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentServiceClient) RemoveCertificate(ctx context.Context, in *RemoveCertificateRequest, opts ...grpc.CallOption) (*RemoveCertificateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveCertificateResponse)
+	err := c.cc.Invoke(ctx, AgentService_RemoveCertificate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentServiceClient) SetMaintenance(ctx context.Context, in *SetMaintenanceRequest, opts ...grpc.CallOption) (*SetMaintenanceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetMaintenanceResponse)
+	err := c.cc.Invoke(ctx, AgentService_SetMaintenance_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentServiceClient) ListAcmeCertificates(ctx context.Context, in *ListAcmeCertificatesRequest, opts ...grpc.CallOption) (*ListAcmeCertificatesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAcmeCertificatesResponse)
+	err := c.cc.Invoke(ctx, AgentService_ListAcmeCertificates_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentServiceClient) DeleteAcmeCertificates(ctx context.Context, in *DeleteAcmeCertificatesRequest, opts ...grpc.CallOption) (*DeleteAcmeCertificatesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteAcmeCertificatesResponse)
+	err := c.cc.Invoke(ctx, AgentService_DeleteAcmeCertificates_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentServiceClient) ConfigureTunnel(ctx context.Context, in *ConfigureTunnelRequest, opts ...grpc.CallOption) (*ConfigureTunnelResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConfigureTunnelResponse)
+	err := c.cc.Invoke(ctx, AgentService_ConfigureTunnel_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentServiceClient) RemoveTunnel(ctx context.Context, in *RemoveTunnelRequest, opts ...grpc.CallOption) (*RemoveTunnelResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveTunnelResponse)
+	err := c.cc.Invoke(ctx, AgentService_RemoveTunnel_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentServiceClient) GetAccessLogStats(ctx context.Context, in *GetAccessLogStatsRequest, opts ...grpc.CallOption) (*GetAccessLogStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAccessLogStatsResponse)
+	err := c.cc.Invoke(ctx, AgentService_GetAccessLogStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentServiceClient) ReadComposeProject(ctx context.Context, in *ReadComposeProjectRequest, opts ...grpc.CallOption) (*ReadComposeProjectResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReadComposeProjectResponse)
+	err := c.cc.Invoke(ctx, AgentService_ReadComposeProject_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentServiceClient) GetMigrationSnapshot(ctx context.Context, in *GetMigrationSnapshotRequest, opts ...grpc.CallOption) (*GetMigrationSnapshotResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetMigrationSnapshotResponse)
+	err := c.cc.Invoke(ctx, AgentService_GetMigrationSnapshot_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentServiceClient) CreateMigrationBackup(ctx context.Context, in *CreateMigrationBackupRequest, opts ...grpc.CallOption) (*CreateMigrationBackupResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateMigrationBackupResponse)
+	err := c.cc.Invoke(ctx, AgentService_CreateMigrationBackup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentServiceClient) MigrateContainer(ctx context.Context, in *MigrateContainerRequest, opts ...grpc.CallOption) (*MigrateContainerResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MigrateContainerResponse)
+	err := c.cc.Invoke(ctx, AgentService_MigrateContainer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentServiceClient) StopNginx(ctx context.Context, in *StopNginxRequest, opts ...grpc.CallOption) (*StopNginxResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StopNginxResponse)
+	err := c.cc.Invoke(ctx, AgentService_StopNginx_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentServiceClient) ListContainerFiles(ctx context.Context, in *ListContainerFilesRequest, opts ...grpc.CallOption) (*ListContainerFilesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListContainerFilesResponse)
+	err := c.cc.Invoke(ctx, AgentService_ListContainerFiles_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentServiceClient) DownloadContainerFile(ctx context.Context, in *DownloadContainerFileRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ContainerFileChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &AgentService_ServiceDesc.Streams[6], AgentService_DownloadContainerFile_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[DownloadContainerFileRequest, ContainerFileChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AgentService_DownloadContainerFileClient = grpc.ServerStreamingClient[ContainerFileChunk]
+
+func (c *agentServiceClient) UploadContainerFile(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ContainerFileChunk, UploadContainerFileResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &AgentService_ServiceDesc.Streams[7], AgentService_UploadContainerFile_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ContainerFileChunk, UploadContainerFileResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AgentService_UploadContainerFileClient = grpc.ClientStreamingClient[ContainerFileChunk, UploadContainerFileResponse]
+
+func (c *agentServiceClient) GetContainerTop(ctx context.Context, in *GetContainerTopRequest, opts ...grpc.CallOption) (*GetContainerTopResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetContainerTopResponse)
+	err := c.cc.Invoke(ctx, AgentService_GetContainerTop_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentServiceClient) InspectContainer(ctx context.Context, in *InspectContainerRequest, opts ...grpc.CallOption) (*InspectContainerResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(InspectContainerResponse)
+	err := c.cc.Invoke(ctx, AgentService_InspectContainer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentServiceClient) CommitContainer(ctx context.Context, in *CommitContainerRequest, opts ...grpc.CallOption) (*CommitContainerResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CommitContainerResponse)
+	err := c.cc.Invoke(ctx, AgentService_CommitContainer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentServiceClient) ListVolumeFiles(ctx context.Context, in *ListVolumeFilesRequest, opts ...grpc.CallOption) (*ListVolumeFilesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListVolumeFilesResponse)
+	err := c.cc.Invoke(ctx, AgentService_ListVolumeFiles_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentServiceClient) StatVolumeFile(ctx context.Context, in *StatVolumeFileRequest, opts ...grpc.CallOption) (*StatVolumeFileResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StatVolumeFileResponse)
+	err := c.cc.Invoke(ctx, AgentService_StatVolumeFile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentServiceClient) DownloadVolumeFile(ctx context.Context, in *DownloadVolumeFileRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ContainerFileChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &AgentService_ServiceDesc.Streams[8], AgentService_DownloadVolumeFile_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[DownloadVolumeFileRequest, ContainerFileChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AgentService_DownloadVolumeFileClient = grpc.ServerStreamingClient[ContainerFileChunk]
+
+func (c *agentServiceClient) RunServerTask(ctx context.Context, in *RunServerTaskRequest, opts ...grpc.CallOption) (*RunServerTaskResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RunServerTaskResponse)
+	err := c.cc.Invoke(ctx, AgentService_RunServerTask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AgentServiceServer is the server API for AgentService service.
 // All implementations must embed UnimplementedAgentServiceServer
 // for forward compatibility.
@@ -461,6 +803,7 @@ type AgentServiceServer interface {
 	Heartbeat(context.Context, *HeartbeatRequest) (*HeartbeatResponse, error)
 	ExecuteDeploy(context.Context, *DeployRequest) (*DeployResponse, error)
 	StreamDeployLogs(*DeployLogSubscription, grpc.ServerStreamingServer[DeployLogEntry]) error
+	StreamDeployLogBatches(*DeployLogSubscription, grpc.ServerStreamingServer[DeployLogBatch]) error
 	ListContainers(context.Context, *ListContainersRequest) (*ListContainersResponse, error)
 	GetContainerLogs(*ContainerLogsRequest, grpc.ServerStreamingServer[ContainerLogEntry]) error
 	GetContainerStats(*ContainerStatsRequest, grpc.ServerStreamingServer[ContainerStats]) error
@@ -489,6 +832,31 @@ type AgentServiceServer interface {
 	CreateContainerFromTemplate(context.Context, *CreateContainerFromTemplateRequest) (*CreateContainerFromTemplateResponse, error)
 	ConfigureContainerSSL(context.Context, *ConfigureContainerSSLRequest) (*ConfigureContainerSSLResponse, error)
 	GetContainerSSLStatus(context.Context, *GetContainerSSLStatusRequest) (*GetContainerSSLStatusResponse, error)
+	GetAgentLogs(context.Context, *GetAgentLogsRequest) (*GetAgentLogsResponse, error)
+	RotateAgentLogs(context.Context, *RotateAgentLogsRequest) (*RotateAgentLogsResponse, error)
+	InstallCertificate(context.Context, *InstallCertificateRequest) (*InstallCertificateResponse, error)
+	RemoveCertificate(context.Context, *RemoveCertificateRequest) (*RemoveCertificateResponse, error)
+	SetMaintenance(context.Context, *SetMaintenanceRequest) (*SetMaintenanceResponse, error)
+	ListAcmeCertificates(context.Context, *ListAcmeCertificatesRequest) (*ListAcmeCertificatesResponse, error)
+	DeleteAcmeCertificates(context.Context, *DeleteAcmeCertificatesRequest) (*DeleteAcmeCertificatesResponse, error)
+	ConfigureTunnel(context.Context, *ConfigureTunnelRequest) (*ConfigureTunnelResponse, error)
+	RemoveTunnel(context.Context, *RemoveTunnelRequest) (*RemoveTunnelResponse, error)
+	GetAccessLogStats(context.Context, *GetAccessLogStatsRequest) (*GetAccessLogStatsResponse, error)
+	ReadComposeProject(context.Context, *ReadComposeProjectRequest) (*ReadComposeProjectResponse, error)
+	GetMigrationSnapshot(context.Context, *GetMigrationSnapshotRequest) (*GetMigrationSnapshotResponse, error)
+	CreateMigrationBackup(context.Context, *CreateMigrationBackupRequest) (*CreateMigrationBackupResponse, error)
+	MigrateContainer(context.Context, *MigrateContainerRequest) (*MigrateContainerResponse, error)
+	StopNginx(context.Context, *StopNginxRequest) (*StopNginxResponse, error)
+	ListContainerFiles(context.Context, *ListContainerFilesRequest) (*ListContainerFilesResponse, error)
+	DownloadContainerFile(*DownloadContainerFileRequest, grpc.ServerStreamingServer[ContainerFileChunk]) error
+	UploadContainerFile(grpc.ClientStreamingServer[ContainerFileChunk, UploadContainerFileResponse]) error
+	GetContainerTop(context.Context, *GetContainerTopRequest) (*GetContainerTopResponse, error)
+	InspectContainer(context.Context, *InspectContainerRequest) (*InspectContainerResponse, error)
+	CommitContainer(context.Context, *CommitContainerRequest) (*CommitContainerResponse, error)
+	ListVolumeFiles(context.Context, *ListVolumeFilesRequest) (*ListVolumeFilesResponse, error)
+	StatVolumeFile(context.Context, *StatVolumeFileRequest) (*StatVolumeFileResponse, error)
+	DownloadVolumeFile(*DownloadVolumeFileRequest, grpc.ServerStreamingServer[ContainerFileChunk]) error
+	RunServerTask(context.Context, *RunServerTaskRequest) (*RunServerTaskResponse, error)
 	mustEmbedUnimplementedAgentServiceServer()
 }
 
@@ -511,6 +879,9 @@ func (UnimplementedAgentServiceServer) ExecuteDeploy(context.Context, *DeployReq
 func (UnimplementedAgentServiceServer) StreamDeployLogs(*DeployLogSubscription, grpc.ServerStreamingServer[DeployLogEntry]) error {
 	return status.Error(codes.Unimplemented, "method StreamDeployLogs not implemented")
 }
+func (UnimplementedAgentServiceServer) StreamDeployLogBatches(*DeployLogSubscription, grpc.ServerStreamingServer[DeployLogBatch]) error {
+	return status.Error(codes.Unimplemented, "method StreamDeployLogBatches not implemented")
+}
 func (UnimplementedAgentServiceServer) ListContainers(context.Context, *ListContainersRequest) (*ListContainersResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ListContainers not implemented")
 }
@@ -595,6 +966,81 @@ func (UnimplementedAgentServiceServer) ConfigureContainerSSL(context.Context, *C
 func (UnimplementedAgentServiceServer) GetContainerSSLStatus(context.Context, *GetContainerSSLStatusRequest) (*GetContainerSSLStatusResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetContainerSSLStatus not implemented")
 }
+func (UnimplementedAgentServiceServer) GetAgentLogs(context.Context, *GetAgentLogsRequest) (*GetAgentLogsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetAgentLogs not implemented")
+}
+func (UnimplementedAgentServiceServer) RotateAgentLogs(context.Context, *RotateAgentLogsRequest) (*RotateAgentLogsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RotateAgentLogs not implemented")
+}
+func (UnimplementedAgentServiceServer) InstallCertificate(context.Context, *InstallCertificateRequest) (*InstallCertificateResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method InstallCertificate not implemented")
+}
+func (UnimplementedAgentServiceServer) RemoveCertificate(context.Context, *RemoveCertificateRequest) (*RemoveCertificateResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RemoveCertificate not implemented")
+}
+func (UnimplementedAgentServiceServer) SetMaintenance(context.Context, *SetMaintenanceRequest) (*SetMaintenanceResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetMaintenance not implemented")
+}
+func (UnimplementedAgentServiceServer) ListAcmeCertificates(context.Context, *ListAcmeCertificatesRequest) (*ListAcmeCertificatesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListAcmeCertificates not implemented")
+}
+func (UnimplementedAgentServiceServer) DeleteAcmeCertificates(context.Context, *DeleteAcmeCertificatesRequest) (*DeleteAcmeCertificatesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteAcmeCertificates not implemented")
+}
+func (UnimplementedAgentServiceServer) ConfigureTunnel(context.Context, *ConfigureTunnelRequest) (*ConfigureTunnelResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ConfigureTunnel not implemented")
+}
+func (UnimplementedAgentServiceServer) RemoveTunnel(context.Context, *RemoveTunnelRequest) (*RemoveTunnelResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RemoveTunnel not implemented")
+}
+func (UnimplementedAgentServiceServer) GetAccessLogStats(context.Context, *GetAccessLogStatsRequest) (*GetAccessLogStatsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetAccessLogStats not implemented")
+}
+func (UnimplementedAgentServiceServer) ReadComposeProject(context.Context, *ReadComposeProjectRequest) (*ReadComposeProjectResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReadComposeProject not implemented")
+}
+func (UnimplementedAgentServiceServer) GetMigrationSnapshot(context.Context, *GetMigrationSnapshotRequest) (*GetMigrationSnapshotResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetMigrationSnapshot not implemented")
+}
+func (UnimplementedAgentServiceServer) CreateMigrationBackup(context.Context, *CreateMigrationBackupRequest) (*CreateMigrationBackupResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateMigrationBackup not implemented")
+}
+func (UnimplementedAgentServiceServer) MigrateContainer(context.Context, *MigrateContainerRequest) (*MigrateContainerResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method MigrateContainer not implemented")
+}
+func (UnimplementedAgentServiceServer) StopNginx(context.Context, *StopNginxRequest) (*StopNginxResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method StopNginx not implemented")
+}
+func (UnimplementedAgentServiceServer) ListContainerFiles(context.Context, *ListContainerFilesRequest) (*ListContainerFilesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListContainerFiles not implemented")
+}
+func (UnimplementedAgentServiceServer) DownloadContainerFile(*DownloadContainerFileRequest, grpc.ServerStreamingServer[ContainerFileChunk]) error {
+	return status.Error(codes.Unimplemented, "method DownloadContainerFile not implemented")
+}
+func (UnimplementedAgentServiceServer) UploadContainerFile(grpc.ClientStreamingServer[ContainerFileChunk, UploadContainerFileResponse]) error {
+	return status.Error(codes.Unimplemented, "method UploadContainerFile not implemented")
+}
+func (UnimplementedAgentServiceServer) GetContainerTop(context.Context, *GetContainerTopRequest) (*GetContainerTopResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetContainerTop not implemented")
+}
+func (UnimplementedAgentServiceServer) InspectContainer(context.Context, *InspectContainerRequest) (*InspectContainerResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method InspectContainer not implemented")
+}
+func (UnimplementedAgentServiceServer) CommitContainer(context.Context, *CommitContainerRequest) (*CommitContainerResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CommitContainer not implemented")
+}
+func (UnimplementedAgentServiceServer) ListVolumeFiles(context.Context, *ListVolumeFilesRequest) (*ListVolumeFilesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListVolumeFiles not implemented")
+}
+func (UnimplementedAgentServiceServer) StatVolumeFile(context.Context, *StatVolumeFileRequest) (*StatVolumeFileResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method StatVolumeFile not implemented")
+}
+func (UnimplementedAgentServiceServer) DownloadVolumeFile(*DownloadVolumeFileRequest, grpc.ServerStreamingServer[ContainerFileChunk]) error {
+	return status.Error(codes.Unimplemented, "method DownloadVolumeFile not implemented")
+}
+func (UnimplementedAgentServiceServer) RunServerTask(context.Context, *RunServerTaskRequest) (*RunServerTaskResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RunServerTask not implemented")
+}
 func (UnimplementedAgentServiceServer) mustEmbedUnimplementedAgentServiceServer() {}
 func (UnimplementedAgentServiceServer) testEmbeddedByValue()                      {}
 
@@ -681,6 +1127,17 @@ func _AgentService_StreamDeployLogs_Handler(srv interface{}, stream grpc.ServerS
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type AgentService_StreamDeployLogsServer = grpc.ServerStreamingServer[DeployLogEntry]
 
+func _AgentService_StreamDeployLogBatches_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DeployLogSubscription)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AgentServiceServer).StreamDeployLogBatches(m, &grpc.GenericServerStream[DeployLogSubscription, DeployLogBatch]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AgentService_StreamDeployLogBatchesServer = grpc.ServerStreamingServer[DeployLogBatch]
+
 func _AgentService_ListContainers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListContainersRequest)
 	if err := dec(in); err != nil {
@@ -1149,20 +1606,445 @@ func _AgentService_GetContainerSSLStatus_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
-// AgentService_ServiceDesc is the grpc.ServiceDesc for AgentService service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var AgentService_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "flowdeploy.v1.AgentService",
-	HandlerType: (*AgentServiceServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "Register",
-			Handler:    _AgentService_Register_Handler,
-		},
-		{
-			MethodName: "Heartbeat",
-			Handler:    _AgentService_Heartbeat_Handler,
+func _AgentService_GetAgentLogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAgentLogsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServiceServer).GetAgentLogs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AgentService_GetAgentLogs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServiceServer).GetAgentLogs(ctx, req.(*GetAgentLogsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AgentService_RotateAgentLogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RotateAgentLogsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServiceServer).RotateAgentLogs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AgentService_RotateAgentLogs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServiceServer).RotateAgentLogs(ctx, req.(*RotateAgentLogsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AgentService_InstallCertificate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InstallCertificateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServiceServer).InstallCertificate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AgentService_InstallCertificate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServiceServer).InstallCertificate(ctx, req.(*InstallCertificateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AgentService_RemoveCertificate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveCertificateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServiceServer).RemoveCertificate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AgentService_RemoveCertificate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServiceServer).RemoveCertificate(ctx, req.(*RemoveCertificateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AgentService_SetMaintenance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetMaintenanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServiceServer).SetMaintenance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AgentService_SetMaintenance_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServiceServer).SetMaintenance(ctx, req.(*SetMaintenanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AgentService_ListAcmeCertificates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAcmeCertificatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServiceServer).ListAcmeCertificates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AgentService_ListAcmeCertificates_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServiceServer).ListAcmeCertificates(ctx, req.(*ListAcmeCertificatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AgentService_DeleteAcmeCertificates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteAcmeCertificatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServiceServer).DeleteAcmeCertificates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AgentService_DeleteAcmeCertificates_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServiceServer).DeleteAcmeCertificates(ctx, req.(*DeleteAcmeCertificatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AgentService_ConfigureTunnel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfigureTunnelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServiceServer).ConfigureTunnel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AgentService_ConfigureTunnel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServiceServer).ConfigureTunnel(ctx, req.(*ConfigureTunnelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AgentService_RemoveTunnel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveTunnelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServiceServer).RemoveTunnel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AgentService_RemoveTunnel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServiceServer).RemoveTunnel(ctx, req.(*RemoveTunnelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AgentService_GetAccessLogStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAccessLogStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServiceServer).GetAccessLogStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AgentService_GetAccessLogStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServiceServer).GetAccessLogStats(ctx, req.(*GetAccessLogStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AgentService_ReadComposeProject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadComposeProjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServiceServer).ReadComposeProject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AgentService_ReadComposeProject_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServiceServer).ReadComposeProject(ctx, req.(*ReadComposeProjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AgentService_GetMigrationSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMigrationSnapshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServiceServer).GetMigrationSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AgentService_GetMigrationSnapshot_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServiceServer).GetMigrationSnapshot(ctx, req.(*GetMigrationSnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AgentService_CreateMigrationBackup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateMigrationBackupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServiceServer).CreateMigrationBackup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AgentService_CreateMigrationBackup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServiceServer).CreateMigrationBackup(ctx, req.(*CreateMigrationBackupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AgentService_MigrateContainer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MigrateContainerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServiceServer).MigrateContainer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AgentService_MigrateContainer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServiceServer).MigrateContainer(ctx, req.(*MigrateContainerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AgentService_StopNginx_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StopNginxRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServiceServer).StopNginx(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AgentService_StopNginx_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServiceServer).StopNginx(ctx, req.(*StopNginxRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AgentService_ListContainerFiles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListContainerFilesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServiceServer).ListContainerFiles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AgentService_ListContainerFiles_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServiceServer).ListContainerFiles(ctx, req.(*ListContainerFilesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AgentService_DownloadContainerFile_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DownloadContainerFileRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AgentServiceServer).DownloadContainerFile(m, &grpc.GenericServerStream[DownloadContainerFileRequest, ContainerFileChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AgentService_DownloadContainerFileServer = grpc.ServerStreamingServer[ContainerFileChunk]
+
+func _AgentService_UploadContainerFile_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(AgentServiceServer).UploadContainerFile(&grpc.GenericServerStream[ContainerFileChunk, UploadContainerFileResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AgentService_UploadContainerFileServer = grpc.ClientStreamingServer[ContainerFileChunk, UploadContainerFileResponse]
+
+func _AgentService_GetContainerTop_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetContainerTopRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServiceServer).GetContainerTop(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AgentService_GetContainerTop_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServiceServer).GetContainerTop(ctx, req.(*GetContainerTopRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AgentService_InspectContainer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InspectContainerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServiceServer).InspectContainer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AgentService_InspectContainer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServiceServer).InspectContainer(ctx, req.(*InspectContainerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AgentService_CommitContainer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CommitContainerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServiceServer).CommitContainer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AgentService_CommitContainer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServiceServer).CommitContainer(ctx, req.(*CommitContainerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AgentService_ListVolumeFiles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListVolumeFilesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServiceServer).ListVolumeFiles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AgentService_ListVolumeFiles_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServiceServer).ListVolumeFiles(ctx, req.(*ListVolumeFilesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AgentService_StatVolumeFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StatVolumeFileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServiceServer).StatVolumeFile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AgentService_StatVolumeFile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServiceServer).StatVolumeFile(ctx, req.(*StatVolumeFileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AgentService_DownloadVolumeFile_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DownloadVolumeFileRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AgentServiceServer).DownloadVolumeFile(m, &grpc.GenericServerStream[DownloadVolumeFileRequest, ContainerFileChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AgentService_DownloadVolumeFileServer = grpc.ServerStreamingServer[ContainerFileChunk]
+
+func _AgentService_RunServerTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RunServerTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServiceServer).RunServerTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AgentService_RunServerTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServiceServer).RunServerTask(ctx, req.(*RunServerTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AgentService_ServiceDesc is the grpc.ServiceDesc for AgentService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AgentService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "flowdeploy.v1.AgentService",
+	HandlerType: (*AgentServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Register",
+			Handler:    _AgentService_Register_Handler,
+		},
+		{
+			MethodName: "Heartbeat",
+			Handler:    _AgentService_Heartbeat_Handler,
 		},
 		{
 			MethodName: "ExecuteDeploy",
@@ -1264,6 +2146,94 @@ var AgentService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetContainerSSLStatus",
 			Handler:    _AgentService_GetContainerSSLStatus_Handler,
 		},
+		{
+			MethodName: "GetAgentLogs",
+			Handler:    _AgentService_GetAgentLogs_Handler,
+		},
+		{
+			MethodName: "RotateAgentLogs",
+			Handler:    _AgentService_RotateAgentLogs_Handler,
+		},
+		{
+			MethodName: "InstallCertificate",
+			Handler:    _AgentService_InstallCertificate_Handler,
+		},
+		{
+			MethodName: "RemoveCertificate",
+			Handler:    _AgentService_RemoveCertificate_Handler,
+		},
+		{
+			MethodName: "SetMaintenance",
+			Handler:    _AgentService_SetMaintenance_Handler,
+		},
+		{
+			MethodName: "ListAcmeCertificates",
+			Handler:    _AgentService_ListAcmeCertificates_Handler,
+		},
+		{
+			MethodName: "DeleteAcmeCertificates",
+			Handler:    _AgentService_DeleteAcmeCertificates_Handler,
+		},
+		{
+			MethodName: "ConfigureTunnel",
+			Handler:    _AgentService_ConfigureTunnel_Handler,
+		},
+		{
+			MethodName: "RemoveTunnel",
+			Handler:    _AgentService_RemoveTunnel_Handler,
+		},
+		{
+			MethodName: "GetAccessLogStats",
+			Handler:    _AgentService_GetAccessLogStats_Handler,
+		},
+		{
+			MethodName: "ReadComposeProject",
+			Handler:    _AgentService_ReadComposeProject_Handler,
+		},
+		{
+			MethodName: "GetMigrationSnapshot",
+			Handler:    _AgentService_GetMigrationSnapshot_Handler,
+		},
+		{
+			MethodName: "CreateMigrationBackup",
+			Handler:    _AgentService_CreateMigrationBackup_Handler,
+		},
+		{
+			MethodName: "MigrateContainer",
+			Handler:    _AgentService_MigrateContainer_Handler,
+		},
+		{
+			MethodName: "StopNginx",
+			Handler:    _AgentService_StopNginx_Handler,
+		},
+		{
+			MethodName: "ListContainerFiles",
+			Handler:    _AgentService_ListContainerFiles_Handler,
+		},
+		{
+			MethodName: "GetContainerTop",
+			Handler:    _AgentService_GetContainerTop_Handler,
+		},
+		{
+			MethodName: "InspectContainer",
+			Handler:    _AgentService_InspectContainer_Handler,
+		},
+		{
+			MethodName: "CommitContainer",
+			Handler:    _AgentService_CommitContainer_Handler,
+		},
+		{
+			MethodName: "ListVolumeFiles",
+			Handler:    _AgentService_ListVolumeFiles_Handler,
+		},
+		{
+			MethodName: "StatVolumeFile",
+			Handler:    _AgentService_StatVolumeFile_Handler,
+		},
+		{
+			MethodName: "RunServerTask",
+			Handler:    _AgentService_RunServerTask_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -1271,6 +2241,11 @@ var AgentService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _AgentService_StreamDeployLogs_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "StreamDeployLogBatches",
+			Handler:       _AgentService_StreamDeployLogBatches_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "GetContainerLogs",
 			Handler:       _AgentService_GetContainerLogs_Handler,
@@ -1292,6 +2267,21 @@ var AgentService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _AgentService_PushUpdate_Handler,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "DownloadContainerFile",
+			Handler:       _AgentService_DownloadContainerFile_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "UploadContainerFile",
+			Handler:       _AgentService_UploadContainerFile_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "DownloadVolumeFile",
+			Handler:       _AgentService_DownloadVolumeFile_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "flowdeploy/v1/agent.proto",
 }
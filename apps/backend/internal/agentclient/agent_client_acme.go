@@ -0,0 +1,43 @@
+package agentclient
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	pb "github.com/paasdeploy/backend/gen/go/flowdeploy/v1"
+)
+
+// acmeDeleteTimeout covers stopping and restarting Traefik on the agent.
+const acmeDeleteTimeout = 2 * time.Minute
+
+func (c *AgentClient) ListAcmeCertificates(ctx context.Context, host string, port int) ([]*pb.AcmeCertificate, error) {
+	cl, err := c.client(host, port)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	resp, err := cl.ListAcmeCertificates(ctx, &pb.ListAcmeCertificatesRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("list acme certificates: %w", err)
+	}
+	return resp.Certificates, nil
+}
+
+func (c *AgentClient) DeleteAcmeCertificates(ctx context.Context, host string, port int, domains []string) (int, error) {
+	cl, err := c.client(host, port)
+	if err != nil {
+		return 0, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, acmeDeleteTimeout)
+	defer cancel()
+	resp, err := cl.DeleteAcmeCertificates(ctx, &pb.DeleteAcmeCertificatesRequest{Domains: domains})
+	if err != nil {
+		return 0, fmt.Errorf("delete acme certificates: %w", err)
+	}
+	if !resp.Success {
+		return 0, fmt.Errorf("delete acme certificates failed: %s", resp.Message)
+	}
+	return int(resp.Removed), nil
+}
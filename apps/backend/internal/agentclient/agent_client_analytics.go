@@ -0,0 +1,23 @@
+package agentclient
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	pb "github.com/paasdeploy/backend/gen/go/flowdeploy/v1"
+)
+
+func (c *AgentClient) GetAccessLogStats(ctx context.Context, host string, port int, domains []string, since time.Time) ([]*pb.DomainAccessStats, error) {
+	cl, err := c.client(host, port)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	resp, err := cl.GetAccessLogStats(ctx, &pb.GetAccessLogStatsRequest{Domains: domains, Since: since.Unix()})
+	if err != nil {
+		return nil, fmt.Errorf("get access log stats: %w", err)
+	}
+	return resp.Domains, nil
+}
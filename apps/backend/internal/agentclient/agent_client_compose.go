@@ -0,0 +1,22 @@
+package agentclient
+
+import (
+	"context"
+	"fmt"
+
+	pb "github.com/paasdeploy/backend/gen/go/flowdeploy/v1"
+)
+
+func (c *AgentClient) ReadComposeProject(ctx context.Context, host string, port int, path string) (*pb.ReadComposeProjectResponse, error) {
+	cl, err := c.client(host, port)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	resp, err := cl.ReadComposeProject(ctx, &pb.ReadComposeProjectRequest{Path: path})
+	if err != nil {
+		return nil, fmt.Errorf("read compose project: %w", err)
+	}
+	return resp, nil
+}
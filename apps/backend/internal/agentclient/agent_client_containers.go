@@ -85,6 +85,34 @@ func (c *AgentClient) GetContainerStats(ctx context.Context, host string, port i
 	}
 }
 
+func (c *AgentClient) GetContainerTop(ctx context.Context, host string, port int, containerID string) ([]*pb.ContainerProcess, error) {
+	cl, err := c.client(host, port)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	resp, err := cl.GetContainerTop(ctx, &pb.GetContainerTopRequest{ContainerId: containerID})
+	if err != nil {
+		return nil, fmt.Errorf("get container top: %w", err)
+	}
+	return resp.Processes, nil
+}
+
+func (c *AgentClient) InspectContainer(ctx context.Context, host string, port int, containerID string) (*pb.InspectContainerResponse, error) {
+	cl, err := c.client(host, port)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	resp, err := cl.InspectContainer(ctx, &pb.InspectContainerRequest{ContainerId: containerID})
+	if err != nil {
+		return nil, fmt.Errorf("inspect container: %w", err)
+	}
+	return resp, nil
+}
+
 func (c *AgentClient) RestartContainer(ctx context.Context, host string, port int, containerID string) error {
 	cl, err := c.client(host, port)
 	if err != nil {
@@ -153,6 +181,29 @@ func (c *AgentClient) RemoveContainer(ctx context.Context, host string, port int
 	return nil
 }
 
+// containerCommitTimeout leaves room for snapshotting large container
+// filesystems.
+const containerCommitTimeout = 10 * time.Minute
+
+// CommitContainer snapshots a remote container into a new image and returns
+// the image ID.
+func (c *AgentClient) CommitContainer(ctx context.Context, host string, port int, req *pb.CommitContainerRequest) (string, error) {
+	cl, err := c.client(host, port)
+	if err != nil {
+		return "", err
+	}
+	ctx, cancel := context.WithTimeout(ctx, containerCommitTimeout)
+	defer cancel()
+	resp, err := cl.CommitContainer(ctx, req)
+	if err != nil {
+		return "", fmt.Errorf("commit container: %w", err)
+	}
+	if !resp.Success {
+		return "", fmt.Errorf("commit container failed: %s", resp.Message)
+	}
+	return resp.ImageId, nil
+}
+
 func (c *AgentClient) PruneContainers(ctx context.Context, host string, port int) (*pb.PruneContainersResponse, error) {
 	cl, err := c.client(host, port)
 	if err != nil {
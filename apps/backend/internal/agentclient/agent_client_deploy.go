@@ -6,9 +6,17 @@ import (
 	"io"
 	"time"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
 	pb "github.com/paasdeploy/backend/gen/go/flowdeploy/v1"
 )
 
+const (
+	logStreamReconnects     = 5
+	logStreamReconnectDelay = time.Second
+)
+
 type DeployLogHandler func(entry *pb.DeployLogEntry)
 
 func (c *AgentClient) ExecuteDeployWithLogs(
@@ -32,16 +40,72 @@ func (c *AgentClient) ExecuteDeployWithLogs(
 	return cl.ExecuteDeploy(ctx, req)
 }
 
+// streamLogs follows the deploy's logs until the agent ends the stream. If
+// the stream breaks, it resubscribes after the last sequence received, which
+// the agent replays from its buffer. Agents that predate batching answer
+// Unimplemented, in which case it follows the per-entry stream.
 func (c *AgentClient) streamLogs(ctx context.Context, cl pb.AgentServiceClient, deploymentID string, onLog DeployLogHandler, ready chan<- struct{}) {
-	stream, err := cl.StreamDeployLogs(ctx, &pb.DeployLogSubscription{DeploymentId: deploymentID})
-	close(ready)
+	sub := &pb.DeployLogSubscription{DeploymentId: deploymentID}
+	onEntry := func(entry *pb.DeployLogEntry) {
+		if entry.Sequence > sub.AfterSequence {
+			sub.AfterSequence = entry.Sequence
+		}
+		onLog(entry)
+	}
+
+	follow := c.followLogBatches
+	err := follow(ctx, cl, sub, onEntry, ready)
+	for attempt := 0; err != nil && ctx.Err() == nil && attempt < logStreamReconnects; attempt++ {
+		if status.Code(err) == codes.Unimplemented {
+			follow = c.followLogEntries
+		} else {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(logStreamReconnectDelay):
+			}
+		}
+		err = follow(ctx, cl, sub, onEntry, nil)
+	}
+}
+
+func (c *AgentClient) followLogBatches(ctx context.Context, cl pb.AgentServiceClient, sub *pb.DeployLogSubscription, onLog DeployLogHandler, ready chan<- struct{}) error {
+	stream, err := cl.StreamDeployLogBatches(ctx, sub)
+	if ready != nil {
+		close(ready)
+	}
 	if err != nil {
-		return
+		return err
+	}
+	for {
+		batch, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		for _, entry := range batch.Entries {
+			onLog(entry)
+		}
+	}
+}
+
+func (c *AgentClient) followLogEntries(ctx context.Context, cl pb.AgentServiceClient, sub *pb.DeployLogSubscription, onLog DeployLogHandler, ready chan<- struct{}) error {
+	stream, err := cl.StreamDeployLogs(ctx, sub)
+	if ready != nil {
+		close(ready)
+	}
+	if err != nil {
+		return err
 	}
 	for {
 		entry, err := stream.Recv()
-		if err == io.EOF || err != nil {
-			return
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
 		}
 		onLog(entry)
 	}
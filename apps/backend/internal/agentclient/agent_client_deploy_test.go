@@ -0,0 +1,116 @@
+package agentclient
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/paasdeploy/backend/gen/go/flowdeploy/v1"
+)
+
+// fakeStream replays items and then returns err, or io.EOF when err is nil.
+type fakeStream[T any] struct {
+	grpc.ClientStream
+	items []*T
+	err   error
+}
+
+func (s *fakeStream[T]) Recv() (*T, error) {
+	if len(s.items) == 0 {
+		if s.err != nil {
+			return nil, s.err
+		}
+		return nil, io.EOF
+	}
+	item := s.items[0]
+	s.items = s.items[1:]
+	return item, nil
+}
+
+type fakeLogClient struct {
+	pb.AgentServiceClient
+	batches []*fakeStream[pb.DeployLogBatch]
+	entries *fakeStream[pb.DeployLogEntry]
+	subs    []uint64
+}
+
+func (c *fakeLogClient) StreamDeployLogBatches(_ context.Context, sub *pb.DeployLogSubscription, _ ...grpc.CallOption) (grpc.ServerStreamingClient[pb.DeployLogBatch], error) {
+	c.subs = append(c.subs, sub.AfterSequence)
+	stream := c.batches[0]
+	c.batches = c.batches[1:]
+	return stream, nil
+}
+
+func (c *fakeLogClient) StreamDeployLogs(_ context.Context, sub *pb.DeployLogSubscription, _ ...grpc.CallOption) (grpc.ServerStreamingClient[pb.DeployLogEntry], error) {
+	c.subs = append(c.subs, sub.AfterSequence)
+	return c.entries, nil
+}
+
+func logEntries(from, to uint64) []*pb.DeployLogEntry {
+	var entries []*pb.DeployLogEntry
+	for seq := from; seq <= to; seq++ {
+		entries = append(entries, &pb.DeployLogEntry{Sequence: seq})
+	}
+	return entries
+}
+
+func collectLogs(t *testing.T, cl pb.AgentServiceClient) []uint64 {
+	t.Helper()
+	var got []uint64
+	ready := make(chan struct{})
+	(&AgentClient{}).streamLogs(context.Background(), cl, "dep-1", func(entry *pb.DeployLogEntry) {
+		got = append(got, entry.Sequence)
+	}, ready)
+	select {
+	case <-ready:
+	default:
+		t.Error("ready was not closed")
+	}
+	return got
+}
+
+func assertSequences(t *testing.T, got []uint64, want uint64) {
+	t.Helper()
+	if uint64(len(got)) != want {
+		t.Fatalf("got sequences %v, want 1 to %d", got, want)
+	}
+	for i, seq := range got {
+		if seq != uint64(i+1) {
+			t.Fatalf("got sequences %v, want 1 to %d", got, want)
+		}
+	}
+}
+
+func TestStreamLogsReadsBatches(t *testing.T) {
+	cl := &fakeLogClient{batches: []*fakeStream[pb.DeployLogBatch]{{
+		items: []*pb.DeployLogBatch{{Entries: logEntries(1, 3)}, {Entries: logEntries(4, 5)}},
+	}}}
+
+	assertSequences(t, collectLogs(t, cl), 5)
+}
+
+func TestStreamLogsFallsBackToEntriesOnOlderAgents(t *testing.T) {
+	cl := &fakeLogClient{
+		batches: []*fakeStream[pb.DeployLogBatch]{{err: status.Error(codes.Unimplemented, "unknown method")}},
+		entries: &fakeStream[pb.DeployLogEntry]{items: logEntries(1, 4)},
+	}
+
+	assertSequences(t, collectLogs(t, cl), 4)
+}
+
+func TestStreamLogsResumesAfterLastSequence(t *testing.T) {
+	cl := &fakeLogClient{batches: []*fakeStream[pb.DeployLogBatch]{
+		{items: []*pb.DeployLogBatch{{Entries: logEntries(1, 3)}}, err: errors.New("connection reset")},
+		{items: []*pb.DeployLogBatch{{Entries: logEntries(4, 6)}}},
+	}}
+
+	assertSequences(t, collectLogs(t, cl), 6)
+	if len(cl.subs) != 2 || cl.subs[1] != 3 {
+		t.Errorf("subscriptions after sequences %v, want a reconnect after 3", cl.subs)
+	}
+}
@@ -0,0 +1,156 @@
+package agentclient
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"google.golang.org/grpc"
+
+	pb "github.com/paasdeploy/backend/gen/go/flowdeploy/v1"
+)
+
+const (
+	containerFileTimeout   = 3 * time.Minute
+	containerFileChunkSize = 1 << 20
+)
+
+func (c *AgentClient) ListContainerFiles(ctx context.Context, host string, port int, containerID, path string) ([]*pb.ContainerFileEntry, error) {
+	cl, err := c.client(host, port)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	resp, err := cl.ListContainerFiles(ctx, &pb.ListContainerFilesRequest{ContainerId: containerID, Path: path})
+	if err != nil {
+		return nil, fmt.Errorf("list container files: %w", err)
+	}
+	return resp.Entries, nil
+}
+
+// DownloadContainerFile returns the name and content of a file inside a
+// container on the remote host.
+func (c *AgentClient) DownloadContainerFile(ctx context.Context, host string, port int, containerID, path string) (string, []byte, error) {
+	cl, err := c.client(host, port)
+	if err != nil {
+		return "", nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, containerFileTimeout)
+	defer cancel()
+	stream, err := cl.DownloadContainerFile(ctx, &pb.DownloadContainerFileRequest{ContainerId: containerID, Path: path})
+	if err != nil {
+		return "", nil, fmt.Errorf("download container file: %w", err)
+	}
+	name, data, err := receiveFileChunks(stream)
+	if err != nil {
+		return "", nil, fmt.Errorf("download container file: %w", err)
+	}
+	return name, data, nil
+}
+
+func receiveFileChunks(stream grpc.ServerStreamingClient[pb.ContainerFileChunk]) (string, []byte, error) {
+	var name string
+	var data []byte
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			return name, data, nil
+		}
+		if err != nil {
+			return "", nil, err
+		}
+		if name == "" {
+			name = chunk.Name
+			data = make([]byte, 0, chunk.TotalSize)
+		}
+		data = append(data, chunk.Data...)
+	}
+}
+
+func (c *AgentClient) UploadContainerFile(ctx context.Context, host string, port int, containerID, dir, name string, data []byte) error {
+	cl, err := c.client(host, port)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, containerFileTimeout)
+	defer cancel()
+	stream, err := cl.UploadContainerFile(ctx)
+	if err != nil {
+		return fmt.Errorf("upload container file: %w", err)
+	}
+
+	chunk := &pb.ContainerFileChunk{
+		ContainerId: containerID,
+		Path:        dir,
+		Name:        name,
+		TotalSize:   int64(len(data)),
+	}
+	for offset := 0; ; offset += containerFileChunkSize {
+		chunk.Data = data[offset:min(offset+containerFileChunkSize, len(data))]
+		// On io.EOF the agent has aborted the stream; CloseAndRecv returns
+		// its error.
+		if err := stream.Send(chunk); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return fmt.Errorf("upload container file: %w", err)
+		}
+		if offset+containerFileChunkSize >= len(data) {
+			break
+		}
+		chunk = &pb.ContainerFileChunk{}
+	}
+
+	if _, err := stream.CloseAndRecv(); err != nil {
+		return fmt.Errorf("upload container file: %w", err)
+	}
+	return nil
+}
+
+func (c *AgentClient) ListVolumeFiles(ctx context.Context, host string, port int, volume, path string) ([]*pb.ContainerFileEntry, error) {
+	cl, err := c.client(host, port)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, containerFileTimeout)
+	defer cancel()
+	resp, err := cl.ListVolumeFiles(ctx, &pb.ListVolumeFilesRequest{VolumeName: volume, Path: path})
+	if err != nil {
+		return nil, fmt.Errorf("list volume files: %w", err)
+	}
+	return resp.Entries, nil
+}
+
+func (c *AgentClient) StatVolumeFile(ctx context.Context, host string, port int, volume, path string) (*pb.ContainerFileEntry, error) {
+	cl, err := c.client(host, port)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, containerFileTimeout)
+	defer cancel()
+	resp, err := cl.StatVolumeFile(ctx, &pb.StatVolumeFileRequest{VolumeName: volume, Path: path})
+	if err != nil {
+		return nil, fmt.Errorf("stat volume file: %w", err)
+	}
+	return resp.Entry, nil
+}
+
+func (c *AgentClient) DownloadVolumeFile(ctx context.Context, host string, port int, volume, path string) (string, []byte, error) {
+	cl, err := c.client(host, port)
+	if err != nil {
+		return "", nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, containerFileTimeout)
+	defer cancel()
+	stream, err := cl.DownloadVolumeFile(ctx, &pb.DownloadVolumeFileRequest{VolumeName: volume, Path: path})
+	if err != nil {
+		return "", nil, fmt.Errorf("download volume file: %w", err)
+	}
+	name, data, err := receiveFileChunks(stream)
+	if err != nil {
+		return "", nil, fmt.Errorf("download volume file: %w", err)
+	}
+	return name, data, nil
+}
@@ -0,0 +1,69 @@
+package agentclient
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	pb "github.com/paasdeploy/backend/gen/go/flowdeploy/v1"
+)
+
+// migrationBackupTimeout covers copying /etc/nginx and /etc/letsencrypt on
+// the remote host, which can take longer than the default RPC timeout.
+const migrationBackupTimeout = 5 * time.Minute
+
+func (c *AgentClient) GetMigrationSnapshot(ctx context.Context, host string, port int) (*pb.GetMigrationSnapshotResponse, error) {
+	cl, err := c.client(host, port)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	resp, err := cl.GetMigrationSnapshot(ctx, &pb.GetMigrationSnapshotRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("get migration snapshot: %w", err)
+	}
+	return resp, nil
+}
+
+func (c *AgentClient) CreateMigrationBackup(ctx context.Context, host string, port int) (*pb.CreateMigrationBackupResponse, error) {
+	cl, err := c.client(host, port)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, migrationBackupTimeout)
+	defer cancel()
+	resp, err := cl.CreateMigrationBackup(ctx, &pb.CreateMigrationBackupRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("create migration backup: %w", err)
+	}
+	return resp, nil
+}
+
+func (c *AgentClient) MigrateContainer(ctx context.Context, host string, port int, containerID string, labels []string) (*pb.MigrateContainerResponse, error) {
+	cl, err := c.client(host, port)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	resp, err := cl.MigrateContainer(ctx, &pb.MigrateContainerRequest{ContainerId: containerID, Labels: labels})
+	if err != nil {
+		return nil, fmt.Errorf("migrate container: %w", err)
+	}
+	return resp, nil
+}
+
+func (c *AgentClient) StopNginx(ctx context.Context, host string, port int) (*pb.StopNginxResponse, error) {
+	cl, err := c.client(host, port)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	resp, err := cl.StopNginx(ctx, &pb.StopNginxRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("stop nginx: %w", err)
+	}
+	return resp, nil
+}
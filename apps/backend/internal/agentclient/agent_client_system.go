@@ -3,6 +3,7 @@ package agentclient
 import (
 	"context"
 	"fmt"
+	"time"
 
 	pb "github.com/paasdeploy/backend/gen/go/flowdeploy/v1"
 	"google.golang.org/protobuf/types/known/emptypb"
@@ -174,3 +175,85 @@ func (c *AgentClient) UpdateDomains(ctx context.Context, host string, port int,
 	}
 	return nil
 }
+
+func (c *AgentClient) InstallCertificate(ctx context.Context, host string, port int, req *pb.InstallCertificateRequest) error {
+	cl, err := c.client(host, port)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	resp, err := cl.InstallCertificate(ctx, req)
+	if err != nil {
+		return fmt.Errorf("install certificate: %w", err)
+	}
+	if !resp.Success {
+		return fmt.Errorf("install certificate failed: %s", resp.Message)
+	}
+	return nil
+}
+
+func (c *AgentClient) RemoveCertificate(ctx context.Context, host string, port int, domainName string) error {
+	cl, err := c.client(host, port)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	resp, err := cl.RemoveCertificate(ctx, &pb.RemoveCertificateRequest{Domain: domainName})
+	if err != nil {
+		return fmt.Errorf("remove certificate: %w", err)
+	}
+	if !resp.Success {
+		return fmt.Errorf("remove certificate failed: %s", resp.Message)
+	}
+	return nil
+}
+
+func (c *AgentClient) SetMaintenance(ctx context.Context, host string, port int, req *pb.SetMaintenanceRequest) error {
+	cl, err := c.client(host, port)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	resp, err := cl.SetMaintenance(ctx, req)
+	if err != nil {
+		return fmt.Errorf("set maintenance: %w", err)
+	}
+	if !resp.Success {
+		return fmt.Errorf("set maintenance failed: %s", resp.Message)
+	}
+	return nil
+}
+
+func (c *AgentClient) GetAgentLogs(ctx context.Context, host string, port int, lines int, since time.Duration) (*pb.GetAgentLogsResponse, error) {
+	cl, err := c.client(host, port)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	resp, err := cl.GetAgentLogs(ctx, &pb.GetAgentLogsRequest{
+		Lines:        int32(lines),
+		SinceSeconds: int64(since.Seconds()),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get agent logs: %w", err)
+	}
+	return resp, nil
+}
+
+func (c *AgentClient) RotateAgentLogs(ctx context.Context, host string, port int) (*pb.RotateAgentLogsResponse, error) {
+	cl, err := c.client(host, port)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	resp, err := cl.RotateAgentLogs(ctx, &pb.RotateAgentLogsRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("rotate agent logs: %w", err)
+	}
+	return resp, nil
+}
@@ -0,0 +1,31 @@
+package agentclient
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	pb "github.com/paasdeploy/backend/gen/go/flowdeploy/v1"
+)
+
+// serverTaskTimeoutMargin is added to a task's own timeout so the agent
+// reports the timeout, with the output so far, before the call is cut.
+const serverTaskTimeoutMargin = 30 * time.Second
+
+func (c *AgentClient) RunServerTask(ctx context.Context, host string, port int, taskID, command string, timeout time.Duration) (*pb.RunServerTaskResponse, error) {
+	cl, err := c.client(host, port)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout+serverTaskTimeoutMargin)
+	defer cancel()
+	resp, err := cl.RunServerTask(ctx, &pb.RunServerTaskRequest{
+		TaskId:         taskID,
+		Command:        command,
+		TimeoutSeconds: int32(timeout / time.Second),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("run server task: %w", err)
+	}
+	return resp, nil
+}
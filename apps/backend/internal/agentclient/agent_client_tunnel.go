@@ -0,0 +1,46 @@
+package agentclient
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	pb "github.com/paasdeploy/backend/gen/go/flowdeploy/v1"
+)
+
+// tunnelConfigureTimeout covers pulling the cloudflared image on first use.
+const tunnelConfigureTimeout = 4 * time.Minute
+
+func (c *AgentClient) ConfigureTunnel(ctx context.Context, host string, port int, token string) error {
+	cl, err := c.client(host, port)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, tunnelConfigureTimeout)
+	defer cancel()
+	resp, err := cl.ConfigureTunnel(ctx, &pb.ConfigureTunnelRequest{Token: token})
+	if err != nil {
+		return fmt.Errorf("configure tunnel: %w", err)
+	}
+	if !resp.Success {
+		return fmt.Errorf("configure tunnel failed: %s", resp.Message)
+	}
+	return nil
+}
+
+func (c *AgentClient) RemoveTunnel(ctx context.Context, host string, port int) error {
+	cl, err := c.client(host, port)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	resp, err := cl.RemoveTunnel(ctx, &pb.RemoveTunnelRequest{})
+	if err != nil {
+		return fmt.Errorf("remove tunnel: %w", err)
+	}
+	if !resp.Success {
+		return fmt.Errorf("remove tunnel failed: %s", resp.Message)
+	}
+	return nil
+}
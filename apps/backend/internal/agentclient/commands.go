@@ -0,0 +1,38 @@
+package agentclient
+
+import (
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// IsUnreachable reports whether err means the agent could not be contacted,
+// as opposed to the agent rejecting the request.
+func IsUnreachable(err error) bool {
+	if err == nil {
+		return false
+	}
+	var st interface{ GRPCStatus() *status.Status }
+	if !errors.As(err, &st) {
+		return false
+	}
+	switch st.GRPCStatus().Code() {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return true
+	default:
+		return false
+	}
+}
+
+// EncodeCommandPayload serializes an RPC request so it can be delivered later
+// as an AgentCommand payload and decoded by the agent.
+func EncodeCommandPayload(msg proto.Message) (string, error) {
+	data, err := protojson.Marshal(msg)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
@@ -94,7 +94,8 @@ func (p *connPool) dial(host, addr string) (*grpc.ClientConn, error) {
 			PermitWithoutStream: true,
 		}),
 		grpc.WithDefaultCallOptions(grpc.UseCompressor("gzip")),
-		grpc.WithUnaryInterceptor(retryUnaryInterceptor()),
+		grpc.WithChainUnaryInterceptor(traceUnaryInterceptor(), retryUnaryInterceptor()),
+		grpc.WithStreamInterceptor(traceStreamInterceptor()),
 	)
 }
 
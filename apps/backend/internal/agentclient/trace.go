@@ -0,0 +1,45 @@
+package agentclient
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/paasdeploy/shared/pkg/tracing"
+)
+
+func withTraceMetadata(ctx context.Context) context.Context {
+	if id := tracing.ID(ctx); id != "" {
+		return metadata.AppendToOutgoingContext(ctx, tracing.MetadataKey, id)
+	}
+	return ctx
+}
+
+// traceUnaryInterceptor sends the trace ID of the calling request to the
+// agent so both sides log it.
+func traceUnaryInterceptor() grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply interface{},
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		return invoker(withTraceMetadata(ctx), method, req, reply, cc, opts...)
+	}
+}
+
+func traceStreamInterceptor() grpc.StreamClientInterceptor {
+	return func(
+		ctx context.Context,
+		desc *grpc.StreamDesc,
+		cc *grpc.ClientConn,
+		method string,
+		streamer grpc.Streamer,
+		opts ...grpc.CallOption,
+	) (grpc.ClientStream, error) {
+		return streamer(withTraceMetadata(ctx), desc, cc, method, opts...)
+	}
+}
@@ -0,0 +1,54 @@
+package agentdownload
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+const (
+	ArchAMD64 = "amd64"
+	ArchARM64 = "arm64"
+
+	DefaultArch = ArchAMD64
+)
+
+var ErrUnsupportedArch = errors.New("unsupported agent architecture")
+
+// NormalizeArch maps `uname -m` output or a GOARCH value to the architecture
+// name used for agent binaries.
+func NormalizeArch(machine string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(machine)) {
+	case "":
+		return DefaultArch, nil
+	case "x86_64", "amd64", "x64":
+		return ArchAMD64, nil
+	case "aarch64", "arm64", "armv8", "armv8l":
+		return ArchARM64, nil
+	default:
+		return "", fmt.Errorf("%w: %s", ErrUnsupportedArch, machine)
+	}
+}
+
+// ResolveBinaryPath returns the agent binary built for arch. Per-arch builds
+// live next to basePath as "<basePath>-<arch>"; basePath itself is the amd64
+// build for installs that ship a single binary.
+func ResolveBinaryPath(basePath, arch string) (string, error) {
+	if basePath == "" {
+		return "", errors.New("agent binary path not configured")
+	}
+	arch, err := NormalizeArch(arch)
+	if err != nil {
+		return "", err
+	}
+
+	candidate := basePath + "-" + arch
+	if _, err := os.Stat(candidate); err == nil {
+		return candidate, nil
+	}
+	if arch == DefaultArch {
+		return basePath, nil
+	}
+	return "", fmt.Errorf("no agent binary for %s (expected %s)", arch, candidate)
+}
@@ -0,0 +1,57 @@
+package agentdownload
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestNormalizeArch(t *testing.T) {
+	cases := map[string]string{
+		"":         ArchAMD64,
+		"x86_64\n": ArchAMD64,
+		"amd64":    ArchAMD64,
+		"aarch64":  ArchARM64,
+		"arm64":    ArchARM64,
+	}
+	for in, want := range cases {
+		got, err := NormalizeArch(in)
+		if err != nil {
+			t.Fatalf("NormalizeArch(%q): %v", in, err)
+		}
+		if got != want {
+			t.Fatalf("NormalizeArch(%q) = %q, want %q", in, got, want)
+		}
+	}
+
+	if _, err := NormalizeArch("riscv64"); !errors.Is(err, ErrUnsupportedArch) {
+		t.Fatalf("expected ErrUnsupportedArch, got %v", err)
+	}
+}
+
+func TestResolveBinaryPath(t *testing.T) {
+	dir := t.TempDir()
+	base := filepath.Join(dir, "agent")
+	if err := os.WriteFile(base, []byte("amd64"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ResolveBinaryPath(base, "x86_64")
+	if err != nil || got != base {
+		t.Fatalf("amd64 fallback: got %q, %v", got, err)
+	}
+
+	if _, err := ResolveBinaryPath(base, "aarch64"); err == nil {
+		t.Fatal("expected error when arm64 binary is missing")
+	}
+
+	arm := base + "-arm64"
+	if err := os.WriteFile(arm, []byte("arm64"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	got, err = ResolveBinaryPath(base, "aarch64")
+	if err != nil || got != arm {
+		t.Fatalf("arm64: got %q, %v", got, err)
+	}
+}
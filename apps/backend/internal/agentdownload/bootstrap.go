@@ -0,0 +1,159 @@
+package agentdownload
+
+import (
+	"archive/tar"
+	"bytes"
+	"errors"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/paasdeploy/backend/internal/crypto"
+	"github.com/paasdeploy/backend/internal/domain"
+	"github.com/paasdeploy/backend/internal/pki"
+	"github.com/paasdeploy/backend/internal/response"
+)
+
+const (
+	BootstrapBinaryPath = "/paas-deploy/v1/agent/bootstrap/binary"
+	BootstrapCertsPath  = "/paas-deploy/v1/agent/bootstrap/certs"
+
+	msgInvalidBootstrapToken = "invalid, used or expired bootstrap token"
+)
+
+// BootstrapHandler serves the public endpoints used by generated install
+// scripts. Both endpoints authenticate with the one-shot bootstrap token;
+// fetching the certificates consumes it.
+type BootstrapHandler struct {
+	tokenRepo  domain.ServerBootstrapTokenRepository
+	serverRepo domain.ServerRepository
+	ca         *pki.CertificateAuthority
+	binaryPath string
+	logger     *slog.Logger
+}
+
+func NewBootstrapHandler(
+	tokenRepo domain.ServerBootstrapTokenRepository,
+	serverRepo domain.ServerRepository,
+	ca *pki.CertificateAuthority,
+	binaryPath string,
+	logger *slog.Logger,
+) *BootstrapHandler {
+	return &BootstrapHandler{
+		tokenRepo:  tokenRepo,
+		serverRepo: serverRepo,
+		ca:         ca,
+		binaryPath: binaryPath,
+		logger:     logger.With("handler", "agent_bootstrap"),
+	}
+}
+
+func (h *BootstrapHandler) ServeBinary(c *fiber.Ctx) error {
+	token := c.Query("token")
+	if token == "" {
+		return response.BadRequest(c, "missing token")
+	}
+	bootstrap, err := h.tokenRepo.FindValid(crypto.HashSessionToken(token))
+	if err != nil {
+		return h.tokenError(c, err)
+	}
+
+	arch, err := NormalizeArch(c.Query("arch"))
+	if err != nil {
+		return response.BadRequest(c, err.Error())
+	}
+	binaryPath, err := ResolveBinaryPath(h.binaryPath, arch)
+	if err != nil {
+		h.logger.Warn("agent binary not available for arch", "arch", arch, "error", err)
+		return response.NotFound(c, "agent binary not available for "+arch)
+	}
+	data, err := os.ReadFile(binaryPath)
+	if err != nil {
+		h.logger.Error("failed to read agent binary", "path", binaryPath, "error", err)
+		return response.InternalError(c)
+	}
+
+	h.logger.Info("serving agent binary for bootstrap", "serverId", bootstrap.ServerID, "arch", arch, "ip", c.IP())
+	c.Set("Content-Type", "application/octet-stream")
+	c.Set("Content-Disposition", "attachment; filename=agent")
+	return c.Send(data)
+}
+
+func (h *BootstrapHandler) ServeCerts(c *fiber.Ctx) error {
+	token := c.Query("token")
+	if token == "" {
+		return response.BadRequest(c, "missing token")
+	}
+	if h.ca == nil {
+		return response.ServerError(c, fiber.StatusServiceUnavailable, "certificate authority not available")
+	}
+
+	bootstrap, err := h.tokenRepo.Consume(crypto.HashSessionToken(token))
+	if err != nil {
+		return h.tokenError(c, err)
+	}
+	server, err := h.serverRepo.FindByID(bootstrap.ServerID)
+	if err != nil {
+		h.logger.Error("bootstrap server not found", "serverId", bootstrap.ServerID, "error", err)
+		return response.NotFound(c, "server not found")
+	}
+
+	agentCert, err := h.ca.GenerateAgentCert(server.ID, server.Host)
+	if err != nil {
+		h.logger.Error("failed to generate agent cert", "serverId", server.ID, "error", err)
+		return response.InternalError(c)
+	}
+	archive, err := certsArchive(map[string][]byte{
+		"ca.pem":   h.ca.GetCACertPEM(),
+		"cert.pem": agentCert.CertPEM,
+		"key.pem":  agentCert.KeyPEM,
+	})
+	if err != nil {
+		h.logger.Error("failed to build certs archive", "serverId", server.ID, "error", err)
+		return response.InternalError(c)
+	}
+
+	status := domain.ServerStatusProvisioning
+	if _, err := h.serverRepo.Update(server.ID, domain.UpdateServerInput{Status: &status}); err != nil {
+		h.logger.Warn("failed to mark server provisioning", "serverId", server.ID, "error", err)
+	}
+
+	h.logger.Info("issued agent certificates via bootstrap", "serverId", server.ID, "ip", c.IP())
+	c.Set("Content-Type", "application/x-tar")
+	c.Set("Content-Disposition", "attachment; filename=certs.tar")
+	return c.Send(archive)
+}
+
+func (h *BootstrapHandler) tokenError(c *fiber.Ctx, err error) error {
+	if errors.Is(err, domain.ErrNotFound) {
+		h.logger.Warn("bootstrap token rejected", "ip", c.IP())
+		return response.Unauthorized(c, msgInvalidBootstrapToken)
+	}
+	h.logger.Error("failed to validate bootstrap token", "error", err)
+	return response.InternalError(c)
+}
+
+func certsArchive(files map[string][]byte) ([]byte, error) {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	now := time.Now()
+	for _, name := range []string{"ca.pem", "cert.pem", "key.pem"} {
+		content := files[name]
+		mode := int64(0o644)
+		if name == "key.pem" {
+			mode = 0o600
+		}
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: mode, Size: int64(len(content)), ModTime: now}); err != nil {
+			return nil, err
+		}
+		if _, err := tw.Write(content); err != nil {
+			return nil, err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -37,18 +37,28 @@ func (h *Handler) ServeBinary(c *fiber.Ctx) error {
 		return response.ServerError(c, fiber.StatusServiceUnavailable, "agent binary not available")
 	}
 
-	info, err := os.Stat(h.binaryPath)
+	arch, err := NormalizeArch(c.Query("arch"))
 	if err != nil {
-		h.logger.Error("agent binary not found", "path", h.binaryPath, "error", err)
+		return response.BadRequest(c, err.Error())
+	}
+	binaryPath, err := ResolveBinaryPath(h.binaryPath, arch)
+	if err != nil {
+		h.logger.Warn("agent binary not available for arch", "arch", arch, "error", err)
+		return response.NotFound(c, "agent binary not available for "+arch)
+	}
+
+	info, err := os.Stat(binaryPath)
+	if err != nil {
+		h.logger.Error("agent binary not found", "path", binaryPath, "error", err)
 		return response.InternalError(c)
 	}
 
-	data, err := os.ReadFile(h.binaryPath)
+	data, err := os.ReadFile(binaryPath)
 	if err != nil {
-		h.logger.Error("failed to read agent binary", "path", h.binaryPath, "error", err)
+		h.logger.Error("failed to read agent binary", "path", binaryPath, "error", err)
 		return response.InternalError(c)
 	}
-	h.logger.Info("serving agent binary", "size", info.Size(), "ip", c.IP())
+	h.logger.Info("serving agent binary", "arch", arch, "size", info.Size(), "ip", c.IP())
 	c.Set("Content-Type", "application/octet-stream")
 	c.Set("Content-Disposition", "attachment; filename=agent")
 	return c.Send(data)
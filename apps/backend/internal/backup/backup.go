@@ -0,0 +1,372 @@
+// Package backup exports the control plane database to an encrypted archive
+// and restores it, so a FlowDeploy installation can be rebuilt on a new host.
+//
+// The archive holds every table of the schema (apps, servers, env vars, the
+// PKI CA, users, ...) as JSON, gzip compressed and sealed with AES-256-GCM
+// under a key derived from a passphrase.
+package backup
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	formatVersion       = 1
+	MinPassphraseLength = 12
+
+	saltSize         = 16
+	keySize          = 32
+	pbkdf2Iterations = 600_000
+	migrationsTable  = "schema_migrations"
+)
+
+var magic = []byte("FDBACKUP")
+
+var (
+	ErrWeakPassphrase = fmt.Errorf("passphrase must be at least %d characters", MinPassphraseLength)
+	ErrNotBackup      = errors.New("file is not a FlowDeploy backup")
+	ErrDecrypt        = errors.New("wrong passphrase or corrupted backup")
+	ErrSchemaMismatch = errors.New("backup schema version does not match the database")
+)
+
+type Table struct {
+	Name string          `json:"name"`
+	Rows int             `json:"rows"`
+	Data json.RawMessage `json:"data"`
+}
+
+// Archive is the decrypted content of a backup. Tables are ordered so that
+// referenced tables come before the tables pointing at them.
+type Archive struct {
+	Format        int       `json:"format"`
+	SchemaVersion uint      `json:"schemaVersion"`
+	CreatedAt     time.Time `json:"createdAt"`
+	Tables        []Table   `json:"tables"`
+}
+
+type Summary struct {
+	SchemaVersion uint      `json:"schemaVersion"`
+	CreatedAt     time.Time `json:"createdAt"`
+	Tables        int       `json:"tables"`
+	Rows          int       `json:"rows"`
+}
+
+func (a *Archive) Summary() Summary {
+	summary := Summary{SchemaVersion: a.SchemaVersion, CreatedAt: a.CreatedAt, Tables: len(a.Tables)}
+	for _, t := range a.Tables {
+		summary.Rows += t.Rows
+	}
+	return summary
+}
+
+type Manager struct {
+	db *sql.DB
+}
+
+func NewManager(db *sql.DB) *Manager {
+	return &Manager{db: db}
+}
+
+// Create exports the database from a single snapshot and returns the
+// encrypted archive.
+func (m *Manager) Create(ctx context.Context, passphrase string) ([]byte, Summary, error) {
+	if len(passphrase) < MinPassphraseLength {
+		return nil, Summary{}, ErrWeakPassphrase
+	}
+
+	tx, err := m.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, Summary{}, fmt.Errorf("begin snapshot: %w", err)
+	}
+	defer tx.Rollback()
+
+	archive, err := export(ctx, tx)
+	if err != nil {
+		return nil, Summary{}, err
+	}
+
+	data, err := Seal(archive, passphrase)
+	if err != nil {
+		return nil, Summary{}, err
+	}
+	return data, archive.Summary(), nil
+}
+
+// Restore replaces the content of every table with the archive. The
+// database must be at the schema version the archive was taken at; nothing
+// is changed if any table fails to load.
+func (m *Manager) Restore(ctx context.Context, archive *Archive) (Summary, error) {
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return Summary{}, fmt.Errorf("begin restore: %w", err)
+	}
+	defer tx.Rollback()
+
+	version, err := schemaVersion(ctx, tx)
+	if err != nil {
+		return Summary{}, err
+	}
+	if version != archive.SchemaVersion {
+		return Summary{}, fmt.Errorf("%w: backup is at version %d, database is at version %d", ErrSchemaMismatch, archive.SchemaVersion, version)
+	}
+
+	tables, err := listTables(ctx, tx)
+	if err != nil {
+		return Summary{}, err
+	}
+	for _, t := range archive.Tables {
+		if !slices.Contains(tables, t.Name) {
+			return Summary{}, fmt.Errorf("backup contains unknown table %q", t.Name)
+		}
+	}
+
+	if len(tables) > 0 {
+		if _, err := tx.ExecContext(ctx, "TRUNCATE "+quoteTables(tables)); err != nil {
+			return Summary{}, fmt.Errorf("truncate tables: %w", err)
+		}
+	}
+	for _, t := range archive.Tables {
+		name := pgx.Identifier{t.Name}.Sanitize()
+		query := fmt.Sprintf("INSERT INTO %s SELECT * FROM json_populate_recordset(NULL::%s, $1::json)", name, name)
+		if _, err := tx.ExecContext(ctx, query, string(t.Data)); err != nil {
+			return Summary{}, fmt.Errorf("restore table %s: %w", t.Name, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return Summary{}, fmt.Errorf("commit restore: %w", err)
+	}
+	return archive.Summary(), nil
+}
+
+func export(ctx context.Context, tx *sql.Tx) (*Archive, error) {
+	version, err := schemaVersion(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	tables, err := listTables(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	deps, err := tableDependencies(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	archive := &Archive{Format: formatVersion, SchemaVersion: version, CreatedAt: time.Now().UTC()}
+	for _, name := range sortTables(tables, deps) {
+		table := Table{Name: name}
+		query := fmt.Sprintf("SELECT COALESCE(json_agg(t), '[]'::json), COUNT(*) FROM %s t", pgx.Identifier{name}.Sanitize())
+		if err := tx.QueryRowContext(ctx, query).Scan(&table.Data, &table.Rows); err != nil {
+			return nil, fmt.Errorf("export table %s: %w", name, err)
+		}
+		archive.Tables = append(archive.Tables, table)
+	}
+	return archive, nil
+}
+
+func schemaVersion(ctx context.Context, tx *sql.Tx) (uint, error) {
+	var version int64
+	var dirty bool
+	err := tx.QueryRowContext(ctx, "SELECT version, dirty FROM "+migrationsTable).Scan(&version, &dirty)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, errors.New("database has no schema, run the migrations first")
+	}
+	if err != nil {
+		return 0, fmt.Errorf("read schema version: %w", err)
+	}
+	if dirty {
+		return 0, fmt.Errorf("database is dirty at version %d", version)
+	}
+	return uint(version), nil
+}
+
+func listTables(ctx context.Context, tx *sql.Tx) ([]string, error) {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT table_name FROM information_schema.tables
+		WHERE table_schema = current_schema() AND table_type = 'BASE TABLE' AND table_name <> $1
+		ORDER BY table_name`, migrationsTable)
+	if err != nil {
+		return nil, fmt.Errorf("list tables: %w", err)
+	}
+	defer rows.Close()
+
+	var tables []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		tables = append(tables, name)
+	}
+	return tables, rows.Err()
+}
+
+// tableDependencies maps each table to the tables its foreign keys reference.
+func tableDependencies(ctx context.Context, tx *sql.Tx) (map[string][]string, error) {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT src.relname, dst.relname
+		FROM pg_constraint c
+		JOIN pg_class src ON src.oid = c.conrelid
+		JOIN pg_class dst ON dst.oid = c.confrelid
+		WHERE c.contype = 'f' AND c.connamespace = current_schema()::regnamespace`)
+	if err != nil {
+		return nil, fmt.Errorf("list foreign keys: %w", err)
+	}
+	defer rows.Close()
+
+	deps := make(map[string][]string)
+	for rows.Next() {
+		var table, referenced string
+		if err := rows.Scan(&table, &referenced); err != nil {
+			return nil, err
+		}
+		deps[table] = append(deps[table], referenced)
+	}
+	return deps, rows.Err()
+}
+
+// sortTables orders tables so every table comes after the tables it
+// references. Self references and cycles keep the input order.
+func sortTables(tables []string, deps map[string][]string) []string {
+	sorted := make([]string, 0, len(tables))
+	state := make(map[string]int, len(tables))
+	known := make(map[string]bool, len(tables))
+	for _, t := range tables {
+		known[t] = true
+	}
+
+	var visit func(string)
+	visit = func(t string) {
+		if state[t] != 0 {
+			return
+		}
+		state[t] = 1
+		for _, dep := range deps[t] {
+			if known[dep] {
+				visit(dep)
+			}
+		}
+		state[t] = 2
+		sorted = append(sorted, t)
+	}
+	for _, t := range tables {
+		visit(t)
+	}
+	return sorted
+}
+
+func quoteTables(tables []string) string {
+	var buf bytes.Buffer
+	for i, t := range tables {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(pgx.Identifier{t}.Sanitize())
+	}
+	return buf.String()
+}
+
+// Seal compresses and encrypts the archive. The output is the magic header,
+// the format version, the PBKDF2 salt, the GCM nonce and the ciphertext.
+func Seal(archive *Archive, passphrase string) ([]byte, error) {
+	var plain bytes.Buffer
+	zw := gzip.NewWriter(&plain)
+	if err := json.NewEncoder(zw).Encode(archive); err != nil {
+		return nil, fmt.Errorf("encode backup: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("compress backup: %w", err)
+	}
+
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	gcm, err := newGCM(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	header := make([]byte, 0, len(magic)+1+saltSize+len(nonce))
+	header = append(header, magic...)
+	header = append(header, formatVersion)
+	header = append(header, salt...)
+	header = append(header, nonce...)
+	return append(header, gcm.Seal(nil, nonce, plain.Bytes(), header)...), nil
+}
+
+// Open decrypts and decodes an archive produced by Seal.
+func Open(r io.Reader, passphrase string) (*Archive, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("read backup: %w", err)
+	}
+	if len(data) < len(magic)+1+saltSize || !bytes.Equal(data[:len(magic)], magic) {
+		return nil, ErrNotBackup
+	}
+	if data[len(magic)] != formatVersion {
+		return nil, fmt.Errorf("unsupported backup format %d", data[len(magic)])
+	}
+
+	saltStart := len(magic) + 1
+	salt := data[saltStart : saltStart+saltSize]
+	gcm, err := newGCM(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	nonceEnd := saltStart + saltSize + gcm.NonceSize()
+	if len(data) < nonceEnd {
+		return nil, ErrNotBackup
+	}
+	plain, err := gcm.Open(nil, data[saltStart+saltSize:nonceEnd], data[nonceEnd:], data[:nonceEnd])
+	if err != nil {
+		return nil, ErrDecrypt
+	}
+
+	zr, err := gzip.NewReader(bytes.NewReader(plain))
+	if err != nil {
+		return nil, fmt.Errorf("decompress backup: %w", err)
+	}
+	defer zr.Close()
+	var archive Archive
+	if err := json.NewDecoder(zr).Decode(&archive); err != nil {
+		return nil, fmt.Errorf("decode backup: %w", err)
+	}
+	if archive.Format != formatVersion {
+		return nil, fmt.Errorf("unsupported backup format %d", archive.Format)
+	}
+	return &archive, nil
+}
+
+func newGCM(passphrase string, salt []byte) (cipher.AEAD, error) {
+	key, err := pbkdf2.Key(sha256.New, passphrase, salt, pbkdf2Iterations, keySize)
+	if err != nil {
+		return nil, fmt.Errorf("derive key: %w", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
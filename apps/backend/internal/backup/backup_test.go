@@ -0,0 +1,99 @@
+package backup
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+const testPassphrase = "correct horse battery staple"
+
+func TestSealOpenRoundTrip(t *testing.T) {
+	archive := &Archive{
+		Format:        formatVersion,
+		SchemaVersion: 60,
+		CreatedAt:     time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		Tables: []Table{
+			{Name: "users", Rows: 1, Data: json.RawMessage(`[{"id":"u1"}]`)},
+			{Name: "apps", Rows: 2, Data: json.RawMessage(`[{"id":"a1"},{"id":"a2"}]`)},
+		},
+	}
+
+	data, err := Seal(archive, testPassphrase)
+	if err != nil {
+		t.Fatalf("Seal() error = %v", err)
+	}
+	if bytes.Contains(data, []byte("users")) {
+		t.Fatal("sealed backup contains plaintext")
+	}
+
+	got, err := Open(bytes.NewReader(data), testPassphrase)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	if got.SchemaVersion != 60 || !got.CreatedAt.Equal(archive.CreatedAt) || len(got.Tables) != 2 {
+		t.Fatalf("Open() = %+v", got)
+	}
+	if got.Tables[1].Name != "apps" || string(got.Tables[1].Data) != `[{"id":"a1"},{"id":"a2"}]` {
+		t.Errorf("apps table = %+v", got.Tables[1])
+	}
+	if summary := got.Summary(); summary.Tables != 2 || summary.Rows != 3 {
+		t.Errorf("Summary() = %+v", summary)
+	}
+}
+
+func TestOpenRejectsWrongPassphrase(t *testing.T) {
+	data, err := Seal(&Archive{Format: formatVersion}, testPassphrase)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Open(bytes.NewReader(data), "another passphrase"); !errors.Is(err, ErrDecrypt) {
+		t.Errorf("Open() error = %v, want ErrDecrypt", err)
+	}
+
+	data[len(data)-1] ^= 0xff
+	if _, err := Open(bytes.NewReader(data), testPassphrase); !errors.Is(err, ErrDecrypt) {
+		t.Errorf("Open() tampered error = %v, want ErrDecrypt", err)
+	}
+}
+
+func TestOpenRejectsOtherFiles(t *testing.T) {
+	if _, err := Open(bytes.NewReader([]byte("PGDMP not a backup at all")), testPassphrase); !errors.Is(err, ErrNotBackup) {
+		t.Errorf("Open() error = %v, want ErrNotBackup", err)
+	}
+}
+
+func TestCreateRejectsWeakPassphrase(t *testing.T) {
+	if _, _, err := NewManager(nil).Create(t.Context(), "short"); !errors.Is(err, ErrWeakPassphrase) {
+		t.Errorf("Create() error = %v, want ErrWeakPassphrase", err)
+	}
+}
+
+func TestSortTables(t *testing.T) {
+	tables := []string{"apps", "deployments", "env_vars", "servers", "users"}
+	deps := map[string][]string{
+		"apps":        {"users", "servers", "apps"},
+		"deployments": {"apps"},
+		"env_vars":    {"apps"},
+		"servers":     {"users"},
+		"audit_logs":  {"users"},
+	}
+
+	got := sortTables(tables, deps)
+	if len(got) != len(tables) {
+		t.Fatalf("sortTables() = %v", got)
+	}
+	for table, refs := range deps {
+		if !slices.Contains(tables, table) {
+			continue
+		}
+		for _, ref := range refs {
+			if ref != table && slices.Index(got, ref) > slices.Index(got, table) {
+				t.Errorf("%s sorted before %s: %v", table, ref, got)
+			}
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/paasdeploy/backend/pkg/client"
+)
+
+func (o *output) apps(ctx context.Context, c *client.Client, args []string) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+	switch args[0] {
+	case "list", "ls":
+		return o.listApps(ctx, c)
+	case "create":
+		return o.createApp(ctx, c, args[1:])
+	default:
+		return errUsage
+	}
+}
+
+func (o *output) listApps(ctx context.Context, c *client.Client) error {
+	apps, err := c.ListApps(ctx)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(o.stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tID\tSTATUS\tBRANCH\tLAST DEPLOY")
+	for _, a := range apps {
+		lastDeploy := "-"
+		if a.LastDeployedAt != nil {
+			lastDeploy = a.LastDeployedAt.Local().Format(time.DateTime)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", a.Name, a.ID, a.Status, a.Branch, lastDeploy)
+	}
+	return w.Flush()
+}
+
+func (o *output) createApp(ctx context.Context, c *client.Client, args []string) error {
+	fs := newFlagSet("apps create")
+	name := fs.String("name", "", "application name")
+	repo := fs.String("repo", "", "git repository URL")
+	branch := fs.String("branch", "main", "branch to deploy")
+	workdir := fs.String("workdir", ".", "directory of the app inside the repository")
+	serverID := fs.String("server", "", "ID of the server to deploy to")
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+	if *name == "" || *repo == "" {
+		return errUsage
+	}
+
+	input := client.CreateAppInput{
+		Name:          *name,
+		RepositoryURL: *repo,
+		Branch:        *branch,
+		Workdir:       *workdir,
+	}
+	if *serverID != "" {
+		input.ServerID = serverID
+	}
+
+	created, err := c.CreateApp(ctx, input)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(o.stdout, "Created app %s (%s)\n", created.Name, created.ID)
+	return nil
+}
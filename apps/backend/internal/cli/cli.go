@@ -0,0 +1,142 @@
+// Package cli implements the flowdeploy command line client on top of the
+// pkg/client SDK.
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/paasdeploy/backend/pkg/client"
+)
+
+const usage = `Usage: flowdeploy [--url URL] [--token TOKEN] <command> [args]
+
+Commands:
+  login --url URL --token TOKEN    Save credentials for later commands
+  apps list                        List applications
+  apps create --name N --repo URL  Create an application
+  deploy <app> [--commit SHA]      Deploy an app and follow its logs
+  logs <app> [-f] [--tail N]       Print container logs
+  env set <app> KEY=VALUE...       Create or update environment variables
+  exec <app> [--shell sh]          Open an interactive shell in the container
+
+<app> is an application name or ID. Credentials can also be passed with the
+FLOWDEPLOY_URL and FLOWDEPLOY_TOKEN environment variables.
+`
+
+// errUsage makes Run print the usage text and exit with status 2.
+var errUsage = errors.New("invalid usage")
+
+type command func(ctx context.Context, c *client.Client, args []string) error
+
+// Run executes the CLI and returns the process exit code.
+func Run(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	global := flag.NewFlagSet("flowdeploy", flag.ContinueOnError)
+	global.SetOutput(io.Discard)
+	url := global.String("url", "", "API base URL")
+	token := global.String("token", "", "API token")
+	if err := global.Parse(args); err != nil || global.NArg() == 0 {
+		fmt.Fprint(stderr, usage)
+		return 2
+	}
+
+	out := &output{stdout: stdout, stderr: stderr}
+	name, rest := global.Arg(0), global.Args()[1:]
+
+	var err error
+	if name == "login" {
+		err = out.login(ctx, rest)
+	} else {
+		err = out.runCommand(ctx, name, rest, *url, *token)
+	}
+
+	switch {
+	case err == nil:
+		return 0
+	case errors.Is(err, errUsage):
+		fmt.Fprint(stderr, usage)
+		return 2
+	case errors.Is(err, errDeployFailed):
+		return 1
+	default:
+		fmt.Fprintln(stderr, "Error:", err)
+		return 1
+	}
+}
+
+type output struct {
+	stdout io.Writer
+	stderr io.Writer
+}
+
+func (o *output) runCommand(ctx context.Context, name string, args []string, url, token string) error {
+	commands := map[string]command{
+		"apps":   o.apps,
+		"deploy": o.deploy,
+		"logs":   o.logs,
+		"env":    o.env,
+		"exec":   o.exec,
+	}
+	cmd, ok := commands[name]
+	if !ok {
+		return errUsage
+	}
+
+	cfg, err := loadConfig(url, token)
+	if err != nil {
+		return err
+	}
+	return cmd(ctx, client.New(cfg.URL, cfg.Token), args)
+}
+
+func (o *output) login(ctx context.Context, args []string) error {
+	fs := newFlagSet("login")
+	url := fs.String("url", os.Getenv(envURL), "API base URL")
+	token := fs.String("token", os.Getenv(envToken), "API token")
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+	if *url == "" || *token == "" {
+		return errUsage
+	}
+
+	cfg := Config{URL: trimURL(*url), Token: *token}
+
+	me, err := client.New(cfg.URL, cfg.Token).Me(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to verify token: %w", err)
+	}
+
+	path, err := saveConfig(cfg)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(o.stdout, "Logged in as %s (saved to %s)\n", me.Email, path)
+	return nil
+}
+
+func newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	return fs
+}
+
+// parseArgs parses flags placed before, between or after positional
+// arguments, which the flag package alone stops at.
+func parseArgs(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, errUsage
+		}
+		if fs.NArg() == 0 {
+			return positional, nil
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+}
@@ -0,0 +1,46 @@
+package cli
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/paasdeploy/backend/pkg/client"
+)
+
+func TestParseArgsInterspersed(t *testing.T) {
+	fs := newFlagSet("deploy")
+	commit := fs.String("commit", "", "")
+	detach := fs.Bool("detach", false, "")
+
+	positional, err := parseArgs(fs, []string{"--detach", "web", "--commit", "abc123"})
+	if err != nil {
+		t.Fatalf("parseArgs() error = %v", err)
+	}
+	if !reflect.DeepEqual(positional, []string{"web"}) {
+		t.Fatalf("positional = %v, want [web]", positional)
+	}
+	if *commit != "abc123" || !*detach {
+		t.Fatalf("commit = %q, detach = %v", *commit, *detach)
+	}
+}
+
+func TestParseEnvAssignments(t *testing.T) {
+	vars, err := parseEnvAssignments([]string{"A=1", "URL=postgres://u:p@h/db?x=y", "EMPTY="}, true)
+	if err != nil {
+		t.Fatalf("parseEnvAssignments() error = %v", err)
+	}
+	want := []client.EnvVarInput{
+		{Key: "A", Value: "1", IsSecret: true},
+		{Key: "URL", Value: "postgres://u:p@h/db?x=y", IsSecret: true},
+		{Key: "EMPTY", Value: "", IsSecret: true},
+	}
+	if !reflect.DeepEqual(vars, want) {
+		t.Fatalf("vars = %+v, want %+v", vars, want)
+	}
+
+	for _, invalid := range []string{"NOVALUE", "=value"} {
+		if _, err := parseEnvAssignments([]string{invalid}, false); err == nil {
+			t.Errorf("parseEnvAssignments(%q) expected error", invalid)
+		}
+	}
+}
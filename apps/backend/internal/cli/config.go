@@ -0,0 +1,77 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	envURL   = "FLOWDEPLOY_URL"
+	envToken = "FLOWDEPLOY_TOKEN"
+)
+
+var errNotConfigured = errors.New("no API URL or token configured; run `flowdeploy login` or set " + envURL + " and " + envToken)
+
+// Config holds the API endpoint and token. Flags win over environment
+// variables, which win over the file written by `flowdeploy login`.
+type Config struct {
+	URL   string `json:"url"`
+	Token string `json:"token"`
+}
+
+func configPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "flowdeploy", "config.json"), nil
+}
+
+func loadConfig(flagURL, flagToken string) (Config, error) {
+	var cfg Config
+	if path, err := configPath(); err == nil {
+		if data, err := os.ReadFile(path); err == nil {
+			_ = json.Unmarshal(data, &cfg)
+		}
+	}
+
+	cfg.URL = firstNonEmpty(flagURL, os.Getenv(envURL), cfg.URL)
+	cfg.Token = firstNonEmpty(flagToken, os.Getenv(envToken), cfg.Token)
+	cfg.URL = trimURL(cfg.URL)
+
+	if cfg.URL == "" || cfg.Token == "" {
+		return cfg, errNotConfigured
+	}
+	return cfg, nil
+}
+
+func saveConfig(cfg Config) (string, error) {
+	path, err := configPath()
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return "", err
+	}
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return path, os.WriteFile(path, data, 0o600)
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+func trimURL(url string) string {
+	return strings.TrimRight(url, "/")
+}
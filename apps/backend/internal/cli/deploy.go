@@ -0,0 +1,77 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/paasdeploy/backend/pkg/client"
+)
+
+// errDeployFailed is returned after the failure was already printed.
+var errDeployFailed = errors.New("deploy failed")
+
+func (o *output) deploy(ctx context.Context, c *client.Client, args []string) error {
+	fs := newFlagSet("deploy")
+	commit := fs.String("commit", "", "commit SHA to deploy instead of the branch head")
+	detach := fs.Bool("detach", false, "return once the deploy is queued")
+	positional, err := parseArgs(fs, args)
+	if err != nil || len(positional) != 1 {
+		return errUsage
+	}
+
+	a, err := c.FindApp(ctx, positional[0])
+	if err != nil {
+		return err
+	}
+
+	// Subscribe before triggering so no event of the new deploy is missed.
+	var events *client.DeployEventStream
+	if !*detach {
+		events, err = c.DeployEvents(ctx)
+		if err != nil {
+			return err
+		}
+		defer events.Close()
+	}
+
+	deployment, err := c.Deploy(ctx, a.ID, *commit)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(o.stdout, "Deploy %s queued for %s\n", deployment.ID, a.Name)
+
+	if *detach {
+		return nil
+	}
+	return o.followDeploy(events, deployment.ID)
+}
+
+func (o *output) followDeploy(events *client.DeployEventStream, deployID string) error {
+	for {
+		event, err := events.Next()
+		if errors.Is(err, io.EOF) {
+			return errors.New("event stream closed before the deploy finished")
+		}
+		if err != nil {
+			return err
+		}
+		if event.DeployID != deployID {
+			continue
+		}
+
+		switch event.Type {
+		case client.DeployEventLog:
+			fmt.Fprintln(o.stdout, event.Message)
+		case client.DeployEventRunning:
+			fmt.Fprintln(o.stdout, "Deploy started")
+		case client.DeployEventSuccess:
+			fmt.Fprintln(o.stdout, "Deploy succeeded")
+			return nil
+		case client.DeployEventFailed:
+			fmt.Fprintln(o.stderr, "Deploy failed:", event.Message)
+			return errDeployFailed
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/paasdeploy/backend/pkg/client"
+)
+
+func (o *output) env(ctx context.Context, c *client.Client, args []string) error {
+	if len(args) == 0 || args[0] != "set" {
+		return errUsage
+	}
+
+	fs := newFlagSet("env set")
+	secret := fs.Bool("secret", false, "mark the variables as secret")
+	positional, err := parseArgs(fs, args[1:])
+	if err != nil || len(positional) < 2 {
+		return errUsage
+	}
+
+	vars, err := parseEnvAssignments(positional[1:], *secret)
+	if err != nil {
+		return err
+	}
+
+	a, err := c.FindApp(ctx, positional[0])
+	if err != nil {
+		return err
+	}
+
+	if _, err := c.SetEnvVars(ctx, a.ID, vars); err != nil {
+		return err
+	}
+	fmt.Fprintf(o.stdout, "Set %d variable(s) on %s; redeploy to apply them\n", len(vars), a.Name)
+	return nil
+}
+
+func parseEnvAssignments(assignments []string, secret bool) ([]client.EnvVarInput, error) {
+	vars := make([]client.EnvVarInput, 0, len(assignments))
+	for _, assignment := range assignments {
+		key, value, ok := strings.Cut(assignment, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid assignment %q, expected KEY=VALUE", assignment)
+		}
+		vars = append(vars, client.EnvVarInput{Key: key, Value: value, IsSecret: secret})
+	}
+	return vars, nil
+}
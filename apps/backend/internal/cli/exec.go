@@ -0,0 +1,77 @@
+package cli
+
+import (
+	"context"
+	"os"
+
+	"github.com/paasdeploy/backend/pkg/client"
+	"golang.org/x/term"
+)
+
+func (o *output) exec(ctx context.Context, c *client.Client, args []string) error {
+	fs := newFlagSet("exec")
+	shell := fs.String("shell", "sh", "shell to start in the container")
+	positional, err := parseArgs(fs, args)
+	if err != nil || len(positional) != 1 {
+		return errUsage
+	}
+
+	a, err := c.FindApp(ctx, positional[0])
+	if err != nil {
+		return err
+	}
+
+	opts := client.ConsoleOptions{Shell: *shell, Cols: 80, Rows: 24, ServerID: a.Server()}
+	fd := int(os.Stdin.Fd())
+	interactive := term.IsTerminal(fd)
+	if interactive {
+		if cols, rows, err := term.GetSize(fd); err == nil {
+			opts.Cols, opts.Rows = cols, rows
+		}
+	}
+
+	console, err := c.OpenConsole(ctx, a.Name, opts)
+	if err != nil {
+		return err
+	}
+	defer console.Close()
+
+	if interactive {
+		state, err := term.MakeRaw(fd)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = term.Restore(fd, state) }()
+
+		stopResize := watchResize(fd, func(cols, rows int) {
+			_ = console.Resize(cols, rows)
+		})
+		defer stopResize()
+	}
+
+	go func() {
+		buf := make([]byte, 1024)
+		for {
+			n, err := os.Stdin.Read(buf)
+			if n > 0 {
+				if _, werr := console.Write(buf[:n]); werr != nil {
+					return
+				}
+			}
+			if err != nil {
+				_ = console.Close()
+				return
+			}
+		}
+	}()
+
+	for {
+		data, err := console.Recv()
+		if err != nil {
+			return nil
+		}
+		if _, err := o.stdout.Write(data); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/paasdeploy/backend/pkg/client"
+)
+
+func (o *output) logs(ctx context.Context, c *client.Client, args []string) error {
+	fs := newFlagSet("logs")
+	follow := fs.Bool("f", false, "stream new log lines")
+	fs.BoolVar(follow, "follow", false, "stream new log lines")
+	tail := fs.Int("tail", 100, "number of lines to show")
+	positional, err := parseArgs(fs, args)
+	if err != nil || len(positional) != 1 {
+		return errUsage
+	}
+
+	a, err := c.FindApp(ctx, positional[0])
+	if err != nil {
+		return err
+	}
+
+	if !*follow {
+		logs, err := c.ContainerLogs(ctx, a.ID, *tail)
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(o.stdout, logs)
+		return nil
+	}
+
+	stream, err := c.FollowContainerLogs(ctx, a.ID, *tail)
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+
+	for {
+		event, err := stream.Next()
+		if errors.Is(err, io.EOF) || ctx.Err() != nil {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(o.stdout, event.Data)
+	}
+}
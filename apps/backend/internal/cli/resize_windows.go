@@ -0,0 +1,9 @@
+//go:build windows
+
+package cli
+
+// watchResize is a no-op on Windows, which has no SIGWINCH; the console
+// keeps the size it was opened with.
+func watchResize(int, func(cols, rows int)) func() {
+	return func() {}
+}
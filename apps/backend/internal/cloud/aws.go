@@ -0,0 +1,313 @@
+package cloud
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/paasdeploy/backend/internal/domain"
+)
+
+const (
+	awsEC2APIVersion     = "2016-11-15"
+	awsDefaultRegion     = "us-east-1"
+	awsDefaultSize       = "t3.small"
+	awsUbuntuOwner       = "099720109477"
+	awsUbuntuImageFilter = "ubuntu/images/hvm-ssd-gp3/ubuntu-noble-24.04-amd64-server-*"
+)
+
+var errInvalidAWSCredentials = errors.New("AWS credentials must be in the form ACCESS_KEY_ID:SECRET_ACCESS_KEY")
+
+// AWSClient launches EC2 instances through the EC2 Query API, signed with
+// Signature Version 4. Instance IDs are returned as "region/instance-id"
+// because the region is chosen per server.
+type AWSClient struct {
+	accessKeyID     string
+	secretAccessKey string
+	endpoint        func(region string) string
+	httpClient      *http.Client
+	now             func() time.Time
+	logger          *slog.Logger
+}
+
+// NewAWSClient parses credentials stored as "ACCESS_KEY_ID:SECRET_ACCESS_KEY".
+func NewAWSClient(credentials string, logger *slog.Logger) (*AWSClient, error) {
+	accessKeyID, secret, ok := strings.Cut(strings.TrimSpace(credentials), ":")
+	if !ok || accessKeyID == "" || secret == "" {
+		return nil, errInvalidAWSCredentials
+	}
+	return &AWSClient{
+		accessKeyID:     accessKeyID,
+		secretAccessKey: secret,
+		endpoint: func(region string) string {
+			return "https://ec2." + region + ".amazonaws.com/"
+		},
+		httpClient: &http.Client{Timeout: httpTimeout},
+		now:        time.Now,
+		logger:     logger.With("component", "aws"),
+	}, nil
+}
+
+type awsErrorResponse struct {
+	Errors []struct {
+		Code    string `xml:"Code"`
+		Message string `xml:"Message"`
+	} `xml:"Errors>Error"`
+}
+
+type awsInstance struct {
+	InstanceID string `xml:"instanceId"`
+	State      struct {
+		Name string `xml:"name"`
+	} `xml:"instanceState"`
+	IPAddress string `xml:"ipAddress"`
+}
+
+func (c *AWSClient) Name() string {
+	return domain.CloudProviderAWS
+}
+
+// SSHUser is the default login of the Ubuntu AMIs; it has passwordless sudo.
+func (c *AWSClient) SSHUser() string {
+	return "ubuntu"
+}
+
+func awsSigningKey(secret, date, region, service string) []byte {
+	mac := func(key []byte, data string) []byte {
+		h := hmac.New(sha256.New, key)
+		h.Write([]byte(data))
+		return h.Sum(nil)
+	}
+	key := mac([]byte("AWS4"+secret), date)
+	key = mac(key, region)
+	key = mac(key, service)
+	return mac(key, "aws4_request")
+}
+
+func sha256Hex(data string) string {
+	sum := sha256.Sum256([]byte(data))
+	return hex.EncodeToString(sum[:])
+}
+
+// call sends one signed EC2 action and decodes the XML response into out.
+func (c *AWSClient) call(ctx context.Context, region, action string, params url.Values, out any) error {
+	params.Set("Action", action)
+	params.Set("Version", awsEC2APIVersion)
+	body := params.Encode()
+
+	endpoint := c.endpoint(region)
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return err
+	}
+	now := c.now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	contentType := "application/x-www-form-urlencoded; charset=utf-8"
+
+	canonicalRequest := strings.Join([]string{
+		http.MethodPost,
+		"/",
+		"",
+		"content-type:" + contentType,
+		"host:" + u.Host,
+		"x-amz-date:" + amzDate,
+		"",
+		"content-type;host;x-amz-date",
+		sha256Hex(body),
+	}, "\n")
+	scope := date + "/" + region + "/ec2/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + sha256Hex(canonicalRequest)
+	signature := hmac.New(sha256.New, awsSigningKey(c.secretAccessKey, date, region, "ec2"))
+	signature.Write([]byte(stringToSign))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=content-type;host;x-amz-date, Signature=%s",
+		c.accessKeyID, scope, hex.EncodeToString(signature.Sum(nil)),
+	))
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("read response: %w", err)
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		var apiErr awsErrorResponse
+		if xml.Unmarshal(data, &apiErr) == nil && len(apiErr.Errors) > 0 {
+			return fmt.Errorf("aws API error: %s (%s)", apiErr.Errors[0].Message, apiErr.Errors[0].Code)
+		}
+		return fmt.Errorf("aws API error: status %d", resp.StatusCode)
+	}
+	if out == nil {
+		return nil
+	}
+	if err := xml.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+	return nil
+}
+
+func (c *AWSClient) VerifyCredentials(ctx context.Context) error {
+	params := url.Values{}
+	params.Set("RegionName.1", awsDefaultRegion)
+	return c.call(ctx, awsDefaultRegion, "DescribeRegions", params, nil)
+}
+
+// latestUbuntuImage resolves the newest official Ubuntu 24.04 AMI, since AMI
+// IDs differ per region.
+func (c *AWSClient) latestUbuntuImage(ctx context.Context, region string) (string, error) {
+	params := url.Values{}
+	params.Set("Owner.1", awsUbuntuOwner)
+	params.Set("Filter.1.Name", "name")
+	params.Set("Filter.1.Value.1", awsUbuntuImageFilter)
+	params.Set("Filter.2.Name", "state")
+	params.Set("Filter.2.Value.1", "available")
+	var result struct {
+		Images []struct {
+			ImageID      string `xml:"imageId"`
+			CreationDate string `xml:"creationDate"`
+		} `xml:"imagesSet>item"`
+	}
+	if err := c.call(ctx, region, "DescribeImages", params, &result); err != nil {
+		return "", err
+	}
+	if len(result.Images) == 0 {
+		return "", fmt.Errorf("no Ubuntu 24.04 image found in %s", region)
+	}
+	sort.Slice(result.Images, func(i, j int) bool {
+		return result.Images[i].CreationDate > result.Images[j].CreationDate
+	})
+	return result.Images[0].ImageID, nil
+}
+
+// createSecurityGroup creates a group in the default VPC that allows the
+// given TCP ports from anywhere.
+func (c *AWSClient) createSecurityGroup(ctx context.Context, region, name string, ports []int) (string, error) {
+	params := url.Values{}
+	params.Set("GroupName", name)
+	params.Set("GroupDescription", "PaasDeploy managed server")
+	var group struct {
+		GroupID string `xml:"groupId"`
+	}
+	if err := c.call(ctx, region, "CreateSecurityGroup", params, &group); err != nil {
+		return "", fmt.Errorf("create security group: %w", err)
+	}
+	if len(ports) == 0 {
+		return group.GroupID, nil
+	}
+
+	ingress := url.Values{}
+	ingress.Set("GroupId", group.GroupID)
+	for i, port := range ports {
+		prefix := "IpPermissions." + strconv.Itoa(i+1) + "."
+		ingress.Set(prefix+"IpProtocol", "tcp")
+		ingress.Set(prefix+"FromPort", strconv.Itoa(port))
+		ingress.Set(prefix+"ToPort", strconv.Itoa(port))
+		ingress.Set(prefix+"IpRanges.1.CidrIp", "0.0.0.0/0")
+	}
+	if err := c.call(ctx, region, "AuthorizeSecurityGroupIngress", ingress, nil); err != nil {
+		return "", fmt.Errorf("open security group ports: %w", err)
+	}
+	return group.GroupID, nil
+}
+
+// CreateInstance imports the public key, creates a security group with the
+// required ports open and launches the instance.
+func (c *AWSClient) CreateInstance(ctx context.Context, input CreateInstanceInput) (*Instance, error) {
+	region := valueOr(input.Region, awsDefaultRegion)
+	resourceName := fmt.Sprintf("paasdeploy-%s-%d", input.Name, c.now().Unix())
+
+	image := input.Image
+	if image == "" {
+		var err error
+		if image, err = c.latestUbuntuImage(ctx, region); err != nil {
+			return nil, fmt.Errorf("resolve image: %w", err)
+		}
+	}
+
+	keyParams := url.Values{}
+	keyParams.Set("KeyName", resourceName)
+	keyParams.Set("PublicKeyMaterial", base64.StdEncoding.EncodeToString([]byte(input.SSHPublicKey)))
+	if err := c.call(ctx, region, "ImportKeyPair", keyParams, nil); err != nil {
+		return nil, fmt.Errorf("import key pair: %w", err)
+	}
+
+	groupID, err := c.createSecurityGroup(ctx, region, resourceName, input.OpenPorts)
+	if err != nil {
+		return nil, err
+	}
+
+	params := url.Values{}
+	params.Set("ImageId", image)
+	params.Set("InstanceType", valueOr(input.Size, awsDefaultSize))
+	params.Set("MinCount", "1")
+	params.Set("MaxCount", "1")
+	params.Set("KeyName", resourceName)
+	params.Set("SecurityGroupId.1", groupID)
+	params.Set("TagSpecification.1.ResourceType", "instance")
+	params.Set("TagSpecification.1.Tag.1.Key", "Name")
+	params.Set("TagSpecification.1.Tag.1.Value", input.Name)
+	var result struct {
+		Instances []awsInstance `xml:"instancesSet>item"`
+	}
+	if err := c.call(ctx, region, "RunInstances", params, &result); err != nil {
+		return nil, fmt.Errorf("run instance: %w", err)
+	}
+	if len(result.Instances) == 0 {
+		return nil, errors.New("run instance: no instance returned")
+	}
+	c.logger.Info("ec2 instance launched", "id", result.Instances[0].InstanceID, "region", region, "name", input.Name)
+	return result.Instances[0].toInstance(region), nil
+}
+
+func (c *AWSClient) GetInstance(ctx context.Context, id string) (*Instance, error) {
+	region, instanceID, ok := strings.Cut(id, "/")
+	if !ok {
+		return nil, fmt.Errorf("invalid instance id %q", id)
+	}
+	params := url.Values{}
+	params.Set("InstanceId.1", instanceID)
+	var result struct {
+		Instances []awsInstance `xml:"reservationSet>item>instancesSet>item"`
+	}
+	if err := c.call(ctx, region, "DescribeInstances", params, &result); err != nil {
+		return nil, err
+	}
+	if len(result.Instances) == 0 {
+		return nil, fmt.Errorf("instance %s not found", instanceID)
+	}
+	return result.Instances[0].toInstance(region), nil
+}
+
+func (i awsInstance) toInstance(region string) *Instance {
+	return &Instance{
+		ID:       region + "/" + i.InstanceID,
+		Status:   i.State.Name,
+		PublicIP: i.IPAddress,
+		Running:  i.State.Name == "running",
+	}
+}
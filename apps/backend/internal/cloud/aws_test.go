@@ -0,0 +1,123 @@
+package cloud
+
+import (
+	"context"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestAWS(t *testing.T, handler http.HandlerFunc) *AWSClient {
+	t.Helper()
+	c, err := NewAWSClient("AKIDEXAMPLE:secret", discardLogger())
+	if err != nil {
+		t.Fatalf("NewAWSClient: %v", err)
+	}
+	baseURL := testAPIServer(t, handler)
+	c.endpoint = func(string) string { return baseURL + "/" }
+	c.now = func() time.Time { return time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC) }
+	return c
+}
+
+func TestAWSSigningKey(t *testing.T) {
+	// Example from the AWS Signature Version 4 documentation.
+	key := awsSigningKey("wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY", "20120215", "us-east-1", "iam")
+	want := "f4780e2d9f65fa895f9c67b32ce1baf0b0d8a43505a000a1a9e090d414db404d"
+	if got := hex.EncodeToString(key); got != want {
+		t.Errorf("signing key = %s, want %s", got, want)
+	}
+}
+
+func TestNewAWSClientRejectsMalformedCredentials(t *testing.T) {
+	if _, err := NewAWSClient("only-an-access-key", discardLogger()); err == nil {
+		t.Error("expected an error for credentials without a secret")
+	}
+}
+
+func TestAWSCreateInstance(t *testing.T) {
+	var actions []string
+	var ingress string
+	c := newTestAWS(t, func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20240501/sa-east-1/ec2/aws4_request") {
+			t.Errorf("unexpected authorization header %q", r.Header.Get("Authorization"))
+		}
+		_ = r.ParseForm()
+		action := r.PostForm.Get("Action")
+		actions = append(actions, action)
+		switch action {
+		case "DescribeImages":
+			_, _ = io.WriteString(w, `<DescribeImagesResponse><imagesSet>
+				<item><imageId>ami-old</imageId><creationDate>2024-01-01T00:00:00.000Z</creationDate></item>
+				<item><imageId>ami-new</imageId><creationDate>2024-04-01T00:00:00.000Z</creationDate></item>
+			</imagesSet></DescribeImagesResponse>`)
+		case "CreateSecurityGroup":
+			_, _ = io.WriteString(w, `<CreateSecurityGroupResponse><groupId>sg-1</groupId></CreateSecurityGroupResponse>`)
+		case "AuthorizeSecurityGroupIngress":
+			ingress = r.PostForm.Encode()
+			_, _ = io.WriteString(w, `<AuthorizeSecurityGroupIngressResponse><return>true</return></AuthorizeSecurityGroupIngressResponse>`)
+		case "RunInstances":
+			if r.PostForm.Get("ImageId") != "ami-new" || r.PostForm.Get("SecurityGroupId.1") != "sg-1" {
+				t.Errorf("unexpected RunInstances params: %v", r.PostForm)
+			}
+			_, _ = io.WriteString(w, `<RunInstancesResponse><instancesSet><item>
+				<instanceId>i-123</instanceId><instanceState><name>pending</name></instanceState>
+			</item></instancesSet></RunInstancesResponse>`)
+		default:
+			_, _ = io.WriteString(w, `<Response/>`)
+		}
+	})
+
+	instance, err := c.CreateInstance(context.Background(), CreateInstanceInput{
+		Name: "web-1", Region: "sa-east-1", SSHPublicKey: "ssh-ed25519 AAAA", OpenPorts: []int{22, 443},
+	})
+	if err != nil {
+		t.Fatalf("CreateInstance: %v", err)
+	}
+	if instance.ID != "sa-east-1/i-123" || instance.Running {
+		t.Errorf("unexpected instance %+v", instance)
+	}
+	if got := strings.Join(actions, ","); got != "DescribeImages,ImportKeyPair,CreateSecurityGroup,AuthorizeSecurityGroupIngress,RunInstances" {
+		t.Errorf("unexpected actions %s", got)
+	}
+	assertContainsParam(t, ingress, "IpPermissions.1.FromPort=22")
+	assertContainsParam(t, ingress, "IpPermissions.2.FromPort=443")
+}
+
+func TestAWSGetInstance(t *testing.T) {
+	c := newTestAWS(t, func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = io.WriteString(w, `<DescribeInstancesResponse><reservationSet><item><instancesSet><item>
+			<instanceId>i-123</instanceId><instanceState><name>running</name></instanceState>
+			<ipAddress>203.0.113.7</ipAddress>
+		</item></instancesSet></item></reservationSet></DescribeInstancesResponse>`)
+	})
+
+	instance, err := c.GetInstance(context.Background(), "sa-east-1/i-123")
+	if err != nil {
+		t.Fatalf("GetInstance: %v", err)
+	}
+	if !instance.Running || instance.PublicIP != "203.0.113.7" {
+		t.Errorf("unexpected instance %+v", instance)
+	}
+}
+
+func TestAWSAPIError(t *testing.T) {
+	c := newTestAWS(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = io.WriteString(w, `<Response><Errors><Error><Code>AuthFailure</Code><Message>AWS was not able to validate the provided access credentials</Message></Error></Errors></Response>`)
+	})
+
+	err := c.VerifyCredentials(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "(AuthFailure)") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func assertContainsParam(t *testing.T, encoded, param string) {
+	t.Helper()
+	if !strings.Contains(encoded, param) {
+		t.Errorf("expected %q in %q", param, encoded)
+	}
+}
@@ -0,0 +1,136 @@
+package cloud
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/paasdeploy/backend/internal/domain"
+)
+
+const httpTimeout = 30 * time.Second
+
+var ErrUnsupportedProvider = errors.New("unsupported cloud provider")
+
+// Instance is the provider-neutral view of a created VM.
+type Instance struct {
+	ID       string
+	Status   string
+	PublicIP string
+	Running  bool
+}
+
+type CreateInstanceInput struct {
+	Name         string
+	Size         string
+	Region       string
+	Image        string
+	SSHPublicKey string
+	// OpenPorts are the inbound TCP ports that must be reachable, for
+	// providers that block traffic by default.
+	OpenPorts []int
+}
+
+// Provider creates VMs that can then be provisioned over SSH as root
+// (or SSHUser) with the injected public key.
+type Provider interface {
+	Name() string
+	SSHUser() string
+	VerifyCredentials(ctx context.Context) error
+	CreateInstance(ctx context.Context, input CreateInstanceInput) (*Instance, error)
+	GetInstance(ctx context.Context, id string) (*Instance, error)
+}
+
+// NewProvider returns the client for provider, authenticated with the
+// credentials the user stored for it.
+func NewProvider(provider, credentials string, logger *slog.Logger) (Provider, error) {
+	switch provider {
+	case domain.CloudProviderHetzner:
+		return NewHetznerClient(credentials, logger), nil
+	case domain.CloudProviderDigitalOcean:
+		return NewDigitalOceanClient(credentials, logger), nil
+	case domain.CloudProviderAWS:
+		return NewAWSClient(credentials, logger)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedProvider, provider)
+	}
+}
+
+// jsonAPI is a token-authenticated JSON REST client, using the Bearer scheme
+// unless authScheme says otherwise, and sending accept as the Accept header
+// when set. errorMessage extracts the provider's error text from a failed
+// response body.
+type jsonAPI struct {
+	name         string
+	baseURL      string
+	token        string
+	authScheme   string
+	accept       string
+	httpClient   *http.Client
+	errorMessage func(body []byte) string
+}
+
+func newJSONAPI(name, baseURL, token string, errorMessage func([]byte) string) jsonAPI {
+	return jsonAPI{
+		name:         name,
+		baseURL:      baseURL,
+		token:        token,
+		authScheme:   "Bearer",
+		httpClient:   &http.Client{Timeout: httpTimeout},
+		errorMessage: errorMessage,
+	}
+}
+
+func (a jsonAPI) do(ctx context.Context, method, path string, body, out any) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("marshal request: %w", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, a.baseURL+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", a.authScheme+" "+a.token)
+	req.Header.Set("Content-Type", "application/json")
+	if a.accept != "" {
+		req.Header.Set("Accept", a.accept)
+	}
+
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		data, _ := io.ReadAll(resp.Body)
+		if msg := a.errorMessage(data); msg != "" {
+			return fmt.Errorf("%s API error: %s", a.name, msg)
+		}
+		return fmt.Errorf("%s API error: status %d", a.name, resp.StatusCode)
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+	return nil
+}
+
+func valueOr(v, fallback string) string {
+	if v == "" {
+		return fallback
+	}
+	return v
+}
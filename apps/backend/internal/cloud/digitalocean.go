@@ -0,0 +1,125 @@
+package cloud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/paasdeploy/backend/internal/domain"
+)
+
+const (
+	digitalOceanBaseURL       = "https://api.digitalocean.com/v2"
+	digitalOceanDefaultSize   = "s-1vcpu-1gb"
+	digitalOceanDefaultRegion = "nyc1"
+	digitalOceanDefaultImage  = "ubuntu-24-04-x64"
+)
+
+type DigitalOceanClient struct {
+	api    jsonAPI
+	logger *slog.Logger
+}
+
+func NewDigitalOceanClient(apiToken string, logger *slog.Logger) *DigitalOceanClient {
+	return &DigitalOceanClient{
+		api:    newJSONAPI("digitalocean", digitalOceanBaseURL, apiToken, digitalOceanErrorMessage),
+		logger: logger.With("component", "digitalocean"),
+	}
+}
+
+type digitalOceanDroplet struct {
+	ID       int64  `json:"id"`
+	Status   string `json:"status"`
+	Networks struct {
+		V4 []struct {
+			IPAddress string `json:"ip_address"`
+			Type      string `json:"type"`
+		} `json:"v4"`
+	} `json:"networks"`
+}
+
+func digitalOceanErrorMessage(body []byte) string {
+	var apiErr struct {
+		ID      string `json:"id"`
+		Message string `json:"message"`
+	}
+	if json.Unmarshal(body, &apiErr) != nil || apiErr.Message == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s (%s)", apiErr.Message, apiErr.ID)
+}
+
+func (c *DigitalOceanClient) Name() string {
+	return domain.CloudProviderDigitalOcean
+}
+
+func (c *DigitalOceanClient) SSHUser() string {
+	return "root"
+}
+
+func (c *DigitalOceanClient) VerifyCredentials(ctx context.Context) error {
+	return c.api.do(ctx, http.MethodGet, "/account", nil, nil)
+}
+
+// CreateInstance registers the public key on the account and creates a
+// droplet with it injected into root's authorized_keys.
+func (c *DigitalOceanClient) CreateInstance(ctx context.Context, input CreateInstanceInput) (*Instance, error) {
+	var key struct {
+		SSHKey struct {
+			ID int64 `json:"id"`
+		} `json:"ssh_key"`
+	}
+	if err := c.api.do(ctx, http.MethodPost, "/account/keys", map[string]string{
+		"name":       "paasdeploy-" + input.Name,
+		"public_key": input.SSHPublicKey,
+	}, &key); err != nil {
+		return nil, fmt.Errorf("upload ssh key: %w", err)
+	}
+
+	body := map[string]any{
+		"name":     input.Name,
+		"size":     valueOr(input.Size, digitalOceanDefaultSize),
+		"region":   valueOr(input.Region, digitalOceanDefaultRegion),
+		"image":    valueOr(input.Image, digitalOceanDefaultImage),
+		"ssh_keys": []int64{key.SSHKey.ID},
+		"tags":     []string{"paasdeploy"},
+	}
+	var created struct {
+		Droplet digitalOceanDroplet `json:"droplet"`
+	}
+	if err := c.api.do(ctx, http.MethodPost, "/droplets", body, &created); err != nil {
+		return nil, fmt.Errorf("create droplet: %w", err)
+	}
+	c.logger.Info("droplet created", "id", created.Droplet.ID, "name", input.Name)
+	return created.Droplet.toInstance(), nil
+}
+
+func (c *DigitalOceanClient) GetInstance(ctx context.Context, id string) (*Instance, error) {
+	var result struct {
+		Droplet digitalOceanDroplet `json:"droplet"`
+	}
+	if err := c.api.do(ctx, http.MethodGet, "/droplets/"+id, nil, &result); err != nil {
+		return nil, err
+	}
+	return result.Droplet.toInstance(), nil
+}
+
+// toInstance picks the public IPv4 address; it is only assigned once the
+// droplet leaves the "new" state.
+func (d digitalOceanDroplet) toInstance() *Instance {
+	instance := &Instance{
+		ID:      strconv.FormatInt(d.ID, 10),
+		Status:  d.Status,
+		Running: d.Status == "active",
+	}
+	for _, n := range d.Networks.V4 {
+		if n.Type == "public" {
+			instance.PublicIP = n.IPAddress
+			break
+		}
+	}
+	return instance
+}
@@ -0,0 +1,59 @@
+package cloud
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func newTestDigitalOcean(t *testing.T, handler http.HandlerFunc) *DigitalOceanClient {
+	t.Helper()
+	c := NewDigitalOceanClient("token", discardLogger())
+	c.api.baseURL = testAPIServer(t, handler)
+	return c
+}
+
+func TestDigitalOceanCreateInstance(t *testing.T) {
+	var dropletBody map[string]any
+	c := newTestDigitalOcean(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/account/keys":
+			_, _ = io.WriteString(w, `{"ssh_key":{"id":512}}`)
+		case "/droplets":
+			_ = json.NewDecoder(r.Body).Decode(&dropletBody)
+			w.WriteHeader(http.StatusAccepted)
+			_, _ = io.WriteString(w, `{"droplet":{"id":3164444,"status":"new","networks":{"v4":[]}}}`)
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	})
+
+	instance, err := c.CreateInstance(context.Background(), CreateInstanceInput{Name: "web-1", Region: "fra1", SSHPublicKey: "ssh-ed25519 AAAA"})
+	if err != nil {
+		t.Fatalf("CreateInstance: %v", err)
+	}
+	if instance.ID != "3164444" || instance.Running || instance.PublicIP != "" {
+		t.Errorf("unexpected instance %+v", instance)
+	}
+	if dropletBody["region"] != "fra1" || dropletBody["size"] != digitalOceanDefaultSize {
+		t.Errorf("unexpected droplet request: %v", dropletBody)
+	}
+}
+
+func TestDigitalOceanGetInstance(t *testing.T) {
+	c := newTestDigitalOcean(t, func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = io.WriteString(w, `{"droplet":{"id":1,"status":"active","networks":{"v4":[
+			{"ip_address":"10.10.0.2","type":"private"},
+			{"ip_address":"203.0.113.9","type":"public"}]}}}`)
+	})
+
+	instance, err := c.GetInstance(context.Background(), "1")
+	if err != nil {
+		t.Fatalf("GetInstance: %v", err)
+	}
+	if !instance.Running || instance.PublicIP != "203.0.113.9" {
+		t.Errorf("unexpected instance %+v", instance)
+	}
+}
@@ -0,0 +1,74 @@
+package cloud
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/paasdeploy/backend/internal/domain"
+)
+
+// dnsRecordTTL is used by providers without an automatic TTL.
+const dnsRecordTTL = 3600
+
+var ErrUnsupportedDNSProvider = errors.New("unsupported DNS provider")
+
+// DNSRecord is the provider-neutral view of a record. Name is the fully
+// qualified name without a trailing dot. Proxied is only honoured by
+// Cloudflare.
+type DNSRecord struct {
+	Type    string
+	Name    string
+	Content string
+	Proxied bool
+}
+
+// DNSProvider manages the records of custom domains in a hosted zone.
+type DNSProvider interface {
+	Name() string
+	VerifyCredentials(ctx context.Context) error
+	// FindZone returns the ID of the hosted zone serving domain.
+	FindZone(ctx context.Context, domain string) (string, error)
+	// UpsertRecord creates the record, or updates the record with the same
+	// name and type, and returns an ID DeleteRecord accepts.
+	UpsertRecord(ctx context.Context, zoneID string, record DNSRecord) (string, error)
+	DeleteRecord(ctx context.Context, zoneID, recordID string) error
+}
+
+// NewDNSProvider returns the client for provider, authenticated with the
+// credentials of the user's DNS connection.
+func NewDNSProvider(provider, credentials string, logger *slog.Logger) (DNSProvider, error) {
+	switch provider {
+	case domain.DNSProviderCloudflare:
+		return NewCloudflareDNSClient(credentials, logger), nil
+	case domain.DNSProviderRoute53:
+		return NewRoute53Client(credentials, logger)
+	case domain.DNSProviderDigitalOcean:
+		return NewDigitalOceanDNSClient(credentials, logger), nil
+	case domain.DNSProviderDeSEC:
+		return NewDeSECClient(credentials, logger), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedDNSProvider, provider)
+	}
+}
+
+// relativeName returns name relative to zone, "@" for the apex.
+func relativeName(name, zone string) string {
+	name = strings.TrimSuffix(strings.ToLower(name), ".")
+	zone = strings.TrimSuffix(strings.ToLower(zone), ".")
+	if name == zone {
+		return "@"
+	}
+	return strings.TrimSuffix(name, "."+zone)
+}
+
+// recordData returns the record content in zone file form, where CNAME
+// targets are fully qualified.
+func recordData(record DNSRecord) string {
+	if record.Type == "CNAME" && !strings.HasSuffix(record.Content, ".") {
+		return record.Content + "."
+	}
+	return record.Content
+}
@@ -0,0 +1,63 @@
+package cloud
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/paasdeploy/backend/internal/cloudflare"
+	"github.com/paasdeploy/backend/internal/domain"
+)
+
+// CloudflareDNSClient adapts the Cloudflare client to DNSProvider. Records
+// get an automatic TTL.
+type CloudflareDNSClient struct {
+	client *cloudflare.Client
+}
+
+func NewCloudflareDNSClient(apiToken string, logger *slog.Logger) *CloudflareDNSClient {
+	return &CloudflareDNSClient{client: cloudflare.NewClient(apiToken, logger)}
+}
+
+func (c *CloudflareDNSClient) Name() string {
+	return domain.DNSProviderCloudflare
+}
+
+func (c *CloudflareDNSClient) VerifyCredentials(ctx context.Context) error {
+	_, err := c.client.VerifyToken(ctx)
+	return err
+}
+
+func (c *CloudflareDNSClient) FindZone(ctx context.Context, domainName string) (string, error) {
+	return c.client.GetZoneID(ctx, domainName)
+}
+
+func (c *CloudflareDNSClient) UpsertRecord(ctx context.Context, zoneID string, record DNSRecord) (string, error) {
+	want := cloudflare.DNSRecord{
+		Type:    record.Type,
+		Name:    record.Name,
+		Content: record.Content,
+		TTL:     1,
+		Proxied: record.Proxied,
+	}
+
+	existing, err := c.client.ListRecords(ctx, zoneID, record.Name)
+	if err != nil {
+		return "", err
+	}
+	for _, r := range existing {
+		if r.Type != record.Type || r.Name != record.Name {
+			continue
+		}
+		if r.Content != want.Content || r.Proxied != want.Proxied {
+			if err := c.client.UpdateRecord(ctx, zoneID, r.ID, want); err != nil {
+				return "", err
+			}
+		}
+		return r.ID, nil
+	}
+	return c.client.CreateOrGetRecord(ctx, zoneID, want)
+}
+
+func (c *CloudflareDNSClient) DeleteRecord(ctx context.Context, zoneID, recordID string) error {
+	return c.client.DeleteRecord(ctx, zoneID, recordID)
+}
@@ -0,0 +1,99 @@
+package cloud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/paasdeploy/backend/internal/domain"
+)
+
+const deSECBaseURL = "https://desec.io/api/v1"
+
+// DeSECClient manages RRsets on deSEC. Zone IDs are domain names and record
+// IDs are "subname/type", since deSEC addresses RRsets rather than records.
+type DeSECClient struct {
+	api    jsonAPI
+	logger *slog.Logger
+}
+
+func NewDeSECClient(apiToken string, logger *slog.Logger) *DeSECClient {
+	api := newJSONAPI("desec", deSECBaseURL, apiToken, deSECErrorMessage)
+	api.authScheme = "Token"
+	return &DeSECClient{
+		api:    api,
+		logger: logger.With("component", "desec"),
+	}
+}
+
+type deSECRRset struct {
+	Subname string   `json:"subname"`
+	Type    string   `json:"type"`
+	TTL     int      `json:"ttl"`
+	Records []string `json:"records"`
+}
+
+func deSECErrorMessage(body []byte) string {
+	var apiErr struct {
+		Detail string `json:"detail"`
+	}
+	if json.Unmarshal(body, &apiErr) != nil || apiErr.Detail == "" {
+		return strings.TrimSpace(string(body))
+	}
+	return apiErr.Detail
+}
+
+func (c *DeSECClient) Name() string {
+	return domain.DNSProviderDeSEC
+}
+
+func (c *DeSECClient) VerifyCredentials(ctx context.Context) error {
+	return c.api.do(ctx, http.MethodGet, "/auth/account/", nil, nil)
+}
+
+// FindZone asks deSEC which of the account's domains is responsible for the
+// name, so subdomains of registered domains resolve too.
+func (c *DeSECClient) FindZone(ctx context.Context, domainName string) (string, error) {
+	var domains []struct {
+		Name string `json:"name"`
+	}
+	path := "/domains/?owns_qname=" + url.QueryEscape(domainName)
+	if err := c.api.do(ctx, http.MethodGet, path, nil, &domains); err != nil {
+		return "", err
+	}
+	if len(domains) == 0 {
+		return "", fmt.Errorf("zone not found for domain: %s", domainName)
+	}
+	return domains[0].Name, nil
+}
+
+func (c *DeSECClient) UpsertRecord(ctx context.Context, zoneID string, record DNSRecord) (string, error) {
+	subname := relativeName(record.Name, zoneID)
+	if subname == "@" {
+		subname = ""
+	}
+	rrsets := []deSECRRset{{
+		Subname: subname,
+		Type:    record.Type,
+		TTL:     dnsRecordTTL,
+		Records: []string{recordData(record)},
+	}}
+	if err := c.api.do(ctx, http.MethodPut, "/domains/"+url.PathEscape(zoneID)+"/rrsets/", rrsets, nil); err != nil {
+		return "", err
+	}
+	c.logger.Info("DNS record saved", "zone", zoneID, "type", record.Type, "name", record.Name)
+	return relativeName(record.Name, zoneID) + "/" + record.Type, nil
+}
+
+func (c *DeSECClient) DeleteRecord(ctx context.Context, zoneID, recordID string) error {
+	subname, recordType, ok := strings.Cut(recordID, "/")
+	if !ok {
+		return fmt.Errorf("invalid deSEC record id: %s", recordID)
+	}
+	path := fmt.Sprintf("/domains/%s/rrsets/%s/%s/", url.PathEscape(zoneID), url.PathEscape(subname), url.PathEscape(recordType))
+	return c.api.do(ctx, http.MethodDelete, path, nil, nil)
+}
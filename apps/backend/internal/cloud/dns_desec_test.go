@@ -0,0 +1,66 @@
+package cloud
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func newTestDeSEC(t *testing.T, handler http.HandlerFunc) *DeSECClient {
+	t.Helper()
+	c := NewDeSECClient("secret", discardLogger())
+	c.api.baseURL = testAPIServer(t, handler)
+	return c
+}
+
+func TestDeSECUsesTokenScheme(t *testing.T) {
+	c := newTestDeSEC(t, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Token secret" {
+			t.Errorf("authorization = %q", got)
+		}
+		_, _ = io.WriteString(w, `[{"name":"example.com"}]`)
+	})
+
+	zone, err := c.FindZone(context.Background(), "app.example.com")
+	if err != nil {
+		t.Fatalf("FindZone: %v", err)
+	}
+	if zone != "example.com" {
+		t.Errorf("zone = %q", zone)
+	}
+}
+
+func TestDeSECUpsertAndDeleteRecord(t *testing.T) {
+	var rrsets []deSECRRset
+	var deletedPath string
+	c := newTestDeSEC(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPut:
+			_ = json.NewDecoder(r.Body).Decode(&rrsets)
+			_, _ = io.WriteString(w, `[]`)
+		case http.MethodDelete:
+			deletedPath = r.URL.Path
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+
+	id, err := c.UpsertRecord(context.Background(), "example.com", DNSRecord{Type: "CNAME", Name: "www.example.com", Content: "example.com"})
+	if err != nil {
+		t.Fatalf("UpsertRecord: %v", err)
+	}
+	if id != "www/CNAME" {
+		t.Errorf("id = %q", id)
+	}
+	if len(rrsets) != 1 || rrsets[0].Subname != "www" || rrsets[0].Records[0] != "example.com." {
+		t.Errorf("unexpected rrsets %+v", rrsets)
+	}
+
+	if err := c.DeleteRecord(context.Background(), "example.com", id); err != nil {
+		t.Fatalf("DeleteRecord: %v", err)
+	}
+	if deletedPath != "/domains/example.com/rrsets/www/CNAME/" {
+		t.Errorf("deleted %q", deletedPath)
+	}
+}
@@ -0,0 +1,95 @@
+package cloud
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/paasdeploy/backend/internal/domain"
+)
+
+// DigitalOceanDNSClient manages records of domains hosted on DigitalOcean
+// DNS. Zone IDs are the domain names themselves.
+type DigitalOceanDNSClient struct {
+	api    jsonAPI
+	logger *slog.Logger
+}
+
+func NewDigitalOceanDNSClient(apiToken string, logger *slog.Logger) *DigitalOceanDNSClient {
+	return &DigitalOceanDNSClient{
+		api:    newJSONAPI("digitalocean", digitalOceanBaseURL, apiToken, digitalOceanErrorMessage),
+		logger: logger.With("component", "digitalocean_dns"),
+	}
+}
+
+type digitalOceanDomainRecord struct {
+	ID   int64  `json:"id,omitempty"`
+	Type string `json:"type"`
+	Name string `json:"name"`
+	Data string `json:"data"`
+	TTL  int    `json:"ttl,omitempty"`
+}
+
+func (c *DigitalOceanDNSClient) Name() string {
+	return domain.DNSProviderDigitalOcean
+}
+
+func (c *DigitalOceanDNSClient) VerifyCredentials(ctx context.Context) error {
+	return c.api.do(ctx, http.MethodGet, "/account", nil, nil)
+}
+
+func (c *DigitalOceanDNSClient) FindZone(ctx context.Context, domainName string) (string, error) {
+	var result struct {
+		Domain struct {
+			Name string `json:"name"`
+		} `json:"domain"`
+	}
+	if err := c.api.do(ctx, http.MethodGet, "/domains/"+url.PathEscape(domainName), nil, &result); err != nil {
+		return "", fmt.Errorf("zone not found for domain %s: %w", domainName, err)
+	}
+	return result.Domain.Name, nil
+}
+
+func (c *DigitalOceanDNSClient) UpsertRecord(ctx context.Context, zoneID string, record DNSRecord) (string, error) {
+	want := digitalOceanDomainRecord{
+		Type: record.Type,
+		Name: relativeName(record.Name, zoneID),
+		Data: recordData(record),
+		TTL:  dnsRecordTTL,
+	}
+
+	query := url.Values{}
+	query.Set("type", record.Type)
+	query.Set("name", record.Name)
+	var existing struct {
+		DomainRecords []digitalOceanDomainRecord `json:"domain_records"`
+	}
+	path := "/domains/" + url.PathEscape(zoneID) + "/records"
+	if err := c.api.do(ctx, http.MethodGet, path+"?"+query.Encode(), nil, &existing); err != nil {
+		return "", err
+	}
+
+	var result struct {
+		DomainRecord digitalOceanDomainRecord `json:"domain_record"`
+	}
+	if len(existing.DomainRecords) > 0 {
+		id := strconv.FormatInt(existing.DomainRecords[0].ID, 10)
+		if err := c.api.do(ctx, http.MethodPut, path+"/"+id, want, &result); err != nil {
+			return "", err
+		}
+		return id, nil
+	}
+
+	if err := c.api.do(ctx, http.MethodPost, path, want, &result); err != nil {
+		return "", err
+	}
+	c.logger.Info("DNS record created", "zone", zoneID, "type", record.Type, "name", record.Name)
+	return strconv.FormatInt(result.DomainRecord.ID, 10), nil
+}
+
+func (c *DigitalOceanDNSClient) DeleteRecord(ctx context.Context, zoneID, recordID string) error {
+	return c.api.do(ctx, http.MethodDelete, "/domains/"+url.PathEscape(zoneID)+"/records/"+url.PathEscape(recordID), nil, nil)
+}
@@ -0,0 +1,69 @@
+package cloud
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func newTestDigitalOceanDNS(t *testing.T, handler http.HandlerFunc) *DigitalOceanDNSClient {
+	t.Helper()
+	c := NewDigitalOceanDNSClient("token", discardLogger())
+	c.api.baseURL = testAPIServer(t, handler)
+	return c
+}
+
+func TestDigitalOceanDNSUpsertCreatesRecord(t *testing.T) {
+	var created digitalOceanDomainRecord
+	c := newTestDigitalOceanDNS(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			if r.URL.Query().Get("name") != "app.example.com" || r.URL.Query().Get("type") != "A" {
+				t.Errorf("unexpected lookup %s", r.URL.RawQuery)
+			}
+			_, _ = io.WriteString(w, `{"domain_records":[]}`)
+		case http.MethodPost:
+			_ = json.NewDecoder(r.Body).Decode(&created)
+			w.WriteHeader(http.StatusCreated)
+			_, _ = io.WriteString(w, `{"domain_record":{"id":42}}`)
+		default:
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	id, err := c.UpsertRecord(context.Background(), "example.com", DNSRecord{Type: "A", Name: "app.example.com", Content: "203.0.113.9"})
+	if err != nil {
+		t.Fatalf("UpsertRecord: %v", err)
+	}
+	if id != "42" {
+		t.Errorf("id = %q, want 42", id)
+	}
+	if created.Name != "app" || created.Data != "203.0.113.9" {
+		t.Errorf("unexpected record %+v", created)
+	}
+}
+
+func TestDigitalOceanDNSUpsertUpdatesExistingRecord(t *testing.T) {
+	var updatedPath string
+	c := newTestDigitalOceanDNS(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			_, _ = io.WriteString(w, `{"domain_records":[{"id":7,"type":"A","name":"app","data":"198.51.100.1"}]}`)
+		case http.MethodPut:
+			updatedPath = r.URL.Path
+			_, _ = io.WriteString(w, `{"domain_record":{"id":7}}`)
+		default:
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	id, err := c.UpsertRecord(context.Background(), "example.com", DNSRecord{Type: "A", Name: "app.example.com", Content: "203.0.113.9"})
+	if err != nil {
+		t.Fatalf("UpsertRecord: %v", err)
+	}
+	if id != "7" || updatedPath != "/domains/example.com/records/7" {
+		t.Errorf("id = %q, updated %q", id, updatedPath)
+	}
+}
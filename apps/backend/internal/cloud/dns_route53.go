@@ -0,0 +1,234 @@
+package cloud
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/paasdeploy/backend/internal/domain"
+)
+
+const (
+	route53BaseURL    = "https://route53.amazonaws.com"
+	route53APIVersion = "/2013-04-01"
+	route53Namespace  = "https://route53.amazonaws.com/doc/2013-04-01/"
+	// route53SigningRegion is fixed: Route 53 is a global service signed
+	// against us-east-1.
+	route53SigningRegion = "us-east-1"
+)
+
+// Route53Client manages records through the Route 53 REST API, signed with
+// Signature Version 4. Record IDs are "type/name", since Route 53 addresses
+// record sets rather than records.
+type Route53Client struct {
+	accessKeyID     string
+	secretAccessKey string
+	baseURL         string
+	httpClient      *http.Client
+	now             func() time.Time
+	logger          *slog.Logger
+}
+
+// NewRoute53Client parses credentials stored as
+// "ACCESS_KEY_ID:SECRET_ACCESS_KEY", like the EC2 client.
+func NewRoute53Client(credentials string, logger *slog.Logger) (*Route53Client, error) {
+	accessKeyID, secret, ok := strings.Cut(strings.TrimSpace(credentials), ":")
+	if !ok || accessKeyID == "" || secret == "" {
+		return nil, errInvalidAWSCredentials
+	}
+	return &Route53Client{
+		accessKeyID:     accessKeyID,
+		secretAccessKey: secret,
+		baseURL:         route53BaseURL,
+		httpClient:      &http.Client{Timeout: httpTimeout},
+		now:             time.Now,
+		logger:          logger.With("component", "route53"),
+	}, nil
+}
+
+type route53ResourceRecord struct {
+	Value string `xml:"Value"`
+}
+
+type route53RecordSet struct {
+	Name            string                  `xml:"Name"`
+	Type            string                  `xml:"Type"`
+	TTL             int                     `xml:"TTL"`
+	ResourceRecords []route53ResourceRecord `xml:"ResourceRecords>ResourceRecord"`
+}
+
+type route53Change struct {
+	Action    string           `xml:"Action"`
+	RecordSet route53RecordSet `xml:"ResourceRecordSet"`
+}
+
+type route53ChangeRequest struct {
+	XMLName xml.Name        `xml:"ChangeResourceRecordSetsRequest"`
+	Xmlns   string          `xml:"xmlns,attr"`
+	Changes []route53Change `xml:"ChangeBatch>Changes>Change"`
+}
+
+type route53ErrorResponse struct {
+	Code    string `xml:"Error>Code"`
+	Message string `xml:"Error>Message"`
+}
+
+func (c *Route53Client) Name() string {
+	return domain.DNSProviderRoute53
+}
+
+func fqdn(name string) string {
+	return strings.TrimSuffix(name, ".") + "."
+}
+
+// call sends one signed request and decodes the XML response into out.
+func (c *Route53Client) call(ctx context.Context, method, path string, query url.Values, body []byte, out any) error {
+	endpoint := c.baseURL + route53APIVersion + path
+	canonicalQuery := strings.ReplaceAll(query.Encode(), "+", "%20")
+	if canonicalQuery != "" {
+		endpoint += "?" + canonicalQuery
+	}
+	u, err := url.Parse(c.baseURL)
+	if err != nil {
+		return err
+	}
+
+	now := c.now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	canonicalRequest := strings.Join([]string{
+		method,
+		route53APIVersion + path,
+		canonicalQuery,
+		"host:" + u.Host,
+		"x-amz-date:" + amzDate,
+		"",
+		"host;x-amz-date",
+		sha256Hex(string(body)),
+	}, "\n")
+	scope := date + "/" + route53SigningRegion + "/route53/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + sha256Hex(canonicalRequest)
+	signature := hmac.New(sha256.New, awsSigningKey(c.secretAccessKey, date, route53SigningRegion, "route53"))
+	signature.Write([]byte(stringToSign))
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	if len(body) > 0 {
+		req.Header.Set("Content-Type", "application/xml")
+	}
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=host;x-amz-date, Signature=%s",
+		c.accessKeyID, scope, hex.EncodeToString(signature.Sum(nil)),
+	))
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("read response: %w", err)
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		var apiErr route53ErrorResponse
+		if xml.Unmarshal(data, &apiErr) == nil && apiErr.Message != "" {
+			return fmt.Errorf("route53 API error: %s (%s)", apiErr.Message, apiErr.Code)
+		}
+		return fmt.Errorf("route53 API error: status %d", resp.StatusCode)
+	}
+	if out == nil {
+		return nil
+	}
+	if err := xml.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+	return nil
+}
+
+func (c *Route53Client) VerifyCredentials(ctx context.Context) error {
+	return c.call(ctx, http.MethodGet, "/hostedzonecount", nil, nil, nil)
+}
+
+func (c *Route53Client) FindZone(ctx context.Context, domainName string) (string, error) {
+	query := url.Values{}
+	query.Set("dnsname", domainName)
+	query.Set("maxitems", "1")
+	var result struct {
+		HostedZones []struct {
+			ID   string `xml:"Id"`
+			Name string `xml:"Name"`
+		} `xml:"HostedZones>HostedZone"`
+	}
+	if err := c.call(ctx, http.MethodGet, "/hostedzonesbyname", query, nil, &result); err != nil {
+		return "", err
+	}
+	if len(result.HostedZones) == 0 || !strings.EqualFold(result.HostedZones[0].Name, fqdn(domainName)) {
+		return "", fmt.Errorf("zone not found for domain: %s", domainName)
+	}
+	return strings.TrimPrefix(result.HostedZones[0].ID, "/hostedzone/"), nil
+}
+
+func (c *Route53Client) changeRecordSet(ctx context.Context, zoneID, action string, set route53RecordSet) error {
+	body, err := xml.Marshal(route53ChangeRequest{
+		Xmlns:   route53Namespace,
+		Changes: []route53Change{{Action: action, RecordSet: set}},
+	})
+	if err != nil {
+		return fmt.Errorf("marshal change: %w", err)
+	}
+	body = append([]byte(xml.Header), body...)
+	return c.call(ctx, http.MethodPost, "/hostedzone/"+url.PathEscape(zoneID)+"/rrset", nil, body, nil)
+}
+
+func (c *Route53Client) UpsertRecord(ctx context.Context, zoneID string, record DNSRecord) (string, error) {
+	set := route53RecordSet{
+		Name:            fqdn(record.Name),
+		Type:            record.Type,
+		TTL:             dnsRecordTTL,
+		ResourceRecords: []route53ResourceRecord{{Value: recordData(record)}},
+	}
+	if err := c.changeRecordSet(ctx, zoneID, "UPSERT", set); err != nil {
+		return "", err
+	}
+	c.logger.Info("DNS record saved", "zone", zoneID, "type", record.Type, "name", record.Name)
+	return record.Type + "/" + strings.TrimSuffix(record.Name, "."), nil
+}
+
+// DeleteRecord looks the record set up first, since Route 53 only deletes a
+// set whose TTL and values match exactly.
+func (c *Route53Client) DeleteRecord(ctx context.Context, zoneID, recordID string) error {
+	recordType, name, ok := strings.Cut(recordID, "/")
+	if !ok {
+		return fmt.Errorf("invalid route53 record id: %s", recordID)
+	}
+
+	query := url.Values{}
+	query.Set("name", fqdn(name))
+	query.Set("type", recordType)
+	query.Set("maxitems", "1")
+	var result struct {
+		RecordSets []route53RecordSet `xml:"ResourceRecordSets>ResourceRecordSet"`
+	}
+	if err := c.call(ctx, http.MethodGet, "/hostedzone/"+url.PathEscape(zoneID)+"/rrset", query, nil, &result); err != nil {
+		return err
+	}
+	if len(result.RecordSets) == 0 || !strings.EqualFold(result.RecordSets[0].Name, fqdn(name)) || result.RecordSets[0].Type != recordType {
+		return nil
+	}
+	return c.changeRecordSet(ctx, zoneID, "DELETE", result.RecordSets[0])
+}
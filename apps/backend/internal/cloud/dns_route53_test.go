@@ -0,0 +1,93 @@
+package cloud
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestRoute53(t *testing.T, handler http.HandlerFunc) *Route53Client {
+	t.Helper()
+	c, err := NewRoute53Client("AKIDEXAMPLE:secret", discardLogger())
+	if err != nil {
+		t.Fatalf("NewRoute53Client: %v", err)
+	}
+	c.baseURL = testAPIServer(t, handler)
+	c.now = func() time.Time { return time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC) }
+	return c
+}
+
+func TestRoute53FindZone(t *testing.T) {
+	c := newTestRoute53(t, func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20240501/us-east-1/route53/aws4_request") {
+			t.Errorf("unexpected authorization header %q", r.Header.Get("Authorization"))
+		}
+		if r.URL.Path != "/2013-04-01/hostedzonesbyname" || r.URL.Query().Get("dnsname") != "example.com" {
+			t.Errorf("unexpected request %s?%s", r.URL.Path, r.URL.RawQuery)
+		}
+		_, _ = io.WriteString(w, `<ListHostedZonesByNameResponse><HostedZones>
+			<HostedZone><Id>/hostedzone/Z123</Id><Name>example.com.</Name></HostedZone>
+		</HostedZones></ListHostedZonesByNameResponse>`)
+	})
+
+	zone, err := c.FindZone(context.Background(), "example.com")
+	if err != nil {
+		t.Fatalf("FindZone: %v", err)
+	}
+	if zone != "Z123" {
+		t.Errorf("zone = %q", zone)
+	}
+}
+
+func TestRoute53FindZoneRejectsOtherZone(t *testing.T) {
+	c := newTestRoute53(t, func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = io.WriteString(w, `<ListHostedZonesByNameResponse><HostedZones>
+			<HostedZone><Id>/hostedzone/Z9</Id><Name>other.com.</Name></HostedZone>
+		</HostedZones></ListHostedZonesByNameResponse>`)
+	})
+
+	if _, err := c.FindZone(context.Background(), "example.com"); err == nil {
+		t.Error("expected an error when the first zone does not match")
+	}
+}
+
+func TestRoute53UpsertAndDeleteRecord(t *testing.T) {
+	var changes []string
+	c := newTestRoute53(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			body, _ := io.ReadAll(r.Body)
+			changes = append(changes, string(body))
+			_, _ = io.WriteString(w, `<ChangeResourceRecordSetsResponse/>`)
+		case http.MethodGet:
+			_, _ = io.WriteString(w, `<ListResourceRecordSetsResponse><ResourceRecordSets><ResourceRecordSet>
+				<Name>app.example.com.</Name><Type>A</Type><TTL>3600</TTL>
+				<ResourceRecords><ResourceRecord><Value>203.0.113.9</Value></ResourceRecord></ResourceRecords>
+			</ResourceRecordSet></ResourceRecordSets></ListResourceRecordSetsResponse>`)
+		}
+	})
+
+	id, err := c.UpsertRecord(context.Background(), "Z123", DNSRecord{Type: "A", Name: "app.example.com", Content: "203.0.113.9"})
+	if err != nil {
+		t.Fatalf("UpsertRecord: %v", err)
+	}
+	if id != "A/app.example.com" {
+		t.Errorf("id = %q", id)
+	}
+	if err := c.DeleteRecord(context.Background(), "Z123", id); err != nil {
+		t.Fatalf("DeleteRecord: %v", err)
+	}
+
+	if len(changes) != 2 {
+		t.Fatalf("expected 2 changes, got %d", len(changes))
+	}
+	if !strings.Contains(changes[0], "<Action>UPSERT</Action>") || !strings.Contains(changes[0], "<Name>app.example.com.</Name>") {
+		t.Errorf("unexpected upsert %s", changes[0])
+	}
+	if !strings.Contains(changes[1], "<Action>DELETE</Action>") || !strings.Contains(changes[1], "<Value>203.0.113.9</Value>") {
+		t.Errorf("unexpected delete %s", changes[1])
+	}
+}
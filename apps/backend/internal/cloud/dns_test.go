@@ -0,0 +1,34 @@
+package cloud
+
+import "testing"
+
+func TestRelativeName(t *testing.T) {
+	tests := []struct {
+		name, zone, want string
+	}{
+		{"example.com", "example.com", "@"},
+		{"Example.com.", "example.com", "@"},
+		{"www.example.com", "example.com", "www"},
+		{"a.b.example.com", "example.com.", "a.b"},
+	}
+	for _, tt := range tests {
+		if got := relativeName(tt.name, tt.zone); got != tt.want {
+			t.Errorf("relativeName(%q, %q) = %q, want %q", tt.name, tt.zone, got, tt.want)
+		}
+	}
+}
+
+func TestRecordDataQualifiesCNAMETargets(t *testing.T) {
+	if got := recordData(DNSRecord{Type: "CNAME", Content: "target.example.net"}); got != "target.example.net." {
+		t.Errorf("CNAME data = %q", got)
+	}
+	if got := recordData(DNSRecord{Type: "A", Content: "203.0.113.9"}); got != "203.0.113.9" {
+		t.Errorf("A data = %q", got)
+	}
+}
+
+func TestNewDNSProviderRejectsUnknownProvider(t *testing.T) {
+	if _, err := NewDNSProvider("bind", "token", discardLogger()); err == nil {
+		t.Error("expected an error for an unsupported provider")
+	}
+}
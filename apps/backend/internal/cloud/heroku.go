@@ -0,0 +1,203 @@
+package cloud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/paasdeploy/backend/internal/domain"
+	"github.com/paasdeploy/shared/pkg/compose"
+)
+
+const (
+	herokuBaseURL = "https://api.heroku.com"
+	herokuAccept  = "application/vnd.heroku+json; version=3"
+)
+
+// HerokuClient reads apps through the Heroku Platform API so they can be
+// recreated here.
+type HerokuClient struct {
+	api    jsonAPI
+	logger *slog.Logger
+}
+
+func NewHerokuClient(apiKey string, logger *slog.Logger) *HerokuClient {
+	api := newJSONAPI("heroku", herokuBaseURL, apiKey, herokuErrorMessage)
+	api.accept = herokuAccept
+	return &HerokuClient{
+		api:    api,
+		logger: logger.With("component", "heroku"),
+	}
+}
+
+func herokuErrorMessage(body []byte) string {
+	var apiErr struct {
+		ID      string `json:"id"`
+		Message string `json:"message"`
+	}
+	if json.Unmarshal(body, &apiErr) != nil || apiErr.Message == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s (%s)", apiErr.Message, apiErr.ID)
+}
+
+// HerokuApp is everything the importer needs from a Heroku app. Buildpacks
+// are in execution order, so the last one is the app's language.
+type HerokuApp struct {
+	Name       string
+	Stack      string
+	ConfigVars map[string]string
+	Buildpacks []string
+	Addons     []HerokuAddon
+	Domains    []string
+}
+
+type HerokuAddon struct {
+	Name       string   `json:"name"`
+	Service    string   `json:"service"`
+	Plan       string   `json:"plan"`
+	ConfigVars []string `json:"configVars"`
+}
+
+// FetchApp loads the app's config vars, buildpacks, add-ons and custom
+// domains.
+func (c *HerokuClient) FetchApp(ctx context.Context, name string) (*HerokuApp, error) {
+	base := "/apps/" + url.PathEscape(name)
+
+	var info struct {
+		Name  string `json:"name"`
+		Stack struct {
+			Name string `json:"name"`
+		} `json:"stack"`
+	}
+	if err := c.api.do(ctx, http.MethodGet, base, nil, &info); err != nil {
+		return nil, fmt.Errorf("get app: %w", err)
+	}
+	app := &HerokuApp{Name: info.Name, Stack: info.Stack.Name}
+
+	if err := c.api.do(ctx, http.MethodGet, base+"/config-vars", nil, &app.ConfigVars); err != nil {
+		return nil, fmt.Errorf("get config vars: %w", err)
+	}
+
+	var buildpacks []struct {
+		Ordinal   int `json:"ordinal"`
+		Buildpack struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+		} `json:"buildpack"`
+	}
+	if err := c.api.do(ctx, http.MethodGet, base+"/buildpack-installations", nil, &buildpacks); err != nil {
+		return nil, fmt.Errorf("get buildpacks: %w", err)
+	}
+	sort.Slice(buildpacks, func(i, j int) bool { return buildpacks[i].Ordinal < buildpacks[j].Ordinal })
+	for _, b := range buildpacks {
+		app.Buildpacks = append(app.Buildpacks, valueOr(b.Buildpack.Name, b.Buildpack.URL))
+	}
+
+	var addons []struct {
+		Name         string   `json:"name"`
+		ConfigVars   []string `json:"config_vars"`
+		AddonService struct {
+			Name string `json:"name"`
+		} `json:"addon_service"`
+		Plan struct {
+			Name string `json:"name"`
+		} `json:"plan"`
+	}
+	if err := c.api.do(ctx, http.MethodGet, base+"/addons", nil, &addons); err != nil {
+		return nil, fmt.Errorf("get addons: %w", err)
+	}
+	for _, a := range addons {
+		app.Addons = append(app.Addons, HerokuAddon{
+			Name:       a.Name,
+			Service:    a.AddonService.Name,
+			Plan:       a.Plan.Name,
+			ConfigVars: a.ConfigVars,
+		})
+	}
+
+	var domains []struct {
+		Hostname string `json:"hostname"`
+		Kind     string `json:"kind"`
+	}
+	if err := c.api.do(ctx, http.MethodGet, base+"/domains", nil, &domains); err != nil {
+		return nil, fmt.Errorf("get domains: %w", err)
+	}
+	for _, d := range domains {
+		if d.Kind == "custom" && !strings.HasPrefix(d.Hostname, "*") {
+			app.Domains = append(app.Domains, d.Hostname)
+		}
+	}
+
+	c.logger.Info("heroku app fetched", "app", app.Name, "configVars", len(app.ConfigVars), "addons", len(app.Addons))
+	return app, nil
+}
+
+// herokuRuntimes maps buildpack name fragments to paasdeploy.json runtimes.
+var herokuRuntimes = []struct {
+	match   string
+	runtime string
+}{
+	{"nodejs", "node"},
+	{"python", "python"},
+	{"ruby", "ruby"},
+	{"php", "php"},
+	{"java", "java"},
+	{"gradle", "java"},
+	{"scala", "java"},
+	{"clojure", "java"},
+	{"dotnet", "dotnet"},
+	{"elixir", "elixir"},
+	{"rust", "rust"},
+	{"go", "go"},
+}
+
+// HerokuRuntime guesses the runtime from the last buildpack that matches a
+// known language, or "other".
+func HerokuRuntime(buildpacks []string) string {
+	for i := len(buildpacks) - 1; i >= 0; i-- {
+		name := strings.ToLower(buildpacks[i])
+		for _, r := range herokuRuntimes {
+			if strings.Contains(name, r.match) {
+				return r.runtime
+			}
+		}
+	}
+	return "other"
+}
+
+// HerokuImport is the plan for recreating a Heroku app: its config vars as
+// secret env vars plus a suggested paasdeploy.json. Add-ons are reported
+// but not migrated; their config vars keep pointing at Heroku.
+type HerokuImport struct {
+	HerokuApp string                     `json:"herokuApp"`
+	EnvVars   []domain.CreateEnvVarInput `json:"envVars"`
+	Config    domain.SuggestedConfig     `json:"config"`
+	Addons    []HerokuAddon              `json:"addons"`
+}
+
+// BuildHerokuImport maps app onto a FlowDeploy app named name, served on
+// the default port.
+func BuildHerokuImport(app *HerokuApp, name string) *HerokuImport {
+	imported := domain.ImportedApp{
+		Name:    app.Name,
+		EnvVars: app.ConfigVars,
+		Domains: app.Domains,
+		Port:    compose.DefaultAppPort,
+	}
+	addons := app.Addons
+	if addons == nil {
+		addons = []HerokuAddon{}
+	}
+	return &HerokuImport{
+		HerokuApp: app.Name,
+		EnvVars:   imported.EnvVarInputs(),
+		Config:    imported.SuggestedConfig(name, HerokuRuntime(app.Buildpacks)),
+		Addons:    addons,
+	}
+}
@@ -0,0 +1,117 @@
+package cloud
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestHerokuFetchApp(t *testing.T) {
+	c := NewHerokuClient("key", discardLogger())
+	c.api.baseURL = testAPIServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept") != herokuAccept {
+			t.Errorf("unexpected Accept header %q", r.Header.Get("Accept"))
+		}
+		switch r.URL.Path {
+		case "/apps/shop":
+			_, _ = io.WriteString(w, `{"name":"shop","stack":{"name":"heroku-24"}}`)
+		case "/apps/shop/config-vars":
+			_, _ = io.WriteString(w, `{"DATABASE_URL":"postgres://u:p@host/db","RAILS_ENV":"production"}`)
+		case "/apps/shop/buildpack-installations":
+			_, _ = io.WriteString(w, `[
+				{"ordinal":1,"buildpack":{"name":"heroku/ruby","url":"heroku/ruby"}},
+				{"ordinal":0,"buildpack":{"name":"heroku/nodejs","url":"heroku/nodejs"}}]`)
+		case "/apps/shop/addons":
+			_, _ = io.WriteString(w, `[{"name":"postgresql-curved-123","config_vars":["DATABASE_URL"],
+				"addon_service":{"name":"heroku-postgresql"},"plan":{"name":"heroku-postgresql:essential-0"}}]`)
+		case "/apps/shop/domains":
+			_, _ = io.WriteString(w, `[
+				{"hostname":"shop.herokuapp.com","kind":"heroku"},
+				{"hostname":"shop.example.com","kind":"custom"},
+				{"hostname":"*.example.com","kind":"custom"}]`)
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	})
+
+	app, err := c.FetchApp(context.Background(), "shop")
+	if err != nil {
+		t.Fatalf("FetchApp: %v", err)
+	}
+	if len(app.Buildpacks) != 2 || app.Buildpacks[1] != "heroku/ruby" {
+		t.Errorf("buildpacks not in ordinal order: %v", app.Buildpacks)
+	}
+	if len(app.Domains) != 1 || app.Domains[0] != "shop.example.com" {
+		t.Errorf("unexpected domains %v", app.Domains)
+	}
+	if len(app.Addons) != 1 || app.Addons[0].Service != "heroku-postgresql" || app.Addons[0].ConfigVars[0] != "DATABASE_URL" {
+		t.Errorf("unexpected addons %+v", app.Addons)
+	}
+	if app.ConfigVars["RAILS_ENV"] != "production" {
+		t.Errorf("unexpected config vars %v", app.ConfigVars)
+	}
+}
+
+func TestHerokuFetchAppError(t *testing.T) {
+	c := NewHerokuClient("key", discardLogger())
+	c.api.baseURL = testAPIServer(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = io.WriteString(w, `{"id":"not_found","message":"Couldn't find that app."}`)
+	})
+
+	_, err := c.FetchApp(context.Background(), "missing")
+	if err == nil || err.Error() != "get app: heroku API error: Couldn't find that app. (not_found)" {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+func TestHerokuRuntime(t *testing.T) {
+	tests := []struct {
+		buildpacks []string
+		want       string
+	}{
+		{nil, "other"},
+		{[]string{"heroku/go"}, "go"},
+		{[]string{"heroku/nodejs", "heroku/python"}, "python"},
+		{[]string{"heroku/python", "https://github.com/heroku/heroku-buildpack-pgbouncer"}, "python"},
+		{[]string{"heroku/gradle"}, "java"},
+		{[]string{"https://github.com/HashNuke/heroku-buildpack-elixir"}, "elixir"},
+	}
+	for _, tt := range tests {
+		if got := HerokuRuntime(tt.buildpacks); got != tt.want {
+			t.Errorf("HerokuRuntime(%v) = %q, want %q", tt.buildpacks, got, tt.want)
+		}
+	}
+}
+
+func TestBuildHerokuImport(t *testing.T) {
+	app := &HerokuApp{
+		Name:       "shop",
+		ConfigVars: map[string]string{"SECRET_KEY_BASE": "abc", "DATABASE_URL": "postgres://db"},
+		Buildpacks: []string{"heroku/ruby"},
+		Domains:    []string{"shop.example.com"},
+	}
+
+	imp := BuildHerokuImport(app, "shop-app")
+	if imp.Config.Name != "shop-app" || imp.Config.Runtime != "ruby" || imp.Config.Port != 8080 {
+		t.Errorf("unexpected config %+v", imp.Config)
+	}
+	if len(imp.Config.Domains) != 1 {
+		t.Errorf("expected custom domains in config, got %v", imp.Config.Domains)
+	}
+	if len(imp.EnvVars) != 3 {
+		t.Fatalf("expected 3 env vars, got %+v", imp.EnvVars)
+	}
+	if imp.EnvVars[0].Key != "DATABASE_URL" || !imp.EnvVars[0].IsSecret {
+		t.Errorf("config vars should be sorted and secret: %+v", imp.EnvVars[0])
+	}
+	if last := imp.EnvVars[2]; last.Key != "PORT" || last.Value != "8080" || last.IsSecret {
+		t.Errorf("expected PORT to be pinned, got %+v", last)
+	}
+
+	app.ConfigVars["PORT"] = "3000"
+	if imp := BuildHerokuImport(app, "shop"); len(imp.EnvVars) != 3 {
+		t.Errorf("existing PORT should not be duplicated: %+v", imp.EnvVars)
+	}
+}
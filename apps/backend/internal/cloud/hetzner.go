@@ -0,0 +1,118 @@
+package cloud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/paasdeploy/backend/internal/domain"
+)
+
+const (
+	hetznerBaseURL       = "https://api.hetzner.cloud/v1"
+	hetznerDefaultSize   = "cx22"
+	hetznerDefaultRegion = "nbg1"
+	hetznerDefaultImage  = "ubuntu-24.04"
+)
+
+type HetznerClient struct {
+	api    jsonAPI
+	logger *slog.Logger
+}
+
+func NewHetznerClient(apiToken string, logger *slog.Logger) *HetznerClient {
+	return &HetznerClient{
+		api:    newJSONAPI("hetzner", hetznerBaseURL, apiToken, hetznerErrorMessage),
+		logger: logger.With("component", "hetzner"),
+	}
+}
+
+type hetznerServer struct {
+	ID        int64  `json:"id"`
+	Status    string `json:"status"`
+	PublicNet struct {
+		IPv4 struct {
+			IP string `json:"ip"`
+		} `json:"ipv4"`
+	} `json:"public_net"`
+}
+
+func hetznerErrorMessage(body []byte) string {
+	var apiErr struct {
+		Error struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if json.Unmarshal(body, &apiErr) != nil || apiErr.Error.Message == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s (%s)", apiErr.Error.Message, apiErr.Error.Code)
+}
+
+func (c *HetznerClient) Name() string {
+	return domain.CloudProviderHetzner
+}
+
+func (c *HetznerClient) SSHUser() string {
+	return "root"
+}
+
+func (c *HetznerClient) VerifyCredentials(ctx context.Context) error {
+	return c.api.do(ctx, http.MethodGet, "/ssh_keys?per_page=1", nil, nil)
+}
+
+// CreateInstance uploads the public key and creates a server that boots
+// with it in root's authorized_keys.
+func (c *HetznerClient) CreateInstance(ctx context.Context, input CreateInstanceInput) (*Instance, error) {
+	var key struct {
+		SSHKey struct {
+			ID int64 `json:"id"`
+		} `json:"ssh_key"`
+	}
+	if err := c.api.do(ctx, http.MethodPost, "/ssh_keys", map[string]string{
+		"name":       "paasdeploy-" + input.Name,
+		"public_key": input.SSHPublicKey,
+	}, &key); err != nil {
+		return nil, fmt.Errorf("upload ssh key: %w", err)
+	}
+
+	body := map[string]any{
+		"name":               input.Name,
+		"server_type":        valueOr(input.Size, hetznerDefaultSize),
+		"location":           valueOr(input.Region, hetznerDefaultRegion),
+		"image":              valueOr(input.Image, hetznerDefaultImage),
+		"ssh_keys":           []int64{key.SSHKey.ID},
+		"start_after_create": true,
+	}
+	var created struct {
+		Server hetznerServer `json:"server"`
+	}
+	if err := c.api.do(ctx, http.MethodPost, "/servers", body, &created); err != nil {
+		return nil, fmt.Errorf("create server: %w", err)
+	}
+	c.logger.Info("hetzner server created", "id", created.Server.ID, "name", input.Name)
+	return created.Server.toInstance(), nil
+}
+
+func (c *HetznerClient) GetInstance(ctx context.Context, id string) (*Instance, error) {
+	var result struct {
+		Server hetznerServer `json:"server"`
+	}
+	if err := c.api.do(ctx, http.MethodGet, "/servers/"+id, nil, &result); err != nil {
+		return nil, err
+	}
+	return result.Server.toInstance(), nil
+}
+
+func (s hetznerServer) toInstance() *Instance {
+	return &Instance{
+		ID:       strconv.FormatInt(s.ID, 10),
+		Status:   s.Status,
+		PublicIP: s.PublicNet.IPv4.IP,
+		Running:  s.Status == "running",
+	}
+}
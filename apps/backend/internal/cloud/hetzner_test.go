@@ -0,0 +1,73 @@
+package cloud
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func testAPIServer(t *testing.T, handler http.HandlerFunc) string {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	return srv.URL
+}
+
+func discardLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+func newTestHetzner(t *testing.T, handler http.HandlerFunc) *HetznerClient {
+	t.Helper()
+	c := NewHetznerClient("token", discardLogger())
+	c.api.baseURL = testAPIServer(t, handler)
+	return c
+}
+
+func TestHetznerCreateInstance(t *testing.T) {
+	var serverBody map[string]any
+	c := newTestHetzner(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			t.Errorf("missing bearer token")
+		}
+		switch r.URL.Path {
+		case "/ssh_keys":
+			_, _ = io.WriteString(w, `{"ssh_key":{"id":42}}`)
+		case "/servers":
+			_ = json.NewDecoder(r.Body).Decode(&serverBody)
+			_, _ = io.WriteString(w, `{"server":{"id":7,"status":"initializing","public_net":{"ipv4":{"ip":"203.0.113.5"}}}}`)
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	})
+
+	instance, err := c.CreateInstance(context.Background(), CreateInstanceInput{Name: "web-1", SSHPublicKey: "ssh-ed25519 AAAA"})
+	if err != nil {
+		t.Fatalf("CreateInstance: %v", err)
+	}
+	if instance.ID != "7" || instance.PublicIP != "203.0.113.5" || instance.Running {
+		t.Errorf("unexpected instance %+v", instance)
+	}
+	if serverBody["server_type"] != hetznerDefaultSize || serverBody["image"] != hetznerDefaultImage {
+		t.Errorf("defaults not applied: %v", serverBody)
+	}
+	if keys, _ := serverBody["ssh_keys"].([]any); len(keys) != 1 || keys[0] != float64(42) {
+		t.Errorf("ssh key not attached: %v", serverBody["ssh_keys"])
+	}
+}
+
+func TestHetznerAPIError(t *testing.T) {
+	c := newTestHetzner(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = io.WriteString(w, `{"error":{"code":"unauthorized","message":"unable to authenticate"}}`)
+	})
+
+	err := c.VerifyCredentials(context.Background())
+	if err == nil || err.Error() != "hetzner API error: unable to authenticate (unauthorized)" {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
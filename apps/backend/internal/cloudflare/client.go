@@ -150,7 +150,19 @@ func (c *Client) CreateARecord(ctx context.Context, zoneID, name, ip string) (st
 }
 
 func (c *Client) CreateOrGetARecord(ctx context.Context, zoneID, name, ip string) (string, error) {
-	recordID, err := c.CreateARecord(ctx, zoneID, name, ip)
+	return c.CreateOrGetRecord(ctx, zoneID, DNSRecord{
+		Type:    "A",
+		Name:    name,
+		Content: ip,
+		TTL:     1,
+		Proxied: false,
+	})
+}
+
+// CreateOrGetRecord creates record, or returns the ID of the record that
+// already exists with the same type and name.
+func (c *Client) CreateOrGetRecord(ctx context.Context, zoneID string, record DNSRecord) (string, error) {
+	recordID, err := c.createRecord(ctx, zoneID, record)
 	if err == nil {
 		return recordID, nil
 	}
@@ -159,15 +171,15 @@ func (c *Client) CreateOrGetARecord(ctx context.Context, zoneID, name, ip string
 		return "", err
 	}
 
-	c.logger.Info("DNS record already exists, fetching existing record", "name", name)
+	c.logger.Info("DNS record already exists, fetching existing record", "name", record.Name)
 
-	records, err := c.ListRecords(ctx, zoneID, name)
+	records, err := c.ListRecords(ctx, zoneID, record.Name)
 	if err != nil {
 		return "", fmt.Errorf("failed to list existing records: %w", err)
 	}
 
 	for _, r := range records {
-		if r.Type == "A" && r.Name == name {
+		if r.Type == record.Type && r.Name == record.Name {
 			c.logger.Info("Found existing DNS record", "record_id", r.ID, "name", r.Name)
 			return r.ID, nil
 		}
@@ -208,6 +220,33 @@ func (c *Client) createRecord(ctx context.Context, zoneID string, record DNSReco
 	return result.Result.ID, nil
 }
 
+func (c *Client) UpdateRecord(ctx context.Context, zoneID, recordID string, record DNSRecord) error {
+	body, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("marshal record: %w", err)
+	}
+
+	path := fmt.Sprintf("/zones/%s/dns_records/%s", zoneID, recordID)
+	resp, err := c.doRequest(ctx, http.MethodPatch, path, strings.NewReader(string(body)))
+	if err != nil {
+		return fmt.Errorf(errRequestFailed, err)
+	}
+	defer resp.Body.Close()
+
+	var result apiResponse[DNSRecord]
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf(errDecodeResponse, err)
+	}
+
+	if !result.Success {
+		return fmt.Errorf(errCloudflareAPI, result.Errors)
+	}
+
+	c.logger.Info("DNS record updated", "record_id", recordID, "type", record.Type, "name", record.Name)
+
+	return nil
+}
+
 func (c *Client) DeleteRecord(ctx context.Context, zoneID, recordID string) error {
 	path := fmt.Sprintf("/zones/%s/dns_records/%s", zoneID, recordID)
 	resp, err := c.doRequest(ctx, http.MethodDelete, path, nil)
@@ -0,0 +1,194 @@
+package cloudflare
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// TunnelCNAMESuffix is appended to a tunnel ID to form the hostname that
+// proxied DNS records point at.
+const TunnelCNAMESuffix = ".cfargotunnel.com"
+
+type Account struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type Tunnel struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type TunnelIngressRule struct {
+	Hostname      string               `json:"hostname,omitempty"`
+	Service       string               `json:"service"`
+	OriginRequest *TunnelOriginRequest `json:"originRequest,omitempty"`
+}
+
+type TunnelOriginRequest struct {
+	NoTLSVerify bool `json:"noTLSVerify,omitempty"`
+}
+
+func TunnelCNAMETarget(tunnelID string) string {
+	return tunnelID + TunnelCNAMESuffix
+}
+
+func decodeResult[T any](resp *http.Response) (T, error) {
+	var result apiResponse[T]
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return result.Result, fmt.Errorf(errDecodeResponse, err)
+	}
+	if !result.Success {
+		return result.Result, fmt.Errorf(errCloudflareAPI, result.Errors)
+	}
+	return result.Result, nil
+}
+
+// DefaultAccountID returns the first account the token has access to.
+// Tunnels live under an account, not a zone.
+func (c *Client) DefaultAccountID(ctx context.Context) (string, error) {
+	resp, err := c.doRequest(ctx, http.MethodGet, "/accounts", nil)
+	if err != nil {
+		return "", fmt.Errorf(errRequestFailed, err)
+	}
+	defer resp.Body.Close()
+
+	accounts, err := decodeResult[[]Account](resp)
+	if err != nil {
+		return "", err
+	}
+	if len(accounts) == 0 {
+		return "", fmt.Errorf("no cloudflare account available for this token")
+	}
+	return accounts[0].ID, nil
+}
+
+// CreateTunnel creates a remotely managed tunnel, so its ingress rules are
+// pushed through the API instead of a config file on the server.
+func (c *Client) CreateTunnel(ctx context.Context, accountID, name string) (*Tunnel, error) {
+	body, err := json.Marshal(map[string]string{"name": name, "config_src": "cloudflare"})
+	if err != nil {
+		return nil, fmt.Errorf("marshal tunnel: %w", err)
+	}
+
+	path := fmt.Sprintf("/accounts/%s/cfd_tunnel", accountID)
+	resp, err := c.doRequest(ctx, http.MethodPost, path, strings.NewReader(string(body)))
+	if err != nil {
+		return nil, fmt.Errorf(errRequestFailed, err)
+	}
+	defer resp.Body.Close()
+
+	tunnel, err := decodeResult[Tunnel](resp)
+	if err != nil {
+		return nil, err
+	}
+
+	c.logger.Info("Tunnel created", "tunnel_id", tunnel.ID, "name", tunnel.Name)
+	return &tunnel, nil
+}
+
+func (c *Client) GetTunnelToken(ctx context.Context, accountID, tunnelID string) (string, error) {
+	path := fmt.Sprintf("/accounts/%s/cfd_tunnel/%s/token", accountID, tunnelID)
+	resp, err := c.doRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return "", fmt.Errorf(errRequestFailed, err)
+	}
+	defer resp.Body.Close()
+
+	return decodeResult[string](resp)
+}
+
+// UpdateTunnelIngress replaces the tunnel ingress so every hostname is sent to
+// service. Requests for any other hostname get a 404 from cloudflared.
+func (c *Client) UpdateTunnelIngress(ctx context.Context, accountID, tunnelID string, hostnames []string, service string) error {
+	rules := make([]TunnelIngressRule, 0, len(hostnames)+1)
+	for _, hostname := range hostnames {
+		rules = append(rules, TunnelIngressRule{
+			Hostname:      hostname,
+			Service:       service,
+			OriginRequest: &TunnelOriginRequest{NoTLSVerify: strings.HasPrefix(service, "https://")},
+		})
+	}
+	rules = append(rules, TunnelIngressRule{Service: "http_status:404"})
+
+	body, err := json.Marshal(map[string]any{"config": map[string]any{"ingress": rules}})
+	if err != nil {
+		return fmt.Errorf("marshal ingress: %w", err)
+	}
+
+	path := fmt.Sprintf("/accounts/%s/cfd_tunnel/%s/configurations", accountID, tunnelID)
+	resp, err := c.doRequest(ctx, http.MethodPut, path, strings.NewReader(string(body)))
+	if err != nil {
+		return fmt.Errorf(errRequestFailed, err)
+	}
+	defer resp.Body.Close()
+
+	if _, err := decodeResult[json.RawMessage](resp); err != nil {
+		return err
+	}
+
+	c.logger.Info("Tunnel ingress updated", "tunnel_id", tunnelID, "hostnames", len(hostnames))
+	return nil
+}
+
+// DeleteTunnel drops any lingering connections first, since Cloudflare
+// refuses to delete a tunnel that still has active connectors.
+func (c *Client) DeleteTunnel(ctx context.Context, accountID, tunnelID string) error {
+	path := fmt.Sprintf("/accounts/%s/cfd_tunnel/%s", accountID, tunnelID)
+
+	resp, err := c.doRequest(ctx, http.MethodDelete, path+"/connections", nil)
+	if err != nil {
+		return fmt.Errorf(errRequestFailed, err)
+	}
+	resp.Body.Close()
+
+	resp, err = c.doRequest(ctx, http.MethodDelete, path, nil)
+	if err != nil {
+		return fmt.Errorf(errRequestFailed, err)
+	}
+	defer resp.Body.Close()
+
+	if _, err := decodeResult[json.RawMessage](resp); err != nil {
+		return err
+	}
+
+	c.logger.Info("Tunnel deleted", "tunnel_id", tunnelID)
+	return nil
+}
+
+// CreateOrGetTunnelCNAME points name at the tunnel through a proxied CNAME.
+// Tunnel hostnames only resolve when proxied through Cloudflare.
+func (c *Client) CreateOrGetTunnelCNAME(ctx context.Context, zoneID, name, tunnelID string) (string, error) {
+	target := TunnelCNAMETarget(tunnelID)
+	recordID, err := c.createRecord(ctx, zoneID, DNSRecord{
+		Type:    "CNAME",
+		Name:    name,
+		Content: target,
+		TTL:     1,
+		Proxied: true,
+	})
+	if err == nil {
+		return recordID, nil
+	}
+
+	if !strings.Contains(err.Error(), "81053") && !strings.Contains(err.Error(), "81058") {
+		return "", err
+	}
+
+	records, err := c.ListRecords(ctx, zoneID, name)
+	if err != nil {
+		return "", fmt.Errorf("failed to list existing records: %w", err)
+	}
+
+	for _, r := range records {
+		if r.Type == "CNAME" && r.Name == name && r.Content == target {
+			c.logger.Info("Found existing DNS record", "record_id", r.ID, "name", r.Name)
+			return r.ID, nil
+		}
+	}
+
+	return "", fmt.Errorf("a conflicting DNS record already exists for %s", name)
+}
@@ -10,16 +10,21 @@ import (
 )
 
 const (
-	DefaultPort              = 8080
-	DefaultDeployWorkers     = 2
-	DefaultDeployTimeoutSec  = 600
-	DefaultHealthTimeoutSec  = 180
-	DefaultHealthRetries     = 5
-	DefaultSessionMaxAgeSec  = 604800
-	DefaultDockerHost        = "unix:///var/run/docker.sock"
-	DefaultFrontendURL       = "http://localhost:3000"
-	DefaultSessionCookieName = "flowdeploy_session"
-	DefaultAppName           = "FlowDeploy"
+	DefaultPort                = 8080
+	DefaultDeployWorkers       = 2
+	DefaultDeployTimeoutSec    = 600
+	DefaultHealthTimeoutSec    = 180
+	DefaultHealthRetries       = 5
+	DefaultTrashRetentionDays  = 7
+	DefaultMeteringMinutes     = 15
+	DefaultStatsIntervalSec    = 3
+	DefaultLogArchiveRegion    = "us-east-1"
+	DefaultLogArchiveThreshold = 1 << 20
+	DefaultSessionMaxAgeSec    = 604800
+	DefaultDockerHost          = "unix:///var/run/docker.sock"
+	DefaultFrontendURL         = "http://localhost:3000"
+	DefaultSessionCookieName   = "flowdeploy_session"
+	DefaultAppName             = "FlowDeploy"
 )
 
 type Config struct {
@@ -32,6 +37,9 @@ type Config struct {
 	Cloudflare CloudflareConfig
 	Traefik    TraefikConfig
 	GRPC       GRPCConfig
+	Quota      QuotaConfig
+	Metering   MeteringConfig
+	LogArchive LogArchiveConfig
 }
 
 type GRPCConfig struct {
@@ -53,7 +61,8 @@ type ServerConfig struct {
 }
 
 type DatabaseConfig struct {
-	URL string
+	URL         string
+	AutoMigrate bool
 }
 
 type DeployConfig struct {
@@ -62,11 +71,24 @@ type DeployConfig struct {
 	Timeout            time.Duration
 	HealthCheckTimeout time.Duration
 	HealthCheckRetries int
+	TrashRetention     time.Duration
+	MaxPerUser         int
+	// MaxBuilds caps the image builds running at once on this host; zero
+	// sizes it from the host's memory.
+	MaxBuilds int
 }
 
 type DockerConfig struct {
 	Host     string
 	Registry string
+	// StatsInterval is how often container stats are sampled unless the
+	// app or its server sets its own interval.
+	StatsInterval time.Duration
+	// SigningKeyPath is the cosign private key images pushed to the
+	// registry are signed with; SigningPublicKey, in PEM, is what images
+	// pulled from it must be signed with. Either may be empty.
+	SigningKeyPath   string
+	SigningPublicKey string
 }
 
 type GitHubConfig struct {
@@ -108,6 +130,36 @@ type TraefikConfig struct {
 	URL string
 }
 
+// QuotaConfig holds the limits applied to every non-admin user. Zero or
+// empty values are unlimited.
+type QuotaConfig struct {
+	MaxApps      int
+	MaxServers   int
+	MaxMemory    string
+	BuildMinutes int
+}
+
+// MeteringConfig selects where usage records go: "db", "webhook" or empty
+// to disable metering.
+type MeteringConfig struct {
+	Sink          string
+	WebhookURL    string
+	WebhookSecret string
+	Interval      time.Duration
+}
+
+// LogArchiveConfig points at the S3 compatible bucket (AWS S3, MinIO, ...)
+// that deployment logs larger than ThresholdBytes are moved to. Archiving is
+// off while Bucket is empty.
+type LogArchiveConfig struct {
+	Endpoint        string
+	Region          string
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+	ThresholdBytes  int
+}
+
 func Load() *Config {
 	return &Config{
 		Server: ServerConfig{
@@ -119,7 +171,8 @@ func Load() *Config {
 			ApiBaseURL:  getEnv("API_BASE_URL", ""),
 		},
 		Database: DatabaseConfig{
-			URL: getEnv("DATABASE_URL", ""),
+			URL:         getEnv("DATABASE_URL", ""),
+			AutoMigrate: getEnv("DB_AUTO_MIGRATE", "true") != "false",
 		},
 		Deploy: DeployConfig{
 			DataDir:            getEnvPath("DEPLOY_DATA_DIR", defaultDataDir()),
@@ -127,10 +180,16 @@ func Load() *Config {
 			Timeout:            time.Duration(getEnvInt("DEPLOY_TIMEOUT", DefaultDeployTimeoutSec)) * time.Second,
 			HealthCheckTimeout: time.Duration(getEnvInt("HEALTH_CHECK_TIMEOUT", DefaultHealthTimeoutSec)) * time.Second,
 			HealthCheckRetries: getEnvInt("HEALTH_CHECK_RETRIES", DefaultHealthRetries),
+			TrashRetention:     time.Duration(getEnvInt("APP_TRASH_RETENTION_DAYS", DefaultTrashRetentionDays)) * 24 * time.Hour,
+			MaxPerUser:         getEnvInt("DEPLOY_MAX_PER_USER", 0),
+			MaxBuilds:          getEnvInt("DEPLOY_MAX_BUILDS", 0),
 		},
 		Docker: DockerConfig{
-			Host:     getEnv("DOCKER_HOST", DefaultDockerHost),
-			Registry: getEnv("DOCKER_REGISTRY", ""),
+			Host:             getEnv("DOCKER_HOST", DefaultDockerHost),
+			Registry:         getEnv("DOCKER_REGISTRY", ""),
+			StatsInterval:    time.Duration(getEnvInt("STATS_INTERVAL_SECONDS", DefaultStatsIntervalSec)) * time.Second,
+			SigningKeyPath:   getEnvPath("IMAGE_SIGNING_KEY_PATH", ""),
+			SigningPublicKey: loadSigningPublicKey(),
 		},
 		GitHub: GitHubConfig{
 			PAT:           getEnv("GIT_HUB_PAT", ""),
@@ -172,6 +231,26 @@ func Load() *Config {
 			AgentPort:                  getEnvInt("AGENT_GRPC_PORT", 50052),
 			AgentTLSInsecureSkipVerify: getEnv("AGENT_TLS_INSECURE_SKIP_VERIFY", "false") == "true",
 		},
+		Quota: QuotaConfig{
+			MaxApps:      getEnvInt("QUOTA_MAX_APPS", 0),
+			MaxServers:   getEnvInt("QUOTA_MAX_SERVERS", 0),
+			MaxMemory:    getEnv("QUOTA_MAX_MEMORY", ""),
+			BuildMinutes: getEnvInt("QUOTA_BUILD_MINUTES", 0),
+		},
+		Metering: MeteringConfig{
+			Sink:          getEnv("METERING_SINK", ""),
+			WebhookURL:    getEnv("METERING_WEBHOOK_URL", ""),
+			WebhookSecret: getEnv("METERING_WEBHOOK_SECRET", ""),
+			Interval:      time.Duration(getEnvInt("METERING_INTERVAL_MINUTES", DefaultMeteringMinutes)) * time.Minute,
+		},
+		LogArchive: LogArchiveConfig{
+			Endpoint:        getEnv("LOG_ARCHIVE_S3_ENDPOINT", ""),
+			Region:          getEnv("LOG_ARCHIVE_S3_REGION", DefaultLogArchiveRegion),
+			Bucket:          getEnv("LOG_ARCHIVE_S3_BUCKET", ""),
+			AccessKeyID:     getEnv("LOG_ARCHIVE_S3_ACCESS_KEY_ID", ""),
+			SecretAccessKey: getEnv("LOG_ARCHIVE_S3_SECRET_ACCESS_KEY", ""),
+			ThresholdBytes:  getEnvInt("LOG_ARCHIVE_THRESHOLD_BYTES", DefaultLogArchiveThreshold),
+		},
 	}
 }
 
@@ -194,6 +273,18 @@ func loadPrivateKey() []byte {
 	return nil
 }
 
+func loadSigningPublicKey() string {
+	keyPath := getEnv("IMAGE_SIGNING_PUBLIC_KEY_PATH", "")
+	if keyPath == "" {
+		return ""
+	}
+	data, err := os.ReadFile(expandPath(keyPath))
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
 func defaultDataDir() string {
 	home, err := os.UserHomeDir()
 	if err != nil {
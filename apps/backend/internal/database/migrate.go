@@ -4,19 +4,61 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"io/fs"
 	"log/slog"
 	"strings"
 
 	"github.com/golang-migrate/migrate/v4"
 	"github.com/golang-migrate/migrate/v4/database/postgres"
-	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/golang-migrate/migrate/v4/source/file"
 )
 
+var (
+	// ErrSchemaNewer means the database was migrated by a newer binary.
+	ErrSchemaNewer = errors.New("database schema is newer than this binary")
+	// ErrSchemaOlder means the database has migrations pending and automatic
+	// migration is disabled.
+	ErrSchemaOlder = errors.New("database schema is older than this binary")
+	// ErrSchemaUnknown means the database version is not one of the
+	// migrations shipped with this binary.
+	ErrSchemaUnknown = errors.New("database schema version is unknown to this binary")
+)
+
+type Migration struct {
+	Version uint
+	Name    string
+	Applied bool
+}
+
+type MigrationStatus struct {
+	Version    uint
+	Dirty      bool
+	Latest     uint
+	Migrations []Migration
+}
+
+func (s MigrationStatus) Pending() int {
+	pending := 0
+	for _, m := range s.Migrations {
+		if !m.Applied {
+			pending++
+		}
+	}
+	return pending
+}
+
 func RunMigrations(db *sql.DB, migrationsPath string, logger *slog.Logger) error {
 	m, err := createMigrateInstance(db, migrationsPath)
 	if err != nil {
 		return err
 	}
+	status, err := readStatus(m, migrationsPath)
+	if err != nil {
+		return err
+	}
+	if err := checkVersion(status); err != nil {
+		return err
+	}
 	if err := runUpWithDirtyRetry(m, logger); err != nil {
 		return err
 	}
@@ -25,6 +67,160 @@ func RunMigrations(db *sql.DB, migrationsPath string, logger *slog.Logger) error
 	return nil
 }
 
+// CheckSchema verifies that the database is at exactly the latest version
+// shipped with the binary, for installs that run migrations separately.
+func CheckSchema(db *sql.DB, migrationsPath string) error {
+	status, err := MigrationsStatus(db, migrationsPath)
+	if err != nil {
+		return err
+	}
+	if err := checkVersion(status); err != nil {
+		return err
+	}
+	if status.Dirty {
+		return fmt.Errorf("database is dirty at version %d", status.Version)
+	}
+	if pending := status.Pending(); pending > 0 {
+		return fmt.Errorf("%w: %d migrations pending, run `api migrate up`", ErrSchemaOlder, pending)
+	}
+	return nil
+}
+
+// MigrateDown rolls back the given number of applied migrations.
+func MigrateDown(db *sql.DB, migrationsPath string, steps int, logger *slog.Logger) error {
+	if steps < 1 {
+		return fmt.Errorf("steps must be at least 1")
+	}
+	m, err := createMigrateInstance(db, migrationsPath)
+	if err != nil {
+		return err
+	}
+	status, err := readStatus(m, migrationsPath)
+	if err != nil {
+		return err
+	}
+	if err := checkVersion(status); err != nil {
+		return err
+	}
+	if status.Dirty {
+		return fmt.Errorf("database is dirty at version %d, fix it before rolling back", status.Version)
+	}
+	if applied := len(status.Migrations) - status.Pending(); steps > applied {
+		return fmt.Errorf("cannot roll back %d migrations, only %d applied", steps, applied)
+	}
+
+	if err := m.Steps(-steps); err != nil {
+		return fmt.Errorf("failed to roll back migrations: %w", err)
+	}
+	version, dirty, _ := m.Version()
+	logger.Info("Migrations rolled back", "steps", steps, "version", version, "dirty", dirty)
+	return nil
+}
+
+// MigrateUpTo applies the pending migrations up to and including version,
+// leaving later ones pending.
+func MigrateUpTo(db *sql.DB, migrationsPath string, version uint, logger *slog.Logger) error {
+	m, err := createMigrateInstance(db, migrationsPath)
+	if err != nil {
+		return err
+	}
+	status, err := readStatus(m, migrationsPath)
+	if err != nil {
+		return err
+	}
+	if err := checkVersion(status); err != nil {
+		return err
+	}
+	if status.Version > version {
+		return fmt.Errorf("%w: database is at version %d, wanted %d", ErrSchemaNewer, status.Version, version)
+	}
+	if err := checkVersion(MigrationStatus{Version: version, Latest: status.Latest, Migrations: status.Migrations}); err != nil {
+		return err
+	}
+
+	if err := m.Migrate(version); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return fmt.Errorf("failed to run migrations: %w", err)
+	}
+	logger.Info("Migrations applied", "version", version)
+	return nil
+}
+
+// MigrationsStatus lists the migrations shipped with the binary and whether
+// each one is applied to the database.
+func MigrationsStatus(db *sql.DB, migrationsPath string) (MigrationStatus, error) {
+	m, err := createMigrateInstance(db, migrationsPath)
+	if err != nil {
+		return MigrationStatus{}, err
+	}
+	return readStatus(m, migrationsPath)
+}
+
+func readStatus(m *migrate.Migrate, migrationsPath string) (MigrationStatus, error) {
+	migrations, err := loadMigrations(migrationsPath)
+	if err != nil {
+		return MigrationStatus{}, err
+	}
+
+	version, dirty, err := m.Version()
+	if err != nil && !errors.Is(err, migrate.ErrNilVersion) {
+		return MigrationStatus{}, fmt.Errorf("failed to read schema version: %w", err)
+	}
+
+	return buildStatus(version, dirty, migrations), nil
+}
+
+func buildStatus(version uint, dirty bool, migrations []Migration) MigrationStatus {
+	status := MigrationStatus{Version: version, Dirty: dirty, Migrations: migrations}
+	for i := range status.Migrations {
+		status.Migrations[i].Applied = status.Migrations[i].Version <= version
+	}
+	if len(migrations) > 0 {
+		status.Latest = migrations[len(migrations)-1].Version
+	}
+	return status
+}
+
+// checkVersion refuses databases migrated past the binary's latest
+// migration or to a version the binary does not ship.
+func checkVersion(status MigrationStatus) error {
+	if status.Version == 0 {
+		return nil
+	}
+	if status.Version > status.Latest {
+		return fmt.Errorf("%w: database is at version %d, binary supports up to %d", ErrSchemaNewer, status.Version, status.Latest)
+	}
+	for _, m := range status.Migrations {
+		if m.Version == status.Version {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %d", ErrSchemaUnknown, status.Version)
+}
+
+func loadMigrations(migrationsPath string) ([]Migration, error) {
+	src, err := (&file.File{}).Open(fmt.Sprintf("file://%s", migrationsPath))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open migrations: %w", err)
+	}
+	defer src.Close()
+
+	var migrations []Migration
+	version, err := src.First()
+	for err == nil {
+		body, name, readErr := src.ReadUp(version)
+		if readErr != nil {
+			return nil, fmt.Errorf("failed to read migration %d: %w", version, readErr)
+		}
+		body.Close()
+		migrations = append(migrations, Migration{Version: version, Name: name})
+		version, err = src.Next(version)
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("failed to list migrations: %w", err)
+	}
+	return migrations, nil
+}
+
 func createMigrateInstance(db *sql.DB, migrationsPath string) (*migrate.Migrate, error) {
 	driver, err := postgres.WithInstance(db, &postgres.Config{})
 	if err != nil {
@@ -0,0 +1,91 @@
+package database
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeMigrations(t *testing.T, names ...string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for _, name := range names {
+		for _, suffix := range []string{"up", "down"} {
+			path := filepath.Join(dir, name+"."+suffix+".sql")
+			if err := os.WriteFile(path, []byte("SELECT 1;"), 0o644); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	return dir
+}
+
+func TestLoadMigrations(t *testing.T) {
+	dir := writeMigrations(t, "000002_add_users", "000001_init", "000003_add_apps")
+
+	migrations, err := loadMigrations(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(migrations) != 3 {
+		t.Fatalf("got %d migrations, want 3", len(migrations))
+	}
+	for i, want := range []Migration{{1, "init", false}, {2, "add_users", false}, {3, "add_apps", false}} {
+		if migrations[i] != want {
+			t.Errorf("migration %d = %+v, want %+v", i, migrations[i], want)
+		}
+	}
+}
+
+func TestLoadMigrationsEmpty(t *testing.T) {
+	migrations, err := loadMigrations(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(migrations) != 0 {
+		t.Fatalf("got %d migrations, want none", len(migrations))
+	}
+}
+
+func TestBuildStatus(t *testing.T) {
+	migrations := []Migration{{Version: 1}, {Version: 2}, {Version: 5}}
+
+	status := buildStatus(2, false, migrations)
+	if status.Latest != 5 {
+		t.Errorf("Latest = %d, want 5", status.Latest)
+	}
+	if got := status.Pending(); got != 1 {
+		t.Errorf("Pending() = %d, want 1", got)
+	}
+	if !status.Migrations[1].Applied || status.Migrations[2].Applied {
+		t.Errorf("unexpected applied flags: %+v", status.Migrations)
+	}
+}
+
+func TestCheckVersion(t *testing.T) {
+	migrations := []Migration{{Version: 1}, {Version: 2}, {Version: 5}}
+
+	tests := []struct {
+		name    string
+		version uint
+		want    error
+	}{
+		{"empty database", 0, nil},
+		{"behind", 1, nil},
+		{"latest", 5, nil},
+		{"newer than binary", 6, ErrSchemaNewer},
+		{"unknown version", 3, ErrSchemaUnknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkVersion(buildStatus(tt.version, false, migrations))
+			if tt.want == nil && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.want != nil && !errors.Is(err, tt.want) {
+				t.Fatalf("got %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -9,11 +9,14 @@ import (
 	"github.com/paasdeploy/backend/internal/agentclient"
 	"github.com/paasdeploy/backend/internal/agentdownload"
 	"github.com/paasdeploy/backend/internal/config"
+	"github.com/paasdeploy/backend/internal/diagnostics"
 	"github.com/paasdeploy/backend/internal/domain"
 	"github.com/paasdeploy/backend/internal/engine"
 	"github.com/paasdeploy/backend/internal/ghclient"
+	"github.com/paasdeploy/backend/internal/gitops"
 	"github.com/paasdeploy/backend/internal/grpcserver"
 	"github.com/paasdeploy/backend/internal/handler"
+	"github.com/paasdeploy/backend/internal/metering"
 	"github.com/paasdeploy/backend/internal/middleware"
 	"github.com/paasdeploy/backend/internal/server"
 	"github.com/paasdeploy/backend/internal/service"
@@ -46,11 +49,16 @@ type Application struct {
 	GrpcServer             *grpcserver.Server
 	HealthHandler          *handler.HealthHandler
 	AppHandler             *handler.AppHandler
+	AppSpecHandler         *handler.AppSpecHandler
+	GitOpsHandler          *handler.GitOpsHandler
+	GitOpsController       *gitops.Controller
 	SSEHandler             *handler.SSEHandler
 	SwaggerHandler         *handler.SwaggerHandler
+	ConfigHandler          *handler.ConfigHandler
 	EnvVarHandler          *handler.EnvVarHandler
 	ContainerHealthHandler *handler.ContainerHealthHandler
 	AppAdminHandler        *handler.AppAdminHandler
+	AppBulkHandler         *handler.AppBulkHandler
 	WebhookHandler         *ghclient.WebhookHandler
 	AuthHandler            *handler.AuthHandler
 	GitHubHandler          *handler.GitHubHandler
@@ -59,20 +67,37 @@ type Application struct {
 	DomainHandler          *handler.DomainHandler
 	MigrationHandler       *handler.MigrationHandler
 	ContainerHandler       *handler.ContainerHandler
+	SearchHandler          *handler.SearchHandler
+	QuotaHandler           *handler.QuotaHandler
 	ContainerExecHandler   *handler.ContainerExecHandler
 	TemplateHandler        *handler.TemplateHandler
 	ImageHandler           *handler.ImageHandler
 	CertificateHandler     *handler.CertificateHandler
+	AppService             *service.AppService
 	AuditService           *service.AuditService
 	AuditHandler           *handler.AuditHandler
+	APITokenHandler        *handler.APITokenHandler
 	ResourceHandler        *handler.ResourceHandler
 	NotificationService    *service.NotificationService
 	NotificationHandler    *handler.NotificationHandler
+	AppWebhookService      *service.AppWebhookService
+	AppWebhookHandler      *handler.AppWebhookHandler
+	DeploymentSBOMHandler  *handler.DeploymentSBOMHandler
+	ServerTaskHandler      *handler.ServerTaskHandler
+	ServerTaskScheduler    *engine.ServerTaskScheduler
 	ServerHandler          *handler.ServerHandler
 	SystemHandler          *handler.SystemHandler
+	Diagnostics            *diagnostics.Checker
 	AgentDownloadHandler   *agentdownload.Handler
+	AgentBootstrapHandler  *agentdownload.BootstrapHandler
 	CleanupHandler         *handler.CleanupHandler
 	ContainerSSLHandler    *handler.ContainerSSLHandler
 	ServerRepo             domain.ServerRepository
+	ServerHeartbeatRepo    domain.ServerHeartbeatRepository
+	AppRepo                domain.AppRepository
+	CustomDomainRepo       domain.CustomDomainRepository
+	CertificateAlertRepo   domain.CertificateExpiryAlertRepository
+	DeploymentRepo         domain.DeploymentRepository
+	UsageSink              metering.Sink
 	AgentClient            *agentclient.AgentClient
 }
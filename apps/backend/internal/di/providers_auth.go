@@ -97,11 +97,13 @@ func ProvideGitHubAppClient(cfg *config.Config, logger *slog.Logger) *ghclient.A
 func ProvideAuthMiddleware(
 	cfg *config.Config,
 	sessionRepo domain.SessionRepository,
+	apiTokenRepo domain.APITokenRepository,
 	userRepo domain.UserRepository,
 	logger *slog.Logger,
 ) *middleware.AuthMiddleware {
 	return middleware.NewAuthMiddleware(middleware.AuthMiddlewareConfig{
 		SessionRepo:       sessionRepo,
+		APITokenRepo:      apiTokenRepo,
 		UserRepo:          userRepo,
 		Logger:            logger,
 		SessionCookieName: cfg.Auth.SessionCookieName,
@@ -17,6 +17,7 @@ import (
 	"github.com/paasdeploy/backend/internal/domain"
 	"github.com/paasdeploy/backend/internal/ghclient"
 	"github.com/paasdeploy/backend/internal/repository"
+	"github.com/paasdeploy/shared/pkg/tracing"
 )
 
 var ConfigSet = wire.NewSet(
@@ -36,6 +37,18 @@ var RepositorySet = wire.NewSet(
 	wire.Bind(new(domain.AppRepository), new(*repository.PostgresAppRepository)),
 	repository.NewPostgresCertificateAuthorityRepository,
 	wire.Bind(new(domain.CertificateAuthorityRepository), new(*repository.PostgresCertificateAuthorityRepository)),
+	repository.NewPostgresCertificateRevocationRepository,
+	wire.Bind(new(domain.CertificateRevocationRepository), new(*repository.PostgresCertificateRevocationRepository)),
+	repository.NewPostgresAgentCommandRepository,
+	wire.Bind(new(domain.AgentCommandRepository), new(*repository.PostgresAgentCommandRepository)),
+	repository.NewPostgresServerHeartbeatRepository,
+	wire.Bind(new(domain.ServerHeartbeatRepository), new(*repository.PostgresServerHeartbeatRepository)),
+	repository.NewPostgresServerBootstrapTokenRepository,
+	wire.Bind(new(domain.ServerBootstrapTokenRepository), new(*repository.PostgresServerBootstrapTokenRepository)),
+	repository.NewPostgresServerFirewallRepository,
+	wire.Bind(new(domain.ServerFirewallRepository), new(*repository.PostgresServerFirewallRepository)),
+	repository.NewPostgresCloudCredentialRepository,
+	wire.Bind(new(domain.CloudCredentialRepository), new(*repository.PostgresCloudCredentialRepository)),
 	repository.NewPostgresDeploymentRepository,
 	wire.Bind(new(domain.DeploymentRepository), new(*repository.PostgresDeploymentRepository)),
 	repository.NewPostgresEnvVarRepository,
@@ -44,6 +57,10 @@ var RepositorySet = wire.NewSet(
 	wire.Bind(new(domain.UserRepository), new(*repository.PostgresUserRepository)),
 	repository.NewPostgresSessionRepository,
 	wire.Bind(new(domain.SessionRepository), new(*repository.PostgresSessionRepository)),
+	repository.NewPostgresAPITokenRepository,
+	wire.Bind(new(domain.APITokenRepository), new(*repository.PostgresAPITokenRepository)),
+	repository.NewPostgresGitOpsSourceRepository,
+	wire.Bind(new(domain.GitOpsSourceRepository), new(*repository.PostgresGitOpsSourceRepository)),
 	repository.NewPostgresInstallationRepository,
 	wire.Bind(new(domain.InstallationRepository), new(*repository.PostgresInstallationRepository)),
 	repository.NewPostgresCloudflareConnectionRepository,
@@ -54,12 +71,32 @@ var RepositorySet = wire.NewSet(
 	wire.Bind(new(domain.NotificationChannelRepository), new(*repository.PostgresNotificationChannelRepository)),
 	repository.NewPostgresNotificationRuleRepository,
 	wire.Bind(new(domain.NotificationRuleRepository), new(*repository.PostgresNotificationRuleRepository)),
+	repository.NewPostgresAppWebhookRepository,
+	wire.Bind(new(domain.AppWebhookRepository), new(*repository.PostgresAppWebhookRepository)),
 	repository.NewPostgresServerRepository,
 	wire.Bind(new(domain.ServerRepository), new(*repository.PostgresServerRepository)),
 	repository.NewPostgresWebhookPayloadRepository,
 	wire.Bind(new(ghclient.WebhookPayloadStore), new(*repository.PostgresWebhookPayloadRepository)),
 	repository.NewPostgresCleanupLogRepository,
 	wire.Bind(new(domain.CleanupLogRepository), new(*repository.PostgresCleanupLogRepository)),
+	repository.NewPostgresCertificateExpiryAlertRepository,
+	wire.Bind(new(domain.CertificateExpiryAlertRepository), new(*repository.PostgresCertificateExpiryAlertRepository)),
+	repository.NewPostgresServerTunnelRepository,
+	wire.Bind(new(domain.ServerTunnelRepository), new(*repository.PostgresServerTunnelRepository)),
+	repository.NewPostgresDNSConnectionRepository,
+	wire.Bind(new(domain.DNSConnectionRepository), new(*repository.PostgresDNSConnectionRepository)),
+	repository.NewPostgresDomainVerificationRepository,
+	wire.Bind(new(domain.DomainVerificationRepository), new(*repository.PostgresDomainVerificationRepository)),
+	repository.NewPostgresExecSessionRepository,
+	wire.Bind(new(domain.ExecSessionRepository), new(*repository.PostgresExecSessionRepository)),
+	repository.NewPostgresUsageRecordRepository,
+	wire.Bind(new(domain.UsageRecordRepository), new(*repository.PostgresUsageRecordRepository)),
+	repository.NewPostgresAppHealthEventRepository,
+	wire.Bind(new(domain.AppHealthEventRepository), new(*repository.PostgresAppHealthEventRepository)),
+	repository.NewPostgresDeploymentSBOMRepository,
+	wire.Bind(new(domain.DeploymentSBOMRepository), new(*repository.PostgresDeploymentSBOMRepository)),
+	repository.NewPostgresServerTaskRepository,
+	wire.Bind(new(domain.ServerTaskRepository), new(*repository.PostgresServerTaskRepository)),
 )
 
 func ProvideConfig() (*config.Config, error) {
@@ -70,20 +107,29 @@ func ProvideConfig() (*config.Config, error) {
 	return cfg, nil
 }
 
-func ProvideLogger(cfg *config.Config) *slog.Logger {
-	var logLevel slog.Level
-	switch cfg.Server.LogLevel {
+// logLevel is shared by every logger so the level can change at runtime.
+var logLevel = new(slog.LevelVar)
+
+func parseLogLevel(level string) slog.Level {
+	switch level {
 	case "debug":
-		logLevel = slog.LevelDebug
-	case "info":
-		logLevel = slog.LevelInfo
+		return slog.LevelDebug
 	case "warn":
-		logLevel = slog.LevelWarn
+		return slog.LevelWarn
 	case "error":
-		logLevel = slog.LevelError
+		return slog.LevelError
 	default:
-		logLevel = slog.LevelInfo
+		return slog.LevelInfo
 	}
+}
+
+// SetLogLevel changes the level of the loggers created by ProvideLogger.
+func SetLogLevel(level string) {
+	logLevel.Set(parseLogLevel(level))
+}
+
+func ProvideLogger(cfg *config.Config) *slog.Logger {
+	SetLogLevel(cfg.Server.LogLevel)
 
 	var handler slog.Handler
 
@@ -98,7 +144,7 @@ func ProvideLogger(cfg *config.Config) *slog.Logger {
 		})
 	}
 
-	return slog.New(handler)
+	return slog.New(tracing.NewHandler(handler))
 }
 
 const (
@@ -16,6 +16,8 @@ import (
 	"github.com/paasdeploy/backend/internal/ghclient"
 	"github.com/paasdeploy/backend/internal/grpcserver"
 	"github.com/paasdeploy/backend/internal/handler"
+	"github.com/paasdeploy/backend/internal/logarchive"
+	"github.com/paasdeploy/backend/internal/metering"
 	"github.com/paasdeploy/backend/internal/pki"
 	"github.com/paasdeploy/backend/internal/provisioner"
 	"github.com/paasdeploy/backend/internal/server"
@@ -23,6 +25,8 @@ import (
 
 var EngineSet = wire.NewSet(
 	ProvideGitTokenProvider,
+	ProvideUsageSink,
+	ProvideLogArchive,
 	engine.New,
 )
 
@@ -34,6 +38,7 @@ var ServerSet = wire.NewSet(
 var AgentDownloadSet = wire.NewSet(
 	agentdownload.NewTokenStore,
 	ProvideAgentDownloadHandler,
+	ProvideAgentBootstrapHandler,
 )
 
 var ProvisionerSet = wire.NewSet(
@@ -55,6 +60,25 @@ func ProvideGitTokenProvider(
 	return engine.NewAppGitTokenProvider(appClient, installationRepo, logger)
 }
 
+func ProvideLogArchive(cfg *config.Config, repo domain.DeploymentRepository, logger *slog.Logger) (*logarchive.Archiver, error) {
+	archive, err := logarchive.New(cfg.LogArchive, repo, logger)
+	if err != nil {
+		return nil, fmt.Errorf("invalid log archive config: %w", err)
+	}
+	if archive == nil {
+		logger.Info("deployment log archive disabled: LOG_ARCHIVE_S3_BUCKET not set")
+	}
+	return archive, nil
+}
+
+func ProvideUsageSink(cfg *config.Config, repo domain.UsageRecordRepository) (metering.Sink, error) {
+	sink, err := metering.NewSink(cfg.Metering, repo)
+	if err != nil {
+		return nil, fmt.Errorf("invalid metering config: %w", err)
+	}
+	return sink, nil
+}
+
 const (
 	httpReadTimeout  = 15 * time.Second
 	httpWriteTimeout = 10 * time.Minute
@@ -125,6 +149,8 @@ func ProvideSSHProvisioner(
 	cfg *config.Config,
 	logger *slog.Logger,
 	serverRepo domain.ServerRepository,
+	firewallRepo domain.ServerFirewallRepository,
+	grpcServer *grpcserver.Server,
 ) *provisioner.SSHProvisioner {
 	serverAddr := cfg.GRPC.ServerAddr
 	if serverAddr == "" && cfg.GRPC.Port > 0 {
@@ -133,25 +159,33 @@ func ProvideSSHProvisioner(
 			serverAddr = fmt.Sprintf("localhost:%d", cfg.GRPC.Port)
 		}
 	}
-	return provisioner.NewSSHProvisioner(provisioner.SSHProvisionerConfig{
+	provCfg := provisioner.SSHProvisionerConfig{
 		CA:              ca,
 		ServerAddr:      serverAddr,
 		AgentBinaryPath: cfg.GRPC.AgentBinaryPath,
 		AgentPort:       cfg.GRPC.AgentPort,
 		Logger:          logger,
 		HostKeyStore:    serverRepo,
-	})
+		FirewallStore:   firewallRepo,
+	}
+	if grpcServer != nil {
+		provCfg.CertRevocations = grpcServer
+	}
+	return provisioner.NewSSHProvisioner(provCfg)
 }
 
 func ProvideGrpcServer(
 	cfg *config.Config,
 	ca *pki.CertificateAuthority,
 	serverRepo domain.ServerRepository,
+	heartbeatRepo domain.ServerHeartbeatRepository,
+	revocationRepo domain.CertificateRevocationRepository,
+	commandRepo domain.AgentCommandRepository,
 	agentTokenStore *agentdownload.TokenStore,
 	sseHandler *handler.SSEHandler,
 	logger *slog.Logger,
 ) *grpcserver.Server {
-	server, err := grpcserver.NewServer(cfg, ca, serverRepo, agentTokenStore, sseHandler, logger)
+	server, err := grpcserver.NewServer(cfg, ca, serverRepo, heartbeatRepo, revocationRepo, commandRepo, agentTokenStore, sseHandler, logger)
 	if err != nil {
 		logger.Error("failed to create gRPC server", "error", err)
 		return nil
@@ -166,3 +200,13 @@ func ProvideAgentDownloadHandler(
 ) *agentdownload.Handler {
 	return agentdownload.NewHandler(store, cfg.GRPC.AgentBinaryPath, logger)
 }
+
+func ProvideAgentBootstrapHandler(
+	tokenRepo domain.ServerBootstrapTokenRepository,
+	serverRepo domain.ServerRepository,
+	ca *pki.CertificateAuthority,
+	cfg *config.Config,
+	logger *slog.Logger,
+) *agentdownload.BootstrapHandler {
+	return agentdownload.NewBootstrapHandler(tokenRepo, serverRepo, ca, cfg.GRPC.AgentBinaryPath, logger)
+}
@@ -2,18 +2,24 @@ package di
 
 import (
 	"database/sql"
+	"fmt"
 	"log/slog"
+	"strings"
 
 	"github.com/google/wire"
 
 	"github.com/paasdeploy/backend/internal/agentclient"
+	"github.com/paasdeploy/backend/internal/backup"
 	"github.com/paasdeploy/backend/internal/config"
 	"github.com/paasdeploy/backend/internal/crypto"
+	"github.com/paasdeploy/backend/internal/diagnostics"
 	"github.com/paasdeploy/backend/internal/domain"
 	"github.com/paasdeploy/backend/internal/engine"
 	"github.com/paasdeploy/backend/internal/ghclient"
+	"github.com/paasdeploy/backend/internal/gitops"
 	"github.com/paasdeploy/backend/internal/grpcserver"
 	"github.com/paasdeploy/backend/internal/handler"
+	"github.com/paasdeploy/backend/internal/logarchive"
 	"github.com/paasdeploy/backend/internal/provisioner"
 	"github.com/paasdeploy/backend/internal/repository"
 	"github.com/paasdeploy/backend/internal/service"
@@ -28,29 +34,49 @@ var WebhookSet = wire.NewSet(
 var ServiceSet = wire.NewSet(
 	ProvideAppCleaner,
 	ProvideAppService,
+	ProvideAppSpecService,
+	ProvideGitOpsController,
 	ProvideNotificationService,
+	ProvideServerTaskScheduler,
+	service.NewAppWebhookService,
+	ProvideTunnelService,
+	service.NewSearchService,
 )
 
 var HandlerSet = wire.NewSet(
 	ProvideHealthHandler,
 	handler.NewAppHandler,
+	handler.NewAppSpecHandler,
+	handler.NewGitOpsHandler,
 	handler.NewSSEHandler,
 	handler.NewSwaggerHandler,
+	handler.NewConfigHandler,
 	handler.NewEnvVarHandler,
 	handler.NewContainerHealthHandler,
 	ProvideAppAdminHandler,
+	handler.NewAppBulkHandler,
 	ProvideCloudflareAuthHandler,
 	ProvideDomainHandler,
 	ProvideMigrationHandler,
 	ProvideContainerHandler,
+	ProvideServerShells,
 	ProvideContainerExecHandler,
+	handler.NewSearchHandler,
+	handler.NewQuotaHandler,
 	ProvideTemplateHandler,
 	ProvideImageHandler,
 	ProvideCertificateHandler,
 	ProvideAuditService,
+	ProvideQuotaService,
 	ProvideAuditHandler,
+	handler.NewAPITokenHandler,
 	ProvideNotificationHandler,
+	handler.NewAppWebhookHandler,
+	handler.NewDeploymentSBOMHandler,
+	ProvideServerTaskHandler,
 	ProvideResourceHandler,
+	diagnostics.New,
+	backup.NewManager,
 	handler.NewSystemHandler,
 	ProvideAgentClient,
 	ProvideAgentHealthChecker,
@@ -84,9 +110,76 @@ func ProvideAppService(
 	envVarRepo domain.EnvVarRepository,
 	webhookManager webhook.Manager,
 	appCleaner *cleaner.Cleaner,
+	quotas *service.QuotaService,
+	logArchive *logarchive.Archiver,
 	logger *slog.Logger,
 ) *service.AppService {
-	return service.NewAppService(appRepo, deploymentRepo, envVarRepo, webhookManager, appCleaner, logger)
+	svc := service.NewAppService(appRepo, deploymentRepo, envVarRepo, webhookManager, appCleaner, quotas, logger)
+	if logArchive != nil {
+		svc.SetLogArchive(logArchive)
+	}
+	return svc
+}
+
+func ProvideQuotaService(
+	cfg *config.Config,
+	appRepo domain.AppRepository,
+	serverRepo domain.ServerRepository,
+	deploymentRepo domain.DeploymentRepository,
+	userRepo domain.UserRepository,
+) (*service.QuotaService, error) {
+	quota := domain.Quota{
+		MaxApps:      cfg.Quota.MaxApps,
+		MaxServers:   cfg.Quota.MaxServers,
+		BuildMinutes: cfg.Quota.BuildMinutes,
+	}
+	if cfg.Quota.MaxMemory != "" {
+		bytes, err := domain.ParseMemory(cfg.Quota.MaxMemory)
+		if err != nil {
+			return nil, fmt.Errorf("invalid QUOTA_MAX_MEMORY %q: %w", cfg.Quota.MaxMemory, err)
+		}
+		quota.MaxMemoryBytes = bytes
+	}
+	return service.NewQuotaService(quota, appRepo, serverRepo, deploymentRepo, userRepo), nil
+}
+
+// ProvideAppSpecService reconciles spec domains through the domain handler,
+// which is nil when no token encryptor is configured.
+func ProvideAppSpecService(
+	appService *service.AppService,
+	appRepo domain.AppRepository,
+	envVarRepo domain.EnvVarRepository,
+	domainRepo domain.CustomDomainRepository,
+	domainHandler *handler.DomainHandler,
+	logger *slog.Logger,
+) *service.AppSpecService {
+	var domains service.SpecDomainReconciler
+	if domainHandler != nil {
+		domains = domainHandler
+	}
+	return service.NewAppSpecService(appService, appRepo, envVarRepo, domainRepo, domains, logger)
+}
+
+func ProvideGitOpsController(
+	cfg *config.Config,
+	sourceRepo domain.GitOpsSourceRepository,
+	appRepo domain.AppRepository,
+	userRepo domain.UserRepository,
+	serverRepo domain.ServerRepository,
+	specService *service.AppSpecService,
+	gitTokenProvider engine.GitTokenProvider,
+	logger *slog.Logger,
+) *gitops.Controller {
+	return gitops.NewController(gitops.ControllerParams{
+		SourceRepo: sourceRepo,
+		AppRepo:    appRepo,
+		UserRepo:   userRepo,
+		ServerRepo: serverRepo,
+		Specs:      specService,
+		Tokens:     gitTokenProvider,
+		DataDir:    cfg.Deploy.DataDir,
+		Logger:     logger,
+	})
 }
 
 type AppAdminHandlerDeps struct {
@@ -96,6 +189,8 @@ type AppAdminHandlerDeps struct {
 	EnvVarRepo       domain.EnvVarRepository
 	Engine           *engine.Engine
 	AgentClient      *agentclient.AgentClient
+	CommandRepo      domain.AgentCommandRepository
+	AuditService     *service.AuditService
 	Config           *config.Config
 	Logger           *slog.Logger
 }
@@ -108,20 +203,58 @@ func ProvideAppAdminHandler(deps AppAdminHandlerDeps) *handler.AppAdminHandler {
 		EnvVarRepo:       deps.EnvVarRepo,
 		Engine:           deps.Engine,
 		AgentClient:      deps.AgentClient,
+		CommandRepo:      deps.CommandRepo,
+		AuditService:     deps.AuditService,
 		AgentPort:        deps.Config.GRPC.AgentPort,
 		DataDir:          deps.Config.Deploy.DataDir,
+		APIBaseURL:       strings.TrimSuffix(deps.Config.Server.ApiBaseURL, "/"),
 		Logger:           deps.Logger,
 	})
 }
 
+type TunnelServiceDeps struct {
+	Config         *config.Config
+	ServerRepo     domain.ServerRepository
+	TunnelRepo     domain.ServerTunnelRepository
+	ConnectionRepo domain.CloudflareConnectionRepository
+	AppRepo        domain.AppRepository
+	DomainRepo     domain.CustomDomainRepository
+	TokenEncryptor *crypto.TokenEncryptor
+	AgentClient    *agentclient.AgentClient
+	Logger         *slog.Logger
+}
+
+func ProvideTunnelService(deps TunnelServiceDeps) *service.TunnelService {
+	if deps.TokenEncryptor == nil || deps.AgentClient == nil {
+		deps.Logger.Info("TunnelService not created: token encryptor or agent client not available")
+		return nil
+	}
+
+	return service.NewTunnelService(service.TunnelServiceParams{
+		ServerRepo:     deps.ServerRepo,
+		TunnelRepo:     deps.TunnelRepo,
+		ConnectionRepo: deps.ConnectionRepo,
+		AppRepo:        deps.AppRepo,
+		DomainRepo:     deps.DomainRepo,
+		TokenEncryptor: deps.TokenEncryptor,
+		AgentClient:    deps.AgentClient,
+		AgentPort:      deps.Config.GRPC.AgentPort,
+		Logger:         deps.Logger,
+	})
+}
+
 type DomainHandlerDeps struct {
 	Config         *config.Config
 	AppRepo        domain.AppRepository
 	DomainRepo     domain.CustomDomainRepository
 	ConnectionRepo domain.CloudflareConnectionRepository
+	DNSConnRepo    domain.DNSConnectionRepository
+	VerifyRepo     domain.DomainVerificationRepository
 	ServerRepo     domain.ServerRepository
 	TokenEncryptor *crypto.TokenEncryptor
 	Engine         *engine.Engine
+	TunnelService  *service.TunnelService
+	AuditService   *service.AuditService
 	Logger         *slog.Logger
 }
 
@@ -131,51 +264,86 @@ func ProvideDomainHandler(deps DomainHandlerDeps) *handler.DomainHandler {
 		return nil
 	}
 
-	return handler.NewDomainHandler(handler.DomainHandlerConfig{
+	cfg := handler.DomainHandlerConfig{
 		AppRepo:        deps.AppRepo,
 		DomainRepo:     deps.DomainRepo,
 		ConnectionRepo: deps.ConnectionRepo,
+		DNSConnRepo:    deps.DNSConnRepo,
+		VerifyRepo:     deps.VerifyRepo,
 		ServerRepo:     deps.ServerRepo,
 		TokenEncryptor: deps.TokenEncryptor,
 		ServerIP:       deps.Config.Cloudflare.ServerIP,
 		Logger:         deps.Logger,
 		DomainUpdater:  deps.Engine,
-	})
+		CertInstaller:  deps.Engine,
+		AuditService:   deps.AuditService,
+	}
+	if deps.TunnelService != nil {
+		cfg.Tunnels = deps.TunnelService
+	}
+	return handler.NewDomainHandler(cfg)
 }
 
-func ProvideMigrationHandler(logger *slog.Logger) *handler.MigrationHandler {
-	return handler.NewMigrationHandler(logger)
+func ProvideMigrationHandler(
+	serverRepo domain.ServerRepository,
+	agentClient *agentclient.AgentClient,
+	cfg *config.Config,
+	logger *slog.Logger,
+) *handler.MigrationHandler {
+	return handler.NewMigrationHandler(handler.MigrationHandlerConfig{
+		AgentClient: agentClient,
+		ServerRepo:  serverRepo,
+		AgentPort:   cfg.GRPC.AgentPort,
+		Logger:      logger,
+	})
 }
 
 func ProvideContainerHandler(
 	eng *engine.Engine,
 	serverRepo domain.ServerRepository,
+	commandRepo domain.AgentCommandRepository,
 	agentClient *agentclient.AgentClient,
+	auditService *service.AuditService,
 	cfg *config.Config,
 	logger *slog.Logger,
 	sseHandler *handler.SSEHandler,
 ) *handler.ContainerHandler {
 	return handler.NewContainerHandler(handler.ContainerHandlerConfig{
-		Docker:      eng.Docker(),
-		AgentClient: agentClient,
-		ServerRepo:  serverRepo,
-		AgentPort:   cfg.GRPC.AgentPort,
-		Logger:      logger,
-		SSEHandler:  sseHandler,
+		Docker:       eng.Docker(),
+		AgentClient:  agentClient,
+		ServerRepo:   serverRepo,
+		CommandRepo:  commandRepo,
+		AuditService: auditService,
+		AgentPort:    cfg.GRPC.AgentPort,
+		Logger:       logger,
+		SSEHandler:   sseHandler,
 	})
 }
 
+func ProvideServerShells(
+	serverRepo domain.ServerRepository,
+	tokenEncryptor *crypto.TokenEncryptor,
+	prov *provisioner.SSHProvisioner,
+	logger *slog.Logger,
+) *handler.ServerShells {
+	return handler.NewServerShells(serverRepo, tokenEncryptor, prov, logger)
+}
+
 func ProvideContainerExecHandler(
 	serverRepo domain.ServerRepository,
+	sessionRepo domain.ExecSessionRepository,
 	agentClient *agentclient.AgentClient,
+	serverShells *handler.ServerShells,
 	cfg *config.Config,
 	logger *slog.Logger,
 ) *handler.ContainerExecHandler {
 	return handler.NewContainerExecHandler(handler.ContainerExecHandlerConfig{
-		AgentClient: agentClient,
-		ServerRepo:  serverRepo,
-		AgentPort:   cfg.GRPC.AgentPort,
-		Logger:      logger,
+		AgentClient:  agentClient,
+		ServerRepo:   serverRepo,
+		SessionRepo:  sessionRepo,
+		ServerShells: serverShells,
+		AgentPort:    cfg.GRPC.AgentPort,
+		Logger:       logger,
 	})
 }
 
@@ -218,38 +386,44 @@ func ProvideAuditService(db *sql.DB, logger *slog.Logger) *service.AuditService
 	return service.NewAuditService(repo, logger)
 }
 
-func ProvideAuditHandler(auditService *service.AuditService, webhookPayloadRepo *repository.PostgresWebhookPayloadRepository) *handler.AuditHandler {
-	return handler.NewAuditHandler(auditService, webhookPayloadRepo)
+func ProvideAuditHandler(auditService *service.AuditService, webhookPayloadRepo *repository.PostgresWebhookPayloadRepository, webhookHandler *ghclient.WebhookHandler) *handler.AuditHandler {
+	return handler.NewAuditHandler(auditService, webhookPayloadRepo, webhookHandler)
 }
 
 func ProvideResourceHandler(
 	eng *engine.Engine,
 	serverRepo domain.ServerRepository,
 	agentClient *agentclient.AgentClient,
+	auditService *service.AuditService,
 	cfg *config.Config,
 	logger *slog.Logger,
 ) *handler.ResourceHandler {
 	return handler.NewResourceHandler(handler.ResourceHandlerConfig{
-		Docker:      eng.Docker(),
-		AgentClient: agentClient,
-		ServerRepo:  serverRepo,
-		AgentPort:   cfg.GRPC.AgentPort,
-		Logger:      logger,
+		Docker:       eng.Docker(),
+		AgentClient:  agentClient,
+		ServerRepo:   serverRepo,
+		AuditService: auditService,
+		AgentPort:    cfg.GRPC.AgentPort,
+		Logger:       logger,
 	})
 }
 
 func ProvideCertificateHandler(
 	cfg *config.Config,
 	serverRepo domain.ServerRepository,
+	appRepo domain.AppRepository,
+	customDomainRepo domain.CustomDomainRepository,
 	agentClient *agentclient.AgentClient,
 	logger *slog.Logger,
 ) *handler.CertificateHandler {
 	return handler.NewCertificateHandler(handler.CertificateHandlerConfig{
-		TraefikURL:  cfg.Traefik.URL,
-		AgentClient: agentClient,
-		ServerRepo:  serverRepo,
-		AgentPort:   cfg.GRPC.AgentPort,
-		Logger:      logger,
+		TraefikURL:       cfg.Traefik.URL,
+		AgentClient:      agentClient,
+		ServerRepo:       serverRepo,
+		AppRepo:          appRepo,
+		CustomDomainRepo: customDomainRepo,
+		AgentPort:        cfg.GRPC.AgentPort,
+		Logger:           logger,
 	})
 }
 
@@ -276,14 +450,33 @@ func ProvideServerHandlerAgentDeps(
 	agentClient *agentclient.AgentClient,
 	cfg *config.Config,
 	grpcServer *grpcserver.Server,
+	commandRepo domain.AgentCommandRepository,
+	heartbeatRepo domain.ServerHeartbeatRepository,
+	bootstrapTokenRepo domain.ServerBootstrapTokenRepository,
+	firewallRepo domain.ServerFirewallRepository,
+	cloudCredentialRepo domain.CloudCredentialRepository,
+	tunnelService *service.TunnelService,
 ) handler.ServerHandlerAgentDeps {
-	return handler.ServerHandlerAgentDeps{
+	deps := handler.ServerHandlerAgentDeps{
 		HealthChecker:       healthChecker,
 		AgentClient:         agentClient,
+		CommandRepo:         commandRepo,
+		HeartbeatRepo:       heartbeatRepo,
+		BootstrapTokenRepo:  bootstrapTokenRepo,
+		FirewallRepo:        firewallRepo,
+		CloudCredentialRepo: cloudCredentialRepo,
+		APIBaseURL:          strings.TrimSuffix(cfg.Server.ApiBaseURL, "/"),
 		AgentPort:           cfg.GRPC.AgentPort,
 		AgentBinaryPath:     cfg.GRPC.AgentBinaryPath,
 		UpdateAgentEnqueuer: grpcServer,
 	}
+	if grpcServer != nil {
+		deps.CertRevoker = grpcServer
+	}
+	if tunnelService != nil {
+		deps.Tunnels = tunnelService
+	}
+	return deps
 }
 
 func ProvideServerHandler(
@@ -293,6 +486,7 @@ func ProvideServerHandler(
 	sseHandler *handler.SSEHandler,
 	agentDeps handler.ServerHandlerAgentDeps,
 	appService *service.AppService,
+	quotas *service.QuotaService,
 	logger *slog.Logger,
 ) *handler.ServerHandler {
 	return handler.NewServerHandler(
@@ -302,6 +496,7 @@ func ProvideServerHandler(
 		sseHandler,
 		agentDeps,
 		appService,
+		quotas,
 		logger,
 	)
 }
@@ -335,3 +530,31 @@ func ProvideCleanupHandler(
 		Logger:         logger,
 	})
 }
+
+func ProvideServerTaskScheduler(
+	taskRepo domain.ServerTaskRepository,
+	serverRepo domain.ServerRepository,
+	agentClient *agentclient.AgentClient,
+	notificationService *service.NotificationService,
+	cfg *config.Config,
+	logger *slog.Logger,
+) *engine.ServerTaskScheduler {
+	return engine.NewServerTaskScheduler(engine.ServerTaskSchedulerParams{
+		TaskRepo:    taskRepo,
+		ServerRepo:  serverRepo,
+		AgentClient: agentClient,
+		AgentPort:   cfg.GRPC.AgentPort,
+		Notifier:    notificationService,
+		Logger:      logger,
+	})
+}
+
+func ProvideServerTaskHandler(
+	serverRepo domain.ServerRepository,
+	taskRepo domain.ServerTaskRepository,
+	scheduler *engine.ServerTaskScheduler,
+	auditService *service.AuditService,
+	logger *slog.Logger,
+) *handler.ServerTaskHandler {
+	return handler.NewServerTaskHandler(serverRepo, taskRepo, scheduler, auditService, logger)
+}
@@ -8,10 +8,13 @@ package di
 
 import (
 	"github.com/paasdeploy/backend/internal/agentdownload"
+	"github.com/paasdeploy/backend/internal/backup"
+	"github.com/paasdeploy/backend/internal/diagnostics"
 	"github.com/paasdeploy/backend/internal/engine"
 	"github.com/paasdeploy/backend/internal/handler"
 	"github.com/paasdeploy/backend/internal/repository"
 	"github.com/paasdeploy/backend/internal/server"
+	"github.com/paasdeploy/backend/internal/service"
 )
 
 // Injectors from wire.go:
@@ -42,11 +45,30 @@ func InitializeApplication() (*Application, func(), error) {
 		return nil, nil, err
 	}
 	postgresServerRepository := repository.NewPostgresServerRepository(db)
+	postgresAgentCommandRepository := repository.NewPostgresAgentCommandRepository(db)
+	postgresServerHeartbeatRepository := repository.NewPostgresServerHeartbeatRepository(db)
+	postgresServerBootstrapTokenRepository := repository.NewPostgresServerBootstrapTokenRepository(db)
+	postgresServerFirewallRepository := repository.NewPostgresServerFirewallRepository(db)
+	postgresCloudCredentialRepository := repository.NewPostgresCloudCredentialRepository(db)
 	agentClientForEngine, err := ProvideAgentClient(certificateAuthority, config)
 	if err != nil {
 		cleanup()
 		return nil, nil, err
 	}
+	postgresDeploymentRepository := repository.NewPostgresDeploymentRepository(db)
+	postgresUserRepository := repository.NewPostgresUserRepository(db)
+	quotaService, err := ProvideQuotaService(config, postgresAppRepository, postgresServerRepository, postgresDeploymentRepository, postgresUserRepository)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	postgresAppHealthEventRepository := repository.NewPostgresAppHealthEventRepository(db)
+	postgresDeploymentSBOMRepository := repository.NewPostgresDeploymentSBOMRepository(db)
+	archiver, err := ProvideLogArchive(config, postgresDeploymentRepository, logger)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
 	engineEngine := engine.New(engine.Params{
 		Cfg:              config,
 		DB:               db,
@@ -54,23 +76,29 @@ func InitializeApplication() (*Application, func(), error) {
 		EnvVarRepo:       postgresEnvVarRepository,
 		CustomDomainRepo: postgresCustomDomainRepository,
 		ServerRepo:       postgresServerRepository,
+		AgentCommandRepo: postgresAgentCommandRepository,
+		HealthEventRepo:  postgresAppHealthEventRepository,
+		SBOMRepo:         postgresDeploymentSBOMRepository,
 		AgentClient:      agentClientForEngine,
 		GitTokenProvider: gitTokenProvider,
 		AuditService:     auditService,
+		Quotas:           quotaService,
+		LogArchive:       archiver,
 		Logger:           logger,
 	})
 	sseHandler := handler.NewSSEHandler()
 	tokenStore := agentdownload.NewTokenStore()
-	grpcserverServer := ProvideGrpcServer(config, certificateAuthority, postgresServerRepository, tokenStore, sseHandler, logger)
+	postgresCertificateRevocationRepository := repository.NewPostgresCertificateRevocationRepository(db)
+	grpcserverServer := ProvideGrpcServer(config, certificateAuthority, postgresServerRepository, postgresServerHeartbeatRepository, postgresCertificateRevocationRepository, postgresAgentCommandRepository, tokenStore, sseHandler, logger)
 	healthHandler := ProvideHealthHandler()
-	postgresDeploymentRepository := repository.NewPostgresDeploymentRepository(db)
 	manager := ProvideWebhookManager(config, logger)
 	appCleaner := ProvideAppCleaner(config, logger)
-	appService := ProvideAppService(postgresAppRepository, postgresDeploymentRepository, postgresEnvVarRepository, manager, appCleaner, logger)
+	appService := ProvideAppService(postgresAppRepository, postgresDeploymentRepository, postgresEnvVarRepository, manager, appCleaner, quotaService, archiver, logger)
 	appHandler := handler.NewAppHandler(appService, auditService, logger)
 	swaggerHandler := handler.NewSwaggerHandler()
-	envVarHandler := handler.NewEnvVarHandler(postgresEnvVarRepository, postgresAppRepository, logger)
-	containerHealthHandler := handler.NewContainerHealthHandler(postgresAppRepository, postgresServerRepository, engineEngine, agentClientForEngine, config.GRPC.AgentPort, logger)
+	configHandler := handler.NewConfigHandler()
+	envVarHandler := handler.NewEnvVarHandler(postgresEnvVarRepository, postgresAppRepository, auditService, logger)
+	containerHealthHandler := handler.NewContainerHealthHandler(postgresAppRepository, postgresServerRepository, postgresAppHealthEventRepository, engineEngine, agentClientForEngine, config.GRPC.AgentPort, logger)
 	appAdminHandler := ProvideAppAdminHandler(AppAdminHandlerDeps{
 		AppRepo:          postgresAppRepository,
 		ServerRepo:       postgresServerRepository,
@@ -78,51 +106,100 @@ func InitializeApplication() (*Application, func(), error) {
 		EnvVarRepo:       postgresEnvVarRepository,
 		Engine:           engineEngine,
 		AgentClient:      agentClientForEngine,
+		CommandRepo:      postgresAgentCommandRepository,
+		AuditService:     auditService,
 		Config:           config,
 		Logger:           logger,
 	})
+	appBulkHandler := handler.NewAppBulkHandler(postgresAppRepository, postgresServerRepository, appService, appAdminHandler, sseHandler, auditService, logger)
 	postgresWebhookPayloadRepository := repository.NewPostgresWebhookPayloadRepository(db)
 	webhookHandler := ProvideGitHubWebhookHandler(config, postgresAppRepository, postgresDeploymentRepository, postgresWebhookPayloadRepository, auditService, logger)
 	oAuthClient := ProvideOAuthClient(config, logger)
-	postgresUserRepository := repository.NewPostgresUserRepository(db)
 	postgresSessionRepository := repository.NewPostgresSessionRepository(db)
 	tokenEncryptor := ProvideTokenEncryptor(config, logger)
 	authHandler := ProvideAuthHandler(config, oAuthClient, postgresUserRepository, postgresSessionRepository, tokenEncryptor, auditService, logger)
 	gitHubHandler := ProvideGitHubHandler(config, appClient, postgresInstallationRepository, postgresUserRepository, logger)
-	authMiddleware := ProvideAuthMiddleware(config, postgresSessionRepository, postgresUserRepository, logger)
+	postgresAPITokenRepository := repository.NewPostgresAPITokenRepository(db)
+	authMiddleware := ProvideAuthMiddleware(config, postgresSessionRepository, postgresAPITokenRepository, postgresUserRepository, logger)
 	postgresCloudflareConnectionRepository := repository.NewPostgresCloudflareConnectionRepository(db)
 	cloudflareAuthHandler := ProvideCloudflareAuthHandler(config, postgresCloudflareConnectionRepository, tokenEncryptor, logger)
+	postgresServerTunnelRepository := repository.NewPostgresServerTunnelRepository(db)
+	tunnelService := ProvideTunnelService(TunnelServiceDeps{
+		Config:         config,
+		ServerRepo:     postgresServerRepository,
+		TunnelRepo:     postgresServerTunnelRepository,
+		ConnectionRepo: postgresCloudflareConnectionRepository,
+		AppRepo:        postgresAppRepository,
+		DomainRepo:     postgresCustomDomainRepository,
+		TokenEncryptor: tokenEncryptor,
+		AgentClient:    agentClientForEngine,
+		Logger:         logger,
+	})
+	postgresDNSConnectionRepository := repository.NewPostgresDNSConnectionRepository(db)
+	postgresDomainVerificationRepository := repository.NewPostgresDomainVerificationRepository(db)
 	domainHandler := ProvideDomainHandler(DomainHandlerDeps{
 		Config:         config,
 		AppRepo:        postgresAppRepository,
 		DomainRepo:     postgresCustomDomainRepository,
 		ConnectionRepo: postgresCloudflareConnectionRepository,
+		DNSConnRepo:    postgresDNSConnectionRepository,
+		VerifyRepo:     postgresDomainVerificationRepository,
 		ServerRepo:     postgresServerRepository,
 		TokenEncryptor: tokenEncryptor,
 		Engine:         engineEngine,
+		TunnelService:  tunnelService,
+		AuditService:   auditService,
 		Logger:         logger,
 	})
-	migrationHandler := ProvideMigrationHandler(logger)
-	containerHandler := ProvideContainerHandler(engineEngine, postgresServerRepository, agentClientForEngine, config, logger, sseHandler)
-	containerExecHandler := ProvideContainerExecHandler(postgresServerRepository, agentClientForEngine, config, logger)
+	appSpecService := ProvideAppSpecService(appService, postgresAppRepository, postgresEnvVarRepository, postgresCustomDomainRepository, domainHandler, logger)
+	appSpecHandler := handler.NewAppSpecHandler(appSpecService, auditService, logger)
+	postgresGitOpsSourceRepository := repository.NewPostgresGitOpsSourceRepository(db)
+	gitopsController := ProvideGitOpsController(config, postgresGitOpsSourceRepository, postgresAppRepository, postgresUserRepository, postgresServerRepository, appSpecService, gitTokenProvider, logger)
+	gitOpsHandler := handler.NewGitOpsHandler(postgresGitOpsSourceRepository, postgresAppRepository, gitopsController, manager, auditService, logger)
+	migrationHandler := ProvideMigrationHandler(postgresServerRepository, agentClientForEngine, config, logger)
+	containerHandler := ProvideContainerHandler(engineEngine, postgresServerRepository, postgresAgentCommandRepository, agentClientForEngine, auditService, config, logger, sseHandler)
+	searchService := service.NewSearchService(postgresAppRepository, postgresDeploymentRepository, postgresServerRepository, postgresCustomDomainRepository)
+	searchHandler := handler.NewSearchHandler(searchService, containerHandler, postgresServerRepository, logger)
+	quotaHandler := handler.NewQuotaHandler(quotaService, logger)
+	postgresExecSessionRepository := repository.NewPostgresExecSessionRepository(db)
 	templateHandler := ProvideTemplateHandler(engineEngine, postgresServerRepository, agentClientForEngine, config, logger)
 	imageHandler := ProvideImageHandler(engineEngine, postgresServerRepository, agentClientForEngine, config, logger, sseHandler)
-	certificateHandler := ProvideCertificateHandler(config, postgresServerRepository, agentClientForEngine, logger)
-	auditHandler := ProvideAuditHandler(auditService, postgresWebhookPayloadRepository)
-	resourceHandler := ProvideResourceHandler(engineEngine, postgresServerRepository, agentClientForEngine, config, logger)
+	certificateHandler := ProvideCertificateHandler(config, postgresServerRepository, postgresAppRepository, postgresCustomDomainRepository, agentClientForEngine, logger)
+	auditHandler := ProvideAuditHandler(auditService, postgresWebhookPayloadRepository, webhookHandler)
+	apiTokenHandler := handler.NewAPITokenHandler(postgresAPITokenRepository, auditService, logger)
+	resourceHandler := ProvideResourceHandler(engineEngine, postgresServerRepository, agentClientForEngine, auditService, config, logger)
 	postgresNotificationChannelRepository := repository.NewPostgresNotificationChannelRepository(db)
 	postgresNotificationRuleRepository := repository.NewPostgresNotificationRuleRepository(db)
 	notificationService := ProvideNotificationService(postgresNotificationChannelRepository, postgresNotificationRuleRepository, postgresAppRepository, logger)
 	notificationHandler := ProvideNotificationHandler(postgresNotificationChannelRepository, postgresNotificationRuleRepository, postgresAppRepository, logger)
-	sshProvisioner := ProvideSSHProvisioner(certificateAuthority, config, logger, postgresServerRepository)
+	postgresAppWebhookRepository := repository.NewPostgresAppWebhookRepository(db)
+	appWebhookService := service.NewAppWebhookService(postgresAppWebhookRepository, postgresAppRepository, tokenEncryptor, logger)
+	appWebhookHandler := handler.NewAppWebhookHandler(postgresAppRepository, postgresAppWebhookRepository, appWebhookService, logger)
+	deploymentSBOMHandler := handler.NewDeploymentSBOMHandler(postgresAppRepository, postgresDeploymentRepository, postgresDeploymentSBOMRepository, logger)
+	postgresServerTaskRepository := repository.NewPostgresServerTaskRepository(db)
+	serverTaskScheduler := ProvideServerTaskScheduler(postgresServerTaskRepository, postgresServerRepository, agentClientForEngine, notificationService, config, logger)
+	serverTaskHandler := ProvideServerTaskHandler(postgresServerRepository, postgresServerTaskRepository, serverTaskScheduler, auditService, logger)
+	sshProvisioner := ProvideSSHProvisioner(certificateAuthority, config, logger, postgresServerRepository, postgresServerFirewallRepository, grpcserverServer)
 	healthChecker := ProvideAgentHealthChecker(agentClientForEngine, config)
-	serverHandlerAgentDeps := ProvideServerHandlerAgentDeps(healthChecker, agentClientForEngine, config, grpcserverServer)
-	serverHandler := ProvideServerHandler(postgresServerRepository, tokenEncryptor, sshProvisioner, sseHandler, serverHandlerAgentDeps, appService, logger)
-	systemHandler := handler.NewSystemHandler()
+	serverHandlerAgentDeps := ProvideServerHandlerAgentDeps(healthChecker, agentClientForEngine, config, grpcserverServer, postgresAgentCommandRepository, postgresServerHeartbeatRepository, postgresServerBootstrapTokenRepository, postgresServerFirewallRepository, postgresCloudCredentialRepository, tunnelService)
+	serverHandler := ProvideServerHandler(postgresServerRepository, tokenEncryptor, sshProvisioner, sseHandler, serverHandlerAgentDeps, appService, quotaService, logger)
+	serverShells := ProvideServerShells(postgresServerRepository, tokenEncryptor, sshProvisioner, logger)
+	containerExecHandler := ProvideContainerExecHandler(postgresServerRepository, postgresExecSessionRepository, agentClientForEngine, serverShells, config, logger)
+	checker := diagnostics.New(config, db)
+	backupManager := backup.NewManager(db)
+	systemHandler := handler.NewSystemHandler(checker, backupManager, auditService, logger)
 	agentdownloadHandler := ProvideAgentDownloadHandler(tokenStore, config, logger)
+	bootstrapHandler := ProvideAgentBootstrapHandler(postgresServerBootstrapTokenRepository, postgresServerRepository, certificateAuthority, config, logger)
 	postgresCleanupLogRepository := repository.NewPostgresCleanupLogRepository(db)
 	cleanupHandler := ProvideCleanupHandler(postgresServerRepository, postgresCleanupLogRepository, agentClientForEngine, config, logger)
 	containerSSLHandler := ProvideContainerSSLHandler(postgresServerRepository, agentClientForEngine, config, logger)
+	postgresCertificateExpiryAlertRepository := repository.NewPostgresCertificateExpiryAlertRepository(db)
+	postgresUsageRecordRepository := repository.NewPostgresUsageRecordRepository(db)
+	sink, err := ProvideUsageSink(config, postgresUsageRecordRepository)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
 	application := &Application{
 		Config:                 config,
 		Logger:                 logger,
@@ -132,11 +209,16 @@ func InitializeApplication() (*Application, func(), error) {
 		GrpcServer:             grpcserverServer,
 		HealthHandler:          healthHandler,
 		AppHandler:             appHandler,
+		AppSpecHandler:         appSpecHandler,
+		GitOpsHandler:          gitOpsHandler,
+		GitOpsController:       gitopsController,
 		SSEHandler:             sseHandler,
 		SwaggerHandler:         swaggerHandler,
+		ConfigHandler:          configHandler,
 		EnvVarHandler:          envVarHandler,
 		ContainerHealthHandler: containerHealthHandler,
 		AppAdminHandler:        appAdminHandler,
+		AppBulkHandler:         appBulkHandler,
 		WebhookHandler:         webhookHandler,
 		AuthHandler:            authHandler,
 		GitHubHandler:          gitHubHandler,
@@ -145,21 +227,38 @@ func InitializeApplication() (*Application, func(), error) {
 		DomainHandler:          domainHandler,
 		MigrationHandler:       migrationHandler,
 		ContainerHandler:       containerHandler,
+		SearchHandler:          searchHandler,
+		QuotaHandler:           quotaHandler,
 		ContainerExecHandler:   containerExecHandler,
 		TemplateHandler:        templateHandler,
 		ImageHandler:           imageHandler,
 		CertificateHandler:     certificateHandler,
+		AppService:             appService,
 		AuditService:           auditService,
 		AuditHandler:           auditHandler,
+		APITokenHandler:        apiTokenHandler,
 		ResourceHandler:        resourceHandler,
 		NotificationService:    notificationService,
 		NotificationHandler:    notificationHandler,
+		AppWebhookService:      appWebhookService,
+		AppWebhookHandler:      appWebhookHandler,
+		DeploymentSBOMHandler:  deploymentSBOMHandler,
+		ServerTaskHandler:      serverTaskHandler,
+		ServerTaskScheduler:    serverTaskScheduler,
 		ServerHandler:          serverHandler,
 		SystemHandler:          systemHandler,
+		Diagnostics:            checker,
 		AgentDownloadHandler:   agentdownloadHandler,
+		AgentBootstrapHandler:  bootstrapHandler,
 		CleanupHandler:         cleanupHandler,
 		ContainerSSLHandler:    containerSSLHandler,
 		ServerRepo:             postgresServerRepository,
+		ServerHeartbeatRepo:    postgresServerHeartbeatRepository,
+		AppRepo:                postgresAppRepository,
+		CustomDomainRepo:       postgresCustomDomainRepository,
+		CertificateAlertRepo:   postgresCertificateExpiryAlertRepository,
+		DeploymentRepo:         postgresDeploymentRepository,
+		UsageSink:              sink,
 		AgentClient:            agentClientForEngine,
 	}
 	return application, func() {
@@ -0,0 +1,201 @@
+// Package diagnostics checks that the control plane is configured and can
+// reach its dependencies.
+package diagnostics
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/paasdeploy/backend/internal/config"
+	"github.com/paasdeploy/backend/internal/crypto"
+	"github.com/paasdeploy/backend/internal/ghclient"
+	"github.com/paasdeploy/shared/pkg/traefik"
+)
+
+type Status string
+
+const (
+	StatusOK       Status = "ok"
+	StatusDegraded Status = "degraded"
+	StatusFailed   Status = "failed"
+	StatusDisabled Status = "disabled"
+)
+
+const checkTimeout = 3 * time.Second
+
+type Check struct {
+	Name    string `json:"name"`
+	Status  Status `json:"status"`
+	Message string `json:"message,omitempty"`
+}
+
+type Report struct {
+	Status    Status    `json:"status"`
+	Checks    []Check   `json:"checks"`
+	CheckedAt time.Time `json:"checkedAt"`
+}
+
+type Checker struct {
+	cfg     *config.Config
+	db      *sql.DB
+	traefik *traefik.Client
+}
+
+func New(cfg *config.Config, db *sql.DB) *Checker {
+	return &Checker{
+		cfg:     cfg,
+		db:      db,
+		traefik: traefik.NewClient(cfg.Traefik.URL),
+	}
+}
+
+// Run performs every check. Failed checks break a feature the platform
+// needs; degraded ones disable an optional feature or reduce security.
+func (c *Checker) Run(ctx context.Context) Report {
+	checks := []Check{
+		c.checkDatabase(ctx),
+		c.checkDataDir(),
+		c.checkEncryptionKey(),
+		checkGitHubOAuth(c.cfg.GitHub),
+		checkGitHubApp(c.cfg.GitHub),
+		checkWebhooks(c.cfg.GitHub),
+		c.checkTraefik(ctx),
+	}
+	return Report{Status: overall(checks), Checks: checks, CheckedAt: time.Now()}
+}
+
+func overall(checks []Check) Status {
+	status := StatusOK
+	for _, check := range checks {
+		switch check.Status {
+		case StatusFailed:
+			return StatusFailed
+		case StatusDegraded:
+			status = StatusDegraded
+		}
+	}
+	return status
+}
+
+func (c *Checker) checkDatabase(ctx context.Context) Check {
+	check := Check{Name: "database"}
+	if c.cfg.Database.URL == "" {
+		check.Status, check.Message = StatusFailed, "DATABASE_URL is not set"
+		return check
+	}
+	if c.db == nil {
+		check.Status, check.Message = StatusFailed, "database is not connected"
+		return check
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+	if err := c.db.PingContext(ctx); err != nil {
+		check.Status, check.Message = StatusFailed, fmt.Sprintf("database is unreachable: %v", err)
+		return check
+	}
+	check.Status = StatusOK
+	return check
+}
+
+func (c *Checker) checkDataDir() Check {
+	check := Check{Name: "data_dir"}
+	if err := c.cfg.EnsureDirectories(); err != nil {
+		check.Status, check.Message = StatusFailed, err.Error()
+		return check
+	}
+	check.Status, check.Message = StatusOK, c.cfg.Deploy.DataDir
+	return check
+}
+
+func (c *Checker) checkEncryptionKey() Check {
+	check := Check{Name: "token_encryption"}
+	switch {
+	case c.cfg.Auth.TokenEncryptionKey == "":
+		check.Message = "TOKEN_ENCRYPTION_KEY is not set, credentials are stored unencrypted"
+	case !validEncryptionKey(c.cfg.Auth.TokenEncryptionKey):
+		check.Message = "TOKEN_ENCRYPTION_KEY must be a base64 encoded 32 byte key"
+	default:
+		check.Status = StatusOK
+		return check
+	}
+
+	check.Status = StatusDegraded
+	if c.cfg.Server.Env == "production" {
+		check.Status = StatusFailed
+	}
+	return check
+}
+
+func validEncryptionKey(key string) bool {
+	_, err := crypto.NewTokenEncryptor(key)
+	return err == nil
+}
+
+func checkGitHubOAuth(cfg config.GitHubConfig) Check {
+	check := Check{Name: "github_oauth"}
+	switch {
+	case cfg.ClientID == "" && cfg.ClientSecret == "":
+		check.Status, check.Message = StatusDisabled, "GitHub login is not configured"
+	case cfg.ClientID == "" || cfg.ClientSecret == "":
+		check.Status, check.Message = StatusDegraded, "GIT_HUB_CLIENT_ID and GIT_HUB_CLIENT_SECRET must both be set"
+	case cfg.CallbackURL == "":
+		check.Status, check.Message = StatusDegraded, "GIT_HUB_OAUTH_CALLBACK_URL is not set"
+	default:
+		check.Status = StatusOK
+	}
+	return check
+}
+
+func checkGitHubApp(cfg config.GitHubConfig) Check {
+	check := Check{Name: "github_app"}
+	switch {
+	case cfg.AppID == 0 && len(cfg.AppPrivateKey) == 0:
+		check.Status, check.Message = StatusDisabled, "GitHub App is not configured, private repositories cannot be deployed"
+		return check
+	case cfg.AppID == 0:
+		check.Status, check.Message = StatusDegraded, "GIT_HUB_APP_ID is not set"
+		return check
+	case len(cfg.AppPrivateKey) == 0:
+		check.Status, check.Message = StatusDegraded, "GitHub App private key is not set or could not be read"
+		return check
+	}
+	if _, err := ghclient.ParsePrivateKey(cfg.AppPrivateKey); err != nil {
+		check.Status, check.Message = StatusDegraded, fmt.Sprintf("GitHub App private key is invalid: %v", err)
+		return check
+	}
+	check.Status = StatusOK
+	return check
+}
+
+func checkWebhooks(cfg config.GitHubConfig) Check {
+	check := Check{Name: "github_webhooks"}
+	switch {
+	case cfg.WebhookSecret == "":
+		check.Status, check.Message = StatusDegraded, "GIT_HUB_WEBHOOK_SECRET is not set, push deploys are disabled"
+	case cfg.WebhookURL == "":
+		check.Status, check.Message = StatusDegraded, "GIT_HUB_WEBHOOK_URL is not set, webhooks cannot be created"
+	default:
+		check.Status = StatusOK
+	}
+	return check
+}
+
+func (c *Checker) checkTraefik(ctx context.Context) Check {
+	check := Check{Name: "traefik"}
+	if c.cfg.Traefik.URL == "" {
+		check.Status, check.Message = StatusDisabled, "TRAEFIK_API_URL is not set"
+		return check
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+	if _, err := c.traefik.GetRouters(ctx); err != nil {
+		check.Status, check.Message = StatusDegraded, fmt.Sprintf("Traefik API at %s is unreachable: %v", c.cfg.Traefik.URL, err)
+		return check
+	}
+	check.Status, check.Message = StatusOK, c.cfg.Traefik.URL
+	return check
+}
@@ -0,0 +1,125 @@
+package diagnostics
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/paasdeploy/backend/internal/config"
+)
+
+func testPrivateKey(t *testing.T) []byte {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+}
+
+func TestOverall(t *testing.T) {
+	tests := []struct {
+		name   string
+		checks []Check
+		want   Status
+	}{
+		{"all ok", []Check{{Status: StatusOK}, {Status: StatusDisabled}}, StatusOK},
+		{"degraded", []Check{{Status: StatusOK}, {Status: StatusDegraded}}, StatusDegraded},
+		{"failed wins", []Check{{Status: StatusDegraded}, {Status: StatusFailed}, {Status: StatusOK}}, StatusFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := overall(tt.checks); got != tt.want {
+				t.Errorf("overall() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckGitHubApp(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  config.GitHubConfig
+		want Status
+	}{
+		{"not configured", config.GitHubConfig{}, StatusDisabled},
+		{"missing key", config.GitHubConfig{AppID: 1}, StatusDegraded},
+		{"missing id", config.GitHubConfig{AppPrivateKey: testPrivateKey(t)}, StatusDegraded},
+		{"invalid key", config.GitHubConfig{AppID: 1, AppPrivateKey: []byte("not a key")}, StatusDegraded},
+		{"valid", config.GitHubConfig{AppID: 1, AppPrivateKey: testPrivateKey(t)}, StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := checkGitHubApp(tt.cfg); got.Status != tt.want {
+				t.Errorf("checkGitHubApp() = %q (%s), want %q", got.Status, got.Message, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckEncryptionKey(t *testing.T) {
+	validKey := base64.StdEncoding.EncodeToString(make([]byte, 32))
+	tests := []struct {
+		name string
+		key  string
+		env  string
+		want Status
+	}{
+		{"missing in development", "", "development", StatusDegraded},
+		{"missing in production", "", "production", StatusFailed},
+		{"wrong length in development", base64.StdEncoding.EncodeToString([]byte("short")), "development", StatusDegraded},
+		{"wrong length in production", base64.StdEncoding.EncodeToString([]byte("short")), "production", StatusFailed},
+		{"valid", validKey, "production", StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{}
+			cfg.Auth.TokenEncryptionKey = tt.key
+			cfg.Server.Env = tt.env
+			c := New(cfg, nil)
+			if got := c.checkEncryptionKey(); got.Status != tt.want {
+				t.Errorf("checkEncryptionKey() = %q (%s), want %q", got.Status, got.Message, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckTraefik(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/http/routers" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte("[]"))
+	}))
+	defer srv.Close()
+
+	cfg := &config.Config{}
+	cfg.Traefik.URL = srv.URL
+	if got := New(cfg, nil).checkTraefik(context.Background()); got.Status != StatusOK {
+		t.Errorf("reachable traefik = %q (%s), want ok", got.Status, got.Message)
+	}
+
+	srv.Close()
+	if got := New(cfg, nil).checkTraefik(context.Background()); got.Status != StatusDegraded {
+		t.Errorf("unreachable traefik = %q, want degraded", got.Status)
+	}
+
+	cfg.Traefik.URL = ""
+	if got := New(cfg, nil).checkTraefik(context.Background()); got.Status != StatusDisabled {
+		t.Errorf("unset traefik = %q, want disabled", got.Status)
+	}
+}
+
+func TestCheckDatabaseWithoutURL(t *testing.T) {
+	if got := New(&config.Config{}, nil).checkDatabase(context.Background()); got.Status != StatusFailed {
+		t.Errorf("checkDatabase() = %q, want failed", got.Status)
+	}
+}
@@ -47,7 +47,10 @@ type Deployment struct {
 	PreviousImageTag string     `json:"previousImageTag,omitempty"`
 	CurrentImageTag  string     `json:"currentImageTag,omitempty"`
 	AppVersion       string     `json:"appVersion,omitempty" example:"1.2.3"`
-	CreatedAt        time.Time  `json:"createdAt"`
+	// Quantidade e tamanho dos trechos do log movidos para o object storage
+	ArchivedLogChunks int       `json:"archivedLogChunks,omitempty" example:"2"`
+	ArchivedLogBytes  int64     `json:"archivedLogBytes,omitempty" example:"2097152"`
+	CreatedAt         time.Time `json:"createdAt"`
 }
 
 // SetupResult representa o resultado do setup de webhook
@@ -72,16 +75,20 @@ type WebhookStatus struct {
 type Envelope struct {
 	Success bool        `json:"success" example:"true"`
 	Data    interface{} `json:"data"`
-	Error   *ErrorInfo  `json:"error"`
 	Meta    Meta        `json:"meta"`
 }
 
-// ErrorInfo representa informacoes de erro
-// @Description Detalhes do erro
-type ErrorInfo struct {
-	Code    string      `json:"code" example:"NOT_FOUND"`
-	Message string      `json:"message" example:"resource not found"`
-	Details interface{} `json:"details,omitempty"`
+// Problem representa um erro no formato RFC 7807
+// @Description Erro no formato RFC 7807 (application/problem+json)
+type Problem struct {
+	Type     string      `json:"type" example:"urn:paasdeploy:error:not-found"`
+	Title    string      `json:"title" example:"Not Found"`
+	Status   int         `json:"status" example:"404"`
+	Detail   string      `json:"detail,omitempty" example:"app not found"`
+	Instance string      `json:"instance,omitempty" example:"/paas-deploy/v1/apps/123"`
+	Code     string      `json:"code" example:"NOT_FOUND"`
+	TraceID  string      `json:"traceId,omitempty" example:"abc123"`
+	Errors   interface{} `json:"errors,omitempty"`
 }
 
 // Meta representa metadados da resposta
@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+type ActivityCategory string
+
+const (
+	ActivityDeployment ActivityCategory = "deployment"
+	ActivityEnv        ActivityCategory = "env"
+	ActivityDomain     ActivityCategory = "domain"
+	ActivityScaling    ActivityCategory = "scaling"
+	ActivityContainer  ActivityCategory = "container"
+	ActivityApp        ActivityCategory = "app"
+)
+
+const (
+	ActorUser   = "user"
+	ActorSystem = "system"
+)
+
+// ActivityActor is who caused an activity. Entries written by the deploy
+// workers and webhooks have no user and are attributed to the system.
+type ActivityActor struct {
+	Type string  `json:"type"`
+	ID   *string `json:"id,omitempty"`
+	Name *string `json:"name,omitempty"`
+}
+
+// AppActivity is one entry of an app's activity feed.
+type AppActivity struct {
+	ID           string           `json:"id"`
+	Type         EventType        `json:"type"`
+	Category     ActivityCategory `json:"category"`
+	ResourceID   *string          `json:"resourceId,omitempty"`
+	ResourceName *string          `json:"resourceName,omitempty"`
+	Actor        ActivityActor    `json:"actor"`
+	Details      json.RawMessage  `json:"details,omitempty"`
+	TraceID      *string          `json:"traceId,omitempty"`
+	CreatedAt    time.Time        `json:"createdAt"`
+}
+
+func NewAppActivity(log AuditLog) AppActivity {
+	actor := ActivityActor{Type: ActorSystem}
+	if log.UserID != nil {
+		actor = ActivityActor{Type: ActorUser, ID: log.UserID, Name: log.UserName}
+	}
+	return AppActivity{
+		ID:           log.ID,
+		Type:         log.EventType,
+		Category:     ActivityCategoryOf(log.EventType),
+		ResourceID:   log.ResourceID,
+		ResourceName: log.ResourceName,
+		Actor:        actor,
+		Details:      log.Details,
+		TraceID:      log.TraceID,
+		CreatedAt:    log.CreatedAt,
+	}
+}
+
+func ActivityCategoryOf(eventType EventType) ActivityCategory {
+	switch eventType {
+	case EventAppScaled:
+		return ActivityScaling
+	case EventAppRestarted, EventAppStopped, EventAppStarted, EventAppAutoHealed:
+		return ActivityContainer
+	}
+	prefix, _, _ := strings.Cut(string(eventType), ".")
+	switch prefix {
+	case "deploy":
+		return ActivityDeployment
+	case "env":
+		return ActivityEnv
+	case "domain":
+		return ActivityDomain
+	default:
+		return ActivityApp
+	}
+}
@@ -0,0 +1,37 @@
+package domain
+
+import "testing"
+
+func TestActivityCategoryOf(t *testing.T) {
+	tests := map[EventType]ActivityCategory{
+		EventDeployStarted:  ActivityDeployment,
+		EventDeployFailed:   ActivityDeployment,
+		EventEnvBulkUpdated: ActivityEnv,
+		EventDomainRemoved:  ActivityDomain,
+		EventAppScaled:      ActivityScaling,
+		EventAppRestarted:   ActivityContainer,
+		EventAppStopped:     ActivityContainer,
+		EventAppAutoHealed:  ActivityContainer,
+		EventAppUpdated:     ActivityApp,
+		EventAppSpecApplied: ActivityApp,
+	}
+	for eventType, want := range tests {
+		if got := ActivityCategoryOf(eventType); got != want {
+			t.Errorf("ActivityCategoryOf(%q) = %q, want %q", eventType, got, want)
+		}
+	}
+}
+
+func TestNewAppActivityActor(t *testing.T) {
+	userID, userName := "u1", "octocat"
+
+	got := NewAppActivity(AuditLog{EventType: EventEnvUpdated, UserID: &userID, UserName: &userName})
+	if got.Actor.Type != ActorUser || got.Actor.ID != &userID || got.Actor.Name != &userName {
+		t.Errorf("user actor = %+v", got.Actor)
+	}
+
+	got = NewAppActivity(AuditLog{EventType: EventDeploySuccess})
+	if got.Actor.Type != ActorSystem || got.Actor.ID != nil {
+		t.Errorf("system actor = %+v", got.Actor)
+	}
+}
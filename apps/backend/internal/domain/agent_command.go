@@ -0,0 +1,76 @@
+package domain
+
+import "time"
+
+type AgentCommandType string
+
+const (
+	AgentCommandUpdateAgent      AgentCommandType = "update_agent"
+	AgentCommandStartContainer   AgentCommandType = "start_container"
+	AgentCommandStopContainer    AgentCommandType = "stop_container"
+	AgentCommandRestartContainer AgentCommandType = "restart_container"
+	AgentCommandRemoveContainer  AgentCommandType = "remove_container"
+	AgentCommandUpdateDomains    AgentCommandType = "update_domains"
+)
+
+type AgentCommandStatus string
+
+const (
+	AgentCommandStatusPending   AgentCommandStatus = "pending"
+	AgentCommandStatusDelivered AgentCommandStatus = "delivered"
+	AgentCommandStatusSucceeded AgentCommandStatus = "succeeded"
+	AgentCommandStatusFailed    AgentCommandStatus = "failed"
+	AgentCommandStatusExpired   AgentCommandStatus = "expired"
+	AgentCommandStatusCancelled AgentCommandStatus = "cancelled"
+)
+
+const (
+	defaultAgentCommandTTL = 15 * time.Minute
+	updateAgentCommandTTL  = time.Hour
+	domainCommandTTL       = 24 * time.Hour
+)
+
+// AgentCommandResultTimeout is how long a delivered command may go without a
+// result before it is marked failed. It covers the agent's command timeout
+// plus the heartbeat that reports the result.
+const AgentCommandResultTimeout = 10 * time.Minute
+
+func DefaultAgentCommandTTL(t AgentCommandType) time.Duration {
+	switch t {
+	case AgentCommandUpdateAgent:
+		return updateAgentCommandTTL
+	case AgentCommandUpdateDomains:
+		return domainCommandTTL
+	default:
+		return defaultAgentCommandTTL
+	}
+}
+
+type AgentCommand struct {
+	ID            string             `json:"id"`
+	ServerID      string             `json:"serverId"`
+	Type          AgentCommandType   `json:"type"`
+	Payload       string             `json:"-"`
+	Status        AgentCommandStatus `json:"status"`
+	ResultMessage string             `json:"resultMessage,omitempty"`
+	ExpiresAt     time.Time          `json:"expiresAt"`
+	CreatedAt     time.Time          `json:"createdAt"`
+	DeliveredAt   *time.Time         `json:"deliveredAt,omitempty"`
+	CompletedAt   *time.Time         `json:"completedAt,omitempty"`
+}
+
+type EnqueueAgentCommandInput struct {
+	ServerID string
+	Type     AgentCommandType
+	Payload  string
+	TTL      time.Duration
+}
+
+type AgentCommandRepository interface {
+	Enqueue(input EnqueueAgentCommandInput) (*AgentCommand, error)
+	ClaimPending(serverID string) ([]AgentCommand, error)
+	Complete(serverID, id string, success bool, message string) error
+	CompleteDelivered(serverID string, cmdType AgentCommandType, success bool, message string) (int64, error)
+	CancelPending(serverID string) (int64, error)
+	FindByServerID(serverID string, limit int) ([]AgentCommand, error)
+}
@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// APITokenPrefix marks personal API tokens so they can be told apart from
+// session cookies and spotted by secret scanners.
+const APITokenPrefix = "fdp_"
+
+// APIToken authenticates scripts and the CLI as the owning user. Only the
+// hash of the token is stored; TokenPrefix is kept to identify it in lists.
+type APIToken struct {
+	ID          string     `json:"id"`
+	UserID      string     `json:"-"`
+	Name        string     `json:"name"`
+	TokenHash   string     `json:"-"`
+	TokenPrefix string     `json:"tokenPrefix"`
+	LastUsedAt  *time.Time `json:"lastUsedAt,omitempty"`
+	ExpiresAt   *time.Time `json:"expiresAt,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt"`
+}
+
+type CreateAPITokenInput struct {
+	UserID      string
+	Name        string
+	TokenHash   string
+	TokenPrefix string
+	ExpiresAt   *time.Time
+}
+
+type APITokenRepository interface {
+	Create(ctx context.Context, input CreateAPITokenInput) (*APIToken, error)
+	FindByTokenHash(ctx context.Context, tokenHash string) (*APIToken, error)
+	FindByUserID(ctx context.Context, userID string) ([]APIToken, error)
+	Delete(ctx context.Context, id, userID string) error
+	TouchLastUsed(ctx context.Context, id string) error
+}
@@ -27,8 +27,70 @@ type App struct {
 	WebhookID      *int64          `json:"webhookId,omitempty"`
 	ServerID       *string         `json:"serverId,omitempty"`
 	LastDeployedAt *time.Time      `json:"lastDeployedAt,omitempty"`
-	CreatedAt      time.Time       `json:"createdAt"`
-	UpdatedAt      time.Time       `json:"updatedAt"`
+	RateLimit      *AppRateLimit   `json:"rateLimit,omitempty"`
+	Redirects      []AppRedirect   `json:"redirects"`
+	// SecurityHeaders are set by Traefik on every response of the app.
+	SecurityHeaders *AppSecurityHeaders `json:"securityHeaders,omitempty"`
+	// Internal apps get no Traefik router and are only reachable by other
+	// apps on the same server, at AppHostname.
+	Internal bool `json:"internal"`
+	// Maintenance is set while the app's domains serve a maintenance page
+	// instead of the container.
+	Maintenance *AppMaintenance `json:"maintenance,omitempty"`
+	// AutoHeal, when set, lets the health monitor restart the container
+	// after it stays unhealthy.
+	AutoHeal *AppAutoHeal `json:"autoHeal,omitempty"`
+	// LinkedAppIDs are apps on the same server whose hostname, port and URL
+	// are injected into this app's environment.
+	LinkedAppIDs []string `json:"linkedAppIds"`
+	// StatsIntervalSeconds overrides how often the container stats are
+	// sampled; nil falls back to the server's interval.
+	StatsIntervalSeconds *int      `json:"statsIntervalSeconds,omitempty"`
+	CreatedAt            time.Time `json:"createdAt"`
+	UpdatedAt            time.Time `json:"updatedAt"`
+	// DeletedAt is set while the app is in the trash.
+	DeletedAt *time.Time `json:"deletedAt,omitempty"`
+}
+
+// AppRateLimit is applied by Traefik to every route of the app. Average is in
+// requests per second; Source is one of the compose.RateLimitSource values.
+type AppRateLimit struct {
+	Average int    `json:"average"`
+	Burst   int    `json:"burst"`
+	Source  string `json:"source"`
+	Header  string `json:"header,omitempty"`
+}
+
+// AppSecurityHeaders configures the response security headers Traefik adds
+// to every route of the app. Zero values leave the header unset.
+type AppSecurityHeaders struct {
+	HSTSMaxAge            int    `json:"hstsMaxAge"`
+	HSTSIncludeSubdomains bool   `json:"hstsIncludeSubdomains"`
+	HSTSPreload           bool   `json:"hstsPreload"`
+	FrameOptions          string `json:"frameOptions,omitempty"`
+	ContentSecurityPolicy string `json:"contentSecurityPolicy,omitempty"`
+	ReferrerPolicy        string `json:"referrerPolicy,omitempty"`
+}
+
+// AppMaintenance is the maintenance page served in place of the app. An
+// empty HTML serves the platform's default page.
+type AppMaintenance struct {
+	HTML      string    `json:"html,omitempty"`
+	EnabledAt time.Time `json:"enabledAt"`
+}
+
+// AppRedirect sends SourceHost (optionally only below SourcePath) to Target
+// with the given status code: 301 or 308 are permanent, 302 or 307 temporary.
+type AppRedirect struct {
+	SourceHost string `json:"sourceHost"`
+	SourcePath string `json:"sourcePath,omitempty"`
+	Target     string `json:"target"`
+	StatusCode int    `json:"statusCode"`
+}
+
+// Permanent reports whether the redirect uses a permanent status code.
+func (r AppRedirect) Permanent() bool {
+	return r.StatusCode == 301 || r.StatusCode == 308
 }
 
 type CreateAppInput struct {
@@ -53,11 +115,25 @@ type UpdateAppInput struct {
 	ServerID      *string          `json:"serverId,omitempty"`
 }
 
+// AppFilter narrows an app list. LocalOnly selects apps on the control
+// plane host and takes precedence over ServerID.
+type AppFilter struct {
+	Status    AppStatus
+	ServerID  string
+	LocalOnly bool
+}
+
+// AppSortFields are the fields app lists can be sorted by.
+var AppSortFields = []string{"createdAt", "updatedAt", "name"}
+
 type AppRepository interface {
 	FindAll() ([]App, error)
 	FindAllByUserID(userID string) ([]App, error)
+	FindPageByUserID(userID string, filter AppFilter, opts ListOptions) (Page[App], error)
 	FindByID(id string) (*App, error)
 	FindByIDAndUserID(id, userID string) (*App, error)
+	FindByIDs(ids []string) ([]App, error)
+	FindByIDsAndUserID(ids []string, userID string) ([]App, error)
 	FindByName(name string) (*App, error)
 	FindByRepoURL(repoURL string) (*App, error)
 	FindAllByRepoURL(repoURL string) ([]App, error)
@@ -66,7 +142,24 @@ type AppRepository interface {
 	Update(id string, input UpdateAppInput) (*App, error)
 	Delete(id string) error
 	HardDelete(id string) error
+	Restore(id string) error
+	FindDeletedByID(id string) (*App, error)
+	FindDeletedByUserID(userID string) ([]App, error)
+	FindDeletedByName(name string) (*App, error)
+	FindDeletedBefore(before time.Time) ([]App, error)
 	UpdateLastDeployedAt(id string, deployedAt time.Time) error
+	UpdateRateLimit(id string, rateLimit *AppRateLimit) error
+	UpdateRedirects(id string, redirects []AppRedirect) error
+	UpdateSecurityHeaders(id string, headers *AppSecurityHeaders) error
+	UpdateInternal(id string, internal bool) error
+	UpdateMaintenance(id string, maintenance *AppMaintenance) error
+	UpdateAutoHeal(id string, policy *AppAutoHeal) error
+	UpdateLinkedApps(id string, appIDs []string) error
+	UpdateMemoryReservation(id string, bytes int64) error
+	UpdateStatsInterval(id string, seconds *int) error
+	// SumMemoryReservation adds up the memory reserved by the user's apps
+	// outside the trash, leaving out exceptAppID.
+	SumMemoryReservation(userID, exceptAppID string) (int64, error)
 }
 
 type AppWithDeployment struct {
@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	DefaultAutoHealUnhealthySeconds = 120
+	MinAutoHealUnhealthySeconds     = 30
+	MaxAutoHealUnhealthySeconds     = 3600
+	DefaultAutoHealMaxRestarts      = 3
+	MaxAutoHealMaxRestarts          = 10
+)
+
+// AppAutoHeal restarts the app's container once its healthcheck has been
+// failing for UnhealthySeconds, at most MaxRestarts times until it is seen
+// healthy again.
+type AppAutoHeal struct {
+	UnhealthySeconds int `json:"unhealthySeconds"`
+	MaxRestarts      int `json:"maxRestarts"`
+}
+
+// Threshold is how long the container must stay unhealthy before it is
+// restarted.
+func (p AppAutoHeal) Threshold() time.Duration {
+	return time.Duration(p.UnhealthySeconds) * time.Second
+}
+
+// Normalize fills unset fields with the defaults and checks the bounds.
+func (p *AppAutoHeal) Normalize() error {
+	if p.UnhealthySeconds == 0 {
+		p.UnhealthySeconds = DefaultAutoHealUnhealthySeconds
+	}
+	if p.MaxRestarts == 0 {
+		p.MaxRestarts = DefaultAutoHealMaxRestarts
+	}
+	if p.UnhealthySeconds < MinAutoHealUnhealthySeconds || p.UnhealthySeconds > MaxAutoHealUnhealthySeconds {
+		return fmt.Errorf("%w: unhealthy threshold must be between %d and %d seconds", ErrInvalidInput, MinAutoHealUnhealthySeconds, MaxAutoHealUnhealthySeconds)
+	}
+	if p.MaxRestarts < 1 || p.MaxRestarts > MaxAutoHealMaxRestarts {
+		return fmt.Errorf("%w: max restarts must be between 1 and %d", ErrInvalidInput, MaxAutoHealMaxRestarts)
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestAppAutoHealNormalize(t *testing.T) {
+	policy := AppAutoHeal{}
+	if err := policy.Normalize(); err != nil {
+		t.Fatalf("Normalize() = %v", err)
+	}
+	if policy.UnhealthySeconds != DefaultAutoHealUnhealthySeconds || policy.MaxRestarts != DefaultAutoHealMaxRestarts {
+		t.Errorf("defaults not applied: %+v", policy)
+	}
+
+	for _, policy := range []AppAutoHeal{
+		{UnhealthySeconds: 10},
+		{UnhealthySeconds: 7200},
+		{MaxRestarts: -1},
+		{MaxRestarts: 11},
+	} {
+		if err := policy.Normalize(); !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("Normalize(%+v) = %v, want ErrInvalidInput", policy, err)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package domain
+
+import "time"
+
+const AppHealthEventRetention = 30 * 24 * time.Hour
+
+// Health values reported instead of the Docker healthcheck status when the
+// container was killed for running out of memory or keeps crashing.
+const (
+	HealthOOMKilled = "oom_killed"
+	HealthCrashLoop = "crash_loop"
+)
+
+// AppHealthEvent is a period during which an app's container kept the same
+// status and health. The current period has no end.
+type AppHealthEvent struct {
+	ID              string     `json:"id"`
+	AppID           string     `json:"appId"`
+	Status          string     `json:"status"`
+	Health          string     `json:"health"`
+	StartedAt       time.Time  `json:"startedAt"`
+	EndedAt         *time.Time `json:"endedAt,omitempty"`
+	DurationSeconds int64      `json:"durationSeconds"`
+}
+
+// Healthy reports whether the container was running and neither failing
+// its healthcheck nor crash-looping.
+func (e AppHealthEvent) Healthy() bool {
+	return e.Status == "running" && e.Health != "unhealthy" && e.Health != HealthCrashLoop
+}
+
+type AppHealthHistory struct {
+	AppID            string           `json:"appId"`
+	From             time.Time        `json:"from"`
+	To               time.Time        `json:"to"`
+	Transitions      int              `json:"transitions"`
+	UnhealthySeconds int64            `json:"unhealthySeconds"`
+	Events           []AppHealthEvent `json:"events"`
+}
+
+type AppHealthEventRepository interface {
+	// Record starts a new period for the app unless its current period
+	// already has this status and health.
+	Record(appID, status, health string, at time.Time) error
+	// FindOverlapping returns the periods of the app that overlap [from, to],
+	// newest first.
+	FindOverlapping(appID string, from, to time.Time) ([]AppHealthEvent, error)
+	DeleteOlderThan(before time.Time) (int64, error)
+}
+
+// ComputeAppHealthHistory fills in the durations of events, counts the
+// transitions inside the window and sums the time spent unhealthy. Durations
+// are clipped to the window.
+func ComputeAppHealthHistory(appID string, events []AppHealthEvent, from, to time.Time) AppHealthHistory {
+	history := AppHealthHistory{
+		AppID:  appID,
+		From:   from,
+		To:     to,
+		Events: make([]AppHealthEvent, 0, len(events)),
+	}
+	for _, event := range events {
+		start, end := event.StartedAt, to
+		if event.EndedAt != nil && event.EndedAt.Before(to) {
+			end = *event.EndedAt
+		}
+		if start.Before(from) {
+			start = from
+		} else {
+			history.Transitions++
+		}
+		if end.After(start) {
+			event.DurationSeconds = int64(end.Sub(start).Seconds())
+		}
+		if !event.Healthy() {
+			history.UnhealthySeconds += event.DurationSeconds
+		}
+		history.Events = append(history.Events, event)
+	}
+	return history
+}
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestComputeAppHealthHistory(t *testing.T) {
+	from := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+	at := func(h int) *time.Time {
+		ts := from.Add(time.Duration(h) * time.Hour)
+		return &ts
+	}
+
+	events := []AppHealthEvent{
+		{Status: "running", Health: "healthy", StartedAt: *at(12)},
+		{Status: "running", Health: "unhealthy", StartedAt: *at(10), EndedAt: at(12)},
+		{Status: "running", Health: "healthy", StartedAt: *at(-5), EndedAt: at(10)},
+	}
+	history := ComputeAppHealthHistory("a1", events, from, to)
+
+	if history.Transitions != 2 {
+		t.Errorf("Transitions = %d, want 2", history.Transitions)
+	}
+	if history.UnhealthySeconds != 2*3600 {
+		t.Errorf("UnhealthySeconds = %d, want %d", history.UnhealthySeconds, 2*3600)
+	}
+	want := []int64{12 * 3600, 2 * 3600, 10 * 3600}
+	for i, event := range history.Events {
+		if event.DurationSeconds != want[i] {
+			t.Errorf("Events[%d].DurationSeconds = %d, want %d", i, event.DurationSeconds, want[i])
+		}
+	}
+}
+
+func TestAppHealthEventHealthy(t *testing.T) {
+	tests := []struct {
+		status, health string
+		want           bool
+	}{
+		{"running", "healthy", true},
+		{"running", "none", true},
+		{"running", "unhealthy", false},
+		{"exited", "none", false},
+		{"not_deployed", "none", false},
+	}
+	for _, tt := range tests {
+		if got := (AppHealthEvent{Status: tt.status, Health: tt.health}).Healthy(); got != tt.want {
+			t.Errorf("Healthy(%s, %s) = %v, want %v", tt.status, tt.health, got, tt.want)
+		}
+	}
+}
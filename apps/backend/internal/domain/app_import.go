@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"sort"
+	"strconv"
+)
+
+const (
+	ImportPlatformDokku    = "dokku"
+	ImportPlatformCapRover = "caprover"
+)
+
+func IsValidImportPlatform(platform string) bool {
+	return platform == ImportPlatformDokku || platform == ImportPlatformCapRover
+}
+
+// ImportedVolume mirrors a volume entry of paasdeploy.json.
+type ImportedVolume struct {
+	Name     string `json:"name,omitempty"`
+	Source   string `json:"source,omitempty"`
+	Target   string `json:"target"`
+	ReadOnly bool   `json:"readOnly,omitempty"`
+}
+
+// ImportedApp is an app definition read from another platform, ready to be
+// recreated as a managed app.
+type ImportedApp struct {
+	Name    string
+	EnvVars map[string]string
+	Domains []string
+	Volumes []ImportedVolume
+	Port    int
+}
+
+// EnvVarInputs returns the app's env vars as secrets, sorted by key. PORT
+// is pinned to the app's port unless already set, since the source
+// platforms inject it at runtime.
+func (a *ImportedApp) EnvVarInputs() []CreateEnvVarInput {
+	keys := make([]string, 0, len(a.EnvVars))
+	for k := range a.EnvVars {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	vars := make([]CreateEnvVarInput, 0, len(keys)+1)
+	for _, k := range keys {
+		vars = append(vars, CreateEnvVarInput{Key: k, Value: a.EnvVars[k], IsSecret: true})
+	}
+	if _, ok := a.EnvVars["PORT"]; !ok && a.Port > 0 {
+		vars = append(vars, CreateEnvVarInput{Key: "PORT", Value: strconv.Itoa(a.Port)})
+	}
+	return vars
+}
+
+// SuggestedConfig is the paasdeploy.json proposed for an imported app.
+type SuggestedConfig struct {
+	Name    string `json:"name"`
+	Runtime string `json:"runtime"`
+	Build   struct {
+		Type       string `json:"type"`
+		Dockerfile string `json:"dockerfile"`
+		Context    string `json:"context"`
+	} `json:"build"`
+	Healthcheck struct {
+		Path        string `json:"path"`
+		Interval    string `json:"interval"`
+		Timeout     string `json:"timeout"`
+		Retries     int    `json:"retries"`
+		StartPeriod string `json:"startPeriod"`
+	} `json:"healthcheck"`
+	Port    int              `json:"port"`
+	Domains []string         `json:"domains,omitempty"`
+	Volumes []ImportedVolume `json:"volumes,omitempty"`
+}
+
+// SuggestedConfig builds a Dockerfile-based paasdeploy.json for the app
+// under its new name, carrying over its port, domains and volumes.
+func (a *ImportedApp) SuggestedConfig(name, runtime string) SuggestedConfig {
+	var cfg SuggestedConfig
+	cfg.Name = name
+	cfg.Runtime = runtime
+	cfg.Build.Type = "dockerfile"
+	cfg.Build.Dockerfile = "./Dockerfile"
+	cfg.Build.Context = "."
+	cfg.Healthcheck.Path = "/"
+	cfg.Healthcheck.Interval = "30s"
+	cfg.Healthcheck.Timeout = "10s"
+	cfg.Healthcheck.Retries = 3
+	cfg.Healthcheck.StartPeriod = "30s"
+	cfg.Port = a.Port
+	cfg.Domains = a.Domains
+	cfg.Volumes = a.Volumes
+	return cfg
+}
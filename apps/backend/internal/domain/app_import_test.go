@@ -0,0 +1,40 @@
+package domain
+
+import "testing"
+
+func TestImportedAppEnvVarInputs(t *testing.T) {
+	app := ImportedApp{EnvVars: map[string]string{"B": "2", "A": "1"}, Port: 5000}
+
+	vars := app.EnvVarInputs()
+	if len(vars) != 3 {
+		t.Fatalf("expected 3 vars, got %+v", vars)
+	}
+	if vars[0].Key != "A" || vars[1].Key != "B" || !vars[0].IsSecret {
+		t.Errorf("expected sorted secret vars, got %+v", vars)
+	}
+	if vars[2] != (CreateEnvVarInput{Key: "PORT", Value: "5000"}) {
+		t.Errorf("expected pinned PORT, got %+v", vars[2])
+	}
+
+	app.EnvVars["PORT"] = "3000"
+	if vars := app.EnvVarInputs(); len(vars) != 3 {
+		t.Errorf("existing PORT should be kept as is, got %+v", vars)
+	}
+}
+
+func TestImportedAppSuggestedConfig(t *testing.T) {
+	app := ImportedApp{
+		Name:    "blog",
+		Port:    80,
+		Domains: []string{"blog.example.com"},
+		Volumes: []ImportedVolume{{Source: "/srv/blog", Target: "/data"}},
+	}
+
+	cfg := app.SuggestedConfig("blog-v2", "other")
+	if cfg.Name != "blog-v2" || cfg.Port != 80 || cfg.Build.Type != "dockerfile" {
+		t.Errorf("unexpected config %+v", cfg)
+	}
+	if len(cfg.Domains) != 1 || len(cfg.Volumes) != 1 || cfg.Volumes[0].Target != "/data" {
+		t.Errorf("domains and volumes not carried over: %+v", cfg)
+	}
+}
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// AppHostname is the DNS name other containers on the paasdeploy network
+// reach the app by. It is the container name, which stays stable across
+// deploys.
+func AppHostname(app *App) string {
+	return app.Name
+}
+
+// LinkEnvPrefix turns an app name into an env var prefix, e.g. "billing-api"
+// becomes "BILLING_API".
+func LinkEnvPrefix(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToUpper(name) {
+		if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		} else {
+			b.WriteByte('_')
+		}
+	}
+	prefix := b.String()
+	if prefix != "" && prefix[0] >= '0' && prefix[0] <= '9' {
+		prefix = "APP_" + prefix
+	}
+	return prefix
+}
+
+// LinkEnvVars returns the variables injected into an app for one of its
+// linked apps listening on port.
+func LinkEnvVars(linked *App, port int) map[string]string {
+	prefix := LinkEnvPrefix(linked.Name)
+	host := AppHostname(linked)
+	return map[string]string{
+		prefix + "_HOST": host,
+		prefix + "_PORT": strconv.Itoa(port),
+		prefix + "_URL":  fmt.Sprintf("http://%s:%d", host, port),
+	}
+}
+
+// SameServer reports whether both apps run on the same server. A nil
+// ServerID means the local host.
+func (a *App) SameServer(b *App) bool {
+	if a.ServerID == nil || b.ServerID == nil {
+		return a.ServerID == nil && b.ServerID == nil
+	}
+	return *a.ServerID == *b.ServerID
+}
@@ -0,0 +1,53 @@
+package domain
+
+import "testing"
+
+func TestLinkEnvPrefix(t *testing.T) {
+	tests := map[string]string{
+		"billing-api": "BILLING_API",
+		"redis":       "REDIS",
+		"my.app_v2":   "MY_APP_V2",
+		"3d-render":   "APP_3D_RENDER",
+	}
+	for name, want := range tests {
+		if got := LinkEnvPrefix(name); got != want {
+			t.Errorf("LinkEnvPrefix(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestLinkEnvVars(t *testing.T) {
+	vars := LinkEnvVars(&App{Name: "billing-api"}, 3000)
+	want := map[string]string{
+		"BILLING_API_HOST": "billing-api",
+		"BILLING_API_PORT": "3000",
+		"BILLING_API_URL":  "http://billing-api:3000",
+	}
+	for k, v := range want {
+		if vars[k] != v {
+			t.Errorf("%s = %q, want %q", k, vars[k], v)
+		}
+	}
+	if len(vars) != len(want) {
+		t.Errorf("unexpected vars: %v", vars)
+	}
+}
+
+func TestAppSameServer(t *testing.T) {
+	s1, s2 := "s1", "s2"
+	local := &App{}
+	remote := &App{ServerID: &s1}
+
+	if !local.SameServer(&App{}) {
+		t.Error("two local apps should share a server")
+	}
+	if local.SameServer(remote) || remote.SameServer(local) {
+		t.Error("local and remote apps should not share a server")
+	}
+	if !remote.SameServer(&App{ServerID: &s1}) {
+		t.Error("apps on s1 should share a server")
+	}
+	if remote.SameServer(&App{ServerID: &s2}) {
+		t.Error("apps on s1 and s2 should not share a server")
+	}
+}
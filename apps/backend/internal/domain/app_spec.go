@@ -0,0 +1,295 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// AppSpec is the full desired state of an app. Applying it creates the app
+// when missing and otherwise reconciles it; env vars and domains not listed
+// are removed.
+type AppSpec struct {
+	Name          string          `json:"name"`
+	RepositoryURL string          `json:"repositoryUrl"`
+	Branch        string          `json:"branch"`
+	Workdir       string          `json:"workdir"`
+	ServerID      *string         `json:"serverId,omitempty"`
+	Env           []AppSpecEnvVar `json:"env"`
+	Domains       []AppSpecDomain `json:"domains"`
+	Resources     AppResources    `json:"resources"`
+}
+
+type AppSpecEnvVar struct {
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+	Secret bool   `json:"secret,omitempty"`
+}
+
+type AppSpecDomain struct {
+	Domain      string `json:"domain"`
+	PathPrefix  string `json:"pathPrefix,omitempty"`
+	DNSProvider string `json:"dnsProvider,omitempty"`
+}
+
+// Key identifies the domain route, the same way custom domains are unique.
+func (d AppSpecDomain) Key() string {
+	return d.Domain + d.PathPrefix
+}
+
+// AppResources override the resource limits of paasdeploy.json. Empty
+// fields keep the value from the file.
+type AppResources struct {
+	Memory string `json:"memory,omitempty"`
+	CPU    string `json:"cpu,omitempty"`
+}
+
+func (r AppResources) IsZero() bool {
+	return r.Memory == "" && r.CPU == ""
+}
+
+// Override returns memory and cpu with the non-empty overrides applied.
+func (r AppResources) Override(memory, cpu string) (string, string) {
+	if r.Memory != "" {
+		memory = r.Memory
+	}
+	if r.CPU != "" {
+		cpu = r.CPU
+	}
+	return memory, cpu
+}
+
+var memoryLimitPattern = regexp.MustCompile(`^[0-9]+[bkmg]?$`)
+
+func (r AppResources) Validate() error {
+	if r.Memory != "" && !memoryLimitPattern.MatchString(r.Memory) {
+		return fmt.Errorf("%w: memory must look like 512m or 1g", ErrInvalidInput)
+	}
+	if r.CPU != "" {
+		cpu, err := strconv.ParseFloat(r.CPU, 64)
+		if err != nil || cpu <= 0 {
+			return fmt.Errorf("%w: cpu must be a positive number", ErrInvalidInput)
+		}
+	}
+	return nil
+}
+
+// Resources returns the overrides stored in the app config.
+func (a *App) Resources() AppResources {
+	var cfg struct {
+		Resources AppResources `json:"resources"`
+	}
+	if len(a.Config) > 0 {
+		_ = json.Unmarshal(a.Config, &cfg)
+	}
+	return cfg.Resources
+}
+
+// WithResources returns config with its resources replaced, keeping any
+// other keys.
+func WithResources(config json.RawMessage, resources AppResources) (json.RawMessage, error) {
+	fields := map[string]json.RawMessage{}
+	if len(config) > 0 {
+		if err := json.Unmarshal(config, &fields); err != nil {
+			return nil, err
+		}
+	}
+	if resources.IsZero() {
+		delete(fields, "resources")
+	} else {
+		raw, err := json.Marshal(resources)
+		if err != nil {
+			return nil, err
+		}
+		fields["resources"] = raw
+	}
+	return json.Marshal(fields)
+}
+
+// Normalize trims the spec and fills the defaults apps are created with.
+func (s *AppSpec) Normalize() {
+	s.Name = strings.TrimSpace(s.Name)
+	s.RepositoryURL = strings.TrimSpace(s.RepositoryURL)
+	s.Branch = strings.TrimSpace(s.Branch)
+	if s.Branch == "" {
+		s.Branch = "main"
+	}
+	s.Workdir = strings.TrimSpace(s.Workdir)
+	if s.Workdir == "" {
+		s.Workdir = "."
+	}
+	for i := range s.Env {
+		s.Env[i].Key = strings.TrimSpace(s.Env[i].Key)
+	}
+	for i := range s.Domains {
+		d := &s.Domains[i]
+		d.Domain = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(d.Domain)), ".")
+		d.PathPrefix = strings.TrimSpace(d.PathPrefix)
+		if d.PathPrefix != "" && !strings.HasPrefix(d.PathPrefix, "/") {
+			d.PathPrefix = "/" + d.PathPrefix
+		}
+		d.DNSProvider = strings.TrimSpace(d.DNSProvider)
+	}
+}
+
+func (s *AppSpec) Validate() error {
+	if s.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidInput)
+	}
+	if s.RepositoryURL == "" {
+		return fmt.Errorf("%w: repositoryUrl is required", ErrInvalidInput)
+	}
+	keys := make(map[string]bool, len(s.Env))
+	for _, v := range s.Env {
+		if v.Key == "" {
+			return fmt.Errorf("%w: all env vars must have a key", ErrInvalidInput)
+		}
+		if keys[v.Key] {
+			return fmt.Errorf("%w: env var %s is listed twice", ErrInvalidInput, v.Key)
+		}
+		keys[v.Key] = true
+	}
+	routes := make(map[string]bool, len(s.Domains))
+	for _, d := range s.Domains {
+		if d.Domain == "" {
+			return fmt.Errorf("%w: all domains must have a name", ErrInvalidInput)
+		}
+		if routes[d.Key()] {
+			return fmt.Errorf("%w: domain %s is listed twice", ErrInvalidInput, d.Key())
+		}
+		routes[d.Key()] = true
+	}
+	return s.Resources.Validate()
+}
+
+// EnvVarInputs returns the spec's env vars in the form stored by the env
+// var repository.
+func (s *AppSpec) EnvVarInputs() []CreateEnvVarInput {
+	vars := make([]CreateEnvVarInput, len(s.Env))
+	for i, v := range s.Env {
+		vars[i] = CreateEnvVarInput{Key: v.Key, Value: v.Value, IsSecret: v.Secret}
+	}
+	return vars
+}
+
+type SpecChangeAction string
+
+const (
+	SpecChangeAdd    SpecChangeAction = "add"
+	SpecChangeUpdate SpecChangeAction = "update"
+	SpecChangeRemove SpecChangeAction = "remove"
+)
+
+// SpecChange is one difference between an app and its spec. Field is a
+// dotted path such as "branch", "env.DATABASE_URL" or "domains.example.com".
+type SpecChange struct {
+	Field  string           `json:"field"`
+	Action SpecChangeAction `json:"action"`
+	From   string           `json:"from,omitempty"`
+	To     string           `json:"to,omitempty"`
+}
+
+const maskedSpecValue = "********"
+
+// DiffAppSpec lists what applying spec changes, ordered by field. app is nil
+// when the app does not exist yet. Secret values are masked, and the
+// redirect half of an apex/www pair is left out since it follows its
+// primary domain.
+func DiffAppSpec(app *App, envVars []EnvVar, domains []CustomDomain, spec AppSpec) []SpecChange {
+	var current App
+	if app != nil {
+		current = *app
+	}
+	var changes []SpecChange
+	diffValue := func(field, from, to string) {
+		if change, ok := specValueChange(field, from, to); ok {
+			changes = append(changes, change)
+		}
+	}
+
+	diffValue("repositoryUrl", current.RepositoryURL, spec.RepositoryURL)
+	diffValue("branch", current.Branch, spec.Branch)
+	diffValue("workdir", current.Workdir, spec.Workdir)
+	resources := current.Resources()
+	diffValue("resources.memory", resources.Memory, spec.Resources.Memory)
+	diffValue("resources.cpu", resources.CPU, spec.Resources.CPU)
+
+	existingEnv := make(map[string]EnvVar, len(envVars))
+	for _, v := range envVars {
+		existingEnv[v.Key] = v
+	}
+	for _, v := range spec.Env {
+		field := "env." + v.Key
+		old, ok := existingEnv[v.Key]
+		delete(existingEnv, v.Key)
+		to := v.Value
+		if v.Secret {
+			to = maskedSpecValue
+		}
+		switch {
+		case !ok:
+			changes = append(changes, SpecChange{Field: field, Action: SpecChangeAdd, To: to})
+		case old.Value != v.Value || old.IsSecret != v.Secret:
+			from := old.Value
+			if old.IsSecret {
+				from = maskedSpecValue
+			}
+			changes = append(changes, SpecChange{Field: field, Action: SpecChangeUpdate, From: from, To: to})
+		}
+	}
+	for key := range existingEnv {
+		changes = append(changes, SpecChange{Field: "env." + key, Action: SpecChangeRemove})
+	}
+
+	toAdd, toRemove := DiffSpecDomains(domains, spec.Domains)
+	for _, d := range toAdd {
+		changes = append(changes, SpecChange{Field: "domains." + d.Key(), Action: SpecChangeAdd, To: d.Key()})
+	}
+	for _, d := range toRemove {
+		key := d.Domain + d.PathPrefix
+		changes = append(changes, SpecChange{Field: "domains." + key, Action: SpecChangeRemove, From: key})
+	}
+
+	sort.SliceStable(changes, func(i, j int) bool { return changes[i].Field < changes[j].Field })
+	return changes
+}
+
+func specValueChange(field, from, to string) (SpecChange, bool) {
+	switch {
+	case from == to:
+		return SpecChange{}, false
+	case from == "":
+		return SpecChange{Field: field, Action: SpecChangeAdd, To: to}, true
+	case to == "":
+		return SpecChange{Field: field, Action: SpecChangeRemove, From: from}, true
+	default:
+		return SpecChange{Field: field, Action: SpecChangeUpdate, From: from, To: to}, true
+	}
+}
+
+// DiffSpecDomains returns the spec domains missing from the app and the
+// app's primary domains missing from the spec.
+func DiffSpecDomains(current []CustomDomain, desired []AppSpecDomain) ([]AppSpecDomain, []CustomDomain) {
+	existing := make(map[string]bool, len(current))
+	for _, d := range current {
+		existing[d.Domain+d.PathPrefix] = true
+	}
+	wanted := make(map[string]bool, len(desired))
+	var toAdd []AppSpecDomain
+	for _, d := range desired {
+		wanted[d.Key()] = true
+		if !existing[d.Key()] {
+			toAdd = append(toAdd, d)
+		}
+	}
+	var toRemove []CustomDomain
+	for _, d := range current {
+		if !d.IsRedirect() && !wanted[d.Domain+d.PathPrefix] {
+			toRemove = append(toRemove, d)
+		}
+	}
+	return toAdd, toRemove
+}
@@ -0,0 +1,121 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestAppSpecNormalizeAndValidate(t *testing.T) {
+	spec := AppSpec{
+		Name:          " api ",
+		RepositoryURL: "https://github.com/acme/api",
+		Domains:       []AppSpecDomain{{Domain: "API.Example.com.", PathPrefix: "v1"}},
+	}
+	spec.Normalize()
+	if spec.Name != "api" || spec.Branch != "main" || spec.Workdir != "." {
+		t.Errorf("unexpected defaults %+v", spec)
+	}
+	if d := spec.Domains[0]; d.Domain != "api.example.com" || d.PathPrefix != "/v1" {
+		t.Errorf("domain not normalized: %+v", d)
+	}
+	if err := spec.Validate(); err != nil {
+		t.Fatalf("expected valid spec, got %v", err)
+	}
+
+	tests := []struct {
+		name string
+		edit func(*AppSpec)
+	}{
+		{"duplicate env", func(s *AppSpec) { s.Env = []AppSpecEnvVar{{Key: "A"}, {Key: "A"}} }},
+		{"empty env key", func(s *AppSpec) { s.Env = []AppSpecEnvVar{{Value: "x"}} }},
+		{"duplicate domain", func(s *AppSpec) { s.Domains = append(s.Domains, s.Domains[0]) }},
+		{"bad memory", func(s *AppSpec) { s.Resources.Memory = "lots" }},
+		{"bad cpu", func(s *AppSpec) { s.Resources.CPU = "-1" }},
+		{"missing repo", func(s *AppSpec) { s.RepositoryURL = "" }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := spec
+			s.Domains = append([]AppSpecDomain(nil), spec.Domains...)
+			tt.edit(&s)
+			if err := s.Validate(); !errors.Is(err, ErrInvalidInput) {
+				t.Errorf("expected ErrInvalidInput, got %v", err)
+			}
+		})
+	}
+}
+
+func TestWithResources(t *testing.T) {
+	config, err := WithResources(json.RawMessage(`{"other":true}`), AppResources{Memory: "1g"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	app := App{Config: config}
+	if got := app.Resources(); got != (AppResources{Memory: "1g"}) {
+		t.Errorf("unexpected resources %+v", got)
+	}
+
+	config, err = WithResources(config, AppResources{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(config) != `{"other":true}` {
+		t.Errorf("expected resources removed and other keys kept, got %s", config)
+	}
+}
+
+func TestDiffAppSpec(t *testing.T) {
+	app := &App{
+		RepositoryURL: "https://github.com/acme/api",
+		Branch:        "main",
+		Workdir:       ".",
+		Config:        json.RawMessage(`{"resources":{"memory":"512m"}}`),
+	}
+	envVars := []EnvVar{
+		{Key: "KEEP", Value: "1"},
+		{Key: "TOKEN", Value: "old", IsSecret: true},
+		{Key: "GONE", Value: "x"},
+	}
+	domains := []CustomDomain{
+		{Domain: "example.com"},
+		{Domain: "www.example.com", RedirectTo: "example.com"},
+		{Domain: "old.example.com"},
+	}
+	spec := AppSpec{
+		RepositoryURL: "https://github.com/acme/api",
+		Branch:        "release",
+		Workdir:       ".",
+		Env: []AppSpecEnvVar{
+			{Key: "KEEP", Value: "1"},
+			{Key: "TOKEN", Value: "new", Secret: true},
+			{Key: "NEW", Value: "2"},
+		},
+		Domains:   []AppSpecDomain{{Domain: "example.com"}, {Domain: "api.example.com"}},
+		Resources: AppResources{Memory: "1g", CPU: "1"},
+	}
+
+	want := []SpecChange{
+		{Field: "branch", Action: SpecChangeUpdate, From: "main", To: "release"},
+		{Field: "domains.api.example.com", Action: SpecChangeAdd, To: "api.example.com"},
+		{Field: "domains.old.example.com", Action: SpecChangeRemove, From: "old.example.com"},
+		{Field: "env.GONE", Action: SpecChangeRemove},
+		{Field: "env.NEW", Action: SpecChangeAdd, To: "2"},
+		{Field: "env.TOKEN", Action: SpecChangeUpdate, From: maskedSpecValue, To: maskedSpecValue},
+		{Field: "resources.cpu", Action: SpecChangeAdd, To: "1"},
+		{Field: "resources.memory", Action: SpecChangeUpdate, From: "512m", To: "1g"},
+	}
+	got := DiffAppSpec(app, envVars, domains, spec)
+	if len(got) != len(want) {
+		t.Fatalf("expected %d changes, got %+v", len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("change %d: expected %+v, got %+v", i, want[i], got[i])
+		}
+	}
+
+	if changes := DiffAppSpec(nil, nil, nil, AppSpec{RepositoryURL: "r", Branch: "main"}); len(changes) != 2 {
+		t.Errorf("expected repositoryUrl and branch added for a new app, got %+v", changes)
+	}
+}
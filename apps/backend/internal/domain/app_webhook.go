@@ -0,0 +1,91 @@
+package domain
+
+import "time"
+
+// Events an app webhook can subscribe to.
+const (
+	AppWebhookEventDeployStarted   = "deploy.started"
+	AppWebhookEventDeploySucceeded = "deploy.succeeded"
+	AppWebhookEventDeployFailed    = "deploy.failed"
+	AppWebhookEventHealthChanged   = "health.changed"
+	// AppWebhookEventPing is only sent when a webhook is tested.
+	AppWebhookEventPing = "ping"
+)
+
+var AppWebhookEvents = []string{
+	AppWebhookEventDeployStarted,
+	AppWebhookEventDeploySucceeded,
+	AppWebhookEventDeployFailed,
+	AppWebhookEventHealthChanged,
+}
+
+// AppWebhook is an outbound HTTP endpoint that receives an app's lifecycle
+// events, signed with a secret only the platform and the receiver know.
+type AppWebhook struct {
+	ID              string     `json:"id"`
+	AppID           string     `json:"appId"`
+	URL             string     `json:"url"`
+	SecretEncrypted string     `json:"-"`
+	Events          []string   `json:"events"`
+	Enabled         bool       `json:"enabled"`
+	LastDeliveryAt  *time.Time `json:"lastDeliveryAt,omitempty"`
+	LastStatusCode  int        `json:"lastStatusCode,omitempty"`
+	LastError       string     `json:"lastError,omitempty"`
+	CreatedAt       time.Time  `json:"createdAt"`
+	UpdatedAt       time.Time  `json:"updatedAt"`
+}
+
+// Subscribes reports whether the webhook receives event. A webhook without
+// events receives all of them.
+func (w AppWebhook) Subscribes(event string) bool {
+	if len(w.Events) == 0 {
+		return true
+	}
+	for _, e := range w.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// IsValidAppWebhookEvent reports whether event is one of AppWebhookEvents.
+func IsValidAppWebhookEvent(event string) bool {
+	for _, e := range AppWebhookEvents {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+type CreateAppWebhookInput struct {
+	AppID           string
+	URL             string
+	SecretEncrypted string
+	Events          []string
+	Enabled         bool
+}
+
+type UpdateAppWebhookInput struct {
+	URL     *string
+	Events  *[]string
+	Enabled *bool
+}
+
+// AppWebhookDelivery is the outcome of the last attempt to deliver an event.
+type AppWebhookDelivery struct {
+	At         time.Time
+	StatusCode int
+	Error      string
+}
+
+type AppWebhookRepository interface {
+	FindByAppID(appID string) ([]AppWebhook, error)
+	FindByIDAndAppID(id, appID string) (*AppWebhook, error)
+	FindEnabledByAppID(appID string) ([]AppWebhook, error)
+	Create(input CreateAppWebhookInput) (*AppWebhook, error)
+	Update(id string, input UpdateAppWebhookInput) (*AppWebhook, error)
+	RecordDelivery(id string, delivery AppWebhookDelivery) error
+	Delete(id string) error
+}
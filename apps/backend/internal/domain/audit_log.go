@@ -8,42 +8,72 @@ import (
 type EventType string
 
 const (
-	EventAppCreated       EventType = "app.created"
-	EventAppUpdated       EventType = "app.updated"
-	EventAppDeleted       EventType = "app.deleted"
-	EventAppPurged        EventType = "app.purged"
-	EventDeployStarted    EventType = "deploy.started"
-	EventDeploySuccess    EventType = "deploy.success"
-	EventDeployFailed     EventType = "deploy.failed"
-	EventEnvCreated       EventType = "env.created"
-	EventEnvUpdated       EventType = "env.updated"
-	EventEnvDeleted       EventType = "env.deleted"
-	EventEnvBulkUpdated   EventType = "env.bulk_updated"
-	EventDomainAdded      EventType = "domain.added"
-	EventDomainRemoved    EventType = "domain.removed"
-	EventContainerStarted EventType = "container.started"
-	EventContainerStopped EventType = "container.stopped"
-	EventContainerRemoved EventType = "container.removed"
-	EventContainerCreated EventType = "container.created"
-	EventUserLoggedIn     EventType = "user.logged_in"
-	EventUserLoggedOut    EventType = "user.logged_out"
-	EventWebhookCreated   EventType = "webhook.created"
-	EventWebhookRemoved   EventType = "webhook.removed"
-	EventImageRemoved     EventType = "image.removed"
-	EventImagesPruned     EventType = "images.pruned"
+	EventAppCreated              EventType = "app.created"
+	EventAppUpdated              EventType = "app.updated"
+	EventAppDeleted              EventType = "app.deleted"
+	EventAppPurged               EventType = "app.purged"
+	EventAppRestored             EventType = "app.restored"
+	EventAppSpecApplied          EventType = "app.spec_applied"
+	EventAppBulkAction           EventType = "app.bulk_action"
+	EventAppScaled               EventType = "app.scaled"
+	EventAppRestarted            EventType = "app.restarted"
+	EventAppStopped              EventType = "app.stopped"
+	EventAppStarted              EventType = "app.started"
+	EventAppMaintenance          EventType = "app.maintenance"
+	EventAppAutoHeal             EventType = "app.auto_heal"
+	EventAppAutoHealed           EventType = "app.auto_healed"
+	EventDeployStarted           EventType = "deploy.started"
+	EventDeploySuccess           EventType = "deploy.success"
+	EventDeployFailed            EventType = "deploy.failed"
+	EventEnvCreated              EventType = "env.created"
+	EventEnvUpdated              EventType = "env.updated"
+	EventEnvDeleted              EventType = "env.deleted"
+	EventEnvBulkUpdated          EventType = "env.bulk_updated"
+	EventDomainAdded             EventType = "domain.added"
+	EventDomainRemoved           EventType = "domain.removed"
+	EventContainerStarted        EventType = "container.started"
+	EventContainerStopped        EventType = "container.stopped"
+	EventContainerRemoved        EventType = "container.removed"
+	EventContainerCreated        EventType = "container.created"
+	EventContainerFileDownloaded EventType = "container.file_downloaded"
+	EventContainerFileUploaded   EventType = "container.file_uploaded"
+	EventContainerCommitted      EventType = "container.committed"
+	EventUserLoggedIn            EventType = "user.logged_in"
+	EventUserLoggedOut           EventType = "user.logged_out"
+	EventAPITokenCreated         EventType = "api_token.created"
+	EventAPITokenRevoked         EventType = "api_token.revoked"
+	EventWebhookCreated          EventType = "webhook.created"
+	EventWebhookRemoved          EventType = "webhook.removed"
+	EventWebhookReplayed         EventType = "webhook.replayed"
+	EventImageRemoved            EventType = "image.removed"
+	EventImagesPruned            EventType = "images.pruned"
+	EventVolumeFileDownloaded    EventType = "volume.file_downloaded"
+	EventGitOpsSourceCreated     EventType = "gitops_source.created"
+	EventGitOpsSourceDeleted     EventType = "gitops_source.deleted"
+	EventGitOpsSourceSynced      EventType = "gitops_source.sync_triggered"
+	EventBackupCreated           EventType = "backup.created"
+	EventServerTaskCreated       EventType = "server_task.created"
+	EventServerTaskUpdated       EventType = "server_task.updated"
+	EventServerTaskDeleted       EventType = "server_task.deleted"
+	EventServerTaskRun           EventType = "server_task.run"
 )
 
 type ResourceType string
 
 const (
-	ResourceApp        ResourceType = "app"
-	ResourceDeployment ResourceType = "deployment"
-	ResourceEnvVar     ResourceType = "env_var"
-	ResourceDomain     ResourceType = "domain"
-	ResourceContainer  ResourceType = "container"
-	ResourceUser       ResourceType = "user"
-	ResourceWebhook    ResourceType = "webhook"
-	ResourceImage      ResourceType = "image"
+	ResourceApp          ResourceType = "app"
+	ResourceDeployment   ResourceType = "deployment"
+	ResourceEnvVar       ResourceType = "env_var"
+	ResourceDomain       ResourceType = "domain"
+	ResourceContainer    ResourceType = "container"
+	ResourceUser         ResourceType = "user"
+	ResourceWebhook      ResourceType = "webhook"
+	ResourceImage        ResourceType = "image"
+	ResourceVolume       ResourceType = "volume"
+	ResourceAPIToken     ResourceType = "api_token"
+	ResourceGitOpsSource ResourceType = "gitops_source"
+	ResourceSystem       ResourceType = "system"
+	ResourceServerTask   ResourceType = "server_task"
 )
 
 type AuditLog struct {
@@ -57,6 +87,7 @@ type AuditLog struct {
 	Details      json.RawMessage `json:"details,omitempty"`
 	IPAddress    *string         `json:"ipAddress,omitempty"`
 	UserAgent    *string         `json:"userAgent,omitempty"`
+	TraceID      *string         `json:"traceId,omitempty"`
 	CreatedAt    time.Time       `json:"createdAt"`
 }
 
@@ -70,6 +101,7 @@ type CreateAuditLogInput struct {
 	Details      map[string]interface{}
 	IPAddress    *string
 	UserAgent    *string
+	TraceID      string
 }
 
 type AuditLogFilter struct {
@@ -77,10 +109,18 @@ type AuditLogFilter struct {
 	ResourceType *ResourceType
 	ResourceID   *string
 	UserID       *string
-	StartDate    *time.Time
-	EndDate      *time.Time
-	Limit        int
-	Offset       int
+	TraceID      *string
+	// AppID matches the logs of the app itself and of the deployments, env
+	// vars and domains that name it in their details.
+	AppID     *string
+	StartDate *time.Time
+	EndDate   *time.Time
+	// Search matches resource and user names.
+	Search string
+	// After continues from a cursor; Offset is ignored when it is set.
+	After  *Cursor
+	Limit  int
+	Offset int
 }
 
 type AuditLogRepository interface {
@@ -1,5 +1,7 @@
 package domain
 
+import "time"
+
 type CertificateAuthorityRecord struct {
 	CertPEM []byte
 	KeyPEM  []byte
@@ -9,3 +11,25 @@ type CertificateAuthorityRepository interface {
 	GetRoot() (*CertificateAuthorityRecord, error)
 	UpsertRoot(record CertificateAuthorityRecord) error
 }
+
+type CertificateRevocation struct {
+	ID           string    `json:"id"`
+	ServerID     string    `json:"serverId"`
+	SerialNumber *string   `json:"serialNumber,omitempty"`
+	Reason       *string   `json:"reason,omitempty"`
+	RevokedBy    *string   `json:"revokedBy,omitempty"`
+	RevokedAt    time.Time `json:"revokedAt"`
+}
+
+type CreateCertificateRevocationInput struct {
+	ServerID     string
+	SerialNumber string
+	Reason       string
+	RevokedBy    string
+}
+
+type CertificateRevocationRepository interface {
+	Create(input CreateCertificateRevocationInput) (*CertificateRevocation, error)
+	FindAll() ([]CertificateRevocation, error)
+	FindByServerID(serverID string) ([]CertificateRevocation, error)
+}
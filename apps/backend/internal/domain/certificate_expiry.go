@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// CertificateExpiry describes a TLS certificate nearing its expiry date.
+// Uploaded is false for certificates issued by Let's Encrypt through Traefik.
+type CertificateExpiry struct {
+	Domain    string
+	AppID     string
+	ServerID  string
+	ExpiresAt time.Time
+	Uploaded  bool
+}
+
+// DaysRemaining rounds down, so a certificate expiring later today reports 0.
+func (c CertificateExpiry) DaysRemaining(now time.Time) int {
+	return int(c.ExpiresAt.Sub(now).Hours() / 24)
+}
+
+// CertificateExpiryAlertRepository remembers which thresholds were alerted for
+// a certificate, identified by its domain and expiry date, so a renewed
+// certificate starts over.
+type CertificateExpiryAlertRepository interface {
+	// Record stores the alert and reports false if it had already been sent.
+	Record(ctx context.Context, domain string, expiresAt time.Time, thresholdDays int) (bool, error)
+	DeleteExpiredBefore(ctx context.Context, before time.Time) error
+}
@@ -0,0 +1,35 @@
+package domain
+
+import "time"
+
+const (
+	CloudProviderHetzner      = "hetzner"
+	CloudProviderDigitalOcean = "digitalocean"
+	CloudProviderAWS          = "aws"
+)
+
+func IsValidCloudProvider(provider string) bool {
+	switch provider {
+	case CloudProviderHetzner, CloudProviderDigitalOcean, CloudProviderAWS:
+		return true
+	}
+	return false
+}
+
+// CloudCredential is a user's API credential for a cloud provider, used to
+// create servers on their behalf. The credential is stored encrypted.
+type CloudCredential struct {
+	ID                   string
+	UserID               string
+	Provider             string
+	CredentialsEncrypted string
+	CreatedAt            time.Time
+	UpdatedAt            time.Time
+}
+
+type CloudCredentialRepository interface {
+	Upsert(userID, provider, credentialsEncrypted string) (*CloudCredential, error)
+	FindByUserAndProvider(userID, provider string) (*CloudCredential, error)
+	ListByUserID(userID string) ([]CloudCredential, error)
+	Delete(userID, provider string) error
+}
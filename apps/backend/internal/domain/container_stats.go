@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	MinStatsIntervalSeconds = 2
+	MaxStatsIntervalSeconds = 300
+
+	// StatsWindowSpan is how far back container stats samples are kept for
+	// the rolling averages.
+	StatsWindowSpan = 5 * time.Minute
+)
+
+func ValidateStatsInterval(seconds *int) error {
+	if seconds == nil {
+		return nil
+	}
+	if *seconds < MinStatsIntervalSeconds || *seconds > MaxStatsIntervalSeconds {
+		return fmt.Errorf("%w: stats interval must be between %d and %d seconds", ErrInvalidInput, MinStatsIntervalSeconds, MaxStatsIntervalSeconds)
+	}
+	return nil
+}
+
+// StatsInterval returns how often the stats of app are sampled: its own
+// interval, else its server's, else fallback. server may be nil.
+func StatsInterval(app *App, server *Server, fallback time.Duration) time.Duration {
+	if app.StatsIntervalSeconds != nil {
+		return time.Duration(*app.StatsIntervalSeconds) * time.Second
+	}
+	if server != nil && server.StatsIntervalSeconds != nil {
+		return time.Duration(*server.StatsIntervalSeconds) * time.Second
+	}
+	return fallback
+}
+
+type StatsSample struct {
+	At            time.Time
+	CPUPercent    float64
+	MemoryUsage   int64
+	MemoryPercent float64
+}
+
+type StatsAverage struct {
+	CPUPercent    float64 `json:"cpuPercent"`
+	MemoryUsage   int64   `json:"memoryUsage"`
+	MemoryPercent float64 `json:"memoryPercent"`
+	Samples       int     `json:"samples"`
+}
+
+// StatsWindow keeps the container stats samples of the last StatsWindowSpan.
+// Samples must be added in time order.
+type StatsWindow struct {
+	samples []StatsSample
+}
+
+func (w *StatsWindow) Add(sample StatsSample) {
+	w.samples = append(w.samples, sample)
+	cutoff := sample.At.Add(-StatsWindowSpan)
+	drop := 0
+	for drop < len(w.samples) && w.samples[drop].At.Before(cutoff) {
+		drop++
+	}
+	w.samples = w.samples[drop:]
+}
+
+// Average averages the samples taken in the span before now.
+func (w *StatsWindow) Average(now time.Time, span time.Duration) StatsAverage {
+	var avg StatsAverage
+	var cpu, memPercent float64
+	var mem int64
+	cutoff := now.Add(-span)
+	for _, s := range w.samples {
+		if s.At.Before(cutoff) {
+			continue
+		}
+		cpu += s.CPUPercent
+		mem += s.MemoryUsage
+		memPercent += s.MemoryPercent
+		avg.Samples++
+	}
+	if avg.Samples == 0 {
+		return avg
+	}
+	avg.CPUPercent = cpu / float64(avg.Samples)
+	avg.MemoryUsage = mem / int64(avg.Samples)
+	avg.MemoryPercent = memPercent / float64(avg.Samples)
+	return avg
+}
@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestStatsWindowAverage(t *testing.T) {
+	start := time.Date(2026, 5, 1, 10, 0, 0, 0, time.UTC)
+	var w StatsWindow
+	for i := 0; i < 10; i++ {
+		w.Add(StatsSample{
+			At:          start.Add(time.Duration(i) * time.Minute),
+			CPUPercent:  float64(i * 10),
+			MemoryUsage: int64(i * 100),
+		})
+	}
+	now := start.Add(9 * time.Minute)
+
+	if n := len(w.samples); n != 6 {
+		t.Fatalf("kept %d samples, want 6", n)
+	}
+	if got := w.Average(now, time.Minute); got.Samples != 2 || got.CPUPercent != 85 || got.MemoryUsage != 850 {
+		t.Errorf("1m average = %+v", got)
+	}
+	if got := w.Average(now, StatsWindowSpan); got.Samples != 6 || got.CPUPercent != 65 {
+		t.Errorf("5m average = %+v", got)
+	}
+	if got := w.Average(now.Add(time.Hour), time.Minute); got.Samples != 0 || got.CPUPercent != 0 {
+		t.Errorf("stale average = %+v", got)
+	}
+}
+
+func TestStatsInterval(t *testing.T) {
+	ten, thirty := 10, 30
+	fallback := 3 * time.Second
+
+	if got := StatsInterval(&App{}, nil, fallback); got != fallback {
+		t.Errorf("default = %v", got)
+	}
+	if got := StatsInterval(&App{}, &Server{StatsIntervalSeconds: &thirty}, fallback); got != 30*time.Second {
+		t.Errorf("server = %v", got)
+	}
+	if got := StatsInterval(&App{StatsIntervalSeconds: &ten}, &Server{StatsIntervalSeconds: &thirty}, fallback); got != 10*time.Second {
+		t.Errorf("app = %v", got)
+	}
+}
+
+func TestValidateStatsInterval(t *testing.T) {
+	for _, seconds := range []int{MinStatsIntervalSeconds, 60, MaxStatsIntervalSeconds} {
+		if err := ValidateStatsInterval(&seconds); err != nil {
+			t.Errorf("ValidateStatsInterval(%d) = %v", seconds, err)
+		}
+	}
+	for _, seconds := range []int{0, 1, MaxStatsIntervalSeconds + 1} {
+		if err := ValidateStatsInterval(&seconds); !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("ValidateStatsInterval(%d) = %v, want ErrInvalidInput", seconds, err)
+		}
+	}
+	if err := ValidateStatsInterval(nil); err != nil {
+		t.Errorf("ValidateStatsInterval(nil) = %v", err)
+	}
+}
@@ -2,6 +2,7 @@ package domain
 
 import (
 	"context"
+	"strings"
 	"time"
 )
 
@@ -13,11 +14,88 @@ type CustomDomain struct {
 	ZoneID      string
 	DNSRecordID string
 	RecordType  string
-	Status      string
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	// DNSProvider is the provider managing DNSRecordID; ZoneID is in its
+	// terms too.
+	DNSProvider string
+	// RedirectTo is set on the secondary name of an apex/www pair, which is
+	// served as a permanent redirect to it instead of routing to the app.
+	RedirectTo string
+	Status     string
+	// BasicAuthUsers holds htpasswd entries ("user:bcrypt-hash"), one per
+	// line. Empty means the domain is not protected.
+	BasicAuthUsers string
+	// IPAllowlist holds the IPs or CIDR ranges allowed to reach the domain,
+	// one per line. Empty means no restriction.
+	IPAllowlist string
+	// TLSCertificate is an uploaded PEM chain served instead of a Let's
+	// Encrypt certificate. TLSPrivateKey holds its key, encrypted.
+	TLSCertificate string
+	TLSPrivateKey  string
+	TLSExpiresAt   *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
 }
 
+func splitLines(value string) []string {
+	var entries []string
+	for _, line := range strings.Split(value, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			entries = append(entries, line)
+		}
+	}
+	return entries
+}
+
+func (d *CustomDomain) BasicAuthEntries() []string {
+	return splitLines(d.BasicAuthUsers)
+}
+
+func (d *CustomDomain) IPAllowlistEntries() []string {
+	return splitLines(d.IPAllowlist)
+}
+
+func (d *CustomDomain) HasCustomCertificate() bool {
+	return d.TLSCertificate != ""
+}
+
+func (d *CustomDomain) BasicAuthUsernames() []string {
+	entries := d.BasicAuthEntries()
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		name, _, _ := strings.Cut(entry, ":")
+		names = append(names, name)
+	}
+	return names
+}
+
+// IsRedirect reports whether the domain only redirects to its pair.
+func (d *CustomDomain) IsRedirect() bool {
+	return d.RedirectTo != ""
+}
+
+// CustomDomainRedirects returns the redirects serving the secondary names of
+// apex/www pairs.
+func CustomDomainRedirects(domains []CustomDomain) []AppRedirect {
+	var redirects []AppRedirect
+	for _, d := range domains {
+		if d.IsRedirect() {
+			redirects = append(redirects, AppRedirect{
+				SourceHost: d.Domain,
+				Target:     "https://" + d.RedirectTo,
+				StatusCode: 301,
+			})
+		}
+	}
+	return redirects
+}
+
+// WWW pairing options when adding an apex domain: the www name is added
+// alongside it and one of the two redirects to the other.
+const (
+	WWWPairingRedirectToApex = "redirect-to-apex"
+	WWWPairingRedirectToWWW  = "redirect-to-www"
+)
+
 type CreateCustomDomainInput struct {
 	AppID       string
 	Domain      string
@@ -25,14 +103,24 @@ type CreateCustomDomainInput struct {
 	ZoneID      string
 	DNSRecordID string
 	RecordType  string
+	DNSProvider string
+	RedirectTo  string
 }
 
 type CustomDomainRepository interface {
 	Create(ctx context.Context, input CreateCustomDomainInput) (*CustomDomain, error)
 	FindByID(ctx context.Context, id string) (*CustomDomain, error)
 	FindByAppID(ctx context.Context, appID string) ([]CustomDomain, error)
+	FindByAppIDs(ctx context.Context, appIDs []string) ([]CustomDomain, error)
 	FindByDomain(ctx context.Context, domain string) (*CustomDomain, error)
 	FindByDomainAndPath(ctx context.Context, domain, pathPrefix string) (*CustomDomain, error)
+	// SearchByUserID matches the names of the domains of the user's apps.
+	SearchByUserID(ctx context.Context, userID, query string, limit int) ([]CustomDomain, error)
+	UpdateBasicAuth(ctx context.Context, id, users string) (*CustomDomain, error)
+	UpdateIPAllowlist(ctx context.Context, id, allowlist string) (*CustomDomain, error)
+	UpdateDNSRecord(ctx context.Context, id, recordType, recordID string) (*CustomDomain, error)
+	UpdateCertificate(ctx context.Context, id, certificate, encryptedKey string, expiresAt *time.Time) (*CustomDomain, error)
+	FindCertificatesExpiringBefore(ctx context.Context, before time.Time) ([]CustomDomain, error)
 	Delete(ctx context.Context, id string) error
 	DeleteByAppID(ctx context.Context, appID string) error
 }
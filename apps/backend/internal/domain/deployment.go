@@ -27,7 +27,12 @@ type Deployment struct {
 	PreviousImageTag string       `json:"previousImageTag,omitempty"`
 	CurrentImageTag  string       `json:"currentImageTag,omitempty"`
 	AppVersion       string       `json:"appVersion,omitempty"`
-	CreatedAt        time.Time    `json:"createdAt"`
+	TraceID          string       `json:"traceId,omitempty"`
+	// ArchivedLogChunks counts the chunks of Logs moved to object storage,
+	// ArchivedLogBytes their size. Logs then only holds what came after.
+	ArchivedLogChunks int       `json:"archivedLogChunks,omitempty"`
+	ArchivedLogBytes  int64     `json:"archivedLogBytes,omitempty"`
+	CreatedAt         time.Time `json:"createdAt"`
 }
 
 type CreateDeploymentInput struct {
@@ -35,6 +40,7 @@ type CreateDeploymentInput struct {
 	CommitSHA     string `json:"commitSha"`
 	CommitMessage string `json:"commitMessage,omitempty"`
 	DeliveryID    string `json:"deliveryId,omitempty"`
+	TraceID       string `json:"traceId,omitempty"`
 }
 
 type UpdateDeploymentInput struct {
@@ -47,9 +53,21 @@ type UpdateDeploymentInput struct {
 	CurrentImageTag  *string       `json:"currentImageTag,omitempty"`
 }
 
+// DeploymentFilter narrows a deployment list. The free-text query of
+// ListOptions matches the commit SHA and message.
+type DeploymentFilter struct {
+	Status DeployStatus
+}
+
+var DeploymentSortFields = []string{"createdAt"}
+
 type DeploymentRepository interface {
 	FindByID(id string) (*Deployment, error)
 	FindByAppID(appID string, limit int) ([]Deployment, error)
+	FindPageByAppID(appID string, filter DeploymentFilter, opts ListOptions) (Page[Deployment], error)
+	// SearchByUserID matches the commit SHA or message of the deployments
+	// of the user's apps, newest first.
+	SearchByUserID(userID, query string, limit int) ([]Deployment, error)
 	FindPendingByAppID(appID string) (*Deployment, error)
 	FindLatestByAppID(appID string) (*Deployment, error)
 	FindMostRecentByAppID(appID string) (*Deployment, error)
@@ -57,9 +75,23 @@ type DeploymentRepository interface {
 	Create(input CreateDeploymentInput) (*Deployment, error)
 	Update(id string, input UpdateDeploymentInput) (*Deployment, error)
 	AppendLogs(id string, logs string) error
+	// FindSnapshot returns what deployment id ran with, or nil when no
+	// snapshot was recorded.
+	FindSnapshot(id string) (*DeploymentSnapshot, error)
+	// TrimArchivedLogs drops the first headChars characters of the logs of
+	// deployment id after they were stored as archive chunk number chunk. It
+	// reports false, changing nothing, when the deployment does not have
+	// exactly chunk-1 archived chunks, i.e. another archiver got there first.
+	TrimArchivedLogs(id string, chunk, headChars int, headBytes int64) (bool, error)
 	GetNextPending() (*Deployment, error)
 	MarkAsRunning(id string) error
 	MarkAsSuccess(id string, imageTag string, appVersion string) error
 	MarkAsFailed(id string, errorMessage string) error
 	DeleteByAppID(appID string) error
+	// SumBuildSecondsByUserID adds up how long the deployments of the user's
+	// apps started since since ran, counting running ones up to now.
+	SumBuildSecondsByUserID(userID string, since time.Time) (int64, error)
+	// SumBuildSecondsByApp adds up, per app ID, how long the deployments that
+	// finished in [since, until) ran.
+	SumBuildSecondsByApp(since, until time.Time) (map[string]float64, error)
 }
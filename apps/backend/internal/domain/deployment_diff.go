@@ -0,0 +1,157 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DeploymentSnapshot records what a successful deployment ran with, so two
+// deployments can be compared. Env var values are kept as fingerprints.
+type DeploymentSnapshot struct {
+	Env       map[string]string `json:"env"`
+	Config    DeploymentConfig  `json:"config"`
+	ImageSize int64             `json:"imageSize"`
+}
+
+// DeploymentConfig is the part of the effective configuration of a
+// deployment that is compared between deployments.
+type DeploymentConfig struct {
+	Runtime         string   `json:"runtime,omitempty"`
+	Port            int      `json:"port"`
+	Memory          string   `json:"memory"`
+	CPU             string   `json:"cpu"`
+	Domains         []string `json:"domains,omitempty"`
+	Dockerfile      string   `json:"dockerfile"`
+	BuildTarget     string   `json:"buildTarget,omitempty"`
+	HealthcheckPath string   `json:"healthcheckPath"`
+}
+
+// EnvFingerprint hashes the value of an env var of an app. Fingerprints of
+// the same value are equal across the app's deployments but differ between
+// apps.
+func EnvFingerprint(appID, value string) string {
+	sum := sha256.Sum256([]byte(appID + "\x00" + value))
+	return hex.EncodeToString(sum[:])
+}
+
+const (
+	EnvVarAdded   = "added"
+	EnvVarRemoved = "removed"
+	EnvVarChanged = "changed"
+)
+
+// EnvVarChange is an env var added, removed or given a new value.
+type EnvVarChange struct {
+	Key    string `json:"key"`
+	Change string `json:"change"`
+}
+
+// ConfigChange is a configuration setting whose value differs.
+type ConfigChange struct {
+	Field string `json:"field"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+// ImageSizeDelta compares the image sizes, in bytes.
+type ImageSizeDelta struct {
+	From  int64 `json:"from"`
+	To    int64 `json:"to"`
+	Delta int64 `json:"delta"`
+}
+
+// DiffCommit is a commit between the commits of two deployments.
+type DiffCommit struct {
+	SHA     string    `json:"sha"`
+	Message string    `json:"message"`
+	Author  string    `json:"author,omitempty"`
+	Date    time.Time `json:"date"`
+	URL     string    `json:"url,omitempty"`
+}
+
+// CommitRange lists the commits from one deployment to another. CompareURL
+// is set for GitHub repositories.
+type CommitRange struct {
+	From       string       `json:"from"`
+	To         string       `json:"to"`
+	CompareURL string       `json:"compareUrl,omitempty"`
+	Commits    []DiffCommit `json:"commits"`
+}
+
+// DeploymentDiff is what changed from one deployment to another. Env,
+// Config and ImageSize are empty when either deployment predates
+// snapshots, which SnapshotMissing then reports.
+type DeploymentDiff struct {
+	From            *Deployment     `json:"from"`
+	To              *Deployment     `json:"to"`
+	Commits         CommitRange     `json:"commits"`
+	Env             []EnvVarChange  `json:"env"`
+	Config          []ConfigChange  `json:"config"`
+	ImageSize       *ImageSizeDelta `json:"imageSize,omitempty"`
+	SnapshotMissing bool            `json:"snapshotMissing,omitempty"`
+}
+
+// CompareSnapshots fills in the env var, configuration and image size
+// changes from one snapshot to another.
+func (d *DeploymentDiff) CompareSnapshots(from, to *DeploymentSnapshot) {
+	d.Env = []EnvVarChange{}
+	d.Config = []ConfigChange{}
+	if from == nil || to == nil {
+		d.SnapshotMissing = true
+		return
+	}
+
+	d.Env = compareEnv(from.Env, to.Env)
+	d.Config = compareConfig(from.Config, to.Config)
+	if from.ImageSize > 0 && to.ImageSize > 0 {
+		d.ImageSize = &ImageSizeDelta{From: from.ImageSize, To: to.ImageSize, Delta: to.ImageSize - from.ImageSize}
+	}
+}
+
+func compareEnv(from, to map[string]string) []EnvVarChange {
+	changes := []EnvVarChange{}
+	for key, value := range to {
+		previous, ok := from[key]
+		switch {
+		case !ok:
+			changes = append(changes, EnvVarChange{Key: key, Change: EnvVarAdded})
+		case previous != value:
+			changes = append(changes, EnvVarChange{Key: key, Change: EnvVarChanged})
+		}
+	}
+	for key := range from {
+		if _, ok := to[key]; !ok {
+			changes = append(changes, EnvVarChange{Key: key, Change: EnvVarRemoved})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Key < changes[j].Key })
+	return changes
+}
+
+func compareConfig(from, to DeploymentConfig) []ConfigChange {
+	fields := []struct {
+		name     string
+		from, to string
+	}{
+		{"runtime", from.Runtime, to.Runtime},
+		{"port", strconv.Itoa(from.Port), strconv.Itoa(to.Port)},
+		{"memory", from.Memory, to.Memory},
+		{"cpu", from.CPU, to.CPU},
+		{"domains", strings.Join(from.Domains, ", "), strings.Join(to.Domains, ", ")},
+		{"dockerfile", from.Dockerfile, to.Dockerfile},
+		{"buildTarget", from.BuildTarget, to.BuildTarget},
+		{"healthcheckPath", from.HealthcheckPath, to.HealthcheckPath},
+	}
+
+	changes := []ConfigChange{}
+	for _, field := range fields {
+		if field.from != field.to {
+			changes = append(changes, ConfigChange{Field: field.name, From: field.from, To: field.to})
+		}
+	}
+	return changes
+}
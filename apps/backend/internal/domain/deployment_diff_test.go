@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDeploymentDiffCompareSnapshots(t *testing.T) {
+	from := &DeploymentSnapshot{
+		Env:       map[string]string{"A": "1", "B": "2", "C": "3"},
+		Config:    DeploymentConfig{Port: 8080, Memory: "512m", CPU: "0.5", Domains: []string{"api.example.com"}, HealthcheckPath: "/health"},
+		ImageSize: 100,
+	}
+	to := &DeploymentSnapshot{
+		Env:       map[string]string{"A": "1", "B": "20", "D": "4"},
+		Config:    DeploymentConfig{Port: 3000, Memory: "512m", CPU: "1", Domains: []string{"api.example.com"}, HealthcheckPath: "/health"},
+		ImageSize: 80,
+	}
+
+	var diff DeploymentDiff
+	diff.CompareSnapshots(from, to)
+
+	wantEnv := []EnvVarChange{
+		{Key: "B", Change: EnvVarChanged},
+		{Key: "C", Change: EnvVarRemoved},
+		{Key: "D", Change: EnvVarAdded},
+	}
+	if !reflect.DeepEqual(diff.Env, wantEnv) {
+		t.Errorf("Env = %+v, want %+v", diff.Env, wantEnv)
+	}
+	wantConfig := []ConfigChange{
+		{Field: "port", From: "8080", To: "3000"},
+		{Field: "cpu", From: "0.5", To: "1"},
+	}
+	if !reflect.DeepEqual(diff.Config, wantConfig) {
+		t.Errorf("Config = %+v, want %+v", diff.Config, wantConfig)
+	}
+	if diff.ImageSize == nil || diff.ImageSize.Delta != -20 {
+		t.Errorf("ImageSize = %+v, want delta -20", diff.ImageSize)
+	}
+}
+
+func TestDeploymentDiffWithoutSnapshot(t *testing.T) {
+	var diff DeploymentDiff
+	diff.CompareSnapshots(nil, &DeploymentSnapshot{})
+	if !diff.SnapshotMissing || diff.Env == nil || diff.Config == nil || diff.ImageSize != nil {
+		t.Errorf("unexpected diff: %+v", diff)
+	}
+}
+
+func TestEnvFingerprint(t *testing.T) {
+	if EnvFingerprint("app-1", "secret") != EnvFingerprint("app-1", "secret") {
+		t.Error("fingerprint is not stable")
+	}
+	if EnvFingerprint("app-1", "secret") == EnvFingerprint("app-2", "secret") {
+		t.Error("fingerprint does not depend on the app")
+	}
+}
@@ -0,0 +1,22 @@
+package domain
+
+import "time"
+
+// DeploymentSBOM is the software bill of materials of the image a
+// deployment ran, as generated by syft.
+type DeploymentSBOM struct {
+	DeploymentID string    `json:"deploymentId"`
+	Format       string    `json:"format"`
+	Document     []byte    `json:"-"`
+	PackageCount int       `json:"packageCount"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+type DeploymentSBOMRepository interface {
+	// Save stores the SBOM of a deployment, replacing any previous one.
+	Save(sbom DeploymentSBOM) error
+	FindByDeploymentID(deploymentID string) (*DeploymentSBOM, error)
+	// FindPrevious returns the SBOM of the latest successful deployment of
+	// the app created before deploymentID, or ErrNotFound.
+	FindPrevious(appID, deploymentID string) (*DeploymentSBOM, error)
+}
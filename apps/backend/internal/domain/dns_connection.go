@@ -0,0 +1,38 @@
+package domain
+
+import "time"
+
+const (
+	DNSProviderCloudflare   = "cloudflare"
+	DNSProviderRoute53      = "route53"
+	DNSProviderDigitalOcean = "digitalocean"
+	DNSProviderDeSEC        = "desec"
+)
+
+// IsValidDNSProvider reports whether provider can be used for a DNS
+// connection. Cloudflare is connected through its own OAuth flow instead.
+func IsValidDNSProvider(provider string) bool {
+	switch provider {
+	case DNSProviderRoute53, DNSProviderDigitalOcean, DNSProviderDeSEC:
+		return true
+	}
+	return false
+}
+
+// DNSConnection is a user's API credential for a DNS provider, used to manage
+// the records of custom domains. The credential is stored encrypted.
+type DNSConnection struct {
+	ID                   string
+	UserID               string
+	Provider             string
+	CredentialsEncrypted string
+	CreatedAt            time.Time
+	UpdatedAt            time.Time
+}
+
+type DNSConnectionRepository interface {
+	Upsert(userID, provider, credentialsEncrypted string) (*DNSConnection, error)
+	FindByUserAndProvider(userID, provider string) (*DNSConnection, error)
+	ListByUserID(userID string) ([]DNSConnection, error)
+	Delete(userID, provider string) error
+}
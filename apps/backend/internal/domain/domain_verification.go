@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// DomainVerificationLabel is the label the verification record is published
+// under, e.g. _flowdeploy-verify.example.com.
+const DomainVerificationLabel = "_flowdeploy-verify"
+
+// DomainVerification proves a user controls a domain before any of its names
+// is attached to an app. A verified domain covers its subdomains.
+type DomainVerification struct {
+	ID         string
+	UserID     string
+	Domain     string
+	Token      string
+	VerifiedAt *time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// RecordName is the DNS name holding the verification TXT or CNAME record.
+func (v *DomainVerification) RecordName() string {
+	return DomainVerificationLabel + "." + v.Domain
+}
+
+// RecordValue is the TXT record content expected at RecordName.
+func (v *DomainVerification) RecordValue() string {
+	return "flowdeploy-verify=" + v.Token
+}
+
+func (v *DomainVerification) IsVerified() bool {
+	return v.VerifiedAt != nil
+}
+
+// Covers reports whether the verification proves ownership of name, the
+// verified domain itself or one of its subdomains.
+func (v *DomainVerification) Covers(name string) bool {
+	if !v.IsVerified() {
+		return false
+	}
+	name = strings.ToLower(strings.TrimSuffix(name, "."))
+	return name == v.Domain || strings.HasSuffix(name, "."+v.Domain)
+}
+
+type DomainVerificationRepository interface {
+	// Create starts a verification, or returns the existing one for the
+	// same user and domain so its token stays stable.
+	Create(ctx context.Context, userID, domain, token string) (*DomainVerification, error)
+	FindByID(ctx context.Context, id string) (*DomainVerification, error)
+	ListByUserID(ctx context.Context, userID string) ([]DomainVerification, error)
+	MarkVerified(ctx context.Context, id string) (*DomainVerification, error)
+	Delete(ctx context.Context, id string) error
+}
@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDomainVerificationCovers(t *testing.T) {
+	now := time.Now()
+	verified := DomainVerification{Domain: "example.com", VerifiedAt: &now}
+	pending := DomainVerification{Domain: "example.com"}
+
+	tests := []struct {
+		name string
+		v    DomainVerification
+		host string
+		want bool
+	}{
+		{"apex", verified, "example.com", true},
+		{"subdomain", verified, "api.example.com", true},
+		{"nested subdomain", verified, "a.b.example.com", true},
+		{"case and trailing dot", verified, "API.Example.com.", true},
+		{"suffix without dot", verified, "notexample.com", false},
+		{"other domain", verified, "example.org", false},
+		{"parent", DomainVerification{Domain: "app.example.com", VerifiedAt: &now}, "example.com", false},
+		{"pending", pending, "example.com", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.v.Covers(tt.host); got != tt.want {
+				t.Fatalf("Covers(%q) = %v, want %v", tt.host, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDomainVerificationRecord(t *testing.T) {
+	v := DomainVerification{Domain: "example.com", Token: "abc123"}
+	if got := v.RecordName(); got != "_flowdeploy-verify.example.com" {
+		t.Fatalf("RecordName() = %q", got)
+	}
+	if got := v.RecordValue(); got != "flowdeploy-verify=abc123" {
+		t.Fatalf("RecordValue() = %q", got)
+	}
+}
@@ -55,6 +55,7 @@ type BulkEnvVarInput struct {
 
 type EnvVarRepository interface {
 	FindByAppID(appID string) ([]EnvVar, error)
+	FindByAppIDs(appIDs []string) (map[string][]EnvVar, error)
 	FindByAppIDAndKey(appID, key string) (*EnvVar, error)
 	Create(appID string, input CreateEnvVarInput) (*EnvVar, error)
 	Update(id string, input UpdateEnvVarInput) (*EnvVar, error)
@@ -18,4 +18,6 @@ var (
 	ErrTimeout              = errors.New("operation timed out")
 	ErrWebhookNotConfigured    = errors.New("webhook management not configured")
 	ErrDeploymentAlreadyActive = errors.New("deployment already active for this app and commit")
+	ErrQuotaExceeded           = errors.New("quota exceeded")
+	ErrServerTaskRunning       = errors.New("server task is already running")
 )
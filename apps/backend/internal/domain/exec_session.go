@@ -0,0 +1,150 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	ExecSessionRetention = 90 * 24 * time.Hour
+	// MaxExecRecordingBytes caps the recorded output of a single session.
+	// Output past the cap is still streamed to the user but not recorded.
+	MaxExecRecordingBytes = 5 << 20
+)
+
+// ExecSession is the audit record of an interactive container console.
+// ServerID is empty for consoles on the backend host, and ContainerID is
+// empty for SSH consoles on a server's host.
+type ExecSession struct {
+	ID          string     `json:"id"`
+	UserID      string     `json:"userId,omitempty"`
+	UserName    string     `json:"userName"`
+	ServerID    string     `json:"serverId,omitempty"`
+	ContainerID string     `json:"containerId"`
+	Shell       string     `json:"shell"`
+	IPAddress   string     `json:"ipAddress,omitempty"`
+	StartedAt   time.Time  `json:"startedAt"`
+	EndedAt     *time.Time `json:"endedAt,omitempty"`
+	ExitCode    *int       `json:"exitCode,omitempty"`
+	OutputBytes int64      `json:"outputBytes"`
+	Truncated   bool       `json:"truncated"`
+}
+
+type CreateExecSessionInput struct {
+	UserID      string
+	UserName    string
+	ServerID    string
+	ContainerID string
+	Shell       string
+	IPAddress   string
+}
+
+type FinishExecSessionInput struct {
+	ExitCode    *int
+	OutputBytes int64
+	Truncated   bool
+	Recording   []byte
+}
+
+type ExecSessionFilter struct {
+	UserID      string
+	ServerID    string
+	ContainerID string
+	Limit       int
+	Offset      int
+}
+
+type ExecSessionRepository interface {
+	Create(input CreateExecSessionInput) (*ExecSession, error)
+	Finish(id string, input FinishExecSessionInput) error
+	FindByID(id string) (*ExecSession, error)
+	FindRecording(id string) ([]byte, error)
+	List(filter ExecSessionFilter) ([]ExecSession, int, error)
+	DeleteOlderThan(before time.Time) (int64, error)
+}
+
+// ExecRecording captures a console session in asciicast v2 format: a JSON
+// header line followed by one [seconds, type, data] event per line. Only
+// output ("o") and resize ("r") events are recorded; keystrokes are never
+// stored since they may contain secrets typed at a prompt.
+type ExecRecording struct {
+	mu        sync.Mutex
+	start     time.Time
+	buf       bytes.Buffer
+	output    int64
+	truncated bool
+}
+
+type asciicastHeader struct {
+	Version   int               `json:"version"`
+	Width     uint16            `json:"width"`
+	Height    uint16            `json:"height"`
+	Timestamp int64             `json:"timestamp"`
+	Env       map[string]string `json:"env,omitempty"`
+}
+
+func NewExecRecording(cols, rows uint16, shell string, start time.Time) *ExecRecording {
+	r := &ExecRecording{start: start}
+	header, _ := json.Marshal(asciicastHeader{
+		Version:   2,
+		Width:     cols,
+		Height:    rows,
+		Timestamp: start.Unix(),
+		Env:       map[string]string{"SHELL": shell},
+	})
+	r.buf.Write(header)
+	r.buf.WriteByte('\n')
+	return r
+}
+
+// Output records data written to the terminal at time at.
+func (r *ExecRecording) Output(data []byte, at time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.output += int64(len(data))
+	r.writeEvent(at, "o", string(data))
+}
+
+// Resize records a terminal size change.
+func (r *ExecRecording) Resize(cols, rows uint16, at time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.writeEvent(at, "r", fmt.Sprintf("%dx%d", cols, rows))
+}
+
+// writeEvent appends an event unless it would push the recording past
+// MaxExecRecordingBytes, after which recording stops for good.
+func (r *ExecRecording) writeEvent(at time.Time, kind, data string) {
+	if r.truncated {
+		return
+	}
+	elapsed := at.Sub(r.start).Seconds()
+	if elapsed < 0 {
+		elapsed = 0
+	}
+	event, _ := json.Marshal([]any{float64(int64(elapsed*1e6)) / 1e6, kind, data})
+	if r.buf.Len()+len(event)+1 > MaxExecRecordingBytes {
+		r.truncated = true
+		return
+	}
+	r.buf.Write(event)
+	r.buf.WriteByte('\n')
+}
+
+// Result returns the finished recording with its output statistics.
+func (r *ExecRecording) Result(exitCode *int) FinishExecSessionInput {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return FinishExecSessionInput{
+		ExitCode:    exitCode,
+		OutputBytes: r.output,
+		Truncated:   r.truncated,
+		Recording:   bytes.Clone(r.buf.Bytes()),
+	}
+}
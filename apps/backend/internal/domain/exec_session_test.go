@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExecRecordingAsciicast(t *testing.T) {
+	start := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	r := NewExecRecording(80, 24, "sh", start)
+	r.Output([]byte("$ ls\r\n"), start.Add(500*time.Millisecond))
+	r.Resize(120, 40, start.Add(time.Second))
+	r.Output([]byte("app\r\n"), start.Add(1500*time.Millisecond))
+
+	code := 0
+	result := r.Result(&code)
+	if result.OutputBytes != 11 || result.Truncated || *result.ExitCode != 0 {
+		t.Fatalf("unexpected result %+v", result)
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(result.Recording)), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("expected header and 3 events, got %d lines:\n%s", len(lines), result.Recording)
+	}
+
+	var header map[string]any
+	if err := json.Unmarshal([]byte(lines[0]), &header); err != nil {
+		t.Fatalf("invalid header: %v", err)
+	}
+	if header["version"] != float64(2) || header["width"] != float64(80) || header["timestamp"] != float64(start.Unix()) {
+		t.Errorf("unexpected header %v", header)
+	}
+
+	want := []string{`[0.5,"o","$ ls\r\n"]`, `[1,"r","120x40"]`, `[1.5,"o","app\r\n"]`}
+	for i, w := range want {
+		if lines[i+1] != w {
+			t.Errorf("event %d = %s, want %s", i, lines[i+1], w)
+		}
+	}
+}
+
+func TestExecRecordingTruncates(t *testing.T) {
+	start := time.Now()
+	r := NewExecRecording(80, 24, "sh", start)
+	chunk := bytes.Repeat([]byte("x"), MaxExecRecordingBytes/2)
+	r.Output(chunk, start)
+	r.Output(chunk, start)
+	r.Output([]byte("tail"), start)
+
+	result := r.Result(nil)
+	if !result.Truncated {
+		t.Error("expected recording to be truncated")
+	}
+	if result.OutputBytes != int64(2*len(chunk)+4) {
+		t.Errorf("output bytes should count all output, got %d", result.OutputBytes)
+	}
+	if len(result.Recording) > MaxExecRecordingBytes {
+		t.Errorf("recording exceeds cap: %d bytes", len(result.Recording))
+	}
+	if bytes.Contains(result.Recording, []byte("tail")) {
+		t.Error("output past the cap should not be recorded")
+	}
+}
@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+type GitOpsStatus string
+
+const (
+	GitOpsStatusPending GitOpsStatus = "pending"
+	GitOpsStatusSyncing GitOpsStatus = "syncing"
+	GitOpsStatusSynced  GitOpsStatus = "synced"
+	GitOpsStatusDrifted GitOpsStatus = "drifted"
+	GitOpsStatusFailed  GitOpsStatus = "failed"
+)
+
+// GitOpsAppStatus is the state of one app spec file of a source after the
+// last sync or drift check. Orphaned apps were synced from the source
+// before but their file is gone; they are reported, never deleted.
+type GitOpsAppStatus struct {
+	Name    string       `json:"name"`
+	File    string       `json:"file,omitempty"`
+	Status  GitOpsStatus `json:"status"`
+	Orphan  bool         `json:"orphan,omitempty"`
+	Changes []SpecChange `json:"changes,omitempty"`
+	Error   string       `json:"error,omitempty"`
+}
+
+// GitOpsSource is a git repository holding one app spec YAML file per app,
+// under Path on Branch. Pushes to it are applied to the owner's apps.
+type GitOpsSource struct {
+	ID            string            `json:"id"`
+	UserID        string            `json:"-"`
+	RepositoryURL string            `json:"repositoryUrl"`
+	Branch        string            `json:"branch"`
+	Path          string            `json:"path"`
+	WebhookID     *int64            `json:"webhookId,omitempty"`
+	Status        GitOpsStatus      `json:"status"`
+	LastCommitSHA string            `json:"lastCommitSha,omitempty"`
+	LastError     string            `json:"lastError,omitempty"`
+	Apps          []GitOpsAppStatus `json:"apps"`
+	LastSyncedAt  *time.Time        `json:"lastSyncedAt,omitempty"`
+	LastCheckedAt *time.Time        `json:"lastCheckedAt,omitempty"`
+	CreatedAt     time.Time         `json:"createdAt"`
+	UpdatedAt     time.Time         `json:"updatedAt"`
+}
+
+type CreateGitOpsSourceInput struct {
+	UserID        string
+	RepositoryURL string
+	Branch        string
+	Path          string
+}
+
+// GitOpsSyncResult is recorded after a sync or drift check; Applied is set
+// when specs were applied rather than only compared.
+type GitOpsSyncResult struct {
+	Status    GitOpsStatus
+	CommitSHA string
+	Error     string
+	Apps      []GitOpsAppStatus
+	Applied   bool
+}
+
+type GitOpsSourceRepository interface {
+	Create(ctx context.Context, input CreateGitOpsSourceInput) (*GitOpsSource, error)
+	FindByID(ctx context.Context, id string) (*GitOpsSource, error)
+	FindByUserID(ctx context.Context, userID string) ([]GitOpsSource, error)
+	FindAll(ctx context.Context) ([]GitOpsSource, error)
+	UpdateStatus(ctx context.Context, id string, status GitOpsStatus) error
+	UpdateWebhookID(ctx context.Context, id string, webhookID *int64) error
+	SaveSyncResult(ctx context.Context, id string, result GitOpsSyncResult) error
+	Delete(ctx context.Context, id string) error
+}
@@ -64,11 +64,16 @@ type UpdateNotificationRuleInput struct {
 }
 
 const (
-	EventTypeDeployRunning   = "deploy_running"
-	EventTypeDeploySuccess   = "deploy_success"
-	EventTypeDeployFailed    = "deploy_failed"
-	EventTypeContainerDown   = "container_down"
-	EventTypeHealthUnhealthy = "health_unhealthy"
+	EventTypeDeployRunning      = "deploy_running"
+	EventTypeDeploySuccess      = "deploy_success"
+	EventTypeDeployFailed       = "deploy_failed"
+	EventTypeContainerDown      = "container_down"
+	EventTypeContainerOOM       = "container_oom"
+	EventTypeContainerCrashLoop = "container_crash_loop"
+	EventTypeHealthUnhealthy    = "health_unhealthy"
+	EventTypeServerOffline      = "server_offline"
+	EventTypeCertExpiring       = "certificate_expiring"
+	EventTypeServerTaskFailed   = "server_task_failed"
 )
 
 type NotificationChannelRepository interface {
@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultPageLimit = 50
+	MaxPageLimit     = 200
+)
+
+// Cursor is the position after the last item of a page. Lists are ordered by
+// a sort key and then by ID, so Value and ID together are unique. SortBy
+// ties the cursor to the order it was issued for.
+type Cursor struct {
+	SortBy string `json:"s"`
+	Value  string `json:"v"`
+	ID     string `json:"id"`
+}
+
+func (c Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func DecodeCursor(s string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid cursor", ErrInvalidInput)
+	}
+	var c Cursor
+	if err := json.Unmarshal(data, &c); err != nil || c.ID == "" {
+		return nil, fmt.Errorf("%w: invalid cursor", ErrInvalidInput)
+	}
+	return &c, nil
+}
+
+// ListOptions page and order a list. A zero Limit returns every item, which
+// list endpoints keep for clients that predate pagination.
+type ListOptions struct {
+	Limit  int
+	After  *Cursor
+	SortBy string
+	Desc   bool
+	// Query is a free-text search; each list decides which fields it
+	// matches.
+	Query string
+	From  *time.Time
+	To    *time.Time
+}
+
+// ParseSort reads a sort parameter such as "name" or "-createdAt" against
+// the allowed fields. An empty value selects def.
+func ParseSort(value string, allowed []string, def string) (string, bool, error) {
+	if value == "" {
+		value = def
+	}
+	desc := strings.HasPrefix(value, "-")
+	field := strings.TrimPrefix(value, "-")
+	for _, a := range allowed {
+		if a == field {
+			return field, desc, nil
+		}
+	}
+	return "", false, fmt.Errorf("%w: sort must be one of %s, optionally prefixed with -", ErrInvalidInput, strings.Join(allowed, ", "))
+}
+
+// Validate checks that the cursor was issued for the requested order.
+func (o *ListOptions) Validate() error {
+	if o.Limit < 0 {
+		return fmt.Errorf("%w: limit must not be negative", ErrInvalidInput)
+	}
+	if o.Limit > MaxPageLimit {
+		o.Limit = MaxPageLimit
+	}
+	if o.After != nil && o.After.SortBy != o.sortKey() {
+		return fmt.Errorf("%w: cursor was issued for a different sort", ErrInvalidInput)
+	}
+	if o.From != nil && o.To != nil && o.To.Before(*o.From) {
+		return fmt.Errorf("%w: to must not be before from", ErrInvalidInput)
+	}
+	return nil
+}
+
+func (o *ListOptions) sortKey() string {
+	if o.Desc {
+		return "-" + o.SortBy
+	}
+	return o.SortBy
+}
+
+// NextCursor returns the cursor after an item with the given sort value and
+// ID.
+func (o *ListOptions) NextCursor(value, id string) string {
+	return Cursor{SortBy: o.sortKey(), Value: value, ID: id}.Encode()
+}
+
+// Page is one page of a list. NextCursor is empty on the last page.
+type Page[T any] struct {
+	Items      []T
+	NextCursor string
+}
+
+// CursorTime renders a time sort value for a cursor.
+func CursorTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
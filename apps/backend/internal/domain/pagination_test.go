@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	opts := ListOptions{SortBy: "createdAt", Desc: true}
+	encoded := opts.NextCursor("2026-01-02T03:04:05Z", "app-1")
+
+	cursor, err := DecodeCursor(encoded)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := Cursor{SortBy: "-createdAt", Value: "2026-01-02T03:04:05Z", ID: "app-1"}
+	if *cursor != want {
+		t.Errorf("cursor = %+v, want %+v", *cursor, want)
+	}
+}
+
+func TestDecodeCursorInvalid(t *testing.T) {
+	for _, s := range []string{"not base64!", "bm90IGpzb24", Cursor{SortBy: "name"}.Encode()} {
+		if _, err := DecodeCursor(s); !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("DecodeCursor(%q) err = %v, want ErrInvalidInput", s, err)
+		}
+	}
+}
+
+func TestParseSort(t *testing.T) {
+	allowed := []string{"createdAt", "name"}
+	tests := []struct {
+		value    string
+		want     string
+		wantDesc bool
+		wantErr  bool
+	}{
+		{"", "createdAt", true, false},
+		{"name", "name", false, false},
+		{"-name", "name", true, false},
+		{"size", "", false, true},
+	}
+	for _, tt := range tests {
+		field, desc, err := ParseSort(tt.value, allowed, "-createdAt")
+		if tt.wantErr {
+			if !errors.Is(err, ErrInvalidInput) {
+				t.Errorf("ParseSort(%q) err = %v, want ErrInvalidInput", tt.value, err)
+			}
+			continue
+		}
+		if err != nil || field != tt.want || desc != tt.wantDesc {
+			t.Errorf("ParseSort(%q) = %q, %v, %v; want %q, %v", tt.value, field, desc, err, tt.want, tt.wantDesc)
+		}
+	}
+}
+
+func TestListOptionsValidate(t *testing.T) {
+	from := time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)
+	to := from.Add(-time.Hour)
+
+	opts := ListOptions{Limit: 1000, SortBy: "name"}
+	if err := opts.Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if opts.Limit != MaxPageLimit {
+		t.Errorf("limit = %d, want %d", opts.Limit, MaxPageLimit)
+	}
+
+	invalid := []ListOptions{
+		{Limit: -1, SortBy: "name"},
+		{SortBy: "name", After: &Cursor{SortBy: "-name", ID: "x"}},
+		{SortBy: "name", From: &from, To: &to},
+	}
+	for _, o := range invalid {
+		if err := o.Validate(); !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("Validate(%+v) err = %v, want ErrInvalidInput", o, err)
+		}
+	}
+}
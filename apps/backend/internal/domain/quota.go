@@ -0,0 +1,112 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Quota limits what each user may use. Zero fields are unlimited. Build
+// minutes are counted per calendar month (UTC).
+type Quota struct {
+	MaxApps        int   `json:"maxApps"`
+	MaxServers     int   `json:"maxServers"`
+	MaxMemoryBytes int64 `json:"maxMemoryBytes"`
+	BuildMinutes   int   `json:"buildMinutes"`
+}
+
+type QuotaUsage struct {
+	Apps         int   `json:"apps"`
+	Servers      int   `json:"servers"`
+	MemoryBytes  int64 `json:"memoryBytes"`
+	BuildSeconds int64 `json:"buildSeconds"`
+}
+
+type QuotaStatus struct {
+	Limits      Quota      `json:"limits"`
+	Usage       QuotaUsage `json:"usage"`
+	PeriodStart time.Time  `json:"periodStart"`
+	Unlimited   bool       `json:"unlimited"`
+}
+
+func (q Quota) IsZero() bool {
+	return q == Quota{}
+}
+
+// CheckApps fails when a user owning apps apps may not create another one.
+func (q Quota) CheckApps(apps int) error {
+	if q.MaxApps > 0 && apps >= q.MaxApps {
+		return fmt.Errorf("%w: app limit of %d reached", ErrQuotaExceeded, q.MaxApps)
+	}
+	return nil
+}
+
+func (q Quota) CheckServers(servers int) error {
+	if q.MaxServers > 0 && servers >= q.MaxServers {
+		return fmt.Errorf("%w: server limit of %d reached", ErrQuotaExceeded, q.MaxServers)
+	}
+	return nil
+}
+
+// CheckMemory fails when reserving requested bytes on top of reserved
+// would go over the memory quota.
+func (q Quota) CheckMemory(reserved, requested int64) error {
+	if q.MaxMemoryBytes <= 0 || reserved+requested <= q.MaxMemoryBytes {
+		return nil
+	}
+	left := max(q.MaxMemoryBytes-reserved, 0)
+	return fmt.Errorf("%w: app needs %s of memory but only %s of the %s memory quota is left",
+		ErrQuotaExceeded, FormatMemory(requested), FormatMemory(left), FormatMemory(q.MaxMemoryBytes))
+}
+
+func (q Quota) CheckBuildTime(buildSeconds int64) error {
+	if q.BuildMinutes > 0 && buildSeconds >= int64(q.BuildMinutes)*60 {
+		return fmt.Errorf("%w: all %d build minutes of this month are used", ErrQuotaExceeded, q.BuildMinutes)
+	}
+	return nil
+}
+
+// QuotaPeriodStart returns the start of the build minutes period containing t.
+func QuotaPeriodStart(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+var memoryUnits = map[byte]int64{
+	'b': 1,
+	'k': 1 << 10,
+	'm': 1 << 20,
+	'g': 1 << 30,
+}
+
+// ParseMemory converts a Docker memory limit such as 512m or 1g to bytes.
+func ParseMemory(s string) (int64, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "" {
+		return 0, fmt.Errorf("%w: empty memory value", ErrInvalidInput)
+	}
+	unit := int64(1)
+	if u, ok := memoryUnits[s[len(s)-1]]; ok {
+		unit = u
+		s = s[:len(s)-1]
+	}
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("%w: memory must look like 512m or 1g", ErrInvalidInput)
+	}
+	return n * unit, nil
+}
+
+func FormatMemory(bytes int64) string {
+	switch {
+	case bytes >= 1<<30 && bytes%(1<<30) == 0:
+		return strconv.FormatInt(bytes>>30, 10) + "g"
+	case bytes >= 1<<20 && bytes%(1<<20) == 0:
+		return strconv.FormatInt(bytes>>20, 10) + "m"
+	case bytes >= 1<<20:
+		return strconv.FormatFloat(float64(bytes)/(1<<20), 'f', 1, 64) + "m"
+	default:
+		return strconv.FormatInt(bytes, 10) + "b"
+	}
+}
@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseMemory(t *testing.T) {
+	tests := map[string]int64{
+		"512m":  512 << 20,
+		"1g":    1 << 30,
+		"2G":    2 << 30,
+		"64k":   64 << 10,
+		"100b":  100,
+		"4096":  4096,
+		" 1g ":  1 << 30,
+		"1024m": 1 << 30,
+	}
+	for in, want := range tests {
+		got, err := ParseMemory(in)
+		if err != nil || got != want {
+			t.Errorf("ParseMemory(%q) = %d, %v, want %d", in, got, err, want)
+		}
+	}
+
+	for _, in := range []string{"", "lots", "1.5g", "-1m", "g"} {
+		if _, err := ParseMemory(in); !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("ParseMemory(%q) error = %v, want ErrInvalidInput", in, err)
+		}
+	}
+}
+
+func TestFormatMemory(t *testing.T) {
+	tests := map[int64]string{
+		2 << 30:           "2g",
+		512 << 20:         "512m",
+		1536 << 20:        "1536m",
+		(1 << 20) + 1<<19: "1.5m",
+		1000:              "1000b",
+	}
+	for in, want := range tests {
+		if got := FormatMemory(in); got != want {
+			t.Errorf("FormatMemory(%d) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestQuotaChecks(t *testing.T) {
+	q := Quota{MaxApps: 2, MaxServers: 1, MaxMemoryBytes: 1 << 30, BuildMinutes: 10}
+
+	if err := q.CheckApps(1); err != nil {
+		t.Errorf("CheckApps(1) = %v", err)
+	}
+	if err := q.CheckApps(2); !errors.Is(err, ErrQuotaExceeded) {
+		t.Errorf("CheckApps(2) = %v, want ErrQuotaExceeded", err)
+	}
+	if err := q.CheckServers(1); !errors.Is(err, ErrQuotaExceeded) {
+		t.Errorf("CheckServers(1) = %v, want ErrQuotaExceeded", err)
+	}
+	if err := q.CheckMemory(512<<20, 512<<20); err != nil {
+		t.Errorf("CheckMemory at limit = %v", err)
+	}
+	err := q.CheckMemory(768<<20, 512<<20)
+	if !errors.Is(err, ErrQuotaExceeded) {
+		t.Fatalf("CheckMemory over limit = %v, want ErrQuotaExceeded", err)
+	}
+	if want := "quota exceeded: app needs 512m of memory but only 256m of the 1g memory quota is left"; err.Error() != want {
+		t.Errorf("CheckMemory message = %q, want %q", err.Error(), want)
+	}
+	if err := q.CheckBuildTime(599); err != nil {
+		t.Errorf("CheckBuildTime(599) = %v", err)
+	}
+	if err := q.CheckBuildTime(600); !errors.Is(err, ErrQuotaExceeded) {
+		t.Errorf("CheckBuildTime(600) = %v, want ErrQuotaExceeded", err)
+	}
+
+	var unlimited Quota
+	if err := unlimited.CheckApps(1000); err != nil {
+		t.Errorf("unlimited CheckApps = %v", err)
+	}
+	if err := unlimited.CheckMemory(1<<40, 1<<40); err != nil {
+		t.Errorf("unlimited CheckMemory = %v", err)
+	}
+}
+
+func TestQuotaPeriodStart(t *testing.T) {
+	got := QuotaPeriodStart(time.Date(2026, 3, 17, 23, 30, 0, 0, time.FixedZone("BRT", -3*3600)))
+	if want := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("QuotaPeriodStart() = %v, want %v", got, want)
+	}
+}
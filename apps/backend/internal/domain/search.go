@@ -0,0 +1,22 @@
+package domain
+
+type SearchResultType string
+
+const (
+	SearchResultApp        SearchResultType = "app"
+	SearchResultDeployment SearchResultType = "deployment"
+	SearchResultServer     SearchResultType = "server"
+	SearchResultDomain     SearchResultType = "domain"
+	SearchResultContainer  SearchResultType = "container"
+)
+
+// SearchResult is one match of a global search. Title is what matched or
+// names the resource; AppID and ServerID link it to its app or server.
+type SearchResult struct {
+	Type     SearchResultType `json:"type"`
+	ID       string           `json:"id"`
+	Title    string           `json:"title"`
+	Subtitle string           `json:"subtitle,omitempty"`
+	AppID    string           `json:"appId,omitempty"`
+	ServerID string           `json:"serverId,omitempty"`
+}
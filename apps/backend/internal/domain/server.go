@@ -14,23 +14,66 @@ const (
 	ServerStatusError        ServerStatus = "error"
 )
 
+const (
+	AgentInstallAuto        = "auto"
+	AgentInstallSystemdUser = "systemd_user"
+	AgentInstallOpenRC      = "openrc"
+	AgentInstallNohup       = "nohup"
+	AgentInstallDocker      = "docker"
+)
+
+func IsValidAgentInstallMethod(method string) bool {
+	switch method {
+	case AgentInstallAuto, AgentInstallSystemdUser, AgentInstallOpenRC, AgentInstallNohup, AgentInstallDocker:
+		return true
+	}
+	return false
+}
+
 type Server struct {
-	ID                   string       `json:"id"`
-	UserID               string       `json:"userId"`
-	Name                 string       `json:"name"`
-	Host                 string       `json:"host"`
-	SSHPort              int          `json:"sshPort"`
-	SSHUser              string       `json:"sshUser"`
-	SSHKeyEncrypted      string       `json:"-"`
-	SSHPasswordEncrypted string       `json:"-"`
-	AcmeEmail            *string      `json:"acmeEmail,omitempty"`
-	SSHHostKey           string       `json:"-"`
-	Status               ServerStatus `json:"status"`
-	AgentVersion         *string      `json:"agentVersion,omitempty"`
-	AgentUpdateMode      string       `json:"agentUpdateMode"`
-	LastHeartbeatAt      *time.Time   `json:"lastHeartbeatAt,omitempty"`
-	CreatedAt            time.Time    `json:"createdAt"`
-	UpdatedAt            time.Time    `json:"updatedAt"`
+	ID                   string             `json:"id"`
+	UserID               string             `json:"userId"`
+	Name                 string             `json:"name"`
+	Host                 string             `json:"host"`
+	SSHPort              int                `json:"sshPort"`
+	SSHUser              string             `json:"sshUser"`
+	SSHKeyEncrypted      string             `json:"-"`
+	SSHPasswordEncrypted string             `json:"-"`
+	AcmeEmail            *string            `json:"acmeEmail,omitempty"`
+	SSHHostKey           string             `json:"-"`
+	SSHPublicKey         string             `json:"sshPublicKey,omitempty"`
+	Status               ServerStatus       `json:"status"`
+	AgentVersion         *string            `json:"agentVersion,omitempty"`
+	AgentUpdateMode      string             `json:"agentUpdateMode"`
+	AgentInstallMethod   string             `json:"agentInstallMethod"`
+	DockerRootless       bool               `json:"dockerRootless"`
+	FirewallEnabled      bool               `json:"firewallEnabled"`
+	SSHHardening         bool               `json:"sshHardening"`
+	AcmeStaging          bool               `json:"acmeStaging"`
+	Entrypoints          []ServerEntrypoint `json:"entrypoints"`
+	MeshIP               string             `json:"meshIp,omitempty"`
+	MeshPublicKey        string             `json:"meshPublicKey,omitempty"`
+	BastionServerID      *string            `json:"bastionServerId,omitempty"`
+	Bastion              *SSHBastion        `json:"-"`
+	CloudProvider        string             `json:"cloudProvider,omitempty"`
+	CloudInstanceID      string             `json:"cloudInstanceId,omitempty"`
+	StatsIntervalSeconds *int               `json:"statsIntervalSeconds,omitempty"`
+	LastHeartbeatAt      *time.Time         `json:"lastHeartbeatAt,omitempty"`
+	CreatedAt            time.Time          `json:"createdAt"`
+	UpdatedAt            time.Time          `json:"updatedAt"`
+}
+
+// SSHBastion is the jump host a server is reached through. It is resolved
+// from the referenced bastion server with decrypted credentials right before
+// an SSH connection is made and is never persisted.
+type SSHBastion struct {
+	ServerID string
+	Host     string
+	Port     int
+	User     string
+	Key      string
+	Password string
+	HostKey  string
 }
 
 type CreateServerInput struct {
@@ -41,7 +84,15 @@ type CreateServerInput struct {
 	SSHUser              string  `json:"sshUser"`
 	SSHKeyEncrypted      string  `json:"-"`
 	SSHPasswordEncrypted string  `json:"-"`
+	SSHPublicKey         string  `json:"-"`
 	AcmeEmail            *string `json:"acmeEmail,omitempty"`
+	AgentInstallMethod   string  `json:"agentInstallMethod,omitempty"`
+	DockerRootless       bool    `json:"dockerRootless,omitempty"`
+	FirewallEnabled      bool    `json:"firewallEnabled,omitempty"`
+	SSHHardening         bool    `json:"sshHardening,omitempty"`
+	BastionServerID      *string `json:"bastionServerId,omitempty"`
+	CloudProvider        string  `json:"-"`
+	CloudInstanceID      string  `json:"-"`
 }
 
 type UpdateServerInput struct {
@@ -51,19 +102,39 @@ type UpdateServerInput struct {
 	SSHUser              *string       `json:"sshUser,omitempty"`
 	SSHKeyEncrypted      *string       `json:"-"`
 	SSHPasswordEncrypted *string       `json:"-"`
+	SSHPublicKey         *string       `json:"-"`
 	AcmeEmail            *string       `json:"acmeEmail,omitempty"`
 	Status               *ServerStatus `json:"status,omitempty"`
 	AgentUpdateMode      *string       `json:"agentUpdateMode,omitempty"`
+	AgentInstallMethod   *string       `json:"agentInstallMethod,omitempty"`
+	DockerRootless       *bool         `json:"dockerRootless,omitempty"`
+	FirewallEnabled      *bool         `json:"firewallEnabled,omitempty"`
+	SSHHardening         *bool         `json:"sshHardening,omitempty"`
+	AcmeStaging          *bool         `json:"acmeStaging,omitempty"`
+	BastionServerID      *string       `json:"bastionServerId,omitempty"`
 }
 
+// ServerFilter narrows a server list. The free-text query of ListOptions
+// matches the name and host.
+type ServerFilter struct {
+	Status ServerStatus
+}
+
+var ServerSortFields = []string{"createdAt", "name"}
+
 type ServerRepository interface {
 	Create(input CreateServerInput) (*Server, error)
 	FindByID(id string) (*Server, error)
 	FindByIDForUser(id string, userID string) (*Server, error)
 	FindAll() ([]Server, error)
 	FindAllByUserID(userID string) ([]Server, error)
+	FindPageByUserID(userID string, filter ServerFilter, opts ListOptions) (Page[Server], error)
 	Update(id string, input UpdateServerInput) (*Server, error)
 	UpdateHeartbeat(id string, agentVersion string) error
+	MarkStaleOffline(threshold time.Duration) ([]Server, error)
 	UpdateSSHHostKey(id string, hostKey string) error
+	UpdateEntrypoints(id string, entrypoints []ServerEntrypoint) error
+	UpdateMesh(id string, meshIP string, publicKey string) error
+	UpdateStatsInterval(id string, seconds *int) error
 	Delete(id string) error
 }
@@ -0,0 +1,25 @@
+package domain
+
+import "time"
+
+const (
+	DefaultBootstrapTokenTTL = 24 * time.Hour
+	MaxBootstrapTokenTTL     = 7 * 24 * time.Hour
+)
+
+// ServerBootstrapToken authorizes a single self-provisioning run of the
+// install script generated for a server. Only the token hash is stored.
+type ServerBootstrapToken struct {
+	TokenHash string
+	ServerID  string
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
+type ServerBootstrapTokenRepository interface {
+	Create(serverID, tokenHash string, expiresAt time.Time) error
+	FindValid(tokenHash string) (*ServerBootstrapToken, error)
+	Consume(tokenHash string) (*ServerBootstrapToken, error)
+	DeleteByServerID(serverID string) error
+}
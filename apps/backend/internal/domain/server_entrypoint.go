@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+)
+
+const (
+	EntrypointProtocolTCP = "tcp"
+	EntrypointProtocolUDP = "udp"
+)
+
+var entrypointNameRe = regexp.MustCompile(`^[a-z][a-z0-9-]{0,30}$`)
+
+// reservedEntrypoints are the entrypoints every provisioned Traefik already
+// listens on.
+var reservedEntrypoints = map[string]int{
+	"web":       80,
+	"websecure": 443,
+	"grpc":      50051,
+	"traefik":   8081,
+}
+
+// ServerEntrypoint is an extra Traefik entrypoint for non-HTTP apps. Apps
+// route to it by name from the ports section of paasdeploy.json.
+type ServerEntrypoint struct {
+	Name     string `json:"name"`
+	Port     int    `json:"port"`
+	Protocol string `json:"protocol"`
+}
+
+// ValidateServerEntrypoints rejects invalid names and protocols, duplicates
+// and ports already used by SSH or the built-in entrypoints.
+func ValidateServerEntrypoints(entrypoints []ServerEntrypoint, sshPort int) error {
+	names := make(map[string]bool, len(entrypoints))
+	ports := make(map[string]bool, len(entrypoints))
+	for _, e := range entrypoints {
+		if !entrypointNameRe.MatchString(e.Name) {
+			return fmt.Errorf("invalid entrypoint name %q: use lowercase letters, digits and hyphens", e.Name)
+		}
+		if _, ok := reservedEntrypoints[e.Name]; ok {
+			return fmt.Errorf("entrypoint name %q is reserved", e.Name)
+		}
+		if names[e.Name] {
+			return fmt.Errorf("duplicate entrypoint name %q", e.Name)
+		}
+		names[e.Name] = true
+
+		if e.Protocol != EntrypointProtocolTCP && e.Protocol != EntrypointProtocolUDP {
+			return fmt.Errorf("entrypoint %q: protocol must be tcp or udp", e.Name)
+		}
+		if e.Port < 1 || e.Port > 65535 {
+			return fmt.Errorf("entrypoint %q: port must be between 1 and 65535", e.Name)
+		}
+		if e.Protocol == EntrypointProtocolTCP {
+			if e.Port == sshPort {
+				return fmt.Errorf("entrypoint %q: port %d is used by SSH", e.Name, e.Port)
+			}
+			for name, port := range reservedEntrypoints {
+				if e.Port == port {
+					return fmt.Errorf("entrypoint %q: port %d is used by the %s entrypoint", e.Name, e.Port, name)
+				}
+			}
+		}
+		key := fmt.Sprintf("%d/%s", e.Port, e.Protocol)
+		if ports[key] {
+			return fmt.Errorf("duplicate entrypoint port %s", key)
+		}
+		ports[key] = true
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package domain
+
+import "testing"
+
+func TestValidateServerEntrypoints(t *testing.T) {
+	tests := []struct {
+		name        string
+		entrypoints []ServerEntrypoint
+		wantErr     bool
+	}{
+		{"empty", nil, false},
+		{"tcp and udp", []ServerEntrypoint{{"mqtt", 1883, "tcp"}, {"game", 27015, "udp"}}, false},
+		{"same port different protocol", []ServerEntrypoint{{"dns-tcp", 53, "tcp"}, {"dns-udp", 53, "udp"}}, false},
+		{"udp on https port", []ServerEntrypoint{{"quic", 443, "udp"}}, false},
+		{"invalid name", []ServerEntrypoint{{"MQTT", 1883, "tcp"}}, true},
+		{"reserved name", []ServerEntrypoint{{"websecure", 8443, "tcp"}}, true},
+		{"duplicate name", []ServerEntrypoint{{"mqtt", 1883, "tcp"}, {"mqtt", 8883, "tcp"}}, true},
+		{"duplicate port", []ServerEntrypoint{{"mqtt", 1883, "tcp"}, {"mqtt2", 1883, "tcp"}}, true},
+		{"invalid protocol", []ServerEntrypoint{{"mqtt", 1883, "sctp"}}, true},
+		{"port out of range", []ServerEntrypoint{{"mqtt", 70000, "tcp"}}, true},
+		{"ssh port", []ServerEntrypoint{{"ssh", 22, "tcp"}}, true},
+		{"built-in port", []ServerEntrypoint{{"alt-grpc", 50051, "tcp"}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateServerEntrypoints(tt.entrypoints, 22)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateServerEntrypoints() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
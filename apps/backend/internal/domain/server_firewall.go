@@ -0,0 +1,30 @@
+package domain
+
+import "time"
+
+const (
+	FirewallBackendUFW      = "ufw"
+	FirewallBackendNftables = "nftables"
+)
+
+// FirewallRule allows inbound traffic to a port. An empty Source allows any
+// address.
+type FirewallRule struct {
+	Port     int    `json:"port"`
+	Protocol string `json:"protocol"`
+	Source   string `json:"source,omitempty"`
+}
+
+// ServerFirewall records the rule set last applied to a server, so it can be
+// shown and re-applied without re-running the full provisioning.
+type ServerFirewall struct {
+	ServerID  string         `json:"serverId"`
+	Backend   string         `json:"backend"`
+	Rules     []FirewallRule `json:"rules"`
+	AppliedAt time.Time      `json:"appliedAt"`
+}
+
+type ServerFirewallRepository interface {
+	Upsert(fw *ServerFirewall) error
+	FindByServerID(serverID string) (*ServerFirewall, error)
+}
@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+const (
+	ServerHeartbeatInterval = 30 * time.Second
+	// A server is considered offline once it misses this many heartbeats.
+	ServerHeartbeatMissedLimit = 3
+	ServerHeartbeatRetention   = 30 * 24 * time.Hour
+)
+
+func ServerOfflineThreshold() time.Duration {
+	return ServerHeartbeatMissedLimit * ServerHeartbeatInterval
+}
+
+type ServerOfflinePeriod struct {
+	Start           time.Time  `json:"start"`
+	End             *time.Time `json:"end,omitempty"`
+	DurationSeconds int64      `json:"durationSeconds"`
+}
+
+type ServerAvailability struct {
+	ServerID       string                `json:"serverId"`
+	From           time.Time             `json:"from"`
+	To             time.Time             `json:"to"`
+	UptimePercent  float64               `json:"uptimePercent"`
+	HeartbeatCount int                   `json:"heartbeatCount"`
+	OfflinePeriods []ServerOfflinePeriod `json:"offlinePeriods"`
+}
+
+type ServerHeartbeatRepository interface {
+	Record(serverID string) error
+	FindSince(serverID string, since time.Time) ([]time.Time, error)
+	DeleteOlderThan(before time.Time) (int64, error)
+}
+
+// ComputeServerAvailability treats every gap between heartbeats longer than
+// threshold as an offline period. A trailing gap that reaches `to` is reported
+// without an end, meaning the server is still offline.
+func ComputeServerAvailability(serverID string, heartbeats []time.Time, from, to time.Time, threshold time.Duration) ServerAvailability {
+	beats := make([]time.Time, 0, len(heartbeats))
+	for _, hb := range heartbeats {
+		if !hb.Before(from) && !hb.After(to) {
+			beats = append(beats, hb)
+		}
+	}
+	sort.Slice(beats, func(i, j int) bool { return beats[i].Before(beats[j]) })
+
+	availability := ServerAvailability{
+		ServerID:       serverID,
+		From:           from,
+		To:             to,
+		HeartbeatCount: len(beats),
+		OfflinePeriods: []ServerOfflinePeriod{},
+	}
+
+	total := to.Sub(from)
+	if total <= 0 {
+		availability.UptimePercent = 100
+		return availability
+	}
+
+	var offline time.Duration
+	addPeriod := func(start, end time.Time, ongoing bool) {
+		period := ServerOfflinePeriod{Start: start, DurationSeconds: int64(end.Sub(start).Seconds())}
+		if !ongoing {
+			periodEnd := end
+			period.End = &periodEnd
+		}
+		availability.OfflinePeriods = append(availability.OfflinePeriods, period)
+		offline += end.Sub(start)
+	}
+
+	last := from
+	for _, hb := range beats {
+		if hb.Sub(last) > threshold {
+			addPeriod(last, hb, false)
+		}
+		last = hb
+	}
+	if to.Sub(last) > threshold {
+		addPeriod(last, to, true)
+	}
+
+	uptime := float64(total-offline) / float64(total) * 100
+	availability.UptimePercent = math.Round(uptime*100) / 100
+	return availability
+}
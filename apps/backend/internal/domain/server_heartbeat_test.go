@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestComputeServerAvailability(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(time.Hour)
+	threshold := 90 * time.Second
+
+	t.Run("continuous heartbeats", func(t *testing.T) {
+		var beats []time.Time
+		for at := from; !at.After(to); at = at.Add(30 * time.Second) {
+			beats = append(beats, at)
+		}
+
+		got := ComputeServerAvailability("srv", beats, from, to, threshold)
+		if got.UptimePercent != 100 {
+			t.Fatalf("expected 100%% uptime, got %v", got.UptimePercent)
+		}
+		if len(got.OfflinePeriods) != 0 {
+			t.Fatalf("expected no offline periods, got %d", len(got.OfflinePeriods))
+		}
+	})
+
+	t.Run("gap and trailing outage", func(t *testing.T) {
+		var beats []time.Time
+		for at := from; at.Before(from.Add(15 * time.Minute)); at = at.Add(30 * time.Second) {
+			beats = append(beats, at)
+		}
+		for at := from.Add(30 * time.Minute); at.Before(from.Add(45 * time.Minute)); at = at.Add(30 * time.Second) {
+			beats = append(beats, at)
+		}
+
+		got := ComputeServerAvailability("srv", beats, from, to, threshold)
+		if len(got.OfflinePeriods) != 2 {
+			t.Fatalf("expected 2 offline periods, got %d", len(got.OfflinePeriods))
+		}
+		if got.OfflinePeriods[0].End == nil {
+			t.Fatalf("expected first offline period to be closed")
+		}
+		if got.OfflinePeriods[1].End != nil {
+			t.Fatalf("expected trailing offline period to be ongoing")
+		}
+		if got.UptimePercent != 48.33 {
+			t.Fatalf("expected 48.33%% uptime, got %v", got.UptimePercent)
+		}
+	})
+
+	t.Run("no heartbeats", func(t *testing.T) {
+		got := ComputeServerAvailability("srv", nil, from, to, threshold)
+		if got.UptimePercent != 0 {
+			t.Fatalf("expected 0%% uptime, got %v", got.UptimePercent)
+		}
+	})
+}
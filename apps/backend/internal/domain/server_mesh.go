@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"net"
+)
+
+const (
+	// MeshInterface is the WireGuard interface joining the user's servers.
+	MeshInterface = "wg-paasdeploy"
+	MeshPort      = 51820
+	MeshSubnet    = "10.210.0.0/16"
+)
+
+var ErrMeshFull = errors.New("no free mesh addresses left")
+
+// MeshPeer is another server on the WireGuard mesh.
+type MeshPeer struct {
+	Name      string
+	PublicKey string
+	Endpoint  string
+	MeshIP    string
+}
+
+// InMesh reports whether the server has joined the private mesh.
+func (s *Server) InMesh() bool {
+	return s.MeshIP != ""
+}
+
+// AllocateMeshIP returns the lowest free host address in MeshSubnet.
+func AllocateMeshIP(used []string) (string, error) {
+	taken := make(map[string]bool, len(used))
+	for _, ip := range used {
+		taken[ip] = true
+	}
+	_, subnet, _ := net.ParseCIDR(MeshSubnet)
+	base := subnet.IP.To4()
+	for i := 1; i < 65535; i++ {
+		ip := net.IPv4(base[0], base[1], byte(i>>8), byte(i)).String()
+		if byte(i) == 0 || byte(i) == 255 || taken[ip] {
+			continue
+		}
+		return ip, nil
+	}
+	return "", ErrMeshFull
+}
+
+// MeshPeers returns every other mesh member of servers as a peer of self.
+// Servers that have not reported a public key yet are skipped.
+func MeshPeers(self *Server, servers []Server) []MeshPeer {
+	var peers []MeshPeer
+	for _, s := range servers {
+		if s.ID == self.ID || !s.InMesh() || s.MeshPublicKey == "" {
+			continue
+		}
+		peers = append(peers, MeshPeer{
+			Name:      s.Name,
+			PublicKey: s.MeshPublicKey,
+			Endpoint:  net.JoinHostPort(s.Host, fmt.Sprintf("%d", MeshPort)),
+			MeshIP:    s.MeshIP,
+		})
+	}
+	return peers
+}
@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestAllocateMeshIP(t *testing.T) {
+	ip, err := AllocateMeshIP(nil)
+	if err != nil || ip != "10.210.0.1" {
+		t.Fatalf("AllocateMeshIP(nil) = %q, %v", ip, err)
+	}
+
+	ip, err = AllocateMeshIP([]string{"10.210.0.1", "10.210.0.3"})
+	if err != nil || ip != "10.210.0.2" {
+		t.Fatalf("expected first gap, got %q, %v", ip, err)
+	}
+
+	used := make([]string, 0, 254)
+	for i := 1; i < 255; i++ {
+		used = append(used, fmt.Sprintf("10.210.0.%d", i))
+	}
+	ip, err = AllocateMeshIP(used)
+	if err != nil || ip != "10.210.1.1" {
+		t.Fatalf("expected to skip network and broadcast addresses, got %q, %v", ip, err)
+	}
+}
+
+func TestMeshPeers(t *testing.T) {
+	self := &Server{ID: "a", MeshIP: "10.210.0.1", MeshPublicKey: "keyA"}
+	servers := []Server{
+		*self,
+		{ID: "b", Name: "db", Host: "203.0.113.2", MeshIP: "10.210.0.2", MeshPublicKey: "keyB"},
+		{ID: "c", Host: "203.0.113.3"},
+		{ID: "d", Host: "203.0.113.4", MeshIP: "10.210.0.4"},
+	}
+
+	peers := MeshPeers(self, servers)
+	if len(peers) != 1 {
+		t.Fatalf("expected 1 peer, got %+v", peers)
+	}
+	if p := peers[0]; p.PublicKey != "keyB" || p.Endpoint != "203.0.113.2:51820" || p.MeshIP != "10.210.0.2" {
+		t.Errorf("unexpected peer %+v", p)
+	}
+}
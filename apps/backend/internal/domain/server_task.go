@@ -0,0 +1,120 @@
+package domain
+
+import "time"
+
+const (
+	DefaultServerTaskTimeoutSeconds = 600
+	MaxServerTaskTimeoutSeconds     = 3600
+	// ServerTaskRunRetention is how many runs are kept per task; older
+	// ones are pruned when a run finishes.
+	ServerTaskRunRetention = 50
+)
+
+// What started a server task run.
+const (
+	ServerTaskTriggerSchedule = "schedule"
+	ServerTaskTriggerManual   = "manual"
+)
+
+type ServerTaskRunStatus string
+
+const (
+	ServerTaskRunStatusRunning ServerTaskRunStatus = "running"
+	ServerTaskRunStatusSuccess ServerTaskRunStatus = "success"
+	ServerTaskRunStatusFailed  ServerTaskRunStatus = "failed"
+)
+
+// ServerTask is a shell command the agent runs on a server's host on a cron
+// schedule, such as a certbot hook or a cleanup script.
+type ServerTask struct {
+	ID              string              `json:"id"`
+	ServerID        string              `json:"serverId"`
+	Name            string              `json:"name"`
+	Schedule        string              `json:"schedule"`
+	Command         string              `json:"command"`
+	TimeoutSeconds  int                 `json:"timeoutSeconds"`
+	Enabled         bool                `json:"enabled"`
+	NotifyOnFailure bool                `json:"notifyOnFailure"`
+	NextRunAt       *time.Time          `json:"nextRunAt,omitempty"`
+	LastRunAt       *time.Time          `json:"lastRunAt,omitempty"`
+	LastStatus      ServerTaskRunStatus `json:"lastStatus,omitempty"`
+	CreatedAt       time.Time           `json:"createdAt"`
+	UpdatedAt       time.Time           `json:"updatedAt"`
+}
+
+// Timeout is how long a run may take before the agent kills it.
+func (t ServerTask) Timeout() time.Duration {
+	seconds := t.TimeoutSeconds
+	if seconds <= 0 {
+		seconds = DefaultServerTaskTimeoutSeconds
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// ServerTaskRun is one execution of a server task, with the tail of its
+// combined output.
+type ServerTaskRun struct {
+	ID         string              `json:"id"`
+	TaskID     string              `json:"taskId"`
+	ServerID   string              `json:"serverId"`
+	Trigger    string              `json:"trigger"`
+	Status     ServerTaskRunStatus `json:"status"`
+	ExitCode   *int                `json:"exitCode,omitempty"`
+	Output     string              `json:"output"`
+	Truncated  bool                `json:"truncated"`
+	Error      string              `json:"error,omitempty"`
+	StartedAt  time.Time           `json:"startedAt"`
+	FinishedAt *time.Time          `json:"finishedAt,omitempty"`
+	DurationMs int64               `json:"durationMs"`
+}
+
+type CreateServerTaskInput struct {
+	ServerID        string
+	Name            string
+	Schedule        string
+	Command         string
+	TimeoutSeconds  int
+	Enabled         bool
+	NotifyOnFailure bool
+	NextRunAt       *time.Time
+}
+
+// UpdateServerTaskInput changes the set fields. NextRunAt is always written,
+// since it follows from the schedule and whether the task is enabled.
+type UpdateServerTaskInput struct {
+	Name            *string
+	Schedule        *string
+	Command         *string
+	TimeoutSeconds  *int
+	Enabled         *bool
+	NotifyOnFailure *bool
+	NextRunAt       *time.Time
+}
+
+type FinishServerTaskRunInput struct {
+	Status     ServerTaskRunStatus
+	ExitCode   *int
+	Output     string
+	Truncated  bool
+	Error      string
+	DurationMs int64
+}
+
+type ServerTaskRepository interface {
+	FindByServerID(serverID string) ([]ServerTask, error)
+	FindByIDAndServerID(id, serverID string) (*ServerTask, error)
+	// FindDue returns the enabled tasks whose next run is at or before now.
+	FindDue(now time.Time) ([]ServerTask, error)
+	CountByServerID(serverID string) (int, error)
+	Create(input CreateServerTaskInput) (*ServerTask, error)
+	Update(id string, input UpdateServerTaskInput) (*ServerTask, error)
+	Delete(id string) error
+	// ClaimDue moves a due task's next run from due to next and reports
+	// whether this caller claimed it, so a run is started only once.
+	ClaimDue(id string, due, next time.Time) (bool, error)
+	StartRun(task ServerTask, trigger string) (*ServerTaskRun, error)
+	FinishRun(run ServerTaskRun, input FinishServerTaskRunInput) (*ServerTaskRun, error)
+	FindRuns(taskID string, limit int) ([]ServerTaskRun, error)
+	// FailRunningRuns fails the runs left running by a previous process.
+	FailRunningRuns(reason string) (int64, error)
+}
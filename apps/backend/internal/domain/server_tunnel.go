@@ -0,0 +1,25 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// ServerTunnel is a Cloudflare Tunnel run by cloudflared on the server. App
+// domains on a tunneled server resolve to the tunnel instead of the server IP,
+// so the server needs no public address or open ports.
+type ServerTunnel struct {
+	ServerID  string
+	AccountID string
+	TunnelID  string
+	// TokenEncrypted is the cloudflared connector token, encrypted.
+	TokenEncrypted string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+type ServerTunnelRepository interface {
+	FindByServerID(ctx context.Context, serverID string) (*ServerTunnel, error)
+	Upsert(ctx context.Context, tunnel *ServerTunnel) error
+	Delete(ctx context.Context, serverID string) error
+}
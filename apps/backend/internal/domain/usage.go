@@ -0,0 +1,29 @@
+package domain
+
+import "time"
+
+type UsageMetric string
+
+const (
+	UsageMetricBuildSeconds UsageMetric = "build_seconds"
+	UsageMetricRuntimeHours UsageMetric = "runtime_hours"
+	UsageMetricNetworkBytes UsageMetric = "network_bytes"
+)
+
+// UsageRecord is the usage of one metric by an app over a metering period.
+// Records are never updated; IDs let billing systems drop duplicates.
+type UsageRecord struct {
+	ID          string      `json:"id"`
+	UserID      string      `json:"userId"`
+	AppID       string      `json:"appId"`
+	AppName     string      `json:"appName"`
+	ServerID    *string     `json:"serverId,omitempty"`
+	Metric      UsageMetric `json:"metric"`
+	Quantity    float64     `json:"quantity"`
+	PeriodStart time.Time   `json:"periodStart"`
+	PeriodEnd   time.Time   `json:"periodEnd"`
+}
+
+type UsageRecordRepository interface {
+	CreateBatch(records []UsageRecord) error
+}
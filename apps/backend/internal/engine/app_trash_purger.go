@@ -0,0 +1,104 @@
+package engine
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/paasdeploy/backend/internal/domain"
+	"github.com/paasdeploy/backend/internal/service"
+)
+
+const defaultTrashPurgeInterval = time.Hour
+
+type AppPurger interface {
+	PurgeApp(ctx context.Context, id string) error
+}
+
+// AppTrashPurger permanently removes apps that stayed in the trash longer
+// than the retention period, together with their webhooks, containers,
+// images, files and volumes.
+type AppTrashPurger struct {
+	appRepo      domain.AppRepository
+	purger       AppPurger
+	auditService *service.AuditService
+	retention    time.Duration
+	logger       *slog.Logger
+	interval     time.Duration
+	stopCh       chan struct{}
+	wg           sync.WaitGroup
+}
+
+func NewAppTrashPurger(
+	appRepo domain.AppRepository,
+	purger AppPurger,
+	auditService *service.AuditService,
+	retention time.Duration,
+	logger *slog.Logger,
+) *AppTrashPurger {
+	return &AppTrashPurger{
+		appRepo:      appRepo,
+		purger:       purger,
+		auditService: auditService,
+		retention:    retention,
+		logger:       logger.With("component", "app_trash_purger"),
+		interval:     defaultTrashPurgeInterval,
+		stopCh:       make(chan struct{}),
+	}
+}
+
+func (p *AppTrashPurger) Start(ctx context.Context) {
+	p.logger.Info("Starting app trash purger", "interval", p.interval, "retention", p.retention)
+	p.wg.Add(1)
+	go p.run(ctx)
+}
+
+func (p *AppTrashPurger) Stop() {
+	p.logger.Info("Stopping app trash purger")
+	close(p.stopCh)
+	p.wg.Wait()
+	p.logger.Info("App trash purger stopped")
+}
+
+func (p *AppTrashPurger) run(ctx context.Context) {
+	defer p.wg.Done()
+
+	p.purgeExpired(ctx)
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-p.stopCh:
+			return
+		case <-ticker.C:
+			p.purgeExpired(ctx)
+		}
+	}
+}
+
+func (p *AppTrashPurger) purgeExpired(ctx context.Context) {
+	apps, err := p.appRepo.FindDeletedBefore(time.Now().Add(-p.retention))
+	if err != nil {
+		p.logger.Error("Failed to list expired trashed apps", "error", err)
+		return
+	}
+
+	for _, app := range apps {
+		if ctx.Err() != nil {
+			return
+		}
+		if err := p.purger.PurgeApp(ctx, app.ID); err != nil {
+			p.logger.Error("Failed to purge trashed app", "appId", app.ID, "appName", app.Name, "error", err)
+			continue
+		}
+		p.logger.Info("Purged trashed app", "appId", app.ID, "appName", app.Name)
+		if p.auditService != nil {
+			p.auditService.LogAppPurged(ctx, service.AuditContext{}, app.ID, app.Name)
+		}
+	}
+}
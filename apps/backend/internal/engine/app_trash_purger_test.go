@@ -0,0 +1,93 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/paasdeploy/backend/internal/domain"
+)
+
+type fakeTrashAppRepo struct {
+	domain.AppRepository
+	trash []domain.App
+}
+
+func (r *fakeTrashAppRepo) FindDeletedBefore(before time.Time) ([]domain.App, error) {
+	var apps []domain.App
+	for _, app := range r.trash {
+		if app.DeletedAt.Before(before) {
+			apps = append(apps, app)
+		}
+	}
+	return apps, nil
+}
+
+type fakeAppPurger struct {
+	purged []string
+	fail   map[string]bool
+}
+
+func (p *fakeAppPurger) PurgeApp(_ context.Context, id string) error {
+	if p.fail[id] {
+		return errors.New("purge failed")
+	}
+	p.purged = append(p.purged, id)
+	return nil
+}
+
+func trashedApp(id string, deletedAgo time.Duration) domain.App {
+	deletedAt := time.Now().Add(-deletedAgo)
+	return domain.App{ID: id, Name: id, Status: domain.AppStatusDeleted, DeletedAt: &deletedAt}
+}
+
+func newTestTrashPurger(purger *fakeAppPurger, trash ...domain.App) *AppTrashPurger {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	return NewAppTrashPurger(&fakeTrashAppRepo{trash: trash}, purger, nil, 7*24*time.Hour, logger)
+}
+
+func TestAppTrashPurgerPurgesOnlyAfterRetention(t *testing.T) {
+	purger := &fakeAppPurger{}
+	p := newTestTrashPurger(purger,
+		trashedApp("expired", 8*24*time.Hour),
+		trashedApp("recent", 24*time.Hour),
+		trashedApp("almost", 7*24*time.Hour-time.Minute),
+	)
+
+	p.purgeExpired(context.Background())
+
+	if want := []string{"expired"}; !reflect.DeepEqual(purger.purged, want) {
+		t.Errorf("purged = %v, want %v", purger.purged, want)
+	}
+}
+
+func TestAppTrashPurgerContinuesAfterFailure(t *testing.T) {
+	purger := &fakeAppPurger{fail: map[string]bool{"broken": true}}
+	p := newTestTrashPurger(purger,
+		trashedApp("broken", 9*24*time.Hour),
+		trashedApp("expired", 8*24*time.Hour),
+	)
+
+	p.purgeExpired(context.Background())
+
+	if want := []string{"expired"}; !reflect.DeepEqual(purger.purged, want) {
+		t.Errorf("purged = %v, want %v", purger.purged, want)
+	}
+}
+
+func TestAppTrashPurgerStopsWhenContextIsDone(t *testing.T) {
+	purger := &fakeAppPurger{}
+	p := newTestTrashPurger(purger, trashedApp("expired", 8*24*time.Hour))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	p.purgeExpired(ctx)
+
+	if len(purger.purged) != 0 {
+		t.Errorf("purged = %v after cancellation", purger.purged)
+	}
+}
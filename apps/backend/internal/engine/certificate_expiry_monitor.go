@@ -0,0 +1,225 @@
+package engine
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/paasdeploy/backend/internal/agentclient"
+	"github.com/paasdeploy/backend/internal/domain"
+)
+
+const (
+	defaultCertificateCheckInterval = 6 * time.Hour
+	certificateListTimeout          = 15 * time.Second
+)
+
+// certificateAlertThresholds are the days before expiry at which an alert is
+// sent, largest first.
+var certificateAlertThresholds = []int{30, 14, 7}
+
+type CertificateExpiryNotifier interface {
+	NotifyCertificateExpiring(cert domain.CertificateExpiry)
+}
+
+type CertificateExpiryMonitorParams struct {
+	DomainRepo  domain.CustomDomainRepository
+	AlertRepo   domain.CertificateExpiryAlertRepository
+	ServerRepo  domain.ServerRepository
+	AppRepo     domain.AppRepository
+	AgentClient *agentclient.AgentClient
+	AgentPort   int
+	Notifier    CertificateExpiryNotifier
+	Logger      *slog.Logger
+}
+
+// CertificateExpiryMonitor alerts when uploaded certificates and the Let's
+// Encrypt certificates stored by Traefik on each online server cross 30, 14
+// and 7 days before expiry. Each threshold fires once per certificate.
+type CertificateExpiryMonitor struct {
+	domainRepo  domain.CustomDomainRepository
+	alertRepo   domain.CertificateExpiryAlertRepository
+	serverRepo  domain.ServerRepository
+	appRepo     domain.AppRepository
+	agentClient *agentclient.AgentClient
+	agentPort   int
+	notifier    CertificateExpiryNotifier
+	logger      *slog.Logger
+	interval    time.Duration
+	stopCh      chan struct{}
+	wg          sync.WaitGroup
+}
+
+func NewCertificateExpiryMonitor(params CertificateExpiryMonitorParams) *CertificateExpiryMonitor {
+	return &CertificateExpiryMonitor{
+		domainRepo:  params.DomainRepo,
+		alertRepo:   params.AlertRepo,
+		serverRepo:  params.ServerRepo,
+		appRepo:     params.AppRepo,
+		agentClient: params.AgentClient,
+		agentPort:   params.AgentPort,
+		notifier:    params.Notifier,
+		logger:      params.Logger.With("component", "certificate_expiry_monitor"),
+		interval:    defaultCertificateCheckInterval,
+		stopCh:      make(chan struct{}),
+	}
+}
+
+func (m *CertificateExpiryMonitor) Start(ctx context.Context) {
+	m.logger.Info("Starting certificate expiry monitor", "interval", m.interval, "thresholdDays", certificateAlertThresholds)
+	m.wg.Add(1)
+	go m.run(ctx)
+}
+
+func (m *CertificateExpiryMonitor) Stop() {
+	m.logger.Info("Stopping certificate expiry monitor")
+	close(m.stopCh)
+	m.wg.Wait()
+	m.logger.Info("Certificate expiry monitor stopped")
+}
+
+func (m *CertificateExpiryMonitor) run(ctx context.Context) {
+	defer m.wg.Done()
+
+	m.checkExpiring(ctx)
+
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-m.stopCh:
+			return
+		case <-ticker.C:
+			m.checkExpiring(ctx)
+		}
+	}
+}
+
+func (m *CertificateExpiryMonitor) checkExpiring(ctx context.Context) {
+	now := time.Now()
+	for _, cert := range m.uploadedCertificates(ctx, now) {
+		m.alert(ctx, cert, now)
+	}
+	for _, cert := range m.acmeCertificates(ctx, now) {
+		m.alert(ctx, cert, now)
+	}
+
+	if err := m.alertRepo.DeleteExpiredBefore(ctx, now.AddDate(0, 0, -certificateAlertThresholds[0])); err != nil {
+		m.logger.Warn("Failed to clean up certificate alerts", "error", err)
+	}
+}
+
+func (m *CertificateExpiryMonitor) uploadedCertificates(ctx context.Context, now time.Time) []domain.CertificateExpiry {
+	domains, err := m.domainRepo.FindCertificatesExpiringBefore(ctx, now.AddDate(0, 0, certificateAlertThresholds[0]))
+	if err != nil {
+		m.logger.Error("Failed to find expiring certificates", "error", err)
+		return nil
+	}
+
+	certs := make([]domain.CertificateExpiry, 0, len(domains))
+	for _, d := range domains {
+		certs = append(certs, domain.CertificateExpiry{
+			Domain:    d.Domain,
+			AppID:     d.AppID,
+			ExpiresAt: *d.TLSExpiresAt,
+			Uploaded:  true,
+		})
+	}
+	return certs
+}
+
+// acmeCertificates reads Traefik's ACME storage on each online server.
+// Staging certificates and certificates for domains no longer routed to an
+// app are skipped, since nobody relies on them.
+func (m *CertificateExpiryMonitor) acmeCertificates(ctx context.Context, now time.Time) []domain.CertificateExpiry {
+	if m.serverRepo == nil || m.appRepo == nil || m.agentClient == nil || m.agentPort == 0 {
+		return nil
+	}
+
+	servers, err := m.serverRepo.FindAll()
+	if err != nil {
+		m.logger.Error("Failed to list servers for certificate check", "error", err)
+		return nil
+	}
+
+	horizon := now.AddDate(0, 0, certificateAlertThresholds[0])
+	var certs []domain.CertificateExpiry
+	for _, server := range servers {
+		if server.Status != domain.ServerStatusOnline {
+			continue
+		}
+
+		listCtx, cancel := context.WithTimeout(ctx, certificateListTimeout)
+		pbCerts, err := m.agentClient.ListAcmeCertificates(listCtx, server.Host, m.agentPort)
+		cancel()
+		if err != nil {
+			m.logger.Debug("Failed to list ACME certificates", "serverId", server.ID, "error", err)
+			continue
+		}
+
+		appsByDomain, err := ServerACMEDomains(ctx, m.appRepo, m.domainRepo, server.ID)
+		if err != nil {
+			m.logger.Warn("Failed to list server domains for certificate check", "serverId", server.ID, "error", err)
+			continue
+		}
+		for _, c := range pbCerts {
+			if c.Staging || c.NotAfter == 0 {
+				continue
+			}
+			appID, routed := appsByDomain[strings.ToLower(c.Domain)]
+			if !routed {
+				continue
+			}
+			expiresAt := time.Unix(c.NotAfter, 0).UTC()
+			if expiresAt.After(horizon) {
+				continue
+			}
+			certs = append(certs, domain.CertificateExpiry{
+				Domain:    c.Domain,
+				AppID:     appID,
+				ServerID:  server.ID,
+				ExpiresAt: expiresAt,
+			})
+		}
+	}
+	return certs
+}
+
+func (m *CertificateExpiryMonitor) alert(ctx context.Context, cert domain.CertificateExpiry, now time.Time) {
+	threshold := alertThreshold(cert.ExpiresAt, now)
+	if threshold == 0 {
+		return
+	}
+
+	sent, err := m.alertRepo.Record(ctx, cert.Domain, cert.ExpiresAt, threshold)
+	if err != nil {
+		m.logger.Error("Failed to record certificate alert", "domain", cert.Domain, "error", err)
+		return
+	}
+	if !sent {
+		return
+	}
+
+	m.logger.Warn("Certificate expiring soon",
+		"domain", cert.Domain, "appId", cert.AppID, "expiresAt", cert.ExpiresAt, "uploaded", cert.Uploaded)
+	if m.notifier != nil {
+		m.notifier.NotifyCertificateExpiring(cert)
+	}
+}
+
+// alertThreshold returns the smallest threshold the certificate has crossed,
+// or 0 if it expires later than all of them.
+func alertThreshold(expiresAt, now time.Time) int {
+	threshold := 0
+	for _, days := range certificateAlertThresholds {
+		if !expiresAt.After(now.AddDate(0, 0, days)) {
+			threshold = days
+		}
+	}
+	return threshold
+}
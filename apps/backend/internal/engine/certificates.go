@@ -0,0 +1,88 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	pb "github.com/paasdeploy/backend/gen/go/flowdeploy/v1"
+	"github.com/paasdeploy/backend/internal/domain"
+)
+
+// ErrCustomCertificateUnsupported is returned for apps that are not deployed
+// to a remote server, since only provisioned servers load Traefik's file
+// provider.
+var ErrCustomCertificateUnsupported = errors.New("custom certificates require an app deployed to a remote server")
+
+// InstallCustomCertificate writes an uploaded certificate into the Traefik
+// dynamic config on the app's server.
+func (e *Engine) InstallCustomCertificate(ctx context.Context, app *domain.App, domainName, certPEM, keyPEM string) error {
+	server, agentPort, err := e.remoteServer(app, ErrCustomCertificateUnsupported)
+	if err != nil {
+		return err
+	}
+	return e.agentClient.InstallCertificate(ctx, server.Host, agentPort, &pb.InstallCertificateRequest{
+		Domain:         domainName,
+		CertificatePem: certPEM,
+		PrivateKeyPem:  keyPEM,
+	})
+}
+
+func (e *Engine) RemoveCustomCertificate(ctx context.Context, app *domain.App, domainName string) error {
+	server, agentPort, err := e.remoteServer(app, ErrCustomCertificateUnsupported)
+	if err != nil {
+		return err
+	}
+	return e.agentClient.RemoveCertificate(ctx, server.Host, agentPort, domainName)
+}
+
+// remoteServer returns the server the app is deployed to, or unsupported for
+// apps that run on the local server.
+func (e *Engine) remoteServer(app *domain.App, unsupported error) (*domain.Server, int, error) {
+	if app == nil || app.ServerID == nil || *app.ServerID == "" {
+		return nil, 0, unsupported
+	}
+	if e.serverRepo == nil || e.agentClient == nil {
+		return nil, 0, fmt.Errorf("remote update not available: server repository or agent client not configured")
+	}
+
+	server, err := e.serverRepo.FindByID(*app.ServerID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to find server %s: %w", *app.ServerID, err)
+	}
+
+	agentPort := e.agentPort
+	if agentPort == 0 {
+		agentPort = 50052
+	}
+	return server, agentPort, nil
+}
+
+// ServerACMEDomains maps the hosts Traefik requests Let's Encrypt certificates
+// for on a server, custom domains without an uploaded certificate and
+// redirect sources, to the app serving them.
+func ServerACMEDomains(ctx context.Context, appRepo domain.AppRepository, domainRepo domain.CustomDomainRepository, serverID string) (map[string]string, error) {
+	apps, err := appRepo.FindByServerID(serverID)
+	if err != nil {
+		return nil, err
+	}
+	result := map[string]string{}
+	appIDs := make([]string, len(apps))
+	for i, app := range apps {
+		appIDs[i] = app.ID
+		for _, r := range app.Redirects {
+			result[strings.ToLower(r.SourceHost)] = app.ID
+		}
+	}
+	domains, err := domainRepo.FindByAppIDs(ctx, appIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range domains {
+		if !d.HasCustomCertificate() {
+			result[strings.ToLower(d.Domain)] = d.AppID
+		}
+	}
+	return result, nil
+}
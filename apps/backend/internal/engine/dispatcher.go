@@ -3,9 +3,12 @@ package engine
 import (
 	"context"
 	"log/slog"
+	"sync/atomic"
 	"time"
 
+	pb "github.com/paasdeploy/backend/gen/go/flowdeploy/v1"
 	"github.com/paasdeploy/backend/internal/domain"
+	"github.com/paasdeploy/backend/internal/logarchive"
 	"github.com/paasdeploy/shared/pkg/lock"
 )
 
@@ -14,15 +17,23 @@ type Dispatcher struct {
 	locker   *lock.Locker
 	logger   *slog.Logger
 	pollTime time.Duration
+	// maxPerUser caps the deployments of one user running at once; the
+	// excess stays pending. Zero means unlimited.
+	maxPerUser atomic.Int64
+	// logArchive, when set, moves the logs of deployments growing past its
+	// threshold to object storage.
+	logArchive *logarchive.Archiver
 }
 
-func NewDispatcher(queue *Queue, locker *lock.Locker, logger *slog.Logger) *Dispatcher {
-	return &Dispatcher{
+func NewDispatcher(queue *Queue, locker *lock.Locker, maxPerUser int, logger *slog.Logger) *Dispatcher {
+	d := &Dispatcher{
 		queue:    queue,
 		locker:   locker,
 		logger:   logger,
 		pollTime: 5 * time.Second,
 	}
+	d.SetMaxPerUser(maxPerUser)
+	return d
 }
 
 func (d *Dispatcher) Next(ctx context.Context) (*domain.Deployment, *domain.App, error) {
@@ -33,7 +44,8 @@ func (d *Dispatcher) Next(ctx context.Context) (*domain.Deployment, *domain.App,
 	}
 	defer tx.Rollback()
 
-	deploy, err := d.queue.GetNextPendingTx(tx)
+	maxPerUser := d.MaxPerUser()
+	deploy, err := d.queue.GetNextPendingTx(tx, maxPerUser)
 	if err != nil {
 		d.logger.Error("Failed to get next pending deployment", "error", err)
 		return nil, nil, err
@@ -59,6 +71,20 @@ func (d *Dispatcher) Next(ctx context.Context) (*domain.Deployment, *domain.App,
 		return nil, nil, err
 	}
 
+	if maxPerUser > 0 && app.UserID != "" {
+		running, err := d.queue.CountRunningByUserTx(tx, app.UserID)
+		if err != nil {
+			d.locker.Release(deploy.AppID)
+			d.logger.Error("Failed to count running deployments of user", "deployId", deploy.ID, "userId", app.UserID, "error", err)
+			return nil, nil, err
+		}
+		if running >= maxPerUser {
+			d.locker.Release(deploy.AppID)
+			d.logger.Debug("User deploy limit reached, leaving deployment queued", "deployId", deploy.ID, "userId", app.UserID, "running", running)
+			return nil, nil, nil
+		}
+	}
+
 	if err := d.queue.MarkAsRunningTx(tx, deploy.ID); err != nil {
 		d.locker.Release(deploy.AppID)
 		d.logger.Error("Failed to mark deployment as running", "deployId", deploy.ID, "error", err)
@@ -90,6 +116,7 @@ func (d *Dispatcher) Release(appID string) error {
 }
 
 func (d *Dispatcher) MarkSuccess(deployID, imageTag, appVersion string) error {
+	d.forgetLogs(deployID)
 	err := d.queue.MarkAsSuccess(deployID, imageTag, appVersion)
 	if err == nil {
 		d.logger.Info("Deployment marked as success", "deployId", deployID, "imageTag", imageTag)
@@ -98,6 +125,7 @@ func (d *Dispatcher) MarkSuccess(deployID, imageTag, appVersion string) error {
 }
 
 func (d *Dispatcher) MarkFailed(deployID, errorMessage string) error {
+	d.forgetLogs(deployID)
 	err := d.queue.MarkAsFailed(deployID, errorMessage)
 	if err == nil {
 		d.logger.Info("Deployment marked as failed", "deployId", deployID, "error", errorMessage)
@@ -108,13 +136,37 @@ func (d *Dispatcher) MarkFailed(deployID, errorMessage string) error {
 }
 
 func (d *Dispatcher) AppendLogs(deployID, logs string) error {
-	return d.queue.AppendLogs(deployID, logs)
+	if err := d.queue.AppendLogs(deployID, logs); err != nil {
+		return err
+	}
+	if d.logArchive != nil {
+		d.logArchive.Track(deployID, len(logs))
+	}
+	return nil
+}
+
+func (d *Dispatcher) forgetLogs(deployID string) {
+	if d.logArchive != nil {
+		d.logArchive.Forget(deployID)
+	}
 }
 
 func (d *Dispatcher) SetPreviousImageTag(deployID, tag string) error {
 	return d.queue.SetPreviousImageTag(deployID, tag)
 }
 
+func (d *Dispatcher) SetStageTimings(deployID string, timings []*pb.StageTiming) error {
+	return d.queue.SetStageTimings(deployID, storedStageTimings(timings))
+}
+
+func (d *Dispatcher) SetSnapshot(deployID string, snapshot domain.DeploymentSnapshot) error {
+	return d.queue.SetSnapshot(deployID, snapshot)
+}
+
+func (d *Dispatcher) AverageStageDurations(appID string, limit int) (map[string]int64, error) {
+	return d.queue.AverageStageDurations(appID, limit)
+}
+
 func (d *Dispatcher) GetLastSuccessfulImageTag(appID string) (string, error) {
 	return d.queue.GetLastSuccessfulImageTag(appID)
 }
@@ -131,6 +183,15 @@ func (d *Dispatcher) UpdateAppVersion(appID, appVersion string) error {
 	return d.queue.UpdateAppVersion(appID, appVersion)
 }
 
+// SetMaxPerUser changes the per-user limit for the next dispatches.
+func (d *Dispatcher) SetMaxPerUser(n int) {
+	d.maxPerUser.Store(int64(max(n, 0)))
+}
+
+func (d *Dispatcher) MaxPerUser() int {
+	return int(d.maxPerUser.Load())
+}
+
 func (d *Dispatcher) SetPollTime(duration time.Duration) {
 	d.pollTime = duration
 }
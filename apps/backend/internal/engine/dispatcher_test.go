@@ -0,0 +1,156 @@
+package engine
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"log/slog"
+	"math/rand/v2"
+	"os"
+	"testing"
+
+	"github.com/google/uuid"
+	_ "github.com/jackc/pgx/v5/stdlib"
+
+	"github.com/paasdeploy/backend/internal/database"
+	"github.com/paasdeploy/backend/internal/domain"
+	"github.com/paasdeploy/backend/internal/repository"
+	"github.com/paasdeploy/shared/pkg/lock"
+)
+
+// The dispatcher tests run only when TEST_DATABASE_URL points at a
+// disposable Postgres database without other pending deployments:
+//
+//	TEST_DATABASE_URL=postgres://... go test ./internal/engine
+
+type dispatcherFixture struct {
+	db          *sql.DB
+	apps        *repository.PostgresAppRepository
+	deployments *repository.PostgresDeploymentRepository
+	userID      string
+}
+
+func newDispatcherFixture(t *testing.T) *dispatcherFixture {
+	t.Helper()
+
+	url := os.Getenv("TEST_DATABASE_URL")
+	if url == "" {
+		t.Skip("TEST_DATABASE_URL not set")
+	}
+	db, err := sql.Open("pgx", url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	if err := database.RunMigrations(db, "../../migrations", logger); err != nil {
+		t.Fatal(err)
+	}
+
+	f := &dispatcherFixture{
+		db:          db,
+		apps:        repository.NewPostgresAppRepository(db),
+		deployments: repository.NewPostgresDeploymentRepository(db),
+	}
+	err = db.QueryRow(`INSERT INTO users (github_id, github_login, access_token_encrypted)
+		VALUES ($1, $2, '') RETURNING id`, 1<<40+rand.Int64N(1<<40), "dispatch-"+uuid.NewString()[:8]).Scan(&f.userID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _, _ = db.Exec(`DELETE FROM users WHERE id = $1`, f.userID) })
+	return f
+}
+
+func (f *dispatcherFixture) pendingDeploy(t *testing.T) *domain.Deployment {
+	t.Helper()
+	name := "dispatch-" + uuid.NewString()[:8]
+	app, err := f.apps.Create(domain.CreateAppInput{
+		UserID:        f.userID,
+		Name:          name,
+		RepositoryURL: "https://github.com/test/" + name,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = f.deployments.DeleteByAppID(app.ID)
+		_ = f.apps.HardDelete(app.ID)
+	})
+
+	deploy, err := f.deployments.Create(domain.CreateDeploymentInput{AppID: app.ID, CommitSHA: "0123456789abcdef0123456789abcdef01234567"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return deploy
+}
+
+func (f *dispatcherFixture) dispatcher(t *testing.T, maxPerUser int) *Dispatcher {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	return NewDispatcher(NewQueue(f.db), lock.New(t.TempDir()), maxPerUser, logger)
+}
+
+func TestDispatcherLimitsRunningDeploymentsPerUser(t *testing.T) {
+	f := newDispatcherFixture(t)
+	first := f.pendingDeploy(t)
+	second := f.pendingDeploy(t)
+	d := f.dispatcher(t, 1)
+	ctx := context.Background()
+
+	deploy, _, err := d.Next(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if deploy == nil || deploy.ID != first.ID {
+		t.Fatalf("dispatched %v, want the oldest deployment %s", deploy, first.ID)
+	}
+
+	blocked, _, err := d.Next(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if blocked != nil {
+		t.Fatalf("dispatched %s while the user is at the limit", blocked.ID)
+	}
+
+	if err := d.MarkSuccess(first.ID, "image:1", ""); err != nil {
+		t.Fatal(err)
+	}
+	_ = d.Release(first.AppID)
+
+	next, _, err := d.Next(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if next == nil || next.ID != second.ID {
+		t.Errorf("dispatched %v after the first finished, want %s", next, second.ID)
+	}
+}
+
+func TestDispatcherWithoutLimitRunsUserDeploymentsConcurrently(t *testing.T) {
+	f := newDispatcherFixture(t)
+	f.pendingDeploy(t)
+	f.pendingDeploy(t)
+	d := f.dispatcher(t, 0)
+
+	for i := 0; i < 2; i++ {
+		deploy, _, err := d.Next(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if deploy == nil {
+			t.Fatalf("dispatch %d found nothing to run", i+1)
+		}
+	}
+}
+
+func TestDispatcherSetMaxPerUser(t *testing.T) {
+	d := NewDispatcher(nil, nil, 3, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if got := d.MaxPerUser(); got != 3 {
+		t.Errorf("MaxPerUser = %d, want 3", got)
+	}
+	d.SetMaxPerUser(-1)
+	if got := d.MaxPerUser(); got != 0 {
+		t.Errorf("MaxPerUser after a negative limit = %d, want 0", got)
+	}
+}
@@ -15,12 +15,15 @@ import (
 	"github.com/paasdeploy/backend/internal/agentclient"
 	"github.com/paasdeploy/backend/internal/config"
 	"github.com/paasdeploy/backend/internal/domain"
+	"github.com/paasdeploy/backend/internal/logarchive"
 	"github.com/paasdeploy/backend/internal/service"
+	"github.com/paasdeploy/backend/internal/sysinfo"
 	"github.com/paasdeploy/shared/pkg/compose"
 	"github.com/paasdeploy/shared/pkg/docker"
 	"github.com/paasdeploy/shared/pkg/git"
 	"github.com/paasdeploy/shared/pkg/health"
 	"github.com/paasdeploy/shared/pkg/lock"
+	"github.com/paasdeploy/shared/pkg/tracing"
 )
 
 type Engine struct {
@@ -33,15 +36,19 @@ type Engine struct {
 	docker           *docker.Client
 	locker           *lock.Locker
 	workers          []*Worker
+	workerStops      []context.CancelFunc
+	workerDeps       WorkerDeps
 	logger           *slog.Logger
 	ctx              context.Context
 	cancel           context.CancelFunc
 	wg               sync.WaitGroup
 	running          bool
 	mu               sync.Mutex
+	appRepo          domain.AppRepository
 	customDomainRepo domain.CustomDomainRepository
 	envVarRepo       domain.EnvVarRepository
 	serverRepo       domain.ServerRepository
+	agentCommandRepo domain.AgentCommandRepository
 	agentClient      *agentclient.AgentClient
 	agentPort        int
 }
@@ -53,9 +60,14 @@ type Params struct {
 	EnvVarRepo       domain.EnvVarRepository
 	CustomDomainRepo domain.CustomDomainRepository
 	ServerRepo       domain.ServerRepository
+	AgentCommandRepo domain.AgentCommandRepository
+	HealthEventRepo  domain.AppHealthEventRepository
+	SBOMRepo         domain.DeploymentSBOMRepository
 	AgentClient      *agentclient.AgentClient
 	GitTokenProvider GitTokenProvider
 	AuditService     *service.AuditService
+	Quotas           *service.QuotaService
+	LogArchive       *logarchive.Archiver
 	Logger           *slog.Logger
 }
 
@@ -65,10 +77,20 @@ func New(p Params) *Engine {
 	queue := NewQueue(p.DB)
 	lk := lock.New(p.Cfg.Deploy.DataDir)
 	notifier := NewChannelNotifier(1000)
-	dispatcher := NewDispatcher(queue, lk, p.Logger)
+	dispatcher := NewDispatcher(queue, lk, p.Cfg.Deploy.MaxPerUser, p.Logger)
+	dispatcher.logArchive = p.LogArchive
 	dockerClient := docker.NewClient(p.Cfg.Deploy.DataDir, p.Cfg.Docker.Registry, p.Logger)
-	healthMonitor := NewHealthMonitor(dockerClient, p.AppRepo, notifier, p.Logger)
-	statsMonitor := NewStatsMonitor(dockerClient, p.AppRepo, notifier, p.Logger)
+	healthMonitor := NewHealthMonitor(dockerClient, p.AppRepo, p.HealthEventRepo, notifier, p.AuditService, p.Logger)
+	statsMonitor := NewStatsMonitor(StatsMonitorParams{
+		Docker:      dockerClient,
+		AppRepo:     p.AppRepo,
+		ServerRepo:  p.ServerRepo,
+		AgentClient: p.AgentClient,
+		AgentPort:   p.Cfg.GRPC.AgentPort,
+		Notifier:    notifier,
+		Interval:    p.Cfg.Docker.StatsInterval,
+		Logger:      p.Logger,
+	})
 
 	engine := &Engine{
 		cfg:              p.Cfg,
@@ -82,29 +104,46 @@ func New(p Params) *Engine {
 		logger:           p.Logger.With("component", "engine"),
 		ctx:              ctx,
 		cancel:           cancel,
+		appRepo:          p.AppRepo,
 		customDomainRepo: p.CustomDomainRepo,
 		envVarRepo:       p.EnvVarRepo,
 		serverRepo:       p.ServerRepo,
+		agentCommandRepo: p.AgentCommandRepo,
 		agentClient:      p.AgentClient,
 		agentPort:        p.Cfg.GRPC.AgentPort,
 	}
 
+	maxBuilds := p.Cfg.Deploy.MaxBuilds
+	if maxBuilds <= 0 {
+		maxBuilds = docker.BuildSlotsForMemory(sysinfo.MemoryTotal())
+	}
+	if p.Cfg.Docker.SigningKeyPath != "" && p.Cfg.Docker.SigningPublicKey == "" {
+		p.Logger.Warn("Images are signed but IMAGE_SIGNING_PUBLIC_KEY_PATH is unset or unreadable, so pulled images are not verified")
+	}
+
 	deps := WorkerDeps{
 		Git:              git.NewClient(p.Cfg.Deploy.DataDir, p.Logger),
 		Docker:           dockerClient,
+		Builds:           docker.NewBuildLimiter(maxBuilds),
+		SigningKeyPath:   p.Cfg.Docker.SigningKeyPath,
+		SigningPublicKey: p.Cfg.Docker.SigningPublicKey,
 		Health:           health.NewChecker(p.Cfg.Deploy.HealthCheckTimeout, p.Cfg.Deploy.HealthCheckRetries, 5*time.Second, p.Logger),
 		Notifier:         notifier,
 		Dispatcher:       dispatcher,
+		AppRepo:          p.AppRepo,
 		EnvVarRepo:       p.EnvVarRepo,
 		CustomDomainRepo: p.CustomDomainRepo,
 		ServerRepo:       p.ServerRepo,
+		SBOMRepo:         p.SBOMRepo,
 		AgentClient:      p.AgentClient,
 		AgentPort:        p.Cfg.GRPC.AgentPort,
 		GitTokenProvider: p.GitTokenProvider,
 		AuditService:     p.AuditService,
+		Quotas:           p.Quotas,
 		Logger:           p.Logger,
 	}
 
+	engine.workerDeps = deps
 	for i := 0; i < p.Cfg.Deploy.Workers; i++ {
 		worker := NewWorker(i, p.Cfg.Deploy.DataDir, deps)
 		engine.workers = append(engine.workers, worker)
@@ -141,14 +180,58 @@ func (e *Engine) Start() error {
 	e.healthMonitor.Start(e.ctx)
 	e.statsMonitor.Start(e.ctx)
 
+	e.mu.Lock()
 	for _, worker := range e.workers {
-		e.wg.Add(1)
-		go e.runWorkerLoop(worker)
+		e.startWorker(worker)
 	}
+	e.mu.Unlock()
 
 	return nil
 }
 
+// startWorker runs the worker loop until the engine stops or the worker is
+// removed by SetWorkers. Callers must hold e.mu.
+func (e *Engine) startWorker(worker *Worker) {
+	ctx, cancel := context.WithCancel(e.ctx)
+	e.workerStops = append(e.workerStops, cancel)
+	e.wg.Add(1)
+	go e.runWorkerLoop(ctx, worker)
+}
+
+// SetWorkers changes the number of deploy workers at runtime. Removed
+// workers finish their current deployment before exiting.
+func (e *Engine) SetWorkers(n int) {
+	if n < 1 {
+		n = 1
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	current := len(e.workers)
+	for i := current; i < n; i++ {
+		worker := NewWorker(i, e.cfg.Deploy.DataDir, e.workerDeps)
+		e.workers = append(e.workers, worker)
+		if e.running {
+			e.startWorker(worker)
+		}
+	}
+	if n < current {
+		e.workers = e.workers[:n]
+		// Workers have stop funcs only once the engine has started them.
+		if len(e.workerStops) > n {
+			for _, stop := range e.workerStops[n:] {
+				stop()
+			}
+			e.workerStops = e.workerStops[:n]
+		}
+	}
+
+	if n != current {
+		e.logger.Info("Deploy workers resized", "from", current, "to", n)
+	}
+}
+
 func (e *Engine) Stop() {
 	e.mu.Lock()
 	if !e.running {
@@ -195,14 +278,14 @@ func (e *Engine) recoverFromRestart() {
 	}
 }
 
-func (e *Engine) runWorkerLoop(worker *Worker) {
+func (e *Engine) runWorkerLoop(ctx context.Context, worker *Worker) {
 	defer e.wg.Done()
 
 	e.logger.Info("Worker started", "workerId", worker.id)
 
 	for {
 		select {
-		case <-e.ctx.Done():
+		case <-ctx.Done():
 			e.logger.Info("Worker shutting down", "workerId", worker.id)
 			return
 		default:
@@ -233,16 +316,16 @@ func (e *Engine) runWorkerLoop(worker *Worker) {
 				)
 				panicMsg := fmt.Sprintf("worker panic: %v", r)
 				e.dispatcher.MarkFailed(deploy.ID, panicMsg)
-				e.notifier.EmitDeployFailed(deploy.ID, app.ID, panicMsg)
+				e.notifier.EmitDeployFailed(deploy.ID, app.ID, deploy.TraceID, panicMsg)
 			}
 			e.dispatcher.Release(app.ID)
 		}()
 
-			ctx, cancel := context.WithTimeout(e.ctx, e.cfg.Deploy.Timeout)
+			ctx, cancel := context.WithTimeout(tracing.WithID(e.ctx, deploy.TraceID), e.cfg.Deploy.Timeout)
 			defer cancel()
 
 			if err := worker.Run(ctx, deploy, app); err != nil {
-				e.logger.Error("Deployment failed",
+				e.logger.ErrorContext(ctx, "Deployment failed",
 					"deployId", deploy.ID,
 					"appId", app.ID,
 					"error", err,
@@ -263,7 +346,15 @@ func (e *Engine) IsRunning() bool {
 	return e.running
 }
 
+// SetMaxDeploysPerUser changes how many deployments of one user may run at
+// once. Zero removes the limit.
+func (e *Engine) SetMaxDeploysPerUser(n int) {
+	e.dispatcher.SetMaxPerUser(n)
+}
+
 func (e *Engine) WorkerCount() int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
 	return len(e.workers)
 }
 
@@ -323,21 +414,27 @@ func (e *Engine) updateLocalContainerDomains(ctx context.Context, app *domain.Ap
 	if err != nil {
 		return err
 	}
+	deployConfig.Resources.Memory, deployConfig.Resources.CPU = app.Resources().Override(deployConfig.Resources.Memory, deployConfig.Resources.CPU)
 
 	currentImage, err := e.getCurrentContainerImage(ctx, app.Name)
 	if err != nil {
 		return err
 	}
 
-	allDomains := e.collectAllDomains(ctx, app.ID, deployConfig.Domains)
-	envVars := e.collectEnvVars(app.ID)
+	envVars := e.collectEnvVars(app)
+	deployConfig.Interpolate(envVars)
+	allDomains, redirects := e.collectAllDomains(ctx, app, deployConfig)
 
 	params := compose.GenerateParams{
-		AppName:  app.Name,
-		ImageTag: currentImage,
-		Config:   deployConfig,
-		Domains:  allDomains,
-		EnvVars:  envVars,
+		AppName:   app.Name,
+		ImageTag:  currentImage,
+		Config:    deployConfig,
+		Domains:   allDomains,
+		EnvVars:   envVars,
+		RateLimit: toComposeRateLimit(app.RateLimit),
+		Redirects: toComposeRedirects(redirects),
+		Headers:   toComposeSecurityHeaders(app.SecurityHeaders),
+		Internal:  app.Internal,
 	}
 
 	if err := e.writeAndApplyCompose(ctx, appDir, app.ID, compose.GenerateContent(params)); err != nil {
@@ -358,16 +455,8 @@ func (e *Engine) updateRemoteContainerDomains(ctx context.Context, app *domain.A
 		return fmt.Errorf("failed to find server %s: %w", *app.ServerID, err)
 	}
 
-	allDomains := e.collectAllDomains(ctx, app.ID, nil)
-	envVars := e.collectEnvVars(app.ID)
-
-	var pbDomains []*pb.DomainRouteConfig
-	for _, d := range allDomains {
-		pbDomains = append(pbDomains, &pb.DomainRouteConfig{
-			Domain:     d.Domain,
-			PathPrefix: d.PathPrefix,
-		})
-	}
+	allDomains, redirects := e.collectAllDomains(ctx, app, nil)
+	envVars := e.collectEnvVars(app)
 
 	defaults := &compose.Config{}
 	compose.ApplyDefaults(defaults)
@@ -384,14 +473,21 @@ func (e *Engine) updateRemoteContainerDomains(ctx context.Context, app *domain.A
 	}
 
 	req := &pb.UpdateDomainsRequest{
-		AppId:   app.ID,
-		AppName: app.Name,
-		Domains: pbDomains,
-		Port:    appPort,
-		EnvVars: envVars,
+		AppId:           app.ID,
+		AppName:         app.Name,
+		Domains:         toPBDomainRoutes(allDomains),
+		Port:            appPort,
+		EnvVars:         envVars,
+		RateLimit:       toPBRateLimit(app.RateLimit),
+		Redirects:       toPBRedirects(redirects),
+		SecurityHeaders: toPBSecurityHeaders(app.SecurityHeaders),
+		Internal:        app.Internal,
 	}
 
 	if err := e.agentClient.UpdateDomains(ctx, server.Host, agentPort, req); err != nil {
+		if agentclient.IsUnreachable(err) && e.queueRemoteDomainUpdate(server.ID, req) {
+			return nil
+		}
 		return fmt.Errorf("failed to update domains on remote agent: %w", err)
 	}
 
@@ -403,6 +499,35 @@ func (e *Engine) updateRemoteContainerDomains(ctx context.Context, app *domain.A
 	return nil
 }
 
+func (e *Engine) queueRemoteDomainUpdate(serverID string, req *pb.UpdateDomainsRequest) bool {
+	if e.agentCommandRepo == nil {
+		return false
+	}
+
+	payload, err := agentclient.EncodeCommandPayload(req)
+	if err != nil {
+		e.logger.Error("Failed to encode domain update command", "app_id", req.AppId, "error", err)
+		return false
+	}
+
+	cmd, err := e.agentCommandRepo.Enqueue(domain.EnqueueAgentCommandInput{
+		ServerID: serverID,
+		Type:     domain.AgentCommandUpdateDomains,
+		Payload:  payload,
+	})
+	if err != nil {
+		e.logger.Error("Failed to queue domain update command", "app_id", req.AppId, "error", err)
+		return false
+	}
+
+	e.logger.Warn("Agent unreachable, domain update queued",
+		"app_id", req.AppId,
+		"server_id", serverID,
+		"command_id", cmd.ID,
+	)
+	return true
+}
+
 func (e *Engine) resolveAppDir(app *domain.App) string {
 	repoDir := filepath.Join(e.cfg.Deploy.DataDir, app.ID)
 	if app.Workdir == "" || app.Workdir == "." {
@@ -422,36 +547,164 @@ func (e *Engine) getCurrentContainerImage(ctx context.Context, containerName str
 	return containerHealth.Image, nil
 }
 
-func (e *Engine) collectAllDomains(ctx context.Context, appID string, configDomains []string) []compose.DomainRoute {
-	var domainRoutes []compose.DomainRoute
-	for _, d := range configDomains {
-		domainRoutes = append(domainRoutes, compose.DomainRoute{Domain: d})
+// collectAllDomains returns the app's routes and its redirects, including
+// the secondary names of apex/www pairs, which redirect instead of routing.
+func (e *Engine) collectAllDomains(ctx context.Context, app *domain.App, deployConfig *compose.Config) ([]compose.DomainRoute, []domain.AppRedirect) {
+	routes, redirects, err := e.findAllDomains(ctx, app, deployConfig)
+	if err != nil {
+		e.logger.Warn("Failed to load custom domains, routing config domains only", "app_id", app.ID, "error", err)
+	}
+	return routes, redirects
+}
+
+// findAllDomains is collectAllDomains for callers that must not go on
+// without the custom domains: on error it still returns the routes and
+// redirects of the config.
+func (e *Engine) findAllDomains(ctx context.Context, app *domain.App, deployConfig *compose.Config) ([]compose.DomainRoute, []domain.AppRedirect, error) {
+	var configRoutes []compose.DomainRoute
+	redirects := app.Redirects
+	if deployConfig != nil {
+		var configRedirects []compose.Redirect
+		configRoutes, configRedirects = deployConfig.DomainRoutes()
+		redirects = append(fromComposeRedirects(configRedirects), redirects...)
 	}
 
 	if e.customDomainRepo == nil {
-		return domainRoutes
+		return configRoutes, redirects, nil
 	}
 
-	customDomains, err := e.customDomainRepo.FindByAppID(ctx, appID)
+	customDomains, err := e.customDomainRepo.FindByAppID(ctx, app.ID)
 	if err != nil {
-		return domainRoutes
+		return configRoutes, redirects, fmt.Errorf("failed to load custom domains of app %s: %w", app.ID, err)
 	}
 
+	var customRoutes []compose.DomainRoute
 	for _, d := range customDomains {
-		domainRoutes = append(domainRoutes, compose.DomainRoute{
-			Domain:     d.Domain,
-			PathPrefix: d.PathPrefix,
+		if d.IsRedirect() {
+			continue
+		}
+		customRoutes = append(customRoutes, compose.DomainRoute{
+			Domain:            d.Domain,
+			PathPrefix:        d.PathPrefix,
+			BasicAuthUsers:    d.BasicAuthEntries(),
+			IPAllowlist:       d.IPAllowlistEntries(),
+			CustomCertificate: d.HasCustomCertificate(),
 		})
 	}
-	return domainRoutes
+	return compose.MergeDomainRoutes(configRoutes, customRoutes), append(domain.CustomDomainRedirects(customDomains), redirects...), nil
 }
 
-func (e *Engine) collectEnvVars(appID string) map[string]string {
+func toPBDomainRoutes(routes []compose.DomainRoute) []*pb.DomainRouteConfig {
+	var pbRoutes []*pb.DomainRouteConfig
+	for _, d := range routes {
+		pbRoutes = append(pbRoutes, &pb.DomainRouteConfig{
+			Domain:            d.Domain,
+			PathPrefix:        d.PathPrefix,
+			BasicAuthUsers:    d.BasicAuthUsers,
+			IpAllowlist:       d.IPAllowlist,
+			CustomCertificate: d.CustomCertificate,
+		})
+	}
+	return pbRoutes
+}
+
+func toComposeRateLimit(rl *domain.AppRateLimit) *compose.RateLimit {
+	if rl == nil {
+		return nil
+	}
+	return &compose.RateLimit{Average: rl.Average, Burst: rl.Burst, Source: rl.Source, Header: rl.Header}
+}
+
+func toPBRateLimit(rl *domain.AppRateLimit) *pb.RateLimitConfig {
+	if rl == nil {
+		return nil
+	}
+	return &pb.RateLimitConfig{
+		Average: int32(rl.Average),
+		Burst:   int32(rl.Burst),
+		Source:  rl.Source,
+		Header:  rl.Header,
+	}
+}
+
+func toComposeSecurityHeaders(h *domain.AppSecurityHeaders) *compose.SecurityHeaders {
+	if h == nil {
+		return nil
+	}
+	return &compose.SecurityHeaders{
+		HSTSMaxAge:            h.HSTSMaxAge,
+		HSTSIncludeSubdomains: h.HSTSIncludeSubdomains,
+		HSTSPreload:           h.HSTSPreload,
+		FrameOptions:          h.FrameOptions,
+		ContentSecurityPolicy: h.ContentSecurityPolicy,
+		ReferrerPolicy:        h.ReferrerPolicy,
+	}
+}
+
+func toPBSecurityHeaders(h *domain.AppSecurityHeaders) *pb.SecurityHeadersConfig {
+	if h == nil {
+		return nil
+	}
+	return &pb.SecurityHeadersConfig{
+		HstsMaxAge:            int32(h.HSTSMaxAge),
+		HstsIncludeSubdomains: h.HSTSIncludeSubdomains,
+		HstsPreload:           h.HSTSPreload,
+		FrameOptions:          h.FrameOptions,
+		ContentSecurityPolicy: h.ContentSecurityPolicy,
+		ReferrerPolicy:        h.ReferrerPolicy,
+	}
+}
+
+// fromComposeRedirects converts the redirects of the paasdeploy config.
+func fromComposeRedirects(redirects []compose.Redirect) []domain.AppRedirect {
+	result := make([]domain.AppRedirect, 0, len(redirects))
+	for _, r := range redirects {
+		statusCode := 302
+		if r.Permanent {
+			statusCode = 301
+		}
+		result = append(result, domain.AppRedirect{
+			SourceHost: r.SourceHost,
+			SourcePath: r.SourcePath,
+			Target:     r.Target,
+			StatusCode: statusCode,
+		})
+	}
+	return result
+}
+
+func toComposeRedirects(redirects []domain.AppRedirect) []compose.Redirect {
+	result := make([]compose.Redirect, 0, len(redirects))
+	for _, r := range redirects {
+		result = append(result, compose.Redirect{
+			SourceHost: r.SourceHost,
+			SourcePath: r.SourcePath,
+			Target:     r.Target,
+			Permanent:  r.Permanent(),
+		})
+	}
+	return result
+}
+
+func toPBRedirects(redirects []domain.AppRedirect) []*pb.RedirectConfig {
+	result := make([]*pb.RedirectConfig, 0, len(redirects))
+	for _, r := range redirects {
+		result = append(result, &pb.RedirectConfig{
+			SourceHost: r.SourceHost,
+			SourcePath: r.SourcePath,
+			Target:     r.Target,
+			Permanent:  r.Permanent(),
+		})
+	}
+	return result
+}
+
+func (e *Engine) collectEnvVars(app *domain.App) map[string]string {
 	if e.envVarRepo == nil {
 		return nil
 	}
 
-	vars, err := e.envVarRepo.FindByAppID(appID)
+	vars, err := e.envVarRepo.FindByAppID(app.ID)
 	if err != nil {
 		return nil
 	}
@@ -460,7 +713,7 @@ func (e *Engine) collectEnvVars(appID string) map[string]string {
 	for _, v := range vars {
 		envVars[v.Key] = v.Value
 	}
-	return envVars
+	return addLinkEnvVars(e.appRepo, e.envVarRepo, app, envVars)
 }
 
 func (e *Engine) writeAndApplyCompose(ctx context.Context, appDir, projectName, content string) error {
@@ -476,4 +729,3 @@ func (e *Engine) writeAndApplyCompose(ctx context.Context, appDir, projectName,
 
 	return nil
 }
-
@@ -0,0 +1,79 @@
+package engine
+
+import (
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/paasdeploy/backend/internal/config"
+)
+
+func newWorkersTestEngine(workers int) *Engine {
+	e := &Engine{
+		cfg:    &config.Config{},
+		logger: slog.New(slog.NewTextHandler(io.Discard, nil)),
+	}
+	for i := 0; i < workers; i++ {
+		e.workers = append(e.workers, NewWorker(i, "", WorkerDeps{}))
+	}
+	return e
+}
+
+func TestSetWorkersGrowsStoppedEngine(t *testing.T) {
+	e := newWorkersTestEngine(2)
+
+	e.SetWorkers(4)
+
+	if got := e.WorkerCount(); got != 4 {
+		t.Fatalf("WorkerCount = %d, want 4", got)
+	}
+	for i, w := range e.workers {
+		if w.id != i {
+			t.Errorf("worker %d has id %d", i, w.id)
+		}
+	}
+	if len(e.workerStops) != 0 {
+		t.Errorf("stopped engine started %d workers", len(e.workerStops))
+	}
+}
+
+func TestSetWorkersStopsRemovedWorkers(t *testing.T) {
+	e := newWorkersTestEngine(3)
+	e.running = true
+	var stopped []int
+	for i := range e.workers {
+		e.workerStops = append(e.workerStops, func() { stopped = append(stopped, i) })
+	}
+
+	e.SetWorkers(1)
+
+	if got := e.WorkerCount(); got != 1 {
+		t.Fatalf("WorkerCount = %d, want 1", got)
+	}
+	if len(stopped) != 2 || stopped[0] != 1 || stopped[1] != 2 {
+		t.Errorf("stopped workers %v, want [1 2]", stopped)
+	}
+	if len(e.workerStops) != 1 {
+		t.Errorf("%d stop funcs kept, want 1", len(e.workerStops))
+	}
+}
+
+func TestSetWorkersKeepsAtLeastOne(t *testing.T) {
+	e := newWorkersTestEngine(2)
+
+	e.SetWorkers(0)
+
+	if got := e.WorkerCount(); got != 1 {
+		t.Errorf("WorkerCount = %d, want 1", got)
+	}
+}
+
+func TestSetWorkersShrinksStoppedEngine(t *testing.T) {
+	e := newWorkersTestEngine(3)
+
+	e.SetWorkers(2)
+
+	if got := e.WorkerCount(); got != 2 {
+		t.Errorf("WorkerCount = %d, want 2", got)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/paasdeploy/backend/internal/domain"
+	"github.com/paasdeploy/backend/internal/service"
 	"github.com/paasdeploy/shared/pkg/docker"
 )
 
@@ -14,34 +15,71 @@ const (
 	defaultMonitorInterval = 30 * time.Second
 	dbFetchRetries         = 3
 	dbFetchRetryDelay      = 2 * time.Second
+	healthEventPruneEvery  = time.Hour
+
+	// A container restarted crashLoopRestarts times within crashLoopWindow
+	// is crash-looping and has its restart policy turned off.
+	crashLoopRestarts = 5
+	crashLoopWindow   = 10 * time.Minute
+
+	// autoHealRecovery is how long a container must stay healthy before its
+	// auto-heal restarts are counted from zero again.
+	autoHealRecovery = 10 * time.Minute
 )
 
+// crashTracker follows the restarts of an app's container between checks.
+type crashTracker struct {
+	restartCount int
+	restarts     []time.Time
+	crashLoop    bool
+}
+
+// healTracker follows how long an app's container has been unhealthy and
+// how many times auto-heal restarted it.
+type healTracker struct {
+	unhealthySince time.Time
+	healthySince   time.Time
+	attempts       int
+	exhausted      bool
+}
+
 type HealthMonitor struct {
-	docker     *docker.Client
-	appRepo    domain.AppRepository
-	notifier   Notifier
-	logger     *slog.Logger
-	interval   time.Duration
-	lastStatus map[string]string
-	mu         sync.RWMutex
-	stopCh     chan struct{}
-	wg         sync.WaitGroup
+	docker      *docker.Client
+	appRepo     domain.AppRepository
+	historyRepo domain.AppHealthEventRepository
+	notifier    Notifier
+	audit       *service.AuditService
+	logger      *slog.Logger
+	interval    time.Duration
+	lastStatus  map[string]string
+	crashes     map[string]*crashTracker
+	heals       map[string]*healTracker
+	lastPrune   time.Time
+	mu          sync.RWMutex
+	stopCh      chan struct{}
+	wg          sync.WaitGroup
 }
 
 func NewHealthMonitor(
 	dockerClient *docker.Client,
 	appRepo domain.AppRepository,
+	historyRepo domain.AppHealthEventRepository,
 	notifier Notifier,
+	auditService *service.AuditService,
 	logger *slog.Logger,
 ) *HealthMonitor {
 	return &HealthMonitor{
-		docker:     dockerClient,
-		appRepo:    appRepo,
-		notifier:   notifier,
-		logger:     logger.With("component", "health_monitor"),
-		interval:   defaultMonitorInterval,
-		lastStatus: make(map[string]string),
-		stopCh:     make(chan struct{}),
+		docker:      dockerClient,
+		appRepo:     appRepo,
+		historyRepo: historyRepo,
+		notifier:    notifier,
+		audit:       auditService,
+		logger:      logger.With("component", "health_monitor"),
+		interval:    defaultMonitorInterval,
+		lastStatus:  make(map[string]string),
+		crashes:     make(map[string]*crashTracker),
+		heals:       make(map[string]*healTracker),
+		stopCh:      make(chan struct{}),
 	}
 }
 
@@ -112,6 +150,8 @@ func (m *HealthMonitor) checkAllApps(ctx context.Context) {
 		if health == nil {
 			continue
 		}
+		m.detectCrashes(ctx, app, health)
+		m.autoHeal(ctx, app, health)
 
 		statusKey := health.Status + "|" + health.Health
 		m.mu.RLock()
@@ -132,12 +172,185 @@ func (m *HealthMonitor) checkAllApps(ctx context.Context) {
 				StartedAt: health.StartedAt,
 				Uptime:    health.Uptime,
 			})
+			m.recordHistory(app.ID, health.Status, health.Health)
 
 			m.mu.Lock()
 			m.lastStatus[app.ID] = statusKey
 			m.mu.Unlock()
 		}
 	}
+
+	m.pruneHistory()
+}
+
+// detectCrashes replaces the health of containers that were OOM-killed or
+// keep crashing. Once a container restarts crashLoopRestarts times within
+// crashLoopWindow its restart policy is turned off, so it stays down until
+// the next deploy recreates it instead of restarting forever.
+func (m *HealthMonitor) detectCrashes(ctx context.Context, app domain.App, health *docker.ContainerHealth) {
+	if health.Status == "not_deployed" {
+		m.mu.Lock()
+		delete(m.crashes, app.ID)
+		m.mu.Unlock()
+		return
+	}
+
+	now := time.Now()
+	m.mu.Lock()
+	tracker := m.crashes[app.ID]
+	if tracker == nil || health.RestartCount < tracker.restartCount {
+		// First check of this container, or a new one since the last check:
+		// restarts it had before are not recent enough to count.
+		tracker = &crashTracker{restartCount: health.RestartCount}
+		m.crashes[app.ID] = tracker
+	}
+	for i := tracker.restartCount; i < health.RestartCount; i++ {
+		tracker.restarts = append(tracker.restarts, now)
+	}
+	tracker.restartCount = health.RestartCount
+
+	recent := tracker.restarts[:0]
+	for _, at := range tracker.restarts {
+		if now.Sub(at) < crashLoopWindow {
+			recent = append(recent, at)
+		}
+	}
+	tracker.restarts = recent
+
+	startedLoop := false
+	switch {
+	case !tracker.crashLoop && len(tracker.restarts) >= crashLoopRestarts:
+		tracker.crashLoop = true
+		startedLoop = true
+	case tracker.crashLoop && health.Status == "running" && len(tracker.restarts) == 0:
+		tracker.crashLoop = false
+	}
+	crashLoop := tracker.crashLoop
+	m.mu.Unlock()
+
+	if startedLoop {
+		m.logger.Warn("Container is crash-looping, disabling restarts",
+			"appId", app.ID,
+			"appName", app.Name,
+			"restarts", crashLoopRestarts,
+			"window", crashLoopWindow,
+			"exitCode", health.ExitCode,
+			"oomKilled", health.OOMKilled,
+		)
+		if err := m.docker.DisableRestart(ctx, app.Name); err != nil {
+			m.logger.Error("Failed to disable restarts of crash-looping container", "appName", app.Name, "error", err)
+		}
+	}
+
+	switch {
+	case crashLoop:
+		health.Health = domain.HealthCrashLoop
+	case health.OOMKilled && health.Status != "running":
+		health.Health = domain.HealthOOMKilled
+	}
+}
+
+// autoHeal restarts the container of an app with an auto-heal policy once
+// its healthcheck has been failing for the policy's threshold. After
+// MaxRestarts restarts it gives up until the container is seen healthy for
+// autoHealRecovery.
+func (m *HealthMonitor) autoHeal(ctx context.Context, app domain.App, health *docker.ContainerHealth) {
+	policy := app.AutoHeal
+	m.mu.Lock()
+	if policy == nil {
+		delete(m.heals, app.ID)
+		m.mu.Unlock()
+		return
+	}
+	tracker := m.heals[app.ID]
+	if tracker == nil {
+		tracker = &healTracker{}
+		m.heals[app.ID] = tracker
+	}
+
+	now := time.Now()
+	if health.Status != "running" || health.Health != "unhealthy" {
+		tracker.unhealthySince = time.Time{}
+		if health.Status == "running" && health.Health == "healthy" {
+			if tracker.healthySince.IsZero() {
+				tracker.healthySince = now
+			}
+			if now.Sub(tracker.healthySince) >= autoHealRecovery {
+				tracker.attempts = 0
+				tracker.exhausted = false
+			}
+		} else {
+			tracker.healthySince = time.Time{}
+		}
+		m.mu.Unlock()
+		return
+	}
+
+	tracker.healthySince = time.Time{}
+	if tracker.unhealthySince.IsZero() {
+		tracker.unhealthySince = now
+	}
+	if now.Sub(tracker.unhealthySince) < policy.Threshold() {
+		m.mu.Unlock()
+		return
+	}
+	if tracker.attempts >= policy.MaxRestarts {
+		warn := !tracker.exhausted
+		tracker.exhausted = true
+		m.mu.Unlock()
+		if warn {
+			m.logger.Warn("Auto-heal gave up, container is still unhealthy",
+				"appId", app.ID,
+				"appName", app.Name,
+				"restarts", policy.MaxRestarts,
+			)
+		}
+		return
+	}
+	tracker.attempts++
+	tracker.unhealthySince = time.Time{}
+	attempt := tracker.attempts
+	m.mu.Unlock()
+
+	m.logger.Info("Auto-healing unhealthy container",
+		"appId", app.ID,
+		"appName", app.Name,
+		"attempt", attempt,
+		"maxRestarts", policy.MaxRestarts,
+	)
+	errorMsg := ""
+	if err := m.docker.RestartContainer(ctx, app.Name); err != nil {
+		m.logger.Error("Failed to restart unhealthy container", "appName", app.Name, "error", err)
+		errorMsg = err.Error()
+	}
+	if m.audit != nil {
+		m.audit.LogAppAutoHealed(ctx, app.ID, app.Name, attempt, policy.MaxRestarts, errorMsg)
+	}
+}
+
+func (m *HealthMonitor) recordHistory(appID, status, health string) {
+	if m.historyRepo == nil {
+		return
+	}
+	if err := m.historyRepo.Record(appID, status, health, time.Now().UTC()); err != nil {
+		m.logger.Error("Failed to record health history", "appId", appID, "error", err)
+	}
+}
+
+func (m *HealthMonitor) pruneHistory() {
+	if m.historyRepo == nil || time.Since(m.lastPrune) < healthEventPruneEvery {
+		return
+	}
+	m.lastPrune = time.Now()
+
+	deleted, err := m.historyRepo.DeleteOlderThan(time.Now().Add(-domain.AppHealthEventRetention))
+	if err != nil {
+		m.logger.Error("Failed to prune health history", "error", err)
+		return
+	}
+	if deleted > 0 {
+		m.logger.Info("Pruned health history", "deleted", deleted)
+	}
 }
 
 func (m *HealthMonitor) CheckApp(ctx context.Context, appName string) *docker.ContainerHealth {
@@ -157,5 +370,7 @@ func (m *HealthMonitor) CheckApp(ctx context.Context, appName string) *docker.Co
 func (m *HealthMonitor) ClearAppStatus(appID string) {
 	m.mu.Lock()
 	delete(m.lastStatus, appID)
+	delete(m.crashes, appID)
+	delete(m.heals, appID)
 	m.mu.Unlock()
 }
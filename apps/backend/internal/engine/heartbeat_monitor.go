@@ -0,0 +1,120 @@
+package engine
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/paasdeploy/backend/internal/domain"
+)
+
+const (
+	defaultHeartbeatCheckInterval = 30 * time.Second
+	heartbeatPruneInterval        = time.Hour
+)
+
+type ServerOfflineNotifier interface {
+	NotifyServerOffline(server domain.Server)
+}
+
+type HeartbeatMonitor struct {
+	serverRepo    domain.ServerRepository
+	heartbeatRepo domain.ServerHeartbeatRepository
+	notifier      ServerOfflineNotifier
+	emitter       BroadcastEmitter
+	logger        *slog.Logger
+	interval      time.Duration
+	lastPrune     time.Time
+	stopCh        chan struct{}
+	wg            sync.WaitGroup
+}
+
+func NewHeartbeatMonitor(
+	serverRepo domain.ServerRepository,
+	heartbeatRepo domain.ServerHeartbeatRepository,
+	notifier ServerOfflineNotifier,
+	emitter BroadcastEmitter,
+	logger *slog.Logger,
+) *HeartbeatMonitor {
+	return &HeartbeatMonitor{
+		serverRepo:    serverRepo,
+		heartbeatRepo: heartbeatRepo,
+		notifier:      notifier,
+		emitter:       emitter,
+		logger:        logger.With("component", "heartbeat_monitor"),
+		interval:      defaultHeartbeatCheckInterval,
+		stopCh:        make(chan struct{}),
+	}
+}
+
+func (m *HeartbeatMonitor) Start(ctx context.Context) {
+	m.logger.Info("Starting heartbeat monitor", "interval", m.interval, "offlineAfter", domain.ServerOfflineThreshold())
+	m.wg.Add(1)
+	go m.run(ctx)
+}
+
+func (m *HeartbeatMonitor) Stop() {
+	m.logger.Info("Stopping heartbeat monitor")
+	close(m.stopCh)
+	m.wg.Wait()
+	m.logger.Info("Heartbeat monitor stopped")
+}
+
+func (m *HeartbeatMonitor) run(ctx context.Context) {
+	defer m.wg.Done()
+
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-m.stopCh:
+			return
+		case <-ticker.C:
+			m.checkStale()
+			m.pruneHistory()
+		}
+	}
+}
+
+func (m *HeartbeatMonitor) checkStale() {
+	servers, err := m.serverRepo.MarkStaleOffline(domain.ServerOfflineThreshold())
+	if err != nil {
+		m.logger.Error("Failed to mark stale servers offline", "error", err)
+		return
+	}
+	if len(servers) == 0 {
+		return
+	}
+
+	for _, srv := range servers {
+		m.logger.Warn("Server missed heartbeats, marked offline",
+			"serverId", srv.ID, "name", srv.Name, "lastHeartbeatAt", srv.LastHeartbeatAt)
+		if m.notifier != nil {
+			m.notifier.NotifyServerOffline(srv)
+		}
+	}
+
+	if m.emitter != nil {
+		m.emitter.EmitInvalidate("servers")
+	}
+}
+
+func (m *HeartbeatMonitor) pruneHistory() {
+	if m.heartbeatRepo == nil || time.Since(m.lastPrune) < heartbeatPruneInterval {
+		return
+	}
+	m.lastPrune = time.Now()
+
+	deleted, err := m.heartbeatRepo.DeleteOlderThan(time.Now().Add(-domain.ServerHeartbeatRetention))
+	if err != nil {
+		m.logger.Error("Failed to prune heartbeat history", "error", err)
+		return
+	}
+	if deleted > 0 {
+		m.logger.Info("Pruned heartbeat history", "deleted", deleted)
+	}
+}
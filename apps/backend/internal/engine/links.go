@@ -0,0 +1,55 @@
+package engine
+
+import (
+	"github.com/paasdeploy/backend/internal/domain"
+	"github.com/paasdeploy/shared/pkg/compose"
+)
+
+// addLinkEnvVars injects the hostname, port and URL of the app's linked apps
+// into envVars. Links to apps on another server are skipped, and variables
+// the user set on the app take precedence.
+func addLinkEnvVars(appRepo domain.AppRepository, envVarRepo domain.EnvVarRepository, app *domain.App, envVars map[string]string) map[string]string {
+	if appRepo == nil || len(app.LinkedAppIDs) == 0 {
+		return envVars
+	}
+	if envVars == nil {
+		envVars = make(map[string]string)
+	}
+
+	linkedApps, err := appRepo.FindByIDs(app.LinkedAppIDs)
+	if err != nil {
+		return envVars
+	}
+
+	byID := make(map[string]*domain.App, len(linkedApps))
+	ids := make([]string, len(linkedApps))
+	for i := range linkedApps {
+		byID[linkedApps[i].ID] = &linkedApps[i]
+		ids[i] = linkedApps[i].ID
+	}
+
+	var varsByApp map[string][]domain.EnvVar
+	if envVarRepo != nil {
+		varsByApp, _ = envVarRepo.FindByAppIDs(ids)
+	}
+
+	for _, id := range app.LinkedAppIDs {
+		linked, ok := byID[id]
+		if !ok || !app.SameServer(linked) {
+			continue
+		}
+
+		linkedVars := map[string]string{}
+		for _, v := range varsByApp[linked.ID] {
+			linkedVars[v.Key] = v.Value
+		}
+		port := resolvePort(linkedVars, compose.DefaultAppPort)
+
+		for k, v := range domain.LinkEnvVars(linked, port) {
+			if _, ok := envVars[k]; !ok {
+				envVars[k] = v
+			}
+		}
+	}
+	return envVars
+}
@@ -0,0 +1,51 @@
+package engine
+
+import (
+	"context"
+	"errors"
+
+	pb "github.com/paasdeploy/backend/gen/go/flowdeploy/v1"
+	"github.com/paasdeploy/backend/internal/domain"
+)
+
+// ErrMaintenanceUnsupported is returned for apps that are not deployed to a
+// remote server, since the maintenance router is written to Traefik's file
+// provider, which only provisioned servers load.
+var ErrMaintenanceUnsupported = errors.New("maintenance mode requires an app deployed to a remote server")
+
+// SetMaintenance routes the app's domains to pageURL, or back to the app
+// when disabled. The container keeps running either way.
+func (e *Engine) SetMaintenance(ctx context.Context, app *domain.App, pageURL string, enabled bool) error {
+	server, agentPort, err := e.remoteServer(app, ErrMaintenanceUnsupported)
+	if err != nil {
+		return err
+	}
+
+	req, err := e.maintenanceRequest(ctx, app, pageURL, enabled)
+	if err != nil {
+		return err
+	}
+	return e.agentClient.SetMaintenance(ctx, server.Host, agentPort, req)
+}
+
+func (e *Engine) maintenanceRequest(ctx context.Context, app *domain.App, pageURL string, enabled bool) (*pb.SetMaintenanceRequest, error) {
+	routes, _, err := e.findAllDomains(ctx, app, nil)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool, len(routes))
+	var domains []string
+	for _, route := range routes {
+		if !seen[route.Domain] {
+			seen[route.Domain] = true
+			domains = append(domains, route.Domain)
+		}
+	}
+
+	return &pb.SetMaintenanceRequest{
+		AppName: app.Name,
+		Domains: domains,
+		PageUrl: pageURL,
+		Enabled: enabled,
+	}, nil
+}
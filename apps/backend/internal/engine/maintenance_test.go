@@ -0,0 +1,85 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"slices"
+	"testing"
+
+	"github.com/paasdeploy/backend/internal/agentclient"
+	"github.com/paasdeploy/backend/internal/domain"
+)
+
+type fakeMaintenanceDomainRepo struct {
+	domain.CustomDomainRepository
+	domains []domain.CustomDomain
+	err     error
+}
+
+func (r *fakeMaintenanceDomainRepo) FindByAppID(context.Context, string) ([]domain.CustomDomain, error) {
+	return r.domains, r.err
+}
+
+type fakeMaintenanceServerRepo struct {
+	domain.ServerRepository
+}
+
+func (r *fakeMaintenanceServerRepo) FindByID(id string) (*domain.Server, error) {
+	return &domain.Server{ID: id, Host: "203.0.113.10"}, nil
+}
+
+func newMaintenanceTestEngine(domainRepo domain.CustomDomainRepository) *Engine {
+	return &Engine{
+		customDomainRepo: domainRepo,
+		serverRepo:       &fakeMaintenanceServerRepo{},
+		// SetMaintenance must fail before dialing; a zero client cannot dial.
+		agentClient: &agentclient.AgentClient{},
+		logger:      slog.New(slog.NewTextHandler(io.Discard, nil)),
+	}
+}
+
+func TestMaintenanceRequestToggle(t *testing.T) {
+	e := newMaintenanceTestEngine(&fakeMaintenanceDomainRepo{domains: []domain.CustomDomain{
+		{Domain: "shop.example.com"},
+		{Domain: "shop.example.com", PathPrefix: "/api"},
+		{Domain: "www.shop.example.com", RedirectTo: "shop.example.com"},
+		{Domain: "admin.example.com"},
+	}})
+	app := &domain.App{ID: "app-1", Name: "shop"}
+
+	for _, enabled := range []bool{true, false} {
+		req, err := e.maintenanceRequest(context.Background(), app, "https://api.example.com/page", enabled)
+		if err != nil {
+			t.Fatalf("enabled=%v: %v", enabled, err)
+		}
+		if req.Enabled != enabled || req.AppName != "shop" || req.PageUrl != "https://api.example.com/page" {
+			t.Errorf("enabled=%v: request = %+v", enabled, req)
+		}
+		slices.Sort(req.Domains)
+		if want := []string{"admin.example.com", "shop.example.com"}; !slices.Equal(req.Domains, want) {
+			t.Errorf("enabled=%v: domains = %v, want %v", enabled, req.Domains, want)
+		}
+	}
+}
+
+func TestSetMaintenanceFailsWhenDomainsCannotBeLoaded(t *testing.T) {
+	dbErr := errors.New("connection refused")
+	e := newMaintenanceTestEngine(&fakeMaintenanceDomainRepo{err: dbErr})
+	serverID := "srv-1"
+	app := &domain.App{ID: "app-1", Name: "shop", ServerID: &serverID}
+
+	err := e.SetMaintenance(context.Background(), app, "https://api.example.com/page", true)
+	if !errors.Is(err, dbErr) {
+		t.Fatalf("err = %v, want %v", err, dbErr)
+	}
+}
+
+func TestSetMaintenanceRequiresRemoteServer(t *testing.T) {
+	e := newMaintenanceTestEngine(&fakeMaintenanceDomainRepo{})
+	err := e.SetMaintenance(context.Background(), &domain.App{ID: "app-1"}, "https://api.example.com/page", true)
+	if !errors.Is(err, ErrMaintenanceUnsupported) {
+		t.Fatalf("err = %v, want ErrMaintenanceUnsupported", err)
+	}
+}
@@ -2,17 +2,21 @@ package engine
 
 import (
 	"time"
+
+	"github.com/paasdeploy/backend/internal/domain"
+	"github.com/paasdeploy/shared/pkg/buildlog"
 )
 
 type EventType string
 
 const (
-	EventTypeRunning EventType = "RUNNING"
-	EventTypeSuccess EventType = "SUCCESS"
-	EventTypeFailed  EventType = "FAILED"
-	EventTypeLog     EventType = "LOG"
-	EventTypeHealth  EventType = "HEALTH"
-	EventTypeStats   EventType = "STATS"
+	EventTypeRunning  EventType = "RUNNING"
+	EventTypeSuccess  EventType = "SUCCESS"
+	EventTypeFailed   EventType = "FAILED"
+	EventTypeLog      EventType = "LOG"
+	EventTypeHealth   EventType = "HEALTH"
+	EventTypeStats    EventType = "STATS"
+	EventTypeProgress EventType = "PROGRESS"
 )
 
 type HealthStatus struct {
@@ -30,23 +34,42 @@ type StatsData struct {
 	NetworkRx     int64   `json:"networkRx"`
 	NetworkTx     int64   `json:"networkTx"`
 	PIDs          int     `json:"pids"`
+	// Averages holds the rolling "1m" and "5m" averages.
+	Averages map[string]domain.StatsAverage `json:"averages,omitempty"`
+}
+
+// DeployProgress is the estimated progress of a running deployment.
+type DeployProgress struct {
+	Stage      string `json:"stage"`
+	StageIndex int    `json:"stageIndex"`
+	StageCount int    `json:"stageCount"`
+	Percent    int    `json:"percent"`
+	ElapsedMs  int64  `json:"elapsedMs"`
+	EtaMs      int64  `json:"etaMs"`
+	// FromHistory is set when the estimate is based on previous
+	// deployments of the app rather than defaults.
+	FromHistory bool `json:"fromHistory"`
 }
 
 type DeployEvent struct {
-	Type      EventType     `json:"type"`
-	DeployID  string        `json:"deployId"`
-	AppID     string        `json:"appId"`
-	Message   string        `json:"message,omitempty"`
-	Health    *HealthStatus `json:"health,omitempty"`
-	Stats     *StatsData    `json:"stats,omitempty"`
-	Timestamp time.Time     `json:"timestamp"`
+	Type      EventType       `json:"type"`
+	DeployID  string          `json:"deployId"`
+	AppID     string          `json:"appId"`
+	TraceID   string          `json:"traceId,omitempty"`
+	Message   string          `json:"message,omitempty"`
+	LogKind   buildlog.Kind   `json:"logKind,omitempty"`
+	Health    *HealthStatus   `json:"health,omitempty"`
+	Stats     *StatsData      `json:"stats,omitempty"`
+	Progress  *DeployProgress `json:"progress,omitempty"`
+	Timestamp time.Time       `json:"timestamp"`
 }
 
 type Notifier interface {
-	EmitDeployRunning(deployID, appID string)
-	EmitDeploySuccess(deployID, appID string)
-	EmitDeployFailed(deployID, appID, message string)
-	EmitLog(deployID, appID, message string)
+	EmitDeployRunning(deployID, appID, traceID string)
+	EmitDeploySuccess(deployID, appID, traceID string)
+	EmitDeployFailed(deployID, appID, traceID, message string)
+	EmitLog(deployID, appID, message string, kind buildlog.Kind)
+	EmitProgress(deployID, appID string, progress DeployProgress)
 	EmitHealth(appID string, health HealthStatus)
 	EmitStats(appID string, stats StatsData)
 }
@@ -85,37 +108,50 @@ func (n *ChannelNotifier) emit(event DeployEvent) {
 	}
 }
 
-func (n *ChannelNotifier) EmitDeployRunning(deployID, appID string) {
+func (n *ChannelNotifier) EmitDeployRunning(deployID, appID, traceID string) {
 	n.emit(DeployEvent{
 		Type:     EventTypeRunning,
 		DeployID: deployID,
 		AppID:    appID,
+		TraceID:  traceID,
 	})
 }
 
-func (n *ChannelNotifier) EmitDeploySuccess(deployID, appID string) {
+func (n *ChannelNotifier) EmitDeploySuccess(deployID, appID, traceID string) {
 	n.emit(DeployEvent{
 		Type:     EventTypeSuccess,
 		DeployID: deployID,
 		AppID:    appID,
+		TraceID:  traceID,
 	})
 }
 
-func (n *ChannelNotifier) EmitDeployFailed(deployID, appID, message string) {
+func (n *ChannelNotifier) EmitDeployFailed(deployID, appID, traceID, message string) {
 	n.emit(DeployEvent{
 		Type:     EventTypeFailed,
 		DeployID: deployID,
 		AppID:    appID,
+		TraceID:  traceID,
 		Message:  message,
 	})
 }
 
-func (n *ChannelNotifier) EmitLog(deployID, appID, message string) {
+func (n *ChannelNotifier) EmitLog(deployID, appID, message string, kind buildlog.Kind) {
 	n.emit(DeployEvent{
 		Type:     EventTypeLog,
 		DeployID: deployID,
 		AppID:    appID,
 		Message:  message,
+		LogKind:  kind,
+	})
+}
+
+func (n *ChannelNotifier) EmitProgress(deployID, appID string, progress DeployProgress) {
+	n.emit(DeployEvent{
+		Type:     EventTypeProgress,
+		DeployID: deployID,
+		AppID:    appID,
+		Progress: &progress,
 	})
 }
 
@@ -0,0 +1,167 @@
+package engine
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	pb "github.com/paasdeploy/backend/gen/go/flowdeploy/v1"
+)
+
+const (
+	progressInterval = 2 * time.Second
+	// progressHistory is how many recent successful deployments of the app
+	// the expected stage durations are averaged over.
+	progressHistory = 10
+)
+
+// progressStages are the stages a deployment reports progress for, in the
+// order they run. The other stages overlap with these or are too short to
+// matter.
+var progressStages = []pb.DeployStage{
+	pb.DeployStage_DEPLOY_STAGE_GIT_SYNC,
+	pb.DeployStage_DEPLOY_STAGE_BUILD,
+	pb.DeployStage_DEPLOY_STAGE_DEPLOY,
+	pb.DeployStage_DEPLOY_STAGE_HEALTH_CHECK,
+}
+
+// defaultStageDurations are the expected durations of the progress stages
+// of an app without deployment history.
+var defaultStageDurations = map[pb.DeployStage]time.Duration{
+	pb.DeployStage_DEPLOY_STAGE_GIT_SYNC:     5 * time.Second,
+	pb.DeployStage_DEPLOY_STAGE_BUILD:        60 * time.Second,
+	pb.DeployStage_DEPLOY_STAGE_DEPLOY:       10 * time.Second,
+	pb.DeployStage_DEPLOY_STAGE_HEALTH_CHECK: 15 * time.Second,
+}
+
+// progressTracker emits progress events for a deployment while it runs.
+// The percentage and ETA are estimated from how long each stage took in
+// the app's recent deployments.
+type progressTracker struct {
+	notifier    Notifier
+	deployID    string
+	appID       string
+	expected    []time.Duration
+	fromHistory bool
+	start       time.Time
+
+	mu         sync.Mutex
+	stage      int
+	stageStart time.Time
+
+	stopCh   chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+func (w *Worker) startProgress(deployID, appID string) *progressTracker {
+	p := &progressTracker{
+		notifier: w.deps.Notifier,
+		deployID: deployID,
+		appID:    appID,
+		expected: make([]time.Duration, len(progressStages)),
+		start:    time.Now(),
+		stage:    -1,
+		stopCh:   make(chan struct{}),
+	}
+
+	history, err := w.deps.Dispatcher.AverageStageDurations(appID, progressHistory)
+	if err != nil {
+		w.deps.Logger.Warn("Failed to load stage durations", "appId", appID, "error", err)
+	}
+	for i, stage := range progressStages {
+		p.expected[i] = defaultStageDurations[stage]
+		if ms, ok := history[stageName(stage)]; ok {
+			p.expected[i] = time.Duration(ms) * time.Millisecond
+			p.fromHistory = true
+		}
+	}
+
+	p.wg.Add(1)
+	go p.run()
+	return p
+}
+
+func (p *progressTracker) run() {
+	defer p.wg.Done()
+	ticker := time.NewTicker(progressInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.stopCh:
+			return
+		case <-ticker.C:
+			p.emit()
+		}
+	}
+}
+
+// enter moves the deployment to stage. Stages that are not tracked or that
+// come before the current one are ignored; the ones skipped over count as
+// done.
+func (p *progressTracker) enter(stage pb.DeployStage) {
+	index := -1
+	for i, s := range progressStages {
+		if s == stage {
+			index = i
+		}
+	}
+
+	p.mu.Lock()
+	if index <= p.stage {
+		p.mu.Unlock()
+		return
+	}
+	p.stage = index
+	p.stageStart = time.Now()
+	p.mu.Unlock()
+
+	p.emit()
+}
+
+func (p *progressTracker) stop() {
+	p.stopOnce.Do(func() { close(p.stopCh) })
+	p.wg.Wait()
+}
+
+func (p *progressTracker) emit() {
+	p.mu.Lock()
+	if p.stage < 0 {
+		p.mu.Unlock()
+		return
+	}
+	stage, stageElapsed := p.stage, time.Since(p.stageStart)
+	p.mu.Unlock()
+
+	var total, done time.Duration
+	for i, expected := range p.expected {
+		total += expected
+		if i < stage {
+			done += expected
+		}
+	}
+	// A stage running longer than expected stays just short of complete
+	// instead of pushing the bar into the next one.
+	done += min(stageElapsed, p.expected[stage]*95/100)
+
+	percent := 100
+	if total > 0 {
+		percent = int(done * 100 / total)
+	}
+
+	p.notifier.EmitProgress(p.deployID, p.appID, DeployProgress{
+		Stage:       stageName(progressStages[stage]),
+		StageIndex:  stage,
+		StageCount:  len(progressStages),
+		Percent:     percent,
+		ElapsedMs:   time.Since(p.start).Milliseconds(),
+		EtaMs:       (total - done).Milliseconds(),
+		FromHistory: p.fromHistory,
+	})
+}
+
+// stageName is the name a stage is logged and stored under.
+func stageName(stage pb.DeployStage) string {
+	return strings.Trim(formatLogStage(stage), "[]")
+}
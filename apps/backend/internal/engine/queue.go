@@ -3,6 +3,7 @@ package engine
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"time"
 
 	"github.com/paasdeploy/backend/internal/domain"
@@ -58,25 +59,49 @@ func scanPendingDeploy(row *sql.Row) (*domain.Deployment, error) {
 	return &d, nil
 }
 
+// pendingDeployQuery picks the oldest pending deployment whose app is not
+// deploying and whose owner has fewer than $1 deployments running. A limit
+// of 0 or less disables the per-user check.
 const pendingDeployQuery = `
 	SELECT d.id, d.app_id, d.commit_sha, d.commit_message, d.status, d.started_at, d.finished_at,
 	       d.error_message, d.logs, d.previous_image_tag, d.current_image_tag, d.app_version, d.created_at
 	FROM deployments d
+	JOIN apps a ON a.id = d.app_id
 	WHERE d.status = 'pending'
 	AND d.app_id NOT IN (
 		SELECT app_id FROM deployments WHERE status = 'running'
 	)
+	AND ($1 <= 0 OR a.user_id IS NULL OR (
+		SELECT COUNT(*) FROM deployments rd
+		JOIN apps ra ON ra.id = rd.app_id
+		WHERE rd.status = 'running' AND ra.user_id = a.user_id
+	) < $1)
 	ORDER BY d.created_at ASC
 	LIMIT 1
-	FOR UPDATE SKIP LOCKED
+	FOR UPDATE OF d SKIP LOCKED
 `
 
 func (q *Queue) GetNextPending() (*domain.Deployment, error) {
-	return scanPendingDeploy(q.db.QueryRow(pendingDeployQuery))
+	return scanPendingDeploy(q.db.QueryRow(pendingDeployQuery, 0))
 }
 
-func (q *Queue) GetNextPendingTx(tx *sql.Tx) (*domain.Deployment, error) {
-	return scanPendingDeploy(tx.QueryRow(pendingDeployQuery))
+func (q *Queue) GetNextPendingTx(tx *sql.Tx, maxPerUser int) (*domain.Deployment, error) {
+	return scanPendingDeploy(tx.QueryRow(pendingDeployQuery, maxPerUser))
+}
+
+// CountRunningByUserTx counts the user's running deployments after taking a
+// transaction-level lock on the user, so concurrent dispatchers see each
+// other's deployments once the lock is granted.
+func (q *Queue) CountRunningByUserTx(tx *sql.Tx, userID string) (int, error) {
+	if _, err := tx.Exec(`SELECT pg_advisory_xact_lock(hashtext('deploy-user:' || $1))`, userID); err != nil {
+		return 0, err
+	}
+	var count int
+	err := tx.QueryRow(`
+		SELECT COUNT(*) FROM deployments d
+		JOIN apps a ON a.id = d.app_id
+		WHERE d.status = 'running' AND a.user_id = $1`, userID).Scan(&count)
+	return count, err
 }
 
 func (q *Queue) MarkAsRunning(id string) error {
@@ -120,6 +145,61 @@ func (q *Queue) SetPreviousImageTag(id string, tag string) error {
 	return err
 }
 
+// stageTiming is how a stage timing is stored in deployments.stage_timings.
+type stageTiming struct {
+	Stage         string `json:"stage"`
+	StartOffsetMs int64  `json:"startOffsetMs"`
+	DurationMs    int64  `json:"durationMs"`
+}
+
+func (q *Queue) SetStageTimings(id string, timings []stageTiming) error {
+	data, err := json.Marshal(timings)
+	if err != nil {
+		return err
+	}
+	_, err = q.db.Exec(`UPDATE deployments SET stage_timings = $2 WHERE id = $1`, id, data)
+	return err
+}
+
+func (q *Queue) SetSnapshot(id string, snapshot domain.DeploymentSnapshot) error {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+	_, err = q.db.Exec(`UPDATE deployments SET snapshot = $2 WHERE id = $1`, id, data)
+	return err
+}
+
+// AverageStageDurations returns the average duration in milliseconds of
+// each stage over the last limit successful deployments of the app.
+func (q *Queue) AverageStageDurations(appID string, limit int) (map[string]int64, error) {
+	rows, err := q.db.Query(`
+		SELECT t.stage, AVG(t."durationMs")::BIGINT
+		FROM (
+			SELECT stage_timings FROM deployments
+			WHERE app_id = $1 AND status = 'success' AND stage_timings IS NOT NULL
+			ORDER BY finished_at DESC
+			LIMIT $2
+		) d,
+		jsonb_to_recordset(d.stage_timings) AS t(stage TEXT, "durationMs" BIGINT)
+		GROUP BY t.stage`, appID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	durations := make(map[string]int64)
+	for rows.Next() {
+		var stage string
+		var ms int64
+		if err := rows.Scan(&stage, &ms); err != nil {
+			return nil, err
+		}
+		durations[stage] = ms
+	}
+	return durations, rows.Err()
+}
+
 func (q *Queue) GetPendingCount() (int, error) {
 	var count int
 	query := `SELECT COUNT(*) FROM deployments WHERE status = 'pending'`
@@ -178,7 +258,7 @@ func (q *Queue) GetLastSuccessfulImageTag(appID string) (string, error) {
 
 func (q *Queue) GetAppByID(appID string) (*domain.App, error) {
 	query := `
-		SELECT id, name, repository_url, branch, workdir, runtime, app_version, config, status, webhook_id, server_id, last_deployed_at, created_at, updated_at
+		SELECT id, name, repository_url, branch, workdir, runtime, app_version, config, status, webhook_id, server_id, user_id, last_deployed_at, rate_limit, redirects, security_headers, internal, linked_app_ids, created_at, updated_at
 		FROM apps
 		WHERE id = $1 AND status != 'deleted'
 	`
@@ -190,6 +270,11 @@ func (q *Queue) GetAppByID(appID string) (*domain.App, error) {
 	var appVersionStr sql.NullString
 	var webhookID sql.NullInt64
 	var serverID sql.NullString
+	var userID sql.NullString
+	var rateLimit []byte
+	var redirects []byte
+	var headers []byte
+	var linkedAppIDs []byte
 
 	err := q.db.QueryRow(query, appID).Scan(
 		&app.ID,
@@ -203,7 +288,13 @@ func (q *Queue) GetAppByID(appID string) (*domain.App, error) {
 		&app.Status,
 		&webhookID,
 		&serverID,
+		&userID,
 		&lastDeployedAt,
+		&rateLimit,
+		&redirects,
+		&headers,
+		&app.Internal,
+		&linkedAppIDs,
 		&app.CreatedAt,
 		&app.UpdatedAt,
 	)
@@ -230,6 +321,25 @@ func (q *Queue) GetAppByID(appID string) (*domain.App, error) {
 	if serverID.Valid {
 		app.ServerID = &serverID.String
 	}
+	app.UserID = userID.String
+	if len(rateLimit) > 0 {
+		var rl domain.AppRateLimit
+		if err := json.Unmarshal(rateLimit, &rl); err == nil {
+			app.RateLimit = &rl
+		}
+	}
+	if len(redirects) > 0 {
+		_ = json.Unmarshal(redirects, &app.Redirects)
+	}
+	if len(headers) > 0 {
+		var h domain.AppSecurityHeaders
+		if err := json.Unmarshal(headers, &h); err == nil {
+			app.SecurityHeaders = &h
+		}
+	}
+	if len(linkedAppIDs) > 0 {
+		_ = json.Unmarshal(linkedAppIDs, &app.LinkedAppIDs)
+	}
 
 	return &app, nil
 }
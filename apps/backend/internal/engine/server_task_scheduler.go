@@ -0,0 +1,242 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/paasdeploy/backend/internal/agentclient"
+	"github.com/paasdeploy/backend/internal/domain"
+	"github.com/paasdeploy/shared/pkg/buildlog"
+)
+
+const defaultServerTaskCheckInterval = 30 * time.Second
+
+type ServerTaskNotifier interface {
+	NotifyServerTaskFailed(server domain.Server, task domain.ServerTask, run domain.ServerTaskRun)
+}
+
+type ServerTaskSchedulerParams struct {
+	TaskRepo    domain.ServerTaskRepository
+	ServerRepo  domain.ServerRepository
+	AgentClient *agentclient.AgentClient
+	AgentPort   int
+	Notifier    ServerTaskNotifier
+	Logger      *slog.Logger
+}
+
+// ServerTaskScheduler starts server tasks when their cron schedule is due
+// and runs them on the server's agent, recording each run. A task never
+// runs twice at once: a due run is skipped while the previous one is still
+// running.
+type ServerTaskScheduler struct {
+	taskRepo    domain.ServerTaskRepository
+	serverRepo  domain.ServerRepository
+	agentClient *agentclient.AgentClient
+	agentPort   int
+	notifier    ServerTaskNotifier
+	logger      *slog.Logger
+	interval    time.Duration
+
+	mu      sync.Mutex
+	running map[string]bool
+	runs    sync.WaitGroup
+
+	runCtx    context.Context
+	cancelRun context.CancelFunc
+	stopCh    chan struct{}
+	wg        sync.WaitGroup
+}
+
+func NewServerTaskScheduler(params ServerTaskSchedulerParams) *ServerTaskScheduler {
+	runCtx, cancelRun := context.WithCancel(context.Background())
+	return &ServerTaskScheduler{
+		taskRepo:    params.TaskRepo,
+		serverRepo:  params.ServerRepo,
+		agentClient: params.AgentClient,
+		agentPort:   params.AgentPort,
+		notifier:    params.Notifier,
+		logger:      params.Logger.With("component", "server_task_scheduler"),
+		interval:    defaultServerTaskCheckInterval,
+		running:     make(map[string]bool),
+		runCtx:      runCtx,
+		cancelRun:   cancelRun,
+		stopCh:      make(chan struct{}),
+	}
+}
+
+func (s *ServerTaskScheduler) Start(ctx context.Context) {
+	s.logger.Info("Starting server task scheduler", "interval", s.interval)
+	if n, err := s.taskRepo.FailRunningRuns("interrupted by a backend restart"); err != nil {
+		s.logger.Warn("Failed to close interrupted server task runs", "error", err)
+	} else if n > 0 {
+		s.logger.Info("Closed interrupted server task runs", "count", n)
+	}
+	s.wg.Add(1)
+	go s.run(ctx)
+}
+
+// Stop stops scheduling and cancels the runs in progress, which the agent
+// kills.
+func (s *ServerTaskScheduler) Stop() {
+	s.logger.Info("Stopping server task scheduler")
+	close(s.stopCh)
+	s.wg.Wait()
+	s.cancelRun()
+	s.runs.Wait()
+	s.logger.Info("Server task scheduler stopped")
+}
+
+func (s *ServerTaskScheduler) run(ctx context.Context) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.stopCh:
+			return
+		case now := <-ticker.C:
+			s.startDue(now)
+		}
+	}
+}
+
+func (s *ServerTaskScheduler) startDue(now time.Time) {
+	tasks, err := s.taskRepo.FindDue(now)
+	if err != nil {
+		s.logger.Error("Failed to find due server tasks", "error", err)
+		return
+	}
+
+	for _, task := range tasks {
+		var next time.Time
+		if schedule, err := domain.ParseCronSchedule(task.Schedule); err != nil {
+			s.logger.Warn("Invalid server task schedule", "taskId", task.ID, "schedule", task.Schedule, "error", err)
+		} else {
+			next = schedule.Next(now)
+		}
+
+		claimed, err := s.taskRepo.ClaimDue(task.ID, *task.NextRunAt, next)
+		if err != nil {
+			s.logger.Error("Failed to schedule next server task run", "taskId", task.ID, "error", err)
+			continue
+		}
+		if !claimed {
+			continue
+		}
+
+		if _, err := s.RunTask(task, domain.ServerTaskTriggerSchedule); err != nil {
+			if errors.Is(err, domain.ErrServerTaskRunning) {
+				s.logger.Info("Skipping server task run, previous run still running", "taskId", task.ID)
+				continue
+			}
+			s.logger.Error("Failed to start server task", "taskId", task.ID, "error", err)
+		}
+	}
+}
+
+// RunTask starts a run of the task in the background and returns it while
+// it is running. It returns domain.ErrServerTaskRunning when the task is
+// already running.
+func (s *ServerTaskScheduler) RunTask(task domain.ServerTask, trigger string) (*domain.ServerTaskRun, error) {
+	s.mu.Lock()
+	if s.running[task.ID] {
+		s.mu.Unlock()
+		return nil, domain.ErrServerTaskRunning
+	}
+	s.running[task.ID] = true
+	s.mu.Unlock()
+
+	release := func() {
+		s.mu.Lock()
+		delete(s.running, task.ID)
+		s.mu.Unlock()
+	}
+
+	run, err := s.taskRepo.StartRun(task, trigger)
+	if err != nil {
+		release()
+		return nil, err
+	}
+
+	s.runs.Add(1)
+	go func() {
+		defer s.runs.Done()
+		defer release()
+		s.execute(task, *run)
+	}()
+	return run, nil
+}
+
+func (s *ServerTaskScheduler) execute(task domain.ServerTask, run domain.ServerTaskRun) {
+	logger := s.logger.With("taskId", task.ID, "runId", run.ID, "serverId", task.ServerID)
+	result := domain.FinishServerTaskRunInput{Status: domain.ServerTaskRunStatusFailed}
+	start := time.Now()
+
+	server, err := s.serverRepo.FindByID(task.ServerID)
+	switch {
+	case err != nil:
+		result.Error = "server not found"
+	case server.Status != domain.ServerStatusOnline:
+		result.Error = "server is " + string(server.Status)
+	case s.agentClient == nil || s.agentPort == 0:
+		result.Error = "agent client not configured"
+	default:
+		s.runOnAgent(server, task, &result)
+	}
+	if result.DurationMs == 0 {
+		result.DurationMs = time.Since(start).Milliseconds()
+	}
+
+	finished, err := s.taskRepo.FinishRun(run, result)
+	if err != nil {
+		logger.Error("Failed to record server task run", "error", err)
+		return
+	}
+	logger.Info("Server task run finished", "status", finished.Status, "durationMs", finished.DurationMs)
+
+	if finished.Status == domain.ServerTaskRunStatusFailed && task.NotifyOnFailure &&
+		s.notifier != nil && server != nil && s.runCtx.Err() == nil {
+		s.notifier.NotifyServerTaskFailed(*server, task, *finished)
+	}
+}
+
+func (s *ServerTaskScheduler) runOnAgent(server *domain.Server, task domain.ServerTask, result *domain.FinishServerTaskRunInput) {
+	resp, err := s.agentClient.RunServerTask(s.runCtx, server.Host, s.agentPort, task.ID, task.Command, task.Timeout())
+	if err != nil {
+		result.Error = err.Error()
+		return
+	}
+
+	if resp.ExitCode >= 0 {
+		exitCode := int(resp.ExitCode)
+		result.ExitCode = &exitCode
+	}
+	result.Output = taskOutputText(resp.Output)
+	result.Truncated = resp.Truncated
+	result.Error = resp.Error
+	result.DurationMs = resp.DurationMs
+	if resp.Error == "" && !resp.TimedOut && resp.ExitCode == 0 {
+		result.Status = domain.ServerTaskRunStatusSuccess
+	}
+}
+
+// taskOutputText makes a task's raw output storable and readable: Postgres
+// text rejects NUL bytes and invalid UTF-8, and terminal escapes are noise
+// outside a terminal.
+func taskOutputText(output []byte) string {
+	text := strings.ToValidUTF8(string(output), "�")
+	text = strings.ReplaceAll(text, "\x00", "")
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = buildlog.StripANSI(line)
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,80 @@
+package engine
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	pb "github.com/paasdeploy/backend/gen/go/flowdeploy/v1"
+)
+
+// stageTimer records when each stage of a local deployment ran, in the same
+// form the agent reports for remote ones. Stages may overlap.
+type stageTimer struct {
+	start time.Time
+
+	mu      sync.Mutex
+	timings []*pb.StageTiming
+}
+
+func newStageTimer() *stageTimer {
+	return &stageTimer{start: time.Now()}
+}
+
+// track starts timing stage and returns the function that stops it.
+func (t *stageTimer) track(stage pb.DeployStage) func() {
+	begin := time.Now()
+	return func() {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		t.timings = append(t.timings, &pb.StageTiming{
+			Stage:         stage,
+			StartOffsetMs: begin.Sub(t.start).Milliseconds(),
+			DurationMs:    time.Since(begin).Milliseconds(),
+		})
+	}
+}
+
+func (t *stageTimer) list() []*pb.StageTiming {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	timings := make([]*pb.StageTiming, len(t.timings))
+	copy(timings, t.timings)
+	sort.SliceStable(timings, func(i, j int) bool {
+		return timings[i].StartOffsetMs < timings[j].StartOffsetMs
+	})
+	return timings
+}
+
+// formatStageTimings renders timings as "git 1.2s, build 30.5s (+1.2s), ...",
+// where the offset in parentheses is when the stage started.
+func formatStageTimings(timings []*pb.StageTiming) string {
+	parts := make([]string, 0, len(timings))
+	for _, timing := range timings {
+		part := fmt.Sprintf("%s %s", stageName(timing.Stage), msDuration(timing.DurationMs))
+		if timing.StartOffsetMs > 0 {
+			part += fmt.Sprintf(" (+%s)", msDuration(timing.StartOffsetMs))
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// storedStageTimings converts timings to the form they are stored in.
+func storedStageTimings(timings []*pb.StageTiming) []stageTiming {
+	stored := make([]stageTiming, 0, len(timings))
+	for _, timing := range timings {
+		stored = append(stored, stageTiming{
+			Stage:         stageName(timing.Stage),
+			StartOffsetMs: timing.StartOffsetMs,
+			DurationMs:    timing.DurationMs,
+		})
+	}
+	return stored
+}
+
+func msDuration(ms int64) time.Duration {
+	return (time.Duration(ms) * time.Millisecond).Round(100 * time.Millisecond)
+}
@@ -6,39 +6,77 @@ import (
 	"sync"
 	"time"
 
+	pb "github.com/paasdeploy/backend/gen/go/flowdeploy/v1"
+	"github.com/paasdeploy/backend/internal/agentclient"
 	"github.com/paasdeploy/backend/internal/domain"
 	"github.com/paasdeploy/shared/pkg/docker"
 )
 
 const (
-	defaultStatsInterval  = 3 * time.Second
-	statsFetchRetries     = 3
-	statsFetchRetryDelay  = 2 * time.Second
+	defaultStatsInterval = 3 * time.Second
+	statsTick            = time.Second
+	statsRefreshInterval = 30 * time.Second
+	statsRemoteTimeout   = 5 * time.Second
+	statsFetchRetries    = 3
+	statsFetchRetryDelay = 2 * time.Second
 )
 
+var statsAverageSpans = map[string]time.Duration{
+	"1m": time.Minute,
+	"5m": domain.StatsWindowSpan,
+}
+
+type StatsMonitorParams struct {
+	Docker      *docker.Client
+	AppRepo     domain.AppRepository
+	ServerRepo  domain.ServerRepository
+	AgentClient *agentclient.AgentClient
+	AgentPort   int
+	Notifier    Notifier
+	Interval    time.Duration
+	Logger      *slog.Logger
+}
+
+// StatsMonitor samples the container stats of every deployed app at the
+// interval set on the app, its server or globally, and emits them with
+// rolling 1m and 5m averages. The app and server list is reloaded every
+// statsRefreshInterval, so interval changes apply within that time.
 type StatsMonitor struct {
-	docker   *docker.Client
-	appRepo  domain.AppRepository
-	notifier Notifier
-	logger   *slog.Logger
-	interval time.Duration
-	stopCh   chan struct{}
-	wg       sync.WaitGroup
+	docker      *docker.Client
+	appRepo     domain.AppRepository
+	serverRepo  domain.ServerRepository
+	agentClient *agentclient.AgentClient
+	agentPort   int
+	notifier    Notifier
+	logger      *slog.Logger
+	interval    time.Duration
+	stopCh      chan struct{}
+	wg          sync.WaitGroup
+
+	apps        []domain.App
+	servers     map[string]*domain.Server
+	lastRefresh time.Time
+	nextDue     map[string]time.Time
+	windows     map[string]*domain.StatsWindow
 }
 
-func NewStatsMonitor(
-	dockerClient *docker.Client,
-	appRepo domain.AppRepository,
-	notifier Notifier,
-	logger *slog.Logger,
-) *StatsMonitor {
+func NewStatsMonitor(p StatsMonitorParams) *StatsMonitor {
+	if p.Interval <= 0 {
+		p.Interval = defaultStatsInterval
+	}
 	return &StatsMonitor{
-		docker:   dockerClient,
-		appRepo:  appRepo,
-		notifier: notifier,
-		logger:   logger.With("component", "stats_monitor"),
-		interval: defaultStatsInterval,
-		stopCh:   make(chan struct{}),
+		docker:      p.Docker,
+		appRepo:     p.AppRepo,
+		serverRepo:  p.ServerRepo,
+		agentClient: p.AgentClient,
+		agentPort:   p.AgentPort,
+		notifier:    p.Notifier,
+		logger:      p.Logger.With("component", "stats_monitor"),
+		interval:    p.Interval,
+		stopCh:      make(chan struct{}),
+		servers:     make(map[string]*domain.Server),
+		nextDue:     make(map[string]time.Time),
+		windows:     make(map[string]*domain.StatsWindow),
 	}
 }
 
@@ -59,9 +97,9 @@ func (m *StatsMonitor) Stop() {
 func (m *StatsMonitor) run(ctx context.Context) {
 	defer m.wg.Done()
 
-	m.collectAllStats(ctx)
+	m.collectDue(ctx)
 
-	ticker := time.NewTicker(m.interval)
+	ticker := time.NewTicker(statsTick)
 	defer ticker.Stop()
 
 	for {
@@ -71,12 +109,55 @@ func (m *StatsMonitor) run(ctx context.Context) {
 		case <-m.stopCh:
 			return
 		case <-ticker.C:
-			m.collectAllStats(ctx)
+			m.collectDue(ctx)
 		}
 	}
 }
 
-func (m *StatsMonitor) collectAllStats(ctx context.Context) {
+func (m *StatsMonitor) collectDue(ctx context.Context) {
+	if time.Since(m.lastRefresh) >= statsRefreshInterval {
+		m.refresh(ctx)
+	}
+
+	for i := range m.apps {
+		app := &m.apps[i]
+		if app.LastDeployedAt == nil {
+			continue
+		}
+		now := time.Now()
+		if now.Before(m.nextDue[app.ID]) {
+			continue
+		}
+		m.nextDue[app.ID] = now.Add(domain.StatsInterval(app, m.server(app), m.interval))
+
+		stats, ok := m.sample(ctx, app)
+		if !ok {
+			continue
+		}
+
+		window := m.windows[app.ID]
+		if window == nil {
+			window = &domain.StatsWindow{}
+			m.windows[app.ID] = window
+		}
+		window.Add(domain.StatsSample{
+			At:            now,
+			CPUPercent:    stats.CPUPercent,
+			MemoryUsage:   stats.MemoryUsage,
+			MemoryPercent: stats.MemoryPercent,
+		})
+		stats.Averages = make(map[string]domain.StatsAverage, len(statsAverageSpans))
+		for name, span := range statsAverageSpans {
+			stats.Averages[name] = window.Average(now, span)
+		}
+
+		m.notifier.EmitStats(app.ID, stats)
+	}
+}
+
+// refresh reloads the apps and servers. On failure the previous lists are
+// kept and the refresh is tried again on the next tick.
+func (m *StatsMonitor) refresh(ctx context.Context) {
 	var apps []domain.App
 	var err error
 	for attempt := 0; attempt < statsFetchRetries; attempt++ {
@@ -100,18 +181,48 @@ func (m *StatsMonitor) collectAllStats(ctx context.Context) {
 		return
 	}
 
+	if m.serverRepo != nil {
+		servers, err := m.serverRepo.FindAll()
+		if err != nil {
+			m.logger.Warn("Failed to fetch servers for stats collection", "error", err)
+		} else {
+			m.servers = make(map[string]*domain.Server, len(servers))
+			for i := range servers {
+				m.servers[servers[i].ID] = &servers[i]
+			}
+		}
+	}
+
+	present := make(map[string]bool, len(apps))
 	for _, app := range apps {
-		if app.LastDeployedAt == nil {
-			continue
+		present[app.ID] = true
+	}
+	for id := range m.windows {
+		if !present[id] {
+			delete(m.windows, id)
+			delete(m.nextDue, id)
 		}
+	}
 
+	m.apps = apps
+	m.lastRefresh = time.Now()
+}
+
+func (m *StatsMonitor) server(app *domain.App) *domain.Server {
+	if app.ServerID == nil {
+		return nil
+	}
+	return m.servers[*app.ServerID]
+}
+
+func (m *StatsMonitor) sample(ctx context.Context, app *domain.App) (StatsData, bool) {
+	if app.ServerID == nil || *app.ServerID == "" {
 		stats, err := m.docker.ContainerStats(ctx, app.Name)
 		if err != nil {
 			m.logger.Debug("Failed to get container stats", "appName", app.Name, "error", err)
-			continue
+			return StatsData{}, false
 		}
-
-		m.notifier.EmitStats(app.ID, StatsData{
+		return StatsData{
 			CPUPercent:    stats.CPUPercent,
 			MemoryUsage:   stats.MemoryUsage,
 			MemoryLimit:   stats.MemoryLimit,
@@ -119,6 +230,35 @@ func (m *StatsMonitor) collectAllStats(ctx context.Context) {
 			NetworkRx:     stats.NetworkRx,
 			NetworkTx:     stats.NetworkTx,
 			PIDs:          stats.PIDs,
-		})
+		}, true
+	}
+
+	srv := m.server(app)
+	if srv == nil || srv.Status != domain.ServerStatusOnline || m.agentClient == nil || m.agentPort == 0 {
+		return StatsData{}, false
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, statsRemoteTimeout)
+	defer cancel()
+
+	var data StatsData
+	var found bool
+	err := m.agentClient.GetContainerStats(ctx, srv.Host, m.agentPort, app.Name, func(stats *pb.ContainerStats) {
+		found = true
+		data = StatsData{
+			CPUPercent:  stats.CpuPercent,
+			MemoryUsage: stats.MemoryUsageBytes,
+			MemoryLimit: stats.MemoryLimitBytes,
+			NetworkRx:   stats.NetworkRxBytes,
+			NetworkTx:   stats.NetworkTxBytes,
+		}
+		if stats.MemoryLimitBytes > 0 {
+			data.MemoryPercent = float64(stats.MemoryUsageBytes) / float64(stats.MemoryLimitBytes) * 100
+		}
+	})
+	if err != nil || !found {
+		m.logger.Debug("Failed to get remote container stats", "appName", app.Name, "serverId", srv.ID, "error", err)
+		return StatsData{}, false
 	}
+	return data, true
 }
@@ -0,0 +1,248 @@
+package engine
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	pb "github.com/paasdeploy/backend/gen/go/flowdeploy/v1"
+	"github.com/paasdeploy/backend/internal/agentclient"
+	"github.com/paasdeploy/backend/internal/domain"
+	"github.com/paasdeploy/backend/internal/metering"
+	"github.com/paasdeploy/shared/pkg/docker"
+)
+
+const (
+	defaultUsageInterval  = 15 * time.Minute
+	usageStatsTimeout     = 10 * time.Second
+	usageFlushTimeout     = 30 * time.Second
+	maxPendingUsageRecord = 50000
+)
+
+type UsageMeterParams struct {
+	AppRepo        domain.AppRepository
+	DeploymentRepo domain.DeploymentRepository
+	ServerRepo     domain.ServerRepository
+	Docker         *docker.Client
+	AgentClient    *agentclient.AgentClient
+	AgentPort      int
+	Sink           metering.Sink
+	Interval       time.Duration
+	Logger         *slog.Logger
+}
+
+// UsageMeter periodically records the build time, container runtime and
+// network traffic of every app and hands the records to a metering sink.
+// Records that could not be delivered are kept and sent with the next batch.
+type UsageMeter struct {
+	appRepo        domain.AppRepository
+	deploymentRepo domain.DeploymentRepository
+	serverRepo     domain.ServerRepository
+	docker         *docker.Client
+	agentClient    *agentclient.AgentClient
+	agentPort      int
+	sink           metering.Sink
+	logger         *slog.Logger
+	interval       time.Duration
+	stopCh         chan struct{}
+	wg             sync.WaitGroup
+
+	periodStart time.Time
+	// network holds the last rx+tx counter seen per app ID.
+	network map[string]int64
+	pending []domain.UsageRecord
+}
+
+type usageSample struct {
+	running      bool
+	networkBytes int64
+}
+
+func NewUsageMeter(p UsageMeterParams) *UsageMeter {
+	if p.Interval <= 0 {
+		p.Interval = defaultUsageInterval
+	}
+	return &UsageMeter{
+		appRepo:        p.AppRepo,
+		deploymentRepo: p.DeploymentRepo,
+		serverRepo:     p.ServerRepo,
+		docker:         p.Docker,
+		agentClient:    p.AgentClient,
+		agentPort:      p.AgentPort,
+		sink:           p.Sink,
+		logger:         p.Logger.With("component", "usage_meter"),
+		interval:       p.Interval,
+		stopCh:         make(chan struct{}),
+		network:        make(map[string]int64),
+	}
+}
+
+func (m *UsageMeter) Start(ctx context.Context) {
+	m.logger.Info("Starting usage meter", "interval", m.interval)
+	m.periodStart = time.Now().UTC()
+	m.wg.Add(1)
+	go m.run(ctx)
+}
+
+func (m *UsageMeter) Stop() {
+	m.logger.Info("Stopping usage meter")
+	close(m.stopCh)
+	m.wg.Wait()
+	m.logger.Info("Usage meter stopped")
+}
+
+func (m *UsageMeter) run(ctx context.Context) {
+	defer m.wg.Done()
+
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-m.stopCh:
+			flushCtx, cancel := context.WithTimeout(context.Background(), usageFlushTimeout)
+			m.flush(flushCtx)
+			cancel()
+			return
+		case <-ticker.C:
+			m.meter(ctx)
+			m.flush(ctx)
+		}
+	}
+}
+
+// meter records the usage of the period since the last successful run. When
+// the apps or deployments cannot be read the period is extended to the next
+// run instead of being lost.
+func (m *UsageMeter) meter(ctx context.Context) {
+	now := time.Now().UTC()
+
+	apps, err := m.appRepo.FindAll()
+	if err != nil {
+		m.logger.Error("Failed to fetch apps for usage metering", "error", err)
+		return
+	}
+	builds, err := m.deploymentRepo.SumBuildSecondsByApp(m.periodStart, now)
+	if err != nil {
+		m.logger.Error("Failed to sum build time for usage metering", "error", err)
+		return
+	}
+	hosts := m.serverHosts()
+
+	start := m.periodStart
+	m.periodStart = now
+	record := func(app *domain.App, metric domain.UsageMetric, quantity float64) {
+		m.pending = append(m.pending, domain.UsageRecord{
+			ID:          uuid.NewString(),
+			UserID:      app.UserID,
+			AppID:       app.ID,
+			AppName:     app.Name,
+			ServerID:    app.ServerID,
+			Metric:      metric,
+			Quantity:    quantity,
+			PeriodStart: start,
+			PeriodEnd:   now,
+		})
+	}
+
+	for i := range apps {
+		app := &apps[i]
+		if seconds := builds[app.ID]; seconds > 0 {
+			record(app, domain.UsageMetricBuildSeconds, seconds)
+		}
+		if app.LastDeployedAt == nil {
+			continue
+		}
+
+		sample, ok := m.sample(ctx, app, hosts)
+		if !ok {
+			continue
+		}
+		if sample.running {
+			record(app, domain.UsageMetricRuntimeHours, now.Sub(start).Hours())
+		}
+		last, seen := m.network[app.ID]
+		m.network[app.ID] = sample.networkBytes
+		if !seen {
+			continue
+		}
+		// Docker counters restart at zero with the container.
+		delta := sample.networkBytes - last
+		if delta < 0 {
+			delta = sample.networkBytes
+		}
+		if delta > 0 {
+			record(app, domain.UsageMetricNetworkBytes, float64(delta))
+		}
+	}
+}
+
+func (m *UsageMeter) serverHosts() map[string]string {
+	hosts := make(map[string]string)
+	if m.serverRepo == nil {
+		return hosts
+	}
+	servers, err := m.serverRepo.FindAll()
+	if err != nil {
+		m.logger.Warn("Failed to fetch servers for usage metering", "error", err)
+		return hosts
+	}
+	for _, srv := range servers {
+		hosts[srv.ID] = srv.Host
+	}
+	return hosts
+}
+
+func (m *UsageMeter) sample(ctx context.Context, app *domain.App, hosts map[string]string) (usageSample, bool) {
+	ctx, cancel := context.WithTimeout(ctx, usageStatsTimeout)
+	defer cancel()
+
+	if app.ServerID == nil || *app.ServerID == "" {
+		stats, err := m.docker.ContainerStats(ctx, app.Name)
+		if err != nil {
+			m.logger.Debug("Failed to get container stats for usage", "appName", app.Name, "error", err)
+			return usageSample{}, false
+		}
+		return usageSample{running: stats.PIDs > 0, networkBytes: stats.NetworkRx + stats.NetworkTx}, true
+	}
+
+	host, ok := hosts[*app.ServerID]
+	if !ok || m.agentClient == nil || m.agentPort == 0 {
+		return usageSample{}, false
+	}
+	var sample usageSample
+	var found bool
+	err := m.agentClient.GetContainerStats(ctx, host, m.agentPort, app.Name, func(stats *pb.ContainerStats) {
+		found = true
+		sample = usageSample{
+			running:      stats.MemoryUsageBytes > 0,
+			networkBytes: stats.NetworkRxBytes + stats.NetworkTxBytes,
+		}
+	})
+	if err != nil || !found {
+		m.logger.Debug("Failed to get remote container stats for usage", "appName", app.Name, "serverId", *app.ServerID, "error", err)
+		return usageSample{}, false
+	}
+	return sample, true
+}
+
+func (m *UsageMeter) flush(ctx context.Context) {
+	if len(m.pending) == 0 {
+		return
+	}
+	if err := m.sink.Write(ctx, m.pending); err != nil {
+		if len(m.pending) > maxPendingUsageRecord {
+			dropped := len(m.pending) - maxPendingUsageRecord
+			m.pending = m.pending[dropped:]
+			m.logger.Error("Dropped undelivered usage records", "count", dropped)
+		}
+		m.logger.Warn("Failed to deliver usage records, will retry", "count", len(m.pending), "error", err)
+		return
+	}
+	m.logger.Debug("Delivered usage records", "count", len(m.pending))
+	m.pending = nil
+}
@@ -2,10 +2,13 @@ package engine
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -14,16 +17,21 @@ import (
 	"github.com/paasdeploy/backend/internal/agentclient"
 	"github.com/paasdeploy/backend/internal/domain"
 	"github.com/paasdeploy/backend/internal/service"
+	"github.com/paasdeploy/shared/pkg/buildlog"
 	"github.com/paasdeploy/shared/pkg/compose"
 	"github.com/paasdeploy/shared/pkg/docker"
 	"github.com/paasdeploy/shared/pkg/git"
 	"github.com/paasdeploy/shared/pkg/health"
+	"github.com/paasdeploy/shared/pkg/sbom"
+	"github.com/paasdeploy/shared/pkg/tracing"
 	"github.com/paasdeploy/shared/pkg/version"
 )
 
 const (
-	outputChannelBuffer   = 100
-	healthCheckStartDelay = 15 * time.Second
+	outputChannelBuffer = 100
+	// healthCheckStartPeriod is how long a new container may fail health
+	// checks while it boots, unless paasdeploy.json allows longer.
+	healthCheckStartPeriod = 15 * time.Second
 )
 
 type GitTokenProvider interface {
@@ -31,18 +39,28 @@ type GitTokenProvider interface {
 }
 
 type WorkerDeps struct {
-	Git              *git.Client
-	Docker           *docker.Client
+	Git    *git.Client
+	Docker *docker.Client
+	// Builds caps the local image builds running at once; remote servers
+	// enforce their own limit in the agent.
+	Builds *docker.BuildLimiter
+	// SigningKeyPath, when set, signs the images pushed to the registry;
+	// SigningPublicKey, when set, is checked on images pulled from it.
+	SigningKeyPath   string
+	SigningPublicKey string
 	Health           *health.Checker
 	Notifier         Notifier
 	Dispatcher       *Dispatcher
+	AppRepo          domain.AppRepository
 	EnvVarRepo       domain.EnvVarRepository
 	CustomDomainRepo domain.CustomDomainRepository
 	ServerRepo       domain.ServerRepository
+	SBOMRepo         domain.DeploymentSBOMRepository
 	AgentClient      *agentclient.AgentClient
 	AgentPort        int
 	GitTokenProvider GitTokenProvider
 	AuditService     *service.AuditService
+	Quotas           *service.QuotaService
 	Logger           *slog.Logger
 }
 
@@ -63,7 +81,7 @@ func NewWorker(id int, dataDir string, deps WorkerDeps) *Worker {
 }
 
 func (w *Worker) Run(ctx context.Context, deploy *domain.Deployment, app *domain.App) error {
-	w.deps.Logger.Info("Starting deployment",
+	w.deps.Logger.InfoContext(ctx, "Starting deployment",
 		"deployId", deploy.ID,
 		"appName", app.Name,
 		"commitSha", deploy.CommitSHA,
@@ -71,6 +89,12 @@ func (w *Worker) Run(ctx context.Context, deploy *domain.Deployment, app *domain
 		"serverID", app.ServerID,
 	)
 
+	if w.deps.Quotas != nil {
+		if err := w.enforceQuota(deploy, app, w.deps.Quotas.CheckBuildTime(ctx, app)); err != nil {
+			return err
+		}
+	}
+
 	if app.ServerID != nil && *app.ServerID != "" {
 		return w.runRemoteDeploy(ctx, deploy, app)
 	}
@@ -79,7 +103,7 @@ func (w *Worker) Run(ctx context.Context, deploy *domain.Deployment, app *domain
 }
 
 func (w *Worker) runRemoteDeploy(ctx context.Context, deploy *domain.Deployment, app *domain.App) error {
-	w.deps.Notifier.EmitDeployRunning(deploy.ID, app.ID)
+	w.deps.Notifier.EmitDeployRunning(deploy.ID, app.ID, deploy.TraceID)
 	w.log(deploy.ID, app.ID, "Starting remote deployment for %s on server %s", app.Name, *app.ServerID)
 
 	if w.deps.ServerRepo == nil || w.deps.AgentClient == nil {
@@ -95,7 +119,7 @@ func (w *Worker) runRemoteDeploy(ctx context.Context, deploy *domain.Deployment,
 		return w.fail(deploy, app, fmt.Errorf("server %s is not online (status: %s)", server.Name, server.Status))
 	}
 
-	if err := w.loadEnvVars(app.ID); err != nil {
+	if err := w.loadEnvVars(app); err != nil {
 		w.appEnvVars = nil
 		w.deps.Logger.Warn("Failed to load env vars for remote deploy", "error", err, "appId", app.ID)
 	}
@@ -104,7 +128,7 @@ func (w *Worker) runRemoteDeploy(ctx context.Context, deploy *domain.Deployment,
 
 	token := w.getGitToken(ctx, app.RepositoryURL)
 
-	domainRoutes := w.collectDomainRoutes(ctx, app.ID)
+	domainRoutes, redirects := w.collectDomainRoutes(ctx, app)
 	var domains []string
 	for _, d := range domainRoutes {
 		domains = append(domains, d.Domain)
@@ -112,6 +136,10 @@ func (w *Worker) runRemoteDeploy(ctx context.Context, deploy *domain.Deployment,
 
 	defaults := &compose.Config{}
 	compose.ApplyDefaults(defaults)
+	defaults.Resources.Memory, defaults.Resources.CPU = app.Resources().Override(defaults.Resources.Memory, defaults.Resources.CPU)
+	if err := w.reserveMemory(ctx, deploy, app, defaults.Resources.Memory); err != nil {
+		return err
+	}
 
 	appPort := resolvePort(w.appEnvVars, defaults.Port)
 
@@ -130,8 +158,13 @@ func (w *Worker) runRemoteDeploy(ctx context.Context, deploy *domain.Deployment,
 			Context:    defaults.Build.Context,
 		},
 		Runtime: &pb.RuntimeConfig{
-			Port:    int32(appPort),
-			Domains: domains,
+			Port:            int32(appPort),
+			Domains:         domains,
+			DomainRoutes:    toPBDomainRoutes(domainRoutes),
+			RateLimit:       toPBRateLimit(app.RateLimit),
+			Redirects:       toPBRedirects(redirects),
+			SecurityHeaders: toPBSecurityHeaders(app.SecurityHeaders),
+			Internal:        app.Internal,
 			Resources: &pb.ResourceLimits{
 				Memory: defaults.Resources.Memory,
 				Cpu:    defaults.Resources.CPU,
@@ -155,6 +188,7 @@ func (w *Worker) runRemoteDeploy(ctx context.Context, deploy *domain.Deployment,
 	if deploy.PreviousImageTag != "" {
 		req.RollbackImage = &deploy.PreviousImageTag
 	}
+	req.ImageSigningPublicKey = w.deps.SigningPublicKey
 
 	agentPort := w.deps.AgentPort
 	if agentPort == 0 {
@@ -163,9 +197,13 @@ func (w *Worker) runRemoteDeploy(ctx context.Context, deploy *domain.Deployment,
 
 	w.log(deploy.ID, app.ID, "Dispatching deploy to agent at %s:%d", server.Host, agentPort)
 
+	progress := w.startProgress(deploy.ID, app.ID)
+	defer progress.stop()
+
 	onLog := func(entry *pb.DeployLogEntry) {
+		progress.enter(entry.Stage)
 		prefix := formatLogStage(entry.Stage)
-		w.log(deploy.ID, app.ID, "%s %s", prefix, entry.Message)
+		w.emitLog(deploy.ID, app.ID, prefix+" "+entry.Message, logKindFromProto(entry.Kind))
 	}
 
 	resp, err := w.deps.AgentClient.ExecuteDeployWithLogs(ctx, server.Host, agentPort, req, onLog)
@@ -182,16 +220,40 @@ func (w *Worker) runRemoteDeploy(ctx context.Context, deploy *domain.Deployment,
 	}
 
 	imageTag, appVersion, remoteRuntime := extractDeployResult(resp)
+	if timings := resp.GetResult().GetStageTimings(); len(timings) > 0 {
+		w.log(deploy.ID, app.ID, "Stage timings: %s", formatStageTimings(timings))
+		w.saveStageTimings(deploy, timings)
+	}
+	if digest := resp.GetResult().GetImageDigest(); digest != "" && w.deps.SigningKeyPath != "" {
+		w.signImage(ctx, deploy, app, digest)
+	}
+	if compressed := resp.GetResult().GetSbom(); len(compressed) > 0 {
+		if document, err := sbom.Decompress(compressed); err != nil {
+			w.log(deploy.ID, app.ID, "Warning: invalid SBOM from agent: %v", err)
+		} else {
+			w.saveSBOM(deploy, app, document)
+		}
+	}
+
+	deployedConfig := defaults
+	if data := resp.GetResult().GetConfig(); len(data) > 0 {
+		deployedConfig = &compose.Config{}
+		if err := json.Unmarshal(data, deployedConfig); err != nil {
+			w.deps.Logger.Warn("Invalid deploy config from agent", "deployId", deploy.ID, "error", err)
+			deployedConfig = defaults
+		}
+	}
+	w.saveSnapshot(ctx, deploy, app, deployedConfig, resp.GetResult().GetImageSize())
 
 	w.log(deploy.ID, app.ID, "Remote deployment completed successfully")
 	return w.success(deploy, app, imageTag, appVersion, remoteRuntime)
 }
 
 func (w *Worker) runLocalDeploy(ctx context.Context, deploy *domain.Deployment, app *domain.App) error {
-	w.deps.Notifier.EmitDeployRunning(deploy.ID, app.ID)
+	w.deps.Notifier.EmitDeployRunning(deploy.ID, app.ID, deploy.TraceID)
 	w.log(deploy.ID, app.ID, "Starting deployment for %s", app.Name)
 
-	if err := w.loadEnvVars(app.ID); err != nil {
+	if err := w.loadEnvVars(app); err != nil {
 		w.appEnvVars = nil
 		w.deps.Logger.Warn("Failed to load env vars", "error", err, "appId", app.ID)
 		w.log(deploy.ID, app.ID, "Warning: could not load env vars from configuration (deploy will use paasdeploy.json env only)")
@@ -201,24 +263,73 @@ func (w *Worker) runLocalDeploy(ctx context.Context, deploy *domain.Deployment,
 
 	repoDir := filepath.Join(w.dataDir, app.ID)
 	appDir := w.getAppDir(repoDir, app.Workdir)
+	imageTag := w.deps.Docker.GetImageTag(app.Name, deploy.CommitSHA)
+	timer := newStageTimer()
+	progress := w.startProgress(deploy.ID, app.ID)
+	defer progress.stop()
+
+	prepareCtx, cancelPrepare := context.WithCancel(ctx)
+	prepared := w.prepare(prepareCtx, deploy, app, appDir, imageTag, timer)
+	var pushed <-chan struct{}
+	defer func() {
+		cancelPrepare()
+		<-prepared.done
+		if pushed != nil {
+			<-pushed
+		}
+	}()
 
-	if err := w.syncGit(ctx, deploy, app, repoDir); err != nil {
+	progress.enter(pb.DeployStage_DEPLOY_STAGE_GIT_SYNC)
+	stopGitSync := timer.track(pb.DeployStage_DEPLOY_STAGE_GIT_SYNC)
+	err := w.syncGit(ctx, deploy, app, repoDir)
+	stopGitSync()
+	if err != nil {
 		return w.fail(deploy, app, fmt.Errorf("git sync failed: %w", err))
 	}
 
-	if err := w.loadConfig(appDir); err != nil {
+	if err := w.loadConfig(appDir, app); err != nil {
 		return w.fail(deploy, app, fmt.Errorf("failed to load paasdeploy.json: %w", err))
 	}
+	if source := w.deployConfig.PortSource; source != "" {
+		w.log(deploy.ID, app.ID, "Port not set in paasdeploy config, using %d (%s)", w.deployConfig.Port, source)
+	}
+	if missing := w.deployConfig.Interpolate(w.appEnvVars); len(missing) > 0 {
+		w.log(deploy.ID, app.ID, "Warning: paasdeploy config references unset variable(s): %s", strings.Join(missing, ", "))
+	}
 
-	w.capturePreviousImage(ctx, deploy, app)
+	if err := w.reserveMemory(ctx, deploy, app, w.deployConfig.Resources.Memory); err != nil {
+		return err
+	}
 
-	imageTag := w.deps.Docker.GetImageTag(app.Name, deploy.CommitSHA)
+	// The build pulls missing base images itself and the rollbacks below
+	// need the previous image, so both wait for the prepare step.
+	<-prepared.done
 
-	if err := w.buildDocker(ctx, deploy, app, appDir, imageTag); err != nil {
-		return w.fail(deploy, app, fmt.Errorf("docker build failed: %w", err))
+	if prepared.imageReady {
+		w.log(deploy.ID, app.ID, "Image %s already exists, skipping build", imageTag)
+	} else {
+		if err := w.acquireBuildSlot(ctx, deploy, app); err != nil {
+			return w.fail(deploy, app, err)
+		}
+		progress.enter(pb.DeployStage_DEPLOY_STAGE_BUILD)
+		stopBuild := timer.track(pb.DeployStage_DEPLOY_STAGE_BUILD)
+		err = w.buildDocker(ctx, deploy, app, appDir, imageTag)
+		stopBuild()
+		w.deps.Builds.Release()
+		if err != nil {
+			return w.fail(deploy, app, fmt.Errorf("docker build failed: %w", err))
+		}
+		if w.deps.Docker.HasRegistry() {
+			pushed = w.push(ctx, deploy, app, imageTag, timer)
+		}
 	}
+	sbomDoc := w.generateSBOM(ctx, deploy, app, imageTag)
 
-	if err := w.deployContainer(ctx, deploy, app, appDir); err != nil {
+	progress.enter(pb.DeployStage_DEPLOY_STAGE_DEPLOY)
+	stopDeploy := timer.track(pb.DeployStage_DEPLOY_STAGE_DEPLOY)
+	err = w.deployContainer(ctx, deploy, app, appDir)
+	stopDeploy()
+	if err != nil {
 		w.log(deploy.ID, app.ID, "Deploy failed, attempting rollback...")
 		if rollbackErr := w.rollback(ctx, deploy, app, appDir); rollbackErr != nil {
 			w.deps.Logger.Error("Rollback failed", "error", rollbackErr)
@@ -226,7 +337,11 @@ func (w *Worker) runLocalDeploy(ctx context.Context, deploy *domain.Deployment,
 		return w.fail(deploy, app, fmt.Errorf("container deploy failed: %w", err))
 	}
 
-	if err := w.checkHealth(ctx, deploy, app); err != nil {
+	progress.enter(pb.DeployStage_DEPLOY_STAGE_HEALTH_CHECK)
+	stopHealth := timer.track(pb.DeployStage_DEPLOY_STAGE_HEALTH_CHECK)
+	err = w.checkHealth(ctx, deploy, app)
+	stopHealth()
+	if err != nil {
 		w.log(deploy.ID, app.ID, "Health check failed, attempting rollback...")
 		if rollbackErr := w.rollback(ctx, deploy, app, appDir); rollbackErr != nil {
 			w.deps.Logger.Error("Rollback failed", "error", rollbackErr)
@@ -235,10 +350,169 @@ func (w *Worker) runLocalDeploy(ctx context.Context, deploy *domain.Deployment,
 	}
 
 	appVersion := version.DetectAppVersion(w.deployConfig.Runtime, appDir)
+	if pushed != nil {
+		<-pushed
+	}
+	timings := timer.list()
+	w.log(deploy.ID, app.ID, "Stage timings: %s", formatStageTimings(timings))
+	w.saveStageTimings(deploy, timings)
+	if document := <-sbomDoc; document != nil {
+		w.saveSBOM(deploy, app, document)
+	}
+	imageSize, err := w.deps.Docker.ImageSize(ctx, imageTag)
+	if err != nil {
+		w.deps.Logger.Warn("Failed to read image size", "deployId", deploy.ID, "error", err)
+	}
+	w.saveSnapshot(ctx, deploy, app, w.deployConfig, imageSize)
 
 	return w.success(deploy, app, imageTag, appVersion, w.deployConfig.Runtime)
 }
 
+// preparation is the outcome of prepare, to be read once done is closed.
+type preparation struct {
+	done chan struct{}
+	// imageReady is set when the image of the commit already exists,
+	// locally or in the registry, so the build can be skipped.
+	imageReady bool
+}
+
+// prepare runs the steps that do not depend on the new commit while the
+// repository syncs: capturing the image to roll back to, ensuring the app
+// network and looking for an existing image of the commit, as left by a
+// retry. Without one it pulls the base images of the cached checkout, which
+// usually match those of the new commit.
+func (w *Worker) prepare(ctx context.Context, deploy *domain.Deployment, app *domain.App, appDir, imageTag string, timer *stageTimer) *preparation {
+	// Read before the sync starts, which moves the checkout.
+	images := cachedBaseImages(appDir)
+
+	p := &preparation{done: make(chan struct{})}
+	go func() {
+		defer close(p.done)
+		defer timer.track(pb.DeployStage_DEPLOY_STAGE_INITIALIZING)()
+
+		w.capturePreviousImage(ctx, deploy, app)
+
+		if err := w.deps.Docker.EnsureNetwork(ctx, docker.DefaultNetworkName); err != nil {
+			w.deps.Logger.Warn("Failed to ensure network ahead of deploy", "error", err, "appId", app.ID)
+		}
+
+		ready, err := w.deps.Docker.ImageAvailable(ctx, imageTag, w.deps.SigningPublicKey)
+		if err != nil {
+			w.log(deploy.ID, app.ID, "Warning: discarded image %s pulled from the registry, building from source instead: %v", imageTag, err)
+		}
+		if ready {
+			p.imageReady = true
+			return
+		}
+
+		if len(images) == 0 {
+			return
+		}
+		w.log(deploy.ID, app.ID, "Preparing base images: %s", strings.Join(images, ", "))
+		if err := w.deps.Docker.EnsureImages(ctx, images); err != nil {
+			w.deps.Logger.Warn("Failed to pull base images ahead of build", "error", err, "appId", app.ID)
+		}
+	}()
+	return p
+}
+
+// push publishes a freshly built image to the registry while the container
+// is deployed, so servers rolling out the same commit can skip their build.
+func (w *Worker) push(ctx context.Context, deploy *domain.Deployment, app *domain.App, imageTag string, timer *stageTimer) <-chan struct{} {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer timer.track(pb.DeployStage_DEPLOY_STAGE_PUSH)()
+
+		if err := w.deps.Docker.Push(ctx, imageTag); err != nil {
+			w.log(deploy.ID, app.ID, "Warning: failed to push image %s: %v", imageTag, err)
+			return
+		}
+		w.log(deploy.ID, app.ID, "Pushed image %s", imageTag)
+
+		if w.deps.SigningKeyPath == "" {
+			return
+		}
+		digest, err := w.deps.Docker.RepoDigest(ctx, imageTag)
+		if err != nil {
+			w.log(deploy.ID, app.ID, "Warning: image %s was not signed: %v", imageTag, err)
+			return
+		}
+		w.signImage(ctx, deploy, app, digest)
+	}()
+	return done
+}
+
+// signImage signs an image pushed to the registry with the platform key,
+// so servers that pull it can check it was built by the platform.
+func (w *Worker) signImage(ctx context.Context, deploy *domain.Deployment, app *domain.App, digest string) {
+	if err := w.deps.Docker.SignImage(ctx, digest, w.deps.SigningKeyPath); err != nil {
+		w.log(deploy.ID, app.ID, "Warning: failed to sign image %s: %v", digest, err)
+		return
+	}
+	w.log(deploy.ID, app.ID, "Signed image %s", digest)
+}
+
+// generateSBOM lists the packages of the image while the container is
+// deployed. The channel yields the document, or nothing when it could not
+// be generated, which does not fail the deploy.
+func (w *Worker) generateSBOM(ctx context.Context, deploy *domain.Deployment, app *domain.App, imageTag string) <-chan []byte {
+	result := make(chan []byte, 1)
+	go func() {
+		defer close(result)
+
+		document, err := w.deps.Docker.GenerateSBOM(ctx, imageTag)
+		if err != nil {
+			w.log(deploy.ID, app.ID, "Warning: failed to generate SBOM for %s: %v", imageTag, err)
+			return
+		}
+		result <- document
+	}()
+	return result
+}
+
+// saveSBOM stores the SBOM of a successful deployment. Failures are only
+// logged since the deployment itself succeeded.
+func (w *Worker) saveSBOM(deploy *domain.Deployment, app *domain.App, document []byte) {
+	if w.deps.SBOMRepo == nil {
+		return
+	}
+	packages, err := sbom.Packages(document)
+	if err != nil {
+		w.log(deploy.ID, app.ID, "Warning: %v", err)
+		return
+	}
+	err = w.deps.SBOMRepo.Save(domain.DeploymentSBOM{
+		DeploymentID: deploy.ID,
+		Format:       sbom.Format,
+		Document:     document,
+		PackageCount: len(packages),
+	})
+	if err != nil {
+		w.deps.Logger.Error("Failed to save SBOM", "deployId", deploy.ID, "error", err)
+		return
+	}
+	w.log(deploy.ID, app.ID, "SBOM generated: %d package(s)", len(packages))
+}
+
+// cachedBaseImages returns the base images of the Dockerfile left by the
+// previous deploy, or nil on a first deploy.
+func cachedBaseImages(appDir string) []string {
+	cfg, err := compose.LoadConfig(appDir)
+	if err != nil {
+		return nil
+	}
+	dockerfile, err := compose.SafeJoin(appDir, cfg.Build.Dockerfile)
+	if err != nil {
+		return nil
+	}
+	images, err := docker.BaseImages(dockerfile)
+	if err != nil {
+		return nil
+	}
+	return images
+}
+
 func (w *Worker) getAppDir(repoDir, workdir string) string {
 	safe, err := compose.SafeJoin(repoDir, workdir)
 	if err != nil {
@@ -275,8 +549,8 @@ func (w *Worker) capturePreviousImage(ctx context.Context, deploy *domain.Deploy
 	w.log(deploy.ID, app.ID, "Captured previous image for rollback (from DB): %s", tag)
 }
 
-func (w *Worker) loadEnvVars(appID string) error {
-	vars, err := w.deps.EnvVarRepo.FindByAppID(appID)
+func (w *Worker) loadEnvVars(app *domain.App) error {
+	vars, err := w.deps.EnvVarRepo.FindByAppID(app.ID)
 	if err != nil {
 		return err
 	}
@@ -285,6 +559,7 @@ func (w *Worker) loadEnvVars(appID string) error {
 	for _, v := range vars {
 		w.appEnvVars[v.Key] = v.Value
 	}
+	w.appEnvVars = addLinkEnvVars(w.deps.AppRepo, w.deps.EnvVarRepo, app, w.appEnvVars)
 
 	return nil
 }
@@ -333,11 +608,14 @@ func (w *Worker) getGitToken(ctx context.Context, repoURL string) string {
 	return token
 }
 
-func (w *Worker) loadConfig(appDir string) error {
+// loadConfig reads paasdeploy.json, with the resource limits set on the app
+// taking precedence over the file.
+func (w *Worker) loadConfig(appDir string, app *domain.App) error {
 	cfg, err := compose.LoadConfig(appDir)
 	if err != nil {
 		return err
 	}
+	cfg.Resources.Memory, cfg.Resources.CPU = app.Resources().Override(cfg.Resources.Memory, cfg.Resources.CPU)
 
 	if err := compose.ValidateDockerfile(appDir, cfg); err != nil {
 		return err
@@ -347,6 +625,19 @@ func (w *Worker) loadConfig(appDir string) error {
 	return nil
 }
 
+// acquireBuildSlot waits until fewer builds than the host's limit are
+// running, so parallel deploys queue up instead of running it out of memory.
+func (w *Worker) acquireBuildSlot(ctx context.Context, deploy *domain.Deployment, app *domain.App) error {
+	if w.deps.Builds.TryAcquire() {
+		return nil
+	}
+	w.log(deploy.ID, app.ID, "Waiting for a build slot (all %d build slots on this server are busy)", w.deps.Builds.Limit())
+	if err := w.deps.Builds.Acquire(ctx); err != nil {
+		return fmt.Errorf("waiting for a build slot: %w", err)
+	}
+	return nil
+}
+
 func (w *Worker) buildDocker(ctx context.Context, deploy *domain.Deployment, app *domain.App, repoDir, imageTag string) error {
 	w.log(deploy.ID, app.ID, "Building Docker image: %s", imageTag)
 
@@ -362,7 +653,7 @@ func (w *Worker) buildDocker(ctx context.Context, deploy *domain.Deployment, app
 	output := make(chan string, outputChannelBuffer)
 	go func() {
 		for line := range output {
-			w.log(deploy.ID, app.ID, "[build] %s", line)
+			w.logOutput(deploy.ID, app.ID, "[build]", line)
 		}
 	}()
 
@@ -390,14 +681,18 @@ func (w *Worker) deployContainer(ctx context.Context, deploy *domain.Deployment,
 	}
 
 	imageTag := w.deps.Docker.GetImageTag(app.Name, deploy.CommitSHA)
-	domainRoutes := w.collectDomainRoutes(ctx, app.ID)
+	domainRoutes, redirects := w.collectDomainRoutes(ctx, app)
 
 	if err := compose.WriteComposeFile(appDir, compose.GenerateParams{
-		AppName:  app.Name,
-		ImageTag: imageTag,
-		Config:   w.deployConfig,
-		Domains:  domainRoutes,
-		EnvVars:  w.appEnvVars,
+		AppName:   app.Name,
+		ImageTag:  imageTag,
+		Config:    w.deployConfig,
+		Domains:   domainRoutes,
+		EnvVars:   w.appEnvVars,
+		RateLimit: toComposeRateLimit(app.RateLimit),
+		Redirects: toComposeRedirects(redirects),
+		Headers:   toComposeSecurityHeaders(app.SecurityHeaders),
+		Internal:  app.Internal,
 	}); err != nil {
 		return fmt.Errorf("failed to generate docker-compose.yml: %w", err)
 	}
@@ -405,7 +700,7 @@ func (w *Worker) deployContainer(ctx context.Context, deploy *domain.Deployment,
 	output := make(chan string, outputChannelBuffer)
 	go func() {
 		for line := range output {
-			w.log(deploy.ID, app.ID, "[deploy] %s", line)
+			w.logOutput(deploy.ID, app.ID, "[deploy]", line)
 		}
 	}()
 
@@ -419,43 +714,49 @@ func (w *Worker) deployContainer(ctx context.Context, deploy *domain.Deployment,
 	return nil
 }
 
-func (w *Worker) collectDomainRoutes(ctx context.Context, appID string) []compose.DomainRoute {
-	var domainRoutes []compose.DomainRoute
+// collectDomainRoutes returns the app's routes and its redirects, including
+// the secondary names of apex/www pairs, which redirect instead of routing.
+func (w *Worker) collectDomainRoutes(ctx context.Context, app *domain.App) ([]compose.DomainRoute, []domain.AppRedirect) {
+	var configRoutes, customRoutes []compose.DomainRoute
+	redirects := app.Redirects
 
 	if w.deployConfig != nil {
-		for _, d := range w.deployConfig.Domains {
-			domainRoutes = append(domainRoutes, compose.DomainRoute{Domain: d})
-		}
+		var configRedirects []compose.Redirect
+		configRoutes, configRedirects = w.deployConfig.DomainRoutes()
+		redirects = append(fromComposeRedirects(configRedirects), redirects...)
 	}
 
 	if w.deps.CustomDomainRepo != nil {
-		customDomains, err := w.deps.CustomDomainRepo.FindByAppID(ctx, appID)
+		customDomains, err := w.deps.CustomDomainRepo.FindByAppID(ctx, app.ID)
 		if err == nil {
+			redirects = append(domain.CustomDomainRedirects(customDomains), redirects...)
 			for _, d := range customDomains {
-				domainRoutes = append(domainRoutes, compose.DomainRoute{
-					Domain:     d.Domain,
-					PathPrefix: d.PathPrefix,
+				if d.IsRedirect() {
+					continue
+				}
+				customRoutes = append(customRoutes, compose.DomainRoute{
+					Domain:         d.Domain,
+					PathPrefix:     d.PathPrefix,
+					BasicAuthUsers: d.BasicAuthEntries(),
+					IPAllowlist:    d.IPAllowlistEntries(),
 				})
 			}
 		}
 	}
 
-	return domainRoutes
+	return compose.MergeDomainRoutes(configRoutes, customRoutes), redirects
 }
 
 func (w *Worker) checkHealth(ctx context.Context, deploy *domain.Deployment, app *domain.App) error {
 	w.log(deploy.ID, app.ID, "Performing health check...")
 
-	startDelay := healthCheckStartDelay
+	startPeriod := healthCheckStartPeriod
 	if w.deployConfig.Healthcheck.StartPeriod != "" {
-		if parsed, err := time.ParseDuration(w.deployConfig.Healthcheck.StartPeriod); err == nil && parsed > startDelay {
-			startDelay = parsed
+		if parsed, err := time.ParseDuration(w.deployConfig.Healthcheck.StartPeriod); err == nil && parsed > startPeriod {
+			startPeriod = parsed
 		}
 	}
 
-	w.log(deploy.ID, app.ID, "Waiting %s for container to be ready...", startDelay)
-	time.Sleep(startDelay)
-
 	scheme := "http"
 	if w.deployConfig.Healthcheck.TLS {
 		scheme = "https"
@@ -476,7 +777,8 @@ func (w *Worker) checkHealth(ctx context.Context, deploy *domain.Deployment, app
 		w.log(deploy.ID, app.ID, "Health check URL: %s", healthURL)
 	}
 
-	if err := w.deps.Health.CheckWithBackoff(ctx, healthURL); err != nil {
+	w.log(deploy.ID, app.ID, "Waiting up to %s for the container to become ready...", startPeriod)
+	if err := w.deps.Health.WaitReady(ctx, healthURL, startPeriod); err != nil {
 		return err
 	}
 
@@ -492,13 +794,17 @@ func (w *Worker) rollback(ctx context.Context, deploy *domain.Deployment, app *d
 
 	w.log(deploy.ID, app.ID, "Rolling back to: %s", deploy.PreviousImageTag)
 
-	domainRoutes := w.collectDomainRoutes(ctx, app.ID)
+	domainRoutes, redirects := w.collectDomainRoutes(ctx, app)
 	if err := compose.WriteComposeFile(appDir, compose.GenerateParams{
-		AppName:  app.Name,
-		ImageTag: deploy.PreviousImageTag,
-		Config:   w.deployConfig,
-		Domains:  domainRoutes,
-		EnvVars:  w.appEnvVars,
+		AppName:   app.Name,
+		ImageTag:  deploy.PreviousImageTag,
+		Config:    w.deployConfig,
+		Domains:   domainRoutes,
+		EnvVars:   w.appEnvVars,
+		RateLimit: toComposeRateLimit(app.RateLimit),
+		Redirects: toComposeRedirects(redirects),
+		Headers:   toComposeSecurityHeaders(app.SecurityHeaders),
+		Internal:  app.Internal,
 	}); err != nil {
 		w.deps.Logger.Error("Rollback compose generation failed", "error", err)
 		return fmt.Errorf("rollback compose generation failed: %w", err)
@@ -518,7 +824,7 @@ func (w *Worker) success(deploy *domain.Deployment, app *domain.App, imageTag, a
 
 	if w.deps.AuditService != nil {
 		auditCtx := service.AuditContext{}
-		w.deps.AuditService.LogDeploySuccess(context.Background(), auditCtx, deploy.ID, app.ID, app.Name)
+		w.deps.AuditService.LogDeploySuccess(tracing.WithID(context.Background(), deploy.TraceID), auditCtx, deploy.ID, app.ID, app.Name)
 	}
 
 	if err := w.deps.Dispatcher.MarkSuccess(deploy.ID, imageTag, appVersion); err != nil {
@@ -543,11 +849,56 @@ func (w *Worker) success(deploy *domain.Deployment, app *domain.App, imageTag, a
 
 	go w.cleanupOldImages(deploy)
 
-	w.deps.Notifier.EmitDeploySuccess(deploy.ID, app.ID)
+	w.deps.Notifier.EmitDeploySuccess(deploy.ID, app.ID, deploy.TraceID)
 
 	return nil
 }
 
+// saveStageTimings stores the stage timings of a successful deployment, from
+// which the progress of the app's next deployments is estimated.
+func (w *Worker) saveStageTimings(deploy *domain.Deployment, timings []*pb.StageTiming) {
+	if err := w.deps.Dispatcher.SetStageTimings(deploy.ID, timings); err != nil {
+		w.deps.Logger.Warn("Failed to save stage timings", "deployId", deploy.ID, "error", err)
+	}
+}
+
+// saveSnapshot records the env vars, configuration and image size the
+// deployment ran with, so it can be compared with other deployments.
+func (w *Worker) saveSnapshot(ctx context.Context, deploy *domain.Deployment, app *domain.App, cfg *compose.Config, imageSize int64) {
+	env := make(map[string]string, len(cfg.Env)+len(w.appEnvVars))
+	for key, value := range cfg.Env {
+		env[key] = domain.EnvFingerprint(app.ID, value)
+	}
+	for key, value := range w.appEnvVars {
+		env[key] = domain.EnvFingerprint(app.ID, value)
+	}
+
+	domainRoutes, _ := w.collectDomainRoutes(ctx, app)
+	domains := make([]string, 0, len(domainRoutes))
+	for _, route := range domainRoutes {
+		domains = append(domains, route.Domain)
+	}
+	sort.Strings(domains)
+
+	snapshot := domain.DeploymentSnapshot{
+		Env: env,
+		Config: domain.DeploymentConfig{
+			Runtime:         cfg.Runtime,
+			Port:            resolvePort(w.appEnvVars, cfg.Port),
+			Memory:          cfg.Resources.Memory,
+			CPU:             cfg.Resources.CPU,
+			Domains:         domains,
+			Dockerfile:      cfg.Build.Dockerfile,
+			BuildTarget:     cfg.Build.Target,
+			HealthcheckPath: cfg.Healthcheck.Path,
+		},
+		ImageSize: imageSize,
+	}
+	if err := w.deps.Dispatcher.SetSnapshot(deploy.ID, snapshot); err != nil {
+		w.deps.Logger.Warn("Failed to save deployment snapshot", "deployId", deploy.ID, "error", err)
+	}
+}
+
 func extractDeployResult(resp *pb.DeployResponse) (imageTag, appVersion, runtime string) {
 	if resp.Result == nil {
 		return "", "", ""
@@ -577,39 +928,87 @@ func (w *Worker) cleanupOldImages(deploy *domain.Deployment) {
 	}
 }
 
+// reserveMemory records the memory limit the app will run with against its
+// owner's memory quota.
+func (w *Worker) reserveMemory(ctx context.Context, deploy *domain.Deployment, app *domain.App, memory string) error {
+	if w.deps.Quotas == nil {
+		return nil
+	}
+	return w.enforceQuota(deploy, app, w.deps.Quotas.ReserveMemory(ctx, app, memory))
+}
+
+// enforceQuota fails the deploy when a quota is exceeded. Other errors only
+// mean the usage could not be read, which should not block deploys.
+func (w *Worker) enforceQuota(deploy *domain.Deployment, app *domain.App, err error) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, domain.ErrQuotaExceeded) {
+		return w.fail(deploy, app, err)
+	}
+	w.deps.Logger.Warn("Failed to check quota", "deployId", deploy.ID, "appId", app.ID, "error", err)
+	return nil
+}
+
 func (w *Worker) fail(deploy *domain.Deployment, app *domain.App, err error) error {
 	w.log(deploy.ID, app.ID, "Deployment failed: %s", err.Error())
 
 	if w.deps.AuditService != nil {
 		auditCtx := service.AuditContext{}
-		w.deps.AuditService.LogDeployFailed(context.Background(), auditCtx, deploy.ID, app.ID, app.Name, err.Error())
+		w.deps.AuditService.LogDeployFailed(tracing.WithID(context.Background(), deploy.TraceID), auditCtx, deploy.ID, app.ID, app.Name, err.Error())
 	}
 
 	if markErr := w.deps.Dispatcher.MarkFailed(deploy.ID, err.Error()); markErr != nil {
 		w.deps.Logger.Error("Failed to mark deploy as failed", "error", markErr)
 	}
 
-	w.deps.Notifier.EmitDeployFailed(deploy.ID, app.ID, err.Error())
+	w.deps.Notifier.EmitDeployFailed(deploy.ID, app.ID, deploy.TraceID, err.Error())
 
 	return err
 }
 
 func (w *Worker) log(deployID, appID, format string, args ...interface{}) {
-	message := fmt.Sprintf(format, args...)
+	w.emitLog(deployID, appID, fmt.Sprintf(format, args...), buildlog.KindOutput)
+}
+
+// logOutput logs a line printed by docker build or docker compose, without
+// its escape sequences and tagged with its kind.
+func (w *Worker) logOutput(deployID, appID, prefix, line string) {
+	text, kind := buildlog.Parse(line)
+	w.emitLog(deployID, appID, prefix+" "+text, kind)
+}
+
+func (w *Worker) emitLog(deployID, appID, message string, kind buildlog.Kind) {
 	timestamp := time.Now().Format("15:04:05")
 	logLine := fmt.Sprintf("[%s] %s", timestamp, message)
 
 	w.deps.Dispatcher.AppendLogs(deployID, logLine+"\n")
-	w.deps.Notifier.EmitLog(deployID, appID, logLine)
+	w.deps.Notifier.EmitLog(deployID, appID, logLine, kind)
 	w.deps.Logger.Info(message, "deployId", deployID, "appId", appID)
 }
 
+func logKindFromProto(kind pb.DeployLogKind) buildlog.Kind {
+	switch kind {
+	case pb.DeployLogKind_DEPLOY_LOG_KIND_STEP:
+		return buildlog.KindStep
+	case pb.DeployLogKind_DEPLOY_LOG_KIND_WARNING:
+		return buildlog.KindWarning
+	case pb.DeployLogKind_DEPLOY_LOG_KIND_ERROR:
+		return buildlog.KindError
+	}
+	return buildlog.KindOutput
+}
+
 func formatLogStage(stage pb.DeployStage) string {
 	switch stage {
+	case pb.DeployStage_DEPLOY_STAGE_INITIALIZING:
+		return "[prepare]"
 	case pb.DeployStage_DEPLOY_STAGE_GIT_SYNC:
 		return "[git]"
 	case pb.DeployStage_DEPLOY_STAGE_BUILD:
 		return "[build]"
+	case pb.DeployStage_DEPLOY_STAGE_PUSH:
+		return "[push]"
 	case pb.DeployStage_DEPLOY_STAGE_DEPLOY:
 		return "[deploy]"
 	case pb.DeployStage_DEPLOY_STAGE_HEALTH_CHECK:
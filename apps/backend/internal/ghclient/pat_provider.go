@@ -183,23 +183,61 @@ func (p *PATProvider) ListCommits(ctx context.Context, owner, repo, branch strin
 		return nil, fmt.Errorf(errUnexpectedStatus, resp.StatusCode, string(respBody))
 	}
 
-	var apiCommits []struct {
-		SHA    string `json:"sha"`
-		Commit struct {
-			Message string `json:"message"`
-			Author  struct {
-				Name  string    `json:"name"`
-				Email string    `json:"email"`
-				Date  time.Time `json:"date"`
-			} `json:"author"`
-		} `json:"commit"`
-		HTMLURL string `json:"html_url"`
+	var apiCommits []apiCommit
+	if err := json.NewDecoder(resp.Body).Decode(&apiCommits); err != nil {
+		return nil, fmt.Errorf(errDecodeResponse, err)
 	}
 
-	if err := json.NewDecoder(resp.Body).Decode(&apiCommits); err != nil {
+	return toCommitInfos(apiCommits), nil
+}
+
+// CompareCommits returns the commits reachable from head but not from base,
+// oldest first. GitHub lists at most 250 of them.
+func (p *PATProvider) CompareCommits(ctx context.Context, owner, repo, base, head string) ([]CommitInfo, error) {
+	url := fmt.Sprintf("%s/repos/%s/%s/compare/%s...%s", p.baseURL, owner, repo, base, head)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf(errCreateRequest, err)
+	}
+
+	p.setHeaders(req)
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf(errSendRequest, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf(errUnexpectedStatus, resp.StatusCode, string(respBody))
+	}
+
+	var comparison struct {
+		Commits []apiCommit `json:"commits"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&comparison); err != nil {
 		return nil, fmt.Errorf(errDecodeResponse, err)
 	}
 
+	return toCommitInfos(comparison.Commits), nil
+}
+
+type apiCommit struct {
+	SHA    string `json:"sha"`
+	Commit struct {
+		Message string `json:"message"`
+		Author  struct {
+			Name  string    `json:"name"`
+			Email string    `json:"email"`
+			Date  time.Time `json:"date"`
+		} `json:"author"`
+	} `json:"commit"`
+	HTMLURL string `json:"html_url"`
+}
+
+func toCommitInfos(apiCommits []apiCommit) []CommitInfo {
 	commits := make([]CommitInfo, len(apiCommits))
 	for i, c := range apiCommits {
 		commits[i] = CommitInfo{
@@ -210,8 +248,7 @@ func (p *PATProvider) ListCommits(ctx context.Context, owner, repo, branch strin
 			URL:     c.HTMLURL,
 		}
 	}
-
-	return commits, nil
+	return commits
 }
 
 func (p *PATProvider) setHeaders(req *http.Request) {
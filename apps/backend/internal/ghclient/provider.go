@@ -35,4 +35,5 @@ type Provider interface {
 	GetWebhook(ctx context.Context, owner, repo string, webhookID int64) (*Webhook, error)
 	ListWebhooks(ctx context.Context, owner, repo string) ([]Webhook, error)
 	ListCommits(ctx context.Context, owner, repo, branch string, perPage int) ([]CommitInfo, error)
+	CompareCommits(ctx context.Context, owner, repo, base, head string) ([]CommitInfo, error)
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/paasdeploy/backend/internal/domain"
 	"github.com/paasdeploy/backend/internal/response"
+	"github.com/paasdeploy/shared/pkg/tracing"
 )
 
 type WebhookPayloadStore interface {
@@ -26,8 +27,16 @@ const (
 	EventPing = "ping"
 
 	RefPrefix = "refs/heads/"
+
+	// OutcomeInvalidSignature marks stored payloads that failed signature
+	// validation. They were never trusted and cannot be replayed.
+	OutcomeInvalidSignature = "invalid_signature"
 )
 
+// ErrReplayNotSupported is returned when a stored delivery is not a valid push
+// that passed signature validation.
+var ErrReplayNotSupported = errors.New("only verified push deliveries can be replayed")
+
 type AppFinder interface {
 	FindByRepoURL(repoURL string) (*domain.App, error)
 	FindAllByRepoURL(repoURL string) ([]domain.App, error)
@@ -41,11 +50,18 @@ type DeployAuditLogger interface {
 	LogDeployStarted(ctx context.Context, deployID, appID, appName, commitSHA string)
 }
 
+// PushListener is told about every branch push before apps are deployed.
+// It reports whether the push concerned it.
+type PushListener interface {
+	HandlePush(repoURLs []string, branch string) bool
+}
+
 type WebhookHandler struct {
 	appFinder         AppFinder
 	deploymentCreator DeploymentCreator
 	deployAudit       DeployAuditLogger
 	payloadStore      WebhookPayloadStore
+	pushListener      PushListener
 	webhookSecret     string
 	logger            *slog.Logger
 }
@@ -68,6 +84,10 @@ func NewWebhookHandler(
 	}
 }
 
+func (h *WebhookHandler) SetPushListener(listener PushListener) {
+	h.pushListener = listener
+}
+
 func (h *WebhookHandler) Register(app *fiber.App) {
 	v1 := app.Group("/paas-deploy/v1")
 	webhooks := v1.Group("/webhooks")
@@ -115,7 +135,7 @@ func (h *WebhookHandler) HandleWebhook(c *fiber.Ctx) error {
 	signature := c.Get(HeaderGitHubSignature)
 	body := c.Body()
 
-	h.logger.Info("webhook POST received",
+	h.logger.InfoContext(c.UserContext(), "webhook POST received",
 		slog.String("event", event),
 		slog.String("delivery_id", deliveryID),
 		slog.String("remote_ip", c.IP()),
@@ -127,20 +147,20 @@ func (h *WebhookHandler) HandleWebhook(c *fiber.Ctx) error {
 	)
 
 	if !ValidateSignature(body, signature, h.webhookSecret) {
-		logger.Warn("invalid webhook signature")
+		logger.WarnContext(c.UserContext(), "invalid webhook signature")
 		errMsg := "invalid signature"
-		h.savePayload(c.Context(), deliveryID, event, body, "invalid_signature", &errMsg)
+		h.savePayload(c.Context(), deliveryID, event, body, OutcomeInvalidSignature, &errMsg)
 		return response.Unauthorized(c, "invalid signature")
 	}
 
 	if event == EventPing {
-		logger.Info("received ping event")
+		logger.InfoContext(c.UserContext(), "received ping event")
 		h.savePayloadAsync(deliveryID, event, body, "pong", nil)
 		return response.OK(c, map[string]string{"message": "pong"})
 	}
 
 	if event != EventPush {
-		logger.Info("ignoring unsupported event")
+		logger.InfoContext(c.UserContext(), "ignoring unsupported event")
 		h.savePayload(c.Context(), deliveryID, event, body, "ignored", nil)
 		return response.OK(c, map[string]string{"message": "event ignored"})
 	}
@@ -149,7 +169,7 @@ func (h *WebhookHandler) HandleWebhook(c *fiber.Ctx) error {
 
 	var pushEvent PushEvent
 	if err := json.Unmarshal(body, &pushEvent); err != nil {
-		logger.Error("failed to parse push event", slog.String("error", err.Error()))
+		logger.ErrorContext(c.UserContext(), "failed to parse push event", slog.String("error", err.Error()))
 		errStr := err.Error()
 		h.savePayload(c.Context(), deliveryID, event, body, "parse_error", &errStr)
 		return response.BadRequest(c, "invalid payload")
@@ -158,6 +178,32 @@ func (h *WebhookHandler) HandleWebhook(c *fiber.Ctx) error {
 	return h.handlePushEvent(c, logger, &pushEvent, deliveryID, event, body)
 }
 
+// Replay runs a stored push delivery through the push pipeline again and
+// writes the result as the response. The signature was checked when the
+// delivery was received; stored JSON is normalized and would not match it
+// anymore. The replay gets its own delivery ID so the deployments it queues
+// are not deduplicated against the original ones.
+func (h *WebhookHandler) Replay(c *fiber.Ctx, deliveryID, eventType, outcome string, body []byte) error {
+	if eventType != EventPush || outcome == OutcomeInvalidSignature {
+		return ErrReplayNotSupported
+	}
+
+	replayID := deliveryID + "-replay-" + uuid.New().String()[:8]
+	logger := h.logger.With(
+		slog.String("delivery_id", replayID),
+		slog.String("replay_of", deliveryID),
+		slog.String("event", eventType),
+	)
+	logger.InfoContext(c.UserContext(), "replaying webhook delivery")
+
+	var pushEvent PushEvent
+	if err := json.Unmarshal(body, &pushEvent); err != nil {
+		return ErrReplayNotSupported
+	}
+
+	return h.handlePushEvent(c, logger, &pushEvent, replayID, eventType, body)
+}
+
 func (h *WebhookHandler) savePayload(ctx context.Context, deliveryID, eventType string, payload []byte, outcome string, errMsg *string) {
 	if h.payloadStore == nil {
 		return
@@ -188,7 +234,7 @@ func (h *WebhookHandler) savePayloadAsync(deliveryID, eventType string, payload
 
 func (h *WebhookHandler) handlePushEvent(c *fiber.Ctx, logger *slog.Logger, event *PushEvent, deliveryID, eventType string, body []byte) error {
 	if event.Repository == nil {
-		logger.Warn("push event missing repository data")
+		logger.WarnContext(c.UserContext(), "push event missing repository data")
 		outcome := "missing_repository"
 		h.savePayload(c.Context(), deliveryID, eventType, body, outcome, nil)
 		return response.OK(c, map[string]string{"message": "missing repository"})
@@ -196,7 +242,7 @@ func (h *WebhookHandler) handlePushEvent(c *fiber.Ctx, logger *slog.Logger, even
 
 	branch := extractBranch(event.Ref)
 	if branch == "" {
-		logger.Info("ignoring non-branch push", slog.String("ref", event.Ref))
+		logger.InfoContext(c.UserContext(), "ignoring non-branch push", slog.String("ref", event.Ref))
 		h.savePayload(c.Context(), deliveryID, eventType, body, "ignored", strPtr("non-branch push"))
 		return response.OK(c, map[string]string{"message": "non-branch push ignored"})
 	}
@@ -208,11 +254,16 @@ func (h *WebhookHandler) handlePushEvent(c *fiber.Ctx, logger *slog.Logger, even
 	)
 
 	if event.Deleted {
-		logger.Info("ignoring branch deletion event")
+		logger.InfoContext(c.UserContext(), "ignoring branch deletion event")
 		h.savePayload(c.Context(), deliveryID, eventType, body, "ignored", strPtr("branch deleted"))
 		return response.OK(c, map[string]string{"message": "branch deletion ignored"})
 	}
 
+	gitOpsQueued := h.pushListener != nil && h.pushListener.HandlePush(getRepoURLVariants(event.Repository), branch)
+	if gitOpsQueued {
+		logger.InfoContext(c.UserContext(), "gitops sync queued")
+	}
+
 	apps, err := h.findAppsByRepository(event.Repository, logger)
 	if err != nil {
 		errStr := err.Error()
@@ -220,8 +271,13 @@ func (h *WebhookHandler) handlePushEvent(c *fiber.Ctx, logger *slog.Logger, even
 		return response.InternalError(c)
 	}
 
+	if len(apps) == 0 && gitOpsQueued {
+		h.savePayload(c.Context(), deliveryID, eventType, body, "gitops_sync_queued", nil)
+		return response.OK(c, map[string]string{"message": "gitops sync queued"})
+	}
+
 	if len(apps) == 0 {
-		logger.Info("no app registered for repository")
+		logger.InfoContext(c.UserContext(), "no app registered for repository")
 		h.savePayload(c.Context(), deliveryID, eventType, body, "ignored", strPtr("repository not registered"))
 		return response.OK(c, map[string]string{"message": "repository not registered"})
 	}
@@ -231,7 +287,7 @@ func (h *WebhookHandler) handlePushEvent(c *fiber.Ctx, logger *slog.Logger, even
 
 	branchApps := filterAppsByBranch(apps, branch)
 	if len(branchApps) == 0 {
-		logger.Info("push to non-tracked branch for all apps", slog.String("branch", branch))
+		logger.InfoContext(c.UserContext(), "push to non-tracked branch for all apps", slog.String("branch", branch))
 		h.savePayload(c.Context(), deliveryID, eventType, body, "ignored", strPtr("branch not tracked"))
 		return response.OK(c, map[string]string{"message": "branch not tracked"})
 	}
@@ -248,7 +304,7 @@ func (h *WebhookHandler) handlePushEvent(c *fiber.Ctx, logger *slog.Logger, even
 			continue
 		}
 
-		result := h.tryCreateDeployment(appLogger, app, event, deliveryID)
+		result := h.tryCreateDeployment(appLogger, app, event, deliveryID, tracing.ID(c.UserContext()))
 		if result != nil {
 			deployments = append(deployments, result)
 		}
@@ -284,7 +340,7 @@ func (h *WebhookHandler) findAppsByRepository(repo *Repository, logger *slog.Log
 	return nil, nil
 }
 
-func (h *WebhookHandler) tryCreateDeployment(logger *slog.Logger, app *domain.App, event *PushEvent, deliveryID string) fiber.Map {
+func (h *WebhookHandler) tryCreateDeployment(logger *slog.Logger, app *domain.App, event *PushEvent, deliveryID, traceID string) fiber.Map {
 	commitMessage := getCommitMessage(event)
 
 	if commitMessageSkipsDeploy(commitMessage) {
@@ -297,6 +353,7 @@ func (h *WebhookHandler) tryCreateDeployment(logger *slog.Logger, app *domain.Ap
 		CommitSHA:     event.After,
 		CommitMessage: commitMessage,
 		DeliveryID:    deliveryID,
+		TraceID:       traceID,
 	}
 
 	deployment, err := h.deploymentCreator.Create(input)
@@ -3,10 +3,12 @@ package ghclient
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"io"
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/gofiber/fiber/v2"
@@ -62,9 +64,11 @@ func (m *mockAppFinder) FindAllByRepoURL(repoURL string) ([]domain.App, error) {
 type mockDeploymentCreator struct {
 	deployment *domain.Deployment
 	createErr  error
+	lastInput  domain.CreateDeploymentInput
 }
 
 func (m *mockDeploymentCreator) Create(input domain.CreateDeploymentInput) (*domain.Deployment, error) {
+	m.lastInput = input
 	if m.createErr != nil {
 		return nil, m.createErr
 	}
@@ -154,6 +158,51 @@ func TestWebhookHandlerPushToMainBranch(t *testing.T) {
 	assertStatus(t, resp, fiber.StatusAccepted)
 }
 
+func TestWebhookHandlerReplay(t *testing.T) {
+	creator := &mockDeploymentCreator{deployment: &domain.Deployment{ID: testDeployID, AppID: testAppID}}
+	handler := NewWebhookHandler(
+		&mockAppFinder{app: &domain.App{ID: testAppID, Name: testAppName, RepositoryURL: testRepoURL, Branch: testBranchMain}},
+		creator,
+		nil,
+		nil,
+		testSecret,
+		newTestLogger(),
+	)
+	payload := createPushPayload(testRefMain, "abc123def456", testRepoURL, testBranchMain)
+
+	tests := []struct {
+		name       string
+		event      string
+		outcome    string
+		wantStatus int
+	}{
+		{"push", EventPush, "deployment_queued", fiber.StatusAccepted},
+		{"unverified push", EventPush, OutcomeInvalidSignature, fiber.StatusUnprocessableEntity},
+		{"ping", EventPing, "pong", fiber.StatusUnprocessableEntity},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			defer app.Shutdown()
+			app.Post("/replay", func(c *fiber.Ctx) error {
+				err := handler.Replay(c, testDeliveryID, tt.event, tt.outcome, payload)
+				if errors.Is(err, ErrReplayNotSupported) {
+					return c.SendStatus(fiber.StatusUnprocessableEntity)
+				}
+				return err
+			})
+
+			resp, err := app.Test(httptest.NewRequest(http.MethodPost, "/replay", nil))
+			assertNoError(t, err)
+			assertStatus(t, resp, tt.wantStatus)
+		})
+	}
+
+	if !strings.HasPrefix(creator.lastInput.DeliveryID, testDeliveryID+"-replay-") {
+		t.Errorf("delivery ID = %q, want a replay of %q", creator.lastInput.DeliveryID, testDeliveryID)
+	}
+}
+
 func TestWebhookHandlerPushToDifferentBranch(t *testing.T) {
 	app := fiber.New()
 	defer app.Shutdown()
@@ -248,6 +297,56 @@ func TestWebhookHandlerAppNotFound(t *testing.T) {
 	assertStatus(t, resp, fiber.StatusOK)
 }
 
+type mockPushListener struct {
+	matched  bool
+	repoURLs []string
+	branch   string
+}
+
+func (m *mockPushListener) HandlePush(repoURLs []string, branch string) bool {
+	m.repoURLs = repoURLs
+	m.branch = branch
+	return m.matched
+}
+
+func TestWebhookHandlerPushNotifiesListener(t *testing.T) {
+	app := fiber.New()
+	defer app.Shutdown()
+
+	handler := NewWebhookHandler(
+		&mockAppFinder{},
+		&mockDeploymentCreator{},
+		nil,
+		nil,
+		testSecret,
+		newTestLogger(),
+	)
+	listener := &mockPushListener{matched: true}
+	handler.SetPushListener(listener)
+
+	handler.Register(app)
+
+	payload := createPushPayload(testRefMain, "abc123def456", testRepoURL, testBranchMain)
+	signature := GenerateSignature(payload, testSecret)
+
+	req := httptest.NewRequest(http.MethodPost, webhookPath, bytes.NewReader(payload))
+	req.Header.Set(headerContentType, contentTypeJSON)
+	req.Header.Set(HeaderGitHubEvent, EventPush)
+	req.Header.Set(HeaderGitHubSignature, signature)
+	req.Header.Set(HeaderGitHubDelivery, testDeliveryID)
+
+	resp, err := app.Test(req)
+	assertNoError(t, err)
+	assertStatus(t, resp, fiber.StatusOK)
+
+	if listener.branch != testBranchMain {
+		t.Errorf("listener branch = %q, want %q", listener.branch, testBranchMain)
+	}
+	if len(listener.repoURLs) == 0 {
+		t.Error("listener got no repository URLs")
+	}
+}
+
 func TestWebhookHandlerPingEvent(t *testing.T) {
 	app := fiber.New()
 	defer app.Shutdown()
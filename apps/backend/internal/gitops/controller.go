@@ -0,0 +1,389 @@
+// Package gitops keeps apps in line with app specs stored in git. Each
+// source is a repository with one YAML spec per app; pushes to it are
+// applied through the app spec service, and a periodic check reports drift
+// between the specs and the apps.
+package gitops
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/paasdeploy/backend/internal/domain"
+	"github.com/paasdeploy/backend/internal/service"
+	"github.com/paasdeploy/shared/pkg/compose"
+	"github.com/paasdeploy/shared/pkg/git"
+)
+
+const (
+	defaultCheckInterval = 5 * time.Minute
+	syncTimeout          = 5 * time.Minute
+)
+
+// TokenProvider returns a token to clone a private repository, or "".
+type TokenProvider interface {
+	GetToken(ctx context.Context, repoURL string) (string, error)
+}
+
+type ControllerParams struct {
+	SourceRepo domain.GitOpsSourceRepository
+	AppRepo    domain.AppRepository
+	UserRepo   domain.UserRepository
+	ServerRepo domain.ServerRepository
+	Specs      *service.AppSpecService
+	Tokens     TokenProvider
+	DataDir    string
+	Logger     *slog.Logger
+}
+
+type Controller struct {
+	sourceRepo domain.GitOpsSourceRepository
+	appRepo    domain.AppRepository
+	userRepo   domain.UserRepository
+	serverRepo domain.ServerRepository
+	specs      *service.AppSpecService
+	tokens     TokenProvider
+	git        *git.Client
+	workDir    string
+	logger     *slog.Logger
+	interval   time.Duration
+
+	// mu serializes syncs: the git client is not safe for concurrent use
+	// and two syncs of one source must not interleave.
+	mu       sync.Mutex
+	queuedMu sync.Mutex
+	queued   map[string]bool
+	stopCh   chan struct{}
+	wg       sync.WaitGroup
+}
+
+func NewController(params ControllerParams) *Controller {
+	workDir := filepath.Join(params.DataDir, ".gitops")
+	return &Controller{
+		sourceRepo: params.SourceRepo,
+		appRepo:    params.AppRepo,
+		userRepo:   params.UserRepo,
+		serverRepo: params.ServerRepo,
+		specs:      params.Specs,
+		tokens:     params.Tokens,
+		git:        git.NewClient(workDir, params.Logger),
+		workDir:    workDir,
+		logger:     params.Logger.With("component", "gitops_controller"),
+		interval:   defaultCheckInterval,
+		queued:     map[string]bool{},
+		stopCh:     make(chan struct{}),
+	}
+}
+
+func (c *Controller) Start(ctx context.Context) {
+	c.logger.Info("Starting gitops controller", "interval", c.interval)
+	c.wg.Add(1)
+	go c.run(ctx)
+}
+
+func (c *Controller) Stop() {
+	c.logger.Info("Stopping gitops controller")
+	close(c.stopCh)
+	c.wg.Wait()
+	c.logger.Info("Gitops controller stopped")
+}
+
+func (c *Controller) run(ctx context.Context) {
+	defer c.wg.Done()
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-c.stopCh:
+			return
+		case <-ticker.C:
+			c.checkAll(ctx)
+		}
+	}
+}
+
+// checkAll compares every source with its apps. A source whose branch moved
+// without a webhook delivery is synced instead.
+func (c *Controller) checkAll(ctx context.Context) {
+	sources, err := c.sourceRepo.FindAll(ctx)
+	if err != nil {
+		c.logger.Error("failed to list gitops sources", "error", err)
+		return
+	}
+	for i := range sources {
+		select {
+		case <-ctx.Done():
+			return
+		case <-c.stopCh:
+			return
+		default:
+		}
+		c.reconcile(ctx, sources[i].ID, false)
+	}
+}
+
+// HandlePush queues a sync of the sources tracking the pushed branch of one
+// of repoURLs, the URL variants of a single repository. It reports whether
+// any source matched.
+func (c *Controller) HandlePush(repoURLs []string, branch string) bool {
+	sources, err := c.sourceRepo.FindAll(context.Background())
+	if err != nil {
+		c.logger.Error("failed to list gitops sources", "error", err)
+		return false
+	}
+	matched := false
+	for i := range sources {
+		if sources[i].Branch == branch && matchesRepository(sources[i].RepositoryURL, repoURLs) {
+			c.Trigger(sources[i].ID)
+			matched = true
+		}
+	}
+	return matched
+}
+
+func matchesRepository(repoURL string, variants []string) bool {
+	normalized := normalizeRepoURL(repoURL)
+	for _, v := range variants {
+		if normalizeRepoURL(v) == normalized {
+			return true
+		}
+	}
+	return false
+}
+
+func normalizeRepoURL(repoURL string) string {
+	return strings.ToLower(strings.TrimSuffix(strings.TrimSuffix(repoURL, "/"), ".git"))
+}
+
+// Trigger syncs a source in the background. A source already waiting for
+// a sync is not queued twice.
+func (c *Controller) Trigger(sourceID string) {
+	c.queuedMu.Lock()
+	if c.queued[sourceID] {
+		c.queuedMu.Unlock()
+		return
+	}
+	c.queued[sourceID] = true
+	c.queuedMu.Unlock()
+
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		c.reconcile(context.Background(), sourceID, true)
+	}()
+}
+
+// reconcile clones the source and applies its specs, or with apply unset
+// only compares them unless the branch moved since the last sync.
+func (c *Controller) reconcile(ctx context.Context, sourceID string, apply bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.queuedMu.Lock()
+	delete(c.queued, sourceID)
+	c.queuedMu.Unlock()
+
+	ctx, cancel := context.WithTimeout(ctx, syncTimeout)
+	defer cancel()
+
+	source, err := c.sourceRepo.FindByID(ctx, sourceID)
+	if err != nil {
+		if !errors.Is(err, domain.ErrNotFound) {
+			c.logger.Error("failed to load gitops source", "sourceId", sourceID, "error", err)
+		}
+		return
+	}
+
+	result := c.sync(ctx, source, apply)
+	if err := c.sourceRepo.SaveSyncResult(ctx, source.ID, result); err != nil {
+		c.logger.Error("failed to save gitops sync result", "sourceId", source.ID, "error", err)
+	}
+	c.logger.Info("gitops source reconciled",
+		"sourceId", source.ID,
+		"repo", source.RepositoryURL,
+		"commit", result.CommitSHA,
+		"applied", result.Applied,
+		"status", result.Status,
+	)
+}
+
+func (c *Controller) sync(ctx context.Context, source *domain.GitOpsSource, apply bool) domain.GitOpsSyncResult {
+	if apply {
+		if err := c.sourceRepo.UpdateStatus(ctx, source.ID, domain.GitOpsStatusSyncing); err != nil {
+			c.logger.Warn("failed to mark gitops source syncing", "sourceId", source.ID, "error", err)
+		}
+	}
+
+	failed := func(err error) domain.GitOpsSyncResult {
+		return domain.GitOpsSyncResult{Status: domain.GitOpsStatusFailed, Error: err.Error(), Apps: source.Apps}
+	}
+
+	user, err := c.userRepo.FindByID(ctx, source.UserID)
+	if err != nil {
+		return failed(fmt.Errorf("load owner: %w", err))
+	}
+
+	checkout, commitSHA, err := c.clone(ctx, source)
+	if err != nil {
+		return failed(err)
+	}
+	defer os.RemoveAll(filepath.Dir(checkout))
+
+	if commitSHA != source.LastCommitSHA {
+		apply = true
+	}
+
+	specDir, err := compose.SafeJoin(checkout, source.Path)
+	if err != nil {
+		return failed(fmt.Errorf("invalid path %s: %w", source.Path, err))
+	}
+	specs, fileErrors, err := ReadSpecDir(specDir)
+	if err != nil {
+		return failed(fmt.Errorf("read specs: %w", err))
+	}
+
+	servers, err := c.serverRepo.FindAllByUserID(user.ID)
+	if err != nil {
+		return failed(fmt.Errorf("list servers: %w", err))
+	}
+
+	apps := make([]domain.GitOpsAppStatus, 0, len(specs)+len(fileErrors))
+	for _, fe := range fileErrors {
+		apps = append(apps, domain.GitOpsAppStatus{File: fe.File, Status: domain.GitOpsStatusFailed, Error: fe.Err.Error()})
+	}
+	for i := range specs {
+		apps = append(apps, c.applySpec(ctx, user, servers, &specs[i], !apply))
+	}
+	apps = append(apps, c.orphans(user, source.Apps, specs)...)
+
+	return domain.GitOpsSyncResult{
+		Status:    overallStatus(apps),
+		CommitSHA: commitSHA,
+		Apps:      apps,
+		Applied:   apply,
+	}
+}
+
+func (c *Controller) clone(ctx context.Context, source *domain.GitOpsSource) (string, string, error) {
+	if err := os.MkdirAll(c.workDir, 0o755); err != nil {
+		return "", "", err
+	}
+	parent, err := os.MkdirTemp(c.workDir, "source-")
+	if err != nil {
+		return "", "", err
+	}
+	checkout := filepath.Join(parent, "repo")
+
+	token := ""
+	if c.tokens != nil {
+		if token, err = c.tokens.GetToken(ctx, source.RepositoryURL); err != nil {
+			c.logger.Warn("failed to get git token, cloning without authentication", "repo", source.RepositoryURL, "error", err)
+			token = ""
+		}
+	}
+	if err := c.git.CloneBranchWithToken(ctx, source.RepositoryURL, source.Branch, checkout, token); err != nil {
+		os.RemoveAll(parent)
+		return "", "", err
+	}
+	sha, err := c.git.GetCurrentCommitSHA(ctx, checkout)
+	if err != nil {
+		os.RemoveAll(parent)
+		return "", "", err
+	}
+	return checkout, sha, nil
+}
+
+func (c *Controller) applySpec(ctx context.Context, user *domain.User, servers []domain.Server, file *FileSpec, dryRun bool) domain.GitOpsAppStatus {
+	status := domain.GitOpsAppStatus{Name: file.Spec.Name, File: file.File}
+	spec := file.Spec
+	if file.Server != "" {
+		serverID, ok := resolveServer(servers, file.Server)
+		if !ok {
+			status.Status = domain.GitOpsStatusFailed
+			status.Error = "server " + file.Server + " not found"
+			return status
+		}
+		spec.ServerID = &serverID
+	}
+
+	result, err := c.specs.Apply(ctx, user, spec, dryRun)
+	if err != nil {
+		status.Status = domain.GitOpsStatusFailed
+		status.Error = specErrorMessage(err)
+		return status
+	}
+	status.Changes = result.Changes
+	status.Status = domain.GitOpsStatusSynced
+	if dryRun && len(result.Changes) > 0 {
+		status.Status = domain.GitOpsStatusDrifted
+	}
+	return status
+}
+
+func resolveServer(servers []domain.Server, nameOrID string) (string, bool) {
+	for i := range servers {
+		if servers[i].ID == nameOrID || servers[i].Name == nameOrID {
+			return servers[i].ID, true
+		}
+	}
+	return "", false
+}
+
+func specErrorMessage(err error) string {
+	switch {
+	case errors.Is(err, domain.ErrAlreadyExists):
+		return "app name is used by another account"
+	case errors.Is(err, domain.ErrForbidden):
+		return "apps on the control plane host require an admin; set a server"
+	}
+	return err.Error()
+}
+
+// orphans returns the apps of the previous result that no spec defines
+// anymore and that still exist.
+func (c *Controller) orphans(user *domain.User, previous []domain.GitOpsAppStatus, specs []FileSpec) []domain.GitOpsAppStatus {
+	defined := make(map[string]bool, len(specs))
+	for i := range specs {
+		defined[specs[i].Spec.Name] = true
+	}
+	var result []domain.GitOpsAppStatus
+	for _, app := range previous {
+		if app.Name == "" || defined[app.Name] {
+			continue
+		}
+		existing, err := c.appRepo.FindByName(app.Name)
+		if err != nil || existing.UserID != user.ID {
+			continue
+		}
+		result = append(result, domain.GitOpsAppStatus{
+			Name:   app.Name,
+			Status: domain.GitOpsStatusDrifted,
+			Orphan: true,
+			Error:  "spec file removed; the app was left in place",
+		})
+	}
+	return result
+}
+
+func overallStatus(apps []domain.GitOpsAppStatus) domain.GitOpsStatus {
+	status := domain.GitOpsStatusSynced
+	for _, app := range apps {
+		switch app.Status {
+		case domain.GitOpsStatusFailed:
+			return domain.GitOpsStatusFailed
+		case domain.GitOpsStatusDrifted:
+			status = domain.GitOpsStatusDrifted
+		}
+	}
+	return status
+}
@@ -0,0 +1,137 @@
+package gitops
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/paasdeploy/backend/internal/domain"
+	"go.yaml.in/yaml/v3"
+)
+
+// specFile is the YAML form of domain.AppSpec. Server takes a server name
+// or ID; leaving it out places the app on the control plane host.
+type specFile struct {
+	Name          string        `yaml:"name"`
+	RepositoryURL string        `yaml:"repositoryUrl"`
+	Branch        string        `yaml:"branch"`
+	Workdir       string        `yaml:"workdir"`
+	Server        string        `yaml:"server"`
+	Env           []specEnvVar  `yaml:"env"`
+	Domains       []specDomain  `yaml:"domains"`
+	Resources     specResources `yaml:"resources"`
+}
+
+type specEnvVar struct {
+	Key    string `yaml:"key"`
+	Value  string `yaml:"value"`
+	Secret bool   `yaml:"secret"`
+}
+
+type specDomain struct {
+	Domain      string `yaml:"domain"`
+	PathPrefix  string `yaml:"pathPrefix"`
+	DNSProvider string `yaml:"dnsProvider"`
+}
+
+type specResources struct {
+	Memory string `yaml:"memory"`
+	CPU    string `yaml:"cpu"`
+}
+
+// FileSpec is an app spec read from one file of a source.
+type FileSpec struct {
+	File   string
+	Server string
+	Spec   domain.AppSpec
+}
+
+// FileError reports a spec file that could not be read.
+type FileError struct {
+	File string
+	Err  error
+}
+
+func (e *FileError) Error() string {
+	return e.File + ": " + e.Err.Error()
+}
+
+// ParseSpec decodes one spec file. The app name defaults to the file name
+// without its extension; unknown keys are rejected to catch typos.
+func ParseSpec(file string, data []byte) (*FileSpec, error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+
+	var raw specFile
+	if err := decoder.Decode(&raw); err != nil {
+		return nil, fmt.Errorf("invalid YAML: %w", err)
+	}
+
+	name := raw.Name
+	if name == "" {
+		name = strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+	}
+	spec := domain.AppSpec{
+		Name:          name,
+		RepositoryURL: raw.RepositoryURL,
+		Branch:        raw.Branch,
+		Workdir:       raw.Workdir,
+		Env:           make([]domain.AppSpecEnvVar, len(raw.Env)),
+		Domains:       make([]domain.AppSpecDomain, len(raw.Domains)),
+		Resources:     domain.AppResources{Memory: raw.Resources.Memory, CPU: raw.Resources.CPU},
+	}
+	for i, v := range raw.Env {
+		spec.Env[i] = domain.AppSpecEnvVar{Key: v.Key, Value: v.Value, Secret: v.Secret}
+	}
+	for i, d := range raw.Domains {
+		spec.Domains[i] = domain.AppSpecDomain{Domain: d.Domain, PathPrefix: d.PathPrefix, DNSProvider: d.DNSProvider}
+	}
+
+	spec.Normalize()
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+	return &FileSpec{File: file, Server: strings.TrimSpace(raw.Server), Spec: spec}, nil
+}
+
+// ReadSpecDir parses the *.yaml and *.yml files directly under dir, in name
+// order. Files that fail to parse, or name an app already defined by an
+// earlier file, are returned as errors without stopping the others.
+func ReadSpecDir(dir string) ([]FileSpec, []FileError, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+
+	var specs []FileSpec
+	var fileErrors []FileError
+	seen := map[string]string{}
+	for _, entry := range entries {
+		ext := filepath.Ext(entry.Name())
+		if entry.IsDir() || (ext != ".yaml" && ext != ".yml") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			fileErrors = append(fileErrors, FileError{File: entry.Name(), Err: err})
+			continue
+		}
+		spec, err := ParseSpec(entry.Name(), data)
+		if err != nil {
+			fileErrors = append(fileErrors, FileError{File: entry.Name(), Err: err})
+			continue
+		}
+		if other, ok := seen[spec.Spec.Name]; ok {
+			fileErrors = append(fileErrors, FileError{File: entry.Name(), Err: errors.New("app " + spec.Spec.Name + " is already defined in " + other)})
+			continue
+		}
+		seen[spec.Spec.Name] = entry.Name()
+		specs = append(specs, *spec)
+	}
+	return specs, fileErrors, nil
+}
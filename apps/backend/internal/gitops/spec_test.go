@@ -0,0 +1,164 @@
+package gitops
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/paasdeploy/backend/internal/domain"
+)
+
+func TestParseSpec(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		data    string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "full spec",
+			file: "api.yaml",
+			data: `
+name: api
+repositoryUrl: https://github.com/acme/api
+branch: develop
+server: edge-1
+env:
+  - key: PORT
+    value: "8080"
+domains:
+  - domain: API.example.com
+resources:
+  memory: 512m
+`,
+			want: "api",
+		},
+		{
+			name: "name from file",
+			file: "web.yml",
+			data: "repositoryUrl: https://github.com/acme/web\n",
+			want: "web",
+		},
+		{
+			name:    "unknown key",
+			file:    "api.yaml",
+			data:    "repositoryUrl: https://github.com/acme/api\nreplicas: 2\n",
+			wantErr: true,
+		},
+		{
+			name:    "missing repository",
+			file:    "api.yaml",
+			data:    "branch: main\n",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseSpec(tt.file, []byte(tt.data))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.Spec.Name != tt.want {
+				t.Errorf("name = %q, want %q", got.Spec.Name, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSpecNormalizes(t *testing.T) {
+	got, err := ParseSpec("api.yaml", []byte(`
+repositoryUrl: https://github.com/acme/api
+server: " edge-1 "
+domains:
+  - domain: API.example.com.
+`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Server != "edge-1" {
+		t.Errorf("server = %q, want edge-1", got.Server)
+	}
+	if got.Spec.Branch != "main" || got.Spec.Workdir != "." {
+		t.Errorf("defaults not applied: branch %q workdir %q", got.Spec.Branch, got.Spec.Workdir)
+	}
+	if got.Spec.Domains[0].Domain != "api.example.com" {
+		t.Errorf("domain = %q, want api.example.com", got.Spec.Domains[0].Domain)
+	}
+}
+
+func TestReadSpecDir(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"api.yaml":    "repositoryUrl: https://github.com/acme/api\n",
+		"broken.yaml": "repositoryUrl: [\n",
+		"copy.yml":    "name: api\nrepositoryUrl: https://github.com/acme/other\n",
+		"README.md":   "# apps\n",
+	}
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Mkdir(filepath.Join(dir, "nested.yaml"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	specs, fileErrors, err := ReadSpecDir(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(specs) != 1 || specs[0].File != "api.yaml" {
+		t.Fatalf("specs = %+v, want only api.yaml", specs)
+	}
+	if len(fileErrors) != 2 {
+		t.Fatalf("got %d file errors, want 2: %+v", len(fileErrors), fileErrors)
+	}
+	if fileErrors[0].File != "broken.yaml" || fileErrors[1].File != "copy.yml" {
+		t.Errorf("file errors = %+v", fileErrors)
+	}
+}
+
+func TestReadSpecDirMissing(t *testing.T) {
+	_, _, err := ReadSpecDir(filepath.Join(t.TempDir(), "apps"))
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("err = %v, want not exist", err)
+	}
+}
+
+func TestOverallStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		apps []domain.GitOpsAppStatus
+		want domain.GitOpsStatus
+	}{
+		{"empty", nil, domain.GitOpsStatusSynced},
+		{"synced", []domain.GitOpsAppStatus{{Status: domain.GitOpsStatusSynced}}, domain.GitOpsStatusSynced},
+		{"drifted", []domain.GitOpsAppStatus{{Status: domain.GitOpsStatusSynced}, {Status: domain.GitOpsStatusDrifted}}, domain.GitOpsStatusDrifted},
+		{"failed wins", []domain.GitOpsAppStatus{{Status: domain.GitOpsStatusDrifted}, {Status: domain.GitOpsStatusFailed}}, domain.GitOpsStatusFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := overallStatus(tt.apps); got != tt.want {
+				t.Errorf("overallStatus() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchesRepository(t *testing.T) {
+	variants := []string{"https://github.com/acme/config", "https://github.com/acme/config.git"}
+	if !matchesRepository("https://github.com/Acme/Config.git", variants) {
+		t.Error("expected match ignoring case and .git suffix")
+	}
+	if matchesRepository("https://github.com/acme/other", variants) {
+		t.Error("unexpected match")
+	}
+}
@@ -1,30 +1,104 @@
 package grpcserver
 
 import (
-	"sync"
+	"errors"
+	"log/slog"
 
 	pb "github.com/paasdeploy/backend/gen/go/flowdeploy/v1"
+	"github.com/paasdeploy/backend/internal/domain"
 )
 
+var agentCommandTypes = map[domain.AgentCommandType]pb.AgentCommandType{
+	domain.AgentCommandUpdateAgent:      pb.AgentCommandType_AGENT_COMMAND_UPDATE_AGENT,
+	domain.AgentCommandStartContainer:   pb.AgentCommandType_AGENT_COMMAND_START_CONTAINER,
+	domain.AgentCommandStopContainer:    pb.AgentCommandType_AGENT_COMMAND_STOP_CONTAINER,
+	domain.AgentCommandRestartContainer: pb.AgentCommandType_AGENT_COMMAND_RESTART_CONTAINER,
+	domain.AgentCommandRemoveContainer:  pb.AgentCommandType_AGENT_COMMAND_REMOVE_CONTAINER,
+	domain.AgentCommandUpdateDomains:    pb.AgentCommandType_AGENT_COMMAND_UPDATE_DOMAINS,
+}
+
 type AgentCommandQueue struct {
-	mu   sync.Mutex
-	cmds map[string][]*pb.AgentCommand
+	repo   domain.AgentCommandRepository
+	logger *slog.Logger
+}
+
+func NewAgentCommandQueue(repo domain.AgentCommandRepository, logger *slog.Logger) *AgentCommandQueue {
+	return &AgentCommandQueue{repo: repo, logger: logger}
+}
+
+func (q *AgentCommandQueue) Enqueue(serverID string, cmdType domain.AgentCommandType, payload string) (*domain.AgentCommand, error) {
+	if q.repo == nil {
+		return nil, errors.New("agent command queue not configured")
+	}
+	return q.repo.Enqueue(domain.EnqueueAgentCommandInput{
+		ServerID: serverID,
+		Type:     cmdType,
+		Payload:  payload,
+	})
 }
 
-func NewAgentCommandQueue() *AgentCommandQueue {
-	return &AgentCommandQueue{cmds: make(map[string][]*pb.AgentCommand)}
+func (q *AgentCommandQueue) Claim(serverID string) []*pb.AgentCommand {
+	if q.repo == nil {
+		return nil
+	}
+
+	pending, err := q.repo.ClaimPending(serverID)
+	if err != nil {
+		q.logger.Error("failed to claim agent commands", "serverId", serverID, "error", err)
+		return nil
+	}
+
+	commands := make([]*pb.AgentCommand, 0, len(pending))
+	for _, cmd := range pending {
+		cmdType, ok := agentCommandTypes[cmd.Type]
+		if !ok {
+			q.logger.Warn("dropping agent command with unknown type", "serverId", serverID, "commandId", cmd.ID, "type", cmd.Type)
+			_ = q.repo.Complete(serverID, cmd.ID, false, "unknown command type")
+			continue
+		}
+		commands = append(commands, &pb.AgentCommand{
+			Id:      cmd.ID,
+			Type:    cmdType,
+			Payload: cmd.Payload,
+		})
+	}
+	return commands
+}
+
+func (q *AgentCommandQueue) RecordResults(serverID string, results []*pb.AgentCommandResult) {
+	if q.repo == nil {
+		return
+	}
+
+	for _, result := range results {
+		if result.GetCommandId() == "" {
+			continue
+		}
+		err := q.repo.Complete(serverID, result.GetCommandId(), result.GetSuccess(), result.GetMessage())
+		if err != nil && !errors.Is(err, domain.ErrNotFound) {
+			q.logger.Error("failed to record agent command result", "serverId", serverID, "commandId", result.GetCommandId(), "error", err)
+		}
+	}
 }
 
-func (q *AgentCommandQueue) Enqueue(serverID string, cmd *pb.AgentCommand) {
-	q.mu.Lock()
-	defer q.mu.Unlock()
-	q.cmds[serverID] = append(q.cmds[serverID], cmd)
+// CompleteUpdates marks delivered update commands succeeded once the agent
+// comes back with a new version. The agent restarts into the new binary, so
+// it cannot report the result of the command itself.
+func (q *AgentCommandQueue) CompleteUpdates(serverID, version string) {
+	if q.repo == nil {
+		return
+	}
+	_, err := q.repo.CompleteDelivered(serverID, domain.AgentCommandUpdateAgent, true, "updated to "+version)
+	if err != nil {
+		q.logger.Error("failed to complete agent update commands", "serverId", serverID, "error", err)
+	}
 }
 
-func (q *AgentCommandQueue) GetAndClear(serverID string) []*pb.AgentCommand {
-	q.mu.Lock()
-	defer q.mu.Unlock()
-	pending := q.cmds[serverID]
-	delete(q.cmds, serverID)
-	return pending
+func (q *AgentCommandQueue) CancelPending(serverID string) {
+	if q.repo == nil {
+		return
+	}
+	if _, err := q.repo.CancelPending(serverID); err != nil {
+		q.logger.Error("failed to cancel agent commands", "serverId", serverID, "error", err)
+	}
 }
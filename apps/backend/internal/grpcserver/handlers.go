@@ -4,13 +4,15 @@ import (
 	"context"
 
 	pb "github.com/paasdeploy/backend/gen/go/flowdeploy/v1"
+	"github.com/paasdeploy/backend/internal/pki"
 )
 
 func (s *Server) Register(ctx context.Context, req *pb.RegisterRequest) (*pb.RegisterResponse, error) {
-	serverID, err := extractServerIDFromCert(ctx)
+	cert, err := extractAgentCert(ctx)
 	if err != nil {
 		return nil, err
 	}
+	serverID := cert.Subject.CommonName
 
 	var previousVersion string
 	if srv, findErr := s.serverRepo.FindByID(serverID); findErr == nil && srv.AgentVersion != nil {
@@ -20,11 +22,15 @@ func (s *Server) Register(ctx context.Context, req *pb.RegisterRequest) (*pb.Reg
 	if err := s.serverRepo.UpdateHeartbeat(serverID, req.AgentVersion); err != nil {
 		s.logger.Error("failed to update heartbeat", "serverId", serverID, "error", err)
 	}
-	s.hub.Update(serverID)
+	s.recordHeartbeat(serverID)
+	s.hub.Update(serverID, pki.SerialString(cert))
 
 	newVersion := req.GetAgentVersion()
-	if s.agentUpdateNotifier != nil && newVersion != "" && newVersion != previousVersion {
-		s.agentUpdateNotifier.NotifyUpdateCompleted(serverID, newVersion)
+	if newVersion != "" && newVersion != previousVersion {
+		s.cmdQueue.CompleteUpdates(serverID, newVersion)
+		if s.agentUpdateNotifier != nil {
+			s.agentUpdateNotifier.NotifyUpdateCompleted(serverID, newVersion)
+		}
 	}
 
 	resp := &pb.RegisterResponse{
@@ -43,10 +49,11 @@ func (s *Server) Register(ctx context.Context, req *pb.RegisterRequest) (*pb.Reg
 }
 
 func (s *Server) Heartbeat(ctx context.Context, req *pb.HeartbeatRequest) (*pb.HeartbeatResponse, error) {
-	serverID, err := extractServerIDFromCert(ctx)
+	cert, err := extractAgentCert(ctx)
 	if err != nil {
 		return nil, err
 	}
+	serverID := cert.Subject.CommonName
 
 	var previousVersion string
 	if srv, findErr := s.serverRepo.FindByID(serverID); findErr == nil && srv.AgentVersion != nil {
@@ -56,9 +63,14 @@ func (s *Server) Heartbeat(ctx context.Context, req *pb.HeartbeatRequest) (*pb.H
 	if hbErr := s.serverRepo.UpdateHeartbeat(serverID, req.GetAgentVersion()); hbErr != nil {
 		s.logger.Error("failed to update heartbeat", "serverId", serverID, "error", hbErr)
 	}
-	s.hub.Update(serverID)
+	s.recordHeartbeat(serverID)
+	s.hub.Update(serverID, pki.SerialString(cert))
 
-	commands := s.cmdQueue.GetAndClear(serverID)
+	s.cmdQueue.RecordResults(serverID, req.GetCommandResults())
+	if newVersion := req.GetAgentVersion(); newVersion != "" && newVersion != previousVersion {
+		s.cmdQueue.CompleteUpdates(serverID, newVersion)
+	}
+	commands := s.cmdQueue.Claim(serverID)
 
 	s.emitAgentUpdateEvents(serverID, previousVersion, req.GetAgentVersion(), commands)
 
@@ -73,6 +85,15 @@ func (s *Server) Heartbeat(ctx context.Context, req *pb.HeartbeatRequest) (*pb.H
 	}, nil
 }
 
+func (s *Server) recordHeartbeat(serverID string) {
+	if s.heartbeatRepo == nil {
+		return
+	}
+	if err := s.heartbeatRepo.Record(serverID); err != nil {
+		s.logger.Error("failed to record heartbeat history", "serverId", serverID, "error", err)
+	}
+}
+
 func (s *Server) emitAgentUpdateEvents(serverID, previousVersion, newVersion string, commands []*pb.AgentCommand) {
 	if s.agentUpdateNotifier == nil {
 		if len(commands) > 0 {
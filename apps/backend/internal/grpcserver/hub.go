@@ -7,6 +7,7 @@ import (
 
 type AgentConnection struct {
 	ServerID        string
+	CertSerial      string
 	LastHeartbeatAt time.Time
 }
 
@@ -19,15 +20,22 @@ func NewAgentHub() *AgentHub {
 	return &AgentHub{agents: make(map[string]AgentConnection)}
 }
 
-func (h *AgentHub) Update(serverID string) {
+func (h *AgentHub) Update(serverID, certSerial string) {
 	h.mu.Lock()
 	defer h.mu.Unlock()
 	h.agents[serverID] = AgentConnection{
 		ServerID:        serverID,
+		CertSerial:      certSerial,
 		LastHeartbeatAt: time.Now(),
 	}
 }
 
+func (h *AgentHub) Remove(serverID string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.agents, serverID)
+}
+
 func (h *AgentHub) Get(serverID string) (AgentConnection, bool) {
 	h.mu.RLock()
 	defer h.mu.RUnlock()
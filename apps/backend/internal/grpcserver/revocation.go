@@ -0,0 +1,67 @@
+package grpcserver
+
+import (
+	"crypto/x509"
+	"fmt"
+
+	"github.com/paasdeploy/backend/internal/domain"
+)
+
+func (s *Server) loadRevocations() error {
+	if s.revocationRepo == nil {
+		return nil
+	}
+
+	revocations, err := s.revocationRepo.FindAll()
+	if err != nil {
+		return err
+	}
+
+	for _, r := range revocations {
+		s.applyRevocation(r)
+	}
+	return nil
+}
+
+func (s *Server) applyRevocation(r domain.CertificateRevocation) {
+	s.revocations.RevokeIdentity(r.ServerID, r.RevokedAt)
+	if r.SerialNumber != nil {
+		s.revocations.RevokeSerial(*r.SerialNumber, r.RevokedAt)
+	}
+}
+
+// IsCertRevoked lets the provisioner decide whether agent certificates
+// already on a server can be kept when it is provisioned again.
+func (s *Server) IsCertRevoked(cert *x509.Certificate) bool {
+	return s.revocations.IsRevoked(cert)
+}
+
+// RevokeServerCert revokes every certificate issued to the server so far.
+// The agent must be re-provisioned to obtain a new identity.
+func (s *Server) RevokeServerCert(serverID, reason, revokedBy string) (*domain.CertificateRevocation, error) {
+	if s.revocationRepo == nil {
+		return nil, fmt.Errorf("certificate revocation not configured")
+	}
+
+	var serial string
+	if conn, ok := s.hub.Get(serverID); ok {
+		serial = conn.CertSerial
+	}
+
+	revocation, err := s.revocationRepo.Create(domain.CreateCertificateRevocationInput{
+		ServerID:     serverID,
+		SerialNumber: serial,
+		Reason:       reason,
+		RevokedBy:    revokedBy,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.applyRevocation(*revocation)
+	s.hub.Remove(serverID)
+	s.cmdQueue.CancelPending(serverID)
+
+	s.logger.Warn("agent certificate revoked", "serverId", serverID, "serial", serial, "revokedBy", revokedBy)
+	return revocation, nil
+}
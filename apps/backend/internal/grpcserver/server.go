@@ -27,11 +27,14 @@ type Server struct {
 	grpcServer           *grpc.Server
 	ca                   *pki.CertificateAuthority
 	serverRepo           domain.ServerRepository
+	heartbeatRepo        domain.ServerHeartbeatRepository
 	hub                  *AgentHub
 	cmdQueue             *AgentCommandQueue
 	agentTokenStore      AgentTokenStore
 	agentDownloadURL     string
 	agentUpdateNotifier  AgentUpdateNotifier
+	revocations          *pki.RevocationList
+	revocationRepo       domain.CertificateRevocationRepository
 	logger               *slog.Logger
 }
 
@@ -58,6 +61,9 @@ func NewServer(
 	cfg *config.Config,
 	ca *pki.CertificateAuthority,
 	serverRepo domain.ServerRepository,
+	heartbeatRepo domain.ServerHeartbeatRepository,
+	revocationRepo domain.CertificateRevocationRepository,
+	commandRepo domain.AgentCommandRepository,
 	agentTokenStore AgentTokenStore,
 	agentUpdateNotifier AgentUpdateNotifier,
 	logger *slog.Logger,
@@ -83,25 +89,31 @@ func NewServer(
 		MinVersion:   tls.VersionTLS13,
 	}
 
-	grpcServer := grpc.NewServer(
-		grpc.Creds(credentials.NewTLS(tlsConfig)),
-		grpc.UnaryInterceptor(authInterceptor),
-		grpc.StreamInterceptor(streamAuthInterceptor),
-	)
-
 	s := &Server{
-		grpcServer:          grpcServer,
 		ca:                  ca,
 		serverRepo:          serverRepo,
+		heartbeatRepo:       heartbeatRepo,
 		hub:                 NewAgentHub(),
-		cmdQueue:            NewAgentCommandQueue(),
+		cmdQueue:            NewAgentCommandQueue(commandRepo, logger.With("component", "grpc")),
 		agentTokenStore:     agentTokenStore,
 		agentDownloadURL:    buildAgentDownloadURL(cfg),
 		agentUpdateNotifier: agentUpdateNotifier,
+		revocations:         pki.NewRevocationList(),
+		revocationRepo:      revocationRepo,
 		logger:              logger.With("component", "grpc"),
 	}
 
-	pb.RegisterAgentServiceServer(grpcServer, s)
+	if err := s.loadRevocations(); err != nil {
+		return nil, fmt.Errorf("load certificate revocations: %w", err)
+	}
+
+	s.grpcServer = grpc.NewServer(
+		grpc.Creds(credentials.NewTLS(tlsConfig)),
+		grpc.UnaryInterceptor(s.authInterceptor),
+		grpc.StreamInterceptor(s.streamAuthInterceptor),
+	)
+
+	pb.RegisterAgentServiceServer(s.grpcServer, s)
 
 	return s, nil
 }
@@ -139,57 +151,67 @@ func (s *Server) EnqueueUpdateAgent(serverID string) {
 	}
 
 	s.logger.Info("enqueuing agent update", "serverId", serverID, "hasPayload", payload != "")
-	s.cmdQueue.Enqueue(serverID, &pb.AgentCommand{
-		Type:    pb.AgentCommandType_AGENT_COMMAND_UPDATE_AGENT,
-		Payload: payload,
-	})
+	if _, err := s.cmdQueue.Enqueue(serverID, domain.AgentCommandUpdateAgent, payload); err != nil {
+		s.logger.Error("failed to enqueue agent update", "serverId", serverID, "error", err)
+	}
 }
 
-func authInterceptor(
+func (s *Server) authInterceptor(
 	ctx context.Context,
 	req any,
 	info *grpc.UnaryServerInfo,
 	handler grpc.UnaryHandler,
 ) (any, error) {
-	_, err := extractServerIDFromCert(ctx)
-	if err != nil {
-		return nil, status.Error(codes.Unauthenticated, "invalid certificate")
+	if err := s.authorizePeer(ctx); err != nil {
+		return nil, err
 	}
 	return handler(ctx, req)
 }
 
-func streamAuthInterceptor(
+func (s *Server) streamAuthInterceptor(
 	srv any,
 	ss grpc.ServerStream,
 	info *grpc.StreamServerInfo,
 	handler grpc.StreamHandler,
 ) error {
-	_, err := extractServerIDFromCert(ss.Context())
+	if err := s.authorizePeer(ss.Context()); err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
+
+func (s *Server) authorizePeer(ctx context.Context) error {
+	cert, err := extractAgentCert(ctx)
 	if err != nil {
 		return status.Error(codes.Unauthenticated, "invalid certificate")
 	}
-	return handler(srv, ss)
+	if s.revocations.IsRevoked(cert) {
+		s.logger.Warn("rejected revoked agent certificate",
+			"serverId", cert.Subject.CommonName, "serial", pki.SerialString(cert))
+		return status.Error(codes.Unauthenticated, "certificate revoked")
+	}
+	return nil
 }
 
-func extractServerIDFromCert(ctx context.Context) (string, error) {
+func extractAgentCert(ctx context.Context) (*x509.Certificate, error) {
 	p, ok := peer.FromContext(ctx)
 	if !ok {
-		return "", fmt.Errorf("no peer info")
+		return nil, fmt.Errorf("no peer info")
 	}
 
 	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
 	if !ok {
-		return "", fmt.Errorf("no TLS info")
+		return nil, fmt.Errorf("no TLS info")
 	}
 
 	if len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.VerifiedChains[0]) == 0 {
-		return "", fmt.Errorf("no verified chains")
+		return nil, fmt.Errorf("no verified chains")
 	}
 
 	cert := tlsInfo.State.VerifiedChains[0][0]
 	if len(cert.Subject.OrganizationalUnit) == 0 || cert.Subject.OrganizationalUnit[0] != "agent" {
-		return "", fmt.Errorf("invalid certificate OU")
+		return nil, fmt.Errorf("invalid certificate OU")
 	}
 
-	return cert.Subject.CommonName, nil
+	return cert, nil
 }
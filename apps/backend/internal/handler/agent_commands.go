@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/paasdeploy/backend/internal/agentclient"
+	"github.com/paasdeploy/backend/internal/domain"
+	"github.com/paasdeploy/backend/internal/response"
+)
+
+const msgAgentCommandQueued = "Agent unreachable, command queued for delivery"
+
+type QueuedCommandResponse struct {
+	Message   string `json:"message"`
+	Queued    bool   `json:"queued"`
+	CommandID string `json:"commandId"`
+	ExpiresAt string `json:"expiresAt"`
+}
+
+// enqueueIfUnreachable stores req as an agent command when callErr means the
+// agent could not be reached. It returns nil when the error is not a
+// connectivity problem or the command could not be queued, leaving the caller
+// to report the original error.
+func enqueueIfUnreachable(
+	repo domain.AgentCommandRepository,
+	callErr error,
+	serverID string,
+	cmdType domain.AgentCommandType,
+	req proto.Message,
+) *domain.AgentCommand {
+	if repo == nil || !agentclient.IsUnreachable(callErr) {
+		return nil
+	}
+
+	payload, err := agentclient.EncodeCommandPayload(req)
+	if err != nil {
+		return nil
+	}
+
+	cmd, err := repo.Enqueue(domain.EnqueueAgentCommandInput{
+		ServerID: serverID,
+		Type:     cmdType,
+		Payload:  payload,
+	})
+	if err != nil {
+		return nil
+	}
+	return cmd
+}
+
+func respondCommandQueued(c *fiber.Ctx, cmd *domain.AgentCommand) error {
+	return response.Accepted(c, QueuedCommandResponse{
+		Message:   msgAgentCommandQueued,
+		Queued:    true,
+		CommandID: cmd.ID,
+		ExpiresAt: cmd.ExpiresAt.Format(DateTimeFormatISO8601),
+	})
+}
@@ -0,0 +1,144 @@
+package handler
+
+import (
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/paasdeploy/backend/internal/crypto"
+	"github.com/paasdeploy/backend/internal/domain"
+	"github.com/paasdeploy/backend/internal/response"
+	"github.com/paasdeploy/backend/internal/service"
+)
+
+const (
+	maxAPITokenNameLength = 100
+	// apiTokenPrefixLength covers APITokenPrefix plus a few random chars,
+	// enough to tell tokens apart in the UI without weakening them.
+	apiTokenPrefixLength = 12
+)
+
+type APITokenHandler struct {
+	tokenRepo    domain.APITokenRepository
+	auditService *service.AuditService
+	logger       *slog.Logger
+}
+
+func NewAPITokenHandler(tokenRepo domain.APITokenRepository, auditService *service.AuditService, logger *slog.Logger) *APITokenHandler {
+	return &APITokenHandler{
+		tokenRepo:    tokenRepo,
+		auditService: auditService,
+		logger:       logger.With("handler", "api_token"),
+	}
+}
+
+func (h *APITokenHandler) Register(app fiber.Router) {
+	tokens := app.Group(APIPrefix + "/api-tokens")
+	tokens.Get("/", h.ListTokens)
+	tokens.Post("/", h.CreateToken)
+	tokens.Delete("/:id", h.RevokeToken)
+}
+
+type CreateAPITokenRequest struct {
+	Name string `json:"name"`
+	// ExpiresInDays of 0 creates a token that never expires.
+	ExpiresInDays int `json:"expiresInDays"`
+}
+
+// CreateAPITokenResponse is the only time the plain token is returned.
+type CreateAPITokenResponse struct {
+	domain.APIToken
+	Token string `json:"token"`
+}
+
+func (h *APITokenHandler) ListTokens(c *fiber.Ctx) error {
+	user := GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, MsgNotAuthenticated)
+	}
+
+	tokens, err := h.tokenRepo.FindByUserID(c.Context(), user.ID)
+	if err != nil {
+		h.logger.ErrorContext(c.UserContext(), "Failed to list api tokens", "userId", user.ID, "error", err)
+		return response.InternalError(c)
+	}
+
+	return response.OK(c, tokens)
+}
+
+func (h *APITokenHandler) CreateToken(c *fiber.Ctx) error {
+	user := GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, MsgNotAuthenticated)
+	}
+
+	var req CreateAPITokenRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.BadRequest(c, MsgInvalidRequestBody)
+	}
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" || len(req.Name) > maxAPITokenNameLength {
+		return response.BadRequest(c, "name is required and must be at most 100 characters")
+	}
+	if req.ExpiresInDays < 0 {
+		return response.BadRequest(c, "expiresInDays must not be negative")
+	}
+
+	secret, err := crypto.GenerateSessionToken()
+	if err != nil {
+		h.logger.ErrorContext(c.UserContext(), "Failed to generate api token", "error", err)
+		return response.InternalError(c)
+	}
+	plain := domain.APITokenPrefix + secret
+
+	input := domain.CreateAPITokenInput{
+		UserID:      user.ID,
+		Name:        req.Name,
+		TokenHash:   crypto.HashSessionToken(plain),
+		TokenPrefix: plain[:apiTokenPrefixLength],
+	}
+	if req.ExpiresInDays > 0 {
+		expiresAt := time.Now().AddDate(0, 0, req.ExpiresInDays)
+		input.ExpiresAt = &expiresAt
+	}
+
+	token, err := h.tokenRepo.Create(c.Context(), input)
+	if err != nil {
+		h.logger.ErrorContext(c.UserContext(), "Failed to create api token", "userId", user.ID, "error", err)
+		return response.InternalError(c)
+	}
+
+	if h.auditService != nil {
+		h.auditService.LogAPITokenCreated(c.Context(), h.auditService.ExtractContext(c), token.ID, token.Name)
+	}
+
+	return response.Created(c, CreateAPITokenResponse{APIToken: *token, Token: plain})
+}
+
+func (h *APITokenHandler) RevokeToken(c *fiber.Ctx) error {
+	user := GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, MsgNotAuthenticated)
+	}
+
+	id := c.Params("id")
+	if _, err := uuid.Parse(id); err != nil {
+		return response.NotFound(c, "API token not found")
+	}
+	if err := h.tokenRepo.Delete(c.Context(), id, user.ID); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return response.NotFound(c, "API token not found")
+		}
+		h.logger.ErrorContext(c.UserContext(), "Failed to revoke api token", "id", id, "error", err)
+		return response.InternalError(c)
+	}
+
+	if h.auditService != nil {
+		h.auditService.LogAPITokenRevoked(c.Context(), h.auditService.ExtractContext(c), id)
+	}
+
+	return response.NoContent(c)
+}
@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/paasdeploy/backend/internal/domain"
+	"github.com/paasdeploy/backend/internal/response"
+)
+
+type AppActivityResponse struct {
+	Activity []domain.AppActivity `json:"activity"`
+	Total    int                  `json:"total"`
+	Limit    int                  `json:"limit"`
+}
+
+// GetAppActivity returns the app's deployments, env var and domain changes,
+// scaling and container actions from the audit log, newest first. It accepts
+// the audit log filters and cursor.
+func (h *AppAdminHandler) GetAppActivity(c *fiber.Ctx) error {
+	app, ok, err := h.requireAppForUser(c)
+	if !ok {
+		return err
+	}
+	if h.auditService == nil {
+		return response.OK(c, AppActivityResponse{Activity: []domain.AppActivity{}})
+	}
+
+	filter, err := buildAuditFilter(c)
+	if err != nil {
+		return response.BadRequest(c, err.Error())
+	}
+	filter.AppID = &app.ID
+
+	logs, total, err := h.auditService.Query(filter)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidInput) {
+			return response.BadRequest(c, err.Error())
+		}
+		h.logger.ErrorContext(c.UserContext(), "Failed to load app activity", "appId", app.ID, "error", err)
+		return response.InternalError(c)
+	}
+
+	activity := make([]domain.AppActivity, len(logs))
+	for i, log := range logs {
+		activity[i] = domain.NewAppActivity(log)
+	}
+
+	var nextCursor string
+	if len(logs) > 0 && len(logs) >= filter.Limit {
+		last := logs[len(logs)-1]
+		nextCursor = domain.Cursor{SortBy: auditLogSort, Value: domain.CursorTime(last.CreatedAt), ID: last.ID}.Encode()
+	}
+
+	return response.OKWithCursor(c, AppActivityResponse{
+		Activity: activity,
+		Total:    total,
+		Limit:    filter.Limit,
+	}, nextCursor)
+}
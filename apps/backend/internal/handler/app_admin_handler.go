@@ -12,10 +12,12 @@ import (
 	"strings"
 
 	"github.com/gofiber/fiber/v2"
+	pb "github.com/paasdeploy/backend/gen/go/flowdeploy/v1"
 	"github.com/paasdeploy/backend/internal/agentclient"
 	"github.com/paasdeploy/backend/internal/domain"
 	"github.com/paasdeploy/backend/internal/engine"
 	"github.com/paasdeploy/backend/internal/response"
+	"github.com/paasdeploy/backend/internal/service"
 	"github.com/paasdeploy/shared/pkg/compose"
 )
 
@@ -26,8 +28,11 @@ type AppAdminHandler struct {
 	envVarRepo       domain.EnvVarRepository
 	engine           *engine.Engine
 	agentClient      *agentclient.AgentClient
+	commandRepo      domain.AgentCommandRepository
+	auditService     *service.AuditService
 	agentPort        int
 	dataDir          string
+	apiBaseURL       string
 	logger           *slog.Logger
 }
 
@@ -38,8 +43,11 @@ type AppAdminHandlerConfig struct {
 	EnvVarRepo       domain.EnvVarRepository
 	Engine           *engine.Engine
 	AgentClient      *agentclient.AgentClient
+	CommandRepo      domain.AgentCommandRepository
+	AuditService     *service.AuditService
 	AgentPort        int
 	DataDir          string
+	APIBaseURL       string
 	Logger           *slog.Logger
 }
 
@@ -51,8 +59,11 @@ func NewAppAdminHandler(cfg AppAdminHandlerConfig) *AppAdminHandler {
 		envVarRepo:       cfg.EnvVarRepo,
 		engine:           cfg.Engine,
 		agentClient:      cfg.AgentClient,
+		commandRepo:      cfg.CommandRepo,
+		auditService:     cfg.AuditService,
 		agentPort:        cfg.AgentPort,
 		dataDir:          cfg.DataDir,
+		apiBaseURL:       cfg.APIBaseURL,
 		logger:           cfg.Logger.With("handler", "app_admin"),
 	}
 }
@@ -67,20 +78,35 @@ func (h *AppAdminHandler) Register(app fiber.Router) {
 	apps.Post("/:id/container/stop", h.StopContainer)
 	apps.Post("/:id/container/start", h.StartContainer)
 	apps.Patch("/:id", h.UpdateApp)
+	apps.Put("/:id/rate-limit", h.UpdateRateLimit)
+	apps.Delete("/:id/rate-limit", h.RemoveRateLimit)
+	apps.Put("/:id/redirects", h.UpdateRedirects)
+	apps.Put("/:id/security-headers", h.UpdateSecurityHeaders)
+	apps.Delete("/:id/security-headers", h.RemoveSecurityHeaders)
+	apps.Put("/:id/internal", h.UpdateInternal)
+	apps.Put("/:id/links", h.UpdateLinkedApps)
+	apps.Get("/:id/analytics", h.GetAppAnalytics)
+	apps.Get("/:id/activity", h.GetAppActivity)
+	apps.Put("/:id/stats-interval", h.UpdateStatsInterval)
+	apps.Post("/:id/maintenance", h.UpdateMaintenance)
+	apps.Put("/:id/auto-heal", h.UpdateAutoHeal)
 }
 
-func (h *AppAdminHandler) requireAppForUser(c *fiber.Ctx) (*domain.App, error) {
+// requireAppForUser loads the app of the route for the user. When it
+// returns false it has already sent the error response, which the caller
+// returns.
+func (h *AppAdminHandler) requireAppForUser(c *fiber.Ctx) (*domain.App, bool, error) {
 	user := GetUserFromContext(c)
 	if user == nil {
-		return nil, response.Unauthorized(c, MsgNotAuthenticated)
+		return nil, false, response.Unauthorized(c, MsgNotAuthenticated)
 	}
 
 	id := c.Params("id")
 	app, err := h.appRepo.FindByIDAndUserID(id, user.ID)
 	if err != nil {
-		return nil, response.NotFound(c, MsgAppNotFound)
+		return nil, false, response.NotFound(c, MsgAppNotFound)
 	}
-	return app, nil
+	return app, true, nil
 }
 
 type AppURLResponse struct {
@@ -90,8 +116,8 @@ type AppURLResponse struct {
 }
 
 func (h *AppAdminHandler) GetAppURL(c *fiber.Ctx) error {
-	app, err := h.requireAppForUser(c)
-	if err != nil {
+	app, ok, err := h.requireAppForUser(c)
+	if !ok {
 		return err
 	}
 
@@ -161,6 +187,24 @@ type AppConfigResponse struct {
 	Resources   ResourcesConfig        `json:"resources"`
 	Domains     []string               `json:"domains"`
 	Volumes     []VolumeConfigResponse `json:"volumes"`
+	Compress    bool                   `json:"compress"`
+	Sticky      *StickySessionsConfig  `json:"stickySessions"`
+	Ports       []PortConfigResponse   `json:"ports"`
+}
+
+type PortConfigResponse struct {
+	Port       int    `json:"port"`
+	Protocol   string `json:"protocol"`
+	Entrypoint string `json:"entrypoint,omitempty"`
+	HostPort   int    `json:"hostPort,omitempty"`
+	Mesh       bool   `json:"mesh,omitempty"`
+}
+
+type StickySessionsConfig struct {
+	CookieName string `json:"cookieName"`
+	Secure     bool   `json:"secure"`
+	HTTPOnly   bool   `json:"httpOnly"`
+	SameSite   string `json:"sameSite"`
 }
 
 type HealthcheckConfig struct {
@@ -177,8 +221,8 @@ type ResourcesConfig struct {
 }
 
 func (h *AppAdminHandler) GetAppConfig(c *fiber.Ctx) error {
-	app, err := h.requireAppForUser(c)
-	if err != nil {
+	app, ok, err := h.requireAppForUser(c)
+	if !ok {
 		return err
 	}
 
@@ -191,11 +235,18 @@ func (h *AppAdminHandler) GetAppConfig(c *fiber.Ctx) error {
 		return response.NotFound(c, "config not found - app may not be deployed yet")
 	}
 
+	config.Resources.Memory, config.Resources.CPU = app.Resources().Override(config.Resources.Memory, config.Resources.CPU)
+
 	var volumes []VolumeConfigResponse
 	for _, v := range config.Volumes {
 		volumes = append(volumes, VolumeConfigResponse(v))
 	}
 
+	domainNames := make([]string, 0, len(config.Domains))
+	for _, d := range config.Domains {
+		domainNames = append(domainNames, d.Domain)
+	}
+
 	return response.OK(c, AppConfigResponse{
 		Name:     config.Name,
 		Port:     config.Port,
@@ -211,14 +262,18 @@ func (h *AppAdminHandler) GetAppConfig(c *fiber.Ctx) error {
 			Memory: config.Resources.Memory,
 			CPU:    config.Resources.CPU,
 		},
-		Domains: config.Domains,
-		Volumes: volumes,
+		Domains:  domainNames,
+		Volumes:  volumes,
+		Compress: config.Compress,
+		Sticky:   config.Sticky,
+		Ports:    config.Ports,
 	})
 }
 
 func (h *AppAdminHandler) getRemoteAppConfig(c *fiber.Ctx, app *domain.App) error {
 	defaults := &compose.Config{}
 	compose.ApplyDefaults(defaults)
+	defaults.Resources.Memory, defaults.Resources.CPU = app.Resources().Override(defaults.Resources.Memory, defaults.Resources.CPU)
 
 	port := h.resolveAppPort(app.ID)
 
@@ -304,34 +359,24 @@ func (h *AppAdminHandler) resolveServerHost(app *domain.App) (string, error) {
 }
 
 func (h *AppAdminHandler) executeContainerAction(c *fiber.Ctx, action containerAction) error {
-	app, err := h.requireAppForUser(c)
-	if err != nil {
+	app, ok, err := h.requireAppForUser(c)
+	if !ok {
 		return err
 	}
 
-	var execErr error
-	if h.isRemoteApp(app) {
-		host, hostErr := h.resolveServerHost(app)
-		if hostErr != nil {
-			execErr = hostErr
-		} else {
-			switch action.name {
-			case "restart":
-				execErr = h.agentClient.RestartContainer(c.Context(), host, h.agentPort, app.Name)
-			case "stop":
-				execErr = h.agentClient.StopContainer(c.Context(), host, h.agentPort, app.Name)
-			case "start":
-				execErr = h.agentClient.StartContainer(c.Context(), host, h.agentPort, app.Name)
-			default:
-				execErr = fmt.Errorf("unknown action: %s", action.name)
-			}
-		}
-	} else {
-		execErr = action.do(c.Context(), app.Name)
+	queued, execErr := h.runContainerAction(c.Context(), app, action)
+	if (queued || execErr == nil) && h.auditService != nil {
+		h.auditService.LogAppContainerAction(c.Context(), h.auditService.ExtractContext(c), app.ID, app.Name, action.name)
+	}
+	if queued {
+		return response.OK(c, ContainerActionResponse{
+			Success: true,
+			Message: msgAgentCommandQueued,
+		})
 	}
 
 	if execErr != nil {
-		h.logger.Error("Failed to "+action.name+" container", "appId", app.ID, "appName", app.Name, "error", execErr)
+		h.logger.ErrorContext(c.UserContext(), "Failed to "+action.name+" container", "appId", app.ID, "appName", app.Name, "error", execErr)
 		return response.OK(c, ContainerActionResponse{
 			Success: false,
 			Message: action.fail,
@@ -344,6 +389,52 @@ func (h *AppAdminHandler) executeContainerAction(c *fiber.Ctx, action containerA
 	})
 }
 
+// runContainerAction applies action to the container of app. For a remote
+// app whose agent is unreachable the action is queued for the agent instead,
+// which is reported as queued with no error.
+func (h *AppAdminHandler) runContainerAction(ctx context.Context, app *domain.App, action containerAction) (bool, error) {
+	if !h.isRemoteApp(app) {
+		return false, action.do(ctx, app.Name)
+	}
+
+	var execErr error
+	host, hostErr := h.resolveServerHost(app)
+	if hostErr != nil {
+		execErr = hostErr
+	} else {
+		switch action.name {
+		case "restart":
+			execErr = h.agentClient.RestartContainer(ctx, host, h.agentPort, app.Name)
+		case "stop":
+			execErr = h.agentClient.StopContainer(ctx, host, h.agentPort, app.Name)
+		case "start":
+			execErr = h.agentClient.StartContainer(ctx, host, h.agentPort, app.Name)
+		default:
+			execErr = fmt.Errorf("unknown action: %s", action.name)
+		}
+	}
+	if cmd := h.queueContainerAction(execErr, app, action.name); cmd != nil {
+		return true, nil
+	}
+	return false, execErr
+}
+
+func (h *AppAdminHandler) queueContainerAction(execErr error, app *domain.App, action string) *domain.AgentCommand {
+	if execErr == nil {
+		return nil
+	}
+	switch action {
+	case "restart":
+		return enqueueIfUnreachable(h.commandRepo, execErr, *app.ServerID, domain.AgentCommandRestartContainer, &pb.RestartContainerRequest{ContainerId: app.Name})
+	case "stop":
+		return enqueueIfUnreachable(h.commandRepo, execErr, *app.ServerID, domain.AgentCommandStopContainer, &pb.StopContainerRequest{ContainerId: app.Name})
+	case "start":
+		return enqueueIfUnreachable(h.commandRepo, execErr, *app.ServerID, domain.AgentCommandStartContainer, &pb.StartContainerRequest{ContainerId: app.Name})
+	default:
+		return nil
+	}
+}
+
 func (h *AppAdminHandler) RestartContainer(c *fiber.Ctx) error {
 	return h.executeContainerAction(c, containerAction{
 		name:    "restart",
@@ -371,15 +462,39 @@ func (h *AppAdminHandler) StartContainer(c *fiber.Ctx) error {
 	})
 }
 
+func (h *AppAdminHandler) StopApp(ctx context.Context, app *domain.App) error {
+	_, err := h.runContainerAction(ctx, app, containerAction{name: "stop", do: h.engine.StopContainer})
+	return err
+}
+
+func (h *AppAdminHandler) StartApp(ctx context.Context, app *domain.App) error {
+	_, err := h.runContainerAction(ctx, app, containerAction{name: "start", do: h.engine.StartContainer})
+	return err
+}
+
 type UpdateAppInput struct {
 	Name    *string `json:"name,omitempty"`
 	Branch  *string `json:"branch,omitempty"`
 	Workdir *string `json:"workdir,omitempty"`
 }
 
+func (i UpdateAppInput) fields() []string {
+	var fields []string
+	if i.Name != nil {
+		fields = append(fields, "name")
+	}
+	if i.Branch != nil {
+		fields = append(fields, "branch")
+	}
+	if i.Workdir != nil {
+		fields = append(fields, "workdir")
+	}
+	return fields
+}
+
 func (h *AppAdminHandler) UpdateApp(c *fiber.Ctx) error {
-	app, err := h.requireAppForUser(c)
-	if err != nil {
+	app, ok, err := h.requireAppForUser(c)
+	if !ok {
 		return err
 	}
 
@@ -411,6 +526,10 @@ func (h *AppAdminHandler) UpdateApp(c *fiber.Ctx) error {
 		return response.InternalError(c)
 	}
 
+	if h.auditService != nil {
+		h.auditService.LogAppUpdated(c.Context(), h.auditService.ExtractContext(c), app.ID, updatedApp.Name, input.fields())
+	}
+
 	return response.OK(c, updatedApp)
 }
 
@@ -444,23 +563,38 @@ type paasDeployConfig struct {
 		Memory string `json:"memory"`
 		CPU    string `json:"cpu"`
 	} `json:"resources"`
-	Domains []string                  `json:"domains,omitempty"`
+	Domains []compose.DomainConfig    `json:"domains,omitempty"`
 	Volumes []paasDeployVolumeConfig  `json:"volumes,omitempty"`
+	Compress bool                     `json:"compress,omitempty"`
+	Sticky   *StickySessionsConfig    `json:"stickySessions,omitempty"`
+	Ports    []PortConfigResponse     `json:"ports,omitempty"`
 }
 
 func (h *AppAdminHandler) readAppConfig(appID, workdir string) (*paasDeployConfig, error) {
 	repoDir := filepath.Join(h.dataDir, appID)
 	sandboxed := os.DirFS(repoDir)
 
-	configRelPath := filepath.Join(workdir, "paasdeploy.json")
-	data, err := fs.ReadFile(sandboxed, configRelPath)
+	var name string
+	var data []byte
+	var err error
+	for _, name = range compose.ConfigFileNames {
+		data, err = fs.ReadFile(sandboxed, filepath.Join(workdir, name))
+		if err == nil {
+			break
+		}
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to read paasdeploy.json: %w", err)
 	}
 
+	data, err = compose.ConfigJSON(name, data)
+	if err != nil {
+		return nil, err
+	}
+
 	var config paasDeployConfig
 	if err := json.Unmarshal(data, &config); err != nil {
-		return nil, fmt.Errorf("invalid paasdeploy.json: %w", err)
+		return nil, fmt.Errorf("invalid %s: %w", name, err)
 	}
 
 	if config.Port == 0 {
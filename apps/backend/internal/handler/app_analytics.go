@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/paasdeploy/backend/internal/response"
+)
+
+var analyticsPeriods = map[string]time.Duration{
+	"1h":  time.Hour,
+	"24h": 24 * time.Hour,
+	"7d":  7 * 24 * time.Hour,
+}
+
+type DomainAnalytics struct {
+	Domain       string          `json:"domain"`
+	Requests     int64           `json:"requests"`
+	StatusCodes  map[int32]int64 `json:"statusCodes"`
+	P95LatencyMs float64         `json:"p95LatencyMs"`
+}
+
+type AppAnalyticsResponse struct {
+	Period  string            `json:"period"`
+	Since   time.Time         `json:"since"`
+	Domains []DomainAnalytics `json:"domains"`
+}
+
+// GetAppAnalytics reports request counts, status codes and p95 latency per
+// domain of the app, aggregated by the agent from Traefik's access log.
+func (h *AppAdminHandler) GetAppAnalytics(c *fiber.Ctx) error {
+	app, ok, err := h.requireAppForUser(c)
+	if !ok {
+		return err
+	}
+
+	period := c.Query("period", "24h")
+	window, ok := analyticsPeriods[period]
+	if !ok {
+		return response.BadRequest(c, "period must be 1h, 24h or 7d")
+	}
+	if !h.isRemoteApp(app) {
+		return response.BadRequest(c, "Analytics are only available for apps on remote servers")
+	}
+	host, err := h.resolveServerHost(app)
+	if err != nil {
+		return response.InternalError(c)
+	}
+
+	customDomains, err := h.customDomainRepo.FindByAppID(c.Context(), app.ID)
+	if err != nil {
+		h.logger.ErrorContext(c.UserContext(), "Failed to load domains", "appId", app.ID, "error", err)
+		return response.InternalError(c)
+	}
+	since := time.Now().Add(-window).UTC()
+	resp := AppAnalyticsResponse{Period: period, Since: since, Domains: []DomainAnalytics{}}
+	if len(customDomains) == 0 {
+		return response.OK(c, resp)
+	}
+
+	domains := make([]string, 0, len(customDomains))
+	for _, d := range customDomains {
+		domains = append(domains, strings.ToLower(d.Domain))
+	}
+
+	stats, err := h.agentClient.GetAccessLogStats(c.Context(), host, h.agentPort, domains, since)
+	if err != nil {
+		h.logger.ErrorContext(c.UserContext(), "Failed to get access log stats", "appId", app.ID, "error", err)
+		return response.ServerError(c, fiber.StatusBadGateway, "Failed to read analytics from server")
+	}
+
+	seen := make(map[string]bool, len(stats))
+	for _, s := range stats {
+		seen[s.Domain] = true
+		resp.Domains = append(resp.Domains, DomainAnalytics{
+			Domain:       s.Domain,
+			Requests:     s.Requests,
+			StatusCodes:  s.StatusCodes,
+			P95LatencyMs: s.P95LatencyMs,
+		})
+	}
+	for _, d := range domains {
+		if !seen[d] {
+			seen[d] = true
+			resp.Domains = append(resp.Domains, DomainAnalytics{Domain: d, StatusCodes: map[int32]int64{}})
+		}
+	}
+	sort.Slice(resp.Domains, func(i, j int) bool { return resp.Domains[i].Domain < resp.Domains[j].Domain })
+	return response.OK(c, resp)
+}
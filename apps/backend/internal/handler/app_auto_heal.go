@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/paasdeploy/backend/internal/domain"
+	"github.com/paasdeploy/backend/internal/response"
+)
+
+type UpdateAutoHealRequest struct {
+	Enabled          bool `json:"enabled"`
+	UnhealthySeconds int  `json:"unhealthySeconds"`
+	MaxRestarts      int  `json:"maxRestarts"`
+}
+
+// UpdateAutoHeal sets or clears the app's auto-heal policy. Unset fields of
+// an enabled policy take the defaults.
+func (h *AppAdminHandler) UpdateAutoHeal(c *fiber.Ctx) error {
+	app, ok, err := h.requireAppForUser(c)
+	if !ok {
+		return err
+	}
+
+	var req UpdateAutoHealRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.BadRequest(c, MsgInvalidRequestBody)
+	}
+
+	var policy *domain.AppAutoHeal
+	if req.Enabled {
+		policy = &domain.AppAutoHeal{UnhealthySeconds: req.UnhealthySeconds, MaxRestarts: req.MaxRestarts}
+		if err := policy.Normalize(); err != nil {
+			return response.BadRequest(c, err.Error())
+		}
+	}
+
+	if err := h.appRepo.UpdateAutoHeal(app.ID, policy); err != nil {
+		h.logger.ErrorContext(c.UserContext(), "Failed to update auto-heal policy", "appId", app.ID, "error", err)
+		return response.InternalError(c)
+	}
+	app.AutoHeal = policy
+
+	if h.auditService != nil {
+		auditCtx := h.auditService.ExtractContext(c)
+		h.auditService.LogAppAutoHeal(c.Context(), auditCtx, app.ID, app.Name, policy)
+	}
+	return response.OK(c, app)
+}
@@ -0,0 +1,382 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"github.com/paasdeploy/backend/internal/domain"
+	"github.com/paasdeploy/backend/internal/response"
+	"github.com/paasdeploy/backend/internal/service"
+)
+
+const (
+	maxBulkApps       = 200
+	appBatchRetention = 24 * time.Hour
+	// localServerID selects the apps on the control plane.
+	localServerID = "local"
+
+	AppBatchRunning   = "running"
+	AppBatchCompleted = "completed"
+
+	AppBulkRestart  = "restart"
+	AppBulkStop     = "stop"
+	AppBulkStart    = "start"
+	AppBulkRedeploy = "redeploy"
+
+	AppBulkSucceeded = "succeeded"
+	AppBulkQueued    = "queued"
+	AppBulkFailed    = "failed"
+)
+
+// BulkAppActionRequest selects apps by ID or, with serverId, every app on
+// a server ("local" for apps on the control plane).
+type BulkAppActionRequest struct {
+	Action      string   `json:"action"`
+	AppIDs      []string `json:"appIds,omitempty"`
+	ServerID    string   `json:"serverId,omitempty"`
+	Concurrency int      `json:"concurrency,omitempty"`
+}
+
+type AppBulkResult struct {
+	AppID        string `json:"appId"`
+	AppName      string `json:"appName"`
+	Status       string `json:"status"`
+	DeploymentID string `json:"deploymentId,omitempty"`
+	Error        string `json:"error,omitempty"`
+}
+
+// AppBatch summarizes a bulk action. Each app's result is streamed over SSE
+// as it finishes.
+type AppBatch struct {
+	ID         string          `json:"id"`
+	Action     string          `json:"action"`
+	Status     string          `json:"status"`
+	Total      int             `json:"total"`
+	Completed  int             `json:"completed"`
+	Failed     int             `json:"failed"`
+	Results    []AppBulkResult `json:"results"`
+	StartedAt  time.Time       `json:"startedAt"`
+	FinishedAt *time.Time      `json:"finishedAt,omitempty"`
+	userID     string
+}
+
+func (b *AppBatch) snapshot() AppBatch {
+	cp := *b
+	cp.Results = append([]AppBulkResult{}, b.Results...)
+	return cp
+}
+
+type appBatchStore struct {
+	mu      sync.Mutex
+	batches map[string]*AppBatch
+}
+
+func newAppBatchStore() *appBatchStore {
+	return &appBatchStore{batches: make(map[string]*AppBatch)}
+}
+
+// add registers a batch and drops finished batches past their retention.
+func (s *appBatchStore) add(batch *AppBatch) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id, b := range s.batches {
+		if b.FinishedAt != nil && time.Since(*b.FinishedAt) > appBatchRetention {
+			delete(s.batches, id)
+		}
+	}
+	s.batches[batch.ID] = batch
+}
+
+func (s *appBatchStore) get(id, userID string) (AppBatch, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b, ok := s.batches[id]
+	if !ok || b.userID != userID {
+		return AppBatch{}, false
+	}
+	return b.snapshot(), true
+}
+
+func (s *appBatchStore) record(batch *AppBatch, result AppBulkResult) AppBatch {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if result.Status == AppBulkFailed {
+		batch.Failed++
+	} else {
+		batch.Completed++
+	}
+	batch.Results = append(batch.Results, result)
+	return batch.snapshot()
+}
+
+func (s *appBatchStore) finish(batch *AppBatch) AppBatch {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	batch.Status = AppBatchCompleted
+	batch.FinishedAt = &now
+	return batch.snapshot()
+}
+
+type AppBulkHandler struct {
+	appRepo      domain.AppRepository
+	serverRepo   domain.ServerRepository
+	appService   *service.AppService
+	appAdmin     *AppAdminHandler
+	sseHandler   *SSEHandler
+	auditService *service.AuditService
+	batches      *appBatchStore
+	logger       *slog.Logger
+}
+
+func NewAppBulkHandler(
+	appRepo domain.AppRepository,
+	serverRepo domain.ServerRepository,
+	appService *service.AppService,
+	appAdmin *AppAdminHandler,
+	sseHandler *SSEHandler,
+	auditService *service.AuditService,
+	logger *slog.Logger,
+) *AppBulkHandler {
+	return &AppBulkHandler{
+		appRepo:      appRepo,
+		serverRepo:   serverRepo,
+		appService:   appService,
+		appAdmin:     appAdmin,
+		sseHandler:   sseHandler,
+		auditService: auditService,
+		batches:      newAppBatchStore(),
+		logger:       logger.With("handler", "app_bulk"),
+	}
+}
+
+func (h *AppBulkHandler) Register(app fiber.Router) {
+	bulk := app.Group(APIPrefix + "/apps/bulk-actions")
+	bulk.Post("/", h.Start)
+	bulk.Get("/:batchId", h.Get)
+}
+
+// Start runs an action on the selected apps in the background and returns
+// the batch immediately.
+func (h *AppBulkHandler) Start(c *fiber.Ctx) error {
+	user := GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, MsgNotAuthenticated)
+	}
+
+	var req BulkAppActionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.BadRequest(c, MsgInvalidRequestBody)
+	}
+	switch req.Action {
+	case AppBulkRestart, AppBulkStop, AppBulkStart, AppBulkRedeploy:
+	default:
+		return response.BadRequest(c, "action must be restart, stop, start or redeploy")
+	}
+	if len(req.AppIDs) > 0 && req.ServerID != "" {
+		return response.BadRequest(c, "set either appIds or serverId")
+	}
+
+	apps, err := h.selectApps(user.ID, req)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return response.NotFound(c, err.Error())
+		}
+		if errors.Is(err, domain.ErrInvalidInput) {
+			return response.BadRequest(c, err.Error())
+		}
+		h.logger.ErrorContext(c.UserContext(), "Failed to select apps for bulk action", "error", err)
+		return response.InternalError(c)
+	}
+
+	batch := h.startBatch(c.UserContext(), user.ID, req.Action, apps, req.Concurrency, h.auditContext(c))
+	return response.Accepted(c, batch)
+}
+
+func (h *AppBulkHandler) Get(c *fiber.Ctx) error {
+	user := GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, MsgNotAuthenticated)
+	}
+	batch, ok := h.batches.get(c.Params("batchId"), user.ID)
+	if !ok {
+		return response.NotFound(c, "bulk action not found")
+	}
+	return response.OK(c, batch)
+}
+
+func (h *AppBulkHandler) selectApps(userID string, req BulkAppActionRequest) ([]domain.App, error) {
+	if req.ServerID != "" {
+		return h.serverApps(userID, req.ServerID)
+	}
+	if len(req.AppIDs) == 0 {
+		return nil, fmt.Errorf("%w: appIds or serverId is required", domain.ErrInvalidInput)
+	}
+	if len(req.AppIDs) > maxBulkApps {
+		return nil, fmt.Errorf("%w: at most %d apps can be selected at once", domain.ErrInvalidInput, maxBulkApps)
+	}
+
+	found, err := h.appRepo.FindByIDsAndUserID(req.AppIDs, userID)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]domain.App, len(found))
+	for _, app := range found {
+		byID[app.ID] = app
+	}
+
+	apps := make([]domain.App, 0, len(req.AppIDs))
+	seen := make(map[string]bool, len(req.AppIDs))
+	for _, id := range req.AppIDs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		app, ok := byID[id]
+		if !ok {
+			return nil, fmt.Errorf("%w: app %s", domain.ErrNotFound, id)
+		}
+		apps = append(apps, app)
+	}
+	return apps, nil
+}
+
+func (h *AppBulkHandler) serverApps(userID, serverID string) ([]domain.App, error) {
+	filter := domain.AppFilter{LocalOnly: serverID == localServerID}
+	if !filter.LocalOnly {
+		if _, err := h.serverRepo.FindByIDForUser(serverID, userID); err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return nil, fmt.Errorf("%w: server %s", domain.ErrNotFound, serverID)
+			}
+			return nil, err
+		}
+		filter.ServerID = serverID
+	}
+
+	page, err := h.appRepo.FindPageByUserID(userID, filter, domain.ListOptions{SortBy: "name"})
+	if err != nil {
+		return nil, err
+	}
+	if len(page.Items) == 0 {
+		return nil, fmt.Errorf("%w: the server has no apps", domain.ErrInvalidInput)
+	}
+	return page.Items, nil
+}
+
+func (h *AppBulkHandler) auditContext(c *fiber.Ctx) service.AuditContext {
+	if h.auditService == nil {
+		return service.AuditContext{}
+	}
+	return h.auditService.ExtractContext(c)
+}
+
+// startBatch runs the action with at most concurrency apps at a time and
+// returns the initial batch state. ctx carries the request's trace ID and
+// must not be cancelled when the request ends.
+func (h *AppBulkHandler) startBatch(ctx context.Context, userID, action string, apps []domain.App, concurrency int, auditCtx service.AuditContext) AppBatch {
+	batch := &AppBatch{
+		ID:        uuid.NewString(),
+		Action:    action,
+		Status:    AppBatchRunning,
+		Total:     len(apps),
+		Results:   []AppBulkResult{},
+		StartedAt: time.Now(),
+		userID:    userID,
+	}
+	h.batches.add(batch)
+	initial := batch.snapshot()
+
+	if h.auditService != nil {
+		appIDs := make([]string, len(apps))
+		for i := range apps {
+			appIDs[i] = apps[i].ID
+		}
+		h.auditService.LogAppBulkAction(ctx, auditCtx, batch.ID, action, appIDs)
+	}
+
+	go func() {
+		sem := make(chan struct{}, clampConcurrency(concurrency))
+		var wg sync.WaitGroup
+		for i := range apps {
+			wg.Add(1)
+			sem <- struct{}{}
+			go func(app *domain.App) {
+				defer wg.Done()
+				defer func() { <-sem }()
+
+				result := h.runAction(ctx, action, app, auditCtx)
+				if result.Status == AppBulkFailed {
+					h.logger.ErrorContext(ctx, "Bulk action failed", "action", action, "appId", app.ID, "batchId", batch.ID, "error", result.Error)
+				}
+				progress := h.batches.record(batch, result)
+				if h.sseHandler != nil {
+					h.sseHandler.EmitAppBatchProgress(progress, app.ID)
+				}
+			}(&apps[i])
+		}
+		wg.Wait()
+
+		summary := h.batches.finish(batch)
+		h.logger.InfoContext(ctx, "bulk action finished", "batchId", summary.ID, "action", action, "total", summary.Total, "failed", summary.Failed)
+		if h.sseHandler != nil {
+			h.sseHandler.EmitAppBatchProgress(summary, "")
+			h.sseHandler.EmitInvalidate("apps")
+		}
+	}()
+
+	return initial
+}
+
+func (h *AppBulkHandler) runAction(ctx context.Context, action string, app *domain.App, auditCtx service.AuditContext) AppBulkResult {
+	result := AppBulkResult{AppID: app.ID, AppName: app.Name, Status: AppBulkSucceeded}
+
+	if action == AppBulkRedeploy {
+		deployment, err := h.appService.TriggerDeploy(ctx, app.ID, "")
+		if err != nil {
+			result.Status = AppBulkFailed
+			result.Error = bulkDeployError(err)
+			return result
+		}
+		result.DeploymentID = deployment.ID
+		if h.auditService != nil {
+			h.auditService.LogDeployStarted(ctx, auditCtx, deployment.ID, app.ID, app.Name, deployment.CommitSHA)
+		}
+		return result
+	}
+
+	queued, err := h.appAdmin.runContainerAction(ctx, app, h.containerAction(action))
+	switch {
+	case err != nil:
+		result.Status = AppBulkFailed
+		result.Error = err.Error()
+	case queued:
+		result.Status = AppBulkQueued
+	}
+	return result
+}
+
+func (h *AppBulkHandler) containerAction(action string) containerAction {
+	eng := h.appAdmin.engine
+	switch action {
+	case AppBulkStop:
+		return containerAction{name: action, do: eng.StopContainer}
+	case AppBulkStart:
+		return containerAction{name: action, do: eng.StartContainer}
+	default:
+		return containerAction{name: AppBulkRestart, do: eng.RestartContainer}
+	}
+}
+
+func bulkDeployError(err error) string {
+	if errors.Is(err, domain.ErrDeployInProgress) {
+		return "deployment already in progress"
+	}
+	return err.Error()
+}
@@ -0,0 +1,143 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/paasdeploy/backend/internal/domain"
+)
+
+func newTestAppBulkHandler() *AppBulkHandler {
+	return NewAppBulkHandler(
+		&fakeAppRepo{apps: map[string]string{"web": testOwner.ID, "api": testOwner.ID, "other": "user-2"}},
+		&fakeServerRepo{servers: map[string]domain.Server{}},
+		nil, nil, nil, nil,
+		testLogger(),
+	)
+}
+
+func TestAppBulkSelectAppsKeepsRequestOrderWithoutDuplicates(t *testing.T) {
+	h := newTestAppBulkHandler()
+
+	apps, err := h.selectApps(testOwner.ID, BulkAppActionRequest{AppIDs: []string{"api", "web", "api"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for _, app := range apps {
+		ids = append(ids, app.ID)
+	}
+	if want := []string{"api", "web"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("selected %v, want %v", ids, want)
+	}
+}
+
+func TestAppBulkSelectAppsRejectsOtherUsersApps(t *testing.T) {
+	h := newTestAppBulkHandler()
+
+	_, err := h.selectApps(testOwner.ID, BulkAppActionRequest{AppIDs: []string{"web", "other"}})
+	if !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("selectApps = %v, want ErrNotFound", err)
+	}
+}
+
+func TestAppBulkSelectAppsLimitsSelection(t *testing.T) {
+	h := newTestAppBulkHandler()
+	ids := make([]string, maxBulkApps+1)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("app-%d", i)
+	}
+
+	if _, err := h.selectApps(testOwner.ID, BulkAppActionRequest{AppIDs: ids}); !errors.Is(err, domain.ErrInvalidInput) {
+		t.Errorf("selectApps with %d apps = %v, want ErrInvalidInput", len(ids), err)
+	}
+	if _, err := h.selectApps(testOwner.ID, BulkAppActionRequest{}); !errors.Is(err, domain.ErrInvalidInput) {
+		t.Errorf("selectApps without a selection = %v, want ErrInvalidInput", err)
+	}
+}
+
+func TestAppBulkStartValidatesRequest(t *testing.T) {
+	h := newTestAppBulkHandler()
+	app := newTestApp(testOwner)
+	h.Register(app)
+
+	tests := []struct {
+		name string
+		body string
+		want int
+	}{
+		{"unknown action", `{"action":"delete","appIds":["web"]}`, http.StatusBadRequest},
+		{"apps and server", `{"action":"restart","appIds":["web"],"serverId":"s1"}`, http.StatusBadRequest},
+		{"unknown server", `{"action":"restart","serverId":"s1"}`, http.StatusNotFound},
+		{"unknown app", `{"action":"stop","appIds":["other"]}`, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := doRequest(t, app, http.MethodPost, APIPrefix+"/apps/bulk-actions/", tt.body)
+			if resp.StatusCode != tt.want {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.want)
+			}
+		})
+	}
+
+	anonymous := newTestApp(nil)
+	h.Register(anonymous)
+	resp := doRequest(t, anonymous, http.MethodPost, APIPrefix+"/apps/bulk-actions/", `{"action":"restart","appIds":["web"]}`)
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("anonymous status = %d, want %d", resp.StatusCode, http.StatusUnauthorized)
+	}
+}
+
+func TestAppBatchStoreTracksProgressPerUser(t *testing.T) {
+	store := newAppBatchStore()
+	batch := &AppBatch{ID: "b1", Total: 2, Status: AppBatchRunning, userID: testOwner.ID}
+	store.add(batch)
+
+	store.record(batch, AppBulkResult{AppID: "web", Status: AppBulkSucceeded})
+	progress := store.record(batch, AppBulkResult{AppID: "api", Status: AppBulkFailed, Error: "boom"})
+	if progress.Completed != 1 || progress.Failed != 1 || len(progress.Results) != 2 {
+		t.Errorf("progress = %+v, want one completed and one failed", progress)
+	}
+
+	summary := store.finish(batch)
+	if summary.Status != AppBatchCompleted || summary.FinishedAt == nil {
+		t.Errorf("summary = %+v, want completed", summary)
+	}
+
+	if _, ok := store.get("b1", "user-2"); ok {
+		t.Error("another user can read the batch")
+	}
+	got, ok := store.get("b1", testOwner.ID)
+	if !ok || got.Failed != 1 {
+		t.Errorf("get = %+v, %v", got, ok)
+	}
+}
+
+func TestAppBatchStoreDropsExpiredBatches(t *testing.T) {
+	store := newAppBatchStore()
+	finishedAt := time.Now().Add(-appBatchRetention - time.Minute)
+	store.add(&AppBatch{ID: "old", FinishedAt: &finishedAt, userID: testOwner.ID})
+	store.add(&AppBatch{ID: "running", userID: testOwner.ID})
+	store.add(&AppBatch{ID: "new", userID: testOwner.ID})
+
+	if _, ok := store.get("old", testOwner.ID); ok {
+		t.Error("expired batch was kept")
+	}
+	if _, ok := store.get("running", testOwner.ID); !ok {
+		t.Error("running batch was dropped")
+	}
+}
+
+func TestBulkDeployError(t *testing.T) {
+	if got := bulkDeployError(fmt.Errorf("trigger: %w", domain.ErrDeployInProgress)); got != "deployment already in progress" {
+		t.Errorf("bulkDeployError = %q", got)
+	}
+	if got := bulkDeployError(errors.New("quota exceeded")); !strings.Contains(got, "quota") {
+		t.Errorf("bulkDeployError = %q", got)
+	}
+}
@@ -1,6 +1,8 @@
 package handler
 
 import (
+	"bytes"
+	"errors"
 	"log/slog"
 
 	"github.com/gofiber/fiber/v2"
@@ -38,9 +40,15 @@ func (h *AppHandler) Register(app fiber.Router) {
 	apps := v1.Group("/apps")
 	apps.Get("/", h.ListApps)
 	apps.Post("/", h.CreateApp)
+	apps.Post("/import/heroku/preview", h.PreviewHerokuImport)
+	apps.Post("/import/heroku", h.ImportHerokuApp)
+	apps.Get("/trash", h.ListTrash)
 	apps.Get("/:id", h.GetApp)
 	apps.Delete("/:id", h.DeleteApp)
+	apps.Post("/:id/restore", h.RestoreApp)
 	apps.Get("/:id/deployments", h.ListDeployments)
+	apps.Get("/:id/deployments/:deployId/logs", h.DownloadDeploymentLogs)
+	apps.Get("/:id/deployments/:from/diff/:to", h.DiffDeployments)
 	apps.Post("/:id/redeploy", h.TriggerRedeploy)
 	apps.Post("/:id/rollback", h.TriggerRollback)
 
@@ -50,12 +58,14 @@ func (h *AppHandler) Register(app fiber.Router) {
 	apps.Get("/:id/commits", h.ListCommits)
 }
 
-func (h *AppHandler) requireAuth(c *fiber.Ctx) (*domain.User, error) {
+// requireAuth returns the user of the request. When it returns false it has
+// already sent the error response, which the caller returns.
+func (h *AppHandler) requireAuth(c *fiber.Ctx) (*domain.User, bool, error) {
 	user := GetUserFromContext(c)
 	if user == nil {
-		return nil, response.Unauthorized(c, MsgNotAuthenticated)
+		return nil, false, response.Unauthorized(c, MsgNotAuthenticated)
 	}
-	return user, nil
+	return user, true, nil
 }
 
 // ListApps godoc
@@ -64,21 +74,41 @@ func (h *AppHandler) requireAuth(c *fiber.Ctx) (*domain.User, error) {
 //	@Description	Retorna lista de apps cadastrados no sistema com ultimo deployment
 //	@Tags			apps
 //	@Produce		json
-//	@Success		200	{array}		docs.AppWithDeployment
-//	@Failure		500	{object}	docs.ErrorInfo
+//	@Param			status		query		string	false	"Status do app"
+//	@Param			serverId	query		string	false	"ID do servidor ou local"
+//	@Param			q			query		string	false	"Busca por nome ou repositorio"
+//	@Param			from		query		string	false	"Criado a partir de (RFC 3339 ou data)"
+//	@Param			to			query		string	false	"Criado ate (RFC 3339 ou data)"
+//	@Param			sort		query		string	false	"createdAt, updatedAt ou name; prefixo - para ordem decrescente"
+//	@Param			limit		query		int		false	"Itens por pagina"
+//	@Param			cursor		query		string	false	"Cursor da proxima pagina (meta.nextCursor)"
+//	@Success		200			{array}		docs.AppWithDeployment
+//	@Failure		400			{object}	docs.Problem
+//	@Failure		500			{object}	docs.Problem
 //	@Router			/apps [get]
 func (h *AppHandler) ListApps(c *fiber.Ctx) error {
-	user, err := h.requireAuth(c)
-	if err != nil {
+	user, ok, err := h.requireAuth(c)
+	if !ok {
 		return err
 	}
 
-	apps, err := h.appService.ListAppsWithDeployments(user.ID)
+	opts, err := parseListOptions(c, domain.AppSortFields, "-createdAt", 0)
+	if err != nil {
+		return response.BadRequest(c, err.Error())
+	}
+	filter := domain.AppFilter{Status: domain.AppStatus(c.Query("status"))}
+	if serverID := c.Query("serverId"); serverID == localServerID {
+		filter.LocalOnly = true
+	} else {
+		filter.ServerID = serverID
+	}
+
+	page, err := h.appService.ListAppsPage(user.ID, filter, opts)
 	if err != nil {
 		return h.handleError(c, err)
 	}
 
-	return response.OK(c, apps)
+	return response.OKWithCursor(c, page.Items, page.NextCursor)
 }
 
 // CreateApp godoc
@@ -90,12 +120,12 @@ func (h *AppHandler) ListApps(c *fiber.Ctx) error {
 //	@Produce		json
 //	@Param			input	body		docs.CreateAppInput	true	"Dados do app"
 //	@Success		201		{object}	docs.App
-//	@Failure		400		{object}	docs.ErrorInfo
-//	@Failure		409		{object}	docs.ErrorInfo
+//	@Failure		400		{object}	docs.Problem
+//	@Failure		409		{object}	docs.Problem
 //	@Router			/apps [post]
 func (h *AppHandler) CreateApp(c *fiber.Ctx) error {
-	user, err := h.requireAuth(c)
-	if err != nil {
+	user, ok, err := h.requireAuth(c)
+	if !ok {
 		return err
 	}
 
@@ -135,11 +165,11 @@ func (h *AppHandler) CreateApp(c *fiber.Ctx) error {
 //	@Produce		json
 //	@Param			id	path		string	true	"ID do app"
 //	@Success		200	{object}	docs.App
-//	@Failure		404	{object}	docs.ErrorInfo
+//	@Failure		404	{object}	docs.Problem
 //	@Router			/apps/{id} [get]
 func (h *AppHandler) GetApp(c *fiber.Ctx) error {
-	user, err := h.requireAuth(c)
-	if err != nil {
+	user, ok, err := h.requireAuth(c)
+	if !ok {
 		return err
 	}
 
@@ -156,16 +186,16 @@ func (h *AppHandler) GetApp(c *fiber.Ctx) error {
 // DeleteApp godoc
 //
 //	@Summary		Remove uma aplicacao
-//	@Description	Deleta um app. Use ?purge=true para remover completamente (containers, imagens, arquivos, banco)
+//	@Description	Move um app para a lixeira e para o container; webhooks, imagens, arquivos e volumes sao mantidos ate o expurgo automatico. Use ?purge=true para remover completamente (containers, imagens, arquivos, banco), inclusive apps ja na lixeira
 //	@Tags			apps
 //	@Param			id		path	string	true	"ID do app"
 //	@Param			purge	query	bool	false	"Se true, remove completamente o app (hard delete)"
 //	@Success		204	"No Content"
-//	@Failure		404	{object}	docs.ErrorInfo
+//	@Failure		404	{object}	docs.Problem
 //	@Router			/apps/{id} [delete]
 func (h *AppHandler) DeleteApp(c *fiber.Ctx) error {
-	user, err := h.requireAuth(c)
-	if err != nil {
+	user, ok, err := h.requireAuth(c)
+	if !ok {
 		return err
 	}
 
@@ -173,6 +203,9 @@ func (h *AppHandler) DeleteApp(c *fiber.Ctx) error {
 	purge := c.QueryBool("purge", false)
 
 	app, err := h.appService.GetAppForUser(id, user.ID)
+	if purge && errors.Is(err, domain.ErrNotFound) {
+		app, err = h.appService.GetDeletedAppForUser(id, user.ID)
+	}
 	if err != nil {
 		return h.handleError(c, err)
 	}
@@ -180,7 +213,7 @@ func (h *AppHandler) DeleteApp(c *fiber.Ctx) error {
 	if purge {
 		err = h.appService.PurgeApp(c.Context(), id)
 	} else {
-		err = h.appService.DeleteApp(c.Context(), id)
+		err = h.appService.DeleteApp(c.UserContext(), id)
 	}
 
 	if err != nil {
@@ -199,19 +232,82 @@ func (h *AppHandler) DeleteApp(c *fiber.Ctx) error {
 	return response.NoContent(c)
 }
 
+// ListTrash godoc
+//
+//	@Summary		Lista aplicacoes na lixeira
+//	@Description	Retorna apps removidos que ainda podem ser restaurados antes do expurgo automatico
+//	@Tags			apps
+//	@Produce		json
+//	@Success		200	{array}	docs.App
+//	@Router			/apps/trash [get]
+func (h *AppHandler) ListTrash(c *fiber.Ctx) error {
+	user, ok, err := h.requireAuth(c)
+	if !ok {
+		return err
+	}
+
+	apps, err := h.appService.ListTrash(user.ID)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return response.OK(c, apps)
+}
+
+// RestoreApp godoc
+//
+//	@Summary		Restaura uma aplicacao da lixeira
+//	@Description	Reativa um app removido e inicia seu container novamente
+//	@Tags			apps
+//	@Produce		json
+//	@Param			id	path		string	true	"ID do app"
+//	@Success		200	{object}	docs.App
+//	@Failure		404	{object}	docs.Problem
+//	@Failure		409	{object}	docs.Problem
+//	@Router			/apps/{id}/restore [post]
+func (h *AppHandler) RestoreApp(c *fiber.Ctx) error {
+	user, ok, err := h.requireAuth(c)
+	if !ok {
+		return err
+	}
+
+	app, err := h.appService.RestoreApp(c.UserContext(), c.Params("id"), user.ID)
+	if err != nil {
+		if errors.Is(err, domain.ErrAlreadyExists) {
+			return response.Conflict(c, "App name already in use")
+		}
+		return h.handleError(c, err)
+	}
+
+	if h.auditService != nil {
+		auditCtx := h.auditService.ExtractContext(c)
+		h.auditService.LogAppRestored(c.Context(), auditCtx, app.ID, app.Name)
+	}
+
+	return response.OK(c, app)
+}
+
 // ListDeployments godoc
 //
 //	@Summary		Lista deploys de uma aplicacao
 //	@Description	Retorna historico de deploys de um app
 //	@Tags			deployments
 //	@Produce		json
-//	@Param			id	path		string	true	"ID do app"
-//	@Success		200	{array}		docs.Deployment
-//	@Failure		404	{object}	docs.ErrorInfo
+//	@Param			id		path		string	true	"ID do app"
+//	@Param			status	query		string	false	"Status do deploy"
+//	@Param			q		query		string	false	"Busca por commit SHA ou mensagem"
+//	@Param			from	query		string	false	"Criado a partir de (RFC 3339 ou data)"
+//	@Param			to		query		string	false	"Criado ate (RFC 3339 ou data)"
+//	@Param			sort	query		string	false	"createdAt; prefixo - para ordem decrescente"
+//	@Param			limit	query		int		false	"Itens por pagina (padrao 50)"
+//	@Param			cursor	query		string	false	"Cursor da proxima pagina (meta.nextCursor)"
+//	@Success		200		{array}		docs.Deployment
+//	@Failure		400		{object}	docs.Problem
+//	@Failure		404		{object}	docs.Problem
 //	@Router			/apps/{id}/deployments [get]
 func (h *AppHandler) ListDeployments(c *fiber.Ctx) error {
-	user, err := h.requireAuth(c)
-	if err != nil {
+	user, ok, err := h.requireAuth(c)
+	if !ok {
 		return err
 	}
 
@@ -221,12 +317,81 @@ func (h *AppHandler) ListDeployments(c *fiber.Ctx) error {
 		return h.handleError(c, err)
 	}
 
-	deployments, err := h.appService.ListDeployments(appID)
+	opts, err := parseListOptions(c, domain.DeploymentSortFields, "-createdAt", domain.DefaultPageLimit)
+	if err != nil {
+		return response.BadRequest(c, err.Error())
+	}
+	filter := domain.DeploymentFilter{Status: domain.DeployStatus(c.Query("status"))}
+
+	page, err := h.appService.ListDeploymentsPage(appID, filter, opts)
 	if err != nil {
 		return h.handleError(c, err)
 	}
 
-	return response.OK(c, deployments)
+	return response.OKWithCursor(c, page.Items, page.NextCursor)
+}
+
+// DownloadDeploymentLogs godoc
+//
+//	@Summary		Baixa o log completo de um deploy
+//	@Description	Retorna o log inteiro, incluindo as partes arquivadas no object storage
+//	@Tags			deployments
+//	@Produce		plain
+//	@Param			id			path		string	true	"ID do app"
+//	@Param			deployId	path		string	true	"ID do deploy"
+//	@Success		200			{string}	string
+//	@Failure		404			{object}	docs.Problem
+//	@Router			/apps/{id}/deployments/{deployId}/logs [get]
+func (h *AppHandler) DownloadDeploymentLogs(c *fiber.Ctx) error {
+	user, ok, err := h.requireAuth(c)
+	if !ok {
+		return err
+	}
+
+	appID := c.Params("id")
+	if _, err := h.appService.GetAppForUser(appID, user.ID); err != nil {
+		return h.handleError(c, err)
+	}
+
+	deployID := c.Params("deployId")
+	var logs bytes.Buffer
+	if err := h.appService.WriteDeploymentLogs(c.UserContext(), appID, deployID, &logs); err != nil {
+		return h.handleError(c, err)
+	}
+
+	c.Set(fiber.HeaderContentType, fiber.MIMETextPlainCharsetUTF8)
+	c.Set(fiber.HeaderContentDisposition, `attachment; filename="deploy-`+deployID+`.log"`)
+	return c.Send(logs.Bytes())
+}
+
+// DiffDeployments godoc
+//
+//	@Summary		Compara dois deploys
+//	@Description	Retorna os commits entre os deploys e as mudancas de variaveis de ambiente, configuracao e tamanho da imagem
+//	@Tags			deployments
+//	@Produce		json
+//	@Param			id		path		string	true	"ID do app"
+//	@Param			from	path		string	true	"ID do deploy de origem"
+//	@Param			to		path		string	true	"ID do deploy de destino"
+//	@Success		200		{object}	domain.DeploymentDiff
+//	@Failure		404		{object}	docs.Problem
+//	@Router			/apps/{id}/deployments/{from}/diff/{to} [get]
+func (h *AppHandler) DiffDeployments(c *fiber.Ctx) error {
+	user, ok, err := h.requireAuth(c)
+	if !ok {
+		return err
+	}
+
+	appID := c.Params("id")
+	if _, err := h.appService.GetAppForUser(appID, user.ID); err != nil {
+		return h.handleError(c, err)
+	}
+
+	diff, err := h.appService.DiffDeployments(c.UserContext(), appID, c.Params("from"), c.Params("to"))
+	if err != nil {
+		return h.handleError(c, err)
+	}
+	return response.OK(c, diff)
 }
 
 // TriggerRedeploy godoc
@@ -239,12 +404,12 @@ func (h *AppHandler) ListDeployments(c *fiber.Ctx) error {
 //	@Param			id		path		string			true	"ID do app"
 //	@Param			input	body		RedeployInput	false	"Commit SHA opcional"
 //	@Success		201		{object}	docs.Deployment
-//	@Failure		404		{object}	docs.ErrorInfo
-//	@Failure		409		{object}	docs.ErrorInfo
+//	@Failure		404		{object}	docs.Problem
+//	@Failure		409		{object}	docs.Problem
 //	@Router			/apps/{id}/redeploy [post]
 func (h *AppHandler) TriggerRedeploy(c *fiber.Ctx) error {
-	user, err := h.requireAuth(c)
-	if err != nil {
+	user, ok, err := h.requireAuth(c)
+	if !ok {
 		return err
 	}
 
@@ -260,7 +425,7 @@ func (h *AppHandler) TriggerRedeploy(c *fiber.Ctx) error {
 	}
 	_ = c.BodyParser(&input)
 
-	deployment, err := h.appService.TriggerDeploy(appID, input.CommitSHA)
+	deployment, err := h.appService.TriggerDeploy(c.UserContext(), appID, input.CommitSHA)
 	if err != nil {
 		return h.handleError(c, err)
 	}
@@ -281,11 +446,11 @@ func (h *AppHandler) TriggerRedeploy(c *fiber.Ctx) error {
 //	@Produce		json
 //	@Param			id	path		string	true	"ID do app"
 //	@Success		201	{object}	docs.Deployment
-//	@Failure		404	{object}	docs.ErrorInfo
+//	@Failure		404	{object}	docs.Problem
 //	@Router			/apps/{id}/rollback [post]
 func (h *AppHandler) TriggerRollback(c *fiber.Ctx) error {
-	user, err := h.requireAuth(c)
-	if err != nil {
+	user, ok, err := h.requireAuth(c)
+	if !ok {
 		return err
 	}
 
@@ -296,7 +461,7 @@ func (h *AppHandler) TriggerRollback(c *fiber.Ctx) error {
 		return h.handleError(c, err)
 	}
 
-	deployment, err := h.appService.TriggerRollback(appID)
+	deployment, err := h.appService.TriggerRollback(c.UserContext(), appID)
 	if err != nil {
 		return h.handleError(c, err)
 	}
@@ -317,12 +482,12 @@ func (h *AppHandler) TriggerRollback(c *fiber.Ctx) error {
 //	@Produce		json
 //	@Param			id	path		string	true	"ID do app"
 //	@Success		200	{object}	docs.SetupResult
-//	@Failure		400	{object}	docs.ErrorInfo
-//	@Failure		404	{object}	docs.ErrorInfo
+//	@Failure		400	{object}	docs.Problem
+//	@Failure		404	{object}	docs.Problem
 //	@Router			/apps/{id}/webhook [post]
 func (h *AppHandler) SetupWebhook(c *fiber.Ctx) error {
-	user, err := h.requireAuth(c)
-	if err != nil {
+	user, ok, err := h.requireAuth(c)
+	if !ok {
 		return err
 	}
 
@@ -347,11 +512,11 @@ func (h *AppHandler) SetupWebhook(c *fiber.Ctx) error {
 //	@Tags			apps
 //	@Param			id	path	string	true	"ID do app"
 //	@Success		204	"No Content"
-//	@Failure		404	{object}	docs.ErrorInfo
+//	@Failure		404	{object}	docs.Problem
 //	@Router			/apps/{id}/webhook [delete]
 func (h *AppHandler) RemoveWebhook(c *fiber.Ctx) error {
-	user, err := h.requireAuth(c)
-	if err != nil {
+	user, ok, err := h.requireAuth(c)
+	if !ok {
 		return err
 	}
 
@@ -376,11 +541,11 @@ func (h *AppHandler) RemoveWebhook(c *fiber.Ctx) error {
 //	@Produce		json
 //	@Param			id	path		string	true	"ID do app"
 //	@Success		200	{object}	docs.WebhookStatus
-//	@Failure		404	{object}	docs.ErrorInfo
+//	@Failure		404	{object}	docs.Problem
 //	@Router			/apps/{id}/webhook/status [get]
 func (h *AppHandler) GetWebhookStatus(c *fiber.Ctx) error {
-	user, err := h.requireAuth(c)
-	if err != nil {
+	user, ok, err := h.requireAuth(c)
+	if !ok {
 		return err
 	}
 
@@ -407,11 +572,11 @@ func (h *AppHandler) GetWebhookStatus(c *fiber.Ctx) error {
 //	@Param			id		path		string	true	"ID do app"
 //	@Param			limit	query		int		false	"Numero de commits a retornar"	default(20)
 //	@Success		200		{array}		object
-//	@Failure		404		{object}	docs.ErrorInfo
+//	@Failure		404		{object}	docs.Problem
 //	@Router			/apps/{id}/commits [get]
 func (h *AppHandler) ListCommits(c *fiber.Ctx) error {
-	user, err := h.requireAuth(c)
-	if err != nil {
+	user, ok, err := h.requireAuth(c)
+	if !ok {
 		return err
 	}
 
@@ -432,15 +597,8 @@ func (h *AppHandler) ListCommits(c *fiber.Ctx) error {
 
 func (h *AppHandler) handleError(c *fiber.Ctx, err error) error {
 	if !isKnownDomainError(err) && h.logger != nil {
-		var traceID string
-		if tid := c.Locals("traceId"); tid != nil {
-			if id, ok := tid.(string); ok {
-				traceID = id
-			}
-		}
-		h.logger.Error("unexpected error in app handler",
+		h.logger.ErrorContext(c.UserContext(), "unexpected error in app handler",
 			"error", err,
-			"traceId", traceID,
 			"path", c.Path(),
 		)
 	}
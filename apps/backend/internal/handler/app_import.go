@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/paasdeploy/backend/internal/cloud"
+	"github.com/paasdeploy/backend/internal/domain"
+	"github.com/paasdeploy/backend/internal/response"
+)
+
+type HerokuImportRequest struct {
+	APIKey        string  `json:"apiKey"`
+	HerokuApp     string  `json:"herokuApp"`
+	Name          string  `json:"name"`
+	RepositoryURL string  `json:"repositoryUrl"`
+	Branch        string  `json:"branch"`
+	Workdir       string  `json:"workdir"`
+	ServerID      *string `json:"serverId,omitempty"`
+}
+
+type HerokuImportResponse struct {
+	App    *domain.App            `json:"app"`
+	Config domain.SuggestedConfig `json:"config"`
+	Addons []cloud.HerokuAddon    `json:"addons"`
+}
+
+// fetchHerokuImport reads the Heroku app named in the request and maps it
+// onto the app name the user chose, defaulting to the Heroku name. When it
+// returns false it has already sent the error response.
+func (h *AppHandler) fetchHerokuImport(c *fiber.Ctx, req HerokuImportRequest) (*cloud.HerokuImport, bool, error) {
+	apiKey := strings.TrimSpace(req.APIKey)
+	herokuApp := strings.TrimSpace(req.HerokuApp)
+	if apiKey == "" || herokuApp == "" {
+		return nil, false, response.BadRequest(c, "apiKey and herokuApp are required")
+	}
+
+	ctx, cancel := context.WithTimeout(c.Context(), cloudRequestTimeout)
+	defer cancel()
+	app, err := cloud.NewHerokuClient(apiKey, h.logger).FetchApp(ctx, herokuApp)
+	if err != nil {
+		h.logger.WarnContext(c.UserContext(), "failed to fetch heroku app", "herokuApp", herokuApp, "error", err)
+		return nil, false, response.BadRequest(c, "failed to read Heroku app: "+err.Error())
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		name = app.Name
+	}
+	return cloud.BuildHerokuImport(app, name), true, nil
+}
+
+func (h *AppHandler) PreviewHerokuImport(c *fiber.Ctx) error {
+	if _, ok, err := h.requireAuth(c); !ok {
+		return err
+	}
+	var req HerokuImportRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.BadRequest(c, MsgInvalidRequestBody)
+	}
+
+	imp, ok, err := h.fetchHerokuImport(c, req)
+	if !ok {
+		return err
+	}
+	return response.OK(c, imp)
+}
+
+// ImportHerokuApp creates an app from a Heroku app's config vars. The
+// suggested paasdeploy.json is returned for the user to commit; it is not
+// written to the repository.
+func (h *AppHandler) ImportHerokuApp(c *fiber.Ctx) error {
+	user, ok, err := h.requireAuth(c)
+	if !ok {
+		return err
+	}
+	var req HerokuImportRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.BadRequest(c, MsgInvalidRequestBody)
+	}
+
+	if (req.ServerID == nil || *req.ServerID == "") && !user.IsAdmin() {
+		return response.Forbidden(c, "local operations require admin role")
+	}
+
+	imp, ok, err := h.fetchHerokuImport(c, req)
+	if !ok {
+		return err
+	}
+
+	app, err := h.appService.ImportApp(c.Context(), domain.CreateAppInput{
+		UserID:        user.ID,
+		Name:          imp.Config.Name,
+		RepositoryURL: req.RepositoryURL,
+		Branch:        req.Branch,
+		Workdir:       req.Workdir,
+		ServerID:      req.ServerID,
+	}, imp.EnvVars)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	if h.auditService != nil {
+		auditCtx := h.auditService.ExtractContext(c)
+		h.auditService.LogAppCreated(c.Context(), auditCtx, app.ID, app.Name, app.RepositoryURL)
+	}
+
+	h.logger.InfoContext(c.UserContext(), "app imported from heroku", "appId", app.ID, "herokuApp", imp.HerokuApp, "envVars", len(imp.EnvVars))
+	return response.Created(c, HerokuImportResponse{
+		App:    app,
+		Config: imp.Config,
+		Addons: imp.Addons,
+	})
+}
@@ -0,0 +1,33 @@
+package handler
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/paasdeploy/backend/internal/domain"
+)
+
+func TestImportHerokuAppRejectsBadRequests(t *testing.T) {
+	tests := []struct {
+		name string
+		user *domain.User
+		path string
+		body string
+		want int
+	}{
+		{"preview without api key", testOwner, "/apps/import/heroku/preview", `{"herokuApp":"shop"}`, http.StatusBadRequest},
+		{"import without heroku app", testAdmin, "/apps/import/heroku", `{"apiKey":"key"}`, http.StatusBadRequest},
+		{"member importing locally", testOwner, "/apps/import/heroku", `{"apiKey":"key","herokuApp":"shop"}`, http.StatusForbidden},
+		{"member with empty server", testOwner, "/apps/import/heroku", `{"apiKey":"key","herokuApp":"shop","serverId":""}`, http.StatusForbidden},
+		{"anonymous", nil, "/apps/import/heroku", `{"apiKey":"key","herokuApp":"shop"}`, http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newTestApp(tt.user)
+			NewAppHandler(nil, nil, testLogger()).Register(app)
+			if resp := doRequest(t, app, http.MethodPost, APIPrefix+tt.path, tt.body); resp.StatusCode != tt.want {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/paasdeploy/backend/internal/domain"
+	"github.com/paasdeploy/backend/internal/response"
+)
+
+const maxLinkedApps = 20
+
+type UpdateInternalRequest struct {
+	Internal bool `json:"internal"`
+}
+
+type UpdateLinkedAppsRequest struct {
+	AppIDs []string `json:"appIds"`
+}
+
+// UpdateInternal toggles whether the app is reachable only from other apps
+// on the same server. Internal apps get no Traefik router, so the labels are
+// re-rendered right away.
+func (h *AppAdminHandler) UpdateInternal(c *fiber.Ctx) error {
+	app, ok, err := h.requireAppForUser(c)
+	if !ok {
+		return err
+	}
+
+	var req UpdateInternalRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.BadRequest(c, MsgInvalidRequestBody)
+	}
+
+	if err := h.appRepo.UpdateInternal(app.ID, req.Internal); err != nil {
+		h.logger.ErrorContext(c.UserContext(), "Failed to update internal flag", "appId", app.ID, "error", err)
+		return response.InternalError(c)
+	}
+	app.Internal = req.Internal
+
+	if err := h.engine.UpdateContainerDomains(c.Context(), app); err != nil {
+		h.logger.WarnContext(c.UserContext(), "Failed to apply internal flag to container", "appId", app.ID, "error", err)
+	}
+
+	h.logger.InfoContext(c.UserContext(), "App internal flag updated", "appId", app.ID, "internal", req.Internal)
+	return response.OK(c, app)
+}
+
+// UpdateLinkedApps sets the apps whose hostname, port and URL are injected
+// into this app's environment. Linked apps must belong to the same user and
+// run on the same server; the change applies on the next deploy.
+func (h *AppAdminHandler) UpdateLinkedApps(c *fiber.Ctx) error {
+	app, ok, err := h.requireAppForUser(c)
+	if !ok {
+		return err
+	}
+	user := GetUserFromContext(c)
+
+	var req UpdateLinkedAppsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.BadRequest(c, MsgInvalidRequestBody)
+	}
+	if len(req.AppIDs) > maxLinkedApps {
+		return response.BadRequest(c, "An app can link to at most 20 apps")
+	}
+
+	found, err := h.appRepo.FindByIDsAndUserID(req.AppIDs, user.ID)
+	if err != nil {
+		return response.BadRequest(c, "Linked app not found")
+	}
+	byID := make(map[string]*domain.App, len(found))
+	for i := range found {
+		byID[found[i].ID] = &found[i]
+	}
+
+	seen := make(map[string]bool, len(req.AppIDs))
+	appIDs := make([]string, 0, len(req.AppIDs))
+	for _, id := range req.AppIDs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		if id == app.ID {
+			return response.BadRequest(c, "An app cannot link to itself")
+		}
+		linked, ok := byID[id]
+		if !ok {
+			return response.BadRequest(c, "Linked app not found: "+id)
+		}
+		if !app.SameServer(linked) {
+			return response.BadRequest(c, "Linked app "+linked.Name+" runs on a different server")
+		}
+		appIDs = append(appIDs, id)
+	}
+
+	if err := h.appRepo.UpdateLinkedApps(app.ID, appIDs); err != nil {
+		h.logger.ErrorContext(c.UserContext(), "Failed to update linked apps", "appId", app.ID, "error", err)
+		return response.InternalError(c)
+	}
+	app.LinkedAppIDs = appIDs
+
+	h.logger.InfoContext(c.UserContext(), "App links updated", "appId", app.ID, "count", len(appIDs))
+	return response.OK(c, app)
+}
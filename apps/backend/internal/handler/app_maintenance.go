@@ -0,0 +1,126 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/paasdeploy/backend/internal/domain"
+	"github.com/paasdeploy/backend/internal/engine"
+	"github.com/paasdeploy/backend/internal/response"
+)
+
+const (
+	maintenancePagePath   = "/paas-deploy/v1/maintenance/"
+	maxMaintenanceHTML    = 64 * 1024
+	maintenanceRetryAfter = "300"
+	// maintenancePagePolicy sandboxes the owner's page, which is served
+	// from the API origin: scripts, forms and same-origin access stay off,
+	// while inline styles and remote images and fonts still render.
+	maintenancePagePolicy = "sandbox; default-src 'none'; style-src 'unsafe-inline'; img-src data: https:; font-src data: https:"
+)
+
+type UpdateMaintenanceRequest struct {
+	Enabled bool   `json:"enabled"`
+	HTML    string `json:"html"`
+}
+
+// RegisterPublic registers the maintenance page, which Traefik proxies to
+// for apps in maintenance and so cannot require authentication.
+func (h *AppAdminHandler) RegisterPublic(app fiber.Router) {
+	app.Get(maintenancePagePath+":id", h.ServeMaintenancePage)
+}
+
+// UpdateMaintenance puts the app in maintenance or takes it out. While in
+// maintenance, its domains are routed to a page served by the platform and
+// the container keeps running, so disabling it is instant.
+func (h *AppAdminHandler) UpdateMaintenance(c *fiber.Ctx) error {
+	app, ok, err := h.requireAppForUser(c)
+	if !ok {
+		return err
+	}
+
+	var req UpdateMaintenanceRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.BadRequest(c, MsgInvalidRequestBody)
+	}
+	if len(req.HTML) > maxMaintenanceHTML {
+		return response.BadRequest(c, "Maintenance page is too large (maximum 64 KB)")
+	}
+	if h.apiBaseURL == "" {
+		return response.ServerError(c, fiber.StatusServiceUnavailable, "Maintenance mode requires API_BASE_URL to be configured")
+	}
+
+	if err := h.engine.SetMaintenance(c.Context(), app, h.apiBaseURL+maintenancePagePath+app.ID, req.Enabled); err != nil {
+		if errors.Is(err, engine.ErrMaintenanceUnsupported) {
+			return response.BadRequest(c, "Maintenance mode is only supported for apps deployed to a remote server")
+		}
+		h.logger.ErrorContext(c.UserContext(), "Failed to update maintenance routing", "appId", app.ID, "error", err)
+		return response.ServerError(c, fiber.StatusServiceUnavailable, "Failed to update routing on the server")
+	}
+
+	var maintenance *domain.AppMaintenance
+	if req.Enabled {
+		maintenance = &domain.AppMaintenance{HTML: req.HTML, EnabledAt: time.Now().UTC()}
+	}
+	if err := h.appRepo.UpdateMaintenance(app.ID, maintenance); err != nil {
+		h.logger.ErrorContext(c.UserContext(), "Failed to save maintenance mode", "appId", app.ID, "error", err)
+		return response.InternalError(c)
+	}
+	app.Maintenance = maintenance
+
+	if h.auditService != nil {
+		auditCtx := h.auditService.ExtractContext(c)
+		h.auditService.LogAppMaintenance(c.Context(), auditCtx, app.ID, app.Name, req.Enabled)
+	}
+
+	h.logger.InfoContext(c.UserContext(), "App maintenance mode updated", "appId", app.ID, "enabled", req.Enabled)
+	return response.OK(c, app)
+}
+
+// ServeMaintenancePage renders the app's maintenance page with a 503, so
+// clients and crawlers treat the outage as temporary.
+func (h *AppAdminHandler) ServeMaintenancePage(c *fiber.Ctx) error {
+	app, err := h.appRepo.FindByID(c.Params("id"))
+	if err != nil || app.Maintenance == nil {
+		return c.SendStatus(fiber.StatusNotFound)
+	}
+
+	page := app.Maintenance.HTML
+	if page == "" {
+		page = defaultMaintenancePage(app.Name)
+	}
+
+	c.Set(fiber.HeaderContentType, fiber.MIMETextHTMLCharsetUTF8)
+	c.Set(fiber.HeaderContentSecurityPolicy, maintenancePagePolicy)
+	c.Set(fiber.HeaderXContentTypeOptions, "nosniff")
+	c.Set(fiber.HeaderCacheControl, "no-store")
+	c.Set(fiber.HeaderRetryAfter, maintenanceRetryAfter)
+	return c.Status(fiber.StatusServiceUnavailable).SendString(page)
+}
+
+func defaultMaintenancePage(appName string) string {
+	return fmt.Sprintf(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>%[1]s is under maintenance</title>
+<style>
+body{margin:0;min-height:100vh;display:flex;align-items:center;justify-content:center;font-family:system-ui,sans-serif;background:#f8fafc;color:#0f172a}
+main{max-width:32rem;padding:2rem;text-align:center}
+p{color:#475569}
+</style>
+</head>
+<body>
+<main>
+<h1>We'll be back soon</h1>
+<p>%[1]s is undergoing scheduled maintenance. Please check back in a few minutes.</p>
+</main>
+</body>
+</html>
+`, html.EscapeString(appName))
+}
@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/paasdeploy/backend/internal/domain"
+	"github.com/paasdeploy/backend/internal/engine"
+)
+
+type fakeMaintenanceAppRepo struct {
+	domain.AppRepository
+	apps map[string]domain.App
+}
+
+func (r *fakeMaintenanceAppRepo) FindByID(id string) (*domain.App, error) {
+	app, ok := r.apps[id]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return &app, nil
+}
+
+func (r *fakeMaintenanceAppRepo) FindByIDAndUserID(id, _ string) (*domain.App, error) {
+	return r.FindByID(id)
+}
+
+func newMaintenanceTestApp(user *domain.User) *fiber.App {
+	repo := &fakeMaintenanceAppRepo{apps: map[string]domain.App{
+		"custom":  {ID: "custom", Name: "shop", Maintenance: &domain.AppMaintenance{HTML: `<h1>Back soon</h1><script>alert(1)</script>`}},
+		"default": {ID: "default", Name: "<shop>", Maintenance: &domain.AppMaintenance{}},
+		"live":    {ID: "live", Name: "blog"},
+	}}
+	h := NewAppAdminHandler(AppAdminHandlerConfig{
+		AppRepo:    repo,
+		Engine:     &engine.Engine{},
+		APIBaseURL: "https://api.example.com",
+		Logger:     testLogger(),
+	})
+	app := newTestApp(user)
+	h.RegisterPublic(app)
+	h.Register(app)
+	return app
+}
+
+func TestServeMaintenancePageIsSandboxed(t *testing.T) {
+	app := newMaintenanceTestApp(nil)
+
+	resp := doRequest(t, app, fiber.MethodGet, maintenancePagePath+"custom", "")
+	if resp.StatusCode != fiber.StatusServiceUnavailable {
+		t.Fatalf("status = %d, want 503", resp.StatusCode)
+	}
+	if csp := resp.Header.Get(fiber.HeaderContentSecurityPolicy); !strings.HasPrefix(csp, "sandbox;") || strings.Contains(csp, "script-src") {
+		t.Errorf("Content-Security-Policy = %q, want a sandbox without scripts", csp)
+	}
+	if got := resp.Header.Get(fiber.HeaderXContentTypeOptions); got != "nosniff" {
+		t.Errorf("X-Content-Type-Options = %q, want nosniff", got)
+	}
+	if got := resp.Header.Get(fiber.HeaderRetryAfter); got == "" {
+		t.Error("Retry-After is not set")
+	}
+	body, _ := io.ReadAll(resp.Body)
+	if !strings.Contains(string(body), "<h1>Back soon</h1>") {
+		t.Errorf("body = %q, want the custom page", body)
+	}
+}
+
+func TestServeMaintenancePageDefault(t *testing.T) {
+	app := newMaintenanceTestApp(nil)
+
+	resp := doRequest(t, app, fiber.MethodGet, maintenancePagePath+"default", "")
+	if resp.StatusCode != fiber.StatusServiceUnavailable {
+		t.Fatalf("status = %d, want 503", resp.StatusCode)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	if !strings.Contains(string(body), "&lt;shop&gt; is undergoing scheduled maintenance") {
+		t.Errorf("default page does not name the escaped app: %q", body)
+	}
+
+	for _, id := range []string{"live", "missing"} {
+		resp := doRequest(t, app, fiber.MethodGet, maintenancePagePath+id, "")
+		if resp.StatusCode != fiber.StatusNotFound {
+			t.Errorf("%s: status = %d, want 404", id, resp.StatusCode)
+		}
+	}
+}
+
+func TestUpdateMaintenance(t *testing.T) {
+	app := newMaintenanceTestApp(testOwner)
+
+	resp := doRequest(t, app, fiber.MethodPost, APIPrefix+"/apps/missing/maintenance", `{"enabled":true}`)
+	if resp.StatusCode != fiber.StatusNotFound {
+		t.Errorf("unknown app: status = %d, want 404", resp.StatusCode)
+	}
+
+	resp = doRequest(t, app, fiber.MethodPost, APIPrefix+"/apps/live/maintenance", `{"enabled":true}`)
+	if resp.StatusCode != fiber.StatusBadRequest {
+		t.Errorf("local app: status = %d, want 400", resp.StatusCode)
+	}
+}
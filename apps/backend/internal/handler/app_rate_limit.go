@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/paasdeploy/backend/internal/domain"
+	"github.com/paasdeploy/backend/internal/response"
+	"github.com/paasdeploy/shared/pkg/compose"
+)
+
+const maxRateLimitAverage = 100000
+
+var headerNameRe = regexp.MustCompile(`^[A-Za-z0-9-]+$`)
+
+type UpdateRateLimitRequest struct {
+	Average int    `json:"average"`
+	Burst   int    `json:"burst"`
+	Source  string `json:"source"`
+	Header  string `json:"header"`
+}
+
+func parseRateLimit(req UpdateRateLimitRequest) (*domain.AppRateLimit, string) {
+	if req.Average < 1 || req.Average > maxRateLimitAverage {
+		return nil, "Average must be between 1 and 100000 requests per second"
+	}
+	if req.Burst < 0 || req.Burst > maxRateLimitAverage {
+		return nil, "Burst must be between 0 and 100000"
+	}
+
+	rl := &domain.AppRateLimit{Average: req.Average, Burst: req.Burst, Source: req.Source}
+	switch rl.Source {
+	case "":
+		rl.Source = compose.RateLimitSourceIP
+	case compose.RateLimitSourceIP, compose.RateLimitSourceForwarded, compose.RateLimitSourceHost:
+	case compose.RateLimitSourceHeader:
+		rl.Header = strings.TrimSpace(req.Header)
+		if !headerNameRe.MatchString(rl.Header) {
+			return nil, "A valid header name is required for the header source"
+		}
+	default:
+		return nil, "Source must be one of ip, forwarded, host or header"
+	}
+	return rl, ""
+}
+
+// UpdateRateLimit sets the Traefik rate limit for all of the app's routes and
+// re-renders the container labels so it takes effect without a redeploy.
+func (h *AppAdminHandler) UpdateRateLimit(c *fiber.Ctx) error {
+	app, ok, err := h.requireAppForUser(c)
+	if !ok {
+		return err
+	}
+
+	var req UpdateRateLimitRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.BadRequest(c, MsgInvalidRequestBody)
+	}
+	rl, msg := parseRateLimit(req)
+	if rl == nil {
+		return response.BadRequest(c, msg)
+	}
+
+	return h.applyRateLimit(c, app, rl)
+}
+
+func (h *AppAdminHandler) RemoveRateLimit(c *fiber.Ctx) error {
+	app, ok, err := h.requireAppForUser(c)
+	if !ok {
+		return err
+	}
+	return h.applyRateLimit(c, app, nil)
+}
+
+func (h *AppAdminHandler) applyRateLimit(c *fiber.Ctx, app *domain.App, rl *domain.AppRateLimit) error {
+	if err := h.appRepo.UpdateRateLimit(app.ID, rl); err != nil {
+		h.logger.ErrorContext(c.UserContext(), "Failed to update rate limit", "appId", app.ID, "error", err)
+		return response.InternalError(c)
+	}
+	app.RateLimit = rl
+
+	if err := h.engine.UpdateContainerDomains(c.Context(), app); err != nil {
+		h.logger.WarnContext(c.UserContext(), "Failed to apply rate limit to container", "appId", app.ID, "error", err)
+	}
+
+	h.logger.InfoContext(c.UserContext(), "App rate limit updated", "appId", app.ID, "enabled", rl != nil)
+	return response.OK(c, app)
+}
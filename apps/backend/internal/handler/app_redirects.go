@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/paasdeploy/backend/internal/domain"
+	"github.com/paasdeploy/backend/internal/response"
+)
+
+const maxAppRedirects = 50
+
+type UpdateRedirectsRequest struct {
+	Redirects []domain.AppRedirect `json:"redirects"`
+}
+
+func normalizeRedirect(r domain.AppRedirect) (domain.AppRedirect, string) {
+	r.SourceHost = strings.ToLower(strings.TrimSpace(r.SourceHost))
+	if !isValidDomain(r.SourceHost) {
+		return r, "Invalid source host: " + r.SourceHost
+	}
+
+	r.SourcePath = strings.TrimSuffix(strings.TrimSpace(r.SourcePath), "/")
+	if r.SourcePath != "" && !strings.HasPrefix(r.SourcePath, "/") {
+		return r, "Source path must start with '/'"
+	}
+	if strings.ContainsAny(r.SourcePath, "` \t") {
+		return r, "Source path cannot contain spaces or backticks"
+	}
+
+	r.Target = strings.TrimSpace(r.Target)
+	if !strings.Contains(r.Target, "://") {
+		r.Target = "https://" + r.Target
+	}
+	target, err := url.Parse(r.Target)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		return r, "Invalid redirect target: " + r.Target
+	}
+	if target.Host == r.SourceHost && r.SourcePath == "" && strings.Trim(target.Path, "/") == "" {
+		return r, "Redirect target cannot point back to its source"
+	}
+
+	switch r.StatusCode {
+	case 0:
+		r.StatusCode = fiber.StatusMovedPermanently
+	case fiber.StatusMovedPermanently, fiber.StatusFound,
+		fiber.StatusTemporaryRedirect, fiber.StatusPermanentRedirect:
+	default:
+		return r, "Status code must be one of 301, 302, 307 or 308"
+	}
+	return r, ""
+}
+
+// UpdateRedirects replaces the app's redirect list. Each entry becomes a
+// Traefik redirectregex middleware, applied right away by re-rendering the
+// container labels.
+func (h *AppAdminHandler) UpdateRedirects(c *fiber.Ctx) error {
+	app, ok, err := h.requireAppForUser(c)
+	if !ok {
+		return err
+	}
+
+	var req UpdateRedirectsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.BadRequest(c, MsgInvalidRequestBody)
+	}
+	if len(req.Redirects) > maxAppRedirects {
+		return response.BadRequest(c, "Too many redirects (maximum 50)")
+	}
+
+	redirects := make([]domain.AppRedirect, 0, len(req.Redirects))
+	seen := make(map[string]bool, len(req.Redirects))
+	for _, r := range req.Redirects {
+		normalized, msg := normalizeRedirect(r)
+		if msg != "" {
+			return response.BadRequest(c, msg)
+		}
+		key := normalized.SourceHost + normalized.SourcePath
+		if seen[key] {
+			return response.BadRequest(c, "Duplicate redirect source: "+key)
+		}
+		seen[key] = true
+		redirects = append(redirects, normalized)
+	}
+
+	if err := h.appRepo.UpdateRedirects(app.ID, redirects); err != nil {
+		h.logger.ErrorContext(c.UserContext(), "Failed to update redirects", "appId", app.ID, "error", err)
+		return response.InternalError(c)
+	}
+	app.Redirects = redirects
+
+	if err := h.engine.UpdateContainerDomains(c.Context(), app); err != nil {
+		h.logger.WarnContext(c.UserContext(), "Failed to apply redirects to container", "appId", app.ID, "error", err)
+	}
+
+	h.logger.InfoContext(c.UserContext(), "App redirects updated", "appId", app.ID, "count", len(redirects))
+	return response.OK(c, app)
+}
@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/paasdeploy/backend/internal/domain"
+	"github.com/paasdeploy/backend/internal/response"
+)
+
+const (
+	maxHSTSMaxAge = 63072000
+	// hstsPreloadMinAge is the shortest max-age browsers accept for the HSTS
+	// preload list.
+	hstsPreloadMinAge = 31536000
+	maxCSPLength      = 4096
+)
+
+var validReferrerPolicies = map[string]bool{
+	"no-referrer":                     true,
+	"no-referrer-when-downgrade":      true,
+	"origin":                          true,
+	"origin-when-cross-origin":        true,
+	"same-origin":                     true,
+	"strict-origin":                   true,
+	"strict-origin-when-cross-origin": true,
+	"unsafe-url":                      true,
+}
+
+type UpdateSecurityHeadersRequest struct {
+	HSTSMaxAge            int    `json:"hstsMaxAge"`
+	HSTSIncludeSubdomains bool   `json:"hstsIncludeSubdomains"`
+	HSTSPreload           bool   `json:"hstsPreload"`
+	FrameOptions          string `json:"frameOptions"`
+	ContentSecurityPolicy string `json:"contentSecurityPolicy"`
+	ReferrerPolicy        string `json:"referrerPolicy"`
+}
+
+func parseSecurityHeaders(req UpdateSecurityHeadersRequest) (*domain.AppSecurityHeaders, string) {
+	h := &domain.AppSecurityHeaders{
+		HSTSMaxAge:            req.HSTSMaxAge,
+		FrameOptions:          strings.ToUpper(strings.TrimSpace(req.FrameOptions)),
+		ContentSecurityPolicy: strings.TrimSpace(req.ContentSecurityPolicy),
+		ReferrerPolicy:        strings.ToLower(strings.TrimSpace(req.ReferrerPolicy)),
+	}
+
+	if h.HSTSMaxAge < 0 || h.HSTSMaxAge > maxHSTSMaxAge {
+		return nil, "HSTS max-age must be between 0 and 63072000 seconds"
+	}
+	if h.HSTSMaxAge > 0 {
+		h.HSTSIncludeSubdomains = req.HSTSIncludeSubdomains
+		h.HSTSPreload = req.HSTSPreload
+	}
+	if h.HSTSPreload && (!h.HSTSIncludeSubdomains || h.HSTSMaxAge < hstsPreloadMinAge) {
+		return nil, "HSTS preload requires includeSubDomains and a max-age of at least 31536000 seconds"
+	}
+
+	switch h.FrameOptions {
+	case "", "DENY", "SAMEORIGIN":
+	default:
+		return nil, "X-Frame-Options must be DENY or SAMEORIGIN"
+	}
+
+	if len(h.ContentSecurityPolicy) > maxCSPLength {
+		return nil, "Content-Security-Policy is too long (maximum 4096 characters)"
+	}
+	if strings.ContainsAny(h.ContentSecurityPolicy, "`\r\n") {
+		return nil, "Content-Security-Policy cannot contain line breaks or backticks"
+	}
+
+	if h.ReferrerPolicy != "" && !validReferrerPolicies[h.ReferrerPolicy] {
+		return nil, "Invalid Referrer-Policy: " + h.ReferrerPolicy
+	}
+
+	if h.HSTSMaxAge == 0 && h.FrameOptions == "" && h.ContentSecurityPolicy == "" && h.ReferrerPolicy == "" {
+		return nil, "Enable at least one header"
+	}
+	return h, ""
+}
+
+// UpdateSecurityHeaders sets the response headers Traefik adds to all of the
+// app's routes and re-renders the container labels so they apply right away.
+func (h *AppAdminHandler) UpdateSecurityHeaders(c *fiber.Ctx) error {
+	app, ok, err := h.requireAppForUser(c)
+	if !ok {
+		return err
+	}
+
+	var req UpdateSecurityHeadersRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.BadRequest(c, MsgInvalidRequestBody)
+	}
+	headers, msg := parseSecurityHeaders(req)
+	if headers == nil {
+		return response.BadRequest(c, msg)
+	}
+
+	return h.applySecurityHeaders(c, app, headers)
+}
+
+func (h *AppAdminHandler) RemoveSecurityHeaders(c *fiber.Ctx) error {
+	app, ok, err := h.requireAppForUser(c)
+	if !ok {
+		return err
+	}
+	return h.applySecurityHeaders(c, app, nil)
+}
+
+func (h *AppAdminHandler) applySecurityHeaders(c *fiber.Ctx, app *domain.App, headers *domain.AppSecurityHeaders) error {
+	if err := h.appRepo.UpdateSecurityHeaders(app.ID, headers); err != nil {
+		h.logger.ErrorContext(c.UserContext(), "Failed to update security headers", "appId", app.ID, "error", err)
+		return response.InternalError(c)
+	}
+	app.SecurityHeaders = headers
+
+	if err := h.engine.UpdateContainerDomains(c.Context(), app); err != nil {
+		h.logger.WarnContext(c.UserContext(), "Failed to apply security headers to container", "appId", app.ID, "error", err)
+	}
+
+	h.logger.InfoContext(c.UserContext(), "App security headers updated", "appId", app.ID, "enabled", headers != nil)
+	return response.OK(c, app)
+}
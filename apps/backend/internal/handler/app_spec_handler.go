@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"errors"
+	"log/slog"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/paasdeploy/backend/internal/domain"
+	"github.com/paasdeploy/backend/internal/response"
+	"github.com/paasdeploy/backend/internal/service"
+)
+
+type AppSpecHandler struct {
+	specService  *service.AppSpecService
+	auditService *service.AuditService
+	logger       *slog.Logger
+}
+
+func NewAppSpecHandler(specService *service.AppSpecService, auditService *service.AuditService, logger *slog.Logger) *AppSpecHandler {
+	return &AppSpecHandler{
+		specService:  specService,
+		auditService: auditService,
+		logger:       logger.With("handler", "app_spec"),
+	}
+}
+
+func (h *AppSpecHandler) Register(app fiber.Router) {
+	app.Put(APIPrefix+"/apps/:name/spec", h.ApplySpec)
+}
+
+// ApplySpec reconciles the app named in the path with a full desired-state
+// spec, creating it when missing, and returns the changes. With
+// ?dryRun=true only the changes are computed.
+func (h *AppSpecHandler) ApplySpec(c *fiber.Ctx) error {
+	user := GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, MsgNotAuthenticated)
+	}
+
+	var spec domain.AppSpec
+	if err := c.BodyParser(&spec); err != nil {
+		return response.BadRequest(c, MsgInvalidRequestBody)
+	}
+	name := c.Params("name")
+	if spec.Name == "" {
+		spec.Name = name
+	}
+	if spec.Name != name {
+		return response.BadRequest(c, "Spec name does not match the app in the path")
+	}
+
+	result, err := h.specService.Apply(c.Context(), user, spec, c.QueryBool("dryRun"))
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidInput):
+			return response.BadRequest(c, err.Error())
+		case errors.Is(err, domain.ErrAlreadyExists):
+			return response.Conflict(c, "App name already in use")
+		case errors.Is(err, domain.ErrForbidden):
+			return response.Forbidden(c, "local operations require admin role")
+		case errors.Is(err, domain.ErrQuotaExceeded):
+			return response.Forbidden(c, err.Error())
+		}
+		h.logger.ErrorContext(c.UserContext(), "failed to apply app spec", "name", name, "error", err)
+		return response.InternalError(c)
+	}
+
+	if !result.DryRun && len(result.Changes) > 0 {
+		auditCtx := h.auditService.ExtractContext(c)
+		h.auditService.LogAppSpecApplied(c.Context(), auditCtx, result.App.ID, result.App.Name, result.Created, len(result.Changes))
+		if scaled := resourceChanges(result.Changes); !result.Created && len(scaled) > 0 {
+			h.auditService.LogAppScaled(c.Context(), auditCtx, result.App.ID, result.App.Name, scaled)
+		}
+	}
+	if result.Created && !result.DryRun {
+		return response.Created(c, result)
+	}
+	return response.OK(c, result)
+}
+
+// resourceChanges returns the new value of each changed resource limit.
+func resourceChanges(changes []domain.SpecChange) map[string]string {
+	scaled := make(map[string]string)
+	for _, change := range changes {
+		if name, ok := strings.CutPrefix(change.Field, "resources."); ok {
+			scaled[name] = change.To
+		}
+	}
+	return scaled
+}
@@ -0,0 +1,237 @@
+package handler
+
+import (
+	"errors"
+	"log/slog"
+	"net/url"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/paasdeploy/backend/internal/domain"
+	"github.com/paasdeploy/backend/internal/response"
+	"github.com/paasdeploy/backend/internal/service"
+)
+
+const maxAppWebhooks = 10
+
+// AppWebhookHandler manages the outbound webhooks of an app, which receive
+// its deploy and health events as signed JSON payloads.
+type AppWebhookHandler struct {
+	appRepo        domain.AppRepository
+	webhookRepo    domain.AppWebhookRepository
+	webhookService *service.AppWebhookService
+	logger         *slog.Logger
+}
+
+func NewAppWebhookHandler(
+	appRepo domain.AppRepository,
+	webhookRepo domain.AppWebhookRepository,
+	webhookService *service.AppWebhookService,
+	logger *slog.Logger,
+) *AppWebhookHandler {
+	return &AppWebhookHandler{
+		appRepo:        appRepo,
+		webhookRepo:    webhookRepo,
+		webhookService: webhookService,
+		logger:         logger.With("handler", "app_webhook"),
+	}
+}
+
+func (h *AppWebhookHandler) Register(app fiber.Router) {
+	webhooks := app.Group(APIPrefix + "/apps/:id/webhooks")
+	webhooks.Get("/", h.List)
+	webhooks.Post("/", h.Create)
+	webhooks.Patch("/:webhookId", h.Update)
+	webhooks.Delete("/:webhookId", h.Delete)
+	webhooks.Post("/:webhookId/test", h.Test)
+}
+
+type CreateAppWebhookRequest struct {
+	URL     string   `json:"url"`
+	Events  []string `json:"events"`
+	Enabled *bool    `json:"enabled"`
+}
+
+type UpdateAppWebhookRequest struct {
+	URL     *string   `json:"url"`
+	Events  *[]string `json:"events"`
+	Enabled *bool     `json:"enabled"`
+}
+
+// CreateAppWebhookResponse carries the signing secret, which is not
+// returned again.
+type CreateAppWebhookResponse struct {
+	*domain.AppWebhook
+	Secret string `json:"secret"`
+}
+
+type AppWebhookTestResponse struct {
+	Success    bool   `json:"success"`
+	StatusCode int    `json:"statusCode,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// requireApp and requireWebhook load the app and webhook of the route for
+// the user. When they return false they have already sent the error
+// response, which the caller returns.
+func (h *AppWebhookHandler) requireApp(c *fiber.Ctx) (*domain.App, bool, error) {
+	user := GetUserFromContext(c)
+	if user == nil {
+		return nil, false, response.Unauthorized(c, MsgNotAuthenticated)
+	}
+	app, err := h.appRepo.FindByIDAndUserID(c.Params("id"), user.ID)
+	if err != nil {
+		return nil, false, response.NotFound(c, MsgAppNotFound)
+	}
+	return app, true, nil
+}
+
+func (h *AppWebhookHandler) requireWebhook(c *fiber.Ctx) (*domain.App, *domain.AppWebhook, bool, error) {
+	app, ok, err := h.requireApp(c)
+	if !ok {
+		return nil, nil, false, err
+	}
+	webhook, err := h.webhookRepo.FindByIDAndAppID(c.Params("webhookId"), app.ID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, nil, false, response.NotFound(c, "Webhook not found")
+		}
+		h.logger.ErrorContext(c.UserContext(), "Failed to find webhook", "error", err)
+		return nil, nil, false, response.InternalError(c)
+	}
+	return app, webhook, true, nil
+}
+
+func validateWebhookURL(raw string) (string, string) {
+	raw = strings.TrimSpace(raw)
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return raw, "Webhook URL must be an http or https URL"
+	}
+	return raw, ""
+}
+
+func validateWebhookEvents(events []string) string {
+	for _, e := range events {
+		if !domain.IsValidAppWebhookEvent(e) {
+			return "Unknown webhook event: " + e + " (use " + strings.Join(domain.AppWebhookEvents, ", ") + ")"
+		}
+	}
+	return ""
+}
+
+func (h *AppWebhookHandler) List(c *fiber.Ctx) error {
+	app, ok, err := h.requireApp(c)
+	if !ok {
+		return err
+	}
+	webhooks, err := h.webhookRepo.FindByAppID(app.ID)
+	if err != nil {
+		h.logger.ErrorContext(c.UserContext(), "Failed to list webhooks", "appId", app.ID, "error", err)
+		return response.InternalError(c)
+	}
+	return response.OK(c, webhooks)
+}
+
+func (h *AppWebhookHandler) Create(c *fiber.Ctx) error {
+	app, ok, err := h.requireApp(c)
+	if !ok {
+		return err
+	}
+
+	var req CreateAppWebhookRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.BadRequest(c, MsgInvalidRequestBody)
+	}
+	webhookURL, msg := validateWebhookURL(req.URL)
+	if msg != "" {
+		return response.BadRequest(c, msg)
+	}
+	if msg := validateWebhookEvents(req.Events); msg != "" {
+		return response.BadRequest(c, msg)
+	}
+
+	existing, err := h.webhookRepo.FindByAppID(app.ID)
+	if err != nil {
+		h.logger.ErrorContext(c.UserContext(), "Failed to list webhooks", "appId", app.ID, "error", err)
+		return response.InternalError(c)
+	}
+	if len(existing) >= maxAppWebhooks {
+		return response.BadRequest(c, "An app can have at most 10 webhooks")
+	}
+
+	enabled := req.Enabled == nil || *req.Enabled
+	webhook, secret, err := h.webhookService.Create(app.ID, webhookURL, req.Events, enabled)
+	if err != nil {
+		h.logger.ErrorContext(c.UserContext(), "Failed to create webhook", "appId", app.ID, "error", err)
+		return response.InternalError(c)
+	}
+
+	h.logger.InfoContext(c.UserContext(), "App webhook created", "appId", app.ID, "webhookId", webhook.ID)
+	return response.Created(c, CreateAppWebhookResponse{AppWebhook: webhook, Secret: secret})
+}
+
+func (h *AppWebhookHandler) Update(c *fiber.Ctx) error {
+	app, webhook, ok, err := h.requireWebhook(c)
+	if !ok {
+		return err
+	}
+
+	var req UpdateAppWebhookRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.BadRequest(c, MsgInvalidRequestBody)
+	}
+	input := domain.UpdateAppWebhookInput{Events: req.Events, Enabled: req.Enabled}
+	if req.URL != nil {
+		webhookURL, msg := validateWebhookURL(*req.URL)
+		if msg != "" {
+			return response.BadRequest(c, msg)
+		}
+		input.URL = &webhookURL
+	}
+	if req.Events != nil {
+		if msg := validateWebhookEvents(*req.Events); msg != "" {
+			return response.BadRequest(c, msg)
+		}
+	}
+
+	updated, err := h.webhookRepo.Update(webhook.ID, input)
+	if err != nil {
+		h.logger.ErrorContext(c.UserContext(), "Failed to update webhook", "appId", app.ID, "webhookId", webhook.ID, "error", err)
+		return response.InternalError(c)
+	}
+	return response.OK(c, updated)
+}
+
+func (h *AppWebhookHandler) Delete(c *fiber.Ctx) error {
+	app, webhook, ok, err := h.requireWebhook(c)
+	if !ok {
+		return err
+	}
+	if err := h.webhookRepo.Delete(webhook.ID); err != nil {
+		h.logger.ErrorContext(c.UserContext(), "Failed to delete webhook", "appId", app.ID, "webhookId", webhook.ID, "error", err)
+		return response.InternalError(c)
+	}
+	h.logger.InfoContext(c.UserContext(), "App webhook deleted", "appId", app.ID, "webhookId", webhook.ID)
+	return response.NoContent(c)
+}
+
+// Test sends a ping event once, without retries, and reports how the
+// receiver answered.
+func (h *AppWebhookHandler) Test(c *fiber.Ctx) error {
+	app, webhook, ok, err := h.requireWebhook(c)
+	if !ok {
+		return err
+	}
+	delivery, err := h.webhookService.Test(c.UserContext(), webhook, app)
+	if err != nil {
+		h.logger.ErrorContext(c.UserContext(), "Failed to test webhook", "appId", app.ID, "webhookId", webhook.ID, "error", err)
+		return response.InternalError(c)
+	}
+	return response.OK(c, AppWebhookTestResponse{
+		Success:    delivery.Error == "",
+		StatusCode: delivery.StatusCode,
+		Error:      delivery.Error,
+	})
+}
@@ -2,10 +2,14 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
 	"github.com/paasdeploy/backend/internal/domain"
+	"github.com/paasdeploy/backend/internal/ghclient"
 	"github.com/paasdeploy/backend/internal/repository"
 	"github.com/paasdeploy/backend/internal/response"
 	"github.com/paasdeploy/backend/internal/service"
@@ -14,12 +18,14 @@ import (
 type AuditHandler struct {
 	auditService      *service.AuditService
 	webhookPayloadRepo *repository.PostgresWebhookPayloadRepository
+	webhookHandler     *ghclient.WebhookHandler
 }
 
-func NewAuditHandler(auditService *service.AuditService, webhookPayloadRepo *repository.PostgresWebhookPayloadRepository) *AuditHandler {
+func NewAuditHandler(auditService *service.AuditService, webhookPayloadRepo *repository.PostgresWebhookPayloadRepository, webhookHandler *ghclient.WebhookHandler) *AuditHandler {
 	return &AuditHandler{
 		auditService:       auditService,
 		webhookPayloadRepo: webhookPayloadRepo,
+		webhookHandler:     webhookHandler,
 	}
 }
 
@@ -27,6 +33,7 @@ func (h *AuditHandler) Register(router fiber.Router) {
 	audit := router.Group("/audit")
 	audit.Get("/logs", h.ListLogs)
 	audit.Get("/webhook-payloads", h.ListWebhookPayloads)
+	audit.Post("/webhooks/:id/replay", h.ReplayWebhook)
 	audit.Post("/cleanup", h.Cleanup)
 }
 
@@ -56,22 +63,35 @@ func (h *AuditHandler) ListLogs(c *fiber.Ctx) error {
 		return response.Unauthorized(c, MsgNotAuthenticated)
 	}
 
-	filter := buildAuditFilter(c)
+	filter, err := buildAuditFilter(c)
+	if err != nil {
+		return response.BadRequest(c, err.Error())
+	}
 	filter.UserID = &user.ID
 
 	logs, total, err := h.auditService.Query(filter)
 	if err != nil {
+		if errors.Is(err, domain.ErrInvalidInput) {
+			return response.BadRequest(c, err.Error())
+		}
 		return response.ServerError(c, fiber.StatusInternalServerError, "Failed to fetch audit logs")
 	}
 
 	result := toAuditLogResponses(logs)
 
-	return response.OK(c, AuditLogsResponse{
+	// A full page gets a cursor, so the last page may come back empty.
+	var nextCursor string
+	if len(logs) > 0 && len(logs) >= filter.Limit {
+		last := logs[len(logs)-1]
+		nextCursor = domain.Cursor{SortBy: auditLogSort, Value: domain.CursorTime(last.CreatedAt), ID: last.ID}.Encode()
+	}
+
+	return response.OKWithCursor(c, AuditLogsResponse{
 		Logs:   result,
 		Total:  total,
 		Limit:  filter.Limit,
 		Offset: filter.Offset,
-	})
+	}, nextCursor)
 }
 
 type WebhookPayloadResponse struct {
@@ -127,10 +147,66 @@ func (h *AuditHandler) ListWebhookPayloads(c *fiber.Ctx) error {
 	})
 }
 
-func buildAuditFilter(c *fiber.Ctx) domain.AuditLogFilter {
+// ReplayWebhook processes a stored GitHub push again, as if it had just been
+// delivered. Admins only: the push may deploy apps of any user.
+func (h *AuditHandler) ReplayWebhook(c *fiber.Ctx) error {
+	user := GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, MsgNotAuthenticated)
+	}
+	if !user.IsAdmin() {
+		return response.Forbidden(c, "only admins can replay webhooks")
+	}
+	if h.webhookHandler == nil {
+		return response.ServerError(c, fiber.StatusServiceUnavailable, "webhooks are not configured")
+	}
+
+	id := c.Params("id")
+	if _, err := uuid.Parse(id); err != nil {
+		return response.NotFound(c, "webhook payload not found")
+	}
+	payload, err := h.webhookPayloadRepo.FindByID(c.Context(), id)
+	if errors.Is(err, domain.ErrNotFound) {
+		return response.NotFound(c, "webhook payload not found")
+	}
+	if err != nil {
+		return response.ServerError(c, fiber.StatusInternalServerError, "Failed to fetch webhook payload")
+	}
+
+	h.auditService.LogWebhookReplayed(c.Context(), h.auditService.ExtractContext(c), payload.ID, payload.DeliveryID)
+
+	err = h.webhookHandler.Replay(c, payload.DeliveryID, payload.EventType, payload.Outcome, payload.Payload)
+	if errors.Is(err, ghclient.ErrReplayNotSupported) {
+		return response.ServerError(c, fiber.StatusUnprocessableEntity, err.Error())
+	}
+	return err
+}
+
+// auditLogSort is the only order of audit logs, newest first.
+const auditLogSort = "-createdAt"
+
+func buildAuditFilter(c *fiber.Ctx) (domain.AuditLogFilter, error) {
 	filter := domain.AuditLogFilter{
 		Limit:  c.QueryInt("limit", 50),
 		Offset: c.QueryInt("offset", 0),
+		Search: c.Query("q"),
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = 50
+	} else if filter.Limit > 500 {
+		filter.Limit = 500
+	}
+
+	if value := c.Query("cursor"); value != "" {
+		cursor, err := domain.DecodeCursor(value)
+		if err != nil {
+			return filter, err
+		}
+		if cursor.SortBy != auditLogSort {
+			return filter, fmt.Errorf("%w: cursor was issued for a different list", domain.ErrInvalidInput)
+		}
+		filter.After = cursor
+		filter.Offset = 0
 	}
 
 	if eventType := c.Query("eventType"); eventType != "" {
@@ -151,6 +227,10 @@ func buildAuditFilter(c *fiber.Ctx) domain.AuditLogFilter {
 		filter.UserID = &userID
 	}
 
+	if traceID := c.Query("traceId"); traceID != "" {
+		filter.TraceID = &traceID
+	}
+
 	if startDate := parseQueryTime(c, "startDate"); startDate != nil {
 		filter.StartDate = startDate
 	}
@@ -159,7 +239,20 @@ func buildAuditFilter(c *fiber.Ctx) domain.AuditLogFilter {
 		filter.EndDate = endDate
 	}
 
-	return filter
+	// from and to are the range parameters shared with the other lists.
+	var err error
+	if filter.StartDate == nil {
+		if filter.StartDate, err = parseListTime(c.Query("from"), false); err != nil {
+			return filter, fmt.Errorf("%w: from must be an RFC 3339 time or a date", domain.ErrInvalidInput)
+		}
+	}
+	if filter.EndDate == nil {
+		if filter.EndDate, err = parseListTime(c.Query("to"), true); err != nil {
+			return filter, fmt.Errorf("%w: to must be an RFC 3339 time or a date", domain.ErrInvalidInput)
+		}
+	}
+
+	return filter, nil
 }
 
 func parseQueryTime(c *fiber.Ctx, key string) *time.Time {
@@ -129,13 +129,13 @@ func (h *AuthHandler) RegisterEmail(c *fiber.Ctx) error {
 
 	hash, err := password.Hash(req.Password)
 	if err != nil {
-		h.logger.Error("failed to hash password", "error", err)
+		h.logger.ErrorContext(c.UserContext(), "failed to hash password", "error", err)
 		return response.InternalError(c)
 	}
 
 	existing, err := h.userRepo.FindByEmail(c.Context(), req.Email)
 	if err != nil && !errors.Is(err, domain.ErrNotFound) {
-		h.logger.Error("failed to check existing email", "error", err)
+		h.logger.ErrorContext(c.UserContext(), "failed to check existing email", "error", err)
 		return response.InternalError(c)
 	}
 
@@ -145,7 +145,7 @@ func (h *AuthHandler) RegisterEmail(c *fiber.Ctx) error {
 	}
 
 	if err := h.createSession(c, user.ID); err != nil {
-		h.logger.Error(errMsgSessionCreation, "error", err)
+		h.logger.ErrorContext(c.UserContext(), errMsgSessionCreation, "error", err)
 		return response.InternalError(c)
 	}
 
@@ -168,10 +168,10 @@ func (h *AuthHandler) resolveOrCreateUser(c *fiber.Ctx, existing *domain.User, e
 		}
 		user, err := h.userRepo.SetPassword(c.Context(), existing.ID, hash)
 		if err != nil {
-			h.logger.Error("failed to set password on existing user", "error", err)
+			h.logger.ErrorContext(c.UserContext(), "failed to set password on existing user", "error", err)
 			return nil, response.InternalError(c)
 		}
-		h.logger.Info("password added to existing account", "user_id", user.ID, "email", email)
+		h.logger.InfoContext(c.UserContext(), "password added to existing account", "user_id", user.ID, "email", email)
 		return user, nil
 	}
 
@@ -181,10 +181,10 @@ func (h *AuthHandler) resolveOrCreateUser(c *fiber.Ctx, existing *domain.User, e
 		PasswordHash: hash,
 	})
 	if err != nil {
-		h.logger.Error("failed to create email user", "error", err)
+		h.logger.ErrorContext(c.UserContext(), "failed to create email user", "error", err)
 		return nil, response.InternalError(c)
 	}
-	h.logger.Info("email user registered", "user_id", user.ID, "email", email)
+	h.logger.InfoContext(c.UserContext(), "email user registered", "user_id", user.ID, "email", email)
 	return user, nil
 }
 
@@ -203,7 +203,7 @@ func (h *AuthHandler) LoginEmail(c *fiber.Ctx) error {
 		if errors.Is(err, domain.ErrNotFound) {
 			return response.Unauthorized(c, errMsgInvalidCreds)
 		}
-		h.logger.Error("failed to find user by email", "error", err)
+		h.logger.ErrorContext(c.UserContext(), "failed to find user by email", "error", err)
 		return response.InternalError(c)
 	}
 
@@ -216,7 +216,7 @@ func (h *AuthHandler) LoginEmail(c *fiber.Ctx) error {
 	}
 
 	if err := h.createSession(c, user.ID); err != nil {
-		h.logger.Error(errMsgSessionCreation, "error", err)
+		h.logger.ErrorContext(c.UserContext(), errMsgSessionCreation, "error", err)
 		return response.InternalError(c)
 	}
 
@@ -242,7 +242,7 @@ func (h *AuthHandler) LinkGitHub(c *fiber.Ctx) error {
 
 	state, err := crypto.GenerateState()
 	if err != nil {
-		h.logger.Error("failed to generate state", "error", err)
+		h.logger.ErrorContext(c.UserContext(), "failed to generate state", "error", err)
 		return response.InternalError(c)
 	}
 
@@ -256,7 +256,7 @@ func (h *AuthHandler) LinkGitHub(c *fiber.Ctx) error {
 func (h *AuthHandler) InitiateOAuth(c *fiber.Ctx) error {
 	state, err := crypto.GenerateState()
 	if err != nil {
-		h.logger.Error("failed to generate state", "error", err)
+		h.logger.ErrorContext(c.UserContext(), "failed to generate state", "error", err)
 		return fiber.NewError(fiber.StatusInternalServerError, "failed to initiate OAuth")
 	}
 
@@ -269,7 +269,7 @@ func (h *AuthHandler) InitiateOAuth(c *fiber.Ctx) error {
 func (h *AuthHandler) validateCallback(c *fiber.Ctx) (string, error) {
 	if errorParam := c.Query("error"); errorParam != "" {
 		errorDesc := c.Query("error_description")
-		h.logger.Warn("OAuth error from GitHub", "error", errorParam, "description", errorDesc)
+		h.logger.WarnContext(c.UserContext(), "OAuth error from GitHub", "error", errorParam, "description", errorDesc)
 		return "", errors.New(errorParam)
 	}
 
@@ -281,7 +281,7 @@ func (h *AuthHandler) validateCallback(c *fiber.Ctx) (string, error) {
 	state := c.Query("state")
 	storedState := c.Cookies("oauth_state")
 	if state != storedState {
-		h.logger.Warn("Invalid OAuth state", "expected", storedState, "got", state)
+		h.logger.WarnContext(c.UserContext(), "Invalid OAuth state", "expected", storedState, "got", state)
 		return "", errors.New("invalid_state")
 	}
 
@@ -430,7 +430,7 @@ func (h *AuthHandler) createSession(c *fiber.Ctx, userID string) error {
 func (h *AuthHandler) HandleCallback(c *fiber.Ctx) error {
 	code, err := h.validateCallback(c)
 	if err != nil {
-		h.logger.Warn("OAuth callback validation failed", "error", err)
+		h.logger.WarnContext(c.UserContext(), "OAuth callback validation failed", "error", err)
 		return h.redirectWithError(c, "oauth_error")
 	}
 
@@ -443,13 +443,13 @@ func (h *AuthHandler) HandleCallback(c *fiber.Ctx) error {
 
 	tokenResp, tokens, err := h.exchangeAndEncryptTokens(ctx, code)
 	if err != nil {
-		h.logger.Error("failed to exchange/encrypt tokens", "error", err)
+		h.logger.ErrorContext(c.UserContext(), "failed to exchange/encrypt tokens", "error", err)
 		return h.redirectWithError(c, "token_exchange_failed")
 	}
 
 	ghUser, err := h.oauthClient.GetUser(ctx, tokenResp.AccessToken)
 	if err != nil {
-		h.logger.Error("failed to get GitHub user", "error", err)
+		h.logger.ErrorContext(c.UserContext(), "failed to get GitHub user", "error", err)
 		return h.redirectWithError(c, "user_fetch_failed")
 	}
 
@@ -464,12 +464,12 @@ func (h *AuthHandler) HandleCallback(c *fiber.Ctx) error {
 
 	user, err := h.upsertUser(ctx, ghUser, email, tokens)
 	if err != nil {
-		h.logger.Error("failed to upsert user", "error", err)
+		h.logger.ErrorContext(c.UserContext(), "failed to upsert user", "error", err)
 		return h.redirectWithError(c, "database_error")
 	}
 
 	if err := h.createSession(c, user.ID); err != nil {
-		h.logger.Error(errMsgSessionCreation, "error", err)
+		h.logger.ErrorContext(c.UserContext(), errMsgSessionCreation, "error", err)
 		return h.redirectWithError(c, "session_error")
 	}
 
@@ -493,7 +493,7 @@ func (h *AuthHandler) handleLinkCallback(c *fiber.Ctx, ghUser *ghclient.GitHubUs
 
 	existingGH, err := h.userRepo.FindByGitHubID(ctx, ghUser.ID)
 	if err != nil && !errors.Is(err, domain.ErrNotFound) {
-		h.logger.Error("failed to check existing github user", "error", err)
+		h.logger.ErrorContext(c.UserContext(), "failed to check existing github user", "error", err)
 		return h.redirectWithError(c, "database_error")
 	}
 	if existingGH != nil {
@@ -511,11 +511,11 @@ func (h *AuthHandler) handleLinkCallback(c *fiber.Ctx, ghUser *ghclient.GitHubUs
 		TokenExpiresAt:        tokens.expiresAt,
 	})
 	if err != nil {
-		h.logger.Error("failed to link github", "error", err)
+		h.logger.ErrorContext(c.UserContext(), "failed to link github", "error", err)
 		return h.redirectWithError(c, "link_failed")
 	}
 
-	h.logger.Info("github linked to user", "user_id", currentUser.ID, "github_login", ghUser.Login)
+	h.logger.InfoContext(c.UserContext(), "github linked to user", "user_id", currentUser.ID, "github_login", ghUser.Login)
 	return c.Redirect(h.frontendURL+"/settings?github_linked=true", fiber.StatusTemporaryRedirect)
 }
 
@@ -562,7 +562,7 @@ func (h *AuthHandler) Logout(c *fiber.Ctx) error {
 				}
 			}
 			if delErr := h.sessionRepo.Delete(c.Context(), session.ID); delErr != nil {
-				h.logger.Warn("failed to delete session", "error", delErr)
+				h.logger.WarnContext(c.UserContext(), "failed to delete session", "error", delErr)
 			}
 		}
 	}
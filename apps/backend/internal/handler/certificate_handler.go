@@ -16,28 +16,34 @@ import (
 const remoteCertTimeout = 5 * time.Second
 
 type CertificateHandler struct {
-	traefikClient *traefik.Client
-	agentClient   *agentclient.AgentClient
-	serverRepo    domain.ServerRepository
-	agentPort     int
-	logger        *slog.Logger
+	traefikClient    *traefik.Client
+	agentClient      *agentclient.AgentClient
+	serverRepo       domain.ServerRepository
+	appRepo          domain.AppRepository
+	customDomainRepo domain.CustomDomainRepository
+	agentPort        int
+	logger           *slog.Logger
 }
 
 type CertificateHandlerConfig struct {
-	TraefikURL  string
-	AgentClient *agentclient.AgentClient
-	ServerRepo  domain.ServerRepository
-	AgentPort   int
-	Logger      *slog.Logger
+	TraefikURL       string
+	AgentClient      *agentclient.AgentClient
+	ServerRepo       domain.ServerRepository
+	AppRepo          domain.AppRepository
+	CustomDomainRepo domain.CustomDomainRepository
+	AgentPort        int
+	Logger           *slog.Logger
 }
 
 func NewCertificateHandler(cfg CertificateHandlerConfig) *CertificateHandler {
 	return &CertificateHandler{
-		traefikClient: traefik.NewClient(cfg.TraefikURL),
-		agentClient:   cfg.AgentClient,
-		serverRepo:    cfg.ServerRepo,
-		agentPort:     cfg.AgentPort,
-		logger:        cfg.Logger,
+		traefikClient:    traefik.NewClient(cfg.TraefikURL),
+		agentClient:      cfg.AgentClient,
+		serverRepo:       cfg.ServerRepo,
+		appRepo:          cfg.AppRepo,
+		customDomainRepo: cfg.CustomDomainRepo,
+		agentPort:        cfg.AgentPort,
+		logger:           cfg.Logger,
 	}
 }
 
@@ -45,12 +51,19 @@ func (h *CertificateHandler) RegisterRoutes(router fiber.Router) {
 	certificates := router.Group("/certificates")
 	certificates.Get("/", h.ListCertificates)
 	certificates.Get("/:domain", h.GetCertificateStatus)
+
+	serverCerts := certificates.Group("/servers/:serverId")
+	serverCerts.Get("/", h.ListServerCertificates)
+	serverCerts.Post("/renew", h.RenewServerCertificate)
+	serverCerts.Post("/prune", h.PruneServerCertificates)
+	serverCerts.Put("/staging", h.SetAcmeStaging)
+	serverCerts.Delete("/:domain", h.DeleteServerCertificate)
 }
 
 func (h *CertificateHandler) ListCertificates(c *fiber.Ctx) error {
 	localCerts, err := h.traefikClient.GetAllCertificatesStatus(c.Context())
 	if err != nil {
-		h.logger.Warn("Traefik unavailable, returning empty local certificates", "error", err)
+		h.logger.WarnContext(c.UserContext(), "Traefik unavailable, returning empty local certificates", "error", err)
 		localCerts = []traefik.CertificateStatus{}
 	}
 
@@ -82,7 +95,7 @@ func (h *CertificateHandler) GetCertificateStatus(c *fiber.Ctx) error {
 
 	status, err := h.traefikClient.GetCertificateStatus(c.Context(), domain)
 	if err != nil {
-		h.logger.Warn("Traefik unavailable for certificate status", "error", err, "domain", domain)
+		h.logger.WarnContext(c.UserContext(), "Traefik unavailable for certificate status", "error", err, "domain", domain)
 		return response.OK(c, &traefik.CertificateStatus{
 			Domain: domain,
 			Status: "unavailable",
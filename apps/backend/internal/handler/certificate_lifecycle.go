@@ -0,0 +1,207 @@
+package handler
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/paasdeploy/backend/internal/domain"
+	"github.com/paasdeploy/backend/internal/engine"
+	"github.com/paasdeploy/backend/internal/response"
+)
+
+type ServerCertificate struct {
+	Domain        string     `json:"domain"`
+	Sans          []string   `json:"sans,omitempty"`
+	Resolver      string     `json:"resolver"`
+	Issuer        string     `json:"issuer,omitempty"`
+	ExpiresAt     *time.Time `json:"expiresAt,omitempty"`
+	DaysRemaining *int       `json:"daysRemaining,omitempty"`
+	Staging       bool       `json:"staging"`
+	Stale         bool       `json:"stale"`
+}
+
+type ServerCertificatesResponse struct {
+	AcmeStaging  bool                `json:"acmeStaging"`
+	Certificates []ServerCertificate `json:"certificates"`
+}
+
+type RenewCertificateRequest struct {
+	Domain string `json:"domain"`
+}
+
+type SetAcmeStagingRequest struct {
+	Enabled bool `json:"enabled"`
+}
+
+type CertificateActionResponse struct {
+	Removed int    `json:"removed"`
+	Message string `json:"message"`
+}
+
+// requireServerForUser loads the server of the route for the user. When it
+// returns false it has already sent the error response.
+func (h *CertificateHandler) requireServerForUser(c *fiber.Ctx) (*domain.Server, bool, error) {
+	user := GetUserFromContext(c)
+	if user == nil {
+		return nil, false, response.Unauthorized(c, MsgNotAuthenticated)
+	}
+	server, err := h.serverRepo.FindByIDForUser(c.Params("serverId"), user.ID)
+	if err != nil {
+		return nil, false, HandleNotFoundOrInternal(c, err, MsgServerNotFound)
+	}
+	return server, true, nil
+}
+
+func (h *CertificateHandler) listServerCertificates(ctx context.Context, server *domain.Server) ([]ServerCertificate, error) {
+	pbCerts, err := h.agentClient.ListAcmeCertificates(ctx, server.Host, h.agentPort)
+	if err != nil {
+		return nil, err
+	}
+	routed, err := engine.ServerACMEDomains(ctx, h.appRepo, h.customDomainRepo, server.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	certs := make([]ServerCertificate, 0, len(pbCerts))
+	for _, pc := range pbCerts {
+		cert := ServerCertificate{
+			Domain:   pc.Domain,
+			Sans:     pc.Sans,
+			Resolver: pc.Resolver,
+			Issuer:   pc.Issuer,
+			Staging:  pc.Staging,
+		}
+		if _, ok := routed[strings.ToLower(pc.Domain)]; !ok {
+			cert.Stale = true
+		}
+		if pc.NotAfter > 0 {
+			expiresAt := time.Unix(pc.NotAfter, 0).UTC()
+			days := int(expiresAt.Sub(now).Hours() / 24)
+			cert.ExpiresAt = &expiresAt
+			cert.DaysRemaining = &days
+		}
+		certs = append(certs, cert)
+	}
+	sort.Slice(certs, func(i, j int) bool { return certs[i].Domain < certs[j].Domain })
+	return certs, nil
+}
+
+func (h *CertificateHandler) ListServerCertificates(c *fiber.Ctx) error {
+	server, ok, err := h.requireServerForUser(c)
+	if !ok {
+		return err
+	}
+
+	certs, err := h.listServerCertificates(c.Context(), server)
+	if err != nil {
+		h.logger.ErrorContext(c.UserContext(), "Failed to list server certificates", "serverId", server.ID, "error", err)
+		return response.ServerError(c, fiber.StatusBadGateway, "Failed to read certificates from server")
+	}
+	return response.OK(c, ServerCertificatesResponse{AcmeStaging: server.AcmeStaging, Certificates: certs})
+}
+
+// RenewServerCertificate drops the stored certificate so Traefik requests a
+// new one for the domain when it restarts.
+func (h *CertificateHandler) RenewServerCertificate(c *fiber.Ctx) error {
+	server, ok, err := h.requireServerForUser(c)
+	if !ok {
+		return err
+	}
+
+	var req RenewCertificateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.BadRequest(c, MsgInvalidRequestBody)
+	}
+	domainName := strings.ToLower(strings.TrimSpace(req.Domain))
+	if domainName == "" {
+		return response.BadRequest(c, "Domain is required")
+	}
+
+	routed, err := engine.ServerACMEDomains(c.Context(), h.appRepo, h.customDomainRepo, server.ID)
+	if err != nil {
+		return response.InternalError(c)
+	}
+	if _, ok := routed[domainName]; !ok {
+		return response.BadRequest(c, "Domain is not routed on this server, delete the certificate instead")
+	}
+
+	return h.deleteServerCertificates(c, server, []string{domainName}, "Certificate will be renewed shortly")
+}
+
+func (h *CertificateHandler) DeleteServerCertificate(c *fiber.Ctx) error {
+	server, ok, err := h.requireServerForUser(c)
+	if !ok {
+		return err
+	}
+
+	domainName := strings.ToLower(strings.TrimSpace(c.Params("domain")))
+	if domainName == "" {
+		return response.BadRequest(c, "Domain is required")
+	}
+	return h.deleteServerCertificates(c, server, []string{domainName}, "Certificate deleted")
+}
+
+// PruneServerCertificates deletes the certificates of domains that are no
+// longer routed to any app on the server.
+func (h *CertificateHandler) PruneServerCertificates(c *fiber.Ctx) error {
+	server, ok, err := h.requireServerForUser(c)
+	if !ok {
+		return err
+	}
+
+	certs, err := h.listServerCertificates(c.Context(), server)
+	if err != nil {
+		h.logger.ErrorContext(c.UserContext(), "Failed to list server certificates", "serverId", server.ID, "error", err)
+		return response.ServerError(c, fiber.StatusBadGateway, "Failed to read certificates from server")
+	}
+
+	var stale []string
+	for _, cert := range certs {
+		if cert.Stale {
+			stale = append(stale, cert.Domain)
+		}
+	}
+	if len(stale) == 0 {
+		return response.OK(c, CertificateActionResponse{Message: "No stale certificates"})
+	}
+	return h.deleteServerCertificates(c, server, stale, "Stale certificates deleted")
+}
+
+func (h *CertificateHandler) deleteServerCertificates(c *fiber.Ctx, server *domain.Server, domains []string, message string) error {
+	removed, err := h.agentClient.DeleteAcmeCertificates(c.Context(), server.Host, h.agentPort, domains)
+	if err != nil {
+		h.logger.ErrorContext(c.UserContext(), "Failed to delete server certificates", "serverId", server.ID, "domains", domains, "error", err)
+		return response.ServerError(c, fiber.StatusBadGateway, "Failed to delete certificates on server")
+	}
+	if removed == 0 {
+		return response.NotFound(c, "Certificate not found")
+	}
+	h.logger.InfoContext(c.UserContext(), "Server certificates deleted", "serverId", server.ID, "domains", domains, "removed", removed)
+	return response.OK(c, CertificateActionResponse{Removed: removed, Message: message})
+}
+
+// SetAcmeStaging switches the server's resolver to the Let's Encrypt staging
+// CA. Like other server settings, it is applied on the next provisioning.
+func (h *CertificateHandler) SetAcmeStaging(c *fiber.Ctx) error {
+	server, ok, err := h.requireServerForUser(c)
+	if !ok {
+		return err
+	}
+
+	var req SetAcmeStagingRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.BadRequest(c, MsgInvalidRequestBody)
+	}
+
+	updated, err := h.serverRepo.Update(server.ID, domain.UpdateServerInput{AcmeStaging: &req.Enabled})
+	if err != nil {
+		h.logger.ErrorContext(c.UserContext(), "Failed to update ACME staging", "serverId", server.ID, "error", err)
+		return response.InternalError(c)
+	}
+	return response.OK(c, toServerResponse(updated))
+}
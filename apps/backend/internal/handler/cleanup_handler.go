@@ -71,7 +71,7 @@ func (h *CleanupHandler) PruneContainers(c *fiber.Ctx) error {
 	resp, err := h.agentClient.PruneContainers(c.Context(), server.Host, h.agentPort)
 	if err != nil {
 		h.logCleanup(serverID, domain.CleanupTypeContainers, 0, 0, err.Error())
-		h.logger.Error("Failed to prune containers", "serverId", serverID, "error", err)
+		h.logger.ErrorContext(c.UserContext(), "Failed to prune containers", "serverId", serverID, "error", err)
 		return response.ServerError(c, fiber.StatusInternalServerError, "Failed to prune containers")
 	}
 
@@ -99,7 +99,7 @@ func (h *CleanupHandler) PruneVolumes(c *fiber.Ctx) error {
 	resp, err := h.agentClient.PruneVolumes(c.Context(), server.Host, h.agentPort)
 	if err != nil {
 		h.logCleanup(serverID, domain.CleanupTypeVolumes, 0, 0, err.Error())
-		h.logger.Error("Failed to prune volumes", "serverId", serverID, "error", err)
+		h.logger.ErrorContext(c.UserContext(), "Failed to prune volumes", "serverId", serverID, "error", err)
 		return response.ServerError(c, fiber.StatusInternalServerError, "Failed to prune volumes")
 	}
 
@@ -138,7 +138,7 @@ func (h *CleanupHandler) ListCleanupLogs(c *fiber.Ctx) error {
 
 	logs, err := h.cleanupLogRepo.FindByServerID(serverID, limit, offset)
 	if err != nil {
-		h.logger.Error("Failed to list cleanup logs", "serverId", serverID, "error", err)
+		h.logger.ErrorContext(c.UserContext(), "Failed to list cleanup logs", "serverId", serverID, "error", err)
 		return response.ServerError(c, fiber.StatusInternalServerError, "Failed to list cleanup logs")
 	}
 
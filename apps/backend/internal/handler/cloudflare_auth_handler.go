@@ -85,7 +85,7 @@ func (h *CloudflareAuthHandler) InitiateOAuth(c *fiber.Ctx) error {
 
 	state, err := h.generateState()
 	if err != nil {
-		h.logger.Error("failed to generate state", "error", err)
+		h.logger.ErrorContext(c.UserContext(), "failed to generate state", "error", err)
 		return response.InternalError(c)
 	}
 
@@ -108,7 +108,7 @@ func (h *CloudflareAuthHandler) HandleCallback(c *fiber.Ctx) error {
 	}
 
 	if errorParam := c.Query("error"); errorParam != "" {
-		h.logger.Warn("OAuth error from Cloudflare",
+		h.logger.WarnContext(c.UserContext(), "OAuth error from Cloudflare",
 			"error", errorParam,
 			"description", c.Query("error_description"),
 		)
@@ -131,7 +131,7 @@ func (h *CloudflareAuthHandler) HandleCallback(c *fiber.Ctx) error {
 
 	accessToken, err := h.exchangeCodeForToken(code)
 	if err != nil {
-		h.logger.Error("failed to exchange code for token", "error", err)
+		h.logger.ErrorContext(c.UserContext(), "failed to exchange code for token", "error", err)
 		return h.redirectWithError(c, "token_exchange_failed")
 	}
 
@@ -139,13 +139,13 @@ func (h *CloudflareAuthHandler) HandleCallback(c *fiber.Ctx) error {
 
 	userInfo, err := cfClient.GetUserInfo(c.Context())
 	if err != nil {
-		h.logger.Error("failed to get cloudflare user info", "error", err)
+		h.logger.ErrorContext(c.UserContext(), "failed to get cloudflare user info", "error", err)
 		return h.redirectWithError(c, "user_info_failed")
 	}
 
 	encryptedToken, err := h.tokenEncryptor.Encrypt(accessToken)
 	if err != nil {
-		h.logger.Error("failed to encrypt token", "error", err)
+		h.logger.ErrorContext(c.UserContext(), "failed to encrypt token", "error", err)
 		return h.redirectWithError(c, "encryption_failed")
 	}
 
@@ -156,11 +156,11 @@ func (h *CloudflareAuthHandler) HandleCallback(c *fiber.Ctx) error {
 		AccessTokenEncrypted: encryptedToken,
 	})
 	if err != nil {
-		h.logger.Error("failed to save cloudflare connection", "error", err)
+		h.logger.ErrorContext(c.UserContext(), "failed to save cloudflare connection", "error", err)
 		return h.redirectWithError(c, "save_failed")
 	}
 
-	h.logger.Info("Cloudflare connected",
+	h.logger.InfoContext(c.UserContext(), "Cloudflare connected",
 		"user_id", user.ID,
 		"cloudflare_email", userInfo.Email,
 	)
@@ -183,11 +183,11 @@ func (h *CloudflareAuthHandler) Disconnect(c *fiber.Ctx) error {
 	}
 
 	if err := h.connectionRepo.DeleteByUserID(c.Context(), user.ID); err != nil {
-		h.logger.Error("failed to delete cloudflare connection", "error", err, "user_id", user.ID)
+		h.logger.ErrorContext(c.UserContext(), "failed to delete cloudflare connection", "error", err, "user_id", user.ID)
 		return response.InternalError(c)
 	}
 
-	h.logger.Info("Cloudflare disconnected", "user_id", user.ID)
+	h.logger.InfoContext(c.UserContext(), "Cloudflare disconnected", "user_id", user.ID)
 
 	return response.OK(c, fiber.Map{"message": "disconnected"})
 }
@@ -235,13 +235,13 @@ func (h *CloudflareAuthHandler) ConnectWithToken(c *fiber.Ctx) error {
 
 	tokenInfo, err := cfClient.VerifyToken(c.Context())
 	if err != nil {
-		h.logger.Warn("invalid cloudflare token", "error", err, "user_id", user.ID)
+		h.logger.WarnContext(c.UserContext(), "invalid cloudflare token", "error", err, "user_id", user.ID)
 		return response.BadRequest(c, "Invalid API token")
 	}
 
 	encryptedToken, err := h.tokenEncryptor.Encrypt(req.APIToken)
 	if err != nil {
-		h.logger.Error("failed to encrypt token", "error", err)
+		h.logger.ErrorContext(c.UserContext(), "failed to encrypt token", "error", err)
 		return response.InternalError(c)
 	}
 
@@ -252,11 +252,11 @@ func (h *CloudflareAuthHandler) ConnectWithToken(c *fiber.Ctx) error {
 		AccessTokenEncrypted: encryptedToken,
 	})
 	if err != nil {
-		h.logger.Error("failed to save cloudflare connection", "error", err)
+		h.logger.ErrorContext(c.UserContext(), "failed to save cloudflare connection", "error", err)
 		return response.InternalError(c)
 	}
 
-	h.logger.Info("Cloudflare connected via API token",
+	h.logger.InfoContext(c.UserContext(), "Cloudflare connected via API token",
 		"user_id", user.ID,
 		"token_id", tokenInfo.ID,
 	)
@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/paasdeploy/backend/internal/response"
+	"github.com/paasdeploy/shared/pkg/compose"
+)
+
+// ConfigHandler publishes the JSON Schema of paasdeploy.json and checks
+// configuration files against it before they are committed.
+type ConfigHandler struct{}
+
+func NewConfigHandler() *ConfigHandler {
+	return &ConfigHandler{}
+}
+
+func (h *ConfigHandler) Register(app fiber.Router) {
+	app.Get(APIPrefix+"/config/schema", h.Schema)
+	app.Post(APIPrefix+"/config/validate", h.Validate)
+}
+
+type ConfigValidationResponse struct {
+	Valid  bool                  `json:"valid"`
+	Errors []compose.ConfigError `json:"errors"`
+}
+
+// Schema serves the schema as is, so editors can reference it from the
+// "$schema" key of a config file.
+func (h *ConfigHandler) Schema(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderContentType, "application/schema+json")
+	return c.Send(compose.ConfigSchema())
+}
+
+// Validate checks the configuration file in the request body. It is read
+// as YAML when ?format=yaml is given or the content type says so, and as
+// JSON otherwise.
+func (h *ConfigHandler) Validate(c *fiber.Ctx) error {
+	body := c.Body()
+	if len(body) == 0 {
+		return response.BadRequest(c, MsgInvalidRequestBody)
+	}
+
+	name := "paasdeploy.json"
+	format := strings.ToLower(c.Query("format"))
+	if format == "yaml" || format == "yml" || strings.Contains(strings.ToLower(c.Get(fiber.HeaderContentType)), "yaml") {
+		name = "paasdeploy.yaml"
+	}
+
+	errs := compose.ValidateConfig(name, body)
+	if errs == nil {
+		errs = []compose.ConfigError{}
+	}
+	return response.OK(c, ConfigValidationResponse{
+		Valid:  len(errs) == 0,
+		Errors: errs,
+	})
+}
@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+	pb "github.com/paasdeploy/backend/gen/go/flowdeploy/v1"
+	"github.com/paasdeploy/backend/internal/response"
+	"github.com/paasdeploy/shared/pkg/docker"
+)
+
+const msgFailedCommitContainer = "Failed to commit container"
+
+type CommitContainerRequest struct {
+	Repository string `json:"repository"`
+	Tag        string `json:"tag"`
+	Message    string `json:"message"`
+	Pause      *bool  `json:"pause"`
+}
+
+type CommitContainerResponse struct {
+	ImageID string `json:"imageId"`
+	Image   string `json:"image"`
+}
+
+// CommitContainer snapshots a container into a tagged image, e.g. to keep a
+// hotfix applied by hand or to debug a broken container offline. The image
+// shows up in the image list afterwards.
+func (h *ContainerHandler) CommitContainer(c *fiber.Ctx) error {
+	id := c.Params("id")
+	serverID := c.Query("serverId", "")
+
+	var req CommitContainerRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.BadRequest(c, "Invalid request body")
+	}
+	opts := docker.CommitOptions{
+		Repository: req.Repository,
+		Tag:        req.Tag,
+		Message:    req.Message,
+		Pause:      req.Pause == nil || *req.Pause,
+	}
+	image, err := opts.ImageReference()
+	if err != nil {
+		return response.BadRequest(c, err.Error())
+	}
+
+	host, ok, err := h.containerTarget(c, serverID)
+	if !ok {
+		return err
+	}
+	opts.Author = GetUserFromContext(c).Email
+
+	var imageID string
+	if serverID == "" {
+		imageID, err = h.docker.CommitContainer(c.Context(), id, opts)
+	} else {
+		imageID, err = h.agentClient.CommitContainer(c.Context(), host, h.agentPort, &pb.CommitContainerRequest{
+			ContainerId: id,
+			Repository:  opts.Repository,
+			Tag:         opts.Tag,
+			Message:     opts.Message,
+			Author:      opts.Author,
+			Pause:       opts.Pause,
+		})
+	}
+	if err != nil {
+		h.logger.ErrorContext(c.UserContext(), "Failed to commit container", "id", id, "serverId", serverID, "image", image, "error", err)
+		return response.ServerError(c, fiber.StatusInternalServerError, msgFailedCommitContainer)
+	}
+
+	h.auditService.LogContainerCommitted(c.Context(), h.auditService.ExtractContext(c), id, serverID, image, imageID)
+	if h.sseHandler != nil {
+		h.sseHandler.EmitInvalidate("images")
+	}
+	return response.OK(c, CommitContainerResponse{ImageID: imageID, Image: image})
+}
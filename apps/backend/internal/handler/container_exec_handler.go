@@ -3,8 +3,8 @@ package handler
 import (
 	"context"
 	"encoding/json"
+	"io"
 	"log/slog"
-	"os"
 	"os/exec"
 	"sync"
 	"time"
@@ -16,6 +16,7 @@ import (
 	pb "github.com/paasdeploy/backend/gen/go/flowdeploy/v1"
 	"github.com/paasdeploy/backend/internal/agentclient"
 	"github.com/paasdeploy/backend/internal/domain"
+	"github.com/paasdeploy/backend/internal/response"
 )
 
 const (
@@ -26,17 +27,24 @@ const (
 )
 
 type ContainerExecHandler struct {
-	agentClient *agentclient.AgentClient
-	serverRepo  domain.ServerRepository
-	agentPort   int
-	logger      *slog.Logger
+	agentClient  *agentclient.AgentClient
+	serverRepo   domain.ServerRepository
+	sessionRepo  domain.ExecSessionRepository
+	serverShells ServerShellOpener
+	agentPort    int
+	logger       *slog.Logger
+
+	pruneMu   sync.Mutex
+	lastPrune time.Time
 }
 
 type ContainerExecHandlerConfig struct {
-	AgentClient *agentclient.AgentClient
-	ServerRepo  domain.ServerRepository
-	AgentPort   int
-	Logger      *slog.Logger
+	AgentClient  *agentclient.AgentClient
+	ServerRepo   domain.ServerRepository
+	SessionRepo  domain.ExecSessionRepository
+	ServerShells ServerShellOpener
+	AgentPort    int
+	Logger       *slog.Logger
 }
 
 type resizeMessage struct {
@@ -46,10 +54,12 @@ type resizeMessage struct {
 
 func NewContainerExecHandler(cfg ContainerExecHandlerConfig) *ContainerExecHandler {
 	return &ContainerExecHandler{
-		agentClient: cfg.AgentClient,
-		serverRepo:  cfg.ServerRepo,
-		agentPort:   cfg.AgentPort,
-		logger:      cfg.Logger,
+		agentClient:  cfg.AgentClient,
+		serverRepo:   cfg.ServerRepo,
+		sessionRepo:  cfg.SessionRepo,
+		serverShells: cfg.ServerShells,
+		agentPort:    cfg.AgentPort,
+		logger:       cfg.Logger,
 	}
 }
 
@@ -61,6 +71,15 @@ func (h *ContainerExecHandler) Register(app fiber.Router) {
 			WriteBufferSize: 1024,
 		},
 	))
+	v1.Get("/servers/:id/console", h.requireAuthForWebSocket, websocket.New(h.handleServerConsole,
+		websocket.Config{
+			ReadBufferSize:  1024,
+			WriteBufferSize: 1024,
+		},
+	))
+	v1.Get("/exec-sessions", h.ListSessions)
+	v1.Get("/exec-sessions/:sessionId", h.GetSession)
+	v1.Get("/exec-sessions/:sessionId/recording", h.GetSessionRecording)
 }
 
 func (h *ContainerExecHandler) requireAuthForWebSocket(c *fiber.Ctx) error {
@@ -69,7 +88,7 @@ func (h *ContainerExecHandler) requireAuthForWebSocket(c *fiber.Ctx) error {
 	}
 	user := GetUserFromContext(c)
 	if user == nil {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "authentication required"})
+		return response.Unauthorized(c, "authentication required")
 	}
 	return c.Next()
 }
@@ -99,14 +118,25 @@ func (h *ContainerExecHandler) handleConsole(c *websocket.Conn) {
 			_ = c.WriteMessage(websocket.TextMessage, []byte("Error: authentication required\r\n"))
 			return
 		}
-		h.handleRemoteConsole(c, containerID, shell, cols, rows, serverID, user.ID)
+		if _, err := h.serverRepo.FindByIDForUser(serverID, user.ID); err != nil {
+			h.logger.Error("server not found for exec", "serverId", serverID, "error", err)
+			_ = c.WriteMessage(websocket.TextMessage, []byte("Error: server not found\r\n"))
+			return
+		}
+	}
+
+	rec := h.startSession(c, user, serverID, containerID, shell, cols, rows)
+	defer h.finishSession(rec)
+
+	if serverID != "" {
+		h.handleRemoteConsole(c, containerID, shell, cols, rows, serverID, user.ID, rec)
 		return
 	}
 
-	h.handleLocalConsole(c, containerID, shell, cols, rows)
+	h.handleLocalConsole(c, containerID, shell, cols, rows, rec)
 }
 
-func (h *ContainerExecHandler) handleLocalConsole(c *websocket.Conn, containerID, shell string, cols, rows uint16) {
+func (h *ContainerExecHandler) handleLocalConsole(c *websocket.Conn, containerID, shell string, cols, rows uint16, rec *execRecorder) {
 	cmd := exec.Command("docker", "exec", "-it", containerID, shell)
 
 	ptmx, err := pty.StartWithSize(cmd, &pty.Winsize{Cols: cols, Rows: rows})
@@ -124,23 +154,28 @@ func (h *ContainerExecHandler) handleLocalConsole(c *websocket.Conn, containerID
 
 	go func() {
 		defer wg.Done()
-		h.readFromPTY(ptmx, c, done)
+		h.readFromPTY(ptmx, c, done, rec)
 	}()
 
 	go func() {
 		defer wg.Done()
-		h.writeFromWS(c, ptmx, done)
+		h.writeFromWS(c, ptmx, func(cols, rows uint16) {
+			_ = pty.Setsize(ptmx, &pty.Winsize{Cols: cols, Rows: rows})
+		}, done, rec)
 	}()
 
 	go func() {
 		_ = cmd.Wait()
+		if cmd.ProcessState != nil {
+			rec.setExitCode(cmd.ProcessState.ExitCode())
+		}
 		close(done)
 	}()
 
 	wg.Wait()
 }
 
-func (h *ContainerExecHandler) handleRemoteConsole(c *websocket.Conn, containerID, shell string, cols, rows uint16, serverID, userID string) {
+func (h *ContainerExecHandler) handleRemoteConsole(c *websocket.Conn, containerID, shell string, cols, rows uint16, serverID, userID string, rec *execRecorder) {
 	server, err := h.serverRepo.FindByIDForUser(serverID, userID)
 	if err != nil {
 		h.logger.Error("server not found for exec", "serverId", serverID, "error", err)
@@ -182,18 +217,18 @@ func (h *ContainerExecHandler) handleRemoteConsole(c *websocket.Conn, containerI
 
 	go func() {
 		defer wg.Done()
-		h.grpcToWS(stream, c, done)
+		h.grpcToWS(stream, c, done, rec)
 	}()
 
 	go func() {
 		defer wg.Done()
-		h.wsToGRPC(c, stream, done, cancel)
+		h.wsToGRPC(c, stream, done, cancel, rec)
 	}()
 
 	wg.Wait()
 }
 
-func (h *ContainerExecHandler) grpcToWS(stream pb.AgentService_ExecContainerClient, conn *websocket.Conn, done chan struct{}) {
+func (h *ContainerExecHandler) grpcToWS(stream pb.AgentService_ExecContainerClient, conn *websocket.Conn, done chan struct{}, rec *execRecorder) {
 	defer close(done)
 	for {
 		out, err := stream.Recv()
@@ -203,10 +238,12 @@ func (h *ContainerExecHandler) grpcToWS(stream pb.AgentService_ExecContainerClie
 
 		switch p := out.Payload.(type) {
 		case *pb.ExecOutput_Data:
+			rec.output(p.Data)
 			if writeErr := conn.WriteMessage(websocket.TextMessage, p.Data); writeErr != nil {
 				return
 			}
 		case *pb.ExecOutput_ExitCode:
+			rec.setExitCode(int(p.ExitCode))
 			return
 		}
 	}
@@ -241,7 +278,7 @@ func readWSMessage(conn *websocket.Conn) (*wsPayload, error) {
 	return &wsPayload{data: msg}, nil
 }
 
-func (h *ContainerExecHandler) wsToGRPC(conn *websocket.Conn, stream pb.AgentService_ExecContainerClient, done <-chan struct{}, cancel context.CancelFunc) {
+func (h *ContainerExecHandler) wsToGRPC(conn *websocket.Conn, stream pb.AgentService_ExecContainerClient, done <-chan struct{}, cancel context.CancelFunc, rec *execRecorder) {
 	defer cancel()
 	for {
 		select {
@@ -259,6 +296,7 @@ func (h *ContainerExecHandler) wsToGRPC(conn *websocket.Conn, stream pb.AgentSer
 		}
 
 		if msg.isResize {
+			rec.resize(msg.resize.Cols, msg.resize.Rows)
 			_ = stream.Send(&pb.ExecInput{
 				Payload: &pb.ExecInput_Resize{
 					Resize: &pb.ExecResize{
@@ -278,7 +316,7 @@ func (h *ContainerExecHandler) wsToGRPC(conn *websocket.Conn, stream pb.AgentSer
 	}
 }
 
-func (h *ContainerExecHandler) readFromPTY(ptmx *os.File, conn *websocket.Conn, done <-chan struct{}) {
+func (h *ContainerExecHandler) readFromPTY(ptmx io.Reader, conn *websocket.Conn, done <-chan struct{}, rec *execRecorder) {
 	buf := make([]byte, ptyReadBufSize)
 	for {
 		select {
@@ -287,6 +325,7 @@ func (h *ContainerExecHandler) readFromPTY(ptmx *os.File, conn *websocket.Conn,
 		default:
 			n, err := ptmx.Read(buf)
 			if n > 0 {
+				rec.output(buf[:n])
 				if writeErr := conn.WriteMessage(websocket.TextMessage, buf[:n]); writeErr != nil {
 					return
 				}
@@ -298,7 +337,9 @@ func (h *ContainerExecHandler) readFromPTY(ptmx *os.File, conn *websocket.Conn,
 	}
 }
 
-func (h *ContainerExecHandler) writeFromWS(conn *websocket.Conn, ptmx *os.File, done <-chan struct{}) {
+// writeFromWS forwards keystrokes to the terminal and applies resize
+// messages with resize.
+func (h *ContainerExecHandler) writeFromWS(conn *websocket.Conn, ptmx io.WriteCloser, resize func(cols, rows uint16), done <-chan struct{}, rec *execRecorder) {
 	defer ptmx.Close()
 	for {
 		select {
@@ -316,7 +357,8 @@ func (h *ContainerExecHandler) writeFromWS(conn *websocket.Conn, ptmx *os.File,
 		}
 
 		if msg.isResize {
-			_ = pty.Setsize(ptmx, &pty.Winsize{Cols: msg.resize.Cols, Rows: msg.resize.Rows})
+			rec.resize(msg.resize.Cols, msg.resize.Rows)
+			resize(msg.resize.Cols, msg.resize.Rows)
 			continue
 		}
 
@@ -0,0 +1,197 @@
+package handler
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/gofiber/contrib/websocket"
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/paasdeploy/backend/internal/domain"
+	"github.com/paasdeploy/backend/internal/response"
+)
+
+const execSessionPruneInterval = time.Hour
+
+// execRecorder records one console session. A nil recorder records nothing,
+// so consoles keep working when no session repository is configured or the
+// session row could not be created.
+type execRecorder struct {
+	sessionID string
+	recording *domain.ExecRecording
+
+	mu       sync.Mutex
+	exitCode *int
+}
+
+func (r *execRecorder) output(data []byte) {
+	if r != nil {
+		r.recording.Output(data, time.Now())
+	}
+}
+
+func (r *execRecorder) resize(cols, rows uint16) {
+	if r != nil {
+		r.recording.Resize(cols, rows, time.Now())
+	}
+}
+
+func (r *execRecorder) setExitCode(code int) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	r.exitCode = &code
+	r.mu.Unlock()
+}
+
+func (h *ContainerExecHandler) startSession(c *websocket.Conn, user *domain.User, serverID, containerID, shell string, cols, rows uint16) *execRecorder {
+	if h.sessionRepo == nil || user == nil {
+		return nil
+	}
+	h.pruneSessions()
+
+	userName := user.Name
+	if userName == "" {
+		userName = user.Email
+	}
+	session, err := h.sessionRepo.Create(domain.CreateExecSessionInput{
+		UserID:      user.ID,
+		UserName:    userName,
+		ServerID:    serverID,
+		ContainerID: containerID,
+		Shell:       shell,
+		IPAddress:   c.IP(),
+	})
+	if err != nil {
+		h.logger.Error("failed to create exec session", "container", containerID, "error", err)
+		return nil
+	}
+
+	h.logger.Info("exec session started", "sessionId", session.ID, "userId", user.ID, "serverId", serverID, "container", containerID)
+	return &execRecorder{
+		sessionID: session.ID,
+		recording: domain.NewExecRecording(cols, rows, shell, session.StartedAt),
+	}
+}
+
+func (h *ContainerExecHandler) finishSession(r *execRecorder) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	exitCode := r.exitCode
+	r.mu.Unlock()
+
+	result := r.recording.Result(exitCode)
+	if err := h.sessionRepo.Finish(r.sessionID, result); err != nil {
+		h.logger.Error("failed to save exec session recording", "sessionId", r.sessionID, "error", err)
+		return
+	}
+	h.logger.Info("exec session ended", "sessionId", r.sessionID, "outputBytes", result.OutputBytes, "truncated", result.Truncated)
+}
+
+// pruneSessions deletes sessions past the retention period, at most once an
+// hour, piggybacking on new sessions instead of running a separate job.
+func (h *ContainerExecHandler) pruneSessions() {
+	h.pruneMu.Lock()
+	if time.Since(h.lastPrune) < execSessionPruneInterval {
+		h.pruneMu.Unlock()
+		return
+	}
+	h.lastPrune = time.Now()
+	h.pruneMu.Unlock()
+
+	deleted, err := h.sessionRepo.DeleteOlderThan(time.Now().Add(-domain.ExecSessionRetention))
+	if err != nil {
+		h.logger.Error("failed to prune exec sessions", "error", err)
+		return
+	}
+	if deleted > 0 {
+		h.logger.Info("pruned exec sessions", "deleted", deleted)
+	}
+}
+
+// ListSessions lists recorded console sessions, newest first. Admins see
+// every session; other users only their own.
+func (h *ContainerExecHandler) ListSessions(c *fiber.Ctx) error {
+	if h.sessionRepo == nil {
+		return response.NotFound(c, "exec session recording not enabled")
+	}
+	user := GetUserFromContext(c)
+	filter := domain.ExecSessionFilter{
+		UserID:      c.Query("userId"),
+		ServerID:    c.Query("serverId"),
+		ContainerID: c.Query("containerId"),
+		Limit:       c.QueryInt("limit", 50),
+		Offset:      c.QueryInt("offset", 0),
+	}
+	if !user.IsAdmin() {
+		filter.UserID = user.ID
+	}
+	if filter.Limit > 200 {
+		filter.Limit = 200
+	}
+
+	sessions, total, err := h.sessionRepo.List(filter)
+	if err != nil {
+		h.logger.ErrorContext(c.UserContext(), "failed to list exec sessions", "error", err)
+		return response.InternalError(c)
+	}
+	return response.OK(c, fiber.Map{"sessions": sessions, "total": total})
+}
+
+func (h *ContainerExecHandler) GetSession(c *fiber.Ctx) error {
+	session, ok, err := h.sessionForUser(c)
+	if !ok {
+		return err
+	}
+	return response.OK(c, session)
+}
+
+// GetSessionRecording returns the session as an asciicast v2 file that can
+// be replayed with asciinema or any compatible player.
+func (h *ContainerExecHandler) GetSessionRecording(c *fiber.Ctx) error {
+	session, ok, err := h.sessionForUser(c)
+	if !ok {
+		return err
+	}
+	recording, err := h.sessionRepo.FindRecording(session.ID)
+	if err != nil {
+		h.logger.ErrorContext(c.UserContext(), "failed to load exec session recording", "sessionId", session.ID, "error", err)
+		return response.InternalError(c)
+	}
+	if len(recording) == 0 {
+		return response.NotFound(c, "recording not available yet")
+	}
+
+	c.Set(fiber.HeaderContentType, "application/x-asciicast")
+	c.Set(fiber.HeaderContentDisposition, `attachment; filename="session-`+session.ID+`.cast"`)
+	return c.Send(recording)
+}
+
+// sessionForUser loads the session of the route for the user. When it
+// returns false it has already sent the error response, also when the
+// session belongs to another user.
+func (h *ContainerExecHandler) sessionForUser(c *fiber.Ctx) (*domain.ExecSession, bool, error) {
+	user := GetUserFromContext(c)
+	if user == nil {
+		return nil, false, response.Unauthorized(c, MsgNotAuthenticated)
+	}
+	if h.sessionRepo == nil {
+		return nil, false, response.NotFound(c, "exec session recording not enabled")
+	}
+	session, err := h.sessionRepo.FindByID(c.Params("sessionId"))
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, false, response.NotFound(c, "session not found")
+	}
+	if err != nil {
+		h.logger.ErrorContext(c.UserContext(), "failed to find exec session", "error", err)
+		return nil, false, response.InternalError(c)
+	}
+	if !user.IsAdmin() && session.UserID != user.ID {
+		return nil, false, response.NotFound(c, "session not found")
+	}
+	return session, true, nil
+}
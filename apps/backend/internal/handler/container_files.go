@@ -0,0 +1,191 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"path"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	pb "github.com/paasdeploy/backend/gen/go/flowdeploy/v1"
+	"github.com/paasdeploy/backend/internal/response"
+	"github.com/paasdeploy/shared/pkg/docker"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type ContainerFilesResponse struct {
+	Path  string                 `json:"path"`
+	Files []docker.ContainerFile `json:"files"`
+}
+
+// ListContainerFiles lists a directory inside a container, on the backend
+// host or, with serverId, on a remote server through its agent.
+func (h *ContainerHandler) ListContainerFiles(c *fiber.Ctx) error {
+	id := c.Params("id")
+	serverID := c.Query("serverId", "")
+	dir, err := docker.CleanContainerPath(c.Query("path", "/"))
+	if err != nil {
+		return response.BadRequest(c, err.Error())
+	}
+	host, ok, err := h.containerTarget(c, serverID)
+	if !ok {
+		return err
+	}
+
+	if serverID == "" {
+		files, err := h.docker.ListContainerFiles(c.Context(), id, dir)
+		if err != nil {
+			h.logger.ErrorContext(c.UserContext(), "Failed to list container files", "id", id, "path", dir, "error", err)
+			return response.ServerError(c, fiber.StatusInternalServerError, "Failed to list container files")
+		}
+		return response.OK(c, ContainerFilesResponse{Path: dir, Files: files})
+	}
+
+	entries, err := h.agentClient.ListContainerFiles(c.Context(), host, h.agentPort, id, dir)
+	if err != nil {
+		h.logger.ErrorContext(c.UserContext(), "Failed to list remote container files", "id", id, "serverId", serverID, "path", dir, "error", err)
+		return containerFileErrorResponse(c, err, "Failed to list container files")
+	}
+	return response.OK(c, ContainerFilesResponse{Path: dir, Files: containerFilesFromProto(entries)})
+}
+
+// DownloadContainerFile copies a single file out of a container, up to
+// docker.MaxContainerFileBytes.
+func (h *ContainerHandler) DownloadContainerFile(c *fiber.Ctx) error {
+	id := c.Params("id")
+	serverID := c.Query("serverId", "")
+	filePath, err := docker.CleanContainerPath(c.Query("path"))
+	if err != nil {
+		return response.BadRequest(c, err.Error())
+	}
+	host, ok, err := h.containerTarget(c, serverID)
+	if !ok {
+		return err
+	}
+
+	var name string
+	var data []byte
+	if serverID == "" {
+		name, data, err = h.docker.CopyFileFromContainer(c.Context(), id, filePath, docker.MaxContainerFileBytes)
+	} else {
+		name, data, err = h.agentClient.DownloadContainerFile(c.Context(), host, h.agentPort, id, filePath)
+	}
+	if err != nil {
+		h.logger.ErrorContext(c.UserContext(), "Failed to download container file", "id", id, "serverId", serverID, "path", filePath, "error", err)
+		return containerFileErrorResponse(c, err, "Failed to download container file")
+	}
+
+	h.auditService.LogContainerFileDownloaded(c.Context(), h.auditService.ExtractContext(c), id, serverID, filePath, len(data))
+	c.Set(fiber.HeaderContentType, fiber.MIMEOctetStream)
+	c.Set(fiber.HeaderContentDisposition, "attachment; filename="+strconv.Quote(name))
+	return c.Send(data)
+}
+
+// UploadContainerFile copies the multipart "file" field into the directory
+// given by path, keeping the uploaded file name.
+func (h *ContainerHandler) UploadContainerFile(c *fiber.Ctx) error {
+	id := c.Params("id")
+	serverID := c.Query("serverId", "")
+	dir, err := docker.CleanContainerPath(c.Query("path"))
+	if err != nil {
+		return response.BadRequest(c, err.Error())
+	}
+	host, ok, err := h.containerTarget(c, serverID)
+	if !ok {
+		return err
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return response.BadRequest(c, "file is required")
+	}
+	if fileHeader.Size > docker.MaxContainerFileBytes {
+		return response.ServerError(c, fiber.StatusRequestEntityTooLarge, docker.ErrContainerFileTooLarge.Error())
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		return response.BadRequest(c, "failed to read uploaded file")
+	}
+	defer file.Close()
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return response.BadRequest(c, "failed to read uploaded file")
+	}
+
+	name := path.Base(fileHeader.Filename)
+	if serverID == "" {
+		err = h.docker.CopyFileToContainer(c.Context(), id, dir, name, data)
+	} else {
+		err = h.agentClient.UploadContainerFile(c.Context(), host, h.agentPort, id, dir, name, data)
+	}
+	if err != nil {
+		h.logger.ErrorContext(c.UserContext(), "Failed to upload container file", "id", id, "serverId", serverID, "path", dir, "error", err)
+		return containerFileErrorResponse(c, err, "Failed to upload container file")
+	}
+
+	target := path.Join(dir, name)
+	h.auditService.LogContainerFileUploaded(c.Context(), h.auditService.ExtractContext(c), id, serverID, target, len(data))
+	return response.OK(c, fiber.Map{"path": target, "size": len(data)})
+}
+
+// containerTarget authorizes an operation inside a container and resolves
+// the agent host for remote servers. Local containers require an admin since
+// the backend host is shared by every user. When ok is false the error
+// response was already written.
+func (h *ContainerHandler) containerTarget(c *fiber.Ctx, serverID string) (string, bool, error) {
+	return resolveTarget(c, serverID, h.resolveServerHost)
+}
+
+func resolveTarget(c *fiber.Ctx, serverID string, resolveServerHost func(serverID, userID string) (string, error)) (string, bool, error) {
+	user := GetUserFromContext(c)
+	if serverID == "" {
+		if user == nil || !user.IsAdmin() {
+			return "", false, response.Forbidden(c, "local operations require admin role")
+		}
+		return "", true, nil
+	}
+	if user == nil {
+		return "", false, response.Unauthorized(c, MsgNotAuthenticated)
+	}
+	host, err := resolveServerHost(serverID, user.ID)
+	if err != nil {
+		return "", false, response.NotFound(c, MsgServerNotFound)
+	}
+	return host, true, nil
+}
+
+func containerFilesFromProto(entries []*pb.ContainerFileEntry) []docker.ContainerFile {
+	files := make([]docker.ContainerFile, 0, len(entries))
+	for _, e := range entries {
+		files = append(files, docker.ContainerFile{
+			Name:       e.Name,
+			Type:       e.Type,
+			Size:       e.Size,
+			Mode:       e.Mode,
+			Owner:      e.Owner,
+			Modified:   e.Modified,
+			LinkTarget: e.LinkTarget,
+		})
+	}
+	return files
+}
+
+func containerFileErrorResponse(c *fiber.Ctx, err error, fallback string) error {
+	switch {
+	case errors.Is(err, docker.ErrInvalidContainerPath), errors.Is(err, docker.ErrNotRegularFile), errors.Is(err, docker.ErrInvalidVolumeName):
+		return response.BadRequest(c, err.Error())
+	case errors.Is(err, docker.ErrContainerFileTooLarge):
+		return response.ServerError(c, fiber.StatusRequestEntityTooLarge, err.Error())
+	}
+
+	switch st, _ := status.FromError(errors.Unwrap(err)); st.Code() {
+	case codes.InvalidArgument:
+		return response.BadRequest(c, st.Message())
+	case codes.ResourceExhausted:
+		return response.ServerError(c, fiber.StatusRequestEntityTooLarge, st.Message())
+	case codes.FailedPrecondition:
+		return response.ServerError(c, fiber.StatusUnprocessableEntity, st.Message())
+	}
+	return response.ServerError(c, fiber.StatusInternalServerError, fallback)
+}
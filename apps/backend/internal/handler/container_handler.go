@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -14,6 +15,7 @@ import (
 	"github.com/paasdeploy/backend/internal/agentclient"
 	"github.com/paasdeploy/backend/internal/domain"
 	"github.com/paasdeploy/backend/internal/response"
+	"github.com/paasdeploy/backend/internal/service"
 	"github.com/paasdeploy/shared/pkg/docker"
 	"github.com/valyala/fasthttp"
 )
@@ -23,6 +25,7 @@ const (
 	msgFailedStopContainer    = "Failed to stop container"
 	msgFailedRestartContainer = "Failed to restart container"
 	msgFailedRemoveContainer  = "Failed to remove container"
+	msgFailedListProcesses    = "Failed to list container processes"
 )
 
 func isSelfContainerError(err error) bool {
@@ -35,31 +38,37 @@ func isSelfContainerError(err error) bool {
 }
 
 type ContainerHandler struct {
-	docker      *docker.Client
-	agentClient *agentclient.AgentClient
-	serverRepo  domain.ServerRepository
-	agentPort   int
-	logger      *slog.Logger
-	sseHandler  *SSEHandler
+	docker       *docker.Client
+	agentClient  *agentclient.AgentClient
+	serverRepo   domain.ServerRepository
+	commandRepo  domain.AgentCommandRepository
+	auditService *service.AuditService
+	agentPort    int
+	logger       *slog.Logger
+	sseHandler   *SSEHandler
 }
 
 type ContainerHandlerConfig struct {
-	Docker      *docker.Client
-	AgentClient *agentclient.AgentClient
-	ServerRepo  domain.ServerRepository
-	AgentPort   int
-	Logger      *slog.Logger
-	SSEHandler  *SSEHandler
+	Docker       *docker.Client
+	AgentClient  *agentclient.AgentClient
+	ServerRepo   domain.ServerRepository
+	CommandRepo  domain.AgentCommandRepository
+	AuditService *service.AuditService
+	AgentPort    int
+	Logger       *slog.Logger
+	SSEHandler   *SSEHandler
 }
 
 func NewContainerHandler(cfg ContainerHandlerConfig) *ContainerHandler {
 	return &ContainerHandler{
-		docker:      cfg.Docker,
-		agentClient: cfg.AgentClient,
-		serverRepo:  cfg.ServerRepo,
-		agentPort:   cfg.AgentPort,
-		logger:      cfg.Logger,
-		sseHandler:  cfg.SSEHandler,
+		docker:       cfg.Docker,
+		agentClient:  cfg.AgentClient,
+		serverRepo:   cfg.ServerRepo,
+		commandRepo:  cfg.CommandRepo,
+		auditService: cfg.AuditService,
+		agentPort:    cfg.AgentPort,
+		logger:       cfg.Logger,
+		sseHandler:   cfg.SSEHandler,
 	}
 }
 
@@ -87,6 +96,11 @@ func (h *ContainerHandler) Register(app fiber.Router) {
 	v1.Post("/containers/:id/restart", h.RestartContainer)
 	v1.Delete("/containers/:id", h.RemoveContainer)
 	v1.Get("/containers/:id/logs", h.GetContainerLogs)
+	v1.Get("/containers/:id/top", h.GetContainerTop)
+	v1.Post("/containers/:id/commit", h.CommitContainer)
+	v1.Get("/containers/:id/files", h.ListContainerFiles)
+	v1.Get("/containers/:id/files/download", h.DownloadContainerFile)
+	v1.Post("/containers/:id/files/upload", h.UploadContainerFile)
 }
 
 type ContainerResponse struct {
@@ -126,13 +140,18 @@ func (h *ContainerHandler) ListContainers(c *fiber.Ctx) error {
 		return err
 	}
 
+	opts, err := parseListOptions(c, containerSortFields, "name", 0)
+	if err != nil {
+		return response.BadRequest(c, err.Error())
+	}
+
 	if serverID != "" {
-		return h.listRemoteContainers(c, serverID, all)
+		return h.listRemoteContainers(c, serverID, all, opts)
 	}
 
 	containers, err := h.docker.ListContainers(c.Context(), all)
 	if err != nil {
-		h.logger.Error("Failed to list containers", "error", err)
+		h.logger.ErrorContext(c.UserContext(), "Failed to list containers", "error", err)
 		return response.ServerError(c, fiber.StatusInternalServerError, "Failed to list containers")
 	}
 
@@ -141,22 +160,78 @@ func (h *ContainerHandler) ListContainers(c *fiber.Ctx) error {
 		result[i] = h.toContainerResponse(container)
 	}
 
-	return response.OK(c, result)
+	page := pageContainers(result, c.Query("status"), opts)
+	return response.OKWithCursor(c, page.Items, page.NextCursor)
 }
 
-func (h *ContainerHandler) listRemoteContainers(c *fiber.Ctx, serverID string, all bool) error {
+var containerSortFields = []string{"name"}
+
+// pageContainers filters, sorts and pages a container list in memory, as
+// Docker has no paging of its own. status matches the container state and
+// q the name or image.
+func pageContainers(containers []ContainerResponse, state string, opts domain.ListOptions) domain.Page[ContainerResponse] {
+	query := strings.ToLower(opts.Query)
+	matched := make([]ContainerResponse, 0, len(containers))
+	for _, ct := range containers {
+		if state != "" && ct.State != state {
+			continue
+		}
+		if query != "" && !strings.Contains(strings.ToLower(ct.Name), query) && !strings.Contains(strings.ToLower(ct.Image), query) {
+			continue
+		}
+		matched = append(matched, ct)
+	}
+
+	less := func(a, b ContainerResponse) bool {
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.ID < b.ID
+	}
+	if opts.Desc {
+		asc := less
+		less = func(a, b ContainerResponse) bool { return asc(b, a) }
+	}
+	sort.Slice(matched, func(i, j int) bool { return less(matched[i], matched[j]) })
+
+	if opts.After != nil {
+		after := ContainerResponse{Name: opts.After.Value, ID: opts.After.ID}
+		start := sort.Search(len(matched), func(i int) bool { return less(after, matched[i]) })
+		matched = matched[start:]
+	}
+
+	page := domain.Page[ContainerResponse]{Items: matched}
+	if opts.Limit > 0 && len(matched) > opts.Limit {
+		page.Items = matched[:opts.Limit]
+		last := page.Items[opts.Limit-1]
+		page.NextCursor = opts.NextCursor(last.Name, last.ID)
+	}
+	return page
+}
+
+func (h *ContainerHandler) listRemoteContainers(c *fiber.Ctx, serverID string, all bool, opts domain.ListOptions) error {
 	host, err := h.resolveServerHost(serverID, GetUserFromContext(c).ID)
 	if err != nil {
-		h.logger.Error("Failed to resolve server", "serverId", serverID, "error", err)
+		h.logger.ErrorContext(c.UserContext(), "Failed to resolve server", "serverId", serverID, "error", err)
 		return response.ServerError(c, fiber.StatusInternalServerError, MsgServerNotFound)
 	}
 
-	containers, err := h.agentClient.ListContainers(c.Context(), host, h.agentPort, all, "")
+	result, err := h.remoteContainers(c.Context(), host, all)
 	if err != nil {
-		h.logger.Error("Failed to list remote containers", "serverId", serverID, "error", err)
+		h.logger.ErrorContext(c.UserContext(), "Failed to list remote containers", "serverId", serverID, "error", err)
 		return response.ServerError(c, fiber.StatusInternalServerError, "Failed to list containers from remote server")
 	}
 
+	page := pageContainers(result, c.Query("status"), opts)
+	return response.OKWithCursor(c, page.Items, page.NextCursor)
+}
+
+func (h *ContainerHandler) remoteContainers(ctx context.Context, host string, all bool) ([]ContainerResponse, error) {
+	containers, err := h.agentClient.ListContainers(ctx, host, h.agentPort, all, "")
+	if err != nil {
+		return nil, err
+	}
+
 	result := make([]ContainerResponse, 0, len(containers))
 	for _, ct := range containers {
 		ports := make([]ContainerPortResponse, 0, len(ct.Ports))
@@ -207,19 +282,41 @@ func (h *ContainerHandler) listRemoteContainers(c *fiber.Ctx, serverID string, a
 		})
 	}
 
-	return response.OK(c, result)
+	return result, nil
+}
+
+type ContainerDetailResponse struct {
+	ContainerResponse
+	Inspect *docker.ContainerInspect `json:"inspect"`
 }
 
 func (h *ContainerHandler) GetContainer(c *fiber.Ctx) error {
 	id := c.Params("id")
+	serverID := c.Query("serverId", "")
+	host, ok, err := h.containerTarget(c, serverID)
+	if !ok {
+		return err
+	}
+
+	if serverID != "" {
+		return h.getRemoteContainer(c, host, serverID, id)
+	}
 
 	container, err := h.docker.GetContainerDetails(c.Context(), id)
 	if err != nil {
-		h.logger.Error("Failed to get container", "id", id, "error", err)
+		h.logger.ErrorContext(c.UserContext(), "Failed to get container", "id", id, "error", err)
+		return response.NotFound(c, "Container not found")
+	}
+	inspect, err := h.docker.InspectContainerFull(c.Context(), id)
+	if err != nil {
+		h.logger.ErrorContext(c.UserContext(), "Failed to inspect container", "id", id, "error", err)
 		return response.NotFound(c, "Container not found")
 	}
 
-	return response.OK(c, h.toContainerResponse(*container))
+	return response.OK(c, ContainerDetailResponse{
+		ContainerResponse: h.toContainerResponse(*container),
+		Inspect:           inspect,
+	})
 }
 
 type CreateContainerRequest struct {
@@ -282,7 +379,7 @@ func (h *ContainerHandler) CreateContainer(c *fiber.Ctx) error {
 
 	containerID, err := h.docker.CreateContainer(c.Context(), opts)
 	if err != nil {
-		h.logger.Error("Failed to create container", "error", err)
+		h.logger.ErrorContext(c.UserContext(), "Failed to create container", "error", err)
 		return response.ServerError(c, fiber.StatusInternalServerError, "Failed to create container")
 	}
 
@@ -309,7 +406,10 @@ func (h *ContainerHandler) StartContainer(c *fiber.Ctx) error {
 			return response.ServerError(c, fiber.StatusInternalServerError, MsgServerNotFound)
 		}
 		if err := h.agentClient.StartContainer(c.Context(), host, h.agentPort, id); err != nil {
-			h.logger.Error("Failed to start remote container", "id", id, "serverId", serverID, "error", err)
+			if cmd := enqueueIfUnreachable(h.commandRepo, err, serverID, domain.AgentCommandStartContainer, &pb.StartContainerRequest{ContainerId: id}); cmd != nil {
+				return respondCommandQueued(c, cmd)
+			}
+			h.logger.ErrorContext(c.UserContext(), "Failed to start remote container", "id", id, "serverId", serverID, "error", err)
 			return response.ServerError(c, fiber.StatusInternalServerError, msgFailedStartContainer)
 		}
 		h.invalidateContainers()
@@ -317,7 +417,7 @@ func (h *ContainerHandler) StartContainer(c *fiber.Ctx) error {
 	}
 
 	if err := h.docker.StartContainer(c.Context(), id); err != nil {
-		h.logger.Error("Failed to start container", "id", id, "error", err)
+		h.logger.ErrorContext(c.UserContext(), "Failed to start container", "id", id, "error", err)
 		return response.ServerError(c, fiber.StatusInternalServerError, msgFailedStartContainer)
 	}
 
@@ -339,7 +439,10 @@ func (h *ContainerHandler) StopContainer(c *fiber.Ctx) error {
 			return response.ServerError(c, fiber.StatusInternalServerError, MsgServerNotFound)
 		}
 		if err := h.agentClient.StopContainer(c.Context(), host, h.agentPort, id); err != nil {
-			h.logger.Error("Failed to stop remote container", "id", id, "serverId", serverID, "error", err)
+			if cmd := enqueueIfUnreachable(h.commandRepo, err, serverID, domain.AgentCommandStopContainer, &pb.StopContainerRequest{ContainerId: id}); cmd != nil {
+				return respondCommandQueued(c, cmd)
+			}
+			h.logger.ErrorContext(c.UserContext(), "Failed to stop remote container", "id", id, "serverId", serverID, "error", err)
 			return response.ServerError(c, fiber.StatusInternalServerError, msgFailedStopContainer)
 		}
 		h.invalidateContainers()
@@ -347,7 +450,7 @@ func (h *ContainerHandler) StopContainer(c *fiber.Ctx) error {
 	}
 
 	if err := h.docker.StopContainer(c.Context(), id); err != nil {
-		h.logger.Error("Failed to stop container", "id", id, "error", err)
+		h.logger.ErrorContext(c.UserContext(), "Failed to stop container", "id", id, "error", err)
 		if isSelfContainerError(err) {
 			return response.BadRequest(c, "Operation not allowed for this container")
 		}
@@ -372,7 +475,10 @@ func (h *ContainerHandler) RestartContainer(c *fiber.Ctx) error {
 			return response.ServerError(c, fiber.StatusInternalServerError, MsgServerNotFound)
 		}
 		if err := h.agentClient.RestartContainer(c.Context(), host, h.agentPort, id); err != nil {
-			h.logger.Error("Failed to restart remote container", "id", id, "serverId", serverID, "error", err)
+			if cmd := enqueueIfUnreachable(h.commandRepo, err, serverID, domain.AgentCommandRestartContainer, &pb.RestartContainerRequest{ContainerId: id}); cmd != nil {
+				return respondCommandQueued(c, cmd)
+			}
+			h.logger.ErrorContext(c.UserContext(), "Failed to restart remote container", "id", id, "serverId", serverID, "error", err)
 			return response.ServerError(c, fiber.StatusInternalServerError, msgFailedRestartContainer)
 		}
 		h.invalidateContainers()
@@ -380,7 +486,7 @@ func (h *ContainerHandler) RestartContainer(c *fiber.Ctx) error {
 	}
 
 	if err := h.docker.RestartContainer(c.Context(), id); err != nil {
-		h.logger.Error("Failed to restart container", "id", id, "error", err)
+		h.logger.ErrorContext(c.UserContext(), "Failed to restart container", "id", id, "error", err)
 		if isSelfContainerError(err) {
 			return response.BadRequest(c, "Operation not allowed for this container")
 		}
@@ -420,13 +526,50 @@ func (h *ContainerHandler) GetContainerLogs(c *fiber.Ctx) error {
 
 	logs, err := h.docker.ContainerLogs(c.Context(), id, tail)
 	if err != nil {
-		h.logger.Error("Failed to get container logs", "id", id, "error", err)
+		h.logger.ErrorContext(c.UserContext(), "Failed to get container logs", "id", id, "error", err)
 		return response.OK(c, ContainerLogsResponseGeneral{Logs: ""})
 	}
 
 	return response.OK(c, ContainerLogsResponseGeneral{Logs: logs})
 }
 
+func (h *ContainerHandler) GetContainerTop(c *fiber.Ctx) error {
+	id := c.Params("id")
+	serverID := c.Query("serverId", "")
+	host, ok, err := h.containerTarget(c, serverID)
+	if !ok {
+		return err
+	}
+
+	if serverID == "" {
+		processes, err := h.docker.ContainerTop(c.Context(), id)
+		if err != nil {
+			h.logger.ErrorContext(c.UserContext(), "Failed to list container processes", "id", id, "error", err)
+			return response.ServerError(c, fiber.StatusInternalServerError, msgFailedListProcesses)
+		}
+		return response.OK(c, processes)
+	}
+
+	remote, err := h.agentClient.GetContainerTop(c.Context(), host, h.agentPort, id)
+	if err != nil {
+		h.logger.ErrorContext(c.UserContext(), "Failed to list remote container processes", "id", id, "serverId", serverID, "error", err)
+		return response.ServerError(c, fiber.StatusInternalServerError, msgFailedListProcesses)
+	}
+	processes := make([]docker.ContainerProcess, 0, len(remote))
+	for _, p := range remote {
+		processes = append(processes, docker.ContainerProcess{
+			PID:        int(p.Pid),
+			PPID:       int(p.Ppid),
+			User:       p.User,
+			CPUPercent: p.CpuPercent,
+			MemPercent: p.MemPercent,
+			Elapsed:    p.Elapsed,
+			Command:    p.Command,
+		})
+	}
+	return response.OK(c, processes)
+}
+
 func (h *ContainerHandler) getRemoteContainerLogs(c *fiber.Ctx, serverID, containerID string, tail int, follow bool) error {
 	host, err := h.resolveServerHost(serverID, GetUserFromContext(c).ID)
 	if err != nil {
@@ -442,7 +585,7 @@ func (h *ContainerHandler) getRemoteContainerLogs(c *fiber.Ctx, serverID, contai
 		logLines = append(logLines, entry.GetMessage())
 	})
 	if err != nil {
-		h.logger.Error("Failed to get remote container logs", "id", containerID, "serverId", serverID, "error", err)
+		h.logger.ErrorContext(c.UserContext(), "Failed to get remote container logs", "id", containerID, "serverId", serverID, "error", err)
 		return response.OK(c, ContainerLogsResponseGeneral{Logs: ""})
 	}
 
@@ -503,7 +646,10 @@ func (h *ContainerHandler) RemoveContainer(c *fiber.Ctx) error {
 			return response.ServerError(c, fiber.StatusInternalServerError, MsgServerNotFound)
 		}
 		if err := h.agentClient.RemoveContainer(c.Context(), host, h.agentPort, id, force); err != nil {
-			h.logger.Error("Failed to remove remote container", "id", id, "serverId", serverID, "error", err)
+			if cmd := enqueueIfUnreachable(h.commandRepo, err, serverID, domain.AgentCommandRemoveContainer, &pb.RemoveContainerRequest{ContainerId: id, Force: force}); cmd != nil {
+				return respondCommandQueued(c, cmd)
+			}
+			h.logger.ErrorContext(c.UserContext(), "Failed to remove remote container", "id", id, "serverId", serverID, "error", err)
 			return response.ServerError(c, fiber.StatusInternalServerError, msgFailedRemoveContainer)
 		}
 		h.invalidateContainers()
@@ -511,7 +657,7 @@ func (h *ContainerHandler) RemoveContainer(c *fiber.Ctx) error {
 	}
 
 	if err := h.docker.RemoveContainer(c.Context(), id, force); err != nil {
-		h.logger.Error("Failed to remove container", "id", id, "error", err)
+		h.logger.ErrorContext(c.UserContext(), "Failed to remove container", "id", id, "error", err)
 		return response.ServerError(c, fiber.StatusInternalServerError, msgFailedRemoveContainer)
 	}
 
@@ -28,6 +28,7 @@ type ContainerHealthResponse struct {
 type ContainerHealthHandler struct {
 	appRepo     domain.AppRepository
 	serverRepo  domain.ServerRepository
+	historyRepo domain.AppHealthEventRepository
 	engine      *engine.Engine
 	agentClient *agentclient.AgentClient
 	agentPort   int
@@ -37,6 +38,7 @@ type ContainerHealthHandler struct {
 func NewContainerHealthHandler(
 	appRepo domain.AppRepository,
 	serverRepo domain.ServerRepository,
+	historyRepo domain.AppHealthEventRepository,
 	eng *engine.Engine,
 	agentClient *agentclient.AgentClient,
 	agentPort int,
@@ -45,6 +47,7 @@ func NewContainerHealthHandler(
 	return &ContainerHealthHandler{
 		appRepo:     appRepo,
 		serverRepo:  serverRepo,
+		historyRepo: historyRepo,
 		engine:      eng,
 		agentClient: agentClient,
 		agentPort:   agentPort,
@@ -70,6 +73,7 @@ func (h *ContainerHealthHandler) resolveServerHost(app *domain.App) (string, err
 func (h *ContainerHealthHandler) Register(app fiber.Router) {
 	v1 := app.Group(APIPrefix)
 	v1.Get("/apps/:id/health", h.GetAppHealth)
+	v1.Get("/apps/:id/health/history", h.GetAppHealthHistory)
 	v1.Get("/apps/:id/container/logs", h.GetContainerLogs)
 	v1.Get("/apps/:id/container/stats", h.GetContainerStats)
 }
@@ -112,7 +116,7 @@ func (h *ContainerHealthHandler) GetAppHealth(c *fiber.Ctx) error {
 func (h *ContainerHealthHandler) getRemoteAppHealth(c *fiber.Ctx, app *domain.App) error {
 	host, err := h.resolveServerHost(app)
 	if err != nil {
-		h.logger.Error("failed to resolve server for health check", "app_id", app.ID, "error", err)
+		h.logger.ErrorContext(c.UserContext(), "failed to resolve server for health check", "app_id", app.ID, "error", err)
 		return response.OK(c, ContainerHealthResponse{
 			Name:   app.Name,
 			Status: "unknown",
@@ -132,7 +136,7 @@ func (h *ContainerHealthHandler) getRemoteAppHealth(c *fiber.Ctx, app *domain.Ap
 	})
 
 	if err != nil || !hasStats {
-		h.logger.Debug("remote container not reachable for health", "app_id", app.ID, "error", err)
+		h.logger.DebugContext(c.UserContext(), "remote container not reachable for health", "app_id", app.ID, "error", err)
 		return response.OK(c, ContainerHealthResponse{
 			Name:   app.Name,
 			Status: "not_found",
@@ -153,6 +157,40 @@ func (h *ContainerHealthHandler) getRemoteAppHealth(c *fiber.Ctx, app *domain.Ap
 	})
 }
 
+var healthHistoryWindows = map[string]time.Duration{
+	"24h": 24 * time.Hour,
+	"7d":  7 * 24 * time.Hour,
+	"30d": domain.AppHealthEventRetention,
+}
+
+// GetAppHealthHistory lists the status and health changes of the app's
+// container with how long each lasted, newest first.
+func (h *ContainerHealthHandler) GetAppHealthHistory(c *fiber.Ctx) error {
+	id := c.Params("id")
+
+	if err := EnsureAppOwnership(c, h.appRepo, id); err != nil {
+		return err
+	}
+
+	window, ok := healthHistoryWindows[c.Query("window", "24h")]
+	if !ok {
+		return response.BadRequest(c, "window must be one of 24h, 7d, 30d")
+	}
+	if h.historyRepo == nil {
+		return response.ServerError(c, fiber.StatusServiceUnavailable, "health history is not available")
+	}
+
+	to := time.Now().UTC()
+	from := to.Add(-window)
+	events, err := h.historyRepo.FindOverlapping(id, from, to)
+	if err != nil {
+		h.logger.ErrorContext(c.UserContext(), "failed to load health history", "app_id", id, "error", err)
+		return response.InternalError(c)
+	}
+
+	return response.OK(c, domain.ComputeAppHealthHistory(id, events, from, to))
+}
+
 type ContainerLogsResponse struct {
 	Logs string `json:"logs"`
 }
@@ -207,7 +245,7 @@ func (h *ContainerHealthHandler) getRemoteContainerLogs(c *fiber.Ctx, app *domai
 		logs += entry.Message + "\n"
 	})
 	if err != nil {
-		h.logger.Error("failed to get remote logs", "app_id", app.ID, "error", err)
+		h.logger.ErrorContext(c.UserContext(), "failed to get remote logs", "app_id", app.ID, "error", err)
 		return response.OK(c, ContainerLogsResponse{Logs: ""})
 	}
 	return response.OK(c, ContainerLogsResponse{Logs: logs})
@@ -362,7 +400,7 @@ func (h *ContainerHealthHandler) getRemoteContainerStats(c *fiber.Ctx, app *doma
 		}
 	})
 	if err != nil {
-		h.logger.Error("failed to get remote stats", "app_id", app.ID, "error", err)
+		h.logger.ErrorContext(c.UserContext(), "failed to get remote stats", "app_id", app.ID, "error", err)
 		return response.OK(c, ContainerStatsResponse{})
 	}
 
@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+	pb "github.com/paasdeploy/backend/gen/go/flowdeploy/v1"
+	"github.com/paasdeploy/backend/internal/response"
+	"github.com/paasdeploy/shared/pkg/docker"
+)
+
+func (h *ContainerHandler) getRemoteContainer(c *fiber.Ctx, host, serverID, id string) error {
+	resp, err := h.agentClient.InspectContainer(c.Context(), host, h.agentPort, id)
+	if err != nil {
+		h.logger.ErrorContext(c.UserContext(), "Failed to inspect remote container", "id", id, "serverId", serverID, "error", err)
+		return response.NotFound(c, "Container not found")
+	}
+
+	inspect := inspectFromProto(resp)
+	return response.OK(c, ContainerDetailResponse{
+		ContainerResponse: h.toContainerResponse(containerInfoFromInspect(inspect)),
+		Inspect:           inspect,
+	})
+}
+
+// containerInfoFromInspect builds the summary returned by the container list
+// from inspect data. Agents do not report published ports through inspect, so
+// Ports stays empty for remote containers.
+func containerInfoFromInspect(inspect *docker.ContainerInspect) docker.ContainerInfo {
+	info := docker.ContainerInfo{
+		ID:       inspect.ID,
+		Name:     inspect.Name,
+		Image:    inspect.Image,
+		State:    inspect.State.Status,
+		Status:   inspect.State.Status,
+		Health:   "none",
+		Created:  inspect.Created,
+		Labels:   inspect.Labels,
+		Mounts:   inspect.Mounts,
+		Networks: make([]string, 0, len(inspect.Networks)),
+	}
+	if inspect.Health != nil {
+		info.Health = inspect.Health.Status
+	}
+	for _, n := range inspect.Networks {
+		info.Networks = append(info.Networks, n.Name)
+		if info.IPAddress == "" {
+			info.IPAddress = n.IPAddress
+		}
+	}
+	return info
+}
+
+func inspectFromProto(resp *pb.InspectContainerResponse) *docker.ContainerInspect {
+	state := resp.GetState()
+	inspect := &docker.ContainerInspect{
+		ID:            resp.Id,
+		Name:          resp.Name,
+		Image:         resp.Image,
+		ImageID:       resp.ImageId,
+		Created:       resp.Created,
+		RestartCount:  int(resp.RestartCount),
+		RestartPolicy: resp.RestartPolicy,
+		State: docker.ContainerInspectState{
+			Status:     state.GetStatus(),
+			Running:    state.GetRunning(),
+			Paused:     state.GetPaused(),
+			Restarting: state.GetRestarting(),
+			OOMKilled:  state.GetOomKilled(),
+			Dead:       state.GetDead(),
+			ExitCode:   int(state.GetExitCode()),
+			Error:      state.GetError(),
+			StartedAt:  state.GetStartedAt(),
+			FinishedAt: state.GetFinishedAt(),
+		},
+		EnvNames: resp.EnvNames,
+		Labels:   resp.Labels,
+		Mounts:   make([]docker.ContainerMount, 0, len(resp.Mounts)),
+		Networks: make([]docker.ContainerNetwork, 0, len(resp.Networks)),
+	}
+	if inspect.EnvNames == nil {
+		inspect.EnvNames = []string{}
+	}
+	if inspect.Labels == nil {
+		inspect.Labels = map[string]string{}
+	}
+	for _, m := range resp.Mounts {
+		inspect.Mounts = append(inspect.Mounts, docker.ContainerMount{
+			Type:        m.Type,
+			Source:      m.Source,
+			Destination: m.Destination,
+			ReadOnly:    m.ReadOnly,
+		})
+	}
+	for _, n := range resp.Networks {
+		inspect.Networks = append(inspect.Networks, docker.ContainerNetwork{
+			Name:       n.Name,
+			IPAddress:  n.IpAddress,
+			Gateway:    n.Gateway,
+			MacAddress: n.MacAddress,
+		})
+	}
+	if health := resp.Health; health != nil {
+		inspect.Health = &docker.ContainerHealthLog{
+			Status:        health.Status,
+			FailingStreak: int(health.FailingStreak),
+			Log:           make([]docker.ContainerHealthCheck, 0, len(health.Log)),
+		}
+		for _, check := range health.Log {
+			inspect.Health.Log = append(inspect.Health.Log, docker.ContainerHealthCheck{
+				Start:    check.Start,
+				End:      check.End,
+				ExitCode: int(check.ExitCode),
+				Output:   check.Output,
+			})
+		}
+	}
+	return inspect
+}
@@ -98,7 +98,7 @@ func (h *ContainerSSLHandler) ConfigureSSL(c *fiber.Ctx) error {
 
 	resp, err := h.agentClient.ConfigureContainerSSL(c.Context(), server.Host, h.agentPort, grpcReq)
 	if err != nil {
-		h.logger.Error("Failed to configure container SSL", "containerId", containerID, "error", err)
+		h.logger.ErrorContext(c.UserContext(), "Failed to configure container SSL", "containerId", containerID, "error", err)
 		return response.ServerError(c, fiber.StatusInternalServerError, "Failed to configure SSL")
 	}
 
@@ -145,7 +145,7 @@ func (h *ContainerSSLHandler) GetSSLStatus(c *fiber.Ctx) error {
 
 	resp, err := h.agentClient.GetContainerSSLStatus(c.Context(), server.Host, h.agentPort, grpcReq)
 	if err != nil {
-		h.logger.Error("Failed to get container SSL status", "containerId", containerID, "error", err)
+		h.logger.ErrorContext(c.UserContext(), "Failed to get container SSL status", "containerId", containerID, "error", err)
 		return response.ServerError(c, fiber.StatusInternalServerError, "Failed to get SSL status")
 	}
 
@@ -0,0 +1,148 @@
+package handler
+
+import (
+	"errors"
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/paasdeploy/backend/internal/domain"
+	"github.com/paasdeploy/backend/internal/response"
+	"github.com/paasdeploy/shared/pkg/sbom"
+)
+
+const msgSBOMNotFound = "No SBOM was generated for this deployment"
+
+// DeploymentSBOMHandler serves the software bill of materials generated
+// for the image of each deployment and compares them between deployments.
+type DeploymentSBOMHandler struct {
+	appRepo        domain.AppRepository
+	deploymentRepo domain.DeploymentRepository
+	sbomRepo       domain.DeploymentSBOMRepository
+	logger         *slog.Logger
+}
+
+func NewDeploymentSBOMHandler(
+	appRepo domain.AppRepository,
+	deploymentRepo domain.DeploymentRepository,
+	sbomRepo domain.DeploymentSBOMRepository,
+	logger *slog.Logger,
+) *DeploymentSBOMHandler {
+	return &DeploymentSBOMHandler{
+		appRepo:        appRepo,
+		deploymentRepo: deploymentRepo,
+		sbomRepo:       sbomRepo,
+		logger:         logger.With("handler", "deployment_sbom"),
+	}
+}
+
+func (h *DeploymentSBOMHandler) Register(app fiber.Router) {
+	deployments := app.Group(APIPrefix + "/deployments/:id/sbom")
+	deployments.Get("/", h.Get)
+	deployments.Get("/diff", h.Diff)
+}
+
+type SBOMDiffResponse struct {
+	From *domain.DeploymentSBOM `json:"from"`
+	To   *domain.DeploymentSBOM `json:"to"`
+	sbom.Diff
+}
+
+// requireDeployment returns the deployment if it belongs to an app of the
+// user. When it returns false it has already sent the error response.
+func (h *DeploymentSBOMHandler) requireDeployment(c *fiber.Ctx, deploymentID string) (*domain.Deployment, bool, error) {
+	user := GetUserFromContext(c)
+	if user == nil {
+		return nil, false, response.Unauthorized(c, MsgNotAuthenticated)
+	}
+	deploy, err := h.deploymentRepo.FindByID(deploymentID)
+	if err != nil {
+		return nil, false, response.NotFound(c, "Deployment not found")
+	}
+	if _, err := h.appRepo.FindByIDAndUserID(deploy.AppID, user.ID); err != nil {
+		return nil, false, response.NotFound(c, "Deployment not found")
+	}
+	return deploy, true, nil
+}
+
+func (h *DeploymentSBOMHandler) findSBOM(c *fiber.Ctx, deploymentID string) (*domain.DeploymentSBOM, bool, error) {
+	doc, err := h.sbomRepo.FindByDeploymentID(deploymentID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, false, response.NotFound(c, msgSBOMNotFound)
+		}
+		h.logger.ErrorContext(c.UserContext(), "Failed to load SBOM", "deployId", deploymentID, "error", err)
+		return nil, false, response.InternalError(c)
+	}
+	return doc, true, nil
+}
+
+// Get returns the CycloneDX JSON document of the deployment.
+func (h *DeploymentSBOMHandler) Get(c *fiber.Ctx) error {
+	deploy, ok, err := h.requireDeployment(c, c.Params("id"))
+	if !ok {
+		return err
+	}
+	doc, ok, err := h.findSBOM(c, deploy.ID)
+	if !ok {
+		return err
+	}
+
+	c.Set(fiber.HeaderContentType, "application/vnd.cyclonedx+json")
+	c.Set(fiber.HeaderContentDisposition, `attachment; filename="sbom-`+deploy.ID+`.cdx.json"`)
+	return c.Send(doc.Document)
+}
+
+// Diff compares the packages of the deployment with those of the
+// deployment given by ?against=, or else the app's previous successful
+// deployment with an SBOM.
+func (h *DeploymentSBOMHandler) Diff(c *fiber.Ctx) error {
+	deploy, ok, err := h.requireDeployment(c, c.Params("id"))
+	if !ok {
+		return err
+	}
+	to, ok, err := h.findSBOM(c, deploy.ID)
+	if !ok {
+		return err
+	}
+
+	var from *domain.DeploymentSBOM
+	if against := c.Query("against"); against != "" {
+		other, ok, err := h.requireDeployment(c, against)
+		if !ok {
+			return err
+		}
+		if other.AppID != deploy.AppID {
+			return response.BadRequest(c, "Deployments must belong to the same app")
+		}
+		if from, ok, err = h.findSBOM(c, other.ID); !ok {
+			return err
+		}
+	} else {
+		from, err = h.sbomRepo.FindPrevious(deploy.AppID, deploy.ID)
+		if errors.Is(err, domain.ErrNotFound) {
+			return response.NotFound(c, "No earlier deployment of this app has an SBOM")
+		}
+		if err != nil {
+			h.logger.ErrorContext(c.UserContext(), "Failed to load previous SBOM", "deployId", deploy.ID, "error", err)
+			return response.InternalError(c)
+		}
+	}
+
+	fromPackages, err := sbom.Packages(from.Document)
+	if err != nil {
+		h.logger.ErrorContext(c.UserContext(), "Failed to read SBOM", "deployId", from.DeploymentID, "error", err)
+		return response.InternalError(c)
+	}
+	toPackages, err := sbom.Packages(to.Document)
+	if err != nil {
+		h.logger.ErrorContext(c.UserContext(), "Failed to read SBOM", "deployId", to.DeploymentID, "error", err)
+		return response.InternalError(c)
+	}
+
+	return response.OK(c, SBOMDiffResponse{
+		From: from,
+		To:   to,
+		Diff: sbom.Compare(fromPackages, toPackages),
+	})
+}
@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/paasdeploy/backend/internal/domain"
+)
+
+type fakeSBOMDeploymentRepo struct {
+	domain.DeploymentRepository
+	deployments map[string]domain.Deployment
+}
+
+func (r *fakeSBOMDeploymentRepo) FindByID(id string) (*domain.Deployment, error) {
+	d, ok := r.deployments[id]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return &d, nil
+}
+
+type fakeSBOMRepo struct {
+	domain.DeploymentSBOMRepository
+}
+
+func (r *fakeSBOMRepo) FindByDeploymentID(string) (*domain.DeploymentSBOM, error) {
+	return nil, domain.ErrNotFound
+}
+
+func TestDeploymentSBOMHandlerNotFound(t *testing.T) {
+	apps := &fakeAppRepo{apps: map[string]string{"app-1": testOwner.ID, "app-2": "someone-else"}}
+	deployments := &fakeSBOMDeploymentRepo{deployments: map[string]domain.Deployment{
+		"dep-1": {ID: "dep-1", AppID: "app-1"},
+		"dep-2": {ID: "dep-2", AppID: "app-2"},
+	}}
+	app := newTestApp(testOwner)
+	NewDeploymentSBOMHandler(apps, deployments, &fakeSBOMRepo{}, testLogger()).Register(app)
+
+	for _, path := range []string{
+		"/deployments/missing/sbom",
+		"/deployments/missing/sbom/diff",
+		"/deployments/dep-2/sbom",
+		"/deployments/dep-1/sbom",
+		"/deployments/dep-1/sbom/diff?against=missing",
+	} {
+		resp := doRequest(t, app, fiber.MethodGet, APIPrefix+path, "")
+		if resp.StatusCode != fiber.StatusNotFound {
+			t.Errorf("GET %s: status = %d, want 404", path, resp.StatusCode)
+		}
+	}
+}
+
+func TestDiffDeploymentsRequiresAuthentication(t *testing.T) {
+	app := newTestApp(nil)
+	NewAppHandler(nil, nil, testLogger()).Register(app)
+
+	resp := doRequest(t, app, fiber.MethodGet, APIPrefix+"/apps/app-1/deployments/dep-1/diff/dep-2", "")
+	if resp.StatusCode != fiber.StatusUnauthorized {
+		t.Errorf("status = %d, want 401", resp.StatusCode)
+	}
+}
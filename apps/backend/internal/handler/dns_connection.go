@@ -0,0 +1,181 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/paasdeploy/backend/internal/cloud"
+	"github.com/paasdeploy/backend/internal/domain"
+	"github.com/paasdeploy/backend/internal/response"
+)
+
+type DNSConnectionResponse struct {
+	Provider  string `json:"provider"`
+	CreatedAt string `json:"createdAt"`
+	UpdatedAt string `json:"updatedAt"`
+}
+
+type SaveDNSConnectionRequest struct {
+	Credentials string `json:"credentials"`
+}
+
+func dnsProviderLabel(provider string) string {
+	switch provider {
+	case domain.DNSProviderCloudflare:
+		return "Cloudflare"
+	case domain.DNSProviderRoute53:
+		return "Route 53"
+	case domain.DNSProviderDigitalOcean:
+		return "DigitalOcean"
+	case domain.DNSProviderDeSEC:
+		return "deSEC"
+	}
+	return provider
+}
+
+// dnsProvider builds the client for one of the user's DNS connections.
+// Cloudflare comes from the OAuth connection, other providers from the
+// stored DNS connections.
+func (h *DomainHandler) dnsProvider(ctx context.Context, userID, provider string) (cloud.DNSProvider, error) {
+	var encrypted string
+	if provider == "" || provider == domain.DNSProviderCloudflare {
+		provider = domain.DNSProviderCloudflare
+		conn, err := h.connectionRepo.FindByUserID(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		encrypted = conn.AccessTokenEncrypted
+	} else {
+		if h.dnsConnRepo == nil {
+			return nil, domain.ErrNotFound
+		}
+		conn, err := h.dnsConnRepo.FindByUserAndProvider(userID, provider)
+		if err != nil {
+			return nil, err
+		}
+		encrypted = conn.CredentialsEncrypted
+	}
+
+	credentials, err := h.tokenEncryptor.Decrypt(encrypted)
+	if err != nil {
+		return nil, err
+	}
+	return cloud.NewDNSProvider(provider, credentials, h.logger)
+}
+
+func (h *DomainHandler) dnsProviderError(c *fiber.Ctx, provider string, err error) error {
+	if errors.Is(err, domain.ErrNotFound) {
+		if provider == domain.DNSProviderCloudflare {
+			return response.BadRequest(c, "Connect your Cloudflare account first")
+		}
+		return response.BadRequest(c, "Add a "+dnsProviderLabel(provider)+" DNS connection first")
+	}
+	h.logger.ErrorContext(c.UserContext(), "failed to load DNS connection", "dns_provider", provider, "error", err)
+	return response.InternalError(c)
+}
+
+// ListDNSConnections returns the providers the user can create domain
+// records with, Cloudflare included when it is connected.
+func (h *DomainHandler) ListDNSConnections(c *fiber.Ctx) error {
+	user := GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, MsgNotAuthenticated)
+	}
+
+	resp := []DNSConnectionResponse{}
+	if conn, err := h.connectionRepo.FindByUserID(c.Context(), user.ID); err == nil {
+		resp = append(resp, DNSConnectionResponse{
+			Provider:  domain.DNSProviderCloudflare,
+			CreatedAt: conn.CreatedAt.Format(DateTimeFormatISO8601),
+			UpdatedAt: conn.UpdatedAt.Format(DateTimeFormatISO8601),
+		})
+	}
+	if h.dnsConnRepo == nil {
+		return response.OK(c, resp)
+	}
+
+	conns, err := h.dnsConnRepo.ListByUserID(user.ID)
+	if err != nil {
+		h.logger.ErrorContext(c.UserContext(), "failed to list DNS connections", "error", err)
+		return response.InternalError(c)
+	}
+	for _, conn := range conns {
+		resp = append(resp, DNSConnectionResponse{
+			Provider:  conn.Provider,
+			CreatedAt: conn.CreatedAt.Format(DateTimeFormatISO8601),
+			UpdatedAt: conn.UpdatedAt.Format(DateTimeFormatISO8601),
+		})
+	}
+	return response.OK(c, resp)
+}
+
+// SaveDNSConnection verifies the credential against the provider API before
+// storing it encrypted, replacing any previous one.
+func (h *DomainHandler) SaveDNSConnection(c *fiber.Ctx) error {
+	user := GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, MsgNotAuthenticated)
+	}
+	if h.dnsConnRepo == nil {
+		return response.ServerError(c, fiber.StatusServiceUnavailable, "DNS connections not available")
+	}
+
+	provider := c.Params("provider")
+	if !domain.IsValidDNSProvider(provider) {
+		return response.BadRequest(c, "unsupported DNS provider")
+	}
+	var req SaveDNSConnectionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.BadRequest(c, MsgInvalidRequestBody)
+	}
+	credentials := strings.TrimSpace(req.Credentials)
+	if credentials == "" {
+		return response.BadRequest(c, "credentials are required")
+	}
+
+	client, err := cloud.NewDNSProvider(provider, credentials, h.logger)
+	if err != nil {
+		return response.BadRequest(c, err.Error())
+	}
+	ctx, cancel := context.WithTimeout(c.Context(), cloudRequestTimeout)
+	defer cancel()
+	if err := client.VerifyCredentials(ctx); err != nil {
+		h.logger.WarnContext(c.UserContext(), "invalid DNS credentials", "provider", provider, "userId", user.ID, "error", err)
+		return response.BadRequest(c, "invalid credentials: "+err.Error())
+	}
+
+	encrypted, err := h.tokenEncryptor.Encrypt(credentials)
+	if err != nil {
+		h.logger.ErrorContext(c.UserContext(), "failed to encrypt DNS credentials", "error", err)
+		return response.InternalError(c)
+	}
+	conn, err := h.dnsConnRepo.Upsert(user.ID, provider, encrypted)
+	if err != nil {
+		h.logger.ErrorContext(c.UserContext(), "failed to save DNS connection", "provider", provider, "error", err)
+		return response.InternalError(c)
+	}
+
+	h.logger.InfoContext(c.UserContext(), "DNS connection saved", "provider", provider, "userId", user.ID)
+	return response.OK(c, DNSConnectionResponse{
+		Provider:  conn.Provider,
+		CreatedAt: conn.CreatedAt.Format(DateTimeFormatISO8601),
+		UpdatedAt: conn.UpdatedAt.Format(DateTimeFormatISO8601),
+	})
+}
+
+func (h *DomainHandler) DeleteDNSConnection(c *fiber.Ctx) error {
+	user := GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, MsgNotAuthenticated)
+	}
+	if h.dnsConnRepo == nil {
+		return response.NotFound(c, "DNS connection not found")
+	}
+	if err := h.dnsConnRepo.Delete(user.ID, c.Params("provider")); err != nil {
+		return HandleNotFoundOrInternal(c, err, "DNS connection not found")
+	}
+	return response.NoContent(c)
+}
@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/paasdeploy/backend/internal/response"
+)
+
+const minBasicAuthPasswordLength = 8
+
+type UpdateDomainBasicAuthRequest struct {
+	Enabled  bool   `json:"enabled"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// UpdateBasicAuth turns Traefik basic auth on or off for a custom domain.
+// Enabling it replaces any existing user with the given credentials; only
+// the bcrypt hash is stored. Traefik checks the hash on every request, so
+// the default cost is used instead of the one for account passwords.
+func (h *DomainHandler) UpdateBasicAuth(c *fiber.Ctx) error {
+	user, app, customDomain, ok, err := h.requireDomainForUser(c)
+	if !ok {
+		return err
+	}
+
+	var req UpdateDomainBasicAuthRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.BadRequest(c, MsgInvalidRequestBody)
+	}
+
+	users := ""
+	if req.Enabled {
+		username := strings.TrimSpace(req.Username)
+		if username == "" || strings.ContainsAny(username, ":, \t\n") {
+			return response.BadRequest(c, "Username is required and cannot contain ':', ',' or spaces")
+		}
+		if len(req.Password) < minBasicAuthPasswordLength {
+			return response.BadRequest(c, "Password must be at least 8 characters")
+		}
+		hash, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+		if err != nil {
+			h.logger.ErrorContext(c.UserContext(), "failed to hash basic auth password", "error", err)
+			return response.InternalError(c)
+		}
+		users = username + ":" + string(hash)
+	}
+
+	updated, err := h.domainRepo.UpdateBasicAuth(c.Context(), customDomain.ID, users)
+	if err != nil {
+		h.logger.ErrorContext(c.UserContext(), "failed to update basic auth", "error", err, "domain", customDomain.Domain)
+		return response.InternalError(c)
+	}
+
+	h.notifyContainerUpdate(c.Context(), app, app.ID, customDomain.Domain)
+
+	h.logger.InfoContext(c.UserContext(), "Custom domain basic auth updated",
+		"app_id", app.ID,
+		"domain", customDomain.Domain,
+		"enabled", req.Enabled,
+		"user_id", user.ID,
+	)
+
+	return response.OK(c, toDomainResponse(updated))
+}
@@ -0,0 +1,151 @@
+package handler
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/paasdeploy/backend/internal/domain"
+	"github.com/paasdeploy/backend/internal/engine"
+	"github.com/paasdeploy/backend/internal/response"
+)
+
+const maxCertificatePEMSize = 64 * 1024
+
+type UploadDomainCertificateRequest struct {
+	Certificate string `json:"certificate"`
+	PrivateKey  string `json:"privateKey"`
+}
+
+// parseCustomCertificate checks that the PEM chain and key belong together and
+// that the leaf certificate is currently valid for the domain.
+func parseCustomCertificate(domainName, certPEM, keyPEM string) (*x509.Certificate, string) {
+	pair, err := tls.X509KeyPair([]byte(certPEM), []byte(keyPEM))
+	if err != nil {
+		return nil, "Certificate and private key do not form a valid pair"
+	}
+	leaf, err := x509.ParseCertificate(pair.Certificate[0])
+	if err != nil {
+		return nil, "Invalid certificate"
+	}
+	if err := leaf.VerifyHostname(domainName); err != nil {
+		return nil, "Certificate is not valid for " + domainName
+	}
+
+	now := time.Now()
+	if now.Before(leaf.NotBefore) {
+		return nil, "Certificate is not valid yet"
+	}
+	if now.After(leaf.NotAfter) {
+		return nil, "Certificate has expired"
+	}
+	return leaf, ""
+}
+
+// UploadCertificate installs a user-provided certificate for a custom domain
+// on the app's server and stops requesting one from Let's Encrypt. The
+// private key is stored encrypted so the certificate can be pushed again.
+func (h *DomainHandler) UploadCertificate(c *fiber.Ctx) error {
+	user, app, customDomain, ok, err := h.requireDomainForUser(c)
+	if !ok {
+		return err
+	}
+
+	var req UploadDomainCertificateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.BadRequest(c, MsgInvalidRequestBody)
+	}
+	certPEM := strings.TrimSpace(req.Certificate) + "\n"
+	keyPEM := strings.TrimSpace(req.PrivateKey) + "\n"
+	if len(certPEM) > maxCertificatePEMSize || len(keyPEM) > maxCertificatePEMSize {
+		return response.BadRequest(c, "Certificate or private key is too large")
+	}
+
+	leaf, msg := parseCustomCertificate(customDomain.Domain, certPEM, keyPEM)
+	if leaf == nil {
+		return response.BadRequest(c, msg)
+	}
+
+	encryptedKey, err := h.tokenEncryptor.Encrypt(keyPEM)
+	if err != nil {
+		h.logger.ErrorContext(c.UserContext(), "failed to encrypt certificate key", "error", err, "domain", customDomain.Domain)
+		return response.InternalError(c)
+	}
+
+	if h.certInstaller == nil {
+		return response.ServerError(c, fiber.StatusServiceUnavailable, "Certificate installation is not available")
+	}
+	if err := h.certInstaller.InstallCustomCertificate(c.Context(), app, customDomain.Domain, certPEM, keyPEM); err != nil {
+		if errors.Is(err, engine.ErrCustomCertificateUnsupported) {
+			return response.BadRequest(c, "Custom certificates are only supported for apps deployed to a remote server")
+		}
+		h.logger.ErrorContext(c.UserContext(), "failed to install certificate", "error", err, "domain", customDomain.Domain)
+		return response.ServerError(c, fiber.StatusServiceUnavailable, "Failed to install certificate on the server")
+	}
+
+	expiresAt := leaf.NotAfter
+	updated, err := h.domainRepo.UpdateCertificate(c.Context(), customDomain.ID, certPEM, encryptedKey, &expiresAt)
+	if err != nil {
+		h.logger.ErrorContext(c.UserContext(), "failed to save certificate", "error", err, "domain", customDomain.Domain)
+		return response.InternalError(c)
+	}
+
+	h.notifyContainerUpdate(c.Context(), app, app.ID, customDomain.Domain)
+
+	h.logger.InfoContext(c.UserContext(), "Custom certificate uploaded",
+		"app_id", app.ID,
+		"domain", customDomain.Domain,
+		"expires_at", expiresAt,
+		"user_id", user.ID,
+	)
+
+	return response.OK(c, toDomainResponse(updated))
+}
+
+// RemoveCertificate switches the domain back to Let's Encrypt. The labels are
+// updated before the files are removed so Traefik never routes the domain
+// without a certificate source.
+func (h *DomainHandler) RemoveCertificate(c *fiber.Ctx) error {
+	user, app, customDomain, ok, err := h.requireDomainForUser(c)
+	if !ok {
+		return err
+	}
+	if !customDomain.HasCustomCertificate() {
+		return response.BadRequest(c, "Domain has no custom certificate")
+	}
+
+	updated, err := h.domainRepo.UpdateCertificate(c.Context(), customDomain.ID, "", "", nil)
+	if err != nil {
+		h.logger.ErrorContext(c.UserContext(), "failed to clear certificate", "error", err, "domain", customDomain.Domain)
+		return response.InternalError(c)
+	}
+
+	h.notifyContainerUpdate(c.Context(), app, app.ID, customDomain.Domain)
+	h.removeInstalledCertificate(c.Context(), app, customDomain.Domain)
+
+	h.logger.InfoContext(c.UserContext(), "Custom certificate removed",
+		"app_id", app.ID,
+		"domain", customDomain.Domain,
+		"user_id", user.ID,
+	)
+
+	return response.OK(c, toDomainResponse(updated))
+}
+
+func (h *DomainHandler) removeInstalledCertificate(ctx context.Context, app *domain.App, domainName string) {
+	if h.certInstaller == nil {
+		return
+	}
+	if err := h.certInstaller.RemoveCustomCertificate(ctx, app, domainName); err != nil {
+		h.logger.Warn("failed to remove certificate from server",
+			"error", err,
+			"app_id", app.ID,
+			"domain", domainName,
+		)
+	}
+}
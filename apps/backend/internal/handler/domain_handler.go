@@ -3,40 +3,66 @@ package handler
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log/slog"
+	"net"
 	"strings"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/paasdeploy/backend/internal/cloud"
 	"github.com/paasdeploy/backend/internal/cloudflare"
 	"github.com/paasdeploy/backend/internal/crypto"
 	"github.com/paasdeploy/backend/internal/domain"
 	"github.com/paasdeploy/backend/internal/response"
+	"github.com/paasdeploy/backend/internal/service"
 )
 
 type ContainerDomainUpdater interface {
 	UpdateContainerDomains(ctx context.Context, app *domain.App) error
 }
 
+type CertificateInstaller interface {
+	InstallCustomCertificate(ctx context.Context, app *domain.App, domainName, certPEM, keyPEM string) error
+	RemoveCustomCertificate(ctx context.Context, app *domain.App, domainName string) error
+}
+
+// DomainTunnelRouter routes domains of servers that run a Cloudflare Tunnel.
+type DomainTunnelRouter interface {
+	FindTunnel(ctx context.Context, serverID string) (*domain.ServerTunnel, error)
+	SyncIngress(ctx context.Context, serverID string) error
+}
+
 type DomainHandler struct {
 	appRepo        domain.AppRepository
 	domainRepo     domain.CustomDomainRepository
 	connectionRepo domain.CloudflareConnectionRepository
+	dnsConnRepo    domain.DNSConnectionRepository
+	verifyRepo     domain.DomainVerificationRepository
+	resolver       DomainVerificationResolver
 	serverRepo     domain.ServerRepository
 	tokenEncryptor *crypto.TokenEncryptor
 	serverIP       string
 	logger         *slog.Logger
 	domainUpdater  ContainerDomainUpdater
+	certInstaller  CertificateInstaller
+	tunnels        DomainTunnelRouter
+	auditService   *service.AuditService
 }
 
 type DomainHandlerConfig struct {
 	AppRepo        domain.AppRepository
 	DomainRepo     domain.CustomDomainRepository
 	ConnectionRepo domain.CloudflareConnectionRepository
+	DNSConnRepo    domain.DNSConnectionRepository
+	VerifyRepo     domain.DomainVerificationRepository
 	ServerRepo     domain.ServerRepository
 	TokenEncryptor *crypto.TokenEncryptor
 	ServerIP       string
 	Logger         *slog.Logger
 	DomainUpdater  ContainerDomainUpdater
+	CertInstaller  CertificateInstaller
+	Tunnels        DomainTunnelRouter
+	AuditService   *service.AuditService
 }
 
 func NewDomainHandler(cfg DomainHandlerConfig) *DomainHandler {
@@ -44,11 +70,17 @@ func NewDomainHandler(cfg DomainHandlerConfig) *DomainHandler {
 		appRepo:        cfg.AppRepo,
 		domainRepo:     cfg.DomainRepo,
 		connectionRepo: cfg.ConnectionRepo,
+		dnsConnRepo:    cfg.DNSConnRepo,
+		verifyRepo:     cfg.VerifyRepo,
+		resolver:       net.DefaultResolver,
 		serverRepo:     cfg.ServerRepo,
 		tokenEncryptor: cfg.TokenEncryptor,
 		serverIP:       cfg.ServerIP,
 		logger:         cfg.Logger.With("handler", "domain"),
 		domainUpdater:  cfg.DomainUpdater,
+		certInstaller:  cfg.CertInstaller,
+		tunnels:        cfg.Tunnels,
+		auditService:   cfg.AuditService,
 	}
 }
 
@@ -58,27 +90,84 @@ func (h *DomainHandler) Register(app fiber.Router) {
 	apps.Get("/:id/domains", h.ListDomains)
 	apps.Post("/:id/domains", h.AddDomain)
 	apps.Delete("/:id/domains/:domainId", h.RemoveDomain)
+	apps.Put("/:id/domains/:domainId/basic-auth", h.UpdateBasicAuth)
+	apps.Put("/:id/domains/:domainId/ip-allowlist", h.UpdateIPAllowlist)
+	apps.Put("/:id/domains/:domainId/certificate", h.UploadCertificate)
+	apps.Delete("/:id/domains/:domainId/certificate", h.RemoveCertificate)
+
+	dnsConnections := v1.Group("/dns-connections")
+	dnsConnections.Get("/", h.ListDNSConnections)
+	dnsConnections.Put("/:provider", h.SaveDNSConnection)
+	dnsConnections.Delete("/:provider", h.DeleteDNSConnection)
+
+	verifications := v1.Group("/domain-verifications")
+	verifications.Get("/", h.ListDomainVerifications)
+	verifications.Post("/", h.CreateDomainVerification)
+	verifications.Post("/:verificationId/check", h.CheckDomainVerification)
+	verifications.Delete("/:verificationId", h.DeleteDomainVerification)
+}
+
+// requireDomainForUser loads the custom domain of the route and its app for
+// the user. When it returns false it has already sent the error response.
+func (h *DomainHandler) requireDomainForUser(c *fiber.Ctx) (*domain.User, *domain.App, *domain.CustomDomain, bool, error) {
+	user := GetUserFromContext(c)
+	if user == nil {
+		return nil, nil, nil, false, response.Unauthorized(c, MsgNotAuthenticated)
+	}
+
+	appID := c.Params("id")
+	app, err := h.appRepo.FindByIDAndUserID(appID, user.ID)
+	if err != nil {
+		return nil, nil, nil, false, response.NotFound(c, MsgAppNotFound)
+	}
+
+	customDomain, err := h.domainRepo.FindByID(c.Context(), c.Params("domainId"))
+	if err != nil || customDomain.AppID != appID {
+		return nil, nil, nil, false, response.NotFound(c, "Domain not found")
+	}
+	return user, app, customDomain, true, nil
 }
 
 type DomainResponse struct {
-	ID         string `json:"id"`
-	AppID      string `json:"appId"`
-	Domain     string `json:"domain"`
-	PathPrefix string `json:"pathPrefix"`
-	RecordType string `json:"recordType"`
-	Status     string `json:"status"`
-	CreatedAt  string `json:"createdAt"`
+	ID             string   `json:"id"`
+	AppID          string   `json:"appId"`
+	Domain         string   `json:"domain"`
+	PathPrefix     string   `json:"pathPrefix"`
+	RecordType     string   `json:"recordType"`
+	DNSProvider    string   `json:"dnsProvider"`
+	RedirectTo     string   `json:"redirectTo,omitempty"`
+	Status         string   `json:"status"`
+	BasicAuth      bool     `json:"basicAuth"`
+	BasicAuthUsers []string `json:"basicAuthUsers"`
+	IPAllowlist    []string `json:"ipAllowlist"`
+	// CustomCertificate is true when an uploaded certificate is served
+	// instead of Let's Encrypt; CertificateExpiresAt is its NotAfter.
+	CustomCertificate    bool    `json:"customCertificate"`
+	CertificateExpiresAt *string `json:"certificateExpiresAt,omitempty"`
+	CreatedAt            string  `json:"createdAt"`
 }
 
 func toDomainResponse(d *domain.CustomDomain) DomainResponse {
+	var expiresAt *string
+	if d.TLSExpiresAt != nil {
+		formatted := d.TLSExpiresAt.UTC().Format("2006-01-02T15:04:05Z")
+		expiresAt = &formatted
+	}
 	return DomainResponse{
-		ID:         d.ID,
-		AppID:      d.AppID,
-		Domain:     d.Domain,
-		PathPrefix: d.PathPrefix,
-		RecordType: d.RecordType,
-		Status:     d.Status,
-		CreatedAt:  d.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		ID:                   d.ID,
+		AppID:                d.AppID,
+		Domain:               d.Domain,
+		PathPrefix:           d.PathPrefix,
+		RecordType:           d.RecordType,
+		DNSProvider:          d.DNSProvider,
+		RedirectTo:           d.RedirectTo,
+		Status:               d.Status,
+		BasicAuth:            d.BasicAuthUsers != "",
+		BasicAuthUsers:       d.BasicAuthUsernames(),
+		IPAllowlist:          d.IPAllowlistEntries(),
+		CustomCertificate:    d.HasCustomCertificate(),
+		CertificateExpiresAt: expiresAt,
+		CreatedAt:            d.CreatedAt.Format("2006-01-02T15:04:05Z"),
 	}
 }
 
@@ -90,7 +179,7 @@ func (h *DomainHandler) ListDomains(c *fiber.Ctx) error {
 
 	domains, err := h.domainRepo.FindByAppID(c.Context(), appID)
 	if err != nil {
-		h.logger.Error("failed to list domains", "error", err, "app_id", appID)
+		h.logger.ErrorContext(c.UserContext(), "failed to list domains", "error", err, "app_id", appID)
 		return response.InternalError(c)
 	}
 
@@ -105,6 +194,12 @@ func (h *DomainHandler) ListDomains(c *fiber.Ctx) error {
 type AddDomainRequest struct {
 	Domain     string `json:"domain"`
 	PathPrefix string `json:"pathPrefix"`
+	// DNSProvider selects the DNS connection that manages the record.
+	// Defaults to Cloudflare.
+	DNSProvider string `json:"dnsProvider"`
+	// WWWPairing, on an apex domain, also adds its www name with one of the
+	// two redirecting to the other: "redirect-to-apex" or "redirect-to-www".
+	WWWPairing string `json:"wwwPairing"`
 }
 
 func (h *DomainHandler) AddDomain(c *fiber.Ctx) error {
@@ -125,84 +220,133 @@ func (h *DomainHandler) AddDomain(c *fiber.Ctx) error {
 		return response.NotFound(c, MsgAppNotFound)
 	}
 
-	domainName, pathPrefix, err := h.parseAndValidateAddDomainInput(c, req)
-	if err != nil {
+	domainName, pathPrefix, ok, err := h.parseAndValidateAddDomainInput(c, req)
+	if !ok {
 		return err
 	}
 
-	if err := h.checkDomainAvailability(c, domainName, pathPrefix); err != nil {
+	if ok, err := h.checkDomainAvailability(c, domainName, pathPrefix); !ok {
 		return err
 	}
 
-	conn, err := h.connectionRepo.FindByUserID(c.Context(), user.ID)
-	if err != nil {
-		return response.BadRequest(c, "Connect your Cloudflare account first")
+	wwwName, msg := wwwPairName(domainName, pathPrefix, req.WWWPairing)
+	if msg != "" {
+		return response.BadRequest(c, msg)
+	}
+	if wwwName != "" {
+		if ok, err := h.checkDomainAvailability(c, wwwName, ""); !ok {
+			return err
+		}
 	}
 
-	accessToken, err := h.tokenEncryptor.Decrypt(conn.AccessTokenEncrypted)
+	if ok, err := h.requireVerifiedDomain(c, user.ID, domainName); !ok {
+		return err
+	}
+
+	providerName := strings.TrimSpace(req.DNSProvider)
+	if providerName == "" {
+		providerName = domain.DNSProviderCloudflare
+	}
+	if providerName != domain.DNSProviderCloudflare && !domain.IsValidDNSProvider(providerName) {
+		return response.BadRequest(c, "unsupported DNS provider")
+	}
+
+	tunnel := h.serverTunnel(c.Context(), app)
+	if tunnel != nil && providerName != domain.DNSProviderCloudflare {
+		return response.BadRequest(c, "Apps on a server behind a Cloudflare Tunnel must use Cloudflare DNS")
+	}
+
+	dns, err := h.dnsProvider(c.Context(), user.ID, providerName)
 	if err != nil {
-		h.logger.Error("failed to decrypt cloudflare token", "error", err)
-		return response.InternalError(c)
+		return h.dnsProviderError(c, providerName, err)
 	}
 
 	targetIP, err := h.resolveTargetIP(app)
 	if err != nil {
-		h.logger.Error("failed to resolve target IP for domain",
+		h.logger.ErrorContext(c.UserContext(), "failed to resolve target IP for domain",
 			"error", err, "app_id", appID, "server_id", app.ServerID,
 		)
 		return response.InternalError(c)
 	}
 
-	customDomain, err := h.createCustomDomainWithDNS(c, appID, domainName, pathPrefix, accessToken, targetIP)
-	if err != nil {
-		return err
+	primaryName, redirectName := domainName, wwwName
+	if req.WWWPairing == domain.WWWPairingRedirectToWWW {
+		primaryName, redirectName = wwwName, domainName
 	}
-	if customDomain == nil {
-		h.logger.Error("unexpected: custom domain nil after create", "app_id", appID, "domain", domainName)
-		return response.InternalError(c)
+
+	customDomain, ok, err := h.createDomainWithPair(c, appID, primaryName, pathPrefix, redirectName, dns, targetIP, tunnel)
+	if !ok {
+		return err
 	}
 
 	h.notifyContainerUpdate(c.Context(), app, appID, domainName)
+	if tunnel != nil {
+		h.syncTunnelIngress(c.Context(), app)
+	}
 
-	h.logger.Info("Custom domain added",
+	h.logger.InfoContext(c.UserContext(), "Custom domain added",
 		"app_id", appID,
 		"domain", domainName,
-		"record_type", "A",
+		"record_type", customDomain.RecordType,
+		"dns_provider", customDomain.DNSProvider,
+		"www_pairing", req.WWWPairing,
 		"target_ip", targetIP,
 		"user_id", user.ID,
 	)
+	if h.auditService != nil {
+		h.auditService.LogDomainAdded(c.Context(), h.auditService.ExtractContext(c), customDomain.ID, appID, customDomain.Domain, pathPrefix)
+	}
 
 	return response.OK(c, toDomainResponse(customDomain))
 }
 
-func (h *DomainHandler) parseAndValidateAddDomainInput(c *fiber.Ctx, req AddDomainRequest) (string, string, error) {
+func (h *DomainHandler) parseAndValidateAddDomainInput(c *fiber.Ctx, req AddDomainRequest) (string, string, bool, error) {
 	domainName := strings.ToLower(strings.TrimSpace(req.Domain))
 	if domainName == "" {
-		return "", "", response.BadRequest(c, "Domain is required")
+		return "", "", false, response.BadRequest(c, "Domain is required")
 	}
 	if !isValidDomain(domainName) {
-		return "", "", response.BadRequest(c, "Invalid domain format")
+		return "", "", false, response.BadRequest(c, "Invalid domain format")
 	}
 
 	pathPrefix := strings.TrimSpace(req.PathPrefix)
 	if pathPrefix != "" && !strings.HasPrefix(pathPrefix, "/") {
 		pathPrefix = "/" + pathPrefix
 	}
-	return domainName, pathPrefix, nil
+	return domainName, pathPrefix, true, nil
 }
 
-func (h *DomainHandler) checkDomainAvailability(c *fiber.Ctx, domainName, pathPrefix string) error {
+func (h *DomainHandler) checkDomainAvailability(c *fiber.Ctx, domainName, pathPrefix string) (bool, error) {
 	existing, _ := h.domainRepo.FindByDomainAndPath(c.Context(), domainName, pathPrefix)
 	if existing != nil {
-		return response.BadRequest(c, "Domain with this path already exists")
+		return false, response.BadRequest(c, "Domain with this path already exists")
 	}
 	if pathPrefix == "" {
 		existingByDomain, _ := h.domainRepo.FindByDomain(c.Context(), domainName)
 		if existingByDomain != nil {
-			return response.BadRequest(c, "Domain already in use")
+			return false, response.BadRequest(c, "Domain already in use")
 		}
 	}
-	return nil
+	return true, nil
+}
+
+// wwwPairName returns the www name to pair with domainName, or a validation
+// message. Pairing is only offered for apex domains without a path prefix.
+func wwwPairName(domainName, pathPrefix, pairing string) (string, string) {
+	switch pairing {
+	case "":
+		return "", ""
+	case domain.WWWPairingRedirectToApex, domain.WWWPairingRedirectToWWW:
+	default:
+		return "", "Invalid www pairing"
+	}
+	if pathPrefix != "" {
+		return "", "www pairing is not available with a path prefix"
+	}
+	if extractRootDomain(domainName) != domainName {
+		return "", "www pairing is only available for apex domains"
+	}
+	return "www." + domainName, ""
 }
 
 func (h *DomainHandler) resolveTargetIP(app *domain.App) (string, error) {
@@ -218,37 +362,116 @@ func (h *DomainHandler) resolveTargetIP(app *domain.App) (string, error) {
 	return server.Host, nil
 }
 
-func (h *DomainHandler) createCustomDomainWithDNS(c *fiber.Ctx, appID, domainName, pathPrefix, accessToken, targetIP string) (*domain.CustomDomain, error) {
-	cfClient := cloudflare.NewClient(accessToken, h.logger)
+// serverTunnel returns the Cloudflare Tunnel of the app's server, or nil when
+// the server is reached directly.
+func (h *DomainHandler) serverTunnel(ctx context.Context, app *domain.App) *domain.ServerTunnel {
+	if h.tunnels == nil || app.ServerID == nil || *app.ServerID == "" {
+		return nil
+	}
+	tunnel, err := h.tunnels.FindTunnel(ctx, *app.ServerID)
+	if err != nil {
+		if !errors.Is(err, domain.ErrNotFound) {
+			h.logger.Warn("failed to load server tunnel", "error", err, "server_id", *app.ServerID)
+		}
+		return nil
+	}
+	return tunnel
+}
+
+func (h *DomainHandler) syncTunnelIngress(ctx context.Context, app *domain.App) {
+	if h.tunnels == nil || app.ServerID == nil || *app.ServerID == "" {
+		return
+	}
+	if err := h.tunnels.SyncIngress(ctx, *app.ServerID); err != nil {
+		h.logger.Warn("failed to update tunnel ingress",
+			"error", err,
+			"app_id", app.ID,
+			"server_id", *app.ServerID,
+		)
+	}
+}
+
+var (
+	errDNSZoneNotFound = errors.New("dns zone not found")
+	errDNSRecordFailed = errors.New("failed to configure dns record")
+)
+
+// createDomainWithPair creates the primary domain and, when redirectName is
+// set, its redirecting pair. The pair is one unit: without its redirect name
+// the primary is rolled back too. When it returns false it has already sent
+// the error response.
+func (h *DomainHandler) createDomainWithPair(c *fiber.Ctx, appID, primaryName, pathPrefix, redirectName string, dns cloud.DNSProvider, targetIP string, tunnel *domain.ServerTunnel) (*domain.CustomDomain, bool, error) {
+	customDomain, ok, err := h.createCustomDomainWithDNS(c, appID, primaryName, pathPrefix, "", dns, targetIP, tunnel)
+	if !ok || redirectName == "" {
+		return customDomain, ok, err
+	}
+	if _, ok, err := h.createCustomDomainWithDNS(c, appID, redirectName, "", primaryName, dns, targetIP, tunnel); !ok {
+		if deleteErr := dns.DeleteRecord(c.Context(), customDomain.ZoneID, customDomain.DNSRecordID); deleteErr != nil {
+			h.logger.WarnContext(c.UserContext(), "failed to delete DNS record of paired domain", "error", deleteErr, "domain", primaryName)
+		}
+		if removeErr := h.domainRepo.Delete(c.Context(), customDomain.ID); removeErr != nil {
+			h.logger.ErrorContext(c.UserContext(), "failed to roll back paired domain", "error", removeErr, "domain", primaryName)
+		}
+		return nil, false, err
+	}
+	return customDomain, true, nil
+}
+
+// createCustomDomainWithDNS provisions one domain. When it returns false it
+// has already sent the error response.
+func (h *DomainHandler) createCustomDomainWithDNS(c *fiber.Ctx, appID, domainName, pathPrefix, redirectTo string, dns cloud.DNSProvider, targetIP string, tunnel *domain.ServerTunnel) (*domain.CustomDomain, bool, error) {
+	customDomain, err := h.provisionCustomDomain(c.Context(), appID, domainName, pathPrefix, redirectTo, dns, targetIP, tunnel)
+	switch {
+	case errors.Is(err, errDNSZoneNotFound):
+		return nil, false, response.BadRequest(c, fmt.Sprintf("Domain/zone not found in your %s account. Add the zone there first.", dnsProviderLabel(dns.Name())))
+	case errors.Is(err, errDNSRecordFailed):
+		return nil, false, response.BadRequest(c, "Failed to configure DNS record")
+	case errors.Is(err, domain.ErrAlreadyExists):
+		return nil, false, response.BadRequest(c, "Domain already in use")
+	case err != nil:
+		return nil, false, response.InternalError(c)
+	}
+	return customDomain, true, nil
+}
+
+// provisionCustomDomain points the name at the app's server, or its tunnel,
+// and stores the domain, deleting the record again if that fails.
+func (h *DomainHandler) provisionCustomDomain(ctx context.Context, appID, domainName, pathPrefix, redirectTo string, dns cloud.DNSProvider, targetIP string, tunnel *domain.ServerTunnel) (*domain.CustomDomain, error) {
 	rootDomain := extractRootDomain(domainName)
 
-	zoneID, err := cfClient.GetZoneID(c.Context(), rootDomain)
+	zoneID, err := dns.FindZone(ctx, rootDomain)
 	if err != nil {
-		h.logger.Error("zone not found", "domain", rootDomain, "error", err)
-		return nil, response.BadRequest(c, "Domain/zone not found in your Cloudflare account. Add the zone in Cloudflare first.")
+		h.logger.Error("zone not found", "domain", rootDomain, "dns_provider", dns.Name(), "error", err)
+		return nil, errDNSZoneNotFound
 	}
 
-	recordID, err := cfClient.CreateOrGetARecord(c.Context(), zoneID, domainName, targetIP)
+	record := cloud.DNSRecord{Type: "A", Name: domainName, Content: targetIP}
+	if tunnel != nil {
+		record = cloud.DNSRecord{Type: "CNAME", Name: domainName, Content: cloudflare.TunnelCNAMETarget(tunnel.TunnelID), Proxied: true}
+	}
+	recordID, err := dns.UpsertRecord(ctx, zoneID, record)
 	if err != nil {
-		h.logger.Error("failed to create/get DNS record", "domain", domainName, "error", err)
-		return nil, response.BadRequest(c, "Failed to configure DNS record")
+		h.logger.Error("failed to create/get DNS record", "domain", domainName, "dns_provider", dns.Name(), "error", err)
+		return nil, errDNSRecordFailed
 	}
 
-	customDomain, err := h.domainRepo.Create(c.Context(), domain.CreateCustomDomainInput{
+	customDomain, err := h.domainRepo.Create(ctx, domain.CreateCustomDomainInput{
 		AppID:       appID,
 		Domain:      domainName,
 		PathPrefix:  pathPrefix,
 		ZoneID:      zoneID,
 		DNSRecordID: recordID,
-		RecordType:  "A",
+		RecordType:  record.Type,
+		DNSProvider: dns.Name(),
+		RedirectTo:  redirectTo,
 	})
 	if err != nil {
 		if errors.Is(err, domain.ErrAlreadyExists) {
-			return nil, response.BadRequest(c, "Domain already in use")
+			return nil, err
 		}
-		_ = cfClient.DeleteRecord(c.Context(), zoneID, recordID)
+		_ = dns.DeleteRecord(ctx, zoneID, recordID)
 		h.logger.Error("failed to save custom domain", "error", err)
-		return nil, response.InternalError(c)
+		return nil, err
 	}
 	return customDomain, nil
 }
@@ -289,28 +512,21 @@ func (h *DomainHandler) RemoveDomain(c *fiber.Ctx) error {
 		return response.NotFound(c, "Domain not found")
 	}
 
-	conn, err := h.connectionRepo.FindByUserID(c.Context(), user.ID)
-	if err == nil {
-		accessToken, err := h.tokenEncryptor.Decrypt(conn.AccessTokenEncrypted)
-		if err == nil {
-			cfClient := cloudflare.NewClient(accessToken, h.logger)
-			if deleteErr := cfClient.DeleteRecord(c.Context(), customDomain.ZoneID, customDomain.DNSRecordID); deleteErr != nil {
-				h.logger.Warn("failed to delete DNS record from Cloudflare",
-					"error", deleteErr,
-					"domain", customDomain.Domain,
-				)
-			}
-		}
-	}
-
-	if err := h.domainRepo.Delete(c.Context(), domainID); err != nil {
-		h.logger.Error("failed to delete custom domain", "error", err)
+	pair := h.domainPair(c.Context(), customDomain)
+	if err := h.removeCustomDomain(c.Context(), user.ID, customDomain); err != nil {
+		h.logger.ErrorContext(c.UserContext(), "failed to delete custom domain", "error", err)
 		return response.InternalError(c)
 	}
+	if pair != nil {
+		if err := h.removeCustomDomain(c.Context(), user.ID, pair); err != nil {
+			h.logger.ErrorContext(c.UserContext(), "failed to delete paired domain", "error", err, "domain", pair.Domain)
+			return response.InternalError(c)
+		}
+	}
 
 	if h.domainUpdater != nil {
 		if err := h.domainUpdater.UpdateContainerDomains(c.Context(), app); err != nil {
-			h.logger.Warn("failed to update container after domain removal",
+			h.logger.WarnContext(c.UserContext(), "failed to update container after domain removal",
 				"error", err,
 				"app_id", appID,
 				"domain", customDomain.Domain,
@@ -318,15 +534,61 @@ func (h *DomainHandler) RemoveDomain(c *fiber.Ctx) error {
 		}
 	}
 
-	h.logger.Info("Custom domain removed",
+	if customDomain.HasCustomCertificate() {
+		h.removeInstalledCertificate(c.Context(), app, customDomain.Domain)
+	}
+	if pair != nil && pair.HasCustomCertificate() {
+		h.removeInstalledCertificate(c.Context(), app, pair.Domain)
+	}
+	h.syncTunnelIngress(c.Context(), app)
+
+	h.logger.InfoContext(c.UserContext(), "Custom domain removed",
 		"app_id", appID,
 		"domain", customDomain.Domain,
 		"user_id", user.ID,
 	)
+	if h.auditService != nil {
+		h.auditService.LogDomainRemoved(c.Context(), h.auditService.ExtractContext(c), customDomain.ID, appID, customDomain.Domain)
+	}
 
 	return response.OK(c, fiber.Map{"message": "Domain removed"})
 }
 
+// domainPair returns the other name of the apex/www pair d belongs to, nil
+// when it is not paired.
+func (h *DomainHandler) domainPair(ctx context.Context, d *domain.CustomDomain) *domain.CustomDomain {
+	domains, err := h.domainRepo.FindByAppID(ctx, d.AppID)
+	if err != nil {
+		return nil
+	}
+	for i := range domains {
+		other := &domains[i]
+		if other.ID == d.ID {
+			continue
+		}
+		if other.RedirectTo == d.Domain || (d.IsRedirect() && other.Domain == d.RedirectTo && other.PathPrefix == "") {
+			return other
+		}
+	}
+	return nil
+}
+
+// removeCustomDomain deletes the domain's DNS record, best effort, and the
+// domain itself.
+func (h *DomainHandler) removeCustomDomain(ctx context.Context, userID string, d *domain.CustomDomain) error {
+	dns, err := h.dnsProvider(ctx, userID, d.DNSProvider)
+	if err == nil {
+		if deleteErr := dns.DeleteRecord(ctx, d.ZoneID, d.DNSRecordID); deleteErr != nil {
+			h.logger.Warn("failed to delete DNS record",
+				"error", deleteErr,
+				"domain", d.Domain,
+				"dns_provider", d.DNSProvider,
+			)
+		}
+	}
+	return h.domainRepo.Delete(ctx, d.ID)
+}
+
 func extractRootDomain(domain string) string {
 	parts := strings.Split(domain, ".")
 	n := len(parts)
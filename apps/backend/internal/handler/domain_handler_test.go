@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/paasdeploy/backend/internal/cloud"
+	"github.com/paasdeploy/backend/internal/response"
+)
+
+// fakeDNSProvider keeps records by ID and fails to write the names in fail.
+type fakeDNSProvider struct {
+	cloud.DNSProvider
+	records map[string]cloud.DNSRecord
+	fail    map[string]bool
+}
+
+func (p *fakeDNSProvider) Name() string { return "cloudflare" }
+
+func (p *fakeDNSProvider) FindZone(context.Context, string) (string, error) {
+	return "zone-1", nil
+}
+
+func (p *fakeDNSProvider) UpsertRecord(_ context.Context, _ string, record cloud.DNSRecord) (string, error) {
+	if p.fail[record.Name] {
+		return "", errors.New("record rejected")
+	}
+	if p.records == nil {
+		p.records = map[string]cloud.DNSRecord{}
+	}
+	id := "record-" + record.Name
+	p.records[id] = record
+	return id, nil
+}
+
+func (p *fakeDNSProvider) DeleteRecord(_ context.Context, _, recordID string) error {
+	delete(p.records, recordID)
+	return nil
+}
+
+func createPair(t *testing.T, h *DomainHandler, dns *fakeDNSProvider) *http.Response {
+	t.Helper()
+	app := newTestApp(testOwner)
+	app.Post("/pair", func(c *fiber.Ctx) error {
+		d, ok, err := h.createDomainWithPair(c, "app-1", "example.com", "", "www.example.com", dns, "203.0.113.10", nil)
+		if !ok {
+			return err
+		}
+		return response.Created(c, toDomainResponse(d))
+	})
+	return doRequest(t, app, http.MethodPost, "/pair", "")
+}
+
+func TestCreateDomainWithPair(t *testing.T) {
+	domains := &fakeCustomDomainRepo{}
+	dns := &fakeDNSProvider{}
+
+	if resp := createPair(t, newTestDomainHandler(domains, nil), dns); resp.StatusCode != http.StatusCreated {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusCreated)
+	}
+	if len(domains.domains) != 2 || len(dns.records) != 2 {
+		t.Fatalf("domains = %v, records = %v, want both names", domains.domains, dns.records)
+	}
+	redirect, err := domains.FindByDomain(context.Background(), "www.example.com")
+	if err != nil || redirect.RedirectTo != "example.com" {
+		t.Errorf("www domain = %+v, %v, want a redirect to example.com", redirect, err)
+	}
+}
+
+func TestCreateDomainWithPairRollsBackPrimary(t *testing.T) {
+	domains := &fakeCustomDomainRepo{}
+	dns := &fakeDNSProvider{fail: map[string]bool{"www.example.com": true}}
+
+	if resp := createPair(t, newTestDomainHandler(domains, nil), dns); resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusBadRequest)
+	}
+	if len(domains.domains) != 0 {
+		t.Errorf("domains = %v, want the primary rolled back", domains.domains)
+	}
+	if len(dns.records) != 0 {
+		t.Errorf("records = %v, want the primary record deleted", dns.records)
+	}
+}
@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/paasdeploy/backend/internal/response"
+)
+
+const maxIPAllowlistEntries = 100
+
+type UpdateDomainIPAllowlistRequest struct {
+	Entries []string `json:"entries"`
+}
+
+// normalizeIPAllowlist validates IPs and CIDR ranges and returns them in
+// canonical form, dropping blanks and duplicates.
+func normalizeIPAllowlist(entries []string) ([]string, error) {
+	seen := make(map[string]bool)
+	var normalized []string
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if strings.Contains(entry, "/") {
+			_, ipNet, err := net.ParseCIDR(entry)
+			if err != nil {
+				return nil, fmt.Errorf("invalid CIDR range %q", entry)
+			}
+			entry = ipNet.String()
+		} else {
+			ip := net.ParseIP(entry)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid IP address %q", entry)
+			}
+			entry = ip.String()
+		}
+		if !seen[entry] {
+			seen[entry] = true
+			normalized = append(normalized, entry)
+		}
+	}
+	if len(normalized) > maxIPAllowlistEntries {
+		return nil, fmt.Errorf("at most %d entries are allowed", maxIPAllowlistEntries)
+	}
+	return normalized, nil
+}
+
+// UpdateIPAllowlist restricts a custom domain to the given IPs and CIDR
+// ranges. An empty list removes the restriction.
+func (h *DomainHandler) UpdateIPAllowlist(c *fiber.Ctx) error {
+	user, app, customDomain, ok, err := h.requireDomainForUser(c)
+	if !ok {
+		return err
+	}
+
+	var req UpdateDomainIPAllowlistRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.BadRequest(c, MsgInvalidRequestBody)
+	}
+	entries, err := normalizeIPAllowlist(req.Entries)
+	if err != nil {
+		return response.BadRequest(c, err.Error())
+	}
+
+	updated, err := h.domainRepo.UpdateIPAllowlist(c.Context(), customDomain.ID, strings.Join(entries, "\n"))
+	if err != nil {
+		h.logger.ErrorContext(c.UserContext(), "failed to update ip allowlist", "error", err, "domain", customDomain.Domain)
+		return response.InternalError(c)
+	}
+
+	h.notifyContainerUpdate(c.Context(), app, app.ID, customDomain.Domain)
+
+	h.logger.InfoContext(c.UserContext(), "Custom domain IP allowlist updated",
+		"app_id", app.ID,
+		"domain", customDomain.Domain,
+		"entries", len(entries),
+		"user_id", user.ID,
+	)
+
+	return response.OK(c, toDomainResponse(updated))
+}
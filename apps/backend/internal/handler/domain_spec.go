@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/paasdeploy/backend/internal/domain"
+)
+
+// ApplySpecDomains reconciles the app's custom domains with an app spec.
+// Removals run first so a name can move between path prefixes. Errors the
+// user can fix wrap domain.ErrInvalidInput.
+func (h *DomainHandler) ApplySpecDomains(ctx context.Context, userID string, app *domain.App, toAdd []domain.AppSpecDomain, toRemove []domain.CustomDomain) error {
+	if len(toAdd) == 0 && len(toRemove) == 0 {
+		return nil
+	}
+	defer h.syncTunnelIngress(ctx, app)
+	defer h.notifyContainerUpdate(ctx, app, app.ID, "")
+
+	for i := range toRemove {
+		d := &toRemove[i]
+		pair := h.domainPair(ctx, d)
+		if err := h.removeCustomDomain(ctx, userID, d); err != nil {
+			return fmt.Errorf("remove domain %s: %w", d.Domain, err)
+		}
+		if pair != nil {
+			if err := h.removeCustomDomain(ctx, userID, pair); err != nil {
+				return fmt.Errorf("remove domain %s: %w", pair.Domain, err)
+			}
+		}
+		if d.HasCustomCertificate() {
+			h.removeInstalledCertificate(ctx, app, d.Domain)
+		}
+	}
+
+	for _, d := range toAdd {
+		if err := h.addSpecDomain(ctx, userID, app, d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (h *DomainHandler) addSpecDomain(ctx context.Context, userID string, app *domain.App, d domain.AppSpecDomain) error {
+	if !isValidDomain(d.Domain) {
+		return fmt.Errorf("%w: invalid domain %s", domain.ErrInvalidInput, d.Domain)
+	}
+	if existing, _ := h.domainRepo.FindByDomainAndPath(ctx, d.Domain, d.PathPrefix); existing != nil {
+		return fmt.Errorf("%w: domain %s is already in use", domain.ErrInvalidInput, d.Key())
+	}
+	if d.PathPrefix == "" {
+		if existing, _ := h.domainRepo.FindByDomain(ctx, d.Domain); existing != nil {
+			return fmt.Errorf("%w: domain %s is already in use", domain.ErrInvalidInput, d.Domain)
+		}
+	}
+
+	verified, err := h.isDomainVerified(ctx, userID, d.Domain)
+	if err != nil {
+		return err
+	}
+	if !verified {
+		return fmt.Errorf("%w: verify ownership of %s before attaching it to an app", domain.ErrInvalidInput, d.Domain)
+	}
+
+	providerName := d.DNSProvider
+	if providerName == "" {
+		providerName = domain.DNSProviderCloudflare
+	}
+	if providerName != domain.DNSProviderCloudflare && !domain.IsValidDNSProvider(providerName) {
+		return fmt.Errorf("%w: unsupported DNS provider %s", domain.ErrInvalidInput, providerName)
+	}
+	tunnel := h.serverTunnel(ctx, app)
+	if tunnel != nil && providerName != domain.DNSProviderCloudflare {
+		return fmt.Errorf("%w: apps on a server behind a Cloudflare Tunnel must use Cloudflare DNS", domain.ErrInvalidInput)
+	}
+
+	dns, err := h.dnsProvider(ctx, userID, providerName)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return fmt.Errorf("%w: add a %s DNS connection first", domain.ErrInvalidInput, dnsProviderLabel(providerName))
+		}
+		return err
+	}
+	targetIP, err := h.resolveTargetIP(app)
+	if err != nil {
+		return err
+	}
+
+	_, err = h.provisionCustomDomain(ctx, app.ID, d.Domain, d.PathPrefix, "", dns, targetIP, tunnel)
+	switch {
+	case errors.Is(err, errDNSZoneNotFound):
+		return fmt.Errorf("%w: zone of %s not found in your %s account", domain.ErrInvalidInput, d.Domain, dnsProviderLabel(dns.Name()))
+	case errors.Is(err, errDNSRecordFailed), errors.Is(err, domain.ErrAlreadyExists):
+		return fmt.Errorf("%w: %s: %v", domain.ErrInvalidInput, d.Domain, err)
+	}
+	return err
+}
@@ -0,0 +1,210 @@
+package handler
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/paasdeploy/backend/internal/domain"
+	"github.com/paasdeploy/backend/internal/response"
+)
+
+const domainVerificationLookupTimeout = 10 * time.Second
+
+// DomainVerificationResolver looks up verification records; *net.Resolver
+// satisfies it. TXT lookups follow CNAMEs, so the verification record may
+// also be a CNAME to a name carrying the TXT record.
+type DomainVerificationResolver interface {
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+}
+
+type DomainVerificationResponse struct {
+	ID          string  `json:"id"`
+	Domain      string  `json:"domain"`
+	RecordType  string  `json:"recordType"`
+	RecordName  string  `json:"recordName"`
+	RecordValue string  `json:"recordValue"`
+	Verified    bool    `json:"verified"`
+	VerifiedAt  *string `json:"verifiedAt,omitempty"`
+	CreatedAt   string  `json:"createdAt"`
+}
+
+type CreateDomainVerificationRequest struct {
+	Domain string `json:"domain"`
+}
+
+func toDomainVerificationResponse(v *domain.DomainVerification) DomainVerificationResponse {
+	resp := DomainVerificationResponse{
+		ID:          v.ID,
+		Domain:      v.Domain,
+		RecordType:  "TXT",
+		RecordName:  v.RecordName(),
+		RecordValue: v.RecordValue(),
+		Verified:    v.IsVerified(),
+		CreatedAt:   v.CreatedAt.Format(DateTimeFormatISO8601),
+	}
+	if v.VerifiedAt != nil {
+		verifiedAt := v.VerifiedAt.Format(DateTimeFormatISO8601)
+		resp.VerifiedAt = &verifiedAt
+	}
+	return resp
+}
+
+func generateVerificationToken() (string, error) {
+	bytes := make([]byte, 16)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(bytes), nil
+}
+
+// requireVerifiedDomain rejects domains the user has not proven to own,
+// directly or through a verified parent domain, so one tenant cannot route
+// another tenant's domain. When it returns false it has already sent the
+// error response.
+func (h *DomainHandler) requireVerifiedDomain(c *fiber.Ctx, userID, domainName string) (bool, error) {
+	verified, err := h.isDomainVerified(c.Context(), userID, domainName)
+	if err != nil {
+		h.logger.ErrorContext(c.UserContext(), "failed to list domain verifications", "error", err, "user_id", userID)
+		return false, response.InternalError(c)
+	}
+	if !verified {
+		return false, response.Forbidden(c, fmt.Sprintf("Verify ownership of %s before attaching it to an app", domainName))
+	}
+	return true, nil
+}
+
+func (h *DomainHandler) isDomainVerified(ctx context.Context, userID, domainName string) (bool, error) {
+	verifications, err := h.verifyRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	for i := range verifications {
+		if verifications[i].Covers(domainName) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (h *DomainHandler) ListDomainVerifications(c *fiber.Ctx) error {
+	user := GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, MsgNotAuthenticated)
+	}
+
+	verifications, err := h.verifyRepo.ListByUserID(c.Context(), user.ID)
+	if err != nil {
+		h.logger.ErrorContext(c.UserContext(), "failed to list domain verifications", "error", err, "user_id", user.ID)
+		return response.InternalError(c)
+	}
+
+	resp := make([]DomainVerificationResponse, len(verifications))
+	for i := range verifications {
+		resp[i] = toDomainVerificationResponse(&verifications[i])
+	}
+	return response.OK(c, resp)
+}
+
+// CreateDomainVerification starts verifying a domain and returns the record
+// to publish. Repeating it for the same domain returns the same token.
+func (h *DomainHandler) CreateDomainVerification(c *fiber.Ctx) error {
+	user := GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, MsgNotAuthenticated)
+	}
+
+	var req CreateDomainVerificationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.BadRequest(c, MsgInvalidRequestBody)
+	}
+	domainName := strings.TrimSuffix(strings.ToLower(strings.TrimSpace(req.Domain)), ".")
+	if domainName == "" {
+		return response.BadRequest(c, "Domain is required")
+	}
+	if !isValidDomain(domainName) {
+		return response.BadRequest(c, "Invalid domain format")
+	}
+
+	token, err := generateVerificationToken()
+	if err != nil {
+		h.logger.ErrorContext(c.UserContext(), "failed to generate verification token", "error", err)
+		return response.InternalError(c)
+	}
+	verification, err := h.verifyRepo.Create(c.Context(), user.ID, domainName, token)
+	if err != nil {
+		h.logger.ErrorContext(c.UserContext(), "failed to create domain verification", "error", err, "domain", domainName)
+		return response.InternalError(c)
+	}
+	return response.OK(c, toDomainVerificationResponse(verification))
+}
+
+// requireVerificationForUser loads the verification of the route for the
+// user. When it returns false it has already sent the error response.
+func (h *DomainHandler) requireVerificationForUser(c *fiber.Ctx) (*domain.DomainVerification, bool, error) {
+	user := GetUserFromContext(c)
+	if user == nil {
+		return nil, false, response.Unauthorized(c, MsgNotAuthenticated)
+	}
+	verification, err := h.verifyRepo.FindByID(c.Context(), c.Params("verificationId"))
+	if err != nil || verification.UserID != user.ID {
+		return nil, false, response.NotFound(c, "Domain verification not found")
+	}
+	return verification, true, nil
+}
+
+// CheckDomainVerification looks the TXT record up and marks the domain
+// verified when it carries the expected value.
+func (h *DomainHandler) CheckDomainVerification(c *fiber.Ctx) error {
+	verification, ok, err := h.requireVerificationForUser(c)
+	if !ok {
+		return err
+	}
+	if verification.IsVerified() {
+		return response.OK(c, toDomainVerificationResponse(verification))
+	}
+
+	ctx, cancel := context.WithTimeout(c.Context(), domainVerificationLookupTimeout)
+	defer cancel()
+	records, err := h.resolver.LookupTXT(ctx, verification.RecordName())
+	if err != nil {
+		h.logger.DebugContext(c.UserContext(), "verification record lookup failed", "domain", verification.Domain, "error", err)
+	}
+	found := false
+	for _, record := range records {
+		if strings.Trim(strings.TrimSpace(record), `"`) == verification.RecordValue() {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return response.BadRequest(c, fmt.Sprintf(
+			"TXT record %s = %s not found yet; DNS changes can take a few minutes to propagate",
+			verification.RecordName(), verification.RecordValue(),
+		))
+	}
+
+	verified, err := h.verifyRepo.MarkVerified(c.Context(), verification.ID)
+	if err != nil {
+		h.logger.ErrorContext(c.UserContext(), "failed to mark domain verified", "error", err, "domain", verification.Domain)
+		return response.InternalError(c)
+	}
+	h.logger.InfoContext(c.UserContext(), "Domain ownership verified", "domain", verified.Domain, "user_id", verified.UserID)
+	return response.OK(c, toDomainVerificationResponse(verified))
+}
+
+func (h *DomainHandler) DeleteDomainVerification(c *fiber.Ctx) error {
+	verification, ok, err := h.requireVerificationForUser(c)
+	if !ok {
+		return err
+	}
+	if err := h.verifyRepo.Delete(c.Context(), verification.ID); err != nil {
+		return HandleNotFoundOrInternal(c, err, "Domain verification not found")
+	}
+	return response.NoContent(c)
+}
@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/paasdeploy/backend/internal/domain"
+)
+
+// fakeCloudflareConnectionRepo holds no connections.
+type fakeCloudflareConnectionRepo struct {
+	domain.CloudflareConnectionRepository
+}
+
+func (r *fakeCloudflareConnectionRepo) FindByUserID(context.Context, string) (*domain.CloudflareConnection, error) {
+	return nil, domain.ErrNotFound
+}
+
+func newTestDomainHandler(domains *fakeCustomDomainRepo, verifications map[string]domain.DomainVerification) *DomainHandler {
+	return NewDomainHandler(DomainHandlerConfig{
+		AppRepo:        &fakeAppRepo{apps: map[string]string{"app-1": testOwner.ID}},
+		DomainRepo:     domains,
+		ConnectionRepo: &fakeCloudflareConnectionRepo{},
+		VerifyRepo:     &fakeVerificationRepo{verifications: verifications},
+		Logger:         testLogger(),
+	})
+}
+
+func TestAddDomainRequiresVerifiedOwnership(t *testing.T) {
+	verifiedAt := time.Now()
+	domains := &fakeCustomDomainRepo{}
+	h := newTestDomainHandler(domains, map[string]domain.DomainVerification{
+		"ver-1": {UserID: testOwner.ID, Domain: "example.com", VerifiedAt: &verifiedAt},
+		"ver-2": {UserID: testOwner.ID, Domain: "pending.dev"},
+		"ver-3": {UserID: testAdmin.ID, Domain: "other.io", VerifiedAt: &verifiedAt},
+	})
+	app := newTestApp(testOwner)
+	h.Register(app)
+
+	for _, name := range []string{"pending.dev", "other.io", "notexample.com"} {
+		resp := doRequest(t, app, http.MethodPost, APIPrefix+"/apps/app-1/domains", `{"domain":"`+name+`"}`)
+		if resp.StatusCode != http.StatusForbidden {
+			t.Errorf("%s: status = %d, want %d", name, resp.StatusCode, http.StatusForbidden)
+		}
+	}
+	if len(domains.domains) != 0 {
+		t.Fatalf("created domains = %v, want none", domains.domains)
+	}
+
+	// A verified parent covers the subdomain; the request then stops at the
+	// missing Cloudflare connection.
+	resp := doRequest(t, app, http.MethodPost, APIPrefix+"/apps/app-1/domains", `{"domain":"api.example.com"}`)
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("verified subdomain: status = %d, want %d", resp.StatusCode, http.StatusBadRequest)
+	}
+}
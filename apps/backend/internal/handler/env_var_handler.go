@@ -7,19 +7,22 @@ import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/paasdeploy/backend/internal/domain"
 	"github.com/paasdeploy/backend/internal/response"
+	"github.com/paasdeploy/backend/internal/service"
 )
 
 type EnvVarHandler struct {
-	envVarRepo domain.EnvVarRepository
-	appRepo    domain.AppRepository
-	logger     *slog.Logger
+	envVarRepo   domain.EnvVarRepository
+	appRepo      domain.AppRepository
+	auditService *service.AuditService
+	logger       *slog.Logger
 }
 
-func NewEnvVarHandler(envVarRepo domain.EnvVarRepository, appRepo domain.AppRepository, logger *slog.Logger) *EnvVarHandler {
+func NewEnvVarHandler(envVarRepo domain.EnvVarRepository, appRepo domain.AppRepository, auditService *service.AuditService, logger *slog.Logger) *EnvVarHandler {
 	return &EnvVarHandler{
-		envVarRepo: envVarRepo,
-		appRepo:    appRepo,
-		logger:     logger,
+		envVarRepo:   envVarRepo,
+		appRepo:      appRepo,
+		auditService: auditService,
+		logger:       logger,
 	}
 }
 
@@ -43,7 +46,7 @@ func (h *EnvVarHandler) ListEnvVars(c *fiber.Ctx) error {
 
 	vars, err := h.envVarRepo.FindByAppID(appID)
 	if err != nil {
-		h.logger.Error("Failed to list env vars", "appId", appID, "error", err)
+		h.logger.ErrorContext(c.UserContext(), "Failed to list env vars", "appId", appID, "error", err)
 		return response.InternalError(c)
 	}
 
@@ -71,10 +74,14 @@ func (h *EnvVarHandler) CreateEnvVar(c *fiber.Ctx) error {
 		if isDuplicateKeyError(err) {
 			return response.BadRequest(c, "Environment variable '"+input.Key+"' already exists")
 		}
-		h.logger.Error("Failed to create env var", "appId", appID, "key", input.Key, "error", err)
+		h.logger.ErrorContext(c.UserContext(), "Failed to create env var", "appId", appID, "key", input.Key, "error", err)
 		return response.InternalError(c)
 	}
 
+	if h.auditService != nil {
+		h.auditService.LogEnvCreated(c.Context(), h.auditService.ExtractContext(c), envVar.ID, appID, envVar.Key, envVar.IsSecret)
+	}
+
 	return response.Created(c, envVar.ToResponse())
 }
 
@@ -106,13 +113,21 @@ func (h *EnvVarHandler) BulkUpsertEnvVars(c *fiber.Ctx) error {
 	}
 
 	if err := h.envVarRepo.BulkUpsert(appID, input.Vars); err != nil {
-		h.logger.Error("Failed to bulk upsert env vars", "appId", appID, "error", err)
+		h.logger.ErrorContext(c.UserContext(), "Failed to bulk upsert env vars", "appId", appID, "error", err)
 		return response.InternalError(c)
 	}
 
+	if h.auditService != nil {
+		keys := make([]string, len(input.Vars))
+		for i, v := range input.Vars {
+			keys[i] = v.Key
+		}
+		h.auditService.LogEnvBulkUpdated(c.Context(), h.auditService.ExtractContext(c), appID, keys)
+	}
+
 	vars, err := h.envVarRepo.FindByAppID(appID)
 	if err != nil {
-		h.logger.Error("Failed to list env vars after bulk upsert", "appId", appID, "error", err)
+		h.logger.ErrorContext(c.UserContext(), "Failed to list env vars after bulk upsert", "appId", appID, "error", err)
 		return response.InternalError(c)
 	}
 
@@ -137,6 +152,10 @@ func (h *EnvVarHandler) UpdateEnvVar(c *fiber.Ctx) error {
 		return HandleNotFoundOrInternal(c, err, MsgEnvVarNotFound)
 	}
 
+	if h.auditService != nil {
+		h.auditService.LogEnvUpdated(c.Context(), h.auditService.ExtractContext(c), envVar.ID, appID, envVar.Key)
+	}
+
 	return response.OK(c, envVar.ToResponse())
 }
 
@@ -147,10 +166,33 @@ func (h *EnvVarHandler) DeleteEnvVar(c *fiber.Ctx) error {
 	}
 
 	varID := c.Params("varId")
+	var key string
+	if h.auditService != nil {
+		key = h.envVarKey(appID, varID)
+	}
 
 	if err := h.envVarRepo.Delete(varID); err != nil {
 		return HandleNotFoundOrInternal(c, err, MsgEnvVarNotFound)
 	}
 
+	if h.auditService != nil {
+		h.auditService.LogEnvDeleted(c.Context(), h.auditService.ExtractContext(c), varID, appID, key)
+	}
+
 	return response.OK(c, map[string]string{"message": MsgEnvVarDeleted})
 }
+
+// envVarKey looks up the key of an env var before it is deleted, so the
+// audit log can name it.
+func (h *EnvVarHandler) envVarKey(appID, varID string) string {
+	vars, err := h.envVarRepo.FindByAppID(appID)
+	if err != nil {
+		return ""
+	}
+	for _, v := range vars {
+		if v.ID == varID {
+			return v.Key
+		}
+	}
+	return ""
+}
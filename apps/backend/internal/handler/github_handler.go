@@ -148,7 +148,7 @@ func (h *GitHubHandler) ListInstallations(c *fiber.Ctx) error {
 
 	installations, err := h.installationRepo.FindUserInstallations(c.Context(), user.ID)
 	if err != nil {
-		h.logger.Error("failed to list installations", "error", err, "user_id", user.ID)
+		h.logger.ErrorContext(c.UserContext(), "failed to list installations", "error", err, "user_id", user.ID)
 		return response.InternalError(c)
 	}
 
@@ -177,7 +177,7 @@ func (h *GitHubHandler) ListRepositories(c *fiber.Ctx) error {
 		if errors.Is(err, domain.ErrNotFound) {
 			return response.OK(c, h.needInstallResponse())
 		}
-		h.logger.Error("failed to find installation", "error", err, "user_id", user.ID)
+		h.logger.ErrorContext(c.UserContext(), "failed to find installation", "error", err, "user_id", user.ID)
 		return response.InternalError(c)
 	}
 
@@ -191,7 +191,7 @@ func (h *GitHubHandler) ListRepositories(c *fiber.Ctx) error {
 
 	repos, err := h.appClient.ListInstallationRepos(c.Context(), result.installation.InstallationID)
 	if err != nil {
-		h.logger.Error("failed to list repos from GitHub", "error", err, "installation_id", result.installation.InstallationID)
+		h.logger.ErrorContext(c.UserContext(), "failed to list repos from GitHub", "error", err, "installation_id", result.installation.InstallationID)
 		return response.InternalError(c)
 	}
 
@@ -216,7 +216,7 @@ func (h *GitHubHandler) GetRepository(c *fiber.Ctx) error {
 
 	result, err := h.findInstallation(c, user.ID, "")
 	if err != nil {
-		h.logger.Error("failed to find installation", "error", err, "user_id", user.ID)
+		h.logger.ErrorContext(c.UserContext(), "failed to find installation", "error", err, "user_id", user.ID)
 		return response.InternalError(c)
 	}
 
@@ -230,7 +230,7 @@ func (h *GitHubHandler) GetRepository(c *fiber.Ctx) error {
 
 	repoData, err := h.appClient.GetRepository(c.Context(), result.installation.InstallationID, owner, repo)
 	if err != nil {
-		h.logger.Error("failed to get repo from GitHub", "error", err, "owner", owner, "repo", repo)
+		h.logger.ErrorContext(c.UserContext(), "failed to get repo from GitHub", "error", err, "owner", owner, "repo", repo)
 		return response.NotFound(c, "repository not found or not accessible")
 	}
 
@@ -240,18 +240,18 @@ func (h *GitHubHandler) GetRepository(c *fiber.Ctx) error {
 func (h *GitHubHandler) HandleInstallationWebhook(c *fiber.Ctx) error {
 	signature := c.Get("X-Hub-Signature-256")
 	if signature == "" {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "missing signature"})
+		return response.Unauthorized(c, "missing signature")
 	}
 
 	body := c.Body()
 
 	if !h.verifySignature(body, signature) {
-		h.logger.Warn("invalid webhook signature")
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "invalid signature"})
+		h.logger.WarnContext(c.UserContext(), "invalid webhook signature")
+		return response.Unauthorized(c, "invalid signature")
 	}
 
 	event := c.Get("X-GitHub-Event")
-	h.logger.Info("received GitHub App webhook", "event", event)
+	h.logger.InfoContext(c.UserContext(), "received GitHub App webhook", "event", event)
 
 	switch event {
 	case "installation":
@@ -356,8 +356,8 @@ func (h *GitHubHandler) handleInstallationSuspend(ctx context.Context, inst Inst
 func (h *GitHubHandler) handleInstallationEvent(c *fiber.Ctx, body []byte) error {
 	var payload InstallationEventPayload
 	if err := json.Unmarshal(body, &payload); err != nil {
-		h.logger.Error("failed to parse installation event", "error", err)
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid payload"})
+		h.logger.ErrorContext(c.UserContext(), "failed to parse installation event", "error", err)
+		return response.BadRequest(c, "invalid payload")
 	}
 
 	ctx := c.Context()
@@ -365,7 +365,7 @@ func (h *GitHubHandler) handleInstallationEvent(c *fiber.Ctx, body []byte) error
 	switch payload.Action {
 	case "created":
 		if err := h.handleInstallationCreated(ctx, payload.Installation, payload.Sender); err != nil {
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to store installation"})
+			return response.ServerError(c, fiber.StatusInternalServerError, "failed to store installation")
 		}
 	case "deleted":
 		h.handleInstallationDeleted(ctx, payload.Installation.ID)
@@ -381,11 +381,11 @@ func (h *GitHubHandler) handleInstallationEvent(c *fiber.Ctx, body []byte) error
 func (h *GitHubHandler) handleInstallationReposEvent(c *fiber.Ctx, body []byte) error {
 	var payload InstallationReposEventPayload
 	if err := json.Unmarshal(body, &payload); err != nil {
-		h.logger.Error("failed to parse installation_repositories event", "error", err)
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid payload"})
+		h.logger.ErrorContext(c.UserContext(), "failed to parse installation_repositories event", "error", err)
+		return response.BadRequest(c, "invalid payload")
 	}
 
-	h.logger.Info("installation repositories changed",
+	h.logger.InfoContext(c.UserContext(), "installation repositories changed",
 		"installation_id", payload.Installation.ID,
 		"action", payload.Action,
 		"added", len(payload.RepositoriesAdded),
@@ -0,0 +1,263 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"path"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/paasdeploy/backend/internal/domain"
+	"github.com/paasdeploy/backend/internal/gitops"
+	"github.com/paasdeploy/backend/internal/response"
+	"github.com/paasdeploy/backend/internal/service"
+	"github.com/paasdeploy/backend/internal/webhook"
+)
+
+const msgGitOpsSourceNotFound = "GitOps source not found"
+
+type GitOpsHandler struct {
+	sourceRepo     domain.GitOpsSourceRepository
+	appRepo        domain.AppRepository
+	controller     *gitops.Controller
+	webhookManager webhook.Manager
+	auditService   *service.AuditService
+	logger         *slog.Logger
+}
+
+func NewGitOpsHandler(
+	sourceRepo domain.GitOpsSourceRepository,
+	appRepo domain.AppRepository,
+	controller *gitops.Controller,
+	webhookManager webhook.Manager,
+	auditService *service.AuditService,
+	logger *slog.Logger,
+) *GitOpsHandler {
+	return &GitOpsHandler{
+		sourceRepo:     sourceRepo,
+		appRepo:        appRepo,
+		controller:     controller,
+		webhookManager: webhookManager,
+		auditService:   auditService,
+		logger:         logger.With("handler", "gitops"),
+	}
+}
+
+func (h *GitOpsHandler) Register(app fiber.Router) {
+	sources := app.Group(APIPrefix + "/gitops/sources")
+	sources.Get("/", h.ListSources)
+	sources.Post("/", h.CreateSource)
+	sources.Get("/:id", h.GetSource)
+	sources.Post("/:id/sync", h.SyncSource)
+	sources.Delete("/:id", h.DeleteSource)
+}
+
+type CreateGitOpsSourceRequest struct {
+	RepositoryURL string `json:"repositoryUrl"`
+	Branch        string `json:"branch"`
+	// Path is the directory holding the spec files, relative to the
+	// repository root.
+	Path string `json:"path"`
+}
+
+func (h *GitOpsHandler) ListSources(c *fiber.Ctx) error {
+	user := GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, MsgNotAuthenticated)
+	}
+
+	sources, err := h.sourceRepo.FindByUserID(c.Context(), user.ID)
+	if err != nil {
+		h.logger.ErrorContext(c.UserContext(), "Failed to list gitops sources", "userId", user.ID, "error", err)
+		return response.InternalError(c)
+	}
+
+	return response.OK(c, sources)
+}
+
+func (h *GitOpsHandler) CreateSource(c *fiber.Ctx) error {
+	user := GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, MsgNotAuthenticated)
+	}
+
+	var req CreateGitOpsSourceRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.BadRequest(c, MsgInvalidRequestBody)
+	}
+	input, err := gitOpsSourceInput(user.ID, req)
+	if err != nil {
+		return response.BadRequest(c, err.Error())
+	}
+
+	source, err := h.sourceRepo.Create(c.Context(), input)
+	if err != nil {
+		if errors.Is(err, domain.ErrAlreadyExists) {
+			return response.Conflict(c, "This repository, branch and path are already synced")
+		}
+		h.logger.ErrorContext(c.UserContext(), "Failed to create gitops source", "userId", user.ID, "error", err)
+		return response.InternalError(c)
+	}
+
+	h.setupWebhook(c.Context(), source)
+	h.controller.Trigger(source.ID)
+
+	if h.auditService != nil {
+		h.auditService.LogGitOpsSourceCreated(c.Context(), h.auditService.ExtractContext(c), source.ID, source.RepositoryURL)
+	}
+
+	return response.Created(c, source)
+}
+
+func gitOpsSourceInput(userID string, req CreateGitOpsSourceRequest) (domain.CreateGitOpsSourceInput, error) {
+	repoURL := strings.TrimSpace(req.RepositoryURL)
+	if !strings.HasPrefix(repoURL, "https://") {
+		return domain.CreateGitOpsSourceInput{}, errors.New("repositoryUrl must be an https URL")
+	}
+	branch := strings.TrimSpace(req.Branch)
+	if branch == "" {
+		branch = "main"
+	}
+	specPath := path.Clean("/" + strings.TrimSpace(req.Path))
+	specPath = strings.TrimPrefix(specPath, "/")
+	if specPath == "" {
+		specPath = "."
+	}
+	return domain.CreateGitOpsSourceInput{
+		UserID:        userID,
+		RepositoryURL: repoURL,
+		Branch:        branch,
+		Path:          specPath,
+	}, nil
+}
+
+// setupWebhook registers the push webhook of the source repository. Without
+// it the source is still synced by the periodic check.
+func (h *GitOpsHandler) setupWebhook(ctx context.Context, source *domain.GitOpsSource) {
+	if h.webhookManager == nil {
+		return
+	}
+	result, err := h.webhookManager.Setup(ctx, webhook.SetupInput{RepositoryURL: source.RepositoryURL})
+	if err != nil {
+		h.logger.Warn("Failed to setup gitops webhook", "sourceId", source.ID, "error", err)
+		return
+	}
+	if result == nil {
+		return
+	}
+	if err := h.sourceRepo.UpdateWebhookID(ctx, source.ID, &result.WebhookID); err != nil {
+		h.logger.Warn("Failed to save gitops webhook id", "sourceId", source.ID, "error", err)
+		return
+	}
+	source.WebhookID = &result.WebhookID
+}
+
+// GetSource returns the sync status of a source, including the state of
+// each app and the changes a drifted app is missing.
+func (h *GitOpsHandler) GetSource(c *fiber.Ctx) error {
+	source, ok, err := h.findSource(c)
+	if !ok {
+		return err
+	}
+	return response.OK(c, source)
+}
+
+func (h *GitOpsHandler) SyncSource(c *fiber.Ctx) error {
+	source, ok, err := h.findSource(c)
+	if !ok {
+		return err
+	}
+
+	h.controller.Trigger(source.ID)
+
+	if h.auditService != nil {
+		h.auditService.LogGitOpsSourceSynced(c.Context(), h.auditService.ExtractContext(c), source.ID, source.RepositoryURL)
+	}
+
+	return response.Accepted(c, fiber.Map{"message": "Sync queued"})
+}
+
+// DeleteSource stops syncing a source. Its apps are left in place.
+func (h *GitOpsHandler) DeleteSource(c *fiber.Ctx) error {
+	source, ok, err := h.findSource(c)
+	if !ok {
+		return err
+	}
+
+	if err := h.sourceRepo.Delete(c.Context(), source.ID); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return response.NotFound(c, msgGitOpsSourceNotFound)
+		}
+		h.logger.ErrorContext(c.UserContext(), "Failed to delete gitops source", "id", source.ID, "error", err)
+		return response.InternalError(c)
+	}
+
+	h.removeWebhook(c.Context(), source)
+
+	if h.auditService != nil {
+		h.auditService.LogGitOpsSourceDeleted(c.Context(), h.auditService.ExtractContext(c), source.ID, source.RepositoryURL)
+	}
+
+	return response.NoContent(c)
+}
+
+// removeWebhook removes the webhook of a deleted source unless an app or
+// another source of the same repository still relies on it.
+func (h *GitOpsHandler) removeWebhook(ctx context.Context, source *domain.GitOpsSource) {
+	if h.webhookManager == nil || source.WebhookID == nil {
+		return
+	}
+	webhookID := *source.WebhookID
+
+	apps, err := h.appRepo.FindAllByRepoURL(source.RepositoryURL)
+	if err != nil {
+		h.logger.Warn("Failed to check gitops webhook usage", "sourceId", source.ID, "error", err)
+		return
+	}
+	for i := range apps {
+		if apps[i].WebhookID != nil && *apps[i].WebhookID == webhookID {
+			return
+		}
+	}
+	sources, err := h.sourceRepo.FindAll(ctx)
+	if err != nil {
+		h.logger.Warn("Failed to check gitops webhook usage", "sourceId", source.ID, "error", err)
+		return
+	}
+	for i := range sources {
+		if sources[i].WebhookID != nil && *sources[i].WebhookID == webhookID {
+			return
+		}
+	}
+
+	err = h.webhookManager.Remove(ctx, webhook.RemoveInput{RepositoryURL: source.RepositoryURL, WebhookID: webhookID})
+	if err != nil {
+		h.logger.Warn("Failed to remove gitops webhook", "sourceId", source.ID, "webhookId", webhookID, "error", err)
+	}
+}
+
+func (h *GitOpsHandler) findSource(c *fiber.Ctx) (*domain.GitOpsSource, bool, error) {
+	user := GetUserFromContext(c)
+	if user == nil {
+		return nil, false, response.Unauthorized(c, MsgNotAuthenticated)
+	}
+
+	id := c.Params("id")
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, false, response.NotFound(c, msgGitOpsSourceNotFound)
+	}
+	source, err := h.sourceRepo.FindByID(c.Context(), id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, false, response.NotFound(c, msgGitOpsSourceNotFound)
+		}
+		h.logger.ErrorContext(c.UserContext(), "Failed to load gitops source", "id", id, "error", err)
+		return nil, false, response.InternalError(c)
+	}
+	if source.UserID != user.ID {
+		return nil, false, response.NotFound(c, msgGitOpsSourceNotFound)
+	}
+	return source, true, nil
+}
@@ -15,7 +15,8 @@ func isKnownDomainError(err error) bool {
 		errors.Is(err, domain.ErrDeployInProgress) ||
 		errors.Is(err, domain.ErrNoDeployAvailable) ||
 		errors.Is(err, domain.ErrWebhookNotConfigured) ||
-		errors.Is(err, domain.ErrForbidden)
+		errors.Is(err, domain.ErrForbidden) ||
+		errors.Is(err, domain.ErrQuotaExceeded)
 }
 
 func HandleDomainError(c *fiber.Ctx, err error) error {
@@ -34,6 +35,8 @@ func HandleDomainError(c *fiber.Ctx, err error) error {
 		return response.BadRequest(c, "webhook management not configured")
 	case errors.Is(err, domain.ErrForbidden):
 		return response.Forbidden(c, "forbidden")
+	case errors.Is(err, domain.ErrQuotaExceeded):
+		return response.Forbidden(c, err.Error())
 	default:
 		return response.InternalError(c)
 	}
@@ -0,0 +1,148 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/paasdeploy/backend/internal/domain"
+	"github.com/paasdeploy/backend/internal/requestctx"
+)
+
+var (
+	testAdmin = &domain.User{ID: "admin-1", Role: domain.RoleAdmin}
+	testOwner = &domain.User{ID: "user-1", Role: domain.RoleMember}
+)
+
+// fakeServerRepo finds the servers it holds for any user.
+type fakeServerRepo struct {
+	domain.ServerRepository
+	servers map[string]domain.Server
+}
+
+func (r *fakeServerRepo) FindByIDForUser(id, _ string) (*domain.Server, error) {
+	s, ok := r.servers[id]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return &s, nil
+}
+
+// fakeAppRepo maps app IDs to the ID of the user owning them.
+type fakeAppRepo struct {
+	domain.AppRepository
+	apps map[string]string
+}
+
+func (r *fakeAppRepo) FindByIDAndUserID(id, userID string) (*domain.App, error) {
+	if r.apps[id] != userID {
+		return nil, domain.ErrNotFound
+	}
+	return &domain.App{ID: id}, nil
+}
+
+func (r *fakeAppRepo) FindByIDsAndUserID(ids []string, userID string) ([]domain.App, error) {
+	var apps []domain.App
+	for _, id := range ids {
+		if r.apps[id] == userID {
+			apps = append(apps, domain.App{ID: id, Name: id})
+		}
+	}
+	return apps, nil
+}
+
+// fakeCustomDomainRepo keeps custom domains by ID.
+type fakeCustomDomainRepo struct {
+	domain.CustomDomainRepository
+	domains map[string]*domain.CustomDomain
+}
+
+func (r *fakeCustomDomainRepo) Create(_ context.Context, input domain.CreateCustomDomainInput) (*domain.CustomDomain, error) {
+	if r.domains == nil {
+		r.domains = map[string]*domain.CustomDomain{}
+	}
+	d := &domain.CustomDomain{
+		ID:          fmt.Sprintf("domain-%d", len(r.domains)+1),
+		AppID:       input.AppID,
+		Domain:      input.Domain,
+		PathPrefix:  input.PathPrefix,
+		ZoneID:      input.ZoneID,
+		DNSRecordID: input.DNSRecordID,
+		RecordType:  input.RecordType,
+		DNSProvider: input.DNSProvider,
+		RedirectTo:  input.RedirectTo,
+	}
+	r.domains[d.ID] = d
+	return d, nil
+}
+
+func (r *fakeCustomDomainRepo) FindByID(_ context.Context, id string) (*domain.CustomDomain, error) {
+	d, ok := r.domains[id]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return d, nil
+}
+
+func (r *fakeCustomDomainRepo) FindByDomain(_ context.Context, name string) (*domain.CustomDomain, error) {
+	for _, d := range r.domains {
+		if d.Domain == name {
+			return d, nil
+		}
+	}
+	return nil, domain.ErrNotFound
+}
+
+func (r *fakeCustomDomainRepo) FindByDomainAndPath(_ context.Context, name, pathPrefix string) (*domain.CustomDomain, error) {
+	for _, d := range r.domains {
+		if d.Domain == name && d.PathPrefix == pathPrefix {
+			return d, nil
+		}
+	}
+	return nil, domain.ErrNotFound
+}
+
+func (r *fakeCustomDomainRepo) Delete(_ context.Context, id string) error {
+	if _, ok := r.domains[id]; !ok {
+		return domain.ErrNotFound
+	}
+	delete(r.domains, id)
+	return nil
+}
+
+func testLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+// newTestApp returns an app that authenticates every request as user, or
+// leaves it anonymous when user is nil.
+func newTestApp(user *domain.User) *fiber.App {
+	app := fiber.New(fiber.Config{DisableStartupMessage: true})
+	app.Use(func(c *fiber.Ctx) error {
+		if user != nil {
+			requestctx.SetUserInContext(c, user)
+		}
+		return c.Next()
+	})
+	return app
+}
+
+func doRequest(t *testing.T, app *fiber.App, method, path, body string) *http.Response {
+	t.Helper()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	}
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("%s %s: %v", method, path, err)
+	}
+	return resp
+}
@@ -89,7 +89,7 @@ func (h *ImageHandler) ListImages(c *fiber.Ctx) error {
 
 	images, err := h.docker.ListImages(c.Context(), false)
 	if err != nil {
-		h.logger.Error(errListImages, "error", err)
+		h.logger.ErrorContext(c.UserContext(), errListImages, "error", err)
 		return response.ServerError(c, fiber.StatusInternalServerError, errListImages)
 	}
 
@@ -118,7 +118,7 @@ func (h *ImageHandler) listRemoteImages(c *fiber.Ctx, serverID string) error {
 
 	images, err := h.agentClient.ListImages(c.Context(), host, h.agentPort, false)
 	if err != nil {
-		h.logger.Error("Failed to list remote images", "serverId", serverID, "error", err)
+		h.logger.ErrorContext(c.UserContext(), "Failed to list remote images", "serverId", serverID, "error", err)
 		return response.ServerError(c, fiber.StatusInternalServerError, errListImages)
 	}
 
@@ -141,7 +141,7 @@ func (h *ImageHandler) listRemoteImages(c *fiber.Ctx, serverID string) error {
 func (h *ImageHandler) ListDanglingImages(c *fiber.Ctx) error {
 	images, err := h.docker.ListImages(c.Context(), true)
 	if err != nil {
-		h.logger.Error("Failed to list dangling images", "error", err)
+		h.logger.ErrorContext(c.UserContext(), "Failed to list dangling images", "error", err)
 		return response.ServerError(c, fiber.StatusInternalServerError, errListImages)
 	}
 
@@ -185,7 +185,7 @@ func (h *ImageHandler) RemoveImage(c *fiber.Ctx) error {
 			return response.ServerError(c, fiber.StatusInternalServerError, MsgServerNotFound)
 		}
 		if err := h.agentClient.RemoveImage(c.Context(), host, h.agentPort, target, force); err != nil {
-			h.logger.Error("Failed to remove remote image", "target", target, "error", err)
+			h.logger.ErrorContext(c.UserContext(), "Failed to remove remote image", "target", target, "error", err)
 			return h.imageRemoveError(c, err)
 		}
 		h.invalidateImages()
@@ -193,7 +193,7 @@ func (h *ImageHandler) RemoveImage(c *fiber.Ctx) error {
 	}
 
 	if err := h.docker.RemoveImageByID(c.Context(), target, force); err != nil {
-		h.logger.Error("Failed to remove image", "target", target, "force", force, "error", err)
+		h.logger.ErrorContext(c.UserContext(), "Failed to remove image", "target", target, "force", force, "error", err)
 		return h.imageRemoveError(c, err)
 	}
 
@@ -228,7 +228,7 @@ func (h *ImageHandler) PruneImages(c *fiber.Ctx) error {
 		}
 		pruneResp, err := h.agentClient.PruneImages(c.Context(), host, h.agentPort)
 		if err != nil {
-			h.logger.Error("Failed to prune remote images", "serverId", serverID, "error", err)
+			h.logger.ErrorContext(c.UserContext(), "Failed to prune remote images", "serverId", serverID, "error", err)
 			return response.ServerError(c, fiber.StatusInternalServerError, MsgFailedPruneImages)
 		}
 		h.invalidateImages()
@@ -240,7 +240,7 @@ func (h *ImageHandler) PruneImages(c *fiber.Ctx) error {
 
 	result, err := h.docker.PruneImages(c.Context())
 	if err != nil {
-		h.logger.Error(MsgFailedPruneImages, "error", err)
+		h.logger.ErrorContext(c.UserContext(), MsgFailedPruneImages, "error", err)
 		return response.ServerError(c, fiber.StatusInternalServerError, MsgFailedPruneImages)
 	}
 
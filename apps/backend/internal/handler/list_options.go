@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/paasdeploy/backend/internal/domain"
+)
+
+// parseListOptions reads the limit, cursor, sort, q, from and to query
+// parameters of a list endpoint. defaultLimit applies when the request sets
+// no limit; zero keeps the whole list for clients that predate pagination.
+// Errors wrap domain.ErrInvalidInput and are meant for the client.
+func parseListOptions(c *fiber.Ctx, sortFields []string, defaultSort string, defaultLimit int) (domain.ListOptions, error) {
+	opts := domain.ListOptions{Limit: defaultLimit, Query: c.Query("q")}
+
+	if value := c.Query("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 1 {
+			return opts, fmt.Errorf("%w: limit must be a positive number", domain.ErrInvalidInput)
+		}
+		opts.Limit = limit
+	}
+
+	if value := c.Query("cursor"); value != "" {
+		cursor, err := domain.DecodeCursor(value)
+		if err != nil {
+			return opts, err
+		}
+		opts.After = cursor
+		if opts.Limit == 0 {
+			opts.Limit = domain.DefaultPageLimit
+		}
+	}
+
+	sortBy, desc, err := domain.ParseSort(c.Query("sort"), sortFields, defaultSort)
+	if err != nil {
+		return opts, err
+	}
+	opts.SortBy, opts.Desc = sortBy, desc
+
+	if opts.From, err = parseListTime(c.Query("from"), false); err != nil {
+		return opts, fmt.Errorf("%w: from must be an RFC 3339 time or a date", domain.ErrInvalidInput)
+	}
+	if opts.To, err = parseListTime(c.Query("to"), true); err != nil {
+		return opts, fmt.Errorf("%w: to must be an RFC 3339 time or a date", domain.ErrInvalidInput)
+	}
+
+	return opts, opts.Validate()
+}
+
+// parseListTime accepts an RFC 3339 time or a plain date. A date used as
+// the end of a range covers the whole day.
+func parseListTime(value string, endOfDay bool) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return &t, nil
+	}
+	t, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return nil, err
+	}
+	if endOfDay {
+		t = t.Add(24*time.Hour - time.Nanosecond)
+	}
+	return &t, nil
+}
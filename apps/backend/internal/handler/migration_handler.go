@@ -1,22 +1,38 @@
 package handler
 
 import (
+	"fmt"
 	"log/slog"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/paasdeploy/backend/internal/agentclient"
+	"github.com/paasdeploy/backend/internal/domain"
 	"github.com/paasdeploy/backend/internal/migration"
 	"github.com/paasdeploy/backend/internal/response"
 )
 
 type MigrationHandler struct {
-	service *migration.MigrationService
-	logger  *slog.Logger
+	service     *migration.MigrationService
+	agentClient *agentclient.AgentClient
+	serverRepo  domain.ServerRepository
+	agentPort   int
+	logger      *slog.Logger
 }
 
-func NewMigrationHandler(logger *slog.Logger) *MigrationHandler {
+type MigrationHandlerConfig struct {
+	AgentClient *agentclient.AgentClient
+	ServerRepo  domain.ServerRepository
+	AgentPort   int
+	Logger      *slog.Logger
+}
+
+func NewMigrationHandler(cfg MigrationHandlerConfig) *MigrationHandler {
 	return &MigrationHandler{
-		service: migration.NewMigrationService(logger),
-		logger:  logger.With("handler", "migration"),
+		service:     migration.NewMigrationService(cfg.Logger),
+		agentClient: cfg.AgentClient,
+		serverRepo:  cfg.ServerRepo,
+		agentPort:   cfg.AgentPort,
+		logger:      cfg.Logger.With("handler", "migration"),
 	}
 }
 
@@ -25,32 +41,57 @@ func (h *MigrationHandler) Register(app fiber.Router) {
 	m := v1.Group("/migration")
 
 	m.Get("/status", h.GetStatus)
+	m.Post("/plan", h.Plan)
 	m.Post("/backup", h.CreateBackup)
 	m.Post("/containers/stop", h.StopContainers)
 	m.Post("/containers/start", h.StartContainers)
 	m.Post("/proxy/stop-nginx", h.StopNginx)
 	m.Get("/sites/:index/traefik", h.GetTraefikConfig)
 	m.Post("/sites/:index/migrate", h.MigrateSite)
+	m.Post("/rollback", h.Rollback)
 }
 
 func (h *MigrationHandler) GetStatus(c *fiber.Ctx) error {
-	status, err := h.service.GetStatus(c.Context())
+	status, err := h.status(c)
 	if err != nil {
-		h.logger.Error("Failed to get migration status", "error", err)
+		h.logger.ErrorContext(c.UserContext(), "Failed to get migration status", "error", err)
 		return response.InternalError(c)
 	}
 
 	return response.OK(c, status)
 }
 
+// Plan returns a dry-run migration report. With ?format=markdown the report is
+// returned as a downloadable markdown file instead of JSON.
+func (h *MigrationHandler) Plan(c *fiber.Ctx) error {
+	plan, err := h.plan(c)
+	if err != nil {
+		h.logger.ErrorContext(c.UserContext(), "Failed to build migration plan", "error", err)
+		return response.InternalError(c)
+	}
+
+	if c.Query("format") == "markdown" {
+		filename := fmt.Sprintf("migration-plan-%s.md", plan.GeneratedAt.Format("2006-01-02-150405"))
+		c.Set(fiber.HeaderContentType, "text/markdown; charset=utf-8")
+		c.Set(fiber.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", filename))
+		return c.SendString(migration.RenderPlanMarkdown(plan))
+	}
+
+	return response.OK(c, plan)
+}
+
 func (h *MigrationHandler) CreateBackup(c *fiber.Ctx) error {
+	if serverID := c.Query("serverId"); serverID != "" {
+		return h.createRemoteBackup(c, serverID)
+	}
+
 	result, err := h.service.CreateBackup(c.Context())
 	if err != nil {
-		h.logger.Error("Failed to create backup", "error", err)
+		h.logger.ErrorContext(c.UserContext(), "Failed to create backup", "error", err)
 		return response.BadRequest(c, "Failed to create backup")
 	}
 
-	h.logger.Info("Backup created", "path", result.Path)
+	h.logger.InfoContext(c.UserContext(), "Backup created", "path", result.Path)
 	return response.OK(c, result)
 }
 
@@ -68,12 +109,12 @@ func (h *MigrationHandler) StopContainers(c *fiber.Ctx) error {
 		return response.BadRequest(c, "No containers specified")
 	}
 
-	if err := h.service.StopContainers(c.Context(), req.ContainerIDs); err != nil {
-		h.logger.Error("Failed to stop containers", "error", err)
+	if err := h.stopContainers(c, req.ContainerIDs); err != nil {
+		h.logger.ErrorContext(c.UserContext(), "Failed to stop containers", "error", err)
 		return response.BadRequest(c, "Failed to stop containers")
 	}
 
-	h.logger.Info("Containers stopped", "count", len(req.ContainerIDs))
+	h.logger.InfoContext(c.UserContext(), "Containers stopped", "count", len(req.ContainerIDs))
 	return response.OK(c, fiber.Map{
 		"message": "Containers stopped successfully",
 		"stopped": req.ContainerIDs,
@@ -90,12 +131,12 @@ func (h *MigrationHandler) StartContainers(c *fiber.Ctx) error {
 		return response.BadRequest(c, "No containers specified")
 	}
 
-	if err := h.service.StartContainers(c.Context(), req.ContainerIDs); err != nil {
-		h.logger.Error("Failed to start containers", "error", err)
+	if err := h.startContainers(c, req.ContainerIDs); err != nil {
+		h.logger.ErrorContext(c.UserContext(), "Failed to start containers", "error", err)
 		return response.BadRequest(c, "Failed to start containers")
 	}
 
-	h.logger.Info("Containers started", "count", len(req.ContainerIDs))
+	h.logger.InfoContext(c.UserContext(), "Containers started", "count", len(req.ContainerIDs))
 	return response.OK(c, fiber.Map{
 		"message": "Containers started successfully",
 		"started": req.ContainerIDs,
@@ -103,12 +144,16 @@ func (h *MigrationHandler) StartContainers(c *fiber.Ctx) error {
 }
 
 func (h *MigrationHandler) StopNginx(c *fiber.Ctx) error {
+	if serverID := c.Query("serverId"); serverID != "" {
+		return h.stopRemoteNginx(c, serverID)
+	}
+
 	if err := h.service.StopNginx(c.Context()); err != nil {
-		h.logger.Error("Failed to stop nginx", "error", err)
+		h.logger.ErrorContext(c.UserContext(), "Failed to stop nginx", "error", err)
 		return response.BadRequest(c, "Failed to stop nginx")
 	}
 
-	h.logger.Info("Nginx stopped and disabled")
+	h.logger.InfoContext(c.UserContext(), "Nginx stopped and disabled")
 	return response.OK(c, fiber.Map{
 		"message": "Nginx stopped and disabled successfully",
 	})
@@ -120,7 +165,7 @@ func (h *MigrationHandler) GetTraefikConfig(c *fiber.Ctx) error {
 		return response.BadRequest(c, "Invalid site index")
 	}
 
-	status, err := h.service.GetStatus(c.Context())
+	status, err := h.status(c)
 	if err != nil {
 		return response.InternalError(c)
 	}
@@ -159,7 +204,7 @@ func (h *MigrationHandler) MigrateSite(c *fiber.Ctx) error {
 		return response.BadRequest(c, "Container ID is required")
 	}
 
-	status, err := h.service.GetStatus(c.Context())
+	status, err := h.status(c)
 	if err != nil {
 		return response.InternalError(c)
 	}
@@ -170,14 +215,35 @@ func (h *MigrationHandler) MigrateSite(c *fiber.Ctx) error {
 
 	site := status.NginxSites[index]
 
-	h.logger.Info("Starting migration", "site", site.ServerNames[0], "container", req.ContainerID)
+	h.logger.InfoContext(c.UserContext(), "Starting migration", "site", site.ServerNames[0], "container", req.ContainerID)
+
+	if serverID := c.Query("serverId"); serverID != "" {
+		return h.migrateRemoteSite(c, serverID, site, req.ContainerID)
+	}
 
 	result, err := h.service.MigrateContainer(c.Context(), site, req.ContainerID)
 	if err != nil {
-		h.logger.Error("Migration failed", "error", err)
+		h.logger.ErrorContext(c.UserContext(), "Migration failed", "error", err)
 		return response.BadRequest(c, "Migration failed")
 	}
 
-	h.logger.Info("Migration completed", "site", site.ServerNames[0], "newContainer", result.ContainerID)
+	h.logger.InfoContext(c.UserContext(), "Migration completed", "site", site.ServerNames[0], "newContainer", result.ContainerID)
+	return response.OK(c, result)
+}
+
+// Rollback restores the original containers and nginx from the changes
+// recorded during the migration.
+func (h *MigrationHandler) Rollback(c *fiber.Ctx) error {
+	if c.Query("serverId") != "" {
+		return response.BadRequest(c, "Rollback is only available for the local host")
+	}
+
+	result, err := h.service.Rollback(c.Context())
+	if err != nil {
+		h.logger.ErrorContext(c.UserContext(), "Rollback failed", "error", err)
+		return response.BadRequest(c, "Rollback failed")
+	}
+
+	h.logger.InfoContext(c.UserContext(), "Rollback completed", "rolledBack", len(result.RolledBack), "errors", len(result.Errors))
 	return response.OK(c, result)
 }
@@ -0,0 +1,193 @@
+package handler
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	pb "github.com/paasdeploy/backend/gen/go/flowdeploy/v1"
+	"github.com/paasdeploy/backend/internal/migration"
+	"github.com/paasdeploy/backend/internal/response"
+)
+
+// Migration endpoints act on the backend host by default. With ?serverId=
+// they run through the agent of that server: the agent collects raw state
+// and executes changes, while parsing and planning stay in the backend.
+
+func (h *MigrationHandler) remoteHost(c *fiber.Ctx, serverID string) (string, error) {
+	if h.agentClient == nil || h.serverRepo == nil {
+		return "", fmt.Errorf("remote migration not configured")
+	}
+	server, err := h.serverRepo.FindByIDForUser(serverID, GetUserFromContext(c).ID)
+	if err != nil {
+		return "", fmt.Errorf("server not found: %w", err)
+	}
+	return server.Host, nil
+}
+
+func (h *MigrationHandler) status(c *fiber.Ctx) (*migration.MigrationStatus, error) {
+	serverID := c.Query("serverId")
+	if serverID == "" {
+		return h.service.GetStatus(c.Context())
+	}
+	snap, err := h.remoteSnapshot(c, serverID)
+	if err != nil {
+		return nil, err
+	}
+	return h.service.AnalyzeSnapshot(snap), nil
+}
+
+func (h *MigrationHandler) plan(c *fiber.Ctx) (*migration.MigrationPlan, error) {
+	serverID := c.Query("serverId")
+	if serverID == "" {
+		return h.service.Plan(c.Context())
+	}
+	snap, err := h.remoteSnapshot(c, serverID)
+	if err != nil {
+		return nil, err
+	}
+	return h.service.PlanSnapshot(snap), nil
+}
+
+func (h *MigrationHandler) remoteSnapshot(c *fiber.Ctx, serverID string) (*migration.HostSnapshot, error) {
+	host, err := h.remoteHost(c, serverID)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := h.agentClient.GetMigrationSnapshot(c.Context(), host, h.agentPort)
+	if err != nil {
+		h.logger.ErrorContext(c.UserContext(), "Failed to get remote migration snapshot", "serverId", serverID, "error", err)
+		return nil, err
+	}
+	return snapshotFromProto(resp), nil
+}
+
+func snapshotFromProto(resp *pb.GetMigrationSnapshotResponse) *migration.HostSnapshot {
+	snap := &migration.HostSnapshot{
+		Proxy: migration.ProxyStatus{
+			Type:    resp.GetProxyType(),
+			Running: resp.GetProxyRunning(),
+			Version: resp.GetProxyVersion(),
+		},
+		TraefikRunning: resp.GetTraefikRunning(),
+	}
+	for _, f := range resp.GetNginxConfigs() {
+		snap.NginxConfigs = append(snap.NginxConfigs, migration.ConfigFile{Path: f.GetPath(), Content: f.GetContent()})
+	}
+	for _, cert := range resp.GetCertificates() {
+		snap.Certificates = append(snap.Certificates, migration.CertificateFile{
+			Domain:        cert.GetDomain(),
+			PEM:           cert.GetCertPem(),
+			RenewalConfig: cert.GetRenewalConfig(),
+		})
+	}
+	for _, ct := range resp.GetContainers() {
+		snap.Containers = append(snap.Containers, migration.ContainerInfo{
+			ID:      ct.GetId(),
+			Name:    ct.GetName(),
+			Image:   ct.GetImage(),
+			Status:  ct.GetStatus(),
+			State:   ct.GetState(),
+			Ports:   ct.GetPorts(),
+			Created: ct.GetCreated(),
+		})
+	}
+	return snap
+}
+
+func (h *MigrationHandler) createRemoteBackup(c *fiber.Ctx, serverID string) error {
+	host, err := h.remoteHost(c, serverID)
+	if err != nil {
+		return response.ServerError(c, fiber.StatusInternalServerError, MsgServerNotFound)
+	}
+	resp, err := h.agentClient.CreateMigrationBackup(c.Context(), host, h.agentPort)
+	if err != nil {
+		h.logger.ErrorContext(c.UserContext(), "Failed to create remote backup", "serverId", serverID, "error", err)
+		return response.BadRequest(c, "Failed to create backup")
+	}
+
+	h.logger.InfoContext(c.UserContext(), "Remote backup created", "serverId", serverID, "path", resp.GetPath())
+	return response.OK(c, migration.BackupResult{
+		Path:      resp.GetPath(),
+		CreatedAt: time.Unix(resp.GetCreatedAt(), 0),
+		Files:     resp.GetFiles(),
+		Size:      resp.GetSize(),
+	})
+}
+
+func (h *MigrationHandler) stopRemoteNginx(c *fiber.Ctx, serverID string) error {
+	host, err := h.remoteHost(c, serverID)
+	if err != nil {
+		return response.ServerError(c, fiber.StatusInternalServerError, MsgServerNotFound)
+	}
+	if _, err := h.agentClient.StopNginx(c.Context(), host, h.agentPort); err != nil {
+		h.logger.ErrorContext(c.UserContext(), "Failed to stop remote nginx", "serverId", serverID, "error", err)
+		return response.BadRequest(c, "Failed to stop nginx")
+	}
+
+	h.logger.InfoContext(c.UserContext(), "Remote nginx stopped and disabled", "serverId", serverID)
+	return response.OK(c, fiber.Map{
+		"message": "Nginx stopped and disabled successfully",
+	})
+}
+
+func (h *MigrationHandler) migrateRemoteSite(c *fiber.Ctx, serverID string, site migration.NginxSite, containerID string) error {
+	labels, err := h.service.ContainerLabels(site)
+	if err != nil {
+		return response.BadRequest(c, "Migration failed")
+	}
+	host, err := h.remoteHost(c, serverID)
+	if err != nil {
+		return response.ServerError(c, fiber.StatusInternalServerError, MsgServerNotFound)
+	}
+	resp, err := h.agentClient.MigrateContainer(c.Context(), host, h.agentPort, containerID, labels)
+	if err != nil {
+		h.logger.ErrorContext(c.UserContext(), "Remote migration failed", "serverId", serverID, "error", err)
+		return response.BadRequest(c, "Migration failed")
+	}
+
+	h.logger.InfoContext(c.UserContext(), "Remote migration completed", "serverId", serverID, "site", site.ServerNames[0], "newContainer", resp.GetContainerId())
+	return response.OK(c, migration.MigrateResult{
+		ContainerID:   resp.GetContainerId(),
+		ContainerName: resp.GetContainerName(),
+		Domain:        site.ServerNames[0],
+		Labels:        labels,
+		Success:       true,
+		Message:       "Container migrated successfully with Traefik labels; the original is kept as " + resp.GetBackupName(),
+	})
+}
+
+func (h *MigrationHandler) stopContainers(c *fiber.Ctx, ids []string) error {
+	serverID := c.Query("serverId")
+	if serverID == "" {
+		return h.service.StopContainers(c.Context(), ids)
+	}
+	host, err := h.remoteHost(c, serverID)
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		if err := h.agentClient.StopContainer(c.Context(), host, h.agentPort, id); err != nil {
+			return fmt.Errorf("failed to stop container %s: %w", id, err)
+		}
+	}
+	return nil
+}
+
+func (h *MigrationHandler) startContainers(c *fiber.Ctx, ids []string) error {
+	serverID := c.Query("serverId")
+	if serverID == "" {
+		return h.service.StartContainers(c.Context(), ids)
+	}
+	host, err := h.remoteHost(c, serverID)
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		if err := h.agentClient.StartContainer(c.Context(), host, h.agentPort, id); err != nil {
+			return fmt.Errorf("failed to start container %s: %w", id, err)
+		}
+	}
+	return nil
+}
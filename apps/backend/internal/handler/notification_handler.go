@@ -144,7 +144,7 @@ func (h *NotificationHandler) ListChannels(c *fiber.Ctx) error {
 
 	channels, err := h.channelRepo.FindAllByUserID(user.ID)
 	if err != nil {
-		h.logger.Error("failed to list channels", "error", err)
+		h.logger.ErrorContext(c.UserContext(), "failed to list channels", "error", err)
 		return response.InternalError(c)
 	}
 
@@ -203,7 +203,7 @@ func (h *NotificationHandler) CreateChannel(c *fiber.Ctx) error {
 
 	ch, err := h.channelRepo.Create(input)
 	if err != nil {
-		h.logger.Error("failed to create channel", "error", err)
+		h.logger.ErrorContext(c.UserContext(), "failed to create channel", "error", err)
 		return response.InternalError(c)
 	}
 	return response.Created(c, toChannelResponse(ch))
@@ -250,7 +250,7 @@ func (h *NotificationHandler) UpdateChannel(c *fiber.Ctx) error {
 
 	ch, err := h.channelRepo.Update(id, input)
 	if err != nil {
-		h.logger.Error("failed to update channel", "error", err)
+		h.logger.ErrorContext(c.UserContext(), "failed to update channel", "error", err)
 		return response.InternalError(c)
 	}
 	return response.OK(c, toChannelResponse(ch))
@@ -278,7 +278,7 @@ func (h *NotificationHandler) ListRulesByChannel(c *fiber.Ctx) error {
 
 	rules, err := h.ruleRepo.FindByChannelID(ch.ID)
 	if err != nil {
-		h.logger.Error("failed to list rules", "error", err)
+		h.logger.ErrorContext(c.UserContext(), "failed to list rules", "error", err)
 		return response.InternalError(c)
 	}
 
@@ -297,7 +297,7 @@ func (h *NotificationHandler) ListRules(c *fiber.Ctx) error {
 
 	rules, err := h.ruleRepo.FindAllByUserID(user.ID)
 	if err != nil {
-		h.logger.Error("failed to list rules", "error", err)
+		h.logger.ErrorContext(c.UserContext(), "failed to list rules", "error", err)
 		return response.InternalError(c)
 	}
 
@@ -320,7 +320,12 @@ var validEventTypes = map[string]bool{
 	domain.EventTypeDeploySuccess:    true,
 	domain.EventTypeDeployFailed:     true,
 	domain.EventTypeContainerDown:    true,
+	domain.EventTypeContainerOOM:     true,
+	domain.EventTypeContainerCrashLoop: true,
 	domain.EventTypeHealthUnhealthy:  true,
+	domain.EventTypeServerOffline:    true,
+	domain.EventTypeCertExpiring:     true,
+	domain.EventTypeServerTaskFailed: true,
 }
 
 func (h *NotificationHandler) CreateRule(c *fiber.Ctx) error {
@@ -360,7 +365,7 @@ func (h *NotificationHandler) CreateRule(c *fiber.Ctx) error {
 
 	rule, err := h.ruleRepo.Create(input)
 	if err != nil {
-		h.logger.Error("failed to create rule", "error", err)
+		h.logger.ErrorContext(c.UserContext(), "failed to create rule", "error", err)
 		return response.InternalError(c)
 	}
 	return response.Created(c, toRuleResponse(rule))
@@ -405,7 +410,7 @@ func (h *NotificationHandler) UpdateRule(c *fiber.Ctx) error {
 
 	rule, err := h.ruleRepo.Update(id, input)
 	if err != nil {
-		h.logger.Error("failed to update rule", "error", err)
+		h.logger.ErrorContext(c.UserContext(), "failed to update rule", "error", err)
 		return response.InternalError(c)
 	}
 	return response.OK(c, toRuleResponse(rule))
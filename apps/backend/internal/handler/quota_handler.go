@@ -0,0 +1,40 @@
+package handler
+
+import (
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/paasdeploy/backend/internal/response"
+	"github.com/paasdeploy/backend/internal/service"
+)
+
+type QuotaHandler struct {
+	quotas *service.QuotaService
+	logger *slog.Logger
+}
+
+func NewQuotaHandler(quotas *service.QuotaService, logger *slog.Logger) *QuotaHandler {
+	return &QuotaHandler{
+		quotas: quotas,
+		logger: logger.With("handler", "quota"),
+	}
+}
+
+func (h *QuotaHandler) Register(app fiber.Router) {
+	app.Get(APIPrefix+"/quota", h.Status)
+}
+
+// Status returns the current user's quota limits and usage.
+func (h *QuotaHandler) Status(c *fiber.Ctx) error {
+	user := GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, MsgNotAuthenticated)
+	}
+
+	status, err := h.quotas.Status(c.UserContext(), user.ID)
+	if err != nil {
+		h.logger.ErrorContext(c.UserContext(), "failed to load quota usage", "error", err)
+		return response.InternalError(c)
+	}
+	return response.OK(c, status)
+}
@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/paasdeploy/backend/internal/domain"
+)
+
+type fakeAppWebhookRepo struct {
+	domain.AppWebhookRepository
+}
+
+func (r *fakeAppWebhookRepo) FindByIDAndAppID(string, string) (*domain.AppWebhook, error) {
+	return nil, domain.ErrNotFound
+}
+
+type fakeVerificationRepo struct {
+	domain.DomainVerificationRepository
+	verifications map[string]domain.DomainVerification
+}
+
+func (r *fakeVerificationRepo) FindByID(_ context.Context, id string) (*domain.DomainVerification, error) {
+	v, ok := r.verifications[id]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return &v, nil
+}
+
+func (r *fakeVerificationRepo) ListByUserID(_ context.Context, userID string) ([]domain.DomainVerification, error) {
+	var verifications []domain.DomainVerification
+	for _, v := range r.verifications {
+		if v.UserID == userID {
+			verifications = append(verifications, v)
+		}
+	}
+	return verifications, nil
+}
+
+type fakeExecSessionRepo struct {
+	domain.ExecSessionRepository
+	sessions map[string]domain.ExecSession
+}
+
+func (r *fakeExecSessionRepo) FindByID(id string) (*domain.ExecSession, error) {
+	s, ok := r.sessions[id]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return &s, nil
+}
+
+// TestRequestHelpersSendNotFound checks that the handlers stop after their
+// lookup helpers send a 404, instead of carrying on with a nil resource.
+func TestRequestHelpersSendNotFound(t *testing.T) {
+	servers := &fakeServerRepo{servers: map[string]domain.Server{}}
+	apps := &fakeAppRepo{apps: map[string]string{"app-1": testOwner.ID, "app-2": "someone-else"}}
+
+	app := newTestApp(testOwner)
+	NewAppWebhookHandler(apps, &fakeAppWebhookRepo{}, nil, testLogger()).Register(app)
+	NewServerHandler(servers, nil, nil, nil, ServerHandlerAgentDeps{}, nil, nil, testLogger()).Register(app)
+	NewCertificateHandler(CertificateHandlerConfig{ServerRepo: servers, Logger: testLogger()}).RegisterRoutes(app.Group(APIPrefix))
+	NewDomainHandler(DomainHandlerConfig{
+		AppRepo: apps,
+		DomainRepo: &fakeCustomDomainRepo{domains: map[string]*domain.CustomDomain{
+			"dom-2": {ID: "dom-2", AppID: "app-3"},
+		}},
+		VerifyRepo: &fakeVerificationRepo{verifications: map[string]domain.DomainVerification{
+			"ver-2": {ID: "ver-2", UserID: "someone-else"},
+		}},
+		Logger: testLogger(),
+	}).Register(app)
+	NewContainerExecHandler(ContainerExecHandlerConfig{
+		SessionRepo: &fakeExecSessionRepo{sessions: map[string]domain.ExecSession{
+			"ses-2": {ID: "ses-2", UserID: "someone-else"},
+		}},
+		Logger: testLogger(),
+	}).Register(app)
+
+	tests := []struct {
+		method, path string
+	}{
+		{fiber.MethodGet, "/apps/missing/webhooks"},
+		{fiber.MethodGet, "/apps/app-2/webhooks"},
+		{fiber.MethodDelete, "/apps/app-1/webhooks/missing"},
+		{fiber.MethodPost, "/apps/app-1/webhooks/missing/test"},
+		{fiber.MethodGet, "/servers/missing"},
+		{fiber.MethodGet, "/servers/missing/stats"},
+		{fiber.MethodGet, "/certificates/servers/missing"},
+		{fiber.MethodPost, "/certificates/servers/missing/renew"},
+		{fiber.MethodPut, "/apps/app-2/domains/dom-2/basic-auth"},
+		{fiber.MethodPut, "/apps/app-1/domains/missing/basic-auth"},
+		{fiber.MethodPut, "/apps/app-1/domains/dom-2/ip-allowlist"},
+		{fiber.MethodPut, "/apps/app-1/domains/dom-2/certificate"},
+		{fiber.MethodDelete, "/apps/app-1/domains/missing/certificate"},
+		{fiber.MethodPost, "/domain-verifications/missing/check"},
+		{fiber.MethodDelete, "/domain-verifications/ver-2"},
+		{fiber.MethodGet, "/exec-sessions/missing"},
+		{fiber.MethodGet, "/exec-sessions/ses-2/recording"},
+	}
+	for _, tt := range tests {
+		resp := doRequest(t, app, tt.method, APIPrefix+tt.path, "")
+		if resp.StatusCode != fiber.StatusNotFound {
+			t.Errorf("%s %s: status = %d, want 404", tt.method, tt.path, resp.StatusCode)
+		}
+	}
+}
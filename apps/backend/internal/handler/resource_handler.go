@@ -10,23 +10,28 @@ import (
 	"github.com/paasdeploy/backend/internal/agentclient"
 	"github.com/paasdeploy/backend/internal/domain"
 	"github.com/paasdeploy/backend/internal/response"
+	"github.com/paasdeploy/backend/internal/service"
 	"github.com/paasdeploy/shared/pkg/docker"
 )
 
 type ResourceHandler struct {
-	docker      *docker.Client
-	agentClient *agentclient.AgentClient
-	serverRepo  domain.ServerRepository
-	agentPort   int
-	logger      *slog.Logger
+	docker       *docker.Client
+	agentClient  *agentclient.AgentClient
+	serverRepo   domain.ServerRepository
+	auditService *service.AuditService
+	agentPort    int
+	logger       *slog.Logger
 }
 
 type NetworkResponse struct {
-	Name     string `json:"name"`
-	ID       string `json:"id"`
-	Driver   string `json:"driver"`
-	Scope    string `json:"scope"`
-	Internal bool   `json:"internal"`
+	Name       string                    `json:"name"`
+	ID         string                    `json:"id"`
+	Driver     string                    `json:"driver"`
+	Scope      string                    `json:"scope"`
+	Internal   bool                      `json:"internal"`
+	Subnet     string                    `json:"subnet"`
+	Gateway    string                    `json:"gateway"`
+	Containers []docker.NetworkContainer `json:"containers"`
 }
 
 type VolumeResponse struct {
@@ -36,20 +41,22 @@ type VolumeResponse struct {
 }
 
 type ResourceHandlerConfig struct {
-	Docker      *docker.Client
-	AgentClient *agentclient.AgentClient
-	ServerRepo  domain.ServerRepository
-	AgentPort   int
-	Logger      *slog.Logger
+	Docker       *docker.Client
+	AgentClient  *agentclient.AgentClient
+	ServerRepo   domain.ServerRepository
+	AuditService *service.AuditService
+	AgentPort    int
+	Logger       *slog.Logger
 }
 
 func NewResourceHandler(cfg ResourceHandlerConfig) *ResourceHandler {
 	return &ResourceHandler{
-		docker:      cfg.Docker,
-		agentClient: cfg.AgentClient,
-		serverRepo:  cfg.ServerRepo,
-		agentPort:   cfg.AgentPort,
-		logger:      cfg.Logger,
+		docker:       cfg.Docker,
+		agentClient:  cfg.AgentClient,
+		serverRepo:   cfg.ServerRepo,
+		auditService: cfg.AuditService,
+		agentPort:    cfg.AgentPort,
+		logger:       cfg.Logger,
 	}
 }
 
@@ -72,6 +79,9 @@ func (h *ResourceHandler) Register(app fiber.Router) {
 	v1.Get("/volumes", h.ListVolumes)
 	v1.Post("/volumes", h.CreateVolume)
 	v1.Delete("/volumes/:name", h.RemoveVolume)
+	v1.Get("/volumes/:name/files", h.ListVolumeFiles)
+	v1.Get("/volumes/:name/files/stat", h.StatVolumeFile)
+	v1.Get("/volumes/:name/files/download", h.DownloadVolumeFile)
 }
 
 func (h *ResourceHandler) ListNetworks(c *fiber.Ctx) error {
@@ -88,17 +98,30 @@ func (h *ResourceHandler) ListNetworks(c *fiber.Ctx) error {
 		}
 		networks, err := h.agentClient.ListNetworks(c.Context(), host, h.agentPort)
 		if err != nil {
-			h.logger.Error("failed to list remote networks", "error", err)
+			h.logger.ErrorContext(c.UserContext(), "failed to list remote networks", "error", err)
 			return response.ServerError(c, fiber.StatusInternalServerError, "Failed to list networks")
 		}
 		result := make([]NetworkResponse, 0, len(networks))
 		for _, n := range networks {
+			containers := make([]docker.NetworkContainer, 0, len(n.Containers))
+			for _, c := range n.Containers {
+				containers = append(containers, docker.NetworkContainer{
+					ID:          c.Id,
+					Name:        c.Name,
+					IPv4Address: c.Ipv4Address,
+					IPv6Address: c.Ipv6Address,
+					MacAddress:  c.MacAddress,
+				})
+			}
 			result = append(result, NetworkResponse{
-				Name:     n.Name,
-				ID:       n.Id,
-				Driver:   n.Driver,
-				Scope:    n.Scope,
-				Internal: n.Internal,
+				Name:       n.Name,
+				ID:         n.Id,
+				Driver:     n.Driver,
+				Scope:      n.Scope,
+				Internal:   n.Internal,
+				Subnet:     n.Subnet,
+				Gateway:    n.Gateway,
+				Containers: containers,
 			})
 		}
 		return response.OK(c, result)
@@ -106,17 +129,20 @@ func (h *ResourceHandler) ListNetworks(c *fiber.Ctx) error {
 
 	nets, err := h.docker.ListNetworks(c.Context())
 	if err != nil {
-		h.logger.Error("failed to list networks", "error", err)
+		h.logger.ErrorContext(c.UserContext(), "failed to list networks", "error", err)
 		return response.ServerError(c, fiber.StatusInternalServerError, "Failed to list networks")
 	}
 	result := make([]NetworkResponse, 0, len(nets))
 	for _, n := range nets {
 		result = append(result, NetworkResponse{
-			Name:     n.Name,
-			ID:       n.ID,
-			Driver:   n.Driver,
-			Scope:    n.Scope,
-			Internal: n.Internal,
+			Name:       n.Name,
+			ID:         n.ID,
+			Driver:     n.Driver,
+			Scope:      n.Scope,
+			Internal:   n.Internal,
+			Subnet:     n.Subnet,
+			Gateway:    n.Gateway,
+			Containers: n.Containers,
 		})
 	}
 	return response.OK(c, result)
@@ -142,14 +168,14 @@ func (h *ResourceHandler) CreateNetwork(c *fiber.Ctx) error {
 			return response.ServerError(c, fiber.StatusInternalServerError, MsgServerNotFound)
 		}
 		if err := h.agentClient.CreateNetwork(c.Context(), host, h.agentPort, body.Name); err != nil {
-			h.logger.Error("failed to create remote network", "name", body.Name, "error", err)
+			h.logger.ErrorContext(c.UserContext(), "failed to create remote network", "name", body.Name, "error", err)
 			return response.ServerError(c, fiber.StatusInternalServerError, "Failed to create network")
 		}
 		return response.Created(c, map[string]string{"name": body.Name})
 	}
 
 	if err := h.docker.EnsureNetwork(c.Context(), body.Name); err != nil {
-		h.logger.Error("failed to create network", "name", body.Name, "error", err)
+		h.logger.ErrorContext(c.UserContext(), "failed to create network", "name", body.Name, "error", err)
 		return response.ServerError(c, fiber.StatusInternalServerError, "Failed to create network")
 	}
 	return response.Created(c, map[string]string{"name": body.Name})
@@ -173,14 +199,14 @@ func (h *ResourceHandler) RemoveNetwork(c *fiber.Ctx) error {
 			return response.ServerError(c, fiber.StatusInternalServerError, MsgServerNotFound)
 		}
 		if err := h.agentClient.RemoveNetwork(c.Context(), host, h.agentPort, name); err != nil {
-			h.logger.Error("failed to remove remote network", "name", name, "error", err)
+			h.logger.ErrorContext(c.UserContext(), "failed to remove remote network", "name", name, "error", err)
 			return response.ServerError(c, fiber.StatusInternalServerError, "Failed to remove network")
 		}
 		return response.NoContent(c)
 	}
 
 	if err := h.docker.RemoveNetwork(c.Context(), name); err != nil {
-		h.logger.Error("failed to remove network", "name", name, "error", err)
+		h.logger.ErrorContext(c.UserContext(), "failed to remove network", "name", name, "error", err)
 		return response.ServerError(c, fiber.StatusInternalServerError, "Failed to remove network")
 	}
 	return response.NoContent(c)
@@ -195,11 +221,11 @@ func (h *ResourceHandler) ConnectContainerNetwork(c *fiber.Ctx) error {
 		return response.BadRequest(c, "network is required")
 	}
 	if err := h.docker.EnsureNetwork(c.Context(), body.Network); err != nil {
-		h.logger.Error("failed to ensure network", "network", body.Network, "error", err)
+		h.logger.ErrorContext(c.UserContext(), "failed to ensure network", "network", body.Network, "error", err)
 		return response.ServerError(c, fiber.StatusInternalServerError, "Failed to ensure network")
 	}
 	if err := h.docker.ConnectToNetwork(c.Context(), id, body.Network); err != nil {
-		h.logger.Error("failed to connect container to network", "container", id, "network", body.Network, "error", err)
+		h.logger.ErrorContext(c.UserContext(), "failed to connect container to network", "container", id, "network", body.Network, "error", err)
 		return response.ServerError(c, fiber.StatusInternalServerError, "Failed to connect container to network")
 	}
 	return response.OK(c, map[string]string{"connected": body.Network})
@@ -212,7 +238,7 @@ func (h *ResourceHandler) DisconnectContainerNetwork(c *fiber.Ctx) error {
 		return response.BadRequest(c, "invalid network name")
 	}
 	if err := h.docker.DisconnectFromNetwork(c.Context(), id, name); err != nil {
-		h.logger.Error("failed to disconnect container from network", "container", id, "network", name, "error", err)
+		h.logger.ErrorContext(c.UserContext(), "failed to disconnect container from network", "container", id, "network", name, "error", err)
 		return response.ServerError(c, fiber.StatusInternalServerError, "Failed to disconnect container from network")
 	}
 	return response.NoContent(c)
@@ -232,7 +258,7 @@ func (h *ResourceHandler) ListVolumes(c *fiber.Ctx) error {
 		}
 		volumes, err := h.agentClient.ListVolumes(c.Context(), host, h.agentPort)
 		if err != nil {
-			h.logger.Error("failed to list remote volumes", "error", err)
+			h.logger.ErrorContext(c.UserContext(), "failed to list remote volumes", "error", err)
 			return response.ServerError(c, fiber.StatusInternalServerError, "Failed to list volumes")
 		}
 		result := make([]VolumeResponse, 0, len(volumes))
@@ -248,7 +274,7 @@ func (h *ResourceHandler) ListVolumes(c *fiber.Ctx) error {
 
 	vols, err := h.docker.ListVolumes(c.Context())
 	if err != nil {
-		h.logger.Error("failed to list volumes", "error", err)
+		h.logger.ErrorContext(c.UserContext(), "failed to list volumes", "error", err)
 		return response.ServerError(c, fiber.StatusInternalServerError, "Failed to list volumes")
 	}
 	result := make([]VolumeResponse, 0, len(vols))
@@ -282,14 +308,14 @@ func (h *ResourceHandler) CreateVolume(c *fiber.Ctx) error {
 			return response.ServerError(c, fiber.StatusInternalServerError, MsgServerNotFound)
 		}
 		if err := h.agentClient.CreateVolume(c.Context(), host, h.agentPort, body.Name); err != nil {
-			h.logger.Error("failed to create remote volume", "name", body.Name, "error", err)
+			h.logger.ErrorContext(c.UserContext(), "failed to create remote volume", "name", body.Name, "error", err)
 			return response.ServerError(c, fiber.StatusInternalServerError, "Failed to create volume")
 		}
 		return response.Created(c, map[string]string{"name": body.Name})
 	}
 
 	if err := h.docker.CreateVolume(c.Context(), body.Name); err != nil {
-		h.logger.Error("failed to create volume", "name", body.Name, "error", err)
+		h.logger.ErrorContext(c.UserContext(), "failed to create volume", "name", body.Name, "error", err)
 		return response.ServerError(c, fiber.StatusInternalServerError, "Failed to create volume")
 	}
 	return response.Created(c, map[string]string{"name": body.Name})
@@ -313,14 +339,14 @@ func (h *ResourceHandler) RemoveVolume(c *fiber.Ctx) error {
 			return response.ServerError(c, fiber.StatusInternalServerError, MsgServerNotFound)
 		}
 		if err := h.agentClient.RemoveVolume(c.Context(), host, h.agentPort, name); err != nil {
-			h.logger.Error("failed to remove remote volume", "name", name, "error", err)
+			h.logger.ErrorContext(c.UserContext(), "failed to remove remote volume", "name", name, "error", err)
 			return response.ServerError(c, fiber.StatusInternalServerError, "Failed to remove volume")
 		}
 		return response.NoContent(c)
 	}
 
 	if err := h.docker.RemoveVolume(c.Context(), name); err != nil {
-		h.logger.Error("failed to remove volume", "name", name, "error", err)
+		h.logger.ErrorContext(c.UserContext(), "failed to remove volume", "name", name, "error", err)
 		return response.ServerError(c, fiber.StatusInternalServerError, "Failed to remove volume")
 	}
 	return response.NoContent(c)
@@ -0,0 +1,162 @@
+package handler
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/paasdeploy/backend/internal/domain"
+	"github.com/paasdeploy/backend/internal/response"
+	"github.com/paasdeploy/backend/internal/service"
+)
+
+const (
+	defaultSearchLimit = 10
+	maxSearchLimit     = 50
+	// searchAgentTimeout bounds how long a slow agent can hold up the
+	// container results.
+	searchAgentTimeout = 3 * time.Second
+)
+
+type SearchHandler struct {
+	searchService *service.SearchService
+	containers    *ContainerHandler
+	serverRepo    domain.ServerRepository
+	logger        *slog.Logger
+}
+
+func NewSearchHandler(
+	searchService *service.SearchService,
+	containers *ContainerHandler,
+	serverRepo domain.ServerRepository,
+	logger *slog.Logger,
+) *SearchHandler {
+	return &SearchHandler{
+		searchService: searchService,
+		containers:    containers,
+		serverRepo:    serverRepo,
+		logger:        logger.With("handler", "search"),
+	}
+}
+
+func (h *SearchHandler) Register(app fiber.Router) {
+	app.Get(APIPrefix+"/search", h.Search)
+}
+
+// Search matches q against apps, deployments (commit SHA or message),
+// servers, domains and containers. limit caps the results of each type.
+func (h *SearchHandler) Search(c *fiber.Ctx) error {
+	user := GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, MsgNotAuthenticated)
+	}
+
+	query := strings.TrimSpace(c.Query("q"))
+	if query == "" {
+		return response.BadRequest(c, "q is required")
+	}
+	limit := c.QueryInt("limit", defaultSearchLimit)
+	if limit < 1 {
+		limit = defaultSearchLimit
+	}
+	limit = min(limit, maxSearchLimit)
+
+	results, err := h.searchService.Search(c.Context(), user.ID, query, limit)
+	if err != nil {
+		h.logger.ErrorContext(c.UserContext(), "Failed to search", "error", err)
+		return response.InternalError(c)
+	}
+	results = append(results, h.searchContainers(c.Context(), user, query, limit)...)
+	if results == nil {
+		results = []domain.SearchResult{}
+	}
+
+	return response.OK(c, results)
+}
+
+// searchContainers matches container names and images on the local Docker,
+// for admins, and on the user's online servers. Servers that fail to answer
+// are skipped.
+func (h *SearchHandler) searchContainers(ctx context.Context, user *domain.User, query string, limit int) []domain.SearchResult {
+	if h.containers == nil {
+		return nil
+	}
+	opts := domain.ListOptions{Query: query, Limit: limit, SortBy: "name"}
+
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		results []domain.SearchResult
+	)
+	add := func(containers []ContainerResponse, serverID string) {
+		page := pageContainers(containers, "", opts)
+		mu.Lock()
+		defer mu.Unlock()
+		for _, ct := range page.Items {
+			results = append(results, domain.SearchResult{
+				Type:     domain.SearchResultContainer,
+				ID:       ct.ID,
+				Title:    ct.Name,
+				Subtitle: ct.Image,
+				ServerID: serverID,
+			})
+		}
+	}
+
+	if user.IsAdmin() && h.containers.docker != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			containers, err := h.containers.docker.ListContainers(ctx, true)
+			if err != nil {
+				h.logger.Warn("Failed to search local containers", "error", err)
+				return
+			}
+			local := make([]ContainerResponse, len(containers))
+			for i, container := range containers {
+				local[i] = h.containers.toContainerResponse(container)
+			}
+			add(local, "")
+		}()
+	}
+
+	if h.containers.agentClient != nil {
+		servers, err := h.serverRepo.FindAllByUserID(user.ID)
+		if err != nil {
+			h.logger.Warn("Failed to list servers for container search", "error", err)
+		}
+		for i := range servers {
+			if servers[i].Status != domain.ServerStatusOnline {
+				continue
+			}
+			wg.Add(1)
+			go func(server *domain.Server) {
+				defer wg.Done()
+				agentCtx, cancel := context.WithTimeout(ctx, searchAgentTimeout)
+				defer cancel()
+				containers, err := h.containers.remoteContainers(agentCtx, server.Host, true)
+				if err != nil {
+					h.logger.Debug("Failed to search remote containers", "serverId", server.ID, "error", err)
+					return
+				}
+				add(containers, server.ID)
+			}(&servers[i])
+		}
+	}
+
+	wg.Wait()
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Title != results[j].Title {
+			return results[i].Title < results[j].Title
+		}
+		return results[i].ServerID < results[j].ServerID
+	})
+	if len(results) > limit {
+		results = results[:limit]
+	}
+	return results
+}
@@ -0,0 +1,46 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/paasdeploy/backend/internal/domain"
+	"github.com/paasdeploy/backend/internal/response"
+)
+
+var errInvalidBastion = errors.New("bastionServerId must reference another of your servers that is not itself behind a bastion")
+
+func hasBastion(id *string) bool {
+	return id != nil && *id != ""
+}
+
+// validateBastion checks that the bastion is another server of the same user.
+// Only one hop is supported, so the bastion cannot have a bastion itself.
+func (h *ServerHandler) validateBastion(userID, serverID string, bastionID *string) error {
+	if !hasBastion(bastionID) {
+		return nil
+	}
+	if *bastionID == serverID {
+		return errInvalidBastion
+	}
+	bastion, err := h.serverRepo.FindByIDForUser(*bastionID, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return errInvalidBastion
+		}
+		return err
+	}
+	if hasBastion(bastion.BastionServerID) {
+		return errInvalidBastion
+	}
+	return nil
+}
+
+func (h *ServerHandler) bastionError(c *fiber.Ctx, err error) error {
+	if errors.Is(err, errInvalidBastion) {
+		return response.BadRequest(c, err.Error())
+	}
+	h.logger.ErrorContext(c.UserContext(), "failed to validate bastion", "error", err)
+	return response.InternalError(c)
+}
@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/paasdeploy/backend/internal/crypto"
+	"github.com/paasdeploy/backend/internal/domain"
+	"github.com/paasdeploy/backend/internal/provisioner"
+	"github.com/paasdeploy/backend/internal/response"
+)
+
+type BootstrapScriptResponse struct {
+	Script    string `json:"script"`
+	ExpiresAt string `json:"expiresAt"`
+}
+
+// GenerateBootstrapScript issues a one-shot token for the server and returns
+// an install script that can be passed as cloud-init user-data, so the server
+// provisions itself at boot without the backend connecting over SSH.
+func (h *ServerHandler) GenerateBootstrapScript(c *fiber.Ctx) error {
+	server, _, ok, err := h.requireServerForUser(c)
+	if !ok {
+		return err
+	}
+	if h.bootstrapTokenRepo == nil || h.provisioner == nil || h.apiBaseURL == "" {
+		return response.ServerError(c, fiber.StatusServiceUnavailable, "bootstrap scripts require API_BASE_URL and gRPC to be configured")
+	}
+
+	ttl := domain.DefaultBootstrapTokenTTL
+	if raw := c.Query("ttl"); raw != "" {
+		parsed, err := time.ParseDuration(raw)
+		if err != nil || parsed <= 0 || parsed > domain.MaxBootstrapTokenTTL {
+			return response.BadRequest(c, "ttl must be a positive duration up to 168h")
+		}
+		ttl = parsed
+	}
+
+	token, err := crypto.GenerateSessionToken()
+	if err != nil {
+		h.logger.ErrorContext(c.UserContext(), "failed to generate bootstrap token", "serverId", server.ID, "error", err)
+		return response.InternalError(c)
+	}
+	expiresAt := time.Now().Add(ttl)
+	if err := h.bootstrapTokenRepo.Create(server.ID, crypto.HashSessionToken(token), expiresAt); err != nil {
+		h.logger.ErrorContext(c.UserContext(), "failed to store bootstrap token", "serverId", server.ID, "error", err)
+		return response.InternalError(c)
+	}
+
+	script := h.provisioner.BootstrapScript(provisioner.BootstrapScriptInput{
+		ServerID:   server.ID,
+		APIBaseURL: h.apiBaseURL,
+		Token:      token,
+		ExpiresAt:  expiresAt,
+	})
+
+	h.logger.InfoContext(c.UserContext(), "bootstrap script generated", "serverId", server.ID, "expiresAt", expiresAt)
+	return response.OK(c, BootstrapScriptResponse{
+		Script:    script,
+		ExpiresAt: expiresAt.UTC().Format(DateTimeFormatISO8601),
+	})
+}
@@ -0,0 +1,412 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"github.com/paasdeploy/backend/internal/domain"
+	"github.com/paasdeploy/backend/internal/response"
+)
+
+const (
+	maxBulkServers                  = 100
+	defaultBulkProvisionConcurrency = 4
+	maxBulkProvisionConcurrency     = 16
+	provisionBatchRetention         = 24 * time.Hour
+
+	ProvisionBatchRunning   = "running"
+	ProvisionBatchCompleted = "completed"
+)
+
+type BulkCreateServersRequest struct {
+	Servers     []CreateServerRequest `json:"servers"`
+	Provision   bool                  `json:"provision"`
+	Concurrency int                   `json:"concurrency,omitempty"`
+}
+
+type BulkProvisionRequest struct {
+	ServerIDs   []string `json:"serverIds"`
+	Concurrency int      `json:"concurrency,omitempty"`
+}
+
+type BulkServerResult struct {
+	Index    int    `json:"index"`
+	Name     string `json:"name"`
+	Host     string `json:"host"`
+	ServerID string `json:"serverId,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+type BulkCreateServersResponse struct {
+	Results []BulkServerResult `json:"results"`
+	Created int                `json:"created"`
+	Failed  int                `json:"failed"`
+	Batch   *ProvisionBatch    `json:"batch,omitempty"`
+}
+
+type ProvisionFailure struct {
+	ServerID string `json:"serverId"`
+	Name     string `json:"name"`
+	Host     string `json:"host"`
+	Error    string `json:"error"`
+}
+
+// ProvisionBatch summarizes a bulk provisioning run. Progress for each server
+// is still streamed through the regular PROVISION_* events.
+type ProvisionBatch struct {
+	ID         string             `json:"id"`
+	Status     string             `json:"status"`
+	Total      int                `json:"total"`
+	Completed  int                `json:"completed"`
+	Failed     int                `json:"failed"`
+	Failures   []ProvisionFailure `json:"failures"`
+	StartedAt  time.Time          `json:"startedAt"`
+	FinishedAt *time.Time         `json:"finishedAt,omitempty"`
+	userID     string
+}
+
+type provisionBatchStore struct {
+	mu      sync.Mutex
+	batches map[string]*ProvisionBatch
+}
+
+func newProvisionBatchStore() *provisionBatchStore {
+	return &provisionBatchStore{batches: make(map[string]*ProvisionBatch)}
+}
+
+// add registers a batch and drops finished batches past their retention.
+func (s *provisionBatchStore) add(batch *ProvisionBatch) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id, b := range s.batches {
+		if b.FinishedAt != nil && time.Since(*b.FinishedAt) > provisionBatchRetention {
+			delete(s.batches, id)
+		}
+	}
+	s.batches[batch.ID] = batch
+}
+
+func (s *provisionBatchStore) get(id, userID string) (ProvisionBatch, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b, ok := s.batches[id]
+	if !ok || b.userID != userID {
+		return ProvisionBatch{}, false
+	}
+	return b.snapshot(), true
+}
+
+// record applies the outcome of one server and returns a copy that is safe
+// to serialize while other workers keep updating the batch.
+func (s *provisionBatchStore) record(batch *ProvisionBatch, failure *ProvisionFailure) ProvisionBatch {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if failure != nil {
+		batch.Failed++
+		batch.Failures = append(batch.Failures, *failure)
+	} else {
+		batch.Completed++
+	}
+	return batch.snapshot()
+}
+
+func (s *provisionBatchStore) finish(batch *ProvisionBatch) ProvisionBatch {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	batch.Status = ProvisionBatchCompleted
+	batch.FinishedAt = &now
+	return batch.snapshot()
+}
+
+func (b *ProvisionBatch) snapshot() ProvisionBatch {
+	cp := *b
+	cp.Failures = append([]ProvisionFailure{}, b.Failures...)
+	return cp
+}
+
+func clampConcurrency(n int) int {
+	if n <= 0 {
+		return defaultBulkProvisionConcurrency
+	}
+	return min(n, maxBulkProvisionConcurrency)
+}
+
+// normalizeCSVHeader lets CSV files use camelCase, snake_case or spaced
+// column names interchangeably.
+func normalizeCSVHeader(h string) string {
+	h = strings.ToLower(strings.TrimSpace(h))
+	return strings.NewReplacer("_", "", "-", "", " ", "").Replace(h)
+}
+
+// parseServersCSV reads one server per row. The header row selects columns
+// by name; unknown columns are ignored.
+func parseServersCSV(data []byte) ([]CreateServerRequest, error) {
+	r := csv.NewReader(bytes.NewReader(data))
+	r.TrimLeadingSpace = true
+	header, err := r.Read()
+	if err != nil {
+		return nil, fmt.Errorf("read csv header: %w", err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, h := range header {
+		columns[normalizeCSVHeader(h)] = i
+	}
+	for _, required := range []string{"name", "host", "sshuser"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("csv is missing the %q column", required)
+		}
+	}
+
+	var servers []CreateServerRequest
+	for line := 2; ; line++ {
+		record, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read csv line %d: %w", line, err)
+		}
+		field := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		req := CreateServerRequest{
+			Name:               field("name"),
+			Host:               field("host"),
+			SSHUser:            field("sshuser"),
+			SSHKey:             field("sshkey"),
+			SSHPassword:        field("sshpassword"),
+			AgentInstallMethod: field("agentinstallmethod"),
+		}
+		if port := field("sshport"); port != "" {
+			if req.SSHPort, err = strconv.Atoi(port); err != nil {
+				return nil, fmt.Errorf("csv line %d: invalid sshPort %q", line, port)
+			}
+		}
+		if email := field("acmeemail"); email != "" {
+			req.AcmeEmail = &email
+		}
+		servers = append(servers, req)
+	}
+	return servers, nil
+}
+
+func (h *ServerHandler) parseBulkCreateRequest(c *fiber.Ctx) (*BulkCreateServersRequest, error) {
+	if !strings.HasPrefix(c.Get(fiber.HeaderContentType), "text/csv") {
+		var req BulkCreateServersRequest
+		if err := c.BodyParser(&req); err != nil {
+			return nil, errors.New(MsgInvalidRequestBody)
+		}
+		return &req, nil
+	}
+
+	servers, err := parseServersCSV(c.Body())
+	if err != nil {
+		return nil, err
+	}
+	return &BulkCreateServersRequest{
+		Servers:     servers,
+		Provision:   c.QueryBool("provision"),
+		Concurrency: c.QueryInt("concurrency"),
+	}, nil
+}
+
+// BulkCreate adds several servers from a JSON list or a CSV upload. Invalid
+// entries are reported per row without aborting the rest. When provision is
+// set, the created servers are provisioned in the background as one batch.
+func (h *ServerHandler) BulkCreate(c *fiber.Ctx) error {
+	user := GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, MsgNotAuthenticated)
+	}
+
+	req, err := h.parseBulkCreateRequest(c)
+	if err != nil {
+		return response.BadRequest(c, err.Error())
+	}
+	if len(req.Servers) == 0 {
+		return response.BadRequest(c, "no servers given")
+	}
+	if len(req.Servers) > maxBulkServers {
+		return response.BadRequest(c, fmt.Sprintf("at most %d servers can be added at once", maxBulkServers))
+	}
+	if req.Provision && h.provisioner == nil {
+		return response.BadRequest(c, "provisioning not available: GRPC and PKI must be configured")
+	}
+
+	resp := BulkCreateServersResponse{Results: make([]BulkServerResult, 0, len(req.Servers))}
+	var created []*domain.Server
+	for i := range req.Servers {
+		entry := &req.Servers[i]
+		result := BulkServerResult{Index: i, Name: entry.Name, Host: entry.Host}
+		server, err := h.bulkCreateServer(user.ID, entry)
+		if err != nil {
+			result.Error = err.Error()
+			resp.Failed++
+		} else {
+			result.ServerID = server.ID
+			created = append(created, server)
+			resp.Created++
+		}
+		resp.Results = append(resp.Results, result)
+	}
+
+	if req.Provision && len(created) > 0 {
+		batch := h.startProvisionBatch(user.ID, created, req.Concurrency)
+		resp.Batch = &batch
+	}
+	if h.sseHandler != nil && resp.Created > 0 {
+		h.sseHandler.EmitInvalidate("servers")
+	}
+	return response.Created(c, resp)
+}
+
+func (h *ServerHandler) bulkCreateServer(userID string, req *CreateServerRequest) (*domain.Server, error) {
+	if err := validateCreateServerRequest(req); err != nil {
+		return nil, err
+	}
+	if err := h.validateBastion(userID, "", req.BastionServerID); err != nil {
+		if errors.Is(err, errInvalidBastion) {
+			return nil, err
+		}
+		return nil, errors.New("failed to validate bastion")
+	}
+	if err := h.quotas.CheckCreateServer(context.Background(), userID); err != nil {
+		if errors.Is(err, domain.ErrQuotaExceeded) {
+			return nil, err
+		}
+		return nil, errors.New("failed to check quota")
+	}
+	server, err := h.createServer(userID, req)
+	switch {
+	case errors.Is(err, domain.ErrAlreadyExists):
+		return nil, errors.New("server already exists")
+	case err != nil:
+		return nil, errors.New("failed to create server")
+	}
+	return server, nil
+}
+
+// BulkProvision provisions existing servers concurrently and returns the
+// batch immediately; its progress is streamed over SSE.
+func (h *ServerHandler) BulkProvision(c *fiber.Ctx) error {
+	user := GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, MsgNotAuthenticated)
+	}
+	if h.provisioner == nil {
+		return response.BadRequest(c, "provisioning not available: GRPC and PKI must be configured")
+	}
+
+	var req BulkProvisionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.BadRequest(c, MsgInvalidRequestBody)
+	}
+	if len(req.ServerIDs) == 0 {
+		return response.BadRequest(c, "serverIds is required")
+	}
+	if len(req.ServerIDs) > maxBulkServers {
+		return response.BadRequest(c, fmt.Sprintf("at most %d servers can be provisioned at once", maxBulkServers))
+	}
+
+	servers := make([]*domain.Server, 0, len(req.ServerIDs))
+	seen := make(map[string]bool, len(req.ServerIDs))
+	for _, id := range req.ServerIDs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		server, err := h.serverRepo.FindByIDForUser(id, user.ID)
+		if err != nil {
+			return HandleNotFoundOrInternal(c, err, fmt.Sprintf("server %s not found", id))
+		}
+		servers = append(servers, server)
+	}
+
+	return response.Accepted(c, h.startProvisionBatch(user.ID, servers, req.Concurrency))
+}
+
+func (h *ServerHandler) GetProvisionBatch(c *fiber.Ctx) error {
+	user := GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, MsgNotAuthenticated)
+	}
+	batch, ok := h.provisionBatches.get(c.Params("batchId"), user.ID)
+	if !ok {
+		return response.NotFound(c, "provision batch not found")
+	}
+	return response.OK(c, batch)
+}
+
+// startProvisionBatch provisions the servers with at most concurrency SSH
+// sessions at a time and returns the initial batch state.
+func (h *ServerHandler) startProvisionBatch(userID string, servers []*domain.Server, concurrency int) ProvisionBatch {
+	batch := &ProvisionBatch{
+		ID:        uuid.NewString(),
+		Status:    ProvisionBatchRunning,
+		Total:     len(servers),
+		Failures:  []ProvisionFailure{},
+		StartedAt: time.Now(),
+		userID:    userID,
+	}
+	h.provisionBatches.add(batch)
+	initial := batch.snapshot()
+
+	go func() {
+		sem := make(chan struct{}, clampConcurrency(concurrency))
+		var wg sync.WaitGroup
+		for _, server := range servers {
+			wg.Add(1)
+			sem <- struct{}{}
+			go func(server *domain.Server) {
+				defer wg.Done()
+				defer func() { <-sem }()
+
+				var failure *ProvisionFailure
+				if err := h.provisionForBatch(server); err != nil {
+					h.logger.Error(msgProvisionFailed, "serverId", server.ID, "batchId", batch.ID, "error", err)
+					failure = &ProvisionFailure{ServerID: server.ID, Name: server.Name, Host: server.Host, Error: err.Error()}
+				}
+				progress := h.provisionBatches.record(batch, failure)
+				if h.sseHandler != nil {
+					h.sseHandler.EmitProvisionBatchProgress(progress)
+				}
+			}(server)
+		}
+		wg.Wait()
+
+		summary := h.provisionBatches.finish(batch)
+		h.logger.Info("provision batch finished", "batchId", summary.ID, "total", summary.Total, "failed", summary.Failed)
+		if h.sseHandler != nil {
+			h.sseHandler.EmitProvisionBatchProgress(summary)
+		}
+	}()
+
+	return initial
+}
+
+func (h *ServerHandler) provisionForBatch(server *domain.Server) error {
+	sshKey, sshPassword, err := h.decryptProvisionCredentials(server)
+	if err != nil {
+		return errors.New("failed to decrypt ssh credentials")
+	}
+	if sshKey == "" && sshPassword == "" {
+		return errors.New("server has no ssh credentials")
+	}
+	return h.runProvision(server, sshKey, sshPassword)
+}
@@ -0,0 +1,335 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/paasdeploy/backend/internal/cloud"
+	"github.com/paasdeploy/backend/internal/crypto"
+	"github.com/paasdeploy/backend/internal/domain"
+	"github.com/paasdeploy/backend/internal/response"
+)
+
+const (
+	cloudBootTimeout      = 10 * time.Minute
+	cloudBootPollInterval = 5 * time.Second
+	cloudSSHWaitTimeout   = 5 * time.Minute
+	cloudRequestTimeout   = 60 * time.Second
+	cloudSSHPort          = 22
+	msgCloudUnavailable   = "cloud servers require provisioning (GRPC and PKI) to be configured"
+)
+
+type CloudCredentialResponse struct {
+	Provider  string `json:"provider"`
+	CreatedAt string `json:"createdAt"`
+	UpdatedAt string `json:"updatedAt"`
+}
+
+type SaveCloudCredentialRequest struct {
+	Credentials string `json:"credentials"`
+}
+
+type CreateCloudServerRequest struct {
+	Provider           string  `json:"provider"`
+	Name               string  `json:"name"`
+	Size               string  `json:"size,omitempty"`
+	Region             string  `json:"region,omitempty"`
+	Image              string  `json:"image,omitempty"`
+	AcmeEmail          *string `json:"acmeEmail,omitempty"`
+	AgentInstallMethod string  `json:"agentInstallMethod,omitempty"`
+	FirewallEnabled    bool    `json:"firewallEnabled,omitempty"`
+	SSHHardening       bool    `json:"sshHardening,omitempty"`
+}
+
+func toCloudCredentialResponse(cred *domain.CloudCredential) CloudCredentialResponse {
+	return CloudCredentialResponse{
+		Provider:  cred.Provider,
+		CreatedAt: cred.CreatedAt.Format(DateTimeFormatISO8601),
+		UpdatedAt: cred.UpdatedAt.Format(DateTimeFormatISO8601),
+	}
+}
+
+func (h *ServerHandler) ListCloudCredentials(c *fiber.Ctx) error {
+	user := GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, MsgNotAuthenticated)
+	}
+	if h.cloudCredentialRepo == nil {
+		return response.OK(c, []CloudCredentialResponse{})
+	}
+
+	creds, err := h.cloudCredentialRepo.ListByUserID(user.ID)
+	if err != nil {
+		h.logger.ErrorContext(c.UserContext(), "failed to list cloud credentials", "error", err)
+		return response.InternalError(c)
+	}
+	resp := make([]CloudCredentialResponse, len(creds))
+	for i := range creds {
+		resp[i] = toCloudCredentialResponse(&creds[i])
+	}
+	return response.OK(c, resp)
+}
+
+// SaveCloudCredential verifies the credential against the provider API
+// before storing it encrypted, replacing any previous one.
+func (h *ServerHandler) SaveCloudCredential(c *fiber.Ctx) error {
+	user := GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, MsgNotAuthenticated)
+	}
+	if h.cloudCredentialRepo == nil || h.tokenEncryptor == nil {
+		return response.ServerError(c, fiber.StatusServiceUnavailable, "cloud credentials require an encryption key to be configured")
+	}
+
+	provider := c.Params("provider")
+	if !domain.IsValidCloudProvider(provider) {
+		return response.BadRequest(c, "unsupported cloud provider")
+	}
+	var req SaveCloudCredentialRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.BadRequest(c, MsgInvalidRequestBody)
+	}
+	credentials := strings.TrimSpace(req.Credentials)
+	if credentials == "" {
+		return response.BadRequest(c, "credentials are required")
+	}
+
+	client, err := cloud.NewProvider(provider, credentials, h.logger)
+	if err != nil {
+		return response.BadRequest(c, err.Error())
+	}
+	ctx, cancel := context.WithTimeout(c.Context(), cloudRequestTimeout)
+	defer cancel()
+	if err := client.VerifyCredentials(ctx); err != nil {
+		h.logger.WarnContext(c.UserContext(), "invalid cloud credentials", "provider", provider, "userId", user.ID, "error", err)
+		return response.BadRequest(c, "invalid credentials: "+err.Error())
+	}
+
+	encrypted, err := h.tokenEncryptor.Encrypt(credentials)
+	if err != nil {
+		h.logger.ErrorContext(c.UserContext(), "failed to encrypt cloud credentials", "error", err)
+		return response.InternalError(c)
+	}
+	cred, err := h.cloudCredentialRepo.Upsert(user.ID, provider, encrypted)
+	if err != nil {
+		h.logger.ErrorContext(c.UserContext(), "failed to save cloud credentials", "provider", provider, "error", err)
+		return response.InternalError(c)
+	}
+
+	h.logger.InfoContext(c.UserContext(), "cloud credentials saved", "provider", provider, "userId", user.ID)
+	return response.OK(c, toCloudCredentialResponse(cred))
+}
+
+func (h *ServerHandler) DeleteCloudCredential(c *fiber.Ctx) error {
+	user := GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, MsgNotAuthenticated)
+	}
+	if h.cloudCredentialRepo == nil {
+		return response.NotFound(c, "cloud credentials not found")
+	}
+	if err := h.cloudCredentialRepo.Delete(user.ID, c.Params("provider")); err != nil {
+		return HandleNotFoundOrInternal(c, err, "cloud credentials not found")
+	}
+	return response.NoContent(c)
+}
+
+func (h *ServerHandler) cloudProviderForUser(userID, provider string) (cloud.Provider, error) {
+	cred, err := h.cloudCredentialRepo.FindByUserAndProvider(userID, provider)
+	if err != nil {
+		return nil, err
+	}
+	credentials, err := h.tokenEncryptor.Decrypt(cred.CredentialsEncrypted)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt cloud credentials: %w", err)
+	}
+	return cloud.NewProvider(provider, credentials, h.logger)
+}
+
+// CreateCloudServer creates a VM at the provider with a managed SSH key,
+// records it as a server and provisions it in the background once it has
+// booted. Progress is streamed through the regular PROVISION_* events.
+func (h *ServerHandler) CreateCloudServer(c *fiber.Ctx) error {
+	user := GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, MsgNotAuthenticated)
+	}
+	if h.provisioner == nil || h.cloudCredentialRepo == nil || h.tokenEncryptor == nil {
+		return response.BadRequest(c, msgCloudUnavailable)
+	}
+
+	var req CreateCloudServerRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.BadRequest(c, MsgInvalidRequestBody)
+	}
+	if req.Name == "" {
+		return response.BadRequest(c, "name is required")
+	}
+	if !domain.IsValidCloudProvider(req.Provider) {
+		return response.BadRequest(c, "unsupported cloud provider")
+	}
+	if err := validateAcmeEmail(req.AcmeEmail); err != nil {
+		return response.BadRequest(c, "invalid ACME email format")
+	}
+	if err := h.quotas.CheckCreateServer(c.UserContext(), user.ID); err != nil {
+		return HandleDomainError(c, err)
+	}
+
+	provider, err := h.cloudProviderForUser(user.ID, req.Provider)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return response.BadRequest(c, fmt.Sprintf("no %s credentials saved", req.Provider))
+		}
+		h.logger.ErrorContext(c.UserContext(), "failed to load cloud credentials", "provider", req.Provider, "error", err)
+		return response.InternalError(c)
+	}
+
+	sshKey, sshPublicKey, err := crypto.GenerateSSHKeyPair(managedKeyComment(req.Name))
+	if err != nil {
+		h.logger.ErrorContext(c.UserContext(), "failed to generate ssh key pair", "error", err)
+		return response.InternalError(c)
+	}
+
+	ctx, cancel := context.WithTimeout(c.Context(), cloudRequestTimeout)
+	defer cancel()
+	instance, err := provider.CreateInstance(ctx, cloud.CreateInstanceInput{
+		Name:         req.Name,
+		Size:         req.Size,
+		Region:       req.Region,
+		Image:        req.Image,
+		SSHPublicKey: sshPublicKey,
+		OpenPorts:    h.cloudOpenPorts(),
+	})
+	if err != nil {
+		h.logger.ErrorContext(c.UserContext(), "failed to create cloud instance", "provider", req.Provider, "error", err)
+		return response.ServerError(c, fiber.StatusBadGateway, err.Error())
+	}
+
+	sshKeyEncrypted, err := encryptCredential(h.tokenEncryptor, sshKey)
+	if err != nil {
+		h.logger.ErrorContext(c.UserContext(), "failed to encrypt ssh key", "error", err)
+		return response.InternalError(c)
+	}
+	server, err := h.serverRepo.Create(domain.CreateServerInput{
+		UserID:             user.ID,
+		Name:               req.Name,
+		Host:               instance.PublicIP,
+		SSHPort:            cloudSSHPort,
+		SSHUser:            provider.SSHUser(),
+		SSHKeyEncrypted:    sshKeyEncrypted,
+		SSHPublicKey:       sshPublicKey,
+		AcmeEmail:          req.AcmeEmail,
+		AgentInstallMethod: req.AgentInstallMethod,
+		FirewallEnabled:    req.FirewallEnabled,
+		SSHHardening:       req.SSHHardening,
+		CloudProvider:      provider.Name(),
+		CloudInstanceID:    instance.ID,
+	})
+	if err != nil {
+		h.logger.ErrorContext(c.UserContext(), "failed to record cloud server", "provider", req.Provider, "instanceId", instance.ID, "error", err)
+		return response.InternalError(c)
+	}
+
+	h.logger.InfoContext(c.UserContext(), "cloud server created", "serverId", server.ID, "provider", req.Provider, "instanceId", instance.ID)
+	go h.bootCloudServer(provider, server, sshKey)
+	return response.Accepted(c, toServerResponse(server))
+}
+
+// cloudOpenPorts lists the ports provisioning and deploys need from outside.
+func (h *ServerHandler) cloudOpenPorts() []int {
+	ports := []int{cloudSSHPort, 80, 443}
+	if h.agentPort != 0 {
+		ports = append(ports, h.agentPort)
+	}
+	return ports
+}
+
+func (h *ServerHandler) bootCloudServer(provider cloud.Provider, server *domain.Server, sshKey string) {
+	step := func(status, message string) {
+		if h.sseHandler != nil {
+			h.sseHandler.EmitProvisionStep(server.ID, "cloud_instance", status, message)
+		}
+	}
+	logLine := func(message string) {
+		if h.sseHandler != nil {
+			h.sseHandler.EmitProvisionLog(server.ID, message)
+		}
+	}
+	fail := func(err error) {
+		h.logger.Error("cloud server boot failed", "serverId", server.ID, "error", err)
+		if h.sseHandler != nil {
+			h.sseHandler.EmitProvisionFailed(server.ID, err.Error())
+		}
+		errStatus := domain.ServerStatusError
+		_, _ = h.serverRepo.Update(server.ID, domain.UpdateServerInput{Status: &errStatus})
+	}
+
+	step("running", "Aguardando a instância iniciar...")
+	logLine(fmt.Sprintf("Aguardando a instância %s (%s) iniciar", server.CloudInstanceID, provider.Name()))
+	instance, err := waitForInstance(provider, server.CloudInstanceID)
+	if err != nil {
+		fail(err)
+		return
+	}
+	if instance.PublicIP != server.Host {
+		host := instance.PublicIP
+		updated, err := h.serverRepo.Update(server.ID, domain.UpdateServerInput{Host: &host})
+		if err != nil {
+			fail(fmt.Errorf("update server host: %w", err))
+			return
+		}
+		server = updated
+	}
+
+	addr := net.JoinHostPort(server.Host, fmt.Sprintf("%d", cloudSSHPort))
+	logLine(fmt.Sprintf("Instância em execução, aguardando SSH em %s", addr))
+	if err := waitForPort(addr, cloudSSHWaitTimeout); err != nil {
+		fail(err)
+		return
+	}
+	step("ok", "Instância pronta em "+server.Host)
+
+	if err := h.runProvision(server, sshKey, ""); err != nil {
+		h.logger.Error(msgProvisionFailed, "serverId", server.ID, "error", err)
+	}
+}
+
+func waitForInstance(provider cloud.Provider, id string) (*cloud.Instance, error) {
+	deadline := time.Now().Add(cloudBootTimeout)
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), cloudRequestTimeout)
+		instance, err := provider.GetInstance(ctx, id)
+		cancel()
+		if err == nil && instance.Running && instance.PublicIP != "" {
+			return instance, nil
+		}
+		if time.Now().After(deadline) {
+			if err != nil {
+				return nil, fmt.Errorf("instance %s did not start: %w", id, err)
+			}
+			return nil, fmt.Errorf("instance %s did not start within %s (status %s)", id, cloudBootTimeout, instance.Status)
+		}
+		time.Sleep(cloudBootPollInterval)
+	}
+}
+
+func waitForPort(addr string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		conn, err := net.DialTimeout("tcp", addr, cloudBootPollInterval)
+		if err == nil {
+			conn.Close()
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("ssh not reachable at %s: %w", addr, err)
+		}
+		time.Sleep(cloudBootPollInterval)
+	}
+}
@@ -0,0 +1,149 @@
+package handler
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+
+	pb "github.com/paasdeploy/backend/gen/go/flowdeploy/v1"
+	"github.com/paasdeploy/backend/internal/domain"
+	"github.com/paasdeploy/backend/internal/response"
+	"github.com/paasdeploy/shared/pkg/compose"
+)
+
+type ComposeServiceResponse struct {
+	DiscoveredAppResponse
+	Image        string `json:"image,omitempty"`
+	BuildContext string `json:"buildContext,omitempty"`
+	Dockerfile   string `json:"dockerfile,omitempty"`
+}
+
+type ComposeProjectResponse struct {
+	ProjectName string                   `json:"projectName"`
+	Services    []ComposeServiceResponse `json:"services"`
+}
+
+type AdoptComposeRequest struct {
+	Path string             `json:"path"`
+	Apps []ImportAppRequest `json:"apps"`
+}
+
+// readComposeProject asks the server's agent to resolve the compose project
+// at path. When it returns false it has already sent the error response.
+func (h *ServerHandler) readComposeProject(c *fiber.Ctx, path string) (*domain.Server, *domain.User, *pb.ReadComposeProjectResponse, bool, error) {
+	server, user, ok, err := h.requireServerForUser(c)
+	if !ok {
+		return nil, nil, nil, false, err
+	}
+	path = strings.TrimSpace(path)
+	if path == "" || !filepath.IsAbs(path) {
+		return nil, nil, nil, false, response.BadRequest(c, "path must be an absolute directory on the server")
+	}
+	if h.agentClient == nil {
+		return nil, nil, nil, false, response.BadRequest(c, "agent client not configured")
+	}
+
+	project, err := h.agentClient.ReadComposeProject(c.Context(), server.Host, h.agentPort, path)
+	if err != nil {
+		h.logger.WarnContext(c.UserContext(), "read compose project failed", "serverId", server.ID, "path", path, "error", err)
+		return nil, nil, nil, false, response.ServerError(c, fiber.StatusBadGateway, "Failed to read compose project: "+err.Error())
+	}
+	return server, user, project, true, nil
+}
+
+// composeServiceApp maps a compose service onto an importable app, falling
+// back to the default app port when the service publishes none.
+func composeServiceApp(svc *pb.ComposeService) domain.ImportedApp {
+	app := domain.ImportedApp{
+		Name:    svc.GetName(),
+		EnvVars: svc.GetEnvironment(),
+		Domains: svc.GetDomains(),
+		Port:    int(svc.GetPort()),
+	}
+	if app.Port == 0 {
+		app.Port = compose.DefaultAppPort
+	}
+	for _, v := range svc.GetVolumes() {
+		app.Volumes = append(app.Volumes, domain.ImportedVolume{Source: v.GetSource(), Target: v.GetTarget(), ReadOnly: v.GetReadOnly()})
+	}
+	return app
+}
+
+// GetComposeProject lists the services of an existing compose project on
+// the server. Env var values are not returned, only their keys.
+func (h *ServerHandler) GetComposeProject(c *fiber.Ctx) error {
+	_, _, project, ok, err := h.readComposeProject(c, c.Query("path"))
+	if !ok {
+		return err
+	}
+
+	result := ComposeProjectResponse{
+		ProjectName: project.GetProjectName(),
+		Services:    make([]ComposeServiceResponse, 0, len(project.GetServices())),
+	}
+	for _, svc := range project.GetServices() {
+		app := composeServiceApp(svc)
+		keys := make([]string, 0, len(app.EnvVars))
+		for k := range app.EnvVars {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		result.Services = append(result.Services, ComposeServiceResponse{
+			DiscoveredAppResponse: DiscoveredAppResponse{
+				Name:       app.Name,
+				EnvVarKeys: keys,
+				Domains:    nonNilStrings(app.Domains),
+				Volumes:    nonNilVolumes(app.Volumes),
+				Port:       app.Port,
+			},
+			Image:        svc.GetImage(),
+			BuildContext: svc.GetBuildContext(),
+			Dockerfile:   svc.GetDockerfile(),
+		})
+	}
+	return response.OK(c, result)
+}
+
+// AdoptComposeProject creates a managed app for each selected service of
+// the project. The suggested paasdeploy.json keeps the service's build
+// context and Dockerfile, assuming the project directory is the repository
+// root, so later deploys build the same image.
+func (h *ServerHandler) AdoptComposeProject(c *fiber.Ctx) error {
+	var req AdoptComposeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.BadRequest(c, MsgInvalidRequestBody)
+	}
+	if ok, err := validateImportCount(c, len(req.Apps)); !ok {
+		return err
+	}
+
+	server, user, project, ok, err := h.readComposeProject(c, req.Path)
+	if !ok {
+		return err
+	}
+	byName := make(map[string]*pb.ComposeService, len(project.GetServices()))
+	for _, svc := range project.GetServices() {
+		byName[svc.GetName()] = svc
+	}
+
+	results := make([]ImportAppResult, 0, len(req.Apps))
+	for _, r := range req.Apps {
+		svc, ok := byName[r.Source]
+		if !ok {
+			results = append(results, h.importApp(c, server, user, nil, r))
+			continue
+		}
+		app := composeServiceApp(svc)
+		result := h.importApp(c, server, user, &app, r)
+		if result.Config != nil && svc.GetBuildContext() != "" {
+			result.Config.Build.Context = svc.GetBuildContext()
+			if svc.GetDockerfile() != "" {
+				result.Config.Build.Dockerfile = "./" + filepath.Join(svc.GetBuildContext(), svc.GetDockerfile())
+			}
+		}
+		results = append(results, result)
+	}
+	return response.OK(c, results)
+}
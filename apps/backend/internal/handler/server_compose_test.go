@@ -0,0 +1,32 @@
+package handler
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/paasdeploy/backend/internal/domain"
+)
+
+func TestComposeProjectStopsWhenItCannotBeRead(t *testing.T) {
+	servers := &fakeServerRepo{servers: map[string]domain.Server{"srv-1": {ID: "srv-1"}}}
+	app := newTestApp(testOwner)
+	NewServerHandler(servers, nil, nil, nil, ServerHandlerAgentDeps{}, nil, nil, testLogger()).Register(app)
+
+	tests := []struct {
+		name, method, path, body string
+	}{
+		{"relative path", fiber.MethodGet, "/servers/srv-1/compose?path=srv/shop", ""},
+		{"no agent client", fiber.MethodGet, "/servers/srv-1/compose?path=/srv/shop", ""},
+		{"adopt relative path", fiber.MethodPost, "/servers/srv-1/compose/adopt", `{"path":"srv/shop","apps":[{"source":"web"}]}`},
+		{"adopt without agent client", fiber.MethodPost, "/servers/srv-1/compose/adopt", `{"path":"/srv/shop","apps":[{"source":"web"}]}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if resp := doRequest(t, app, tt.method, APIPrefix+tt.path, tt.body); resp.StatusCode != http.StatusBadRequest {
+				t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusBadRequest)
+			}
+		})
+	}
+}
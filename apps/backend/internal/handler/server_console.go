@@ -0,0 +1,143 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/gofiber/contrib/websocket"
+
+	"github.com/paasdeploy/backend/internal/crypto"
+	"github.com/paasdeploy/backend/internal/domain"
+	"github.com/paasdeploy/backend/internal/provisioner"
+)
+
+// serverConsoleShell is recorded as the shell of server console sessions,
+// which run the login shell of the server's SSH user.
+const serverConsoleShell = "ssh"
+
+// ServerShell is a login shell on a server's host.
+type ServerShell interface {
+	Stdin() io.WriteCloser
+	Stdout() io.Reader
+	Resize(cols, rows uint16) error
+	// Wait returns the exit code of the shell once it exits.
+	Wait() int
+	Close() error
+}
+
+// ServerShellOpener opens a login shell on a server with its stored SSH
+// credentials.
+type ServerShellOpener interface {
+	OpenServerShell(server *domain.Server, cols, rows uint16) (ServerShell, error)
+}
+
+// ServerShells opens server shells over SSH through the provisioner.
+type ServerShells struct {
+	credentials serverCredentials
+	provisioner *provisioner.SSHProvisioner
+}
+
+func NewServerShells(
+	serverRepo domain.ServerRepository,
+	tokenEncryptor *crypto.TokenEncryptor,
+	prov *provisioner.SSHProvisioner,
+	logger *slog.Logger,
+) *ServerShells {
+	return &ServerShells{
+		credentials: serverCredentials{serverRepo: serverRepo, tokenEncryptor: tokenEncryptor, logger: logger},
+		provisioner: prov,
+	}
+}
+
+func (s *ServerShells) OpenServerShell(server *domain.Server, cols, rows uint16) (ServerShell, error) {
+	if s.provisioner == nil {
+		return nil, errors.New("SSH provisioner not configured")
+	}
+	sshKey, sshPassword, err := s.credentials.forProvision(server)
+	if err != nil {
+		return nil, fmt.Errorf("load ssh credentials: %w", err)
+	}
+	if sshKey == "" && sshPassword == "" {
+		return nil, errors.New("server has no ssh credentials")
+	}
+	shell, err := s.provisioner.OpenShell(server, sshKey, sshPassword, cols, rows)
+	if err != nil {
+		return nil, err
+	}
+	return shell, nil
+}
+
+// handleServerConsole opens an SSH terminal on a server's host. It gives the
+// same access as the server's SSH user, so it is limited to admins, and each
+// session is recorded like a container console.
+func (h *ContainerExecHandler) handleServerConsole(c *websocket.Conn) {
+	user, _ := c.Locals("user").(*domain.User)
+	if user == nil || !user.IsAdmin() {
+		_ = c.WriteMessage(websocket.TextMessage, []byte("Error: server console requires admin role\r\n"))
+		return
+	}
+	if h.serverShells == nil {
+		_ = c.WriteMessage(websocket.TextMessage, []byte("Error: server console not available\r\n"))
+		return
+	}
+
+	serverID := c.Params("id")
+	server, err := h.serverRepo.FindByIDForUser(serverID, user.ID)
+	if err != nil {
+		h.logger.Error("server not found for console", "serverId", serverID, "error", err)
+		_ = c.WriteMessage(websocket.TextMessage, []byte("Error: server not found\r\n"))
+		return
+	}
+
+	cols := parseUint16Query(c, "cols", defaultCols)
+	rows := parseUint16Query(c, "rows", defaultRows)
+
+	rec := h.startSession(c, user, server.ID, "", serverConsoleShell, cols, rows)
+	defer h.finishSession(rec)
+
+	shell, err := h.serverShells.OpenServerShell(server, cols, rows)
+	if err != nil {
+		h.logger.Error("failed to open server console", "serverId", server.ID, "error", err)
+		_ = c.WriteMessage(websocket.TextMessage, []byte("Error: failed to connect to server: "+err.Error()+"\r\n"))
+		return
+	}
+	defer shell.Close()
+
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+		h.readFromPTY(shell.Stdout(), c, done, rec)
+		// The shell is gone. Tell the browser and stop waiting for its input;
+		// the hijacked connection itself is closed when the handler returns.
+		_ = c.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(time.Second))
+		_ = c.SetReadDeadline(time.Now())
+	}()
+
+	go func() {
+		defer wg.Done()
+		h.writeFromWS(c, shell.Stdin(), func(cols, rows uint16) {
+			_ = shell.Resize(cols, rows)
+		}, done, rec)
+		// The browser is gone. Closing stdin does not end a login shell on a
+		// PTY, so close the connection to end the shell and the PTY reader.
+		_ = shell.Close()
+	}()
+
+	go func() {
+		rec.setExitCode(shell.Wait())
+		close(done)
+	}()
+
+	wg.Wait()
+	// The writer closed the shell on its way out, so Wait has returned or
+	// is about to; let it record the exit code before the session ends.
+	<-done
+}
@@ -0,0 +1,196 @@
+package handler
+
+import (
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	fastws "github.com/fasthttp/websocket"
+
+	"github.com/paasdeploy/backend/internal/domain"
+)
+
+// fakeServerShell behaves like a login shell on a PTY: closing stdin does
+// not end it, only Close does.
+type fakeServerShell struct {
+	outR *io.PipeReader
+	outW *io.PipeWriter
+
+	mu     sync.Mutex
+	input  strings.Builder
+	closed chan struct{}
+	once   sync.Once
+}
+
+func newFakeServerShell() *fakeServerShell {
+	r, w := io.Pipe()
+	return &fakeServerShell{outR: r, outW: w, closed: make(chan struct{})}
+}
+
+func (s *fakeServerShell) Stdin() io.WriteCloser { return fakeShellStdin{s} }
+func (s *fakeServerShell) Stdout() io.Reader     { return s.outR }
+func (s *fakeServerShell) Resize(uint16, uint16) error {
+	return nil
+}
+
+func (s *fakeServerShell) Wait() int {
+	<-s.closed
+	return -1
+}
+
+func (s *fakeServerShell) Close() error {
+	s.once.Do(func() {
+		close(s.closed)
+		_ = s.outW.Close()
+	})
+	return nil
+}
+
+func (s *fakeServerShell) typed() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.input.String()
+}
+
+type fakeShellStdin struct{ s *fakeServerShell }
+
+func (w fakeShellStdin) Write(p []byte) (int, error) {
+	w.s.mu.Lock()
+	defer w.s.mu.Unlock()
+	return w.s.input.Write(p)
+}
+
+func (w fakeShellStdin) Close() error { return nil }
+
+type fakeShellOpener struct {
+	shell *fakeServerShell
+}
+
+func (o *fakeShellOpener) OpenServerShell(*domain.Server, uint16, uint16) (ServerShell, error) {
+	return o.shell, nil
+}
+
+// fakeConsoleSessionRepo reports when a session is finished, which the
+// console does when its handler returns.
+type fakeConsoleSessionRepo struct {
+	domain.ExecSessionRepository
+	finished chan domain.FinishExecSessionInput
+}
+
+func (r *fakeConsoleSessionRepo) Create(domain.CreateExecSessionInput) (*domain.ExecSession, error) {
+	return &domain.ExecSession{ID: "ses-1", StartedAt: time.Now()}, nil
+}
+
+func (r *fakeConsoleSessionRepo) Finish(_ string, input domain.FinishExecSessionInput) error {
+	r.finished <- input
+	return nil
+}
+
+func (r *fakeConsoleSessionRepo) DeleteOlderThan(time.Time) (int64, error) {
+	return 0, nil
+}
+
+func startConsoleServer(t *testing.T, user *domain.User, shell *fakeServerShell) (string, *fakeConsoleSessionRepo) {
+	t.Helper()
+	sessions := &fakeConsoleSessionRepo{finished: make(chan domain.FinishExecSessionInput, 1)}
+	app := newTestApp(user)
+	NewContainerExecHandler(ContainerExecHandlerConfig{
+		ServerRepo:   &fakeServerRepo{servers: map[string]domain.Server{"srv-1": {ID: "srv-1"}}},
+		SessionRepo:  sessions,
+		ServerShells: &fakeShellOpener{shell: shell},
+		Logger:       testLogger(),
+	}).Register(app)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() { _ = app.Listener(ln) }()
+	t.Cleanup(func() { _ = app.Shutdown() })
+	return "ws://" + ln.Addr().String() + APIPrefix + "/servers/srv-1/console", sessions
+}
+
+func dialConsole(t *testing.T, url string) *fastws.Conn {
+	t.Helper()
+	conn, _, err := fastws.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatalf("dial console: %v", err)
+	}
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	return conn
+}
+
+func TestServerConsoleClosesShellWhenBrowserDisconnects(t *testing.T) {
+	shell := newFakeServerShell()
+	url, sessions := startConsoleServer(t, testAdmin, shell)
+	conn := dialConsole(t, url)
+
+	go func() { _, _ = shell.outW.Write([]byte("$ ")) }()
+	if _, msg, err := conn.ReadMessage(); err != nil || string(msg) != "$ " {
+		t.Fatalf("read prompt = %q, %v", msg, err)
+	}
+	if err := conn.WriteMessage(fastws.TextMessage, []byte("uptime\n")); err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for shell.typed() != "uptime\n" && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if got := shell.typed(); got != "uptime\n" {
+		t.Fatalf("shell input = %q, want %q", got, "uptime\n")
+	}
+
+	_ = conn.Close()
+
+	select {
+	case <-shell.closed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("shell was not closed after the browser disconnected")
+	}
+	select {
+	case result := <-sessions.finished:
+		if result.ExitCode == nil || *result.ExitCode != -1 {
+			t.Errorf("exit code = %v, want -1", result.ExitCode)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("console session was not finished after the browser disconnected")
+	}
+}
+
+func TestServerConsoleClosesSocketWhenShellExits(t *testing.T) {
+	shell := newFakeServerShell()
+	url, sessions := startConsoleServer(t, testAdmin, shell)
+	conn := dialConsole(t, url)
+	defer conn.Close()
+
+	_ = shell.Close()
+
+	if _, _, err := conn.ReadMessage(); err == nil {
+		t.Fatal("socket still open after the shell exited")
+	}
+	select {
+	case <-sessions.finished:
+	case <-time.After(5 * time.Second):
+		t.Fatal("console session was not finished after the shell exited")
+	}
+}
+
+func TestServerConsoleRequiresAdmin(t *testing.T) {
+	shell := newFakeServerShell()
+	url, _ := startConsoleServer(t, testOwner, shell)
+	conn := dialConsole(t, url)
+	defer conn.Close()
+
+	_, msg, err := conn.ReadMessage()
+	if err != nil || !strings.Contains(string(msg), "requires admin role") {
+		t.Fatalf("message = %q, %v; want the admin error", msg, err)
+	}
+	select {
+	case <-shell.closed:
+		t.Error("shell touched for a non-admin")
+	default:
+	}
+}
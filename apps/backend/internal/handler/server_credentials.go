@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/paasdeploy/backend/internal/crypto"
+	"github.com/paasdeploy/backend/internal/domain"
+)
+
+// serverCredentials decrypts the stored SSH credentials of servers and of
+// the bastions they are reached through.
+type serverCredentials struct {
+	serverRepo     domain.ServerRepository
+	tokenEncryptor *crypto.TokenEncryptor
+	logger         *slog.Logger
+}
+
+// forProvision returns the credentials of the server and attaches its
+// bastion, ready to hand to the provisioner.
+func (s serverCredentials) forProvision(server *domain.Server) (sshKey, sshPassword string, err error) {
+	sshKey, sshPassword, err = s.decrypt(server)
+	if err != nil {
+		return "", "", err
+	}
+	if err := s.resolveBastion(server); err != nil {
+		s.logger.Error("failed to resolve bastion", "serverId", server.ID, "error", err)
+		return "", "", err
+	}
+	return sshKey, sshPassword, nil
+}
+
+func (s serverCredentials) decrypt(server *domain.Server) (sshKey, sshPassword string, err error) {
+	sshKey = server.SSHKeyEncrypted
+	if s.tokenEncryptor != nil && sshKey != "" {
+		sshKey, err = s.tokenEncryptor.Decrypt(server.SSHKeyEncrypted)
+		if err != nil {
+			s.logger.Error("failed to decrypt ssh key", "error", err)
+			return "", "", err
+		}
+	}
+	sshPassword = server.SSHPasswordEncrypted
+	if s.tokenEncryptor != nil && sshPassword != "" {
+		sshPassword, err = s.tokenEncryptor.Decrypt(server.SSHPasswordEncrypted)
+		if err != nil {
+			s.logger.Error("failed to decrypt ssh password", "error", err)
+			return "", "", err
+		}
+	}
+	return sshKey, sshPassword, nil
+}
+
+// resolveBastion attaches the decrypted bastion connection to the server so
+// the provisioner can tunnel through it.
+func (s serverCredentials) resolveBastion(server *domain.Server) error {
+	if !hasBastion(server.BastionServerID) {
+		return nil
+	}
+	bastion, err := s.serverRepo.FindByID(*server.BastionServerID)
+	if err != nil {
+		return fmt.Errorf("load bastion %s: %w", *server.BastionServerID, err)
+	}
+	sshKey, sshPassword, err := s.decrypt(bastion)
+	if err != nil {
+		return err
+	}
+	server.Bastion = &domain.SSHBastion{
+		ServerID: bastion.ID,
+		Host:     bastion.Host,
+		Port:     bastion.SSHPort,
+		User:     bastion.SSHUser,
+		Key:      sshKey,
+		Password: sshPassword,
+		HostKey:  bastion.SSHHostKey,
+	}
+	return nil
+}
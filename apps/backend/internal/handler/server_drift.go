@@ -0,0 +1,36 @@
+package handler
+
+import (
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/paasdeploy/backend/internal/response"
+)
+
+// GetDrift inspects the server over SSH and reports where it differs from the
+// state Provision would leave it in. Nothing is changed on the server.
+func (h *ServerHandler) GetDrift(c *fiber.Ctx) error {
+	server, _, ok, err := h.requireServerForUser(c)
+	if !ok {
+		return err
+	}
+	if h.provisioner == nil {
+		return response.BadRequest(c, "drift detection not available: SSH provisioner not configured")
+	}
+
+	sshKey, sshPassword, err := h.decryptProvisionCredentials(server)
+	if err != nil {
+		return response.InternalError(c)
+	}
+	if sshKey == "" && sshPassword == "" {
+		return response.BadRequest(c, "server has no ssh credentials")
+	}
+
+	report, err := h.provisioner.DetectDrift(server, sshKey, sshPassword)
+	if err != nil {
+		h.logger.ErrorContext(c.UserContext(), "drift detection failed", "serverId", server.ID, "error", err)
+		return response.ServerError(c, fiber.StatusBadGateway, fmt.Sprintf("SSH command failed: %s", err))
+	}
+	return response.OK(c, report)
+}
@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/paasdeploy/backend/internal/domain"
+	"github.com/paasdeploy/backend/internal/response"
+)
+
+const defaultSSHPort = 22
+
+type UpdateEntrypointsRequest struct {
+	Entrypoints []domain.ServerEntrypoint `json:"entrypoints"`
+}
+
+// UpdateEntrypoints replaces the server's extra Traefik entrypoints. Like
+// other server settings, they are applied on the next provisioning.
+func (h *ServerHandler) UpdateEntrypoints(c *fiber.Ctx) error {
+	server, _, ok, err := h.requireServerForUser(c)
+	if !ok {
+		return err
+	}
+
+	var req UpdateEntrypointsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.BadRequest(c, MsgInvalidRequestBody)
+	}
+	sshPort := server.SSHPort
+	if sshPort == 0 {
+		sshPort = defaultSSHPort
+	}
+	if err := domain.ValidateServerEntrypoints(req.Entrypoints, sshPort); err != nil {
+		return response.BadRequest(c, err.Error())
+	}
+
+	if err := h.serverRepo.UpdateEntrypoints(server.ID, req.Entrypoints); err != nil {
+		h.logger.ErrorContext(c.UserContext(), "Failed to update entrypoints", "serverId", server.ID, "error", err)
+		return response.InternalError(c)
+	}
+	server.Entrypoints = req.Entrypoints
+	return response.OK(c, toServerResponse(server))
+}
@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/paasdeploy/backend/internal/domain"
+	"github.com/paasdeploy/backend/internal/response"
+)
+
+func (h *ServerHandler) GetFirewall(c *fiber.Ctx) error {
+	server, _, ok, err := h.requireServerForUser(c)
+	if !ok {
+		return err
+	}
+	if h.firewallRepo == nil {
+		return response.ServerError(c, fiber.StatusServiceUnavailable, "firewall records not available")
+	}
+
+	fw, err := h.firewallRepo.FindByServerID(server.ID)
+	if err != nil {
+		return HandleNotFoundOrInternal(c, err, "firewall has not been applied to this server")
+	}
+	return response.OK(c, fw)
+}
+
+// ApplyFirewall re-applies the recorded rules over SSH, or the default rule
+// set when the server has never had its firewall configured.
+func (h *ServerHandler) ApplyFirewall(c *fiber.Ctx) error {
+	server, _, ok, err := h.requireServerForUser(c)
+	if !ok {
+		return err
+	}
+	if h.provisioner == nil || h.firewallRepo == nil {
+		return response.BadRequest(c, "firewall management not available: SSH provisioner not configured")
+	}
+
+	sshKey, sshPassword, err := h.decryptProvisionCredentials(server)
+	if err != nil {
+		return response.InternalError(c)
+	}
+	if sshKey == "" && sshPassword == "" {
+		return response.BadRequest(c, "server has no ssh credentials")
+	}
+
+	var rules []domain.FirewallRule
+	recorded, err := h.firewallRepo.FindByServerID(server.ID)
+	switch {
+	case err == nil:
+		rules = recorded.Rules
+	case !errors.Is(err, domain.ErrNotFound):
+		h.logger.ErrorContext(c.UserContext(), "failed to load firewall rules", "serverId", server.ID, "error", err)
+		return response.InternalError(c)
+	}
+
+	fw, err := h.provisioner.ReapplyFirewall(server, sshKey, sshPassword, rules)
+	if err != nil {
+		h.logger.ErrorContext(c.UserContext(), "apply firewall failed", "serverId", server.ID, "error", err)
+		return response.ServerError(c, fiber.StatusBadGateway, fmt.Sprintf("SSH command failed: %s", err))
+	}
+	return response.OK(c, fw)
+}
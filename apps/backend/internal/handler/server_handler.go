@@ -2,6 +2,7 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net"
@@ -10,6 +11,7 @@ import (
 	"time"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/paasdeploy/backend/internal/agentdownload"
 	"github.com/paasdeploy/backend/internal/agentclient"
 	"github.com/paasdeploy/backend/internal/crypto"
 	"github.com/paasdeploy/backend/internal/domain"
@@ -61,18 +63,33 @@ var agentStatsTimeout = 5 * time.Second
 
 var LatestAgentVersion = "dev"
 
-const msgProvisionFailed = "provision failed"
+const (
+	msgProvisionFailed      = "provision failed"
+	msgInvalidInstallMethod = "invalid agentInstallMethod; allowed: auto, systemd_user, openrc, nohup, docker"
+)
 
 type UpdateAgentEnqueuer interface {
 	EnqueueUpdateAgent(serverID string)
 }
 
+type AgentCertRevoker interface {
+	RevokeServerCert(serverID, reason, revokedBy string) (*domain.CertificateRevocation, error)
+}
+
 type ServerHandlerAgentDeps struct {
 	HealthChecker        *agentclient.HealthChecker
 	AgentClient          *agentclient.AgentClient
 	AgentPort            int
 	AgentBinaryPath      string
 	UpdateAgentEnqueuer  UpdateAgentEnqueuer
+	CertRevoker          AgentCertRevoker
+	CommandRepo          domain.AgentCommandRepository
+	HeartbeatRepo        domain.ServerHeartbeatRepository
+	BootstrapTokenRepo   domain.ServerBootstrapTokenRepository
+	FirewallRepo         domain.ServerFirewallRepository
+	CloudCredentialRepo  domain.CloudCredentialRepository
+	Tunnels              ServerTunnelManager
+	APIBaseURL           string
 }
 
 type ServerHandler struct {
@@ -85,12 +102,27 @@ type ServerHandler struct {
 	agentPort            int
 	agentBinaryPath      string
 	updateAgentEnqueuer  UpdateAgentEnqueuer
-	appService           AppsByServerLister
+	certRevoker          AgentCertRevoker
+	commandRepo          domain.AgentCommandRepository
+	heartbeatRepo        domain.ServerHeartbeatRepository
+	bootstrapTokenRepo   domain.ServerBootstrapTokenRepository
+	firewallRepo         domain.ServerFirewallRepository
+	cloudCredentialRepo  domain.CloudCredentialRepository
+	tunnels              ServerTunnelManager
+	apiBaseURL           string
+	appService           ServerAppService
+	quotas               ServerQuotaChecker
+	provisionBatches     *provisionBatchStore
 	logger               *slog.Logger
 }
 
-type AppsByServerLister interface {
+type ServerAppService interface {
 	ListAppsByServerID(serverID, userID string) ([]domain.AppWithDeployment, error)
+	ImportApp(ctx context.Context, input domain.CreateAppInput, envVars []domain.CreateEnvVarInput) (*domain.App, error)
+}
+
+type ServerQuotaChecker interface {
+	CheckCreateServer(ctx context.Context, userID string) error
 }
 
 func NewServerHandler(
@@ -99,7 +131,8 @@ func NewServerHandler(
 	prov *provisioner.SSHProvisioner,
 	sseHandler *SSEHandler,
 	agentDeps ServerHandlerAgentDeps,
-	appService AppsByServerLister,
+	appService ServerAppService,
+	quotas ServerQuotaChecker,
 	logger *slog.Logger,
 ) *ServerHandler {
 	return &ServerHandler{
@@ -112,7 +145,17 @@ func NewServerHandler(
 		agentPort:          agentDeps.AgentPort,
 		agentBinaryPath:    agentDeps.AgentBinaryPath,
 		updateAgentEnqueuer: agentDeps.UpdateAgentEnqueuer,
+		certRevoker:         agentDeps.CertRevoker,
+		commandRepo:         agentDeps.CommandRepo,
+		heartbeatRepo:       agentDeps.HeartbeatRepo,
+		bootstrapTokenRepo:  agentDeps.BootstrapTokenRepo,
+		firewallRepo:        agentDeps.FirewallRepo,
+		cloudCredentialRepo: agentDeps.CloudCredentialRepo,
+		tunnels:             agentDeps.Tunnels,
+		apiBaseURL:          agentDeps.APIBaseURL,
 		appService:         appService,
+		quotas:             quotas,
+		provisionBatches:   newProvisionBatchStore(),
 		logger:             logger.With("handler", "server"),
 	}
 }
@@ -120,8 +163,17 @@ func NewServerHandler(
 func (h *ServerHandler) Register(app fiber.Router) {
 	v1 := app.Group(APIPrefix)
 	servers := v1.Group("/servers")
+	cloudCredentials := v1.Group("/cloud-credentials")
+	cloudCredentials.Get("/", h.ListCloudCredentials)
+	cloudCredentials.Put("/:provider", h.SaveCloudCredential)
+	cloudCredentials.Delete("/:provider", h.DeleteCloudCredential)
+
 	servers.Get("/", h.List)
 	servers.Post("/", h.Create)
+	servers.Post("/bulk", h.BulkCreate)
+	servers.Post("/cloud", h.CreateCloudServer)
+	servers.Post("/bulk-provision", h.BulkProvision)
+	servers.Get("/provision-batches/:batchId", h.GetProvisionBatch)
 	servers.Get("/:id/stats", h.GetStats)
 	servers.Get("/:id", h.Get)
 	servers.Put("/:id", h.Update)
@@ -131,6 +183,27 @@ func (h *ServerHandler) Register(app fiber.Router) {
 	servers.Get("/:id/health", h.HealthCheck)
 	servers.Get("/:id/apps", h.ListServerApps)
 	servers.Post("/:id/manage", h.ManageServer)
+	servers.Post("/:id/revoke-cert", h.RevokeCert)
+	servers.Get("/:id/commands", h.ListCommands)
+	servers.Get("/:id/availability", h.GetAvailability)
+	servers.Get("/:id/agent-logs", h.GetAgentLogs)
+	servers.Post("/:id/agent-logs/rotate", h.RotateAgentLogs)
+	servers.Post("/:id/bootstrap-script", h.GenerateBootstrapScript)
+	servers.Get("/:id/firewall", h.GetFirewall)
+	servers.Post("/:id/firewall/apply", h.ApplyFirewall)
+	servers.Get("/:id/tunnel", h.GetTunnel)
+	servers.Post("/:id/tunnel", h.EnableTunnel)
+	servers.Delete("/:id/tunnel", h.DisableTunnel)
+	servers.Get("/:id/drift", h.GetDrift)
+	servers.Post("/:id/ssh-key", h.GenerateSSHKey)
+	servers.Put("/:id/entrypoints", h.UpdateEntrypoints)
+	servers.Put("/:id/stats-interval", h.UpdateStatsInterval)
+	servers.Post("/:id/mesh", h.EnableMesh)
+	servers.Delete("/:id/mesh", h.DisableMesh)
+	servers.Get("/:id/import/:platform", h.DiscoverImportableApps)
+	servers.Post("/:id/import/:platform", h.ImportApps)
+	servers.Get("/:id/compose", h.GetComposeProject)
+	servers.Post("/:id/compose/adopt", h.AdoptComposeProject)
 }
 
 type ServerResponse struct {
@@ -143,6 +216,17 @@ type ServerResponse struct {
 	Status               string  `json:"status"`
 	AgentVersion         *string `json:"agentVersion,omitempty"`
 	AgentUpdateMode      string  `json:"agentUpdateMode"`
+	AgentInstallMethod   string  `json:"agentInstallMethod"`
+	DockerRootless       bool    `json:"dockerRootless"`
+	FirewallEnabled      bool    `json:"firewallEnabled"`
+	SSHHardening         bool    `json:"sshHardening"`
+	AcmeStaging          bool    `json:"acmeStaging"`
+	Entrypoints          []domain.ServerEntrypoint `json:"entrypoints"`
+	MeshIP               string  `json:"meshIp,omitempty"`
+	BastionServerID      *string `json:"bastionServerId,omitempty"`
+	SSHPublicKey         string  `json:"sshPublicKey,omitempty"`
+	CloudProvider        string  `json:"cloudProvider,omitempty"`
+	StatsIntervalSeconds *int    `json:"statsIntervalSeconds,omitempty"`
 	LatestAgentVersion   string  `json:"latestAgentVersion"`
 	LastHeartbeatAt      *string `json:"lastHeartbeatAt,omitempty"`
 	CreatedAt            string  `json:"createdAt"`
@@ -157,17 +241,28 @@ type ServerHealthResponse struct {
 
 func toServerResponse(s *domain.Server) ServerResponse {
 	resp := ServerResponse{
-		ID:                 s.ID,
-		Name:               s.Name,
-		Host:               s.Host,
-		SSHPort:            s.SSHPort,
-		SSHUser:            s.SSHUser,
-		AcmeEmail:          s.AcmeEmail,
-		Status:             string(s.Status),
-		AgentUpdateMode:    s.AgentUpdateMode,
-		LatestAgentVersion: LatestAgentVersion,
-		CreatedAt:          s.CreatedAt.Format(DateTimeFormatISO8601),
-		UpdatedAt:          s.UpdatedAt.Format(DateTimeFormatISO8601),
+		ID:                   s.ID,
+		Name:                 s.Name,
+		Host:                 s.Host,
+		SSHPort:              s.SSHPort,
+		SSHUser:              s.SSHUser,
+		AcmeEmail:            s.AcmeEmail,
+		Status:               string(s.Status),
+		AgentUpdateMode:      s.AgentUpdateMode,
+		AgentInstallMethod:   s.AgentInstallMethod,
+		DockerRootless:       s.DockerRootless,
+		FirewallEnabled:      s.FirewallEnabled,
+		SSHHardening:         s.SSHHardening,
+		AcmeStaging:          s.AcmeStaging,
+		Entrypoints:          s.Entrypoints,
+		MeshIP:               s.MeshIP,
+		BastionServerID:      s.BastionServerID,
+		SSHPublicKey:         s.SSHPublicKey,
+		CloudProvider:        s.CloudProvider,
+		StatsIntervalSeconds: s.StatsIntervalSeconds,
+		LatestAgentVersion:   LatestAgentVersion,
+		CreatedAt:            s.CreatedAt.Format(DateTimeFormatISO8601),
+		UpdatedAt:            s.UpdatedAt.Format(DateTimeFormatISO8601),
 	}
 	if s.AgentVersion != nil {
 		resp.AgentVersion = s.AgentVersion
@@ -180,24 +275,35 @@ func toServerResponse(s *domain.Server) ServerResponse {
 }
 
 type CreateServerRequest struct {
-	Name        string  `json:"name"`
-	Host        string  `json:"host"`
-	SSHPort     int     `json:"sshPort"`
-	SSHUser     string  `json:"sshUser"`
-	SSHKey      string  `json:"sshKey"`
-	SSHPassword string  `json:"sshPassword"`
-	AcmeEmail   *string `json:"acmeEmail,omitempty"`
+	Name               string  `json:"name"`
+	Host               string  `json:"host"`
+	SSHPort            int     `json:"sshPort"`
+	SSHUser            string  `json:"sshUser"`
+	SSHKey             string  `json:"sshKey"`
+	SSHPassword        string  `json:"sshPassword"`
+	AcmeEmail          *string `json:"acmeEmail,omitempty"`
+	AgentInstallMethod string  `json:"agentInstallMethod,omitempty"`
+	DockerRootless     bool    `json:"dockerRootless,omitempty"`
+	FirewallEnabled    bool    `json:"firewallEnabled,omitempty"`
+	SSHHardening       bool    `json:"sshHardening,omitempty"`
+	BastionServerID    *string `json:"bastionServerId,omitempty"`
+	GenerateSSHKey     bool    `json:"generateSshKey,omitempty"`
 }
 
 type UpdateServerRequest struct {
-	Name            *string `json:"name,omitempty"`
-	Host            *string `json:"host,omitempty"`
-	SSHPort         *int    `json:"sshPort,omitempty"`
-	SSHUser         *string `json:"sshUser,omitempty"`
-	SSHKey          *string `json:"sshKey,omitempty"`
-	SSHPassword     *string `json:"sshPassword,omitempty"`
-	AcmeEmail       *string `json:"acmeEmail,omitempty"`
-	AgentUpdateMode *string `json:"agentUpdateMode,omitempty"`
+	Name               *string `json:"name,omitempty"`
+	Host               *string `json:"host,omitempty"`
+	SSHPort            *int    `json:"sshPort,omitempty"`
+	SSHUser            *string `json:"sshUser,omitempty"`
+	SSHKey             *string `json:"sshKey,omitempty"`
+	SSHPassword        *string `json:"sshPassword,omitempty"`
+	AcmeEmail          *string `json:"acmeEmail,omitempty"`
+	AgentUpdateMode    *string `json:"agentUpdateMode,omitempty"`
+	AgentInstallMethod *string `json:"agentInstallMethod,omitempty"`
+	DockerRootless     *bool   `json:"dockerRootless,omitempty"`
+	FirewallEnabled    *bool   `json:"firewallEnabled,omitempty"`
+	SSHHardening       *bool   `json:"sshHardening,omitempty"`
+	BastionServerID    *string `json:"bastionServerId,omitempty"`
 }
 
 func encryptCredential(encryptor *crypto.TokenEncryptor, plain string) (string, error) {
@@ -217,6 +323,9 @@ func applyUpdateSSHCredentials(encryptor *crypto.TokenEncryptor, req *UpdateServ
 			return err
 		}
 		input.SSHKeyEncrypted = &encrypted
+		// A pasted key replaces the managed key pair, if any.
+		noPublicKey := ""
+		input.SSHPublicKey = &noPublicKey
 	}
 	if req.SSHPassword != nil && *req.SSHPassword != "" {
 		encrypted, err := encryptCredential(encryptor, *req.SSHPassword)
@@ -228,18 +337,21 @@ func applyUpdateSSHCredentials(encryptor *crypto.TokenEncryptor, req *UpdateServ
 	return nil
 }
 
-func (h *ServerHandler) requireServerForUser(c *fiber.Ctx) (*domain.Server, *domain.User, error) {
+// requireServerForUser loads the server of the route for the user. When it
+// returns false it has already sent the error response, which the caller
+// returns.
+func (h *ServerHandler) requireServerForUser(c *fiber.Ctx) (*domain.Server, *domain.User, bool, error) {
 	user := GetUserFromContext(c)
 	if user == nil {
-		return nil, nil, response.Unauthorized(c, MsgNotAuthenticated)
+		return nil, nil, false, response.Unauthorized(c, MsgNotAuthenticated)
 	}
 
 	id := c.Params("id")
 	server, err := h.serverRepo.FindByIDForUser(id, user.ID)
 	if err != nil {
-		return nil, nil, HandleNotFoundOrInternal(c, err, MsgServerNotFound)
+		return nil, nil, false, HandleNotFoundOrInternal(c, err, MsgServerNotFound)
 	}
-	return server, user, nil
+	return server, user, true, nil
 }
 
 func (h *ServerHandler) List(c *fiber.Ctx) error {
@@ -248,17 +360,23 @@ func (h *ServerHandler) List(c *fiber.Ctx) error {
 		return response.Unauthorized(c, MsgNotAuthenticated)
 	}
 
-	servers, err := h.serverRepo.FindAllByUserID(user.ID)
+	opts, err := parseListOptions(c, domain.ServerSortFields, "-createdAt", 0)
 	if err != nil {
-		h.logger.Error("failed to list servers", "error", err)
+		return response.BadRequest(c, err.Error())
+	}
+	filter := domain.ServerFilter{Status: domain.ServerStatus(c.Query("status"))}
+
+	page, err := h.serverRepo.FindPageByUserID(user.ID, filter, opts)
+	if err != nil {
+		h.logger.ErrorContext(c.UserContext(), "failed to list servers", "error", err)
 		return response.InternalError(c)
 	}
 
-	resp := make([]ServerResponse, len(servers))
-	for i := range servers {
-		resp[i] = toServerResponse(&servers[i])
+	resp := make([]ServerResponse, len(page.Items))
+	for i := range page.Items {
+		resp[i] = toServerResponse(&page.Items[i])
 	}
-	return response.OK(c, resp)
+	return response.OKWithCursor(c, resp, page.NextCursor)
 }
 
 func (h *ServerHandler) Create(c *fiber.Ctx) error {
@@ -272,54 +390,90 @@ func (h *ServerHandler) Create(c *fiber.Ctx) error {
 		return response.BadRequest(c, MsgInvalidRequestBody)
 	}
 
-	if req.Name == "" || req.Host == "" || req.SSHUser == "" {
-		return response.BadRequest(c, "name, host and sshUser are required")
+	if err := validateCreateServerRequest(&req); err != nil {
+		return response.BadRequest(c, err.Error())
+	}
+	if err := h.validateBastion(user.ID, "", req.BastionServerID); err != nil {
+		return h.bastionError(c, err)
 	}
-	if req.SSHKey == "" && req.SSHPassword == "" {
-		return response.BadRequest(c, "provide sshKey or sshPassword")
+	if err := h.quotas.CheckCreateServer(c.UserContext(), user.ID); err != nil {
+		return HandleDomainError(c, err)
 	}
 
-	if err := validateServerHost(req.Host); err != nil {
-		return response.BadRequest(c, err.Error())
+	server, err := h.createServer(user.ID, &req)
+	if err != nil {
+		return HandleDomainError(c, err)
 	}
 
+	return response.Created(c, toServerResponse(server))
+}
+
+// validateCreateServerRequest returns an error whose message can be shown to
+// the user as is.
+func validateCreateServerRequest(req *CreateServerRequest) error {
+	if req.Name == "" || req.Host == "" || req.SSHUser == "" {
+		return errors.New("name, host and sshUser are required")
+	}
+	if req.SSHKey == "" && req.SSHPassword == "" && !req.GenerateSSHKey {
+		return errors.New("provide sshKey, sshPassword or generateSshKey")
+	}
+	if !hasBastion(req.BastionServerID) {
+		if err := validateServerHost(req.Host); err != nil {
+			return err
+		}
+	}
 	if err := validateAcmeEmail(req.AcmeEmail); err != nil {
-		return response.BadRequest(c, "invalid ACME email format")
+		return errors.New("invalid ACME email format")
+	}
+	if req.AgentInstallMethod != "" && !domain.IsValidAgentInstallMethod(req.AgentInstallMethod) {
+		return errors.New(msgInvalidInstallMethod)
 	}
+	return nil
+}
 
-	sshKeyEncrypted, err := encryptCredential(h.tokenEncryptor, req.SSHKey)
+func (h *ServerHandler) createServer(userID string, req *CreateServerRequest) (*domain.Server, error) {
+	sshKey, sshPublicKey := req.SSHKey, ""
+	if req.GenerateSSHKey && sshKey == "" {
+		var err error
+		sshKey, sshPublicKey, err = crypto.GenerateSSHKeyPair(managedKeyComment(req.Name))
+		if err != nil {
+			h.logger.Error("failed to generate ssh key pair", "error", err)
+			return nil, err
+		}
+	}
+	sshKeyEncrypted, err := encryptCredential(h.tokenEncryptor, sshKey)
 	if err != nil {
 		h.logger.Error("failed to encrypt ssh key", "error", err)
-		return response.InternalError(c)
+		return nil, err
 	}
 	sshPasswordEncrypted, err := encryptCredential(h.tokenEncryptor, req.SSHPassword)
 	if err != nil {
 		h.logger.Error("failed to encrypt ssh password", "error", err)
-		return response.InternalError(c)
+		return nil, err
 	}
 
 	input := domain.CreateServerInput{
-		UserID:               user.ID,
+		UserID:               userID,
 		Name:                 req.Name,
 		Host:                 req.Host,
 		SSHPort:              req.SSHPort,
 		SSHUser:              req.SSHUser,
 		SSHKeyEncrypted:      sshKeyEncrypted,
 		SSHPasswordEncrypted: sshPasswordEncrypted,
+		SSHPublicKey:         sshPublicKey,
 		AcmeEmail:            req.AcmeEmail,
+		AgentInstallMethod:   req.AgentInstallMethod,
+		DockerRootless:       req.DockerRootless,
+		FirewallEnabled:      req.FirewallEnabled,
+		SSHHardening:         req.SSHHardening,
+		BastionServerID:      req.BastionServerID,
 	}
-
-	server, err := h.serverRepo.Create(input)
-	if err != nil {
-		return HandleDomainError(c, err)
-	}
-
-	return response.Created(c, toServerResponse(server))
+	return h.serverRepo.Create(input)
 }
 
 func (h *ServerHandler) Get(c *fiber.Ctx) error {
-	server, _, err := h.requireServerForUser(c)
-	if err != nil {
+	server, _, ok, err := h.requireServerForUser(c)
+	if !ok {
 		return err
 	}
 
@@ -327,8 +481,8 @@ func (h *ServerHandler) Get(c *fiber.Ctx) error {
 }
 
 func (h *ServerHandler) GetStats(c *fiber.Ctx) error {
-	server, _, err := h.requireServerForUser(c)
-	if err != nil {
+	server, _, ok, err := h.requireServerForUser(c)
+	if !ok {
 		return err
 	}
 
@@ -341,13 +495,13 @@ func (h *ServerHandler) GetStats(c *fiber.Ctx) error {
 
 	sysInfo, err := h.agentClient.GetSystemInfo(ctx, server.Host, h.agentPort)
 	if err != nil {
-		h.logger.Warn("get system info failed", "serverId", server.ID, "error", err)
+		h.logger.WarnContext(c.UserContext(), "get system info failed", "serverId", server.ID, "error", err)
 		return response.ServerError(c, fiber.StatusServiceUnavailable, "agent unreachable; check if the agent is running and port 50052 is reachable")
 	}
 
 	sysMetrics, err := h.agentClient.GetSystemMetrics(ctx, server.Host, h.agentPort)
 	if err != nil {
-		h.logger.Warn("get system metrics failed", "serverId", server.ID, "error", err)
+		h.logger.WarnContext(c.UserContext(), "get system metrics failed", "serverId", server.ID, "error", err)
 		return response.ServerError(c, fiber.StatusServiceUnavailable, "agent unreachable; check if the agent is running and port 50052 is reachable")
 	}
 
@@ -358,8 +512,8 @@ func (h *ServerHandler) GetStats(c *fiber.Ctx) error {
 }
 
 func (h *ServerHandler) Update(c *fiber.Ctx) error {
-	server, _, err := h.requireServerForUser(c)
-	if err != nil {
+	server, _, ok, err := h.requireServerForUser(c)
+	if !ok {
 		return err
 	}
 
@@ -369,7 +523,14 @@ func (h *ServerHandler) Update(c *fiber.Ctx) error {
 		return response.BadRequest(c, MsgInvalidRequestBody)
 	}
 
-	if req.Host != nil {
+	if err := h.validateBastion(server.UserID, id, req.BastionServerID); err != nil {
+		return h.bastionError(c, err)
+	}
+	behindBastion := hasBastion(server.BastionServerID)
+	if req.BastionServerID != nil {
+		behindBastion = hasBastion(req.BastionServerID)
+	}
+	if req.Host != nil && !behindBastion {
 		if err := validateServerHost(*req.Host); err != nil {
 			return response.BadRequest(c, err.Error())
 		}
@@ -379,16 +540,25 @@ func (h *ServerHandler) Update(c *fiber.Ctx) error {
 		return response.BadRequest(c, "invalid ACME email format")
 	}
 
+	if req.AgentInstallMethod != nil && !domain.IsValidAgentInstallMethod(*req.AgentInstallMethod) {
+		return response.BadRequest(c, msgInvalidInstallMethod)
+	}
+
 	input := domain.UpdateServerInput{
-		Name:            req.Name,
-		Host:            req.Host,
-		SSHPort:         req.SSHPort,
-		SSHUser:         req.SSHUser,
-		AcmeEmail:       req.AcmeEmail,
-		AgentUpdateMode: req.AgentUpdateMode,
+		Name:               req.Name,
+		Host:               req.Host,
+		SSHPort:            req.SSHPort,
+		SSHUser:            req.SSHUser,
+		AcmeEmail:          req.AcmeEmail,
+		AgentUpdateMode:    req.AgentUpdateMode,
+		AgentInstallMethod: req.AgentInstallMethod,
+		DockerRootless:     req.DockerRootless,
+		FirewallEnabled:    req.FirewallEnabled,
+		SSHHardening:       req.SSHHardening,
+		BastionServerID:    req.BastionServerID,
 	}
 	if err := applyUpdateSSHCredentials(h.tokenEncryptor, &req, &input); err != nil {
-		h.logger.Error("failed to encrypt ssh credentials", "error", err)
+		h.logger.ErrorContext(c.UserContext(), "failed to encrypt ssh credentials", "error", err)
 		return response.InternalError(c)
 	}
 
@@ -401,8 +571,8 @@ func (h *ServerHandler) Update(c *fiber.Ctx) error {
 }
 
 func (h *ServerHandler) Delete(c *fiber.Ctx) error {
-	server, _, err := h.requireServerForUser(c)
-	if err != nil {
+	server, _, ok, err := h.requireServerForUser(c)
+	if !ok {
 		return err
 	}
 
@@ -412,7 +582,7 @@ func (h *ServerHandler) Delete(c *fiber.Ctx) error {
 		sshKey, sshPassword, decErr := h.decryptProvisionCredentials(server)
 		if decErr == nil && (sshKey != "" || sshPassword != "") {
 			if depErr := h.provisioner.Deprovision(server, sshKey, sshPassword); depErr != nil {
-				h.logger.Warn("deprovision failed, deleting from db anyway",
+				h.logger.WarnContext(c.UserContext(), "deprovision failed, deleting from db anyway",
 					"serverId", id, "host", server.Host, "error", depErr)
 			}
 		}
@@ -421,13 +591,16 @@ func (h *ServerHandler) Delete(c *fiber.Ctx) error {
 	if err := h.serverRepo.Delete(id); err != nil {
 		return HandleNotFoundOrInternal(c, err, MsgServerNotFound)
 	}
+	if server.InMesh() && h.provisioner != nil {
+		go h.syncMeshPeers(server.UserID, id)
+	}
 
 	return response.NoContent(c)
 }
 
 func (h *ServerHandler) Provision(c *fiber.Ctx) error {
-	server, _, err := h.requireServerForUser(c)
-	if err != nil {
+	server, _, ok, err := h.requireServerForUser(c)
+	if !ok {
 		return err
 	}
 
@@ -446,6 +619,26 @@ func (h *ServerHandler) Provision(c *fiber.Ctx) error {
 		return response.BadRequest(c, "server has no ssh credentials")
 	}
 
+	if c.QueryBool("dryRun") {
+		plan, err := h.provisioner.PlanProvision(server, sshKey, sshPassword)
+		if err != nil {
+			h.logger.ErrorContext(c.UserContext(), "provision dry run failed", "serverId", id, "error", err)
+			return response.ServerError(c, fiber.StatusBadGateway, fmt.Sprintf("SSH command failed: %s", err))
+		}
+		return response.OK(c, plan)
+	}
+
+	if err := h.runProvision(server, sshKey, sshPassword); err != nil {
+		h.logProvisionFailure(c, id, err)
+		return response.BadRequest(c, err.Error())
+	}
+	return response.OK(c, map[string]string{"message": "provision completed"})
+}
+
+// runProvision provisions the server, streaming progress over SSE and
+// keeping the server status in sync with the outcome.
+func (h *ServerHandler) runProvision(server *domain.Server, sshKey, sshPassword string) error {
+	id := server.ID
 	status := domain.ServerStatusProvisioning
 	_, _ = h.serverRepo.Update(id, domain.UpdateServerInput{Status: &status})
 
@@ -462,14 +655,12 @@ func (h *ServerHandler) Provision(c *fiber.Ctx) error {
 	}
 
 	if err := h.provisioner.Provision(server, sshKey, sshPassword, progress); err != nil {
-		userMsg := err.Error()
 		if h.sseHandler != nil {
-			h.sseHandler.EmitProvisionFailed(id, userMsg)
+			h.sseHandler.EmitProvisionFailed(id, err.Error())
 		}
-		h.logProvisionFailure(c, id, err)
 		errStatus := domain.ServerStatusError
 		_, _ = h.serverRepo.Update(id, domain.UpdateServerInput{Status: &errStatus})
-		return response.BadRequest(c, userMsg)
+		return err
 	}
 
 	if h.sseHandler != nil {
@@ -477,12 +668,12 @@ func (h *ServerHandler) Provision(c *fiber.Ctx) error {
 	}
 	onlineStatus := domain.ServerStatusOnline
 	_, _ = h.serverRepo.Update(id, domain.UpdateServerInput{Status: &onlineStatus})
-	return response.OK(c, map[string]string{"message": "provision completed"})
+	return nil
 }
 
 func (h *ServerHandler) HealthCheck(c *fiber.Ctx) error {
-	server, _, err := h.requireServerForUser(c)
-	if err != nil {
+	server, _, ok, err := h.requireServerForUser(c)
+	if !ok {
 		return err
 	}
 
@@ -495,7 +686,7 @@ func (h *ServerHandler) HealthCheck(c *fiber.Ctx) error {
 
 	latency, err := h.healthChecker.Check(ctx, server.Host, h.agentPort)
 	if err != nil {
-		h.logger.Error("health check failed", "serverId", server.ID, "error", err)
+		h.logger.ErrorContext(c.UserContext(), "health check failed", "serverId", server.ID, "error", err)
 		return response.BadRequest(c, "health check failed")
 	}
 
@@ -506,8 +697,8 @@ func (h *ServerHandler) HealthCheck(c *fiber.Ctx) error {
 }
 
 func (h *ServerHandler) UpdateAgent(c *fiber.Ctx) error {
-	server, _, err := h.requireServerForUser(c)
-	if err != nil {
+	server, _, ok, err := h.requireServerForUser(c)
+	if !ok {
 		return err
 	}
 
@@ -520,6 +711,18 @@ func (h *ServerHandler) UpdateAgent(c *fiber.Ctx) error {
 	return h.updateAgentViaHTTPS(c, id)
 }
 
+func (h *ServerHandler) agentBinaryForServer(ctx context.Context, server *domain.Server) (string, error) {
+	arch := agentdownload.DefaultArch
+	infoCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	if info, err := h.agentClient.GetSystemInfo(infoCtx, server.Host, h.agentPort); err != nil {
+		h.logger.Warn("could not read agent architecture, assuming default", "serverId", server.ID, "arch", arch, "error", err)
+	} else if info.GetArchitecture() != "" {
+		arch = info.GetArchitecture()
+	}
+	return agentdownload.ResolveBinaryPath(h.agentBinaryPath, arch)
+}
+
 func (h *ServerHandler) updateAgentViaGRPC(c *fiber.Ctx, server *domain.Server) error {
 	if h.agentClient == nil || h.agentBinaryPath == "" {
 		return response.ServerError(c, fiber.StatusServiceUnavailable, "gRPC agent update not available")
@@ -537,11 +740,20 @@ func (h *ServerHandler) updateAgentViaGRPC(c *fiber.Ctx, server *domain.Server)
 		pushCtx, pushCancel := context.WithTimeout(context.Background(), 5*time.Minute)
 		defer pushCancel()
 
-		err := h.agentClient.PushUpdate(
+		binaryPath, err := h.agentBinaryForServer(pushCtx, server)
+		if err != nil {
+			h.logger.Error("gRPC push update failed", "serverId", server.ID, "error", err)
+			if h.sseHandler != nil {
+				h.sseHandler.EmitAgentUpdateError(server.ID, "no agent binary for server architecture")
+			}
+			return
+		}
+
+		err = h.agentClient.PushUpdate(
 			pushCtx,
 			server.Host,
 			h.agentPort,
-			h.agentBinaryPath,
+			binaryPath,
 			LatestAgentVersion,
 		)
 		if err != nil {
@@ -580,42 +792,30 @@ func (h *ServerHandler) updateAgentViaHTTPS(c *fiber.Ctx, serverID string) error
 }
 
 func (h *ServerHandler) decryptProvisionCredentials(server *domain.Server) (sshKey, sshPassword string, err error) {
-	sshKey = server.SSHKeyEncrypted
-	if h.tokenEncryptor != nil && sshKey != "" {
-		sshKey, err = h.tokenEncryptor.Decrypt(server.SSHKeyEncrypted)
-		if err != nil {
-			h.logger.Error("failed to decrypt ssh key", "error", err)
-			return "", "", err
-		}
-	}
-	sshPassword = server.SSHPasswordEncrypted
-	if h.tokenEncryptor != nil && sshPassword != "" {
-		sshPassword, err = h.tokenEncryptor.Decrypt(server.SSHPasswordEncrypted)
-		if err != nil {
-			h.logger.Error("failed to decrypt ssh password", "error", err)
-			return "", "", err
-		}
-	}
-	return sshKey, sshPassword, nil
+	return h.credentials().forProvision(server)
+}
+
+func (h *ServerHandler) decryptServerCredentials(server *domain.Server) (sshKey, sshPassword string, err error) {
+	return h.credentials().decrypt(server)
+}
+
+func (h *ServerHandler) credentials() serverCredentials {
+	return serverCredentials{serverRepo: h.serverRepo, tokenEncryptor: h.tokenEncryptor, logger: h.logger}
 }
 
 func (h *ServerHandler) logProvisionFailure(c *fiber.Ctx, serverID string, err error) {
-	attrs := []any{"serverId", serverID, "error", err}
-	if tid, ok := c.Locals("traceId").(string); ok && tid != "" {
-		attrs = append(attrs, "traceId", tid)
-	}
-	h.logger.Error(msgProvisionFailed, attrs...)
+	h.logger.ErrorContext(c.UserContext(), msgProvisionFailed, "serverId", serverID, "error", err)
 }
 
 func (h *ServerHandler) ListServerApps(c *fiber.Ctx) error {
-	server, user, err := h.requireServerForUser(c)
-	if err != nil {
+	server, user, ok, err := h.requireServerForUser(c)
+	if !ok {
 		return err
 	}
 
 	apps, err := h.appService.ListAppsByServerID(server.ID, user.ID)
 	if err != nil {
-		h.logger.Error("failed to list server apps", "serverId", server.ID, "error", err)
+		h.logger.ErrorContext(c.UserContext(), "failed to list server apps", "serverId", server.ID, "error", err)
 		return response.InternalError(c)
 	}
 
@@ -632,8 +832,8 @@ type ManageServerResponse struct {
 }
 
 func (h *ServerHandler) ManageServer(c *fiber.Ctx) error {
-	server, _, err := h.requireServerForUser(c)
-	if err != nil {
+	server, _, ok, err := h.requireServerForUser(c)
+	if !ok {
 		return err
 	}
 
@@ -661,7 +861,7 @@ func (h *ServerHandler) ManageServer(c *fiber.Ctx) error {
 
 	result, err := h.provisioner.ManageServer(server, sshKey, sshPassword, provisioner.ManageAction(req.Action))
 	if err != nil {
-		h.logger.Error("manage server failed", "serverId", server.ID, "action", req.Action, "error", err)
+		h.logger.ErrorContext(c.UserContext(), "manage server failed", "serverId", server.ID, "action", req.Action, "error", err)
 		return response.ServerError(c, fiber.StatusBadGateway, fmt.Sprintf("SSH command failed: %s", err))
 	}
 
@@ -670,3 +870,166 @@ func (h *ServerHandler) ManageServer(c *fiber.Ctx) error {
 		Output:  result.Output,
 	})
 }
+
+type RevokeCertRequest struct {
+	Reason string `json:"reason"`
+}
+
+func (h *ServerHandler) RevokeCert(c *fiber.Ctx) error {
+	user := GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, MsgNotAuthenticated)
+	}
+	if !user.IsAdmin() {
+		return response.Forbidden(c, "certificate revocation requires admin role")
+	}
+
+	server, err := h.serverRepo.FindByID(c.Params("id"))
+	if err != nil {
+		return HandleNotFoundOrInternal(c, err, MsgServerNotFound)
+	}
+
+	var req RevokeCertRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return response.BadRequest(c, MsgInvalidRequestBody)
+		}
+	}
+
+	if h.certRevoker == nil {
+		return response.ServerError(c, fiber.StatusServiceUnavailable, "certificate revocation not available")
+	}
+
+	revocation, err := h.certRevoker.RevokeServerCert(server.ID, strings.TrimSpace(req.Reason), user.ID)
+	if err != nil {
+		h.logger.ErrorContext(c.UserContext(), "failed to revoke agent certificate", "serverId", server.ID, "error", err)
+		return response.InternalError(c)
+	}
+
+	errStatus := domain.ServerStatusError
+	_, _ = h.serverRepo.Update(server.ID, domain.UpdateServerInput{Status: &errStatus})
+
+	return response.OK(c, revocation)
+}
+
+func (h *ServerHandler) ListCommands(c *fiber.Ctx) error {
+	server, _, ok, err := h.requireServerForUser(c)
+	if !ok {
+		return err
+	}
+
+	if h.commandRepo == nil {
+		return response.OK(c, []domain.AgentCommand{})
+	}
+
+	commands, err := h.commandRepo.FindByServerID(server.ID, c.QueryInt("limit", 50))
+	if err != nil {
+		h.logger.ErrorContext(c.UserContext(), "failed to list agent commands", "serverId", server.ID, "error", err)
+		return response.InternalError(c)
+	}
+	if commands == nil {
+		commands = []domain.AgentCommand{}
+	}
+
+	return response.OK(c, commands)
+}
+
+var availabilityWindows = map[string]time.Duration{
+	"24h": 24 * time.Hour,
+	"7d":  7 * 24 * time.Hour,
+	"30d": domain.ServerHeartbeatRetention,
+}
+
+func (h *ServerHandler) GetAvailability(c *fiber.Ctx) error {
+	server, _, ok, err := h.requireServerForUser(c)
+	if !ok {
+		return err
+	}
+
+	window, ok := availabilityWindows[c.Query("window", "24h")]
+	if !ok {
+		return response.BadRequest(c, "window must be one of 24h, 7d, 30d")
+	}
+	if h.heartbeatRepo == nil {
+		return response.ServerError(c, fiber.StatusServiceUnavailable, "heartbeat history is not available")
+	}
+
+	to := time.Now()
+	from := to.Add(-window)
+	if server.CreatedAt.After(from) {
+		from = server.CreatedAt
+	}
+
+	heartbeats, err := h.heartbeatRepo.FindSince(server.ID, from)
+	if err != nil {
+		h.logger.ErrorContext(c.UserContext(), "failed to load heartbeat history", "serverId", server.ID, "error", err)
+		return response.InternalError(c)
+	}
+
+	return response.OK(c, domain.ComputeServerAvailability(server.ID, heartbeats, from, to, domain.ServerOfflineThreshold()))
+}
+
+type AgentLogsResponse struct {
+	Lines  []string `json:"lines"`
+	Source string   `json:"source"`
+}
+
+type RotateAgentLogsResponse struct {
+	Success     bool   `json:"success"`
+	Message     string `json:"message"`
+	RotatedFile string `json:"rotatedFile,omitempty"`
+}
+
+func (h *ServerHandler) GetAgentLogs(c *fiber.Ctx) error {
+	server, _, ok, err := h.requireServerForUser(c)
+	if !ok {
+		return err
+	}
+
+	if h.agentClient == nil || h.agentPort == 0 {
+		return response.ServerError(c, fiber.StatusServiceUnavailable, "agent logs not available")
+	}
+
+	var since time.Duration
+	if raw := c.Query("since"); raw != "" {
+		since, err = time.ParseDuration(raw)
+		if err != nil || since < 0 {
+			return response.BadRequest(c, "since must be a positive duration, e.g. 30m or 2h")
+		}
+	}
+
+	logs, err := h.agentClient.GetAgentLogs(c.Context(), server.Host, h.agentPort, c.QueryInt("lines", 200), since)
+	if err != nil {
+		h.logger.WarnContext(c.UserContext(), "get agent logs failed", "serverId", server.ID, "error", err)
+		return response.ServerError(c, fiber.StatusServiceUnavailable, "failed to fetch agent logs; check if the agent is running and reachable")
+	}
+
+	lines := logs.GetLines()
+	if lines == nil {
+		lines = []string{}
+	}
+	return response.OK(c, AgentLogsResponse{Lines: lines, Source: logs.GetSource()})
+}
+
+func (h *ServerHandler) RotateAgentLogs(c *fiber.Ctx) error {
+	server, _, ok, err := h.requireServerForUser(c)
+	if !ok {
+		return err
+	}
+
+	if h.agentClient == nil || h.agentPort == 0 {
+		return response.ServerError(c, fiber.StatusServiceUnavailable, "agent logs not available")
+	}
+
+	result, err := h.agentClient.RotateAgentLogs(c.Context(), server.Host, h.agentPort)
+	if err != nil {
+		h.logger.WarnContext(c.UserContext(), "rotate agent logs failed", "serverId", server.ID, "error", err)
+		return response.ServerError(c, fiber.StatusServiceUnavailable, "failed to rotate agent logs; check if the agent is running and reachable")
+	}
+
+	return response.OK(c, RotateAgentLogsResponse{
+		Success:     result.GetSuccess(),
+		Message:     result.GetMessage(),
+		RotatedFile: result.GetRotatedFile(),
+	})
+}
@@ -0,0 +1,191 @@
+package handler
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/paasdeploy/backend/internal/domain"
+	"github.com/paasdeploy/backend/internal/response"
+)
+
+const maxImportApps = 50
+
+type DiscoveredAppResponse struct {
+	Name       string                  `json:"name"`
+	EnvVarKeys []string                `json:"envVarKeys"`
+	Domains    []string                `json:"domains"`
+	Volumes    []domain.ImportedVolume `json:"volumes"`
+	Port       int                     `json:"port"`
+}
+
+type ImportAppRequest struct {
+	Source        string `json:"source"`
+	Name          string `json:"name"`
+	RepositoryURL string `json:"repositoryUrl"`
+	Branch        string `json:"branch"`
+	Workdir       string `json:"workdir"`
+}
+
+type ImportAppsRequest struct {
+	Apps []ImportAppRequest `json:"apps"`
+}
+
+type ImportAppResult struct {
+	Source string                  `json:"source"`
+	App    *domain.App             `json:"app,omitempty"`
+	Config *domain.SuggestedConfig `json:"config,omitempty"`
+	Error  string                  `json:"error,omitempty"`
+}
+
+// discoverApps reads the apps of the Dokku or CapRover install on the
+// server named in the route over SSH. When it returns false it has already
+// sent the error response.
+func (h *ServerHandler) discoverApps(c *fiber.Ctx) (*domain.Server, *domain.User, []domain.ImportedApp, bool, error) {
+	server, user, ok, err := h.requireServerForUser(c)
+	if !ok {
+		return nil, nil, nil, false, err
+	}
+	platform := c.Params("platform")
+	if !domain.IsValidImportPlatform(platform) {
+		return nil, nil, nil, false, response.BadRequest(c, "unsupported platform: use dokku or caprover")
+	}
+	if h.provisioner == nil {
+		return nil, nil, nil, false, response.BadRequest(c, "import not available: SSH provisioner not configured")
+	}
+
+	sshKey, sshPassword, err := h.decryptProvisionCredentials(server)
+	if err != nil {
+		return nil, nil, nil, false, response.InternalError(c)
+	}
+	if sshKey == "" && sshPassword == "" {
+		return nil, nil, nil, false, response.BadRequest(c, "server has no ssh credentials")
+	}
+
+	apps, err := h.provisioner.DiscoverApps(server, sshKey, sshPassword, platform)
+	if err != nil {
+		h.logger.ErrorContext(c.UserContext(), "discover apps failed", "serverId", server.ID, "platform", platform, "error", err)
+		return nil, nil, nil, false, response.ServerError(c, fiber.StatusBadGateway, fmt.Sprintf("Failed to read %s apps: %s", platform, err))
+	}
+	return server, user, apps, true, nil
+}
+
+// DiscoverImportableApps lists the apps that can be imported from the
+// server. Env var values are not returned, only their keys.
+func (h *ServerHandler) DiscoverImportableApps(c *fiber.Ctx) error {
+	_, _, apps, ok, err := h.discoverApps(c)
+	if !ok {
+		return err
+	}
+
+	result := make([]DiscoveredAppResponse, 0, len(apps))
+	for _, app := range apps {
+		keys := make([]string, 0, len(app.EnvVars))
+		for k := range app.EnvVars {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		result = append(result, DiscoveredAppResponse{
+			Name:       app.Name,
+			EnvVarKeys: keys,
+			Domains:    nonNilStrings(app.Domains),
+			Volumes:    nonNilVolumes(app.Volumes),
+			Port:       app.Port,
+		})
+	}
+	return response.OK(c, result)
+}
+
+// ImportApps creates a managed app on the server for each selected app,
+// with its env vars. Domains and volumes go into the suggested
+// paasdeploy.json returned for each app.
+func (h *ServerHandler) ImportApps(c *fiber.Ctx) error {
+	var req ImportAppsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.BadRequest(c, MsgInvalidRequestBody)
+	}
+	if ok, err := validateImportCount(c, len(req.Apps)); !ok {
+		return err
+	}
+
+	server, user, apps, ok, err := h.discoverApps(c)
+	if !ok {
+		return err
+	}
+	byName := make(map[string]*domain.ImportedApp, len(apps))
+	for i := range apps {
+		byName[apps[i].Name] = &apps[i]
+	}
+
+	results := make([]ImportAppResult, 0, len(req.Apps))
+	for _, r := range req.Apps {
+		results = append(results, h.importApp(c, server, user, byName[r.Source], r))
+	}
+	return response.OK(c, results)
+}
+
+// importApp creates a managed app on server from source, which is nil when
+// the requested app was not found.
+func (h *ServerHandler) importApp(c *fiber.Ctx, server *domain.Server, user *domain.User, source *domain.ImportedApp, r ImportAppRequest) ImportAppResult {
+	result := ImportAppResult{Source: r.Source}
+	if source == nil {
+		result.Error = "app not found on server"
+		return result
+	}
+
+	name := strings.TrimSpace(r.Name)
+	if name == "" {
+		name = source.Name
+	}
+	app, err := h.appService.ImportApp(c.Context(), domain.CreateAppInput{
+		UserID:        user.ID,
+		Name:          name,
+		RepositoryURL: r.RepositoryURL,
+		Branch:        r.Branch,
+		Workdir:       r.Workdir,
+		ServerID:      &server.ID,
+	}, source.EnvVarInputs())
+	if err != nil {
+		h.logger.WarnContext(c.UserContext(), "import app failed", "serverId", server.ID, "source", r.Source, "error", err)
+		result.Error = importErrorMessage(err)
+		return result
+	}
+
+	config := source.SuggestedConfig(name, "other")
+	result.App, result.Config = app, &config
+	h.logger.InfoContext(c.UserContext(), "app imported", "appId", app.ID, "serverId", server.ID, "source", r.Source)
+	return result
+}
+
+func validateImportCount(c *fiber.Ctx, n int) (bool, error) {
+	if n == 0 {
+		return false, response.BadRequest(c, "select at least one app to import")
+	}
+	if n > maxImportApps {
+		return false, response.BadRequest(c, fmt.Sprintf("at most %d apps can be imported at once", maxImportApps))
+	}
+	return true, nil
+}
+
+func importErrorMessage(err error) string {
+	if isKnownDomainError(err) {
+		return err.Error()
+	}
+	return "failed to create app"
+}
+
+func nonNilStrings(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
+
+func nonNilVolumes(v []domain.ImportedVolume) []domain.ImportedVolume {
+	if v == nil {
+		return []domain.ImportedVolume{}
+	}
+	return v
+}
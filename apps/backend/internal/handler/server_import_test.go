@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/paasdeploy/backend/internal/domain"
+)
+
+func importAppsBody(n int) string {
+	apps := make([]string, n)
+	for i := range apps {
+		apps[i] = fmt.Sprintf(`{"source":"app-%d"}`, i)
+	}
+	return `{"apps":[` + strings.Join(apps, ",") + `]}`
+}
+
+func TestImportAppsValidatesRequest(t *testing.T) {
+	servers := &fakeServerRepo{servers: map[string]domain.Server{"srv-1": {ID: "srv-1"}}}
+	app := newTestApp(testOwner)
+	NewServerHandler(servers, nil, nil, nil, ServerHandlerAgentDeps{}, nil, nil, testLogger()).Register(app)
+
+	tests := []struct {
+		name, method, path, body, detail string
+	}{
+		{"too many apps", fiber.MethodPost, "/servers/srv-1/import/dokku", importAppsBody(maxImportApps + 1), fmt.Sprintf("at most %d apps", maxImportApps)},
+		{"no apps", fiber.MethodPost, "/servers/srv-1/import/dokku", importAppsBody(0), "select at least one app"},
+		{"unsupported platform", fiber.MethodPost, "/servers/srv-1/import/heroku", importAppsBody(1), "unsupported platform"},
+		{"discover without provisioner", fiber.MethodGet, "/servers/srv-1/import/dokku", "", "SSH provisioner not configured"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := doRequest(t, app, tt.method, APIPrefix+tt.path, tt.body)
+			if resp.StatusCode != http.StatusBadRequest {
+				t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusBadRequest)
+			}
+			var problem struct {
+				Detail string `json:"detail"`
+			}
+			if err := json.NewDecoder(resp.Body).Decode(&problem); err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(problem.Detail, tt.detail) {
+				t.Errorf("detail = %q, want it to mention %q", problem.Detail, tt.detail)
+			}
+		})
+	}
+}
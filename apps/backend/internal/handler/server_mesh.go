@@ -0,0 +1,120 @@
+package handler
+
+import (
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/paasdeploy/backend/internal/domain"
+	"github.com/paasdeploy/backend/internal/response"
+)
+
+// EnableMesh joins the server to the user's WireGuard mesh so its apps can
+// reach apps on the other members over private addresses. The existing
+// members are then updated with the new peer in the background.
+func (h *ServerHandler) EnableMesh(c *fiber.Ctx) error {
+	server, user, ok, err := h.requireServerForUser(c)
+	if !ok {
+		return err
+	}
+	if h.provisioner == nil {
+		return response.BadRequest(c, "mesh not available: SSH provisioner not configured")
+	}
+
+	sshKey, sshPassword, err := h.decryptProvisionCredentials(server)
+	if err != nil {
+		return response.InternalError(c)
+	}
+	if sshKey == "" && sshPassword == "" {
+		return response.BadRequest(c, "server has no ssh credentials")
+	}
+
+	servers, err := h.serverRepo.FindAllByUserID(user.ID)
+	if err != nil {
+		h.logger.ErrorContext(c.UserContext(), "failed to list servers", "userId", user.ID, "error", err)
+		return response.InternalError(c)
+	}
+	if !server.InMesh() {
+		used := make([]string, 0, len(servers))
+		for _, s := range servers {
+			if s.InMesh() {
+				used = append(used, s.MeshIP)
+			}
+		}
+		meshIP, err := domain.AllocateMeshIP(used)
+		if err != nil {
+			return response.BadRequest(c, err.Error())
+		}
+		server.MeshIP = meshIP
+	}
+
+	publicKey, err := h.provisioner.ConfigureMesh(server, sshKey, sshPassword, domain.MeshPeers(server, servers))
+	if err != nil {
+		h.logger.ErrorContext(c.UserContext(), "configure mesh failed", "serverId", server.ID, "error", err)
+		return response.ServerError(c, fiber.StatusBadGateway, fmt.Sprintf("Failed to configure mesh: %s", err))
+	}
+	if err := h.serverRepo.UpdateMesh(server.ID, server.MeshIP, publicKey); err != nil {
+		h.logger.ErrorContext(c.UserContext(), "failed to save mesh settings", "serverId", server.ID, "error", err)
+		return response.InternalError(c)
+	}
+	server.MeshPublicKey = publicKey
+
+	go h.syncMeshPeers(user.ID, server.ID)
+	return response.OK(c, toServerResponse(server))
+}
+
+// DisableMesh removes the server from the mesh and drops it from the peer
+// lists of the remaining members.
+func (h *ServerHandler) DisableMesh(c *fiber.Ctx) error {
+	server, user, ok, err := h.requireServerForUser(c)
+	if !ok {
+		return err
+	}
+	if !server.InMesh() {
+		return response.OK(c, toServerResponse(server))
+	}
+	if h.provisioner == nil {
+		return response.BadRequest(c, "mesh not available: SSH provisioner not configured")
+	}
+
+	server.MeshIP, server.MeshPublicKey = "", ""
+	sshKey, sshPassword, err := h.decryptProvisionCredentials(server)
+	if err != nil {
+		return response.InternalError(c)
+	}
+	if err := h.provisioner.RemoveMesh(server, sshKey, sshPassword); err != nil {
+		h.logger.ErrorContext(c.UserContext(), "remove mesh failed", "serverId", server.ID, "error", err)
+		return response.ServerError(c, fiber.StatusBadGateway, fmt.Sprintf("Failed to remove mesh: %s", err))
+	}
+	if err := h.serverRepo.UpdateMesh(server.ID, "", ""); err != nil {
+		h.logger.ErrorContext(c.UserContext(), "failed to save mesh settings", "serverId", server.ID, "error", err)
+		return response.InternalError(c)
+	}
+
+	go h.syncMeshPeers(user.ID, server.ID)
+	return response.OK(c, toServerResponse(server))
+}
+
+// syncMeshPeers rewrites the peer list on every mesh member except skipID.
+// Failures are logged; the next sync or an explicit enable retries them.
+func (h *ServerHandler) syncMeshPeers(userID, skipID string) {
+	servers, err := h.serverRepo.FindAllByUserID(userID)
+	if err != nil {
+		h.logger.Error("mesh sync: failed to list servers", "userId", userID, "error", err)
+		return
+	}
+	for i := range servers {
+		member := &servers[i]
+		if member.ID == skipID || !member.InMesh() {
+			continue
+		}
+		sshKey, sshPassword, err := h.decryptProvisionCredentials(member)
+		if err != nil || (sshKey == "" && sshPassword == "") {
+			h.logger.Warn("mesh sync: no usable ssh credentials", "serverId", member.ID)
+			continue
+		}
+		if _, err := h.provisioner.ConfigureMesh(member, sshKey, sshPassword, domain.MeshPeers(member, servers)); err != nil {
+			h.logger.Warn("mesh sync failed", "serverId", member.ID, "error", err)
+		}
+	}
+}
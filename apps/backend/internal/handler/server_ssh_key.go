@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/paasdeploy/backend/internal/crypto"
+	"github.com/paasdeploy/backend/internal/domain"
+	"github.com/paasdeploy/backend/internal/response"
+)
+
+type ServerSSHKeyResponse struct {
+	PublicKey string `json:"publicKey"`
+}
+
+func managedKeyComment(serverName string) string {
+	return "paasdeploy@" + serverName
+}
+
+// GenerateSSHKey replaces the server's SSH key with a new key pair managed by
+// the backend. Only the public key is returned; it must be added to the
+// server's authorized_keys, which the next provision does automatically when
+// it can still log in with the password.
+func (h *ServerHandler) GenerateSSHKey(c *fiber.Ctx) error {
+	server, _, ok, err := h.requireServerForUser(c)
+	if !ok {
+		return err
+	}
+
+	privateKey, publicKey, err := crypto.GenerateSSHKeyPair(managedKeyComment(server.Name))
+	if err != nil {
+		h.logger.ErrorContext(c.UserContext(), "failed to generate ssh key pair", "serverId", server.ID, "error", err)
+		return response.InternalError(c)
+	}
+	encrypted, err := encryptCredential(h.tokenEncryptor, privateKey)
+	if err != nil {
+		h.logger.ErrorContext(c.UserContext(), "failed to encrypt ssh key", "serverId", server.ID, "error", err)
+		return response.InternalError(c)
+	}
+
+	if _, err := h.serverRepo.Update(server.ID, domain.UpdateServerInput{
+		SSHKeyEncrypted: &encrypted,
+		SSHPublicKey:    &publicKey,
+	}); err != nil {
+		h.logger.ErrorContext(c.UserContext(), "failed to store ssh key pair", "serverId", server.ID, "error", err)
+		return response.InternalError(c)
+	}
+
+	h.logger.InfoContext(c.UserContext(), "managed ssh key generated", "serverId", server.ID)
+	return response.OK(c, ServerSSHKeyResponse{PublicKey: publicKey})
+}
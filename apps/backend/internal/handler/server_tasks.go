@@ -0,0 +1,377 @@
+package handler
+
+import (
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/paasdeploy/backend/internal/domain"
+	"github.com/paasdeploy/backend/internal/response"
+	"github.com/paasdeploy/backend/internal/service"
+)
+
+const (
+	maxServerTasks          = 50
+	maxServerTaskNameLength = 100
+	maxServerTaskCommand    = 8 << 10
+	defaultServerTaskRuns   = 20
+)
+
+// ServerTaskRunner starts a run of a server task in the background.
+type ServerTaskRunner interface {
+	RunTask(task domain.ServerTask, trigger string) (*domain.ServerTaskRun, error)
+}
+
+// ServerTaskHandler manages the scheduled tasks of a server. Tasks run shell
+// commands on the host as the agent's user, so only admins can change or
+// run them; server owners can see them and their history.
+type ServerTaskHandler struct {
+	serverRepo   domain.ServerRepository
+	taskRepo     domain.ServerTaskRepository
+	runner       ServerTaskRunner
+	auditService *service.AuditService
+	logger       *slog.Logger
+}
+
+func NewServerTaskHandler(
+	serverRepo domain.ServerRepository,
+	taskRepo domain.ServerTaskRepository,
+	runner ServerTaskRunner,
+	auditService *service.AuditService,
+	logger *slog.Logger,
+) *ServerTaskHandler {
+	return &ServerTaskHandler{
+		serverRepo:   serverRepo,
+		taskRepo:     taskRepo,
+		runner:       runner,
+		auditService: auditService,
+		logger:       logger.With("handler", "server_task"),
+	}
+}
+
+func (h *ServerTaskHandler) Register(app fiber.Router) {
+	tasks := app.Group(APIPrefix + "/servers/:id/tasks")
+	tasks.Get("/", h.List)
+	tasks.Post("/", h.Create)
+	tasks.Patch("/:taskId", h.Update)
+	tasks.Delete("/:taskId", h.Delete)
+	tasks.Post("/:taskId/run", h.Run)
+	tasks.Get("/:taskId/runs", h.ListRuns)
+}
+
+type CreateServerTaskRequest struct {
+	Name            string `json:"name"`
+	Schedule        string `json:"schedule"`
+	Command         string `json:"command"`
+	TimeoutSeconds  int    `json:"timeoutSeconds"`
+	Enabled         *bool  `json:"enabled"`
+	NotifyOnFailure *bool  `json:"notifyOnFailure"`
+}
+
+type UpdateServerTaskRequest struct {
+	Name            *string `json:"name"`
+	Schedule        *string `json:"schedule"`
+	Command         *string `json:"command"`
+	TimeoutSeconds  *int    `json:"timeoutSeconds"`
+	Enabled         *bool   `json:"enabled"`
+	NotifyOnFailure *bool   `json:"notifyOnFailure"`
+}
+
+// requireServer loads the server in the route for the user. When it
+// returns false it has already sent the error response, which the caller
+// returns.
+func (h *ServerTaskHandler) requireServer(c *fiber.Ctx, admin bool) (*domain.Server, bool, error) {
+	user := GetUserFromContext(c)
+	if user == nil {
+		return nil, false, response.Unauthorized(c, MsgNotAuthenticated)
+	}
+	if admin && !user.IsAdmin() {
+		return nil, false, response.Forbidden(c, "Managing server tasks requires admin role")
+	}
+	server, err := h.serverRepo.FindByIDForUser(c.Params("id"), user.ID)
+	if err != nil {
+		return nil, false, response.NotFound(c, MsgServerNotFound)
+	}
+	return server, true, nil
+}
+
+func (h *ServerTaskHandler) requireTask(c *fiber.Ctx, admin bool) (*domain.ServerTask, bool, error) {
+	server, ok, err := h.requireServer(c, admin)
+	if !ok {
+		return nil, false, err
+	}
+	task, err := h.taskRepo.FindByIDAndServerID(c.Params("taskId"), server.ID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, false, response.NotFound(c, "Task not found")
+		}
+		h.logger.ErrorContext(c.UserContext(), "Failed to find server task", "error", err)
+		return nil, false, response.InternalError(c)
+	}
+	return task, true, nil
+}
+
+func validateServerTaskName(name string) (string, string) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return name, "Task name is required"
+	}
+	if len(name) > maxServerTaskNameLength {
+		return name, "Task name must be at most 100 characters"
+	}
+	return name, ""
+}
+
+func validateServerTaskCommand(command string) (string, string) {
+	command = strings.TrimSpace(command)
+	if command == "" {
+		return command, "Task command is required"
+	}
+	if len(command) > maxServerTaskCommand {
+		return command, "Task command must be at most 8 KiB"
+	}
+	return command, ""
+}
+
+func validateServerTaskSchedule(schedule string) (string, domain.CronSchedule, string) {
+	schedule = strings.Join(strings.Fields(schedule), " ")
+	parsed, err := domain.ParseCronSchedule(schedule)
+	if err != nil {
+		return schedule, parsed, "Invalid schedule: " + err.Error()
+	}
+	if parsed.Next(time.Now()).IsZero() {
+		return schedule, parsed, "Schedule never runs"
+	}
+	return schedule, parsed, ""
+}
+
+func validateServerTaskTimeout(seconds int) (int, string) {
+	if seconds == 0 {
+		return domain.DefaultServerTaskTimeoutSeconds, ""
+	}
+	if seconds < 1 || seconds > domain.MaxServerTaskTimeoutSeconds {
+		return seconds, "Timeout must be between 1 and 3600 seconds"
+	}
+	return seconds, ""
+}
+
+// nextServerTaskRun is when an enabled task runs next; disabled tasks have
+// no next run.
+func nextServerTaskRun(schedule domain.CronSchedule, enabled bool) *time.Time {
+	if !enabled {
+		return nil
+	}
+	next := schedule.Next(time.Now())
+	return &next
+}
+
+func (h *ServerTaskHandler) List(c *fiber.Ctx) error {
+	server, ok, err := h.requireServer(c, false)
+	if !ok {
+		return err
+	}
+	tasks, err := h.taskRepo.FindByServerID(server.ID)
+	if err != nil {
+		h.logger.ErrorContext(c.UserContext(), "Failed to list server tasks", "serverId", server.ID, "error", err)
+		return response.InternalError(c)
+	}
+	return response.OK(c, tasks)
+}
+
+func (h *ServerTaskHandler) Create(c *fiber.Ctx) error {
+	server, ok, err := h.requireServer(c, true)
+	if !ok {
+		return err
+	}
+
+	var req CreateServerTaskRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.BadRequest(c, MsgInvalidRequestBody)
+	}
+	name, msg := validateServerTaskName(req.Name)
+	if msg != "" {
+		return response.BadRequest(c, msg)
+	}
+	command, msg := validateServerTaskCommand(req.Command)
+	if msg != "" {
+		return response.BadRequest(c, msg)
+	}
+	scheduleExpr, schedule, msg := validateServerTaskSchedule(req.Schedule)
+	if msg != "" {
+		return response.BadRequest(c, msg)
+	}
+	timeout, msg := validateServerTaskTimeout(req.TimeoutSeconds)
+	if msg != "" {
+		return response.BadRequest(c, msg)
+	}
+
+	count, err := h.taskRepo.CountByServerID(server.ID)
+	if err != nil {
+		h.logger.ErrorContext(c.UserContext(), "Failed to count server tasks", "serverId", server.ID, "error", err)
+		return response.InternalError(c)
+	}
+	if count >= maxServerTasks {
+		return response.BadRequest(c, "A server can have at most 50 tasks")
+	}
+
+	enabled := req.Enabled == nil || *req.Enabled
+	task, err := h.taskRepo.Create(domain.CreateServerTaskInput{
+		ServerID:        server.ID,
+		Name:            name,
+		Schedule:        scheduleExpr,
+		Command:         command,
+		TimeoutSeconds:  timeout,
+		Enabled:         enabled,
+		NotifyOnFailure: req.NotifyOnFailure == nil || *req.NotifyOnFailure,
+		NextRunAt:       nextServerTaskRun(schedule, enabled),
+	})
+	if err != nil {
+		h.logger.ErrorContext(c.UserContext(), "Failed to create server task", "serverId", server.ID, "error", err)
+		return response.InternalError(c)
+	}
+
+	if h.auditService != nil {
+		h.auditService.LogServerTaskCreated(c.Context(), h.auditService.ExtractContext(c), *task)
+	}
+	h.logger.InfoContext(c.UserContext(), "Server task created", "serverId", server.ID, "taskId", task.ID)
+	return response.Created(c, task)
+}
+
+func (h *ServerTaskHandler) Update(c *fiber.Ctx) error {
+	task, ok, err := h.requireTask(c, true)
+	if !ok {
+		return err
+	}
+
+	var req UpdateServerTaskRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.BadRequest(c, MsgInvalidRequestBody)
+	}
+
+	input := domain.UpdateServerTaskInput{Enabled: req.Enabled, NotifyOnFailure: req.NotifyOnFailure}
+	var fields []string
+	if req.Name != nil {
+		name, msg := validateServerTaskName(*req.Name)
+		if msg != "" {
+			return response.BadRequest(c, msg)
+		}
+		input.Name = &name
+		fields = append(fields, "name")
+	}
+	if req.Command != nil {
+		command, msg := validateServerTaskCommand(*req.Command)
+		if msg != "" {
+			return response.BadRequest(c, msg)
+		}
+		input.Command = &command
+		fields = append(fields, "command")
+	}
+	scheduleExpr := task.Schedule
+	if req.Schedule != nil {
+		expr, _, msg := validateServerTaskSchedule(*req.Schedule)
+		if msg != "" {
+			return response.BadRequest(c, msg)
+		}
+		input.Schedule = &expr
+		scheduleExpr = expr
+		fields = append(fields, "schedule")
+	}
+	if req.TimeoutSeconds != nil {
+		timeout, msg := validateServerTaskTimeout(*req.TimeoutSeconds)
+		if msg != "" {
+			return response.BadRequest(c, msg)
+		}
+		input.TimeoutSeconds = &timeout
+		fields = append(fields, "timeoutSeconds")
+	}
+	if req.Enabled != nil {
+		fields = append(fields, "enabled")
+	}
+	if req.NotifyOnFailure != nil {
+		fields = append(fields, "notifyOnFailure")
+	}
+
+	enabled := task.Enabled
+	if req.Enabled != nil {
+		enabled = *req.Enabled
+	}
+	if schedule, err := domain.ParseCronSchedule(scheduleExpr); err == nil {
+		input.NextRunAt = nextServerTaskRun(schedule, enabled)
+	}
+
+	updated, err := h.taskRepo.Update(task.ID, input)
+	if err != nil {
+		h.logger.ErrorContext(c.UserContext(), "Failed to update server task", "taskId", task.ID, "error", err)
+		return response.InternalError(c)
+	}
+
+	if h.auditService != nil {
+		h.auditService.LogServerTaskUpdated(c.Context(), h.auditService.ExtractContext(c), *updated, fields)
+	}
+	return response.OK(c, updated)
+}
+
+func (h *ServerTaskHandler) Delete(c *fiber.Ctx) error {
+	task, ok, err := h.requireTask(c, true)
+	if !ok {
+		return err
+	}
+	if err := h.taskRepo.Delete(task.ID); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return response.NotFound(c, "Task not found")
+		}
+		h.logger.ErrorContext(c.UserContext(), "Failed to delete server task", "taskId", task.ID, "error", err)
+		return response.InternalError(c)
+	}
+
+	if h.auditService != nil {
+		h.auditService.LogServerTaskDeleted(c.Context(), h.auditService.ExtractContext(c), *task)
+	}
+	return response.NoContent(c)
+}
+
+// Run starts the task now, outside its schedule. The run is returned while
+// it is still running; its outcome shows up in the task's runs.
+func (h *ServerTaskHandler) Run(c *fiber.Ctx) error {
+	task, ok, err := h.requireTask(c, true)
+	if !ok {
+		return err
+	}
+	if h.runner == nil {
+		return response.ServerError(c, fiber.StatusServiceUnavailable, "Server tasks are not available")
+	}
+
+	run, err := h.runner.RunTask(*task, domain.ServerTaskTriggerManual)
+	if err != nil {
+		if errors.Is(err, domain.ErrServerTaskRunning) {
+			return response.Conflict(c, "Task is already running")
+		}
+		h.logger.ErrorContext(c.UserContext(), "Failed to run server task", "taskId", task.ID, "error", err)
+		return response.InternalError(c)
+	}
+
+	if h.auditService != nil {
+		h.auditService.LogServerTaskRun(c.Context(), h.auditService.ExtractContext(c), *task, run.ID)
+	}
+	return response.Accepted(c, run)
+}
+
+func (h *ServerTaskHandler) ListRuns(c *fiber.Ctx) error {
+	task, ok, err := h.requireTask(c, false)
+	if !ok {
+		return err
+	}
+	limit := c.QueryInt("limit", defaultServerTaskRuns)
+	if limit < 1 || limit > domain.ServerTaskRunRetention {
+		limit = defaultServerTaskRuns
+	}
+	runs, err := h.taskRepo.FindRuns(task.ID, limit)
+	if err != nil {
+		h.logger.ErrorContext(c.UserContext(), "Failed to list server task runs", "taskId", task.ID, "error", err)
+		return response.InternalError(c)
+	}
+	return response.OK(c, runs)
+}
@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/paasdeploy/backend/internal/domain"
+)
+
+type fakeServerTaskRepo struct {
+	domain.ServerTaskRepository
+	tasks   map[string]domain.ServerTask
+	deleted []string
+}
+
+func (r *fakeServerTaskRepo) FindByIDAndServerID(id, serverID string) (*domain.ServerTask, error) {
+	t, ok := r.tasks[id]
+	if !ok || t.ServerID != serverID {
+		return nil, domain.ErrNotFound
+	}
+	return &t, nil
+}
+
+func (r *fakeServerTaskRepo) FindByServerID(serverID string) ([]domain.ServerTask, error) {
+	tasks := []domain.ServerTask{}
+	for _, t := range r.tasks {
+		if t.ServerID == serverID {
+			tasks = append(tasks, t)
+		}
+	}
+	return tasks, nil
+}
+
+func (r *fakeServerTaskRepo) FindRuns(string, int) ([]domain.ServerTaskRun, error) {
+	return []domain.ServerTaskRun{}, nil
+}
+
+func (r *fakeServerTaskRepo) Delete(id string) error {
+	r.deleted = append(r.deleted, id)
+	return nil
+}
+
+type fakeTaskRunner struct {
+	started []string
+}
+
+func (r *fakeTaskRunner) RunTask(task domain.ServerTask, trigger string) (*domain.ServerTaskRun, error) {
+	r.started = append(r.started, task.ID)
+	return &domain.ServerTaskRun{ID: "run-1", TaskID: task.ID, Trigger: trigger, Status: domain.ServerTaskRunStatusRunning}, nil
+}
+
+func newServerTaskTestApp(user *domain.User) (*fiber.App, *fakeServerTaskRepo, *fakeTaskRunner) {
+	servers := &fakeServerRepo{servers: map[string]domain.Server{"srv-1": {ID: "srv-1"}}}
+	tasks := &fakeServerTaskRepo{tasks: map[string]domain.ServerTask{
+		"task-1": {ID: "task-1", ServerID: "srv-1", Name: "cleanup", Schedule: "@daily", Command: "true"},
+	}}
+	runner := &fakeTaskRunner{}
+	app := newTestApp(user)
+	NewServerTaskHandler(servers, tasks, runner, nil, testLogger()).Register(app)
+	return app, tasks, runner
+}
+
+func TestServerTaskHandlerRequiresAdminToManageTasks(t *testing.T) {
+	const tasksPath = APIPrefix + "/servers/srv-1/tasks"
+	tests := []struct {
+		method, path, body string
+	}{
+		{fiber.MethodPost, tasksPath, `{"name":"x","schedule":"@daily","command":"true"}`},
+		{fiber.MethodPatch, tasksPath + "/task-1", `{"enabled":false}`},
+		{fiber.MethodDelete, tasksPath + "/task-1", ""},
+		{fiber.MethodPost, tasksPath + "/task-1/run", ""},
+	}
+	for _, tt := range tests {
+		app, tasks, runner := newServerTaskTestApp(testOwner)
+		resp := doRequest(t, app, tt.method, tt.path, tt.body)
+		if resp.StatusCode != fiber.StatusForbidden {
+			t.Errorf("%s %s: status = %d, want 403", tt.method, tt.path, resp.StatusCode)
+		}
+		if len(tasks.deleted) != 0 || len(runner.started) != 0 {
+			t.Errorf("%s %s: non-admin request changed tasks", tt.method, tt.path)
+		}
+	}
+}
+
+func TestServerTaskHandlerLetsOwnersListTasks(t *testing.T) {
+	app, _, _ := newServerTaskTestApp(testOwner)
+	for _, path := range []string{"/servers/srv-1/tasks", "/servers/srv-1/tasks/task-1/runs"} {
+		resp := doRequest(t, app, fiber.MethodGet, APIPrefix+path, "")
+		if resp.StatusCode != fiber.StatusOK {
+			t.Errorf("GET %s: status = %d, want 200", path, resp.StatusCode)
+		}
+	}
+}
+
+func TestServerTaskHandlerNotFound(t *testing.T) {
+	tests := []struct {
+		method, path string
+	}{
+		{fiber.MethodGet, "/servers/missing/tasks"},
+		{fiber.MethodPost, "/servers/missing/tasks/task-1/run"},
+		{fiber.MethodPost, "/servers/srv-1/tasks/missing/run"},
+		{fiber.MethodDelete, "/servers/srv-1/tasks/missing"},
+		{fiber.MethodGet, "/servers/srv-1/tasks/missing/runs"},
+	}
+	for _, tt := range tests {
+		app, _, _ := newServerTaskTestApp(testAdmin)
+		resp := doRequest(t, app, tt.method, APIPrefix+tt.path, "")
+		if resp.StatusCode != fiber.StatusNotFound {
+			t.Errorf("%s %s: status = %d, want 404", tt.method, tt.path, resp.StatusCode)
+		}
+	}
+}
+
+func TestServerTaskHandlerRequiresAuthentication(t *testing.T) {
+	app, _, _ := newServerTaskTestApp(nil)
+	resp := doRequest(t, app, fiber.MethodGet, APIPrefix+"/servers/srv-1/tasks", "")
+	if resp.StatusCode != fiber.StatusUnauthorized {
+		t.Errorf("status = %d, want 401", resp.StatusCode)
+	}
+}
+
+func TestServerTaskHandlerAdminRunsTask(t *testing.T) {
+	app, tasks, runner := newServerTaskTestApp(testAdmin)
+
+	resp := doRequest(t, app, fiber.MethodPost, APIPrefix+"/servers/srv-1/tasks/task-1/run", "")
+	if resp.StatusCode != fiber.StatusAccepted {
+		t.Fatalf("run: status = %d, want 202", resp.StatusCode)
+	}
+	if len(runner.started) != 1 || runner.started[0] != "task-1" {
+		t.Errorf("started = %v, want [task-1]", runner.started)
+	}
+
+	resp = doRequest(t, app, fiber.MethodDelete, APIPrefix+"/servers/srv-1/tasks/task-1", "")
+	if resp.StatusCode != fiber.StatusNoContent {
+		t.Fatalf("delete: status = %d, want 204", resp.StatusCode)
+	}
+	if len(tasks.deleted) != 1 || tasks.deleted[0] != "task-1" {
+		t.Errorf("deleted = %v, want [task-1]", tasks.deleted)
+	}
+}
@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/paasdeploy/backend/internal/cloudflare"
+	"github.com/paasdeploy/backend/internal/domain"
+	"github.com/paasdeploy/backend/internal/response"
+	"github.com/paasdeploy/backend/internal/service"
+)
+
+type ServerTunnelManager interface {
+	FindTunnel(ctx context.Context, serverID string) (*domain.ServerTunnel, error)
+	Enable(ctx context.Context, server *domain.Server) (*domain.ServerTunnel, error)
+	Disable(ctx context.Context, server *domain.Server) error
+}
+
+type ServerTunnelResponse struct {
+	Enabled   bool       `json:"enabled"`
+	TunnelID  string     `json:"tunnelId,omitempty"`
+	Target    string     `json:"target,omitempty"`
+	CreatedAt *time.Time `json:"createdAt,omitempty"`
+}
+
+func toServerTunnelResponse(t *domain.ServerTunnel) ServerTunnelResponse {
+	if t == nil {
+		return ServerTunnelResponse{}
+	}
+	return ServerTunnelResponse{
+		Enabled:   true,
+		TunnelID:  t.TunnelID,
+		Target:    cloudflare.TunnelCNAMETarget(t.TunnelID),
+		CreatedAt: &t.CreatedAt,
+	}
+}
+
+func (h *ServerHandler) GetTunnel(c *fiber.Ctx) error {
+	server, _, ok, err := h.requireServerForUser(c)
+	if !ok {
+		return err
+	}
+	if h.tunnels == nil {
+		return response.OK(c, ServerTunnelResponse{})
+	}
+
+	tunnel, err := h.tunnels.FindTunnel(c.Context(), server.ID)
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		h.logger.ErrorContext(c.UserContext(), "failed to load server tunnel", "serverId", server.ID, "error", err)
+		return response.InternalError(c)
+	}
+	return response.OK(c, toServerTunnelResponse(tunnel))
+}
+
+// EnableTunnel runs cloudflared on the server and moves its domains behind a
+// Cloudflare Tunnel, for servers that cannot accept inbound connections.
+func (h *ServerHandler) EnableTunnel(c *fiber.Ctx) error {
+	server, _, ok, err := h.requireServerForUser(c)
+	if !ok {
+		return err
+	}
+	if h.tunnels == nil {
+		return response.ServerError(c, fiber.StatusServiceUnavailable, "tunnels not available: token encryption not configured")
+	}
+
+	tunnel, err := h.tunnels.Enable(c.Context(), server)
+	if err != nil {
+		if errors.Is(err, service.ErrCloudflareNotConnected) {
+			return response.BadRequest(c, "Connect your Cloudflare account first")
+		}
+		h.logger.ErrorContext(c.UserContext(), "enable tunnel failed", "serverId", server.ID, "error", err)
+		return response.ServerError(c, fiber.StatusBadGateway, fmt.Sprintf("Failed to enable tunnel: %s", err))
+	}
+	return response.OK(c, toServerTunnelResponse(tunnel))
+}
+
+func (h *ServerHandler) DisableTunnel(c *fiber.Ctx) error {
+	server, _, ok, err := h.requireServerForUser(c)
+	if !ok {
+		return err
+	}
+	if h.tunnels == nil {
+		return response.OK(c, ServerTunnelResponse{})
+	}
+
+	if err := h.tunnels.Disable(c.Context(), server); err != nil {
+		h.logger.ErrorContext(c.UserContext(), "disable tunnel failed", "serverId", server.ID, "error", err)
+		return response.InternalError(c)
+	}
+	return response.OK(c, ServerTunnelResponse{})
+}
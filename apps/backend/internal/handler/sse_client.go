@@ -0,0 +1,124 @@
+package handler
+
+import "sync"
+
+// sseMaxMergedLogBytes caps the message of a LOG event built by merging
+// queued lines, which bounds a client's queue to about
+// sseClientBufferSize * sseMaxMergedLogBytes.
+const sseMaxMergedLogBytes = 16 * 1024
+
+// sseClient is the outgoing queue of one SSE connection. Emit never blocks
+// on it. While the browser reads slower than events arrive, queued log lines
+// of the same deploy and kind are merged into one event and stats or health
+// events are replaced by the newer sample; once the queue is full the oldest
+// log or stats event is dropped, lifecycle events only as a last resort.
+type sseClient struct {
+	mu      sync.Mutex
+	queue   []SSEEvent
+	dropped int
+	closed  bool
+	wake    chan struct{}
+}
+
+func newSSEClient() *sseClient {
+	return &sseClient{wake: make(chan struct{}, 1)}
+}
+
+func (c *sseClient) push(event SSEEvent) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return
+	}
+	if !c.merge(event) {
+		if len(c.queue) >= sseClientBufferSize {
+			c.dropOldest()
+		}
+		c.queue = append(c.queue, event)
+	}
+	c.signal()
+}
+
+func (c *sseClient) merge(event SSEEvent) bool {
+	switch event.Type {
+	case "LOG":
+		if len(c.queue) == 0 {
+			return false
+		}
+		last := &c.queue[len(c.queue)-1]
+		if last.Type != "LOG" || last.DeployID != event.DeployID || last.Kind != event.Kind ||
+			len(last.Message)+len(event.Message) >= sseMaxMergedLogBytes {
+			return false
+		}
+		last.Message += "\n" + event.Message
+		last.Timestamp = event.Timestamp
+		return true
+	case "STATS", "HEALTH", "SYSTEM_STATS", "SERVER_STATS":
+		for i := range c.queue {
+			queued := &c.queue[i]
+			if queued.Type == event.Type && queued.AppID == event.AppID && queued.ServerID == event.ServerID {
+				*queued = event
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (c *sseClient) dropOldest() {
+	drop := 0
+	for i, queued := range c.queue {
+		if sseEventDroppable(queued.Type) {
+			drop = i
+			break
+		}
+	}
+	c.queue = append(c.queue[:drop], c.queue[drop+1:]...)
+	c.dropped++
+}
+
+func sseEventDroppable(eventType string) bool {
+	switch eventType {
+	case "LOG", "PROVISION_LOG", "STATS", "HEALTH", "SYSTEM_STATS", "SERVER_STATS":
+		return true
+	}
+	return false
+}
+
+// next waits for queued events and takes all of them, along with how many
+// were dropped since the previous call. ok is false once the client is
+// closed.
+func (c *sseClient) next() (events []SSEEvent, dropped int, ok bool) {
+	for {
+		c.mu.Lock()
+		if c.closed {
+			c.mu.Unlock()
+			return nil, 0, false
+		}
+		if len(c.queue) > 0 {
+			events, dropped = c.queue, c.dropped
+			c.queue, c.dropped = nil, 0
+			c.mu.Unlock()
+			return events, dropped, true
+		}
+		c.mu.Unlock()
+		<-c.wake
+	}
+}
+
+func (c *sseClient) close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.closed = true
+	c.queue = nil
+	c.signal()
+}
+
+func (c *sseClient) signal() {
+	select {
+	case c.wake <- struct{}{}:
+	default:
+	}
+}
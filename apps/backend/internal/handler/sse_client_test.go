@@ -0,0 +1,132 @@
+package handler
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func logEvent(deployID, kind, message string) SSEEvent {
+	return SSEEvent{Type: "LOG", DeployID: deployID, Kind: kind, Message: message}
+}
+
+func TestSSEClientMergesLogLinesOfTheSameKind(t *testing.T) {
+	c := newSSEClient()
+	c.push(logEvent("d1", "build", "step 1"))
+	c.push(logEvent("d1", "build", "step 2"))
+	c.push(logEvent("d1", "error", "failed"))
+	c.push(logEvent("d1", "build", "step 3"))
+
+	events, _, ok := c.next()
+	if !ok {
+		t.Fatal("client closed")
+	}
+	want := []SSEEvent{
+		logEvent("d1", "build", "step 1\nstep 2"),
+		logEvent("d1", "error", "failed"),
+		logEvent("d1", "build", "step 3"),
+	}
+	if len(events) != len(want) {
+		t.Fatalf("events = %+v, want %d", events, len(want))
+	}
+	for i := range want {
+		if events[i].Kind != want[i].Kind || events[i].Message != want[i].Message {
+			t.Errorf("event %d = %q %q, want %q %q", i, events[i].Kind, events[i].Message, want[i].Kind, want[i].Message)
+		}
+	}
+}
+
+func TestSSEClientCapsMergedLogMessages(t *testing.T) {
+	c := newSSEClient()
+	line := strings.Repeat("x", sseMaxMergedLogBytes/2)
+	c.push(logEvent("d1", "", line))
+	c.push(logEvent("d1", "", line))
+	c.push(logEvent("d2", "", "other deploy"))
+
+	events, _, _ := c.next()
+	if len(events) != 3 {
+		t.Fatalf("got %d events, want 3", len(events))
+	}
+	for _, event := range events {
+		if len(event.Message) >= sseMaxMergedLogBytes {
+			t.Errorf("merged message is %d bytes, want less than %d", len(event.Message), sseMaxMergedLogBytes)
+		}
+	}
+}
+
+func TestSSEClientReplacesQueuedStats(t *testing.T) {
+	c := newSSEClient()
+	c.push(SSEEvent{Type: "STATS", AppID: "a1", Message: "old"})
+	c.push(SSEEvent{Type: "STATS", AppID: "a2", Message: "other app"})
+	c.push(SSEEvent{Type: "STATS", AppID: "a1", Message: "new"})
+
+	events, _, _ := c.next()
+	if len(events) != 2 || events[0].Message != "new" || events[1].Message != "other app" {
+		t.Fatalf("events = %+v, want the newer a1 sample in place and a2", events)
+	}
+}
+
+func TestSSEClientDropsOldestDroppableEventWhenFull(t *testing.T) {
+	c := newSSEClient()
+	c.push(SSEEvent{Type: "RUNNING", DeployID: "run"})
+	for i := 0; i < sseClientBufferSize-1; i++ {
+		c.push(SSEEvent{Type: "LOG", DeployID: fmt.Sprintf("log-%d", i)})
+	}
+	c.push(SSEEvent{Type: "SUCCESS", DeployID: "run"})
+
+	events, dropped, _ := c.next()
+	if len(events) != sseClientBufferSize {
+		t.Fatalf("queue length = %d, want %d", len(events), sseClientBufferSize)
+	}
+	if dropped != 1 {
+		t.Errorf("dropped = %d, want 1", dropped)
+	}
+	if events[0].Type != "RUNNING" || events[1].DeployID != "log-1" {
+		t.Errorf("queue starts with %s %s, want the lifecycle event kept and the first log line dropped", events[0].Type, events[1].DeployID)
+	}
+	if last := events[len(events)-1]; last.Type != "SUCCESS" {
+		t.Errorf("last event = %s, want SUCCESS", last.Type)
+	}
+}
+
+func TestSSEClientDropsLifecycleEventsAsLastResort(t *testing.T) {
+	c := newSSEClient()
+	for i := 0; i <= sseClientBufferSize; i++ {
+		c.push(SSEEvent{Type: "RUNNING", DeployID: fmt.Sprintf("run-%d", i)})
+	}
+
+	events, dropped, _ := c.next()
+	if len(events) != sseClientBufferSize || dropped != 1 {
+		t.Fatalf("got %d events, %d dropped", len(events), dropped)
+	}
+	if events[0].DeployID != "run-1" {
+		t.Errorf("first event = %s, want the oldest one dropped", events[0].DeployID)
+	}
+}
+
+func TestSSEClientCloseUnblocksNext(t *testing.T) {
+	c := newSSEClient()
+	done := make(chan bool)
+	go func() {
+		_, _, ok := c.next()
+		done <- ok
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	c.close()
+
+	select {
+	case ok := <-done:
+		if ok {
+			t.Error("next returned ok after close")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("next still blocked after close")
+	}
+
+	c.push(logEvent("d1", "", "late"))
+	if _, _, ok := c.next(); ok {
+		t.Error("next returned events pushed after close")
+	}
+}
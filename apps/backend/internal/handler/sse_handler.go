@@ -9,6 +9,7 @@ import (
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
+	"github.com/paasdeploy/backend/internal/domain"
 	"github.com/valyala/fasthttp"
 )
 
@@ -32,6 +33,18 @@ type SSEContainerStats struct {
 	NetworkRx     int64   `json:"networkRx"`
 	NetworkTx     int64   `json:"networkTx"`
 	PIDs          int     `json:"pids"`
+	// Averages holds the rolling "1m" and "5m" averages.
+	Averages map[string]domain.StatsAverage `json:"averages,omitempty"`
+}
+
+type SSEDeployProgress struct {
+	Stage       string `json:"stage"`
+	StageIndex  int    `json:"stageIndex"`
+	StageCount  int    `json:"stageCount"`
+	Percent     int    `json:"percent"`
+	ElapsedMs   int64  `json:"elapsedMs"`
+	EtaMs       int64  `json:"etaMs"`
+	FromHistory bool   `json:"fromHistory"`
 }
 
 type SSESystemStats struct {
@@ -43,19 +56,25 @@ type SSEEvent struct {
 	Type        string             `json:"type"`
 	DeployID    string             `json:"deployId,omitempty"`
 	AppID       string             `json:"appId,omitempty"`
+	TraceID     string             `json:"traceId,omitempty"`
 	ServerID    string             `json:"serverId,omitempty"`
 	Step        string             `json:"step,omitempty"`
 	Status      string             `json:"status,omitempty"`
 	Message     string             `json:"message,omitempty"`
+	Kind        string             `json:"kind,omitempty"`
 	Health      *SSEHealthStatus   `json:"health,omitempty"`
 	Stats       *SSEContainerStats `json:"stats,omitempty"`
+	Progress    *SSEDeployProgress `json:"progress,omitempty"`
 	SystemStats *SSESystemStats    `json:"systemStats,omitempty"`
 	Resource    string             `json:"resource,omitempty"`
+	BatchID     string             `json:"batchId,omitempty"`
+	Batch       *ProvisionBatch    `json:"batch,omitempty"`
+	AppBatch    *AppBatch          `json:"appBatch,omitempty"`
 	Timestamp   time.Time          `json:"timestamp"`
 }
 
 type SSEHandler struct {
-	clients   map[string]chan SSEEvent
+	clients   map[string]*sseClient
 	mu        sync.RWMutex
 	eventBuf  []SSEEvent
 	bufSize   int
@@ -64,7 +83,7 @@ type SSEHandler struct {
 
 func NewSSEHandler() *SSEHandler {
 	return &SSEHandler{
-		clients:  make(map[string]chan SSEEvent),
+		clients:  make(map[string]*sseClient),
 		eventBuf: make([]SSEEvent, 0, sseEventBufferSize),
 		bufSize:  sseEventBufferSize,
 	}
@@ -81,22 +100,27 @@ func (h *SSEHandler) Stream(c *fiber.Ctx) error {
 	c.Set("Transfer-Encoding", "chunked")
 
 	clientID := uuid.New().String()
-	eventChan := h.subscribe(clientID)
+	client := h.subscribe(clientID)
 
 	c.Context().SetBodyStreamWriter(fasthttp.StreamWriter(func(w *bufio.Writer) {
 		defer h.unsubscribe(clientID)
 
 		h.sendRecentEvents(w)
 
-		for event := range eventChan {
-			data, err := json.Marshal(event)
-			if err != nil {
-				continue
+		// Everything queued while the previous batch was being written goes
+		// out in one flush, so a slow connection costs one write per batch
+		// rather than per event.
+		for {
+			events, dropped, ok := client.next()
+			if !ok {
+				return
+			}
+			if dropped > 0 {
+				fmt.Fprintf(w, ": %d events dropped\n\n", dropped)
+			}
+			for _, event := range events {
+				writeSSEEvent(w, event)
 			}
-
-			fmt.Fprintf(w, "event: %s\n", sseEventName(event.Type))
-			fmt.Fprintf(w, "data: %s\n\n", data)
-
 			if err := w.Flush(); err != nil {
 				return
 			}
@@ -106,21 +130,21 @@ func (h *SSEHandler) Stream(c *fiber.Ctx) error {
 	return nil
 }
 
-func (h *SSEHandler) subscribe(clientID string) <-chan SSEEvent {
+func (h *SSEHandler) subscribe(clientID string) *sseClient {
 	h.mu.Lock()
 	defer h.mu.Unlock()
 
-	ch := make(chan SSEEvent, sseClientBufferSize)
-	h.clients[clientID] = ch
-	return ch
+	client := newSSEClient()
+	h.clients[clientID] = client
+	return client
 }
 
 func (h *SSEHandler) unsubscribe(clientID string) {
 	h.mu.Lock()
 	defer h.mu.Unlock()
 
-	if ch, ok := h.clients[clientID]; ok {
-		close(ch)
+	if client, ok := h.clients[clientID]; ok {
+		client.close()
 		delete(h.clients, clientID)
 	}
 }
@@ -138,45 +162,57 @@ func (h *SSEHandler) Emit(event SSEEvent) {
 	h.mu.RLock()
 	defer h.mu.RUnlock()
 
-	for _, ch := range h.clients {
-		select {
-		case ch <- event:
-		default:
-		}
+	for _, client := range h.clients {
+		client.push(event)
 	}
 }
 
-func (h *SSEHandler) EmitDeployRunning(deployID, appID string) {
+func (h *SSEHandler) EmitDeployRunning(deployID, appID, traceID string) {
 	h.Emit(SSEEvent{
 		Type:     "RUNNING",
 		DeployID: deployID,
 		AppID:    appID,
+		TraceID:  traceID,
 	})
 }
 
-func (h *SSEHandler) EmitDeploySuccess(deployID, appID string) {
+func (h *SSEHandler) EmitDeploySuccess(deployID, appID, traceID string) {
 	h.Emit(SSEEvent{
 		Type:     "SUCCESS",
 		DeployID: deployID,
 		AppID:    appID,
+		TraceID:  traceID,
 	})
 }
 
-func (h *SSEHandler) EmitDeployFailed(deployID, appID, message string) {
+func (h *SSEHandler) EmitDeployFailed(deployID, appID, traceID, message string) {
 	h.Emit(SSEEvent{
 		Type:     "FAILED",
 		DeployID: deployID,
 		AppID:    appID,
+		TraceID:  traceID,
 		Message:  message,
 	})
 }
 
-func (h *SSEHandler) EmitLog(deployID, appID, message string) {
+// EmitLog sends a line of a deployment log. kind is set for builder output
+// that starts a step or reads as a warning or an error.
+func (h *SSEHandler) EmitLog(deployID, appID, message, kind string) {
 	h.Emit(SSEEvent{
 		Type:     "LOG",
 		DeployID: deployID,
 		AppID:    appID,
 		Message:  message,
+		Kind:     kind,
+	})
+}
+
+func (h *SSEHandler) EmitProgress(deployID, appID string, progress SSEDeployProgress) {
+	h.Emit(SSEEvent{
+		Type:     "PROGRESS",
+		DeployID: deployID,
+		AppID:    appID,
+		Progress: &progress,
 	})
 }
 
@@ -229,6 +265,33 @@ func (h *SSEHandler) EmitProvisionFailed(serverID, message string) {
 	})
 }
 
+func (h *SSEHandler) EmitProvisionBatchProgress(batch ProvisionBatch) {
+	eventType := "PROVISION_BATCH_PROGRESS"
+	if batch.Status == ProvisionBatchCompleted {
+		eventType = "PROVISION_BATCH_COMPLETED"
+	}
+	h.Emit(SSEEvent{
+		Type:    eventType,
+		BatchID: batch.ID,
+		Batch:   &batch,
+	})
+}
+
+// EmitAppBatchProgress reports a bulk app action after appID finished, or
+// its completion when appID is empty.
+func (h *SSEHandler) EmitAppBatchProgress(batch AppBatch, appID string) {
+	eventType := "APP_BATCH_PROGRESS"
+	if batch.Status == AppBatchCompleted {
+		eventType = "APP_BATCH_COMPLETED"
+	}
+	h.Emit(SSEEvent{
+		Type:     eventType,
+		AppID:    appID,
+		BatchID:  batch.ID,
+		AppBatch: &batch,
+	})
+}
+
 func (h *SSEHandler) EmitAgentUpdateEnqueued(serverID string) {
 	h.Emit(SSEEvent{
 		Type:     "AGENT_UPDATE_STEP",
@@ -276,17 +339,21 @@ func (h *SSEHandler) sendRecentEvents(w *bufio.Writer) {
 	h.bufMu.RUnlock()
 
 	for _, event := range events {
-		data, err := json.Marshal(event)
-		if err != nil {
-			continue
-		}
-
-		fmt.Fprintf(w, "event: %s\n", sseEventName(event.Type))
-		fmt.Fprintf(w, "data: %s\n\n", data)
+		writeSSEEvent(w, event)
 	}
 	w.Flush()
 }
 
+func writeSSEEvent(w *bufio.Writer, event SSEEvent) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+
+	fmt.Fprintf(w, "event: %s\n", sseEventName(event.Type))
+	fmt.Fprintf(w, "data: %s\n\n", data)
+}
+
 func sseEventName(eventType string) string {
 	switch eventType {
 	case "LOG":
@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/paasdeploy/backend/internal/domain"
+	"github.com/paasdeploy/backend/internal/response"
+)
+
+// UpdateStatsIntervalRequest sets how often container stats are sampled. A
+// null interval falls back to the server's, then the global one.
+type UpdateStatsIntervalRequest struct {
+	IntervalSeconds *int `json:"intervalSeconds"`
+}
+
+// parseStatsInterval reads and validates the requested interval. When it
+// returns false it has already sent the error response.
+func parseStatsInterval(c *fiber.Ctx) (*int, bool, error) {
+	var req UpdateStatsIntervalRequest
+	if err := c.BodyParser(&req); err != nil {
+		return nil, false, response.BadRequest(c, MsgInvalidRequestBody)
+	}
+	if err := domain.ValidateStatsInterval(req.IntervalSeconds); err != nil {
+		return nil, false, response.BadRequest(c, err.Error())
+	}
+	return req.IntervalSeconds, true, nil
+}
+
+func (h *AppAdminHandler) UpdateStatsInterval(c *fiber.Ctx) error {
+	app, ok, err := h.requireAppForUser(c)
+	if !ok {
+		return err
+	}
+	seconds, ok, err := parseStatsInterval(c)
+	if !ok {
+		return err
+	}
+
+	if err := h.appRepo.UpdateStatsInterval(app.ID, seconds); err != nil {
+		h.logger.ErrorContext(c.UserContext(), "Failed to update stats interval", "appId", app.ID, "error", err)
+		return response.InternalError(c)
+	}
+	app.StatsIntervalSeconds = seconds
+	return response.OK(c, app)
+}
+
+func (h *ServerHandler) UpdateStatsInterval(c *fiber.Ctx) error {
+	server, _, ok, err := h.requireServerForUser(c)
+	if !ok {
+		return err
+	}
+	seconds, ok, err := parseStatsInterval(c)
+	if !ok {
+		return err
+	}
+
+	if err := h.serverRepo.UpdateStatsInterval(server.ID, seconds); err != nil {
+		return HandleNotFoundOrInternal(c, err, MsgServerNotFound)
+	}
+	server.StatsIntervalSeconds = seconds
+	return response.OK(c, toServerResponse(server))
+}
@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/paasdeploy/backend/internal/domain"
+)
+
+// statsIntervals records the intervals stored through UpdateStatsInterval.
+type statsIntervals map[string]*int
+
+func (s statsIntervals) UpdateStatsInterval(id string, seconds *int) error {
+	s[id] = seconds
+	return nil
+}
+
+type fakeStatsAppRepo struct {
+	*fakeAppRepo
+	statsIntervals
+}
+
+type fakeStatsServerRepo struct {
+	*fakeServerRepo
+	statsIntervals
+}
+
+func TestUpdateStatsIntervalKeepsValueOnInvalidInput(t *testing.T) {
+	stored := 60
+	apps := &fakeStatsAppRepo{
+		fakeAppRepo:    &fakeAppRepo{apps: map[string]string{"app-1": testOwner.ID}},
+		statsIntervals: statsIntervals{"app-1": &stored},
+	}
+	servers := &fakeStatsServerRepo{
+		fakeServerRepo: &fakeServerRepo{servers: map[string]domain.Server{"srv-1": {ID: "srv-1"}}},
+		statsIntervals: statsIntervals{"srv-1": &stored},
+	}
+
+	app := newTestApp(testOwner)
+	NewAppAdminHandler(AppAdminHandlerConfig{AppRepo: apps, Logger: testLogger()}).Register(app)
+	NewServerHandler(servers, nil, nil, nil, ServerHandlerAgentDeps{}, nil, nil, testLogger()).Register(app)
+
+	for _, path := range []string{"/apps/app-1/stats-interval", "/servers/srv-1/stats-interval"} {
+		for _, body := range []string{`{"intervalSeconds":0}`, `{"intervalSeconds":999999}`, `{`} {
+			resp := doRequest(t, app, fiber.MethodPut, APIPrefix+path, body)
+			if resp.StatusCode != http.StatusBadRequest {
+				t.Errorf("%s %s: status = %d, want %d", path, body, resp.StatusCode, http.StatusBadRequest)
+			}
+		}
+	}
+	for id, seconds := range map[string]*int{"app-1": apps.statsIntervals["app-1"], "srv-1": servers.statsIntervals["srv-1"]} {
+		if seconds == nil || *seconds != stored {
+			t.Errorf("%s interval = %v, want %d", id, seconds, stored)
+		}
+	}
+
+	resp := doRequest(t, app, fiber.MethodPut, APIPrefix+"/apps/app-1/stats-interval", `{"intervalSeconds":null}`)
+	if resp.StatusCode != http.StatusOK || apps.statsIntervals["app-1"] != nil {
+		t.Errorf("null interval: status = %d, stored = %v, want it cleared", resp.StatusCode, apps.statsIntervals["app-1"])
+	}
+}
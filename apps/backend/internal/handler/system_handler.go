@@ -1,22 +1,134 @@
 package handler
 
 import (
+	"errors"
+	"log/slog"
+	"strconv"
+
 	"github.com/gofiber/fiber/v2"
+	"github.com/paasdeploy/backend/internal/backup"
+	"github.com/paasdeploy/backend/internal/diagnostics"
 	"github.com/paasdeploy/backend/internal/response"
+	"github.com/paasdeploy/backend/internal/service"
 	"github.com/paasdeploy/backend/internal/sysinfo"
 )
 
-type SystemHandler struct{}
+// ConfigReloader re-reads the reloadable settings and returns the names of
+// the ones that changed.
+type ConfigReloader func() ([]string, error)
+
+type ConfigReloadResponse struct {
+	Changed []string `json:"changed"`
+}
+
+type CreateBackupRequest struct {
+	Passphrase string `json:"passphrase"`
+}
+
+type SystemHandler struct {
+	diagnostics  *diagnostics.Checker
+	backups      *backup.Manager
+	auditService *service.AuditService
+	logger       *slog.Logger
+	reloader     ConfigReloader
+}
 
-func NewSystemHandler() *SystemHandler {
-	return &SystemHandler{}
+func NewSystemHandler(
+	diagnostics *diagnostics.Checker,
+	backups *backup.Manager,
+	auditService *service.AuditService,
+	logger *slog.Logger,
+) *SystemHandler {
+	return &SystemHandler{
+		diagnostics:  diagnostics,
+		backups:      backups,
+		auditService: auditService,
+		logger:       logger.With("handler", "system"),
+	}
+}
+
+func (h *SystemHandler) SetConfigReloader(reloader ConfigReloader) {
+	h.reloader = reloader
 }
 
 func (h *SystemHandler) Register(app fiber.Router) {
 	v1 := app.Group(APIPrefix)
 	v1.Get("/system/stats", h.GetStats)
+	v1.Get("/system/diagnostics", h.GetDiagnostics)
+	v1.Post("/system/reload", h.ReloadConfig)
+	v1.Post("/system/backup", h.CreateBackup)
 }
 
 func (h *SystemHandler) GetStats(c *fiber.Ctx) error {
 	return response.OK(c, sysinfo.GetStats())
 }
+
+// GetDiagnostics reports which parts of the configuration are working,
+// degraded or disabled. Admins only.
+func (h *SystemHandler) GetDiagnostics(c *fiber.Ctx) error {
+	user := GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, MsgNotAuthenticated)
+	}
+	if !user.IsAdmin() {
+		return response.Forbidden(c, "only admins can view diagnostics")
+	}
+
+	return response.OK(c, h.diagnostics.Run(c.UserContext()))
+}
+
+// ReloadConfig applies the log level, CORS origins and deploy worker count
+// from the environment without restarting the API. Admins only.
+func (h *SystemHandler) ReloadConfig(c *fiber.Ctx) error {
+	user := GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, MsgNotAuthenticated)
+	}
+	if !user.IsAdmin() {
+		return response.Forbidden(c, "only admins can reload the configuration")
+	}
+	if h.reloader == nil {
+		return response.ServerError(c, fiber.StatusServiceUnavailable, "configuration reload is not available")
+	}
+
+	changed, err := h.reloader()
+	if err != nil {
+		return response.BadRequest(c, err.Error())
+	}
+	if changed == nil {
+		changed = []string{}
+	}
+	return response.OK(c, ConfigReloadResponse{Changed: changed})
+}
+
+// CreateBackup returns the whole database as an archive encrypted with the
+// given passphrase. Admins only; restore it with `api restore`.
+func (h *SystemHandler) CreateBackup(c *fiber.Ctx) error {
+	user := GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, MsgNotAuthenticated)
+	}
+	if !user.IsAdmin() {
+		return response.Forbidden(c, "only admins can create backups")
+	}
+
+	var req CreateBackupRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.BadRequest(c, MsgInvalidRequestBody)
+	}
+
+	data, summary, err := h.backups.Create(c.UserContext(), req.Passphrase)
+	if errors.Is(err, backup.ErrWeakPassphrase) {
+		return response.BadRequest(c, err.Error())
+	}
+	if err != nil {
+		h.logger.ErrorContext(c.UserContext(), "Failed to create backup", "error", err)
+		return response.InternalError(c)
+	}
+
+	h.auditService.LogBackupCreated(c.UserContext(), h.auditService.ExtractContext(c), summary.SchemaVersion, summary.Tables, summary.Rows, len(data))
+	name := "flowdeploy-backup-" + summary.CreatedAt.Format("20060102-150405") + ".fdbk"
+	c.Set(fiber.HeaderContentType, fiber.MIMEOctetStream)
+	c.Set(fiber.HeaderContentDisposition, "attachment; filename="+strconv.Quote(name))
+	return c.Send(data)
+}
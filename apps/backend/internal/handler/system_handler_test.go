@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/paasdeploy/backend/internal/domain"
+)
+
+func reloadConfig(t *testing.T, reloader ConfigReloader, user *domain.User) *http.Response {
+	t.Helper()
+	h := NewSystemHandler(nil, nil, nil, testLogger())
+	if reloader != nil {
+		h.SetConfigReloader(reloader)
+	}
+	app := newTestApp(user)
+	h.Register(app)
+	return doRequest(t, app, http.MethodPost, APIPrefix+"/system/reload", "")
+}
+
+func TestReloadConfigReturnsChangedSettings(t *testing.T) {
+	calls := 0
+	resp := reloadConfig(t, func() ([]string, error) {
+		calls++
+		return []string{"LOG_LEVEL", "DEPLOY_WORKERS"}, nil
+	}, testAdmin)
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	var body struct {
+		Data ConfigReloadResponse `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"LOG_LEVEL", "DEPLOY_WORKERS"}; !reflect.DeepEqual(body.Data.Changed, want) {
+		t.Errorf("changed = %v, want %v", body.Data.Changed, want)
+	}
+	if calls != 1 {
+		t.Errorf("reloader called %d times", calls)
+	}
+}
+
+func TestReloadConfigErrors(t *testing.T) {
+	ok := func() ([]string, error) { return nil, nil }
+	tests := []struct {
+		name     string
+		reloader ConfigReloader
+		user     *domain.User
+		want     int
+	}{
+		{"anonymous", ok, nil, http.StatusUnauthorized},
+		{"member", ok, testOwner, http.StatusForbidden},
+		{"not configured", nil, testAdmin, http.StatusServiceUnavailable},
+		{"invalid setting", func() ([]string, error) { return nil, errors.New("invalid CORS origins") }, testAdmin, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if resp := reloadConfig(t, tt.reloader, tt.user); resp.StatusCode != tt.want {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.want)
+			}
+		})
+	}
+}
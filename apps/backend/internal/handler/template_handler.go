@@ -112,7 +112,7 @@ func (h *TemplateHandler) deployTemplateLocal(c *fiber.Ctx, templateID string, t
 
 	containerID, err := h.docker.CreateContainer(c.Context(), opts)
 	if err != nil {
-		h.logger.Error("Failed to deploy template", "template", templateID, "error", err)
+		h.logger.ErrorContext(c.UserContext(), "Failed to deploy template", "template", templateID, "error", err)
 		return response.ServerError(c, fiber.StatusInternalServerError, "Failed to deploy template")
 	}
 
@@ -139,7 +139,7 @@ func (h *TemplateHandler) deployTemplateRemote(c *fiber.Ctx, serverID string, te
 
 	server, err := h.serverRepo.FindByIDForUser(serverID, user.ID)
 	if err != nil {
-		h.logger.Error("Failed to resolve server for template deploy", "serverId", serverID, "error", err)
+		h.logger.ErrorContext(c.UserContext(), "Failed to resolve server for template deploy", "serverId", serverID, "error", err)
 		return response.ServerError(c, fiber.StatusInternalServerError, MsgServerNotFound)
 	}
 
@@ -147,7 +147,7 @@ func (h *TemplateHandler) deployTemplateRemote(c *fiber.Ctx, serverID string, te
 
 	resp, err := h.agentClient.CreateContainerFromTemplate(c.Context(), server.Host, h.agentPort, grpcReq)
 	if err != nil {
-		h.logger.Error("Failed to deploy template on remote agent", "serverId", serverID, "template", template.ID, "error", err)
+		h.logger.ErrorContext(c.UserContext(), "Failed to deploy template on remote agent", "serverId", serverID, "template", template.ID, "error", err)
 		return response.ServerError(c, fiber.StatusInternalServerError, "Failed to deploy template on remote server")
 	}
 
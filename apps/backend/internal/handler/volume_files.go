@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"net/url"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	pb "github.com/paasdeploy/backend/gen/go/flowdeploy/v1"
+	"github.com/paasdeploy/backend/internal/response"
+	"github.com/paasdeploy/shared/pkg/docker"
+)
+
+type VolumeFilesResponse struct {
+	Volume string                 `json:"volume"`
+	Path   string                 `json:"path"`
+	Files  []docker.ContainerFile `json:"files"`
+}
+
+// volumeFileRequest parses the volume name and path shared by the volume
+// file endpoints and resolves where they run. When ok is false the error
+// response was already written.
+func (h *ResourceHandler) volumeFileRequest(c *fiber.Ctx, defaultPath string) (volume, filePath, host string, ok bool, err error) {
+	volume, err = url.PathUnescape(c.Params("name"))
+	if err != nil || volume == "" {
+		return "", "", "", false, response.BadRequest(c, "invalid volume name")
+	}
+	filePath, err = docker.CleanContainerPath(c.Query("path", defaultPath))
+	if err != nil {
+		return "", "", "", false, response.BadRequest(c, err.Error())
+	}
+	host, ok, err = resolveTarget(c, c.Query("serverId", ""), h.resolveServerHost)
+	return volume, filePath, host, ok, err
+}
+
+// ListVolumeFiles lists a directory inside a named volume through a
+// read-only helper container.
+func (h *ResourceHandler) ListVolumeFiles(c *fiber.Ctx) error {
+	serverID := c.Query("serverId", "")
+	volume, dir, host, ok, err := h.volumeFileRequest(c, "/")
+	if !ok {
+		return err
+	}
+
+	var files []docker.ContainerFile
+	if serverID == "" {
+		files, err = h.docker.ListVolumeFiles(c.Context(), volume, dir)
+	} else {
+		var entries []*pb.ContainerFileEntry
+		entries, err = h.agentClient.ListVolumeFiles(c.Context(), host, h.agentPort, volume, dir)
+		files = containerFilesFromProto(entries)
+	}
+	if err != nil {
+		h.logger.ErrorContext(c.UserContext(), "Failed to list volume files", "volume", volume, "serverId", serverID, "path", dir, "error", err)
+		return containerFileErrorResponse(c, err, "Failed to list volume files")
+	}
+	return response.OK(c, VolumeFilesResponse{Volume: volume, Path: dir, Files: files})
+}
+
+func (h *ResourceHandler) StatVolumeFile(c *fiber.Ctx) error {
+	serverID := c.Query("serverId", "")
+	volume, filePath, host, ok, err := h.volumeFileRequest(c, "")
+	if !ok {
+		return err
+	}
+
+	var file *docker.ContainerFile
+	if serverID == "" {
+		file, err = h.docker.StatVolumeFile(c.Context(), volume, filePath)
+	} else {
+		var entry *pb.ContainerFileEntry
+		entry, err = h.agentClient.StatVolumeFile(c.Context(), host, h.agentPort, volume, filePath)
+		if err == nil {
+			file = &containerFilesFromProto([]*pb.ContainerFileEntry{entry})[0]
+		}
+	}
+	if err != nil {
+		h.logger.ErrorContext(c.UserContext(), "Failed to stat volume file", "volume", volume, "serverId", serverID, "path", filePath, "error", err)
+		return containerFileErrorResponse(c, err, "Failed to stat volume file")
+	}
+	return response.OK(c, file)
+}
+
+// DownloadVolumeFile copies a single file out of a named volume, up to
+// docker.MaxContainerFileBytes, e.g. to pull a database dump left there by
+// an addon.
+func (h *ResourceHandler) DownloadVolumeFile(c *fiber.Ctx) error {
+	serverID := c.Query("serverId", "")
+	volume, filePath, host, ok, err := h.volumeFileRequest(c, "")
+	if !ok {
+		return err
+	}
+
+	var name string
+	var data []byte
+	if serverID == "" {
+		name, data, err = h.docker.CopyFileFromVolume(c.Context(), volume, filePath, docker.MaxContainerFileBytes)
+	} else {
+		name, data, err = h.agentClient.DownloadVolumeFile(c.Context(), host, h.agentPort, volume, filePath)
+	}
+	if err != nil {
+		h.logger.ErrorContext(c.UserContext(), "Failed to download volume file", "volume", volume, "serverId", serverID, "path", filePath, "error", err)
+		return containerFileErrorResponse(c, err, "Failed to download volume file")
+	}
+
+	h.auditService.LogVolumeFileDownloaded(c.Context(), h.auditService.ExtractContext(c), volume, serverID, filePath, len(data))
+	c.Set(fiber.HeaderContentType, fiber.MIMEOctetStream)
+	c.Set(fiber.HeaderContentDisposition, "attachment; filename="+strconv.Quote(name))
+	return c.Send(data)
+}
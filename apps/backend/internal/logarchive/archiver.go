@@ -0,0 +1,185 @@
+// Package logarchive moves the bulk of large deployment logs out of
+// Postgres into S3 compatible object storage. The deployments row keeps how
+// many gzip chunks were archived and the lines written since, which always
+// include a tail excerpt of the log.
+package logarchive
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/paasdeploy/backend/internal/config"
+	"github.com/paasdeploy/backend/internal/domain"
+)
+
+const (
+	// maxTailBytes caps the excerpt left in the database after an offload.
+	maxTailBytes   = 64 * 1024
+	offloadTimeout = 2 * time.Minute
+)
+
+// Store holds the archived chunks.
+type Store interface {
+	Put(ctx context.Context, key string, data []byte) error
+	Get(ctx context.Context, key string) ([]byte, error)
+	Delete(ctx context.Context, key string) error
+}
+
+type Archiver struct {
+	store     Store
+	repo      domain.DeploymentRepository
+	threshold int
+	logger    *slog.Logger
+
+	mu      sync.Mutex
+	pending map[string]int
+	running map[string]bool
+}
+
+// New returns the archiver configured by cfg, or nil when archiving is off.
+func New(cfg config.LogArchiveConfig, repo domain.DeploymentRepository, logger *slog.Logger) (*Archiver, error) {
+	if cfg.Bucket == "" {
+		return nil, nil
+	}
+	if cfg.AccessKeyID == "" || cfg.SecretAccessKey == "" {
+		return nil, fmt.Errorf("LOG_ARCHIVE_S3_ACCESS_KEY_ID and LOG_ARCHIVE_S3_SECRET_ACCESS_KEY are required with LOG_ARCHIVE_S3_BUCKET")
+	}
+	if cfg.ThresholdBytes <= 0 {
+		return nil, fmt.Errorf("LOG_ARCHIVE_THRESHOLD_BYTES must be positive")
+	}
+	store := NewS3Store(cfg.Endpoint, cfg.Region, cfg.Bucket, cfg.AccessKeyID, cfg.SecretAccessKey)
+	return NewArchiver(store, repo, cfg.ThresholdBytes, logger), nil
+}
+
+func NewArchiver(store Store, repo domain.DeploymentRepository, threshold int, logger *slog.Logger) *Archiver {
+	return &Archiver{
+		store:     store,
+		repo:      repo,
+		threshold: threshold,
+		logger:    logger.With("component", "log_archive"),
+		pending:   make(map[string]int),
+		running:   make(map[string]bool),
+	}
+}
+
+// ChunkKey is the object key of chunk n, counted from 1, of a deployment.
+func ChunkKey(deployID string, n int) string {
+	return fmt.Sprintf("deployment-logs/%s/%06d.log.gz", deployID, n)
+}
+
+// Track records that n bytes were appended to the logs of deployID and
+// offloads them in the background once threshold bytes have built up.
+func (a *Archiver) Track(deployID string, n int) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.pending[deployID] += n
+	if a.pending[deployID] < a.threshold || a.running[deployID] {
+		return
+	}
+	a.pending[deployID] = 0
+	a.running[deployID] = true
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), offloadTimeout)
+		defer cancel()
+		if err := a.Offload(ctx, deployID); err != nil {
+			a.logger.Warn("Failed to archive deployment logs", "deployId", deployID, "error", err)
+		}
+		a.mu.Lock()
+		delete(a.running, deployID)
+		a.mu.Unlock()
+	}()
+}
+
+// Forget drops the bookkeeping of a finished deployment.
+func (a *Archiver) Forget(deployID string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	delete(a.pending, deployID)
+}
+
+// Offload stores the logs of deployID, except the last lines up to the tail
+// excerpt size, as the next chunk and removes them from the database. Logs
+// under the threshold are left alone.
+func (a *Archiver) Offload(ctx context.Context, deployID string) error {
+	d, err := a.repo.FindByID(deployID)
+	if err != nil {
+		return err
+	}
+	if len(d.Logs) < a.threshold {
+		return nil
+	}
+
+	cut := len(d.Logs) - min(maxTailBytes, a.threshold/2)
+	end := strings.LastIndexByte(d.Logs[:cut], '\n')
+	if end < 0 {
+		return nil
+	}
+	head := d.Logs[:end+1]
+
+	var compressed bytes.Buffer
+	zw := gzip.NewWriter(&compressed)
+	if _, err := zw.Write([]byte(head)); err != nil {
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+
+	chunk := d.ArchivedLogChunks + 1
+	key := ChunkKey(deployID, chunk)
+	if err := a.store.Put(ctx, key, compressed.Bytes()); err != nil {
+		return fmt.Errorf("upload %s: %w", key, err)
+	}
+
+	trimmed, err := a.repo.TrimArchivedLogs(deployID, chunk, utf8.RuneCountInString(head), int64(len(head)))
+	if err != nil || !trimmed {
+		if delErr := a.store.Delete(ctx, key); delErr != nil {
+			a.logger.Warn("Failed to remove unused log chunk", "key", key, "error", delErr)
+		}
+		return err
+	}
+
+	a.logger.Info("Archived deployment logs", "deployId", deployID, "chunk", chunk, "bytes", len(head), "compressedBytes", compressed.Len())
+	return nil
+}
+
+// WriteFullLogs writes the archived chunks of d followed by the logs still
+// in the database.
+func (a *Archiver) WriteFullLogs(ctx context.Context, w io.Writer, d *domain.Deployment) error {
+	for n := 1; n <= d.ArchivedLogChunks; n++ {
+		key := ChunkKey(d.ID, n)
+		data, err := a.store.Get(ctx, key)
+		if err != nil {
+			return fmt.Errorf("download %s: %w", key, err)
+		}
+		zr, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return fmt.Errorf("decompress %s: %w", key, err)
+		}
+		if _, err := io.Copy(w, zr); err != nil {
+			return err
+		}
+	}
+	_, err := io.WriteString(w, d.Logs)
+	return err
+}
+
+// DeleteLogs removes the archived chunks of d.
+func (a *Archiver) DeleteLogs(ctx context.Context, d *domain.Deployment) error {
+	for n := 1; n <= d.ArchivedLogChunks; n++ {
+		if err := a.store.Delete(ctx, ChunkKey(d.ID, n)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package logarchive
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/paasdeploy/backend/internal/domain"
+)
+
+// fakeDeploymentRepo keeps one deployment and applies TrimArchivedLogs the
+// way the Postgres query does.
+type fakeDeploymentRepo struct {
+	domain.DeploymentRepository
+	d domain.Deployment
+}
+
+func (r *fakeDeploymentRepo) FindByID(string) (*domain.Deployment, error) {
+	d := r.d
+	return &d, nil
+}
+
+func (r *fakeDeploymentRepo) TrimArchivedLogs(_ string, chunk, headChars int, headBytes int64) (bool, error) {
+	if r.d.ArchivedLogChunks != chunk-1 || utf8.RuneCountInString(r.d.Logs) < headChars {
+		return false, nil
+	}
+	r.d.Logs = string([]rune(r.d.Logs)[headChars:])
+	r.d.ArchivedLogChunks = chunk
+	r.d.ArchivedLogBytes += headBytes
+	return true, nil
+}
+
+func TestArchiverOffloadKeepsTailAndRestoresFullLogs(t *testing.T) {
+	var full strings.Builder
+	for i := 0; i < 200; i++ {
+		fmt.Fprintf(&full, "[12:00:00] step %d — building layer\n", i)
+	}
+	repo := &fakeDeploymentRepo{d: domain.Deployment{ID: "d1", Logs: full.String()}}
+	a := NewArchiver(newTestS3(t), repo, 2048, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	ctx := context.Background()
+
+	if err := a.Offload(ctx, "d1"); err != nil {
+		t.Fatalf("Offload: %v", err)
+	}
+	if repo.d.ArchivedLogChunks != 1 || len(repo.d.Logs) > 1100 || !strings.HasSuffix(repo.d.Logs, "step 199 — building layer\n") {
+		t.Fatalf("unexpected row after offload: chunks=%d logs=%d bytes", repo.d.ArchivedLogChunks, len(repo.d.Logs))
+	}
+	if int(repo.d.ArchivedLogBytes)+len(repo.d.Logs) != full.Len() {
+		t.Errorf("archived %d + kept %d bytes, want %d", repo.d.ArchivedLogBytes, len(repo.d.Logs), full.Len())
+	}
+
+	repo.d.Logs += strings.Repeat("more output\n", 300)
+	full.WriteString(strings.Repeat("more output\n", 300))
+	if err := a.Offload(ctx, "d1"); err != nil {
+		t.Fatalf("second Offload: %v", err)
+	}
+	if repo.d.ArchivedLogChunks != 2 {
+		t.Fatalf("chunks = %d, want 2", repo.d.ArchivedLogChunks)
+	}
+
+	var restored strings.Builder
+	if err := a.WriteFullLogs(ctx, &restored, &repo.d); err != nil {
+		t.Fatalf("WriteFullLogs: %v", err)
+	}
+	if restored.String() != full.String() {
+		t.Error("restored logs differ from the original")
+	}
+}
+
+func TestArchiverOffloadSkipsSmallLogs(t *testing.T) {
+	repo := &fakeDeploymentRepo{d: domain.Deployment{ID: "d1", Logs: "short\n"}}
+	a := NewArchiver(newTestS3(t), repo, 2048, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err := a.Offload(context.Background(), "d1"); err != nil {
+		t.Fatalf("Offload: %v", err)
+	}
+	if repo.d.ArchivedLogChunks != 0 || repo.d.Logs != "short\n" {
+		t.Errorf("small logs were changed: %+v", repo.d)
+	}
+}
@@ -0,0 +1,81 @@
+package logarchive
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newTestS3 serves a minimal in-memory S3 bucket named "logs".
+func newTestS3(t *testing.T) *S3Store {
+	t.Helper()
+	var mu sync.Mutex
+	objects := make(map[string][]byte)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKID/20240501/us-east-1/s3/aws4_request") {
+			t.Errorf("unexpected authorization header %q", r.Header.Get("Authorization"))
+		}
+		if r.Header.Get("X-Amz-Content-Sha256") == "" {
+			t.Error("missing payload hash header")
+		}
+		key, ok := strings.CutPrefix(r.URL.Path, "/logs/")
+		if !ok {
+			http.Error(w, "no such bucket", http.StatusNotFound)
+			return
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		switch r.Method {
+		case http.MethodPut:
+			objects[key], _ = io.ReadAll(r.Body)
+		case http.MethodGet:
+			data, found := objects[key]
+			if !found {
+				http.Error(w, "NoSuchKey", http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write(data)
+		case http.MethodDelete:
+			delete(objects, key)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	s := NewS3Store(srv.URL, "us-east-1", "logs", "AKID", "secret")
+	s.now = func() time.Time { return time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC) }
+	return s
+}
+
+func TestS3StoreRoundTrip(t *testing.T) {
+	s := newTestS3(t)
+	ctx := context.Background()
+	key := ChunkKey("d1", 1)
+
+	if err := s.Put(ctx, key, []byte("hello")); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	data, err := s.Get(ctx, key)
+	if err != nil || string(data) != "hello" {
+		t.Fatalf("Get = %q, %v", data, err)
+	}
+	if err := s.Delete(ctx, key); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := s.Get(ctx, key); !errors.Is(err, ErrObjectNotFound) {
+		t.Errorf("Get after delete = %v, want ErrObjectNotFound", err)
+	}
+}
+
+func TestNewS3StoreDefaultsToAWS(t *testing.T) {
+	if s := NewS3Store("", "eu-west-1", "logs", "a", "b"); s.endpoint != "https://s3.eu-west-1.amazonaws.com" {
+		t.Errorf("endpoint = %s", s.endpoint)
+	}
+}
@@ -0,0 +1,119 @@
+// Package metering delivers usage records to the sink chosen by the
+// operator, so installations serving clients can bill them.
+package metering
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/paasdeploy/backend/internal/config"
+	"github.com/paasdeploy/backend/internal/domain"
+)
+
+const (
+	SinkDB      = "db"
+	SinkWebhook = "webhook"
+
+	// SignatureHeader carries the hex HMAC-SHA256 of the webhook body keyed
+	// with METERING_WEBHOOK_SECRET, prefixed with "sha256=".
+	SignatureHeader = "X-FlowDeploy-Signature-256"
+
+	webhookTimeout = 15 * time.Second
+)
+
+// Sink receives batches of usage records. Write must be safe to retry with
+// the same batch.
+type Sink interface {
+	Write(ctx context.Context, records []domain.UsageRecord) error
+}
+
+// NewSink returns the sink configured by cfg, or nil when metering is off.
+func NewSink(cfg config.MeteringConfig, repo domain.UsageRecordRepository) (Sink, error) {
+	switch cfg.Sink {
+	case "":
+		return nil, nil
+	case SinkDB:
+		return NewDBSink(repo), nil
+	case SinkWebhook:
+		if cfg.WebhookURL == "" {
+			return nil, fmt.Errorf("METERING_WEBHOOK_URL is required for the webhook sink")
+		}
+		return NewWebhookSink(cfg.WebhookURL, cfg.WebhookSecret), nil
+	default:
+		return nil, fmt.Errorf("unknown METERING_SINK %q, use %s or %s", cfg.Sink, SinkDB, SinkWebhook)
+	}
+}
+
+type DBSink struct {
+	repo domain.UsageRecordRepository
+}
+
+func NewDBSink(repo domain.UsageRecordRepository) *DBSink {
+	return &DBSink{repo: repo}
+}
+
+func (s *DBSink) Write(_ context.Context, records []domain.UsageRecord) error {
+	return s.repo.CreateBatch(records)
+}
+
+// WebhookSink posts each batch as JSON. Receivers should drop records whose
+// ID they have already seen, since failed batches are sent again.
+type WebhookSink struct {
+	url    string
+	secret string
+	client *http.Client
+}
+
+func NewWebhookSink(url, secret string) *WebhookSink {
+	return &WebhookSink{
+		url:    url,
+		secret: secret,
+		client: &http.Client{Timeout: webhookTimeout},
+	}
+}
+
+type webhookPayload struct {
+	Records []domain.UsageRecord `json:"records"`
+	SentAt  time.Time            `json:"sentAt"`
+}
+
+func (s *WebhookSink) Write(ctx context.Context, records []domain.UsageRecord) error {
+	body, err := json.Marshal(webhookPayload{Records: records, SentAt: time.Now().UTC()})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.secret != "" {
+		req.Header.Set(SignatureHeader, Sign(s.secret, body))
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("metering webhook returned %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// Sign returns the SignatureHeader value for body.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
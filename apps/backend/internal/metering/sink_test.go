@@ -0,0 +1,78 @@
+package metering
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/paasdeploy/backend/internal/config"
+	"github.com/paasdeploy/backend/internal/domain"
+)
+
+func TestWebhookSinkSignsBatch(t *testing.T) {
+	var body []byte
+	var signature string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		signature = r.Header.Get(SignatureHeader)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	records := []domain.UsageRecord{{
+		ID:          "r1",
+		AppID:       "a1",
+		Metric:      domain.UsageMetricRuntimeHours,
+		Quantity:    0.25,
+		PeriodStart: time.Date(2026, 5, 1, 10, 0, 0, 0, time.UTC),
+		PeriodEnd:   time.Date(2026, 5, 1, 10, 15, 0, 0, time.UTC),
+	}}
+	if err := NewWebhookSink(srv.URL, "s3cret").Write(t.Context(), records); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	if want := Sign("s3cret", body); signature != want {
+		t.Errorf("signature = %q, want %q", signature, want)
+	}
+	var payload webhookPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		t.Fatal(err)
+	}
+	if len(payload.Records) != 1 || payload.Records[0].Metric != domain.UsageMetricRuntimeHours || payload.Records[0].Quantity != 0.25 {
+		t.Errorf("payload = %+v", payload)
+	}
+}
+
+func TestWebhookSinkFailsOnErrorStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(SignatureHeader) != "" {
+			t.Error("unexpected signature without a secret")
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	if err := NewWebhookSink(srv.URL, "").Write(t.Context(), nil); err == nil {
+		t.Fatal("Write() error = nil, want error")
+	}
+}
+
+func TestNewSink(t *testing.T) {
+	if sink, err := NewSink(config.MeteringConfig{}, nil); sink != nil || err != nil {
+		t.Errorf("NewSink(disabled) = %v, %v", sink, err)
+	}
+	if sink, err := NewSink(config.MeteringConfig{Sink: SinkDB}, nil); err != nil {
+		t.Errorf("NewSink(db) error = %v", err)
+	} else if _, ok := sink.(*DBSink); !ok {
+		t.Errorf("NewSink(db) = %T", sink)
+	}
+	if _, err := NewSink(config.MeteringConfig{Sink: SinkWebhook}, nil); err == nil {
+		t.Error("NewSink(webhook without URL) error = nil")
+	}
+	if _, err := NewSink(config.MeteringConfig{Sink: "kafka"}, nil); err == nil {
+		t.Error("NewSink(kafka) error = nil")
+	}
+}
@@ -1,7 +1,10 @@
 package middleware
 
 import (
+	"errors"
 	"log/slog"
+	"strings"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/paasdeploy/backend/internal/crypto"
@@ -10,8 +13,20 @@ import (
 	"github.com/paasdeploy/backend/internal/response"
 )
 
+// apiTokenTouchInterval limits last_used_at writes to one per token and
+// interval, so scripts polling the API don't write on every request.
+const apiTokenTouchInterval = time.Minute
+
+var (
+	errNoCredentials  = errors.New("authentication required")
+	errInvalidSession = errors.New("invalid or expired session")
+	errInvalidToken   = errors.New("invalid or expired API token")
+	errUserNotFound   = errors.New("user not found")
+)
+
 type AuthMiddleware struct {
 	sessionRepo       domain.SessionRepository
+	apiTokenRepo      domain.APITokenRepository
 	userRepo          domain.UserRepository
 	logger            *slog.Logger
 	sessionCookieName string
@@ -19,6 +34,7 @@ type AuthMiddleware struct {
 
 type AuthMiddlewareConfig struct {
 	SessionRepo       domain.SessionRepository
+	APITokenRepo      domain.APITokenRepository
 	UserRepo          domain.UserRepository
 	Logger            *slog.Logger
 	SessionCookieName string
@@ -27,6 +43,7 @@ type AuthMiddlewareConfig struct {
 func NewAuthMiddleware(cfg AuthMiddlewareConfig) *AuthMiddleware {
 	return &AuthMiddleware{
 		sessionRepo:       cfg.SessionRepo,
+		apiTokenRepo:      cfg.APITokenRepo,
 		userRepo:          cfg.UserRepo,
 		logger:            cfg.Logger,
 		sessionCookieName: cfg.SessionCookieName,
@@ -35,23 +52,9 @@ func NewAuthMiddleware(cfg AuthMiddlewareConfig) *AuthMiddleware {
 
 func (m *AuthMiddleware) Require() fiber.Handler {
 	return func(c *fiber.Ctx) error {
-		sessionToken := c.Cookies(m.sessionCookieName)
-		if sessionToken == "" {
-			return response.Unauthorized(c, "authentication required")
-		}
-
-		tokenHash := crypto.HashSessionToken(sessionToken)
-
-		session, err := m.sessionRepo.FindByTokenHash(c.Context(), tokenHash)
+		user, err := m.authenticate(c)
 		if err != nil {
-			m.logger.Debug("session not found", "error", err)
-			return response.Unauthorized(c, "invalid or expired session")
-		}
-
-		user, err := m.userRepo.FindByID(c.Context(), session.UserID)
-		if err != nil {
-			m.logger.Error("user not found for valid session", "error", err, "user_id", session.UserID)
-			return response.Unauthorized(c, "user not found")
+			return response.Unauthorized(c, err.Error())
 		}
 
 		requestctx.SetUserInContext(c, user)
@@ -62,25 +65,77 @@ func (m *AuthMiddleware) Require() fiber.Handler {
 
 func (m *AuthMiddleware) Optional() fiber.Handler {
 	return func(c *fiber.Ctx) error {
-		sessionToken := c.Cookies(m.sessionCookieName)
-		if sessionToken == "" {
+		user, err := m.authenticate(c)
+		if err != nil {
 			return c.Next()
 		}
 
-		tokenHash := crypto.HashSessionToken(sessionToken)
+		requestctx.SetUserInContext(c, user)
 
-		session, err := m.sessionRepo.FindByTokenHash(c.Context(), tokenHash)
-		if err != nil {
-			return c.Next()
-		}
+		return c.Next()
+	}
+}
 
-		user, err := m.userRepo.FindByID(c.Context(), session.UserID)
-		if err != nil {
-			return c.Next()
+// authenticate resolves the user from an "Authorization: Bearer" API token
+// or, without one, from the session cookie.
+func (m *AuthMiddleware) authenticate(c *fiber.Ctx) (*domain.User, error) {
+	if token, ok := bearerToken(c); ok {
+		return m.authenticateToken(c, token)
+	}
+
+	sessionToken := c.Cookies(m.sessionCookieName)
+	if sessionToken == "" {
+		return nil, errNoCredentials
+	}
+
+	tokenHash := crypto.HashSessionToken(sessionToken)
+
+	session, err := m.sessionRepo.FindByTokenHash(c.Context(), tokenHash)
+	if err != nil {
+		m.logger.Debug("session not found", "error", err)
+		return nil, errInvalidSession
+	}
+
+	user, err := m.userRepo.FindByID(c.Context(), session.UserID)
+	if err != nil {
+		m.logger.Error("user not found for valid session", "error", err, "user_id", session.UserID)
+		return nil, errUserNotFound
+	}
+
+	return user, nil
+}
+
+func (m *AuthMiddleware) authenticateToken(c *fiber.Ctx, token string) (*domain.User, error) {
+	if m.apiTokenRepo == nil || !strings.HasPrefix(token, domain.APITokenPrefix) {
+		return nil, errInvalidToken
+	}
+
+	apiToken, err := m.apiTokenRepo.FindByTokenHash(c.Context(), crypto.HashSessionToken(token))
+	if err != nil {
+		m.logger.Debug("api token not found", "error", err)
+		return nil, errInvalidToken
+	}
+
+	user, err := m.userRepo.FindByID(c.Context(), apiToken.UserID)
+	if err != nil {
+		m.logger.Error("user not found for valid api token", "error", err, "user_id", apiToken.UserID)
+		return nil, errUserNotFound
+	}
+
+	if apiToken.LastUsedAt == nil || time.Since(*apiToken.LastUsedAt) > apiTokenTouchInterval {
+		if err := m.apiTokenRepo.TouchLastUsed(c.Context(), apiToken.ID); err != nil {
+			m.logger.Warn("failed to update api token usage", "error", err, "token_id", apiToken.ID)
 		}
+	}
 
-		requestctx.SetUserInContext(c, user)
+	return user, nil
+}
 
-		return c.Next()
+func bearerToken(c *fiber.Ctx) (string, bool) {
+	scheme, token, ok := strings.Cut(c.Get(fiber.HeaderAuthorization), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
 	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
 }
@@ -3,19 +3,34 @@ package middleware
 import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
+	"github.com/paasdeploy/shared/pkg/tracing"
 )
 
 const (
-	TraceIDHeader = "X-Trace-ID"
-	TraceIDKey    = "traceId"
+	TraceIDHeader   = "X-Trace-ID"
+	RequestIDHeader = "X-Request-ID"
+	TraceIDKey      = "traceId"
 )
 
+// TraceID reuses the ID sent by a proxy or client in X-Trace-ID or
+// X-Request-ID, or generates one. The ID is returned in both headers and
+// stored in the request context, so handlers passing c.Context() or
+// c.UserContext() down have it attached to their logs and agent calls.
 func TraceID() fiber.Handler {
 	return func(c *fiber.Ctx) error {
-		traceID := uuid.New().String()
+		traceID := c.Get(TraceIDHeader)
+		if !tracing.ValidID(traceID) {
+			traceID = c.Get(RequestIDHeader)
+		}
+		if !tracing.ValidID(traceID) {
+			traceID = uuid.New().String()
+		}
 
 		c.Locals(TraceIDKey, traceID)
+		c.Context().SetUserValue(tracing.Key{}, traceID)
+		c.SetUserContext(tracing.WithID(c.UserContext(), traceID))
 		c.Set(TraceIDHeader, traceID)
+		c.Set(RequestIDHeader, traceID)
 
 		return c.Next()
 	}
@@ -0,0 +1,177 @@
+package migration
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	ChangeContainerMigrated = "container_migrated"
+	ChangeNginxStopped      = "nginx_stopped"
+
+	journalFileName = "migration-journal.json"
+)
+
+// MigrationChange records one change made to the host during a migration,
+// with what is needed to undo it.
+type MigrationChange struct {
+	Type          string    `json:"type"`
+	At            time.Time `json:"at"`
+	Domain        string    `json:"domain,omitempty"`
+	ContainerName string    `json:"containerName,omitempty"`
+	OriginalID    string    `json:"originalId,omitempty"`
+	BackupName    string    `json:"backupName,omitempty"`
+	NewID         string    `json:"newId,omitempty"`
+	NginxEnabled  bool      `json:"nginxEnabled,omitempty"`
+}
+
+type RollbackResult struct {
+	RolledBack []MigrationChange `json:"rolledBack"`
+	Errors     []string          `json:"errors"`
+}
+
+func (s *MigrationService) journalPath() string {
+	return filepath.Join(s.backupBasePath, journalFileName)
+}
+
+func (s *MigrationService) loadJournal() ([]MigrationChange, error) {
+	data, err := os.ReadFile(s.journalPath())
+	if errors.Is(err, os.ErrNotExist) {
+		return []MigrationChange{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read migration journal: %w", err)
+	}
+	var changes []MigrationChange
+	if err := json.Unmarshal(data, &changes); err != nil {
+		return nil, fmt.Errorf("parse migration journal: %w", err)
+	}
+	return changes, nil
+}
+
+func (s *MigrationService) saveJournal(changes []MigrationChange) error {
+	if err := os.MkdirAll(s.backupBasePath, 0755); err != nil {
+		return fmt.Errorf("create backup directory: %w", err)
+	}
+	data, err := json.MarshalIndent(changes, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := s.journalPath() + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("write migration journal: %w", err)
+	}
+	return os.Rename(tmp, s.journalPath())
+}
+
+func (s *MigrationService) recordChange(change MigrationChange) {
+	s.journalMu.Lock()
+	defer s.journalMu.Unlock()
+
+	changes, err := s.loadJournal()
+	if err != nil {
+		s.logger.Error("Failed to load migration journal", "error", err)
+		changes = []MigrationChange{}
+	}
+	change.At = time.Now()
+	if err := s.saveJournal(append(changes, change)); err != nil {
+		s.logger.Error("Failed to record migration change", "type", change.Type, "error", err)
+	}
+}
+
+// Changes returns the recorded changes that have not been rolled back,
+// oldest first.
+func (s *MigrationService) Changes() ([]MigrationChange, error) {
+	s.journalMu.Lock()
+	defer s.journalMu.Unlock()
+	return s.loadJournal()
+}
+
+// Rollback undoes the recorded changes newest first: migrated containers are
+// removed and their originals restored from the backup rename, then nginx is
+// re-enabled and started. Changes that fail to roll back stay in the journal
+// so the rollback can be retried.
+func (s *MigrationService) Rollback(ctx context.Context) (*RollbackResult, error) {
+	s.journalMu.Lock()
+	defer s.journalMu.Unlock()
+
+	changes, err := s.loadJournal()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &RollbackResult{RolledBack: []MigrationChange{}, Errors: []string{}}
+	var remaining []MigrationChange
+	for i := len(changes) - 1; i >= 0; i-- {
+		change := changes[i]
+		if err := s.rollbackChange(ctx, change); err != nil {
+			s.logger.Error("Rollback step failed", "type", change.Type, "container", change.ContainerName, "error", err)
+			result.Errors = append(result.Errors, err.Error())
+			remaining = append([]MigrationChange{change}, remaining...)
+			continue
+		}
+		result.RolledBack = append(result.RolledBack, change)
+	}
+
+	if remaining == nil {
+		remaining = []MigrationChange{}
+	}
+	if err := s.saveJournal(remaining); err != nil {
+		return nil, err
+	}
+	s.logger.Info("Migration rolled back", "rolledBack", len(result.RolledBack), "failed", len(result.Errors))
+	return result, nil
+}
+
+func (s *MigrationService) rollbackChange(ctx context.Context, change MigrationChange) error {
+	switch change.Type {
+	case ChangeContainerMigrated:
+		return s.restoreContainer(ctx, change)
+	case ChangeNginxStopped:
+		return s.restoreNginx(ctx, change)
+	default:
+		return fmt.Errorf("unknown change type %q", change.Type)
+	}
+}
+
+func (s *MigrationService) restoreContainer(ctx context.Context, change MigrationChange) error {
+	s.logger.Info("Restoring original container", "name", change.ContainerName)
+
+	if change.NewID != "" {
+		if out, err := exec.CommandContext(ctx, "docker", "rm", "-f", change.NewID).CombinedOutput(); err != nil && !containerMissing(out) {
+			return fmt.Errorf("remove migrated container %s: %s", change.ContainerName, string(out))
+		}
+	}
+	if out, err := exec.CommandContext(ctx, "docker", "rename", change.BackupName, change.ContainerName).CombinedOutput(); err != nil {
+		return fmt.Errorf("restore container %s from %s: %s", change.ContainerName, change.BackupName, string(out))
+	}
+	if out, err := exec.CommandContext(ctx, "docker", "start", change.ContainerName).CombinedOutput(); err != nil {
+		return fmt.Errorf("start container %s: %s", change.ContainerName, string(out))
+	}
+	return nil
+}
+
+func (s *MigrationService) restoreNginx(ctx context.Context, change MigrationChange) error {
+	s.logger.Info("Restarting nginx")
+
+	if change.NginxEnabled {
+		exec.CommandContext(ctx, "systemctl", "enable", "nginx").Run()
+	}
+	if err := exec.CommandContext(ctx, "systemctl", "start", "nginx").Run(); err != nil {
+		if out, err := exec.CommandContext(ctx, "service", "nginx", "start").CombinedOutput(); err != nil {
+			return fmt.Errorf("start nginx (is Traefik still bound to ports 80/443?): %s", string(out))
+		}
+	}
+	return nil
+}
+
+func containerMissing(output []byte) bool {
+	return strings.Contains(string(output), "No such container")
+}
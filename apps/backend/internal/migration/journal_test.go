@@ -0,0 +1,56 @@
+package migration
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+)
+
+func newTestJournalService(t *testing.T) *MigrationService {
+	t.Helper()
+	s := NewMigrationService(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	s.backupBasePath = t.TempDir()
+	return s
+}
+
+func TestJournalRecordsChanges(t *testing.T) {
+	s := newTestJournalService(t)
+
+	changes, err := s.Changes()
+	if err != nil || len(changes) != 0 {
+		t.Fatalf("expected empty journal, got %v, %v", changes, err)
+	}
+
+	s.recordChange(MigrationChange{Type: ChangeNginxStopped, NginxEnabled: true})
+	s.recordChange(MigrationChange{Type: ChangeContainerMigrated, ContainerName: "web", BackupName: "web-backup", NewID: "abc"})
+
+	changes, err = s.Changes()
+	if err != nil {
+		t.Fatalf("Changes: %v", err)
+	}
+	if len(changes) != 2 || changes[0].Type != ChangeNginxStopped || changes[1].ContainerName != "web" {
+		t.Fatalf("unexpected journal %+v", changes)
+	}
+	if changes[0].At.IsZero() {
+		t.Error("expected change time to be set")
+	}
+}
+
+func TestRollbackKeepsFailedChanges(t *testing.T) {
+	s := newTestJournalService(t)
+	s.recordChange(MigrationChange{Type: "unknown"})
+
+	result, err := s.Rollback(context.Background())
+	if err != nil {
+		t.Fatalf("Rollback: %v", err)
+	}
+	if len(result.RolledBack) != 0 || len(result.Errors) != 1 {
+		t.Fatalf("unexpected result %+v", result)
+	}
+
+	changes, _ := s.Changes()
+	if len(changes) != 1 {
+		t.Errorf("failed change should stay in the journal, got %+v", changes)
+	}
+}
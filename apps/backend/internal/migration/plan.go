@@ -0,0 +1,231 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	CertStrategyNone        = "none"
+	CertStrategyACME        = "acme"
+	CertStrategyACMEReissue = "acme-reissue"
+	CertStrategyManual      = "manual"
+)
+
+// MigrationPlan is a dry-run report of what migrating the host from nginx to
+// Traefik would do. Building it only reads state; no container is touched.
+type MigrationPlan struct {
+	GeneratedAt     time.Time   `json:"generatedAt"`
+	Proxy           ProxyStatus `json:"proxy"`
+	TraefikReady    bool        `json:"traefikReady"`
+	MigrationNeeded bool        `json:"migrationNeeded"`
+	Sites           []SitePlan  `json:"sites"`
+	Warnings        []string    `json:"warnings"`
+}
+
+type SitePlan struct {
+	ConfigFile   string        `json:"configFile"`
+	Domains      []string      `json:"domains"`
+	SSLEnabled   bool          `json:"sslEnabled"`
+	Backends     []BackendPlan `json:"backends"`
+	CertStrategy CertStrategy  `json:"certStrategy"`
+	Warnings     []string      `json:"warnings"`
+}
+
+// BackendPlan maps one upstream port of a site to the container publishing
+// it, if any, and the labels the container would be recreated with.
+type BackendPlan struct {
+	TraefikConfig
+	Container *ContainerInfo `json:"container,omitempty"`
+}
+
+type CertStrategy struct {
+	Strategy    string          `json:"strategy"`
+	Certificate *SSLCertificate `json:"certificate,omitempty"`
+	Description string          `json:"description"`
+}
+
+// Plan builds the migration report for every nginx site on the host.
+func (s *MigrationService) Plan(ctx context.Context) (*MigrationPlan, error) {
+	status, err := s.GetStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.buildPlan(status, time.Now()), nil
+}
+
+func (s *MigrationService) buildPlan(status *MigrationStatus, now time.Time) *MigrationPlan {
+	plan := &MigrationPlan{
+		GeneratedAt:     now,
+		Proxy:           status.Proxy,
+		TraefikReady:    status.TraefikReady,
+		MigrationNeeded: status.MigrationNeeded,
+		Sites:           make([]SitePlan, 0, len(status.NginxSites)),
+		Warnings:        append([]string{}, status.Warnings...),
+	}
+	if status.Proxy.Type == "nginx" && status.Proxy.Running {
+		plan.Warnings = append(plan.Warnings, "nginx must be stopped before Traefik can bind ports 80 and 443")
+	}
+
+	for _, site := range status.NginxSites {
+		if len(site.ServerNames) == 0 {
+			continue
+		}
+		plan.Sites = append(plan.Sites, s.planSite(site, status))
+	}
+	return plan
+}
+
+func (s *MigrationService) planSite(site NginxSite, status *MigrationStatus) SitePlan {
+	sp := SitePlan{
+		ConfigFile:   site.ConfigFile,
+		Domains:      site.ServerNames,
+		SSLEnabled:   site.SSLEnabled,
+		Backends:     []BackendPlan{},
+		CertStrategy: certStrategyFor(site, status.SSLCertificates),
+		Warnings:     s.checkSiteWarnings(site),
+	}
+	if sp.Warnings == nil {
+		sp.Warnings = []string{}
+	}
+
+	configs := s.traefikConverter.ConvertSite(site)
+	sort.Slice(configs, func(i, j int) bool { return configs[i].Port < configs[j].Port })
+	for _, cfg := range configs {
+		backend := BackendPlan{TraefikConfig: cfg, Container: containerForPort(status.Containers, cfg.Port)}
+		if backend.Container == nil {
+			sp.Warnings = append(sp.Warnings, fmt.Sprintf(
+				"%s: no container publishes port %d - the backend must be migrated manually", cfg.Domain, cfg.Port))
+		}
+		sp.Backends = append(sp.Backends, backend)
+	}
+	if len(configs) == 0 && site.Root == "" {
+		sp.Warnings = append(sp.Warnings, fmt.Sprintf("%s: no proxied backend found", site.ServerNames[0]))
+	}
+	return sp
+}
+
+func certStrategyFor(site NginxSite, certs []SSLCertificate) CertStrategy {
+	if !site.SSLEnabled {
+		return CertStrategy{
+			Strategy:    CertStrategyNone,
+			Description: "Site is served over plain HTTP; routers use the web entrypoint only",
+		}
+	}
+
+	domain := site.ServerNames[0]
+	for i := range certs {
+		if certs[i].Domain != domain {
+			continue
+		}
+		cert := certs[i]
+		if cert.IsExpired {
+			return CertStrategy{
+				Strategy:    CertStrategyACMEReissue,
+				Certificate: &cert,
+				Description: "Existing certificate has expired; Traefik will request a new one from Let's Encrypt",
+			}
+		}
+		return CertStrategy{
+			Strategy:    CertStrategyACME,
+			Certificate: &cert,
+			Description: fmt.Sprintf("Traefik will request a new Let's Encrypt certificate; the current one stays valid for %d days as a fallback", cert.DaysUntilExpiry),
+		}
+	}
+
+	if site.SSLProvider == "manual" {
+		return CertStrategy{
+			Strategy:    CertStrategyManual,
+			Description: "Custom certificate at " + site.SSLCertPath + " must be loaded through the Traefik file provider or replaced by Let's Encrypt",
+		}
+	}
+	return CertStrategy{
+		Strategy:    CertStrategyACME,
+		Description: "Traefik will request a Let's Encrypt certificate; DNS must point to this server and port 80 must be reachable",
+	}
+}
+
+// containerForPort returns the container publishing hostPort on the host,
+// matching Docker's "0.0.0.0:3000->3000/tcp" port notation.
+func containerForPort(containers []ContainerInfo, hostPort int) *ContainerInfo {
+	for i := range containers {
+		for _, p := range containers[i].Ports {
+			mapping, _, ok := strings.Cut(p, "->")
+			if !ok {
+				continue
+			}
+			idx := strings.LastIndex(mapping, ":")
+			if idx < 0 {
+				continue
+			}
+			if port, err := strconv.Atoi(mapping[idx+1:]); err == nil && port == hostPort {
+				c := containers[i]
+				return &c
+			}
+		}
+	}
+	return nil
+}
+
+// RenderPlanMarkdown formats the plan as a markdown document suitable for
+// review before running the migration.
+func RenderPlanMarkdown(plan *MigrationPlan) string {
+	var b strings.Builder
+
+	b.WriteString("# Migration plan: nginx to Traefik\n\n")
+	fmt.Fprintf(&b, "Generated: %s\n\n", plan.GeneratedAt.UTC().Format(time.RFC3339))
+	fmt.Fprintf(&b, "- Current proxy: %s (running: %t)\n", plan.Proxy.Type, plan.Proxy.Running)
+	fmt.Fprintf(&b, "- Traefik running: %t\n", plan.TraefikReady)
+	fmt.Fprintf(&b, "- Sites: %d\n\n", len(plan.Sites))
+
+	if len(plan.Warnings) > 0 {
+		b.WriteString("## Warnings\n\n")
+		for _, w := range plan.Warnings {
+			fmt.Fprintf(&b, "- %s\n", w)
+		}
+		b.WriteString("\n")
+	}
+
+	for _, site := range plan.Sites {
+		fmt.Fprintf(&b, "## %s\n\n", site.Domains[0])
+		fmt.Fprintf(&b, "- Config file: `%s`\n", site.ConfigFile)
+		fmt.Fprintf(&b, "- Domains: %s\n", strings.Join(site.Domains, ", "))
+		fmt.Fprintf(&b, "- Certificate strategy: **%s** - %s\n\n", site.CertStrategy.Strategy, site.CertStrategy.Description)
+
+		for _, backend := range site.Backends {
+			fmt.Fprintf(&b, "### Backend %s (port %d)\n\n", backend.ServiceName, backend.Port)
+			if backend.Container != nil {
+				fmt.Fprintf(&b, "Container: `%s` (%s)\n\n", backend.Container.Name, backend.Container.Image)
+			} else {
+				b.WriteString("Container: not found\n\n")
+			}
+			b.WriteString("```yaml\nlabels:\n")
+			for _, key := range sortedKeys(backend.Labels) {
+				fmt.Fprintf(&b, "  - \"%s=%s\"\n", key, backend.Labels[key])
+			}
+			b.WriteString("```\n\n")
+		}
+
+		if len(site.Warnings) > 0 {
+			b.WriteString("Warnings:\n\n")
+			for _, w := range site.Warnings {
+				fmt.Fprintf(&b, "- %s\n", w)
+			}
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
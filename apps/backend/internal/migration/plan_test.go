@@ -0,0 +1,115 @@
+package migration
+
+import (
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testPlanStatus() *MigrationStatus {
+	return &MigrationStatus{
+		Proxy: ProxyStatus{Type: "nginx", Running: true},
+		NginxSites: []NginxSite{
+			{
+				ConfigFile:  "/etc/nginx/sites-enabled/shop",
+				ServerNames: []string{"shop.example.com", "www.shop.example.com"},
+				SSLEnabled:  true,
+				Locations: []NginxLocation{
+					{Path: "/", ProxyPass: "http://127.0.0.1:3000", ProxyPort: 3000},
+					{Path: "/api/", ProxyPass: "http://127.0.0.1:4000", ProxyPort: 4000},
+				},
+			},
+			{
+				ConfigFile:  "/etc/nginx/sites-enabled/blog",
+				ServerNames: []string{"blog.example.com"},
+				Locations:   []NginxLocation{{Path: "/", ProxyPass: "http://127.0.0.1:8080", ProxyPort: 8080}},
+			},
+		},
+		SSLCertificates: []SSLCertificate{{Domain: "shop.example.com", Provider: "letsencrypt", DaysUntilExpiry: 40}},
+		Containers: []ContainerInfo{
+			{ID: "abc", Name: "shop-web", Image: "shop:latest", Ports: []string{"0.0.0.0:3000->3000/tcp", ":::3000->3000/tcp"}},
+			{ID: "def", Name: "blog", Image: "ghost:5", Ports: []string{"127.0.0.1:8080->2368/tcp"}},
+		},
+		MigrationNeeded: true,
+	}
+}
+
+func TestBuildPlan(t *testing.T) {
+	s := NewMigrationService(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	plan := s.buildPlan(testPlanStatus(), time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC))
+
+	if len(plan.Sites) != 2 {
+		t.Fatalf("expected 2 sites, got %d", len(plan.Sites))
+	}
+
+	shop := plan.Sites[0]
+	if shop.CertStrategy.Strategy != CertStrategyACME || shop.CertStrategy.Certificate == nil {
+		t.Errorf("unexpected shop cert strategy %+v", shop.CertStrategy)
+	}
+	if len(shop.Backends) != 2 || shop.Backends[0].Port != 3000 || shop.Backends[1].Port != 4000 {
+		t.Fatalf("unexpected shop backends %+v", shop.Backends)
+	}
+	if shop.Backends[0].Container == nil || shop.Backends[0].Container.Name != "shop-web" {
+		t.Errorf("port 3000 should map to shop-web, got %+v", shop.Backends[0].Container)
+	}
+	if shop.Backends[1].Container != nil {
+		t.Errorf("port 4000 should have no container, got %+v", shop.Backends[1].Container)
+	}
+	if !containsSubstring(shop.Warnings, "no container publishes port 4000") {
+		t.Errorf("expected missing container warning, got %v", shop.Warnings)
+	}
+
+	blog := plan.Sites[1]
+	if blog.CertStrategy.Strategy != CertStrategyNone {
+		t.Errorf("unexpected blog cert strategy %q", blog.CertStrategy.Strategy)
+	}
+	if blog.Backends[0].Container == nil || blog.Backends[0].Container.Name != "blog" {
+		t.Errorf("port 8080 should map to blog, got %+v", blog.Backends[0].Container)
+	}
+	if !containsSubstring(plan.Warnings, "nginx must be stopped") {
+		t.Errorf("expected nginx warning, got %v", plan.Warnings)
+	}
+}
+
+func TestCertStrategyFor(t *testing.T) {
+	expired := []SSLCertificate{{Domain: "a.example.com", IsExpired: true}}
+	site := NginxSite{ServerNames: []string{"a.example.com"}, SSLEnabled: true}
+	if got := certStrategyFor(site, expired).Strategy; got != CertStrategyACMEReissue {
+		t.Errorf("expired cert: got %q", got)
+	}
+
+	site.SSLProvider = "manual"
+	site.SSLCertPath = "/etc/ssl/a.pem"
+	got := certStrategyFor(site, nil)
+	if got.Strategy != CertStrategyManual || !strings.Contains(got.Description, "/etc/ssl/a.pem") {
+		t.Errorf("manual cert: got %+v", got)
+	}
+}
+
+func TestRenderPlanMarkdown(t *testing.T) {
+	s := NewMigrationService(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	md := RenderPlanMarkdown(s.buildPlan(testPlanStatus(), time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)))
+
+	for _, want := range []string{
+		"Generated: 2026-01-02T03:04:05Z",
+		"## shop.example.com",
+		"Container: `shop-web` (shop:latest)",
+		"\"traefik.http.routers.shop-example-com.rule=Host(`shop.example.com`)\"",
+		"Certificate strategy: **none**",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("markdown missing %q:\n%s", want, md)
+		}
+	}
+}
+
+func containsSubstring(list []string, sub string) bool {
+	for _, s := range list {
+		if strings.Contains(s, sub) {
+			return true
+		}
+	}
+	return false
+}
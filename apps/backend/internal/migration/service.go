@@ -9,6 +9,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -41,6 +42,7 @@ type MigrationStatus struct {
 	Warnings         []string          `json:"warnings"`
 	LastBackupPath   string            `json:"lastBackupPath,omitempty"`
 	LastBackupTime   *time.Time        `json:"lastBackupTime,omitempty"`
+	Changes          []MigrationChange `json:"changes"`
 }
 
 type BackupResult struct {
@@ -56,6 +58,7 @@ type MigrationService struct {
 	traefikConverter *TraefikConverter
 	logger           *slog.Logger
 	backupBasePath   string
+	journalMu        sync.Mutex
 }
 
 func NewMigrationService(logger *slog.Logger) *MigrationService {
@@ -101,6 +104,13 @@ func (s *MigrationService) GetStatus(ctx context.Context) (*MigrationStatus, err
 
 	status.Warnings = s.generateWarnings(status)
 
+	changes, err := s.Changes()
+	if err != nil {
+		s.logger.Warn("Failed to load migration journal", "error", err)
+		changes = []MigrationChange{}
+	}
+	status.Changes = changes
+
 	return status, nil
 }
 
@@ -357,6 +367,8 @@ func (s *MigrationService) StartContainers(ctx context.Context, containerIDs []s
 func (s *MigrationService) StopNginx(ctx context.Context) error {
 	s.logger.Info("Stopping nginx")
 
+	wasEnabled := exec.CommandContext(ctx, "systemctl", "is-enabled", "--quiet", "nginx").Run() == nil
+
 	cmd := exec.CommandContext(ctx, "systemctl", "stop", "nginx")
 	if err := cmd.Run(); err != nil {
 		cmd = exec.CommandContext(ctx, "service", "nginx", "stop")
@@ -368,6 +380,8 @@ func (s *MigrationService) StopNginx(ctx context.Context) error {
 	cmd = exec.CommandContext(ctx, "systemctl", "disable", "nginx")
 	cmd.Run()
 
+	s.recordChange(MigrationChange{Type: ChangeNginxStopped, NginxEnabled: wasEnabled})
+
 	return nil
 }
 
@@ -421,21 +435,7 @@ func (s *MigrationService) MigrateContainer(ctx context.Context, site NginxSite,
 
 	image := config["Image"].(string)
 
-	var labels []string
-	labels = append(labels, "traefik.enable=true")
-	labels = append(labels, "traefik.docker.network=paasdeploy")
-	for _, cfg := range configs {
-		labels = append(labels, fmt.Sprintf("traefik.http.routers.%s.rule=Host(`%s`)", cfg.ServiceName, cfg.Domain))
-		labels = append(labels, fmt.Sprintf("traefik.http.routers.%s.entrypoints=websecure", cfg.ServiceName))
-		labels = append(labels, fmt.Sprintf("traefik.http.routers.%s.tls=true", cfg.ServiceName))
-		labels = append(labels, fmt.Sprintf("traefik.http.routers.%s.tls.certresolver=letsencrypt", cfg.ServiceName))
-		labels = append(labels, fmt.Sprintf("traefik.http.services.%s.loadbalancer.server.port=%d", cfg.ServiceName, cfg.Port))
-
-		labels = append(labels, fmt.Sprintf("traefik.http.routers.%s-http.rule=Host(`%s`)", cfg.ServiceName, cfg.Domain))
-		labels = append(labels, fmt.Sprintf("traefik.http.routers.%s-http.entrypoints=web", cfg.ServiceName))
-		labels = append(labels, fmt.Sprintf("traefik.http.routers.%s-http.middlewares=redirect-to-https", cfg.ServiceName))
-	}
-	labels = append(labels, "traefik.http.middlewares.redirect-to-https.redirectscheme.scheme=https")
+	labels := containerLabels(configs)
 
 	s.logger.Info("Stopping container", "name", containerName)
 	stopCmd := exec.CommandContext(ctx, "docker", "stop", containerID)
@@ -501,8 +501,14 @@ func (s *MigrationService) MigrateContainer(ctx context.Context, site NginxSite,
 	newContainerID := strings.TrimSpace(string(output))
 	s.logger.Info("Container migrated successfully", "newId", newContainerID)
 
-	removeBackup := exec.CommandContext(ctx, "docker", "rm", containerName+"-backup")
-	removeBackup.Run()
+	s.recordChange(MigrationChange{
+		Type:          ChangeContainerMigrated,
+		Domain:        site.ServerNames[0],
+		ContainerName: containerName,
+		OriginalID:    containerID,
+		BackupName:    containerName + "-backup",
+		NewID:         newContainerID,
+	})
 
 	return &MigrateResult{
 		ContainerID:   newContainerID,
@@ -510,10 +516,40 @@ func (s *MigrationService) MigrateContainer(ctx context.Context, site NginxSite,
 		Domain:        site.ServerNames[0],
 		Labels:        labels,
 		Success:       true,
-		Message:       "Container migrated successfully with Traefik labels",
+		Message:       "Container migrated successfully with Traefik labels; the original is kept as " + containerName + "-backup for rollback",
 	}, nil
 }
 
+// containerLabels returns the Traefik labels a migrated container is
+// recreated with, serving each config over HTTPS with an HTTP redirect.
+func containerLabels(configs []TraefikConfig) []string {
+	var labels []string
+	labels = append(labels, "traefik.enable=true")
+	labels = append(labels, "traefik.docker.network=paasdeploy")
+	for _, cfg := range configs {
+		labels = append(labels, fmt.Sprintf("traefik.http.routers.%s.rule=Host(`%s`)", cfg.ServiceName, cfg.Domain))
+		labels = append(labels, fmt.Sprintf("traefik.http.routers.%s.entrypoints=websecure", cfg.ServiceName))
+		labels = append(labels, fmt.Sprintf("traefik.http.routers.%s.tls=true", cfg.ServiceName))
+		labels = append(labels, fmt.Sprintf("traefik.http.routers.%s.tls.certresolver=letsencrypt", cfg.ServiceName))
+		labels = append(labels, fmt.Sprintf("traefik.http.services.%s.loadbalancer.server.port=%d", cfg.ServiceName, cfg.Port))
+
+		labels = append(labels, fmt.Sprintf("traefik.http.routers.%s-http.rule=Host(`%s`)", cfg.ServiceName, cfg.Domain))
+		labels = append(labels, fmt.Sprintf("traefik.http.routers.%s-http.entrypoints=web", cfg.ServiceName))
+		labels = append(labels, fmt.Sprintf("traefik.http.routers.%s-http.middlewares=redirect-to-https", cfg.ServiceName))
+	}
+	labels = append(labels, "traefik.http.middlewares.redirect-to-https.redirectscheme.scheme=https")
+	return labels
+}
+
+// ContainerLabels returns the labels MigrateContainer would apply for site.
+func (s *MigrationService) ContainerLabels(site NginxSite) ([]string, error) {
+	configs := s.traefikConverter.ConvertSite(site)
+	if len(configs) == 0 {
+		return nil, fmt.Errorf("no traefik config generated for site")
+	}
+	return containerLabels(configs), nil
+}
+
 func parseJSON(data []byte, v interface{}) error {
 	return json.Unmarshal(data, v)
 }
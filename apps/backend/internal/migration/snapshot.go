@@ -0,0 +1,85 @@
+package migration
+
+import (
+	"time"
+)
+
+// HostSnapshot is the raw state of a remote host collected by its agent.
+// AnalyzeSnapshot turns it into the same status the local analysis returns.
+type HostSnapshot struct {
+	Proxy          ProxyStatus
+	TraefikRunning bool
+	NginxConfigs   []ConfigFile
+	Certificates   []CertificateFile
+	Containers     []ContainerInfo
+}
+
+type ConfigFile struct {
+	Path    string
+	Content string
+}
+
+type CertificateFile struct {
+	Domain        string
+	PEM           []byte
+	RenewalConfig string
+}
+
+// AnalyzeSnapshot parses the nginx sites and certificates of a remote host.
+// The journal is local to the backend, so Changes is always empty.
+func (s *MigrationService) AnalyzeSnapshot(snap *HostSnapshot) *MigrationStatus {
+	status := &MigrationStatus{
+		Proxy:        snap.Proxy,
+		Containers:   snap.Containers,
+		TraefikReady: snap.TraefikRunning,
+		Changes:      []MigrationChange{},
+	}
+
+	for _, file := range snap.NginxConfigs {
+		site, err := s.nginxParser.ParseContent(file.Content, file.Path)
+		if err != nil {
+			continue
+		}
+		if len(site.ServerNames) > 0 && site.ServerNames[0] != "_" {
+			status.NginxSites = append(status.NginxSites, *site)
+		}
+	}
+
+	for _, file := range snap.Certificates {
+		status.SSLCertificates = append(status.SSLCertificates, certificateFromPEM(file))
+	}
+
+	for i := range status.Containers {
+		status.Containers[i].Uptime = s.parseUptime(status.Containers[i].Status)
+	}
+
+	status.MigrationNeeded = status.Proxy.Type == "nginx" && status.Proxy.Running && !status.TraefikReady
+	status.Warnings = s.generateWarnings(status)
+	return status
+}
+
+// PlanSnapshot builds the dry-run migration report for a remote host.
+func (s *MigrationService) PlanSnapshot(snap *HostSnapshot) *MigrationPlan {
+	return s.buildPlan(s.AnalyzeSnapshot(snap), time.Now())
+}
+
+func certificateFromPEM(file CertificateFile) SSLCertificate {
+	cert := SSLCertificate{
+		Domain:        file.Domain,
+		Provider:      "letsencrypt",
+		CertPath:      "/etc/letsencrypt/live/" + file.Domain + "/cert.pem",
+		KeyPath:       "/etc/letsencrypt/live/" + file.Domain + "/privkey.pem",
+		ChainPath:     "/etc/letsencrypt/live/" + file.Domain + "/chain.pem",
+		FullChainPath: "/etc/letsencrypt/live/" + file.Domain + "/fullchain.pem",
+		AutoRenew:     file.RenewalConfig != "",
+		RenewalConfig: file.RenewalConfig,
+	}
+	if info, err := parseCertificatePEM(file.PEM); err == nil {
+		cert.ExpiresAt = info.NotAfter
+		cert.DaysUntilExpiry = int(time.Until(info.NotAfter).Hours() / 24)
+		cert.IsExpired = time.Now().After(info.NotAfter)
+		cert.Issuer = info.Issuer.CommonName
+		cert.Subject = info.Subject.CommonName
+	}
+	return cert
+}
@@ -0,0 +1,81 @@
+package migration
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"log/slog"
+	"math/big"
+	"testing"
+	"time"
+)
+
+const testNginxConfig = `server {
+    listen 443 ssl;
+    server_name app.example.com;
+    ssl_certificate /etc/letsencrypt/live/app.example.com/fullchain.pem;
+    location / {
+        proxy_pass http://127.0.0.1:3000;
+    }
+}`
+
+func testCertificatePEM(t *testing.T, notAfter time.Time) []byte {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "app.example.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func TestAnalyzeSnapshot(t *testing.T) {
+	s := NewMigrationService(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	status := s.AnalyzeSnapshot(&HostSnapshot{
+		Proxy: ProxyStatus{Type: "nginx", Running: true},
+		NginxConfigs: []ConfigFile{
+			{Path: "/etc/nginx/sites-enabled/app", Content: testNginxConfig},
+			{Path: "/etc/nginx/conf.d/catchall.conf", Content: "server {\n    server_name _;\n}"},
+		},
+		Certificates: []CertificateFile{{
+			Domain:        "app.example.com",
+			PEM:           testCertificatePEM(t, time.Now().Add(3*24*time.Hour)),
+			RenewalConfig: "/etc/letsencrypt/renewal/app.example.com.conf",
+		}},
+		Containers: []ContainerInfo{{ID: "abc", Name: "app", Status: "Up 2 hours (healthy)"}},
+	})
+
+	if len(status.NginxSites) != 1 || status.NginxSites[0].ServerNames[0] != "app.example.com" {
+		t.Fatalf("unexpected sites %+v", status.NginxSites)
+	}
+	if !status.NginxSites[0].SSLEnabled {
+		t.Error("expected SSL to be detected")
+	}
+	if !status.MigrationNeeded {
+		t.Error("expected migration to be needed")
+	}
+
+	cert := status.SSLCertificates[0]
+	if !cert.AutoRenew || cert.Subject != "app.example.com" || cert.DaysUntilExpiry > 3 {
+		t.Errorf("unexpected certificate %+v", cert)
+	}
+	if !containsSubstring(status.Warnings, "Certificate for app.example.com expires") {
+		t.Errorf("expected expiry warning, got %v", status.Warnings)
+	}
+	if status.Containers[0].Uptime != "2 hours" {
+		t.Errorf("unexpected uptime %q", status.Containers[0].Uptime)
+	}
+}
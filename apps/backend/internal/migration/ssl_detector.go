@@ -3,6 +3,7 @@ package migration
 import (
 	"crypto/x509"
 	"encoding/pem"
+	"errors"
 	"os"
 	"path/filepath"
 	"strings"
@@ -103,9 +104,13 @@ func (d *SSLDetector) parseCertificate(certPath string) (*x509.Certificate, erro
 		return nil, err
 	}
 
+	return parseCertificatePEM(certPEM)
+}
+
+func parseCertificatePEM(certPEM []byte) (*x509.Certificate, error) {
 	block, _ := pem.Decode(certPEM)
 	if block == nil {
-		return nil, err
+		return nil, errors.New("no PEM certificate found")
 	}
 
 	cert, err := x509.ParseCertificate(block.Bytes)
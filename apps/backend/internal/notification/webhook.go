@@ -0,0 +1,142 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/paasdeploy/backend/internal/domain"
+)
+
+const (
+	WebhookEventHeader     = "X-FlowDeploy-Event"
+	WebhookDeliveryHeader  = "X-FlowDeploy-Delivery"
+	WebhookSignatureHeader = "X-FlowDeploy-Signature-256"
+
+	webhookAttempts = 5
+	webhookBackoff  = 2 * time.Second
+)
+
+// WebhookPayload is the body of an app webhook delivery.
+type WebhookPayload struct {
+	ID         string             `json:"id"`
+	Event      string             `json:"event"`
+	Timestamp  time.Time          `json:"timestamp"`
+	App        WebhookApp         `json:"app"`
+	Deployment *WebhookDeployment `json:"deployment,omitempty"`
+	Health     *WebhookHealth     `json:"health,omitempty"`
+}
+
+type WebhookApp struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type WebhookDeployment struct {
+	ID      string `json:"id"`
+	Message string `json:"message,omitempty"`
+}
+
+type WebhookHealth struct {
+	Status string `json:"status"`
+	Health string `json:"health,omitempty"`
+}
+
+// WebhookSender posts signed payloads to app webhooks, retrying failed
+// deliveries with exponential backoff.
+type WebhookSender struct {
+	client   *http.Client
+	attempts int
+	backoff  time.Duration
+}
+
+func NewWebhookSender() *WebhookSender {
+	return &WebhookSender{
+		client:   &http.Client{Timeout: 10 * time.Second},
+		attempts: webhookAttempts,
+		backoff:  webhookBackoff,
+	}
+}
+
+// SignWebhook returns the signature header value of body: the hex HMAC-SHA256
+// of the raw body keyed with the webhook secret, prefixed with "sha256=".
+// Receivers recompute it over the body they got and compare.
+func SignWebhook(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Deliver posts payload to url until it is accepted, the error is not worth
+// retrying or the attempts run out, and returns the outcome of the last
+// attempt. Network errors, 429 and 5xx responses are retried.
+func (s *WebhookSender) Deliver(ctx context.Context, url, secret string, payload WebhookPayload) domain.AppWebhookDelivery {
+	return s.deliver(ctx, url, secret, payload, s.attempts)
+}
+
+// DeliverOnce posts payload a single time, to test a webhook.
+func (s *WebhookSender) DeliverOnce(ctx context.Context, url, secret string, payload WebhookPayload) domain.AppWebhookDelivery {
+	return s.deliver(ctx, url, secret, payload, 1)
+}
+
+func (s *WebhookSender) deliver(ctx context.Context, url, secret string, payload WebhookPayload, attempts int) domain.AppWebhookDelivery {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return domain.AppWebhookDelivery{At: time.Now().UTC(), Error: err.Error()}
+	}
+
+	var delivery domain.AppWebhookDelivery
+	for attempt := 1; attempt <= attempts; attempt++ {
+		var retry bool
+		delivery, retry = s.post(ctx, url, secret, payload, body)
+		if !retry || attempt == attempts {
+			break
+		}
+
+		wait := s.backoff << (attempt - 1)
+		select {
+		case <-ctx.Done():
+			delivery.Error = ctx.Err().Error()
+			return delivery
+		case <-time.After(wait):
+		}
+	}
+	return delivery
+}
+
+func (s *WebhookSender) post(ctx context.Context, url, secret string, payload WebhookPayload, body []byte) (domain.AppWebhookDelivery, bool) {
+	delivery := domain.AppWebhookDelivery{At: time.Now().UTC()}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		delivery.Error = err.Error()
+		return delivery, false
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "FlowDeploy-Webhook")
+	req.Header.Set(WebhookEventHeader, payload.Event)
+	req.Header.Set(WebhookDeliveryHeader, payload.ID)
+	req.Header.Set(WebhookSignatureHeader, SignWebhook(secret, body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		delivery.Error = err.Error()
+		return delivery, ctx.Err() == nil
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	delivery.StatusCode = resp.StatusCode
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return delivery, false
+	}
+	delivery.Error = fmt.Sprintf("webhook returned %d", resp.StatusCode)
+	return delivery, resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+}
@@ -0,0 +1,65 @@
+package notification
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWebhookSenderSignsAndRetries(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if got, want := r.Header.Get(WebhookSignatureHeader), SignWebhook("s3cret", body); got != want {
+			t.Errorf("signature = %q, want %q", got, want)
+		}
+		if r.Header.Get(WebhookEventHeader) != "deploy.succeeded" || r.Header.Get(WebhookDeliveryHeader) != "delivery-1" {
+			t.Errorf("unexpected headers: %v", r.Header)
+		}
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	sender := NewWebhookSender()
+	sender.backoff = time.Millisecond
+
+	delivery := sender.Deliver(context.Background(), server.URL, "s3cret", WebhookPayload{
+		ID:    "delivery-1",
+		Event: "deploy.succeeded",
+		App:   WebhookApp{ID: "app-1", Name: "shop"},
+	})
+	if calls.Load() != 3 {
+		t.Errorf("calls = %d, want 3", calls.Load())
+	}
+	if delivery.StatusCode != http.StatusNoContent || delivery.Error != "" {
+		t.Errorf("delivery = %+v, want 204 without error", delivery)
+	}
+}
+
+func TestWebhookSenderDoesNotRetryClientErrors(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	sender := NewWebhookSender()
+	sender.backoff = time.Millisecond
+
+	delivery := sender.Deliver(context.Background(), server.URL, "s3cret", WebhookPayload{ID: "delivery-2", Event: "ping"})
+	if calls.Load() != 1 {
+		t.Errorf("calls = %d, want 1", calls.Load())
+	}
+	if delivery.StatusCode != http.StatusNotFound || delivery.Error == "" {
+		t.Errorf("delivery = %+v, want a 404 error", delivery)
+	}
+}
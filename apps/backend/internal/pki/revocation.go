@@ -0,0 +1,66 @@
+package pki
+
+import (
+	"crypto/x509"
+	"sync"
+	"time"
+)
+
+// RevocationList tracks revoked agent certificates. Entries are matched
+// either by serial number or by identity (the certificate CN): revoking an
+// identity rejects every certificate for it issued up to the revocation time,
+// so a re-provisioned agent with a fresh certificate is accepted again.
+type RevocationList struct {
+	mu         sync.RWMutex
+	serials    map[string]time.Time
+	identities map[string]time.Time
+}
+
+func NewRevocationList() *RevocationList {
+	return &RevocationList{
+		serials:    make(map[string]time.Time),
+		identities: make(map[string]time.Time),
+	}
+}
+
+func (l *RevocationList) RevokeSerial(serial string, revokedAt time.Time) {
+	if serial == "" {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.serials[serial] = revokedAt
+}
+
+func (l *RevocationList) RevokeIdentity(identity string, revokedAt time.Time) {
+	if identity == "" {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if current, ok := l.identities[identity]; ok && current.After(revokedAt) {
+		return
+	}
+	l.identities[identity] = revokedAt
+}
+
+func (l *RevocationList) IsRevoked(cert *x509.Certificate) bool {
+	if cert == nil {
+		return false
+	}
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	if _, ok := l.serials[SerialString(cert)]; ok {
+		return true
+	}
+	revokedAt, ok := l.identities[cert.Subject.CommonName]
+	return ok && !cert.NotBefore.After(revokedAt)
+}
+
+func SerialString(cert *x509.Certificate) string {
+	if cert == nil || cert.SerialNumber == nil {
+		return ""
+	}
+	return cert.SerialNumber.Text(16)
+}
@@ -0,0 +1,64 @@
+package pki
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+)
+
+func parseAgentCert(t *testing.T, ca *CertificateAuthority, serverID string) *x509.Certificate {
+	t.Helper()
+	issued, err := ca.GenerateAgentCert(serverID, "example.com")
+	if err != nil {
+		t.Fatalf("generate agent cert error: %v", err)
+	}
+	block, _ := pem.Decode(issued.CertPEM)
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		t.Fatalf("parse agent cert error: %v", err)
+	}
+	return cert
+}
+
+func TestRevocationList(t *testing.T) {
+	ca, err := NewCA()
+	if err != nil {
+		t.Fatalf("new CA error: %v", err)
+	}
+
+	t.Run("serial", func(t *testing.T) {
+		list := NewRevocationList()
+		cert := parseAgentCert(t, ca, "server-a")
+		other := parseAgentCert(t, ca, "server-a")
+
+		list.RevokeSerial(SerialString(cert), time.Now())
+
+		if !list.IsRevoked(cert) {
+			t.Fatalf("expected cert to be revoked")
+		}
+		if list.IsRevoked(other) {
+			t.Fatalf("expected cert with other serial to be accepted")
+		}
+	})
+
+	t.Run("identity", func(t *testing.T) {
+		list := NewRevocationList()
+		cert := parseAgentCert(t, ca, "server-b")
+
+		list.RevokeIdentity("server-b", time.Now().Add(time.Second))
+
+		if !list.IsRevoked(cert) {
+			t.Fatalf("expected cert issued before revocation to be revoked")
+		}
+		if list.IsRevoked(parseAgentCert(t, ca, "server-c")) {
+			t.Fatalf("expected other identity to be accepted")
+		}
+
+		reissued := parseAgentCert(t, ca, "server-b")
+		reissued.NotBefore = time.Now().Add(time.Minute)
+		if list.IsRevoked(reissued) {
+			t.Fatalf("expected cert issued after revocation to be accepted")
+		}
+	})
+}
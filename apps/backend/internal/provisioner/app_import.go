@@ -0,0 +1,214 @@
+package provisioner
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+
+	"github.com/paasdeploy/backend/internal/domain"
+)
+
+const (
+	capRoverConfigPath = "/captain/data/config-captain.json"
+	// dokkuDefaultPort is the port Dokku assigns to buildpack apps when no
+	// port mapping is configured.
+	dokkuDefaultPort    = 5000
+	capRoverDefaultPort = 80
+)
+
+var dokkuAppNameRe = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+// DiscoverApps reads the app definitions of a Dokku or CapRover install on
+// the server so they can be recreated as managed apps.
+func (p *SSHProvisioner) DiscoverApps(server *domain.Server, sshKey, sshPassword, platform string) ([]domain.ImportedApp, error) {
+	client, err := p.connectServer(server, sshKey, sshPassword)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	uid, err := runCommandOutput(client, "id -u")
+	if err != nil {
+		return nil, fmt.Errorf("get uid: %w", err)
+	}
+
+	switch platform {
+	case domain.ImportPlatformDokku:
+		return discoverDokkuApps(client, uid, sshPassword)
+	case domain.ImportPlatformCapRover:
+		out, err := runPrivilegedCommandOutput(client, uid, sshPassword, "cat "+capRoverConfigPath)
+		if err != nil {
+			return nil, fmt.Errorf("read caprover config: %w", err)
+		}
+		return parseCapRoverApps([]byte(out))
+	default:
+		return nil, fmt.Errorf("unsupported platform %q", platform)
+	}
+}
+
+func discoverDokkuApps(client *ssh.Client, uid, password string) ([]domain.ImportedApp, error) {
+	dokku := func(args string) (string, error) {
+		return runPrivilegedCommandOutput(client, uid, password, fmt.Sprintf("sh -c 'dokku %s 2>/dev/null'", args))
+	}
+
+	if !commandSucceeds(client, "command -v dokku") {
+		return nil, fmt.Errorf("dokku is not installed on this server")
+	}
+	out, err := dokku("--quiet apps:list")
+	if err != nil {
+		return nil, fmt.Errorf("list dokku apps: %w", err)
+	}
+
+	var apps []domain.ImportedApp
+	for _, name := range parseDokkuAppList(out) {
+		app := domain.ImportedApp{Name: name, Port: dokkuDefaultPort}
+
+		config, err := dokku("config:export --format json " + name)
+		if err != nil {
+			return nil, fmt.Errorf("read config of %s: %w", name, err)
+		}
+		if app.EnvVars, err = parseDokkuConfig(config); err != nil {
+			return nil, fmt.Errorf("parse config of %s: %w", name, err)
+		}
+		if vhosts, err := dokku("domains:report " + name + " --domains-app-vhosts"); err == nil {
+			app.Domains = strings.Fields(vhosts)
+		}
+		if mounts, err := dokku("storage:report " + name + " --storage-deploy-mounts"); err == nil {
+			app.Volumes = parseDokkuMounts(mounts)
+		}
+		if ports, err := dokku("ports:report " + name + " --ports-map"); err == nil {
+			if port := parseDokkuPortMap(ports); port > 0 {
+				app.Port = port
+			}
+		}
+		apps = append(apps, app)
+	}
+	return apps, nil
+}
+
+// parseDokkuAppList keeps valid app names only, so they are safe to pass
+// back to the dokku CLI.
+func parseDokkuAppList(out string) []string {
+	var names []string
+	for _, line := range strings.Split(out, "\n") {
+		name := strings.TrimSpace(line)
+		if dokkuAppNameRe.MatchString(name) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// parseDokkuConfig drops the variables Dokku sets for its own bookkeeping.
+func parseDokkuConfig(out string) (map[string]string, error) {
+	vars := map[string]string{}
+	if strings.TrimSpace(out) == "" {
+		return vars, nil
+	}
+	if err := json.Unmarshal([]byte(out), &vars); err != nil {
+		return nil, err
+	}
+	for k := range vars {
+		if strings.HasPrefix(k, "DOKKU_") || k == "GIT_REV" {
+			delete(vars, k)
+		}
+	}
+	return vars, nil
+}
+
+// parseDokkuMounts reads "-v host:container[:ro]" options.
+func parseDokkuMounts(out string) []domain.ImportedVolume {
+	var volumes []domain.ImportedVolume
+	for _, field := range strings.Fields(out) {
+		if field == "-v" {
+			continue
+		}
+		parts := strings.Split(field, ":")
+		if len(parts) < 2 {
+			continue
+		}
+		volumes = append(volumes, domain.ImportedVolume{
+			Source:   parts[0],
+			Target:   parts[1],
+			ReadOnly: len(parts) > 2 && parts[2] == "ro",
+		})
+	}
+	return volumes
+}
+
+// parseDokkuPortMap returns the container port of the first http or https
+// mapping, e.g. 3000 for "http:80:3000 https:443:3000".
+func parseDokkuPortMap(out string) int {
+	for _, mapping := range strings.Fields(out) {
+		parts := strings.Split(mapping, ":")
+		if len(parts) != 3 || (parts[0] != "http" && parts[0] != "https") {
+			continue
+		}
+		if port, err := strconv.Atoi(parts[2]); err == nil {
+			return port
+		}
+	}
+	return 0
+}
+
+type capRoverConfig struct {
+	AppDefinitions map[string]struct {
+		ContainerHTTPPort int `json:"containerHttpPort"`
+		EnvVars           []struct {
+			Key   string `json:"key"`
+			Value string `json:"value"`
+		} `json:"envVars"`
+		Volumes []struct {
+			ContainerPath string `json:"containerPath"`
+			VolumeName    string `json:"volumeName"`
+			HostPath      string `json:"hostPath"`
+		} `json:"volumes"`
+		CustomDomain []struct {
+			PublicDomain string `json:"publicDomain"`
+		} `json:"customDomain"`
+	} `json:"appDefinitions"`
+}
+
+// parseCapRoverApps reads the app definitions from CapRover's config file.
+// Named volumes are mounted from the Docker volume CapRover created, so the
+// imported app keeps its data.
+func parseCapRoverApps(data []byte) ([]domain.ImportedApp, error) {
+	var cfg capRoverConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parse caprover config: %w", err)
+	}
+
+	apps := make([]domain.ImportedApp, 0, len(cfg.AppDefinitions))
+	for name, def := range cfg.AppDefinitions {
+		app := domain.ImportedApp{
+			Name:    name,
+			EnvVars: make(map[string]string, len(def.EnvVars)),
+			Port:    def.ContainerHTTPPort,
+		}
+		if app.Port == 0 {
+			app.Port = capRoverDefaultPort
+		}
+		for _, v := range def.EnvVars {
+			app.EnvVars[v.Key] = v.Value
+		}
+		for _, d := range def.CustomDomain {
+			app.Domains = append(app.Domains, d.PublicDomain)
+		}
+		for _, v := range def.Volumes {
+			source := v.HostPath
+			if source == "" {
+				source = fmt.Sprintf("/var/lib/docker/volumes/captain--%s/_data", v.VolumeName)
+			}
+			app.Volumes = append(app.Volumes, domain.ImportedVolume{Source: source, Target: v.ContainerPath})
+		}
+		apps = append(apps, app)
+	}
+	sort.Slice(apps, func(i, j int) bool { return apps[i].Name < apps[j].Name })
+	return apps, nil
+}
@@ -0,0 +1,87 @@
+package provisioner
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/paasdeploy/backend/internal/domain"
+)
+
+func TestParseDokkuAppList(t *testing.T) {
+	got := parseDokkuAppList("web\n=====> My Apps\napi\n\nbad;name\n")
+	if !reflect.DeepEqual(got, []string{"api", "web"}) {
+		t.Errorf("parseDokkuAppList() = %v", got)
+	}
+}
+
+func TestParseDokkuConfig(t *testing.T) {
+	vars, err := parseDokkuConfig(`{"DATABASE_URL":"postgres://db","DOKKU_PROXY_PORT":"80","GIT_REV":"abc"}`)
+	if err != nil {
+		t.Fatalf("parseDokkuConfig: %v", err)
+	}
+	if !reflect.DeepEqual(vars, map[string]string{"DATABASE_URL": "postgres://db"}) {
+		t.Errorf("expected Dokku internal vars to be dropped, got %v", vars)
+	}
+
+	if vars, err := parseDokkuConfig(""); err != nil || len(vars) != 0 {
+		t.Errorf("expected empty config, got %v, %v", vars, err)
+	}
+}
+
+func TestParseDokkuMounts(t *testing.T) {
+	got := parseDokkuMounts("-v /var/lib/dokku/data/storage/web:/app/storage -v /etc/certs:/certs:ro")
+	want := []domain.ImportedVolume{
+		{Source: "/var/lib/dokku/data/storage/web", Target: "/app/storage"},
+		{Source: "/etc/certs", Target: "/certs", ReadOnly: true},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseDokkuMounts() = %+v", got)
+	}
+}
+
+func TestParseDokkuPortMap(t *testing.T) {
+	tests := map[string]int{
+		"http:80:3000 https:443:3000": 3000,
+		"tcp:2222:22 http:80:5000":    5000,
+		"":                            0,
+	}
+	for in, want := range tests {
+		if got := parseDokkuPortMap(in); got != want {
+			t.Errorf("parseDokkuPortMap(%q) = %d, want %d", in, got, want)
+		}
+	}
+}
+
+func TestParseCapRoverApps(t *testing.T) {
+	apps, err := parseCapRoverApps([]byte(`{"appDefinitions":{
+		"wordpress":{"containerHttpPort":0,
+			"envVars":[{"key":"WORDPRESS_DB_HOST","value":"srv-captain--db"}],
+			"volumes":[{"containerPath":"/var/www/html","volumeName":"wp-data"},{"containerPath":"/backup","hostPath":"/srv/backup"}],
+			"customDomain":[{"publicDomain":"blog.example.com","hasSsl":true}]},
+		"api":{"containerHttpPort":3000,"envVars":[],"volumes":[],"customDomain":[]}}}`))
+	if err != nil {
+		t.Fatalf("parseCapRoverApps: %v", err)
+	}
+	if len(apps) != 2 || apps[0].Name != "api" || apps[0].Port != 3000 {
+		t.Fatalf("unexpected apps %+v", apps)
+	}
+
+	wp := apps[1]
+	if wp.Port != capRoverDefaultPort || wp.EnvVars["WORDPRESS_DB_HOST"] != "srv-captain--db" {
+		t.Errorf("unexpected app %+v", wp)
+	}
+	if !reflect.DeepEqual(wp.Domains, []string{"blog.example.com"}) {
+		t.Errorf("unexpected domains %v", wp.Domains)
+	}
+	want := []domain.ImportedVolume{
+		{Source: "/var/lib/docker/volumes/captain--wp-data/_data", Target: "/var/www/html"},
+		{Source: "/srv/backup", Target: "/backup"},
+	}
+	if !reflect.DeepEqual(wp.Volumes, want) {
+		t.Errorf("unexpected volumes %+v", wp.Volumes)
+	}
+
+	if _, err := parseCapRoverApps([]byte("not json")); err == nil {
+		t.Error("expected error for invalid config")
+	}
+}
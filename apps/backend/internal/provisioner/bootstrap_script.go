@@ -0,0 +1,141 @@
+package provisioner
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/paasdeploy/backend/internal/agentdownload"
+)
+
+const (
+	bootstrapInstallDir = "/opt/paasdeploy-agent"
+	bootstrapUnitPath   = "/etc/systemd/system/" + agentSystemdUnit
+)
+
+type BootstrapScriptInput struct {
+	ServerID   string
+	APIBaseURL string
+	Token      string
+	ExpiresAt  time.Time
+}
+
+// BootstrapScript returns a shell script suitable for cloud-init user-data.
+// Run as root at first boot, it installs Docker, downloads the agent binary
+// for the host architecture, exchanges the one-shot token for the agent
+// certificates and starts the agent, which then registers over gRPC.
+func (p *SSHProvisioner) BootstrapScript(in BootstrapScriptInput) string {
+	launch := agentLaunchOpts{
+		installDir: bootstrapInstallDir,
+		serverID:   in.ServerID,
+		serverAddr: p.cfg.ServerAddr,
+		agentPort:  p.cfg.AgentPort,
+	}
+	logLaunch := launch
+	logLaunch.logFile = bootstrapInstallDir + "/" + agentLogFileName
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `#!/bin/sh
+# PaasDeploy agent bootstrap for server %s.
+# The embedded token is single-use and expires at %s.
+set -eu
+
+API_URL=%s
+TOKEN=%s
+INSTALL_DIR=%s
+
+if [ "$(id -u)" != "0" ]; then
+	echo "paasdeploy: bootstrap must run as root" >&2
+	exit 1
+fi
+
+fetch() {
+	if command -v curl >/dev/null 2>&1; then
+		curl -fsSL --retry 5 --retry-delay 5 -o "$2" "$1"
+	else
+		wget -q -O "$2" "$1"
+	fi
+}
+
+fetch_post() {
+	if command -v curl >/dev/null 2>&1; then
+		curl -fsSL --retry 5 --retry-delay 5 -X POST -o "$2" "$1"
+	else
+		wget -q --post-data= -O "$2" "$1"
+	fi
+}
+
+case "$(uname -m)" in
+	x86_64|amd64) ARCH=amd64 ;;
+	aarch64|arm64) ARCH=arm64 ;;
+	*) echo "paasdeploy: unsupported architecture $(uname -m)" >&2; exit 1 ;;
+esac
+
+. /etc/os-release 2>/dev/null || true
+if ! command -v docker >/dev/null 2>&1; then
+	if command -v apk >/dev/null 2>&1; then
+		apk add --no-cache docker docker-cli-compose docker-cli-buildx
+	elif command -v dnf >/dev/null 2>&1 && [ "${ID:-}" != "fedora" ]; then
+		dnf -y install dnf-plugins-core
+		dnf config-manager --add-repo %s
+		dnf -y install docker-ce docker-ce-cli containerd.io docker-buildx-plugin docker-compose-plugin
+	else
+		fetch https://get.docker.com /tmp/get-docker.sh
+		sh /tmp/get-docker.sh
+		rm -f /tmp/get-docker.sh
+	fi
+fi
+if command -v systemctl >/dev/null 2>&1 && [ -d /run/systemd/system ]; then
+	systemctl enable --now docker
+elif command -v rc-service >/dev/null 2>&1; then
+	rc-update add docker default
+	rc-service docker start || true
+fi
+docker network inspect %s >/dev/null 2>&1 || docker network create %s
+
+mkdir -p "$INSTALL_DIR"
+fetch "$API_URL%s?token=$TOKEN&arch=$ARCH" "$INSTALL_DIR/agent.new"
+chmod 755 "$INSTALL_DIR/agent.new"
+mv "$INSTALL_DIR/agent.new" "$INSTALL_DIR/agent"
+
+fetch_post "$API_URL%s?token=$TOKEN" "$INSTALL_DIR/certs.tar"
+tar -xf "$INSTALL_DIR/certs.tar" -C "$INSTALL_DIR"
+rm -f "$INSTALL_DIR/certs.tar"
+chmod 600 "$INSTALL_DIR/key.pem"
+
+`, in.ServerID, in.ExpiresAt.UTC().Format(time.RFC3339),
+		shellQuote(strings.TrimSuffix(in.APIBaseURL, "/")), shellQuote(in.Token), shellQuote(bootstrapInstallDir),
+		dockerCERepoURL, dockerNetworkName, dockerNetworkName, agentdownload.BootstrapBinaryPath, agentdownload.BootstrapCertsPath)
+
+	fmt.Fprintf(&b, `if command -v systemctl >/dev/null 2>&1 && [ -d /run/systemd/system ]; then
+	cat > %s <<'UNIT'
+%sUNIT
+	systemctl daemon-reload
+	systemctl enable --now %s
+elif command -v openrc-run >/dev/null 2>&1; then
+	cat > %s <<'INITD'
+%sINITD
+	chmod 755 %s
+	rc-update add %s default
+	rc-service %s restart
+else
+	cat > "$INSTALL_DIR/%s" <<'WATCHDOG'
+%sWATCHDOG
+	chmod 755 "$INSTALL_DIR/%s"
+	(crontab -l 2>/dev/null | grep -v %s; echo "@reboot sh $INSTALL_DIR/%s >/dev/null 2>&1") | crontab -
+	%s
+fi
+
+echo "paasdeploy: agent installed, waiting for registration"
+`,
+		bootstrapUnitPath, buildSystemdUnit(launch), agentSystemdUnit,
+		openRCScriptPath, buildOpenRCScript(logLaunch, "root"), openRCScriptPath, openRCServiceName, openRCServiceName,
+		agentWatchdogScript, buildWatchdogScript(logLaunch), agentWatchdogScript, agentWatchdogScript, agentWatchdogScript,
+		watchdogStartCommand(bootstrapInstallDir))
+
+	return b.String()
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
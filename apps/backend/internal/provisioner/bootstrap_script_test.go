@@ -0,0 +1,24 @@
+package provisioner
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBootstrapScript(t *testing.T) {
+	p := NewSSHProvisioner(SSHProvisionerConfig{ServerAddr: "paas.example.com:50051", AgentPort: 50052})
+	script := p.BootstrapScript(BootstrapScriptInput{
+		ServerID:   "srv-1",
+		APIBaseURL: "https://paas.example.com/",
+		Token:      "tok'en",
+		ExpiresAt:  time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+	})
+
+	assertContains(t, script, "API_URL='https://paas.example.com'")
+	assertContains(t, script, `TOKEN='tok'\''en'`)
+	assertContains(t, script, "/paas-deploy/v1/agent/bootstrap/binary?token=$TOKEN&arch=$ARCH")
+	assertContains(t, script, "-server-addr=paas.example.com:50051 -server-id=srv-1")
+	assertContains(t, script, "systemctl enable --now "+agentSystemdUnit)
+	assertContains(t, script, "rc-update add "+openRCServiceName)
+	assertContains(t, script, "expires at 2025-01-01T00:00:00Z")
+}
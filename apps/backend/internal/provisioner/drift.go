@@ -0,0 +1,314 @@
+package provisioner
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"net"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+
+	"github.com/paasdeploy/backend/internal/agentdownload"
+	"github.com/paasdeploy/backend/internal/domain"
+)
+
+const (
+	DriftDocker        = "docker"
+	DriftNetwork       = "network"
+	DriftTraefikImage  = "traefik_image"
+	DriftTraefikConfig = "traefik_config"
+	DriftAgentCerts    = "agent_certs"
+	DriftAgentBinary   = "agent_binary"
+	DriftInstallMethod = "install_method"
+	DriftAgentService  = "agent_service"
+	DriftAgentRunning  = "agent_running"
+
+	certRenewBefore = 30 * 24 * time.Hour
+	driftMissing    = "missing"
+	driftModified   = "modified"
+	driftManaged    = "managed"
+)
+
+type DriftItem struct {
+	Component string `json:"component"`
+	Desired   string `json:"desired"`
+	Actual    string `json:"actual"`
+}
+
+// DriftReport lists the components whose state on the server differs from
+// what Provision would install. Running Provision again reconciles exactly
+// these components.
+type DriftReport struct {
+	ServerID  string      `json:"serverId"`
+	InSync    bool        `json:"inSync"`
+	Drift     []DriftItem `json:"drift"`
+	CheckedAt time.Time   `json:"checkedAt"`
+}
+
+func remoteFileContent(client *ssh.Client, remotePath string) (string, bool) {
+	out, err := runCommandOutput(client, fmt.Sprintf("cat %q 2>/dev/null", remotePath))
+	if err != nil {
+		return "", false
+	}
+	return out, true
+}
+
+// fileDrift compares a remote file with its desired content, ignoring
+// surrounding whitespace. It returns "" when the file is up to date.
+func fileDrift(client *ssh.Client, remotePath, desired string) string {
+	actual, ok := remoteFileContent(client, remotePath)
+	if !ok || strings.TrimSpace(actual) == "" {
+		return driftMissing
+	}
+	if strings.TrimSpace(actual) != strings.TrimSpace(desired) {
+		return driftModified
+	}
+	return ""
+}
+
+func shortHash(hash string) string {
+	if len(hash) > 12 {
+		return hash[:12]
+	}
+	return hash
+}
+
+func localFileHash(localPath string) (string, error) {
+	data, err := os.ReadFile(localPath)
+	if err != nil {
+		return "", fmt.Errorf(errReadAgentBinaryFmt, err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func remoteFileHash(client *ssh.Client, remotePath string) string {
+	out, err := runCommandOutput(client, fmt.Sprintf("sha256sum %q 2>/dev/null | cut -d' ' -f1", remotePath))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(out)
+}
+
+func (p *SSHProvisioner) traefikConfigDrift(client *ssh.Client, acmeEmail string, acmeStaging bool, entrypoints []domain.ServerEntrypoint) string {
+	desired, err := buildTraefikConfig(acmeEmail, acmeStaging, entrypoints)
+	if err != nil {
+		return ""
+	}
+	return fileDrift(client, traefikConfigPath, string(desired))
+}
+
+// certsDrift reports agent certificates that were issued by another CA, for
+// another server or host, were revoked or are close to expiring.
+func (p *SSHProvisioner) certsDrift(client *ssh.Client, installDir string, server *domain.Server) string {
+	caPEM, ok := remoteFileContent(client, path.Join(installDir, "ca.pem"))
+	if !ok || caPEM == "" {
+		return driftMissing
+	}
+	if p.cfg.CA != nil && strings.TrimSpace(caPEM) != strings.TrimSpace(string(p.cfg.CA.GetCACertPEM())) {
+		return "issued by another CA"
+	}
+	certPEM, ok := remoteFileContent(client, path.Join(installDir, "cert.pem"))
+	if !ok {
+		return driftMissing
+	}
+	block, _ := pem.Decode([]byte(certPEM))
+	if block == nil {
+		return "invalid"
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return "invalid"
+	}
+	if cert.Subject.CommonName != server.ID || cert.VerifyHostname(server.Host) != nil {
+		return "issued for another server"
+	}
+	if p.cfg.CertRevocations != nil && p.cfg.CertRevocations.IsCertRevoked(cert) {
+		return "revoked"
+	}
+	if time.Until(cert.NotAfter) < certRenewBefore {
+		return "expires " + cert.NotAfter.UTC().Format(time.DateOnly)
+	}
+	return ""
+}
+
+// agentBinaryDrift returns the desired and actual hashes when the remote
+// agent binary differs from the one the backend would deploy.
+func (p *SSHProvisioner) agentBinaryDrift(client *ssh.Client, installDir string) (string, string, error) {
+	arch, err := detectRemoteArch(client)
+	if err != nil {
+		return "", "", err
+	}
+	localPath, err := agentdownload.ResolveBinaryPath(p.cfg.AgentBinaryPath, arch)
+	if err != nil {
+		return "", "", err
+	}
+	desired, err := localFileHash(localPath)
+	if err != nil {
+		return "", "", err
+	}
+	actual := remoteFileHash(client, path.Join(installDir, "agent"))
+	if actual == desired {
+		return "", "", nil
+	}
+	if actual == "" {
+		actual = driftMissing
+	}
+	return shortHash(desired), shortHash(actual), nil
+}
+
+// agentServiceFile returns the path and content of the unit or script written
+// for the install method. Docker installs have no file, so ok is false.
+func (p *SSHProvisioner) agentServiceFile(client *ssh.Client, paths provisionPaths, method string) (string, string, bool) {
+	switch method {
+	case domain.AgentInstallSystemdUser:
+		return path.Join(paths.unitDir, agentSystemdUnit), buildSystemdUnit(p.launchOpts(paths, false)), true
+	case domain.AgentInstallOpenRC:
+		user, err := runCommandOutput(client, "whoami")
+		if err != nil {
+			return "", "", false
+		}
+		return openRCScriptPath, buildOpenRCScript(p.launchOpts(paths, true), strings.TrimSpace(user)), true
+	case domain.AgentInstallNohup:
+		return path.Join(paths.installDir, agentWatchdogScript), buildWatchdogScript(p.launchOpts(paths, true)), true
+	default:
+		return "", "", false
+	}
+}
+
+// agentServiceDrift compares the unit or script written for the install
+// method with what is on the server.
+func (p *SSHProvisioner) agentServiceDrift(client *ssh.Client, paths provisionPaths, method string) string {
+	filePath, desired, ok := p.agentServiceFile(client, paths, method)
+	if !ok {
+		return ""
+	}
+	return fileDrift(client, filePath, desired)
+}
+
+func agentRunning(client *ssh.Client, method string, paths provisionPaths) bool {
+	out, err := runCommandOutput(client, agentStatusCommand(method, paths.installDir, paths.runtimeDir))
+	return err == nil && strings.TrimSpace(out) == "active"
+}
+
+// desiredInstallMethod resolves "auto" to the method already installed, so a
+// server provisioned with auto-detection is not reported as drifted.
+func desiredInstallMethod(client *ssh.Client, requested string, paths provisionPaths, uid, password string) string {
+	if requested != "" && requested != domain.AgentInstallAuto {
+		return requested
+	}
+	if installed := readInstallMarker(client, paths.installDir); installed != "" {
+		return installed
+	}
+	return detectInstallMethod(client, paths.runtimeDir, uid, password)
+}
+
+// DetectDrift connects to the server and compares it with the desired state
+// without changing anything.
+func (p *SSHProvisioner) DetectDrift(server *domain.Server, sshKey, sshPassword string) (*DriftReport, error) {
+	client, err := p.connectServer(server, sshKey, sshPassword)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	paths, uid, err := inspectPaths(client, server.ID)
+	if err != nil {
+		return nil, err
+	}
+	return p.inspectDrift(client, server, paths, uid, sshPassword), nil
+}
+
+func serverAddr(server *domain.Server) string {
+	port := server.SSHPort
+	if port == 0 {
+		port = defaultSSHPort
+	}
+	return net.JoinHostPort(server.Host, fmt.Sprintf("%d", port))
+}
+
+func (p *SSHProvisioner) connectServer(server *domain.Server, sshKey, sshPassword string) (*ssh.Client, error) {
+	client, err := p.connect(server.SSHUser, serverAddr(server), sshKey, sshPassword, server.SSHHostKey, server.ID, server.Bastion)
+	if err != nil {
+		return nil, fmt.Errorf("ssh connect: %w", err)
+	}
+	return client, nil
+}
+
+// inspectPaths resolves the same paths Provision uses without creating the
+// install directory.
+func inspectPaths(client *ssh.Client, serverID string) (provisionPaths, string, error) {
+	homeDir, err := runCommandOutput(client, "printf $HOME")
+	if err != nil {
+		return provisionPaths{}, "", fmt.Errorf("get remote home: %w", err)
+	}
+	uid, err := runCommandOutput(client, "id -u")
+	if err != nil {
+		return provisionPaths{}, "", fmt.Errorf("get remote uid: %w", err)
+	}
+	return provisionPaths{
+		homeDir:      homeDir,
+		installDir:   path.Join(homeDir, agentInstallDirName),
+		unitDir:      path.Join(homeDir, dotConfigDir, "systemd", "user"),
+		runtimeDir:   path.Join("/run/user", uid),
+		serverID:     serverID,
+		dockerSocket: dockerSocketPath(client),
+	}, uid, nil
+}
+
+func (p *SSHProvisioner) inspectDrift(client *ssh.Client, server *domain.Server, paths provisionPaths, uid, password string) *DriftReport {
+	report := &DriftReport{ServerID: server.ID, Drift: []DriftItem{}, CheckedAt: time.Now()}
+	add := func(component, desired, actual string) {
+		report.Drift = append(report.Drift, DriftItem{Component: component, Desired: desired, Actual: actual})
+	}
+
+	if !commandSucceeds(client, "docker --version") {
+		add(DriftDocker, "installed", driftMissing)
+	}
+	if !commandSucceeds(client, fmt.Sprintf("docker network inspect %s", dockerNetworkName)) {
+		add(DriftNetwork, dockerNetworkName, driftMissing)
+	}
+
+	if server.AcmeEmail != nil && *server.AcmeEmail != "" {
+		if !p.isTraefikRunning(client) {
+			add(DriftTraefikImage, traefikImage, "not running")
+		} else if p.traefikNeedsUpgrade(client) {
+			current, _ := runCommandOutput(client, fmt.Sprintf("docker inspect %s --format '{{.Config.Image}}' 2>/dev/null", traefikContainerName))
+			add(DriftTraefikImage, traefikImage, strings.TrimSpace(current))
+		}
+		if actual := p.traefikConfigDrift(client, *server.AcmeEmail, server.AcmeStaging, server.Entrypoints); actual != "" {
+			add(DriftTraefikConfig, driftManaged, actual)
+		}
+	}
+
+	if actual := p.certsDrift(client, paths.installDir, server); actual != "" {
+		add(DriftAgentCerts, "valid", actual)
+	}
+	if p.cfg.AgentBinaryPath != "" {
+		desired, actual, err := p.agentBinaryDrift(client, paths.installDir)
+		if err != nil {
+			p.cfg.Logger.Warn("agent binary drift check failed", "serverId", server.ID, "error", err)
+		} else if desired != "" {
+			add(DriftAgentBinary, desired, actual)
+		}
+	}
+
+	method := desiredInstallMethod(client, server.AgentInstallMethod, paths, uid, password)
+	if installed := installedMethod(client, paths.installDir); installed != method {
+		add(DriftInstallMethod, method, installed)
+	} else if actual := p.agentServiceDrift(client, paths, method); actual != "" {
+		add(DriftAgentService, driftManaged, actual)
+	}
+	if !agentRunning(client, method, paths) {
+		add(DriftAgentRunning, "active", "inactive")
+	}
+
+	report.InSync = len(report.Drift) == 0
+	return report
+}
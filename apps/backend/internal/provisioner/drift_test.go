@@ -0,0 +1,121 @@
+package provisioner
+
+import (
+	"errors"
+	"path"
+	"testing"
+
+	"github.com/paasdeploy/backend/internal/domain"
+	"github.com/paasdeploy/backend/internal/pki"
+)
+
+const (
+	testDriftServerID = "srv-drift"
+	testDriftHost     = "203.0.113.10"
+)
+
+func testDriftPaths() provisionPaths {
+	return provisionPaths{
+		homeDir:    "/home/deploy",
+		installDir: testInstallDir,
+		unitDir:    "/home/deploy/.config/systemd/user",
+		runtimeDir: "/run/user/1000",
+		serverID:   testDriftServerID,
+	}
+}
+
+func findDrift(report *DriftReport, component string) *DriftItem {
+	for i := range report.Drift {
+		if report.Drift[i].Component == component {
+			return &report.Drift[i]
+		}
+	}
+	return nil
+}
+
+func TestInspectDrift(t *testing.T) {
+	server := &domain.Server{ID: testDriftServerID, Host: testDriftHost, AgentInstallMethod: domain.AgentInstallSystemdUser}
+
+	t.Run("ReportsMissingComponents", func(t *testing.T) {
+		mock := newCommandMock()
+		mock.setError("docker network inspect", errors.New(errNotFound))
+		defer mock.install(t)()
+
+		p := newTestProvisioner()
+		report := p.inspectDrift(nil, server, testDriftPaths(), uidNonRoot, "")
+
+		if report.InSync {
+			t.Fatal("expected drift to be reported")
+		}
+		for _, component := range []string{DriftNetwork, DriftAgentCerts, DriftAgentService, DriftAgentRunning} {
+			if findDrift(report, component) == nil {
+				t.Errorf("expected %s drift", component)
+			}
+		}
+		if findDrift(report, DriftDocker) != nil {
+			t.Error("docker is installed and should not be reported")
+		}
+	})
+
+	t.Run("InSyncWhenServerMatches", func(t *testing.T) {
+		ca, err := pki.NewCA()
+		requireNoError(t, err)
+		cert, err := ca.GenerateAgentCert(testDriftServerID, testDriftHost)
+		requireNoError(t, err)
+
+		p := newTestProvisioner()
+		p.cfg.CA = ca
+		paths := testDriftPaths()
+
+		mock := newCommandMock()
+		mock.setResponse("ca.pem", string(ca.GetCACertPEM()))
+		mock.setResponse("cert.pem", string(cert.CertPEM))
+		mock.setResponse("cat \""+path.Join(paths.unitDir, agentSystemdUnit), buildSystemdUnit(p.launchOpts(paths, false)))
+		mock.setResponse("is-active", "active")
+		defer mock.install(t)()
+
+		report := p.inspectDrift(nil, server, paths, uidNonRoot, "")
+
+		if !report.InSync {
+			t.Errorf("expected server in sync, got drift %+v", report.Drift)
+		}
+	})
+
+	t.Run("ReportsCertsFromAnotherCA", func(t *testing.T) {
+		ca, err := pki.NewCA()
+		requireNoError(t, err)
+		other, err := pki.NewCA()
+		requireNoError(t, err)
+
+		mock := newCommandMock()
+		mock.setResponse("ca.pem", string(other.GetCACertPEM()))
+		defer mock.install(t)()
+
+		p := newTestProvisioner()
+		p.cfg.CA = ca
+		if got := p.certsDrift(nil, testInstallDir, server); got != "issued by another CA" {
+			t.Errorf("certsDrift = %q, want issued by another CA", got)
+		}
+	})
+
+	t.Run("ReportsTraefikConfigDrift", func(t *testing.T) {
+		email := testEmailDefault
+		withAcme := *server
+		withAcme.AcmeEmail = &email
+
+		mock := setupTraefikMock(t, true, traefikImage)
+		mock.setResponse("cat \""+traefikConfigPath, "log:\n  level: DEBUG")
+		defer mock.install(t)()
+
+		p := newTestProvisioner()
+		report := p.inspectDrift(nil, &withAcme, testDriftPaths(), uidNonRoot, "")
+
+		item := findDrift(report, DriftTraefikConfig)
+		if item == nil || item.Actual != driftModified {
+			t.Errorf("expected modified traefik config drift, got %+v", item)
+		}
+		if findDrift(report, DriftTraefikImage) != nil {
+			t.Error("traefik image is current and should not be reported")
+		}
+	})
+}
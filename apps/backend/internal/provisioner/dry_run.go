@@ -0,0 +1,297 @@
+package provisioner
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+
+	"github.com/paasdeploy/backend/internal/domain"
+)
+
+type PlannedStep struct {
+	Step        string   `json:"step"`
+	Description string   `json:"description"`
+	Commands    []string `json:"commands,omitempty"`
+	Diff        string   `json:"diff,omitempty"`
+}
+
+// ProvisionPlan is what Provision would do on the server in its current
+// state. An empty plan means provisioning again would change nothing.
+type ProvisionPlan struct {
+	ServerID    string        `json:"serverId"`
+	Steps       []PlannedStep `json:"steps"`
+	GeneratedAt time.Time     `json:"generatedAt"`
+}
+
+func (pl *ProvisionPlan) add(step, description string, commands ...string) *PlannedStep {
+	pl.Steps = append(pl.Steps, PlannedStep{Step: step, Description: description, Commands: commands})
+	return &pl.Steps[len(pl.Steps)-1]
+}
+
+// lineDiff renders a minimal line diff between the remote file and the
+// desired content, prefixing removed lines with "-" and added lines with "+".
+func lineDiff(actual, desired string) string {
+	a := strings.Split(strings.TrimSpace(actual), "\n")
+	d := strings.Split(strings.TrimSpace(desired), "\n")
+	if strings.TrimSpace(actual) == "" {
+		a = nil
+	}
+
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(d)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(d) - 1; j >= 0; j-- {
+			if a[i] == d[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var b strings.Builder
+	i, j := 0, 0
+	for i < len(a) || j < len(d) {
+		switch {
+		case i < len(a) && j < len(d) && a[i] == d[j]:
+			b.WriteString(" " + a[i] + "\n")
+			i++
+			j++
+		case j < len(d) && (i == len(a) || lcs[i][j+1] >= lcs[i+1][j]):
+			b.WriteString("+" + d[j] + "\n")
+			j++
+		default:
+			b.WriteString("-" + a[i] + "\n")
+			i++
+		}
+	}
+	return b.String()
+}
+
+// fileChange returns the diff for a managed file, or "" when it is current.
+func fileChange(client *ssh.Client, remotePath, desired string) string {
+	if fileDrift(client, remotePath, desired) == "" {
+		return ""
+	}
+	actual, _ := remoteFileContent(client, remotePath)
+	return lineDiff(actual, desired)
+}
+
+// PlanProvision is the dry-run mode of Provision: it connects and inspects
+// the server with read-only commands and returns the steps and commands a
+// real run would execute, including diffs for the files it would rewrite.
+func (p *SSHProvisioner) PlanProvision(server *domain.Server, sshKey, sshPassword string) (*ProvisionPlan, error) {
+	client, err := p.connectServer(server, sshKey, sshPassword)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	paths, uid, err := inspectPaths(client, server.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &ProvisionPlan{ServerID: server.ID, Steps: []PlannedStep{}, GeneratedAt: time.Now()}
+	platform := detectPlatform(client)
+	openRC := usesOpenRC(client)
+
+	planServerPrep(client, plan)
+	if server.DockerRootless {
+		planRootlessDocker(client, plan, platform)
+	} else {
+		planDocker(client, plan, platform, uid, sshPassword, openRC)
+	}
+	if !commandSucceeds(client, fmt.Sprintf("docker network inspect %s", dockerNetworkName)) {
+		plan.add("docker_network", "Create Docker network "+dockerNetworkName, "docker network create "+dockerNetworkName)
+	}
+	if server.FirewallEnabled {
+		p.planFirewall(client, server, plan, platform)
+	}
+	if server.SSHHardening {
+		p.planSSHHardening(client, server, plan, platform, sshKey, openRC)
+	}
+	if server.AcmeEmail != nil && *server.AcmeEmail != "" {
+		p.planTraefik(client, plan, *server.AcmeEmail, server.AcmeStaging, server.Entrypoints, paths.dockerSocket)
+	}
+	p.planAgent(client, server, plan, paths, uid, sshPassword)
+	return plan, nil
+}
+
+func planServerPrep(client *ssh.Client, plan *ProvisionPlan) {
+	if memMB, needed := swapNeeded(client, func(string) {}); needed {
+		plan.add("server_prep", fmt.Sprintf("Create %d MB swap file (%d MB of memory)", swapSizeMB, memMB), swapCommands()...)
+	}
+	if diff := fileChange(client, serverPrepSysctlPath, buildServerPrepSysctl()); diff != "" {
+		plan.add("server_prep", "Write "+serverPrepSysctlPath, "sysctl -p "+serverPrepSysctlPath).Diff = diff
+	}
+}
+
+func planDocker(client *ssh.Client, plan *ProvisionPlan, platform hostPlatform, uid, password string, openRC bool) {
+	startCmd := dockerStartSystemd
+	if openRC {
+		startCmd = dockerStartOpenRC
+	}
+
+	if !commandSucceeds(client, "docker --version") {
+		cmds := []string{platform.dockerInstallCommand()}
+		if uid != "0" {
+			if user, err := runCommandOutput(client, "whoami"); err == nil && strings.TrimSpace(user) != "" {
+				cmds = append(cmds, platform.addToDockerGroupCommand(strings.TrimSpace(user)))
+			}
+		}
+		plan.add("docker_install", fmt.Sprintf("Install Docker via %s", platform.pkg), append(cmds, startCmd)...)
+		return
+	}
+
+	statusCmd := "systemctl is-active docker"
+	if openRC {
+		statusCmd = "rc-service docker status"
+	}
+	if out, err := runPrivilegedCommandOutput(client, uid, password, statusCmd); err != nil || (!openRC && strings.TrimSpace(out) != "active") {
+		plan.add("docker_start", "Start and enable the Docker daemon", startCmd)
+	}
+
+	var packages []string
+	if !commandSucceeds(client, "docker compose version") {
+		packages = append(packages, platform.composePackage())
+	}
+	if !commandSucceeds(client, "docker buildx version") {
+		packages = append(packages, platform.buildxPackage())
+	}
+	if len(packages) > 0 {
+		plan.add("docker_plugins", "Install "+strings.Join(packages, ", "), platform.installPackagesCommand(packages))
+	}
+}
+
+func planRootlessDocker(client *ssh.Client, plan *ProvisionPlan, platform hostPlatform) {
+	if !commandSucceeds(client, "docker --version") {
+		plan.add("docker_install", fmt.Sprintf("Install Docker via %s", platform.pkg), platform.dockerInstallCommand())
+	}
+	if dockerSocketPath(client) != defaultDockerSocket {
+		return
+	}
+	var cmds []string
+	if !commandSucceeds(client, "command -v dockerd-rootless-setuptool.sh") {
+		cmds = append(cmds, platform.installPackagesCommand(platform.rootlessPackages()))
+	}
+	cmds = append(cmds,
+		"systemctl disable --now docker.service docker.socket",
+		"dockerd-rootless-setuptool.sh install",
+		"systemctl --user enable --now docker",
+	)
+	plan.add("docker_rootless", "Run the Docker daemon as the SSH user", cmds...)
+}
+
+func (p *SSHProvisioner) planFirewall(client *ssh.Client, server *domain.Server, plan *ProvisionPlan, platform hostPlatform) {
+	backendIP, err := detectBackendIP(client)
+	if err != nil {
+		plan.add("firewall", "Firewall cannot be planned: "+err.Error())
+		return
+	}
+	rules := p.firewallRules(server, backendIP)
+
+	switch {
+	case commandSucceeds(client, "command -v ufw"):
+		plan.add("firewall", "Apply UFW rules", ufwCommands(rules)...)
+	case commandSucceeds(client, "command -v nft"):
+		diff := fileChange(client, firewallNftablesPath, buildNftablesRuleset(rules))
+		if diff != "" {
+			plan.add("firewall", "Write and load "+firewallNftablesPath, "nft -f "+firewallNftablesPath).Diff = diff
+		}
+	case platform.pkg == packageManagerApt:
+		plan.add("firewall", "Install UFW and apply rules",
+			append([]string{platform.installPackagesCommand([]string{"ufw"})}, ufwCommands(rules)...)...)
+	default:
+		plan.add("firewall", "Install nftables and load "+firewallNftablesPath,
+			platform.installPackagesCommand([]string{"nftables"}), "nft -f "+firewallNftablesPath).Diff = lineDiff("", buildNftablesRuleset(rules))
+	}
+}
+
+func (p *SSHProvisioner) planSSHHardening(client *ssh.Client, server *domain.Server, plan *ProvisionPlan, platform hostPlatform, sshKey string, openRC bool) {
+	keyVerified := p.verifyKeyAuth(server, serverAddr(server), sshKey)
+	if diff := fileChange(client, sshdHardeningPath, buildSSHDHardeningConfig(keyVerified)); diff != "" {
+		plan.add("ssh_hardening", "Write "+sshdHardeningPath+" and reload sshd", "sshd -t").Diff = diff
+	}
+
+	if !commandSucceeds(client, "command -v fail2ban-client") {
+		plan.add("fail2ban", "Install fail2ban", platform.fail2banInstallCommand())
+	}
+	backendIP, _ := detectBackendIP(client)
+	sshPort := server.SSHPort
+	if sshPort == 0 {
+		sshPort = defaultSSHPort
+	}
+	if diff := fileChange(client, fail2banJailPath, buildFail2banJail(sshPort, backendIP, !openRC)); diff != "" {
+		plan.add("fail2ban", "Write "+fail2banJailPath+" and restart fail2ban").Diff = diff
+	}
+}
+
+func (p *SSHProvisioner) planTraefik(client *ssh.Client, plan *ProvisionPlan, acmeEmail string, acmeStaging bool, entrypoints []domain.ServerEntrypoint, dockerSocket string) {
+	desired, err := buildTraefikConfig(acmeEmail, acmeStaging, entrypoints)
+	if err != nil {
+		plan.add("traefik_install", "Traefik cannot be planned: "+err.Error())
+		return
+	}
+	diff := fileChange(client, traefikConfigPath, string(desired))
+
+	var description string
+	switch {
+	case !p.isTraefikRunning(client):
+		description = "Start Traefik " + traefikImage
+	case p.traefikNeedsUpgrade(client):
+		description = "Upgrade Traefik to " + traefikImage
+	case diff != "":
+		description = "Recreate Traefik with the updated " + traefikConfigPath
+	default:
+		return
+	}
+	plan.add("traefik_install", description,
+		fmt.Sprintf("docker rm -f %s", traefikContainerName),
+		"docker pull "+traefikImage,
+		traefikRunCommand(dockerSocket, entrypoints),
+	).Diff = diff
+}
+
+func (p *SSHProvisioner) planAgent(client *ssh.Client, server *domain.Server, plan *ProvisionPlan, paths provisionPaths, uid, password string) {
+	agentChanged := false
+	if actual := p.certsDrift(client, paths.installDir, server); actual != "" {
+		plan.add("agent_certs", fmt.Sprintf("Issue new agent certificates (current: %s)", actual))
+		agentChanged = true
+	}
+	if p.cfg.AgentBinaryPath != "" {
+		desired, actual, err := p.agentBinaryDrift(client, paths.installDir)
+		if err != nil {
+			plan.add("agent_binary", "Agent binary cannot be planned: "+err.Error())
+		} else if desired != "" {
+			plan.add("agent_binary", fmt.Sprintf("Copy agent binary %s (current: %s)", desired, actual))
+			agentChanged = true
+		}
+	}
+
+	method := desiredInstallMethod(client, server.AgentInstallMethod, paths, uid, password)
+	previous := readInstallMarker(client, paths.installDir)
+	if previous != "" && previous != method {
+		plan.add("start_agent", fmt.Sprintf("Stop agent installed via %s", previous))
+	}
+
+	filePath, desired, hasFile := p.agentServiceFile(client, paths, method)
+	diff := ""
+	if hasFile {
+		diff = fileChange(client, filePath, desired)
+	}
+	if agentChanged || previous != method || diff != "" || !agentRunning(client, method, paths) {
+		step := plan.add("start_agent", fmt.Sprintf("Install and restart agent via %s", method))
+		step.Diff = diff
+		if method == domain.AgentInstallSystemdUser {
+			step.Commands = []string{
+				fmt.Sprintf("XDG_RUNTIME_DIR=%s systemctl --user daemon-reload", paths.runtimeDir),
+				fmt.Sprintf("XDG_RUNTIME_DIR=%s systemctl --user restart %s", paths.runtimeDir, agentSystemdUnit),
+			}
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package provisioner
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func findPlannedStep(plan *ProvisionPlan, step string) *PlannedStep {
+	for i := range plan.Steps {
+		if plan.Steps[i].Step == step {
+			return &plan.Steps[i]
+		}
+	}
+	return nil
+}
+
+func TestLineDiff(t *testing.T) {
+	diff := lineDiff("a\nb\nc", "a\nx\nc")
+	want := " a\n+x\n-b\n c\n"
+	if diff != want {
+		t.Errorf("lineDiff = %q, want %q", diff, want)
+	}
+
+	diff = lineDiff("", "a\nb")
+	if diff != "+a\n+b\n" {
+		t.Errorf("lineDiff on missing file = %q", diff)
+	}
+}
+
+func TestPlanDocker(t *testing.T) {
+	t.Run("NotInstalled", func(t *testing.T) {
+		mock := newCommandMock()
+		mock.setError(cmdDockerVersion, errors.New(errNotFound))
+		mock.setResponse("whoami", "deploy")
+		defer mock.install(t)()
+
+		plan := &ProvisionPlan{}
+		planDocker(nil, plan, hostPlatform{pkg: packageManagerApt, osID: "ubuntu"}, uidNonRoot, "", false)
+
+		step := findPlannedStep(plan, "docker_install")
+		if step == nil {
+			t.Fatal("expected docker_install step")
+		}
+		assertContains(t, strings.Join(step.Commands, "\n"), "get.docker.com")
+		assertContains(t, strings.Join(step.Commands, "\n"), "usermod -aG docker deploy")
+		if mock.hasCommand("get.docker.com") {
+			t.Error("dry run must not install docker")
+		}
+	})
+
+	t.Run("InstalledAndActive", func(t *testing.T) {
+		mock := newCommandMock()
+		mock.setResponse(cmdDockerVersion, mockDockerVersionOut)
+		mock.setResponse("systemctl is-active docker", "active")
+		defer mock.install(t)()
+
+		plan := &ProvisionPlan{}
+		planDocker(nil, plan, hostPlatform{pkg: packageManagerApt, osID: "ubuntu"}, uidRoot, "", false)
+
+		if len(plan.Steps) != 0 {
+			t.Errorf("expected no steps, got %+v", plan.Steps)
+		}
+	})
+}
+
+func TestPlanTraefik(t *testing.T) {
+	t.Run("ConfigDriftShowsDiff", func(t *testing.T) {
+		mock := setupTraefikMock(t, true, traefikImage)
+		mock.setResponse("cat \""+traefikConfigPath, "log:\n  level: DEBUG")
+		defer mock.install(t)()
+
+		plan := &ProvisionPlan{}
+		newTestProvisioner().planTraefik(nil, plan, testEmailDefault, false, nil, defaultDockerSocket)
+
+		step := findPlannedStep(plan, "traefik_install")
+		if step == nil {
+			t.Fatal("expected traefik_install step")
+		}
+		assertContains(t, step.Diff, "-  level: DEBUG")
+		assertContains(t, step.Diff, "+")
+		if mock.hasCommand(cmdDockerRun) {
+			t.Error("dry run must not start traefik")
+		}
+	})
+
+	t.Run("UpToDate", func(t *testing.T) {
+		mock := setupTraefikMock(t, true, traefikImage)
+		mock.setResponse("cat \""+traefikConfigPath, requireTraefikConfig(t, testEmailDefault))
+		defer mock.install(t)()
+
+		plan := &ProvisionPlan{}
+		newTestProvisioner().planTraefik(nil, plan, testEmailDefault, false, nil, defaultDockerSocket)
+
+		if len(plan.Steps) != 0 {
+			t.Errorf("expected no steps, got %+v", plan.Steps)
+		}
+	})
+}
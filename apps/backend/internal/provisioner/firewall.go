@@ -0,0 +1,283 @@
+package provisioner
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+
+	"github.com/paasdeploy/backend/internal/domain"
+)
+
+const (
+	firewallNftablesDir  = "/etc/nftables.d"
+	firewallNftablesPath = firewallNftablesDir + "/paasdeploy.nft"
+	firewallUnitName     = "paasdeploy-firewall.service"
+	firewallUnitPath     = "/etc/systemd/system/" + firewallUnitName
+	firewallProtocolTCP  = "tcp"
+)
+
+type FirewallStore interface {
+	Upsert(fw *domain.ServerFirewall) error
+}
+
+// detectBackendIP returns the address the server sees the backend connecting
+// from, which is also where agent gRPC calls originate.
+func detectBackendIP(client *ssh.Client) (string, error) {
+	out, err := runCommandOutput(client, "echo $SSH_CLIENT")
+	if err != nil {
+		return "", fmt.Errorf("read SSH_CLIENT: %w", err)
+	}
+	fields := strings.Fields(out)
+	if len(fields) == 0 || net.ParseIP(fields[0]) == nil {
+		return "", errors.New("could not determine backend address from SSH_CLIENT")
+	}
+	return fields[0], nil
+}
+
+// firewallRules returns the inbound rules for a provisioned server: SSH, HTTP,
+// HTTPS, the extra Traefik entrypoints and, for mesh members, WireGuard from
+// anywhere and the agent port only from the backend.
+func (p *SSHProvisioner) firewallRules(server *domain.Server, backendIP string) []domain.FirewallRule {
+	sshPort := server.SSHPort
+	if sshPort == 0 {
+		sshPort = defaultSSHPort
+	}
+	rules := []domain.FirewallRule{
+		{Port: sshPort, Protocol: firewallProtocolTCP},
+		{Port: 80, Protocol: firewallProtocolTCP},
+		{Port: 443, Protocol: firewallProtocolTCP},
+	}
+	for _, e := range server.Entrypoints {
+		rules = append(rules, domain.FirewallRule{Port: e.Port, Protocol: e.Protocol})
+	}
+	if server.InMesh() {
+		rules = append(rules, domain.FirewallRule{Port: domain.MeshPort, Protocol: domain.EntrypointProtocolUDP})
+	}
+	if p.cfg.AgentPort > 0 {
+		rules = append(rules, domain.FirewallRule{Port: p.cfg.AgentPort, Protocol: firewallProtocolTCP, Source: backendIP})
+	}
+	return rules
+}
+
+func (p *SSHProvisioner) provisionFirewall(
+	client *ssh.Client,
+	server *domain.Server,
+	uid string,
+	password string,
+	step func(string, string, string),
+	logLine func(string),
+) error {
+	step("firewall", "running", "Configurando firewall...")
+	backendIP, err := detectBackendIP(client)
+	if err != nil {
+		return err
+	}
+	logLine(fmt.Sprintf("Porta do agent liberada apenas para %s", backendIP))
+
+	fw, err := applyFirewall(client, uid, password, p.firewallRules(server, backendIP), logLine)
+	if err != nil {
+		return err
+	}
+	fw.ServerID = server.ID
+	p.recordFirewall(fw)
+	step("firewall", "ok", fmt.Sprintf("Firewall configurado (%s)", fw.Backend))
+	return nil
+}
+
+// ReapplyFirewall connects to the server and applies the given rules again.
+// When no rules are given, the default rule set is computed from the current
+// server settings.
+func (p *SSHProvisioner) ReapplyFirewall(
+	server *domain.Server,
+	sshKey string,
+	sshPassword string,
+	rules []domain.FirewallRule,
+) (*domain.ServerFirewall, error) {
+	port := server.SSHPort
+	if port == 0 {
+		port = defaultSSHPort
+	}
+	addr := net.JoinHostPort(server.Host, fmt.Sprintf("%d", port))
+
+	client, err := p.connect(server.SSHUser, addr, sshKey, sshPassword, server.SSHHostKey, server.ID, server.Bastion)
+	if err != nil {
+		return nil, fmt.Errorf("ssh connect: %w", err)
+	}
+	defer client.Close()
+
+	uid, err := runCommandOutput(client, "id -u")
+	if err != nil {
+		return nil, fmt.Errorf("get uid: %w", err)
+	}
+	if len(rules) == 0 {
+		backendIP, err := detectBackendIP(client)
+		if err != nil {
+			return nil, err
+		}
+		rules = p.firewallRules(server, backendIP)
+	}
+
+	fw, err := applyFirewall(client, uid, sshPassword, rules, func(string) {})
+	if err != nil {
+		return nil, err
+	}
+	fw.ServerID = server.ID
+	p.recordFirewall(fw)
+	return fw, nil
+}
+
+func (p *SSHProvisioner) recordFirewall(fw *domain.ServerFirewall) {
+	if p.cfg.FirewallStore == nil {
+		return
+	}
+	if err := p.cfg.FirewallStore.Upsert(fw); err != nil {
+		p.cfg.Logger.Warn("failed to record firewall rules", "serverId", fw.ServerID, "error", err)
+	}
+}
+
+func applyFirewall(
+	client *ssh.Client,
+	uid string,
+	password string,
+	rules []domain.FirewallRule,
+	logLine func(string),
+) (*domain.ServerFirewall, error) {
+	backend, err := ensureFirewallBackend(client, uid, password, logLine)
+	if err != nil {
+		return nil, err
+	}
+
+	switch backend {
+	case domain.FirewallBackendUFW:
+		logLine("Aplicando regras UFW")
+		for _, cmd := range ufwCommands(rules) {
+			if err := runPrivilegedCommand(client, uid, password, cmd); err != nil {
+				return nil, fmt.Errorf("ufw: %w", err)
+			}
+		}
+	default:
+		logLine("Aplicando regras nftables")
+		if err := applyNftables(client, uid, password, rules); err != nil {
+			return nil, err
+		}
+	}
+
+	return &domain.ServerFirewall{Backend: backend, Rules: rules, AppliedAt: time.Now()}, nil
+}
+
+// ensureFirewallBackend prefers an installed UFW, then nftables. When neither
+// is present, UFW is installed on apt hosts and nftables everywhere else.
+func ensureFirewallBackend(client *ssh.Client, uid, password string, logLine func(string)) (string, error) {
+	if commandSucceeds(client, "command -v ufw") {
+		return domain.FirewallBackendUFW, nil
+	}
+	if commandSucceeds(client, "command -v nft") {
+		return domain.FirewallBackendNftables, nil
+	}
+
+	platform := detectPlatform(client)
+	backend, pkg := domain.FirewallBackendNftables, "nftables"
+	if platform.pkg == packageManagerApt {
+		backend, pkg = domain.FirewallBackendUFW, "ufw"
+	}
+	logLine(fmt.Sprintf("Instalando %s", pkg))
+	if err := runPrivilegedCommand(client, uid, password, platform.installPackagesCommand([]string{pkg})); err != nil {
+		return "", fmt.Errorf("install %s: %w", pkg, err)
+	}
+	return backend, nil
+}
+
+func ufwCommands(rules []domain.FirewallRule) []string {
+	cmds := []string{
+		"ufw --force reset",
+		"ufw default deny incoming",
+		"ufw default allow outgoing",
+	}
+	for _, r := range rules {
+		if r.Source == "" {
+			cmds = append(cmds, fmt.Sprintf("ufw allow %d/%s", r.Port, r.Protocol))
+			continue
+		}
+		cmds = append(cmds, fmt.Sprintf("ufw allow from %s to any port %d proto %s", r.Source, r.Port, r.Protocol))
+	}
+	cmds = append(cmds, "ufw allow in on "+domain.MeshInterface)
+	return append(cmds, "ufw --force enable")
+}
+
+// buildNftablesRuleset renders a dedicated inet table so the rules can be
+// replaced atomically without touching tables owned by Docker or the distro.
+// Traffic from Docker bridges is accepted so containers can still reach the
+// host, as is traffic from mesh peers.
+func buildNftablesRuleset(rules []domain.FirewallRule) string {
+	var b strings.Builder
+	b.WriteString("table inet paasdeploy\n")
+	b.WriteString("delete table inet paasdeploy\n\n")
+	b.WriteString("table inet paasdeploy {\n")
+	b.WriteString("\tchain input {\n")
+	b.WriteString("\t\ttype filter hook input priority 0; policy drop;\n")
+	b.WriteString("\t\tct state established,related accept\n")
+	b.WriteString("\t\tiifname \"lo\" accept\n")
+	b.WriteString("\t\tiifname \"docker*\" accept\n")
+	b.WriteString("\t\tiifname \"br-*\" accept\n")
+	fmt.Fprintf(&b, "\t\tiifname %q accept\n", domain.MeshInterface)
+	b.WriteString("\t\tmeta l4proto { icmp, ipv6-icmp } accept\n")
+	for _, r := range rules {
+		match := ""
+		if r.Source != "" {
+			family := "ip"
+			if ip := net.ParseIP(r.Source); ip != nil && ip.To4() == nil {
+				family = "ip6"
+			}
+			match = fmt.Sprintf("%s saddr %s ", family, r.Source)
+		}
+		fmt.Fprintf(&b, "\t\t%s%s dport %d accept\n", match, r.Protocol, r.Port)
+	}
+	b.WriteString("\t}\n")
+	b.WriteString("}\n")
+	return b.String()
+}
+
+func buildFirewallUnit() string {
+	return fmt.Sprintf(`[Unit]
+Description=PaasDeploy firewall rules
+Wants=network-pre.target
+Before=network-pre.target
+
+[Service]
+Type=oneshot
+RemainAfterExit=yes
+ExecStart=/bin/sh -c 'nft -f %s'
+
+[Install]
+WantedBy=multi-user.target
+`, firewallNftablesPath)
+}
+
+// applyNftables loads the ruleset and makes it persistent. Alpine's nftables
+// service already includes /etc/nftables.d; systemd hosts get a oneshot unit.
+func applyNftables(client *ssh.Client, uid, password string, rules []domain.FirewallRule) error {
+	if err := runPrivilegedCommand(client, uid, password, "mkdir -p "+firewallNftablesDir); err != nil {
+		return fmt.Errorf("create nftables dir: %w", err)
+	}
+	if err := writeRemoteFileViaSSH(client, uid, password, firewallNftablesPath, []byte(buildNftablesRuleset(rules))); err != nil {
+		return fmt.Errorf("write nftables ruleset: %w", err)
+	}
+	if err := runPrivilegedCommand(client, uid, password, "nft -f "+firewallNftablesPath); err != nil {
+		return fmt.Errorf("load nftables ruleset: %w", err)
+	}
+
+	if usesOpenRC(client) {
+		return runPrivilegedCommand(client, uid, password, "rc-update add nftables default")
+	}
+	if err := writeRemoteFileViaSSH(client, uid, password, firewallUnitPath, []byte(buildFirewallUnit())); err != nil {
+		return fmt.Errorf("write firewall unit: %w", err)
+	}
+	if err := runPrivilegedCommand(client, uid, password, "systemctl daemon-reload"); err != nil {
+		return fmt.Errorf("reload systemd: %w", err)
+	}
+	return runPrivilegedCommand(client, uid, password, "systemctl enable "+firewallUnitName)
+}
@@ -0,0 +1,115 @@
+package provisioner
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/paasdeploy/backend/internal/domain"
+)
+
+func TestFirewallRules(t *testing.T) {
+	p := newTestProvisioner()
+	p.cfg.AgentPort = 50052
+
+	rules := p.firewallRules(&domain.Server{}, "203.0.113.10")
+	if len(rules) != 4 {
+		t.Fatalf("expected 4 rules, got %d", len(rules))
+	}
+	if rules[0].Port != defaultSSHPort || rules[0].Source != "" {
+		t.Errorf("expected SSH open on port %d, got %+v", defaultSSHPort, rules[0])
+	}
+	if agent := rules[3]; agent.Port != 50052 || agent.Source != "203.0.113.10" {
+		t.Errorf("expected agent port restricted to backend, got %+v", agent)
+	}
+
+	server := &domain.Server{SSHPort: 22, Entrypoints: []domain.ServerEntrypoint{{Name: "game", Port: 27015, Protocol: "udp"}}}
+	rules = p.firewallRules(server, "203.0.113.10")
+	if len(rules) != 5 || rules[3].Port != 27015 || rules[3].Protocol != "udp" || rules[3].Source != "" {
+		t.Errorf("expected entrypoint port open to anyone, got %+v", rules)
+	}
+
+	server.MeshIP = "10.210.0.1"
+	rules = p.firewallRules(server, "203.0.113.10")
+	if len(rules) != 6 || rules[4].Port != domain.MeshPort || rules[4].Protocol != "udp" || rules[4].Source != "" {
+		t.Errorf("expected WireGuard port open for mesh members, got %+v", rules)
+	}
+}
+
+func TestUfwCommands(t *testing.T) {
+	cmds := ufwCommands([]domain.FirewallRule{
+		{Port: 22, Protocol: "tcp"},
+		{Port: 50052, Protocol: "tcp", Source: "203.0.113.10"},
+	})
+	if cmds[0] != "ufw --force reset" || cmds[len(cmds)-1] != "ufw --force enable" {
+		t.Errorf("expected reset first and enable last, got %v", cmds)
+	}
+	assertContains(t, cmds[3], "ufw allow 22/tcp")
+	assertContains(t, cmds[4], "ufw allow from 203.0.113.10 to any port 50052 proto tcp")
+}
+
+func TestBuildNftablesRuleset(t *testing.T) {
+	ruleset := buildNftablesRuleset([]domain.FirewallRule{
+		{Port: 443, Protocol: "tcp"},
+		{Port: 50052, Protocol: "tcp", Source: "203.0.113.10"},
+		{Port: 50052, Protocol: "tcp", Source: "2001:db8::1"},
+	})
+	assertContains(t, ruleset, "policy drop;")
+	assertContains(t, ruleset, "\t\ttcp dport 443 accept\n")
+	assertContains(t, ruleset, "ip saddr 203.0.113.10 tcp dport 50052 accept")
+	assertContains(t, ruleset, "ip6 saddr 2001:db8::1 tcp dport 50052 accept")
+	assertContains(t, ruleset, "iifname \"wg-paasdeploy\" accept")
+}
+
+func TestProvisionFirewall(t *testing.T) {
+	t.Run("UsesInstalledUfw", func(t *testing.T) {
+		mock := newCommandMock()
+		mock.setResponse("SSH_CLIENT", "203.0.113.10 51234 22")
+		defer mock.install(t)()
+
+		p := newTestProvisioner()
+		p.cfg.AgentPort = 50052
+		server := &domain.Server{ID: "srv-1", SSHPort: 2222}
+		requireNoError(t, p.provisionFirewall(nil, server, uidRoot, "", noopStep, noopLog))
+
+		if !mock.hasCommand("ufw allow 2222/tcp") {
+			t.Error("expected custom SSH port to be allowed")
+		}
+		if !mock.hasCommand("ufw allow from 203.0.113.10 to any port 50052 proto tcp") {
+			t.Error("expected agent port restricted to backend")
+		}
+	})
+
+	t.Run("InstallsNftablesOnDnf", func(t *testing.T) {
+		mock := newCommandMock()
+		mock.setResponse("SSH_CLIENT", "203.0.113.10 51234 22")
+		mock.setResponse(detectPlatformCmd, "dnf almalinux")
+		mock.setError("command -v ufw", errors.New(errNotFound))
+		mock.setError("command -v nft", errors.New(errNotFound))
+		mock.setError("command -v rc-service", errors.New(errNotFound))
+		defer mock.install(t)()
+
+		p := newTestProvisioner()
+		server := &domain.Server{ID: "srv-1"}
+		requireNoError(t, p.provisionFirewall(nil, server, uidRoot, "", noopStep, noopLog))
+
+		if !mock.hasCommand("dnf install -y -q nftables") {
+			t.Error("expected nftables to be installed")
+		}
+		if !mock.hasCommand("nft -f " + firewallNftablesPath) {
+			t.Error("expected ruleset to be loaded")
+		}
+		if !mock.hasCommand("systemctl enable " + firewallUnitName) {
+			t.Error("expected firewall unit to be enabled")
+		}
+	})
+
+	t.Run("FailsWithoutBackendAddress", func(t *testing.T) {
+		mock := newCommandMock()
+		defer mock.install(t)()
+
+		p := newTestProvisioner()
+		if err := p.provisionFirewall(nil, &domain.Server{ID: "srv-1"}, uidRoot, "", noopStep, noopLog); err == nil {
+			t.Fatal("expected error when SSH_CLIENT is empty")
+		}
+	})
+}
@@ -8,6 +8,8 @@ import (
 	"time"
 
 	"golang.org/x/crypto/ssh"
+
+	"github.com/paasdeploy/backend/internal/domain"
 )
 
 var validEmailRegex = regexp.MustCompile(`^[a-zA-Z0-9._%+\-]+@[a-zA-Z0-9.\-]+\.[a-zA-Z]{2,}$`)
@@ -19,6 +21,12 @@ const (
 	traefikConfigDir      = "/opt/traefik"
 	traefikLetsencryptDir = "/opt/traefik/letsencrypt"
 	traefikConfigPath     = "/opt/traefik/traefik.yml"
+	traefikDynamicDir     = "/opt/traefik/dynamic"
+	traefikLogsDir        = "/opt/traefik/logs"
+	traefikLogrotatePath  = "/etc/logrotate.d/paasdeploy-traefik"
+	letsencryptStagingCA  = "https://acme-staging-v02.api.letsencrypt.org/directory"
+	dockerStartSystemd    = "systemctl start docker && systemctl enable docker"
+	dockerStartOpenRC     = "rc-update add docker default && rc-service docker start"
 )
 
 func (p *SSHProvisioner) provisionDocker(
@@ -42,10 +50,10 @@ func (p *SSHProvisioner) provisionDocker(
 	}
 
 	step("docker_install", "running", "Instalando Docker...")
-	logLine("Docker nao encontrado, instalando via get.docker.com")
+	platform := detectPlatform(client)
+	logLine(fmt.Sprintf("Docker nao encontrado, instalando via %s", platform.pkg))
 
-	installCmd := "curl -fsSL https://get.docker.com | sh"
-	if err := runPrivilegedCommandWithTimeout(client, uid, password, fmt.Sprintf("sh -c '%s'", installCmd), timeoutDockerInstall); err != nil {
+	if err := runPrivilegedCommandWithTimeout(client, uid, password, platform.dockerInstallCommand(), timeoutDockerInstall); err != nil {
 		return fmt.Errorf("install docker: %w", err)
 	}
 
@@ -55,8 +63,7 @@ func (p *SSHProvisioner) provisionDocker(
 	if uid != "0" {
 		currentUser, userErr := runCommandOutput(client, "whoami")
 		if userErr == nil && currentUser != "" {
-			addGroupCmd := fmt.Sprintf("usermod -aG docker %s", strings.TrimSpace(currentUser))
-			_ = runPrivilegedCommand(client, uid, password, addGroupCmd)
+			_ = runPrivilegedCommand(client, uid, password, platform.addToDockerGroupCommand(strings.TrimSpace(currentUser)))
 		}
 	}
 
@@ -86,16 +93,17 @@ func (p *SSHProvisioner) ensureDockerPlugins(
 
 	step("docker_plugins", "running", "Installing Docker plugins...")
 
+	platform := detectPlatform(client)
 	var packages []string
 	if needCompose {
-		packages = append(packages, "docker-compose-plugin")
+		packages = append(packages, platform.composePackage())
 	}
 	if needBuildx {
-		packages = append(packages, "docker-buildx-plugin")
+		packages = append(packages, platform.buildxPackage())
 	}
 
-	logLine(fmt.Sprintf("Installing %s", strings.Join(packages, ", ")))
-	installCmd := fmt.Sprintf("apt-get update -qq && apt-get install -y -qq %s", strings.Join(packages, " "))
+	logLine(fmt.Sprintf("Installing %s via %s", strings.Join(packages, ", "), platform.pkg))
+	installCmd := platform.installPackagesCommand(packages)
 	if err := runPrivilegedCommandWithTimeout(client, uid, password, installCmd, timeoutDockerInstall); err != nil {
 		if needCompose {
 			return fmt.Errorf("install docker plugins: %w", err)
@@ -121,6 +129,10 @@ func (p *SSHProvisioner) ensureDockerRunning(
 ) error {
 	step("docker_start", "running", "Verificando Docker daemon...")
 
+	if usesOpenRC(client) {
+		return p.ensureDockerRunningOpenRC(client, uid, password, step, logLine)
+	}
+
 	out, err := runPrivilegedCommandOutput(client, uid, password, "systemctl is-active docker")
 	if err == nil && strings.TrimSpace(out) == "active" {
 		logLine("Docker daemon esta ativo")
@@ -129,8 +141,30 @@ func (p *SSHProvisioner) ensureDockerRunning(
 	}
 
 	logLine("Iniciando Docker daemon")
-	startCmd := "systemctl start docker && systemctl enable docker"
-	if err := runPrivilegedCommandWithTimeout(client, uid, password, startCmd, timeoutDockerCheck); err != nil {
+	if err := runPrivilegedCommandWithTimeout(client, uid, password, dockerStartSystemd, timeoutDockerCheck); err != nil {
+		return fmt.Errorf("start docker daemon: %w", err)
+	}
+
+	logLine("Docker daemon iniciado e habilitado")
+	step("docker_start", "ok", "Docker daemon iniciado")
+	return nil
+}
+
+func (p *SSHProvisioner) ensureDockerRunningOpenRC(
+	client *ssh.Client,
+	uid string,
+	password string,
+	step func(string, string, string),
+	logLine func(string),
+) error {
+	if _, err := runPrivilegedCommandOutput(client, uid, password, "rc-service docker status"); err == nil {
+		logLine("Docker daemon esta ativo (OpenRC)")
+		step("docker_start", "ok", "Docker daemon ativo")
+		return nil
+	}
+
+	logLine("Iniciando Docker daemon (OpenRC)")
+	if err := runPrivilegedCommandWithTimeout(client, uid, password, dockerStartOpenRC, timeoutDockerCheck); err != nil {
 		return fmt.Errorf("start docker daemon: %w", err)
 	}
 
@@ -170,6 +204,8 @@ func (p *SSHProvisioner) provisionTraefik(
 	uid string,
 	password string,
 	acmeEmail string,
+	acmeStaging bool,
+	entrypoints []domain.ServerEntrypoint,
 	step func(string, string, string),
 	logLine func(string),
 ) error {
@@ -179,22 +215,27 @@ func (p *SSHProvisioner) provisionTraefik(
 	running := p.isTraefikRunning(client)
 	if running {
 		needsUpgrade := p.traefikNeedsUpgrade(client)
-		if !needsUpgrade {
-			logLine("Traefik ja esta rodando com versao correta")
+		configDrift := p.traefikConfigDrift(client, acmeEmail, acmeStaging, entrypoints)
+		if !needsUpgrade && configDrift == "" {
+			logLine("Traefik ja esta rodando com versao e configuracao corretas")
 			step("traefik_check", "ok", "Traefik encontrado")
 			return nil
 		}
-		logLine(fmt.Sprintf("Traefik rodando com versao diferente da desejada (%s), atualizando", traefikImage))
+		if needsUpgrade {
+			logLine(fmt.Sprintf("Traefik rodando com versao diferente da desejada (%s), atualizando", traefikImage))
+		} else {
+			logLine("Configuracao do Traefik divergente, reaplicando")
+		}
 	}
 
 	step("traefik_install", "running", "Instalando Traefik...")
 	logLine("Configurando Traefik")
 
-	if err := p.setupTraefikConfig(client, uid, password, acmeEmail, logLine); err != nil {
+	if err := p.setupTraefikConfig(client, uid, password, acmeEmail, acmeStaging, entrypoints, logLine); err != nil {
 		return err
 	}
 
-	if err := p.startTraefikContainer(client, uid, logLine); err != nil {
+	if err := p.startTraefikContainer(client, uid, entrypoints, logLine); err != nil {
 		return err
 	}
 
@@ -222,16 +263,28 @@ func (p *SSHProvisioner) setupTraefikConfig(
 	uid string,
 	password string,
 	acmeEmail string,
+	acmeStaging bool,
+	entrypoints []domain.ServerEntrypoint,
 	logLine func(string),
 ) error {
 	logLine("Criando diretorios do Traefik")
-	mkdirCmd := fmt.Sprintf("mkdir -p %s %s", traefikConfigDir, traefikLetsencryptDir)
+	mkdirCmd := fmt.Sprintf("mkdir -p %s %s %s %s", traefikConfigDir, traefikLetsencryptDir, traefikDynamicDir, traefikLogsDir)
 	if err := runPrivilegedCommand(client, uid, password, mkdirCmd); err != nil {
 		return fmt.Errorf("create traefik dirs: %w", err)
 	}
 
+	// The agent runs as the SSH user and writes uploaded certificates here.
+	if uid != "0" {
+		if owner, err := runCommandOutput(client, "id -un"); err == nil && strings.TrimSpace(owner) != "" {
+			chownCmd := fmt.Sprintf("chown %s %s", strings.TrimSpace(owner), traefikDynamicDir)
+			if err := runPrivilegedCommand(client, uid, password, chownCmd); err != nil {
+				return fmt.Errorf("chown traefik dynamic dir: %w", err)
+			}
+		}
+	}
+
 	logLine("Escrevendo configuracao do Traefik")
-	configContent, err := buildTraefikConfig(acmeEmail)
+	configContent, err := buildTraefikConfig(acmeEmail, acmeStaging, entrypoints)
 	if err != nil {
 		return fmt.Errorf("build traefik config: %w", err)
 	}
@@ -239,12 +292,35 @@ func (p *SSHProvisioner) setupTraefikConfig(
 		return fmt.Errorf("write traefik config: %w", err)
 	}
 
+	if commandSucceeds(client, "command -v logrotate") {
+		if err := writeRemoteFileViaSSH(client, uid, password, traefikLogrotatePath, []byte(buildTraefikLogrotate())); err != nil {
+			logLine(fmt.Sprintf("Aviso: nao foi possivel configurar logrotate do Traefik: %v", err))
+		}
+	}
+
 	return nil
 }
 
+// buildTraefikLogrotate rotates the access log in place, since Traefik keeps
+// the file open.
+func buildTraefikLogrotate() string {
+	return fmt.Sprintf(`%s/*.log {
+	daily
+	rotate 7
+	maxsize 100M
+	missingok
+	notifempty
+	compress
+	delaycompress
+	copytruncate
+}
+`, traefikLogsDir)
+}
+
 func (p *SSHProvisioner) startTraefikContainer(
 	client *ssh.Client,
 	_ string,
+	entrypoints []domain.ServerEntrypoint,
 	logLine func(string),
 ) error {
 	logLine("Removendo container Traefik anterior (se existir)")
@@ -258,25 +334,39 @@ func (p *SSHProvisioner) startTraefikContainer(
 	_ = runCommandWithTimeout(client, pullCmd, timeoutTraefikSetup)
 
 	logLine("Iniciando container Traefik (portas 80, 443, 50051, 8081)")
-	runCmd := fmt.Sprintf(
+	runCmd := traefikRunCommand(dockerSocketPath(client), entrypoints)
+	if err := runCommandWithTimeout(client, runCmd, timeoutTraefikSetup); err != nil {
+		return fmt.Errorf("start traefik container: %w", err)
+	}
+
+	logLine("Traefik iniciado com sucesso")
+	return nil
+}
+
+func traefikRunCommand(dockerSocket string, entrypoints []domain.ServerEntrypoint) string {
+	var extraPorts strings.Builder
+	for _, e := range entrypoints {
+		extraPorts.WriteString(fmt.Sprintf("-p %d:%d/%s ", e.Port, e.Port, e.Protocol))
+	}
+	return fmt.Sprintf(
 		"docker run -d --name %s --network %s --restart unless-stopped "+
-			"-p 80:80 -p 443:443 -p 50051:50051 -p 8081:8081 "+
-			"-v /var/run/docker.sock:/var/run/docker.sock:ro "+
+			"-p 80:80 -p 443:443 -p 50051:50051 -p 8081:8081 %s"+
+			"-v %s:/var/run/docker.sock:ro "+
 			"-v %s:/etc/traefik/traefik.yml:ro "+
 			"-v %s:/letsencrypt "+
+			"-v %s:/etc/traefik/dynamic:ro "+
+			"-v %s:/var/log/traefik "+
 			"%s",
 		traefikContainerName,
 		dockerNetworkName,
+		extraPorts.String(),
+		dockerSocket,
 		traefikConfigPath,
 		traefikLetsencryptDir,
+		traefikDynamicDir,
+		traefikLogsDir,
 		traefikImage,
 	)
-	if err := runCommandWithTimeout(client, runCmd, timeoutTraefikSetup); err != nil {
-		return fmt.Errorf("start traefik container: %w", err)
-	}
-
-	logLine("Traefik iniciado com sucesso")
-	return nil
 }
 
 func sanitizeAcmeEmail(email string) (string, error) {
@@ -287,7 +377,12 @@ func sanitizeAcmeEmail(email string) (string, error) {
 	return email, nil
 }
 
-func buildTraefikConfig(acmeEmail string) ([]byte, error) {
+// buildTraefikConfig renders traefik.yml. Staging points the resolver at the
+// Let's Encrypt staging CA, with its own storage so production certificates
+// are kept for when staging is turned off. Extra entrypoints are added for
+// apps exposing TCP or UDP ports. Access logs are written as JSON for the
+// agent's per-domain analytics, without request headers.
+func buildTraefikConfig(acmeEmail string, staging bool, entrypoints []domain.ServerEntrypoint) ([]byte, error) {
 	sanitized, err := sanitizeAcmeEmail(acmeEmail)
 	if err != nil {
 		return nil, err
@@ -307,22 +402,42 @@ func buildTraefikConfig(acmeEmail string) ([]byte, error) {
 	buf.WriteString("    address: \":50051\"\n")
 	buf.WriteString("  traefik:\n")
 	buf.WriteString("    address: \":8081\"\n")
+	for _, e := range entrypoints {
+		buf.WriteString("  " + e.Name + ":\n")
+		buf.WriteString(fmt.Sprintf("    address: \":%d/%s\"\n", e.Port, e.Protocol))
+	}
 	buf.WriteString("\n")
 	buf.WriteString("providers:\n")
 	buf.WriteString("  docker:\n")
 	buf.WriteString("    endpoint: \"unix:///var/run/docker.sock\"\n")
 	buf.WriteString("    exposedByDefault: false\n")
 	buf.WriteString("    network: " + dockerNetworkName + "\n")
+	buf.WriteString("  file:\n")
+	buf.WriteString("    directory: /etc/traefik/dynamic\n")
+	buf.WriteString("    watch: true\n")
 	buf.WriteString("\n")
 	buf.WriteString("certificatesResolvers:\n")
 	buf.WriteString("  letsencrypt:\n")
 	buf.WriteString("    acme:\n")
 	buf.WriteString("      email: \"" + sanitized + "\"\n")
-	buf.WriteString("      storage: /letsencrypt/acme.json\n")
+	if staging {
+		buf.WriteString("      caServer: " + letsencryptStagingCA + "\n")
+		buf.WriteString("      storage: /letsencrypt/acme-staging.json\n")
+	} else {
+		buf.WriteString("      storage: /letsencrypt/acme.json\n")
+	}
 	buf.WriteString("      tlsChallenge: {}\n")
 	buf.WriteString("\n")
 	buf.WriteString("log:\n")
 	buf.WriteString("  level: INFO\n")
+	buf.WriteString("\n")
+	buf.WriteString("accessLog:\n")
+	buf.WriteString("  filePath: /var/log/traefik/access.log\n")
+	buf.WriteString("  format: json\n")
+	buf.WriteString("  bufferingSize: 100\n")
+	buf.WriteString("  fields:\n")
+	buf.WriteString("    headers:\n")
+	buf.WriteString("      defaultMode: drop\n")
 	return buf.Bytes(), nil
 }
 
@@ -0,0 +1,369 @@
+package provisioner
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+
+	"github.com/paasdeploy/backend/internal/domain"
+)
+
+const (
+	agentInstallMarker     = "install-method"
+	agentLogFileName       = "agent.log"
+	agentWatchdogScript    = "agent-watchdog.sh"
+	agentWatchdogPIDFile   = "agent-watchdog.pid"
+	agentContainerName     = "paasdeploy-agent"
+	agentContainerImage    = "docker:cli"
+	openRCServiceName      = "paasdeploy-agent"
+	openRCScriptPath       = "/etc/init.d/paasdeploy-agent"
+	defaultAgentServerAddr = "localhost:50051"
+	defaultAgentPort       = 50052
+	traefikAPIURL          = "http://127.0.0.1:8081"
+)
+
+type agentLaunchOpts struct {
+	installDir   string
+	serverID     string
+	serverAddr   string
+	agentPort    int
+	logFile      string
+	dockerSocket string
+}
+
+func (p *SSHProvisioner) launchOpts(paths provisionPaths, withLogFile bool) agentLaunchOpts {
+	opts := agentLaunchOpts{
+		installDir:   paths.installDir,
+		serverID:     paths.serverID,
+		serverAddr:   p.cfg.ServerAddr,
+		agentPort:    p.cfg.AgentPort,
+		dockerSocket: paths.dockerSocket,
+	}
+	if withLogFile {
+		opts.logFile = path.Join(paths.installDir, agentLogFileName)
+	}
+	return opts
+}
+
+func (o agentLaunchOpts) args() string {
+	serverAddr := o.serverAddr
+	if serverAddr == "" {
+		serverAddr = defaultAgentServerAddr
+	}
+	agentPort := o.agentPort
+	if agentPort == 0 {
+		agentPort = defaultAgentPort
+	}
+
+	args := fmt.Sprintf("-server-addr=%s -server-id=%s -ca-cert=%s/ca.pem -cert=%s/cert.pem -key=%s/key.pem -agent-port=%d",
+		serverAddr, o.serverID, o.installDir, o.installDir, o.installDir, agentPort)
+	if o.logFile != "" {
+		args += fmt.Sprintf(" -log-file=%s", o.logFile)
+	}
+	return args
+}
+
+func (o agentLaunchOpts) hostDockerSocket() string {
+	if o.dockerSocket == "" {
+		return defaultDockerSocket
+	}
+	return o.dockerSocket
+}
+
+// detectInstallMethod prefers a systemd user session, then OpenRC when the
+// user can install system services, and falls back to nohup with a watchdog.
+func detectInstallMethod(client *ssh.Client, runtimeDir, uid, password string) string {
+	if commandSucceeds(client, fmt.Sprintf("XDG_RUNTIME_DIR=%s systemctl --user show-environment", runtimeDir)) {
+		return domain.AgentInstallSystemdUser
+	}
+	if commandSucceeds(client, "command -v openrc-run") && canRunPrivileged(client, uid, password) {
+		return domain.AgentInstallOpenRC
+	}
+	return domain.AgentInstallNohup
+}
+
+func canRunPrivileged(client *ssh.Client, uid, password string) bool {
+	if uid == "0" || password != "" {
+		return true
+	}
+	return commandSucceeds(client, "sudo -n true")
+}
+
+func readInstallMarker(client *ssh.Client, installDir string) string {
+	out, err := runCommandOutput(client, fmt.Sprintf("cat %q 2>/dev/null", path.Join(installDir, agentInstallMarker)))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(out)
+}
+
+// installedMethod returns the method recorded by the last provision. Servers
+// provisioned before install methods existed always use systemd.
+func installedMethod(client *ssh.Client, installDir string) string {
+	if method := readInstallMarker(client, installDir); method != "" {
+		return method
+	}
+	return domain.AgentInstallSystemdUser
+}
+
+func (p *SSHProvisioner) provisionAgentService(
+	client *ssh.Client,
+	sftpClient *sftp.Client,
+	paths provisionPaths,
+	requested, uid, password string,
+	agentChanged bool,
+	step func(string, string, string),
+	logLine func(string),
+) error {
+	method := requested
+	if method == "" || method == domain.AgentInstallAuto {
+		method = detectInstallMethod(client, paths.runtimeDir, uid, password)
+	}
+	logLine(fmt.Sprintf("Método de instalação do agent: %s", method))
+
+	previous := readInstallMarker(client, paths.installDir)
+	if previous != "" && previous != method {
+		logLine(fmt.Sprintf("Parando agent instalado via %s", previous))
+		stopAgent(client, previous, paths.installDir, paths.runtimeDir, uid, password)
+	}
+	if !agentChanged && previous == method && p.agentServiceDrift(client, paths, method) == "" && agentRunning(client, method, paths) {
+		logLine("Serviço do agent em dia, nada a reaplicar")
+		step("start_agent", "ok", "Agent em execução")
+		return nil
+	}
+
+	var err error
+	switch method {
+	case domain.AgentInstallSystemdUser:
+		err = p.provisionSystemdAndStart(client, sftpClient, paths, step, logLine)
+	case domain.AgentInstallOpenRC:
+		err = p.provisionOpenRCAndStart(client, paths, uid, password, step, logLine)
+	case domain.AgentInstallNohup:
+		err = p.provisionNohupAndStart(client, sftpClient, paths, step, logLine)
+	case domain.AgentInstallDocker:
+		err = p.provisionDockerAgentAndStart(client, paths, step, logLine)
+	default:
+		err = fmt.Errorf("unsupported agent install method: %s", method)
+	}
+	if err != nil {
+		return err
+	}
+
+	markerPath := path.Join(paths.installDir, agentInstallMarker)
+	if err := writeRemoteFile(sftpClient, markerPath, []byte(method+"\n"), 0o644); err != nil {
+		logLine(fmt.Sprintf("Falha ao registrar método de instalação: %s", err))
+	}
+	return nil
+}
+
+func buildOpenRCScript(opts agentLaunchOpts, user string) string {
+	return fmt.Sprintf(`#!/sbin/openrc-run
+
+name="PaasDeploy Agent"
+command="%s/agent"
+command_args="%s"
+command_user="%s"
+supervisor="supervise-daemon"
+respawn_delay=5
+respawn_max=0
+pidfile="/run/%s.pid"
+
+export TRAEFIK_API_URL="%s"
+
+depend() {
+	need net
+	after docker
+}
+`, opts.installDir, opts.args(), user, openRCServiceName, traefikAPIURL)
+}
+
+func (p *SSHProvisioner) provisionOpenRCAndStart(
+	client *ssh.Client,
+	paths provisionPaths,
+	uid, password string,
+	step func(string, string, string),
+	logLine func(string),
+) error {
+	step("systemd_unit", "running", "Configurando serviço OpenRC...")
+	user, err := runCommandOutput(client, "whoami")
+	if err != nil {
+		return fmt.Errorf("get remote user: %w", err)
+	}
+
+	script := buildOpenRCScript(p.launchOpts(paths, true), strings.TrimSpace(user))
+	if err := writeRemoteFileViaSSH(client, uid, password, openRCScriptPath, []byte(script)); err != nil {
+		return fmt.Errorf("write openrc script: %w", err)
+	}
+	if err := runPrivilegedCommand(client, uid, password, fmt.Sprintf("chmod 755 %s", openRCScriptPath)); err != nil {
+		return fmt.Errorf("chmod openrc script: %w", err)
+	}
+	if err := runPrivilegedCommand(client, uid, password, fmt.Sprintf("rc-update add %s default", openRCServiceName)); err != nil {
+		return fmt.Errorf("enable openrc service: %w", err)
+	}
+	step("systemd_unit", "ok", "Serviço OpenRC configurado")
+
+	logLine("Iniciando agent")
+	step("start_agent", "running", "Iniciando agent...")
+	if err := runPrivilegedCommand(client, uid, password, fmt.Sprintf("rc-service %s restart", openRCServiceName)); err != nil {
+		return fmt.Errorf("start agent: %w", err)
+	}
+	step("start_agent", "ok", "Agent iniciado")
+	return nil
+}
+
+func buildWatchdogScript(opts agentLaunchOpts) string {
+	return fmt.Sprintf(`#!/bin/sh
+# Keeps the agent running on hosts without an init system we can use.
+cd %q || exit 1
+echo $$ > %s
+export TRAEFIK_API_URL=%s
+while true; do
+	%s/agent %s >/dev/null 2>&1
+	sleep 5
+done
+`, opts.installDir, agentWatchdogPIDFile, traefikAPIURL, opts.installDir, opts.args())
+}
+
+func watchdogStartCommand(installDir string) string {
+	script := path.Join(installDir, agentWatchdogScript)
+	return fmt.Sprintf("nohup sh %q </dev/null >/dev/null 2>&1 &", script)
+}
+
+func (p *SSHProvisioner) provisionNohupAndStart(
+	client *ssh.Client,
+	sftpClient *sftp.Client,
+	paths provisionPaths,
+	step func(string, string, string),
+	logLine func(string),
+) error {
+	step("systemd_unit", "running", "Configurando watchdog...")
+	scriptPath := path.Join(paths.installDir, agentWatchdogScript)
+	script := buildWatchdogScript(p.launchOpts(paths, true))
+	if err := writeRemoteFile(sftpClient, scriptPath, []byte(script), 0o755); err != nil {
+		return fmt.Errorf("write watchdog script: %w", err)
+	}
+
+	cronLine := fmt.Sprintf("@reboot sh %s >/dev/null 2>&1", scriptPath)
+	cronCmd := fmt.Sprintf("(crontab -l 2>/dev/null | grep -v %q; echo %q) | crontab -", agentWatchdogScript, cronLine)
+	if err := runCommand(client, cronCmd); err != nil {
+		logLine(fmt.Sprintf("crontab indisponível, o agent não iniciará após reboot: %s", err))
+	}
+	step("systemd_unit", "ok", "Watchdog configurado")
+
+	logLine("Iniciando agent")
+	step("start_agent", "running", "Iniciando agent...")
+	stopWatchdog(client, paths.installDir)
+	if err := runCommand(client, watchdogStartCommand(paths.installDir)); err != nil {
+		return fmt.Errorf("start agent: %w", err)
+	}
+	step("start_agent", "ok", "Agent iniciado")
+	return nil
+}
+
+func agentProcessPattern(installDir string) string {
+	return path.Join(installDir, "agent") + " -server-addr"
+}
+
+func stopWatchdog(client *ssh.Client, installDir string) {
+	pidFile := path.Join(installDir, agentWatchdogPIDFile)
+	killCmd := fmt.Sprintf("if [ -f %q ]; then kill $(cat %q) 2>/dev/null; rm -f %q; fi; pkill -f %q 2>/dev/null || true",
+		pidFile, pidFile, pidFile, agentProcessPattern(installDir))
+	_ = runCommand(client, killCmd)
+}
+
+func buildDockerAgentRunCommand(opts agentLaunchOpts, dataDir string) string {
+	entrypoint := fmt.Sprintf("apk add --no-cache git >/dev/null 2>&1; exec %s/agent %s", opts.installDir, opts.args())
+	return fmt.Sprintf(
+		"docker run -d --name %s --restart unless-stopped --network host "+
+			"-e TRAEFIK_API_URL=%s -e DEPLOY_DATA_DIR=%s -e HOME=/root "+
+			"-v %s:/var/run/docker.sock "+
+			"-v %s:%s -v %s:%s "+
+			"--entrypoint sh %s -c %q",
+		agentContainerName,
+		traefikAPIURL, dataDir,
+		opts.hostDockerSocket(),
+		opts.installDir, opts.installDir, dataDir, dataDir,
+		agentContainerImage, entrypoint,
+	)
+}
+
+func (p *SSHProvisioner) provisionDockerAgentAndStart(
+	client *ssh.Client,
+	paths provisionPaths,
+	step func(string, string, string),
+	logLine func(string),
+) error {
+	step("systemd_unit", "running", "Preparando container do agent...")
+	dataDir := path.Join(paths.homeDir, ".paasdeploy", "apps")
+	if err := runCommand(client, fmt.Sprintf("mkdir -p %q", dataDir)); err != nil {
+		return fmt.Errorf("create data dir: %w", err)
+	}
+	_ = runCommand(client, fmt.Sprintf("docker rm -f %s 2>/dev/null || true", agentContainerName))
+	if err := runCommandWithTimeout(client, fmt.Sprintf("docker pull %s", agentContainerImage), timeoutDockerCheck*4); err != nil {
+		logLine(fmt.Sprintf("Falha ao baixar %s, tentando imagem local: %s", agentContainerImage, err))
+	}
+	step("systemd_unit", "ok", "Container do agent preparado")
+
+	logLine("Iniciando agent")
+	step("start_agent", "running", "Iniciando agent...")
+	runCmd := buildDockerAgentRunCommand(p.launchOpts(paths, true), dataDir)
+	if err := runCommandWithTimeout(client, runCmd, timeoutDockerCheck); err != nil {
+		return fmt.Errorf("start agent container: %w", err)
+	}
+	step("start_agent", "ok", "Agent iniciado")
+	return nil
+}
+
+func restartInstalledAgent(client *ssh.Client, method, installDir, runtimeDir, uid, password string) error {
+	switch method {
+	case domain.AgentInstallOpenRC:
+		return runPrivilegedCommand(client, uid, password, fmt.Sprintf("rc-service %s restart", openRCServiceName))
+	case domain.AgentInstallNohup:
+		stopWatchdog(client, installDir)
+		return runCommand(client, watchdogStartCommand(installDir))
+	case domain.AgentInstallDocker:
+		return runCommand(client, fmt.Sprintf("docker restart %s", agentContainerName))
+	default:
+		return runCommand(client, fmt.Sprintf("XDG_RUNTIME_DIR=%s systemctl --user restart %s", runtimeDir, agentSystemdUnit))
+	}
+}
+
+// agentStatusCommand prints "active" when the agent is running.
+func agentStatusCommand(method, installDir, runtimeDir string) string {
+	switch method {
+	case domain.AgentInstallOpenRC:
+		return fmt.Sprintf("rc-service %s status >/dev/null 2>&1 && echo active || echo inactive", openRCServiceName)
+	case domain.AgentInstallNohup:
+		return fmt.Sprintf("sleep 1; pgrep -f %q >/dev/null && echo active || echo inactive", agentProcessPattern(installDir))
+	case domain.AgentInstallDocker:
+		return fmt.Sprintf("[ \"$(docker inspect -f '{{.State.Running}}' %s 2>/dev/null)\" = true ] && echo active || echo inactive", agentContainerName)
+	default:
+		return fmt.Sprintf("XDG_RUNTIME_DIR=%s systemctl --user is-active %s", runtimeDir, agentSystemdUnit)
+	}
+}
+
+func agentLogsCommand(method, installDir, runtimeDir string) string {
+	if method == domain.AgentInstallSystemdUser {
+		return fmt.Sprintf("XDG_RUNTIME_DIR=%s journalctl --user -u %s -n 100 --no-pager 2>&1", runtimeDir, agentSystemdUnit)
+	}
+	return fmt.Sprintf("tail -n 100 %q 2>&1", path.Join(installDir, agentLogFileName))
+}
+
+func stopAgent(client *ssh.Client, method, installDir, runtimeDir, uid, password string) {
+	switch method {
+	case domain.AgentInstallOpenRC:
+		_ = runPrivilegedCommand(client, uid, password, fmt.Sprintf("rc-service %s stop 2>/dev/null || true", openRCServiceName))
+		_ = runPrivilegedCommand(client, uid, password, fmt.Sprintf("rc-update del %s default 2>/dev/null || true", openRCServiceName))
+	case domain.AgentInstallNohup:
+		stopWatchdog(client, installDir)
+		_ = runCommand(client, fmt.Sprintf("(crontab -l 2>/dev/null | grep -v %q) | crontab - 2>/dev/null || true", agentWatchdogScript))
+	case domain.AgentInstallDocker:
+		_ = runCommand(client, fmt.Sprintf("docker rm -f %s 2>/dev/null || true", agentContainerName))
+	default:
+		_ = runCommand(client, fmt.Sprintf("XDG_RUNTIME_DIR=%s systemctl --user stop %s 2>/dev/null || true", runtimeDir, agentSystemdUnit))
+		_ = runCommand(client, fmt.Sprintf("XDG_RUNTIME_DIR=%s systemctl --user disable %s 2>/dev/null || true", runtimeDir, agentSystemdUnit))
+	}
+}
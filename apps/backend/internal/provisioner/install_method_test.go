@@ -0,0 +1,104 @@
+package provisioner
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/paasdeploy/backend/internal/domain"
+)
+
+const (
+	cmdSystemdUserEnv = "systemctl --user show-environment"
+	cmdOpenRCRun      = "command -v openrc-run"
+	cmdSudoCheck      = "sudo -n true"
+	testInstallDir    = "/home/deploy/paasdeploy-agent"
+)
+
+func TestDetectInstallMethod(t *testing.T) {
+	t.Run("SystemdUserSession", func(t *testing.T) {
+		mock := newCommandMock()
+		defer mock.install(t)()
+
+		if got := detectInstallMethod(nil, "/run/user/1000", uidNonRoot, ""); got != domain.AgentInstallSystemdUser {
+			t.Errorf("expected systemd_user, got %q", got)
+		}
+	})
+
+	t.Run("OpenRCWithSudo", func(t *testing.T) {
+		mock := newCommandMock()
+		mock.setError(cmdSystemdUserEnv, errors.New("no user session"))
+		defer mock.install(t)()
+
+		if got := detectInstallMethod(nil, "/run/user/1000", uidNonRoot, ""); got != domain.AgentInstallOpenRC {
+			t.Errorf("expected openrc, got %q", got)
+		}
+	})
+
+	t.Run("OpenRCWithoutPrivilegesFallsBackToNohup", func(t *testing.T) {
+		mock := newCommandMock()
+		mock.setError(cmdSystemdUserEnv, errors.New("no user session"))
+		mock.setError(cmdSudoCheck, errors.New("password required"))
+		defer mock.install(t)()
+
+		if got := detectInstallMethod(nil, "/run/user/1000", uidNonRoot, ""); got != domain.AgentInstallNohup {
+			t.Errorf("expected nohup, got %q", got)
+		}
+	})
+
+	t.Run("NoInitSystem", func(t *testing.T) {
+		mock := newCommandMock()
+		mock.setError(cmdSystemdUserEnv, errors.New("no user session"))
+		mock.setError(cmdOpenRCRun, errors.New(errNotFound))
+		defer mock.install(t)()
+
+		if got := detectInstallMethod(nil, "/run/user/0", uidRoot, ""); got != domain.AgentInstallNohup {
+			t.Errorf("expected nohup, got %q", got)
+		}
+	})
+}
+
+func TestAgentLaunchScripts(t *testing.T) {
+	opts := agentLaunchOpts{
+		installDir: testInstallDir,
+		serverID:   "srv-1",
+		serverAddr: "control.example.com:50051",
+		logFile:    testInstallDir + "/agent.log",
+	}
+
+	t.Run("OpenRC", func(t *testing.T) {
+		script := buildOpenRCScript(opts, "deploy")
+		assertContains(t, script, "#!/sbin/openrc-run")
+		assertContains(t, script, `command_user="deploy"`)
+		assertContains(t, script, "-server-id=srv-1")
+		assertContains(t, script, "-agent-port=50052")
+		assertContains(t, script, "-log-file="+testInstallDir+"/agent.log")
+	})
+
+	t.Run("Watchdog", func(t *testing.T) {
+		script := buildWatchdogScript(opts)
+		assertContains(t, script, testInstallDir+"/agent -server-addr=control.example.com:50051")
+		assertContains(t, script, "echo $$ > "+agentWatchdogPIDFile)
+	})
+
+	t.Run("Docker", func(t *testing.T) {
+		cmd := buildDockerAgentRunCommand(opts, "/home/deploy/.paasdeploy/apps")
+		assertContains(t, cmd, "--network host")
+		assertContains(t, cmd, "-v /var/run/docker.sock:/var/run/docker.sock")
+		assertContains(t, cmd, "-e DEPLOY_DATA_DIR=/home/deploy/.paasdeploy/apps")
+		assertContains(t, cmd, "--restart unless-stopped")
+	})
+}
+
+func TestRestartInstalledAgent(t *testing.T) {
+	mock := newCommandMock()
+	defer mock.install(t)()
+
+	requireNoError(t, restartInstalledAgent(nil, domain.AgentInstallNohup, testInstallDir, "/run/user/1000", uidNonRoot, ""))
+
+	if !mock.hasCommand("pkill -f") {
+		t.Error("expected previous agent process to be stopped")
+	}
+	if !mock.hasCommandWith("nohup sh", agentWatchdogScript) {
+		t.Error("expected watchdog to be started with nohup")
+	}
+}
@@ -0,0 +1,152 @@
+package provisioner
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+
+	"github.com/paasdeploy/backend/internal/domain"
+)
+
+const (
+	meshConfigDir       = "/etc/wireguard"
+	meshConfigPath      = meshConfigDir + "/" + domain.MeshInterface + ".conf"
+	meshKeyPath         = meshConfigDir + "/" + domain.MeshInterface + ".key"
+	meshOpenRCStartPath = "/etc/local.d/paasdeploy-mesh.start"
+	meshKeepalive       = 25
+)
+
+// buildMeshConfig renders the wg-quick config. The private key is loaded from
+// a file on the server in PostUp so it never has to leave the host.
+func buildMeshConfig(meshIP string, peers []domain.MeshPeer) string {
+	var b strings.Builder
+	b.WriteString("# Managed by PaasDeploy\n")
+	b.WriteString("[Interface]\n")
+	fmt.Fprintf(&b, "Address = %s/16\n", meshIP)
+	fmt.Fprintf(&b, "ListenPort = %d\n", domain.MeshPort)
+	fmt.Fprintf(&b, "PostUp = wg set %%i private-key %s\n", meshKeyPath)
+	for _, peer := range peers {
+		b.WriteString("\n[Peer]\n")
+		if peer.Name != "" {
+			fmt.Fprintf(&b, "# %s\n", peer.Name)
+		}
+		fmt.Fprintf(&b, "PublicKey = %s\n", peer.PublicKey)
+		fmt.Fprintf(&b, "Endpoint = %s\n", peer.Endpoint)
+		fmt.Fprintf(&b, "AllowedIPs = %s/32\n", peer.MeshIP)
+		fmt.Fprintf(&b, "PersistentKeepalive = %d\n", meshKeepalive)
+	}
+	return b.String()
+}
+
+func meshKeyCommand() string {
+	return fmt.Sprintf("sh -c 'umask 077; mkdir -p %s; [ -s %s ] || wg genkey > %s'", meshConfigDir, meshKeyPath, meshKeyPath)
+}
+
+func meshUpCommand(openRC bool) string {
+	if openRC {
+		return fmt.Sprintf("sh -c 'wg-quick down %s 2>/dev/null; wg-quick up %s'", domain.MeshInterface, domain.MeshInterface)
+	}
+	unit := "wg-quick@" + domain.MeshInterface
+	return fmt.Sprintf("sh -c 'systemctl enable %s && systemctl restart %s'", unit, unit)
+}
+
+func meshDownCommand(openRC bool) string {
+	cleanup := fmt.Sprintf("rm -f %s %s %s", meshConfigPath, meshKeyPath, meshOpenRCStartPath)
+	if openRC {
+		return fmt.Sprintf("sh -c 'wg-quick down %s 2>/dev/null; %s'", domain.MeshInterface, cleanup)
+	}
+	return fmt.Sprintf("sh -c 'systemctl disable --now wg-quick@%s 2>/dev/null; %s'", domain.MeshInterface, cleanup)
+}
+
+// ConfigureMesh installs WireGuard, writes the mesh interface with the given
+// peers and restarts it. The key pair is generated on the server on first
+// use; only the public key is returned.
+func (p *SSHProvisioner) ConfigureMesh(server *domain.Server, sshKey, sshPassword string, peers []domain.MeshPeer) (string, error) {
+	client, err := p.connectServer(server, sshKey, sshPassword)
+	if err != nil {
+		return "", err
+	}
+	defer client.Close()
+
+	uid, err := runCommandOutput(client, "id -u")
+	if err != nil {
+		return "", fmt.Errorf("get uid: %w", err)
+	}
+
+	if !commandSucceeds(client, "command -v wg") {
+		if err := runPrivilegedCommand(client, uid, sshPassword, detectPlatform(client).installPackagesCommand([]string{"wireguard-tools"})); err != nil {
+			return "", fmt.Errorf("install wireguard-tools: %w", err)
+		}
+	}
+	if err := runPrivilegedCommand(client, uid, sshPassword, meshKeyCommand()); err != nil {
+		return "", fmt.Errorf("generate mesh key: %w", err)
+	}
+	publicKey, err := runPrivilegedCommandOutput(client, uid, sshPassword, fmt.Sprintf("sh -c 'wg pubkey < %s'", meshKeyPath))
+	if err != nil {
+		return "", fmt.Errorf("read mesh public key: %w", err)
+	}
+
+	if err := writeRemoteFileViaSSH(client, uid, sshPassword, meshConfigPath, []byte(buildMeshConfig(server.MeshIP, peers))); err != nil {
+		return "", fmt.Errorf("write mesh config: %w", err)
+	}
+	if err := runPrivilegedCommand(client, uid, sshPassword, "chmod 600 "+meshConfigPath); err != nil {
+		return "", fmt.Errorf("chmod mesh config: %w", err)
+	}
+
+	openRC := usesOpenRC(client)
+	if openRC {
+		start := fmt.Sprintf("#!/bin/sh\nwg-quick up %s\n", domain.MeshInterface)
+		if err := writeRemoteFileViaSSH(client, uid, sshPassword, meshOpenRCStartPath, []byte(start)); err != nil {
+			return "", fmt.Errorf("write mesh start script: %w", err)
+		}
+		if err := runPrivilegedCommand(client, uid, sshPassword, "sh -c 'chmod +x "+meshOpenRCStartPath+" && rc-update add local default'"); err != nil {
+			return "", fmt.Errorf("enable mesh start script: %w", err)
+		}
+	}
+	if err := runPrivilegedCommand(client, uid, sshPassword, meshUpCommand(openRC)); err != nil {
+		return "", fmt.Errorf("start mesh interface: %w", err)
+	}
+
+	if err := p.refreshFirewall(client, server, uid, sshPassword); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(publicKey), nil
+}
+
+// RemoveMesh takes the mesh interface down and deletes its config and key.
+func (p *SSHProvisioner) RemoveMesh(server *domain.Server, sshKey, sshPassword string) error {
+	client, err := p.connectServer(server, sshKey, sshPassword)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	uid, err := runCommandOutput(client, "id -u")
+	if err != nil {
+		return fmt.Errorf("get uid: %w", err)
+	}
+	if err := runPrivilegedCommand(client, uid, sshPassword, meshDownCommand(usesOpenRC(client))); err != nil {
+		return fmt.Errorf("stop mesh interface: %w", err)
+	}
+	return p.refreshFirewall(client, server, uid, sshPassword)
+}
+
+// refreshFirewall re-applies the default rules on servers with a managed
+// firewall so the WireGuard port follows the server's mesh membership.
+func (p *SSHProvisioner) refreshFirewall(client *ssh.Client, server *domain.Server, uid, password string) error {
+	if !server.FirewallEnabled {
+		return nil
+	}
+	backendIP, err := detectBackendIP(client)
+	if err != nil {
+		return err
+	}
+	fw, err := applyFirewall(client, uid, password, p.firewallRules(server, backendIP), func(string) {})
+	if err != nil {
+		return err
+	}
+	fw.ServerID = server.ID
+	p.recordFirewall(fw)
+	return nil
+}
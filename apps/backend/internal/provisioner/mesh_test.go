@@ -0,0 +1,30 @@
+package provisioner
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/paasdeploy/backend/internal/domain"
+)
+
+func TestBuildMeshConfig(t *testing.T) {
+	config := buildMeshConfig("10.210.0.1", []domain.MeshPeer{
+		{Name: "db", PublicKey: "keyB", Endpoint: "203.0.113.2:51820", MeshIP: "10.210.0.2"},
+	})
+	assertContains(t, config, "Address = 10.210.0.1/16\n")
+	assertContains(t, config, "ListenPort = 51820\n")
+	assertContains(t, config, "PostUp = wg set %i private-key /etc/wireguard/wg-paasdeploy.key\n")
+	assertContains(t, config, "PublicKey = keyB\nEndpoint = 203.0.113.2:51820\nAllowedIPs = 10.210.0.2/32\n")
+	if strings.Contains(config, "PrivateKey") {
+		t.Error("private key must not be rendered into the config")
+	}
+
+	if alone := buildMeshConfig("10.210.0.1", nil); strings.Contains(alone, "[Peer]") {
+		t.Errorf("expected no peers, got %q", alone)
+	}
+}
+
+func TestMeshUpCommand(t *testing.T) {
+	assertContains(t, meshUpCommand(false), "systemctl restart wg-quick@wg-paasdeploy")
+	assertContains(t, meshUpCommand(true), "wg-quick up wg-paasdeploy")
+}
@@ -0,0 +1,110 @@
+package provisioner
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+)
+
+type packageManager string
+
+const (
+	packageManagerApt packageManager = "apt-get"
+	packageManagerDnf packageManager = "dnf"
+	packageManagerYum packageManager = "yum"
+	packageManagerApk packageManager = "apk"
+
+	dockerCERepoURL = "https://download.docker.com/linux/centos/docker-ce.repo"
+)
+
+// hostPlatform describes what the provisioner needs to know about the
+// remote distribution to install packages and manage services.
+type hostPlatform struct {
+	pkg  packageManager
+	osID string
+}
+
+const detectPlatformCmd = `sh -c '. /etc/os-release 2>/dev/null; ` +
+	`for pm in apt-get dnf yum apk; do if command -v $pm >/dev/null 2>&1; then echo "$pm $ID"; exit 0; fi; done'`
+
+// detectPlatform falls back to apt-get, which was the only supported package
+// manager before other distributions were handled.
+func detectPlatform(client *ssh.Client) hostPlatform {
+	platform := hostPlatform{pkg: packageManagerApt}
+	out, err := runCommandOutput(client, detectPlatformCmd)
+	if err != nil {
+		return platform
+	}
+	fields := strings.Fields(out)
+	if len(fields) == 0 {
+		return platform
+	}
+	switch pm := packageManager(fields[0]); pm {
+	case packageManagerApt, packageManagerDnf, packageManagerYum, packageManagerApk:
+		platform.pkg = pm
+	}
+	if len(fields) > 1 {
+		platform.osID = fields[1]
+	}
+	return platform
+}
+
+// dockerInstallCommand returns the privileged command that installs Docker
+// Engine with the compose and buildx plugins. get.docker.com covers Debian,
+// Ubuntu and Fedora; RHEL clones use the CentOS repository and Alpine ships
+// Docker in its community repository.
+func (h hostPlatform) dockerInstallCommand() string {
+	switch {
+	case h.pkg == packageManagerApk:
+		return "apk add --no-cache docker docker-cli-compose docker-cli-buildx"
+	case (h.pkg == packageManagerDnf || h.pkg == packageManagerYum) && h.osID != "fedora":
+		configManager := "dnf -y install dnf-plugins-core && dnf config-manager --add-repo " + dockerCERepoURL
+		if h.pkg == packageManagerYum {
+			configManager = "yum -y install yum-utils && yum-config-manager --add-repo " + dockerCERepoURL
+		}
+		return fmt.Sprintf("sh -c '%s && %s -y install docker-ce docker-ce-cli containerd.io docker-buildx-plugin docker-compose-plugin'",
+			configManager, h.pkg)
+	default:
+		return "sh -c 'curl -fsSL https://get.docker.com | sh'"
+	}
+}
+
+func (h hostPlatform) composePackage() string {
+	if h.pkg == packageManagerApk {
+		return "docker-cli-compose"
+	}
+	return "docker-compose-plugin"
+}
+
+func (h hostPlatform) buildxPackage() string {
+	if h.pkg == packageManagerApk {
+		return "docker-cli-buildx"
+	}
+	return "docker-buildx-plugin"
+}
+
+func (h hostPlatform) installPackagesCommand(packages []string) string {
+	list := strings.Join(packages, " ")
+	switch h.pkg {
+	case packageManagerDnf, packageManagerYum:
+		return fmt.Sprintf("%s install -y -q %s", h.pkg, list)
+	case packageManagerApk:
+		return fmt.Sprintf("apk add --no-cache %s", list)
+	default:
+		return fmt.Sprintf("apt-get update -qq && apt-get install -y -qq %s", list)
+	}
+}
+
+// addToDockerGroupCommand uses addgroup on Alpine, where usermod is not part
+// of the base system.
+func (h hostPlatform) addToDockerGroupCommand(user string) string {
+	if h.pkg == packageManagerApk {
+		return fmt.Sprintf("addgroup %s docker", user)
+	}
+	return fmt.Sprintf("usermod -aG docker %s", user)
+}
+
+func usesOpenRC(client *ssh.Client) bool {
+	return !commandSucceeds(client, "command -v systemctl") && commandSucceeds(client, "command -v rc-service")
+}
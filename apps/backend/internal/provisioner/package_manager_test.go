@@ -0,0 +1,89 @@
+package provisioner
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDetectPlatform(t *testing.T) {
+	cases := []struct {
+		name   string
+		output string
+		want   hostPlatform
+	}{
+		{"Debian", "apt-get debian", hostPlatform{pkg: packageManagerApt, osID: "debian"}},
+		{"Alma", "dnf almalinux", hostPlatform{pkg: packageManagerDnf, osID: "almalinux"}},
+		{"Alpine", "apk alpine", hostPlatform{pkg: packageManagerApk, osID: "alpine"}},
+		{"UnknownFallsBackToApt", "", hostPlatform{pkg: packageManagerApt}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := newCommandMock()
+			mock.setResponse("/etc/os-release", tc.output)
+			defer mock.install(t)()
+
+			if got := detectPlatform(nil); got != tc.want {
+				t.Errorf("expected %+v, got %+v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestProvisionDockerOnOtherDistros(t *testing.T) {
+	t.Run("AlmaUsesDockerCERepo", func(t *testing.T) {
+		mock := setupDockerMock(t, false, true)
+		mock.setResponse("/etc/os-release", "dnf almalinux")
+		defer mock.install(t)()
+
+		p := newTestProvisioner()
+		requireNoError(t, p.provisionDocker(nil, uidRoot, "", noopStep, noopLog))
+
+		if !mock.hasCommandWith("dnf config-manager --add-repo", dockerCERepoURL, cmdComposePlugin) {
+			t.Error("expected docker-ce install from the CentOS repository")
+		}
+		if mock.hasCommand(cmdGetDockerCom) {
+			t.Error("should not use get.docker.com on RHEL clones")
+		}
+	})
+
+	t.Run("AlpineUsesApkAndOpenRC", func(t *testing.T) {
+		mock := newCommandMock()
+		mock.setError(cmdDockerVersion, errors.New(errNotFound))
+		mock.setResponse("/etc/os-release", "apk alpine")
+		mock.setResponse("whoami", "deploy")
+		mock.setError("command -v systemctl", errors.New(errNotFound))
+		mock.setError("rc-service docker status", errors.New("stopped"))
+		defer mock.install(t)()
+
+		p := newTestProvisioner()
+		requireNoError(t, p.provisionDocker(nil, uidNonRoot, "testpass", noopStep, noopLog))
+
+		if !mock.hasCommandWith("apk add", "docker-cli-compose") {
+			t.Error("expected apk docker install")
+		}
+		if !mock.hasCommand("addgroup deploy docker") {
+			t.Error("expected addgroup for docker group membership")
+		}
+		if !mock.hasCommand("rc-service docker start") {
+			t.Error("expected docker to be started through OpenRC")
+		}
+		if mock.hasCommand(cmdSystemctlStart) {
+			t.Error("should not call systemctl on OpenRC hosts")
+		}
+	})
+
+	t.Run("AlpinePluginsUseApkNames", func(t *testing.T) {
+		mock := newCommandMock()
+		mock.setResponse("/etc/os-release", "apk alpine")
+		mock.setError(cmdDockerComposeVersion, errors.New(errNotFound))
+		mock.setError(cmdDockerBuildxVersion, errors.New(errNotFound))
+		defer mock.install(t)()
+
+		p := newTestProvisioner()
+		requireNoError(t, p.ensureDockerPlugins(nil, uidRoot, "", noopStep, noopLog))
+
+		if !mock.hasCommandWith("apk add --no-cache", "docker-cli-compose", "docker-cli-buildx") {
+			t.Error("expected apk plugin install")
+		}
+	})
+}
@@ -8,6 +8,8 @@ import (
 	"testing"
 
 	"golang.org/x/crypto/ssh"
+
+	"github.com/paasdeploy/backend/internal/domain"
 )
 
 const (
@@ -186,7 +188,7 @@ func assertContains(t *testing.T, haystack, needle string) {
 
 func requireTraefikConfig(t *testing.T, email string) string {
 	t.Helper()
-	config, err := buildTraefikConfig(email)
+	config, err := buildTraefikConfig(email, false, nil)
 	requireNoError(t, err)
 	return string(config)
 }
@@ -379,22 +381,36 @@ func setupTraefikMock(t *testing.T, running bool, image string) *commandMock {
 func TestProvisionTraefik(t *testing.T) {
 	t.Run("AlreadyRunningCorrectVersion", func(t *testing.T) {
 		mock := setupTraefikMock(t, true, traefikImage)
+		mock.setResponse("cat \""+traefikConfigPath, requireTraefikConfig(t, testEmailDefault))
 		defer mock.install(t)()
 
 		p := newTestProvisioner()
-		requireNoError(t, p.provisionTraefik(nil, uidRoot, "", testEmailDefault, noopStep, noopLog))
+		requireNoError(t, p.provisionTraefik(nil, uidRoot, "", testEmailDefault, false, nil, noopStep, noopLog))
 
 		if mock.hasCommand(cmdDockerRun) {
 			t.Error("should not start traefik when already running with correct version")
 		}
 	})
 
+	t.Run("AlreadyRunningConfigDriftReapplies", func(t *testing.T) {
+		mock := setupTraefikMock(t, true, traefikImage)
+		mock.setResponse("cat \""+traefikConfigPath, "log:\n  level: DEBUG")
+		defer mock.install(t)()
+
+		p := newTestProvisioner()
+		requireNoError(t, p.provisionTraefik(nil, uidRoot, "", testEmailDefault, false, nil, noopStep, noopLog))
+
+		if !mock.hasCommand(cmdDockerRun) {
+			t.Error("should recreate traefik when its config drifted")
+		}
+	})
+
 	t.Run("AlreadyRunningOldVersionUpgrades", func(t *testing.T) {
 		mock := setupTraefikMock(t, true, "traefik:v2.10")
 		defer mock.install(t)()
 
 		p := newTestProvisioner()
-		requireNoError(t, p.provisionTraefik(nil, uidRoot, "", testEmailDefault, noopStep, noopLog))
+		requireNoError(t, p.provisionTraefik(nil, uidRoot, "", testEmailDefault, false, nil, noopStep, noopLog))
 
 		if !mock.hasCommand(cmdDockerRun) {
 			t.Error("should upgrade traefik when running old version")
@@ -409,7 +425,7 @@ func TestProvisionTraefik(t *testing.T) {
 		defer mock.install(t)()
 
 		p := newTestProvisioner()
-		requireNoError(t, p.provisionTraefik(nil, uidRoot, "", testEmailDefault, noopStep, noopLog))
+		requireNoError(t, p.provisionTraefik(nil, uidRoot, "", testEmailDefault, false, nil, noopStep, noopLog))
 
 		if !mock.hasCommand("mkdir -p") {
 			t.Error("expected mkdir for traefik dirs")
@@ -529,13 +545,42 @@ func TestBuildTraefikConfig(t *testing.T) {
 		assertContains(t, cfg, "tlsChallenge")
 	})
 
+	t.Run("ContainsFileProvider", func(t *testing.T) {
+		cfg := requireTraefikConfig(t, testEmailAdmin)
+		assertContains(t, cfg, "directory: /etc/traefik/dynamic")
+		assertContains(t, cfg, "watch: true")
+	})
+
+	t.Run("StagingUsesStagingCA", func(t *testing.T) {
+		config, err := buildTraefikConfig(testEmailAdmin, true, nil)
+		requireNoError(t, err)
+		assertContains(t, string(config), "caServer: https://acme-staging-v02.api.letsencrypt.org/directory")
+		assertContains(t, string(config), "storage: /letsencrypt/acme-staging.json")
+	})
+
+	t.Run("ExtraEntrypoints", func(t *testing.T) {
+		config, err := buildTraefikConfig(testEmailAdmin, false, []domain.ServerEntrypoint{
+			{Name: "mqtt", Port: 1883, Protocol: "tcp"},
+			{Name: "game", Port: 27015, Protocol: "udp"},
+		})
+		requireNoError(t, err)
+		assertContains(t, string(config), "  mqtt:\n    address: \":1883/tcp\"")
+		assertContains(t, string(config), "  game:\n    address: \":27015/udp\"")
+	})
+
+	t.Run("JSONAccessLog", func(t *testing.T) {
+		cfg := requireTraefikConfig(t, testEmailAdmin)
+		assertContains(t, cfg, "accessLog:\n  filePath: /var/log/traefik/access.log\n  format: json\n")
+		assertContains(t, cfg, "defaultMode: drop")
+	})
+
 	t.Run("DisableExposedByDefault", func(t *testing.T) {
 		cfg := requireTraefikConfig(t, testEmailAdmin)
 		assertContains(t, cfg, "exposedByDefault: false")
 	})
 
 	t.Run("RejectsInvalidEmail", func(t *testing.T) {
-		_, err := buildTraefikConfig("not-an-email")
+		_, err := buildTraefikConfig("not-an-email", false, nil)
 		if err == nil {
 			t.Error("expected error for invalid email")
 		}
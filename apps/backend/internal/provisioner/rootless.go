@@ -0,0 +1,135 @@
+package provisioner
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	defaultDockerSocket   = "/var/run/docker.sock"
+	rootlessDockerContext = "rootless"
+	rootlessSysctlPath    = "/etc/sysctl.d/99-paasdeploy-rootless.conf"
+	rootlessSubIDRange    = "100000:65536"
+)
+
+var errRootlessRequiresUser = errors.New("rootless Docker requires a non-root SSH user")
+
+// dockerSocketPath returns the host path of the socket the SSH user's docker
+// CLI talks to, following the active context so rootless daemons are found.
+func dockerSocketPath(client *ssh.Client) string {
+	out, err := runCommandOutput(client, "docker context inspect --format '{{.Endpoints.docker.Host}}' 2>/dev/null")
+	if err != nil {
+		return defaultDockerSocket
+	}
+	host := strings.TrimSpace(out)
+	if !strings.HasPrefix(host, "unix://") {
+		return defaultDockerSocket
+	}
+	return strings.TrimPrefix(host, "unix://")
+}
+
+func (h hostPlatform) rootlessPackages() []string {
+	switch h.pkg {
+	case packageManagerDnf, packageManagerYum:
+		return []string{"docker-ce-rootless-extras", "shadow-utils", "fuse-overlayfs"}
+	default:
+		return []string{"docker-ce-rootless-extras", "uidmap", "dbus-user-session"}
+	}
+}
+
+func subIDCommand(user, file string) string {
+	return fmt.Sprintf(`sh -c 'grep -q "^%s:" %s || echo "%s:%s" >> %s'`, user, file, user, rootlessSubIDRange, file)
+}
+
+// provisionRootlessDocker installs Docker and runs the daemon as the SSH user
+// through dockerd-rootless-setuptool.sh. The system daemon is disabled and a
+// "rootless" docker context becomes the default, so the agent, compose and
+// later provisioning steps all target the user's socket.
+func (p *SSHProvisioner) provisionRootlessDocker(
+	client *ssh.Client,
+	uid string,
+	password string,
+	step func(string, string, string),
+	logLine func(string),
+) error {
+	if uid == "0" {
+		return errRootlessRequiresUser
+	}
+	platform := detectPlatform(client)
+	if platform.pkg == packageManagerApk {
+		return errors.New("rootless Docker is not supported on Alpine hosts")
+	}
+
+	step("docker_check", "running", "Verificando Docker...")
+	if !commandSucceeds(client, "docker --version") {
+		step("docker_install", "running", "Instalando Docker...")
+		logLine(fmt.Sprintf("Docker nao encontrado, instalando via %s", platform.pkg))
+		if err := runPrivilegedCommandWithTimeout(client, uid, password, platform.dockerInstallCommand(), timeoutDockerInstall); err != nil {
+			return fmt.Errorf("install docker: %w", err)
+		}
+		step("docker_install", "ok", "Docker instalado")
+	}
+	step("docker_check", "ok", "Docker encontrado")
+
+	step("docker_rootless", "running", "Configurando Docker rootless...")
+	user, err := runCommandOutput(client, "whoami")
+	if err != nil {
+		return fmt.Errorf("get remote user: %w", err)
+	}
+	user = strings.TrimSpace(user)
+	runtimeDir := path.Join("/run/user", uid)
+
+	if !commandSucceeds(client, "command -v dockerd-rootless-setuptool.sh") {
+		logLine("Instalando docker-ce-rootless-extras")
+		installCmd := platform.installPackagesCommand(platform.rootlessPackages())
+		if err := runPrivilegedCommandWithTimeout(client, uid, password, installCmd, timeoutDockerInstall); err != nil {
+			return fmt.Errorf("install rootless extras: %w", err)
+		}
+	}
+
+	for _, file := range []string{"/etc/subuid", "/etc/subgid"} {
+		if err := runPrivilegedCommand(client, uid, password, subIDCommand(user, file)); err != nil {
+			return fmt.Errorf("configure %s: %w", file, err)
+		}
+	}
+
+	logLine("Permitindo portas 80/443 para processos sem privilegios")
+	if err := writeRemoteFileViaSSH(client, uid, password, rootlessSysctlPath, []byte("net.ipv4.ip_unprivileged_port_start=80\n")); err != nil {
+		return fmt.Errorf("write sysctl config: %w", err)
+	}
+	if err := runPrivilegedCommand(client, uid, password, "sysctl -p "+rootlessSysctlPath); err != nil {
+		return fmt.Errorf("apply sysctl config: %w", err)
+	}
+	if err := runPrivilegedCommand(client, uid, password, "loginctl enable-linger "+user); err != nil {
+		return fmt.Errorf("enable linger: %w", err)
+	}
+
+	logLine("Desativando Docker daemon do sistema")
+	_ = runPrivilegedCommand(client, uid, password, "systemctl disable --now docker.service docker.socket")
+
+	logLine("Executando dockerd-rootless-setuptool.sh")
+	setupCmd := fmt.Sprintf("XDG_RUNTIME_DIR=%s dockerd-rootless-setuptool.sh install", runtimeDir)
+	if err := runCommandWithTimeout(client, setupCmd, timeoutDockerInstall); err != nil {
+		return fmt.Errorf("rootless setup: %w", err)
+	}
+	if err := runCommand(client, fmt.Sprintf("XDG_RUNTIME_DIR=%s systemctl --user enable --now docker", runtimeDir)); err != nil {
+		return fmt.Errorf("enable rootless docker: %w", err)
+	}
+
+	socket := path.Join(runtimeDir, "docker.sock")
+	contextCmd := fmt.Sprintf("(docker context inspect %s >/dev/null 2>&1 || docker context create %s --docker host=unix://%s) && docker context use %s",
+		rootlessDockerContext, rootlessDockerContext, socket, rootlessDockerContext)
+	if err := runCommand(client, contextCmd); err != nil {
+		return fmt.Errorf("select rootless docker context: %w", err)
+	}
+
+	if err := p.ensureDockerPlugins(client, uid, password, step, logLine); err != nil {
+		return err
+	}
+	step("docker_rootless", "ok", "Docker rootless ativo")
+	return nil
+}
@@ -0,0 +1,64 @@
+package provisioner
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestProvisionRootlessDocker(t *testing.T) {
+	t.Run("RejectsRoot", func(t *testing.T) {
+		mock := newCommandMock()
+		defer mock.install(t)()
+
+		p := newTestProvisioner()
+		if err := p.provisionRootlessDocker(nil, uidRoot, "", noopStep, noopLog); !errors.Is(err, errRootlessRequiresUser) {
+			t.Fatalf("expected errRootlessRequiresUser, got %v", err)
+		}
+	})
+
+	t.Run("SetsUpRootlessDaemon", func(t *testing.T) {
+		mock := newCommandMock()
+		mock.setResponse("whoami", "deploy")
+		mock.setResponse(cmdDockerVersion, mockDockerVersionOut)
+		mock.setError("command -v dockerd-rootless-setuptool.sh", errors.New(errNotFound))
+		defer mock.install(t)()
+
+		p := newTestProvisioner()
+		requireNoError(t, p.provisionRootlessDocker(nil, uidNonRoot, "testpass", noopStep, noopLog))
+
+		if !mock.hasCommand("docker-ce-rootless-extras") {
+			t.Error("expected rootless extras install")
+		}
+		if !mock.hasCommandWith("deploy:"+rootlessSubIDRange, "/etc/subuid") {
+			t.Error("expected subuid range for the SSH user")
+		}
+		if !mock.hasCommand("loginctl enable-linger deploy") {
+			t.Error("expected linger to be enabled")
+		}
+		if !mock.hasCommand("systemctl disable --now docker.service") {
+			t.Error("expected the system daemon to be disabled")
+		}
+		if !mock.hasCommand("XDG_RUNTIME_DIR=/run/user/1000 dockerd-rootless-setuptool.sh install") {
+			t.Error("expected dockerd-rootless-setuptool.sh install")
+		}
+		if !mock.hasCommandWith("docker context use rootless", "unix:///run/user/1000/docker.sock") {
+			t.Error("expected rootless docker context to be selected")
+		}
+		if mock.hasCommand(cmdSystemctlStart) {
+			t.Error("should not start the system docker daemon")
+		}
+	})
+}
+
+func TestDockerSocketPath(t *testing.T) {
+	mock := newCommandMock()
+	mock.setResponse("docker context inspect", "unix:///run/user/1000/docker.sock\n")
+	defer mock.install(t)()
+
+	if got := dockerSocketPath(nil); got != "/run/user/1000/docker.sock" {
+		t.Fatalf("expected rootless socket, got %q", got)
+	}
+
+	unit := buildSystemdUnit(agentLaunchOpts{installDir: testInstallDir, dockerSocket: "/run/user/1000/docker.sock"})
+	assertContains(t, unit, "Environment=DOCKER_HOST=unix:///run/user/1000/docker.sock")
+}
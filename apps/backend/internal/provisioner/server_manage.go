@@ -3,6 +3,7 @@ package provisioner
 import (
 	"fmt"
 	"net"
+	"path"
 	"strings"
 
 	"golang.org/x/crypto/ssh"
@@ -45,7 +46,7 @@ func (p *SSHProvisioner) ManageServer(
 	}
 	addr := net.JoinHostPort(server.Host, fmt.Sprintf("%d", port))
 
-	client, err := p.connect(server.SSHUser, addr, sshKey, sshPassword, server.SSHHostKey, server.ID)
+	client, err := p.connect(server.SSHUser, addr, sshKey, sshPassword, server.SSHHostKey, server.ID, server.Bastion)
 	if err != nil {
 		return nil, fmt.Errorf("ssh connect: %w", err)
 	}
@@ -55,14 +56,19 @@ func (p *SSHProvisioner) ManageServer(
 	if err != nil {
 		return nil, fmt.Errorf("get uid: %w", err)
 	}
+	homeDir, err := runCommandOutput(client, "printf $HOME")
+	if err != nil {
+		return nil, fmt.Errorf("get remote home: %w", err)
+	}
+	installDir := path.Join(homeDir, agentInstallDirName)
 
 	switch action {
 	case ManageActionRestartAgent:
-		return restartAgent(client, uid)
+		return restartAgent(client, uid, sshPassword, installDir)
 	case ManageActionRestartUserMgr:
 		return restartUserManager(client, uid, sshPassword)
 	case ManageActionAgentLogs:
-		return getAgentLogs(client, uid)
+		return getAgentLogs(client, uid, installDir)
 	case ManageActionFixDockerPerms:
 		return fixDockerPermissions(client, uid, sshPassword)
 	default:
@@ -70,15 +76,14 @@ func (p *SSHProvisioner) ManageServer(
 	}
 }
 
-func restartAgent(client *ssh.Client, uid string) (*ManageResult, error) {
+func restartAgent(client *ssh.Client, uid, password, installDir string) (*ManageResult, error) {
 	runtimeDir := fmt.Sprintf("/run/user/%s", uid)
-	restartCmd := fmt.Sprintf("XDG_RUNTIME_DIR=%s systemctl --user restart %s", runtimeDir, agentSystemdUnit)
-	if err := runCommand(client, restartCmd); err != nil {
+	method := installedMethod(client, installDir)
+	if err := restartInstalledAgent(client, method, installDir, runtimeDir, uid, password); err != nil {
 		return &ManageResult{Success: false, Output: fmt.Sprintf("restart failed: %s", err)}, nil
 	}
 
-	statusCmd := fmt.Sprintf("XDG_RUNTIME_DIR=%s systemctl --user is-active %s", runtimeDir, agentSystemdUnit)
-	status, err := runCommandOutput(client, statusCmd)
+	status, err := runCommandOutput(client, agentStatusCommand(method, installDir, runtimeDir))
 	if err != nil {
 		return &ManageResult{Success: false, Output: fmt.Sprintf("agent restarted but status check failed: %s", err)}, nil
 	}
@@ -101,9 +106,9 @@ func restartUserManager(client *ssh.Client, uid string, password string) (*Manag
 	}, nil
 }
 
-func getAgentLogs(client *ssh.Client, uid string) (*ManageResult, error) {
+func getAgentLogs(client *ssh.Client, uid, installDir string) (*ManageResult, error) {
 	runtimeDir := fmt.Sprintf("/run/user/%s", uid)
-	cmd := fmt.Sprintf("XDG_RUNTIME_DIR=%s journalctl --user -u %s -n 100 --no-pager 2>&1", runtimeDir, agentSystemdUnit)
+	cmd := agentLogsCommand(installedMethod(client, installDir), installDir, runtimeDir)
 	output, err := runCommandOutput(client, cmd)
 	if err != nil {
 		return &ManageResult{Success: false, Output: fmt.Sprintf("failed to fetch logs: %s", err)}, nil
@@ -0,0 +1,120 @@
+package provisioner
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	serverPrepSysctlPath = "/etc/sysctl.d/99-paasdeploy.conf"
+	swapFilePath         = "/swapfile"
+	swapSizeMB           = 2048
+	lowMemoryMaxMB       = 2560
+	swapMinFreeDiskMB    = swapSizeMB + 1024
+)
+
+// serverPrepSysctl keeps build processes from being swapped out eagerly and
+// raises the inotify limits that file watchers in dev tooling run into.
+var serverPrepSysctl = []string{
+	"vm.swappiness=10",
+	"vm.vfs_cache_pressure=50",
+	"fs.inotify.max_user_watches=524288",
+	"fs.inotify.max_user_instances=512",
+}
+
+func buildServerPrepSysctl() string {
+	return "# Managed by PaasDeploy\n" + strings.Join(serverPrepSysctl, "\n") + "\n"
+}
+
+// readMeminfoMB returns a /proc/meminfo field in megabytes.
+func readMeminfoMB(client *ssh.Client, field string) (int, error) {
+	out, err := runCommandOutput(client, fmt.Sprintf("awk '/^%s:/ {print $2}' /proc/meminfo", field))
+	if err != nil {
+		return 0, err
+	}
+	kb, err := strconv.Atoi(strings.TrimSpace(out))
+	if err != nil {
+		return 0, fmt.Errorf("parse %s: %w", field, err)
+	}
+	return kb / 1024, nil
+}
+
+func freeDiskMB(client *ssh.Client, dir string) (int, error) {
+	out, err := runCommandOutput(client, fmt.Sprintf("df -Pm %s | awk 'NR==2 {print $4}'", dir))
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(strings.TrimSpace(out))
+}
+
+// provisionServerPrep tunes the host before Docker is installed. Each part
+// is best effort: containers and some VPS virtualizations refuse swapon or
+// individual sysctl keys, which should not fail the whole provisioning.
+func (p *SSHProvisioner) provisionServerPrep(
+	client *ssh.Client,
+	uid string,
+	password string,
+	step func(string, string, string),
+	logLine func(string),
+) {
+	step("server_prep", "running", "Preparando servidor...")
+	ensureSwap(client, uid, password, logLine)
+
+	logLine("Aplicando ajustes de sysctl")
+	if err := writeRemoteFileViaSSH(client, uid, password, serverPrepSysctlPath, []byte(buildServerPrepSysctl())); err != nil {
+		logLine(fmt.Sprintf("Aviso: falha ao gravar %s: %v", serverPrepSysctlPath, err))
+	} else if err := runPrivilegedCommand(client, uid, password, "sysctl -p "+serverPrepSysctlPath); err != nil {
+		logLine(fmt.Sprintf("Aviso: falha ao aplicar sysctl: %v", err))
+	}
+	step("server_prep", "ok", "Servidor preparado")
+}
+
+// swapNeeded reports whether the host is low on memory, has no swap and has
+// room for the swap file.
+func swapNeeded(client *ssh.Client, logLine func(string)) (int, bool) {
+	memMB, err := readMeminfoMB(client, "MemTotal")
+	if err != nil {
+		logLine("Nao foi possivel ler a memoria do servidor, swap ignorado")
+		return 0, false
+	}
+	if memMB > lowMemoryMaxMB {
+		return memMB, false
+	}
+	if swapMB, err := readMeminfoMB(client, "SwapTotal"); err != nil || swapMB > 0 {
+		return memMB, false
+	}
+	if free, err := freeDiskMB(client, "/"); err != nil || free < swapMinFreeDiskMB {
+		logLine("Espaco em disco insuficiente para criar swap")
+		return memMB, false
+	}
+	return memMB, true
+}
+
+func swapCommands() []string {
+	return []string{
+		fmt.Sprintf("sh -c 'fallocate -l %dM %s || dd if=/dev/zero of=%s bs=1M count=%d'", swapSizeMB, swapFilePath, swapFilePath, swapSizeMB),
+		"chmod 600 " + swapFilePath,
+		"mkswap " + swapFilePath,
+		"swapon " + swapFilePath,
+		fmt.Sprintf(`sh -c 'grep -q "^%s " /etc/fstab || echo "%s none swap sw 0 0" >> /etc/fstab'`, swapFilePath, swapFilePath),
+	}
+}
+
+func ensureSwap(client *ssh.Client, uid, password string, logLine func(string)) {
+	memMB, needed := swapNeeded(client, logLine)
+	if !needed {
+		return
+	}
+
+	logLine(fmt.Sprintf("Memoria de %d MB, criando swap de %d MB em %s", memMB, swapSizeMB, swapFilePath))
+	for _, cmd := range swapCommands() {
+		if err := runPrivilegedCommandWithTimeout(client, uid, password, cmd, timeoutDockerInstall); err != nil {
+			logLine(fmt.Sprintf("Aviso: falha ao criar swap: %v", err))
+			_ = runPrivilegedCommand(client, uid, password, fmt.Sprintf("sh -c 'swapoff %s 2>/dev/null; rm -f %s'", swapFilePath, swapFilePath))
+			return
+		}
+	}
+}
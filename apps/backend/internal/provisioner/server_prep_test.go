@@ -0,0 +1,71 @@
+package provisioner
+
+import "testing"
+
+func TestProvisionServerPrep(t *testing.T) {
+	t.Run("CreatesSwapOnLowMemory", func(t *testing.T) {
+		mock := newCommandMock()
+		mock.setResponse("MemTotal", "1015808")
+		mock.setResponse("SwapTotal", "0")
+		mock.setResponse("df -Pm", "20480")
+		defer mock.install(t)()
+
+		p := newTestProvisioner()
+		p.provisionServerPrep(nil, uidRoot, "", noopStep, noopLog)
+
+		if !mock.hasCommand("mkswap " + swapFilePath) {
+			t.Error("expected swap file to be created")
+		}
+		if !mock.hasCommand("swapon " + swapFilePath) {
+			t.Error("expected swap to be enabled")
+		}
+		if !mock.hasCommand("sysctl -p " + serverPrepSysctlPath) {
+			t.Error("expected sysctl settings to be applied")
+		}
+	})
+
+	t.Run("SkipsWhenSwapExists", func(t *testing.T) {
+		mock := newCommandMock()
+		mock.setResponse("MemTotal", "1015808")
+		mock.setResponse("SwapTotal", "1048572")
+		mock.setResponse("df -Pm", "20480")
+		defer mock.install(t)()
+
+		p := newTestProvisioner()
+		p.provisionServerPrep(nil, uidRoot, "", noopStep, noopLog)
+
+		if mock.hasCommand("mkswap") {
+			t.Error("should not create swap when swap is already active")
+		}
+	})
+
+	t.Run("SkipsOnLargeMemory", func(t *testing.T) {
+		mock := newCommandMock()
+		mock.setResponse("MemTotal", "8127492")
+		mock.setResponse("SwapTotal", "0")
+		mock.setResponse("df -Pm", "20480")
+		defer mock.install(t)()
+
+		p := newTestProvisioner()
+		p.provisionServerPrep(nil, uidRoot, "", noopStep, noopLog)
+
+		if mock.hasCommand("mkswap") {
+			t.Error("should not create swap on servers with enough memory")
+		}
+	})
+
+	t.Run("SkipsOnLowDisk", func(t *testing.T) {
+		mock := newCommandMock()
+		mock.setResponse("MemTotal", "1015808")
+		mock.setResponse("SwapTotal", "0")
+		mock.setResponse("df -Pm", "1024")
+		defer mock.install(t)()
+
+		p := newTestProvisioner()
+		p.provisionServerPrep(nil, uidRoot, "", noopStep, noopLog)
+
+		if mock.hasCommand("fallocate") {
+			t.Error("should not create swap without enough free disk")
+		}
+	})
+}
@@ -0,0 +1,108 @@
+package provisioner
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/ssh"
+
+	"github.com/paasdeploy/backend/internal/domain"
+)
+
+const shellTerm = "xterm-256color"
+
+// ServerShell is an interactive login shell on a server, with a PTY so the
+// remote side merges stderr into Stdout.
+type ServerShell struct {
+	client  *ssh.Client
+	session *ssh.Session
+	stdin   io.WriteCloser
+	stdout  io.Reader
+}
+
+// OpenShell starts a login shell on the server over SSH with the stored
+// credentials, going through the bastion when the server has one.
+func (p *SSHProvisioner) OpenShell(server *domain.Server, sshKey, sshPassword string, cols, rows uint16) (*ServerShell, error) {
+	client, err := p.connectServer(server, sshKey, sshPassword)
+	if err != nil {
+		return nil, err
+	}
+
+	shell, err := startShell(client, cols, rows)
+	if err != nil {
+		client.Close()
+		return nil, err
+	}
+	return shell, nil
+}
+
+func startShell(client *ssh.Client, cols, rows uint16) (*ServerShell, error) {
+	session, err := client.NewSession()
+	if err != nil {
+		return nil, fmt.Errorf("new session: %w", err)
+	}
+
+	modes := ssh.TerminalModes{
+		ssh.ECHO:          1,
+		ssh.TTY_OP_ISPEED: 14400,
+		ssh.TTY_OP_OSPEED: 14400,
+	}
+	if err := session.RequestPty(shellTerm, int(rows), int(cols), modes); err != nil {
+		session.Close()
+		return nil, fmt.Errorf("request pty: %w", err)
+	}
+
+	stdin, err := session.StdinPipe()
+	if err != nil {
+		session.Close()
+		return nil, fmt.Errorf("stdin: %w", err)
+	}
+	stdout, err := session.StdoutPipe()
+	if err != nil {
+		session.Close()
+		return nil, fmt.Errorf("stdout: %w", err)
+	}
+
+	if err := session.Shell(); err != nil {
+		session.Close()
+		return nil, fmt.Errorf("start shell: %w", err)
+	}
+
+	return &ServerShell{client: client, session: session, stdin: stdin, stdout: stdout}, nil
+}
+
+// Stdin is the shell's terminal input. Closing it does not end a login
+// shell; Close does.
+func (s *ServerShell) Stdin() io.WriteCloser {
+	return s.stdin
+}
+
+// Stdout is the shell's terminal output. It returns EOF once the shell
+// exits or the shell is closed.
+func (s *ServerShell) Stdout() io.Reader {
+	return s.stdout
+}
+
+func (s *ServerShell) Resize(cols, rows uint16) error {
+	return s.session.WindowChange(int(rows), int(cols))
+}
+
+// Wait blocks until the shell exits and returns its exit code, or -1 when
+// the connection dropped before the shell reported one.
+func (s *ServerShell) Wait() int {
+	err := s.session.Wait()
+	if err == nil {
+		return 0
+	}
+	var exitErr *ssh.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitStatus()
+	}
+	return -1
+}
+
+func (s *ServerShell) Close() error {
+	s.session.Close()
+	return s.client.Close()
+}
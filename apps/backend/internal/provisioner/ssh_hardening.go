@@ -0,0 +1,187 @@
+package provisioner
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+
+	"github.com/paasdeploy/backend/internal/domain"
+)
+
+const (
+	sshdConfigPath       = "/etc/ssh/sshd_config"
+	sshdDropInDir        = "/etc/ssh/sshd_config.d"
+	sshdHardeningPath    = sshdDropInDir + "/99-paasdeploy.conf"
+	fail2banJailPath     = "/etc/fail2ban/jail.d/paasdeploy.local"
+	sshdMaxAuthTries     = 3
+	fail2banMaxRetry     = 5
+	fail2banFindTime     = "10m"
+	fail2banBanTime      = "1h"
+	sshdIncludeDirective = "Include " + sshdDropInDir + "/*.conf"
+)
+
+// buildSSHDHardeningConfig returns the sshd drop-in. Password and root
+// password logins are only turned off once key authentication has been
+// verified, so a password-only server is never locked out.
+func buildSSHDHardeningConfig(keyVerified bool) string {
+	var b strings.Builder
+	b.WriteString("# Managed by PaasDeploy\n")
+	fmt.Fprintf(&b, "MaxAuthTries %d\n", sshdMaxAuthTries)
+	b.WriteString("LoginGraceTime 30\n")
+	b.WriteString("PermitEmptyPasswords no\n")
+	b.WriteString("X11Forwarding no\n")
+	if keyVerified {
+		b.WriteString("PasswordAuthentication no\n")
+		b.WriteString("KbdInteractiveAuthentication no\n")
+		b.WriteString("PermitRootLogin prohibit-password\n")
+	}
+	return b.String()
+}
+
+// authorizedKeyCommand appends publicKey to the login user's authorized_keys
+// unless it is already there.
+func authorizedKeyCommand(publicKey string) string {
+	key := shellQuote(strings.TrimSpace(publicKey))
+	return "mkdir -p ~/.ssh && chmod 700 ~/.ssh && touch ~/.ssh/authorized_keys && chmod 600 ~/.ssh/authorized_keys && " +
+		"(grep -qxF " + key + " ~/.ssh/authorized_keys || echo " + key + " >> ~/.ssh/authorized_keys)"
+}
+
+// authorizeManagedKey installs the backend-generated public key so later
+// connections, and SSH hardening, can rely on key authentication.
+func (p *SSHProvisioner) authorizeManagedKey(client *ssh.Client, publicKey string, logLine func(string)) {
+	if err := runCommand(client, authorizedKeyCommand(publicKey)); err != nil {
+		logLine(fmt.Sprintf("Aviso: não foi possível instalar a chave SSH gerenciada: %v", err))
+		return
+	}
+	logLine("Chave SSH gerenciada presente em authorized_keys")
+}
+
+func buildFail2banJail(sshPort int, ignoreIP string, systemdBackend bool) string {
+	var b strings.Builder
+	b.WriteString("[sshd]\n")
+	b.WriteString("enabled = true\n")
+	fmt.Fprintf(&b, "port = %d\n", sshPort)
+	fmt.Fprintf(&b, "maxretry = %d\n", fail2banMaxRetry)
+	fmt.Fprintf(&b, "findtime = %s\n", fail2banFindTime)
+	fmt.Fprintf(&b, "bantime = %s\n", fail2banBanTime)
+	if systemdBackend {
+		b.WriteString("backend = systemd\n")
+	}
+	ignore := "127.0.0.1/8 ::1"
+	if ignoreIP != "" {
+		ignore += " " + ignoreIP
+	}
+	fmt.Fprintf(&b, "ignoreip = %s\n", ignore)
+	return b.String()
+}
+
+func (h hostPlatform) fail2banInstallCommand() string {
+	if (h.pkg == packageManagerDnf || h.pkg == packageManagerYum) && h.osID != "fedora" {
+		return fmt.Sprintf("sh -c '%s install -y -q epel-release && %s install -y -q fail2ban'", h.pkg, h.pkg)
+	}
+	return h.installPackagesCommand([]string{"fail2ban"})
+}
+
+// verifyKeyAuth opens a second connection using only the private key, so
+// password authentication is disabled only when the key is known to work.
+func (p *SSHProvisioner) verifyKeyAuth(server *domain.Server, addr, sshKey string) bool {
+	if sshKey == "" {
+		return false
+	}
+	client, err := p.connect(server.SSHUser, addr, sshKey, "", server.SSHHostKey, server.ID, server.Bastion)
+	if err != nil {
+		return false
+	}
+	client.Close()
+	return true
+}
+
+func (p *SSHProvisioner) provisionSSHHardening(
+	client *ssh.Client,
+	server *domain.Server,
+	uid string,
+	password string,
+	keyVerified bool,
+	step func(string, string, string),
+	logLine func(string),
+) error {
+	step("ssh_hardening", "running", "Endurecendo configuracao SSH...")
+	if !keyVerified {
+		logLine("Autenticacao por chave nao verificada, login por senha sera mantido")
+	}
+	if err := applySSHDHardening(client, uid, password, keyVerified, logLine); err != nil {
+		return err
+	}
+	step("ssh_hardening", "ok", "SSH endurecido")
+
+	step("fail2ban", "running", "Configurando fail2ban...")
+	if err := p.installFail2ban(client, server, uid, password, logLine); err != nil {
+		return err
+	}
+	step("fail2ban", "ok", "fail2ban ativo")
+	return nil
+}
+
+func applySSHDHardening(client *ssh.Client, uid, password string, keyVerified bool, logLine func(string)) error {
+	if err := runPrivilegedCommand(client, uid, password, "mkdir -p "+sshdDropInDir); err != nil {
+		return fmt.Errorf("create sshd drop-in dir: %w", err)
+	}
+	includeCmd := fmt.Sprintf(`sh -c 'grep -q "^Include %s" %s || sed -i "1i %s" %s'`,
+		sshdDropInDir, sshdConfigPath, sshdIncludeDirective, sshdConfigPath)
+	if err := runPrivilegedCommand(client, uid, password, includeCmd); err != nil {
+		return fmt.Errorf("enable sshd drop-ins: %w", err)
+	}
+
+	logLine(fmt.Sprintf("Gravando %s", sshdHardeningPath))
+	if err := writeRemoteFileViaSSH(client, uid, password, sshdHardeningPath, []byte(buildSSHDHardeningConfig(keyVerified))); err != nil {
+		return fmt.Errorf("write sshd config: %w", err)
+	}
+	if err := runPrivilegedCommand(client, uid, password, "sshd -t"); err != nil {
+		_ = runPrivilegedCommand(client, uid, password, "rm -f "+sshdHardeningPath)
+		return fmt.Errorf("sshd config validation failed, changes reverted: %w", err)
+	}
+
+	logLine("Recarregando sshd")
+	reloadCmd := "sh -c 'systemctl reload sshd 2>/dev/null || systemctl reload ssh'"
+	if usesOpenRC(client) {
+		reloadCmd = "rc-service sshd reload"
+	}
+	if err := runPrivilegedCommand(client, uid, password, reloadCmd); err != nil {
+		return fmt.Errorf("reload sshd: %w", err)
+	}
+	return nil
+}
+
+func (p *SSHProvisioner) installFail2ban(client *ssh.Client, server *domain.Server, uid, password string, logLine func(string)) error {
+	if !commandSucceeds(client, "command -v fail2ban-client") {
+		platform := detectPlatform(client)
+		logLine(fmt.Sprintf("Instalando fail2ban via %s", platform.pkg))
+		if err := runPrivilegedCommandWithTimeout(client, uid, password, platform.fail2banInstallCommand(), timeoutDockerInstall); err != nil {
+			return fmt.Errorf("install fail2ban: %w", err)
+		}
+	}
+
+	backendIP, err := detectBackendIP(client)
+	if err != nil {
+		logLine("Nao foi possivel detectar o IP do backend para a ignoreip do fail2ban")
+	}
+	sshPort := server.SSHPort
+	if sshPort == 0 {
+		sshPort = defaultSSHPort
+	}
+	openRC := usesOpenRC(client)
+	jail := buildFail2banJail(sshPort, backendIP, !openRC)
+	if err := writeRemoteFileViaSSH(client, uid, password, fail2banJailPath, []byte(jail)); err != nil {
+		return fmt.Errorf("write fail2ban jail: %w", err)
+	}
+
+	startCmd := "systemctl enable fail2ban && systemctl restart fail2ban"
+	if openRC {
+		startCmd = "rc-update add fail2ban default && rc-service fail2ban restart"
+	}
+	if err := runPrivilegedCommand(client, uid, password, fmt.Sprintf("sh -c '%s'", startCmd)); err != nil {
+		return fmt.Errorf("start fail2ban: %w", err)
+	}
+	return nil
+}
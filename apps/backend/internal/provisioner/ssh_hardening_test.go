@@ -0,0 +1,85 @@
+package provisioner
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/paasdeploy/backend/internal/domain"
+)
+
+func TestBuildSSHDHardeningConfig(t *testing.T) {
+	t.Run("KeyVerified", func(t *testing.T) {
+		cfg := buildSSHDHardeningConfig(true)
+		assertContains(t, cfg, "MaxAuthTries 3")
+		assertContains(t, cfg, "PasswordAuthentication no")
+		assertContains(t, cfg, "PermitRootLogin prohibit-password")
+	})
+
+	t.Run("PasswordOnly", func(t *testing.T) {
+		cfg := buildSSHDHardeningConfig(false)
+		assertContains(t, cfg, "MaxAuthTries 3")
+		if strings.Contains(cfg, "PasswordAuthentication") {
+			t.Error("password auth must stay enabled without a verified key")
+		}
+	})
+}
+
+func TestBuildFail2banJail(t *testing.T) {
+	jail := buildFail2banJail(2222, "203.0.113.10", true)
+	assertContains(t, jail, "port = 2222")
+	assertContains(t, jail, "backend = systemd")
+	assertContains(t, jail, "ignoreip = 127.0.0.1/8 ::1 203.0.113.10")
+}
+
+func TestAuthorizedKeyCommand(t *testing.T) {
+	cmd := authorizedKeyCommand("ssh-ed25519 AAAAC3Nza paasdeploy@web-1\n")
+	assertContains(t, cmd, "grep -qxF 'ssh-ed25519 AAAAC3Nza paasdeploy@web-1' ~/.ssh/authorized_keys")
+	assertContains(t, cmd, "echo 'ssh-ed25519 AAAAC3Nza paasdeploy@web-1' >> ~/.ssh/authorized_keys")
+	assertContains(t, cmd, "chmod 600 ~/.ssh/authorized_keys")
+}
+
+func TestProvisionSSHHardening(t *testing.T) {
+	t.Run("InstallsFail2banAndReloadsSSHD", func(t *testing.T) {
+		mock := newCommandMock()
+		mock.setResponse("SSH_CLIENT", "203.0.113.10 51234 22")
+		mock.setResponse(detectPlatformCmd, "apt-get ubuntu")
+		mock.setError("command -v fail2ban-client", errors.New(errNotFound))
+		mock.setError("command -v rc-service", errors.New(errNotFound))
+		defer mock.install(t)()
+
+		p := newTestProvisioner()
+		requireNoError(t, p.provisionSSHHardening(nil, &domain.Server{ID: "srv-1"}, uidRoot, "", true, noopStep, noopLog))
+
+		if !mock.hasCommand("sshd -t") {
+			t.Error("expected sshd config to be validated")
+		}
+		if !mock.hasCommand("systemctl reload sshd") {
+			t.Error("expected sshd reload")
+		}
+		if !mock.hasCommand("apt-get install -y -qq fail2ban") {
+			t.Error("expected fail2ban install")
+		}
+		if !mock.hasCommand("systemctl restart fail2ban") {
+			t.Error("expected fail2ban restart")
+		}
+	})
+
+	t.Run("RevertsInvalidConfig", func(t *testing.T) {
+		mock := newCommandMock()
+		mock.setError("sshd -t", errors.New("bad config"))
+		defer mock.install(t)()
+
+		p := newTestProvisioner()
+		err := p.provisionSSHHardening(nil, &domain.Server{ID: "srv-1"}, uidRoot, "", true, noopStep, noopLog)
+		if err == nil {
+			t.Fatal("expected validation error")
+		}
+		if !mock.hasCommand("rm -f " + sshdHardeningPath) {
+			t.Error("expected drop-in to be removed")
+		}
+		if mock.hasCommand("fail2ban") {
+			t.Error("should not continue to fail2ban after sshd failure")
+		}
+	})
+}
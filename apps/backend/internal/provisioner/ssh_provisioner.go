@@ -2,6 +2,9 @@ package provisioner
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"log/slog"
@@ -14,6 +17,7 @@ import (
 	"github.com/pkg/sftp"
 	"golang.org/x/crypto/ssh"
 
+	"github.com/paasdeploy/backend/internal/agentdownload"
 	"github.com/paasdeploy/backend/internal/domain"
 	"github.com/paasdeploy/backend/internal/pki"
 )
@@ -37,6 +41,10 @@ type SSHHostKeyStore interface {
 	UpdateSSHHostKey(serverID string, hostKey string) error
 }
 
+type CertRevocationChecker interface {
+	IsCertRevoked(cert *x509.Certificate) bool
+}
+
 type SSHProvisionerConfig struct {
 	CA              *pki.CertificateAuthority
 	ServerAddr      string
@@ -44,6 +52,8 @@ type SSHProvisionerConfig struct {
 	AgentPort       int
 	Logger          *slog.Logger
 	HostKeyStore    SSHHostKeyStore
+	FirewallStore   FirewallStore
+	CertRevocations CertRevocationChecker
 }
 
 type ProvisionProgress struct {
@@ -70,8 +80,11 @@ func (p *SSHProvisioner) Provision(server *domain.Server, sshKeyPlain string, ss
 	}
 	addr := net.JoinHostPort(server.Host, fmt.Sprintf("%d", port))
 	logLine(fmt.Sprintf("Conectando a %s como %s", addr, server.SSHUser))
+	if server.Bastion != nil {
+		logLine(fmt.Sprintf("Usando bastion %s", server.Bastion.Host))
+	}
 
-	client, err := p.provisionSSH(server.SSHUser, addr, sshKeyPlain, sshPasswordPlain, server.SSHHostKey, server.ID, step)
+	client, err := p.provisionSSH(server.SSHUser, addr, sshKeyPlain, sshPasswordPlain, server.SSHHostKey, server.ID, server.Bastion, step)
 	if err != nil {
 		return err
 	}
@@ -81,20 +94,41 @@ func (p *SSHProvisioner) Provision(server *domain.Server, sshKeyPlain string, ss
 	if err != nil {
 		return err
 	}
+	if server.SSHPublicKey != "" {
+		p.authorizeManagedKey(client, server.SSHPublicKey, logLine)
+	}
+	p.provisionServerPrep(client, uid, sshPasswordPlain, step, logLine)
 
-	if err := p.provisionDocker(client, uid, sshPasswordPlain, step, logLine); err != nil {
+	if server.DockerRootless {
+		err = p.provisionRootlessDocker(client, uid, sshPasswordPlain, step, logLine)
+	} else {
+		err = p.provisionDocker(client, uid, sshPasswordPlain, step, logLine)
+	}
+	if err != nil {
 		return err
 	}
+	dockerSocket := dockerSocketPath(client)
 	if err := p.provisionDockerNetwork(client, step, logLine); err != nil {
 		return err
 	}
+	if server.FirewallEnabled {
+		if err := p.provisionFirewall(client, server, uid, sshPasswordPlain, step, logLine); err != nil {
+			return err
+		}
+	}
+	if server.SSHHardening {
+		keyVerified := p.verifyKeyAuth(server, addr, sshKeyPlain)
+		if err := p.provisionSSHHardening(client, server, uid, sshPasswordPlain, keyVerified, step, logLine); err != nil {
+			return err
+		}
+	}
 
 	acmeEmail := ""
 	if server.AcmeEmail != nil {
 		acmeEmail = *server.AcmeEmail
 	}
 	if acmeEmail != "" {
-		if err := p.provisionTraefik(client, uid, sshPasswordPlain, acmeEmail, step, logLine); err != nil {
+		if err := p.provisionTraefik(client, uid, sshPasswordPlain, acmeEmail, server.AcmeStaging, server.Entrypoints, step, logLine); err != nil {
 			return err
 		}
 	}
@@ -110,14 +144,17 @@ func (p *SSHProvisioner) Provision(server *domain.Server, sshKeyPlain string, ss
 		return err
 	}
 
-	if err := p.provisionCerts(sftpClient, installDir, server, step); err != nil {
+	certsChanged, err := p.provisionCerts(client, sftpClient, installDir, server, step, logLine)
+	if err != nil {
 		return err
 	}
-	if err := p.deployAgentBinary(client, sftpClient, installDir, log, step, logLine); err != nil {
+	binaryChanged, err := p.deployAgentBinary(client, sftpClient, installDir, log, step, logLine)
+	if err != nil {
 		return err
 	}
-	paths := provisionPaths{installDir: installDir, unitDir: unitDir, runtimeDir: runtimeDir, serverID: server.ID}
-	if err := p.provisionSystemdAndStart(client, sftpClient, paths, step, logLine); err != nil {
+	paths := provisionPaths{homeDir: homeDir, installDir: installDir, unitDir: unitDir, runtimeDir: runtimeDir, serverID: server.ID, dockerSocket: dockerSocket}
+	agentChanged := certsChanged || binaryChanged
+	if err := p.provisionAgentService(client, sftpClient, paths, server.AgentInstallMethod, uid, sshPasswordPlain, agentChanged, step, logLine); err != nil {
 		return err
 	}
 	logLine("Provisionamento concluído")
@@ -141,9 +178,9 @@ func (p *SSHProvisioner) makeLogFn(progress *ProvisionProgress) func(string) {
 	}
 }
 
-func (p *SSHProvisioner) provisionSSH(user, addr, key, password, knownHostKey, serverID string, step func(string, string, string)) (*ssh.Client, error) {
+func (p *SSHProvisioner) provisionSSH(user, addr, key, password, knownHostKey, serverID string, bastion *domain.SSHBastion, step func(string, string, string)) (*ssh.Client, error) {
 	step("ssh_connect", "running", "Conectando via SSH...")
-	client, err := p.connect(user, addr, key, password, knownHostKey, serverID)
+	client, err := p.connect(user, addr, key, password, knownHostKey, serverID, bastion)
 	if err != nil {
 		return nil, fmt.Errorf("ssh connect: %w", err)
 	}
@@ -199,22 +236,31 @@ func (p *SSHProvisioner) provisionInstallDir(
 	return installDir, unitDir, runtimeDir, nil
 }
 
+// provisionCerts keeps certificates that are still valid for the server so
+// re-provisioning does not rotate the agent identity needlessly.
 func (p *SSHProvisioner) provisionCerts(
+	client *ssh.Client,
 	sftpClient *sftp.Client,
 	installDir string,
 	server *domain.Server,
 	step func(string, string, string),
-) error {
+	logLine func(string),
+) (bool, error) {
 	step("agent_certs", "running", "Instalando certificados...")
+	if p.certsDrift(client, installDir, server) == "" {
+		logLine("Certificados do agent em dia")
+		step("agent_certs", "ok", "Certificados em dia")
+		return false, nil
+	}
 	agentCert, err := p.cfg.CA.GenerateAgentCert(server.ID, server.Host)
 	if err != nil {
-		return fmt.Errorf("generate agent cert: %w", err)
+		return false, fmt.Errorf("generate agent cert: %w", err)
 	}
 	if err := writeCertFiles(sftpClient, installDir, agentCert, p.cfg.CA); err != nil {
-		return err
+		return false, err
 	}
 	step("agent_certs", "ok", "Certificados instalados")
-	return nil
+	return true, nil
 }
 
 func (p *SSHProvisioner) provisionSystemdAndStart(
@@ -228,12 +274,9 @@ func (p *SSHProvisioner) provisionSystemdAndStart(
 	unitOpts := systemdUnitOpts{
 		sshClient:  client,
 		sftpClient: sftpClient,
-		installDir: paths.installDir,
 		unitDir:    paths.unitDir,
 		runtimeDir: paths.runtimeDir,
-		serverID:   paths.serverID,
-		serverAddr: p.cfg.ServerAddr,
-		agentPort:  p.cfg.AgentPort,
+		launch:     p.launchOpts(paths, false),
 	}
 	if err := installSystemdUnit(unitOpts); err != nil {
 		return err
@@ -255,31 +298,53 @@ func (p *SSHProvisioner) deployAgentBinary(
 	log *slog.Logger,
 	step func(string, string, string),
 	logLine func(string),
-) error {
+) (bool, error) {
 	if p.cfg.AgentBinaryPath == "" {
 		log.Info("provision", logKeyStep, "agent_binary", "status", "skipped", "reason", "AGENT_BINARY_PATH empty")
-		return nil
+		return false, nil
 	}
 	step("agent_binary", "running", "Copiando agent...")
-	log.Info("provision", logKeyStep, "agent_binary", "localPath", p.cfg.AgentBinaryPath)
-	data, err := os.ReadFile(p.cfg.AgentBinaryPath)
+	arch, err := detectRemoteArch(sshClient)
 	if err != nil {
-		return fmt.Errorf(errReadAgentBinaryFmt, err)
+		return false, err
+	}
+	localPath, err := agentdownload.ResolveBinaryPath(p.cfg.AgentBinaryPath, arch)
+	if err != nil {
+		return false, err
+	}
+	log.Info("provision", logKeyStep, "agent_binary", "arch", arch, "localPath", localPath)
+	data, err := os.ReadFile(localPath)
+	if err != nil {
+		return false, fmt.Errorf(errReadAgentBinaryFmt, err)
+	}
+	sum := sha256.Sum256(data)
+	if remoteFileHash(sshClient, path.Join(installDir, "agent")) == hex.EncodeToString(sum[:]) {
+		logLine("Agent ja esta na versao correta")
+		step("agent_binary", "ok", "Agent em dia")
+		return false, nil
 	}
 	sizeKB := len(data) / 1024
-	logLine(fmt.Sprintf("Copiando agent (%d KB)...", sizeKB))
-	if err := copyAgentBinary(sftpClient, installDir, p.cfg.AgentBinaryPath); err != nil {
+	logLine(fmt.Sprintf("Copiando agent %s (%d KB)...", arch, sizeKB))
+	if err := copyAgentBinary(sftpClient, installDir, localPath); err != nil {
 		log.Info("provision", logKeyStep, "agent_binary", "fallback", "ssh_pipe", "sftp_err", err)
 		logLine("Fallback SSH pipe (SFTP falhou)")
-		if pipeErr := copyAgentBinaryViaSSH(sshClient, installDir, p.cfg.AgentBinaryPath); pipeErr != nil {
-			return fmt.Errorf("sftp: %w; ssh pipe fallback: %w", err, pipeErr)
+		if pipeErr := copyAgentBinaryViaSSH(sshClient, installDir, localPath); pipeErr != nil {
+			return false, fmt.Errorf("sftp: %w; ssh pipe fallback: %w", err, pipeErr)
 		}
 	}
 	step("agent_binary", "ok", "Agent copiado")
-	return nil
+	return true, nil
 }
 
-func (p *SSHProvisioner) connect(user, addr, privateKey string, password string, knownHostKey string, serverID string) (*ssh.Client, error) {
+func detectRemoteArch(client *ssh.Client) (string, error) {
+	out, err := runCommandOutput(client, "uname -m")
+	if err != nil {
+		return "", fmt.Errorf("detect remote architecture: %w", err)
+	}
+	return agentdownload.NormalizeArch(out)
+}
+
+func (p *SSHProvisioner) clientConfig(user, privateKey, password, knownHostKey, serverID string) (*ssh.ClientConfig, error) {
 	var authMethods []ssh.AuthMethod
 	if privateKey != "" {
 		signer, err := ssh.ParsePrivateKey([]byte(privateKey))
@@ -297,20 +362,66 @@ func (p *SSHProvisioner) connect(user, addr, privateKey string, password string,
 		return nil, fmt.Errorf("no ssh auth methods configured")
 	}
 
-	hostKeyCallback := p.buildHostKeyCallback(knownHostKey, serverID)
-
-	config := &ssh.ClientConfig{
+	return &ssh.ClientConfig{
 		User:            user,
 		Auth:            authMethods,
-		HostKeyCallback: hostKeyCallback,
+		HostKeyCallback: p.buildHostKeyCallback(knownHostKey, serverID),
 		Timeout:         sshConnectTimeout,
+	}, nil
+}
+
+// connect opens an SSH session to addr. When bastion is set the connection
+// is tunneled through it, like ssh -J, so the target needs no public SSH port.
+func (p *SSHProvisioner) connect(user, addr, privateKey string, password string, knownHostKey string, serverID string, bastion *domain.SSHBastion) (*ssh.Client, error) {
+	config, err := p.clientConfig(user, privateKey, password, knownHostKey, serverID)
+	if err != nil {
+		return nil, err
+	}
+
+	if bastion == nil {
+		client, err := ssh.Dial("tcp", addr, config)
+		if err != nil {
+			return nil, fmt.Errorf("dial: %w", err)
+		}
+		return client, nil
+	}
+
+	return p.connectViaBastion(addr, config, bastion)
+}
+
+func (p *SSHProvisioner) connectViaBastion(addr string, config *ssh.ClientConfig, bastion *domain.SSHBastion) (*ssh.Client, error) {
+	jumpConfig, err := p.clientConfig(bastion.User, bastion.Key, bastion.Password, bastion.HostKey, bastion.ServerID)
+	if err != nil {
+		return nil, fmt.Errorf("bastion: %w", err)
+	}
+	port := bastion.Port
+	if port == 0 {
+		port = defaultSSHPort
+	}
+	jump, err := ssh.Dial("tcp", net.JoinHostPort(bastion.Host, fmt.Sprintf("%d", port)), jumpConfig)
+	if err != nil {
+		return nil, fmt.Errorf("dial bastion: %w", err)
 	}
 
-	client, err := ssh.Dial("tcp", addr, config)
+	conn, err := jump.Dial("tcp", addr)
+	if err != nil {
+		jump.Close()
+		return nil, fmt.Errorf("dial through bastion: %w", err)
+	}
+	_ = conn.SetDeadline(time.Now().Add(sshConnectTimeout))
+	clientConn, chans, reqs, err := ssh.NewClientConn(conn, addr, config)
 	if err != nil {
-		return nil, fmt.Errorf("dial: %w", err)
+		conn.Close()
+		jump.Close()
+		return nil, fmt.Errorf("handshake through bastion: %w", err)
 	}
+	_ = conn.SetDeadline(time.Time{})
 
+	client := ssh.NewClient(clientConn, chans, reqs)
+	go func() {
+		_ = client.Wait()
+		jump.Close()
+	}()
 	return client, nil
 }
 
@@ -435,54 +546,50 @@ func copyAgentBinary(client *sftp.Client, installDir string, localPath string) e
 }
 
 type provisionPaths struct {
-	installDir string
-	unitDir    string
-	runtimeDir string
-	serverID   string
+	homeDir      string
+	installDir   string
+	unitDir      string
+	runtimeDir   string
+	serverID     string
+	dockerSocket string
 }
 
 type systemdUnitOpts struct {
 	sshClient  *ssh.Client
 	sftpClient *sftp.Client
-	installDir string
 	unitDir    string
 	runtimeDir string
-	serverID   string
-	serverAddr string
-	agentPort  int
+	launch     agentLaunchOpts
 }
 
-func installSystemdUnit(opts systemdUnitOpts) error {
-	serverAddr := opts.serverAddr
-	if serverAddr == "" {
-		serverAddr = "localhost:50051"
-	}
-	agentPort := opts.agentPort
-	if agentPort == 0 {
-		agentPort = 50052
-	}
-
-	if err := opts.sftpClient.MkdirAll(opts.unitDir); err != nil {
-		return fmt.Errorf("create systemd dir: %w", err)
+func buildSystemdUnit(launch agentLaunchOpts) string {
+	env := "Environment=TRAEFIK_API_URL=" + traefikAPIURL
+	if socket := launch.hostDockerSocket(); socket != defaultDockerSocket {
+		env += "\nEnvironment=DOCKER_HOST=unix://" + socket
 	}
-
-	unitContent := fmt.Sprintf(`[Unit]
+	return fmt.Sprintf(`[Unit]
 Description=PaasDeploy Agent
 After=network.target
 
 [Service]
 Type=simple
-Environment=TRAEFIK_API_URL=http://127.0.0.1:8081
-ExecStart=%s/agent -server-addr=%s -server-id=%s -ca-cert=%s/ca.pem -cert=%s/cert.pem -key=%s/key.pem -agent-port=%d
+%s
+ExecStart=%s/agent %s
 Restart=always
 RestartSec=5
 
 [Install]
 WantedBy=multi-user.target
-`, opts.installDir, serverAddr, opts.serverID, opts.installDir, opts.installDir, opts.installDir, agentPort)
+`, env, launch.installDir, launch.args())
+}
+
+func installSystemdUnit(opts systemdUnitOpts) error {
+	if err := opts.sftpClient.MkdirAll(opts.unitDir); err != nil {
+		return fmt.Errorf("create systemd dir: %w", err)
+	}
 
 	unitPath := path.Join(opts.unitDir, agentSystemdUnit)
-	if err := writeRemoteFile(opts.sftpClient, unitPath, []byte(unitContent), 0o644); err != nil {
+	if err := writeRemoteFile(opts.sftpClient, unitPath, []byte(buildSystemdUnit(opts.launch)), 0o644); err != nil {
 		return err
 	}
 
@@ -508,7 +615,7 @@ func (p *SSHProvisioner) Deprovision(server *domain.Server, sshKeyPlain string,
 		port = defaultSSHPort
 	}
 	addr := net.JoinHostPort(server.Host, fmt.Sprintf("%d", port))
-	client, err := p.connect(server.SSHUser, addr, sshKeyPlain, sshPasswordPlain, server.SSHHostKey, server.ID)
+	client, err := p.connect(server.SSHUser, addr, sshKeyPlain, sshPasswordPlain, server.SSHHostKey, server.ID, server.Bastion)
 	if err != nil {
 		return fmt.Errorf("ssh connect: %w", err)
 	}
@@ -526,10 +633,11 @@ func (p *SSHProvisioner) Deprovision(server *domain.Server, sshKeyPlain string,
 	installDir := path.Join(homeDir, agentInstallDirName)
 	unitPath := path.Join(homeDir, dotConfigDir, "systemd", "user", agentSystemdUnit)
 
-	stopCmd := fmt.Sprintf("XDG_RUNTIME_DIR=%s systemctl --user stop %s 2>/dev/null || true", runtimeDir, agentSystemdUnit)
-	_ = runCommand(client, stopCmd)
-	disableCmd := fmt.Sprintf("XDG_RUNTIME_DIR=%s systemctl --user disable %s 2>/dev/null || true", runtimeDir, agentSystemdUnit)
-	_ = runCommand(client, disableCmd)
+	method := installedMethod(client, installDir)
+	stopAgent(client, method, installDir, runtimeDir, uid, sshPasswordPlain)
+	if method == domain.AgentInstallOpenRC {
+		_ = runPrivilegedCommand(client, uid, sshPasswordPlain, fmt.Sprintf("rm -f %s", openRCScriptPath))
+	}
 	rmUnitCmd := fmt.Sprintf("rm -f %q", unitPath)
 	_ = runCommand(client, rmUnitCmd)
 	rmInstallCmd := fmt.Sprintf("rm -rf %q", installDir)
@@ -0,0 +1,202 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/paasdeploy/backend/internal/domain"
+)
+
+const agentCommandTimedOutMessage = "agent did not report a result"
+
+const agentCommandColumns = `id, server_id, command_type, payload, status, result_message, expires_at, created_at, delivered_at, completed_at`
+
+type PostgresAgentCommandRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresAgentCommandRepository(db *sql.DB) *PostgresAgentCommandRepository {
+	return &PostgresAgentCommandRepository{db: db}
+}
+
+func (r *PostgresAgentCommandRepository) Enqueue(input domain.EnqueueAgentCommandInput) (*domain.AgentCommand, error) {
+	ttl := input.TTL
+	if ttl <= 0 {
+		ttl = domain.DefaultAgentCommandTTL(input.Type)
+	}
+
+	query := `
+		INSERT INTO agent_commands (id, server_id, command_type, payload, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING ` + agentCommandColumns
+
+	cmd, err := scanAgentCommand(r.db.QueryRow(
+		query,
+		uuid.New().String(),
+		input.ServerID,
+		input.Type,
+		input.Payload,
+		time.Now().Add(ttl),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to enqueue agent command: %w", err)
+	}
+	return cmd, nil
+}
+
+func (r *PostgresAgentCommandRepository) ClaimPending(serverID string) ([]domain.AgentCommand, error) {
+	expireQuery := `
+		UPDATE agent_commands
+		SET status = 'expired', completed_at = NOW()
+		WHERE server_id = $1 AND status = 'pending' AND expires_at <= NOW()
+	`
+	if _, err := r.db.Exec(expireQuery, serverID); err != nil {
+		return nil, fmt.Errorf("failed to expire agent commands: %w", err)
+	}
+
+	timeoutQuery := `
+		UPDATE agent_commands
+		SET status = 'failed', result_message = $2, completed_at = NOW()
+		WHERE server_id = $1 AND status = 'delivered' AND delivered_at <= $3
+	`
+	if _, err := r.db.Exec(timeoutQuery, serverID, agentCommandTimedOutMessage, time.Now().Add(-domain.AgentCommandResultTimeout)); err != nil {
+		return nil, fmt.Errorf("failed to time out agent commands: %w", err)
+	}
+
+	claimQuery := `
+		UPDATE agent_commands
+		SET status = 'delivered', delivered_at = NOW()
+		WHERE id IN (
+			SELECT id FROM agent_commands
+			WHERE server_id = $1 AND status = 'pending' AND expires_at > NOW()
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + agentCommandColumns
+
+	commands, err := r.queryCommands(claimQuery, serverID)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(commands, func(i, j int) bool {
+		return commands[i].CreatedAt.Before(commands[j].CreatedAt)
+	})
+	return commands, nil
+}
+
+func (r *PostgresAgentCommandRepository) Complete(serverID, id string, success bool, message string) error {
+	status := domain.AgentCommandStatusSucceeded
+	if !success {
+		status = domain.AgentCommandStatusFailed
+	}
+
+	query := `
+		UPDATE agent_commands
+		SET status = $1, result_message = $2, completed_at = NOW()
+		WHERE id = $3 AND server_id = $4 AND status = 'delivered'
+	`
+	result, err := r.db.Exec(query, status, toNullStringValue(message), id, serverID)
+	if err != nil {
+		return fmt.Errorf("failed to complete agent command: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// CompleteDelivered records a result for every delivered command of a type.
+// It is used for commands whose outcome the agent cannot report itself, such
+// as an update that restarts the agent.
+func (r *PostgresAgentCommandRepository) CompleteDelivered(serverID string, cmdType domain.AgentCommandType, success bool, message string) (int64, error) {
+	status := domain.AgentCommandStatusSucceeded
+	if !success {
+		status = domain.AgentCommandStatusFailed
+	}
+
+	query := `
+		UPDATE agent_commands
+		SET status = $1, result_message = $2, completed_at = NOW()
+		WHERE server_id = $3 AND command_type = $4 AND status = 'delivered'
+	`
+	result, err := r.db.Exec(query, status, toNullStringValue(message), serverID, cmdType)
+	if err != nil {
+		return 0, fmt.Errorf("failed to complete agent commands: %w", err)
+	}
+	return result.RowsAffected()
+}
+
+func (r *PostgresAgentCommandRepository) CancelPending(serverID string) (int64, error) {
+	query := `
+		UPDATE agent_commands
+		SET status = 'cancelled', completed_at = NOW()
+		WHERE server_id = $1 AND status = 'pending'
+	`
+	result, err := r.db.Exec(query, serverID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to cancel agent commands: %w", err)
+	}
+	return result.RowsAffected()
+}
+
+func (r *PostgresAgentCommandRepository) FindByServerID(serverID string, limit int) ([]domain.AgentCommand, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+
+	query := `SELECT ` + agentCommandColumns + ` FROM agent_commands WHERE server_id = $1 ORDER BY created_at DESC LIMIT $2`
+	return r.queryCommands(query, serverID, limit)
+}
+
+func (r *PostgresAgentCommandRepository) queryCommands(query string, args ...any) ([]domain.AgentCommand, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query agent commands: %w", err)
+	}
+	defer rows.Close()
+
+	var commands []domain.AgentCommand
+	for rows.Next() {
+		cmd, err := scanAgentCommand(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan agent command: %w", err)
+		}
+		commands = append(commands, *cmd)
+	}
+
+	return commands, rows.Err()
+}
+
+func scanAgentCommand(row rowScanner) (*domain.AgentCommand, error) {
+	var cmd domain.AgentCommand
+	var resultMessage sql.NullString
+	var deliveredAt, completedAt sql.NullTime
+
+	if err := row.Scan(
+		&cmd.ID,
+		&cmd.ServerID,
+		&cmd.Type,
+		&cmd.Payload,
+		&cmd.Status,
+		&resultMessage,
+		&cmd.ExpiresAt,
+		&cmd.CreatedAt,
+		&deliveredAt,
+		&completedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	cmd.ResultMessage = fromNullString(resultMessage)
+	cmd.DeliveredAt = fromNullTime(deliveredAt)
+	cmd.CompletedAt = fromNullTime(completedAt)
+	return &cmd, nil
+}
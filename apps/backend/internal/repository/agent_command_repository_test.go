@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/paasdeploy/backend/internal/domain"
+)
+
+func newAgentCommandFixture(t *testing.T) (*PostgresAgentCommandRepository, *sql.DB, string) {
+	t.Helper()
+	db := openTestDB(t)
+	userID := createTestUser(t, db)
+
+	var serverID string
+	err := db.QueryRow(`INSERT INTO servers (user_id, name, host, ssh_user, ssh_key_encrypted)
+		VALUES ($1, $2, '127.0.0.1', 'root', '') RETURNING id`, userID, "cmd-"+uuid.NewString()[:8]).Scan(&serverID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewPostgresAgentCommandRepository(db), db, serverID
+}
+
+func enqueueCommand(t *testing.T, repo *PostgresAgentCommandRepository, serverID string, cmdType domain.AgentCommandType) *domain.AgentCommand {
+	t.Helper()
+	cmd, err := repo.Enqueue(domain.EnqueueAgentCommandInput{ServerID: serverID, Type: cmdType, Payload: "{}"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cmd
+}
+
+func findCommand(t *testing.T, repo *PostgresAgentCommandRepository, serverID, id string) domain.AgentCommand {
+	t.Helper()
+	commands, err := repo.FindByServerID(serverID, 50)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, cmd := range commands {
+		if cmd.ID == id {
+			return cmd
+		}
+	}
+	t.Fatalf("command %s not found", id)
+	return domain.AgentCommand{}
+}
+
+func TestAgentCommandClaimDeliversEachCommandOnce(t *testing.T) {
+	repo, _, serverID := newAgentCommandFixture(t)
+	first := enqueueCommand(t, repo, serverID, domain.AgentCommandStartContainer)
+	second := enqueueCommand(t, repo, serverID, domain.AgentCommandStopContainer)
+
+	claimed, err := repo.ClaimPending(serverID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(claimed) != 2 || claimed[0].ID != first.ID || claimed[1].ID != second.ID {
+		t.Fatalf("claimed = %+v, want both commands in order", claimed)
+	}
+	for _, cmd := range claimed {
+		if cmd.Status != domain.AgentCommandStatusDelivered || cmd.DeliveredAt == nil {
+			t.Errorf("command %s status = %s, want delivered", cmd.ID, cmd.Status)
+		}
+	}
+
+	again, err := repo.ClaimPending(serverID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(again) != 0 {
+		t.Errorf("second claim returned %d commands, want none", len(again))
+	}
+}
+
+func TestAgentCommandCompleteRequiresDelivery(t *testing.T) {
+	repo, _, serverID := newAgentCommandFixture(t)
+	cmd := enqueueCommand(t, repo, serverID, domain.AgentCommandRestartContainer)
+
+	if err := repo.Complete(serverID, cmd.ID, true, "ok"); !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("Complete on pending command = %v, want ErrNotFound", err)
+	}
+
+	if _, err := repo.ClaimPending(serverID); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Complete(serverID, cmd.ID, false, "container not found"); err != nil {
+		t.Fatal(err)
+	}
+	got := findCommand(t, repo, serverID, cmd.ID)
+	if got.Status != domain.AgentCommandStatusFailed || got.ResultMessage != "container not found" || got.CompletedAt == nil {
+		t.Errorf("completed command = %+v", got)
+	}
+
+	if err := repo.Complete(serverID, cmd.ID, true, "again"); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("second Complete = %v, want ErrNotFound", err)
+	}
+}
+
+func TestAgentCommandClaimExpiresPendingCommands(t *testing.T) {
+	repo, db, serverID := newAgentCommandFixture(t)
+	cmd := enqueueCommand(t, repo, serverID, domain.AgentCommandStartContainer)
+	if _, err := db.Exec(`UPDATE agent_commands SET expires_at = NOW() - INTERVAL '1 minute' WHERE id = $1`, cmd.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	claimed, err := repo.ClaimPending(serverID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(claimed) != 0 {
+		t.Fatalf("claimed %d expired commands", len(claimed))
+	}
+	if got := findCommand(t, repo, serverID, cmd.ID); got.Status != domain.AgentCommandStatusExpired {
+		t.Errorf("status = %s, want expired", got.Status)
+	}
+}
+
+func TestAgentCommandClaimFailsCommandsWithoutResult(t *testing.T) {
+	repo, db, serverID := newAgentCommandFixture(t)
+	stale := enqueueCommand(t, repo, serverID, domain.AgentCommandStopContainer)
+	if _, err := repo.ClaimPending(serverID); err != nil {
+		t.Fatal(err)
+	}
+	recent := enqueueCommand(t, repo, serverID, domain.AgentCommandStartContainer)
+	if _, err := repo.ClaimPending(serverID); err != nil {
+		t.Fatal(err)
+	}
+
+	deliveredAt := time.Now().Add(-domain.AgentCommandResultTimeout - time.Minute)
+	if _, err := db.Exec(`UPDATE agent_commands SET delivered_at = $1 WHERE id = $2`, deliveredAt, stale.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.ClaimPending(serverID); err != nil {
+		t.Fatal(err)
+	}
+
+	got := findCommand(t, repo, serverID, stale.ID)
+	if got.Status != domain.AgentCommandStatusFailed || got.ResultMessage != agentCommandTimedOutMessage {
+		t.Errorf("stale command = %s %q, want failed without result", got.Status, got.ResultMessage)
+	}
+	if got := findCommand(t, repo, serverID, recent.ID); got.Status != domain.AgentCommandStatusDelivered {
+		t.Errorf("recent command status = %s, want delivered", got.Status)
+	}
+}
+
+func TestAgentCommandCompleteDeliveredUpdates(t *testing.T) {
+	repo, _, serverID := newAgentCommandFixture(t)
+	update := enqueueCommand(t, repo, serverID, domain.AgentCommandUpdateAgent)
+	restart := enqueueCommand(t, repo, serverID, domain.AgentCommandRestartContainer)
+	if _, err := repo.ClaimPending(serverID); err != nil {
+		t.Fatal(err)
+	}
+
+	completed, err := repo.CompleteDelivered(serverID, domain.AgentCommandUpdateAgent, true, "updated to 1.2.3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if completed != 1 {
+		t.Errorf("completed = %d, want 1", completed)
+	}
+	if got := findCommand(t, repo, serverID, update.ID); got.Status != domain.AgentCommandStatusSucceeded {
+		t.Errorf("update status = %s, want succeeded", got.Status)
+	}
+	if got := findCommand(t, repo, serverID, restart.ID); got.Status != domain.AgentCommandStatusDelivered {
+		t.Errorf("restart status = %s, want delivered", got.Status)
+	}
+}
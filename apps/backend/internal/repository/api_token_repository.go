@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/paasdeploy/backend/internal/domain"
+)
+
+const apiTokenSelectColumns = `id, user_id, name, token_hash, token_prefix, last_used_at, expires_at, created_at`
+
+type PostgresAPITokenRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresAPITokenRepository(db *sql.DB) *PostgresAPITokenRepository {
+	return &PostgresAPITokenRepository{db: db}
+}
+
+func scanAPIToken(row rowScanner) (*domain.APIToken, error) {
+	var token domain.APIToken
+	var lastUsedAt, expiresAt sql.NullTime
+	err := row.Scan(
+		&token.ID,
+		&token.UserID,
+		&token.Name,
+		&token.TokenHash,
+		&token.TokenPrefix,
+		&lastUsedAt,
+		&expiresAt,
+		&token.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	token.LastUsedAt = fromNullTime(lastUsedAt)
+	token.ExpiresAt = fromNullTime(expiresAt)
+	return &token, nil
+}
+
+func (r *PostgresAPITokenRepository) Create(ctx context.Context, input domain.CreateAPITokenInput) (*domain.APIToken, error) {
+	query := `
+		INSERT INTO api_tokens (user_id, name, token_hash, token_prefix, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING ` + apiTokenSelectColumns
+
+	row := r.db.QueryRowContext(ctx, query,
+		input.UserID,
+		input.Name,
+		input.TokenHash,
+		input.TokenPrefix,
+		toNullTime(input.ExpiresAt),
+	)
+	return scanAPIToken(row)
+}
+
+func (r *PostgresAPITokenRepository) FindByTokenHash(ctx context.Context, tokenHash string) (*domain.APIToken, error) {
+	query := `SELECT ` + apiTokenSelectColumns + ` FROM api_tokens
+		WHERE token_hash = $1 AND (expires_at IS NULL OR expires_at > NOW())`
+	token, err := scanAPIToken(r.db.QueryRowContext(ctx, query, tokenHash))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrNotFound
+	}
+	return token, err
+}
+
+func (r *PostgresAPITokenRepository) FindByUserID(ctx context.Context, userID string) ([]domain.APIToken, error) {
+	query := `SELECT ` + apiTokenSelectColumns + ` FROM api_tokens WHERE user_id = $1 ORDER BY created_at DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tokens := []domain.APIToken{}
+	for rows.Next() {
+		token, err := scanAPIToken(rows)
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, *token)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return tokens, nil
+}
+
+func (r *PostgresAPITokenRepository) Delete(ctx context.Context, id, userID string) error {
+	query := `DELETE FROM api_tokens WHERE id = $1 AND user_id = $2`
+
+	result, err := r.db.ExecContext(ctx, query, id, userID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *PostgresAPITokenRepository) TouchLastUsed(ctx context.Context, id string) error {
+	query := `UPDATE api_tokens SET last_used_at = NOW() WHERE id = $1`
+	_, err := r.db.ExecContext(ctx, query, id)
+	return err
+}
+
+var _ domain.APITokenRepository = (*PostgresAPITokenRepository)(nil)
@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/paasdeploy/backend/internal/domain"
+)
+
+type PostgresAppHealthEventRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresAppHealthEventRepository(db *sql.DB) *PostgresAppHealthEventRepository {
+	return &PostgresAppHealthEventRepository{db: db}
+}
+
+// Record closes the app's open period and starts a new one at `at`. A
+// repeated status, as reported again after a restart of the backend, keeps
+// the open period.
+func (r *PostgresAppHealthEventRepository) Record(appID, status, health string, at time.Time) (err error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to record app health event: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	var openID, openStatus, openHealth string
+	err = tx.QueryRow(`
+		SELECT id, status, health FROM app_health_events
+		WHERE app_id = $1 AND ended_at IS NULL
+		FOR UPDATE
+	`, appID).Scan(&openID, &openStatus, &openHealth)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		err = nil
+	case err != nil:
+		return fmt.Errorf("failed to find open app health event: %w", err)
+	case openStatus == status && openHealth == health:
+		return tx.Commit()
+	default:
+		if _, err = tx.Exec(`UPDATE app_health_events SET ended_at = $2 WHERE id = $1`, openID, at); err != nil {
+			return fmt.Errorf("failed to close app health event: %w", err)
+		}
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO app_health_events (app_id, status, health, started_at)
+		VALUES ($1, $2, $3, $4)
+	`, appID, status, health, at)
+	if err != nil {
+		return fmt.Errorf("failed to insert app health event: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+func (r *PostgresAppHealthEventRepository) FindOverlapping(appID string, from, to time.Time) ([]domain.AppHealthEvent, error) {
+	query := `
+		SELECT id, app_id, status, health, started_at, ended_at
+		FROM app_health_events
+		WHERE app_id = $1 AND started_at <= $3 AND (ended_at IS NULL OR ended_at >= $2)
+		ORDER BY started_at DESC
+	`
+	rows, err := r.db.Query(query, appID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query app health events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []domain.AppHealthEvent
+	for rows.Next() {
+		var event domain.AppHealthEvent
+		var endedAt sql.NullTime
+		if err := rows.Scan(&event.ID, &event.AppID, &event.Status, &event.Health, &event.StartedAt, &endedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan app health event: %w", err)
+		}
+		if endedAt.Valid {
+			event.EndedAt = &endedAt.Time
+		}
+		events = append(events, event)
+	}
+
+	return events, rows.Err()
+}
+
+// DeleteOlderThan removes closed periods that ended before `before`.
+func (r *PostgresAppHealthEventRepository) DeleteOlderThan(before time.Time) (int64, error) {
+	result, err := r.db.Exec(`DELETE FROM app_health_events WHERE ended_at < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune app health events: %w", err)
+	}
+	return result.RowsAffected()
+}
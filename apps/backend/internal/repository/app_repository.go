@@ -9,7 +9,7 @@ import (
 	"github.com/paasdeploy/backend/internal/domain"
 )
 
-const appSelectColumns = `id, user_id, name, repository_url, branch, workdir, runtime, app_version, config, status, webhook_id, server_id, last_deployed_at, created_at, updated_at`
+const appSelectColumns = `id, user_id, name, repository_url, branch, workdir, runtime, app_version, config, status, webhook_id, server_id, last_deployed_at, rate_limit, redirects, security_headers, internal, maintenance, auto_heal, linked_app_ids, stats_interval_seconds, created_at, updated_at, deleted_at`
 
 type PostgresAppRepository struct {
 	db *sql.DB
@@ -26,6 +26,14 @@ type appScanFields struct {
 	lastDeployedAt sql.NullTime
 	runtime        sql.NullString
 	appVersion     sql.NullString
+	rateLimit      []byte
+	redirects      []byte
+	headers        []byte
+	maintenance    []byte
+	autoHeal       []byte
+	linkedAppIDs   []byte
+	statsInterval  sql.NullInt32
+	deletedAt      sql.NullTime
 }
 
 func (f *appScanFields) scanDest() []any {
@@ -43,8 +51,17 @@ func (f *appScanFields) scanDest() []any {
 		&f.webhookID,
 		&f.serverID,
 		&f.lastDeployedAt,
+		&f.rateLimit,
+		&f.redirects,
+		&f.headers,
+		&f.app.Internal,
+		&f.maintenance,
+		&f.autoHeal,
+		&f.linkedAppIDs,
+		&f.statsInterval,
 		&f.app.CreatedAt,
 		&f.app.UpdatedAt,
+		&f.deletedAt,
 	}
 }
 
@@ -58,12 +75,46 @@ func (f *appScanFields) toApp() *domain.App {
 	if f.lastDeployedAt.Valid {
 		f.app.LastDeployedAt = &f.lastDeployedAt.Time
 	}
+	if f.deletedAt.Valid {
+		f.app.DeletedAt = &f.deletedAt.Time
+	}
 	if f.runtime.Valid {
 		f.app.Runtime = &f.runtime.String
 	}
 	if f.appVersion.Valid {
 		f.app.AppVersion = &f.appVersion.String
 	}
+	if len(f.rateLimit) > 0 {
+		var rateLimit domain.AppRateLimit
+		if err := json.Unmarshal(f.rateLimit, &rateLimit); err == nil {
+			f.app.RateLimit = &rateLimit
+		}
+	}
+	if len(f.redirects) > 0 {
+		_ = json.Unmarshal(f.redirects, &f.app.Redirects)
+	}
+	if len(f.headers) > 0 {
+		var headers domain.AppSecurityHeaders
+		if err := json.Unmarshal(f.headers, &headers); err == nil {
+			f.app.SecurityHeaders = &headers
+		}
+	}
+	if len(f.maintenance) > 0 {
+		var maintenance domain.AppMaintenance
+		if err := json.Unmarshal(f.maintenance, &maintenance); err == nil {
+			f.app.Maintenance = &maintenance
+		}
+	}
+	if len(f.autoHeal) > 0 {
+		var policy domain.AppAutoHeal
+		if err := json.Unmarshal(f.autoHeal, &policy); err == nil {
+			f.app.AutoHeal = &policy
+		}
+	}
+	if len(f.linkedAppIDs) > 0 {
+		_ = json.Unmarshal(f.linkedAppIDs, &f.app.LinkedAppIDs)
+	}
+	f.app.StatsIntervalSeconds = fromNullInt32(f.statsInterval)
 	return &f.app
 }
 
@@ -128,6 +179,61 @@ func (r *PostgresAppRepository) FindAllByUserID(userID string) ([]domain.App, er
 	return apps, nil
 }
 
+var appSortColumns = map[string]sortColumn{
+	"createdAt": {column: "created_at", time: true},
+	"updatedAt": {column: "updated_at", time: true},
+	"name":      {column: "name"},
+}
+
+func (r *PostgresAppRepository) FindPageByUserID(userID string, filter domain.AppFilter, opts domain.ListOptions) (domain.Page[domain.App], error) {
+	var q listQuery
+	q.where("user_id = " + q.arg(userID))
+	q.where("status != 'deleted'")
+	if filter.Status != "" {
+		q.where("status = " + q.arg(filter.Status))
+	}
+	switch {
+	case filter.LocalOnly:
+		q.where("server_id IS NULL")
+	case filter.ServerID != "":
+		q.where("server_id = " + q.arg(filter.ServerID))
+	}
+	q.search(opts.Query, "name", "repository_url")
+
+	where, suffix, err := q.page(opts, appSortColumns, "created_at", "id")
+	if err != nil {
+		return domain.Page[domain.App]{}, err
+	}
+
+	rows, err := r.db.Query(`SELECT `+appSelectColumns+` FROM apps`+where+suffix, q.args...)
+	if err != nil {
+		return domain.Page[domain.App]{}, err
+	}
+	defer rows.Close()
+
+	var apps []domain.App
+	for rows.Next() {
+		var f appScanFields
+		if err := rows.Scan(f.scanDest()...); err != nil {
+			return domain.Page[domain.App]{}, err
+		}
+		apps = append(apps, *f.toApp())
+	}
+	if err := rows.Err(); err != nil {
+		return domain.Page[domain.App]{}, err
+	}
+
+	return toPage(apps, opts, func(a *domain.App) (string, string) {
+		switch opts.SortBy {
+		case "name":
+			return a.Name, a.ID
+		case "updatedAt":
+			return domain.CursorTime(a.UpdatedAt), a.ID
+		}
+		return domain.CursorTime(a.CreatedAt), a.ID
+	}), nil
+}
+
 func (r *PostgresAppRepository) FindByID(id string) (*domain.App, error) {
 	query := `SELECT ` + appSelectColumns + ` FROM apps WHERE id = $1 AND status != 'deleted'`
 	return r.scanApp(r.db.QueryRow(query, id))
@@ -138,6 +244,22 @@ func (r *PostgresAppRepository) FindByIDAndUserID(id, userID string) (*domain.Ap
 	return r.scanApp(r.db.QueryRow(query, id, userID))
 }
 
+func (r *PostgresAppRepository) FindByIDs(ids []string) ([]domain.App, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	query := `SELECT ` + appSelectColumns + ` FROM apps WHERE id = ANY($1) AND status != 'deleted'`
+	return r.queryApps(query, ids)
+}
+
+func (r *PostgresAppRepository) FindByIDsAndUserID(ids []string, userID string) ([]domain.App, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	query := `SELECT ` + appSelectColumns + ` FROM apps WHERE id = ANY($1) AND user_id = $2 AND status != 'deleted'`
+	return r.queryApps(query, ids, userID)
+}
+
 func (r *PostgresAppRepository) FindByName(name string) (*domain.App, error) {
 	query := `SELECT ` + appSelectColumns + ` FROM apps WHERE name = $1 AND status != 'deleted'`
 	return r.scanApp(r.db.QueryRow(query, name))
@@ -283,7 +405,7 @@ func (r *PostgresAppRepository) Update(id string, input domain.UpdateAppInput) (
 }
 
 func (r *PostgresAppRepository) Delete(id string) error {
-	query := `UPDATE apps SET status = 'deleted', updated_at = NOW() WHERE id = $1`
+	query := `UPDATE apps SET status = 'deleted', deleted_at = NOW(), updated_at = NOW() WHERE id = $1 AND status != 'deleted'`
 	result, err := r.db.Exec(query, id)
 	if err != nil {
 		return err
@@ -307,6 +429,116 @@ func (r *PostgresAppRepository) UpdateLastDeployedAt(id string, deployedAt time.
 	return err
 }
 
+func (r *PostgresAppRepository) UpdateRateLimit(id string, rateLimit *domain.AppRateLimit) error {
+	var value any
+	if rateLimit != nil {
+		data, err := json.Marshal(rateLimit)
+		if err != nil {
+			return err
+		}
+		value = data
+	}
+	query := `UPDATE apps SET rate_limit = $2, updated_at = NOW() WHERE id = $1`
+	_, err := r.db.Exec(query, id, value)
+	return err
+}
+
+func (r *PostgresAppRepository) UpdateRedirects(id string, redirects []domain.AppRedirect) error {
+	var value any
+	if len(redirects) > 0 {
+		data, err := json.Marshal(redirects)
+		if err != nil {
+			return err
+		}
+		value = data
+	}
+	query := `UPDATE apps SET redirects = $2, updated_at = NOW() WHERE id = $1`
+	_, err := r.db.Exec(query, id, value)
+	return err
+}
+
+func (r *PostgresAppRepository) UpdateSecurityHeaders(id string, headers *domain.AppSecurityHeaders) error {
+	var value any
+	if headers != nil {
+		data, err := json.Marshal(headers)
+		if err != nil {
+			return err
+		}
+		value = data
+	}
+	query := `UPDATE apps SET security_headers = $2, updated_at = NOW() WHERE id = $1`
+	_, err := r.db.Exec(query, id, value)
+	return err
+}
+
+func (r *PostgresAppRepository) UpdateInternal(id string, internal bool) error {
+	query := `UPDATE apps SET internal = $2, updated_at = NOW() WHERE id = $1`
+	_, err := r.db.Exec(query, id, internal)
+	return err
+}
+
+func (r *PostgresAppRepository) UpdateMaintenance(id string, maintenance *domain.AppMaintenance) error {
+	var value any
+	if maintenance != nil {
+		data, err := json.Marshal(maintenance)
+		if err != nil {
+			return err
+		}
+		value = data
+	}
+	query := `UPDATE apps SET maintenance = $2, updated_at = NOW() WHERE id = $1`
+	_, err := r.db.Exec(query, id, value)
+	return err
+}
+
+func (r *PostgresAppRepository) UpdateAutoHeal(id string, policy *domain.AppAutoHeal) error {
+	var value any
+	if policy != nil {
+		data, err := json.Marshal(policy)
+		if err != nil {
+			return err
+		}
+		value = data
+	}
+	query := `UPDATE apps SET auto_heal = $2, updated_at = NOW() WHERE id = $1`
+	_, err := r.db.Exec(query, id, value)
+	return err
+}
+
+func (r *PostgresAppRepository) UpdateMemoryReservation(id string, bytes int64) error {
+	query := `UPDATE apps SET memory_reservation_bytes = $2 WHERE id = $1`
+	_, err := r.db.Exec(query, id, bytes)
+	return err
+}
+
+func (r *PostgresAppRepository) UpdateStatsInterval(id string, seconds *int) error {
+	query := `UPDATE apps SET stats_interval_seconds = $2, updated_at = NOW() WHERE id = $1`
+	_, err := r.db.Exec(query, id, seconds)
+	return err
+}
+
+func (r *PostgresAppRepository) SumMemoryReservation(userID, exceptAppID string) (int64, error) {
+	query := `SELECT COALESCE(SUM(memory_reservation_bytes), 0) FROM apps
+		WHERE user_id = $1 AND status != 'deleted' AND id::text != $2`
+	var total int64
+	err := r.db.QueryRow(query, userID, exceptAppID).Scan(&total)
+	return total, err
+}
+
+func (r *PostgresAppRepository) UpdateLinkedApps(id string, appIDs []string) error {
+	var value any
+	if len(appIDs) > 0 {
+		data, err := json.Marshal(appIDs)
+		if err != nil {
+			return err
+		}
+		value = data
+	}
+	query := `UPDATE apps SET linked_app_ids = $2, updated_at = NOW() WHERE id = $1`
+	_, err := r.db.Exec(query, id, value)
+	return err
+}
+
 func (r *PostgresAppRepository) HardDelete(id string) error {
 	query := `DELETE FROM apps WHERE id = $1`
 	result, err := r.db.Exec(query, id)
@@ -325,3 +557,65 @@ func (r *PostgresAppRepository) HardDelete(id string) error {
 
 	return nil
 }
+
+func (r *PostgresAppRepository) Restore(id string) error {
+	query := `UPDATE apps SET status = 'active', deleted_at = NULL, updated_at = NOW() WHERE id = $1 AND status = 'deleted'`
+	result, err := r.db.Exec(query, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *PostgresAppRepository) FindDeletedByID(id string) (*domain.App, error) {
+	query := `SELECT ` + appSelectColumns + ` FROM apps WHERE id = $1 AND status = 'deleted'`
+	return r.scanApp(r.db.QueryRow(query, id))
+}
+
+func (r *PostgresAppRepository) FindDeletedByName(name string) (*domain.App, error) {
+	query := `SELECT ` + appSelectColumns + ` FROM apps WHERE name = $1 AND status = 'deleted' ORDER BY deleted_at DESC LIMIT 1`
+	return r.scanApp(r.db.QueryRow(query, name))
+}
+
+func (r *PostgresAppRepository) FindDeletedByUserID(userID string) ([]domain.App, error) {
+	query := `SELECT ` + appSelectColumns + ` FROM apps WHERE user_id = $1 AND status = 'deleted' ORDER BY deleted_at DESC`
+	return r.queryApps(query, userID)
+}
+
+func (r *PostgresAppRepository) FindDeletedBefore(before time.Time) ([]domain.App, error) {
+	query := `SELECT ` + appSelectColumns + ` FROM apps WHERE status = 'deleted' AND deleted_at < $1 ORDER BY deleted_at ASC`
+	return r.queryApps(query, before)
+}
+
+func (r *PostgresAppRepository) queryApps(query string, args ...any) ([]domain.App, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var apps []domain.App
+	for rows.Next() {
+		var f appScanFields
+		if err := rows.Scan(f.scanDest()...); err != nil {
+			return nil, err
+		}
+		apps = append(apps, *f.toApp())
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return apps, nil
+}
@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/paasdeploy/backend/internal/domain"
+)
+
+func createTestApp(t *testing.T, repo *PostgresAppRepository, userID string) *domain.App {
+	t.Helper()
+	name := "trash-" + uuid.NewString()[:8]
+	app, err := repo.Create(domain.CreateAppInput{
+		UserID:        userID,
+		Name:          name,
+		RepositoryURL: "https://github.com/test/" + name,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = repo.HardDelete(app.ID) })
+	return app
+}
+
+func containsApp(apps []domain.App, id string) bool {
+	for _, app := range apps {
+		if app.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+func TestAppRepositoryHidesTrashedApps(t *testing.T) {
+	db := openTestDB(t)
+	userID := createTestUser(t, db)
+	repo := NewPostgresAppRepository(db)
+	kept := createTestApp(t, repo, userID)
+	trashed := createTestApp(t, repo, userID)
+
+	if err := repo.Delete(trashed.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	apps, err := repo.FindAllByUserID(userID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !containsApp(apps, kept.ID) || containsApp(apps, trashed.ID) {
+		t.Errorf("FindAllByUserID = %v, want only %s", apps, kept.ID)
+	}
+	if _, err := repo.FindByID(trashed.ID); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("FindByID on trashed app = %v, want ErrNotFound", err)
+	}
+	if _, err := repo.FindByName(trashed.Name); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("FindByName on trashed app = %v, want ErrNotFound", err)
+	}
+
+	trash, err := repo.FindDeletedByUserID(userID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(trash) != 1 || trash[0].ID != trashed.ID || trash[0].DeletedAt == nil {
+		t.Errorf("FindDeletedByUserID = %v, want %s with a deletion time", trash, trashed.ID)
+	}
+	if err := repo.Delete(trashed.ID); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("second Delete = %v, want ErrNotFound", err)
+	}
+}
+
+func TestAppRepositoryFindDeletedBeforeAndRestore(t *testing.T) {
+	db := openTestDB(t)
+	userID := createTestUser(t, db)
+	repo := NewPostgresAppRepository(db)
+	app := createTestApp(t, repo, userID)
+	if err := repo.Delete(app.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	expired, err := repo.FindDeletedBefore(time.Now().Add(-time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if containsApp(expired, app.ID) {
+		t.Error("app deleted just now is past the retention window")
+	}
+	expired, err = repo.FindDeletedBefore(time.Now().Add(time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !containsApp(expired, app.ID) {
+		t.Error("FindDeletedBefore missed the trashed app")
+	}
+
+	if err := repo.Restore(app.ID); err != nil {
+		t.Fatal(err)
+	}
+	restored, err := repo.FindByID(app.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if restored.Status != domain.AppStatusActive || restored.DeletedAt != nil {
+		t.Errorf("restored app = %s deletedAt=%v", restored.Status, restored.DeletedAt)
+	}
+	if err := repo.Restore(app.ID); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("Restore of an active app = %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	"github.com/paasdeploy/backend/internal/domain"
+)
+
+const appWebhookSelectColumns = `id, app_id, url, secret_encrypted, events, enabled, last_delivery_at, last_status_code, last_error, created_at, updated_at`
+
+type PostgresAppWebhookRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresAppWebhookRepository(db *sql.DB) *PostgresAppWebhookRepository {
+	return &PostgresAppWebhookRepository{db: db}
+}
+
+func scanAppWebhook(row rowScanner) (*domain.AppWebhook, error) {
+	var w domain.AppWebhook
+	var events []byte
+	var lastDeliveryAt sql.NullTime
+	var lastStatusCode sql.NullInt64
+	var lastError sql.NullString
+	if err := row.Scan(
+		&w.ID,
+		&w.AppID,
+		&w.URL,
+		&w.SecretEncrypted,
+		&events,
+		&w.Enabled,
+		&lastDeliveryAt,
+		&lastStatusCode,
+		&lastError,
+		&w.CreatedAt,
+		&w.UpdatedAt,
+	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(events, &w.Events); err != nil {
+		return nil, err
+	}
+	if w.Events == nil {
+		w.Events = []string{}
+	}
+	w.LastDeliveryAt = fromNullTime(lastDeliveryAt)
+	w.LastStatusCode = int(lastStatusCode.Int64)
+	w.LastError = fromNullString(lastError)
+	return &w, nil
+}
+
+func (r *PostgresAppWebhookRepository) findMany(query string, args ...any) ([]domain.AppWebhook, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	webhooks := []domain.AppWebhook{}
+	for rows.Next() {
+		w, err := scanAppWebhook(rows)
+		if err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, *w)
+	}
+	return webhooks, rows.Err()
+}
+
+func (r *PostgresAppWebhookRepository) FindByAppID(appID string) ([]domain.AppWebhook, error) {
+	return r.findMany(`SELECT `+appWebhookSelectColumns+` FROM app_webhooks WHERE app_id = $1 ORDER BY created_at`, appID)
+}
+
+func (r *PostgresAppWebhookRepository) FindEnabledByAppID(appID string) ([]domain.AppWebhook, error) {
+	return r.findMany(`SELECT `+appWebhookSelectColumns+` FROM app_webhooks WHERE app_id = $1 AND enabled ORDER BY created_at`, appID)
+}
+
+func (r *PostgresAppWebhookRepository) FindByIDAndAppID(id, appID string) (*domain.AppWebhook, error) {
+	query := `SELECT ` + appWebhookSelectColumns + ` FROM app_webhooks WHERE id = $1 AND app_id = $2`
+	return scanAppWebhook(r.db.QueryRow(query, id, appID))
+}
+
+func (r *PostgresAppWebhookRepository) Create(input domain.CreateAppWebhookInput) (*domain.AppWebhook, error) {
+	events, err := json.Marshal(nonNilEvents(input.Events))
+	if err != nil {
+		return nil, err
+	}
+	query := `
+		INSERT INTO app_webhooks (app_id, url, secret_encrypted, events, enabled)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING ` + appWebhookSelectColumns
+	return scanAppWebhook(r.db.QueryRow(query, input.AppID, input.URL, input.SecretEncrypted, events, input.Enabled))
+}
+
+func (r *PostgresAppWebhookRepository) Update(id string, input domain.UpdateAppWebhookInput) (*domain.AppWebhook, error) {
+	var urlVal, eventsVal, enabledVal interface{}
+	if input.URL != nil {
+		urlVal = *input.URL
+	}
+	if input.Events != nil {
+		events, err := json.Marshal(nonNilEvents(*input.Events))
+		if err != nil {
+			return nil, err
+		}
+		eventsVal = events
+	}
+	if input.Enabled != nil {
+		enabledVal = *input.Enabled
+	}
+	query := `
+		UPDATE app_webhooks
+		SET url = COALESCE($2, url),
+		    events = COALESCE($3::jsonb, events),
+		    enabled = COALESCE($4, enabled),
+		    updated_at = NOW()
+		WHERE id = $1
+		RETURNING ` + appWebhookSelectColumns
+	return scanAppWebhook(r.db.QueryRow(query, id, urlVal, eventsVal, enabledVal))
+}
+
+// RecordDelivery stores the outcome of the last delivery. It leaves
+// updated_at alone, which tracks changes to the webhook itself.
+func (r *PostgresAppWebhookRepository) RecordDelivery(id string, delivery domain.AppWebhookDelivery) error {
+	statusCode := sql.NullInt64{Int64: int64(delivery.StatusCode), Valid: delivery.StatusCode != 0}
+	_, err := r.db.Exec(`
+		UPDATE app_webhooks
+		SET last_delivery_at = $2, last_status_code = $3, last_error = $4
+		WHERE id = $1
+	`, id, delivery.At, statusCode, toNullStringValue(delivery.Error))
+	return err
+}
+
+func (r *PostgresAppWebhookRepository) Delete(id string) error {
+	result, err := r.db.Exec(`DELETE FROM app_webhooks WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func nonNilEvents(events []string) []string {
+	if events == nil {
+		return []string{}
+	}
+	return events
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/paasdeploy/backend/internal/domain"
 )
@@ -28,13 +29,13 @@ func (r *PostgresAuditLogRepository) Create(input domain.CreateAuditLogInput) (*
 	}
 
 	query := `
-		INSERT INTO audit_logs (event_type, resource_type, resource_id, resource_name, user_id, user_name, details, ip_address, user_agent)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
-		RETURNING id, event_type, resource_type, resource_id, resource_name, user_id, user_name, details, ip_address, user_agent, created_at
+		INSERT INTO audit_logs (event_type, resource_type, resource_id, resource_name, user_id, user_name, details, ip_address, user_agent, trace_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NULLIF($10, ''))
+		RETURNING id, event_type, resource_type, resource_id, resource_name, user_id, user_name, details, ip_address, user_agent, trace_id, created_at
 	`
 
 	var log domain.AuditLog
-	var resourceID, resourceName, userID, userName, ipAddress, userAgent sql.NullString
+	var resourceID, resourceName, userID, userName, ipAddress, userAgent, traceID sql.NullString
 	var details []byte
 
 	err = r.db.QueryRow(
@@ -48,6 +49,7 @@ func (r *PostgresAuditLogRepository) Create(input domain.CreateAuditLogInput) (*
 		detailsJSON,
 		input.IPAddress,
 		input.UserAgent,
+		input.TraceID,
 	).Scan(
 		&log.ID,
 		&log.EventType,
@@ -59,6 +61,7 @@ func (r *PostgresAuditLogRepository) Create(input domain.CreateAuditLogInput) (*
 		&details,
 		&ipAddress,
 		&userAgent,
+		&traceID,
 		&log.CreatedAt,
 	)
 	if err != nil {
@@ -83,6 +86,9 @@ func (r *PostgresAuditLogRepository) Create(input domain.CreateAuditLogInput) (*
 	if userAgent.Valid {
 		log.UserAgent = &userAgent.String
 	}
+	if traceID.Valid {
+		log.TraceID = &traceID.String
+	}
 	log.Details = details
 
 	return &log, nil
@@ -90,13 +96,13 @@ func (r *PostgresAuditLogRepository) Create(input domain.CreateAuditLogInput) (*
 
 func (r *PostgresAuditLogRepository) FindByID(id string) (*domain.AuditLog, error) {
 	query := `
-		SELECT id, event_type, resource_type, resource_id, resource_name, user_id, user_name, details, ip_address, user_agent, created_at
+		SELECT id, event_type, resource_type, resource_id, resource_name, user_id, user_name, details, ip_address, user_agent, trace_id, created_at
 		FROM audit_logs
 		WHERE id = $1
 	`
 
 	var log domain.AuditLog
-	var resourceID, resourceName, userID, userName, ipAddress, userAgent sql.NullString
+	var resourceID, resourceName, userID, userName, ipAddress, userAgent, traceID sql.NullString
 	var details []byte
 
 	err := r.db.QueryRow(query, id).Scan(
@@ -110,6 +116,7 @@ func (r *PostgresAuditLogRepository) FindByID(id string) (*domain.AuditLog, erro
 		&details,
 		&ipAddress,
 		&userAgent,
+		&traceID,
 		&log.CreatedAt,
 	)
 	if err == sql.ErrNoRows {
@@ -137,6 +144,9 @@ func (r *PostgresAuditLogRepository) FindByID(id string) (*domain.AuditLog, erro
 	if userAgent.Valid {
 		log.UserAgent = &userAgent.String
 	}
+	if traceID.Valid {
+		log.TraceID = &traceID.String
+	}
 	log.Details = details
 
 	return &log, nil
@@ -150,6 +160,13 @@ func (r *PostgresAuditLogRepository) FindAll(filter domain.AuditLogFilter) ([]do
 	}
 
 	limit, offset := normalizePagination(filter.Limit, filter.Offset)
+	if filter.After != nil {
+		whereClause, args, argIndex, err = appendAuditCursor(whereClause, args, argIndex, filter.After)
+		if err != nil {
+			return nil, 0, err
+		}
+		offset = 0
+	}
 	query := buildAuditLogQuery(whereClause, argIndex)
 
 	args = append(args, limit, offset)
@@ -196,6 +213,16 @@ func buildAuditFilters(filter domain.AuditLogFilter) (string, []interface{}, int
 		args = append(args, *filter.UserID)
 		argIndex++
 	}
+	if filter.TraceID != nil {
+		conditions = append(conditions, fmt.Sprintf("trace_id = $%d", argIndex))
+		args = append(args, *filter.TraceID)
+		argIndex++
+	}
+	if filter.AppID != nil {
+		conditions = append(conditions, fmt.Sprintf("((resource_type = 'app' AND resource_id = $%d) OR details->>'app_id' = $%d)", argIndex, argIndex+1))
+		args = append(args, *filter.AppID, *filter.AppID)
+		argIndex += 2
+	}
 	if filter.StartDate != nil {
 		conditions = append(conditions, fmt.Sprintf("created_at >= $%d", argIndex))
 		args = append(args, *filter.StartDate)
@@ -207,6 +234,12 @@ func buildAuditFilters(filter domain.AuditLogFilter) (string, []interface{}, int
 		argIndex++
 	}
 
+	if filter.Search != "" {
+		conditions = append(conditions, fmt.Sprintf("(resource_name ILIKE $%d OR user_name ILIKE $%d)", argIndex, argIndex))
+		args = append(args, "%"+escapeLike(filter.Search)+"%")
+		argIndex++
+	}
+
 	if len(conditions) == 0 {
 		return "", args, argIndex
 	}
@@ -214,6 +247,21 @@ func buildAuditFilters(filter domain.AuditLogFilter) (string, []interface{}, int
 	return "WHERE " + strings.Join(conditions, " AND "), args, argIndex
 }
 
+// appendAuditCursor restricts the query to logs after the cursor. It is kept
+// out of buildAuditFilters so the total still counts every matching log.
+func appendAuditCursor(whereClause string, args []interface{}, argIndex int, after *domain.Cursor) (string, []interface{}, int, error) {
+	createdAt, err := time.Parse(time.RFC3339Nano, after.Value)
+	if err != nil {
+		return "", nil, 0, fmt.Errorf("%w: invalid cursor", domain.ErrInvalidInput)
+	}
+	condition := fmt.Sprintf("(created_at, id) < ($%d, $%d)", argIndex, argIndex+1)
+	args = append(args, createdAt, after.ID)
+	if whereClause == "" {
+		return "WHERE " + condition, args, argIndex + 2, nil
+	}
+	return whereClause + " AND " + condition, args, argIndex + 2, nil
+}
+
 func (r *PostgresAuditLogRepository) countAuditLogs(whereClause string, args []interface{}) (int, error) {
 	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM audit_logs %s", whereClause)
 	var total int
@@ -239,17 +287,17 @@ func normalizePagination(limit int, offset int) (int, int) {
 
 func buildAuditLogQuery(whereClause string, argIndex int) string {
 	return fmt.Sprintf(`
-		SELECT id, event_type, resource_type, resource_id, resource_name, user_id, user_name, details, ip_address, user_agent, created_at
+		SELECT id, event_type, resource_type, resource_id, resource_name, user_id, user_name, details, ip_address, user_agent, trace_id, created_at
 		FROM audit_logs
 		%s
-		ORDER BY created_at DESC
+		ORDER BY created_at DESC, id DESC
 		LIMIT $%d OFFSET $%d
 	`, whereClause, argIndex, argIndex+1)
 }
 
 func scanAuditLogRow(rows *sql.Rows) (*domain.AuditLog, error) {
 	var log domain.AuditLog
-	var resourceID, resourceName, userID, userName, ipAddress, userAgent sql.NullString
+	var resourceID, resourceName, userID, userName, ipAddress, userAgent, traceID sql.NullString
 	var details []byte
 
 	err := rows.Scan(
@@ -263,6 +311,7 @@ func scanAuditLogRow(rows *sql.Rows) (*domain.AuditLog, error) {
 		&details,
 		&ipAddress,
 		&userAgent,
+		&traceID,
 		&log.CreatedAt,
 	)
 	if err != nil {
@@ -287,6 +336,9 @@ func scanAuditLogRow(rows *sql.Rows) (*domain.AuditLog, error) {
 	if userAgent.Valid {
 		log.UserAgent = &userAgent.String
 	}
+	if traceID.Valid {
+		log.TraceID = &traceID.String
+	}
 	log.Details = details
 
 	return &log, nil
@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+)
+
+type PostgresCertificateExpiryAlertRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresCertificateExpiryAlertRepository(db *sql.DB) *PostgresCertificateExpiryAlertRepository {
+	return &PostgresCertificateExpiryAlertRepository{db: db}
+}
+
+func (r *PostgresCertificateExpiryAlertRepository) Record(ctx context.Context, domainName string, expiresAt time.Time, thresholdDays int) (bool, error) {
+	query := `
+		INSERT INTO certificate_expiry_alerts (domain, expires_at, threshold_days)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (domain, expires_at, threshold_days) DO NOTHING`
+	result, err := r.db.ExecContext(ctx, query, strings.ToLower(domainName), expiresAt.UTC().Truncate(time.Second), thresholdDays)
+	if err != nil {
+		return false, err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rows == 1, nil
+}
+
+func (r *PostgresCertificateExpiryAlertRepository) DeleteExpiredBefore(ctx context.Context, before time.Time) error {
+	query := `DELETE FROM certificate_expiry_alerts WHERE expires_at < $1`
+	_, err := r.db.ExecContext(ctx, query, before)
+	return err
+}
@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/paasdeploy/backend/internal/domain"
+)
+
+const certificateRevocationColumns = `id, server_id, serial_number, reason, revoked_by, revoked_at`
+
+type PostgresCertificateRevocationRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresCertificateRevocationRepository(db *sql.DB) *PostgresCertificateRevocationRepository {
+	return &PostgresCertificateRevocationRepository{db: db}
+}
+
+func (r *PostgresCertificateRevocationRepository) Create(input domain.CreateCertificateRevocationInput) (*domain.CertificateRevocation, error) {
+	query := `
+		INSERT INTO pki_revocations (id, server_id, serial_number, reason, revoked_by)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING ` + certificateRevocationColumns
+
+	row := r.db.QueryRow(
+		query,
+		uuid.New().String(),
+		input.ServerID,
+		toNullStringValue(input.SerialNumber),
+		toNullStringValue(input.Reason),
+		toNullStringValue(input.RevokedBy),
+	)
+
+	revocation, err := scanCertificateRevocation(row)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create certificate revocation: %w", err)
+	}
+	return revocation, nil
+}
+
+func (r *PostgresCertificateRevocationRepository) FindAll() ([]domain.CertificateRevocation, error) {
+	query := `SELECT ` + certificateRevocationColumns + ` FROM pki_revocations ORDER BY revoked_at DESC`
+	return r.queryRevocations(query)
+}
+
+func (r *PostgresCertificateRevocationRepository) FindByServerID(serverID string) ([]domain.CertificateRevocation, error) {
+	query := `SELECT ` + certificateRevocationColumns + ` FROM pki_revocations WHERE server_id = $1 ORDER BY revoked_at DESC`
+	return r.queryRevocations(query, serverID)
+}
+
+func (r *PostgresCertificateRevocationRepository) queryRevocations(query string, args ...any) ([]domain.CertificateRevocation, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query certificate revocations: %w", err)
+	}
+	defer rows.Close()
+
+	var revocations []domain.CertificateRevocation
+	for rows.Next() {
+		revocation, err := scanCertificateRevocation(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan certificate revocation: %w", err)
+		}
+		revocations = append(revocations, *revocation)
+	}
+
+	return revocations, rows.Err()
+}
+
+func scanCertificateRevocation(row rowScanner) (*domain.CertificateRevocation, error) {
+	var revocation domain.CertificateRevocation
+	var serial, reason, revokedBy sql.NullString
+
+	if err := row.Scan(
+		&revocation.ID,
+		&revocation.ServerID,
+		&serial,
+		&reason,
+		&revokedBy,
+		&revocation.RevokedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	if serial.Valid {
+		revocation.SerialNumber = &serial.String
+	}
+	if reason.Valid {
+		revocation.Reason = &reason.String
+	}
+	if revokedBy.Valid {
+		revocation.RevokedBy = &revokedBy.String
+	}
+	return &revocation, nil
+}
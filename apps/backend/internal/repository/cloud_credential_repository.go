@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/paasdeploy/backend/internal/domain"
+)
+
+const cloudCredentialSelectColumns = `id, user_id, provider, credentials_encrypted, created_at, updated_at`
+
+type PostgresCloudCredentialRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresCloudCredentialRepository(db *sql.DB) *PostgresCloudCredentialRepository {
+	return &PostgresCloudCredentialRepository{db: db}
+}
+
+type cloudCredentialScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanCloudCredential(row cloudCredentialScanner) (*domain.CloudCredential, error) {
+	var cred domain.CloudCredential
+	err := row.Scan(&cred.ID, &cred.UserID, &cred.Provider, &cred.CredentialsEncrypted, &cred.CreatedAt, &cred.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &cred, nil
+}
+
+func (r *PostgresCloudCredentialRepository) Upsert(userID, provider, credentialsEncrypted string) (*domain.CloudCredential, error) {
+	query := `INSERT INTO cloud_credentials (user_id, provider, credentials_encrypted)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id, provider) DO UPDATE SET
+			credentials_encrypted = EXCLUDED.credentials_encrypted,
+			updated_at = NOW()
+		RETURNING ` + cloudCredentialSelectColumns
+	return scanCloudCredential(r.db.QueryRow(query, userID, provider, credentialsEncrypted))
+}
+
+func (r *PostgresCloudCredentialRepository) FindByUserAndProvider(userID, provider string) (*domain.CloudCredential, error) {
+	query := `SELECT ` + cloudCredentialSelectColumns + ` FROM cloud_credentials WHERE user_id = $1 AND provider = $2`
+	return scanCloudCredential(r.db.QueryRow(query, userID, provider))
+}
+
+func (r *PostgresCloudCredentialRepository) ListByUserID(userID string) ([]domain.CloudCredential, error) {
+	query := `SELECT ` + cloudCredentialSelectColumns + ` FROM cloud_credentials WHERE user_id = $1 ORDER BY provider`
+	rows, err := r.db.Query(query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var creds []domain.CloudCredential
+	for rows.Next() {
+		cred, err := scanCloudCredential(rows)
+		if err != nil {
+			return nil, err
+		}
+		creds = append(creds, *cred)
+	}
+	return creds, rows.Err()
+}
+
+func (r *PostgresCloudCredentialRepository) Delete(userID, provider string) error {
+	query := `DELETE FROM cloud_credentials WHERE user_id = $1 AND provider = $2`
+	result, err := r.db.Exec(query, userID, provider)
+	if err != nil {
+		return err
+	}
+	affected, _ := result.RowsAffected()
+	if affected == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+var _ domain.CloudCredentialRepository = (*PostgresCloudCredentialRepository)(nil)
@@ -4,12 +4,13 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"time"
 
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/paasdeploy/backend/internal/domain"
 )
 
-const customDomainSelectColumns = `id, app_id, domain, path_prefix, zone_id, dns_record_id, record_type, status, created_at, updated_at`
+const customDomainSelectColumns = `id, app_id, domain, path_prefix, zone_id, dns_record_id, record_type, dns_provider, redirect_to, status, basic_auth_users, ip_allowlist, tls_certificate, tls_private_key, tls_expires_at, created_at, updated_at`
 
 type PostgresCustomDomainRepository struct {
 	db *sql.DB
@@ -29,7 +30,14 @@ func (r *PostgresCustomDomainRepository) scanDomain(row *sql.Row) (*domain.Custo
 		&d.ZoneID,
 		&d.DNSRecordID,
 		&d.RecordType,
+		&d.DNSProvider,
+		&d.RedirectTo,
 		&d.Status,
+		&d.BasicAuthUsers,
+		&d.IPAllowlist,
+		&d.TLSCertificate,
+		&d.TLSPrivateKey,
+		&d.TLSExpiresAt,
 		&d.CreatedAt,
 		&d.UpdatedAt,
 	)
@@ -54,7 +62,14 @@ func (r *PostgresCustomDomainRepository) scanDomains(rows *sql.Rows) ([]domain.C
 			&d.ZoneID,
 			&d.DNSRecordID,
 			&d.RecordType,
+			&d.DNSProvider,
+			&d.RedirectTo,
 			&d.Status,
+			&d.BasicAuthUsers,
+			&d.IPAllowlist,
+			&d.TLSCertificate,
+			&d.TLSPrivateKey,
+			&d.TLSExpiresAt,
 			&d.CreatedAt,
 			&d.UpdatedAt,
 		)
@@ -68,10 +83,15 @@ func (r *PostgresCustomDomainRepository) scanDomains(rows *sql.Rows) ([]domain.C
 
 func (r *PostgresCustomDomainRepository) Create(ctx context.Context, input domain.CreateCustomDomainInput) (*domain.CustomDomain, error) {
 	query := `
-		INSERT INTO custom_domains (app_id, domain, path_prefix, zone_id, dns_record_id, record_type)
-		VALUES ($1, $2, $3, $4, $5, $6)
+		INSERT INTO custom_domains (app_id, domain, path_prefix, zone_id, dns_record_id, record_type, dns_provider, redirect_to)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 		RETURNING ` + customDomainSelectColumns
 
+	dnsProvider := input.DNSProvider
+	if dnsProvider == "" {
+		dnsProvider = domain.DNSProviderCloudflare
+	}
+
 	customDomain, err := r.scanDomain(r.db.QueryRowContext(ctx, query,
 		input.AppID,
 		input.Domain,
@@ -79,6 +99,8 @@ func (r *PostgresCustomDomainRepository) Create(ctx context.Context, input domai
 		input.ZoneID,
 		input.DNSRecordID,
 		input.RecordType,
+		dnsProvider,
+		input.RedirectTo,
 	))
 	if err != nil {
 		var pgErr *pgconn.PgError
@@ -105,6 +127,34 @@ func (r *PostgresCustomDomainRepository) FindByAppID(ctx context.Context, appID
 	return r.scanDomains(rows)
 }
 
+func (r *PostgresCustomDomainRepository) FindByAppIDs(ctx context.Context, appIDs []string) ([]domain.CustomDomain, error) {
+	if len(appIDs) == 0 {
+		return nil, nil
+	}
+	query := `SELECT ` + customDomainSelectColumns + ` FROM custom_domains WHERE app_id = ANY($1) ORDER BY app_id, created_at DESC`
+	rows, err := r.db.QueryContext(ctx, query, appIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return r.scanDomains(rows)
+}
+
+func (r *PostgresCustomDomainRepository) SearchByUserID(ctx context.Context, userID, query string, limit int) ([]domain.CustomDomain, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+customDomainSelectColumns+`
+		FROM custom_domains
+		WHERE app_id IN (SELECT id FROM apps WHERE user_id = $1 AND status != 'deleted')
+			AND domain ILIKE $2
+		ORDER BY domain, path_prefix
+		LIMIT $3`, userID, "%"+escapeLike(query)+"%", limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return r.scanDomains(rows)
+}
+
 func (r *PostgresCustomDomainRepository) FindByDomain(ctx context.Context, domainName string) (*domain.CustomDomain, error) {
 	query := `SELECT ` + customDomainSelectColumns + ` FROM custom_domains WHERE domain = $1`
 	return r.scanDomain(r.db.QueryRowContext(ctx, query, domainName))
@@ -115,6 +165,44 @@ func (r *PostgresCustomDomainRepository) FindByDomainAndPath(ctx context.Context
 	return r.scanDomain(r.db.QueryRowContext(ctx, query, domainName, pathPrefix))
 }
 
+func (r *PostgresCustomDomainRepository) UpdateBasicAuth(ctx context.Context, id, users string) (*domain.CustomDomain, error) {
+	query := `UPDATE custom_domains SET basic_auth_users = $2, updated_at = NOW() WHERE id = $1 RETURNING ` + customDomainSelectColumns
+	return r.scanDomain(r.db.QueryRowContext(ctx, query, id, users))
+}
+
+func (r *PostgresCustomDomainRepository) UpdateIPAllowlist(ctx context.Context, id, allowlist string) (*domain.CustomDomain, error) {
+	query := `UPDATE custom_domains SET ip_allowlist = $2, updated_at = NOW() WHERE id = $1 RETURNING ` + customDomainSelectColumns
+	return r.scanDomain(r.db.QueryRowContext(ctx, query, id, allowlist))
+}
+
+func (r *PostgresCustomDomainRepository) UpdateDNSRecord(ctx context.Context, id, recordType, recordID string) (*domain.CustomDomain, error) {
+	query := `UPDATE custom_domains SET record_type = $2, dns_record_id = $3, updated_at = NOW() WHERE id = $1 RETURNING ` + customDomainSelectColumns
+	return r.scanDomain(r.db.QueryRowContext(ctx, query, id, recordType, recordID))
+}
+
+func (r *PostgresCustomDomainRepository) UpdateCertificate(ctx context.Context, id, certificate, encryptedKey string, expiresAt *time.Time) (*domain.CustomDomain, error) {
+	query := `
+		UPDATE custom_domains
+		SET tls_certificate = $2, tls_private_key = $3, tls_expires_at = $4, updated_at = NOW()
+		WHERE id = $1
+		RETURNING ` + customDomainSelectColumns
+	return r.scanDomain(r.db.QueryRowContext(ctx, query, id, certificate, encryptedKey, expiresAt))
+}
+
+func (r *PostgresCustomDomainRepository) FindCertificatesExpiringBefore(ctx context.Context, before time.Time) ([]domain.CustomDomain, error) {
+	query := `
+		SELECT ` + customDomainSelectColumns + `
+		FROM custom_domains
+		WHERE tls_expires_at IS NOT NULL AND tls_expires_at < $1
+		ORDER BY tls_expires_at`
+	rows, err := r.db.QueryContext(ctx, query, before)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return r.scanDomains(rows)
+}
+
 func (r *PostgresCustomDomainRepository) Delete(ctx context.Context, id string) error {
 	query := `DELETE FROM custom_domains WHERE id = $1`
 	result, err := r.db.ExecContext(ctx, query, id)
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"time"
 
@@ -9,7 +10,8 @@ import (
 )
 
 const deploymentSelectColumns = `id, app_id, commit_sha, commit_message, status, started_at, finished_at,
-       error_message, logs, previous_image_tag, current_image_tag, app_version, created_at`
+       error_message, logs, previous_image_tag, current_image_tag, app_version, trace_id,
+       archived_log_chunks, archived_log_bytes, created_at`
 
 type PostgresDeploymentRepository struct {
 	db *sql.DB
@@ -29,13 +31,15 @@ type deploymentScanTargets struct {
 	previousImageTag sql.NullString
 	currentImageTag  sql.NullString
 	appVersion       sql.NullString
+	traceID          sql.NullString
 }
 
 func (t *deploymentScanTargets) scanArgs() []interface{} {
 	return []interface{}{
 		&t.d.ID, &t.d.AppID, &t.d.CommitSHA, &t.commitMessage, &t.d.Status,
 		&t.startedAt, &t.finishedAt, &t.errorMessage, &t.logs,
-		&t.previousImageTag, &t.currentImageTag, &t.appVersion, &t.d.CreatedAt,
+		&t.previousImageTag, &t.currentImageTag, &t.appVersion, &t.traceID,
+		&t.d.ArchivedLogChunks, &t.d.ArchivedLogBytes, &t.d.CreatedAt,
 	}
 }
 
@@ -52,6 +56,7 @@ func (t *deploymentScanTargets) toDeployment() domain.Deployment {
 	t.d.PreviousImageTag = t.previousImageTag.String
 	t.d.CurrentImageTag = t.currentImageTag.String
 	t.d.AppVersion = t.appVersion.String
+	t.d.TraceID = t.traceID.String
 	return t.d
 }
 
@@ -84,6 +89,24 @@ func (r *PostgresDeploymentRepository) FindByID(id string) (*domain.Deployment,
 	return scanDeploymentRow(r.db.QueryRow(query, id))
 }
 
+func (r *PostgresDeploymentRepository) FindSnapshot(id string) (*domain.DeploymentSnapshot, error) {
+	var data []byte
+	if err := r.db.QueryRow(`SELECT snapshot FROM deployments WHERE id = $1`, id).Scan(&data); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+	var snapshot domain.DeploymentSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, err
+	}
+	return &snapshot, nil
+}
+
 func (r *PostgresDeploymentRepository) FindByAppID(appID string, limit int) ([]domain.Deployment, error) {
 	query := `SELECT ` + deploymentSelectColumns + `
 		FROM deployments WHERE app_id = $1 ORDER BY created_at DESC LIMIT $2`
@@ -97,6 +120,86 @@ func (r *PostgresDeploymentRepository) FindByAppID(appID string, limit int) ([]d
 	return scanDeploymentRows(rows)
 }
 
+var deploymentSortColumns = map[string]sortColumn{
+	"createdAt": {column: "created_at", time: true},
+}
+
+func (r *PostgresDeploymentRepository) FindPageByAppID(appID string, filter domain.DeploymentFilter, opts domain.ListOptions) (domain.Page[domain.Deployment], error) {
+	var q listQuery
+	q.where("app_id = " + q.arg(appID))
+	if filter.Status != "" {
+		q.where("status = " + q.arg(filter.Status))
+	}
+	q.search(opts.Query, "commit_sha", "commit_message")
+
+	where, suffix, err := q.page(opts, deploymentSortColumns, "created_at", "id")
+	if err != nil {
+		return domain.Page[domain.Deployment]{}, err
+	}
+
+	rows, err := r.db.Query(`SELECT `+deploymentSelectColumns+` FROM deployments`+where+suffix, q.args...)
+	if err != nil {
+		return domain.Page[domain.Deployment]{}, err
+	}
+	defer rows.Close()
+
+	deployments, err := scanDeploymentRows(rows)
+	if err != nil {
+		return domain.Page[domain.Deployment]{}, err
+	}
+	return toPage(deployments, opts, func(d *domain.Deployment) (string, string) {
+		return domain.CursorTime(d.CreatedAt), d.ID
+	}), nil
+}
+
+func (r *PostgresDeploymentRepository) SearchByUserID(userID, query string, limit int) ([]domain.Deployment, error) {
+	rows, err := r.db.Query(`
+		SELECT `+deploymentSelectColumns+`
+		FROM deployments
+		WHERE app_id IN (SELECT id FROM apps WHERE user_id = $1 AND status != 'deleted')
+			AND (commit_sha ILIKE $2 OR commit_message ILIKE $2)
+		ORDER BY created_at DESC
+		LIMIT $3`, userID, "%"+escapeLike(query)+"%", limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanDeploymentRows(rows)
+}
+
+func (r *PostgresDeploymentRepository) SumBuildSecondsByUserID(userID string, since time.Time) (int64, error) {
+	var seconds int64
+	err := r.db.QueryRow(`
+		SELECT COALESCE(SUM(EXTRACT(EPOCH FROM COALESCE(finished_at, NOW()) - started_at)), 0)::bigint
+		FROM deployments
+		WHERE app_id IN (SELECT id FROM apps WHERE user_id = $1)
+			AND started_at >= $2`, userID, since).Scan(&seconds)
+	return seconds, err
+}
+
+func (r *PostgresDeploymentRepository) SumBuildSecondsByApp(since, until time.Time) (map[string]float64, error) {
+	rows, err := r.db.Query(`
+		SELECT app_id, SUM(EXTRACT(EPOCH FROM finished_at - started_at))::float8
+		FROM deployments
+		WHERE started_at IS NOT NULL AND finished_at >= $1 AND finished_at < $2
+		GROUP BY app_id`, since, until)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	seconds := make(map[string]float64)
+	for rows.Next() {
+		var appID string
+		var total float64
+		if err := rows.Scan(&appID, &total); err != nil {
+			return nil, err
+		}
+		seconds[appID] = total
+	}
+	return seconds, rows.Err()
+}
+
 func (r *PostgresDeploymentRepository) FindPendingByAppID(appID string) (*domain.Deployment, error) {
 	query := `SELECT ` + deploymentSelectColumns + `
 		FROM deployments WHERE app_id = $1 AND status = 'pending'
@@ -147,17 +250,20 @@ func (r *PostgresDeploymentRepository) FindMostRecentByAppIDs(appIDs []string) (
 }
 
 func (r *PostgresDeploymentRepository) Create(input domain.CreateDeploymentInput) (*domain.Deployment, error) {
-	var deliveryID *string
+	var deliveryID, traceID *string
 	if input.DeliveryID != "" {
 		deliveryID = &input.DeliveryID
 	}
+	if input.TraceID != "" {
+		traceID = &input.TraceID
+	}
 
-	query := `INSERT INTO deployments (app_id, commit_sha, commit_message, status, delivery_id, created_at)
-		VALUES ($1, $2, $3, 'pending', $4, NOW())
+	query := `INSERT INTO deployments (app_id, commit_sha, commit_message, status, delivery_id, trace_id, created_at)
+		VALUES ($1, $2, $3, 'pending', $4, $5, NOW())
 		ON CONFLICT (app_id, commit_sha) WHERE status IN ('pending', 'running') DO NOTHING
 		RETURNING ` + deploymentSelectColumns
 
-	row := r.db.QueryRow(query, input.AppID, input.CommitSHA, input.CommitMessage, deliveryID)
+	row := r.db.QueryRow(query, input.AppID, input.CommitSHA, input.CommitMessage, deliveryID, traceID)
 	d, err := scanDeploymentRowNullable(row)
 	if err != nil {
 		return nil, err
@@ -215,6 +321,21 @@ func (r *PostgresDeploymentRepository) AppendLogs(id string, logs string) error
 	return err
 }
 
+func (r *PostgresDeploymentRepository) TrimArchivedLogs(id string, chunk, headChars int, headBytes int64) (bool, error) {
+	query := `UPDATE deployments
+		SET logs = substr(logs, $4 + 1), archived_log_chunks = $2, archived_log_bytes = archived_log_bytes + $5
+		WHERE id = $1 AND archived_log_chunks = $3 AND length(logs) >= $4`
+	result, err := r.db.Exec(query, id, chunk, chunk-1, headChars, headBytes)
+	if err != nil {
+		return false, err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rows == 1, nil
+}
+
 func (r *PostgresDeploymentRepository) GetNextPending() (*domain.Deployment, error) {
 	query := `SELECT ` + deploymentSelectColumns + `
 		FROM deployments
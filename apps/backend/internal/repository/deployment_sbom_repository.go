@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/paasdeploy/backend/internal/domain"
+)
+
+type PostgresDeploymentSBOMRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresDeploymentSBOMRepository(db *sql.DB) *PostgresDeploymentSBOMRepository {
+	return &PostgresDeploymentSBOMRepository{db: db}
+}
+
+func (r *PostgresDeploymentSBOMRepository) Save(sbom domain.DeploymentSBOM) error {
+	_, err := r.db.Exec(`
+		INSERT INTO deployment_sboms (deployment_id, format, document, package_count)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (deployment_id) DO UPDATE
+		SET format = EXCLUDED.format, document = EXCLUDED.document,
+		    package_count = EXCLUDED.package_count, created_at = NOW()
+	`, sbom.DeploymentID, sbom.Format, sbom.Document, sbom.PackageCount)
+	if err != nil {
+		return fmt.Errorf("failed to save deployment sbom: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresDeploymentSBOMRepository) FindByDeploymentID(deploymentID string) (*domain.DeploymentSBOM, error) {
+	return r.scan(r.db.QueryRow(`
+		SELECT deployment_id, format, document, package_count, created_at
+		FROM deployment_sboms
+		WHERE deployment_id = $1
+	`, deploymentID))
+}
+
+func (r *PostgresDeploymentSBOMRepository) FindPrevious(appID, deploymentID string) (*domain.DeploymentSBOM, error) {
+	return r.scan(r.db.QueryRow(`
+		SELECT s.deployment_id, s.format, s.document, s.package_count, s.created_at
+		FROM deployment_sboms s
+		JOIN deployments d ON d.id = s.deployment_id
+		WHERE d.app_id = $1 AND d.status = 'success' AND d.id != $2
+		  AND d.created_at < (SELECT created_at FROM deployments WHERE id = $2)
+		ORDER BY d.created_at DESC
+		LIMIT 1
+	`, appID, deploymentID))
+}
+
+func (r *PostgresDeploymentSBOMRepository) scan(row *sql.Row) (*domain.DeploymentSBOM, error) {
+	var sbom domain.DeploymentSBOM
+	err := row.Scan(&sbom.DeploymentID, &sbom.Format, &sbom.Document, &sbom.PackageCount, &sbom.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find deployment sbom: %w", err)
+	}
+	return &sbom, nil
+}
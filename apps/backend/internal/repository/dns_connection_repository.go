@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/paasdeploy/backend/internal/domain"
+)
+
+const dnsConnectionSelectColumns = `id, user_id, provider, credentials_encrypted, created_at, updated_at`
+
+type PostgresDNSConnectionRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresDNSConnectionRepository(db *sql.DB) *PostgresDNSConnectionRepository {
+	return &PostgresDNSConnectionRepository{db: db}
+}
+
+type dnsConnectionScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanDNSConnection(row dnsConnectionScanner) (*domain.DNSConnection, error) {
+	var conn domain.DNSConnection
+	err := row.Scan(&conn.ID, &conn.UserID, &conn.Provider, &conn.CredentialsEncrypted, &conn.CreatedAt, &conn.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &conn, nil
+}
+
+func (r *PostgresDNSConnectionRepository) Upsert(userID, provider, credentialsEncrypted string) (*domain.DNSConnection, error) {
+	query := `INSERT INTO dns_connections (user_id, provider, credentials_encrypted)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id, provider) DO UPDATE SET
+			credentials_encrypted = EXCLUDED.credentials_encrypted,
+			updated_at = NOW()
+		RETURNING ` + dnsConnectionSelectColumns
+	return scanDNSConnection(r.db.QueryRow(query, userID, provider, credentialsEncrypted))
+}
+
+func (r *PostgresDNSConnectionRepository) FindByUserAndProvider(userID, provider string) (*domain.DNSConnection, error) {
+	query := `SELECT ` + dnsConnectionSelectColumns + ` FROM dns_connections WHERE user_id = $1 AND provider = $2`
+	return scanDNSConnection(r.db.QueryRow(query, userID, provider))
+}
+
+func (r *PostgresDNSConnectionRepository) ListByUserID(userID string) ([]domain.DNSConnection, error) {
+	query := `SELECT ` + dnsConnectionSelectColumns + ` FROM dns_connections WHERE user_id = $1 ORDER BY provider`
+	rows, err := r.db.Query(query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var conns []domain.DNSConnection
+	for rows.Next() {
+		conn, err := scanDNSConnection(rows)
+		if err != nil {
+			return nil, err
+		}
+		conns = append(conns, *conn)
+	}
+	return conns, rows.Err()
+}
+
+func (r *PostgresDNSConnectionRepository) Delete(userID, provider string) error {
+	query := `DELETE FROM dns_connections WHERE user_id = $1 AND provider = $2`
+	result, err := r.db.Exec(query, userID, provider)
+	if err != nil {
+		return err
+	}
+	affected, _ := result.RowsAffected()
+	if affected == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+var _ domain.DNSConnectionRepository = (*PostgresDNSConnectionRepository)(nil)
@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/paasdeploy/backend/internal/domain"
+)
+
+const domainVerificationSelectColumns = `id, user_id, domain, token, verified_at, created_at, updated_at`
+
+type PostgresDomainVerificationRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresDomainVerificationRepository(db *sql.DB) *PostgresDomainVerificationRepository {
+	return &PostgresDomainVerificationRepository{db: db}
+}
+
+type domainVerificationScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanDomainVerification(row domainVerificationScanner) (*domain.DomainVerification, error) {
+	var v domain.DomainVerification
+	err := row.Scan(&v.ID, &v.UserID, &v.Domain, &v.Token, &v.VerifiedAt, &v.CreatedAt, &v.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+func (r *PostgresDomainVerificationRepository) Create(ctx context.Context, userID, domainName, token string) (*domain.DomainVerification, error) {
+	query := `INSERT INTO domain_verifications (user_id, domain, token)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id, domain) DO UPDATE SET updated_at = NOW()
+		RETURNING ` + domainVerificationSelectColumns
+	return scanDomainVerification(r.db.QueryRowContext(ctx, query, userID, domainName, token))
+}
+
+func (r *PostgresDomainVerificationRepository) FindByID(ctx context.Context, id string) (*domain.DomainVerification, error) {
+	query := `SELECT ` + domainVerificationSelectColumns + ` FROM domain_verifications WHERE id = $1`
+	return scanDomainVerification(r.db.QueryRowContext(ctx, query, id))
+}
+
+func (r *PostgresDomainVerificationRepository) ListByUserID(ctx context.Context, userID string) ([]domain.DomainVerification, error) {
+	query := `SELECT ` + domainVerificationSelectColumns + ` FROM domain_verifications WHERE user_id = $1 ORDER BY domain`
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var verifications []domain.DomainVerification
+	for rows.Next() {
+		v, err := scanDomainVerification(rows)
+		if err != nil {
+			return nil, err
+		}
+		verifications = append(verifications, *v)
+	}
+	return verifications, rows.Err()
+}
+
+func (r *PostgresDomainVerificationRepository) MarkVerified(ctx context.Context, id string) (*domain.DomainVerification, error) {
+	query := `UPDATE domain_verifications SET verified_at = NOW(), updated_at = NOW()
+		WHERE id = $1
+		RETURNING ` + domainVerificationSelectColumns
+	return scanDomainVerification(r.db.QueryRowContext(ctx, query, id))
+}
+
+func (r *PostgresDomainVerificationRepository) Delete(ctx context.Context, id string) error {
+	query := `DELETE FROM domain_verifications WHERE id = $1`
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+	affected, _ := result.RowsAffected()
+	if affected == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+var _ domain.DomainVerificationRepository = (*PostgresDomainVerificationRepository)(nil)
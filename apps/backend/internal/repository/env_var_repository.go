@@ -28,6 +28,39 @@ func (r *PostgresEnvVarRepository) FindByAppID(appID string) ([]domain.EnvVar, e
 	}
 	defer rows.Close()
 
+	return scanEnvVars(rows)
+}
+
+func (r *PostgresEnvVarRepository) FindByAppIDs(appIDs []string) (map[string][]domain.EnvVar, error) {
+	result := make(map[string][]domain.EnvVar)
+	if len(appIDs) == 0 {
+		return result, nil
+	}
+
+	query := `
+		SELECT id, app_id, key, value, is_secret, created_at, updated_at
+		FROM app_env_vars
+		WHERE app_id = ANY($1)
+		ORDER BY app_id, key ASC
+	`
+
+	rows, err := r.db.Query(query, appIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	vars, err := scanEnvVars(rows)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range vars {
+		result[v.AppID] = append(result[v.AppID], v)
+	}
+	return result, nil
+}
+
+func scanEnvVars(rows *sql.Rows) ([]domain.EnvVar, error) {
 	var vars []domain.EnvVar
 	for rows.Next() {
 		var v domain.EnvVar
@@ -0,0 +1,174 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/paasdeploy/backend/internal/domain"
+)
+
+const execSessionColumns = `id, user_id, user_name, server_id, container_id, shell, ip_address,
+	started_at, ended_at, exit_code, output_bytes, truncated`
+
+type PostgresExecSessionRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresExecSessionRepository(db *sql.DB) *PostgresExecSessionRepository {
+	return &PostgresExecSessionRepository{db: db}
+}
+
+func (r *PostgresExecSessionRepository) Create(input domain.CreateExecSessionInput) (*domain.ExecSession, error) {
+	query := `
+		INSERT INTO exec_sessions (user_id, user_name, server_id, container_id, shell, ip_address)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING ` + execSessionColumns
+
+	session, err := scanExecSession(r.db.QueryRow(query,
+		toNullStringValue(input.UserID),
+		input.UserName,
+		toNullStringValue(input.ServerID),
+		input.ContainerID,
+		input.Shell,
+		toNullStringValue(input.IPAddress),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create exec session: %w", err)
+	}
+	return session, nil
+}
+
+func (r *PostgresExecSessionRepository) Finish(id string, input domain.FinishExecSessionInput) error {
+	var exitCode sql.NullInt32
+	if input.ExitCode != nil {
+		exitCode = sql.NullInt32{Int32: int32(*input.ExitCode), Valid: true}
+	}
+
+	query := `
+		UPDATE exec_sessions
+		SET ended_at = NOW(), exit_code = $2, output_bytes = $3, truncated = $4, recording = $5
+		WHERE id = $1
+	`
+	if _, err := r.db.Exec(query, id, exitCode, input.OutputBytes, input.Truncated, input.Recording); err != nil {
+		return fmt.Errorf("failed to finish exec session: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresExecSessionRepository) FindByID(id string) (*domain.ExecSession, error) {
+	query := `SELECT ` + execSessionColumns + ` FROM exec_sessions WHERE id = $1`
+	session, err := scanExecSession(r.db.QueryRow(query, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find exec session: %w", err)
+	}
+	return session, nil
+}
+
+func (r *PostgresExecSessionRepository) FindRecording(id string) ([]byte, error) {
+	var recording []byte
+	err := r.db.QueryRow(`SELECT recording FROM exec_sessions WHERE id = $1`, id).Scan(&recording)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find exec session recording: %w", err)
+	}
+	return recording, nil
+}
+
+func (r *PostgresExecSessionRepository) List(filter domain.ExecSessionFilter) ([]domain.ExecSession, int, error) {
+	var conditions []string
+	var args []any
+	addCondition := func(column, value string) {
+		if value == "" {
+			return
+		}
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf("%s = $%d", column, len(args)))
+	}
+	addCondition("user_id", filter.UserID)
+	addCondition("server_id", filter.ServerID)
+	addCondition("container_id", filter.ContainerID)
+
+	where := ""
+	if len(conditions) > 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total int
+	if err := r.db.QueryRow(`SELECT COUNT(*) FROM exec_sessions`+where, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count exec sessions: %w", err)
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = 50
+	}
+	args = append(args, limit, filter.Offset)
+	query := fmt.Sprintf(`SELECT %s FROM exec_sessions%s ORDER BY started_at DESC LIMIT $%d OFFSET $%d`,
+		execSessionColumns, where, len(args)-1, len(args))
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query exec sessions: %w", err)
+	}
+	defer rows.Close()
+
+	sessions := []domain.ExecSession{}
+	for rows.Next() {
+		session, err := scanExecSession(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan exec session: %w", err)
+		}
+		sessions = append(sessions, *session)
+	}
+	return sessions, total, rows.Err()
+}
+
+func (r *PostgresExecSessionRepository) DeleteOlderThan(before time.Time) (int64, error) {
+	result, err := r.db.Exec(`DELETE FROM exec_sessions WHERE started_at < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune exec sessions: %w", err)
+	}
+	return result.RowsAffected()
+}
+
+func scanExecSession(row rowScanner) (*domain.ExecSession, error) {
+	var s domain.ExecSession
+	var userID, serverID, ipAddress sql.NullString
+	var endedAt sql.NullTime
+	var exitCode sql.NullInt32
+
+	if err := row.Scan(
+		&s.ID,
+		&userID,
+		&s.UserName,
+		&serverID,
+		&s.ContainerID,
+		&s.Shell,
+		&ipAddress,
+		&s.StartedAt,
+		&endedAt,
+		&exitCode,
+		&s.OutputBytes,
+		&s.Truncated,
+	); err != nil {
+		return nil, err
+	}
+
+	s.UserID = fromNullString(userID)
+	s.ServerID = fromNullString(serverID)
+	s.IPAddress = fromNullString(ipAddress)
+	s.EndedAt = fromNullTime(endedAt)
+	if exitCode.Valid {
+		code := int(exitCode.Int32)
+		s.ExitCode = &code
+	}
+	return &s, nil
+}
@@ -0,0 +1,175 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/paasdeploy/backend/internal/domain"
+)
+
+const gitOpsSourceSelectColumns = `id, user_id, repository_url, branch, path, webhook_id, status, last_commit_sha, last_error, apps, last_synced_at, last_checked_at, created_at, updated_at`
+
+type PostgresGitOpsSourceRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresGitOpsSourceRepository(db *sql.DB) *PostgresGitOpsSourceRepository {
+	return &PostgresGitOpsSourceRepository{db: db}
+}
+
+func scanGitOpsSource(row rowScanner) (*domain.GitOpsSource, error) {
+	var source domain.GitOpsSource
+	var webhookID sql.NullInt64
+	var lastCommitSHA, lastError sql.NullString
+	var apps []byte
+	var lastSyncedAt, lastCheckedAt sql.NullTime
+	err := row.Scan(
+		&source.ID,
+		&source.UserID,
+		&source.RepositoryURL,
+		&source.Branch,
+		&source.Path,
+		&webhookID,
+		&source.Status,
+		&lastCommitSHA,
+		&lastError,
+		&apps,
+		&lastSyncedAt,
+		&lastCheckedAt,
+		&source.CreatedAt,
+		&source.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if webhookID.Valid {
+		source.WebhookID = &webhookID.Int64
+	}
+	source.LastCommitSHA = fromNullString(lastCommitSHA)
+	source.LastError = fromNullString(lastError)
+	source.LastSyncedAt = fromNullTime(lastSyncedAt)
+	source.LastCheckedAt = fromNullTime(lastCheckedAt)
+	source.Apps = []domain.GitOpsAppStatus{}
+	if len(apps) > 0 {
+		if err := json.Unmarshal(apps, &source.Apps); err != nil {
+			return nil, err
+		}
+	}
+	return &source, nil
+}
+
+func (r *PostgresGitOpsSourceRepository) Create(ctx context.Context, input domain.CreateGitOpsSourceInput) (*domain.GitOpsSource, error) {
+	query := `
+		INSERT INTO gitops_sources (user_id, repository_url, branch, path)
+		VALUES ($1, $2, $3, $4)
+		RETURNING ` + gitOpsSourceSelectColumns
+
+	source, err := scanGitOpsSource(r.db.QueryRowContext(ctx, query,
+		input.UserID,
+		input.RepositoryURL,
+		input.Branch,
+		input.Path,
+	))
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return nil, domain.ErrAlreadyExists
+		}
+		return nil, err
+	}
+	return source, nil
+}
+
+func (r *PostgresGitOpsSourceRepository) FindByID(ctx context.Context, id string) (*domain.GitOpsSource, error) {
+	query := `SELECT ` + gitOpsSourceSelectColumns + ` FROM gitops_sources WHERE id = $1`
+	source, err := scanGitOpsSource(r.db.QueryRowContext(ctx, query, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrNotFound
+	}
+	return source, err
+}
+
+func (r *PostgresGitOpsSourceRepository) FindByUserID(ctx context.Context, userID string) ([]domain.GitOpsSource, error) {
+	query := `SELECT ` + gitOpsSourceSelectColumns + ` FROM gitops_sources WHERE user_id = $1 ORDER BY created_at`
+	return r.query(ctx, query, userID)
+}
+
+func (r *PostgresGitOpsSourceRepository) FindAll(ctx context.Context) ([]domain.GitOpsSource, error) {
+	query := `SELECT ` + gitOpsSourceSelectColumns + ` FROM gitops_sources ORDER BY created_at`
+	return r.query(ctx, query)
+}
+
+func (r *PostgresGitOpsSourceRepository) query(ctx context.Context, query string, args ...any) ([]domain.GitOpsSource, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sources := []domain.GitOpsSource{}
+	for rows.Next() {
+		source, err := scanGitOpsSource(rows)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, *source)
+	}
+	return sources, rows.Err()
+}
+
+func (r *PostgresGitOpsSourceRepository) UpdateStatus(ctx context.Context, id string, status domain.GitOpsStatus) error {
+	query := `UPDATE gitops_sources SET status = $2, updated_at = NOW() WHERE id = $1`
+	return r.exec(ctx, query, id, status)
+}
+
+func (r *PostgresGitOpsSourceRepository) UpdateWebhookID(ctx context.Context, id string, webhookID *int64) error {
+	var value sql.NullInt64
+	if webhookID != nil {
+		value = sql.NullInt64{Int64: *webhookID, Valid: true}
+	}
+	query := `UPDATE gitops_sources SET webhook_id = $2, updated_at = NOW() WHERE id = $1`
+	return r.exec(ctx, query, id, value)
+}
+
+func (r *PostgresGitOpsSourceRepository) SaveSyncResult(ctx context.Context, id string, result domain.GitOpsSyncResult) error {
+	apps := result.Apps
+	if apps == nil {
+		apps = []domain.GitOpsAppStatus{}
+	}
+	appsJSON, err := json.Marshal(apps)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE gitops_sources
+		SET status = $2, last_commit_sha = COALESCE($3, last_commit_sha), last_error = $4, apps = $5,
+			last_synced_at = CASE WHEN $6 THEN NOW() ELSE last_synced_at END,
+			last_checked_at = NOW(), updated_at = NOW()
+		WHERE id = $1`
+	return r.exec(ctx, query, id, result.Status, toNullStringValue(result.CommitSHA), toNullStringValue(result.Error), appsJSON, result.Applied)
+}
+
+func (r *PostgresGitOpsSourceRepository) Delete(ctx context.Context, id string) error {
+	return r.exec(ctx, `DELETE FROM gitops_sources WHERE id = $1`, id)
+}
+
+func (r *PostgresGitOpsSourceRepository) exec(ctx context.Context, query string, args ...any) error {
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+var _ domain.GitOpsSourceRepository = (*PostgresGitOpsSourceRepository)(nil)
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"database/sql"
+	"io"
+	"log/slog"
+	"math/rand/v2"
+	"os"
+	"testing"
+
+	"github.com/google/uuid"
+	_ "github.com/jackc/pgx/v5/stdlib"
+
+	"github.com/paasdeploy/backend/internal/database"
+)
+
+// The repository tests run only when TEST_DATABASE_URL points at a
+// disposable Postgres database:
+//
+//	TEST_DATABASE_URL=postgres://... go test ./internal/repository
+
+func openTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	url := os.Getenv("TEST_DATABASE_URL")
+	if url == "" {
+		t.Skip("TEST_DATABASE_URL not set")
+	}
+
+	db, err := sql.Open("pgx", url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	if err := database.RunMigrations(db, "../../migrations", logger); err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+// createTestUser inserts a user that is deleted, with everything it owns,
+// when the test ends.
+func createTestUser(t *testing.T, db *sql.DB) string {
+	t.Helper()
+
+	var userID string
+	err := db.QueryRow(`INSERT INTO users (github_id, github_login, access_token_encrypted)
+		VALUES ($1, $2, '') RETURNING id`, 1<<40+rand.Int64N(1<<40), "test-"+uuid.NewString()[:8]).Scan(&userID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _, _ = db.Exec(`DELETE FROM users WHERE id = $1`, userID) })
+	return userID
+}
@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/paasdeploy/backend/internal/domain"
+)
+
+// sortColumn is a column a list can be ordered by. Cursor values of time
+// columns are parsed back into times.
+type sortColumn struct {
+	column string
+	time   bool
+}
+
+// listQuery collects the WHERE conditions and arguments of a list query.
+type listQuery struct {
+	conditions []string
+	args       []interface{}
+}
+
+// arg adds an argument and returns its placeholder.
+func (q *listQuery) arg(value interface{}) string {
+	q.args = append(q.args, value)
+	return fmt.Sprintf("$%d", len(q.args))
+}
+
+func (q *listQuery) where(condition string) {
+	q.conditions = append(q.conditions, condition)
+}
+
+// search matches the free-text query against columns, case-insensitively.
+func (q *listQuery) search(text string, columns ...string) {
+	if text == "" {
+		return
+	}
+	placeholder := q.arg("%" + escapeLike(text) + "%")
+	matches := make([]string, len(columns))
+	for i, column := range columns {
+		matches[i] = column + " ILIKE " + placeholder
+	}
+	q.where("(" + strings.Join(matches, " OR ") + ")")
+}
+
+func (q *listQuery) whereClause() string {
+	if len(q.conditions) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(q.conditions, " AND ")
+}
+
+// page adds the date range, cursor, order and limit of opts and returns the
+// WHERE clause and the ORDER BY/LIMIT suffix. One row past the limit is
+// fetched to tell whether another page follows.
+func (q *listQuery) page(opts domain.ListOptions, columns map[string]sortColumn, timeColumn, idColumn string) (string, string, error) {
+	if opts.From != nil {
+		q.where(timeColumn + " >= " + q.arg(*opts.From))
+	}
+	if opts.To != nil {
+		q.where(timeColumn + " <= " + q.arg(*opts.To))
+	}
+
+	sort, ok := columns[opts.SortBy]
+	if !ok {
+		return "", "", fmt.Errorf("unknown sort field %q", opts.SortBy)
+	}
+	direction, comparison := "ASC", ">"
+	if opts.Desc {
+		direction, comparison = "DESC", "<"
+	}
+
+	if opts.After != nil {
+		var value interface{} = opts.After.Value
+		if sort.time {
+			t, err := time.Parse(time.RFC3339Nano, opts.After.Value)
+			if err != nil {
+				return "", "", fmt.Errorf("%w: invalid cursor", domain.ErrInvalidInput)
+			}
+			value = t
+		}
+		q.where(fmt.Sprintf("(%s, %s) %s (%s, %s)", sort.column, idColumn, comparison, q.arg(value), q.arg(opts.After.ID)))
+	}
+
+	suffix := fmt.Sprintf(" ORDER BY %s %s, %s %s", sort.column, direction, idColumn, direction)
+	if opts.Limit > 0 {
+		suffix += " LIMIT " + q.arg(opts.Limit+1)
+	}
+	return q.whereClause(), suffix, nil
+}
+
+// toPage trims the extra row fetched by listQuery.page and sets the cursor
+// of the next page.
+func toPage[T any](items []T, opts domain.ListOptions, cursorOf func(*T) (string, string)) domain.Page[T] {
+	if items == nil {
+		items = []T{}
+	}
+	page := domain.Page[T]{Items: items}
+	if opts.Limit > 0 && len(items) > opts.Limit {
+		page.Items = items[:opts.Limit]
+		value, id := cursorOf(&page.Items[opts.Limit-1])
+		page.NextCursor = opts.NextCursor(value, id)
+	}
+	return page
+}
+
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
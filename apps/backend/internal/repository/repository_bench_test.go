@@ -0,0 +1,212 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/rand/v2"
+	"os"
+	"testing"
+
+	"github.com/google/uuid"
+	_ "github.com/jackc/pgx/v5/stdlib"
+
+	"github.com/paasdeploy/backend/internal/database"
+	"github.com/paasdeploy/backend/internal/domain"
+)
+
+// The benchmarks compare per-app lookups with their batched counterparts
+// against a real database. They run only when TEST_DATABASE_URL points at a
+// disposable Postgres database:
+//
+//	TEST_DATABASE_URL=postgres://... go test -run '^$' -bench . ./internal/repository
+
+const (
+	benchApps          = 50
+	benchVarsPerApp    = 10
+	benchDomainsPerApp = 2
+	benchDeploysPerApp = 20
+)
+
+type benchFixture struct {
+	db          *sql.DB
+	apps        *PostgresAppRepository
+	envVars     *PostgresEnvVarRepository
+	domains     *PostgresCustomDomainRepository
+	deployments *PostgresDeploymentRepository
+	appIDs      []string
+}
+
+func newBenchFixture(b *testing.B) *benchFixture {
+	b.Helper()
+
+	url := os.Getenv("TEST_DATABASE_URL")
+	if url == "" {
+		b.Skip("TEST_DATABASE_URL not set")
+	}
+
+	db, err := sql.Open("pgx", url)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.Cleanup(func() { db.Close() })
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	if err := database.RunMigrations(db, "../../migrations", logger); err != nil {
+		b.Fatal(err)
+	}
+
+	f := &benchFixture{
+		db:          db,
+		apps:        NewPostgresAppRepository(db),
+		envVars:     NewPostgresEnvVarRepository(db),
+		domains:     NewPostgresCustomDomainRepository(db),
+		deployments: NewPostgresDeploymentRepository(db),
+	}
+	f.seed(b)
+	return f
+}
+
+func (f *benchFixture) seed(b *testing.B) {
+	b.Helper()
+	ctx := context.Background()
+	prefix := "bench-" + uuid.NewString()[:8]
+
+	var userID string
+	err := f.db.QueryRow(`INSERT INTO users (github_id, github_login, access_token_encrypted)
+		VALUES ($1, $2, '') RETURNING id`, 1<<40+rand.Int64N(1<<40), prefix).Scan(&userID)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.Cleanup(func() { f.cleanup(b, userID) })
+
+	for i := 0; i < benchApps; i++ {
+		name := fmt.Sprintf("%s-%d", prefix, i)
+		app, err := f.apps.Create(domain.CreateAppInput{
+			UserID:        userID,
+			Name:          name,
+			RepositoryURL: "https://github.com/bench/" + name,
+		})
+		if err != nil {
+			b.Fatal(err)
+		}
+		f.appIDs = append(f.appIDs, app.ID)
+
+		vars := make([]domain.CreateEnvVarInput, benchVarsPerApp)
+		for j := range vars {
+			vars[j] = domain.CreateEnvVarInput{Key: fmt.Sprintf("VAR_%d", j), Value: "value"}
+		}
+		if err := f.envVars.BulkUpsert(app.ID, vars); err != nil {
+			b.Fatal(err)
+		}
+
+		for j := 0; j < benchDomainsPerApp; j++ {
+			_, err := f.domains.Create(ctx, domain.CreateCustomDomainInput{
+				AppID:      app.ID,
+				Domain:     fmt.Sprintf("%s-%d.bench.test", name, j),
+				RecordType: "CNAME",
+			})
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+
+		for j := 0; j < benchDeploysPerApp; j++ {
+			d, err := f.deployments.Create(domain.CreateDeploymentInput{AppID: app.ID, CommitSHA: fmt.Sprintf("%040d", j)})
+			if err != nil {
+				b.Fatal(err)
+			}
+			if err := f.deployments.MarkAsFailed(d.ID, "bench"); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func (f *benchFixture) cleanup(b *testing.B, userID string) {
+	ctx := context.Background()
+	for _, id := range f.appIDs {
+		_ = f.envVars.DeleteByAppID(id)
+		_ = f.domains.DeleteByAppID(ctx, id)
+		_ = f.deployments.DeleteByAppID(id)
+		_ = f.apps.HardDelete(id)
+	}
+	if _, err := f.db.Exec(`DELETE FROM users WHERE id = $1`, userID); err != nil {
+		b.Log(err)
+	}
+}
+
+func BenchmarkLinkedAppsWithEnvVars(b *testing.B) {
+	f := newBenchFixture(b)
+
+	b.Run("PerApp", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, id := range f.appIDs {
+				if _, err := f.apps.FindByID(id); err != nil {
+					b.Fatal(err)
+				}
+				if _, err := f.envVars.FindByAppID(id); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+
+	b.Run("Batched", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := f.apps.FindByIDs(f.appIDs); err != nil {
+				b.Fatal(err)
+			}
+			if _, err := f.envVars.FindByAppIDs(f.appIDs); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkCustomDomainsByApps(b *testing.B) {
+	f := newBenchFixture(b)
+	ctx := context.Background()
+
+	b.Run("PerApp", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, id := range f.appIDs {
+				if _, err := f.domains.FindByAppID(ctx, id); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+
+	b.Run("Batched", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := f.domains.FindByAppIDs(ctx, f.appIDs); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkLatestDeployments(b *testing.B) {
+	f := newBenchFixture(b)
+
+	b.Run("PerApp", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, id := range f.appIDs {
+				if _, err := f.deployments.FindMostRecentByAppID(id); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+
+	b.Run("Batched", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := f.deployments.FindMostRecentByAppIDs(f.appIDs); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
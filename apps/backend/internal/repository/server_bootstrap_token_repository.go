@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/paasdeploy/backend/internal/domain"
+)
+
+type PostgresServerBootstrapTokenRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresServerBootstrapTokenRepository(db *sql.DB) *PostgresServerBootstrapTokenRepository {
+	return &PostgresServerBootstrapTokenRepository{db: db}
+}
+
+const bootstrapTokenColumns = `token_hash, server_id, expires_at, used_at, created_at`
+
+func (r *PostgresServerBootstrapTokenRepository) Create(serverID, tokenHash string, expiresAt time.Time) error {
+	query := `INSERT INTO server_bootstrap_tokens (token_hash, server_id, expires_at) VALUES ($1, $2, $3)`
+	if _, err := r.db.Exec(query, tokenHash, serverID, expiresAt); err != nil {
+		return fmt.Errorf("failed to create bootstrap token: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresServerBootstrapTokenRepository) FindValid(tokenHash string) (*domain.ServerBootstrapToken, error) {
+	query := `SELECT ` + bootstrapTokenColumns + ` FROM server_bootstrap_tokens
+		WHERE token_hash = $1 AND used_at IS NULL AND expires_at > NOW()`
+	token, err := scanBootstrapToken(r.db.QueryRow(query, tokenHash))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find bootstrap token: %w", err)
+	}
+	return token, nil
+}
+
+// Consume marks the token as used and returns it. A token can only be
+// consumed once; later calls return domain.ErrNotFound.
+func (r *PostgresServerBootstrapTokenRepository) Consume(tokenHash string) (*domain.ServerBootstrapToken, error) {
+	query := `UPDATE server_bootstrap_tokens SET used_at = NOW()
+		WHERE token_hash = $1 AND used_at IS NULL AND expires_at > NOW()
+		RETURNING ` + bootstrapTokenColumns
+	token, err := scanBootstrapToken(r.db.QueryRow(query, tokenHash))
+	if err != nil {
+		return nil, fmt.Errorf("failed to consume bootstrap token: %w", err)
+	}
+	return token, nil
+}
+
+func (r *PostgresServerBootstrapTokenRepository) DeleteByServerID(serverID string) error {
+	if _, err := r.db.Exec(`DELETE FROM server_bootstrap_tokens WHERE server_id = $1`, serverID); err != nil {
+		return fmt.Errorf("failed to delete bootstrap tokens: %w", err)
+	}
+	return nil
+}
+
+func scanBootstrapToken(row rowScanner) (*domain.ServerBootstrapToken, error) {
+	var token domain.ServerBootstrapToken
+	var usedAt sql.NullTime
+	err := row.Scan(&token.TokenHash, &token.ServerID, &token.ExpiresAt, &usedAt, &token.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if usedAt.Valid {
+		token.UsedAt = &usedAt.Time
+	}
+	return &token, nil
+}
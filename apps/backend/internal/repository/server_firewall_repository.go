@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/paasdeploy/backend/internal/domain"
+)
+
+type PostgresServerFirewallRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresServerFirewallRepository(db *sql.DB) *PostgresServerFirewallRepository {
+	return &PostgresServerFirewallRepository{db: db}
+}
+
+func (r *PostgresServerFirewallRepository) Upsert(fw *domain.ServerFirewall) error {
+	rulesJSON, err := json.Marshal(fw.Rules)
+	if err != nil {
+		return fmt.Errorf("failed to encode firewall rules: %w", err)
+	}
+	query := `INSERT INTO server_firewalls (server_id, backend, rules, applied_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (server_id) DO UPDATE SET backend = EXCLUDED.backend, rules = EXCLUDED.rules, applied_at = EXCLUDED.applied_at`
+	if _, err := r.db.Exec(query, fw.ServerID, fw.Backend, rulesJSON, fw.AppliedAt); err != nil {
+		return fmt.Errorf("failed to save server firewall: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresServerFirewallRepository) FindByServerID(serverID string) (*domain.ServerFirewall, error) {
+	query := `SELECT server_id, backend, rules, applied_at FROM server_firewalls WHERE server_id = $1`
+	var fw domain.ServerFirewall
+	var rulesJSON []byte
+	err := r.db.QueryRow(query, serverID).Scan(&fw.ServerID, &fw.Backend, &rulesJSON, &fw.AppliedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find server firewall: %w", err)
+	}
+	if err := json.Unmarshal(rulesJSON, &fw.Rules); err != nil {
+		return nil, fmt.Errorf("failed to decode firewall rules: %w", err)
+	}
+	return &fw, nil
+}
@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+type PostgresServerHeartbeatRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresServerHeartbeatRepository(db *sql.DB) *PostgresServerHeartbeatRepository {
+	return &PostgresServerHeartbeatRepository{db: db}
+}
+
+func (r *PostgresServerHeartbeatRepository) Record(serverID string) error {
+	query := `INSERT INTO server_heartbeats (server_id, received_at) VALUES ($1, NOW()) ON CONFLICT DO NOTHING`
+	if _, err := r.db.Exec(query, serverID); err != nil {
+		return fmt.Errorf("failed to record server heartbeat: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresServerHeartbeatRepository) FindSince(serverID string, since time.Time) ([]time.Time, error) {
+	query := `SELECT received_at FROM server_heartbeats WHERE server_id = $1 AND received_at >= $2 ORDER BY received_at`
+	rows, err := r.db.Query(query, serverID, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query server heartbeats: %w", err)
+	}
+	defer rows.Close()
+
+	var heartbeats []time.Time
+	for rows.Next() {
+		var receivedAt time.Time
+		if err := rows.Scan(&receivedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan server heartbeat: %w", err)
+		}
+		heartbeats = append(heartbeats, receivedAt)
+	}
+
+	return heartbeats, rows.Err()
+}
+
+func (r *PostgresServerHeartbeatRepository) DeleteOlderThan(before time.Time) (int64, error) {
+	result, err := r.db.Exec(`DELETE FROM server_heartbeats WHERE received_at < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune server heartbeats: %w", err)
+	}
+	return result.RowsAffected()
+}
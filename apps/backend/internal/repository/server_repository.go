@@ -2,12 +2,14 @@ package repository
 
 import (
 	"database/sql"
+	"encoding/json"
 	"errors"
+	"time"
 
 	"github.com/paasdeploy/backend/internal/domain"
 )
 
-const serverSelectColumns = `id, user_id, name, host, ssh_port, ssh_user, ssh_key_encrypted, ssh_password_encrypted, acme_email, ssh_host_key, status, agent_version, agent_update_mode, last_heartbeat_at, created_at, updated_at`
+const serverSelectColumns = `id, user_id, name, host, ssh_port, ssh_user, ssh_key_encrypted, ssh_password_encrypted, acme_email, ssh_host_key, ssh_public_key, status, agent_version, agent_update_mode, agent_install_method, docker_rootless, firewall_enabled, ssh_hardening, acme_staging, entrypoints, mesh_ip, mesh_public_key, bastion_server_id, cloud_provider, cloud_instance_id, stats_interval_seconds, last_heartbeat_at, created_at, updated_at`
 
 type PostgresServerRepository struct {
 	db *sql.DB
@@ -23,6 +25,12 @@ func (r *PostgresServerRepository) scanServer(row *sql.Row) (*domain.Server, err
 	var lastHeartbeatAt sql.NullTime
 	var sshPassword sql.NullString
 	var acmeEmail sql.NullString
+	var bastionServerID sql.NullString
+	var sshPublicKey sql.NullString
+	var cloudProvider, cloudInstanceID sql.NullString
+	var meshIP, meshPublicKey sql.NullString
+	var statsInterval sql.NullInt32
+	var entrypoints []byte
 	err := row.Scan(
 		&s.ID,
 		&s.UserID,
@@ -34,9 +42,22 @@ func (r *PostgresServerRepository) scanServer(row *sql.Row) (*domain.Server, err
 		&sshPassword,
 		&acmeEmail,
 		&s.SSHHostKey,
+		&sshPublicKey,
 		&s.Status,
 		&agentVersion,
 		&s.AgentUpdateMode,
+		&s.AgentInstallMethod,
+		&s.DockerRootless,
+		&s.FirewallEnabled,
+		&s.SSHHardening,
+		&s.AcmeStaging,
+		&entrypoints,
+		&meshIP,
+		&meshPublicKey,
+		&bastionServerID,
+		&cloudProvider,
+		&cloudInstanceID,
+		&statsInterval,
 		&lastHeartbeatAt,
 		&s.CreatedAt,
 		&s.UpdatedAt,
@@ -59,12 +80,24 @@ func (r *PostgresServerRepository) scanServer(row *sql.Row) (*domain.Server, err
 	if acmeEmail.Valid {
 		s.AcmeEmail = &acmeEmail.String
 	}
+	if bastionServerID.Valid {
+		s.BastionServerID = &bastionServerID.String
+	}
+	if sshPublicKey.Valid {
+		s.SSHPublicKey = sshPublicKey.String
+	}
+	_ = json.Unmarshal(entrypoints, &s.Entrypoints)
+	s.CloudProvider = fromNullString(cloudProvider)
+	s.CloudInstanceID = fromNullString(cloudInstanceID)
+	s.MeshIP = fromNullString(meshIP)
+	s.MeshPublicKey = fromNullString(meshPublicKey)
+	s.StatsIntervalSeconds = fromNullInt32(statsInterval)
 	return &s, nil
 }
 
 func (r *PostgresServerRepository) Create(input domain.CreateServerInput) (*domain.Server, error) {
-	query := `INSERT INTO servers (user_id, name, host, ssh_port, ssh_user, ssh_key_encrypted, ssh_password_encrypted, acme_email, status)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, 'pending')
+	query := `INSERT INTO servers (user_id, name, host, ssh_port, ssh_user, ssh_key_encrypted, ssh_password_encrypted, acme_email, agent_install_method, docker_rootless, firewall_enabled, ssh_hardening, bastion_server_id, ssh_public_key, cloud_provider, cloud_instance_id, status)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, COALESCE(NULLIF($9, ''), 'auto'), $10, $11, $12, NULLIF($13, '')::uuid, $14, NULLIF($15, ''), NULLIF($16, ''), 'pending')
 		RETURNING ` + serverSelectColumns
 
 	sshPort := input.SSHPort
@@ -72,7 +105,7 @@ func (r *PostgresServerRepository) Create(input domain.CreateServerInput) (*doma
 		sshPort = 22
 	}
 
-	return r.scanServer(r.db.QueryRow(query, input.UserID, input.Name, input.Host, sshPort, input.SSHUser, input.SSHKeyEncrypted, input.SSHPasswordEncrypted, input.AcmeEmail))
+	return r.scanServer(r.db.QueryRow(query, input.UserID, input.Name, input.Host, sshPort, input.SSHUser, input.SSHKeyEncrypted, input.SSHPasswordEncrypted, input.AcmeEmail, input.AgentInstallMethod, input.DockerRootless, input.FirewallEnabled, input.SSHHardening, input.BastionServerID, input.SSHPublicKey, input.CloudProvider, input.CloudInstanceID))
 }
 
 func (r *PostgresServerRepository) FindByID(id string) (*domain.Server, error) {
@@ -88,6 +121,12 @@ func (r *PostgresServerRepository) scanServerRows(rows *sql.Rows) ([]domain.Serv
 		var lastHeartbeatAt sql.NullTime
 		var sshPassword sql.NullString
 		var acmeEmail sql.NullString
+		var bastionServerID sql.NullString
+		var sshPublicKey sql.NullString
+		var cloudProvider, cloudInstanceID sql.NullString
+		var meshIP, meshPublicKey sql.NullString
+		var statsInterval sql.NullInt32
+		var entrypoints []byte
 		if err := rows.Scan(
 			&s.ID,
 			&s.UserID,
@@ -99,9 +138,22 @@ func (r *PostgresServerRepository) scanServerRows(rows *sql.Rows) ([]domain.Serv
 			&sshPassword,
 			&acmeEmail,
 			&s.SSHHostKey,
+			&sshPublicKey,
 			&s.Status,
 			&agentVersion,
 			&s.AgentUpdateMode,
+			&s.AgentInstallMethod,
+			&s.DockerRootless,
+			&s.FirewallEnabled,
+			&s.SSHHardening,
+			&s.AcmeStaging,
+			&entrypoints,
+			&meshIP,
+			&meshPublicKey,
+			&bastionServerID,
+			&cloudProvider,
+			&cloudInstanceID,
+			&statsInterval,
 			&lastHeartbeatAt,
 			&s.CreatedAt,
 			&s.UpdatedAt,
@@ -120,6 +172,18 @@ func (r *PostgresServerRepository) scanServerRows(rows *sql.Rows) ([]domain.Serv
 		if acmeEmail.Valid {
 			s.AcmeEmail = &acmeEmail.String
 		}
+		if bastionServerID.Valid {
+			s.BastionServerID = &bastionServerID.String
+		}
+		if sshPublicKey.Valid {
+			s.SSHPublicKey = sshPublicKey.String
+		}
+		_ = json.Unmarshal(entrypoints, &s.Entrypoints)
+		s.CloudProvider = fromNullString(cloudProvider)
+		s.CloudInstanceID = fromNullString(cloudInstanceID)
+		s.MeshIP = fromNullString(meshIP)
+		s.MeshPublicKey = fromNullString(meshPublicKey)
+		s.StatsIntervalSeconds = fromNullInt32(statsInterval)
 		servers = append(servers, s)
 	}
 	return servers, rows.Err()
@@ -145,6 +209,42 @@ func (r *PostgresServerRepository) FindAllByUserID(userID string) ([]domain.Serv
 	return r.scanServerRows(rows)
 }
 
+var serverSortColumns = map[string]sortColumn{
+	"createdAt": {column: "created_at", time: true},
+	"name":      {column: "name"},
+}
+
+func (r *PostgresServerRepository) FindPageByUserID(userID string, filter domain.ServerFilter, opts domain.ListOptions) (domain.Page[domain.Server], error) {
+	var q listQuery
+	q.where("user_id = " + q.arg(userID))
+	if filter.Status != "" {
+		q.where("status = " + q.arg(filter.Status))
+	}
+	q.search(opts.Query, "name", "host")
+
+	where, suffix, err := q.page(opts, serverSortColumns, "created_at", "id")
+	if err != nil {
+		return domain.Page[domain.Server]{}, err
+	}
+
+	rows, err := r.db.Query(`SELECT `+serverSelectColumns+` FROM servers`+where+suffix, q.args...)
+	if err != nil {
+		return domain.Page[domain.Server]{}, err
+	}
+	defer rows.Close()
+
+	servers, err := r.scanServerRows(rows)
+	if err != nil {
+		return domain.Page[domain.Server]{}, err
+	}
+	return toPage(servers, opts, func(s *domain.Server) (string, string) {
+		if opts.SortBy == "name" {
+			return s.Name, s.ID
+		}
+		return domain.CursorTime(s.CreatedAt), s.ID
+	}), nil
+}
+
 func (r *PostgresServerRepository) FindByIDForUser(id string, userID string) (*domain.Server, error) {
 	query := `SELECT ` + serverSelectColumns + ` FROM servers WHERE id = $1 AND user_id = $2`
 	return r.scanServer(r.db.QueryRow(query, id, userID))
@@ -161,6 +261,13 @@ func (r *PostgresServerRepository) Update(id string, input domain.UpdateServerIn
 		acme_email = COALESCE($8, acme_email),
 		status = COALESCE($9, status),
 		agent_update_mode = COALESCE($10, agent_update_mode),
+		agent_install_method = COALESCE($11, agent_install_method),
+		docker_rootless = COALESCE($12, docker_rootless),
+		firewall_enabled = COALESCE($13, firewall_enabled),
+		ssh_hardening = COALESCE($14, ssh_hardening),
+		bastion_server_id = CASE WHEN $15::text IS NULL THEN bastion_server_id ELSE NULLIF($15, '')::uuid END,
+		ssh_public_key = COALESCE($16, ssh_public_key),
+		acme_staging = COALESCE($17, acme_staging),
 		updated_at = NOW()
 		WHERE id = $1
 		RETURNING ` + serverSelectColumns
@@ -200,7 +307,7 @@ func (r *PostgresServerRepository) Update(id string, input domain.UpdateServerIn
 		agentUpdateMode = input.AgentUpdateMode
 	}
 
-	return r.scanServer(r.db.QueryRow(query, id, name, host, sshPort, sshUser, sshKeyEncrypted, sshPasswordEncrypted, acmeEmail, status, agentUpdateMode))
+	return r.scanServer(r.db.QueryRow(query, id, name, host, sshPort, sshUser, sshKeyEncrypted, sshPasswordEncrypted, acmeEmail, status, agentUpdateMode, input.AgentInstallMethod, input.DockerRootless, input.FirewallEnabled, input.SSHHardening, input.BastionServerID, input.SSHPublicKey, input.AcmeStaging))
 }
 
 func (r *PostgresServerRepository) UpdateHeartbeat(id string, agentVersion string) error {
@@ -209,12 +316,55 @@ func (r *PostgresServerRepository) UpdateHeartbeat(id string, agentVersion strin
 	return err
 }
 
+func (r *PostgresServerRepository) MarkStaleOffline(threshold time.Duration) ([]domain.Server, error) {
+	query := `UPDATE servers SET status = 'offline', updated_at = NOW()
+		WHERE status = 'online' AND last_heartbeat_at < NOW() - ($1 * INTERVAL '1 second')
+		RETURNING ` + serverSelectColumns
+	rows, err := r.db.Query(query, threshold.Seconds())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return r.scanServerRows(rows)
+}
+
 func (r *PostgresServerRepository) UpdateSSHHostKey(id string, hostKey string) error {
 	query := `UPDATE servers SET ssh_host_key = $2, updated_at = NOW() WHERE id = $1`
 	_, err := r.db.Exec(query, id, hostKey)
 	return err
 }
 
+func (r *PostgresServerRepository) UpdateEntrypoints(id string, entrypoints []domain.ServerEntrypoint) error {
+	if entrypoints == nil {
+		entrypoints = []domain.ServerEntrypoint{}
+	}
+	data, err := json.Marshal(entrypoints)
+	if err != nil {
+		return err
+	}
+	query := `UPDATE servers SET entrypoints = $2, updated_at = NOW() WHERE id = $1`
+	result, err := r.db.Exec(query, id, data)
+	if err != nil {
+		return err
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *PostgresServerRepository) UpdateStatsInterval(id string, seconds *int) error {
+	query := `UPDATE servers SET stats_interval_seconds = $2, updated_at = NOW() WHERE id = $1`
+	result, err := r.db.Exec(query, id, seconds)
+	if err != nil {
+		return err
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
 func (r *PostgresServerRepository) Delete(id string) error {
 	query := `DELETE FROM servers WHERE id = $1`
 	result, err := r.db.Exec(query, id)
@@ -227,3 +377,15 @@ func (r *PostgresServerRepository) Delete(id string) error {
 	}
 	return nil
 }
+
+func (r *PostgresServerRepository) UpdateMesh(id string, meshIP string, publicKey string) error {
+	query := `UPDATE servers SET mesh_ip = NULLIF($2, ''), mesh_public_key = NULLIF($3, ''), updated_at = NOW() WHERE id = $1`
+	result, err := r.db.Exec(query, id, meshIP, publicKey)
+	if err != nil {
+		return err
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
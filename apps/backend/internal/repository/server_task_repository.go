@@ -0,0 +1,322 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/paasdeploy/backend/internal/domain"
+)
+
+const (
+	serverTaskSelectColumns    = `id, server_id, name, schedule, command, timeout_seconds, enabled, notify_on_failure, next_run_at, last_run_at, last_status, created_at, updated_at`
+	serverTaskRunSelectColumns = `id, task_id, server_id, trigger, status, exit_code, output, truncated, error, started_at, finished_at, duration_ms`
+)
+
+type PostgresServerTaskRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresServerTaskRepository(db *sql.DB) *PostgresServerTaskRepository {
+	return &PostgresServerTaskRepository{db: db}
+}
+
+func scanServerTask(row rowScanner) (*domain.ServerTask, error) {
+	var t domain.ServerTask
+	var nextRunAt, lastRunAt sql.NullTime
+	var lastStatus sql.NullString
+	if err := row.Scan(
+		&t.ID,
+		&t.ServerID,
+		&t.Name,
+		&t.Schedule,
+		&t.Command,
+		&t.TimeoutSeconds,
+		&t.Enabled,
+		&t.NotifyOnFailure,
+		&nextRunAt,
+		&lastRunAt,
+		&lastStatus,
+		&t.CreatedAt,
+		&t.UpdatedAt,
+	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	t.NextRunAt = fromNullTime(nextRunAt)
+	t.LastRunAt = fromNullTime(lastRunAt)
+	t.LastStatus = domain.ServerTaskRunStatus(fromNullString(lastStatus))
+	return &t, nil
+}
+
+func scanServerTaskRun(row rowScanner) (*domain.ServerTaskRun, error) {
+	var r domain.ServerTaskRun
+	var exitCode sql.NullInt32
+	var runError sql.NullString
+	var finishedAt sql.NullTime
+	if err := row.Scan(
+		&r.ID,
+		&r.TaskID,
+		&r.ServerID,
+		&r.Trigger,
+		&r.Status,
+		&exitCode,
+		&r.Output,
+		&r.Truncated,
+		&runError,
+		&r.StartedAt,
+		&finishedAt,
+		&r.DurationMs,
+	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	r.ExitCode = fromNullInt32(exitCode)
+	r.Error = fromNullString(runError)
+	r.FinishedAt = fromNullTime(finishedAt)
+	return &r, nil
+}
+
+func (r *PostgresServerTaskRepository) findMany(query string, args ...any) ([]domain.ServerTask, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tasks := []domain.ServerTask{}
+	for rows.Next() {
+		t, err := scanServerTask(rows)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, *t)
+	}
+	return tasks, rows.Err()
+}
+
+func (r *PostgresServerTaskRepository) FindByServerID(serverID string) ([]domain.ServerTask, error) {
+	return r.findMany(`SELECT `+serverTaskSelectColumns+` FROM server_tasks WHERE server_id = $1 ORDER BY created_at`, serverID)
+}
+
+func (r *PostgresServerTaskRepository) FindByIDAndServerID(id, serverID string) (*domain.ServerTask, error) {
+	query := `SELECT ` + serverTaskSelectColumns + ` FROM server_tasks WHERE id = $1 AND server_id = $2`
+	return scanServerTask(r.db.QueryRow(query, id, serverID))
+}
+
+func (r *PostgresServerTaskRepository) FindDue(now time.Time) ([]domain.ServerTask, error) {
+	return r.findMany(`
+		SELECT `+serverTaskSelectColumns+` FROM server_tasks
+		WHERE enabled AND next_run_at IS NOT NULL AND next_run_at <= $1
+		ORDER BY next_run_at`, now)
+}
+
+func (r *PostgresServerTaskRepository) CountByServerID(serverID string) (int, error) {
+	var count int
+	err := r.db.QueryRow(`SELECT COUNT(*) FROM server_tasks WHERE server_id = $1`, serverID).Scan(&count)
+	return count, err
+}
+
+func (r *PostgresServerTaskRepository) Create(input domain.CreateServerTaskInput) (*domain.ServerTask, error) {
+	query := `
+		INSERT INTO server_tasks (server_id, name, schedule, command, timeout_seconds, enabled, notify_on_failure, next_run_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING ` + serverTaskSelectColumns
+	return scanServerTask(r.db.QueryRow(query,
+		input.ServerID, input.Name, input.Schedule, input.Command,
+		input.TimeoutSeconds, input.Enabled, input.NotifyOnFailure, toNullTime(input.NextRunAt),
+	))
+}
+
+func (r *PostgresServerTaskRepository) Update(id string, input domain.UpdateServerTaskInput) (*domain.ServerTask, error) {
+	var nameVal, scheduleVal, commandVal, timeoutVal, enabledVal, notifyVal interface{}
+	if input.Name != nil {
+		nameVal = *input.Name
+	}
+	if input.Schedule != nil {
+		scheduleVal = *input.Schedule
+	}
+	if input.Command != nil {
+		commandVal = *input.Command
+	}
+	if input.TimeoutSeconds != nil {
+		timeoutVal = *input.TimeoutSeconds
+	}
+	if input.Enabled != nil {
+		enabledVal = *input.Enabled
+	}
+	if input.NotifyOnFailure != nil {
+		notifyVal = *input.NotifyOnFailure
+	}
+	query := `
+		UPDATE server_tasks
+		SET name = COALESCE($2, name),
+		    schedule = COALESCE($3, schedule),
+		    command = COALESCE($4, command),
+		    timeout_seconds = COALESCE($5, timeout_seconds),
+		    enabled = COALESCE($6, enabled),
+		    notify_on_failure = COALESCE($7, notify_on_failure),
+		    next_run_at = $8,
+		    updated_at = NOW()
+		WHERE id = $1
+		RETURNING ` + serverTaskSelectColumns
+	return scanServerTask(r.db.QueryRow(query,
+		id, nameVal, scheduleVal, commandVal, timeoutVal, enabledVal, notifyVal, toNullTime(input.NextRunAt),
+	))
+}
+
+func (r *PostgresServerTaskRepository) Delete(id string) error {
+	result, err := r.db.Exec(`DELETE FROM server_tasks WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *PostgresServerTaskRepository) ClaimDue(id string, due, next time.Time) (bool, error) {
+	result, err := r.db.Exec(`
+		UPDATE server_tasks SET next_run_at = $3
+		WHERE id = $1 AND enabled AND next_run_at = $2
+	`, id, due, toNullTime(nonZeroTime(next)))
+	if err != nil {
+		return false, err
+	}
+	rows, _ := result.RowsAffected()
+	return rows > 0, nil
+}
+
+// StartRun records a running run and marks it as the task's last run.
+func (r *PostgresServerTaskRepository) StartRun(task domain.ServerTask, trigger string) (*domain.ServerTaskRun, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	run, err := scanServerTaskRun(tx.QueryRow(`
+		INSERT INTO server_task_runs (task_id, server_id, trigger, status)
+		VALUES ($1, $2, $3, $4)
+		RETURNING `+serverTaskRunSelectColumns,
+		task.ID, task.ServerID, trigger, domain.ServerTaskRunStatusRunning,
+	))
+	if err != nil {
+		return nil, err
+	}
+	if _, err := tx.Exec(`
+		UPDATE server_tasks SET last_run_at = $2, last_status = $3 WHERE id = $1
+	`, task.ID, run.StartedAt, run.Status); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return run, nil
+}
+
+// FinishRun stores the outcome of a run on it and on its task, and prunes
+// the task's runs beyond domain.ServerTaskRunRetention.
+func (r *PostgresServerTaskRepository) FinishRun(run domain.ServerTaskRun, input domain.FinishServerTaskRunInput) (*domain.ServerTaskRun, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var exitCode sql.NullInt32
+	if input.ExitCode != nil {
+		exitCode = sql.NullInt32{Int32: int32(*input.ExitCode), Valid: true}
+	}
+	finished, err := scanServerTaskRun(tx.QueryRow(`
+		UPDATE server_task_runs
+		SET status = $2, exit_code = $3, output = $4, truncated = $5, error = $6,
+		    finished_at = NOW(), duration_ms = $7
+		WHERE id = $1
+		RETURNING `+serverTaskRunSelectColumns,
+		run.ID, input.Status, exitCode, input.Output, input.Truncated,
+		toNullStringValue(input.Error), input.DurationMs,
+	))
+	if err != nil {
+		return nil, err
+	}
+	if _, err := tx.Exec(`
+		UPDATE server_tasks SET last_status = $3
+		WHERE id = $1 AND last_run_at = $2
+	`, run.TaskID, run.StartedAt, input.Status); err != nil {
+		return nil, err
+	}
+	if _, err := tx.Exec(`
+		DELETE FROM server_task_runs
+		WHERE task_id = $1 AND id NOT IN (
+			SELECT id FROM server_task_runs WHERE task_id = $1 ORDER BY started_at DESC LIMIT $2
+		)
+	`, run.TaskID, domain.ServerTaskRunRetention); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return finished, nil
+}
+
+func (r *PostgresServerTaskRepository) FindRuns(taskID string, limit int) ([]domain.ServerTaskRun, error) {
+	rows, err := r.db.Query(`
+		SELECT `+serverTaskRunSelectColumns+` FROM server_task_runs
+		WHERE task_id = $1 ORDER BY started_at DESC LIMIT $2
+	`, taskID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	runs := []domain.ServerTaskRun{}
+	for rows.Next() {
+		run, err := scanServerTaskRun(rows)
+		if err != nil {
+			return nil, err
+		}
+		runs = append(runs, *run)
+	}
+	return runs, rows.Err()
+}
+
+func (r *PostgresServerTaskRepository) FailRunningRuns(reason string) (int64, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(`
+		UPDATE server_task_runs
+		SET status = $1, error = $2, finished_at = NOW(),
+		    duration_ms = (EXTRACT(EPOCH FROM NOW() - started_at) * 1000)::BIGINT
+		WHERE status = $3
+	`, domain.ServerTaskRunStatusFailed, reason, domain.ServerTaskRunStatusRunning)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := tx.Exec(`
+		UPDATE server_tasks SET last_status = $1 WHERE last_status = $2
+	`, domain.ServerTaskRunStatusFailed, domain.ServerTaskRunStatusRunning); err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+func nonZeroTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/paasdeploy/backend/internal/domain"
+)
+
+type PostgresServerTunnelRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresServerTunnelRepository(db *sql.DB) *PostgresServerTunnelRepository {
+	return &PostgresServerTunnelRepository{db: db}
+}
+
+func (r *PostgresServerTunnelRepository) FindByServerID(ctx context.Context, serverID string) (*domain.ServerTunnel, error) {
+	query := `SELECT server_id, account_id, tunnel_id, token_encrypted, created_at, updated_at
+		FROM server_tunnels WHERE server_id = $1`
+	var t domain.ServerTunnel
+	err := r.db.QueryRowContext(ctx, query, serverID).Scan(
+		&t.ServerID, &t.AccountID, &t.TunnelID, &t.TokenEncrypted, &t.CreatedAt, &t.UpdatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find server tunnel: %w", err)
+	}
+	return &t, nil
+}
+
+func (r *PostgresServerTunnelRepository) Upsert(ctx context.Context, t *domain.ServerTunnel) error {
+	query := `INSERT INTO server_tunnels (server_id, account_id, tunnel_id, token_encrypted)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (server_id) DO UPDATE SET account_id = EXCLUDED.account_id, tunnel_id = EXCLUDED.tunnel_id,
+			token_encrypted = EXCLUDED.token_encrypted, updated_at = NOW()
+		RETURNING created_at, updated_at`
+	err := r.db.QueryRowContext(ctx, query, t.ServerID, t.AccountID, t.TunnelID, t.TokenEncrypted).
+		Scan(&t.CreatedAt, &t.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save server tunnel: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresServerTunnelRepository) Delete(ctx context.Context, serverID string) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM server_tunnels WHERE server_id = $1`, serverID); err != nil {
+		return fmt.Errorf("failed to delete server tunnel: %w", err)
+	}
+	return nil
+}
@@ -5,6 +5,10 @@ import (
 	"time"
 )
 
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
 func toNullString(s *string) sql.NullString {
 	if s == nil {
 		return sql.NullString{}
@@ -39,3 +43,11 @@ func fromNullTime(nt sql.NullTime) *time.Time {
 	}
 	return nil
 }
+
+func fromNullInt32(ni sql.NullInt32) *int {
+	if ni.Valid {
+		v := int(ni.Int32)
+		return &v
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"database/sql"
+
+	"github.com/paasdeploy/backend/internal/domain"
+)
+
+type PostgresUsageRecordRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresUsageRecordRepository(db *sql.DB) *PostgresUsageRecordRepository {
+	return &PostgresUsageRecordRepository{db: db}
+}
+
+// CreateBatch stores the records in one transaction. Records already stored
+// under the same ID are skipped, so a failed batch can be retried.
+func (r *PostgresUsageRecordRepository) CreateBatch(records []domain.UsageRecord) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	query := `
+		INSERT INTO usage_records (id, user_id, app_id, app_name, server_id, metric, quantity, period_start, period_end)
+		VALUES ($1, NULLIF($2, '')::uuid, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (id) DO NOTHING
+	`
+
+	stmt, err := tx.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, rec := range records {
+		_, err = stmt.Exec(rec.ID, rec.UserID, rec.AppID, rec.AppName, rec.ServerID, rec.Metric, rec.Quantity, rec.PeriodStart, rec.PeriodEnd)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
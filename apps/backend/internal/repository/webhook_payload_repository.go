@@ -3,8 +3,10 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"time"
 
+	"github.com/paasdeploy/backend/internal/domain"
 	"github.com/paasdeploy/backend/internal/ghclient"
 )
 
@@ -124,3 +126,25 @@ func (r *PostgresWebhookPayloadRepository) FindAll(ctx context.Context, filter W
 	}
 	return results, total, nil
 }
+
+func (r *PostgresWebhookPayloadRepository) FindByID(ctx context.Context, id string) (*WebhookPayloadResult, error) {
+	var row WebhookPayloadResult
+	var errMsg sql.NullString
+	var createdAt time.Time
+	err := r.db.QueryRowContext(ctx,
+		`SELECT id, delivery_id, event_type, provider, payload, outcome, error_message, created_at
+		 FROM webhook_payloads WHERE id = $1`, id).
+		Scan(&row.ID, &row.DeliveryID, &row.EventType, &row.Provider,
+			&row.Payload, &row.Outcome, &errMsg, &createdAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if errMsg.Valid {
+		row.ErrorMessage = &errMsg.String
+	}
+	row.CreatedAt = createdAt.Format(time.RFC3339)
+	return &row, nil
+}
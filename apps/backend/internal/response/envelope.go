@@ -4,23 +4,20 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
+// Envelope wraps successful responses. Errors are sent as a Problem.
 type Envelope struct {
 	Success bool        `json:"success"`
 	Data    interface{} `json:"data"`
-	Error   *ErrorInfo  `json:"error"`
 	Meta    Meta        `json:"meta"`
 }
 
-type ErrorInfo struct {
-	Code    ErrorCode   `json:"code"`
-	Message string      `json:"message"`
-	Details interface{} `json:"details,omitempty"`
-}
-
 type Meta struct {
 	TraceID    string      `json:"traceId,omitempty"`
 	Pagination *Pagination `json:"pagination,omitempty"`
-	Warnings   []string    `json:"warnings,omitempty"`
+	// NextCursor fetches the next page of a cursor-paginated list. It is
+	// omitted on the last page.
+	NextCursor string   `json:"nextCursor,omitempty"`
+	Warnings   []string `json:"warnings,omitempty"`
 }
 
 type Pagination struct {
@@ -29,28 +26,70 @@ type Pagination struct {
 	Total   int `json:"total"`
 }
 
+// ErrorCode is the stable, machine-readable code of an error response.
+// Clients branch on it; messages are for humans and may change. Codes are
+// never renamed or reused.
 type ErrorCode string
 
 const (
-	ErrCodeInvalidPayload ErrorCode = "INVALID_PAYLOAD"
-	ErrCodeUnauthorized   ErrorCode = "UNAUTHORIZED"
-	ErrCodeForbidden      ErrorCode = "FORBIDDEN"
-	ErrCodeNotFound       ErrorCode = "NOT_FOUND"
-	ErrCodeConflict       ErrorCode = "CONFLICT"
-	ErrCodeRateLimited    ErrorCode = "RATE_LIMITED"
-	ErrCodeInternal       ErrorCode = "INTERNAL_ERROR"
+	ErrCodeInvalidPayload   ErrorCode = "INVALID_PAYLOAD"
+	ErrCodeUnauthorized     ErrorCode = "UNAUTHORIZED"
+	ErrCodeForbidden        ErrorCode = "FORBIDDEN"
+	ErrCodeNotFound         ErrorCode = "NOT_FOUND"
+	ErrCodeMethodNotAllowed ErrorCode = "METHOD_NOT_ALLOWED"
+	ErrCodeConflict         ErrorCode = "CONFLICT"
+	ErrCodePayloadTooLarge  ErrorCode = "PAYLOAD_TOO_LARGE"
+	ErrCodeUnprocessable    ErrorCode = "UNPROCESSABLE"
+	ErrCodeRateLimited      ErrorCode = "RATE_LIMITED"
+	ErrCodeInternal         ErrorCode = "INTERNAL_ERROR"
+	ErrCodeBadGateway       ErrorCode = "BAD_GATEWAY"
+	ErrCodeUnavailable      ErrorCode = "SERVICE_UNAVAILABLE"
+	ErrCodeTimeout          ErrorCode = "TIMEOUT"
 )
 
+// CodeForStatus returns the error code of a status that has no more specific
+// code.
+func CodeForStatus(status int) ErrorCode {
+	switch status {
+	case fiber.StatusUnauthorized:
+		return ErrCodeUnauthorized
+	case fiber.StatusForbidden:
+		return ErrCodeForbidden
+	case fiber.StatusNotFound:
+		return ErrCodeNotFound
+	case fiber.StatusMethodNotAllowed:
+		return ErrCodeMethodNotAllowed
+	case fiber.StatusConflict:
+		return ErrCodeConflict
+	case fiber.StatusRequestEntityTooLarge:
+		return ErrCodePayloadTooLarge
+	case fiber.StatusUnprocessableEntity:
+		return ErrCodeUnprocessable
+	case fiber.StatusTooManyRequests:
+		return ErrCodeRateLimited
+	case fiber.StatusBadGateway:
+		return ErrCodeBadGateway
+	case fiber.StatusServiceUnavailable:
+		return ErrCodeUnavailable
+	case fiber.StatusRequestTimeout, fiber.StatusGatewayTimeout:
+		return ErrCodeTimeout
+	}
+	if status < fiber.StatusInternalServerError {
+		return ErrCodeInvalidPayload
+	}
+	return ErrCodeInternal
+}
+
 func OK(c *fiber.Ctx, data interface{}) error {
-	return send(c, fiber.StatusOK, data, nil)
+	return send(c, fiber.StatusOK, data)
 }
 
 func Created(c *fiber.Ctx, data interface{}) error {
-	return send(c, fiber.StatusCreated, data, nil)
+	return send(c, fiber.StatusCreated, data)
 }
 
 func Accepted(c *fiber.Ctx, data interface{}) error {
-	return send(c, fiber.StatusAccepted, data, nil)
+	return send(c, fiber.StatusAccepted, data)
 }
 
 func NoContent(c *fiber.Ctx) error {
@@ -66,7 +105,11 @@ func OKWithPagination(c *fiber.Ctx, data interface{}, page, perPage, total int)
 			Total:   total,
 		},
 	}
-	return sendWithMeta(c, fiber.StatusOK, data, nil, meta)
+	return sendWithMeta(c, fiber.StatusOK, data, meta)
+}
+
+func OKWithCursor(c *fiber.Ctx, data interface{}, nextCursor string) error {
+	return sendWithMeta(c, fiber.StatusOK, data, Meta{NextCursor: nextCursor})
 }
 
 func BadRequest(c *fiber.Ctx, message string) error {
@@ -102,25 +145,30 @@ func InternalError(c *fiber.Ctx) error {
 }
 
 func ServerError(c *fiber.Ctx, status int, message string) error {
-	return sendError(c, status, ErrCodeInternal, message, nil)
+	return sendError(c, status, CodeForStatus(status), message, nil)
 }
 
-func send(c *fiber.Ctx, status int, data interface{}, errInfo *ErrorInfo) error {
+// Fail sends an error response with an explicit code, for errors the
+// helpers above do not cover.
+func Fail(c *fiber.Ctx, status int, code ErrorCode, message string) error {
+	return sendError(c, status, code, message, nil)
+}
+
+func send(c *fiber.Ctx, status int, data interface{}) error {
 	meta := Meta{
 		TraceID: getTraceID(c),
 	}
-	return sendWithMeta(c, status, data, errInfo, meta)
+	return sendWithMeta(c, status, data, meta)
 }
 
-func sendWithMeta(c *fiber.Ctx, status int, data interface{}, errInfo *ErrorInfo, meta Meta) error {
+func sendWithMeta(c *fiber.Ctx, status int, data interface{}, meta Meta) error {
 	if meta.TraceID == "" {
 		meta.TraceID = getTraceID(c)
 	}
 
 	envelope := Envelope{
-		Success: errInfo == nil,
+		Success: true,
 		Data:    data,
-		Error:   errInfo,
 		Meta:    meta,
 	}
 
@@ -128,12 +176,11 @@ func sendWithMeta(c *fiber.Ctx, status int, data interface{}, errInfo *ErrorInfo
 }
 
 func sendError(c *fiber.Ctx, status int, code ErrorCode, message string, details interface{}) error {
-	errInfo := &ErrorInfo{
-		Code:    code,
-		Message: message,
-		Details: details,
-	}
-	return send(c, status, nil, errInfo)
+	problem := NewProblem(status, code, message)
+	problem.Instance = c.OriginalURL()
+	problem.TraceID = getTraceID(c)
+	problem.Errors = details
+	return c.Status(status).JSON(problem, ProblemContentType)
 }
 
 func getTraceID(c *fiber.Ctx) string {
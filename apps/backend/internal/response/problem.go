@@ -0,0 +1,42 @@
+package response
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2/utils"
+)
+
+// ProblemContentType is the media type of error responses.
+const ProblemContentType = "application/problem+json"
+
+const problemTypePrefix = "urn:paasdeploy:error:"
+
+// Problem is the body of every error response, an RFC 7807 problem details
+// object. Code and TraceID are extension members; Errors carries per-field
+// details when the request was rejected for more than one reason.
+type Problem struct {
+	Type     string      `json:"type"`
+	Title    string      `json:"title"`
+	Status   int         `json:"status"`
+	Detail   string      `json:"detail,omitempty"`
+	Instance string      `json:"instance,omitempty"`
+	Code     ErrorCode   `json:"code"`
+	TraceID  string      `json:"traceId,omitempty"`
+	Errors   interface{} `json:"errors,omitempty"`
+}
+
+func NewProblem(status int, code ErrorCode, detail string) Problem {
+	return Problem{
+		Type:   ProblemType(code),
+		Title:  utils.StatusMessage(status),
+		Status: status,
+		Detail: detail,
+		Code:   code,
+	}
+}
+
+// ProblemType returns the problem type URI of a code, such as
+// urn:paasdeploy:error:not-found.
+func ProblemType(code ErrorCode) string {
+	return problemTypePrefix + strings.ReplaceAll(strings.ToLower(string(code)), "_", "-")
+}
@@ -0,0 +1,66 @@
+package response
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestErrorResponsesAreProblems(t *testing.T) {
+	app := fiber.New()
+	app.Get("/apps/:id", func(c *fiber.Ctx) error {
+		c.Locals("traceId", "trace-1")
+		return NotFound(c, "app not found")
+	})
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/apps/42?verbose=1", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != fiber.StatusNotFound {
+		t.Fatalf("status = %d, want 404", resp.StatusCode)
+	}
+	if got := resp.Header.Get(fiber.HeaderContentType); got != ProblemContentType {
+		t.Errorf("content type = %q, want %q", got, ProblemContentType)
+	}
+
+	var problem Problem
+	if err := json.NewDecoder(resp.Body).Decode(&problem); err != nil {
+		t.Fatal(err)
+	}
+	want := Problem{
+		Type:     "urn:paasdeploy:error:not-found",
+		Title:    "Not Found",
+		Status:   fiber.StatusNotFound,
+		Detail:   "app not found",
+		Instance: "/apps/42?verbose=1",
+		Code:     ErrCodeNotFound,
+		TraceID:  "trace-1",
+	}
+	if problem != want {
+		t.Errorf("problem = %+v, want %+v", problem, want)
+	}
+}
+
+func TestCodeForStatus(t *testing.T) {
+	tests := []struct {
+		status int
+		want   ErrorCode
+	}{
+		{fiber.StatusBadRequest, ErrCodeInvalidPayload},
+		{fiber.StatusRequestEntityTooLarge, ErrCodePayloadTooLarge},
+		{fiber.StatusUnprocessableEntity, ErrCodeUnprocessable},
+		{fiber.StatusTeapot, ErrCodeInvalidPayload},
+		{fiber.StatusBadGateway, ErrCodeBadGateway},
+		{fiber.StatusServiceUnavailable, ErrCodeUnavailable},
+		{fiber.StatusGatewayTimeout, ErrCodeTimeout},
+		{fiber.StatusNotImplemented, ErrCodeInternal},
+	}
+	for _, tt := range tests {
+		if got := CodeForStatus(tt.status); got != tt.want {
+			t.Errorf("CodeForStatus(%d) = %q, want %q", tt.status, got, tt.want)
+		}
+	}
+}
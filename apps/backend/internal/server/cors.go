@@ -0,0 +1,38 @@
+package server
+
+import (
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/cors"
+)
+
+// SetCorsOrigins replaces the allowed CORS origins without restarting the
+// server. Credentials are allowed only for an explicit origin list.
+func (s *Server) SetCorsOrigins(corsOrigins string) (err error) {
+	if corsOrigins == "*" || corsOrigins == "" {
+		s.logger.Warn("CORS_ORIGINS is wildcard or empty; in production, set explicit origins")
+	}
+	corsConfig := cors.Config{
+		AllowOrigins:  corsOrigins,
+		AllowMethods:  "GET,POST,PUT,PATCH,DELETE,OPTIONS",
+		AllowHeaders:  "Content-Type,Authorization,X-Trace-ID,X-Request-ID,X-GitHub-Event,X-Hub-Signature-256,X-GitHub-Delivery",
+		ExposeHeaders: "X-Trace-ID,X-Request-ID",
+	}
+	if corsOrigins != "*" && corsOrigins != "" {
+		corsConfig.AllowCredentials = true
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("invalid CORS origins: %v", r)
+		}
+	}()
+	handler := cors.New(corsConfig)
+	s.cors.Store(&handler)
+	return nil
+}
+
+func (s *Server) corsMiddleware(c *fiber.Ctx) error {
+	return (*s.cors.Load())(c)
+}
@@ -0,0 +1,57 @@
+package server
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func allowedOrigin(t *testing.T, s *Server, origin string) string {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+	req.Header.Set(fiber.HeaderOrigin, origin)
+	resp, err := s.App().Test(req, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp.Header.Get(fiber.HeaderAccessControlAllowOrigin)
+}
+
+func newTestServer(t *testing.T, corsOrigins string) *Server {
+	t.Helper()
+	s := New(Config{CorsOrigins: corsOrigins}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	s.App().Get("/ping", func(c *fiber.Ctx) error { return c.SendString("pong") })
+	return s
+}
+
+func TestSetCorsOriginsReplacesAllowedOrigins(t *testing.T) {
+	s := newTestServer(t, "https://old.example.com")
+	if got := allowedOrigin(t, s, "https://old.example.com"); got != "https://old.example.com" {
+		t.Fatalf("allowed origin = %q before reload", got)
+	}
+
+	if err := s.SetCorsOrigins("https://new.example.com"); err != nil {
+		t.Fatal(err)
+	}
+	if got := allowedOrigin(t, s, "https://new.example.com"); got != "https://new.example.com" {
+		t.Errorf("new origin allowed as %q", got)
+	}
+	if got := allowedOrigin(t, s, "https://old.example.com"); got != "" {
+		t.Errorf("old origin still allowed as %q", got)
+	}
+}
+
+func TestSetCorsOriginsKeepsOriginsOnInvalidInput(t *testing.T) {
+	s := newTestServer(t, "https://app.example.com")
+
+	if err := s.SetCorsOrigins("not an origin"); err == nil {
+		t.Fatal("SetCorsOrigins accepted an invalid origin")
+	}
+	if got := allowedOrigin(t, s, "https://app.example.com"); got != "https://app.example.com" {
+		t.Errorf("allowed origin = %q after a failed reload", got)
+	}
+}
@@ -2,17 +2,20 @@ package server
 
 import (
 	"fmt"
+	"io"
 	"log/slog"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
-	"github.com/gofiber/fiber/v2/middleware/cors"
 	"github.com/gofiber/fiber/v2/middleware/limiter"
 	"github.com/gofiber/fiber/v2/middleware/logger"
 	"github.com/gofiber/fiber/v2/middleware/recover"
 
 	"github.com/paasdeploy/backend/internal/middleware"
 	"github.com/paasdeploy/backend/internal/response"
+	"github.com/paasdeploy/shared/pkg/docker"
 )
 
 const (
@@ -20,6 +23,11 @@ const (
 	apiRateLimitWindow  = 1 * time.Minute
 	authRateLimitMax    = 10
 	authRateLimitWindow = 1 * time.Minute
+
+	// uploadBodyLimit leaves room for container file uploads plus the
+	// multipart envelope. Every other route keeps fiber's default limit.
+	uploadBodyLimit  = docker.MaxContainerFileBytes + 2<<20
+	uploadPathSuffix = "/files/upload"
 )
 
 type Config struct {
@@ -35,6 +43,7 @@ type Server struct {
 	app    *fiber.App
 	config Config
 	logger *slog.Logger
+	cors   atomic.Pointer[fiber.Handler]
 }
 
 func New(cfg Config, log *slog.Logger) *Server {
@@ -44,7 +53,11 @@ func New(cfg Config, log *slog.Logger) *Server {
 		WriteTimeout:          cfg.WriteTimeout,
 		IdleTimeout:           cfg.IdleTimeout,
 		DisableStartupMessage: true,
-		ErrorHandler:          customErrorHandler(log),
+		// Bodies above the default limit are streamed instead of rejected
+		// so limitBody can let uploads through; it rejects the rest.
+		StreamRequestBody:            true,
+		DisablePreParseMultipartForm: true,
+		ErrorHandler:                 customErrorHandler(log),
 	})
 
 	s := &Server{
@@ -65,22 +78,14 @@ func (s *Server) setupMiddlewares() {
 
 	s.app.Use(middleware.TraceID())
 
+	s.app.Use(limitBody)
+
 	s.app.Use(securityHeaders)
 
-	corsOrigins := s.config.CorsOrigins
-	if corsOrigins == "*" || corsOrigins == "" {
-		s.logger.Warn("CORS_ORIGINS is wildcard or empty; in production, set explicit origins")
-	}
-	corsConfig := cors.Config{
-		AllowOrigins:  corsOrigins,
-		AllowMethods:  "GET,POST,PUT,PATCH,DELETE,OPTIONS",
-		AllowHeaders:  "Content-Type,Authorization,X-Trace-ID,X-GitHub-Event,X-Hub-Signature-256,X-GitHub-Delivery",
-		ExposeHeaders: "X-Trace-ID",
-	}
-	if corsOrigins != "*" && corsOrigins != "" {
-		corsConfig.AllowCredentials = true
+	if err := s.SetCorsOrigins(s.config.CorsOrigins); err != nil {
+		panic(err)
 	}
-	s.app.Use(cors.New(corsConfig))
+	s.app.Use(s.corsMiddleware)
 
 	s.app.Use(logger.New(logger.Config{
 		Format:     "${time} | ${status} | ${latency} | ${method} ${path} | trace=${locals:traceId}\n",
@@ -98,13 +103,7 @@ func (s *Server) setupMiddlewares() {
 			return c.IP()
 		},
 		LimitReached: func(c *fiber.Ctx) error {
-			return c.Status(fiber.StatusTooManyRequests).JSON(response.Envelope{
-				Success: false,
-				Error: &response.ErrorInfo{
-					Code:    response.ErrCodeRateLimited,
-					Message: "too many requests",
-				},
-			})
+			return response.RateLimited(c, "too many requests")
 		},
 		Next: func(c *fiber.Ctx) bool {
 			return c.Path() == "/health" || c.Path() == "/events/deploys"
@@ -120,13 +119,7 @@ func AuthRateLimiter() fiber.Handler {
 			return c.IP()
 		},
 		LimitReached: func(c *fiber.Ctx) error {
-			return c.Status(fiber.StatusTooManyRequests).JSON(response.Envelope{
-				Success: false,
-				Error: &response.ErrorInfo{
-					Code:    response.ErrCodeRateLimited,
-					Message: "too many authentication attempts",
-				},
-			})
+			return response.RateLimited(c, "too many authentication attempts")
 		},
 	})
 }
@@ -144,6 +137,37 @@ func securityHeaders(c *fiber.Ctx) error {
 	return c.Next()
 }
 
+// limitBody enforces fiber's default body limit, raised to uploadBodyLimit
+// for container file uploads. Chunked bodies are read up to the limit here,
+// since their length is only known once read.
+func limitBody(c *fiber.Ctx) error {
+	limit := fiber.DefaultBodyLimit
+	if c.Method() == fiber.MethodPost && strings.HasSuffix(c.Path(), uploadPathSuffix) {
+		limit = uploadBodyLimit
+	}
+
+	// The rest of a rejected body is never read, so the connection cannot
+	// carry another request.
+	req := c.Request()
+	if req.Header.ContentLength() > limit {
+		c.Context().SetConnectionClose()
+		return fiber.ErrRequestEntityTooLarge
+	}
+	if req.Header.ContentLength() == -1 && req.IsBodyStream() {
+		body, err := io.ReadAll(io.LimitReader(req.BodyStream(), int64(limit)+1))
+		if err != nil {
+			c.Context().SetConnectionClose()
+			return fiber.ErrBadRequest
+		}
+		if len(body) > limit {
+			c.Context().SetConnectionClose()
+			return fiber.ErrRequestEntityTooLarge
+		}
+		req.SetBody(body)
+	}
+	return c.Next()
+}
+
 func (s *Server) App() *fiber.App {
 	return s.app
 }
@@ -162,27 +186,11 @@ func (s *Server) Shutdown() error {
 func customErrorHandler(log *slog.Logger) fiber.ErrorHandler {
 	return func(c *fiber.Ctx, err error) error {
 		code := fiber.StatusInternalServerError
-		errCode := response.ErrCodeInternal
 		message := "internal server error"
 
 		if e, ok := err.(*fiber.Error); ok {
 			code = e.Code
 			message = e.Message
-
-			switch code {
-			case fiber.StatusBadRequest:
-				errCode = response.ErrCodeInvalidPayload
-			case fiber.StatusUnauthorized:
-				errCode = response.ErrCodeUnauthorized
-			case fiber.StatusForbidden:
-				errCode = response.ErrCodeForbidden
-			case fiber.StatusNotFound:
-				errCode = response.ErrCodeNotFound
-			case fiber.StatusConflict:
-				errCode = response.ErrCodeConflict
-			case fiber.StatusTooManyRequests:
-				errCode = response.ErrCodeRateLimited
-			}
 		}
 
 		traceID := middleware.GetTraceID(c)
@@ -195,16 +203,6 @@ func customErrorHandler(log *slog.Logger) fiber.ErrorHandler {
 			"traceId", traceID,
 		)
 
-		return c.Status(code).JSON(response.Envelope{
-			Success: false,
-			Data:    nil,
-			Error: &response.ErrorInfo{
-				Code:    errCode,
-				Message: message,
-			},
-			Meta: response.Meta{
-				TraceID: traceID,
-			},
-		})
+		return response.Fail(c, code, response.CodeForStatus(code), message)
 	}
 }
@@ -0,0 +1,89 @@
+package server
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func newBodyLimitServer(t *testing.T) *Server {
+	t.Helper()
+	s := newTestServer(t, "")
+	s.App().Post("/echo", func(c *fiber.Ctx) error {
+		return c.SendString(strconv.Itoa(len(c.Body())))
+	})
+	s.App().Post("/api/containers/:id/files/upload", func(c *fiber.Ctx) error {
+		file, err := c.FormFile("file")
+		if err != nil {
+			return fiber.ErrBadRequest
+		}
+		return c.SendString(strconv.FormatInt(file.Size, 10))
+	})
+	return s
+}
+
+func postBody(t *testing.T, s *Server, req *http.Request) (int, string) {
+	t.Helper()
+	resp, err := s.App().Test(req, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, string(body)
+}
+
+func TestLimitBodyKeepsDefaultLimit(t *testing.T) {
+	s := newBodyLimitServer(t)
+
+	small := httptest.NewRequest(http.MethodPost, "/echo", bytes.NewReader(make([]byte, 1024)))
+	if status, body := postBody(t, s, small); status != http.StatusOK || body != "1024" {
+		t.Errorf("small body: status = %d, body = %q", status, body)
+	}
+
+	large := httptest.NewRequest(http.MethodPost, "/echo", bytes.NewReader(make([]byte, fiber.DefaultBodyLimit+1)))
+	if status, _ := postBody(t, s, large); status != http.StatusRequestEntityTooLarge {
+		t.Errorf("large body: status = %d, want %d", status, http.StatusRequestEntityTooLarge)
+	}
+
+	chunked := httptest.NewRequest(http.MethodPost, "/echo", io.MultiReader(bytes.NewReader(make([]byte, fiber.DefaultBodyLimit+1))))
+	chunked.ContentLength = -1
+	chunked.TransferEncoding = []string{"chunked"}
+	if status, _ := postBody(t, s, chunked); status != http.StatusRequestEntityTooLarge {
+		t.Errorf("chunked body: status = %d, want %d", status, http.StatusRequestEntityTooLarge)
+	}
+}
+
+func TestLimitBodyAllowsFileUploads(t *testing.T) {
+	s := newBodyLimitServer(t)
+	size := 3 * fiber.DefaultBodyLimit
+
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	part, err := w.CreateFormFile("file", "dump.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := part.Write(make([]byte, size)); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/api/containers/web/files/upload", &buf)
+	req.Header.Set(fiber.HeaderContentType, w.FormDataContentType())
+	if status, body := postBody(t, s, req); status != http.StatusOK || body != strconv.Itoa(size) {
+		t.Errorf("upload: status = %d, body = %q, want %d bytes", status, body, size)
+	}
+
+	tooLarge := httptest.NewRequest(http.MethodPost, "/api/containers/web/files/upload", bytes.NewReader(make([]byte, uploadBodyLimit+1)))
+	if status, _ := postBody(t, s, tooLarge); status != http.StatusRequestEntityTooLarge {
+		t.Errorf("oversized upload: status = %d, want %d", status, http.StatusRequestEntityTooLarge)
+	}
+}
@@ -5,24 +5,50 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"strings"
 
 	"github.com/paasdeploy/backend/internal/domain"
 	"github.com/paasdeploy/backend/internal/ghclient"
 	"github.com/paasdeploy/backend/internal/webhook"
+	"github.com/paasdeploy/shared/pkg/tracing"
+)
+
+const (
+	// purgeDeploymentsLimit bounds how many deployments of a purged app are
+	// checked for archived logs.
+	purgeDeploymentsLimit = 10000
+	// diffDeploymentsLimit bounds how many deployments are scanned for the
+	// commits between two deployments when GitHub cannot compare them.
+	diffDeploymentsLimit = 500
 )
 
 type AppCleaner interface {
 	CleanApp(ctx context.Context, appID, appName string) error
 }
 
+// LogArchive reads and deletes deployment logs moved to object storage.
+type LogArchive interface {
+	WriteFullLogs(ctx context.Context, w io.Writer, d *domain.Deployment) error
+	DeleteLogs(ctx context.Context, d *domain.Deployment) error
+}
+
+// AppRunner stops and starts the container of an app wherever it runs.
+type AppRunner interface {
+	StopApp(ctx context.Context, app *domain.App) error
+	StartApp(ctx context.Context, app *domain.App) error
+}
+
 type AppService struct {
 	appRepo        domain.AppRepository
 	deploymentRepo domain.DeploymentRepository
 	envVarRepo     domain.EnvVarRepository
 	webhookManager webhook.Manager
 	appCleaner     AppCleaner
+	appRunner      AppRunner
+	logArchive     LogArchive
+	quotas         *QuotaService
 	logger         *slog.Logger
 }
 
@@ -32,6 +58,7 @@ func NewAppService(
 	envVarRepo domain.EnvVarRepository,
 	webhookManager webhook.Manager,
 	appCleaner AppCleaner,
+	quotas *QuotaService,
 	logger *slog.Logger,
 ) *AppService {
 	return &AppService{
@@ -40,10 +67,19 @@ func NewAppService(
 		envVarRepo:     envVarRepo,
 		webhookManager: webhookManager,
 		appCleaner:     appCleaner,
+		quotas:         quotas,
 		logger:         logger,
 	}
 }
 
+func (s *AppService) SetAppRunner(runner AppRunner) {
+	s.appRunner = runner
+}
+
+func (s *AppService) SetLogArchive(archive LogArchive) {
+	s.logArchive = archive
+}
+
 func (s *AppService) ListApps() ([]domain.App, error) {
 	apps, err := s.appRepo.FindAll()
 	if err != nil {
@@ -55,13 +91,21 @@ func (s *AppService) ListApps() ([]domain.App, error) {
 	return apps, nil
 }
 
-func (s *AppService) ListAppsWithDeployments(userID string) ([]domain.AppWithDeployment, error) {
-	apps, err := s.appRepo.FindAllByUserID(userID)
+// ListAppsPage returns one page of the user's apps matching filter.
+func (s *AppService) ListAppsPage(userID string, filter domain.AppFilter, opts domain.ListOptions) (domain.Page[domain.AppWithDeployment], error) {
+	page, err := s.appRepo.FindPageByUserID(userID, filter, opts)
 	if err != nil {
-		return nil, err
+		return domain.Page[domain.AppWithDeployment]{}, err
 	}
+	return domain.Page[domain.AppWithDeployment]{
+		Items:      s.withLastDeployments(page.Items),
+		NextCursor: page.NextCursor,
+	}, nil
+}
+
+func (s *AppService) withLastDeployments(apps []domain.App) []domain.AppWithDeployment {
 	if len(apps) == 0 {
-		return []domain.AppWithDeployment{}, nil
+		return []domain.AppWithDeployment{}
 	}
 
 	appIDs := make([]string, len(apps))
@@ -83,7 +127,7 @@ func (s *AppService) ListAppsWithDeployments(userID string) ([]domain.AppWithDep
 		}
 	}
 
-	return result, nil
+	return result
 }
 
 func (s *AppService) ListAppsByServerID(serverID, userID string) ([]domain.AppWithDeployment, error) {
@@ -166,6 +210,18 @@ func (s *AppService) CreateApp(ctx context.Context, input domain.CreateAppInput)
 		return nil, domain.ErrAlreadyExists
 	}
 
+	trashed, err := s.appRepo.FindDeletedByName(input.Name)
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		return nil, err
+	}
+	if trashed != nil {
+		return nil, domain.ErrAlreadyExists
+	}
+
+	if err := s.checkQuota(ctx, input.UserID); err != nil {
+		return nil, err
+	}
+
 	app, err := s.appRepo.Create(input)
 	if err != nil {
 		return nil, err
@@ -178,6 +234,25 @@ func (s *AppService) CreateApp(ctx context.Context, input domain.CreateAppInput)
 	return app, nil
 }
 
+// ImportApp creates an app together with its env vars, removing the app
+// again if the vars cannot be stored.
+func (s *AppService) ImportApp(ctx context.Context, input domain.CreateAppInput, envVars []domain.CreateEnvVarInput) (*domain.App, error) {
+	app, err := s.CreateApp(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	if len(envVars) == 0 {
+		return app, nil
+	}
+	if err := s.envVarRepo.BulkUpsert(app.ID, envVars); err != nil {
+		if delErr := s.appRepo.HardDelete(app.ID); delErr != nil {
+			s.logger.Error("failed to remove app after env var import failure", "app_id", app.ID, "error", delErr)
+		}
+		return nil, fmt.Errorf("import env vars: %w", err)
+	}
+	return app, nil
+}
+
 func (s *AppService) setupWebhookAsync(ctx context.Context, app *domain.App) {
 	result, err := s.webhookManager.Setup(ctx, webhook.SetupInput{
 		RepositoryURL: app.RepositoryURL,
@@ -217,26 +292,29 @@ func (s *AppService) UpdateApp(id string, input domain.UpdateAppInput) (*domain.
 	return s.appRepo.Update(id, input)
 }
 
+// DeleteApp moves an app to the trash and stops its container. Webhooks,
+// images, files and volumes are kept until the app is purged, so the app
+// can still be restored.
 func (s *AppService) DeleteApp(ctx context.Context, id string) error {
 	app, err := s.appRepo.FindByID(id)
 	if err != nil {
 		return err
 	}
 
-	if s.webhookManager != nil && app.WebhookID != nil {
-		go s.removeWebhookAsync(ctx, app)
+	if err := s.appRepo.Delete(id); err != nil {
+		return err
 	}
 
-	if s.appCleaner != nil {
-		go s.cleanAppAsync(ctx, app)
+	if s.appRunner != nil {
+		go s.runAppAsync(ctx, app, "stop", s.appRunner.StopApp)
 	}
 
-	return s.appRepo.Delete(id)
+	return nil
 }
 
-func (s *AppService) cleanAppAsync(ctx context.Context, app *domain.App) {
-	if err := s.appCleaner.CleanApp(ctx, app.ID, app.Name); err != nil {
-		s.logger.Warn("failed to clean app resources",
+func (s *AppService) runAppAsync(ctx context.Context, app *domain.App, action string, run func(context.Context, *domain.App) error) {
+	if err := run(ctx, app); err != nil {
+		s.logger.WarnContext(ctx, "failed to "+action+" app container",
 			"app_id", app.ID,
 			"app_name", app.Name,
 			"error", err,
@@ -244,34 +322,69 @@ func (s *AppService) cleanAppAsync(ctx context.Context, app *domain.App) {
 	}
 }
 
-func (s *AppService) removeWebhookAsync(ctx context.Context, app *domain.App) {
-	if app.WebhookID == nil {
-		return
+func (s *AppService) ListTrash(userID string) ([]domain.App, error) {
+	apps, err := s.appRepo.FindDeletedByUserID(userID)
+	if err != nil {
+		return nil, err
 	}
+	if apps == nil {
+		return []domain.App{}, nil
+	}
+	return apps, nil
+}
 
-	err := s.webhookManager.Remove(ctx, webhook.RemoveInput{
-		RepositoryURL: app.RepositoryURL,
-		WebhookID:     *app.WebhookID,
-	})
+func (s *AppService) GetDeletedAppForUser(id, userID string) (*domain.App, error) {
+	app, err := s.appRepo.FindDeletedByID(id)
 	if err != nil {
-		s.logger.Error("failed to remove webhook",
-			"app_id", app.ID,
-			"app_name", app.Name,
-			"webhook_id", *app.WebhookID,
-			"error", err,
-		)
-		return
+		return nil, err
 	}
+	if app.UserID != userID {
+		return nil, domain.ErrNotFound
+	}
+	return app, nil
+}
 
-	s.logger.Info("webhook removed",
-		"app_id", app.ID,
-		"app_name", app.Name,
-		"webhook_id", *app.WebhookID,
-	)
+// RestoreApp brings an app back from the trash and starts its container
+// again. It fails with ErrAlreadyExists when another app took its name.
+func (s *AppService) RestoreApp(ctx context.Context, id, userID string) (*domain.App, error) {
+	app, err := s.GetDeletedAppForUser(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.appRepo.FindByName(app.Name)
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, domain.ErrAlreadyExists
+	}
+
+	if err := s.checkQuota(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	if err := s.appRepo.Restore(id); err != nil {
+		return nil, err
+	}
+
+	restored, err := s.appRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.appRunner != nil {
+		go s.runAppAsync(ctx, restored, "start", s.appRunner.StartApp)
+	}
+
+	return restored, nil
 }
 
 func (s *AppService) PurgeApp(ctx context.Context, id string) error {
 	app, err := s.appRepo.FindByID(id)
+	if errors.Is(err, domain.ErrNotFound) {
+		app, err = s.appRepo.FindDeletedByID(id)
+	}
 	if err != nil {
 		return err
 	}
@@ -312,6 +425,8 @@ func (s *AppService) PurgeApp(ctx context.Context, id string) error {
 		}
 	}
 
+	s.deleteArchivedLogs(ctx, app.ID)
+
 	if err := s.deploymentRepo.DeleteByAppID(app.ID); err != nil {
 		s.logger.Warn("failed to delete deployments",
 			"app_id", app.ID,
@@ -331,23 +446,164 @@ func (s *AppService) PurgeApp(ctx context.Context, id string) error {
 	return nil
 }
 
-func (s *AppService) ListDeployments(appID string) ([]domain.Deployment, error) {
-	_, err := s.appRepo.FindByID(appID)
+func (s *AppService) ListDeploymentsPage(appID string, filter domain.DeploymentFilter, opts domain.ListOptions) (domain.Page[domain.Deployment], error) {
+	return s.deploymentRepo.FindPageByAppID(appID, filter, opts)
+}
+
+// WriteDeploymentLogs writes the complete logs of a deployment of appID,
+// including the parts archived to object storage.
+func (s *AppService) WriteDeploymentLogs(ctx context.Context, appID, deployID string, w io.Writer) error {
+	d, err := s.deploymentRepo.FindByID(deployID)
+	if err != nil {
+		return err
+	}
+	if d.AppID != appID {
+		return domain.ErrNotFound
+	}
+	if d.ArchivedLogChunks == 0 || s.logArchive == nil {
+		_, err := io.WriteString(w, d.Logs)
+		return err
+	}
+	return s.logArchive.WriteFullLogs(ctx, w, d)
+}
+
+// DiffDeployments compares two deployments of the app: the commits between
+// them, and the env vars, configuration and image size they ran with.
+func (s *AppService) DiffDeployments(ctx context.Context, appID, fromID, toID string) (*domain.DeploymentDiff, error) {
+	app, err := s.appRepo.FindByID(appID)
+	if err != nil {
+		return nil, err
+	}
+	from, err := s.findAppDeployment(appID, fromID)
+	if err != nil {
+		return nil, err
+	}
+	to, err := s.findAppDeployment(appID, toID)
+	if err != nil {
+		return nil, err
+	}
+
+	fromSnapshot, err := s.deploymentRepo.FindSnapshot(from.ID)
 	if err != nil {
 		return nil, err
 	}
+	toSnapshot, err := s.deploymentRepo.FindSnapshot(to.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	from.Logs, to.Logs = "", ""
+	diff := &domain.DeploymentDiff{
+		From:    from,
+		To:      to,
+		Commits: s.commitRange(ctx, app, from, to),
+	}
+	diff.CompareSnapshots(fromSnapshot, toSnapshot)
+	return diff, nil
+}
 
-	deployments, err := s.deploymentRepo.FindByAppID(appID, 50)
+func (s *AppService) findAppDeployment(appID, deployID string) (*domain.Deployment, error) {
+	d, err := s.deploymentRepo.FindByID(deployID)
 	if err != nil {
 		return nil, err
 	}
-	if deployments == nil {
-		deployments = []domain.Deployment{}
+	if d.AppID != appID {
+		return nil, domain.ErrNotFound
+	}
+	return d, nil
+}
+
+// commitRange lists the commits from one deployment to the other, from
+// GitHub when the repository is there and otherwise from the commits of the
+// deployments made in between.
+func (s *AppService) commitRange(ctx context.Context, app *domain.App, from, to *domain.Deployment) domain.CommitRange {
+	commitRange := domain.CommitRange{
+		From:       from.CommitSHA,
+		To:         to.CommitSHA,
+		CompareURL: githubCompareURL(app.RepositoryURL, from.CommitSHA, to.CommitSHA),
+		Commits:    []domain.DiffCommit{},
+	}
+	if from.CommitSHA == to.CommitSHA {
+		return commitRange
+	}
+
+	if s.webhookManager != nil {
+		commits, err := s.webhookManager.CompareCommits(ctx, app.RepositoryURL, from.CommitSHA, to.CommitSHA)
+		if err != nil {
+			s.logger.Debug("failed to compare commits", "app_id", app.ID, "error", err)
+		} else if commits != nil {
+			for _, c := range commits {
+				commitRange.Commits = append(commitRange.Commits, domain.DiffCommit{
+					SHA: c.SHA, Message: c.Message, Author: c.Author, Date: c.Date, URL: c.URL,
+				})
+			}
+			return commitRange
+		}
+	}
+
+	older, newer := from, to
+	if older.CreatedAt.After(newer.CreatedAt) {
+		older, newer = newer, older
+	}
+	deployments, err := s.deploymentRepo.FindByAppID(app.ID, diffDeploymentsLimit)
+	if err != nil {
+		s.logger.Warn("failed to list deployments for diff", "app_id", app.ID, "error", err)
+		return commitRange
+	}
+	seen := map[string]bool{older.CommitSHA: true}
+	for i := len(deployments) - 1; i >= 0; i-- {
+		d := deployments[i]
+		if !d.CreatedAt.After(older.CreatedAt) || d.CreatedAt.After(newer.CreatedAt) || seen[d.CommitSHA] {
+			continue
+		}
+		seen[d.CommitSHA] = true
+		commitRange.Commits = append(commitRange.Commits, domain.DiffCommit{
+			SHA: d.CommitSHA, Message: d.CommitMessage, Date: d.CreatedAt,
+		})
+	}
+	return commitRange
+}
+
+// githubCompareURL returns the GitHub page comparing two commits, or "" for
+// repositories hosted elsewhere.
+func githubCompareURL(repoURL, base, head string) string {
+	repoURL = strings.TrimSuffix(strings.TrimSuffix(strings.TrimSpace(repoURL), "/"), ".git")
+	if path, ok := strings.CutPrefix(repoURL, "git@github.com:"); ok {
+		repoURL = "https://github.com/" + path
+	}
+	if !strings.HasPrefix(repoURL, "https://github.com/") || base == "" || head == "" {
+		return ""
+	}
+	return repoURL + "/compare/" + base + "..." + head
+}
+
+func (s *AppService) deleteArchivedLogs(ctx context.Context, appID string) {
+	if s.logArchive == nil {
+		return
+	}
+	deployments, err := s.deploymentRepo.FindByAppID(appID, purgeDeploymentsLimit)
+	if err != nil {
+		s.logger.Warn("failed to list deployments for log archive cleanup",
+			"app_id", appID,
+			"error", err,
+		)
+		return
+	}
+	for i := range deployments {
+		if deployments[i].ArchivedLogChunks == 0 {
+			continue
+		}
+		if err := s.logArchive.DeleteLogs(ctx, &deployments[i]); err != nil {
+			s.logger.Warn("failed to delete archived deployment logs",
+				"app_id", appID,
+				"deploy_id", deployments[i].ID,
+				"error", err,
+			)
+		}
 	}
-	return deployments, nil
 }
 
-func (s *AppService) TriggerDeploy(appID string, commitSHA string) (*domain.Deployment, error) {
+func (s *AppService) TriggerDeploy(ctx context.Context, appID string, commitSHA string) (*domain.Deployment, error) {
 	app, err := s.appRepo.FindByID(appID)
 	if err != nil {
 		return nil, err
@@ -361,6 +617,10 @@ func (s *AppService) TriggerDeploy(appID string, commitSHA string) (*domain.Depl
 		return nil, domain.ErrDeployInProgress
 	}
 
+	if err := s.checkBuildTime(ctx, app); err != nil {
+		return nil, err
+	}
+
 	if commitSHA == "" {
 		commitSHA = "HEAD"
 	}
@@ -369,17 +629,22 @@ func (s *AppService) TriggerDeploy(appID string, commitSHA string) (*domain.Depl
 		AppID:         app.ID,
 		CommitSHA:     commitSHA,
 		CommitMessage: "Manual deploy triggered",
+		TraceID:       tracing.ID(ctx),
 	}
 
 	return s.deploymentRepo.Create(input)
 }
 
-func (s *AppService) TriggerRollback(appID string) (*domain.Deployment, error) {
-	_, err := s.appRepo.FindByID(appID)
+func (s *AppService) TriggerRollback(ctx context.Context, appID string) (*domain.Deployment, error) {
+	app, err := s.appRepo.FindByID(appID)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := s.checkBuildTime(ctx, app); err != nil {
+		return nil, err
+	}
+
 	latestSuccess, err := s.deploymentRepo.FindLatestByAppID(appID)
 	if err != nil {
 		if errors.Is(err, domain.ErrNotFound) {
@@ -392,11 +657,26 @@ func (s *AppService) TriggerRollback(appID string) (*domain.Deployment, error) {
 		AppID:         appID,
 		CommitSHA:     latestSuccess.CommitSHA,
 		CommitMessage: "Rollback to " + latestSuccess.CommitSHA[:7],
+		TraceID:       tracing.ID(ctx),
 	}
 
 	return s.deploymentRepo.Create(input)
 }
 
+func (s *AppService) checkQuota(ctx context.Context, userID string) error {
+	if s.quotas == nil {
+		return nil
+	}
+	return s.quotas.CheckCreateApp(ctx, userID)
+}
+
+func (s *AppService) checkBuildTime(ctx context.Context, app *domain.App) error {
+	if s.quotas == nil {
+		return nil
+	}
+	return s.quotas.CheckBuildTime(ctx, app)
+}
+
 func (s *AppService) validateCreateInput(input domain.CreateAppInput) error {
 	if input.Name == "" {
 		return domain.ErrInvalidInput
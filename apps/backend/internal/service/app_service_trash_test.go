@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/paasdeploy/backend/internal/domain"
+)
+
+type fakeAppRunner struct {
+	stopped chan string
+	started chan string
+}
+
+func newFakeAppRunner() *fakeAppRunner {
+	return &fakeAppRunner{stopped: make(chan string, 1), started: make(chan string, 1)}
+}
+
+func (r *fakeAppRunner) StopApp(_ context.Context, app *domain.App) error {
+	r.stopped <- app.ID
+	return nil
+}
+
+func (r *fakeAppRunner) StartApp(_ context.Context, app *domain.App) error {
+	r.started <- app.ID
+	return nil
+}
+
+func expectRun(t *testing.T, ch chan string, appID, action string) {
+	t.Helper()
+	select {
+	case id := <-ch:
+		if id != appID {
+			t.Errorf("%s app %s, want %s", action, id, appID)
+		}
+	case <-time.After(time.Second):
+		t.Errorf("app %s was not %s", appID, action)
+	}
+}
+
+func newTrashTestService(apps ...domain.App) (*AppService, *fakeAppRepo, *fakeAppRunner) {
+	repo := &fakeAppRepo{apps: apps}
+	runner := newFakeAppRunner()
+	svc := NewAppService(repo, nil, nil, nil, nil, nil, testLogger())
+	svc.SetAppRunner(runner)
+	return svc, repo, runner
+}
+
+func activeApp(id, userID string) domain.App {
+	return domain.App{ID: id, UserID: userID, Name: id, Status: domain.AppStatusActive}
+}
+
+func TestDeleteAppMovesItToTheTrash(t *testing.T) {
+	svc, _, runner := newTrashTestService(activeApp("web", "u1"), activeApp("api", "u1"))
+
+	if err := svc.DeleteApp(context.Background(), "web"); err != nil {
+		t.Fatal(err)
+	}
+	expectRun(t, runner.stopped, "web", "stopped")
+
+	if _, err := svc.GetApp("web"); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("GetApp on trashed app = %v, want ErrNotFound", err)
+	}
+	apps, err := svc.ListApps()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(apps) != 1 || apps[0].ID != "api" {
+		t.Errorf("ListApps = %v, want only api", apps)
+	}
+	trash, err := svc.ListTrash("u1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(trash) != 1 || trash[0].ID != "web" || trash[0].DeletedAt == nil {
+		t.Errorf("ListTrash = %v, want web with a deletion time", trash)
+	}
+	if other, _ := svc.ListTrash("u2"); len(other) != 0 {
+		t.Errorf("ListTrash for another user = %v, want empty", other)
+	}
+}
+
+func TestRestoreAppFromTheTrash(t *testing.T) {
+	svc, _, runner := newTrashTestService(activeApp("web", "u1"))
+	ctx := context.Background()
+	if err := svc.DeleteApp(ctx, "web"); err != nil {
+		t.Fatal(err)
+	}
+	expectRun(t, runner.stopped, "web", "stopped")
+
+	if _, err := svc.RestoreApp(ctx, "web", "u2"); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("RestoreApp by another user = %v, want ErrNotFound", err)
+	}
+
+	restored, err := svc.RestoreApp(ctx, "web", "u1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if restored.Status != domain.AppStatusActive || restored.DeletedAt != nil {
+		t.Errorf("restored app = %+v, want active outside the trash", restored)
+	}
+	expectRun(t, runner.started, "web", "started")
+
+	if _, err := svc.GetApp("web"); err != nil {
+		t.Errorf("GetApp after restore = %v", err)
+	}
+	if trash, _ := svc.ListTrash("u1"); len(trash) != 0 {
+		t.Errorf("ListTrash after restore = %v, want empty", trash)
+	}
+}
+
+func TestRestoreAppFailsWhenTheNameWasTaken(t *testing.T) {
+	deletedAt := time.Now().Add(-time.Hour)
+	old := domain.App{ID: "old-web", UserID: "u1", Name: "web", Status: domain.AppStatusDeleted, DeletedAt: &deletedAt}
+	taken := domain.App{ID: "new-web", UserID: "u1", Name: "web", Status: domain.AppStatusActive}
+	svc, _, _ := newTrashTestService(old, taken)
+
+	if _, err := svc.RestoreApp(context.Background(), "old-web", "u1"); !errors.Is(err, domain.ErrAlreadyExists) {
+		t.Errorf("RestoreApp = %v, want ErrAlreadyExists", err)
+	}
+}
+
+func TestCreateAppRejectsNamesInTheTrash(t *testing.T) {
+	deletedAt := time.Now()
+	svc, _, _ := newTrashTestService(domain.App{ID: "web", UserID: "u1", Name: "web", Status: domain.AppStatusDeleted, DeletedAt: &deletedAt})
+
+	_, err := svc.CreateApp(context.Background(), domain.CreateAppInput{
+		UserID:        "u1",
+		Name:          "web",
+		RepositoryURL: "https://github.com/acme/web",
+	})
+	if !errors.Is(err, domain.ErrAlreadyExists) {
+		t.Errorf("CreateApp = %v, want ErrAlreadyExists", err)
+	}
+}
@@ -0,0 +1,208 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/paasdeploy/backend/internal/domain"
+)
+
+// SpecDomainReconciler attaches and detaches custom domains, DNS records
+// included, on behalf of an app spec.
+type SpecDomainReconciler interface {
+	ApplySpecDomains(ctx context.Context, userID string, app *domain.App, toAdd []domain.AppSpecDomain, toRemove []domain.CustomDomain) error
+}
+
+// AppSpecResult is the outcome of applying an app spec. Changes are what
+// was applied, or what would be on a dry run.
+type AppSpecResult struct {
+	App     *domain.App         `json:"app,omitempty"`
+	Created bool                `json:"created"`
+	DryRun  bool                `json:"dryRun"`
+	Changes []domain.SpecChange `json:"changes"`
+}
+
+// AppSpecService reconciles apps with declarative specs, for infrastructure
+// as code tooling.
+type AppSpecService struct {
+	appService *AppService
+	appRepo    domain.AppRepository
+	envVarRepo domain.EnvVarRepository
+	domainRepo domain.CustomDomainRepository
+	domains    SpecDomainReconciler
+	logger     *slog.Logger
+}
+
+// NewAppSpecService creates the service; domains may be nil when custom
+// domains are unavailable, in which case specs changing domains are
+// rejected.
+func NewAppSpecService(
+	appService *AppService,
+	appRepo domain.AppRepository,
+	envVarRepo domain.EnvVarRepository,
+	domainRepo domain.CustomDomainRepository,
+	domains SpecDomainReconciler,
+	logger *slog.Logger,
+) *AppSpecService {
+	return &AppSpecService{
+		appService: appService,
+		appRepo:    appRepo,
+		envVarRepo: envVarRepo,
+		domainRepo: domainRepo,
+		domains:    domains,
+		logger:     logger.With("service", "app_spec"),
+	}
+}
+
+// Apply makes the app named in spec match it, creating the app when it does
+// not exist. Names are global, so a name owned by another user is reported
+// as domain.ErrAlreadyExists.
+func (s *AppSpecService) Apply(ctx context.Context, user *domain.User, spec domain.AppSpec, dryRun bool) (*AppSpecResult, error) {
+	spec.Normalize()
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+
+	app, err := s.appRepo.FindByName(spec.Name)
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		return nil, err
+	}
+	if err != nil {
+		app = nil
+	}
+	if app != nil && app.UserID != user.ID {
+		return nil, domain.ErrAlreadyExists
+	}
+	if err := s.checkServer(user, app, spec); err != nil {
+		return nil, err
+	}
+
+	var envVars []domain.EnvVar
+	var domains []domain.CustomDomain
+	if app != nil {
+		if envVars, err = s.envVarRepo.FindByAppID(app.ID); err != nil {
+			return nil, fmt.Errorf("list env vars: %w", err)
+		}
+		if domains, err = s.domainRepo.FindByAppID(ctx, app.ID); err != nil {
+			return nil, fmt.Errorf("list domains: %w", err)
+		}
+	}
+	toAdd, toRemove := domain.DiffSpecDomains(domains, spec.Domains)
+	if s.domains == nil && (len(toAdd) > 0 || len(toRemove) > 0) {
+		return nil, fmt.Errorf("%w: custom domains are not configured on this instance", domain.ErrInvalidInput)
+	}
+
+	result := &AppSpecResult{
+		App:     app,
+		Created: app == nil,
+		DryRun:  dryRun,
+		Changes: domain.DiffAppSpec(app, envVars, domains, spec),
+	}
+	if result.Changes == nil {
+		result.Changes = []domain.SpecChange{}
+	}
+	if dryRun || len(result.Changes) == 0 {
+		return result, nil
+	}
+
+	if app == nil {
+		app, err = s.create(ctx, user, spec)
+	} else {
+		app, err = s.update(app, spec)
+	}
+	if err != nil {
+		return nil, err
+	}
+	result.App = app
+
+	if err := s.applyEnv(app.ID, envVars, spec); err != nil {
+		return nil, err
+	}
+	if s.domains != nil {
+		if err := s.domains.ApplySpecDomains(ctx, user.ID, app, toAdd, toRemove); err != nil {
+			return nil, err
+		}
+	}
+
+	s.logger.Info("app spec applied", "appId", app.ID, "name", app.Name, "created", result.Created, "changes", len(result.Changes))
+	return result, nil
+}
+
+// checkServer keeps local apps admin-only, as on create, and refuses to move
+// an existing app, which needs a migration rather than an update.
+func (s *AppSpecService) checkServer(user *domain.User, app *domain.App, spec domain.AppSpec) error {
+	if app == nil {
+		if (spec.ServerID == nil || *spec.ServerID == "") && !user.IsAdmin() {
+			return domain.ErrForbidden
+		}
+		return nil
+	}
+	if spec.ServerID == nil {
+		return nil
+	}
+	current := ""
+	if app.ServerID != nil {
+		current = *app.ServerID
+	}
+	if *spec.ServerID != current {
+		return fmt.Errorf("%w: serverId cannot be changed by a spec, migrate the app instead", domain.ErrInvalidInput)
+	}
+	return nil
+}
+
+func (s *AppSpecService) create(ctx context.Context, user *domain.User, spec domain.AppSpec) (*domain.App, error) {
+	config, err := domain.WithResources(nil, spec.Resources)
+	if err != nil {
+		return nil, err
+	}
+	return s.appService.CreateApp(ctx, domain.CreateAppInput{
+		UserID:        user.ID,
+		Name:          spec.Name,
+		RepositoryURL: spec.RepositoryURL,
+		Branch:        spec.Branch,
+		Workdir:       spec.Workdir,
+		ServerID:      spec.ServerID,
+		Config:        config,
+	})
+}
+
+func (s *AppSpecService) update(app *domain.App, spec domain.AppSpec) (*domain.App, error) {
+	if err := s.appService.validateCreateInput(domain.CreateAppInput{Name: app.Name, RepositoryURL: spec.RepositoryURL}); err != nil {
+		return nil, err
+	}
+	config, err := domain.WithResources(app.Config, spec.Resources)
+	if err != nil {
+		return nil, fmt.Errorf("update app config: %w", err)
+	}
+	return s.appService.UpdateApp(app.ID, domain.UpdateAppInput{
+		RepositoryURL: &spec.RepositoryURL,
+		Branch:        &spec.Branch,
+		Workdir:       &spec.Workdir,
+		Config:        &config,
+	})
+}
+
+// applyEnv upserts the spec's env vars and deletes the ones it no longer
+// lists.
+func (s *AppSpecService) applyEnv(appID string, current []domain.EnvVar, spec domain.AppSpec) error {
+	if len(spec.Env) > 0 {
+		if err := s.envVarRepo.BulkUpsert(appID, spec.EnvVarInputs()); err != nil {
+			return fmt.Errorf("upsert env vars: %w", err)
+		}
+	}
+	wanted := make(map[string]bool, len(spec.Env))
+	for _, v := range spec.Env {
+		wanted[v.Key] = true
+	}
+	for _, v := range current {
+		if wanted[v.Key] {
+			continue
+		}
+		if err := s.envVarRepo.DeleteByAppIDAndKey(appID, v.Key); err != nil {
+			return fmt.Errorf("delete env var %s: %w", v.Key, err)
+		}
+	}
+	return nil
+}
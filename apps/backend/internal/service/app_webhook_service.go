@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/paasdeploy/backend/internal/crypto"
+	"github.com/paasdeploy/backend/internal/domain"
+	"github.com/paasdeploy/backend/internal/notification"
+)
+
+// appWebhookTimeout bounds a delivery including its retries.
+const appWebhookTimeout = 2 * time.Minute
+
+// AppWebhookService delivers an app's lifecycle events to the webhooks the
+// app defines. Deliveries run in the background so a slow receiver never
+// holds up the engine events.
+type AppWebhookService struct {
+	repo      domain.AppWebhookRepository
+	appRepo   domain.AppRepository
+	encryptor *crypto.TokenEncryptor
+	sender    *notification.WebhookSender
+	logger    *slog.Logger
+}
+
+func NewAppWebhookService(
+	repo domain.AppWebhookRepository,
+	appRepo domain.AppRepository,
+	encryptor *crypto.TokenEncryptor,
+	logger *slog.Logger,
+) *AppWebhookService {
+	return &AppWebhookService{
+		repo:      repo,
+		appRepo:   appRepo,
+		encryptor: encryptor,
+		sender:    notification.NewWebhookSender(),
+		logger:    logger.With("component", "app_webhook_service"),
+	}
+}
+
+func (s *AppWebhookService) NotifyDeployStarted(deployID, appID string) {
+	go s.dispatch(domain.AppWebhookEventDeployStarted, appID, func(p *notification.WebhookPayload) {
+		p.Deployment = &notification.WebhookDeployment{ID: deployID}
+	})
+}
+
+func (s *AppWebhookService) NotifyDeploySuccess(deployID, appID string) {
+	go s.dispatch(domain.AppWebhookEventDeploySucceeded, appID, func(p *notification.WebhookPayload) {
+		p.Deployment = &notification.WebhookDeployment{ID: deployID}
+	})
+}
+
+func (s *AppWebhookService) NotifyDeployFailed(deployID, appID, message string) {
+	go s.dispatch(domain.AppWebhookEventDeployFailed, appID, func(p *notification.WebhookPayload) {
+		p.Deployment = &notification.WebhookDeployment{ID: deployID, Message: message}
+	})
+}
+
+func (s *AppWebhookService) NotifyHealthChange(appID, status, health string) {
+	go s.dispatch(domain.AppWebhookEventHealthChanged, appID, func(p *notification.WebhookPayload) {
+		p.Health = &notification.WebhookHealth{Status: status, Health: health}
+	})
+}
+
+// Create adds a webhook with a new secret and returns the secret, which is
+// only shown this once.
+func (s *AppWebhookService) Create(appID, url string, events []string, enabled bool) (*domain.AppWebhook, string, error) {
+	secret, err := generateWebhookSecret()
+	if err != nil {
+		return nil, "", err
+	}
+	encrypted := secret
+	if s.encryptor != nil {
+		if encrypted, err = s.encryptor.Encrypt(secret); err != nil {
+			return nil, "", err
+		}
+	}
+
+	webhook, err := s.repo.Create(domain.CreateAppWebhookInput{
+		AppID:           appID,
+		URL:             url,
+		SecretEncrypted: encrypted,
+		Events:          events,
+		Enabled:         enabled,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	return webhook, secret, nil
+}
+
+// Test sends a ping event to the webhook once and records the outcome.
+func (s *AppWebhookService) Test(ctx context.Context, webhook *domain.AppWebhook, app *domain.App) (domain.AppWebhookDelivery, error) {
+	secret, err := s.secret(webhook)
+	if err != nil {
+		return domain.AppWebhookDelivery{}, err
+	}
+	payload := newWebhookPayload(domain.AppWebhookEventPing, app)
+	delivery := s.sender.DeliverOnce(ctx, webhook.URL, secret, payload)
+	s.recordDelivery(webhook, delivery)
+	return delivery, nil
+}
+
+func (s *AppWebhookService) dispatch(event, appID string, fill func(*notification.WebhookPayload)) {
+	webhooks, err := s.repo.FindEnabledByAppID(appID)
+	if err != nil {
+		s.logger.Error("Failed to find app webhooks", "appId", appID, "error", err)
+		return
+	}
+
+	var subscribed []domain.AppWebhook
+	for _, w := range webhooks {
+		if w.Subscribes(event) {
+			subscribed = append(subscribed, w)
+		}
+	}
+	if len(subscribed) == 0 {
+		return
+	}
+
+	app, err := s.appRepo.FindByID(appID)
+	if err != nil {
+		s.logger.Warn("App not found for webhook event", "appId", appID, "event", event, "error", err)
+		return
+	}
+	payload := newWebhookPayload(event, app)
+	fill(&payload)
+
+	for i := range subscribed {
+		go s.deliver(&subscribed[i], payload)
+	}
+}
+
+func (s *AppWebhookService) deliver(webhook *domain.AppWebhook, payload notification.WebhookPayload) {
+	secret, err := s.secret(webhook)
+	if err != nil {
+		s.logger.Error("Failed to decrypt webhook secret", "webhookId", webhook.ID, "error", err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), appWebhookTimeout)
+	defer cancel()
+
+	delivery := s.sender.Deliver(ctx, webhook.URL, secret, payload)
+	if delivery.Error != "" {
+		s.logger.Warn("Failed to deliver app webhook",
+			"webhookId", webhook.ID, "appId", webhook.AppID, "event", payload.Event, "error", delivery.Error)
+	}
+	s.recordDelivery(webhook, delivery)
+}
+
+func (s *AppWebhookService) recordDelivery(webhook *domain.AppWebhook, delivery domain.AppWebhookDelivery) {
+	if err := s.repo.RecordDelivery(webhook.ID, delivery); err != nil {
+		s.logger.Warn("Failed to record webhook delivery", "webhookId", webhook.ID, "error", err)
+	}
+}
+
+func (s *AppWebhookService) secret(webhook *domain.AppWebhook) (string, error) {
+	if s.encryptor == nil {
+		return webhook.SecretEncrypted, nil
+	}
+	return s.encryptor.Decrypt(webhook.SecretEncrypted)
+}
+
+func newWebhookPayload(event string, app *domain.App) notification.WebhookPayload {
+	return notification.WebhookPayload{
+		ID:        uuid.NewString(),
+		Event:     event,
+		Timestamp: time.Now().UTC(),
+		App:       notification.WebhookApp{ID: app.ID, Name: app.Name},
+	}
+}
+
+func generateWebhookSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(b), nil
+}
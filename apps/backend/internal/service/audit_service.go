@@ -6,6 +6,7 @@ import (
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/paasdeploy/backend/internal/domain"
+	"github.com/paasdeploy/shared/pkg/tracing"
 )
 
 type AuditService struct {
@@ -25,6 +26,7 @@ type AuditContext struct {
 	UserName  *string
 	IPAddress *string
 	UserAgent *string
+	TraceID   string
 }
 
 func (s *AuditService) ExtractContext(c *fiber.Ctx) AuditContext {
@@ -50,10 +52,17 @@ func (s *AuditService) ExtractContext(c *fiber.Ctx) AuditContext {
 		ctx.UserAgent = &ua
 	}
 
+	ctx.TraceID = tracing.ID(c.UserContext())
+
 	return ctx
 }
 
 func (s *AuditService) Log(ctx context.Context, auditCtx AuditContext, eventType domain.EventType, resourceType domain.ResourceType, resourceID, resourceName *string, details map[string]interface{}) {
+	traceID := auditCtx.TraceID
+	if traceID == "" {
+		traceID = tracing.ID(ctx)
+	}
+
 	input := domain.CreateAuditLogInput{
 		EventType:    eventType,
 		ResourceType: resourceType,
@@ -64,11 +73,12 @@ func (s *AuditService) Log(ctx context.Context, auditCtx AuditContext, eventType
 		Details:      details,
 		IPAddress:    auditCtx.IPAddress,
 		UserAgent:    auditCtx.UserAgent,
+		TraceID:      traceID,
 	}
 
 	_, err := s.repo.Create(input)
 	if err != nil {
-		s.logger.Error("failed to create audit log",
+		s.logger.ErrorContext(ctx, "failed to create audit log",
 			"event_type", eventType,
 			"resource_type", resourceType,
 			"resource_id", resourceID,
@@ -91,6 +101,82 @@ func (s *AuditService) LogAppPurged(ctx context.Context, auditCtx AuditContext,
 	s.Log(ctx, auditCtx, domain.EventAppPurged, domain.ResourceApp, &appID, &appName, nil)
 }
 
+func (s *AuditService) LogAppRestored(ctx context.Context, auditCtx AuditContext, appID, appName string) {
+	s.Log(ctx, auditCtx, domain.EventAppRestored, domain.ResourceApp, &appID, &appName, nil)
+}
+
+func (s *AuditService) LogAppSpecApplied(ctx context.Context, auditCtx AuditContext, appID, appName string, created bool, changes int) {
+	s.Log(ctx, auditCtx, domain.EventAppSpecApplied, domain.ResourceApp, &appID, &appName, map[string]interface{}{
+		"created": created,
+		"changes": changes,
+	})
+}
+
+func (s *AuditService) LogAppBulkAction(ctx context.Context, auditCtx AuditContext, batchID, action string, appIDs []string) {
+	s.Log(ctx, auditCtx, domain.EventAppBulkAction, domain.ResourceApp, nil, nil, map[string]interface{}{
+		"batch_id": batchID,
+		"action":   action,
+		"app_ids":  appIDs,
+	})
+}
+
+func (s *AuditService) LogAppUpdated(ctx context.Context, auditCtx AuditContext, appID, appName string, fields []string) {
+	s.Log(ctx, auditCtx, domain.EventAppUpdated, domain.ResourceApp, &appID, &appName, map[string]interface{}{
+		"fields": fields,
+	})
+}
+
+func (s *AuditService) LogAppScaled(ctx context.Context, auditCtx AuditContext, appID, appName string, changes map[string]string) {
+	s.Log(ctx, auditCtx, domain.EventAppScaled, domain.ResourceApp, &appID, &appName, map[string]interface{}{
+		"changes": changes,
+	})
+}
+
+func (s *AuditService) LogAppMaintenance(ctx context.Context, auditCtx AuditContext, appID, appName string, enabled bool) {
+	s.Log(ctx, auditCtx, domain.EventAppMaintenance, domain.ResourceApp, &appID, &appName, map[string]interface{}{
+		"enabled": enabled,
+	})
+}
+
+func (s *AuditService) LogAppAutoHeal(ctx context.Context, auditCtx AuditContext, appID, appName string, policy *domain.AppAutoHeal) {
+	details := map[string]interface{}{"enabled": policy != nil}
+	if policy != nil {
+		details["unhealthy_seconds"] = policy.UnhealthySeconds
+		details["max_restarts"] = policy.MaxRestarts
+	}
+	s.Log(ctx, auditCtx, domain.EventAppAutoHeal, domain.ResourceApp, &appID, &appName, details)
+}
+
+// LogAppAutoHealed records a restart of the app's container by the health
+// monitor after it stayed unhealthy. errorMsg is empty when it succeeded.
+func (s *AuditService) LogAppAutoHealed(ctx context.Context, appID, appName string, attempt, maxRestarts int, errorMsg string) {
+	details := map[string]interface{}{
+		"attempt":      attempt,
+		"max_restarts": maxRestarts,
+	}
+	if errorMsg != "" {
+		details["error"] = errorMsg
+	}
+	s.Log(ctx, AuditContext{}, domain.EventAppAutoHealed, domain.ResourceApp, &appID, &appName, details)
+}
+
+// LogAppContainerAction records a restart, stop or start of the app's
+// container.
+func (s *AuditService) LogAppContainerAction(ctx context.Context, auditCtx AuditContext, appID, appName, action string) {
+	eventType := domain.EventAppRestarted
+	switch action {
+	case "stop":
+		eventType = domain.EventAppStopped
+	case "start":
+		eventType = domain.EventAppStarted
+	}
+	s.Log(ctx, auditCtx, eventType, domain.ResourceApp, &appID, &appName, nil)
+}
+
+func (s *AuditService) LogWebhookReplayed(ctx context.Context, auditCtx AuditContext, payloadID, deliveryID string) {
+	s.Log(ctx, auditCtx, domain.EventWebhookReplayed, domain.ResourceWebhook, &payloadID, &deliveryID, nil)
+}
+
 func (s *AuditService) LogDeployStarted(ctx context.Context, auditCtx AuditContext, deployID, appID, appName, commitSHA string) {
 	s.Log(ctx, auditCtx, domain.EventDeployStarted, domain.ResourceDeployment, &deployID, &appName, map[string]interface{}{
 		"app_id":     appID,
@@ -130,6 +216,13 @@ func (s *AuditService) LogEnvDeleted(ctx context.Context, auditCtx AuditContext,
 	})
 }
 
+func (s *AuditService) LogEnvBulkUpdated(ctx context.Context, auditCtx AuditContext, appID string, keys []string) {
+	s.Log(ctx, auditCtx, domain.EventEnvBulkUpdated, domain.ResourceEnvVar, nil, nil, map[string]interface{}{
+		"app_id": appID,
+		"keys":   keys,
+	})
+}
+
 func (s *AuditService) LogDomainAdded(ctx context.Context, auditCtx AuditContext, domainID, appID, domainName, pathPrefix string) {
 	s.Log(ctx, auditCtx, domain.EventDomainAdded, domain.ResourceDomain, &domainID, &domainName, map[string]interface{}{
 		"app_id":      appID,
@@ -161,6 +254,34 @@ func (s *AuditService) LogContainerCreated(ctx context.Context, auditCtx AuditCo
 	})
 }
 
+func (s *AuditService) LogContainerFileDownloaded(ctx context.Context, auditCtx AuditContext, containerID, serverID, path string, size int) {
+	s.Log(ctx, auditCtx, domain.EventContainerFileDownloaded, domain.ResourceContainer, &containerID, &path, map[string]interface{}{
+		"server_id": serverID,
+		"size":      size,
+	})
+}
+
+func (s *AuditService) LogContainerFileUploaded(ctx context.Context, auditCtx AuditContext, containerID, serverID, path string, size int) {
+	s.Log(ctx, auditCtx, domain.EventContainerFileUploaded, domain.ResourceContainer, &containerID, &path, map[string]interface{}{
+		"server_id": serverID,
+		"size":      size,
+	})
+}
+
+func (s *AuditService) LogContainerCommitted(ctx context.Context, auditCtx AuditContext, containerID, serverID, image, imageID string) {
+	s.Log(ctx, auditCtx, domain.EventContainerCommitted, domain.ResourceContainer, &containerID, &image, map[string]interface{}{
+		"server_id": serverID,
+		"image_id":  imageID,
+	})
+}
+
+func (s *AuditService) LogVolumeFileDownloaded(ctx context.Context, auditCtx AuditContext, volume, serverID, path string, size int) {
+	s.Log(ctx, auditCtx, domain.EventVolumeFileDownloaded, domain.ResourceVolume, &volume, &path, map[string]interface{}{
+		"server_id": serverID,
+		"size":      size,
+	})
+}
+
 func (s *AuditService) LogUserLoggedIn(ctx context.Context, auditCtx AuditContext, userID, userName string) {
 	s.Log(ctx, auditCtx, domain.EventUserLoggedIn, domain.ResourceUser, &userID, &userName, nil)
 }
@@ -169,6 +290,54 @@ func (s *AuditService) LogUserLoggedOut(ctx context.Context, auditCtx AuditConte
 	s.Log(ctx, auditCtx, domain.EventUserLoggedOut, domain.ResourceUser, &userID, &userName, nil)
 }
 
+func (s *AuditService) LogAPITokenCreated(ctx context.Context, auditCtx AuditContext, tokenID, tokenName string) {
+	s.Log(ctx, auditCtx, domain.EventAPITokenCreated, domain.ResourceAPIToken, &tokenID, &tokenName, nil)
+}
+
+func (s *AuditService) LogAPITokenRevoked(ctx context.Context, auditCtx AuditContext, tokenID string) {
+	s.Log(ctx, auditCtx, domain.EventAPITokenRevoked, domain.ResourceAPIToken, &tokenID, nil, nil)
+}
+
+func (s *AuditService) LogGitOpsSourceCreated(ctx context.Context, auditCtx AuditContext, sourceID, repoURL string) {
+	s.Log(ctx, auditCtx, domain.EventGitOpsSourceCreated, domain.ResourceGitOpsSource, &sourceID, &repoURL, nil)
+}
+
+func (s *AuditService) LogGitOpsSourceDeleted(ctx context.Context, auditCtx AuditContext, sourceID, repoURL string) {
+	s.Log(ctx, auditCtx, domain.EventGitOpsSourceDeleted, domain.ResourceGitOpsSource, &sourceID, &repoURL, nil)
+}
+
+func (s *AuditService) LogGitOpsSourceSynced(ctx context.Context, auditCtx AuditContext, sourceID, repoURL string) {
+	s.Log(ctx, auditCtx, domain.EventGitOpsSourceSynced, domain.ResourceGitOpsSource, &sourceID, &repoURL, nil)
+}
+
+func (s *AuditService) LogServerTaskCreated(ctx context.Context, auditCtx AuditContext, task domain.ServerTask) {
+	s.Log(ctx, auditCtx, domain.EventServerTaskCreated, domain.ResourceServerTask, &task.ID, &task.Name, map[string]interface{}{
+		"server_id": task.ServerID,
+		"schedule":  task.Schedule,
+		"command":   task.Command,
+	})
+}
+
+func (s *AuditService) LogServerTaskUpdated(ctx context.Context, auditCtx AuditContext, task domain.ServerTask, fields []string) {
+	s.Log(ctx, auditCtx, domain.EventServerTaskUpdated, domain.ResourceServerTask, &task.ID, &task.Name, map[string]interface{}{
+		"server_id": task.ServerID,
+		"fields":    fields,
+	})
+}
+
+func (s *AuditService) LogServerTaskDeleted(ctx context.Context, auditCtx AuditContext, task domain.ServerTask) {
+	s.Log(ctx, auditCtx, domain.EventServerTaskDeleted, domain.ResourceServerTask, &task.ID, &task.Name, map[string]interface{}{
+		"server_id": task.ServerID,
+	})
+}
+
+func (s *AuditService) LogServerTaskRun(ctx context.Context, auditCtx AuditContext, task domain.ServerTask, runID string) {
+	s.Log(ctx, auditCtx, domain.EventServerTaskRun, domain.ResourceServerTask, &task.ID, &task.Name, map[string]interface{}{
+		"server_id": task.ServerID,
+		"run_id":    runID,
+	})
+}
+
 func (s *AuditService) LogImageRemoved(ctx context.Context, auditCtx AuditContext, imageID string) {
 	s.Log(ctx, auditCtx, domain.EventImageRemoved, domain.ResourceImage, &imageID, nil, nil)
 }
@@ -180,6 +349,15 @@ func (s *AuditService) LogImagesPruned(ctx context.Context, auditCtx AuditContex
 	})
 }
 
+func (s *AuditService) LogBackupCreated(ctx context.Context, auditCtx AuditContext, schemaVersion uint, tables, rows, size int) {
+	s.Log(ctx, auditCtx, domain.EventBackupCreated, domain.ResourceSystem, nil, nil, map[string]interface{}{
+		"schema_version": schemaVersion,
+		"tables":         tables,
+		"rows":           rows,
+		"size":           size,
+	})
+}
+
 func (s *AuditService) Query(filter domain.AuditLogFilter) ([]domain.AuditLog, int, error) {
 	return s.repo.FindAll(filter)
 }
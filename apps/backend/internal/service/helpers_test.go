@@ -0,0 +1,108 @@
+package service
+
+import (
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/paasdeploy/backend/internal/domain"
+)
+
+func testLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+// fakeAppRepo keeps apps in memory and, like the Postgres repository, hides
+// apps in the trash from every lookup but the FindDeleted ones. Methods the
+// tests do not use panic through the embedded nil interface.
+type fakeAppRepo struct {
+	domain.AppRepository
+	apps []domain.App
+}
+
+func (r *fakeAppRepo) find(match func(domain.App) bool) []domain.App {
+	var apps []domain.App
+	for _, app := range r.apps {
+		if match(app) {
+			apps = append(apps, app)
+		}
+	}
+	return apps
+}
+
+func (r *fakeAppRepo) first(match func(domain.App) bool) (*domain.App, error) {
+	apps := r.find(match)
+	if len(apps) == 0 {
+		return nil, domain.ErrNotFound
+	}
+	return &apps[0], nil
+}
+
+func trashed(app domain.App) bool {
+	return app.Status == domain.AppStatusDeleted
+}
+
+func (r *fakeAppRepo) Create(input domain.CreateAppInput) (*domain.App, error) {
+	app := domain.App{ID: input.Name, UserID: input.UserID, Name: input.Name, Status: domain.AppStatusActive}
+	r.apps = append(r.apps, app)
+	return &app, nil
+}
+
+func (r *fakeAppRepo) FindAll() ([]domain.App, error) {
+	return r.find(func(app domain.App) bool { return !trashed(app) }), nil
+}
+
+func (r *fakeAppRepo) FindAllByUserID(userID string) ([]domain.App, error) {
+	return r.find(func(app domain.App) bool { return app.UserID == userID && !trashed(app) }), nil
+}
+
+func (r *fakeAppRepo) FindByID(id string) (*domain.App, error) {
+	return r.first(func(app domain.App) bool { return app.ID == id && !trashed(app) })
+}
+
+func (r *fakeAppRepo) FindByName(name string) (*domain.App, error) {
+	return r.first(func(app domain.App) bool { return app.Name == name && !trashed(app) })
+}
+
+func (r *fakeAppRepo) FindDeletedByID(id string) (*domain.App, error) {
+	return r.first(func(app domain.App) bool { return app.ID == id && trashed(app) })
+}
+
+func (r *fakeAppRepo) FindDeletedByName(name string) (*domain.App, error) {
+	return r.first(func(app domain.App) bool { return app.Name == name && trashed(app) })
+}
+
+func (r *fakeAppRepo) FindDeletedByUserID(userID string) ([]domain.App, error) {
+	return r.find(func(app domain.App) bool { return app.UserID == userID && trashed(app) }), nil
+}
+
+func (r *fakeAppRepo) Delete(id string) error {
+	return r.setStatus(id, false, domain.AppStatusDeleted)
+}
+
+func (r *fakeAppRepo) Restore(id string) error {
+	return r.setStatus(id, true, domain.AppStatusActive)
+}
+
+func (r *fakeAppRepo) setStatus(id string, inTrash bool, status domain.AppStatus) error {
+	for i := range r.apps {
+		app := &r.apps[i]
+		if app.ID != id || trashed(*app) != inTrash {
+			continue
+		}
+		app.Status = status
+		app.DeletedAt = nil
+		if status == domain.AppStatusDeleted {
+			now := time.Now()
+			app.DeletedAt = &now
+		}
+		return nil
+	}
+	return domain.ErrNotFound
+}
+
+func (r *fakeAppRepo) FindByServerID(serverID string) ([]domain.App, error) {
+	return r.find(func(app domain.App) bool {
+		return app.ServerID != nil && *app.ServerID == serverID && !trashed(app)
+	}), nil
+}
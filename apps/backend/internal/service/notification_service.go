@@ -1,7 +1,9 @@
 package service
 
 import (
+	"fmt"
 	"log/slog"
+	"strings"
 	"sync"
 	"time"
 
@@ -50,10 +52,60 @@ func (s *NotificationService) NotifyDeployFailed(deployID, appID, message string
 
 func (s *NotificationService) NotifyHealthChange(appID, status, health string) {
 	eventType := domain.EventTypeHealthUnhealthy
-	if status == "not_found" {
+	message := ""
+	switch {
+	case health == domain.HealthCrashLoop:
+		eventType = domain.EventTypeContainerCrashLoop
+		message = "Container keeps crashing and was left stopped; check the app logs and redeploy"
+	case health == domain.HealthOOMKilled:
+		eventType = domain.EventTypeContainerOOM
+		message = "Container was killed for running out of memory; consider raising its memory limit"
+	case status == "not_found":
 		eventType = domain.EventTypeContainerDown
 	}
-	s.notify(eventType, "", appID, "", status, health)
+	s.notify(eventType, "", appID, message, status, health)
+}
+
+func (s *NotificationService) NotifyServerOffline(server domain.Server) {
+	message := fmt.Sprintf("Server %s (%s) missed heartbeats", server.Name, server.Host)
+	if server.LastHeartbeatAt != nil {
+		message += fmt.Sprintf(", last seen %s", server.LastHeartbeatAt.Format(time.RFC3339))
+	}
+	s.notify(domain.EventTypeServerOffline, "", "", message, string(domain.ServerStatusOffline), "")
+}
+
+func (s *NotificationService) NotifyCertificateExpiring(cert domain.CertificateExpiry) {
+	expires := fmt.Sprintf("expires on %s (in %d days)", cert.ExpiresAt.Format("2006-01-02"), cert.DaysRemaining(time.Now()))
+	var message string
+	if cert.Uploaded {
+		message = fmt.Sprintf("Custom certificate for %s %s; upload a renewed certificate", cert.Domain, expires)
+	} else {
+		message = fmt.Sprintf("Let's Encrypt certificate for %s %s and has not been renewed; check DNS and the Traefik logs on the server", cert.Domain, expires)
+	}
+	s.notify(domain.EventTypeCertExpiring, "", cert.AppID, message, "expiring", "")
+}
+
+func (s *NotificationService) NotifyServerTaskFailed(server domain.Server, task domain.ServerTask, run domain.ServerTaskRun) {
+	reason := run.Error
+	if reason == "" && run.ExitCode != nil {
+		reason = fmt.Sprintf("exit code %d", *run.ExitCode)
+	}
+	message := fmt.Sprintf("Scheduled task %q on server %s failed: %s", task.Name, server.Name, reason)
+	if line := lastOutputLine(run.Output); line != "" {
+		message += "\n" + line
+	}
+	s.notify(domain.EventTypeServerTaskFailed, "", "", message, string(run.Status), "")
+}
+
+// lastOutputLine returns the last non-empty line of a task's output, which
+// usually says why it failed.
+func lastOutputLine(output string) string {
+	lines := strings.Split(strings.TrimRight(output, "\n"), "\n")
+	line := strings.TrimSpace(lines[len(lines)-1])
+	if len(line) > 200 {
+		line = line[:200] + "..."
+	}
+	return line
 }
 
 func (s *NotificationService) notify(eventType, deployID, appID, message, status, health string) {
@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/paasdeploy/backend/internal/domain"
+)
+
+// QuotaService enforces the per-user quotas. Admins and apps without an
+// owner are not limited.
+type QuotaService struct {
+	quota          domain.Quota
+	appRepo        domain.AppRepository
+	serverRepo     domain.ServerRepository
+	deploymentRepo domain.DeploymentRepository
+	userRepo       domain.UserRepository
+}
+
+func NewQuotaService(
+	quota domain.Quota,
+	appRepo domain.AppRepository,
+	serverRepo domain.ServerRepository,
+	deploymentRepo domain.DeploymentRepository,
+	userRepo domain.UserRepository,
+) *QuotaService {
+	return &QuotaService{
+		quota:          quota,
+		appRepo:        appRepo,
+		serverRepo:     serverRepo,
+		deploymentRepo: deploymentRepo,
+		userRepo:       userRepo,
+	}
+}
+
+func (s *QuotaService) Limits() domain.Quota {
+	return s.quota
+}
+
+// Status reports the user's limits and what they use of them.
+func (s *QuotaService) Status(ctx context.Context, userID string) (domain.QuotaStatus, error) {
+	status := domain.QuotaStatus{Limits: s.quota, PeriodStart: domain.QuotaPeriodStart(time.Now())}
+
+	exempt, err := s.exempt(ctx, userID)
+	if err != nil {
+		return status, err
+	}
+	status.Unlimited = exempt || s.quota.IsZero()
+
+	apps, err := s.appRepo.FindAllByUserID(userID)
+	if err != nil {
+		return status, err
+	}
+	servers, err := s.serverRepo.FindAllByUserID(userID)
+	if err != nil {
+		return status, err
+	}
+	memory, err := s.appRepo.SumMemoryReservation(userID, "")
+	if err != nil {
+		return status, err
+	}
+	buildSeconds, err := s.deploymentRepo.SumBuildSecondsByUserID(userID, status.PeriodStart)
+	if err != nil {
+		return status, err
+	}
+
+	status.Usage = domain.QuotaUsage{
+		Apps:         len(apps),
+		Servers:      len(servers),
+		MemoryBytes:  memory,
+		BuildSeconds: buildSeconds,
+	}
+	return status, nil
+}
+
+// CheckCreateApp fails with ErrQuotaExceeded when the user may not own
+// another app.
+func (s *QuotaService) CheckCreateApp(ctx context.Context, userID string) error {
+	if s.quota.MaxApps == 0 {
+		return nil
+	}
+	if exempt, err := s.exempt(ctx, userID); exempt || err != nil {
+		return err
+	}
+	apps, err := s.appRepo.FindAllByUserID(userID)
+	if err != nil {
+		return err
+	}
+	return s.quota.CheckApps(len(apps))
+}
+
+func (s *QuotaService) CheckCreateServer(ctx context.Context, userID string) error {
+	if s.quota.MaxServers == 0 {
+		return nil
+	}
+	if exempt, err := s.exempt(ctx, userID); exempt || err != nil {
+		return err
+	}
+	servers, err := s.serverRepo.FindAllByUserID(userID)
+	if err != nil {
+		return err
+	}
+	return s.quota.CheckServers(len(servers))
+}
+
+// CheckBuildTime fails when the owner of app used up this month's build
+// minutes.
+func (s *QuotaService) CheckBuildTime(ctx context.Context, app *domain.App) error {
+	if s.quota.BuildMinutes == 0 {
+		return nil
+	}
+	if exempt, err := s.exempt(ctx, app.UserID); exempt || err != nil {
+		return err
+	}
+	seconds, err := s.deploymentRepo.SumBuildSecondsByUserID(app.UserID, domain.QuotaPeriodStart(time.Now()))
+	if err != nil {
+		return err
+	}
+	return s.quota.CheckBuildTime(seconds)
+}
+
+// ReserveMemory checks that running app with the given memory limit keeps
+// its owner within the memory quota and records the reservation. An app
+// without a limit could use all of the host's memory, so it is refused
+// while a memory quota is set.
+func (s *QuotaService) ReserveMemory(ctx context.Context, app *domain.App, memory string) error {
+	var requested int64
+	if memory != "" {
+		bytes, err := domain.ParseMemory(memory)
+		if err != nil {
+			return err
+		}
+		requested = bytes
+	}
+
+	if s.quota.MaxMemoryBytes > 0 {
+		exempt, err := s.exempt(ctx, app.UserID)
+		if err != nil {
+			return err
+		}
+		if !exempt {
+			if requested == 0 {
+				return fmt.Errorf("%w: set a memory limit, apps without one are not allowed under a memory quota", domain.ErrQuotaExceeded)
+			}
+			reserved, err := s.appRepo.SumMemoryReservation(app.UserID, app.ID)
+			if err != nil {
+				return err
+			}
+			if err := s.quota.CheckMemory(reserved, requested); err != nil {
+				return err
+			}
+		}
+	}
+
+	return s.appRepo.UpdateMemoryReservation(app.ID, requested)
+}
+
+func (s *QuotaService) exempt(ctx context.Context, userID string) (bool, error) {
+	if userID == "" {
+		return true, nil
+	}
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return user.IsAdmin(), nil
+}
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/paasdeploy/backend/internal/domain"
+)
+
+const shortSHALength = 7
+
+// SearchService searches the user's apps, deployments, servers and domains.
+// Containers live on Docker rather than in the database and are searched by
+// the handler.
+type SearchService struct {
+	appRepo        domain.AppRepository
+	deploymentRepo domain.DeploymentRepository
+	serverRepo     domain.ServerRepository
+	domainRepo     domain.CustomDomainRepository
+}
+
+func NewSearchService(
+	appRepo domain.AppRepository,
+	deploymentRepo domain.DeploymentRepository,
+	serverRepo domain.ServerRepository,
+	domainRepo domain.CustomDomainRepository,
+) *SearchService {
+	return &SearchService{
+		appRepo:        appRepo,
+		deploymentRepo: deploymentRepo,
+		serverRepo:     serverRepo,
+		domainRepo:     domainRepo,
+	}
+}
+
+// Search returns up to limit matches of each type, apps first.
+func (s *SearchService) Search(ctx context.Context, userID, query string, limit int) ([]domain.SearchResult, error) {
+	apps, err := s.appRepo.FindAllByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	appNames := make(map[string]string, len(apps))
+	for _, app := range apps {
+		appNames[app.ID] = app.Name
+	}
+
+	results := matchApps(apps, query, limit)
+
+	servers, err := s.serverRepo.FindPageByUserID(userID, domain.ServerFilter{}, domain.ListOptions{Limit: limit, SortBy: "name", Query: query})
+	if err != nil {
+		return nil, err
+	}
+	for _, server := range servers.Items {
+		results = append(results, domain.SearchResult{
+			Type:     domain.SearchResultServer,
+			ID:       server.ID,
+			Title:    server.Name,
+			Subtitle: server.Host,
+			ServerID: server.ID,
+		})
+	}
+
+	domains, err := s.domainRepo.SearchByUserID(ctx, userID, query, limit)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range domains {
+		results = append(results, domain.SearchResult{
+			Type:     domain.SearchResultDomain,
+			ID:       d.ID,
+			Title:    d.Domain + strings.TrimSuffix(d.PathPrefix, "/"),
+			Subtitle: appNames[d.AppID],
+			AppID:    d.AppID,
+		})
+	}
+
+	deployments, err := s.deploymentRepo.SearchByUserID(userID, query, limit)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range deployments {
+		results = append(results, domain.SearchResult{
+			Type:     domain.SearchResultDeployment,
+			ID:       d.ID,
+			Title:    deploymentTitle(d),
+			Subtitle: appNames[d.AppID],
+			AppID:    d.AppID,
+		})
+	}
+
+	return results, nil
+}
+
+func matchApps(apps []domain.App, query string, limit int) []domain.SearchResult {
+	query = strings.ToLower(query)
+	var results []domain.SearchResult
+	for _, app := range apps {
+		if len(results) == limit {
+			break
+		}
+		if !strings.Contains(strings.ToLower(app.Name), query) && !strings.Contains(strings.ToLower(app.RepositoryURL), query) {
+			continue
+		}
+		result := domain.SearchResult{
+			Type:     domain.SearchResultApp,
+			ID:       app.ID,
+			Title:    app.Name,
+			Subtitle: app.RepositoryURL,
+			AppID:    app.ID,
+		}
+		if app.ServerID != nil {
+			result.ServerID = *app.ServerID
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// deploymentTitle is the short commit SHA and the first line of the commit
+// message.
+func deploymentTitle(d domain.Deployment) string {
+	sha := d.CommitSHA
+	if len(sha) > shortSHALength {
+		sha = sha[:shortSHALength]
+	}
+	message, _, _ := strings.Cut(d.CommitMessage, "\n")
+	if message == "" {
+		return sha
+	}
+	return sha + " " + message
+}
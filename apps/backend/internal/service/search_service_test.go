@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/paasdeploy/backend/internal/domain"
+)
+
+type fakeSearchServerRepo struct {
+	domain.ServerRepository
+	servers []domain.Server
+	opts    domain.ListOptions
+}
+
+func (r *fakeSearchServerRepo) FindPageByUserID(_ string, _ domain.ServerFilter, opts domain.ListOptions) (domain.Page[domain.Server], error) {
+	r.opts = opts
+	return domain.Page[domain.Server]{Items: r.servers}, nil
+}
+
+type fakeSearchDomainRepo struct {
+	domain.CustomDomainRepository
+	domains []domain.CustomDomain
+}
+
+func (r *fakeSearchDomainRepo) SearchByUserID(context.Context, string, string, int) ([]domain.CustomDomain, error) {
+	return r.domains, nil
+}
+
+type fakeSearchDeploymentRepo struct {
+	domain.DeploymentRepository
+	deployments []domain.Deployment
+}
+
+func (r *fakeSearchDeploymentRepo) SearchByUserID(string, string, int) ([]domain.Deployment, error) {
+	return r.deployments, nil
+}
+
+func TestMatchApps(t *testing.T) {
+	serverID := "s1"
+	apps := []domain.App{
+		{ID: "1", Name: "Web-Frontend", RepositoryURL: "https://github.com/acme/site"},
+		{ID: "2", Name: "billing", RepositoryURL: "https://github.com/acme/WEB-billing", ServerID: &serverID},
+		{ID: "3", Name: "worker", RepositoryURL: "https://github.com/acme/worker"},
+		{ID: "4", Name: "webhooks", RepositoryURL: "https://github.com/acme/hooks"},
+	}
+
+	results := matchApps(apps, "web", 2)
+
+	if len(results) != 2 || results[0].ID != "1" || results[1].ID != "2" {
+		t.Fatalf("results = %+v, want apps 1 and 2", results)
+	}
+	if results[1].ServerID != "s1" || results[0].ServerID != "" {
+		t.Errorf("server IDs = %q, %q", results[0].ServerID, results[1].ServerID)
+	}
+}
+
+func TestDeploymentTitle(t *testing.T) {
+	tests := []struct {
+		sha, message, want string
+	}{
+		{"0123456789abcdef", "Fix login\n\nLonger description", "0123456 Fix login"},
+		{"0123456789abcdef", "", "0123456"},
+		{"abc", "Short sha", "abc Short sha"},
+	}
+	for _, tt := range tests {
+		if got := deploymentTitle(domain.Deployment{CommitSHA: tt.sha, CommitMessage: tt.message}); got != tt.want {
+			t.Errorf("deploymentTitle(%q, %q) = %q, want %q", tt.sha, tt.message, got, tt.want)
+		}
+	}
+}
+
+func TestSearchGroupsResultsByType(t *testing.T) {
+	appRepo := &fakeAppRepo{apps: []domain.App{
+		{ID: "a1", UserID: "u1", Name: "shop", Status: domain.AppStatusActive},
+		{ID: "a2", UserID: "u1", Name: "api", Status: domain.AppStatusActive},
+		{ID: "a3", UserID: "u2", Name: "shop-copy", Status: domain.AppStatusActive},
+	}}
+	serverRepo := &fakeSearchServerRepo{servers: []domain.Server{{ID: "s1", Name: "shop-vps", Host: "203.0.113.10"}}}
+	domainRepo := &fakeSearchDomainRepo{domains: []domain.CustomDomain{{ID: "d1", AppID: "a2", Domain: "shop.example.com", PathPrefix: "/api/"}}}
+	deploymentRepo := &fakeSearchDeploymentRepo{deployments: []domain.Deployment{{ID: "dep1", AppID: "a1", CommitSHA: "abcdef0123", CommitMessage: "Shop checkout"}}}
+	svc := NewSearchService(appRepo, deploymentRepo, serverRepo, domainRepo)
+
+	results, err := svc.Search(context.Background(), "u1", "shop", 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []domain.SearchResult{
+		{Type: domain.SearchResultApp, ID: "a1", Title: "shop", AppID: "a1"},
+		{Type: domain.SearchResultServer, ID: "s1", Title: "shop-vps", Subtitle: "203.0.113.10", ServerID: "s1"},
+		{Type: domain.SearchResultDomain, ID: "d1", Title: "shop.example.com/api", Subtitle: "api", AppID: "a2"},
+		{Type: domain.SearchResultDeployment, ID: "dep1", Title: "abcdef0 Shop checkout", Subtitle: "shop", AppID: "a1"},
+	}
+	if !reflect.DeepEqual(results, want) {
+		t.Errorf("results = %+v\nwant %+v", results, want)
+	}
+	if serverRepo.opts.Query != "shop" || serverRepo.opts.Limit != 5 {
+		t.Errorf("server search options = %+v", serverRepo.opts)
+	}
+}
@@ -0,0 +1,303 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+
+	"github.com/paasdeploy/backend/internal/agentclient"
+	"github.com/paasdeploy/backend/internal/cloudflare"
+	"github.com/paasdeploy/backend/internal/crypto"
+	"github.com/paasdeploy/backend/internal/domain"
+)
+
+const (
+	// tunnelOriginService is where cloudflared sends requests. Traefik keeps
+	// doing the routing; its certificate is not verified since Cloudflare
+	// terminates TLS for visitors.
+	tunnelOriginService = "https://traefik:443"
+
+	recordTypeA     = "A"
+	recordTypeCNAME = "CNAME"
+)
+
+var ErrCloudflareNotConnected = errors.New("cloudflare account not connected")
+
+// tunnelCloudflare is the part of the Cloudflare client that keeps a tunnel's
+// ingress and DNS records in sync; *cloudflare.Client satisfies it.
+type tunnelCloudflare interface {
+	UpdateTunnelIngress(ctx context.Context, accountID, tunnelID string, hostnames []string, service string) error
+	DeleteRecord(ctx context.Context, zoneID, recordID string) error
+	CreateOrGetTunnelCNAME(ctx context.Context, zoneID, name, tunnelID string) (string, error)
+	CreateOrGetARecord(ctx context.Context, zoneID, name, ip string) (string, error)
+}
+
+type TunnelServiceParams struct {
+	ServerRepo     domain.ServerRepository
+	TunnelRepo     domain.ServerTunnelRepository
+	ConnectionRepo domain.CloudflareConnectionRepository
+	AppRepo        domain.AppRepository
+	DomainRepo     domain.CustomDomainRepository
+	TokenEncryptor *crypto.TokenEncryptor
+	AgentClient    *agentclient.AgentClient
+	AgentPort      int
+	Logger         *slog.Logger
+}
+
+// TunnelService runs a Cloudflare Tunnel on servers without a public IP. The
+// tunnel is created in the server owner's Cloudflare account, cloudflared is
+// started by the agent and the DNS records of the server's domains are
+// switched to proxied CNAMEs pointing at the tunnel.
+type TunnelService struct {
+	serverRepo     domain.ServerRepository
+	tunnelRepo     domain.ServerTunnelRepository
+	connectionRepo domain.CloudflareConnectionRepository
+	appRepo        domain.AppRepository
+	domainRepo     domain.CustomDomainRepository
+	tokenEncryptor *crypto.TokenEncryptor
+	agentClient    *agentclient.AgentClient
+	agentPort      int
+	logger         *slog.Logger
+}
+
+func NewTunnelService(params TunnelServiceParams) *TunnelService {
+	return &TunnelService{
+		serverRepo:     params.ServerRepo,
+		tunnelRepo:     params.TunnelRepo,
+		connectionRepo: params.ConnectionRepo,
+		appRepo:        params.AppRepo,
+		domainRepo:     params.DomainRepo,
+		tokenEncryptor: params.TokenEncryptor,
+		agentClient:    params.AgentClient,
+		agentPort:      params.AgentPort,
+		logger:         params.Logger.With("service", "tunnel"),
+	}
+}
+
+func (s *TunnelService) FindTunnel(ctx context.Context, serverID string) (*domain.ServerTunnel, error) {
+	return s.tunnelRepo.FindByServerID(ctx, serverID)
+}
+
+func (s *TunnelService) cloudflareClient(ctx context.Context, userID string) (*cloudflare.Client, error) {
+	conn, err := s.connectionRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, ErrCloudflareNotConnected
+		}
+		return nil, err
+	}
+	accessToken, err := s.tokenEncryptor.Decrypt(conn.AccessTokenEncrypted)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt cloudflare token: %w", err)
+	}
+	return cloudflare.NewClient(accessToken, s.logger), nil
+}
+
+// Enable creates the server's tunnel, or reuses the existing one, and starts
+// cloudflared on the server. Calling it again restarts cloudflared.
+func (s *TunnelService) Enable(ctx context.Context, server *domain.Server) (*domain.ServerTunnel, error) {
+	cf, err := s.cloudflareClient(ctx, server.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	tunnel, err := s.tunnelRepo.FindByServerID(ctx, server.ID)
+	if errors.Is(err, domain.ErrNotFound) {
+		tunnel, err = s.createTunnel(ctx, cf, server)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := s.tokenEncryptor.Decrypt(tunnel.TokenEncrypted)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt tunnel token: %w", err)
+	}
+	if err := s.agentClient.ConfigureTunnel(ctx, server.Host, s.agentPort, token); err != nil {
+		return nil, err
+	}
+
+	if err := s.syncIngress(ctx, cf, server.ID, tunnel); err != nil {
+		return nil, err
+	}
+	s.switchDNS(ctx, cf, server, tunnel)
+
+	s.logger.Info("Tunnel enabled", "serverId", server.ID, "tunnelId", tunnel.TunnelID)
+	return tunnel, nil
+}
+
+func (s *TunnelService) createTunnel(ctx context.Context, cf *cloudflare.Client, server *domain.Server) (*domain.ServerTunnel, error) {
+	accountID, err := cf.DefaultAccountID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	created, err := cf.CreateTunnel(ctx, accountID, "paasdeploy-"+server.ID)
+	if err != nil {
+		return nil, err
+	}
+	token, err := cf.GetTunnelToken(ctx, accountID, created.ID)
+	if err != nil {
+		return nil, err
+	}
+	encrypted, err := s.tokenEncryptor.Encrypt(token)
+	if err != nil {
+		return nil, fmt.Errorf("encrypt tunnel token: %w", err)
+	}
+
+	tunnel := &domain.ServerTunnel{
+		ServerID:       server.ID,
+		AccountID:      accountID,
+		TunnelID:       created.ID,
+		TokenEncrypted: encrypted,
+	}
+	if err := s.tunnelRepo.Upsert(ctx, tunnel); err != nil {
+		return nil, err
+	}
+	return tunnel, nil
+}
+
+// Disable stops cloudflared, deletes the tunnel and points the server's
+// domains back at its host. Cleanup on Cloudflare and the agent is best
+// effort so a lost server can still be detached.
+func (s *TunnelService) Disable(ctx context.Context, server *domain.Server) error {
+	tunnel, err := s.tunnelRepo.FindByServerID(ctx, server.ID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := s.agentClient.RemoveTunnel(ctx, server.Host, s.agentPort); err != nil {
+		s.logger.Warn("Failed to stop cloudflared", "serverId", server.ID, "error", err)
+	}
+
+	if err := s.tunnelRepo.Delete(ctx, server.ID); err != nil {
+		return err
+	}
+
+	cf, err := s.cloudflareClient(ctx, server.UserID)
+	if err != nil {
+		s.logger.Warn("Cloudflare unavailable while disabling tunnel", "serverId", server.ID, "error", err)
+	} else {
+		s.switchDNS(ctx, cf, server, nil)
+		if err := cf.DeleteTunnel(ctx, tunnel.AccountID, tunnel.TunnelID); err != nil {
+			s.logger.Warn("Failed to delete tunnel", "serverId", server.ID, "tunnelId", tunnel.TunnelID, "error", err)
+		}
+	}
+
+	s.logger.Info("Tunnel disabled", "serverId", server.ID, "tunnelId", tunnel.TunnelID)
+	return nil
+}
+
+// SyncIngress pushes the server's current hostnames to its tunnel. It is a
+// no-op for servers without a tunnel.
+func (s *TunnelService) SyncIngress(ctx context.Context, serverID string) error {
+	tunnel, err := s.tunnelRepo.FindByServerID(ctx, serverID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	server, err := s.serverRepo.FindByID(serverID)
+	if err != nil {
+		return err
+	}
+	cf, err := s.cloudflareClient(ctx, server.UserID)
+	if err != nil {
+		return err
+	}
+	return s.syncIngress(ctx, cf, serverID, tunnel)
+}
+
+func (s *TunnelService) syncIngress(ctx context.Context, cf tunnelCloudflare, serverID string, tunnel *domain.ServerTunnel) error {
+	hostnames, err := s.serverHostnames(ctx, serverID)
+	if err != nil {
+		return err
+	}
+	return cf.UpdateTunnelIngress(ctx, tunnel.AccountID, tunnel.TunnelID, hostnames, tunnelOriginService)
+}
+
+func (s *TunnelService) serverHostnames(ctx context.Context, serverID string) ([]string, error) {
+	apps, err := s.appRepo.FindByServerID(serverID)
+	if err != nil {
+		return nil, err
+	}
+	seen := map[string]bool{}
+	appIDs := make([]string, len(apps))
+	for i, app := range apps {
+		appIDs[i] = app.ID
+		for _, r := range app.Redirects {
+			seen[strings.ToLower(r.SourceHost)] = true
+		}
+	}
+	domains, err := s.domainRepo.FindByAppIDs(ctx, appIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range domains {
+		seen[strings.ToLower(d.Domain)] = true
+	}
+
+	hostnames := make([]string, 0, len(seen))
+	for hostname := range seen {
+		if hostname != "" {
+			hostnames = append(hostnames, hostname)
+		}
+	}
+	sort.Strings(hostnames)
+	return hostnames, nil
+}
+
+// switchDNS repoints the Cloudflare DNS records of the server's custom
+// domains at the tunnel, or back at the server host when tunnel is nil. Failures are logged
+// per domain so one broken zone does not block the rest.
+func (s *TunnelService) switchDNS(ctx context.Context, cf tunnelCloudflare, server *domain.Server, tunnel *domain.ServerTunnel) {
+	apps, err := s.appRepo.FindByServerID(server.ID)
+	if err != nil {
+		s.logger.Warn("Failed to list server apps for DNS switch", "serverId", server.ID, "error", err)
+		return
+	}
+
+	wantType := recordTypeA
+	if tunnel != nil {
+		wantType = recordTypeCNAME
+	}
+
+	appIDs := make([]string, len(apps))
+	for i, app := range apps {
+		appIDs[i] = app.ID
+	}
+	domains, err := s.domainRepo.FindByAppIDs(ctx, appIDs)
+	if err != nil {
+		s.logger.Warn("Failed to list server domains for DNS switch", "serverId", server.ID, "error", err)
+		return
+	}
+
+	for _, d := range domains {
+		if d.RecordType == wantType || d.ZoneID == "" || d.DNSProvider != domain.DNSProviderCloudflare {
+			continue
+		}
+		if err := cf.DeleteRecord(ctx, d.ZoneID, d.DNSRecordID); err != nil {
+			s.logger.Warn("Failed to delete DNS record", "domain", d.Domain, "error", err)
+		}
+
+		var recordID string
+		if tunnel != nil {
+			recordID, err = cf.CreateOrGetTunnelCNAME(ctx, d.ZoneID, d.Domain, tunnel.TunnelID)
+		} else {
+			recordID, err = cf.CreateOrGetARecord(ctx, d.ZoneID, d.Domain, server.Host)
+		}
+		if err != nil {
+			s.logger.Warn("Failed to create DNS record", "domain", d.Domain, "type", wantType, "error", err)
+			continue
+		}
+		if _, err := s.domainRepo.UpdateDNSRecord(ctx, d.ID, wantType, recordID); err != nil {
+			s.logger.Warn("Failed to save DNS record", "domain", d.Domain, "error", err)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package service
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/paasdeploy/backend/internal/domain"
+)
+
+type fakeDomainRepo struct {
+	domain.CustomDomainRepository
+	domains []domain.CustomDomain
+}
+
+func (r *fakeDomainRepo) FindByAppIDs(_ context.Context, appIDs []string) ([]domain.CustomDomain, error) {
+	var found []domain.CustomDomain
+	for _, d := range r.domains {
+		for _, id := range appIDs {
+			if d.AppID == id {
+				found = append(found, d)
+			}
+		}
+	}
+	return found, nil
+}
+
+func (r *fakeDomainRepo) UpdateDNSRecord(_ context.Context, id, recordType, recordID string) (*domain.CustomDomain, error) {
+	for i := range r.domains {
+		if r.domains[i].ID == id {
+			r.domains[i].RecordType = recordType
+			r.domains[i].DNSRecordID = recordID
+			return &r.domains[i], nil
+		}
+	}
+	return nil, domain.ErrNotFound
+}
+
+type fakeTunnelCloudflare struct {
+	ingress []string
+	deleted []string
+	created []string
+}
+
+func (c *fakeTunnelCloudflare) UpdateTunnelIngress(_ context.Context, _, _ string, hostnames []string, _ string) error {
+	c.ingress = hostnames
+	return nil
+}
+
+func (c *fakeTunnelCloudflare) DeleteRecord(_ context.Context, _, recordID string) error {
+	c.deleted = append(c.deleted, recordID)
+	return nil
+}
+
+func (c *fakeTunnelCloudflare) CreateOrGetTunnelCNAME(_ context.Context, _, name, _ string) (string, error) {
+	c.created = append(c.created, "CNAME "+name)
+	return "cname-" + name, nil
+}
+
+func (c *fakeTunnelCloudflare) CreateOrGetARecord(_ context.Context, _, name, _ string) (string, error) {
+	c.created = append(c.created, "A "+name)
+	return "a-" + name, nil
+}
+
+func newTestTunnelService(apps []domain.App, domains []domain.CustomDomain) (*TunnelService, *fakeDomainRepo) {
+	domainRepo := &fakeDomainRepo{domains: domains}
+	return NewTunnelService(TunnelServiceParams{
+		AppRepo:    &fakeAppRepo{apps: apps},
+		DomainRepo: domainRepo,
+		Logger:     testLogger(),
+	}), domainRepo
+}
+
+func serverApp(id, serverID string, redirects ...domain.AppRedirect) domain.App {
+	return domain.App{ID: id, ServerID: &serverID, Redirects: redirects}
+}
+
+func cloudflareDomain(id, appID, name, recordType string) domain.CustomDomain {
+	return domain.CustomDomain{
+		ID:          id,
+		AppID:       appID,
+		Domain:      name,
+		ZoneID:      "zone",
+		DNSRecordID: "record-" + id,
+		RecordType:  recordType,
+		DNSProvider: domain.DNSProviderCloudflare,
+	}
+}
+
+func TestTunnelServiceServerHostnames(t *testing.T) {
+	svc, _ := newTestTunnelService(
+		[]domain.App{
+			serverApp("a1", "s1", domain.AppRedirect{SourceHost: "Old.Example.com"}),
+			serverApp("a2", "s1"),
+			serverApp("a3", "s2"),
+		},
+		[]domain.CustomDomain{
+			cloudflareDomain("d1", "a1", "App.example.com", recordTypeA),
+			cloudflareDomain("d2", "a2", "app.example.com", recordTypeA),
+			cloudflareDomain("d3", "a2", "api.example.com", recordTypeA),
+			cloudflareDomain("d4", "a3", "other.example.com", recordTypeA),
+		},
+	)
+
+	hostnames, err := svc.serverHostnames(context.Background(), "s1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"api.example.com", "app.example.com", "old.example.com"}
+	if !reflect.DeepEqual(hostnames, want) {
+		t.Errorf("hostnames = %v, want %v", hostnames, want)
+	}
+}
+
+func TestTunnelServiceSyncIngressDropsRemovedHostnames(t *testing.T) {
+	svc, domainRepo := newTestTunnelService(
+		[]domain.App{serverApp("a1", "s1")},
+		[]domain.CustomDomain{
+			cloudflareDomain("d1", "a1", "app.example.com", recordTypeCNAME),
+			cloudflareDomain("d2", "a1", "old.example.com", recordTypeCNAME),
+		},
+	)
+	cf := &fakeTunnelCloudflare{}
+	tunnel := &domain.ServerTunnel{ServerID: "s1", AccountID: "acc", TunnelID: "tun"}
+
+	if err := svc.syncIngress(context.Background(), cf, "s1", tunnel); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"app.example.com", "old.example.com"}; !reflect.DeepEqual(cf.ingress, want) {
+		t.Fatalf("ingress = %v, want %v", cf.ingress, want)
+	}
+
+	domainRepo.domains = domainRepo.domains[:1]
+	if err := svc.syncIngress(context.Background(), cf, "s1", tunnel); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"app.example.com"}; !reflect.DeepEqual(cf.ingress, want) {
+		t.Errorf("ingress = %v, want %v", cf.ingress, want)
+	}
+}
+
+func TestTunnelServiceSwitchDNS(t *testing.T) {
+	external := cloudflareDomain("d3", "a1", "external.example.com", recordTypeA)
+	external.DNSProvider = ""
+	svc, domainRepo := newTestTunnelService(
+		[]domain.App{serverApp("a1", "s1")},
+		[]domain.CustomDomain{
+			cloudflareDomain("d1", "a1", "app.example.com", recordTypeA),
+			cloudflareDomain("d2", "a1", "api.example.com", recordTypeCNAME),
+			external,
+		},
+	)
+	server := &domain.Server{ID: "s1", Host: "203.0.113.10"}
+	tunnel := &domain.ServerTunnel{ServerID: "s1", TunnelID: "tun"}
+
+	cf := &fakeTunnelCloudflare{}
+	svc.switchDNS(context.Background(), cf, server, tunnel)
+	if want := []string{"CNAME app.example.com"}; !reflect.DeepEqual(cf.created, want) {
+		t.Fatalf("created = %v, want %v", cf.created, want)
+	}
+	if want := []string{"record-d1"}; !reflect.DeepEqual(cf.deleted, want) {
+		t.Errorf("deleted = %v, want %v", cf.deleted, want)
+	}
+	if d := domainRepo.domains[0]; d.RecordType != recordTypeCNAME || d.DNSRecordID != "cname-app.example.com" {
+		t.Errorf("domain after switch to tunnel = %s %s", d.RecordType, d.DNSRecordID)
+	}
+
+	cf = &fakeTunnelCloudflare{}
+	svc.switchDNS(context.Background(), cf, server, nil)
+	if want := []string{"A app.example.com", "A api.example.com"}; !reflect.DeepEqual(cf.created, want) {
+		t.Fatalf("created = %v, want %v", cf.created, want)
+	}
+	if want := []string{"cname-app.example.com", "record-d2"}; !reflect.DeepEqual(cf.deleted, want) {
+		t.Errorf("deleted = %v, want %v", cf.deleted, want)
+	}
+	for _, d := range domainRepo.domains[:2] {
+		if d.RecordType != recordTypeA || d.DNSRecordID != "a-"+d.Domain {
+			t.Errorf("domain %s after switch back = %s %s", d.Domain, d.RecordType, d.DNSRecordID)
+		}
+	}
+	if d := domainRepo.domains[2]; d.RecordType != recordTypeA || d.DNSRecordID != "record-d3" {
+		t.Errorf("domain without a Cloudflare record was changed: %+v", d)
+	}
+}
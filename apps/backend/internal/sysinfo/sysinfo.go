@@ -93,6 +93,11 @@ func getSystemMetrics() SystemMetrics {
 	}
 }
 
+// MemoryTotal returns the RAM of the host in bytes, or 0 if unknown.
+func MemoryTotal() int64 {
+	return readMemTotal()
+}
+
 func readMemTotal() int64 {
 	return readMemInfoKey("MemTotal")
 }
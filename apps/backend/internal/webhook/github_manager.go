@@ -152,6 +152,20 @@ func (m *GitHubManager) ListCommits(ctx context.Context, repoURL, branch string,
 	return commits, nil
 }
 
+func (m *GitHubManager) CompareCommits(ctx context.Context, repoURL, base, head string) ([]ghclient.CommitInfo, error) {
+	owner, repo, err := parseGitHubURL(repoURL)
+	if err != nil {
+		return nil, fmt.Errorf(errParseRepoURL, err)
+	}
+
+	commits, err := m.provider.CompareCommits(ctx, owner, repo, base, head)
+	if err != nil {
+		return nil, fmt.Errorf("compare commits: %w", err)
+	}
+
+	return commits, nil
+}
+
 var (
 	httpsPattern = regexp.MustCompile(`^https://github\.com/([^/]+)/([^/]+?)(?:\.git)?$`)
 	sshPattern   = regexp.MustCompile(`^git@github\.com:([^/]+)/([^/]+?)(?:\.git)?$`)
@@ -58,6 +58,10 @@ func (m *mockProvider) ListCommits(ctx context.Context, owner, repo, branch stri
 	return []ghclient.CommitInfo{}, nil
 }
 
+func (m *mockProvider) CompareCommits(ctx context.Context, owner, repo, base, head string) ([]ghclient.CommitInfo, error) {
+	return []ghclient.CommitInfo{}, nil
+}
+
 func TestGitHubManagerSetup(t *testing.T) {
 	provider := &mockProvider{}
 	manager := NewGitHubManager(provider, testWebhookURL, testWebhookSecret)
@@ -38,5 +38,7 @@ type Manager interface {
 	Remove(ctx context.Context, input RemoveInput) error
 	Status(ctx context.Context, repoURL string, webhookID int64) (*Status, error)
 	ListCommits(ctx context.Context, repoURL, branch string, perPage int) ([]ghclient.CommitInfo, error)
+	// CompareCommits lists the commits from base to head, oldest first.
+	CompareCommits(ctx context.Context, repoURL, base, head string) ([]ghclient.CommitInfo, error)
 	WebhookURL() string
 }
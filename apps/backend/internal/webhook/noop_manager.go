@@ -33,6 +33,10 @@ func (m *NoOpManager) ListCommits(ctx context.Context, repoURL, branch string, p
 	return nil, nil
 }
 
+func (m *NoOpManager) CompareCommits(ctx context.Context, repoURL, base, head string) ([]ghclient.CommitInfo, error) {
+	return nil, nil
+}
+
 func (m *NoOpManager) WebhookURL() string {
 	return ""
 }
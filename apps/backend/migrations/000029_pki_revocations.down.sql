@@ -0,0 +1 @@
+DROP TABLE IF EXISTS pki_revocations;
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS pki_revocations (
+    id TEXT PRIMARY KEY,
+    server_id UUID NOT NULL REFERENCES servers(id) ON DELETE CASCADE,
+    serial_number TEXT,
+    reason TEXT,
+    revoked_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    revoked_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_pki_revocations_server ON pki_revocations(server_id, revoked_at DESC);
+CREATE INDEX idx_pki_revocations_serial ON pki_revocations(serial_number) WHERE serial_number IS NOT NULL;
+
+COMMENT ON TABLE pki_revocations IS 'Revoked agent certificates, by serial and by server identity';
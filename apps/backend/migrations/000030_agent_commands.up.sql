@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS agent_commands (
+    id TEXT PRIMARY KEY,
+    server_id UUID NOT NULL REFERENCES servers(id) ON DELETE CASCADE,
+    command_type TEXT NOT NULL,
+    payload TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'delivered', 'succeeded', 'failed', 'expired', 'cancelled')),
+    result_message TEXT,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    delivered_at TIMESTAMPTZ,
+    completed_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_agent_commands_server_status ON agent_commands(server_id, status, created_at);
+
+COMMENT ON TABLE agent_commands IS 'Commands queued for delivery to agents through heartbeats';
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS server_heartbeats (
+    server_id UUID NOT NULL REFERENCES servers(id) ON DELETE CASCADE,
+    received_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (server_id, received_at)
+);
+
+CREATE INDEX idx_server_heartbeats_received_at ON server_heartbeats(received_at);
+
+COMMENT ON TABLE server_heartbeats IS 'Agent heartbeat history used to compute server availability';
@@ -0,0 +1 @@
+ALTER TABLE servers DROP COLUMN agent_install_method;
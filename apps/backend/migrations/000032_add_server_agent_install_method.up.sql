@@ -0,0 +1 @@
+ALTER TABLE servers ADD COLUMN agent_install_method VARCHAR(20) NOT NULL DEFAULT 'auto';
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS server_bootstrap_tokens (
+    token_hash VARCHAR(64) PRIMARY KEY,
+    server_id UUID NOT NULL REFERENCES servers(id) ON DELETE CASCADE,
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_server_bootstrap_tokens_server_id ON server_bootstrap_tokens(server_id);
+
+COMMENT ON TABLE server_bootstrap_tokens IS 'One-shot tokens embedded in self-provisioning install scripts';
@@ -0,0 +1 @@
+ALTER TABLE servers ADD COLUMN docker_rootless BOOLEAN NOT NULL DEFAULT FALSE;
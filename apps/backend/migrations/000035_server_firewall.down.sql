@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS server_firewalls;
+
+ALTER TABLE servers DROP COLUMN IF EXISTS firewall_enabled;
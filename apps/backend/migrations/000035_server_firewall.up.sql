@@ -0,0 +1,10 @@
+ALTER TABLE servers ADD COLUMN firewall_enabled BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE IF NOT EXISTS server_firewalls (
+    server_id UUID PRIMARY KEY REFERENCES servers(id) ON DELETE CASCADE,
+    backend VARCHAR(16) NOT NULL,
+    rules JSONB NOT NULL DEFAULT '[]',
+    applied_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON TABLE server_firewalls IS 'Inbound firewall rules last applied to each server during provisioning';
@@ -0,0 +1 @@
+ALTER TABLE servers ADD COLUMN ssh_hardening BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1 @@
+ALTER TABLE servers DROP COLUMN bastion_server_id;
@@ -0,0 +1 @@
+ALTER TABLE servers ADD COLUMN bastion_server_id UUID REFERENCES servers(id) ON DELETE SET NULL;
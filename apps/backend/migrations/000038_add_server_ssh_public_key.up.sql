@@ -0,0 +1 @@
+ALTER TABLE servers ADD COLUMN ssh_public_key TEXT DEFAULT '';
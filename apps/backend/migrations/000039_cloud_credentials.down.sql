@@ -0,0 +1,3 @@
+ALTER TABLE servers DROP COLUMN IF EXISTS cloud_instance_id;
+ALTER TABLE servers DROP COLUMN IF EXISTS cloud_provider;
+DROP TABLE IF EXISTS cloud_credentials;
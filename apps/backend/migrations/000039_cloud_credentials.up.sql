@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS cloud_credentials (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    provider VARCHAR(32) NOT NULL,
+    credentials_encrypted TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (user_id, provider)
+);
+
+ALTER TABLE servers ADD COLUMN cloud_provider VARCHAR(32);
+ALTER TABLE servers ADD COLUMN cloud_instance_id VARCHAR(128);
+
+COMMENT ON TABLE cloud_credentials IS 'Encrypted cloud provider API credentials used to create servers';
@@ -0,0 +1 @@
+ALTER TABLE custom_domains DROP COLUMN IF EXISTS basic_auth_users;
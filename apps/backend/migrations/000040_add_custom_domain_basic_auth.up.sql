@@ -0,0 +1 @@
+ALTER TABLE custom_domains ADD COLUMN basic_auth_users TEXT NOT NULL DEFAULT '';
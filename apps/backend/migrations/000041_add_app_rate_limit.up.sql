@@ -0,0 +1 @@
+ALTER TABLE apps ADD COLUMN rate_limit JSONB;
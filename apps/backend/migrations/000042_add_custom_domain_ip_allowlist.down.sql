@@ -0,0 +1 @@
+ALTER TABLE custom_domains DROP COLUMN IF EXISTS ip_allowlist;
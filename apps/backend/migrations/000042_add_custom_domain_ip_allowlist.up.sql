@@ -0,0 +1 @@
+ALTER TABLE custom_domains ADD COLUMN ip_allowlist TEXT NOT NULL DEFAULT '';
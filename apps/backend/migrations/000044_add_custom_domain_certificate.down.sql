@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_custom_domains_tls_expires_at;
+
+ALTER TABLE custom_domains
+    DROP COLUMN IF EXISTS tls_reminder_sent_at,
+    DROP COLUMN IF EXISTS tls_expires_at,
+    DROP COLUMN IF EXISTS tls_private_key,
+    DROP COLUMN IF EXISTS tls_certificate;
@@ -0,0 +1,7 @@
+ALTER TABLE custom_domains
+    ADD COLUMN tls_certificate TEXT NOT NULL DEFAULT '',
+    ADD COLUMN tls_private_key TEXT NOT NULL DEFAULT '',
+    ADD COLUMN tls_expires_at TIMESTAMPTZ,
+    ADD COLUMN tls_reminder_sent_at TIMESTAMPTZ;
+
+CREATE INDEX idx_custom_domains_tls_expires_at ON custom_domains (tls_expires_at) WHERE tls_expires_at IS NOT NULL;
@@ -0,0 +1 @@
+ALTER TABLE servers ADD COLUMN IF NOT EXISTS acme_staging BOOLEAN NOT NULL DEFAULT false;
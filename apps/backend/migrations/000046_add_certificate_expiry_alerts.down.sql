@@ -0,0 +1,3 @@
+ALTER TABLE custom_domains ADD COLUMN IF NOT EXISTS tls_reminder_sent_at TIMESTAMPTZ;
+
+DROP TABLE IF EXISTS certificate_expiry_alerts;
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS certificate_expiry_alerts (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    domain VARCHAR(255) NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    threshold_days INTEGER NOT NULL,
+    sent_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (domain, expires_at, threshold_days)
+);
+
+CREATE INDEX idx_certificate_expiry_alerts_expires_at ON certificate_expiry_alerts (expires_at);
+
+ALTER TABLE custom_domains DROP COLUMN IF EXISTS tls_reminder_sent_at;
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS server_tunnels (
+    server_id UUID PRIMARY KEY REFERENCES servers(id) ON DELETE CASCADE,
+    account_id VARCHAR(64) NOT NULL,
+    tunnel_id VARCHAR(64) NOT NULL,
+    token_encrypted TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
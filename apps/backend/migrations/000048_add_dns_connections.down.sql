@@ -0,0 +1,3 @@
+ALTER TABLE custom_domains DROP COLUMN IF EXISTS dns_provider;
+
+DROP TABLE IF EXISTS dns_connections;
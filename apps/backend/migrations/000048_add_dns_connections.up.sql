@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS dns_connections (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    provider VARCHAR(32) NOT NULL,
+    credentials_encrypted TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (user_id, provider)
+);
+
+ALTER TABLE custom_domains ADD COLUMN IF NOT EXISTS dns_provider VARCHAR(32) NOT NULL DEFAULT 'cloudflare';
+
+COMMENT ON TABLE dns_connections IS 'Encrypted DNS provider API credentials used to manage custom domain records';
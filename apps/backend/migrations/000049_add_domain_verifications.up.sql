@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS domain_verifications (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    domain VARCHAR(255) NOT NULL,
+    token VARCHAR(64) NOT NULL,
+    verified_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (user_id, domain)
+);
+
+CREATE INDEX IF NOT EXISTS idx_domain_verifications_user ON domain_verifications(user_id);
+
+-- Domains attached before verification existed stay usable by their owners.
+INSERT INTO domain_verifications (user_id, domain, token, verified_at)
+SELECT DISTINCT a.user_id, cd.domain, md5(random()::text), NOW()
+FROM custom_domains cd
+JOIN apps a ON a.id = cd.app_id
+ON CONFLICT (user_id, domain) DO NOTHING;
+
+COMMENT ON TABLE domain_verifications IS 'Proof of domain ownership through a DNS TXT or CNAME record, required before attaching a custom domain';
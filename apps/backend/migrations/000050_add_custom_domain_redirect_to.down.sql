@@ -0,0 +1 @@
+ALTER TABLE custom_domains DROP COLUMN IF EXISTS redirect_to;
@@ -0,0 +1,3 @@
+ALTER TABLE custom_domains ADD COLUMN IF NOT EXISTS redirect_to VARCHAR(255) NOT NULL DEFAULT '';
+
+COMMENT ON COLUMN custom_domains.redirect_to IS 'Domain this one permanently redirects to, set on the secondary name of an apex/www pair';
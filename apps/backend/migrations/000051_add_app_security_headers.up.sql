@@ -0,0 +1 @@
+ALTER TABLE apps ADD COLUMN IF NOT EXISTS security_headers JSONB;
@@ -0,0 +1,2 @@
+ALTER TABLE apps DROP COLUMN IF EXISTS linked_app_ids;
+ALTER TABLE apps DROP COLUMN IF EXISTS internal;
@@ -0,0 +1,2 @@
+ALTER TABLE apps ADD COLUMN IF NOT EXISTS internal BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE apps ADD COLUMN IF NOT EXISTS linked_app_ids JSONB;
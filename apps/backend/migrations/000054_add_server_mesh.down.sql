@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_servers_user_mesh_ip;
+ALTER TABLE servers DROP COLUMN IF EXISTS mesh_public_key;
+ALTER TABLE servers DROP COLUMN IF EXISTS mesh_ip;
@@ -0,0 +1,3 @@
+ALTER TABLE servers ADD COLUMN IF NOT EXISTS mesh_ip TEXT;
+ALTER TABLE servers ADD COLUMN IF NOT EXISTS mesh_public_key TEXT;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_servers_user_mesh_ip ON servers (user_id, mesh_ip) WHERE mesh_ip IS NOT NULL;
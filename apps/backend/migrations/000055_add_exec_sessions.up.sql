@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS exec_sessions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    user_name VARCHAR(255) NOT NULL DEFAULT '',
+    server_id UUID REFERENCES servers(id) ON DELETE SET NULL,
+    container_id VARCHAR(255) NOT NULL,
+    shell VARCHAR(64) NOT NULL,
+    ip_address VARCHAR(45),
+    started_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    ended_at TIMESTAMPTZ,
+    exit_code INTEGER,
+    output_bytes BIGINT NOT NULL DEFAULT 0,
+    truncated BOOLEAN NOT NULL DEFAULT FALSE,
+    recording BYTEA
+);
+
+CREATE INDEX IF NOT EXISTS idx_exec_sessions_started_at ON exec_sessions (started_at DESC);
+CREATE INDEX IF NOT EXISTS idx_exec_sessions_user_id ON exec_sessions (user_id);
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS gitops_sources (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    repository_url TEXT NOT NULL,
+    branch VARCHAR(255) NOT NULL DEFAULT 'main',
+    path VARCHAR(500) NOT NULL DEFAULT '.',
+    webhook_id BIGINT,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    last_commit_sha VARCHAR(64),
+    last_error TEXT,
+    apps JSONB NOT NULL DEFAULT '[]',
+    last_synced_at TIMESTAMPTZ,
+    last_checked_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (user_id, repository_url, branch, path)
+);
+
+CREATE INDEX IF NOT EXISTS idx_gitops_sources_user_id ON gitops_sources (user_id);
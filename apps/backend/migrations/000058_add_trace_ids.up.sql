@@ -0,0 +1,3 @@
+ALTER TABLE audit_logs ADD COLUMN IF NOT EXISTS trace_id VARCHAR(128);
+ALTER TABLE deployments ADD COLUMN IF NOT EXISTS trace_id VARCHAR(128);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_trace_id ON audit_logs(trace_id) WHERE trace_id IS NOT NULL;
@@ -0,0 +1,3 @@
+ALTER TABLE apps ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+UPDATE apps SET deleted_at = updated_at WHERE status = 'deleted' AND deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_apps_deleted_at ON apps(deleted_at) WHERE deleted_at IS NOT NULL;
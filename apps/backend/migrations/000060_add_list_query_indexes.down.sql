@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_apps_user_created;
+CREATE INDEX IF NOT EXISTS idx_deployments_app_id ON deployments(app_id);
+DROP INDEX IF EXISTS idx_deployments_app_created;
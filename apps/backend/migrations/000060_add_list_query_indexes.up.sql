@@ -0,0 +1,3 @@
+CREATE INDEX IF NOT EXISTS idx_deployments_app_created ON deployments(app_id, created_at DESC, id DESC);
+DROP INDEX IF EXISTS idx_deployments_app_id;
+CREATE INDEX IF NOT EXISTS idx_apps_user_created ON apps(user_id, created_at DESC, id DESC) WHERE status != 'deleted';
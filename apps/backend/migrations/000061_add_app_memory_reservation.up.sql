@@ -0,0 +1,3 @@
+ALTER TABLE apps ADD COLUMN IF NOT EXISTS memory_reservation_bytes BIGINT NOT NULL DEFAULT 0;
+
+COMMENT ON COLUMN apps.memory_reservation_bytes IS 'Memory limit of the last successful deploy, counted against the owner memory quota';
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS usage_records (
+    id UUID PRIMARY KEY,
+    user_id UUID,
+    app_id UUID NOT NULL,
+    app_name VARCHAR(255) NOT NULL,
+    server_id UUID,
+    metric VARCHAR(32) NOT NULL,
+    quantity DOUBLE PRECISION NOT NULL,
+    period_start TIMESTAMPTZ NOT NULL,
+    period_end TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_usage_records_user_period ON usage_records(user_id, period_start);
+CREATE INDEX IF NOT EXISTS idx_usage_records_app_period ON usage_records(app_id, period_start);
+
+COMMENT ON TABLE usage_records IS 'Metered usage per app for billing; no foreign keys so records outlive deleted apps and users';
@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS idx_audit_logs_app_id ON audit_logs((details->>'app_id')) WHERE details ? 'app_id';
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS app_health_events (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    app_id UUID NOT NULL REFERENCES apps(id) ON DELETE CASCADE,
+    status VARCHAR(32) NOT NULL,
+    health VARCHAR(32) NOT NULL,
+    started_at TIMESTAMPTZ NOT NULL,
+    ended_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_app_health_events_app_started ON app_health_events(app_id, started_at DESC);
+CREATE UNIQUE INDEX idx_app_health_events_open ON app_health_events(app_id) WHERE ended_at IS NULL;
+
+COMMENT ON TABLE app_health_events IS 'Container status and health periods per app, used to diagnose flapping apps';
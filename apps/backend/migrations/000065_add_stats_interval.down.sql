@@ -0,0 +1,2 @@
+ALTER TABLE servers DROP COLUMN IF EXISTS stats_interval_seconds;
+ALTER TABLE apps DROP COLUMN IF EXISTS stats_interval_seconds;
@@ -0,0 +1,2 @@
+ALTER TABLE apps ADD COLUMN IF NOT EXISTS stats_interval_seconds INTEGER;
+ALTER TABLE servers ADD COLUMN IF NOT EXISTS stats_interval_seconds INTEGER;
@@ -0,0 +1,2 @@
+ALTER TABLE deployments DROP COLUMN IF EXISTS archived_log_bytes;
+ALTER TABLE deployments DROP COLUMN IF EXISTS archived_log_chunks;
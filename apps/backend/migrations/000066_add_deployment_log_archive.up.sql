@@ -0,0 +1,2 @@
+ALTER TABLE deployments ADD COLUMN IF NOT EXISTS archived_log_chunks INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE deployments ADD COLUMN IF NOT EXISTS archived_log_bytes BIGINT NOT NULL DEFAULT 0;
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS deployment_sboms (
+    deployment_id UUID PRIMARY KEY REFERENCES deployments(id) ON DELETE CASCADE,
+    format VARCHAR(32) NOT NULL,
+    document BYTEA NOT NULL,
+    package_count INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON TABLE deployment_sboms IS 'Software bill of materials of the image of each deployment';
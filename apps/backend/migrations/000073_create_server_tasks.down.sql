@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS server_task_runs;
+DROP TABLE IF EXISTS server_tasks;
@@ -0,0 +1,35 @@
+CREATE TABLE IF NOT EXISTS server_tasks (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    server_id UUID NOT NULL REFERENCES servers(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    schedule VARCHAR(100) NOT NULL,
+    command TEXT NOT NULL,
+    timeout_seconds INTEGER NOT NULL DEFAULT 600,
+    enabled BOOLEAN NOT NULL DEFAULT true,
+    notify_on_failure BOOLEAN NOT NULL DEFAULT true,
+    next_run_at TIMESTAMPTZ,
+    last_run_at TIMESTAMPTZ,
+    last_status VARCHAR(20),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_server_tasks_server_id ON server_tasks(server_id);
+CREATE INDEX IF NOT EXISTS idx_server_tasks_next_run_at ON server_tasks(next_run_at) WHERE enabled;
+
+CREATE TABLE IF NOT EXISTS server_task_runs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    task_id UUID NOT NULL REFERENCES server_tasks(id) ON DELETE CASCADE,
+    server_id UUID NOT NULL REFERENCES servers(id) ON DELETE CASCADE,
+    trigger VARCHAR(20) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    exit_code INTEGER,
+    output TEXT NOT NULL DEFAULT '',
+    truncated BOOLEAN NOT NULL DEFAULT false,
+    error TEXT,
+    started_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    finished_at TIMESTAMPTZ,
+    duration_ms BIGINT NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS idx_server_task_runs_task_id ON server_task_runs(task_id, started_at DESC);
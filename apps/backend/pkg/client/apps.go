@@ -0,0 +1,127 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// Me returns the user the token belongs to.
+func (c *Client) Me(ctx context.Context) (*User, error) {
+	var user User
+	if err := c.Do(ctx, http.MethodGet, "/auth/me", nil, &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (c *Client) ListApps(ctx context.Context) ([]App, error) {
+	var apps []App
+	if err := c.Do(ctx, http.MethodGet, APIPrefix+"/apps", nil, &apps); err != nil {
+		return nil, err
+	}
+	return apps, nil
+}
+
+func (c *Client) GetApp(ctx context.Context, id string) (*App, error) {
+	var app App
+	if err := c.Do(ctx, http.MethodGet, appPath(id), nil, &app); err != nil {
+		return nil, err
+	}
+	return &app, nil
+}
+
+// FindApp looks an app up by ID or name. A missing app is reported as an
+// *APIError with CodeNotFound, like GetApp.
+func (c *Client) FindApp(ctx context.Context, idOrName string) (*App, error) {
+	apps, err := c.ListApps(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range apps {
+		if apps[i].ID == idOrName || apps[i].Name == idOrName {
+			return &apps[i], nil
+		}
+	}
+	return nil, &APIError{StatusCode: http.StatusNotFound, Code: CodeNotFound, Message: fmt.Sprintf("app %q not found", idOrName)}
+}
+
+func (c *Client) CreateApp(ctx context.Context, input CreateAppInput) (*App, error) {
+	var app App
+	if err := c.Do(ctx, http.MethodPost, APIPrefix+"/apps", input, &app); err != nil {
+		return nil, err
+	}
+	return &app, nil
+}
+
+func (c *Client) DeleteApp(ctx context.Context, id string) error {
+	return c.Do(ctx, http.MethodDelete, appPath(id), nil, nil)
+}
+
+// Deploy queues a deploy of the app. An empty commitSHA deploys the head of
+// the app's branch.
+func (c *Client) Deploy(ctx context.Context, appID, commitSHA string) (*Deployment, error) {
+	body := map[string]string{"commitSha": commitSHA}
+	var deployment Deployment
+	if err := c.Do(ctx, http.MethodPost, appPath(appID)+"/redeploy", body, &deployment); err != nil {
+		return nil, err
+	}
+	return &deployment, nil
+}
+
+// Rollback redeploys the last successful image of the app.
+func (c *Client) Rollback(ctx context.Context, appID string) (*Deployment, error) {
+	var deployment Deployment
+	if err := c.Do(ctx, http.MethodPost, appPath(appID)+"/rollback", nil, &deployment); err != nil {
+		return nil, err
+	}
+	return &deployment, nil
+}
+
+func (c *Client) ListDeployments(ctx context.Context, appID string) ([]Deployment, error) {
+	var deployments []Deployment
+	if err := c.Do(ctx, http.MethodGet, appPath(appID)+"/deployments", nil, &deployments); err != nil {
+		return nil, err
+	}
+	return deployments, nil
+}
+
+func (c *Client) ListEnvVars(ctx context.Context, appID string) ([]EnvVar, error) {
+	var vars []EnvVar
+	if err := c.Do(ctx, http.MethodGet, appPath(appID)+"/env", nil, &vars); err != nil {
+		return nil, err
+	}
+	return vars, nil
+}
+
+// SetEnvVars creates or updates the given variables and returns the full
+// list. Changes take effect on the next deploy.
+func (c *Client) SetEnvVars(ctx context.Context, appID string, vars []EnvVarInput) ([]EnvVar, error) {
+	body := map[string]any{"vars": vars}
+	var result []EnvVar
+	if err := c.Do(ctx, http.MethodPut, appPath(appID)+"/env/bulk", body, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *Client) ListServers(ctx context.Context) ([]Server, error) {
+	var servers []Server
+	if err := c.Do(ctx, http.MethodGet, APIPrefix+"/servers", nil, &servers); err != nil {
+		return nil, err
+	}
+	return servers, nil
+}
+
+func (c *Client) GetServer(ctx context.Context, id string) (*Server, error) {
+	var server Server
+	if err := c.Do(ctx, http.MethodGet, APIPrefix+"/servers/"+url.PathEscape(id), nil, &server); err != nil {
+		return nil, err
+	}
+	return &server, nil
+}
+
+func appPath(id string) string {
+	return APIPrefix + "/apps/" + url.PathEscape(id)
+}
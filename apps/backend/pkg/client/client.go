@@ -0,0 +1,180 @@
+// Package client is a Go SDK for the FlowDeploy REST API. It authenticates
+// with a personal API token (Settings > API Tokens) and exposes typed
+// wrappers for apps, deployments, environment variables and servers, plus
+// the SSE streams used to follow deploys and container logs.
+//
+//	c := client.New("https://deploy.example.com", os.Getenv("FLOWDEPLOY_TOKEN"))
+//	apps, err := c.ListApps(ctx)
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// APIPrefix is the path prefix of the versioned REST API.
+const APIPrefix = "/paas-deploy/v1"
+
+type Client struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+}
+
+type Option func(*Client)
+
+// WithHTTPClient replaces http.DefaultClient, e.g. to set timeouts or a
+// custom transport. Streaming calls need a client without a total timeout.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+func New(baseURL, token string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		token:      token,
+		httpClient: http.DefaultClient,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Error codes returned by the API. They are stable; branch on them rather
+// than on messages.
+const (
+	CodeInvalidPayload   = "INVALID_PAYLOAD"
+	CodeUnauthorized     = "UNAUTHORIZED"
+	CodeForbidden        = "FORBIDDEN"
+	CodeNotFound         = "NOT_FOUND"
+	CodeMethodNotAllowed = "METHOD_NOT_ALLOWED"
+	CodeConflict         = "CONFLICT"
+	CodePayloadTooLarge  = "PAYLOAD_TOO_LARGE"
+	CodeUnprocessable    = "UNPROCESSABLE"
+	CodeRateLimited      = "RATE_LIMITED"
+	CodeInternal         = "INTERNAL_ERROR"
+	CodeBadGateway       = "BAD_GATEWAY"
+	CodeUnavailable      = "SERVICE_UNAVAILABLE"
+	CodeTimeout          = "TIMEOUT"
+)
+
+// APIError is returned for responses with a non-2xx status, decoded from
+// the RFC 7807 problem details body.
+type APIError struct {
+	StatusCode int
+	Code       string
+	Message    string
+	TraceID    string
+}
+
+func (e *APIError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("request failed with status %d", e.StatusCode)
+	}
+	return fmt.Sprintf("%s: %s", strings.ToLower(e.Code), e.Message)
+}
+
+// ErrorCode returns the API error code of err, or "" when err is not an
+// *APIError.
+func ErrorCode(err error) string {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Code
+	}
+	return ""
+}
+
+type envelope struct {
+	Success bool            `json:"success"`
+	Data    json.RawMessage `json:"data"`
+}
+
+type problem struct {
+	Title   string `json:"title"`
+	Detail  string `json:"detail"`
+	Code    string `json:"code"`
+	TraceID string `json:"traceId"`
+}
+
+func (c *Client) newRequest(ctx context.Context, method, path string, body any) (*http.Request, error) {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reader)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return req, nil
+}
+
+// Do sends a JSON request to path, relative to the base URL, and decodes the
+// data field of the response envelope into out, which may be nil. It is the
+// escape hatch for endpoints without a typed wrapper.
+func (c *Client) Do(ctx context.Context, method, path string, body, out any) error {
+	req, err := c.newRequest(ctx, method, path, body)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return decodeError(resp)
+	}
+
+	var env envelope
+	if err := json.NewDecoder(resp.Body).Decode(&env); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	if !env.Success {
+		return &APIError{StatusCode: resp.StatusCode}
+	}
+	if out == nil {
+		return nil
+	}
+	return json.Unmarshal(env.Data, out)
+}
+
+// decodeError builds an *APIError from a problem details body. Bodies that
+// are not JSON, such as proxy error pages, leave Code empty.
+func decodeError(resp *http.Response) error {
+	apiErr := &APIError{StatusCode: resp.StatusCode}
+	var p problem
+	if err := json.NewDecoder(resp.Body).Decode(&p); err != nil {
+		return apiErr
+	}
+	apiErr.Code = p.Code
+	apiErr.Message = p.Detail
+	if apiErr.Message == "" {
+		apiErr.Message = p.Title
+	}
+	apiErr.TraceID = p.TraceID
+	return apiErr
+}
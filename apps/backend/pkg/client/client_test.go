@@ -0,0 +1,88 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDoDecodesEnvelope(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer fdp_test" {
+			t.Errorf("Authorization = %q", got)
+		}
+		switch r.URL.Path {
+		case APIPrefix + "/apps":
+			_, _ = io.WriteString(w, `{"success":true,"data":[{"id":"a1","name":"web","status":"active"}]}`)
+		default:
+			w.Header().Set("Content-Type", "application/problem+json")
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = io.WriteString(w, `{"type":"urn:paasdeploy:error:not-found","title":"Not Found","status":404,"detail":"app not found","code":"NOT_FOUND","traceId":"t1"}`)
+		}
+	}))
+	defer server.Close()
+
+	c := New(server.URL+"/", "fdp_test")
+
+	app, err := c.FindApp(context.Background(), "web")
+	if err != nil {
+		t.Fatalf("FindApp() error = %v", err)
+	}
+	if app.ID != "a1" {
+		t.Fatalf("app.ID = %q, want a1", app.ID)
+	}
+
+	_, err = c.GetApp(context.Background(), "missing")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("GetApp() error = %v, want *APIError", err)
+	}
+	if apiErr.StatusCode != http.StatusNotFound || apiErr.Message != "app not found" || apiErr.TraceID != "t1" {
+		t.Fatalf("apiErr = %+v", apiErr)
+	}
+	if code := ErrorCode(err); code != CodeNotFound {
+		t.Fatalf("ErrorCode() = %q, want %q", code, CodeNotFound)
+	}
+}
+
+func TestEventStreamNext(t *testing.T) {
+	raw := ": keepalive\n\n" +
+		"event: log\ndata: {\"type\":\"LOG\"}\n\n" +
+		"data: first\ndata: second\n\n"
+	stream := newEventStream(io.NopCloser(strings.NewReader(raw)))
+
+	want := []Event{
+		{Name: "log", Data: `{"type":"LOG"}`},
+		{Data: "first\nsecond"},
+	}
+	for _, w := range want {
+		event, err := stream.Next()
+		if err != nil {
+			t.Fatalf("Next() error = %v", err)
+		}
+		if event != w {
+			t.Fatalf("Next() = %+v, want %+v", event, w)
+		}
+	}
+	if _, err := stream.Next(); err != io.EOF {
+		t.Fatalf("Next() error = %v, want io.EOF", err)
+	}
+}
+
+func TestDeployEventStreamSkipsOtherEvents(t *testing.T) {
+	raw := "event: stats\ndata: {\"type\":\"STATS\"}\n\n" +
+		"event: deploy\ndata: {\"type\":\"SUCCESS\",\"deployId\":\"d1\"}\n\n"
+	stream := &DeployEventStream{newEventStream(io.NopCloser(strings.NewReader(raw)))}
+
+	event, err := stream.Next()
+	if err != nil {
+		t.Fatalf("Next() error = %v", err)
+	}
+	if event.Type != DeployEventSuccess || event.DeployID != "d1" {
+		t.Fatalf("Next() = %+v", event)
+	}
+}
@@ -0,0 +1,94 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/fasthttp/websocket"
+)
+
+// consoleResizeByte prefixes terminal resize messages on the console socket.
+const consoleResizeByte = 0x01
+
+// Console is an interactive shell in a container, the same session the
+// dashboard terminal opens. Sessions are recorded server-side.
+// Write and Resize may be called concurrently with each other and with Recv.
+type Console struct {
+	conn    *websocket.Conn
+	writeMu sync.Mutex
+}
+
+type ConsoleOptions struct {
+	Shell string
+	Cols  int
+	Rows  int
+	// ServerID is empty for containers on the backend host, which requires
+	// an admin token.
+	ServerID string
+}
+
+// OpenConsole starts a shell in a container. Apps run in a container named
+// after the app, so app.Name and app.Server() can be passed directly.
+func (c *Client) OpenConsole(ctx context.Context, containerID string, opts ConsoleOptions) (*Console, error) {
+	query := url.Values{}
+	query.Set("shell", opts.Shell)
+	query.Set("cols", strconv.Itoa(opts.Cols))
+	query.Set("rows", strconv.Itoa(opts.Rows))
+	if opts.ServerID != "" {
+		query.Set("serverId", opts.ServerID)
+	}
+
+	wsURL := c.baseURL + APIPrefix + "/containers/" + url.PathEscape(containerID) + "/console?" + query.Encode()
+	wsURL = "ws" + strings.TrimPrefix(wsURL, "http")
+
+	header := http.Header{}
+	header.Set("Authorization", "Bearer "+c.token)
+
+	conn, resp, err := websocket.DefaultDialer.DialContext(ctx, wsURL, header)
+	if err != nil {
+		if resp != nil {
+			return nil, &APIError{StatusCode: resp.StatusCode, Message: "failed to open console"}
+		}
+		return nil, fmt.Errorf("failed to open console: %w", err)
+	}
+	return &Console{conn: conn}, nil
+}
+
+// Write sends input to the shell.
+func (c *Console) Write(data []byte) (int, error) {
+	if err := c.write(data); err != nil {
+		return 0, err
+	}
+	return len(data), nil
+}
+
+// Recv returns the next chunk of shell output. It returns an error once the
+// shell exits and the server closes the socket.
+func (c *Console) Recv() ([]byte, error) {
+	_, data, err := c.conn.ReadMessage()
+	return data, err
+}
+
+func (c *Console) Resize(cols, rows int) error {
+	payload, err := json.Marshal(map[string]int{"cols": cols, "rows": rows})
+	if err != nil {
+		return err
+	}
+	return c.write(append([]byte{consoleResizeByte}, payload...))
+}
+
+func (c *Console) write(data []byte) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	return c.conn.WriteMessage(websocket.BinaryMessage, data)
+}
+
+func (c *Console) Close() error {
+	return c.conn.Close()
+}
@@ -0,0 +1,111 @@
+package client
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type App struct {
+	ID             string          `json:"id"`
+	Name           string          `json:"name"`
+	RepositoryURL  string          `json:"repositoryUrl"`
+	Branch         string          `json:"branch"`
+	Workdir        string          `json:"workdir"`
+	Runtime        *string         `json:"runtime,omitempty"`
+	Config         json.RawMessage `json:"config,omitempty"`
+	Status         string          `json:"status"`
+	ServerID       *string         `json:"serverId,omitempty"`
+	Internal       bool            `json:"internal"`
+	LinkedAppIDs   []string        `json:"linkedAppIds"`
+	LastDeployedAt *time.Time      `json:"lastDeployedAt,omitempty"`
+	CreatedAt      time.Time       `json:"createdAt"`
+	UpdatedAt      time.Time       `json:"updatedAt"`
+}
+
+// Server returns the ID of the server the app runs on, or "" for apps on the
+// backend host.
+func (a App) Server() string {
+	if a.ServerID == nil {
+		return ""
+	}
+	return *a.ServerID
+}
+
+type CreateAppInput struct {
+	Name          string          `json:"name"`
+	RepositoryURL string          `json:"repositoryUrl"`
+	Branch        string          `json:"branch"`
+	Workdir       string          `json:"workdir"`
+	ServerID      *string         `json:"serverId,omitempty"`
+	Config        json.RawMessage `json:"config,omitempty"`
+}
+
+type Deployment struct {
+	ID               string     `json:"id"`
+	AppID            string     `json:"appId"`
+	CommitSHA        string     `json:"commitSha"`
+	CommitMessage    string     `json:"commitMessage,omitempty"`
+	Status           string     `json:"status"`
+	StartedAt        *time.Time `json:"startedAt,omitempty"`
+	FinishedAt       *time.Time `json:"finishedAt,omitempty"`
+	ErrorMessage     string     `json:"errorMessage,omitempty"`
+	PreviousImageTag string     `json:"previousImageTag,omitempty"`
+	CurrentImageTag  string     `json:"currentImageTag,omitempty"`
+	CreatedAt        time.Time  `json:"createdAt"`
+}
+
+type EnvVar struct {
+	ID        string    `json:"id"`
+	AppID     string    `json:"appId"`
+	Key       string    `json:"key"`
+	Value     string    `json:"value"`
+	IsSecret  bool      `json:"isSecret"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type EnvVarInput struct {
+	Key      string `json:"key"`
+	Value    string `json:"value"`
+	IsSecret bool   `json:"isSecret"`
+}
+
+type Server struct {
+	ID              string  `json:"id"`
+	Name            string  `json:"name"`
+	Host            string  `json:"host"`
+	SSHPort         int     `json:"sshPort"`
+	SSHUser         string  `json:"sshUser"`
+	Status          string  `json:"status"`
+	AgentVersion    *string `json:"agentVersion,omitempty"`
+	DockerRootless  bool    `json:"dockerRootless"`
+	FirewallEnabled bool    `json:"firewallEnabled"`
+	CloudProvider   string  `json:"cloudProvider,omitempty"`
+	LastHeartbeatAt *string `json:"lastHeartbeatAt,omitempty"`
+	CreatedAt       string  `json:"createdAt"`
+	UpdatedAt       string  `json:"updatedAt"`
+}
+
+type User struct {
+	ID    string `json:"id"`
+	Email string `json:"email"`
+	Name  string `json:"name"`
+	Role  string `json:"role"`
+}
+
+// Deploy event types sent on the deploy event stream.
+const (
+	DeployEventRunning = "RUNNING"
+	DeployEventSuccess = "SUCCESS"
+	DeployEventFailed  = "FAILED"
+	DeployEventLog     = "LOG"
+)
+
+type DeployEvent struct {
+	Type      string    `json:"type"`
+	DeployID  string    `json:"deployId,omitempty"`
+	AppID     string    `json:"appId,omitempty"`
+	Status    string    `json:"status,omitempty"`
+	Message   string    `json:"message,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+}
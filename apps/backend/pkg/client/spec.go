@@ -0,0 +1,67 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// AppSpec is the full desired state of an app. Env vars and domains not
+// listed are removed when it is applied.
+type AppSpec struct {
+	Name          string          `json:"name"`
+	RepositoryURL string          `json:"repositoryUrl"`
+	Branch        string          `json:"branch,omitempty"`
+	Workdir       string          `json:"workdir,omitempty"`
+	ServerID      *string         `json:"serverId,omitempty"`
+	Env           []AppSpecEnvVar `json:"env"`
+	Domains       []AppSpecDomain `json:"domains"`
+	Resources     AppResources    `json:"resources"`
+}
+
+type AppSpecEnvVar struct {
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+	Secret bool   `json:"secret,omitempty"`
+}
+
+type AppSpecDomain struct {
+	Domain      string `json:"domain"`
+	PathPrefix  string `json:"pathPrefix,omitempty"`
+	DNSProvider string `json:"dnsProvider,omitempty"`
+}
+
+type AppResources struct {
+	Memory string `json:"memory,omitempty"`
+	CPU    string `json:"cpu,omitempty"`
+}
+
+// SpecChange is one difference between an app and its spec; Action is
+// "add", "update" or "remove".
+type SpecChange struct {
+	Field  string `json:"field"`
+	Action string `json:"action"`
+	From   string `json:"from,omitempty"`
+	To     string `json:"to,omitempty"`
+}
+
+type ApplySpecResult struct {
+	App     *App         `json:"app,omitempty"`
+	Created bool         `json:"created"`
+	DryRun  bool         `json:"dryRun"`
+	Changes []SpecChange `json:"changes"`
+}
+
+// ApplySpec creates or reconciles the app named in spec. With dryRun the
+// changes are only computed.
+func (c *Client) ApplySpec(ctx context.Context, spec AppSpec, dryRun bool) (*ApplySpecResult, error) {
+	path := APIPrefix + "/apps/" + url.PathEscape(spec.Name) + "/spec"
+	if dryRun {
+		path += "?dryRun=true"
+	}
+	var result ApplySpecResult
+	if err := c.Do(ctx, http.MethodPut, path, spec, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
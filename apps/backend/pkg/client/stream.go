@@ -0,0 +1,137 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Event is a single server-sent event.
+type Event struct {
+	Name string
+	Data string
+}
+
+// EventStream reads a server-sent events response until it is closed.
+type EventStream struct {
+	body    io.ReadCloser
+	scanner *bufio.Scanner
+}
+
+func newEventStream(body io.ReadCloser) *EventStream {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	return &EventStream{body: body, scanner: scanner}
+}
+
+// OpenStream opens a server-sent events endpoint at path.
+func (c *Client) OpenStream(ctx context.Context, path string) (*EventStream, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		if resp.StatusCode >= http.StatusBadRequest {
+			return nil, decodeError(resp)
+		}
+		return nil, &APIError{StatusCode: resp.StatusCode, Message: fmt.Sprintf("failed to open stream %s", path)}
+	}
+	return newEventStream(resp.Body), nil
+}
+
+// Next returns the next event, skipping comments such as keepalives. It
+// returns io.EOF when the server closes the stream.
+func (s *EventStream) Next() (Event, error) {
+	var event Event
+	var data []string
+	for s.scanner.Scan() {
+		line := s.scanner.Text()
+		switch {
+		case line == "":
+			if len(data) > 0 {
+				event.Data = strings.Join(data, "\n")
+				return event, nil
+			}
+		case strings.HasPrefix(line, ":"):
+		case strings.HasPrefix(line, "event:"):
+			event.Name = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			data = append(data, strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		}
+	}
+	if err := s.scanner.Err(); err != nil {
+		return event, err
+	}
+	return event, io.EOF
+}
+
+func (s *EventStream) Close() error {
+	return s.body.Close()
+}
+
+// DeployEventStream yields deploy lifecycle and log events.
+type DeployEventStream struct {
+	*EventStream
+}
+
+// DeployEvents subscribes to deploy events of all apps the user can see. The
+// server replays a short backlog of recent events first.
+func (c *Client) DeployEvents(ctx context.Context) (*DeployEventStream, error) {
+	stream, err := c.OpenStream(ctx, "/events/deploys")
+	if err != nil {
+		return nil, err
+	}
+	return &DeployEventStream{stream}, nil
+}
+
+// Next returns the next deploy or deploy log event, skipping health, stats
+// and other events sent on the same stream.
+func (s *DeployEventStream) Next() (DeployEvent, error) {
+	for {
+		event, err := s.EventStream.Next()
+		if err != nil {
+			return DeployEvent{}, err
+		}
+		if event.Name != "deploy" && event.Name != "log" {
+			continue
+		}
+		var deployEvent DeployEvent
+		if err := json.Unmarshal([]byte(event.Data), &deployEvent); err != nil {
+			continue
+		}
+		return deployEvent, nil
+	}
+}
+
+// ContainerLogs returns the last tail lines of the app's container output.
+func (c *Client) ContainerLogs(ctx context.Context, appID string, tail int) (string, error) {
+	var logs struct {
+		Logs string `json:"logs"`
+	}
+	if err := c.Do(ctx, http.MethodGet, containerLogsPath(appID, tail), nil, &logs); err != nil {
+		return "", err
+	}
+	return logs.Logs, nil
+}
+
+// FollowContainerLogs streams the app's container output; each event's Data
+// is one log line.
+func (c *Client) FollowContainerLogs(ctx context.Context, appID string, tail int) (*EventStream, error) {
+	return c.OpenStream(ctx, containerLogsPath(appID, tail)+"&follow=true")
+}
+
+func containerLogsPath(appID string, tail int) string {
+	return appPath(appID) + "/container/logs?tail=" + strconv.Itoa(tail)
+}
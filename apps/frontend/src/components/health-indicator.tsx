@@ -8,7 +8,14 @@ interface HealthIndicatorProps {
   readonly size?: "sm" | "md" | "lg";
 }
 
-type HealthState = "healthy" | "unhealthy" | "starting" | "offline" | "unknown";
+type HealthState =
+  | "healthy"
+  | "unhealthy"
+  | "crash_loop"
+  | "oom_killed"
+  | "starting"
+  | "offline"
+  | "unknown";
 
 interface HealthConfig {
   readonly label: string;
@@ -31,6 +38,18 @@ const healthConfig: Record<HealthState, HealthConfig> = {
     bgColor: "bg-status-failed",
     icon: AlertCircle,
   },
+  crash_loop: {
+    label: "Crash loop",
+    color: "text-status-failed",
+    bgColor: "bg-status-failed",
+    icon: AlertCircle,
+  },
+  oom_killed: {
+    label: "Out of memory",
+    color: "text-status-failed",
+    bgColor: "bg-status-failed",
+    icon: AlertCircle,
+  },
   starting: {
     label: "Starting",
     color: "text-status-running",
@@ -76,6 +95,9 @@ const sizeConfig = {
 function getHealthState(health: HealthStatus | null | undefined): HealthState {
   if (!health) return "unknown";
 
+  if (health.health === "crash_loop") return "crash_loop";
+  if (health.health === "oom_killed") return "oom_killed";
+
   if (health.status === "not_found" || health.status === "exited") {
     return "offline";
   }
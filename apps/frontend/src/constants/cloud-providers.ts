@@ -0,0 +1,54 @@
+import type { CloudProvider } from "@/types";
+
+export interface CloudProviderInfo {
+  readonly id: CloudProvider;
+  readonly label: string;
+  readonly credentialLabel: string;
+  readonly credentialHint: string;
+  readonly tokenUrl: string;
+  readonly defaults: {
+    readonly size: string;
+    readonly region: string;
+    readonly image: string;
+  };
+}
+
+export const CLOUD_PROVIDERS: readonly CloudProviderInfo[] = [
+  {
+    id: "hetzner",
+    label: "Hetzner Cloud",
+    credentialLabel: "API Token",
+    credentialHint: "Project API token with Read & Write permission.",
+    tokenUrl: "https://console.hetzner.cloud/",
+    defaults: { size: "cx22", region: "nbg1", image: "ubuntu-24.04" },
+  },
+  {
+    id: "digitalocean",
+    label: "DigitalOcean",
+    credentialLabel: "Personal Access Token",
+    credentialHint: "Token with read and write scopes.",
+    tokenUrl: "https://cloud.digitalocean.com/account/api/tokens",
+    defaults: {
+      size: "s-1vcpu-1gb",
+      region: "nyc1",
+      image: "ubuntu-24-04-x64",
+    },
+  },
+  {
+    id: "aws",
+    label: "AWS EC2",
+    credentialLabel: "ACCESS_KEY_ID:SECRET_ACCESS_KEY",
+    credentialHint:
+      "IAM access key allowed to manage EC2 instances, key pairs and security groups.",
+    tokenUrl: "https://console.aws.amazon.com/iam/home#/security_credentials",
+    defaults: {
+      size: "t3.small",
+      region: "us-east-1",
+      image: "Ubuntu 24.04",
+    },
+  },
+];
+
+export function cloudProviderInfo(id: CloudProvider): CloudProviderInfo {
+  return CLOUD_PROVIDERS.find((p) => p.id === id) ?? CLOUD_PROVIDERS[0];
+}
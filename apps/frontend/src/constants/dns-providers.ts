@@ -0,0 +1,41 @@
+import type { DnsProvider } from "@/types";
+
+export interface DnsProviderInfo {
+  readonly id: DnsProvider;
+  readonly label: string;
+  readonly credentialLabel: string;
+  readonly credentialHint: string;
+  readonly tokenUrl: string;
+}
+
+export const DNS_PROVIDERS: readonly DnsProviderInfo[] = [
+  {
+    id: "route53",
+    label: "Amazon Route 53",
+    credentialLabel: "ACCESS_KEY_ID:SECRET_ACCESS_KEY",
+    credentialHint:
+      "IAM access key allowed to list hosted zones and change record sets.",
+    tokenUrl: "https://console.aws.amazon.com/iam/home#/security_credentials",
+  },
+  {
+    id: "digitalocean",
+    label: "DigitalOcean DNS",
+    credentialLabel: "Personal Access Token",
+    credentialHint: "Token with read and write scopes for domains.",
+    tokenUrl: "https://cloud.digitalocean.com/account/api/tokens",
+  },
+  {
+    id: "desec",
+    label: "deSEC",
+    credentialLabel: "API Token",
+    credentialHint: "Token allowed to manage the domain's RRsets.",
+    tokenUrl: "https://desec.io/tokens",
+  },
+];
+
+export function dnsProviderLabel(id: DnsProvider): string {
+  if (id === "cloudflare") {
+    return "Cloudflare";
+  }
+  return DNS_PROVIDERS.find((p) => p.id === id)?.label ?? id;
+}
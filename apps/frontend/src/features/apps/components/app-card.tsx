@@ -27,7 +27,7 @@ import { HealthIndicator } from "@/components/health-indicator";
 import { IconText } from "@/components/icon-text";
 import { StatusBadge } from "@/components/status-badge";
 import { usePurgeApp } from "@/features/apps/hooks/use-apps";
-import { useAppHealth } from "@/hooks/use-sse";
+import { useAppHealth, useDeployProgress } from "@/hooks/use-sse";
 import { formatDuration, formatRelativeTime } from "@/lib/format";
 import { cn, formatRepositoryUrl } from "@/lib/utils";
 import type { App, Deployment, DeploymentSummary } from "@/types";
@@ -50,11 +50,13 @@ function DeployProgress({
 }: {
   readonly deploy: Deployment | DeploymentSummary;
 }) {
+  const { data: reported } = useDeployProgress(deploy.id);
   const state = deriveDeployProgressState(deploy.logs, deploy.status);
   if (!state) return null;
 
   const Icon = DEPLOY_ICON_MAP[state.icon];
   const isRunning = deploy.status === "running";
+  const progress = isRunning && reported ? reported.percent : state.progress;
 
   return (
     <div className="absolute inset-0 z-5 bg-background/80 backdrop-blur-[2px] rounded-lg flex flex-col items-center justify-center gap-2 p-4 pointer-events-none">
@@ -65,11 +67,14 @@ function DeployProgress({
       <div className="w-full max-w-[200px] h-1.5 bg-muted rounded-full overflow-hidden">
         <div
           className="h-full bg-primary rounded-full transition-all duration-500 ease-out"
-          style={{ width: `${state.progress}%` }}
+          style={{ width: `${progress}%` }}
         />
       </div>
       <span className="text-[10px] text-muted-foreground">
         {deploy.commitSha?.slice(0, 7)}
+        {isRunning && reported && reported.etaMs > 0 && (
+          <> · ~{formatDuration(reported.etaMs)} left</>
+        )}
       </span>
     </div>
   );
@@ -0,0 +1,134 @@
+import { useState } from "react";
+import { BarChart3, Loader2 } from "lucide-react";
+import { Button } from "@/components/ui/button";
+import { CollapsibleSection } from "@/features/apps/components/collapsible-section";
+import { useAppAnalytics } from "@/features/apps/hooks/use-apps";
+import type { AnalyticsPeriod, DomainAnalytics } from "@/types";
+
+const PERIODS: readonly AnalyticsPeriod[] = ["1h", "24h", "7d"];
+const STATUS_CLASSES = ["2xx", "3xx", "4xx", "5xx"] as const;
+
+function countByClass(stats: DomainAnalytics): Record<string, number> {
+  const counts: Record<string, number> = {};
+  for (const [code, n] of Object.entries(stats.statusCodes)) {
+    const key = `${code.charAt(0)}xx`;
+    counts[key] = (counts[key] ?? 0) + n;
+  }
+  return counts;
+}
+
+interface AnalyticsSectionProps {
+  readonly appId: string;
+  readonly expanded: boolean;
+  readonly onToggle: () => void;
+}
+
+export function AnalyticsSection({
+  appId,
+  expanded,
+  onToggle,
+}: AnalyticsSectionProps) {
+  const [period, setPeriod] = useState<AnalyticsPeriod>("24h");
+  const { data, isLoading, error } = useAppAnalytics(appId, period, expanded);
+
+  const totalRequests =
+    data?.domains.reduce((sum, d) => sum + d.requests, 0) ?? 0;
+
+  return (
+    <CollapsibleSection
+      title="Traffic Analytics"
+      icon={BarChart3}
+      expanded={expanded}
+      onToggle={onToggle}
+      summary={
+        <span className="text-muted-foreground">
+          {data
+            ? `${totalRequests.toLocaleString()} requests in the last ${period}`
+            : "Requests, status codes and latency per domain"}
+        </span>
+      }
+    >
+      <div className="space-y-4">
+        <div className="flex gap-2">
+          {PERIODS.map((p) => (
+            <Button
+              key={p}
+              size="sm"
+              variant={p === period ? "default" : "outline"}
+              onClick={() => setPeriod(p)}
+            >
+              {p}
+            </Button>
+          ))}
+        </div>
+
+        {isLoading && (
+          <Loader2 className="h-4 w-4 animate-spin text-muted-foreground" />
+        )}
+
+        {error && (
+          <p className="text-sm text-destructive">
+            {error instanceof Error ? error.message : "Failed to load analytics"}
+          </p>
+        )}
+
+        {data && data.domains.length === 0 && (
+          <p className="text-sm text-muted-foreground">
+            Add a custom domain to collect traffic analytics.
+          </p>
+        )}
+
+        {data && data.domains.length > 0 && (
+          <div className="overflow-x-auto">
+            <table className="w-full text-sm">
+              <thead>
+                <tr className="border-b text-left text-muted-foreground">
+                  <th className="py-2 pr-4 font-medium">Domain</th>
+                  <th className="py-2 pr-4 font-medium">Requests</th>
+                  {STATUS_CLASSES.map((c) => (
+                    <th key={c} className="py-2 pr-4 font-medium">
+                      {c}
+                    </th>
+                  ))}
+                  <th className="py-2 font-medium">p95</th>
+                </tr>
+              </thead>
+              <tbody>
+                {data.domains.map((d) => {
+                  const classes = countByClass(d);
+                  return (
+                    <tr key={d.domain} className="border-b last:border-0">
+                      <td className="py-2 pr-4 font-mono text-xs">
+                        {d.domain}
+                      </td>
+                      <td className="py-2 pr-4">
+                        {d.requests.toLocaleString()}
+                      </td>
+                      {STATUS_CLASSES.map((c) => (
+                        <td
+                          key={c}
+                          className={
+                            c === "5xx" && (classes[c] ?? 0) > 0
+                              ? "py-2 pr-4 text-destructive"
+                              : "py-2 pr-4"
+                          }
+                        >
+                          {(classes[c] ?? 0).toLocaleString()}
+                        </td>
+                      ))}
+                      <td className="py-2">
+                        {d.requests > 0
+                          ? `${Math.round(d.p95LatencyMs)} ms`
+                          : "—"}
+                      </td>
+                    </tr>
+                  );
+                })}
+              </tbody>
+            </table>
+          </div>
+        )}
+      </div>
+    </CollapsibleSection>
+  );
+}
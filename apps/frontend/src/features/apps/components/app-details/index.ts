@@ -1,3 +1,4 @@
+export { AnalyticsSection } from "./analytics-section";
 export { AppDescription } from "./app-description";
 export { ContainerHealthSection } from "./container-health-section";
 export { EnvVarsSection } from "./env-vars-section";
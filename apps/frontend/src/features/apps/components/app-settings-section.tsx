@@ -1,6 +1,8 @@
 import {
   CheckCircle,
+  Cookie,
   Cpu,
+  FileArchive,
   Globe,
   HardDrive,
   HeartPulse,
@@ -8,6 +10,7 @@ import {
   Link2Off,
   Network,
   Settings,
+  Shield,
   XCircle,
 } from "lucide-react";
 import { Button } from "@/components/ui/button";
@@ -15,6 +18,10 @@ import { NetworksManager, VolumesManager } from "@/features/resources";
 import type { ContainerMount } from "@/types";
 import { CollapsibleSection } from "./collapsible-section";
 import { DomainManager } from "./domain-manager";
+import { RateLimitManager } from "./rate-limit-manager";
+import { RedirectsManager } from "./redirects-manager";
+import { SecurityHeadersManager } from "./security-headers-manager";
+import { ServiceDiscoveryManager } from "./service-discovery-manager";
 
 interface AppVolumeConfigData {
   readonly name?: string;
@@ -23,6 +30,14 @@ interface AppVolumeConfigData {
   readonly readOnly?: boolean;
 }
 
+interface AppPortConfigData {
+  readonly port: number;
+  readonly protocol: string;
+  readonly entrypoint?: string;
+  readonly hostPort?: number;
+  readonly mesh?: boolean;
+}
+
 interface AppConfigData {
   readonly hostPort: number;
   readonly port: number;
@@ -35,6 +50,9 @@ interface AppConfigData {
   };
   readonly domains?: readonly string[];
   readonly volumes?: readonly AppVolumeConfigData[];
+  readonly compress?: boolean;
+  readonly stickySessions?: { cookieName: string } | null;
+  readonly ports?: readonly AppPortConfigData[] | null;
 }
 
 interface WebhookActions {
@@ -89,6 +107,19 @@ function DeploymentConfigSection({
             <span className="font-medium">Port:</span>
             <span className="font-mono">{portDisplay}</span>
           </div>
+          {appConfig.ports?.map((p) => (
+            <div
+              key={`${p.port}/${p.protocol}`}
+              className="flex items-center gap-2 text-xs text-muted-foreground"
+            >
+              <span className="ml-6 font-mono">
+                {p.port}/{p.protocol || "tcp"} →{" "}
+                {p.entrypoint
+                  ? `entrypoint ${p.entrypoint}`
+                  : `${p.mesh ? "mesh" : "host"} port ${p.hostPort}`}
+              </span>
+            </div>
+          ))}
           <div className="flex items-center gap-2 text-sm">
             <HeartPulse className="h-4 w-4 text-muted-foreground" />
             <span className="font-medium">Health Check:</span>
@@ -113,6 +144,22 @@ function DeploymentConfigSection({
             <span className="font-medium">CPU:</span>
             <span className="font-mono">{appConfig.resources.cpu}</span>
           </div>
+          <div className="flex items-center gap-2 text-sm">
+            <FileArchive className="h-4 w-4 text-muted-foreground" />
+            <span className="font-medium">Compression:</span>
+            <span className="font-mono">
+              {appConfig.compress ? "enabled" : "disabled"}
+            </span>
+          </div>
+          {appConfig.stickySessions && (
+            <div className="flex items-center gap-2 text-sm">
+              <Cookie className="h-4 w-4 text-muted-foreground" />
+              <span className="font-medium">Sticky Sessions:</span>
+              <span className="font-mono">
+                {appConfig.stickySessions.cookieName || "enabled"}
+              </span>
+            </div>
+          )}
           {appConfig.domains && appConfig.domains.length > 0 && (
             <div className="flex items-start gap-2 text-sm">
               <Globe className="h-4 w-4 text-muted-foreground mt-0.5" />
@@ -282,13 +329,55 @@ function DomainsSection({ appId, expanded, onToggle }: DomainsSectionProps) {
       icon={Globe}
       expanded={expanded}
       onToggle={onToggle}
-      summary={<span className="text-muted-foreground">Cloudflare DNS</span>}
+      summary={
+        <span className="text-muted-foreground">
+          Cloudflare DNS, basic auth and IP allowlists
+        </span>
+      }
     >
       <DomainManager appId={appId} />
     </CollapsibleSection>
   );
 }
 
+interface TrafficSectionProps {
+  readonly appId: string;
+  readonly expanded: boolean;
+  readonly onToggle: () => void;
+}
+
+function TrafficSection({ appId, expanded, onToggle }: TrafficSectionProps) {
+  return (
+    <CollapsibleSection
+      title="Traffic Rules"
+      icon={Shield}
+      expanded={expanded}
+      onToggle={onToggle}
+      summary={
+        <span className="text-muted-foreground">
+          Rate limiting, redirects, security headers and service discovery
+        </span>
+      }
+    >
+      <div className="space-y-6">
+        <RateLimitManager appId={appId} />
+        <div className="border-t pt-6">
+          <h4 className="text-sm font-medium mb-3">Redirects</h4>
+          <RedirectsManager appId={appId} />
+        </div>
+        <div className="border-t pt-6">
+          <h4 className="text-sm font-medium mb-3">Security Headers</h4>
+          <SecurityHeadersManager appId={appId} />
+        </div>
+        <div className="border-t pt-6">
+          <h4 className="text-sm font-medium mb-3">Service Discovery</h4>
+          <ServiceDiscoveryManager appId={appId} />
+        </div>
+      </div>
+    </CollapsibleSection>
+  );
+}
+
 interface NetworksSectionProps {
   readonly expanded: boolean;
   readonly onToggle: () => void;
@@ -372,11 +461,18 @@ export interface AppSettingsSectionProps {
     readonly config?: boolean;
     readonly webhook?: boolean;
     readonly domains?: boolean;
+    readonly traffic?: boolean;
     readonly networks?: boolean;
     readonly volumes?: boolean;
   };
   readonly toggleSection: (
-    section: "config" | "webhook" | "domains" | "networks" | "volumes",
+    section:
+      | "config"
+      | "webhook"
+      | "domains"
+      | "traffic"
+      | "networks"
+      | "volumes",
   ) => void;
 }
 
@@ -415,6 +511,11 @@ export function AppSettingsSection({
         expanded={expandedSections.domains ?? false}
         onToggle={() => toggleSection("domains")}
       />
+      <TrafficSection
+        appId={appId}
+        expanded={expandedSections.traffic ?? false}
+        onToggle={() => toggleSection("traffic")}
+      />
       <NetworksSection
         expanded={expandedSections.networks ?? false}
         onToggle={() => toggleSection("networks")}
@@ -1,8 +1,9 @@
-import { FileText, GitCommit, History, Rocket } from "lucide-react";
+import { Download, FileText, GitCommit, History, Rocket } from "lucide-react";
 import { Tabs, TabsContent, TabsList, TabsTrigger } from "@/components/ui/tabs";
 import { StatusBadge } from "@/components/status-badge";
 import { DeployTimeline } from "@/features/deploys/components/deploy-timeline";
 import { LogViewer } from "@/features/deploys/components/log-viewer";
+import { api } from "@/services/api";
 import type { App, Deployment } from "@/types";
 import { CollapsibleSection } from "./collapsible-section";
 import { CommitSelectorInline } from "./commit-selector";
@@ -92,6 +93,17 @@ export function DeploymentsSection({
                 {selectedDeploy.commitSha.slice(0, 7)}
               </span>
             )}
+            {selectedDeploy && (selectedDeploy.archivedLogChunks ?? 0) > 0 && (
+              <a
+                href={api.deployments.logsUrl(app.id, selectedDeploy.id)}
+                download={`deploy-${selectedDeploy.id}.log`}
+                className="ml-auto inline-flex items-center gap-1 text-xs hover:text-foreground"
+                title="Older lines were archived; download the full log"
+              >
+                <Download className="h-3.5 w-3.5" />
+                Full log
+              </a>
+            )}
           </div>
           <LogViewer
             logs={selectedDeploy?.logs ?? null}
@@ -0,0 +1,135 @@
+import { useState } from "react";
+import { useMutation, useQueryClient } from "@tanstack/react-query";
+import { Loader2 } from "lucide-react";
+import { Button } from "@/components/ui/button";
+import { Checkbox } from "@/components/ui/checkbox";
+import {
+  Dialog,
+  DialogContent,
+  DialogDescription,
+  DialogFooter,
+  DialogHeader,
+  DialogTitle,
+} from "@/components/ui/dialog";
+import { Input } from "@/components/ui/input";
+import { Label } from "@/components/ui/label";
+import { api } from "@/services/api";
+import type { CustomDomain, UpdateDomainBasicAuthInput } from "@/types";
+
+interface DomainBasicAuthDialogProps {
+  readonly appId: string;
+  readonly domain: CustomDomain;
+  readonly onClose: () => void;
+}
+
+export function DomainBasicAuthDialog({
+  appId,
+  domain,
+  onClose,
+}: DomainBasicAuthDialogProps) {
+  const queryClient = useQueryClient();
+  const [enabled, setEnabled] = useState(domain.basicAuth);
+  const [username, setUsername] = useState(domain.basicAuthUsers[0] ?? "");
+  const [password, setPassword] = useState("");
+
+  const mutation = useMutation({
+    mutationFn: (input: UpdateDomainBasicAuthInput) =>
+      api.domains.updateBasicAuth(appId, domain.id, input),
+    onSuccess: async () => {
+      await queryClient.invalidateQueries({
+        queryKey: ["custom-domains", appId],
+      });
+      onClose();
+    },
+  });
+
+  const handleSubmit = (e: React.FormEvent) => {
+    e.preventDefault();
+    mutation.mutate(
+      enabled ? { enabled, username: username.trim(), password } : { enabled },
+    );
+  };
+
+  const canSubmit =
+    !enabled || (username.trim() !== "" && password.length >= 8);
+
+  return (
+    <Dialog open onOpenChange={(open) => !open && onClose()}>
+      <DialogContent className="sm:max-w-[420px]">
+        <form onSubmit={handleSubmit}>
+          <DialogHeader>
+            <DialogTitle>Basic Auth</DialogTitle>
+            <DialogDescription>
+              Require a username and password before requests to{" "}
+              <strong>
+                {domain.domain}
+                {domain.pathPrefix}
+              </strong>{" "}
+              reach the app.
+            </DialogDescription>
+          </DialogHeader>
+
+          <div className="grid gap-4 py-4">
+            <label className="flex items-center gap-2 text-sm">
+              <Checkbox
+                checked={enabled}
+                onCheckedChange={(checked) => setEnabled(checked === true)}
+              />
+              Protect this domain
+            </label>
+
+            {enabled && (
+              <>
+                <div className="grid gap-2">
+                  <Label htmlFor="basic-auth-username">Username</Label>
+                  <Input
+                    id="basic-auth-username"
+                    value={username}
+                    onChange={(e) => setUsername(e.target.value)}
+                    autoComplete="off"
+                  />
+                </div>
+                <div className="grid gap-2">
+                  <Label htmlFor="basic-auth-password">Password</Label>
+                  <Input
+                    id="basic-auth-password"
+                    type="password"
+                    value={password}
+                    onChange={(e) => setPassword(e.target.value)}
+                    autoComplete="new-password"
+                    placeholder={
+                      domain.basicAuth ? "Enter a new password" : undefined
+                    }
+                  />
+                  <p className="text-xs text-muted-foreground">
+                    At least 8 characters. Only a hash is stored.
+                  </p>
+                </div>
+              </>
+            )}
+
+            {mutation.isError && (
+              <p className="text-sm text-destructive">
+                {mutation.error instanceof Error
+                  ? mutation.error.message
+                  : "Failed to update basic auth"}
+              </p>
+            )}
+          </div>
+
+          <DialogFooter>
+            <Button type="button" variant="outline" onClick={onClose}>
+              Cancel
+            </Button>
+            <Button type="submit" disabled={!canSubmit || mutation.isPending}>
+              {mutation.isPending && (
+                <Loader2 className="h-4 w-4 animate-spin mr-2" />
+              )}
+              Save
+            </Button>
+          </DialogFooter>
+        </form>
+      </DialogContent>
+    </Dialog>
+  );
+}
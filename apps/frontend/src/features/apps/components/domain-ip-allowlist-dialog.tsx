@@ -0,0 +1,101 @@
+import { useState } from "react";
+import { useMutation, useQueryClient } from "@tanstack/react-query";
+import { Loader2 } from "lucide-react";
+import { Button } from "@/components/ui/button";
+import {
+  Dialog,
+  DialogContent,
+  DialogDescription,
+  DialogFooter,
+  DialogHeader,
+  DialogTitle,
+} from "@/components/ui/dialog";
+import { api } from "@/services/api";
+import type { CustomDomain } from "@/types";
+
+interface DomainIPAllowlistDialogProps {
+  readonly appId: string;
+  readonly domain: CustomDomain;
+  readonly onClose: () => void;
+}
+
+export function DomainIPAllowlistDialog({
+  appId,
+  domain,
+  onClose,
+}: DomainIPAllowlistDialogProps) {
+  const queryClient = useQueryClient();
+  const [entries, setEntries] = useState(domain.ipAllowlist.join("\n"));
+
+  const mutation = useMutation({
+    mutationFn: (list: readonly string[]) =>
+      api.domains.updateIPAllowlist(appId, domain.id, list),
+    onSuccess: async () => {
+      await queryClient.invalidateQueries({
+        queryKey: ["custom-domains", appId],
+      });
+      onClose();
+    },
+  });
+
+  const handleSubmit = (e: React.FormEvent) => {
+    e.preventDefault();
+    mutation.mutate(
+      entries
+        .split(/[\n,]/)
+        .map((entry) => entry.trim())
+        .filter(Boolean),
+    );
+  };
+
+  return (
+    <Dialog open onOpenChange={(open) => !open && onClose()}>
+      <DialogContent className="sm:max-w-[420px]">
+        <form onSubmit={handleSubmit}>
+          <DialogHeader>
+            <DialogTitle>IP Allowlist</DialogTitle>
+            <DialogDescription>
+              Only these IPs or CIDR ranges can reach{" "}
+              <strong>
+                {domain.domain}
+                {domain.pathPrefix}
+              </strong>
+              . Leave empty to allow everyone.
+            </DialogDescription>
+          </DialogHeader>
+
+          <div className="grid gap-2 py-4">
+            <textarea
+              value={entries}
+              onChange={(e) => setEntries(e.target.value)}
+              placeholder={"203.0.113.0/24\n198.51.100.7"}
+              rows={6}
+              className="flex min-h-[120px] w-full rounded-md border border-input bg-background px-3 py-2 font-mono text-xs ring-offset-background placeholder:text-muted-foreground focus-visible:outline-none focus-visible:ring-2 focus-visible:ring-ring focus-visible:ring-offset-2"
+            />
+            <p className="text-xs text-muted-foreground">One entry per line.</p>
+
+            {mutation.isError && (
+              <p className="text-sm text-destructive">
+                {mutation.error instanceof Error
+                  ? mutation.error.message
+                  : "Failed to update IP allowlist"}
+              </p>
+            )}
+          </div>
+
+          <DialogFooter>
+            <Button type="button" variant="outline" onClick={onClose}>
+              Cancel
+            </Button>
+            <Button type="submit" disabled={mutation.isPending}>
+              {mutation.isPending && (
+                <Loader2 className="h-4 w-4 animate-spin mr-2" />
+              )}
+              Save
+            </Button>
+          </DialogFooter>
+        </form>
+      </DialogContent>
+    </Dialog>
+  );
+}
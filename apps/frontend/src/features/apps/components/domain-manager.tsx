@@ -3,8 +3,12 @@ import { useMutation, useQuery, useQueryClient } from "@tanstack/react-query";
 import {
   Cloud,
   ExternalLink,
+  FileKey,
   Globe,
   Loader2,
+  Lock,
+  LockOpen,
+  Network,
   Plus,
   ShieldAlert,
   ShieldCheck,
@@ -31,14 +35,36 @@ import {
   CardTitle,
 } from "@/components/ui/card";
 import { Input } from "@/components/ui/input";
+import {
+  Select,
+  SelectContent,
+  SelectItem,
+  SelectTrigger,
+  SelectValue,
+} from "@/components/ui/select";
 import {
   Tooltip,
   TooltipContent,
   TooltipProvider,
   TooltipTrigger,
 } from "@/components/ui/tooltip";
+import { dnsProviderLabel } from "@/constants/dns-providers";
+import { formatDateOnly } from "@/lib/format";
 import { api } from "@/services/api";
-import type { CertificateStatus, CustomDomain } from "@/types";
+import type {
+  CertificateStatus,
+  CustomDomain,
+  DnsProvider,
+  WwwPairing,
+} from "@/types";
+import { DomainBasicAuthDialog } from "./domain-basic-auth-dialog";
+import { DomainCertificateDialog } from "./domain-certificate-dialog";
+import { DomainIPAllowlistDialog } from "./domain-ip-allowlist-dialog";
+import {
+  DOMAIN_VERIFICATIONS_QUERY_KEY,
+  DomainVerifications,
+  isDomainVerified,
+} from "./domain-verifications";
 
 interface DomainManagerProps {
   readonly appId: string;
@@ -125,14 +151,35 @@ export function DomainManager({ appId }: DomainManagerProps) {
   const queryClient = useQueryClient();
   const [newDomain, setNewDomain] = useState("");
   const [pathPrefix, setPathPrefix] = useState("");
+  const [dnsProvider, setDnsProvider] = useState<DnsProvider | "">("");
+  const [wwwPairing, setWwwPairing] = useState<WwwPairing>("");
   const [domainToDelete, setDomainToDelete] = useState<CustomDomain | null>(
     null,
   );
+  const [domainToProtect, setDomainToProtect] = useState<CustomDomain | null>(
+    null,
+  );
+  const [domainToRestrict, setDomainToRestrict] =
+    useState<CustomDomain | null>(null);
+  const [domainForCertificate, setDomainForCertificate] =
+    useState<CustomDomain | null>(null);
 
-  const { data: cloudflareStatus, isLoading: isLoadingCloudflare } = useQuery({
-    queryKey: ["cloudflare-status"],
-    queryFn: () => api.cloudflare.status(),
+  const { data: dnsConnections = [], isLoading: isLoadingConnections } =
+    useQuery({
+      queryKey: ["dns-connections"],
+      queryFn: () => api.dnsConnections.list(),
+    });
+  const selectedProvider = dnsProvider || dnsConnections[0]?.provider;
+
+  const { data: verifications = [] } = useQuery({
+    queryKey: DOMAIN_VERIFICATIONS_QUERY_KEY,
+    queryFn: () => api.domainVerifications.list(),
   });
+  const canPairWww =
+    !pathPrefix.trim() && !newDomain.trim().toLowerCase().startsWith("www.");
+  const needsVerification =
+    newDomain.trim() !== "" &&
+    !isDomainVerified(verifications, newDomain.trim());
 
   const { data: domains = [], isLoading: isLoadingDomains } = useQuery({
     queryKey: ["custom-domains", appId],
@@ -152,12 +199,33 @@ export function DomainManager({ appId }: DomainManagerProps) {
   };
 
   const addDomainMutation = useMutation({
-    mutationFn: (data: { domain: string; pathPrefix?: string }) =>
-      api.domains.add(appId, data.domain, data.pathPrefix),
+    mutationFn: (data: {
+      domain: string;
+      pathPrefix?: string;
+      dnsProvider?: DnsProvider;
+      wwwPairing?: WwwPairing;
+    }) =>
+      api.domains.add(
+        appId,
+        data.domain,
+        data.pathPrefix,
+        data.dnsProvider,
+        data.wwwPairing,
+      ),
     onSuccess: () => {
       queryClient.invalidateQueries({ queryKey: ["custom-domains", appId] });
       setNewDomain("");
       setPathPrefix("");
+      setWwwPairing("");
+    },
+  });
+
+  const verifyDomainMutation = useMutation({
+    mutationFn: (domain: string) => api.domainVerifications.create(domain),
+    onSuccess: () => {
+      queryClient.invalidateQueries({
+        queryKey: DOMAIN_VERIFICATIONS_QUERY_KEY,
+      });
     },
   });
 
@@ -175,15 +243,21 @@ export function DomainManager({ appId }: DomainManagerProps) {
 
   const handleAddDomain = (e: React.FormEvent) => {
     e.preventDefault();
+    if (needsVerification) {
+      verifyDomainMutation.mutate(newDomain.trim());
+      return;
+    }
     if (newDomain.trim()) {
       addDomainMutation.mutate({
         domain: newDomain.trim(),
         pathPrefix: pathPrefix.trim() || undefined,
+        dnsProvider: selectedProvider,
+        wwwPairing: canPairWww ? wwwPairing || undefined : undefined,
       });
     }
   };
 
-  const isLoading = isLoadingCloudflare || isLoadingDomains;
+  const isLoading = isLoadingConnections || isLoadingDomains;
 
   if (isLoading) {
     return (
@@ -195,7 +269,7 @@ export function DomainManager({ appId }: DomainManagerProps) {
     );
   }
 
-  if (!cloudflareStatus?.connected) {
+  if (dnsConnections.length === 0) {
     return (
       <Card>
         <CardHeader>
@@ -211,10 +285,10 @@ export function DomainManager({ appId }: DomainManagerProps) {
           <div className="flex flex-col items-center justify-center gap-4 py-8 text-center">
             <Cloud className="h-12 w-12 text-muted-foreground" />
             <div>
-              <p className="font-medium">Cloudflare Not Connected</p>
+              <p className="font-medium">No DNS Provider Connected</p>
               <p className="text-sm text-muted-foreground">
-                Connect your Cloudflare account in Settings to manage custom
-                domains
+                Connect Cloudflare or another DNS provider in Settings to
+                manage custom domains
               </p>
             </div>
             <Button variant="outline" asChild>
@@ -235,7 +309,7 @@ export function DomainManager({ appId }: DomainManagerProps) {
             Custom Domains
           </CardTitle>
           <CardDescription>
-            Add custom domains from your Cloudflare account
+            Add custom domains from your connected DNS providers
           </CardDescription>
         </CardHeader>
         <CardContent className="space-y-4">
@@ -255,24 +329,83 @@ export function DomainManager({ appId }: DomainManagerProps) {
                 disabled={addDomainMutation.isPending}
                 className="w-32 sm:w-40"
               />
+              {dnsConnections.length > 1 && (
+                <Select
+                  value={selectedProvider}
+                  onValueChange={(v) => setDnsProvider(v as DnsProvider)}
+                  disabled={addDomainMutation.isPending}
+                >
+                  <SelectTrigger className="w-36 sm:w-44">
+                    <SelectValue />
+                  </SelectTrigger>
+                  <SelectContent>
+                    {dnsConnections.map((conn) => (
+                      <SelectItem key={conn.provider} value={conn.provider}>
+                        {dnsProviderLabel(conn.provider)}
+                      </SelectItem>
+                    ))}
+                  </SelectContent>
+                </Select>
+              )}
               <Button
                 type="submit"
-                disabled={!newDomain.trim() || addDomainMutation.isPending}
+                disabled={
+                  !newDomain.trim() ||
+                  addDomainMutation.isPending ||
+                  verifyDomainMutation.isPending
+                }
               >
-                {addDomainMutation.isPending ? (
+                {addDomainMutation.isPending ||
+                verifyDomainMutation.isPending ? (
                   <Loader2 className="h-4 w-4 animate-spin" />
+                ) : needsVerification ? (
+                  <ShieldQuestion className="h-4 w-4" />
                 ) : (
                   <Plus className="h-4 w-4" />
                 )}
-                <span className="ml-2 hidden sm:inline">Add</span>
+                <span className="ml-2 hidden sm:inline">
+                  {needsVerification ? "Verify" : "Add"}
+                </span>
               </Button>
             </div>
+            {canPairWww && (
+              <Select
+                value={wwwPairing || "none"}
+                onValueChange={(v) =>
+                  setWwwPairing(v === "none" ? "" : (v as WwwPairing))
+                }
+                disabled={addDomainMutation.isPending}
+              >
+                <SelectTrigger className="w-full sm:w-72">
+                  <SelectValue />
+                </SelectTrigger>
+                <SelectContent>
+                  <SelectItem value="none">Don&apos;t add www</SelectItem>
+                  <SelectItem value="redirect-to-apex">
+                    Add www, redirect www to apex
+                  </SelectItem>
+                  <SelectItem value="redirect-to-www">
+                    Add www, redirect apex to www
+                  </SelectItem>
+                </SelectContent>
+              </Select>
+            )}
             <p className="text-xs text-muted-foreground">
               Path prefix routes specific paths to this app (e.g., /api or
               /integration-bank)
             </p>
           </form>
 
+          {verifyDomainMutation.isError && (
+            <p className="text-sm text-destructive">
+              {verifyDomainMutation.error instanceof Error
+                ? verifyDomainMutation.error.message
+                : "Failed to start domain verification"}
+            </p>
+          )}
+
+          <DomainVerifications verifications={verifications} />
+
           {addDomainMutation.isError && (
             <p className="text-sm text-destructive">
               {addDomainMutation.error instanceof Error
@@ -309,11 +442,39 @@ export function DomainManager({ appId }: DomainManagerProps) {
                         <Badge variant="secondary" className="text-xs">
                           {domain.recordType}
                         </Badge>
+                        {domain.redirectTo && (
+                          <Badge variant="outline" className="text-xs">
+                            Redirects to {domain.redirectTo}
+                          </Badge>
+                        )}
+                        {domain.dnsProvider &&
+                          domain.dnsProvider !== "cloudflare" && (
+                            <Badge variant="outline" className="text-xs">
+                              {dnsProviderLabel(domain.dnsProvider)}
+                            </Badge>
+                          )}
                         {domain.pathPrefix && (
                           <Badge variant="outline" className="text-xs">
                             Path Prefix
                           </Badge>
                         )}
+                        {domain.basicAuth && (
+                          <Badge variant="outline" className="text-xs">
+                            Basic Auth
+                          </Badge>
+                        )}
+                        {domain.ipAllowlist.length > 0 && (
+                          <Badge variant="outline" className="text-xs">
+                            IP Allowlist
+                          </Badge>
+                        )}
+                        {domain.customCertificate && (
+                          <Badge variant="outline" className="text-xs">
+                            Custom Cert
+                            {domain.certificateExpiresAt &&
+                              ` · expires ${formatDateOnly(domain.certificateExpiresAt)}`}
+                          </Badge>
+                        )}
                         <span>Proxied via Cloudflare</span>
                       </div>
                     </div>
@@ -322,6 +483,38 @@ export function DomainManager({ appId }: DomainManagerProps) {
                     <CertificateStatusBadge
                       status={getCertificateStatus(domain.domain)}
                     />
+                    <Button
+                      variant="ghost"
+                      size="icon"
+                      onClick={() => setDomainForCertificate(domain)}
+                      aria-label="TLS certificate"
+                    >
+                      <FileKey
+                        className={`h-4 w-4 ${domain.customCertificate ? "" : "text-muted-foreground"}`}
+                      />
+                    </Button>
+                    <Button
+                      variant="ghost"
+                      size="icon"
+                      onClick={() => setDomainToRestrict(domain)}
+                      aria-label="IP allowlist"
+                    >
+                      <Network
+                        className={`h-4 w-4 ${domain.ipAllowlist.length > 0 ? "" : "text-muted-foreground"}`}
+                      />
+                    </Button>
+                    <Button
+                      variant="ghost"
+                      size="icon"
+                      onClick={() => setDomainToProtect(domain)}
+                      aria-label="Basic auth"
+                    >
+                      {domain.basicAuth ? (
+                        <Lock className="h-4 w-4" />
+                      ) : (
+                        <LockOpen className="h-4 w-4 text-muted-foreground" />
+                      )}
+                    </Button>
                     <Button
                       variant="ghost"
                       size="icon"
@@ -342,6 +535,30 @@ export function DomainManager({ appId }: DomainManagerProps) {
         </CardContent>
       </Card>
 
+      {domainToProtect && (
+        <DomainBasicAuthDialog
+          appId={appId}
+          domain={domainToProtect}
+          onClose={() => setDomainToProtect(null)}
+        />
+      )}
+
+      {domainToRestrict && (
+        <DomainIPAllowlistDialog
+          appId={appId}
+          domain={domainToRestrict}
+          onClose={() => setDomainToRestrict(null)}
+        />
+      )}
+
+      {domainForCertificate && (
+        <DomainCertificateDialog
+          appId={appId}
+          domain={domainForCertificate}
+          onClose={() => setDomainForCertificate(null)}
+        />
+      )}
+
       <AlertDialog
         open={!!domainToDelete}
         onOpenChange={() => setDomainToDelete(null)}
@@ -0,0 +1,115 @@
+import { useMutation, useQueryClient } from "@tanstack/react-query";
+import { Loader2, ShieldQuestion, Trash2 } from "lucide-react";
+import { Button } from "@/components/ui/button";
+import { api } from "@/services/api";
+import type { DomainVerification } from "@/types";
+
+export const DOMAIN_VERIFICATIONS_QUERY_KEY = ["domain-verifications"] as const;
+
+export function isDomainVerified(
+  verifications: readonly DomainVerification[],
+  domain: string,
+): boolean {
+  const name = domain.toLowerCase();
+  return verifications.some(
+    (v) =>
+      v.verified && (name === v.domain || name.endsWith(`.${v.domain}`)),
+  );
+}
+
+interface DomainVerificationsProps {
+  readonly verifications: readonly DomainVerification[];
+}
+
+export function DomainVerifications({
+  verifications,
+}: DomainVerificationsProps) {
+  const pending = verifications.filter((v) => !v.verified);
+
+  if (pending.length === 0) {
+    return null;
+  }
+
+  return (
+    <div className="space-y-2">
+      {pending.map((verification) => (
+        <PendingVerification
+          key={verification.id}
+          verification={verification}
+        />
+      ))}
+    </div>
+  );
+}
+
+interface PendingVerificationProps {
+  readonly verification: DomainVerification;
+}
+
+function PendingVerification({ verification }: PendingVerificationProps) {
+  const queryClient = useQueryClient();
+
+  const invalidate = () =>
+    queryClient.invalidateQueries({
+      queryKey: DOMAIN_VERIFICATIONS_QUERY_KEY,
+    });
+
+  const checkMutation = useMutation({
+    mutationFn: () => api.domainVerifications.check(verification.id),
+    onSuccess: () => void invalidate(),
+  });
+
+  const removeMutation = useMutation({
+    mutationFn: () => api.domainVerifications.remove(verification.id),
+    onSuccess: () => void invalidate(),
+  });
+
+  return (
+    <div className="space-y-2 rounded-lg border border-dashed p-3">
+      <div className="flex items-center justify-between gap-3">
+        <div className="flex items-center gap-2">
+          <ShieldQuestion className="h-4 w-4 text-yellow-500" />
+          <p className="font-medium text-sm">
+            Verify ownership of {verification.domain}
+          </p>
+        </div>
+        <div className="flex items-center gap-2">
+          <Button
+            size="sm"
+            onClick={() => checkMutation.mutate()}
+            disabled={checkMutation.isPending}
+          >
+            {checkMutation.isPending && (
+              <Loader2 className="h-4 w-4 animate-spin mr-2" />
+            )}
+            Check
+          </Button>
+          <Button
+            variant="ghost"
+            size="sm"
+            onClick={() => removeMutation.mutate()}
+            disabled={removeMutation.isPending}
+          >
+            <Trash2 className="h-4 w-4" />
+          </Button>
+        </div>
+      </div>
+      <p className="text-xs text-muted-foreground">
+        Add this {verification.recordType} record at your DNS provider, or a
+        CNAME at the same name pointing to a record that holds it. Verifying a
+        domain also covers its subdomains.
+      </p>
+      <div className="grid gap-1 rounded bg-muted p-2 font-mono text-xs">
+        <span className="break-all">{verification.recordName}</span>
+        <span className="break-all">{verification.recordValue}</span>
+      </div>
+      {checkMutation.isError && (
+        <p className="text-sm text-destructive">
+          {checkMutation.error instanceof Error
+            ? checkMutation.error.message
+            : "Verification record not found"}
+        </p>
+      )}
+    </div>
+  );
+}
@@ -1,8 +1,18 @@
 import { useState } from "react";
-import { Eye, EyeOff, FileText, Plus, Trash2, Variable } from "lucide-react";
+import {
+  CloudDownload,
+  Eye,
+  EyeOff,
+  FileText,
+  Plus,
+  Trash2,
+  Variable,
+} from "lucide-react";
 import { Button } from "@/components/ui/button";
 import { Card, CardContent, CardFooter } from "@/components/ui/card";
 import { Input } from "@/components/ui/input";
+import type { CreateEnvVarInput } from "@/types";
+import { HerokuImportPanel } from "./heroku-import-panel";
 import type { LocalEnvVar, StepProps } from "./types";
 
 interface EditingEnvVar {
@@ -27,6 +37,7 @@ export function EnvironmentStep({
   const [showSecrets, setShowSecrets] = useState<Record<string, boolean>>({});
   const [showPasteMode, setShowPasteMode] = useState(false);
   const [pasteContent, setPasteContent] = useState("");
+  const [showHerokuImport, setShowHerokuImport] = useState(false);
 
   const handleAddVar = () => {
     if (!newVar.key.trim()) return;
@@ -101,6 +112,17 @@ export function EnvironmentStep({
     setShowPasteMode(false);
   };
 
+  const handleHerokuImport = (vars: readonly CreateEnvVarInput[]) => {
+    const existing = new Set(data.envVars.map((v) => v.key));
+    const newVars: LocalEnvVar[] = vars
+      .filter((v) => !existing.has(v.key))
+      .map((v) => ({ ...v, localId: crypto.randomUUID() }));
+
+    if (newVars.length > 0) {
+      onUpdate({ envVars: [...data.envVars, ...newVars] });
+    }
+  };
+
   const getEnvVarDisplayValue = (
     isSecret: boolean,
     localId: string,
@@ -141,8 +163,25 @@ export function EnvironmentStep({
             <FileText className="h-4 w-4 mr-2" />
             Paste .env file
           </Button>
+          <Button
+            type="button"
+            variant="outline"
+            size="sm"
+            onClick={() => setShowHerokuImport(!showHerokuImport)}
+          >
+            <CloudDownload className="h-4 w-4 mr-2" />
+            Import from Heroku
+          </Button>
         </div>
 
+        {showHerokuImport && (
+          <HerokuImportPanel
+            appName={data.name}
+            onImport={handleHerokuImport}
+            onClose={() => setShowHerokuImport(false)}
+          />
+        )}
+
         {showPasteMode && (
           <div className="space-y-2 p-4 border rounded-lg bg-muted/50">
             <textarea
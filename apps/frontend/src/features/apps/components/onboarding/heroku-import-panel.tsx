@@ -0,0 +1,163 @@
+import { useState } from "react";
+import { useMutation } from "@tanstack/react-query";
+import { Check, Copy, Download, Loader2 } from "lucide-react";
+import { Button } from "@/components/ui/button";
+import { Input } from "@/components/ui/input";
+import { api } from "@/services/api";
+import type { CreateEnvVarInput, HerokuImportPreview } from "@/types";
+
+interface HerokuImportPanelProps {
+  readonly appName: string;
+  readonly onImport: (vars: readonly CreateEnvVarInput[]) => void;
+  readonly onClose: () => void;
+}
+
+export function HerokuImportPanel({
+  appName,
+  onImport,
+  onClose,
+}: HerokuImportPanelProps) {
+  const [apiKey, setApiKey] = useState("");
+  const [herokuApp, setHerokuApp] = useState("");
+  const [preview, setPreview] = useState<HerokuImportPreview | null>(null);
+  const [copied, setCopied] = useState(false);
+
+  const previewMutation = useMutation({
+    mutationFn: () =>
+      api.apps.previewHerokuImport({
+        apiKey: apiKey.trim(),
+        herokuApp: herokuApp.trim(),
+        name: appName || undefined,
+      }),
+    onSuccess: (result) => {
+      setPreview(result);
+      onImport(result.envVars);
+    },
+  });
+
+  const configJson = preview
+    ? JSON.stringify(
+        { $schema: "./paasdeploy.schema.json", ...preview.config },
+        null,
+        2,
+      )
+    : "";
+
+  const handleCopy = async () => {
+    await navigator.clipboard.writeText(configJson);
+    setCopied(true);
+    setTimeout(() => setCopied(false), 2000);
+  };
+
+  return (
+    <div className="space-y-3 p-4 border rounded-lg bg-muted/50">
+      <p className="text-sm text-muted-foreground">
+        Reads config vars, buildpacks, add-ons and domains through the Heroku
+        Platform API. The API key is only used for this request.
+      </p>
+      <div className="flex flex-col md:flex-row gap-2">
+        <Input
+          placeholder="Heroku app name"
+          value={herokuApp}
+          onChange={(e) => setHerokuApp(e.target.value)}
+          className="md:w-1/3"
+        />
+        <Input
+          type="password"
+          placeholder="Heroku API key"
+          value={apiKey}
+          onChange={(e) => setApiKey(e.target.value)}
+          className="flex-1 font-mono text-sm"
+        />
+        <Button
+          type="button"
+          size="sm"
+          className="shrink-0"
+          onClick={() => previewMutation.mutate()}
+          disabled={
+            !apiKey.trim() || !herokuApp.trim() || previewMutation.isPending
+          }
+        >
+          {previewMutation.isPending ? (
+            <Loader2 className="h-4 w-4 mr-2 animate-spin" />
+          ) : (
+            <Download className="h-4 w-4 mr-2" />
+          )}
+          Import
+        </Button>
+      </div>
+
+      {previewMutation.isError && (
+        <p className="text-sm text-destructive">
+          {previewMutation.error instanceof Error
+            ? previewMutation.error.message
+            : "Failed to read Heroku app"}
+        </p>
+      )}
+
+      {preview && (
+        <div className="space-y-3">
+          <p className="text-sm">
+            Imported {preview.envVars.length} variables from{" "}
+            <span className="font-mono">{preview.herokuApp}</span>.
+          </p>
+
+          {preview.addons.length > 0 && (
+            <div className="space-y-1">
+              <p className="text-sm font-medium">Add-ons</p>
+              <p className="text-xs text-muted-foreground">
+                Add-ons are not migrated. Their variables still point at Heroku
+                until you replace them.
+              </p>
+              {preview.addons.map((addon) => (
+                <div
+                  key={addon.name}
+                  className="rounded-md border bg-background px-3 py-2 text-sm"
+                >
+                  <span className="font-mono">{addon.plan}</span>
+                  {addon.configVars && addon.configVars.length > 0 && (
+                    <span className="text-muted-foreground">
+                      {" "}
+                      · {addon.configVars.join(", ")}
+                    </span>
+                  )}
+                </div>
+              ))}
+            </div>
+          )}
+
+          <div className="space-y-1">
+            <div className="flex items-center justify-between">
+              <p className="text-sm font-medium">Suggested paasdeploy.json</p>
+              <Button
+                type="button"
+                variant="ghost"
+                size="sm"
+                onClick={handleCopy}
+              >
+                {copied ? (
+                  <Check className="h-4 w-4 mr-2" />
+                ) : (
+                  <Copy className="h-4 w-4 mr-2" />
+                )}
+                Copy
+              </Button>
+            </div>
+            <p className="text-xs text-muted-foreground">
+              Commit it with a Dockerfile at the root of the repository.
+            </p>
+            <pre className="max-h-64 overflow-auto rounded-md border bg-background p-3 text-xs font-mono">
+              {configJson}
+            </pre>
+          </div>
+        </div>
+      )}
+
+      <div className="flex justify-end">
+        <Button type="button" variant="ghost" size="sm" onClick={onClose}>
+          Close
+        </Button>
+      </div>
+    </div>
+  );
+}
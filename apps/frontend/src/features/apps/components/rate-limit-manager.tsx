@@ -0,0 +1,142 @@
+import { useEffect, useState } from "react";
+import { Loader2 } from "lucide-react";
+import { Button } from "@/components/ui/button";
+import { Checkbox } from "@/components/ui/checkbox";
+import { Input } from "@/components/ui/input";
+import { Label } from "@/components/ui/label";
+import {
+  Select,
+  SelectContent,
+  SelectItem,
+  SelectTrigger,
+  SelectValue,
+} from "@/components/ui/select";
+import type { AppRateLimit, RateLimitSource } from "@/types";
+import { useApp, useUpdateRateLimit } from "../hooks/use-apps";
+
+const SOURCE_LABELS: Record<RateLimitSource, string> = {
+  ip: "Client IP",
+  forwarded: "Forwarded IP (behind Cloudflare)",
+  host: "Request host",
+  header: "Request header",
+};
+
+interface RateLimitManagerProps {
+  readonly appId: string;
+}
+
+export function RateLimitManager({ appId }: RateLimitManagerProps) {
+  const { data: app } = useApp(appId);
+  const updateRateLimit = useUpdateRateLimit();
+
+  const [enabled, setEnabled] = useState(false);
+  const [average, setAverage] = useState("100");
+  const [burst, setBurst] = useState("200");
+  const [source, setSource] = useState<RateLimitSource>("forwarded");
+  const [header, setHeader] = useState("");
+
+  useEffect(() => {
+    const rateLimit = app?.rateLimit;
+    setEnabled(rateLimit != null);
+    if (rateLimit) {
+      setAverage(String(rateLimit.average));
+      setBurst(String(rateLimit.burst));
+      setSource(rateLimit.source);
+      setHeader(rateLimit.header ?? "");
+    }
+  }, [app?.rateLimit]);
+
+  const handleSubmit = (e: React.FormEvent) => {
+    e.preventDefault();
+    const input: AppRateLimit | null = enabled
+      ? {
+          average: Number(average),
+          burst: Number(burst),
+          source,
+          header: source === "header" ? header.trim() : undefined,
+        }
+      : null;
+    updateRateLimit.mutate({ id: appId, input });
+  };
+
+  return (
+    <form onSubmit={handleSubmit} className="space-y-4">
+      <label className="flex items-center gap-2 text-sm">
+        <Checkbox
+          checked={enabled}
+          onCheckedChange={(checked) => setEnabled(checked === true)}
+        />
+        Limit requests to this app
+      </label>
+
+      {enabled && (
+        <div className="grid gap-4 sm:grid-cols-2">
+          <div className="grid gap-2">
+            <Label htmlFor="rate-limit-average">Requests per second</Label>
+            <Input
+              id="rate-limit-average"
+              type="number"
+              min={1}
+              value={average}
+              onChange={(e) => setAverage(e.target.value)}
+            />
+          </div>
+          <div className="grid gap-2">
+            <Label htmlFor="rate-limit-burst">Burst</Label>
+            <Input
+              id="rate-limit-burst"
+              type="number"
+              min={0}
+              value={burst}
+              onChange={(e) => setBurst(e.target.value)}
+            />
+          </div>
+          <div className="grid gap-2">
+            <Label>Limit per</Label>
+            <Select
+              value={source}
+              onValueChange={(v) => setSource(v as RateLimitSource)}
+            >
+              <SelectTrigger>
+                <SelectValue />
+              </SelectTrigger>
+              <SelectContent>
+                {Object.entries(SOURCE_LABELS).map(([value, label]) => (
+                  <SelectItem key={value} value={value}>
+                    {label}
+                  </SelectItem>
+                ))}
+              </SelectContent>
+            </Select>
+          </div>
+          {source === "header" && (
+            <div className="grid gap-2">
+              <Label htmlFor="rate-limit-header">Header name</Label>
+              <Input
+                id="rate-limit-header"
+                placeholder="X-Api-Key"
+                value={header}
+                onChange={(e) => setHeader(e.target.value)}
+              />
+            </div>
+          )}
+        </div>
+      )}
+
+      {updateRateLimit.isError && (
+        <p className="text-sm text-destructive">
+          {updateRateLimit.error instanceof Error
+            ? updateRateLimit.error.message
+            : "Failed to update rate limit"}
+        </p>
+      )}
+
+      <Button type="submit" size="sm" disabled={updateRateLimit.isPending}>
+        {updateRateLimit.isPending && (
+          <Loader2 className="h-4 w-4 animate-spin mr-2" />
+        )}
+        Save
+      </Button>
+    </form>
+  );
+}
@@ -0,0 +1,158 @@
+import { useEffect, useState } from "react";
+import { ArrowRight, Loader2, Plus, Trash2 } from "lucide-react";
+import { Button } from "@/components/ui/button";
+import { Input } from "@/components/ui/input";
+import {
+  Select,
+  SelectContent,
+  SelectItem,
+  SelectTrigger,
+  SelectValue,
+} from "@/components/ui/select";
+import type { AppRedirect, RedirectStatusCode } from "@/types";
+import { useApp, useUpdateRedirects } from "../hooks/use-apps";
+
+const STATUS_LABELS: Record<RedirectStatusCode, string> = {
+  301: "301 Permanent",
+  308: "308 Permanent",
+  302: "302 Temporary",
+  307: "307 Temporary",
+};
+
+interface RedirectRow {
+  sourceHost: string;
+  sourcePath: string;
+  target: string;
+  statusCode: RedirectStatusCode;
+}
+
+const emptyRow: RedirectRow = {
+  sourceHost: "",
+  sourcePath: "",
+  target: "",
+  statusCode: 301,
+};
+
+interface RedirectsManagerProps {
+  readonly appId: string;
+}
+
+export function RedirectsManager({ appId }: RedirectsManagerProps) {
+  const { data: app } = useApp(appId);
+  const updateRedirects = useUpdateRedirects();
+  const [rows, setRows] = useState<RedirectRow[]>([]);
+
+  useEffect(() => {
+    setRows(
+      (app?.redirects ?? []).map((r) => ({
+        sourceHost: r.sourceHost,
+        sourcePath: r.sourcePath ?? "",
+        target: r.target,
+        statusCode: r.statusCode,
+      })),
+    );
+  }, [app?.redirects]);
+
+  const updateRow = (index: number, patch: Partial<RedirectRow>) => {
+    setRows((prev) =>
+      prev.map((row, i) => (i === index ? { ...row, ...patch } : row)),
+    );
+  };
+
+  const handleSubmit = (e: React.FormEvent) => {
+    e.preventDefault();
+    const redirects: AppRedirect[] = rows
+      .filter((row) => row.sourceHost.trim() !== "")
+      .map((row) => ({
+        sourceHost: row.sourceHost.trim(),
+        sourcePath: row.sourcePath.trim() || undefined,
+        target: row.target.trim(),
+        statusCode: row.statusCode,
+      }));
+    updateRedirects.mutate({ id: appId, redirects });
+  };
+
+  return (
+    <form onSubmit={handleSubmit} className="space-y-3">
+      <p className="text-xs text-muted-foreground">
+        Send a host, or a path below it, to another URL. The rest of the path
+        is kept, e.g. www.example.com → https://example.com.
+      </p>
+
+      {rows.map((row, index) => (
+        <div
+          key={index}
+          className="grid gap-2 sm:grid-cols-[1fr_8rem_auto_1fr_9rem_auto] items-center"
+        >
+          <Input
+            placeholder="www.example.com"
+            value={row.sourceHost}
+            onChange={(e) => updateRow(index, { sourceHost: e.target.value })}
+          />
+          <Input
+            placeholder="/path"
+            value={row.sourcePath}
+            onChange={(e) => updateRow(index, { sourcePath: e.target.value })}
+          />
+          <ArrowRight className="hidden sm:block h-4 w-4 text-muted-foreground" />
+          <Input
+            placeholder="https://example.com"
+            value={row.target}
+            onChange={(e) => updateRow(index, { target: e.target.value })}
+          />
+          <Select
+            value={String(row.statusCode)}
+            onValueChange={(v) =>
+              updateRow(index, { statusCode: Number(v) as RedirectStatusCode })
+            }
+          >
+            <SelectTrigger>
+              <SelectValue />
+            </SelectTrigger>
+            <SelectContent>
+              {Object.entries(STATUS_LABELS).map(([value, label]) => (
+                <SelectItem key={value} value={value}>
+                  {label}
+                </SelectItem>
+              ))}
+            </SelectContent>
+          </Select>
+          <Button
+            type="button"
+            variant="ghost"
+            size="icon"
+            onClick={() => setRows((prev) => prev.filter((_, i) => i !== index))}
+          >
+            <Trash2 className="h-4 w-4" />
+          </Button>
+        </div>
+      ))}
+
+      {updateRedirects.isError && (
+        <p className="text-sm text-destructive">
+          {updateRedirects.error instanceof Error
+            ? updateRedirects.error.message
+            : "Failed to update redirects"}
+        </p>
+      )}
+
+      <div className="flex gap-2">
+        <Button
+          type="button"
+          variant="outline"
+          size="sm"
+          onClick={() => setRows((prev) => [...prev, emptyRow])}
+        >
+          <Plus className="h-4 w-4 mr-2" />
+          Add redirect
+        </Button>
+        <Button type="submit" size="sm" disabled={updateRedirects.isPending}>
+          {updateRedirects.isPending && (
+            <Loader2 className="h-4 w-4 animate-spin mr-2" />
+          )}
+          Save
+        </Button>
+      </div>
+    </form>
+  );
+}
@@ -0,0 +1,187 @@
+import { useEffect, useState } from "react";
+import { Loader2 } from "lucide-react";
+import { Button } from "@/components/ui/button";
+import { Checkbox } from "@/components/ui/checkbox";
+import { Input } from "@/components/ui/input";
+import { Label } from "@/components/ui/label";
+import {
+  Select,
+  SelectContent,
+  SelectItem,
+  SelectTrigger,
+  SelectValue,
+} from "@/components/ui/select";
+import type { AppSecurityHeaders, FrameOptions } from "@/types";
+import { useApp, useUpdateSecurityHeaders } from "../hooks/use-apps";
+
+const ONE_YEAR_SECONDS = 31536000;
+
+const REFERRER_POLICIES = [
+  "no-referrer",
+  "no-referrer-when-downgrade",
+  "origin",
+  "origin-when-cross-origin",
+  "same-origin",
+  "strict-origin",
+  "strict-origin-when-cross-origin",
+  "unsafe-url",
+] as const;
+
+interface SecurityHeadersManagerProps {
+  readonly appId: string;
+}
+
+export function SecurityHeadersManager({
+  appId,
+}: SecurityHeadersManagerProps) {
+  const { data: app } = useApp(appId);
+  const updateHeaders = useUpdateSecurityHeaders();
+
+  const [hsts, setHsts] = useState(false);
+  const [hstsMaxAge, setHstsMaxAge] = useState(String(ONE_YEAR_SECONDS));
+  const [includeSubdomains, setIncludeSubdomains] = useState(false);
+  const [preload, setPreload] = useState(false);
+  const [frameOptions, setFrameOptions] = useState<FrameOptions | "">("");
+  const [csp, setCsp] = useState("");
+  const [referrerPolicy, setReferrerPolicy] = useState("");
+
+  useEffect(() => {
+    const headers = app?.securityHeaders;
+    setHsts((headers?.hstsMaxAge ?? 0) > 0);
+    setHstsMaxAge(String(headers?.hstsMaxAge || ONE_YEAR_SECONDS));
+    setIncludeSubdomains(headers?.hstsIncludeSubdomains ?? false);
+    setPreload(headers?.hstsPreload ?? false);
+    setFrameOptions(headers?.frameOptions ?? "");
+    setCsp(headers?.contentSecurityPolicy ?? "");
+    setReferrerPolicy(headers?.referrerPolicy ?? "");
+  }, [app?.securityHeaders]);
+
+  const handleSubmit = (e: React.FormEvent) => {
+    e.preventDefault();
+    const input: AppSecurityHeaders = {
+      hstsMaxAge: hsts ? Number(hstsMaxAge) : 0,
+      hstsIncludeSubdomains: hsts && includeSubdomains,
+      hstsPreload: hsts && preload,
+      frameOptions: frameOptions || undefined,
+      contentSecurityPolicy: csp.trim() || undefined,
+      referrerPolicy: referrerPolicy || undefined,
+    };
+    const enabled =
+      input.hstsMaxAge > 0 ||
+      input.frameOptions != null ||
+      input.contentSecurityPolicy != null ||
+      input.referrerPolicy != null;
+    updateHeaders.mutate({ id: appId, input: enabled ? input : null });
+  };
+
+  return (
+    <form onSubmit={handleSubmit} className="space-y-4">
+      <div className="space-y-2">
+        <label className="flex items-center gap-2 text-sm">
+          <Checkbox
+            checked={hsts}
+            onCheckedChange={(checked) => setHsts(checked === true)}
+          />
+          Strict-Transport-Security (HSTS)
+        </label>
+        {hsts && (
+          <div className="grid gap-4 pl-6 sm:grid-cols-2">
+            <div className="grid gap-2">
+              <Label htmlFor="hsts-max-age">Max age (seconds)</Label>
+              <Input
+                id="hsts-max-age"
+                type="number"
+                min={1}
+                value={hstsMaxAge}
+                onChange={(e) => setHstsMaxAge(e.target.value)}
+              />
+            </div>
+            <div className="flex flex-col justify-end gap-2">
+              <label className="flex items-center gap-2 text-sm">
+                <Checkbox
+                  checked={includeSubdomains}
+                  onCheckedChange={(checked) =>
+                    setIncludeSubdomains(checked === true)
+                  }
+                />
+                Include subdomains
+              </label>
+              <label className="flex items-center gap-2 text-sm">
+                <Checkbox
+                  checked={preload}
+                  onCheckedChange={(checked) => setPreload(checked === true)}
+                />
+                Preload
+              </label>
+            </div>
+          </div>
+        )}
+      </div>
+
+      <div className="grid gap-4 sm:grid-cols-2">
+        <div className="grid gap-2">
+          <Label>X-Frame-Options</Label>
+          <Select
+            value={frameOptions || "none"}
+            onValueChange={(v) =>
+              setFrameOptions(v === "none" ? "" : (v as FrameOptions))
+            }
+          >
+            <SelectTrigger>
+              <SelectValue />
+            </SelectTrigger>
+            <SelectContent>
+              <SelectItem value="none">Not set</SelectItem>
+              <SelectItem value="DENY">DENY</SelectItem>
+              <SelectItem value="SAMEORIGIN">SAMEORIGIN</SelectItem>
+            </SelectContent>
+          </Select>
+        </div>
+        <div className="grid gap-2">
+          <Label>Referrer-Policy</Label>
+          <Select
+            value={referrerPolicy || "none"}
+            onValueChange={(v) => setReferrerPolicy(v === "none" ? "" : v)}
+          >
+            <SelectTrigger>
+              <SelectValue />
+            </SelectTrigger>
+            <SelectContent>
+              <SelectItem value="none">Not set</SelectItem>
+              {REFERRER_POLICIES.map((policy) => (
+                <SelectItem key={policy} value={policy}>
+                  {policy}
+                </SelectItem>
+              ))}
+            </SelectContent>
+          </Select>
+        </div>
+      </div>
+
+      <div className="grid gap-2">
+        <Label htmlFor="content-security-policy">Content-Security-Policy</Label>
+        <Input
+          id="content-security-policy"
+          placeholder="default-src 'self'"
+          value={csp}
+          onChange={(e) => setCsp(e.target.value)}
+        />
+      </div>
+
+      {updateHeaders.isError && (
+        <p className="text-sm text-destructive">
+          {updateHeaders.error instanceof Error
+            ? updateHeaders.error.message
+            : "Failed to update security headers"}
+        </p>
+      )}
+
+      <Button type="submit" size="sm" disabled={updateHeaders.isPending}>
+        {updateHeaders.isPending && (
+          <Loader2 className="h-4 w-4 animate-spin mr-2" />
+        )}
+        Save
+      </Button>
+    </form>
+  );
+}
@@ -0,0 +1,120 @@
+import { useEffect, useState } from "react";
+import { Loader2 } from "lucide-react";
+import { Button } from "@/components/ui/button";
+import { Checkbox } from "@/components/ui/checkbox";
+import {
+  useApp,
+  useApps,
+  useUpdateInternal,
+  useUpdateLinks,
+} from "../hooks/use-apps";
+
+interface ServiceDiscoveryManagerProps {
+  readonly appId: string;
+}
+
+function linkEnvPrefix(name: string): string {
+  const prefix = name.toUpperCase().replaceAll(/[^A-Z0-9]/g, "_");
+  return /^\d/.test(prefix) ? `APP_${prefix}` : prefix;
+}
+
+export function ServiceDiscoveryManager({
+  appId,
+}: ServiceDiscoveryManagerProps) {
+  const { data: app } = useApp(appId);
+  const { data: apps } = useApps();
+  const updateInternal = useUpdateInternal();
+  const updateLinks = useUpdateLinks();
+
+  const [linked, setLinked] = useState<readonly string[]>([]);
+
+  useEffect(() => {
+    setLinked(app?.linkedAppIds ?? []);
+  }, [app?.linkedAppIds]);
+
+  if (!app) return null;
+
+  const candidates = (apps ?? []).filter(
+    (a) =>
+      a.id !== app.id &&
+      a.status !== "deleted" &&
+      (a.serverId ?? null) === (app.serverId ?? null),
+  );
+
+  const toggleLink = (id: string, checked: boolean) => {
+    setLinked(checked ? [...linked, id] : linked.filter((l) => l !== id));
+  };
+
+  const error = updateInternal.error ?? updateLinks.error;
+
+  return (
+    <div className="space-y-4">
+      <div className="space-y-1">
+        <label className="flex items-center gap-2 text-sm">
+          <Checkbox
+            checked={app.internal}
+            disabled={updateInternal.isPending}
+            onCheckedChange={(checked) =>
+              updateInternal.mutate({ id: app.id, internal: checked === true })
+            }
+          />
+          Internal only
+        </label>
+        <p className="pl-6 text-xs text-muted-foreground">
+          No public route is created. Other apps on this server reach it at{" "}
+          <span className="font-mono">{app.name}</span>.
+        </p>
+      </div>
+
+      <div className="space-y-2">
+        <p className="text-sm">Linked apps</p>
+        {candidates.length === 0 ? (
+          <p className="text-xs text-muted-foreground">
+            No other apps on this server.
+          </p>
+        ) : (
+          candidates.map((candidate) => (
+            <label
+              key={candidate.id}
+              className="flex items-center gap-2 text-sm"
+            >
+              <Checkbox
+                checked={linked.includes(candidate.id)}
+                onCheckedChange={(checked) =>
+                  toggleLink(candidate.id, checked === true)
+                }
+              />
+              {candidate.name}
+              <span className="font-mono text-xs text-muted-foreground">
+                {linkEnvPrefix(candidate.name)}_HOST, _PORT, _URL
+              </span>
+            </label>
+          ))
+        )}
+        <p className="text-xs text-muted-foreground">
+          Linked apps are injected as environment variables on the next
+          deploy. Variables you set yourself take precedence.
+        </p>
+      </div>
+
+      {error && (
+        <p className="text-sm text-destructive">
+          {error instanceof Error ? error.message : "Failed to update app"}
+        </p>
+      )}
+
+      {candidates.length > 0 && (
+        <Button
+          size="sm"
+          disabled={updateLinks.isPending}
+          onClick={() => updateLinks.mutate({ id: app.id, appIds: linked })}
+        >
+          {updateLinks.isPending && (
+            <Loader2 className="h-4 w-4 animate-spin mr-2" />
+          )}
+          Save links
+        </Button>
+      )}
+    </div>
+  );
+}
@@ -2,7 +2,14 @@ import { useMutation, useQuery, useQueryClient } from "@tanstack/react-query";
 import { STALE_TIMES } from "@/constants/query-config";
 import { DEFAULTS } from "@/constants/routes";
 import { api } from "@/services/api";
-import type { CreateAppInput, UpdateAppInput } from "@/types";
+import type {
+  AnalyticsPeriod,
+  AppRateLimit,
+  AppRedirect,
+  AppSecurityHeaders,
+  CreateAppInput,
+  UpdateAppInput,
+} from "@/types";
 
 export function useApps() {
   return useQuery({
@@ -39,6 +46,7 @@ export function useDeleteApp() {
     mutationFn: (id: string) => api.apps.delete(id),
     onSuccess: () => {
       queryClient.invalidateQueries({ queryKey: ["apps"] });
+      queryClient.invalidateQueries({ queryKey: ["apps-trash"] });
     },
   });
 }
@@ -50,11 +58,31 @@ export function usePurgeApp() {
     mutationFn: (id: string) => api.apps.purge(id),
     onSuccess: (_data, id) => {
       queryClient.invalidateQueries({ queryKey: ["apps"] });
+      queryClient.invalidateQueries({ queryKey: ["apps-trash"] });
       queryClient.removeQueries({ queryKey: ["app", id] });
     },
   });
 }
 
+export function useTrashedApps() {
+  return useQuery({
+    queryKey: ["apps-trash"],
+    queryFn: () => api.apps.trash(),
+  });
+}
+
+export function useRestoreApp() {
+  const queryClient = useQueryClient();
+
+  return useMutation({
+    mutationFn: (id: string) => api.apps.restore(id),
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ["apps"] });
+      queryClient.invalidateQueries({ queryKey: ["apps-trash"] });
+    },
+  });
+}
+
 export function useSetupWebhook() {
   const queryClient = useQueryClient();
 
@@ -97,6 +125,19 @@ export function useAppURL(appId: string | undefined) {
   });
 }
 
+export function useAppAnalytics(
+  appId: string,
+  period: AnalyticsPeriod,
+  enabled: boolean,
+) {
+  return useQuery({
+    queryKey: ["appAnalytics", appId, period],
+    queryFn: () => api.apps.analytics(appId, period),
+    enabled: !!appId && enabled,
+    refetchInterval: 60_000,
+  });
+}
+
 export function useAppConfig(appId: string | undefined) {
   return useQuery({
     queryKey: ["appConfig", appId],
@@ -118,6 +159,82 @@ export function useUpdateApp() {
   });
 }
 
+export function useUpdateRateLimit() {
+  const queryClient = useQueryClient();
+
+  return useMutation({
+    mutationFn: ({ id, input }: { id: string; input: AppRateLimit | null }) =>
+      input
+        ? api.apps.updateRateLimit(id, input)
+        : api.apps.removeRateLimit(id),
+    onSuccess: (_data, { id }) => {
+      queryClient.invalidateQueries({ queryKey: ["app", id] });
+    },
+  });
+}
+
+export function useUpdateRedirects() {
+  const queryClient = useQueryClient();
+
+  return useMutation({
+    mutationFn: ({
+      id,
+      redirects,
+    }: {
+      id: string;
+      redirects: readonly AppRedirect[];
+    }) => api.apps.updateRedirects(id, redirects),
+    onSuccess: (_data, { id }) => {
+      queryClient.invalidateQueries({ queryKey: ["app", id] });
+    },
+  });
+}
+
+export function useUpdateSecurityHeaders() {
+  const queryClient = useQueryClient();
+
+  return useMutation({
+    mutationFn: ({
+      id,
+      input,
+    }: {
+      id: string;
+      input: AppSecurityHeaders | null;
+    }) =>
+      input
+        ? api.apps.updateSecurityHeaders(id, input)
+        : api.apps.removeSecurityHeaders(id),
+    onSuccess: (_data, { id }) => {
+      queryClient.invalidateQueries({ queryKey: ["app", id] });
+    },
+  });
+}
+
+export function useUpdateInternal() {
+  const queryClient = useQueryClient();
+
+  return useMutation({
+    mutationFn: ({ id, internal }: { id: string; internal: boolean }) =>
+      api.apps.updateInternal(id, internal),
+    onSuccess: (_data, { id }) => {
+      queryClient.invalidateQueries({ queryKey: ["app", id] });
+      queryClient.invalidateQueries({ queryKey: ["apps"] });
+    },
+  });
+}
+
+export function useUpdateLinks() {
+  const queryClient = useQueryClient();
+
+  return useMutation({
+    mutationFn: ({ id, appIds }: { id: string; appIds: readonly string[] }) =>
+      api.apps.updateLinks(id, appIds),
+    onSuccess: (_data, { id }) => {
+      queryClient.invalidateQueries({ queryKey: ["app", id] });
+    },
+  });
+}
+
 export function useRestartContainer() {
   const queryClient = useQueryClient();
 
@@ -5,10 +5,12 @@ export type SectionKey =
   | "containerLogs"
   | "metrics"
   | "envVars"
+  | "analytics"
   | "health"
   | "config"
   | "webhook"
   | "domains"
+  | "traffic"
   | "networks"
   | "volumes";
 
@@ -19,10 +21,12 @@ const DEFAULT_STATE: Record<SectionKey, boolean> = {
   containerLogs: true,
   metrics: false,
   envVars: false,
+  analytics: false,
   health: false,
   config: false,
   webhook: false,
   domains: false,
+  traffic: false,
   networks: false,
   volumes: false,
 };
@@ -1,9 +1,10 @@
-export type AuditTab = "platform" | "webhooks";
+export type AuditTab = "platform" | "webhooks" | "sessions";
 
 export const EVENT_TYPES = [
   { value: "app.created", label: "App Created" },
   { value: "app.deleted", label: "App Deleted" },
   { value: "app.purged", label: "App Purged" },
+  { value: "app.restored", label: "App Restored" },
   { value: "deploy.started", label: "Deploy Started" },
   { value: "deploy.success", label: "Deploy Success" },
   { value: "deploy.failed", label: "Deploy Failed" },
@@ -19,6 +20,10 @@ export const EVENT_TYPES = [
   { value: "user.logged_out", label: "User Logout" },
   { value: "image.removed", label: "Image Removed" },
   { value: "images.pruned", label: "Images Pruned" },
+  { value: "server_task.created", label: "Server Task Created" },
+  { value: "server_task.updated", label: "Server Task Updated" },
+  { value: "server_task.deleted", label: "Server Task Deleted" },
+  { value: "server_task.run", label: "Server Task Run" },
 ] as const;
 
 export const RESOURCE_TYPES = [
@@ -29,4 +34,5 @@ export const RESOURCE_TYPES = [
   { value: "container", label: "Container" },
   { value: "user", label: "User" },
   { value: "image", label: "Image" },
+  { value: "server_task", label: "Server Task" },
 ] as const;
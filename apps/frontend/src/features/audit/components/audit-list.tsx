@@ -5,17 +5,32 @@ import { EmptyState } from "@/components/empty-state";
 import { ErrorMessage } from "@/components/error-message";
 import {
   type AuditFilter,
+  type ExecSessionsFilter,
   type WebhookPayloadsFilter,
   useAuditLogs,
+  useExecSessions,
   useWebhookPayloads,
 } from "../hooks/use-audit";
 import type { AuditTab } from "./audit-constants";
 import { AuditFiltersBar } from "./audit-filters-bar";
 import { AuditListSkeleton } from "./audit-list-skeleton";
 import { AuditPagination } from "./audit-pagination";
+import { ExecSessionsTable } from "./exec-sessions-table";
 import { PlatformEventsTable } from "./platform-events-table";
 import { WebhookEventsTable } from "./webhook-events-table";
 
+const ERROR_MESSAGES: Record<AuditTab, string> = {
+  platform: "Failed to load audit logs",
+  webhooks: "Failed to load webhook payloads",
+  sessions: "Failed to load console sessions",
+};
+
+const PAGINATION_LABELS: Record<AuditTab, string> = {
+  platform: "events",
+  webhooks: "webhooks",
+  sessions: "sessions",
+};
+
 export function AuditList() {
   const [activeTab, setActiveTab] = useState<AuditTab>("platform");
   const [filter, setFilter] = useState<AuditFilter>({
@@ -26,6 +41,10 @@ export function AuditList() {
     limit: 25,
     offset: 0,
   });
+  const [sessionFilter, setSessionFilter] = useState<ExecSessionsFilter>({
+    limit: 25,
+    offset: 0,
+  });
   const [search, setSearch] = useState("");
 
   const { data, isLoading, error } = useAuditLogs(filter, {
@@ -36,6 +55,11 @@ export function AuditList() {
     isLoading: webhookLoading,
     error: webhookError,
   } = useWebhookPayloads(webhookFilter, { enabled: activeTab === "webhooks" });
+  const {
+    data: sessionData,
+    isLoading: sessionLoading,
+    error: sessionError,
+  } = useExecSessions(sessionFilter, { enabled: activeTab === "sessions" });
 
   const handleEventTypeChange = (value: string) => {
     setFilter((prev) => ({
@@ -54,7 +78,12 @@ export function AuditList() {
   };
 
   const handlePrevPage = () => {
-    if (activeTab === "webhooks") {
+    if (activeTab === "sessions") {
+      setSessionFilter((prev) => ({
+        ...prev,
+        offset: Math.max(0, (prev.offset ?? 0) - (prev.limit ?? 25)),
+      }));
+    } else if (activeTab === "webhooks") {
       setWebhookFilter((prev) => ({
         ...prev,
         offset: Math.max(0, (prev.offset ?? 0) - (prev.limit ?? 25)),
@@ -68,7 +97,12 @@ export function AuditList() {
   };
 
   const handleNextPage = () => {
-    if (activeTab === "webhooks") {
+    if (activeTab === "sessions") {
+      setSessionFilter((prev) => ({
+        ...prev,
+        offset: (prev.offset ?? 0) + (prev.limit ?? 25),
+      }));
+    } else if (activeTab === "webhooks") {
       setWebhookFilter((prev) => ({
         ...prev,
         offset: (prev.offset ?? 0) + (prev.limit ?? 25),
@@ -81,23 +115,23 @@ export function AuditList() {
     }
   };
 
-  const isLoadingData = activeTab === "platform" ? isLoading : webhookLoading;
-  const hasError = activeTab === "platform" ? error : webhookError;
+  const isLoadingData = {
+    platform: isLoading,
+    webhooks: webhookLoading,
+    sessions: sessionLoading,
+  }[activeTab];
+  const hasError = {
+    platform: error,
+    webhooks: webhookError,
+    sessions: sessionError,
+  }[activeTab];
 
   if (isLoadingData) {
     return <AuditListSkeleton />;
   }
 
   if (hasError) {
-    return (
-      <ErrorMessage
-        message={
-          activeTab === "platform"
-            ? "Failed to load audit logs"
-            : "Failed to load webhook payloads"
-        }
-      />
-    );
+    return <ErrorMessage message={ERROR_MESSAGES[activeTab]} />;
   }
 
   const filteredLogs = data?.logs.filter((log) => {
@@ -111,28 +145,16 @@ export function AuditList() {
     );
   });
 
-  const currentPage =
-    Math.floor((filter.offset ?? 0) / (filter.limit ?? 25)) + 1;
-  const totalPages = Math.ceil((data?.total ?? 0) / (filter.limit ?? 25));
-  const webhookCurrentPage =
-    Math.floor((webhookFilter.offset ?? 0) / (webhookFilter.limit ?? 25)) + 1;
-  const webhookTotalPages = Math.ceil(
-    (webhookData?.total ?? 0) / (webhookFilter.limit ?? 25),
-  );
-  const displayTotal =
-    activeTab === "platform" ? (data?.total ?? 0) : (webhookData?.total ?? 0);
-  const displayOffset =
-    activeTab === "platform"
-      ? (filter.offset ?? 0)
-      : (webhookFilter.offset ?? 0);
-  const displayLimit =
-    activeTab === "platform"
-      ? (filter.limit ?? 25)
-      : (webhookFilter.limit ?? 25);
-  const displayCurrentPage =
-    activeTab === "platform" ? currentPage : webhookCurrentPage;
-  const displayTotalPages =
-    activeTab === "platform" ? totalPages : webhookTotalPages;
+  const pages = {
+    platform: { total: data?.total ?? 0, filter },
+    webhooks: { total: webhookData?.total ?? 0, filter: webhookFilter },
+    sessions: { total: sessionData?.total ?? 0, filter: sessionFilter },
+  }[activeTab];
+  const displayTotal = pages.total;
+  const displayOffset = pages.filter.offset ?? 0;
+  const displayLimit = pages.filter.limit ?? 25;
+  const displayCurrentPage = Math.floor(displayOffset / displayLimit) + 1;
+  const displayTotalPages = Math.ceil(displayTotal / displayLimit);
 
   return (
     <div className="space-y-4">
@@ -151,6 +173,13 @@ export function AuditList() {
         >
           Webhook Events
         </Button>
+        <Button
+          variant={activeTab === "sessions" ? "default" : "ghost"}
+          size="sm"
+          onClick={() => setActiveTab("sessions")}
+        >
+          Console Sessions
+        </Button>
       </div>
 
       <AuditFiltersBar
@@ -174,6 +203,17 @@ export function AuditList() {
         <WebhookEventsTable payloads={webhookData?.payloads ?? []} />
       )}
 
+      {activeTab === "sessions" && sessionData?.sessions.length === 0 && (
+        <EmptyState
+          icon={Activity}
+          title="No console sessions found"
+          description="Container console sessions are recorded here for 90 days. Keystrokes are never stored, only terminal output."
+        />
+      )}
+      {activeTab === "sessions" && (sessionData?.sessions.length ?? 0) > 0 && (
+        <ExecSessionsTable sessions={sessionData?.sessions ?? []} />
+      )}
+
       {activeTab === "platform" && filteredLogs?.length === 0 && (
         <EmptyState
           icon={Activity}
@@ -192,7 +232,7 @@ export function AuditList() {
           limit={displayLimit}
           currentPage={displayCurrentPage}
           totalPages={displayTotalPages}
-          label={activeTab === "platform" ? "events" : "webhooks"}
+          label={PAGINATION_LABELS[activeTab]}
           onPrevPage={handlePrevPage}
           onNextPage={handleNextPage}
         />
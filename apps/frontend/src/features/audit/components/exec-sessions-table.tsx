@@ -0,0 +1,122 @@
+import { Download } from "lucide-react";
+import { Badge } from "@/components/ui/badge";
+import { Button } from "@/components/ui/button";
+import { Card } from "@/components/ui/card";
+import { formatBytes, formatDateWithSeconds } from "@/lib/format";
+import { type ExecSession, execSessionRecordingUrl } from "../hooks/use-audit";
+
+interface ExecSessionsTableProps {
+  readonly sessions: readonly ExecSession[];
+}
+
+export function ExecSessionsTable({
+  sessions,
+}: Readonly<ExecSessionsTableProps>) {
+  return (
+    <Card>
+      <div className="overflow-x-auto">
+        <table className="w-full">
+          <thead>
+            <tr className="border-b border-border">
+              <th className="text-left py-3 px-4 text-sm font-medium text-muted-foreground">
+                User
+              </th>
+              <th className="text-left py-3 px-4 text-sm font-medium text-muted-foreground">
+                Container
+              </th>
+              <th className="text-left py-3 px-4 text-sm font-medium text-muted-foreground hidden md:table-cell">
+                Server
+              </th>
+              <th className="text-left py-3 px-4 text-sm font-medium text-muted-foreground">
+                Exit
+              </th>
+              <th className="text-left py-3 px-4 text-sm font-medium text-muted-foreground hidden md:table-cell">
+                Output
+              </th>
+              <th className="text-left py-3 px-4 text-sm font-medium text-muted-foreground">
+                Started
+              </th>
+              <th className="py-3 px-4" />
+            </tr>
+          </thead>
+          <tbody>
+            {sessions.map((s) => (
+              <tr
+                key={s.id}
+                className="border-b border-border hover:bg-muted/50 transition-colors"
+              >
+                <td className="py-3 px-4 text-sm">
+                  {s.userName}
+                  {s.ipAddress && (
+                    <span className="block text-xs text-muted-foreground">
+                      {s.ipAddress}
+                    </span>
+                  )}
+                </td>
+                <td className="py-3 px-4 font-mono text-xs">
+                  {s.containerId ? s.containerId.slice(0, 12) : "host"}
+                  <span className="block text-muted-foreground">
+                    {s.shell}
+                  </span>
+                </td>
+                <td className="py-3 px-4 hidden md:table-cell text-sm text-muted-foreground">
+                  {s.serverId ? s.serverId.slice(0, 8) : "local"}
+                </td>
+                <td className="py-3 px-4">
+                  <ExitBadge session={s} />
+                </td>
+                <td className="py-3 px-4 hidden md:table-cell text-sm text-muted-foreground">
+                  {formatBytes(s.outputBytes)}
+                  {s.truncated && " (truncated)"}
+                </td>
+                <td className="py-3 px-4">
+                  <span className="text-sm text-muted-foreground">
+                    {formatDateWithSeconds(s.startedAt)}
+                  </span>
+                </td>
+                <td className="py-3 px-4 text-right">
+                  {s.endedAt && (
+                    <Button variant="ghost" size="sm" asChild>
+                      <a
+                        href={execSessionRecordingUrl(s.id)}
+                        download={`session-${s.id}.cast`}
+                        title="Download asciicast recording"
+                      >
+                        <Download className="h-4 w-4" />
+                      </a>
+                    </Button>
+                  )}
+                </td>
+              </tr>
+            ))}
+          </tbody>
+        </table>
+      </div>
+    </Card>
+  );
+}
+
+interface ExitBadgeProps {
+  readonly session: ExecSession;
+}
+
+function ExitBadge({ session }: ExitBadgeProps) {
+  if (!session.endedAt) {
+    return (
+      <Badge variant="outline" className="text-xs">
+        active
+      </Badge>
+    );
+  }
+  if (session.exitCode == null) {
+    return <span className="text-sm text-muted-foreground">-</span>;
+  }
+  return (
+    <Badge
+      variant="outline"
+      className={`text-xs ${session.exitCode === 0 ? "text-status-success" : "text-status-failed"}`}
+    >
+      {session.exitCode}
+    </Badge>
+  );
+}
@@ -1,5 +1,5 @@
 import type { UseQueryOptions } from "@tanstack/react-query";
-import { useQuery } from "@tanstack/react-query";
+import { useMutation, useQuery, useQueryClient } from "@tanstack/react-query";
 
 const API_URL = import.meta.env.VITE_API_URL ?? "";
 const API_BASE = `${API_URL}/paas-deploy/v1`;
@@ -14,6 +14,7 @@ export interface AuditLog {
   readonly userName?: string;
   readonly details?: Record<string, unknown>;
   readonly ipAddress?: string;
+  readonly traceId?: string;
   readonly createdAt: string;
 }
 
@@ -29,6 +30,7 @@ export interface AuditFilter {
   resourceType?: string;
   resourceId?: string;
   userId?: string;
+  traceId?: string;
   startDate?: string;
   endDate?: string;
   limit?: number;
@@ -41,6 +43,7 @@ async function fetchAuditLogs(filter: AuditFilter): Promise<AuditLogsResponse> {
   if (filter.resourceType) params.set("resourceType", filter.resourceType);
   if (filter.resourceId) params.set("resourceId", filter.resourceId);
   if (filter.userId) params.set("userId", filter.userId);
+  if (filter.traceId) params.set("traceId", filter.traceId);
   if (filter.startDate) params.set("startDate", filter.startDate);
   if (filter.endDate) params.set("endDate", filter.endDate);
   if (filter.limit) params.set("limit", filter.limit.toString());
@@ -129,3 +132,93 @@ export function useWebhookPayloads(
     ...options,
   });
 }
+
+async function replayWebhookPayload(id: string): Promise<unknown> {
+  const response = await fetch(`${API_BASE}/audit/webhooks/${id}/replay`, {
+    method: "POST",
+    credentials: "include",
+  });
+
+  if (!response.ok) {
+    throw new Error("Failed to replay webhook");
+  }
+
+  const data = await response.json();
+  return data.data;
+}
+
+export function useReplayWebhook() {
+  const queryClient = useQueryClient();
+
+  return useMutation({
+    mutationFn: (id: string) => replayWebhookPayload(id),
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ["webhook-payloads"] });
+      queryClient.invalidateQueries({ queryKey: ["audit-logs"] });
+    },
+  });
+}
+
+export interface ExecSession {
+  readonly id: string;
+  readonly userId?: string;
+  readonly userName: string;
+  readonly serverId?: string;
+  readonly containerId: string;
+  readonly shell: string;
+  readonly ipAddress?: string;
+  readonly startedAt: string;
+  readonly endedAt?: string;
+  readonly exitCode?: number;
+  readonly outputBytes: number;
+  readonly truncated: boolean;
+}
+
+export interface ExecSessionsResponse {
+  readonly sessions: readonly ExecSession[];
+  readonly total: number;
+}
+
+export interface ExecSessionsFilter {
+  readonly limit?: number;
+  readonly offset?: number;
+}
+
+async function fetchExecSessions(
+  filter: ExecSessionsFilter = {},
+): Promise<ExecSessionsResponse> {
+  const params = new URLSearchParams();
+  if (filter.limit != null) params.set("limit", filter.limit.toString());
+  if (filter.offset != null) params.set("offset", filter.offset.toString());
+
+  const response = await fetch(
+    `${API_BASE}/exec-sessions?${params.toString()}`,
+    { credentials: "include" },
+  );
+
+  if (!response.ok) {
+    throw new Error("Failed to fetch exec sessions");
+  }
+
+  const data = await response.json();
+  return data.data;
+}
+
+export function useExecSessions(
+  filter: ExecSessionsFilter = {},
+  options?: Omit<
+    UseQueryOptions<ExecSessionsResponse, Error>,
+    "queryKey" | "queryFn"
+  >,
+) {
+  return useQuery({
+    queryKey: ["exec-sessions", filter],
+    queryFn: () => fetchExecSessions(filter),
+    refetchOnWindowFocus: true,
+    ...options,
+  });
+}
+
+export function execSessionRecordingUrl(sessionId: string): string {
+  return `${API_BASE}/exec-sessions/${sessionId}/recording`;
+}
@@ -1,2 +1,6 @@
 export { AuditList } from "./components/audit-list";
-export { useAuditLogs, useWebhookPayloads } from "./hooks/use-audit";
+export {
+  useAuditLogs,
+  useExecSessions,
+  useWebhookPayloads,
+} from "./hooks/use-audit";
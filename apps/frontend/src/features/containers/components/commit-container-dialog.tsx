@@ -0,0 +1,154 @@
+import { useState } from "react";
+import { Loader2 } from "lucide-react";
+import { Button } from "@/components/ui/button";
+import { Checkbox } from "@/components/ui/checkbox";
+import {
+  Dialog,
+  DialogContent,
+  DialogDescription,
+  DialogFooter,
+  DialogHeader,
+  DialogTitle,
+} from "@/components/ui/dialog";
+import { Input } from "@/components/ui/input";
+import { Label } from "@/components/ui/label";
+import { useCommitContainer } from "../hooks/use-containers";
+
+interface CommitContainerDialogProps {
+  readonly containerId: string;
+  readonly containerName: string;
+  readonly serverId?: string;
+  readonly open: boolean;
+  readonly onOpenChange: (open: boolean) => void;
+}
+
+export function CommitContainerDialog({
+  containerId,
+  containerName,
+  serverId,
+  open,
+  onOpenChange,
+}: CommitContainerDialogProps) {
+  const [repository, setRepository] = useState(
+    `${containerName.toLowerCase()}-snapshot`,
+  );
+  const [tag, setTag] = useState("");
+  const [message, setMessage] = useState("");
+  const [pause, setPause] = useState(true);
+  const commitContainer = useCommitContainer();
+
+  const handleClose = (value: boolean) => {
+    if (!value) {
+      commitContainer.reset();
+    }
+    onOpenChange(value);
+  };
+
+  const handleSubmit = (event: React.FormEvent) => {
+    event.preventDefault();
+    commitContainer.mutate({
+      id: containerId,
+      input: { repository: repository.trim(), tag: tag.trim(), message, pause },
+      serverId,
+    });
+  };
+
+  const result = commitContainer.data;
+
+  return (
+    <Dialog open={open} onOpenChange={handleClose}>
+      <DialogContent className="sm:max-w-lg">
+        <DialogHeader>
+          <DialogTitle>Commit to Image - {containerName}</DialogTitle>
+          <DialogDescription>
+            Save the container filesystem as a new image, e.g. to preserve a
+            hotfix or debug it elsewhere. Volumes are not included.
+          </DialogDescription>
+        </DialogHeader>
+
+        {result ? (
+          <div className="space-y-2 py-2 text-sm">
+            <p>
+              Created image{" "}
+              <code className="rounded bg-muted px-1">{result.image}</code>
+            </p>
+            <p className="font-mono text-xs text-muted-foreground break-all">
+              {result.imageId}
+            </p>
+            <DialogFooter>
+              <Button onClick={() => handleClose(false)}>Close</Button>
+            </DialogFooter>
+          </div>
+        ) : (
+          <form onSubmit={handleSubmit} className="space-y-4 py-2">
+            <div className="space-y-2">
+              <Label htmlFor="commit-repository">Repository</Label>
+              <Input
+                id="commit-repository"
+                value={repository}
+                onChange={(e) => setRepository(e.target.value)}
+                autoComplete="off"
+              />
+            </div>
+            <div className="space-y-2">
+              <Label htmlFor="commit-tag">Tag</Label>
+              <Input
+                id="commit-tag"
+                placeholder="latest"
+                value={tag}
+                onChange={(e) => setTag(e.target.value)}
+                autoComplete="off"
+              />
+            </div>
+            <div className="space-y-2">
+              <Label htmlFor="commit-message">Message</Label>
+              <Input
+                id="commit-message"
+                placeholder="Optional commit message"
+                value={message}
+                onChange={(e) => setMessage(e.target.value)}
+                autoComplete="off"
+              />
+            </div>
+            <div className="flex items-center space-x-2">
+              <Checkbox
+                id="commit-pause"
+                checked={pause}
+                onCheckedChange={(c) => setPause(c === true)}
+              />
+              <label
+                htmlFor="commit-pause"
+                className="text-sm font-normal cursor-pointer"
+              >
+                Pause the container while committing
+              </label>
+            </div>
+            {commitContainer.isError && (
+              <p className="text-sm text-destructive">
+                {commitContainer.error.message}
+              </p>
+            )}
+            <DialogFooter>
+              <Button
+                type="button"
+                variant="outline"
+                onClick={() => handleClose(false)}
+              >
+                Cancel
+              </Button>
+              <Button
+                type="submit"
+                disabled={!repository.trim() || commitContainer.isPending}
+              >
+                {commitContainer.isPending && (
+                  <Loader2 className="h-4 w-4 mr-2 animate-spin" />
+                )}
+                Commit
+              </Button>
+            </DialogFooter>
+          </form>
+        )}
+      </DialogContent>
+    </Dialog>
+  );
+}
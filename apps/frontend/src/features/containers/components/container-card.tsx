@@ -2,8 +2,12 @@ import { useState } from "react";
 import {
   ChevronDown,
   ChevronUp,
+  Cpu,
   ExternalLink,
+  FolderOpen,
+  GitCommitHorizontal,
   HardDrive,
+  Info,
   MoreVertical,
   Network,
   Play,
@@ -48,6 +52,10 @@ import {
 } from "../hooks/use-containers";
 import { ContainerActions } from "./container-actions";
 import { ContainerConsoleDialog } from "./container-console-dialog";
+import { CommitContainerDialog } from "./commit-container-dialog";
+import { ContainerFilesDialog } from "./container-files-dialog";
+import { ContainerInspectDialog } from "./container-inspect-dialog";
+import { ContainerProcessesDialog } from "./container-processes-dialog";
 import { ContainerLogsDialog } from "./container-logs-dialog";
 import { ContainerSSLDialog, isDatabaseImage } from "./container-ssl-dialog";
 import {
@@ -88,6 +96,10 @@ export function ContainerCard({
   const [showDeleteDialog, setShowDeleteDialog] = useState(false);
   const [showLogsDialog, setShowLogsDialog] = useState(false);
   const [showConsoleDialog, setShowConsoleDialog] = useState(false);
+  const [showFilesDialog, setShowFilesDialog] = useState(false);
+  const [showProcessesDialog, setShowProcessesDialog] = useState(false);
+  const [showInspectDialog, setShowInspectDialog] = useState(false);
+  const [showCommitDialog, setShowCommitDialog] = useState(false);
   const [showSSLDialog, setShowSSLDialog] = useState(false);
   const [expanded, setExpanded] = useState(false);
 
@@ -308,12 +320,32 @@ export function ContainerCard({
                 <ScrollText className="mr-2 h-4 w-4" />
                 View Logs
               </DropdownMenuItem>
+              <DropdownMenuItem onClick={() => setShowInspectDialog(true)}>
+                <Info className="mr-2 h-4 w-4" />
+                Inspect
+              </DropdownMenuItem>
+              <DropdownMenuItem onClick={() => setShowCommitDialog(true)}>
+                <GitCommitHorizontal className="mr-2 h-4 w-4" />
+                Commit to Image
+              </DropdownMenuItem>
               {isRunning && (
                 <DropdownMenuItem onClick={() => setShowConsoleDialog(true)}>
                   <Terminal className="mr-2 h-4 w-4" />
                   Open Console
                 </DropdownMenuItem>
               )}
+              {isRunning && (
+                <DropdownMenuItem onClick={() => setShowFilesDialog(true)}>
+                  <FolderOpen className="mr-2 h-4 w-4" />
+                  Browse Files
+                </DropdownMenuItem>
+              )}
+              {isRunning && (
+                <DropdownMenuItem onClick={() => setShowProcessesDialog(true)}>
+                  <Cpu className="mr-2 h-4 w-4" />
+                  Processes
+                </DropdownMenuItem>
+              )}
               {canConfigureSSL && (
                 <DropdownMenuItem onClick={() => setShowSSLDialog(true)}>
                   <ShieldCheck className="mr-2 h-4 w-4" />
@@ -380,6 +412,38 @@ export function ContainerCard({
         onOpenChange={setShowConsoleDialog}
       />
 
+      <ContainerFilesDialog
+        containerId={container.id}
+        containerName={container.name}
+        serverId={serverId}
+        open={showFilesDialog}
+        onOpenChange={setShowFilesDialog}
+      />
+
+      <ContainerProcessesDialog
+        containerId={container.id}
+        containerName={container.name}
+        serverId={serverId}
+        open={showProcessesDialog}
+        onOpenChange={setShowProcessesDialog}
+      />
+
+      <ContainerInspectDialog
+        containerId={container.id}
+        containerName={container.name}
+        serverId={serverId}
+        open={showInspectDialog}
+        onOpenChange={setShowInspectDialog}
+      />
+
+      <CommitContainerDialog
+        containerId={container.id}
+        containerName={container.name}
+        serverId={serverId}
+        open={showCommitDialog}
+        onOpenChange={setShowCommitDialog}
+      />
+
       {canConfigureSSL && serverId && serverHost && (
         <ContainerSSLDialog
           containerId={container.id}
@@ -0,0 +1,243 @@
+import { useRef, useState } from "react";
+import {
+  ArrowUp,
+  Download,
+  File,
+  FileSymlink,
+  Folder,
+  Loader2,
+  Upload,
+} from "lucide-react";
+import { Button } from "@/components/ui/button";
+import {
+  Dialog,
+  DialogContent,
+  DialogDescription,
+  DialogHeader,
+  DialogTitle,
+} from "@/components/ui/dialog";
+import { formatBytes } from "@/lib/format";
+import type { ContainerFile } from "@/types";
+import {
+  useContainerFiles,
+  useDownloadContainerFile,
+  useUploadContainerFile,
+} from "../hooks/use-containers";
+
+const MAX_FILE_BYTES = 50 * 1024 * 1024;
+
+interface ContainerFilesDialogProps {
+  readonly containerId: string | null;
+  readonly containerName: string;
+  readonly serverId?: string;
+  readonly open: boolean;
+  readonly onOpenChange: (open: boolean) => void;
+}
+
+function joinPath(dir: string, name: string): string {
+  return dir === "/" ? `/${name}` : `${dir}/${name}`;
+}
+
+function parentPath(dir: string): string {
+  const parent = dir.slice(0, dir.lastIndexOf("/"));
+  return parent === "" ? "/" : parent;
+}
+
+export function ContainerFilesDialog({
+  containerId,
+  containerName,
+  serverId,
+  open,
+  onOpenChange,
+}: ContainerFilesDialogProps) {
+  const [path, setPath] = useState("/");
+  const [error, setError] = useState<string | null>(null);
+  const inputRef = useRef<HTMLInputElement>(null);
+
+  const { data, isLoading, isError } = useContainerFiles(
+    open ? (containerId ?? undefined) : undefined,
+    path,
+    serverId,
+  );
+  const downloadFile = useDownloadContainerFile();
+  const uploadFile = useUploadContainerFile();
+
+  const navigate = (next: string) => {
+    setError(null);
+    setPath(next);
+  };
+
+  const handleOpen = (file: ContainerFile) => {
+    if (!containerId) return;
+    const target = joinPath(path, file.name);
+    if (file.type === "dir" || file.type === "symlink") {
+      navigate(target);
+      return;
+    }
+    setError(null);
+    downloadFile.mutate(
+      { id: containerId, path: target, serverId },
+      { onError: (err) => setError(err.message) },
+    );
+  };
+
+  const handleUpload = (event: React.ChangeEvent<HTMLInputElement>) => {
+    const file = event.target.files?.[0];
+    event.target.value = "";
+    if (!file || !containerId) return;
+    if (file.size > MAX_FILE_BYTES) {
+      setError(`${file.name} exceeds the 50 MB upload limit`);
+      return;
+    }
+    setError(null);
+    uploadFile.mutate(
+      { id: containerId, path, file, serverId },
+      { onError: (err) => setError(err.message) },
+    );
+  };
+
+  return (
+    <Dialog open={open} onOpenChange={onOpenChange}>
+      <DialogContent className="max-w-3xl h-[70vh] flex flex-col">
+        <DialogHeader>
+          <DialogTitle>Files - {containerName}</DialogTitle>
+          <DialogDescription>
+            Downloads and uploads are limited to 50 MB and recorded in the audit
+            log
+          </DialogDescription>
+        </DialogHeader>
+
+        <div className="flex items-center gap-2">
+          <Button
+            variant="outline"
+            size="icon"
+            className="h-8 w-8"
+            onClick={() => navigate(parentPath(path))}
+            disabled={path === "/"}
+            title="Parent directory"
+          >
+            <ArrowUp className="h-4 w-4" />
+          </Button>
+          <code className="flex-1 truncate rounded bg-muted px-2 py-1 text-sm">
+            {path}
+          </code>
+          <input
+            ref={inputRef}
+            type="file"
+            className="hidden"
+            onChange={handleUpload}
+          />
+          <Button
+            variant="outline"
+            size="sm"
+            onClick={() => inputRef.current?.click()}
+            disabled={uploadFile.isPending}
+          >
+            {uploadFile.isPending ? (
+              <Loader2 className="h-4 w-4 mr-2 animate-spin" />
+            ) : (
+              <Upload className="h-4 w-4 mr-2" />
+            )}
+            Upload
+          </Button>
+        </div>
+
+        {error && <p className="text-sm text-destructive">{error}</p>}
+
+        <div className="flex-1 min-h-0 overflow-auto rounded-md border">
+          {isLoading && (
+            <div className="flex h-full items-center justify-center">
+              <Loader2 className="h-6 w-6 animate-spin text-muted-foreground" />
+            </div>
+          )}
+          {isError && (
+            <p className="p-4 text-sm text-destructive">
+              Failed to list {path}. The container must be running and have
+              a shell with ls.
+            </p>
+          )}
+          {data?.files.length === 0 && (
+            <p className="p-4 text-sm text-muted-foreground">Empty directory</p>
+          )}
+          {data && data.files.length > 0 && (
+            <table className="w-full text-sm">
+              <tbody>
+                {data.files.map((file) => (
+                  <FileRow
+                    key={file.name}
+                    file={file}
+                    onOpen={() => handleOpen(file)}
+                  />
+                ))}
+              </tbody>
+            </table>
+          )}
+        </div>
+      </DialogContent>
+    </Dialog>
+  );
+}
+
+interface FileRowProps {
+  readonly file: ContainerFile;
+  readonly onOpen: () => void;
+}
+
+function FileRow({ file, onOpen }: FileRowProps) {
+  const isDir = file.type === "dir";
+  const canDownload = file.type === "file";
+  const Icon = {
+    dir: Folder,
+    symlink: FileSymlink,
+    file: File,
+    other: File,
+  }[file.type];
+
+  return (
+    <tr className="border-b border-border hover:bg-muted/50 transition-colors">
+      <td className="py-2 px-3">
+        <button
+          type="button"
+          className="flex items-center gap-2 text-left disabled:cursor-default"
+          onClick={onOpen}
+          disabled={!isDir && !canDownload && file.type !== "symlink"}
+        >
+          <Icon
+            className={`h-4 w-4 shrink-0 ${isDir ? "text-primary" : "text-muted-foreground"}`}
+          />
+          <span className="font-mono">{file.name}</span>
+          {file.linkTarget && (
+            <span className="text-xs text-muted-foreground">
+              → {file.linkTarget}
+            </span>
+          )}
+        </button>
+      </td>
+      <td className="py-2 px-3 font-mono text-xs text-muted-foreground hidden md:table-cell">
+        {file.mode}
+      </td>
+      <td className="py-2 px-3 text-xs text-muted-foreground hidden md:table-cell">
+        {file.owner}
+      </td>
+      <td className="py-2 px-3 text-xs text-muted-foreground whitespace-nowrap">
+        {canDownload ? formatBytes(file.size) : ""}
+      </td>
+      <td className="py-2 px-3 text-xs text-muted-foreground whitespace-nowrap hidden md:table-cell">
+        {file.modified}
+      </td>
+      <td className="py-2 px-3 text-right">
+        {canDownload && (
+          <Button
+            variant="ghost"
+            size="icon"
+            className="h-7 w-7"
+            onClick={onOpen}
+            title="Download"
+          >
+            <Download className="h-4 w-4" />
+          </Button>
+        )}
+      </td>
+    </tr>
+  );
+}
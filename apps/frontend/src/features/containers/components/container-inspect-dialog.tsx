@@ -0,0 +1,180 @@
+import { Loader2 } from "lucide-react";
+import { Badge } from "@/components/ui/badge";
+import {
+  Dialog,
+  DialogContent,
+  DialogDescription,
+  DialogHeader,
+  DialogTitle,
+} from "@/components/ui/dialog";
+import type { ContainerInspect } from "@/types";
+import { useContainer } from "../hooks/use-containers";
+
+interface ContainerInspectDialogProps {
+  readonly containerId: string | null;
+  readonly containerName: string;
+  readonly serverId?: string;
+  readonly open: boolean;
+  readonly onOpenChange: (open: boolean) => void;
+}
+
+export function ContainerInspectDialog({
+  containerId,
+  containerName,
+  serverId,
+  open,
+  onOpenChange,
+}: ContainerInspectDialogProps) {
+  const { data, isLoading, isError } = useContainer(
+    open ? (containerId ?? undefined) : undefined,
+    serverId,
+  );
+
+  return (
+    <Dialog open={open} onOpenChange={onOpenChange}>
+      <DialogContent className="max-w-3xl max-h-[80vh] overflow-y-auto">
+        <DialogHeader>
+          <DialogTitle>Inspect - {containerName}</DialogTitle>
+          <DialogDescription>
+            Environment values are hidden; only variable names are shown
+          </DialogDescription>
+        </DialogHeader>
+
+        {isLoading && (
+          <div className="flex justify-center py-8">
+            <Loader2 className="h-6 w-6 animate-spin text-muted-foreground" />
+          </div>
+        )}
+        {isError && (
+          <p className="text-sm text-destructive">
+            Failed to inspect container
+          </p>
+        )}
+        {data && <InspectDetails inspect={data.inspect} />}
+      </DialogContent>
+    </Dialog>
+  );
+}
+
+function InspectDetails({ inspect }: { readonly inspect: ContainerInspect }) {
+  const { state, health } = inspect;
+
+  return (
+    <div className="space-y-5 text-sm">
+      <Section title="State">
+        <div className="grid grid-cols-2 gap-x-4 gap-y-1 md:grid-cols-3">
+          <Field label="Status" value={state.status} />
+          <Field label="Exit code" value={String(state.exitCode)} />
+          <Field label="Restart count" value={String(inspect.restartCount)} />
+          <Field label="Restart policy" value={inspect.restartPolicy || "-"} />
+          <Field label="Started" value={state.startedAt} />
+          <Field label="Finished" value={state.finishedAt ?? "-"} />
+        </div>
+        <div className="mt-2 flex flex-wrap gap-2">
+          {state.oomKilled && <Badge variant="destructive">OOM killed</Badge>}
+          {state.dead && <Badge variant="destructive">Dead</Badge>}
+          {state.restarting && <Badge variant="secondary">Restarting</Badge>}
+        </div>
+        {state.error && <p className="mt-2 text-destructive">{state.error}</p>}
+      </Section>
+
+      {health && (
+        <Section
+          title={`Health: ${health.status} (failing streak ${health.failingStreak})`}
+        >
+          <ul className="space-y-2">
+            {health.log.map((check) => (
+              <li key={check.start} className="rounded border p-2">
+                <div className="flex justify-between text-xs text-muted-foreground">
+                  <span>{check.start}</span>
+                  <span>exit {check.exitCode}</span>
+                </div>
+                {check.output && (
+                  <pre className="mt-1 whitespace-pre-wrap font-mono text-xs">
+                    {check.output}
+                  </pre>
+                )}
+              </li>
+            ))}
+          </ul>
+        </Section>
+      )}
+
+      <Section title="Image">
+        <p className="font-mono text-xs break-all">{inspect.image}</p>
+        <p className="font-mono text-xs text-muted-foreground break-all">
+          {inspect.imageId}
+        </p>
+      </Section>
+
+      <Section title={`Environment (${inspect.envNames.length})`}>
+        <div className="flex flex-wrap gap-1">
+          {inspect.envNames.map((name) => (
+            <code
+              key={name}
+              className="rounded bg-muted px-1.5 py-0.5 text-xs"
+            >
+              {name}
+            </code>
+          ))}
+        </div>
+      </Section>
+
+      {inspect.mounts.length > 0 && (
+        <Section title="Mounts">
+          <ul className="space-y-1 font-mono text-xs">
+            {inspect.mounts.map((mount) => (
+              <li key={mount.destination} className="break-all">
+                {mount.source} → {mount.destination} ({mount.type}
+                {mount.readOnly ? ", ro" : ""})
+              </li>
+            ))}
+          </ul>
+        </Section>
+      )}
+
+      {inspect.networks.length > 0 && (
+        <Section title="Networks">
+          <ul className="space-y-1 font-mono text-xs">
+            {inspect.networks.map((network) => (
+              <li key={network.name}>
+                {network.name}: {network.ipAddress || "-"}
+                {network.gateway && ` via ${network.gateway}`}
+              </li>
+            ))}
+          </ul>
+        </Section>
+      )}
+    </div>
+  );
+}
+
+function Section({
+  title,
+  children,
+}: {
+  readonly title: string;
+  readonly children: React.ReactNode;
+}) {
+  return (
+    <section>
+      <h4 className="mb-2 font-medium">{title}</h4>
+      {children}
+    </section>
+  );
+}
+
+function Field({
+  label,
+  value,
+}: {
+  readonly label: string;
+  readonly value: string;
+}) {
+  return (
+    <div>
+      <span className="text-muted-foreground">{label}: </span>
+      <span className="font-mono text-xs">{value}</span>
+    </div>
+  );
+}
@@ -0,0 +1,102 @@
+import { Loader2 } from "lucide-react";
+import {
+  Dialog,
+  DialogContent,
+  DialogDescription,
+  DialogHeader,
+  DialogTitle,
+} from "@/components/ui/dialog";
+import { useContainerTop } from "../hooks/use-containers";
+
+const HIGH_CPU_PERCENT = 80;
+
+interface ContainerProcessesDialogProps {
+  readonly containerId: string | null;
+  readonly containerName: string;
+  readonly serverId?: string;
+  readonly open: boolean;
+  readonly onOpenChange: (open: boolean) => void;
+}
+
+export function ContainerProcessesDialog({
+  containerId,
+  containerName,
+  serverId,
+  open,
+  onOpenChange,
+}: ContainerProcessesDialogProps) {
+  const { data, isLoading, isError } = useContainerTop(
+    open ? (containerId ?? undefined) : undefined,
+    serverId,
+  );
+  const processes = [...(data ?? [])].sort(
+    (a, b) => b.cpuPercent - a.cpuPercent,
+  );
+
+  return (
+    <Dialog open={open} onOpenChange={onOpenChange}>
+      <DialogContent className="max-w-4xl max-h-[80vh] flex flex-col">
+        <DialogHeader>
+          <DialogTitle>Processes - {containerName}</DialogTitle>
+          <DialogDescription>
+            Sorted by CPU usage, refreshed every 5 seconds
+          </DialogDescription>
+        </DialogHeader>
+
+        <div className="flex-1 min-h-0 overflow-auto rounded-md border">
+          {isLoading && (
+            <div className="flex items-center justify-center p-8">
+              <Loader2 className="h-6 w-6 animate-spin text-muted-foreground" />
+            </div>
+          )}
+          {isError && (
+            <p className="p-4 text-sm text-destructive">
+              Failed to list container processes
+            </p>
+          )}
+          {processes.length > 0 && (
+            <table className="w-full text-sm">
+              <thead>
+                <tr className="border-b border-border text-left text-muted-foreground">
+                  <th className="py-2 px-3 font-medium">PID</th>
+                  <th className="py-2 px-3 font-medium">User</th>
+                  <th className="py-2 px-3 font-medium text-right">CPU %</th>
+                  <th className="py-2 px-3 font-medium text-right">MEM %</th>
+                  <th className="py-2 px-3 font-medium hidden md:table-cell">
+                    Elapsed
+                  </th>
+                  <th className="py-2 px-3 font-medium">Command</th>
+                </tr>
+              </thead>
+              <tbody>
+                {processes.map((p) => (
+                  <tr key={p.pid} className="border-b border-border">
+                    <td className="py-2 px-3 font-mono text-xs">{p.pid}</td>
+                    <td className="py-2 px-3 text-xs">{p.user}</td>
+                    <td
+                      className={`py-2 px-3 text-right font-mono text-xs ${p.cpuPercent >= HIGH_CPU_PERCENT ? "text-status-failed" : ""}`}
+                    >
+                      {p.cpuPercent.toFixed(1)}
+                    </td>
+                    <td className="py-2 px-3 text-right font-mono text-xs">
+                      {p.memPercent.toFixed(1)}
+                    </td>
+                    <td className="py-2 px-3 font-mono text-xs hidden md:table-cell">
+                      {p.elapsed}
+                    </td>
+                    <td
+                      className="py-2 px-3 font-mono text-xs max-w-[320px] truncate"
+                      title={p.command}
+                    >
+                      {p.command}
+                    </td>
+                  </tr>
+                ))}
+              </tbody>
+            </table>
+          )}
+        </div>
+      </DialogContent>
+    </Dialog>
+  );
+}
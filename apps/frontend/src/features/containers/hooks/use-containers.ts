@@ -1,6 +1,6 @@
 import { useMutation, useQuery, useQueryClient } from "@tanstack/react-query";
 import { api } from "@/services/api";
-import type { CreateContainerInput } from "@/types";
+import type { CommitContainerInput, CreateContainerInput } from "@/types";
 
 export function useContainers(all = true, serverId?: string) {
   return useQuery({
@@ -10,10 +10,10 @@ export function useContainers(all = true, serverId?: string) {
   });
 }
 
-export function useContainer(id: string | undefined) {
+export function useContainer(id: string | undefined, serverId?: string) {
   return useQuery({
-    queryKey: ["containers", id],
-    queryFn: () => api.containers.get(id!),
+    queryKey: ["containers", id, serverId],
+    queryFn: () => api.containers.get(id!, serverId),
     enabled: Boolean(id),
   });
 }
@@ -31,6 +31,15 @@ export function useContainerLogs(
   });
 }
 
+export function useContainerTop(id: string | undefined, serverId?: string) {
+  return useQuery({
+    queryKey: ["containers", id, "top", serverId],
+    queryFn: () => api.containers.top(id!, serverId),
+    enabled: Boolean(id),
+    refetchInterval: 5000,
+  });
+}
+
 export function useCreateContainer() {
   const queryClient = useQueryClient();
 
@@ -100,3 +109,69 @@ export function useRemoveContainer() {
     },
   });
 }
+
+interface CommitContainerMutationInput {
+  readonly id: string;
+  readonly input: CommitContainerInput;
+  readonly serverId?: string;
+}
+
+export function useCommitContainer() {
+  const queryClient = useQueryClient();
+
+  return useMutation({
+    mutationFn: ({ id, input, serverId }: CommitContainerMutationInput) =>
+      api.containers.commit(id, input, serverId),
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ["images"] });
+    },
+  });
+}
+
+export function useContainerFiles(
+  id: string | undefined,
+  path: string,
+  serverId?: string,
+) {
+  return useQuery({
+    queryKey: ["containers", id, "files", path, serverId],
+    queryFn: () => api.containers.files(id!, path, serverId),
+    enabled: Boolean(id),
+  });
+}
+
+interface ContainerFileInput {
+  readonly id: string;
+  readonly path: string;
+  readonly serverId?: string;
+}
+
+export function useDownloadContainerFile() {
+  return useMutation({
+    mutationFn: async ({ id, path, serverId }: ContainerFileInput) => {
+      const blob = await api.containers.downloadFile(id, path, serverId);
+      const url = URL.createObjectURL(blob);
+      const link = document.createElement("a");
+      link.href = url;
+      link.download = path.split("/").pop() ?? "download";
+      link.click();
+      URL.revokeObjectURL(url);
+    },
+  });
+}
+
+interface UploadContainerFileInput extends ContainerFileInput {
+  readonly file: File;
+}
+
+export function useUploadContainerFile() {
+  const queryClient = useQueryClient();
+
+  return useMutation({
+    mutationFn: ({ id, path, file, serverId }: UploadContainerFileInput) =>
+      api.containers.uploadFile(id, path, file, serverId),
+    onSuccess: (_, { id }) => {
+      queryClient.invalidateQueries({ queryKey: ["containers", id, "files"] });
+    },
+  });
+}
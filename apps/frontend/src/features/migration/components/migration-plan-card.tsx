@@ -0,0 +1,131 @@
+import { ClipboardList, Download, Loader2 } from "lucide-react";
+import { Badge } from "@/components/ui/badge";
+import { Button } from "@/components/ui/button";
+import {
+  Card,
+  CardContent,
+  CardDescription,
+  CardHeader,
+  CardTitle,
+} from "@/components/ui/card";
+import type { SitePlan } from "@/types";
+import {
+  useDownloadMigrationPlanMutation,
+  useMigrationPlanMutation,
+} from "../hooks/use-migration";
+
+interface MigrationPlanCardProps {
+  readonly serverId?: string;
+}
+
+export function MigrationPlanCard({ serverId }: MigrationPlanCardProps) {
+  const planMutation = useMigrationPlanMutation(serverId);
+  const downloadMutation = useDownloadMigrationPlanMutation(serverId);
+  const plan = planMutation.data;
+
+  return (
+    <Card>
+      <CardHeader>
+        <div className="flex items-center justify-between gap-2">
+          <div>
+            <CardTitle className="flex items-center gap-2">
+              <ClipboardList className="h-5 w-5" />
+              Dry Run
+            </CardTitle>
+            <CardDescription>
+              Preview the migration of every site without touching any
+              container
+            </CardDescription>
+          </div>
+          <div className="flex gap-2">
+            <Button
+              variant="outline"
+              size="sm"
+              onClick={() => planMutation.mutate()}
+              disabled={planMutation.isPending}
+            >
+              {planMutation.isPending ? (
+                <Loader2 className="h-4 w-4 mr-2 animate-spin" />
+              ) : (
+                <ClipboardList className="h-4 w-4 mr-2" />
+              )}
+              Generate plan
+            </Button>
+            <Button
+              variant="outline"
+              size="sm"
+              onClick={() => downloadMutation.mutate()}
+              disabled={downloadMutation.isPending}
+            >
+              <Download className="h-4 w-4 mr-2" />
+              Markdown
+            </Button>
+          </div>
+        </div>
+      </CardHeader>
+      <CardContent className="space-y-3">
+        {(planMutation.isError || downloadMutation.isError) && (
+          <p className="text-sm text-destructive">
+            Failed to generate migration plan
+          </p>
+        )}
+
+        {plan && plan.warnings.length > 0 && (
+          <ul className="list-disc list-inside text-sm text-status-pending">
+            {plan.warnings.map((warning) => (
+              <li key={warning}>{warning}</li>
+            ))}
+          </ul>
+        )}
+
+        {plan && plan.sites.length === 0 && (
+          <p className="text-sm text-muted-foreground">No sites to migrate</p>
+        )}
+
+        {plan?.sites.map((site) => (
+          <SitePlanRow key={site.configFile + site.domains[0]} site={site} />
+        ))}
+      </CardContent>
+    </Card>
+  );
+}
+
+interface SitePlanRowProps {
+  readonly site: SitePlan;
+}
+
+function SitePlanRow({ site }: SitePlanRowProps) {
+  return (
+    <div className="space-y-2 p-3 border rounded-lg">
+      <div className="flex items-center justify-between gap-2">
+        <p className="font-medium">{site.domains.join(", ")}</p>
+        <Badge variant="outline">{site.certStrategy.strategy}</Badge>
+      </div>
+      <p className="text-xs text-muted-foreground">
+        {site.certStrategy.description}
+      </p>
+
+      {site.backends.map((backend) => (
+        <div key={backend.serviceName} className="text-sm">
+          <span className="font-mono">{backend.serviceName}</span>
+          {" :"}
+          {backend.port}
+          {" → "}
+          {backend.container ? (
+            <span>{backend.container.name}</span>
+          ) : (
+            <span className="text-status-failed">no container</span>
+          )}
+        </div>
+      ))}
+
+      {site.warnings.length > 0 && (
+        <ul className="list-disc list-inside text-xs text-status-pending">
+          {site.warnings.map((warning) => (
+            <li key={warning}>{warning}</li>
+          ))}
+        </ul>
+      )}
+    </div>
+  );
+}
@@ -0,0 +1,114 @@
+import { useState } from "react";
+import { History, Undo2 } from "lucide-react";
+import {
+  AlertDialog,
+  AlertDialogAction,
+  AlertDialogCancel,
+  AlertDialogContent,
+  AlertDialogDescription,
+  AlertDialogFooter,
+  AlertDialogHeader,
+  AlertDialogTitle,
+} from "@/components/ui/alert-dialog";
+import { Button } from "@/components/ui/button";
+import {
+  Card,
+  CardContent,
+  CardDescription,
+  CardHeader,
+  CardTitle,
+} from "@/components/ui/card";
+import type { MigrationChange } from "@/types";
+import { useRollbackMutation } from "../hooks/use-migration";
+
+interface MigrationRollbackCardProps {
+  readonly changes: readonly MigrationChange[];
+}
+
+function describeChange(change: MigrationChange): string {
+  if (change.type === "nginx_stopped") {
+    return "Nginx stopped and disabled";
+  }
+  return `Container ${change.containerName} migrated (${change.domain})`;
+}
+
+export function MigrationRollbackCard({
+  changes,
+}: MigrationRollbackCardProps) {
+  const [showDialog, setShowDialog] = useState(false);
+  const rollbackMutation = useRollbackMutation();
+  const errors = rollbackMutation.data?.errors ?? [];
+
+  return (
+    <Card>
+      <CardHeader>
+        <div className="flex items-center justify-between gap-2">
+          <div>
+            <CardTitle className="flex items-center gap-2">
+              <History className="h-5 w-5" />
+              Migration Changes ({changes.length})
+            </CardTitle>
+            <CardDescription>
+              Changes made to this host that can be rolled back
+            </CardDescription>
+          </div>
+          <Button
+            variant="destructive"
+            size="sm"
+            onClick={() => setShowDialog(true)}
+            disabled={rollbackMutation.isPending}
+          >
+            <Undo2 className="h-4 w-4 mr-2" />
+            {rollbackMutation.isPending ? "Rolling back..." : "Rollback"}
+          </Button>
+        </div>
+      </CardHeader>
+      <CardContent className="space-y-2">
+        {changes.map((change) => (
+          <div
+            key={`${change.type}-${change.at}`}
+            className="flex items-center justify-between text-sm"
+          >
+            <span>{describeChange(change)}</span>
+            <span className="text-muted-foreground">
+              {new Date(change.at).toLocaleString()}
+            </span>
+          </div>
+        ))}
+
+        {rollbackMutation.isError && (
+          <p className="text-sm text-destructive">Rollback failed</p>
+        )}
+        {errors.length > 0 && (
+          <ul className="list-disc list-inside text-sm text-destructive">
+            {errors.map((error) => (
+              <li key={error}>{error}</li>
+            ))}
+          </ul>
+        )}
+      </CardContent>
+
+      <AlertDialog open={showDialog} onOpenChange={setShowDialog}>
+        <AlertDialogContent>
+          <AlertDialogHeader>
+            <AlertDialogTitle>Roll back the migration?</AlertDialogTitle>
+            <AlertDialogDescription>
+              Migrated containers will be removed and the originals restored
+              from their backups, then nginx will be re-enabled. Stop Traefik
+              first so nginx can bind ports 80 and 443.
+            </AlertDialogDescription>
+          </AlertDialogHeader>
+          <AlertDialogFooter>
+            <AlertDialogCancel>Cancel</AlertDialogCancel>
+            <AlertDialogAction
+              onClick={() => rollbackMutation.mutate()}
+              className="bg-destructive text-destructive-foreground hover:bg-destructive/90"
+            >
+              Rollback
+            </AlertDialogAction>
+          </AlertDialogFooter>
+        </AlertDialogContent>
+      </AlertDialog>
+    </Card>
+  );
+}
@@ -43,6 +43,7 @@ interface NginxSiteCardProps {
   readonly onToggle: () => void;
   readonly certificates: readonly SSLCertificate[];
   readonly containers: readonly MigrationContainer[];
+  readonly serverId?: string;
 }
 
 export function NginxSiteCard({
@@ -52,13 +53,14 @@ export function NginxSiteCard({
   onToggle,
   certificates,
   containers,
+  serverId,
 }: NginxSiteCardProps) {
   const [traefikPreview, setTraefikPreview] = useState<string | null>(null);
   const [selectedContainer, setSelectedContainer] = useState<string>("");
-  const migrateMutation = useMigrateSiteMutation();
+  const migrateMutation = useMigrateSiteMutation(serverId);
 
   const loadTraefikPreview = async () => {
-    const preview = await api.migration.getTraefikConfig(index);
+    const preview = await api.migration.getTraefikConfig(index, serverId);
     setTraefikPreview(preview.yaml);
   };
 
@@ -2,57 +2,80 @@ import { useMutation, useQuery, useQueryClient } from "@tanstack/react-query";
 import { api } from "@/services/api";
 
 const QUERY_KEYS = {
-  status: ["migration-status"] as const,
+  status: (serverId?: string) => ["migration-status", serverId] as const,
 } as const;
 
-export function useMigrationStatus() {
+export function useMigrationStatus(serverId?: string) {
   return useQuery({
-    queryKey: QUERY_KEYS.status,
-    queryFn: () => api.migration.status(),
+    queryKey: QUERY_KEYS.status(serverId),
+    queryFn: () => api.migration.status(serverId),
     refetchOnWindowFocus: true,
   });
 }
 
-export function useBackupMutation() {
+export function useMigrationPlanMutation(serverId?: string) {
+  return useMutation({
+    mutationFn: () => api.migration.plan(serverId),
+  });
+}
+
+export function useDownloadMigrationPlanMutation(serverId?: string) {
+  return useMutation({
+    mutationFn: async () => {
+      const blob = await api.migration.planMarkdown(serverId);
+      const url = URL.createObjectURL(blob);
+      const link = document.createElement("a");
+      link.href = url;
+      const date = new Date().toISOString().slice(0, 10);
+      link.download = `migration-plan-${date}.md`;
+      link.click();
+      URL.revokeObjectURL(url);
+    },
+  });
+}
+
+export function useBackupMutation(serverId?: string) {
   const queryClient = useQueryClient();
 
   return useMutation({
-    mutationFn: () => api.migration.backup(),
+    mutationFn: () => api.migration.backup(serverId),
     onSuccess: () => {
-      queryClient.invalidateQueries({ queryKey: QUERY_KEYS.status });
+      queryClient.invalidateQueries({ queryKey: QUERY_KEYS.status(serverId) });
     },
   });
 }
 
-export function useStopContainersMutation() {
+export function useStopContainersMutation(serverId?: string) {
   const queryClient = useQueryClient();
 
   return useMutation({
-    mutationFn: (ids: readonly string[]) => api.migration.stopContainers(ids),
+    mutationFn: (ids: readonly string[]) =>
+      api.migration.stopContainers(ids, serverId),
     onSuccess: () => {
-      queryClient.invalidateQueries({ queryKey: QUERY_KEYS.status });
+      queryClient.invalidateQueries({ queryKey: QUERY_KEYS.status(serverId) });
     },
   });
 }
 
-export function useStartContainersMutation() {
+export function useStartContainersMutation(serverId?: string) {
   const queryClient = useQueryClient();
 
   return useMutation({
-    mutationFn: (ids: readonly string[]) => api.migration.startContainers(ids),
+    mutationFn: (ids: readonly string[]) =>
+      api.migration.startContainers(ids, serverId),
     onSuccess: () => {
-      queryClient.invalidateQueries({ queryKey: QUERY_KEYS.status });
+      queryClient.invalidateQueries({ queryKey: QUERY_KEYS.status(serverId) });
     },
   });
 }
 
-export function useStopNginxMutation() {
+export function useStopNginxMutation(serverId?: string) {
   const queryClient = useQueryClient();
 
   return useMutation({
-    mutationFn: () => api.migration.stopNginx(),
+    mutationFn: () => api.migration.stopNginx(serverId),
     onSuccess: () => {
-      queryClient.invalidateQueries({ queryKey: QUERY_KEYS.status });
+      queryClient.invalidateQueries({ queryKey: QUERY_KEYS.status(serverId) });
     },
   });
 }
@@ -62,14 +85,25 @@ interface MigrateSiteParams {
   readonly containerId: string;
 }
 
-export function useMigrateSiteMutation() {
+export function useMigrateSiteMutation(serverId?: string) {
   const queryClient = useQueryClient();
 
   return useMutation({
     mutationFn: ({ siteIndex, containerId }: MigrateSiteParams) =>
-      api.migration.migrateSite(siteIndex, containerId),
+      api.migration.migrateSite(siteIndex, containerId, serverId),
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: QUERY_KEYS.status(serverId) });
+    },
+  });
+}
+
+export function useRollbackMutation() {
+  const queryClient = useQueryClient();
+
+  return useMutation({
+    mutationFn: () => api.migration.rollback(),
     onSuccess: () => {
-      queryClient.invalidateQueries({ queryKey: QUERY_KEYS.status });
+      queryClient.invalidateQueries({ queryKey: QUERY_KEYS.status() });
     },
   });
 }
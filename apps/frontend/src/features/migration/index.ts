@@ -1,10 +1,15 @@
 export { ContainerRow } from "./components/container-row";
+export { MigrationPlanCard } from "./components/migration-plan-card";
+export { MigrationRollbackCard } from "./components/migration-rollback-card";
 export { NginxSiteCard } from "./components/nginx-site-card";
 export { SSLCertificateRow } from "./components/ssl-certificate-row";
 export {
   useBackupMutation,
+  useDownloadMigrationPlanMutation,
   useMigrateSiteMutation,
+  useMigrationPlanMutation,
   useMigrationStatus,
+  useRollbackMutation,
   useStartContainersMutation,
   useStopContainersMutation,
   useStopNginxMutation,
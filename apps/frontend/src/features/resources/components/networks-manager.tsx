@@ -234,6 +234,12 @@ export function NetworksManager({
                       <span>{network.driver}</span>
                       <span>•</span>
                       <span>{network.scope}</span>
+                      {network.subnet && (
+                        <>
+                          <span>•</span>
+                          <span className="font-mono">{network.subnet}</span>
+                        </>
+                      )}
                       {networkContainers.length > 0 && (
                         <>
                           <span>•</span>
@@ -249,7 +255,12 @@ export function NetworksManager({
                                 <p className="font-medium mb-1">Containers:</p>
                                 <ul className="text-xs">
                                   {networkContainers.map((c) => (
-                                    <li key={c}>{c}</li>
+                                    <li key={c.id}>
+                                      {c.name}{" "}
+                                      <span className="font-mono text-muted-foreground">
+                                        {c.ipv4Address || "-"}
+                                      </span>
+                                    </li>
                                   ))}
                                 </ul>
                               </TooltipContent>
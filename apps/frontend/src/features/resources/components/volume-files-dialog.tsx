@@ -0,0 +1,185 @@
+import { useState } from "react";
+import {
+  ArrowUp,
+  Download,
+  File,
+  FileSymlink,
+  Folder,
+  Loader2,
+} from "lucide-react";
+import { Button } from "@/components/ui/button";
+import {
+  Dialog,
+  DialogContent,
+  DialogDescription,
+  DialogHeader,
+  DialogTitle,
+} from "@/components/ui/dialog";
+import { formatBytes } from "@/lib/format";
+import type { ContainerFile } from "@/types";
+import { useDownloadVolumeFile, useVolumeFiles } from "../hooks/use-volumes";
+
+interface VolumeFilesDialogProps {
+  readonly volumeName: string | null;
+  readonly serverId?: string;
+  readonly onOpenChange: (open: boolean) => void;
+}
+
+const FILE_ICONS = {
+  dir: Folder,
+  symlink: FileSymlink,
+  file: File,
+  other: File,
+} as const;
+
+function joinPath(dir: string, name: string): string {
+  return dir === "/" ? `/${name}` : `${dir}/${name}`;
+}
+
+function parentPath(dir: string): string {
+  const parent = dir.slice(0, dir.lastIndexOf("/"));
+  return parent === "" ? "/" : parent;
+}
+
+export function VolumeFilesDialog({
+  volumeName,
+  serverId,
+  onOpenChange,
+}: VolumeFilesDialogProps) {
+  const [path, setPath] = useState("/");
+  const [error, setError] = useState<string | null>(null);
+  const { data, isLoading, isError } = useVolumeFiles(
+    volumeName ?? undefined,
+    path,
+    serverId,
+  );
+  const downloadFile = useDownloadVolumeFile();
+
+  const handleOpenChange = (open: boolean) => {
+    if (!open) {
+      setPath("/");
+      setError(null);
+    }
+    onOpenChange(open);
+  };
+
+  const handleOpen = (file: ContainerFile) => {
+    if (!volumeName) return;
+    const target = joinPath(path, file.name);
+    setError(null);
+    if (file.type === "dir") {
+      setPath(target);
+      return;
+    }
+    downloadFile.mutate(
+      { name: volumeName, path: target, serverId },
+      { onError: (err) => setError(err.message) },
+    );
+  };
+
+  return (
+    <Dialog open={volumeName !== null} onOpenChange={handleOpenChange}>
+      <DialogContent className="max-w-3xl h-[70vh] flex flex-col">
+        <DialogHeader>
+          <DialogTitle>Volume - {volumeName}</DialogTitle>
+          <DialogDescription>
+            Read-only view through a temporary helper container. Downloads are
+            limited to 50 MB and recorded in the audit log
+          </DialogDescription>
+        </DialogHeader>
+
+        <div className="flex items-center gap-2">
+          <Button
+            variant="outline"
+            size="icon"
+            className="h-8 w-8"
+            onClick={() => setPath(parentPath(path))}
+            disabled={path === "/"}
+            title="Parent directory"
+          >
+            <ArrowUp className="h-4 w-4" />
+          </Button>
+          <code className="flex-1 truncate rounded bg-muted px-2 py-1 text-sm">
+            {path}
+          </code>
+        </div>
+
+        {error && <p className="text-sm text-destructive">{error}</p>}
+
+        <div className="flex-1 min-h-0 overflow-auto rounded-md border">
+          {isLoading && (
+            <div className="flex h-full items-center justify-center">
+              <Loader2 className="h-6 w-6 animate-spin text-muted-foreground" />
+            </div>
+          )}
+          {isError && (
+            <p className="p-4 text-sm text-destructive">
+              Failed to list {path}
+            </p>
+          )}
+          {data?.files.length === 0 && (
+            <p className="p-4 text-sm text-muted-foreground">Empty directory</p>
+          )}
+          {data && data.files.length > 0 && (
+            <table className="w-full text-sm">
+              <tbody>
+                {data.files.map((file) => {
+                  const Icon = FILE_ICONS[file.type];
+                  const canOpen = file.type === "dir" || file.type === "file";
+                  return (
+                    <tr
+                      key={file.name}
+                      className="border-b border-border hover:bg-muted/50"
+                    >
+                      <td className="py-2 px-3">
+                        <button
+                          type="button"
+                          className="flex items-center gap-2 text-left disabled:cursor-default"
+                          onClick={() => handleOpen(file)}
+                          disabled={!canOpen}
+                        >
+                          <Icon
+                            className="h-4 w-4 shrink-0 text-muted-foreground"
+                          />
+                          <span className="font-mono">{file.name}</span>
+                          {file.linkTarget && (
+                            <span className="text-xs text-muted-foreground">
+                              → {file.linkTarget}
+                            </span>
+                          )}
+                        </button>
+                      </td>
+                      <td className="py-2 px-3 font-mono text-xs text-muted-foreground hidden md:table-cell">
+                        {file.mode}
+                      </td>
+                      <td className="py-2 px-3 text-xs text-muted-foreground whitespace-nowrap">
+                        {file.type === "file" ? formatBytes(file.size) : ""}
+                      </td>
+                      <td className="py-2 px-3 text-xs text-muted-foreground whitespace-nowrap hidden md:table-cell">
+                        {file.modified}
+                      </td>
+                      <td className="py-2 px-3 text-right">
+                        {file.type === "file" && (
+                          <Button
+                            variant="ghost"
+                            size="icon"
+                            className="h-7 w-7"
+                            onClick={() => handleOpen(file)}
+                            disabled={downloadFile.isPending}
+                            title="Download"
+                          >
+                            <Download className="h-4 w-4" />
+                          </Button>
+                        )}
+                      </td>
+                    </tr>
+                  );
+                })}
+              </tbody>
+            </table>
+          )}
+        </div>
+      </DialogContent>
+    </Dialog>
+  );
+}
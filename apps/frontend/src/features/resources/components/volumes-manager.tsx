@@ -3,6 +3,7 @@ import {
   ArrowRight,
   Calendar,
   FolderOpen,
+  FolderSearch,
   HardDrive,
   Link,
   Loader2,
@@ -45,6 +46,7 @@ import {
   useRemoveVolume,
   useVolumes,
 } from "../hooks/use-volumes";
+import { VolumeFilesDialog } from "./volume-files-dialog";
 
 interface VolumesManagerProps {
   readonly containerVolumes?: readonly string[];
@@ -65,6 +67,7 @@ export function VolumesManager({
   const [newVolumeName, setNewVolumeName] = useState("");
   const [showCreateDialog, setShowCreateDialog] = useState(false);
   const [volumeToDelete, setVolumeToDelete] = useState<string | null>(null);
+  const [volumeToBrowse, setVolumeToBrowse] = useState<string | null>(null);
 
   const isScoped = containerVolumes !== undefined;
   const scopedVolumes = isScoped
@@ -257,6 +260,20 @@ export function VolumesManager({
                   </div>
                 </div>
               </div>
+              <TooltipProvider>
+                <Tooltip>
+                  <TooltipTrigger asChild>
+                    <Button
+                      variant="ghost"
+                      size="sm"
+                      onClick={() => setVolumeToBrowse(volume.name)}
+                    >
+                      <FolderSearch className="h-4 w-4" />
+                    </Button>
+                  </TooltipTrigger>
+                  <TooltipContent>Browse files</TooltipContent>
+                </Tooltip>
+              </TooltipProvider>
               <TooltipProvider>
                 <Tooltip>
                   <TooltipTrigger asChild>
@@ -320,6 +337,12 @@ export function VolumesManager({
         </DialogContent>
       </Dialog>
 
+      <VolumeFilesDialog
+        volumeName={volumeToBrowse}
+        serverId={serverId}
+        onOpenChange={(open) => !open && setVolumeToBrowse(null)}
+      />
+
       <AlertDialog
         open={!!volumeToDelete}
         onOpenChange={(open) => !open && setVolumeToDelete(null)}
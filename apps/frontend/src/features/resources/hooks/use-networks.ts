@@ -1,6 +1,7 @@
 import { useMutation, useQuery, useQueryClient } from "@tanstack/react-query";
 import { STALE_TIMES } from "@/constants/query-config";
 import { api } from "@/services/api";
+import type { DockerNetworkContainer } from "@/types";
 
 export interface DockerNetwork {
   readonly name: string;
@@ -8,7 +9,9 @@ export interface DockerNetwork {
   readonly driver: string;
   readonly scope: string;
   readonly internal: boolean;
-  readonly containers: readonly string[];
+  readonly subnet: string;
+  readonly gateway: string;
+  readonly containers: readonly DockerNetworkContainer[];
 }
 
 export function useNetworks(serverId?: string) {
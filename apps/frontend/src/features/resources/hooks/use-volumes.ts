@@ -39,3 +39,35 @@ export function useRemoveVolume() {
     },
   });
 }
+
+export function useVolumeFiles(
+  name: string | undefined,
+  path: string,
+  serverId?: string,
+) {
+  return useQuery({
+    queryKey: ["volumes", name, "files", path, serverId],
+    queryFn: () => api.volumes.files(name!, path, serverId),
+    enabled: Boolean(name),
+  });
+}
+
+interface VolumeFileInput {
+  readonly name: string;
+  readonly path: string;
+  readonly serverId?: string;
+}
+
+export function useDownloadVolumeFile() {
+  return useMutation({
+    mutationFn: async ({ name, path, serverId }: VolumeFileInput) => {
+      const blob = await api.volumes.downloadFile(name, path, serverId);
+      const url = URL.createObjectURL(blob);
+      const link = document.createElement("a");
+      link.href = url;
+      link.download = path.split("/").pop() ?? "download";
+      link.click();
+      URL.revokeObjectURL(url);
+    },
+  });
+}
@@ -0,0 +1,217 @@
+import { useState } from "react";
+import { Link } from "react-router-dom";
+import { Cloud } from "lucide-react";
+import { Button } from "@/components/ui/button";
+import {
+  Dialog,
+  DialogContent,
+  DialogDescription,
+  DialogFooter,
+  DialogHeader,
+  DialogTitle,
+  DialogTrigger,
+} from "@/components/ui/dialog";
+import { Input } from "@/components/ui/input";
+import {
+  Select,
+  SelectContent,
+  SelectItem,
+  SelectTrigger,
+  SelectValue,
+} from "@/components/ui/select";
+import { cloudProviderInfo } from "@/constants/cloud-providers";
+import { ROUTES } from "@/constants/routes";
+import type { CloudProvider } from "@/types";
+import {
+  useCloudCredentials,
+  useCreateCloudServer,
+} from "../hooks/use-servers";
+
+export function CreateCloudServerDialog() {
+  const [open, setOpen] = useState(false);
+  const [provider, setProvider] = useState<CloudProvider | "">("");
+  const [name, setName] = useState("");
+  const [size, setSize] = useState("");
+  const [region, setRegion] = useState("");
+  const [image, setImage] = useState("");
+  const [acmeEmail, setAcmeEmail] = useState("");
+
+  const { data: credentials } = useCloudCredentials();
+  const createServer = useCreateCloudServer();
+
+  const connected = credentials ?? [];
+  const selected = provider || connected[0]?.provider;
+  const defaults = selected ? cloudProviderInfo(selected).defaults : null;
+
+  const reset = () => {
+    setName("");
+    setSize("");
+    setRegion("");
+    setImage("");
+    setAcmeEmail("");
+    createServer.reset();
+  };
+
+  const handleSubmit = async (e: React.FormEvent) => {
+    e.preventDefault();
+    if (!selected) {
+      return;
+    }
+    try {
+      await createServer.mutateAsync({
+        provider: selected,
+        name,
+        ...(size ? { size } : {}),
+        ...(region ? { region } : {}),
+        ...(image ? { image } : {}),
+        ...(acmeEmail ? { acmeEmail } : {}),
+      });
+      setOpen(false);
+      reset();
+    } catch (error) {
+      console.error("Failed to create cloud server:", error);
+    }
+  };
+
+  return (
+    <Dialog
+      open={open}
+      onOpenChange={(next) => {
+        setOpen(next);
+        if (!next) {
+          reset();
+        }
+      }}
+    >
+      <DialogTrigger asChild>
+        <Button size="sm" variant="outline">
+          <Cloud className="h-4 w-4 mr-2" aria-hidden />
+          Create in Cloud
+        </Button>
+      </DialogTrigger>
+      <DialogContent className="sm:max-w-[425px]">
+        <form onSubmit={handleSubmit}>
+          <DialogHeader>
+            <DialogTitle>Create Cloud Server</DialogTitle>
+            <DialogDescription>
+              Creates a VPS with a managed SSH key, waits for it to boot and
+              provisions it automatically.
+            </DialogDescription>
+          </DialogHeader>
+
+          {connected.length === 0 ? (
+            <p className="py-4 text-sm text-muted-foreground">
+              No cloud provider connected yet. Add credentials in{" "}
+              <Link to={ROUTES.SETTINGS} className="text-primary hover:underline">
+                Settings
+              </Link>
+              .
+            </p>
+          ) : (
+            <div className="grid gap-4 py-4">
+              <div className="grid gap-2">
+                <label
+                  htmlFor="cloud-provider"
+                  className="text-sm font-medium leading-none"
+                >
+                  Provider
+                </label>
+                <Select
+                  value={selected}
+                  onValueChange={(v) => setProvider(v as CloudProvider)}
+                >
+                  <SelectTrigger id="cloud-provider">
+                    <SelectValue />
+                  </SelectTrigger>
+                  <SelectContent>
+                    {connected.map((c) => (
+                      <SelectItem key={c.provider} value={c.provider}>
+                        {cloudProviderInfo(c.provider).label}
+                      </SelectItem>
+                    ))}
+                  </SelectContent>
+                </Select>
+              </div>
+              <div className="grid gap-2">
+                <label
+                  htmlFor="cloud-name"
+                  className="text-sm font-medium leading-none"
+                >
+                  Name
+                </label>
+                <Input
+                  id="cloud-name"
+                  value={name}
+                  onChange={(e) => setName(e.target.value)}
+                  placeholder="web-1"
+                  required
+                />
+              </div>
+              <div className="grid grid-cols-3 gap-2">
+                <Input
+                  aria-label="Size"
+                  value={size}
+                  onChange={(e) => setSize(e.target.value)}
+                  placeholder={defaults?.size}
+                />
+                <Input
+                  aria-label="Region"
+                  value={region}
+                  onChange={(e) => setRegion(e.target.value)}
+                  placeholder={defaults?.region}
+                />
+                <Input
+                  aria-label="Image"
+                  value={image}
+                  onChange={(e) => setImage(e.target.value)}
+                  placeholder={defaults?.image}
+                />
+              </div>
+              <p className="text-xs text-muted-foreground">
+                Size, region and image use the provider defaults when empty.
+              </p>
+              <div className="grid gap-2">
+                <label
+                  htmlFor="cloud-acme-email"
+                  className="text-sm font-medium leading-none"
+                >
+                  ACME Email (Let&apos;s Encrypt)
+                </label>
+                <Input
+                  id="cloud-acme-email"
+                  type="email"
+                  value={acmeEmail}
+                  onChange={(e) => setAcmeEmail(e.target.value)}
+                  placeholder="admin@example.com"
+                />
+              </div>
+              {createServer.error != null && (
+                <p className="text-sm text-destructive">
+                  {createServer.error instanceof Error
+                    ? createServer.error.message
+                    : "Failed to create server"}
+                </p>
+              )}
+            </div>
+          )}
+
+          <DialogFooter>
+            <Button
+              type="button"
+              variant="outline"
+              onClick={() => setOpen(false)}
+            >
+              Cancel
+            </Button>
+            <Button
+              type="submit"
+              disabled={createServer.isPending || !selected || !name}
+            >
+              {createServer.isPending ? "Creating..." : "Create Server"}
+            </Button>
+          </DialogFooter>
+        </form>
+      </DialogContent>
+    </Dialog>
+  );
+}
@@ -0,0 +1,152 @@
+import { useState } from "react";
+import { Upload } from "lucide-react";
+import { Button } from "@/components/ui/button";
+import { Checkbox } from "@/components/ui/checkbox";
+import {
+  Dialog,
+  DialogContent,
+  DialogDescription,
+  DialogFooter,
+  DialogHeader,
+  DialogTitle,
+  DialogTrigger,
+} from "@/components/ui/dialog";
+import type { BulkCreateServersResponse } from "@/services/api/servers";
+import { useBulkCreateServers, useProvisionBatch } from "../hooks/use-servers";
+
+const CSV_PLACEHOLDER = `name,host,sshPort,sshUser,sshPassword,acmeEmail
+web-1,203.0.113.10,22,root,secret,admin@example.com
+web-2,203.0.113.11,22,root,secret,admin@example.com`;
+
+export function ImportServersDialog() {
+  const [open, setOpen] = useState(false);
+  const [csv, setCsv] = useState("");
+  const [provision, setProvision] = useState(true);
+  const [result, setResult] = useState<BulkCreateServersResponse | null>(
+    null,
+  );
+
+  const bulkCreate = useBulkCreateServers();
+  const batchQuery = useProvisionBatch(result?.batch?.id);
+  const batch = batchQuery.data ?? result?.batch;
+
+  const handleFile = async (file: File | undefined) => {
+    if (file) {
+      setCsv(await file.text());
+    }
+  };
+
+  const handleSubmit = async (e: React.FormEvent) => {
+    e.preventDefault();
+    try {
+      setResult(await bulkCreate.mutateAsync({ csv, provision }));
+    } catch (error) {
+      console.error("Failed to import servers:", error);
+    }
+  };
+
+  const handleOpenChange = (next: boolean) => {
+    setOpen(next);
+    if (!next) {
+      setCsv("");
+      setResult(null);
+      bulkCreate.reset();
+    }
+  };
+
+  return (
+    <Dialog open={open} onOpenChange={handleOpenChange}>
+      <DialogTrigger asChild>
+        <Button size="sm" variant="outline">
+          <Upload className="h-4 w-4 mr-2" aria-hidden />
+          Import
+        </Button>
+      </DialogTrigger>
+      <DialogContent className="sm:max-w-[560px]">
+        <form onSubmit={handleSubmit}>
+          <DialogHeader>
+            <DialogTitle>Import Servers</DialogTitle>
+            <DialogDescription>
+              Paste or upload a CSV with one server per row. Columns: name,
+              host, sshPort, sshUser, sshKey, sshPassword, acmeEmail.
+            </DialogDescription>
+          </DialogHeader>
+
+          <div className="grid gap-4 py-4">
+            <textarea
+              value={csv}
+              onChange={(e) => setCsv(e.target.value)}
+              placeholder={CSV_PLACEHOLDER}
+              rows={8}
+              className="flex min-h-[120px] w-full rounded-md border border-input bg-background px-3 py-2 font-mono text-xs ring-offset-background placeholder:text-muted-foreground focus-visible:outline-none focus-visible:ring-2 focus-visible:ring-ring focus-visible:ring-offset-2"
+            />
+            <input
+              type="file"
+              accept=".csv,text/csv"
+              onChange={(e) => void handleFile(e.target.files?.[0])}
+              className="text-xs"
+            />
+            <label className="flex items-center gap-2 text-sm">
+              <Checkbox
+                checked={provision}
+                onCheckedChange={(checked) => setProvision(checked === true)}
+              />
+              Provision imported servers
+            </label>
+
+            {bulkCreate.error != null && (
+              <p className="text-sm text-destructive">
+                {bulkCreate.error instanceof Error
+                  ? bulkCreate.error.message
+                  : "Failed to import servers"}
+              </p>
+            )}
+
+            {result != null && (
+              <div className="space-y-2 text-xs">
+                <p>
+                  {result.created} added, {result.failed} rejected
+                </p>
+                {result.results
+                  .filter((row) => row.error)
+                  .map((row) => (
+                    <p key={row.index} className="text-destructive">
+                      Row {row.index + 1} ({row.name || row.host}): {row.error}
+                    </p>
+                  ))}
+              </div>
+            )}
+
+            {batch != null && (
+              <div className="space-y-1 rounded-md border px-3 py-2 text-xs">
+                <p>
+                  Provisioning: {batch.completed + batch.failed}/{batch.total}{" "}
+                  done, {batch.failed} failed
+                  {batch.status === "completed" ? "" : "..."}
+                </p>
+                {batch.failures.map((failure) => (
+                  <p key={failure.serverId} className="text-destructive">
+                    {failure.name} ({failure.host}): {failure.error}
+                  </p>
+                ))}
+              </div>
+            )}
+          </div>
+
+          <DialogFooter>
+            <Button
+              type="button"
+              variant="outline"
+              onClick={() => handleOpenChange(false)}
+            >
+              Close
+            </Button>
+            <Button type="submit" disabled={bulkCreate.isPending || !csv}>
+              {bulkCreate.isPending ? "Importing..." : "Import"}
+            </Button>
+          </DialogFooter>
+        </form>
+      </DialogContent>
+    </Dialog>
+  );
+}
@@ -0,0 +1,120 @@
+import { useState } from "react";
+import { Checkbox } from "@/components/ui/checkbox";
+import { Input } from "@/components/ui/input";
+import type { DiscoveredApp, ImportAppInput } from "@/types";
+
+interface Selection {
+  readonly repositoryUrl: string;
+  readonly branch: string;
+}
+
+export function useImportSelection() {
+  const [selected, setSelected] = useState<Record<string, Selection>>({});
+
+  const toggle = (name: string, checked: boolean) => {
+    setSelected((prev) => {
+      const next = { ...prev };
+      if (checked) {
+        next[name] = { repositoryUrl: "", branch: "main" };
+      } else {
+        delete next[name];
+      }
+      return next;
+    });
+  };
+
+  const update = (name: string, changes: Partial<Selection>) => {
+    setSelected((prev) => ({
+      ...prev,
+      [name]: { ...prev[name], ...changes },
+    }));
+  };
+
+  const entries = Object.entries(selected);
+  const isValid =
+    entries.length > 0 &&
+    entries.every(([, s]) => s.repositoryUrl.trim() && s.branch.trim());
+
+  const toInputs = (): ImportAppInput[] =>
+    entries.map(([source, s]) => ({
+      source,
+      repositoryUrl: s.repositoryUrl.trim(),
+      branch: s.branch.trim(),
+    }));
+
+  return {
+    selected,
+    count: entries.length,
+    isValid,
+    toggle,
+    update,
+    toInputs,
+    clear: () => setSelected({}),
+  };
+}
+
+interface ImportAppPickerProps {
+  readonly apps: readonly DiscoveredApp[];
+  readonly selection: ReturnType<typeof useImportSelection>;
+  readonly renderDetail?: (app: DiscoveredApp) => React.ReactNode;
+}
+
+export function ImportAppPicker({
+  apps,
+  selection,
+  renderDetail,
+}: ImportAppPickerProps) {
+  return (
+    <>
+      {apps.map((app) => {
+        const sel = selection.selected[app.name];
+        return (
+          <div
+            key={app.name}
+            className="rounded-md border px-3 py-2 text-sm space-y-2"
+          >
+            <div className="flex items-center gap-2">
+              <Checkbox
+                checked={sel != null}
+                onCheckedChange={(c) => selection.toggle(app.name, c === true)}
+              />
+              <span className="font-mono font-medium">{app.name}</span>
+              <span className="text-muted-foreground">
+                · port {app.port} · {app.envVarKeys.length} vars ·{" "}
+                {app.volumes.length} volumes
+              </span>
+            </div>
+            {renderDetail?.(app)}
+            {app.domains.length > 0 && (
+              <p className="text-xs text-muted-foreground font-mono">
+                {app.domains.join(", ")}
+              </p>
+            )}
+            {sel && (
+              <div className="flex flex-col md:flex-row gap-2">
+                <Input
+                  placeholder="https://github.com/owner/repo"
+                  value={sel.repositoryUrl}
+                  onChange={(e) =>
+                    selection.update(app.name, {
+                      repositoryUrl: e.target.value,
+                    })
+                  }
+                  className="flex-1"
+                />
+                <Input
+                  placeholder="main"
+                  value={sel.branch}
+                  onChange={(e) =>
+                    selection.update(app.name, { branch: e.target.value })
+                  }
+                  className="md:w-32"
+                />
+              </div>
+            )}
+          </div>
+        );
+      })}
+    </>
+  );
+}
@@ -0,0 +1,32 @@
+import { CheckCircle2, XCircle } from "lucide-react";
+import { CodeBlock } from "@/components/ui/code-block";
+import type { ImportAppResult } from "@/types";
+
+interface ImportResultsProps {
+  readonly results: readonly ImportAppResult[];
+}
+
+export function ImportResults({ results }: ImportResultsProps) {
+  return (
+    <>
+      {results.map((result) => (
+        <div key={result.source} className="space-y-2">
+          <div className="flex items-center gap-2 text-sm">
+            {result.error ? (
+              <XCircle className="h-4 w-4 text-destructive" />
+            ) : (
+              <CheckCircle2 className="h-4 w-4 text-green-500" />
+            )}
+            <span className="font-mono">{result.source}</span>
+            {result.error && (
+              <span className="text-destructive">{result.error}</span>
+            )}
+          </div>
+          {result.config && (
+            <CodeBlock>{JSON.stringify(result.config, null, 2)}</CodeBlock>
+          )}
+        </div>
+      ))}
+    </>
+  );
+}
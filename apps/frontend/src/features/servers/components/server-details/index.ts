@@ -2,6 +2,14 @@ export { AgentVersionCard } from "./agent-version-card";
 export { MetricCard } from "./metric-card";
 export { ResourceUsageSection } from "./resource-usage-section";
 export { ServerAppsSection } from "./server-apps-section";
+export { ServerCertificatesSection } from "./server-certificates-section";
+export { ServerComposeSection } from "./server-compose-section";
+export { ServerConsoleSection } from "./server-console-section";
+export { ServerEntrypointsSection } from "./server-entrypoints-section";
+export { ServerImportSection } from "./server-import-section";
 export { ServerMaintenanceSection } from "./server-maintenance-section";
+export { ServerMeshSection } from "./server-mesh-section";
 export { ServerSettingsSection } from "./server-settings-section";
+export { ServerTasksSection } from "./server-tasks-section";
+export { ServerTunnelSection } from "./server-tunnel-section";
 export { SystemInfoBar } from "./system-info-bar";
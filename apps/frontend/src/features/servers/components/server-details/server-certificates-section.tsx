@@ -0,0 +1,191 @@
+import { useMutation, useQuery, useQueryClient } from "@tanstack/react-query";
+import { Loader2, RefreshCw, ShieldCheck, Trash2 } from "lucide-react";
+import { Badge } from "@/components/ui/badge";
+import { Button } from "@/components/ui/button";
+import { Card, CardContent, CardHeader, CardTitle } from "@/components/ui/card";
+import { Checkbox } from "@/components/ui/checkbox";
+import { Label } from "@/components/ui/label";
+import { SERVERS_QUERY_KEY } from "@/features/servers/hooks/use-servers";
+import { formatDateOnly } from "@/lib/format";
+import { api } from "@/services/api";
+import type { ServerCertificate } from "@/types";
+
+interface ServerCertificatesSectionProps {
+  readonly serverId: string;
+}
+
+function ExpiryBadge({ cert }: { readonly cert: ServerCertificate }) {
+  if (cert.expiresAt === undefined || cert.daysRemaining === undefined) {
+    return <Badge variant="secondary">unknown</Badge>;
+  }
+  const variant = cert.daysRemaining < 14 ? "destructive" : "secondary";
+  return (
+    <Badge variant={variant}>
+      {formatDateOnly(cert.expiresAt)} · {cert.daysRemaining}d
+    </Badge>
+  );
+}
+
+export function ServerCertificatesSection({
+  serverId,
+}: ServerCertificatesSectionProps) {
+  const queryClient = useQueryClient();
+
+  const certificatesQuery = useQuery({
+    queryKey: ["server-certificates", serverId],
+    queryFn: () => api.certificates.listForServer(serverId),
+  });
+
+  const invalidate = async () => {
+    await queryClient.invalidateQueries({
+      queryKey: ["server-certificates", serverId],
+    });
+  };
+
+  const renewMutation = useMutation({
+    mutationFn: (domain: string) => api.certificates.renew(serverId, domain),
+    onSuccess: invalidate,
+  });
+
+  const removeMutation = useMutation({
+    mutationFn: (domain: string) => api.certificates.remove(serverId, domain),
+    onSuccess: invalidate,
+  });
+
+  const pruneMutation = useMutation({
+    mutationFn: () => api.certificates.pruneStale(serverId),
+    onSuccess: invalidate,
+  });
+
+  const stagingMutation = useMutation({
+    mutationFn: (enabled: boolean) =>
+      api.certificates.setStaging(serverId, enabled),
+    onSuccess: async () => {
+      await invalidate();
+      await queryClient.invalidateQueries({ queryKey: SERVERS_QUERY_KEY });
+    },
+  });
+
+  const isPending =
+    renewMutation.isPending ||
+    removeMutation.isPending ||
+    pruneMutation.isPending;
+  const error =
+    renewMutation.error ??
+    removeMutation.error ??
+    pruneMutation.error ??
+    stagingMutation.error;
+  const certificates = certificatesQuery.data?.certificates ?? [];
+  const hasStale = certificates.some((cert) => cert.stale);
+
+  return (
+    <Card>
+      <CardHeader className="pb-3">
+        <div className="flex items-center justify-between">
+          <CardTitle className="text-base">TLS Certificates</CardTitle>
+          <Button
+            variant="outline"
+            size="sm"
+            disabled={!hasStale || isPending}
+            onClick={() => pruneMutation.mutate()}
+          >
+            {pruneMutation.isPending ? (
+              <Loader2 className="h-4 w-4 mr-2 animate-spin" />
+            ) : (
+              <Trash2 className="h-4 w-4 mr-2" />
+            )}
+            Delete Stale
+          </Button>
+        </div>
+      </CardHeader>
+      <CardContent className="space-y-3">
+        <div className="flex items-start gap-2">
+          <Checkbox
+            id="acme-staging"
+            checked={certificatesQuery.data?.acmeStaging ?? false}
+            disabled={!certificatesQuery.data || stagingMutation.isPending}
+            onCheckedChange={(c) => stagingMutation.mutate(c === true)}
+          />
+          <div className="grid gap-1">
+            <Label htmlFor="acme-staging">Use Let&apos;s Encrypt staging</Label>
+            <p className="text-xs text-muted-foreground">
+              Staging certificates are not trusted by browsers. Re-provision
+              the server to apply this change.
+            </p>
+          </div>
+        </div>
+
+        {certificatesQuery.isLoading && (
+          <div className="flex justify-center py-4">
+            <Loader2 className="h-5 w-5 animate-spin text-muted-foreground" />
+          </div>
+        )}
+
+        {certificatesQuery.isError && (
+          <p className="text-sm text-muted-foreground">
+            Failed to read certificates. The server may be unreachable.
+          </p>
+        )}
+
+        {certificatesQuery.isSuccess && certificates.length === 0 && (
+          <p className="text-sm text-muted-foreground">
+            No certificates issued yet.
+          </p>
+        )}
+
+        {certificates.length > 0 && (
+          <div className="divide-y rounded-md border">
+            {certificates.map((cert) => (
+              <div
+                key={`${cert.resolver}-${cert.staging}-${cert.domain}`}
+                className="flex items-center justify-between gap-2 p-2"
+              >
+                <div className="flex min-w-0 items-center gap-2">
+                  <ShieldCheck
+                    className="h-4 w-4 shrink-0 text-muted-foreground"
+                  />
+                  <span className="truncate font-mono text-sm">
+                    {cert.domain}
+                  </span>
+                  {cert.staging && <Badge variant="outline">staging</Badge>}
+                  {cert.stale && <Badge variant="outline">stale</Badge>}
+                  <ExpiryBadge cert={cert} />
+                </div>
+                <div className="flex shrink-0 gap-1">
+                  {!cert.stale && (
+                    <Button
+                      variant="ghost"
+                      size="icon"
+                      title="Renew"
+                      disabled={isPending}
+                      onClick={() => renewMutation.mutate(cert.domain)}
+                    >
+                      <RefreshCw className="h-4 w-4" />
+                    </Button>
+                  )}
+                  <Button
+                    variant="ghost"
+                    size="icon"
+                    title="Delete"
+                    disabled={isPending}
+                    onClick={() => removeMutation.mutate(cert.domain)}
+                  >
+                    <Trash2 className="h-4 w-4" />
+                  </Button>
+                </div>
+              </div>
+            ))}
+          </div>
+        )}
+
+        {error && (
+          <p className="text-sm text-destructive">
+            {error instanceof Error
+              ? error.message
+              : "Failed to update certificates"}
+          </p>
+        )}
+      </CardContent>
+    </Card>
+  );
+}
@@ -0,0 +1,143 @@
+import { useState } from "react";
+import { useMutation, useQueryClient } from "@tanstack/react-query";
+import { Download, Loader2, Search } from "lucide-react";
+import { Button } from "@/components/ui/button";
+import { Card, CardContent, CardHeader, CardTitle } from "@/components/ui/card";
+import { Input } from "@/components/ui/input";
+import { api } from "@/services/api";
+import type { ComposeService, ImportAppInput } from "@/types";
+import { ImportAppPicker, useImportSelection } from "./import-app-picker";
+import { ImportResults } from "./import-results";
+
+interface ServerComposeSectionProps {
+  readonly serverId: string;
+}
+
+function serviceSource(service: ComposeService): string {
+  if (service.buildContext) {
+    return `build ${service.buildContext}`;
+  }
+  return service.image ?? "";
+}
+
+export function ServerComposeSection({ serverId }: ServerComposeSectionProps) {
+  const queryClient = useQueryClient();
+  const [path, setPath] = useState("");
+  const selection = useImportSelection();
+
+  const readMutation = useMutation({
+    mutationFn: (projectPath: string) =>
+      api.servers.getComposeProject(serverId, projectPath),
+    onSuccess: () => selection.clear(),
+  });
+
+  const adoptMutation = useMutation({
+    mutationFn: (apps: readonly ImportAppInput[]) =>
+      api.servers.adoptComposeProject(serverId, path.trim(), apps),
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ["apps"] });
+      selection.clear();
+    },
+  });
+
+  const handleRead = (e: React.FormEvent) => {
+    e.preventDefault();
+    if (!path.trim()) return;
+    adoptMutation.reset();
+    readMutation.mutate(path.trim());
+  };
+
+  const project = readMutation.data;
+  const services = project?.services ?? [];
+
+  return (
+    <Card>
+      <CardHeader className="pb-3">
+        <CardTitle className="text-base">Adopt Compose Project</CardTitle>
+      </CardHeader>
+      <CardContent className="space-y-3">
+        <p className="text-sm text-muted-foreground">
+          Point at an existing docker-compose project directory on this server
+          to turn its services into managed apps. Future deploys are built from
+          the repository; stop the original project once the first deploy is
+          healthy.
+        </p>
+
+        <form onSubmit={handleRead} className="flex flex-wrap gap-2">
+          <Input
+            placeholder="/srv/my-project"
+            value={path}
+            onChange={(e) => setPath(e.target.value)}
+            className="flex-1 min-w-48 font-mono text-sm"
+          />
+          <Button
+            type="submit"
+            variant="outline"
+            size="sm"
+            disabled={!path.trim() || readMutation.isPending}
+          >
+            {readMutation.isPending ? (
+              <Loader2 className="h-4 w-4 mr-2 animate-spin" />
+            ) : (
+              <Search className="h-4 w-4 mr-2" />
+            )}
+            Read project
+          </Button>
+        </form>
+
+        {readMutation.isError && (
+          <p className="text-sm text-destructive">
+            {readMutation.error instanceof Error
+              ? readMutation.error.message
+              : "Failed to read compose project"}
+          </p>
+        )}
+
+        {project && services.length === 0 && (
+          <p className="text-sm text-muted-foreground">
+            Project has no services.
+          </p>
+        )}
+
+        {services.length > 0 && (
+          <div className="space-y-2">
+            <p className="text-sm">
+              Project <span className="font-mono">{project?.projectName}</span>
+            </p>
+            <ImportAppPicker
+              apps={services}
+              selection={selection}
+              renderDetail={(app) => (
+                <p className="text-xs text-muted-foreground font-mono">
+                  {serviceSource(app as ComposeService)}
+                </p>
+              )}
+            />
+            <Button
+              size="sm"
+              onClick={() => adoptMutation.mutate(selection.toInputs())}
+              disabled={!selection.isValid || adoptMutation.isPending}
+            >
+              {adoptMutation.isPending ? (
+                <Loader2 className="h-4 w-4 mr-2 animate-spin" />
+              ) : (
+                <Download className="h-4 w-4 mr-2" />
+              )}
+              Adopt {selection.count > 0 ? selection.count : ""} services
+            </Button>
+          </div>
+        )}
+
+        {adoptMutation.isError && (
+          <p className="text-sm text-destructive">
+            {adoptMutation.error instanceof Error
+              ? adoptMutation.error.message
+              : "Failed to adopt services"}
+          </p>
+        )}
+
+        {adoptMutation.data && <ImportResults results={adoptMutation.data} />}
+      </CardContent>
+    </Card>
+  );
+}
@@ -0,0 +1,72 @@
+import { useCallback, useState } from "react";
+import { Terminal } from "lucide-react";
+import { Button } from "@/components/ui/button";
+import { Card, CardContent, CardHeader, CardTitle } from "@/components/ui/card";
+import {
+  Dialog,
+  DialogContent,
+  DialogHeader,
+  DialogTitle,
+} from "@/components/ui/dialog";
+import { TerminalView } from "@/components/terminal";
+import type { TerminalStatus } from "@/components/terminal";
+import { useAuth } from "@/contexts/auth-context";
+import { api } from "@/services/api";
+import type { Server } from "@/types";
+
+interface ServerConsoleSectionProps {
+  readonly server: Server;
+}
+
+export function ServerConsoleSection({ server }: ServerConsoleSectionProps) {
+  const { isAdmin } = useAuth();
+  const [open, setOpen] = useState(false);
+
+  const handleStatusChange = useCallback((status: TerminalStatus) => {
+    if (status === "closed") {
+      setOpen(false);
+    }
+  }, []);
+
+  if (!isAdmin) return null;
+
+  return (
+    <Card>
+      <CardHeader className="pb-3">
+        <div className="flex items-center justify-between">
+          <CardTitle className="text-base">SSH Console</CardTitle>
+          <Button variant="outline" size="sm" onClick={() => setOpen(true)}>
+            <Terminal className="h-4 w-4 mr-2" />
+            Open console
+          </Button>
+        </div>
+      </CardHeader>
+      <CardContent>
+        <p className="text-sm text-muted-foreground">
+          Opens a shell on the host as {server.sshUser}, with the stored SSH
+          credentials. Sessions are recorded in the audit log.
+        </p>
+      </CardContent>
+
+      <Dialog open={open} onOpenChange={setOpen}>
+        <DialogContent
+          className="max-w-4xl h-[80vh] flex flex-col p-0"
+          onOpenAutoFocus={(e) => e.preventDefault()}
+          aria-describedby={undefined}
+        >
+          <DialogHeader className="px-6 pt-6 pb-2">
+            <DialogTitle>Console - {server.name}</DialogTitle>
+          </DialogHeader>
+          {open && (
+            <TerminalView
+              wsUrl={api.servers.consoleUrl(server.id)}
+              autoConnect={open}
+              onStatusChange={handleStatusChange}
+              className="flex-1 min-h-[240px] p-4"
+            />
+          )}
+        </DialogContent>
+      </Dialog>
+    </Card>
+  );
+}
@@ -0,0 +1,152 @@
+import { useEffect, useState } from "react";
+import { useMutation } from "@tanstack/react-query";
+import { Loader2, Plus, Trash2 } from "lucide-react";
+import { Button } from "@/components/ui/button";
+import { Card, CardContent, CardHeader, CardTitle } from "@/components/ui/card";
+import { Input } from "@/components/ui/input";
+import {
+  Select,
+  SelectContent,
+  SelectItem,
+  SelectTrigger,
+  SelectValue,
+} from "@/components/ui/select";
+import { api } from "@/services/api";
+import type { EntrypointProtocol, Server, ServerEntrypoint } from "@/types";
+
+interface ServerEntrypointsSectionProps {
+  readonly server: Server;
+  readonly onSaved: () => void;
+}
+
+export function ServerEntrypointsSection({
+  server,
+  onSaved,
+}: ServerEntrypointsSectionProps) {
+  const [entrypoints, setEntrypoints] = useState<readonly ServerEntrypoint[]>(
+    server.entrypoints ?? [],
+  );
+  const [name, setName] = useState("");
+  const [port, setPort] = useState("");
+  const [protocol, setProtocol] = useState<EntrypointProtocol>("tcp");
+
+  useEffect(() => {
+    setEntrypoints(server.entrypoints ?? []);
+  }, [server.entrypoints]);
+
+  const saveMutation = useMutation({
+    mutationFn: (next: readonly ServerEntrypoint[]) =>
+      api.servers.updateEntrypoints(server.id, next),
+    onSuccess: onSaved,
+    onError: () => setEntrypoints(server.entrypoints ?? []),
+  });
+
+  const save = (next: readonly ServerEntrypoint[]) => {
+    setEntrypoints(next);
+    saveMutation.mutate(next);
+  };
+
+  const handleAdd = (e: React.FormEvent) => {
+    e.preventDefault();
+    if (!name.trim() || !port) return;
+    save([
+      ...entrypoints,
+      { name: name.trim().toLowerCase(), port: Number(port), protocol },
+    ]);
+    setName("");
+    setPort("");
+  };
+
+  return (
+    <Card>
+      <CardHeader className="pb-3">
+        <CardTitle className="text-base">TCP/UDP Entrypoints</CardTitle>
+      </CardHeader>
+      <CardContent className="space-y-3">
+        <p className="text-sm text-muted-foreground">
+          Extra ports Traefik listens on for non-HTTP apps such as databases,
+          MQTT brokers or game servers. Apps route to an entrypoint by name from
+          the <span className="font-mono text-xs">ports</span> section of
+          paasdeploy.json. Changes are applied on the next provisioning.
+        </p>
+
+        {entrypoints.length > 0 && (
+          <div className="space-y-2">
+            {entrypoints.map((entrypoint) => (
+              <div
+                key={entrypoint.name}
+                className="flex items-center justify-between rounded-md border px-3 py-2 text-sm"
+              >
+                <span className="font-mono">
+                  {entrypoint.name} · {entrypoint.port}/{entrypoint.protocol}
+                </span>
+                <Button
+                  variant="ghost"
+                  size="icon"
+                  className="h-7 w-7"
+                  disabled={saveMutation.isPending}
+                  onClick={() =>
+                    save(entrypoints.filter((e) => e.name !== entrypoint.name))
+                  }
+                >
+                  <Trash2 className="h-4 w-4" />
+                </Button>
+              </div>
+            ))}
+          </div>
+        )}
+
+        <form onSubmit={handleAdd} className="flex flex-wrap gap-2">
+          <Input
+            placeholder="mqtt"
+            value={name}
+            onChange={(e) => setName(e.target.value)}
+            className="w-32"
+          />
+          <Input
+            type="number"
+            min={1}
+            max={65535}
+            placeholder="1883"
+            value={port}
+            onChange={(e) => setPort(e.target.value)}
+            className="w-28"
+          />
+          <Select
+            value={protocol}
+            onValueChange={(v) => setProtocol(v as EntrypointProtocol)}
+          >
+            <SelectTrigger className="w-24">
+              <SelectValue />
+            </SelectTrigger>
+            <SelectContent>
+              <SelectItem value="tcp">TCP</SelectItem>
+              <SelectItem value="udp">UDP</SelectItem>
+            </SelectContent>
+          </Select>
+          <Button
+            type="submit"
+            variant="outline"
+            size="sm"
+            disabled={!name.trim() || !port || saveMutation.isPending}
+          >
+            {saveMutation.isPending ? (
+              <Loader2 className="h-4 w-4 mr-2 animate-spin" />
+            ) : (
+              <Plus className="h-4 w-4 mr-2" />
+            )}
+            Add
+          </Button>
+        </form>
+
+        {saveMutation.isError && (
+          <p className="text-sm text-destructive">
+            {saveMutation.error instanceof Error
+              ? saveMutation.error.message
+              : "Failed to update entrypoints"}
+          </p>
+        )}
+      </CardContent>
+    </Card>
+  );
+}
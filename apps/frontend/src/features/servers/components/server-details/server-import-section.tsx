@@ -0,0 +1,132 @@
+import { useState } from "react";
+import { useMutation, useQueryClient } from "@tanstack/react-query";
+import { Download, Loader2, Search } from "lucide-react";
+import { Button } from "@/components/ui/button";
+import { Card, CardContent, CardHeader, CardTitle } from "@/components/ui/card";
+import {
+  Select,
+  SelectContent,
+  SelectItem,
+  SelectTrigger,
+  SelectValue,
+} from "@/components/ui/select";
+import { api } from "@/services/api";
+import type { ImportAppInput, ImportPlatform } from "@/types";
+import { ImportAppPicker, useImportSelection } from "./import-app-picker";
+import { ImportResults } from "./import-results";
+
+interface ServerImportSectionProps {
+  readonly serverId: string;
+}
+
+export function ServerImportSection({ serverId }: ServerImportSectionProps) {
+  const queryClient = useQueryClient();
+  const [platform, setPlatform] = useState<ImportPlatform>("dokku");
+  const selection = useImportSelection();
+
+  const discoverMutation = useMutation({
+    mutationFn: () => api.servers.discoverApps(serverId, platform),
+    onSuccess: () => selection.clear(),
+  });
+
+  const importMutation = useMutation({
+    mutationFn: (apps: readonly ImportAppInput[]) =>
+      api.servers.importApps(serverId, platform, apps),
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ["apps"] });
+      selection.clear();
+    },
+  });
+
+  const apps = discoverMutation.data ?? [];
+
+  return (
+    <Card>
+      <CardHeader className="pb-3">
+        <CardTitle className="text-base">Import Apps</CardTitle>
+      </CardHeader>
+      <CardContent className="space-y-3">
+        <p className="text-sm text-muted-foreground">
+          Reads the apps of an existing Dokku or CapRover install on this server
+          over SSH and recreates them as managed apps with their environment
+          variables. Domains and volumes are carried over in a suggested
+          paasdeploy.json for each app.
+        </p>
+
+        <div className="flex flex-wrap gap-2">
+          <Select
+            value={platform}
+            onValueChange={(v) => {
+              setPlatform(v as ImportPlatform);
+              discoverMutation.reset();
+              importMutation.reset();
+            }}
+          >
+            <SelectTrigger className="w-36">
+              <SelectValue />
+            </SelectTrigger>
+            <SelectContent>
+              <SelectItem value="dokku">Dokku</SelectItem>
+              <SelectItem value="caprover">CapRover</SelectItem>
+            </SelectContent>
+          </Select>
+          <Button
+            variant="outline"
+            size="sm"
+            onClick={() => discoverMutation.mutate()}
+            disabled={discoverMutation.isPending}
+          >
+            {discoverMutation.isPending ? (
+              <Loader2 className="h-4 w-4 mr-2 animate-spin" />
+            ) : (
+              <Search className="h-4 w-4 mr-2" />
+            )}
+            Scan server
+          </Button>
+        </div>
+
+        {discoverMutation.isError && (
+          <p className="text-sm text-destructive">
+            {discoverMutation.error instanceof Error
+              ? discoverMutation.error.message
+              : "Failed to read apps"}
+          </p>
+        )}
+
+        {discoverMutation.isSuccess && apps.length === 0 && (
+          <p className="text-sm text-muted-foreground">No apps found.</p>
+        )}
+
+        {apps.length > 0 && (
+          <div className="space-y-2">
+            <ImportAppPicker apps={apps} selection={selection} />
+            <Button
+              size="sm"
+              onClick={() => importMutation.mutate(selection.toInputs())}
+              disabled={!selection.isValid || importMutation.isPending}
+            >
+              {importMutation.isPending ? (
+                <Loader2 className="h-4 w-4 mr-2 animate-spin" />
+              ) : (
+                <Download className="h-4 w-4 mr-2" />
+              )}
+              Import {selection.count > 0 ? selection.count : ""} apps
+            </Button>
+          </div>
+        )}
+
+        {importMutation.isError && (
+          <p className="text-sm text-destructive">
+            {importMutation.error instanceof Error
+              ? importMutation.error.message
+              : "Failed to import apps"}
+          </p>
+        )}
+
+        {importMutation.data && (
+          <ImportResults results={importMutation.data} />
+        )}
+      </CardContent>
+    </Card>
+  );
+}
@@ -0,0 +1,92 @@
+import { useMutation } from "@tanstack/react-query";
+import { Loader2, Network, Unplug } from "lucide-react";
+import { Badge } from "@/components/ui/badge";
+import { Button } from "@/components/ui/button";
+import { Card, CardContent, CardHeader, CardTitle } from "@/components/ui/card";
+import { api } from "@/services/api";
+import type { Server } from "@/types";
+
+interface ServerMeshSectionProps {
+  readonly server: Server;
+  readonly onSaved: () => void;
+}
+
+export function ServerMeshSection({ server, onSaved }: ServerMeshSectionProps) {
+  const enableMutation = useMutation({
+    mutationFn: () => api.servers.enableMesh(server.id),
+    onSuccess: onSaved,
+  });
+
+  const disableMutation = useMutation({
+    mutationFn: () => api.servers.disableMesh(server.id),
+    onSuccess: onSaved,
+  });
+
+  const inMesh = Boolean(server.meshIp);
+  const isPending = enableMutation.isPending || disableMutation.isPending;
+  const error = enableMutation.error ?? disableMutation.error;
+
+  return (
+    <Card>
+      <CardHeader className="pb-3">
+        <div className="flex items-center justify-between">
+          <CardTitle className="flex items-center gap-2 text-base">
+            Private Mesh
+            {inMesh && <Badge variant="secondary">active</Badge>}
+          </CardTitle>
+          {inMesh ? (
+            <Button
+              variant="outline"
+              size="sm"
+              disabled={isPending}
+              onClick={() => disableMutation.mutate()}
+            >
+              {disableMutation.isPending ? (
+                <Loader2 className="h-4 w-4 mr-2 animate-spin" />
+              ) : (
+                <Unplug className="h-4 w-4 mr-2" />
+              )}
+              Leave
+            </Button>
+          ) : (
+            <Button
+              variant="outline"
+              size="sm"
+              disabled={isPending}
+              onClick={() => enableMutation.mutate()}
+            >
+              {enableMutation.isPending ? (
+                <Loader2 className="h-4 w-4 mr-2 animate-spin" />
+              ) : (
+                <Network className="h-4 w-4 mr-2" />
+              )}
+              Join
+            </Button>
+          )}
+        </div>
+      </CardHeader>
+      <CardContent className="space-y-3">
+        <p className="text-sm text-muted-foreground">
+          Connects your servers with a WireGuard mesh so apps can talk to each
+          other privately across servers. Publish a port on the mesh with{" "}
+          <span className="font-mono text-xs">"mesh": true</span> and a{" "}
+          <span className="font-mono text-xs">hostPort</span> in the ports
+          section of paasdeploy.json; it is never exposed publicly.
+        </p>
+
+        {inMesh && (
+          <p className="text-sm">
+            Mesh address{" "}
+            <span className="font-mono text-xs">{server.meshIp}</span>
+          </p>
+        )}
+
+        {error && (
+          <p className="text-sm text-destructive">
+            {error instanceof Error ? error.message : "Failed to update mesh"}
+          </p>
+        )}
+      </CardContent>
+    </Card>
+  );
+}
@@ -1,11 +1,14 @@
 import { useCallback, useState } from "react";
+import { useMutation, useQuery, useQueryClient } from "@tanstack/react-query";
 import {
   AlertTriangle,
   CheckCircle2,
   Loader2,
+  KeyRound,
   RefreshCw,
   ScrollText,
   Shield,
+  ShieldCheck,
   Terminal,
   XCircle,
 } from "lucide-react";
@@ -20,9 +23,20 @@ import {
   SelectTrigger,
   SelectValue,
 } from "@/components/ui/select";
+import { useCopyToClipboard } from "@/hooks/use-copy-to-clipboard";
 import { api } from "@/services/api";
-import type { ManageServerResponse } from "@/services/api/servers";
-import type { AgentUpdateMode, Server } from "@/types";
+import type {
+  BootstrapScript,
+  DriftReport,
+  ManageServerResponse,
+  ProvisionPlan,
+} from "@/services/api/servers";
+import type {
+  AgentInstallMethod,
+  AgentUpdateMode,
+  Server,
+} from "@/types";
+import { useServers } from "../../hooks/use-servers";
 
 interface ServerSettingsSectionProps {
   readonly server: Server;
@@ -36,18 +50,50 @@ export function ServerSettingsSection({
   const [updateMode, setUpdateMode] = useState<AgentUpdateMode>(
     server.agentUpdateMode ?? "grpc",
   );
+  const [installMethod, setInstallMethod] = useState<AgentInstallMethod>(
+    server.agentInstallMethod ?? "auto",
+  );
+  const [dockerRootless, setDockerRootless] = useState(
+    server.dockerRootless ?? false,
+  );
+  const [firewallEnabled, setFirewallEnabled] = useState(
+    server.firewallEnabled ?? false,
+  );
+  const [sshHardening, setSSHHardening] = useState(
+    server.sshHardening ?? false,
+  );
+  const [bastionServerId, setBastionServerId] = useState(
+    server.bastionServerId ?? "",
+  );
+  const { data: servers } = useServers();
+  const bastionCandidates = (servers ?? []).filter(
+    (s) => s.id !== server.id && !s.bastionServerId,
+  );
   const [isSaving, setIsSaving] = useState(false);
   const [saveError, setSaveError] = useState<string | null>(null);
   const [saved, setSaved] = useState(false);
 
-  const isDirty = updateMode !== (server.agentUpdateMode ?? "grpc");
+  const isDirty =
+    updateMode !== (server.agentUpdateMode ?? "grpc") ||
+    installMethod !== (server.agentInstallMethod ?? "auto") ||
+    dockerRootless !== (server.dockerRootless ?? false) ||
+    firewallEnabled !== (server.firewallEnabled ?? false) ||
+    sshHardening !== (server.sshHardening ?? false) ||
+    bastionServerId !== (server.bastionServerId ?? "");
 
   const handleSave = useCallback(async () => {
     setIsSaving(true);
     setSaveError(null);
     setSaved(false);
     try {
-      await api.servers.update(server.id, { agentUpdateMode: updateMode });
+      await api.servers.update(server.id, {
+        agentUpdateMode: updateMode,
+        agentInstallMethod: installMethod,
+        dockerRootless,
+        firewallEnabled,
+        sshHardening,
+        bastionServerId,
+      });
       setSaved(true);
       onSaved();
       globalThis.setTimeout(() => setSaved(false), 3000);
@@ -56,7 +102,16 @@ export function ServerSettingsSection({
     } finally {
       setIsSaving(false);
     }
-  }, [server.id, updateMode, onSaved]);
+  }, [
+    server.id,
+    updateMode,
+    installMethod,
+    dockerRootless,
+    firewallEnabled,
+    sshHardening,
+    bastionServerId,
+    onSaved,
+  ]);
 
   return (
     <div className="space-y-4">
@@ -118,6 +173,117 @@ export function ServerSettingsSection({
             </p>
           </div>
 
+          <div className="space-y-2 max-w-sm">
+            <Label htmlFor="agent-install-method">Agent Install Method</Label>
+            <Select
+              value={installMethod}
+              onValueChange={(v) => setInstallMethod(v as AgentInstallMethod)}
+            >
+              <SelectTrigger id="agent-install-method">
+                <SelectValue />
+              </SelectTrigger>
+              <SelectContent>
+                <SelectItem value="auto">Auto-detect</SelectItem>
+                <SelectItem value="systemd_user">systemd (user)</SelectItem>
+                <SelectItem value="openrc">OpenRC</SelectItem>
+                <SelectItem value="nohup">nohup + watchdog</SelectItem>
+                <SelectItem value="docker">Docker container</SelectItem>
+              </SelectContent>
+            </Select>
+            <p className="text-xs text-muted-foreground">
+              How the agent is kept running on the host. Applied on the next
+              provision.
+            </p>
+          </div>
+
+          <div className="space-y-2 max-w-sm">
+            <Label htmlFor="docker-mode">Docker Mode</Label>
+            <Select
+              value={dockerRootless ? "rootless" : "standard"}
+              onValueChange={(v) => setDockerRootless(v === "rootless")}
+            >
+              <SelectTrigger id="docker-mode">
+                <SelectValue />
+              </SelectTrigger>
+              <SelectContent>
+                <SelectItem value="standard">Standard (root daemon)</SelectItem>
+                <SelectItem value="rootless">Rootless</SelectItem>
+              </SelectContent>
+            </Select>
+            <p className="text-xs text-muted-foreground">
+              Rootless runs the Docker daemon as the SSH user inside a user
+              namespace. Requires a non-root SSH user and a systemd host.
+              Applied on the next provision.
+            </p>
+          </div>
+
+          <div className="space-y-2 max-w-sm">
+            <Label htmlFor="firewall-mode">Firewall</Label>
+            <Select
+              value={firewallEnabled ? "enabled" : "disabled"}
+              onValueChange={(v) => setFirewallEnabled(v === "enabled")}
+            >
+              <SelectTrigger id="firewall-mode">
+                <SelectValue />
+              </SelectTrigger>
+              <SelectContent>
+                <SelectItem value="disabled">Not managed</SelectItem>
+                <SelectItem value="enabled">
+                  Managed (UFW / nftables)
+                </SelectItem>
+              </SelectContent>
+            </Select>
+            <p className="text-xs text-muted-foreground">
+              Allows only SSH, 80 and 443, plus the agent port from the backend
+              IP. Applied on the next provision.
+            </p>
+          </div>
+
+          <div className="space-y-2 max-w-sm">
+            <Label htmlFor="ssh-hardening">SSH Hardening</Label>
+            <Select
+              value={sshHardening ? "enabled" : "disabled"}
+              onValueChange={(v) => setSSHHardening(v === "enabled")}
+            >
+              <SelectTrigger id="ssh-hardening">
+                <SelectValue />
+              </SelectTrigger>
+              <SelectContent>
+                <SelectItem value="disabled">Not managed</SelectItem>
+                <SelectItem value="enabled">sshd hardening + fail2ban</SelectItem>
+              </SelectContent>
+            </Select>
+            <p className="text-xs text-muted-foreground">
+              Limits auth tries and installs fail2ban. Password login is
+              disabled only after the SSH key is verified. Applied on the next
+              provision.
+            </p>
+          </div>
+
+          <div className="space-y-2 max-w-sm">
+            <Label htmlFor="bastion-server">Bastion</Label>
+            <Select
+              value={bastionServerId || "none"}
+              onValueChange={(v) => setBastionServerId(v === "none" ? "" : v)}
+            >
+              <SelectTrigger id="bastion-server">
+                <SelectValue />
+              </SelectTrigger>
+              <SelectContent>
+                <SelectItem value="none">Direct connection</SelectItem>
+                {bastionCandidates.map((candidate) => (
+                  <SelectItem key={candidate.id} value={candidate.id}>
+                    {candidate.name} ({candidate.host})
+                  </SelectItem>
+                ))}
+              </SelectContent>
+            </Select>
+            <p className="text-xs text-muted-foreground">
+              SSH through another server as a jump host, for servers without a
+              public SSH port. The host may then be a private address.
+            </p>
+          </div>
+
           <div className="flex items-center gap-3">
             <Button
               size="sm"
@@ -140,6 +306,14 @@ export function ServerSettingsSection({
       </Card>
 
       <ServerManagementCard serverId={server.id} />
+
+      {server.firewallEnabled && <FirewallCard serverId={server.id} />}
+
+      <DriftCard serverId={server.id} />
+
+      <SSHKeyCard server={server} onSaved={onSaved} />
+
+      <BootstrapScriptCard serverId={server.id} />
     </div>
   );
 }
@@ -283,6 +457,357 @@ function ServerManagementCard({ serverId }: ServerManagementCardProps) {
   );
 }
 
+interface FirewallCardProps {
+  readonly serverId: string;
+}
+
+function FirewallCard({ serverId }: FirewallCardProps) {
+  const queryClient = useQueryClient();
+  const firewallQuery = useQuery({
+    queryKey: ["server-firewall", serverId],
+    queryFn: () => api.servers.firewall(serverId),
+    retry: false,
+  });
+
+  const applyMutation = useMutation({
+    mutationFn: () => api.servers.applyFirewall(serverId),
+    onSuccess: (firewall) => {
+      queryClient.setQueryData(["server-firewall", serverId], firewall);
+    },
+  });
+
+  const firewall = firewallQuery.data;
+
+  return (
+    <Card>
+      <CardContent className="py-4 space-y-4">
+        <div>
+          <h3 className="text-sm font-semibold">Firewall</h3>
+          <p className="text-xs text-muted-foreground mt-0.5">
+            {firewall == null
+              ? "No rules recorded yet. They are recorded on the next provision."
+              : `Applied with ${firewall.backend} on ${new Date(firewall.appliedAt).toLocaleString()}`}
+          </p>
+        </div>
+
+        {firewall != null && (
+          <div className="rounded-md border divide-y text-xs">
+            {firewall.rules.map((rule) => (
+              <div
+                key={`${rule.port}/${rule.protocol}/${rule.source ?? ""}`}
+                className="flex items-center justify-between px-3 py-1.5"
+              >
+                <span className="font-mono">
+                  {rule.port}/{rule.protocol}
+                </span>
+                <span className="text-muted-foreground">
+                  {rule.source ?? "anywhere"}
+                </span>
+              </div>
+            ))}
+          </div>
+        )}
+
+        <Button
+          size="sm"
+          variant="outline"
+          onClick={() => applyMutation.mutate()}
+          disabled={applyMutation.isPending}
+        >
+          {applyMutation.isPending ? (
+            <Loader2 className="h-4 w-4 animate-spin" />
+          ) : (
+            <ShieldCheck className="h-4 w-4" />
+          )}
+          Re-apply Rules
+        </Button>
+
+        {applyMutation.error != null && (
+          <p className="text-xs text-destructive">
+            {applyMutation.error instanceof Error
+              ? applyMutation.error.message
+              : "Failed to apply firewall rules"}
+          </p>
+        )}
+      </CardContent>
+    </Card>
+  );
+}
+
+interface DriftCardProps {
+  readonly serverId: string;
+}
+
+function DriftCard({ serverId }: DriftCardProps) {
+  const checkMutation = useMutation<DriftReport>({
+    mutationFn: () => api.servers.drift(serverId),
+  });
+
+  const planMutation = useMutation<ProvisionPlan>({
+    mutationFn: () => api.servers.provisionPlan(serverId),
+  });
+
+  const report = checkMutation.data;
+  const plan = planMutation.data;
+  const error = checkMutation.error ?? planMutation.error;
+
+  return (
+    <Card>
+      <CardContent className="py-4 space-y-4">
+        <div>
+          <h3 className="text-sm font-semibold">Configuration Drift</h3>
+          <p className="text-xs text-muted-foreground mt-0.5">
+            Compare the server with the state provisioning would leave it in.
+            Provisioning again only reconciles the drifted components.
+          </p>
+        </div>
+
+        {report != null && report.inSync && (
+          <div className="flex items-center gap-2 text-xs text-emerald-500">
+            <CheckCircle2 className="h-4 w-4" />
+            Server matches the desired state
+          </div>
+        )}
+
+        {report != null && !report.inSync && (
+          <div className="rounded-md border divide-y text-xs">
+            {report.drift.map((item) => (
+              <div
+                key={item.component}
+                className="flex items-center justify-between gap-4 px-3 py-1.5"
+              >
+                <span className="font-mono">{item.component}</span>
+                <span className="text-muted-foreground truncate">
+                  {item.actual} → {item.desired}
+                </span>
+              </div>
+            ))}
+          </div>
+        )}
+
+        {plan != null && (
+          <div className="space-y-2 text-xs">
+            {plan.steps.length === 0 ? (
+              <p className="text-muted-foreground">
+                Provisioning would not change anything.
+              </p>
+            ) : (
+              plan.steps.map((step, index) => (
+                <div
+                  key={`${step.step}-${index}`}
+                  className="rounded-md border px-3 py-2 space-y-1"
+                >
+                  <p>
+                    <span className="font-mono">{step.step}</span>{" "}
+                    <span className="text-muted-foreground">
+                      {step.description}
+                    </span>
+                  </p>
+                  {step.commands != null && step.commands.length > 0 && (
+                    <pre className="overflow-x-auto rounded bg-muted p-2 font-mono">
+                      {step.commands.join("\n")}
+                    </pre>
+                  )}
+                  {step.diff != null && step.diff !== "" && (
+                    <pre className="overflow-x-auto rounded bg-muted p-2 font-mono">
+                      {step.diff}
+                    </pre>
+                  )}
+                </div>
+              ))
+            )}
+          </div>
+        )}
+
+        <div className="flex gap-2">
+          <Button
+            size="sm"
+            variant="outline"
+            onClick={() => checkMutation.mutate()}
+            disabled={checkMutation.isPending}
+          >
+            {checkMutation.isPending ? (
+              <Loader2 className="h-4 w-4 animate-spin" />
+            ) : (
+              <RefreshCw className="h-4 w-4" />
+            )}
+            Check Drift
+          </Button>
+          <Button
+            size="sm"
+            variant="outline"
+            onClick={() => planMutation.mutate()}
+            disabled={planMutation.isPending}
+          >
+            {planMutation.isPending ? (
+              <Loader2 className="h-4 w-4 animate-spin" />
+            ) : (
+              <ScrollText className="h-4 w-4" />
+            )}
+            Preview Provision
+          </Button>
+        </div>
+
+        {error != null && (
+          <p className="text-xs text-destructive">
+            {error instanceof Error ? error.message : "Failed to inspect server"}
+          </p>
+        )}
+      </CardContent>
+    </Card>
+  );
+}
+
+interface SSHKeyCardProps {
+  readonly server: Server;
+  readonly onSaved: () => void;
+}
+
+function SSHKeyCard({ server, onSaved }: SSHKeyCardProps) {
+  const [isGenerating, setIsGenerating] = useState(false);
+  const [error, setError] = useState<string | null>(null);
+  const { copy, copied } = useCopyToClipboard();
+
+  const generate = useCallback(async () => {
+    setIsGenerating(true);
+    setError(null);
+    try {
+      await api.servers.generateSshKey(server.id);
+      onSaved();
+    } catch (err) {
+      setError(err instanceof Error ? err.message : "Failed to generate key");
+    } finally {
+      setIsGenerating(false);
+    }
+  }, [server.id, onSaved]);
+
+  const publicKey = server.sshPublicKey;
+
+  return (
+    <Card>
+      <CardContent className="py-4 space-y-4">
+        <div>
+          <h3 className="text-sm font-semibold">Managed SSH Key</h3>
+          <p className="text-xs text-muted-foreground mt-0.5">
+            An ed25519 key pair generated and stored by PaasDeploy. Add the
+            public key to ~/.ssh/authorized_keys of {server.sshUser}; if the
+            password is still set, the next provision adds it for you.
+          </p>
+        </div>
+
+        {publicKey ? (
+          <div className="space-y-2">
+            <div className="rounded-md border bg-muted/50 overflow-hidden">
+              <pre className="text-[11px] leading-relaxed p-3 overflow-x-auto font-mono">
+                {publicKey}
+              </pre>
+            </div>
+            <Button size="sm" variant="ghost" onClick={() => copy(publicKey)}>
+              {copied ? "Copied" : "Copy"}
+            </Button>
+          </div>
+        ) : (
+          <p className="text-xs text-muted-foreground">
+            This server uses a key or password you provided.
+          </p>
+        )}
+
+        <Button
+          size="sm"
+          variant="outline"
+          onClick={generate}
+          disabled={isGenerating}
+        >
+          {isGenerating ? (
+            <Loader2 className="h-4 w-4 animate-spin" />
+          ) : (
+            <KeyRound className="h-4 w-4" />
+          )}
+          {publicKey ? "Rotate Key" : "Generate Key"}
+        </Button>
+
+        {error != null && <p className="text-xs text-destructive">{error}</p>}
+      </CardContent>
+    </Card>
+  );
+}
+
+interface BootstrapScriptCardProps {
+  readonly serverId: string;
+}
+
+function BootstrapScriptCard({ serverId }: BootstrapScriptCardProps) {
+  const [isGenerating, setIsGenerating] = useState(false);
+  const [bootstrap, setBootstrap] = useState<BootstrapScript | null>(null);
+  const [error, setError] = useState<string | null>(null);
+  const { copy, copied } = useCopyToClipboard();
+
+  const generate = useCallback(async () => {
+    setIsGenerating(true);
+    setError(null);
+    try {
+      setBootstrap(await api.servers.bootstrapScript(serverId));
+    } catch (err) {
+      setError(
+        err instanceof Error ? err.message : "Failed to generate script",
+      );
+    } finally {
+      setIsGenerating(false);
+    }
+  }, [serverId]);
+
+  return (
+    <Card>
+      <CardContent className="py-4 space-y-4">
+        <div>
+          <h3 className="text-sm font-semibold">Self-Provisioning Script</h3>
+          <p className="text-xs text-muted-foreground mt-0.5">
+            One-shot install script for cloud-init user-data. The server
+            installs the agent at boot without SSH access from the backend.
+          </p>
+        </div>
+
+        <Button
+          size="sm"
+          variant="outline"
+          onClick={generate}
+          disabled={isGenerating}
+        >
+          {isGenerating ? (
+            <Loader2 className="h-4 w-4 animate-spin" />
+          ) : (
+            <Terminal className="h-4 w-4" />
+          )}
+          Generate Script
+        </Button>
+
+        {error != null && <p className="text-xs text-destructive">{error}</p>}
+
+        {bootstrap != null && (
+          <div className="space-y-2">
+            <p className="text-xs text-muted-foreground">
+              Valid once, until {new Date(bootstrap.expiresAt).toLocaleString()}
+              . Generating a new script does not revoke this one.
+            </p>
+            <div className="rounded-md border bg-muted/50 overflow-hidden">
+              <pre className="text-[11px] leading-relaxed p-3 overflow-x-auto max-h-96 font-mono">
+                {bootstrap.script}
+              </pre>
+            </div>
+            <Button
+              size="sm"
+              variant="ghost"
+              onClick={() => copy(bootstrap.script)}
+            >
+              {copied ? "Copied" : "Copy"}
+            </Button>
+          </div>
+        )}
+      </CardContent>
+    </Card>
+  );
+}
+
 interface ManageResultFeedbackProps {
   readonly result: ManageServerResponse | null;
   readonly error: string | null;
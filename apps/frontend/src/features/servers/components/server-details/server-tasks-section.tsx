@@ -0,0 +1,410 @@
+import { useState } from "react";
+import { useMutation, useQuery, useQueryClient } from "@tanstack/react-query";
+import { History, Loader2, Play, Plus, Trash2 } from "lucide-react";
+import { Badge } from "@/components/ui/badge";
+import { Button } from "@/components/ui/button";
+import { Card, CardContent, CardHeader, CardTitle } from "@/components/ui/card";
+import { Checkbox } from "@/components/ui/checkbox";
+import { Input } from "@/components/ui/input";
+import { Label } from "@/components/ui/label";
+import { useAuth } from "@/contexts/auth-context";
+import { formatDate, formatDuration } from "@/lib/format";
+import { api } from "@/services/api";
+import type { Server, ServerTask, ServerTaskRun } from "@/types";
+
+const textareaClassName =
+  "flex min-h-[80px] w-full rounded-md border border-input bg-background px-3 py-2 font-mono text-xs ring-offset-background placeholder:text-muted-foreground focus-visible:outline-none focus-visible:ring-2 focus-visible:ring-ring focus-visible:ring-offset-2";
+
+const RUNNING_REFETCH_INTERVAL = 5000;
+
+interface ServerTasksSectionProps {
+  readonly server: Server;
+}
+
+function tasksQueryKey(serverId: string) {
+  return ["server-tasks", serverId];
+}
+
+function runsQueryKey(serverId: string, taskId: string) {
+  return ["server-task-runs", serverId, taskId];
+}
+
+function RunStatusBadge({
+  status,
+}: {
+  readonly status: ServerTask["lastStatus"];
+}) {
+  if (!status) return null;
+  return <Badge variant={status}>{status}</Badge>;
+}
+
+function TaskRuns({
+  serverId,
+  taskId,
+}: {
+  readonly serverId: string;
+  readonly taskId: string;
+}) {
+  const runsQuery = useQuery({
+    queryKey: runsQueryKey(serverId, taskId),
+    queryFn: () => api.servers.listTaskRuns(serverId, taskId),
+    refetchInterval: (query) =>
+      query.state.data?.some((run) => run.status === "running")
+        ? RUNNING_REFETCH_INTERVAL
+        : false,
+  });
+  const runs = runsQuery.data ?? [];
+
+  if (runsQuery.isLoading) {
+    return (
+      <div className="flex justify-center py-2">
+        <Loader2 className="h-4 w-4 animate-spin text-muted-foreground" />
+      </div>
+    );
+  }
+  if (runs.length === 0) {
+    return <p className="text-xs text-muted-foreground">No runs yet.</p>;
+  }
+
+  return (
+    <div className="space-y-2">
+      {runs.map((run) => (
+        <TaskRunItem key={run.id} run={run} />
+      ))}
+    </div>
+  );
+}
+
+function TaskRunItem({ run }: { readonly run: ServerTaskRun }) {
+  return (
+    <details className="rounded-md border text-xs">
+      <summary className="flex cursor-pointer flex-wrap items-center gap-2 px-2 py-1.5">
+        <RunStatusBadge status={run.status} />
+        <span>{formatDate(run.startedAt)}</span>
+        <span className="text-muted-foreground">{run.trigger}</span>
+        {run.status !== "running" && (
+          <span className="text-muted-foreground">
+            {formatDuration(run.durationMs)}
+          </span>
+        )}
+        {run.exitCode !== undefined && (
+          <span className="font-mono text-muted-foreground">
+            exit {run.exitCode}
+          </span>
+        )}
+      </summary>
+      <div className="space-y-1 border-t px-2 py-1.5">
+        {run.error && <p className="text-destructive">{run.error}</p>}
+        {run.truncated && (
+          <p className="text-muted-foreground">
+            Output truncated; showing the last 64 KiB.
+          </p>
+        )}
+        <pre className="max-h-64 overflow-auto whitespace-pre-wrap break-all font-mono">
+          {run.output || "(no output)"}
+        </pre>
+      </div>
+    </details>
+  );
+}
+
+function NewTaskForm({
+  serverId,
+  onCreated,
+}: {
+  readonly serverId: string;
+  readonly onCreated: () => void;
+}) {
+  const [name, setName] = useState("");
+  const [schedule, setSchedule] = useState("");
+  const [command, setCommand] = useState("");
+  const [timeoutSeconds, setTimeoutSeconds] = useState("");
+  const [notifyOnFailure, setNotifyOnFailure] = useState(true);
+
+  const createMutation = useMutation({
+    mutationFn: () =>
+      api.servers.createTask(serverId, {
+        name: name.trim(),
+        schedule: schedule.trim(),
+        command,
+        timeoutSeconds: timeoutSeconds ? Number(timeoutSeconds) : undefined,
+        notifyOnFailure,
+      }),
+    onSuccess: onCreated,
+  });
+
+  const canSubmit = name.trim() && schedule.trim() && command.trim();
+
+  return (
+    <form
+      className="space-y-3 rounded-md border p-3"
+      onSubmit={(e) => {
+        e.preventDefault();
+        if (canSubmit) createMutation.mutate();
+      }}
+    >
+      <div className="grid gap-2 sm:grid-cols-3">
+        <div className="grid gap-1">
+          <Label htmlFor="task-name">Name</Label>
+          <Input
+            id="task-name"
+            placeholder="Renew certificates"
+            value={name}
+            onChange={(e) => setName(e.target.value)}
+          />
+        </div>
+        <div className="grid gap-1">
+          <Label htmlFor="task-schedule">Schedule (UTC)</Label>
+          <Input
+            id="task-schedule"
+            placeholder="0 3 * * *"
+            value={schedule}
+            onChange={(e) => setSchedule(e.target.value)}
+            className="font-mono"
+          />
+        </div>
+        <div className="grid gap-1">
+          <Label htmlFor="task-timeout">Timeout (seconds)</Label>
+          <Input
+            id="task-timeout"
+            type="number"
+            min={1}
+            max={3600}
+            placeholder="600"
+            value={timeoutSeconds}
+            onChange={(e) => setTimeoutSeconds(e.target.value)}
+          />
+        </div>
+      </div>
+      <div className="grid gap-1">
+        <Label htmlFor="task-command">Command</Label>
+        <textarea
+          id="task-command"
+          placeholder="certbot renew --quiet"
+          value={command}
+          onChange={(e) => setCommand(e.target.value)}
+          rows={3}
+          className={textareaClassName}
+        />
+      </div>
+      <div className="flex items-center justify-between gap-2">
+        <div className="flex items-center gap-2">
+          <Checkbox
+            id="task-notify"
+            checked={notifyOnFailure}
+            onCheckedChange={(c) => setNotifyOnFailure(c === true)}
+          />
+          <Label htmlFor="task-notify">Alert when a run fails</Label>
+        </div>
+        <Button
+          type="submit"
+          size="sm"
+          disabled={!canSubmit || createMutation.isPending}
+        >
+          {createMutation.isPending && (
+            <Loader2 className="h-4 w-4 mr-2 animate-spin" />
+          )}
+          Create task
+        </Button>
+      </div>
+      {createMutation.isError && (
+        <p className="text-sm text-destructive">
+          {createMutation.error instanceof Error
+            ? createMutation.error.message
+            : "Failed to create task"}
+        </p>
+      )}
+    </form>
+  );
+}
+
+export function ServerTasksSection({ server }: ServerTasksSectionProps) {
+  const { isAdmin } = useAuth();
+  const queryClient = useQueryClient();
+  const [showForm, setShowForm] = useState(false);
+  const [expandedTaskId, setExpandedTaskId] = useState<string | null>(null);
+
+  const tasksQuery = useQuery({
+    queryKey: tasksQueryKey(server.id),
+    queryFn: () => api.servers.listTasks(server.id),
+    refetchInterval: (query) =>
+      query.state.data?.some((task) => task.lastStatus === "running")
+        ? RUNNING_REFETCH_INTERVAL
+        : false,
+  });
+
+  const invalidate = async (taskId?: string) => {
+    await queryClient.invalidateQueries({
+      queryKey: tasksQueryKey(server.id),
+    });
+    if (taskId) {
+      await queryClient.invalidateQueries({
+        queryKey: runsQueryKey(server.id, taskId),
+      });
+    }
+  };
+
+  const runMutation = useMutation({
+    mutationFn: (taskId: string) => api.servers.runTask(server.id, taskId),
+    onSuccess: (run) => {
+      setExpandedTaskId(run.taskId);
+      return invalidate(run.taskId);
+    },
+  });
+
+  const toggleMutation = useMutation({
+    mutationFn: (task: ServerTask) =>
+      api.servers.updateTask(server.id, task.id, { enabled: !task.enabled }),
+    onSuccess: () => invalidate(),
+  });
+
+  const deleteMutation = useMutation({
+    mutationFn: (taskId: string) => api.servers.deleteTask(server.id, taskId),
+    onSuccess: () => invalidate(),
+  });
+
+  const isPending =
+    runMutation.isPending ||
+    toggleMutation.isPending ||
+    deleteMutation.isPending;
+  const error =
+    runMutation.error ?? toggleMutation.error ?? deleteMutation.error;
+  const tasks = tasksQuery.data ?? [];
+
+  return (
+    <Card>
+      <CardHeader className="pb-3">
+        <div className="flex items-center justify-between">
+          <CardTitle className="text-base">Scheduled Tasks</CardTitle>
+          {isAdmin && (
+            <Button
+              variant="outline"
+              size="sm"
+              onClick={() => setShowForm((v) => !v)}
+            >
+              <Plus className="h-4 w-4 mr-2" />
+              New task
+            </Button>
+          )}
+        </div>
+      </CardHeader>
+      <CardContent className="space-y-3">
+        <p className="text-sm text-muted-foreground">
+          Shell commands the agent runs on the host on a cron schedule, such as
+          certbot hooks or cleanup scripts. Schedules are in UTC. Failed runs
+          trigger the{" "}
+          <span className="font-mono text-xs">server_task_failed</span>{" "}
+          notification.
+        </p>
+
+        {showForm && isAdmin && (
+          <NewTaskForm
+            serverId={server.id}
+            onCreated={() => {
+              setShowForm(false);
+              void invalidate();
+            }}
+          />
+        )}
+
+        {tasksQuery.isLoading && (
+          <div className="flex justify-center py-4">
+            <Loader2 className="h-5 w-5 animate-spin text-muted-foreground" />
+          </div>
+        )}
+
+        {tasksQuery.isSuccess && tasks.length === 0 && (
+          <p className="text-sm text-muted-foreground">
+            No scheduled tasks yet.
+          </p>
+        )}
+
+        {tasks.length > 0 && (
+          <div className="divide-y rounded-md border">
+            {tasks.map((task) => (
+              <div key={task.id} className="space-y-2 p-2">
+                <div className="flex items-center justify-between gap-2">
+                  <div className="min-w-0 space-y-0.5">
+                    <div className="flex items-center gap-2">
+                      <span className="truncate text-sm font-medium">
+                        {task.name}
+                      </span>
+                      <Badge variant="outline" className="font-mono">
+                        {task.schedule}
+                      </Badge>
+                      <RunStatusBadge status={task.lastStatus} />
+                      {!task.enabled && (
+                        <Badge variant="secondary">disabled</Badge>
+                      )}
+                    </div>
+                    <p className="truncate font-mono text-xs text-muted-foreground">
+                      {task.command}
+                    </p>
+                    {task.enabled && task.nextRunAt && (
+                      <p className="text-xs text-muted-foreground">
+                        Next run {formatDate(task.nextRunAt)}
+                      </p>
+                    )}
+                  </div>
+                  <div className="flex shrink-0 items-center gap-1">
+                    {isAdmin && (
+                      <Checkbox
+                        title={task.enabled ? "Disable" : "Enable"}
+                        checked={task.enabled}
+                        disabled={isPending}
+                        onCheckedChange={() => toggleMutation.mutate(task)}
+                      />
+                    )}
+                    <Button
+                      variant="ghost"
+                      size="icon"
+                      title="Run history"
+                      onClick={() =>
+                        setExpandedTaskId((id) =>
+                          id === task.id ? null : task.id,
+                        )
+                      }
+                    >
+                      <History className="h-4 w-4" />
+                    </Button>
+                    {isAdmin && (
+                      <>
+                        <Button
+                          variant="ghost"
+                          size="icon"
+                          title="Run now"
+                          disabled={isPending || task.lastStatus === "running"}
+                          onClick={() => runMutation.mutate(task.id)}
+                        >
+                          <Play className="h-4 w-4" />
+                        </Button>
+                        <Button
+                          variant="ghost"
+                          size="icon"
+                          title="Delete"
+                          disabled={isPending}
+                          onClick={() => deleteMutation.mutate(task.id)}
+                        >
+                          <Trash2 className="h-4 w-4" />
+                        </Button>
+                      </>
+                    )}
+                  </div>
+                </div>
+                {expandedTaskId === task.id && (
+                  <TaskRuns serverId={server.id} taskId={task.id} />
+                )}
+              </div>
+            ))}
+          </div>
+        )}
+
+        {error && (
+          <p className="text-sm text-destructive">
+            {error instanceof Error ? error.message : "Failed to update task"}
+          </p>
+        )}
+      </CardContent>
+    </Card>
+  );
+}
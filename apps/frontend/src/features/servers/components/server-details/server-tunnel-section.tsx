@@ -0,0 +1,102 @@
+import { useMutation, useQuery, useQueryClient } from "@tanstack/react-query";
+import { Cloud, Loader2, Unplug } from "lucide-react";
+import { Badge } from "@/components/ui/badge";
+import { Button } from "@/components/ui/button";
+import { Card, CardContent, CardHeader, CardTitle } from "@/components/ui/card";
+import { api } from "@/services/api";
+
+interface ServerTunnelSectionProps {
+  readonly serverId: string;
+}
+
+export function ServerTunnelSection({ serverId }: ServerTunnelSectionProps) {
+  const queryClient = useQueryClient();
+
+  const tunnelQuery = useQuery({
+    queryKey: ["server-tunnel", serverId],
+    queryFn: () => api.servers.tunnel(serverId),
+  });
+
+  const onSuccess = async () => {
+    await queryClient.invalidateQueries({
+      queryKey: ["server-tunnel", serverId],
+    });
+  };
+
+  const enableMutation = useMutation({
+    mutationFn: () => api.servers.enableTunnel(serverId),
+    onSuccess,
+  });
+
+  const disableMutation = useMutation({
+    mutationFn: () => api.servers.disableTunnel(serverId),
+    onSuccess,
+  });
+
+  const tunnel = tunnelQuery.data;
+  const isPending = enableMutation.isPending || disableMutation.isPending;
+  const error = enableMutation.error ?? disableMutation.error;
+
+  return (
+    <Card>
+      <CardHeader className="pb-3">
+        <div className="flex items-center justify-between">
+          <CardTitle className="flex items-center gap-2 text-base">
+            Cloudflare Tunnel
+            {tunnel?.enabled && <Badge variant="secondary">active</Badge>}
+          </CardTitle>
+          {tunnel?.enabled ? (
+            <Button
+              variant="outline"
+              size="sm"
+              disabled={isPending}
+              onClick={() => disableMutation.mutate()}
+            >
+              {disableMutation.isPending ? (
+                <Loader2 className="h-4 w-4 mr-2 animate-spin" />
+              ) : (
+                <Unplug className="h-4 w-4 mr-2" />
+              )}
+              Disable
+            </Button>
+          ) : (
+            <Button
+              variant="outline"
+              size="sm"
+              disabled={!tunnelQuery.isSuccess || isPending}
+              onClick={() => enableMutation.mutate()}
+            >
+              {enableMutation.isPending ? (
+                <Loader2 className="h-4 w-4 mr-2 animate-spin" />
+              ) : (
+                <Cloud className="h-4 w-4 mr-2" />
+              )}
+              Enable
+            </Button>
+          )}
+        </div>
+      </CardHeader>
+      <CardContent className="space-y-3">
+        <p className="text-sm text-muted-foreground">
+          Runs cloudflared on the server and routes app domains through your
+          Cloudflare account, so servers without a public IP or open ports can
+          host apps. Domains on this server are switched to proxied CNAME
+          records.
+        </p>
+
+        {tunnel?.enabled && tunnel.target && (
+          <p className="text-sm">
+            Domains point to{" "}
+            <span className="font-mono text-xs">{tunnel.target}</span>
+          </p>
+        )}
+
+        {error && (
+          <p className="text-sm text-destructive">
+            {error instanceof Error ? error.message : "Failed to update tunnel"}
+          </p>
+        )}
+      </CardContent>
+    </Card>
+  );
+}
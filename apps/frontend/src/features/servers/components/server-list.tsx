@@ -63,6 +63,7 @@ function StatusBadge({ status }: { readonly status: ServerStatus }) {
 }
 
 const STEP_LABELS: Record<string, string> = {
+  cloud_instance: "Criando instância na nuvem",
   ssh_connect: "Conectando via SSH",
   remote_env: "Verificando ambiente",
   sftp_client: "Conectando SFTP",
@@ -184,6 +185,7 @@ function ServerCard({ server }: { readonly server: Server }) {
           <div className="flex flex-col gap-4 flex-1 min-h-0">
             <div className="space-y-2">
               {[
+                ...(server.cloudProvider ? ["cloud_instance"] : []),
                 "ssh_connect",
                 "remote_env",
                 "sftp_client",
@@ -1,7 +1,7 @@
 import { useMutation, useQuery, useQueryClient } from "@tanstack/react-query";
 import { STALE_TIMES } from "@/constants/query-config";
 import { api } from "@/services/api";
-import type { CreateServerInput } from "@/types";
+import type { CreateCloudServerInput, CreateServerInput } from "@/types";
 
 export const SERVERS_QUERY_KEY = ["servers"] as const;
 
@@ -44,6 +44,27 @@ export function useCreateServer() {
   });
 }
 
+export const CLOUD_CREDENTIALS_QUERY_KEY = ["cloud-credentials"] as const;
+
+export function useCloudCredentials() {
+  return useQuery({
+    queryKey: CLOUD_CREDENTIALS_QUERY_KEY,
+    queryFn: () => api.cloud.credentials(),
+    staleTime: STALE_TIMES.NORMAL,
+  });
+}
+
+export function useCreateCloudServer() {
+  const queryClient = useQueryClient();
+  return useMutation({
+    mutationFn: (input: CreateCloudServerInput) =>
+      api.cloud.createServer(input),
+    onSuccess: () => {
+      void queryClient.invalidateQueries({ queryKey: SERVERS_QUERY_KEY });
+    },
+  });
+}
+
 export function useDeleteServer() {
   const queryClient = useQueryClient();
   return useMutation({
@@ -63,3 +84,23 @@ export function useProvisionServer() {
     },
   });
 }
+
+export function useBulkCreateServers() {
+  const queryClient = useQueryClient();
+  return useMutation({
+    mutationFn: ({ csv, provision }: { csv: string; provision: boolean }) =>
+      api.servers.bulkCreateCsv(csv, provision),
+    onSuccess: () => {
+      void queryClient.invalidateQueries({ queryKey: SERVERS_QUERY_KEY });
+    },
+  });
+}
+
+export function useProvisionBatch(batchId: string | undefined) {
+  return useQuery({
+    queryKey: ["provision-batch", batchId],
+    queryFn: () => api.servers.provisionBatch(batchId!),
+    enabled: Boolean(batchId),
+    staleTime: STALE_TIMES.SHORT,
+  });
+}
@@ -5,6 +5,7 @@ import type {
 } from "@/types";
 
 const STEP_ORDER = [
+  "cloud_instance",
   "ssh_connect",
   "remote_env",
   "sftp_client",
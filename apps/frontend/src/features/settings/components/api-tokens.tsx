@@ -0,0 +1,210 @@
+import { useState } from "react";
+import { useMutation, useQuery, useQueryClient } from "@tanstack/react-query";
+import { Check, Copy, KeyRound, Loader2, Trash2 } from "lucide-react";
+import { Alert, AlertDescription } from "@/components/ui/alert";
+import { Button } from "@/components/ui/button";
+import {
+  Card,
+  CardContent,
+  CardDescription,
+  CardHeader,
+  CardTitle,
+} from "@/components/ui/card";
+import { Input } from "@/components/ui/input";
+import {
+  Select,
+  SelectContent,
+  SelectItem,
+  SelectTrigger,
+  SelectValue,
+} from "@/components/ui/select";
+import { useCopyToClipboard } from "@/hooks/use-copy-to-clipboard";
+import { formatDateOnly, formatRelativeTime } from "@/lib/format";
+import { api, type APIToken } from "@/services/api";
+
+const API_TOKENS_QUERY_KEY = ["api-tokens"] as const;
+
+const EXPIRY_OPTIONS = [
+  { value: "30", label: "30 days" },
+  { value: "90", label: "90 days" },
+  { value: "365", label: "1 year" },
+  { value: "0", label: "No expiration" },
+] as const;
+
+export function ApiTokens() {
+  const queryClient = useQueryClient();
+  const [name, setName] = useState("");
+  const [expiresInDays, setExpiresInDays] = useState("90");
+  const [createdToken, setCreatedToken] = useState<string | null>(null);
+  const { copy, copied } = useCopyToClipboard();
+
+  const { data: tokens, isLoading } = useQuery({
+    queryKey: API_TOKENS_QUERY_KEY,
+    queryFn: () => api.apiTokens.list(),
+  });
+
+  const invalidate = () =>
+    queryClient.invalidateQueries({ queryKey: API_TOKENS_QUERY_KEY });
+
+  const createMutation = useMutation({
+    mutationFn: () =>
+      api.apiTokens.create({
+        name: name.trim(),
+        expiresInDays: Number(expiresInDays),
+      }),
+    onSuccess: (token) => {
+      setName("");
+      setCreatedToken(token.token);
+      void invalidate();
+    },
+  });
+
+  const revokeMutation = useMutation({
+    mutationFn: (id: string) => api.apiTokens.revoke(id),
+    onSuccess: () => void invalidate(),
+  });
+
+  const handleCreate = (e: React.FormEvent) => {
+    e.preventDefault();
+    if (name.trim()) {
+      createMutation.mutate();
+    }
+  };
+
+  return (
+    <Card>
+      <CardHeader>
+        <CardTitle className="flex items-center gap-2">
+          <KeyRound className="h-5 w-5" />
+          API Tokens
+        </CardTitle>
+        <CardDescription>
+          Authenticate the flowdeploy CLI and scripts with{" "}
+          <code>Authorization: Bearer &lt;token&gt;</code>. Tokens act as
+          your account
+        </CardDescription>
+      </CardHeader>
+      <CardContent className="space-y-4">
+        {createdToken && (
+          <Alert>
+            <AlertDescription className="space-y-2">
+              <p>Copy the token now, it will not be shown again.</p>
+              <div className="flex items-center gap-2">
+                <code className="flex-1 break-all rounded bg-muted px-2 py-1 text-xs">
+                  {createdToken}
+                </code>
+                <Button
+                  variant="outline"
+                  size="icon"
+                  className="h-8 w-8 shrink-0"
+                  onClick={() => void copy(createdToken)}
+                  title="Copy token"
+                >
+                  {copied ? (
+                    <Check className="h-4 w-4" />
+                  ) : (
+                    <Copy className="h-4 w-4" />
+                  )}
+                </Button>
+              </div>
+            </AlertDescription>
+          </Alert>
+        )}
+
+        <form onSubmit={handleCreate} className="flex flex-col sm:flex-row gap-2">
+          <Input
+            placeholder="Token name, e.g. CI pipeline"
+            value={name}
+            onChange={(e) => setName(e.target.value)}
+            maxLength={100}
+            disabled={createMutation.isPending}
+          />
+          <Select value={expiresInDays} onValueChange={setExpiresInDays}>
+            <SelectTrigger className="sm:w-44">
+              <SelectValue />
+            </SelectTrigger>
+            <SelectContent>
+              {EXPIRY_OPTIONS.map((option) => (
+                <SelectItem key={option.value} value={option.value}>
+                  {option.label}
+                </SelectItem>
+              ))}
+            </SelectContent>
+          </Select>
+          <Button
+            type="submit"
+            size="sm"
+            disabled={!name.trim() || createMutation.isPending}
+          >
+            {createMutation.isPending && (
+              <Loader2 className="h-4 w-4 animate-spin mr-2" />
+            )}
+            Create
+          </Button>
+        </form>
+        {createMutation.isError && (
+          <p className="text-sm text-destructive">
+            {createMutation.error instanceof Error
+              ? createMutation.error.message
+              : "Failed to create token"}
+          </p>
+        )}
+
+        {isLoading ? (
+          <Loader2 className="h-6 w-6 animate-spin text-muted-foreground" />
+        ) : (
+          tokens?.map((token) => (
+            <ApiTokenRow
+              key={token.id}
+              token={token}
+              onRevoke={() => revokeMutation.mutate(token.id)}
+              isRevoking={
+                revokeMutation.isPending &&
+                revokeMutation.variables === token.id
+              }
+            />
+          ))
+        )}
+      </CardContent>
+    </Card>
+  );
+}
+
+interface ApiTokenRowProps {
+  readonly token: APIToken;
+  readonly onRevoke: () => void;
+  readonly isRevoking: boolean;
+}
+
+function ApiTokenRow({ token, onRevoke, isRevoking }: ApiTokenRowProps) {
+  const lastUsed = token.lastUsedAt
+    ? `last used ${formatRelativeTime(token.lastUsedAt)}`
+    : "never used";
+  const expires = token.expiresAt
+    ? `expires ${formatDateOnly(token.expiresAt)}`
+    : "no expiration";
+
+  return (
+    <div className="flex items-center justify-between gap-3 rounded-lg border p-3">
+      <div className="min-w-0">
+        <p className="font-medium text-sm truncate">{token.name}</p>
+        <p className="text-xs text-muted-foreground">
+          <code>{token.tokenPrefix}…</code> · {lastUsed} · {expires}
+        </p>
+      </div>
+      <Button
+        variant="outline"
+        size="sm"
+        onClick={onRevoke}
+        disabled={isRevoking}
+      >
+        {isRevoking ? (
+          <Loader2 className="h-4 w-4 animate-spin mr-2" />
+        ) : (
+          <Trash2 className="h-4 w-4 mr-2" />
+        )}
+        Revoke
+      </Button>
+    </div>
+  );
+}
@@ -0,0 +1,160 @@
+import { useState } from "react";
+import { useMutation, useQueryClient } from "@tanstack/react-query";
+import { Check, ExternalLink, Loader2, Server, Unlink } from "lucide-react";
+import { Button } from "@/components/ui/button";
+import {
+  Card,
+  CardContent,
+  CardDescription,
+  CardHeader,
+  CardTitle,
+} from "@/components/ui/card";
+import { Input } from "@/components/ui/input";
+import {
+  CLOUD_PROVIDERS,
+  type CloudProviderInfo,
+} from "@/constants/cloud-providers";
+import {
+  CLOUD_CREDENTIALS_QUERY_KEY,
+  useCloudCredentials,
+} from "@/features/servers/hooks/use-servers";
+import { api } from "@/services/api";
+import type { CloudCredential } from "@/types";
+
+export function CloudProviders() {
+  const { data: credentials, isLoading } = useCloudCredentials();
+
+  return (
+    <Card>
+      <CardHeader>
+        <CardTitle className="flex items-center gap-2">
+          <Server className="h-5 w-5" />
+          Cloud Providers
+        </CardTitle>
+        <CardDescription>
+          Save provider credentials to create and provision servers in one
+          step from the Servers page
+        </CardDescription>
+      </CardHeader>
+      <CardContent className="space-y-4">
+        {isLoading ? (
+          <Loader2 className="h-6 w-6 animate-spin text-muted-foreground" />
+        ) : (
+          CLOUD_PROVIDERS.map((provider) => (
+            <CloudProviderRow
+              key={provider.id}
+              provider={provider}
+              credential={credentials?.find((c) => c.provider === provider.id)}
+            />
+          ))
+        )}
+      </CardContent>
+    </Card>
+  );
+}
+
+interface CloudProviderRowProps {
+  readonly provider: CloudProviderInfo;
+  readonly credential?: CloudCredential;
+}
+
+function CloudProviderRow({ provider, credential }: CloudProviderRowProps) {
+  const queryClient = useQueryClient();
+  const [value, setValue] = useState("");
+
+  const invalidate = () =>
+    queryClient.invalidateQueries({ queryKey: CLOUD_CREDENTIALS_QUERY_KEY });
+
+  const saveMutation = useMutation({
+    mutationFn: (credentials: string) =>
+      api.cloud.saveCredentials(provider.id, credentials),
+    onSuccess: () => {
+      setValue("");
+      void invalidate();
+    },
+  });
+
+  const deleteMutation = useMutation({
+    mutationFn: () => api.cloud.deleteCredentials(provider.id),
+    onSuccess: () => void invalidate(),
+  });
+
+  const handleSave = (e: React.FormEvent) => {
+    e.preventDefault();
+    if (value.trim()) {
+      saveMutation.mutate(value.trim());
+    }
+  };
+
+  return (
+    <div className="space-y-2 rounded-lg border p-3 sm:p-4">
+      <div className="flex items-center justify-between gap-3">
+        <p className="font-medium text-sm">{provider.label}</p>
+        {credential != null && (
+          <div className="flex items-center gap-2">
+            <span className="flex items-center gap-1 text-xs text-green-600 dark:text-green-400">
+              <Check className="h-3.5 w-3.5" />
+              Connected
+            </span>
+            <Button
+              variant="outline"
+              size="sm"
+              onClick={() => deleteMutation.mutate()}
+              disabled={deleteMutation.isPending}
+            >
+              {deleteMutation.isPending ? (
+                <Loader2 className="h-4 w-4 animate-spin mr-2" />
+              ) : (
+                <Unlink className="h-4 w-4 mr-2" />
+              )}
+              Remove
+            </Button>
+          </div>
+        )}
+      </div>
+
+      <form onSubmit={handleSave} className="flex flex-col sm:flex-row gap-2">
+        <Input
+          type="password"
+          placeholder={
+            credential != null
+              ? `Replace ${provider.credentialLabel}`
+              : provider.credentialLabel
+          }
+          value={value}
+          onChange={(e) => setValue(e.target.value)}
+          disabled={saveMutation.isPending}
+        />
+        <Button
+          type="submit"
+          size="sm"
+          disabled={!value.trim() || saveMutation.isPending}
+        >
+          {saveMutation.isPending && (
+            <Loader2 className="h-4 w-4 animate-spin mr-2" />
+          )}
+          Save
+        </Button>
+      </form>
+      <p className="text-xs text-muted-foreground">
+        {provider.credentialHint}{" "}
+        <a
+          href={provider.tokenUrl}
+          target="_blank"
+          rel="noopener noreferrer"
+          className="text-primary hover:underline inline-flex items-center gap-1"
+        >
+          Open console
+          <ExternalLink className="h-3 w-3" />
+        </a>
+      </p>
+      {saveMutation.isError && (
+        <p className="text-sm text-destructive">
+          {saveMutation.error instanceof Error
+            ? saveMutation.error.message
+            : "Failed to save credentials"}
+        </p>
+      )}
+    </div>
+  );
+}
@@ -37,6 +37,7 @@ export function CloudflareConnection() {
     mutationFn: (token: string) => api.cloudflare.connect(token),
     onSuccess: () => {
       queryClient.invalidateQueries({ queryKey: ["cloudflare-status"] });
+      queryClient.invalidateQueries({ queryKey: ["dns-connections"] });
       setApiToken("");
       setShowTokenInput(false);
     },
@@ -46,6 +47,7 @@ export function CloudflareConnection() {
     mutationFn: () => api.cloudflare.disconnect(),
     onSuccess: () => {
       queryClient.invalidateQueries({ queryKey: ["cloudflare-status"] });
+      queryClient.invalidateQueries({ queryKey: ["dns-connections"] });
     },
   });
 
@@ -0,0 +1,158 @@
+import { useState } from "react";
+import { useMutation, useQuery, useQueryClient } from "@tanstack/react-query";
+import { Check, ExternalLink, Globe, Loader2, Unlink } from "lucide-react";
+import { Button } from "@/components/ui/button";
+import {
+  Card,
+  CardContent,
+  CardDescription,
+  CardHeader,
+  CardTitle,
+} from "@/components/ui/card";
+import { Input } from "@/components/ui/input";
+import { DNS_PROVIDERS, type DnsProviderInfo } from "@/constants/dns-providers";
+import { api } from "@/services/api";
+import type { DnsConnection } from "@/types";
+
+const DNS_CONNECTIONS_QUERY_KEY = ["dns-connections"] as const;
+
+export function DnsProviders() {
+  const { data: connections, isLoading } = useQuery({
+    queryKey: DNS_CONNECTIONS_QUERY_KEY,
+    queryFn: () => api.dnsConnections.list(),
+  });
+
+  return (
+    <Card>
+      <CardHeader>
+        <CardTitle className="flex items-center gap-2">
+          <Globe className="h-5 w-5" />
+          DNS Providers
+        </CardTitle>
+        <CardDescription>
+          Connect other DNS providers to create custom domain records outside
+          Cloudflare
+        </CardDescription>
+      </CardHeader>
+      <CardContent className="space-y-4">
+        {isLoading ? (
+          <Loader2 className="h-6 w-6 animate-spin text-muted-foreground" />
+        ) : (
+          DNS_PROVIDERS.map((provider) => (
+            <DnsProviderRow
+              key={provider.id}
+              provider={provider}
+              connection={connections?.find((c) => c.provider === provider.id)}
+            />
+          ))
+        )}
+      </CardContent>
+    </Card>
+  );
+}
+
+interface DnsProviderRowProps {
+  readonly provider: DnsProviderInfo;
+  readonly connection?: DnsConnection;
+}
+
+function DnsProviderRow({ provider, connection }: DnsProviderRowProps) {
+  const queryClient = useQueryClient();
+  const [value, setValue] = useState("");
+
+  const invalidate = () =>
+    queryClient.invalidateQueries({ queryKey: DNS_CONNECTIONS_QUERY_KEY });
+
+  const saveMutation = useMutation({
+    mutationFn: (credentials: string) =>
+      api.dnsConnections.save(provider.id, credentials),
+    onSuccess: () => {
+      setValue("");
+      void invalidate();
+    },
+  });
+
+  const deleteMutation = useMutation({
+    mutationFn: () => api.dnsConnections.remove(provider.id),
+    onSuccess: () => void invalidate(),
+  });
+
+  const handleSave = (e: React.FormEvent) => {
+    e.preventDefault();
+    if (value.trim()) {
+      saveMutation.mutate(value.trim());
+    }
+  };
+
+  return (
+    <div className="space-y-2 rounded-lg border p-3 sm:p-4">
+      <div className="flex items-center justify-between gap-3">
+        <p className="font-medium text-sm">{provider.label}</p>
+        {connection != null && (
+          <div className="flex items-center gap-2">
+            <span className="flex items-center gap-1 text-xs text-green-600 dark:text-green-400">
+              <Check className="h-3.5 w-3.5" />
+              Connected
+            </span>
+            <Button
+              variant="outline"
+              size="sm"
+              onClick={() => deleteMutation.mutate()}
+              disabled={deleteMutation.isPending}
+            >
+              {deleteMutation.isPending ? (
+                <Loader2 className="h-4 w-4 animate-spin mr-2" />
+              ) : (
+                <Unlink className="h-4 w-4 mr-2" />
+              )}
+              Remove
+            </Button>
+          </div>
+        )}
+      </div>
+
+      <form onSubmit={handleSave} className="flex flex-col sm:flex-row gap-2">
+        <Input
+          type="password"
+          placeholder={
+            connection != null
+              ? `Replace ${provider.credentialLabel}`
+              : provider.credentialLabel
+          }
+          value={value}
+          onChange={(e) => setValue(e.target.value)}
+          disabled={saveMutation.isPending}
+        />
+        <Button
+          type="submit"
+          size="sm"
+          disabled={!value.trim() || saveMutation.isPending}
+        >
+          {saveMutation.isPending && (
+            <Loader2 className="h-4 w-4 animate-spin mr-2" />
+          )}
+          Save
+        </Button>
+      </form>
+      <p className="text-xs text-muted-foreground">
+        {provider.credentialHint}{" "}
+        <a
+          href={provider.tokenUrl}
+          target="_blank"
+          rel="noopener noreferrer"
+          className="text-primary hover:underline inline-flex items-center gap-1"
+        >
+          Open console
+          <ExternalLink className="h-3 w-3" />
+        </a>
+      </p>
+      {saveMutation.isError && (
+        <p className="text-sm text-destructive">
+          {saveMutation.error instanceof Error
+            ? saveMutation.error.message
+            : "Failed to save credentials"}
+        </p>
+      )}
+    </div>
+  );
+}
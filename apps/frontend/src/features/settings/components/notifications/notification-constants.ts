@@ -17,7 +17,12 @@ export const EVENT_TYPES: {
   { value: "deploy_success", label: "Deploy success" },
   { value: "deploy_failed", label: "Deploy failed" },
   { value: "container_down", label: "Container down" },
+  { value: "container_oom", label: "Container out of memory" },
+  { value: "container_crash_loop", label: "Container crash loop" },
   { value: "health_unhealthy", label: "Health unhealthy" },
+  { value: "server_offline", label: "Server offline" },
+  { value: "certificate_expiring", label: "Certificate expiring" },
+  { value: "server_task_failed", label: "Server task failed" },
 ];
 
 export function getEventTypeLabel(eventType: string): string {
@@ -8,10 +8,13 @@ import { api } from "@/services/api";
 import { sseClient } from "@/services/sse";
 import type {
   App,
+  AppBatch,
   ContainerStats,
+  DeployProgress,
   DeployStatus,
   Deployment,
   HealthStatus,
+  ProvisionBatch,
   SSEEvent,
   ServerStats,
 } from "@/types";
@@ -109,6 +112,25 @@ function handleProvisionEvent(qc: QueryClient, event: SSEEvent) {
   }
 }
 
+function handleProvisionBatchEvent(qc: QueryClient, event: SSEEvent) {
+  if (!event.batch) return;
+  qc.setQueryData<ProvisionBatch>(
+    ["provision-batch", event.batch.id],
+    event.batch,
+  );
+  if (event.type === "PROVISION_BATCH_COMPLETED") {
+    qc.invalidateQueries({ queryKey: ["servers"] });
+  }
+}
+
+function handleAppBatchEvent(qc: QueryClient, event: SSEEvent) {
+  if (!event.appBatch) return;
+  qc.setQueryData<AppBatch>(["app-batch", event.appBatch.id], event.appBatch);
+  if (event.type === "APP_BATCH_COMPLETED") {
+    qc.invalidateQueries({ queryKey: ["apps"] });
+  }
+}
+
 function handleAgentUpdateEvent(qc: QueryClient, event: SSEEvent) {
   if (!event.serverId) return;
   applyAgentUpdateEvent(event.serverId, event);
@@ -135,6 +157,15 @@ export function useSSE() {
           handleLogEvent(queryClient, event);
           break;
 
+        case "PROGRESS":
+          if (event.progress && event.deployId) {
+            queryClient.setQueryData<DeployProgress>(
+              ["deploy-progress", event.deployId],
+              event.progress,
+            );
+          }
+          break;
+
         case "HEALTH":
           if (event.health) {
             queryClient.setQueryData<HealthStatus>(
@@ -184,6 +215,16 @@ export function useSSE() {
           handleProvisionEvent(queryClient, event);
           break;
 
+        case "PROVISION_BATCH_PROGRESS":
+        case "PROVISION_BATCH_COMPLETED":
+          handleProvisionBatchEvent(queryClient, event);
+          break;
+
+        case "APP_BATCH_PROGRESS":
+        case "APP_BATCH_COMPLETED":
+          handleAppBatchEvent(queryClient, event);
+          break;
+
         case "AGENT_UPDATE_STEP":
           handleAgentUpdateEvent(queryClient, event);
           break;
@@ -204,6 +245,16 @@ export function useSSE() {
   return { isConnected: sseClient.isConnected };
 }
 
+// useDeployProgress returns the latest progress the server reported for a
+// running deployment; it is only filled by SSE events.
+export function useDeployProgress(deployId: string | undefined) {
+  return useQuery<DeployProgress | null>({
+    queryKey: ["deploy-progress", deployId],
+    queryFn: () => null,
+    enabled: false,
+  });
+}
+
 export function useAppHealth(appId: string | undefined) {
   return useQuery<HealthStatus | null>({
     queryKey: ["app-health", appId],
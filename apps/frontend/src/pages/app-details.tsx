@@ -8,6 +8,7 @@ import { HealthIndicator } from "@/components/health-indicator";
 import { PageHeader } from "@/components/page-header";
 import { StatusBadge } from "@/components/status-badge";
 import {
+  AnalyticsSection,
   AppDescription,
   ContainerHealthSection,
   EnvVarsSection,
@@ -171,6 +172,14 @@ export function AppDetailsPage() {
           onToggle={() => toggleSection("envVars")}
         />
 
+        {app.serverId && (
+          <AnalyticsSection
+            appId={app.id}
+            expanded={expandedSections.analytics ?? false}
+            onToggle={() => toggleSection("analytics")}
+          />
+        )}
+
         <ContainerHealthSection
           appId={app.id}
           health={health}
@@ -194,6 +203,7 @@ export function AppDetailsPage() {
             config: expandedSections.config,
             webhook: expandedSections.webhook,
             domains: expandedSections.domains,
+            traffic: expandedSections.traffic,
             networks: expandedSections.networks,
             volumes: expandedSections.volumes,
           }}
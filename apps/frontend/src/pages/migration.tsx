@@ -22,8 +22,11 @@ import {
 } from "@/components/ui/card";
 import { Checkbox } from "@/components/ui/checkbox";
 import { PageHeader } from "@/components/page-header";
+import { ServerSelector } from "@/components/server-selector";
 import {
   ContainerRow,
+  MigrationPlanCard,
+  MigrationRollbackCard,
   NginxSiteCard,
   SSLCertificateRow,
   useBackupMutation,
@@ -34,16 +37,23 @@ import {
 } from "@/features/migration";
 
 export function MigrationPage() {
+  const [serverId, setServerId] = useState<string | undefined>();
   const [selectedContainers, setSelectedContainers] = useState<Set<string>>(
     new Set(),
   );
   const [expandedSites, setExpandedSites] = useState<Set<number>>(new Set());
 
-  const { data: status, isLoading, refetch } = useMigrationStatus();
-  const backupMutation = useBackupMutation();
-  const stopContainersMutation = useStopContainersMutation();
-  const startContainersMutation = useStartContainersMutation();
-  const stopNginxMutation = useStopNginxMutation();
+  const { data: status, isLoading, refetch } = useMigrationStatus(serverId);
+  const backupMutation = useBackupMutation(serverId);
+  const stopContainersMutation = useStopContainersMutation(serverId);
+  const startContainersMutation = useStartContainersMutation(serverId);
+  const stopNginxMutation = useStopNginxMutation(serverId);
+
+  const handleServerChange = (id: string | undefined) => {
+    setServerId(id);
+    setSelectedContainers(new Set());
+    setExpandedSites(new Set());
+  };
 
   const toggleSiteExpanded = (index: number) => {
     setExpandedSites((prev) => {
@@ -112,6 +122,7 @@ export function MigrationPage() {
   const nginxSites = status.nginxSites ?? [];
   const containers = status.containers ?? [];
   const sslCertificates = status.sslCertificates ?? [];
+  const changes = status.changes ?? [];
 
   return (
     <div className="space-y-4 sm:space-y-6">
@@ -121,10 +132,13 @@ export function MigrationPage() {
         description="Migrate from Nginx to Traefik"
         icon={Server}
         actions={
-          <Button variant="outline" size="sm" onClick={() => refetch()}>
-            <RefreshCw className="h-4 w-4 mr-2" />
-            Refresh
-          </Button>
+          <div className="flex items-center gap-2">
+            <ServerSelector value={serverId} onChange={handleServerChange} />
+            <Button variant="outline" size="sm" onClick={() => refetch()}>
+              <RefreshCw className="h-4 w-4 mr-2" />
+              Refresh
+            </Button>
+          </div>
         }
       />
 
@@ -151,6 +165,12 @@ export function MigrationPage() {
         isStoppingNginx={stopNginxMutation.isPending}
       />
 
+      <MigrationPlanCard key={serverId ?? "local"} serverId={serverId} />
+
+      {!serverId && changes.length > 0 && (
+        <MigrationRollbackCard changes={changes} />
+      )}
+
       <Card>
         <CardHeader>
           <CardTitle className="flex items-center gap-2">
@@ -174,6 +194,7 @@ export function MigrationPage() {
                 onToggle={() => toggleSiteExpanded(index)}
                 certificates={sslCertificates}
                 containers={containers}
+                serverId={serverId}
               />
             ))
           )}
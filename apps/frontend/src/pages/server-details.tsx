@@ -24,8 +24,16 @@ import {
   AgentVersionCard,
   ResourceUsageSection,
   ServerAppsSection,
+  ServerCertificatesSection,
+  ServerComposeSection,
+  ServerConsoleSection,
+  ServerEntrypointsSection,
+  ServerImportSection,
   ServerMaintenanceSection,
+  ServerMeshSection,
   ServerSettingsSection,
+  ServerTasksSection,
+  ServerTunnelSection,
   SystemInfoBar,
 } from "@/features/servers/components/server-details";
 import { useServerStats } from "@/features/servers/hooks/use-server-stats";
@@ -217,12 +225,20 @@ export function ServerDetailsPage() {
           <ImageList serverId={server.id} />
         </TabsContent>
 
-        <TabsContent value="maintenance">
+        <TabsContent value="maintenance" className="space-y-4">
+          <ServerConsoleSection server={server} />
           <ServerMaintenanceSection serverId={server.id} />
+          <ServerTasksSection server={server} />
+          <ServerCertificatesSection serverId={server.id} />
+          <ServerImportSection serverId={server.id} />
+          <ServerComposeSection serverId={server.id} />
         </TabsContent>
 
-        <TabsContent value="settings">
+        <TabsContent value="settings" className="space-y-4">
           <ServerSettingsSection server={server} onSaved={refetchAll} />
+          <ServerEntrypointsSection server={server} onSaved={refetchAll} />
+          <ServerMeshSection server={server} onSaved={refetchAll} />
+          <ServerTunnelSection serverId={server.id} />
         </TabsContent>
       </Tabs>
     </div>
@@ -4,6 +4,8 @@ import { HelpCircle, Server } from "lucide-react";
 import { Button } from "@/components/ui/button";
 import { PageHeader } from "@/components/page-header";
 import { AddServerDialog } from "@/features/servers/components/add-server-dialog";
+import { CreateCloudServerDialog } from "@/features/servers/components/create-cloud-server-dialog";
+import { ImportServersDialog } from "@/features/servers/components/import-servers-dialog";
 import { ServerList } from "@/features/servers/components/server-list";
 
 export function ServersPage() {
@@ -25,6 +27,8 @@ export function ServersPage() {
                 Setup guide
               </Link>
             </Button>
+            <ImportServersDialog />
+            <CreateCloudServerDialog />
             <AddServerDialog />
           </div>
         }
@@ -9,7 +9,10 @@ import {
   CardTitle,
 } from "@/components/ui/card";
 import { PageHeader } from "@/components/page-header";
+import { ApiTokens } from "@/features/settings/components/api-tokens";
+import { CloudProviders } from "@/features/settings/components/cloud-providers";
 import { CloudflareConnection } from "@/features/settings/components/cloudflare-connection";
+import { DnsProviders } from "@/features/settings/components/dns-providers";
 import { GitHubLinkCard } from "@/features/settings/components/github-link-card";
 import { NotificationSettings } from "@/features/settings/components/notification-settings";
 
@@ -26,7 +29,10 @@ export function SettingsPage() {
       <div className="space-y-6">
         <section>
           <h2 className="text-lg font-semibold mb-4">Account</h2>
-          <GitHubLinkCard />
+          <div className="space-y-6">
+            <GitHubLinkCard />
+            <ApiTokens />
+          </div>
         </section>
 
         <section>
@@ -57,6 +63,8 @@ export function SettingsPage() {
           <h2 className="text-lg font-semibold mb-4">Integrations</h2>
           <div className="space-y-6">
             <CloudflareConnection />
+            <DnsProviders />
+            <CloudProviders />
             <NotificationSettings />
           </div>
         </section>
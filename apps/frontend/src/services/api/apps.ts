@@ -1,7 +1,14 @@
 import type {
+  AnalyticsPeriod,
   App,
+  AppBatch,
+  AppAnalytics,
   AppConfig,
+  AppRateLimit,
+  AppRedirect,
+  AppSecurityHeaders,
   AppURL,
+  BulkAppActionInput,
   BulkEnvVarInput,
   CommitInfo,
   ContainerActionResult,
@@ -11,11 +18,17 @@ import type {
   CreateEnvVarInput,
   CustomDomain,
   Deployment,
+  DnsProvider,
   EnvVar,
   HealthStatus,
+  HerokuImportInput,
+  HerokuImportPreview,
   UpdateAppInput,
+  UpdateDomainBasicAuthInput,
+  UploadDomainCertificateInput,
   WebhookSetupResult,
   WebhookStatus,
+  WwwPairing,
 } from "@/types";
 import { API_BASE, fetchApi, fetchApiDelete, fetchApiList } from "./client";
 
@@ -24,12 +37,24 @@ export const appsApi = {
 
   get: (id: string): Promise<App> => fetchApi<App>(`${API_BASE}/apps/${id}`),
 
+  bulkAction: (input: BulkAppActionInput): Promise<AppBatch> =>
+    fetchApi<AppBatch>(`${API_BASE}/apps/bulk-actions`, {
+      method: "POST",
+      body: JSON.stringify(input),
+    }),
+
+  bulkActionStatus: (batchId: string): Promise<AppBatch> =>
+    fetchApi<AppBatch>(`${API_BASE}/apps/bulk-actions/${batchId}`),
+
   health: (id: string): Promise<HealthStatus> =>
     fetchApi<HealthStatus>(`${API_BASE}/apps/${id}/health`),
 
   url: (id: string): Promise<AppURL> =>
     fetchApi<AppURL>(`${API_BASE}/apps/${id}/url`),
 
+  analytics: (id: string, period: AnalyticsPeriod): Promise<AppAnalytics> =>
+    fetchApi<AppAnalytics>(`${API_BASE}/apps/${id}/analytics?period=${period}`),
+
   config: (id: string): Promise<AppConfig> =>
     fetchApi<AppConfig>(`${API_BASE}/apps/${id}/config`),
 
@@ -39,18 +64,78 @@ export const appsApi = {
       body: JSON.stringify(input),
     }),
 
+  updateRateLimit: (id: string, input: AppRateLimit): Promise<App> =>
+    fetchApi<App>(`${API_BASE}/apps/${id}/rate-limit`, {
+      method: "PUT",
+      body: JSON.stringify(input),
+    }),
+
+  removeRateLimit: (id: string): Promise<App> =>
+    fetchApi<App>(`${API_BASE}/apps/${id}/rate-limit`, {
+      method: "DELETE",
+    }),
+
+  updateRedirects: (
+    id: string,
+    redirects: readonly AppRedirect[],
+  ): Promise<App> =>
+    fetchApi<App>(`${API_BASE}/apps/${id}/redirects`, {
+      method: "PUT",
+      body: JSON.stringify({ redirects }),
+    }),
+
+  updateSecurityHeaders: (
+    id: string,
+    input: AppSecurityHeaders,
+  ): Promise<App> =>
+    fetchApi<App>(`${API_BASE}/apps/${id}/security-headers`, {
+      method: "PUT",
+      body: JSON.stringify(input),
+    }),
+
+  removeSecurityHeaders: (id: string): Promise<App> =>
+    fetchApi<App>(`${API_BASE}/apps/${id}/security-headers`, {
+      method: "DELETE",
+    }),
+
+  updateInternal: (id: string, internal: boolean): Promise<App> =>
+    fetchApi<App>(`${API_BASE}/apps/${id}/internal`, {
+      method: "PUT",
+      body: JSON.stringify({ internal }),
+    }),
+
+  updateLinks: (id: string, appIds: readonly string[]): Promise<App> =>
+    fetchApi<App>(`${API_BASE}/apps/${id}/links`, {
+      method: "PUT",
+      body: JSON.stringify({ appIds }),
+    }),
+
   create: (input: CreateAppInput): Promise<App> =>
     fetchApi<App>(`${API_BASE}/apps`, {
       method: "POST",
       body: JSON.stringify(input),
     }),
 
+  previewHerokuImport: (
+    input: HerokuImportInput,
+  ): Promise<HerokuImportPreview> =>
+    fetchApi<HerokuImportPreview>(`${API_BASE}/apps/import/heroku/preview`, {
+      method: "POST",
+      body: JSON.stringify(input),
+    }),
+
   delete: (id: string): Promise<void> =>
     fetchApiDelete(`${API_BASE}/apps/${id}`),
 
   purge: (id: string): Promise<void> =>
     fetchApiDelete(`${API_BASE}/apps/${id}?purge=true`),
 
+  trash: (): Promise<readonly App[]> =>
+    fetchApiList<App>(`${API_BASE}/apps/trash`),
+
+  restore: (id: string): Promise<App> =>
+    fetchApi<App>(`${API_BASE}/apps/${id}/restore`, { method: "POST" }),
+
   commits: (id: string, limit = 20): Promise<readonly CommitInfo[]> =>
     fetchApiList<CommitInfo>(`${API_BASE}/apps/${id}/commits?limit=${limit}`),
 };
@@ -69,6 +154,9 @@ export const deploymentsApi = {
     fetchApi<Deployment>(`${API_BASE}/apps/${appId}/rollback`, {
       method: "POST",
     }),
+
+  logsUrl: (appId: string, deployId: string): string =>
+    `${API_BASE}/apps/${appId}/deployments/${deployId}/logs`,
 };
 
 export const containerApi = {
@@ -156,12 +244,59 @@ export const domainsApi = {
     appId: string,
     domain: string,
     pathPrefix?: string,
+    dnsProvider?: DnsProvider,
+    wwwPairing?: WwwPairing,
   ): Promise<CustomDomain> =>
     fetchApi<CustomDomain>(`${API_BASE}/apps/${appId}/domains`, {
       method: "POST",
-      body: JSON.stringify({ domain, pathPrefix }),
+      body: JSON.stringify({ domain, pathPrefix, dnsProvider, wwwPairing }),
     }),
 
   remove: (appId: string, domainId: string): Promise<void> =>
     fetchApiDelete(`${API_BASE}/apps/${appId}/domains/${domainId}`),
+
+  updateBasicAuth: (
+    appId: string,
+    domainId: string,
+    input: UpdateDomainBasicAuthInput,
+  ): Promise<CustomDomain> =>
+    fetchApi<CustomDomain>(
+      `${API_BASE}/apps/${appId}/domains/${domainId}/basic-auth`,
+      {
+        method: "PUT",
+        body: JSON.stringify(input),
+      },
+    ),
+
+  updateIPAllowlist: (
+    appId: string,
+    domainId: string,
+    entries: readonly string[],
+  ): Promise<CustomDomain> =>
+    fetchApi<CustomDomain>(
+      `${API_BASE}/apps/${appId}/domains/${domainId}/ip-allowlist`,
+      {
+        method: "PUT",
+        body: JSON.stringify({ entries }),
+      },
+    ),
+
+  uploadCertificate: (
+    appId: string,
+    domainId: string,
+    input: UploadDomainCertificateInput,
+  ): Promise<CustomDomain> =>
+    fetchApi<CustomDomain>(
+      `${API_BASE}/apps/${appId}/domains/${domainId}/certificate`,
+      {
+        method: "PUT",
+        body: JSON.stringify(input),
+      },
+    ),
+
+  removeCertificate: (appId: string, domainId: string): Promise<CustomDomain> =>
+    fetchApi<CustomDomain>(
+      `${API_BASE}/apps/${appId}/domains/${domainId}/certificate`,
+      { method: "DELETE" },
+    ),
 };
@@ -1,5 +1,11 @@
 import type { User } from "@/contexts/auth-context";
-import { API_URL, fetchApi, fetchApiList } from "./client";
+import {
+  API_BASE,
+  API_URL,
+  fetchApi,
+  fetchApiDelete,
+  fetchApiList,
+} from "./client";
 
 export interface RegisterInput {
   readonly email: string;
@@ -43,6 +49,24 @@ export interface ReposResponse {
   readonly installMessage?: string;
 }
 
+export interface APIToken {
+  readonly id: string;
+  readonly name: string;
+  readonly tokenPrefix: string;
+  readonly lastUsedAt?: string;
+  readonly expiresAt?: string;
+  readonly createdAt: string;
+}
+
+export interface CreatedAPIToken extends APIToken {
+  readonly token: string;
+}
+
+export interface CreateAPITokenInput {
+  readonly name: string;
+  readonly expiresInDays: number;
+}
+
 export const authApi = {
   me: (): Promise<User> => fetchApi<User>(`${API_URL}/auth/me`),
 
@@ -71,6 +95,20 @@ export const authApi = {
   },
 };
 
+export const apiTokensApi = {
+  list: (): Promise<readonly APIToken[]> =>
+    fetchApiList<APIToken>(`${API_BASE}/api-tokens`),
+
+  create: (input: CreateAPITokenInput): Promise<CreatedAPIToken> =>
+    fetchApi<CreatedAPIToken>(`${API_BASE}/api-tokens`, {
+      method: "POST",
+      body: JSON.stringify(input),
+    }),
+
+  revoke: (id: string): Promise<void> =>
+    fetchApiDelete(`${API_BASE}/api-tokens/${id}`),
+};
+
 export const githubApi = {
   installations: (): Promise<readonly GitHubInstallation[]> =>
     fetchApiList<GitHubInstallation>(`${API_URL}/api/github/installations`),
@@ -1,4 +1,19 @@
-import type { Container, ContainerLogs, CreateContainerInput } from "@/types";
+import type {
+  ApiEnvelope,
+  CommitContainerInput,
+  CommitContainerResult,
+  Container,
+  ContainerDetails,
+  ContainerFile,
+  ContainerFileList,
+  ContainerFileUploadResult,
+  ContainerLogs,
+  ContainerProcess,
+  CreateContainerInput,
+  DockerNetworkContainer,
+  VolumeFileList,
+} from "@/types";
+import { ApiError, isApiError } from "@/types";
 import {
   API_BASE,
   API_URL,
@@ -25,7 +40,9 @@ export interface DockerNetworkInfo {
   readonly driver: string;
   readonly scope: string;
   readonly internal: boolean;
-  readonly containers: readonly string[];
+  readonly subnet: string;
+  readonly gateway: string;
+  readonly containers: readonly DockerNetworkContainer[];
 }
 
 export interface DockerVolumeInfo {
@@ -46,6 +63,8 @@ function normalizeNetwork(
       driver: "unknown",
       scope: "unknown",
       internal: false,
+      subnet: "",
+      gateway: "",
       containers: [],
     };
   }
@@ -55,6 +74,8 @@ function normalizeNetwork(
     driver: item.driver ?? "unknown",
     scope: item.scope ?? "unknown",
     internal: item.internal ?? false,
+    subnet: item.subnet ?? "",
+    gateway: item.gateway ?? "",
     containers: item.containers ?? [],
   };
 }
@@ -86,8 +107,10 @@ export const containersApi = {
       buildUrl(`${API_BASE}/containers`, { all, serverId }),
     ),
 
-  get: (id: string): Promise<Container> =>
-    fetchApi<Container>(`${API_BASE}/containers/${id}`),
+  get: (id: string, serverId?: string): Promise<ContainerDetails> =>
+    fetchApi<ContainerDetails>(
+      buildUrl(`${API_BASE}/containers/${id}`, { serverId }),
+    ),
 
   create: (input: CreateContainerInput): Promise<Container> =>
     fetchApi<Container>(`${API_BASE}/containers`, {
@@ -138,6 +161,72 @@ export const containersApi = {
       serverId,
     }),
 
+  top: (id: string, serverId?: string): Promise<readonly ContainerProcess[]> =>
+    fetchApiList<ContainerProcess>(
+      buildUrl(`${API_BASE}/containers/${id}/top`, { serverId }),
+    ),
+
+  commit: (
+    id: string,
+    input: CommitContainerInput,
+    serverId?: string,
+  ): Promise<CommitContainerResult> =>
+    fetchApi<CommitContainerResult>(
+      buildUrl(`${API_BASE}/containers/${id}/commit`, { serverId }),
+      { method: "POST", body: JSON.stringify(input) },
+    ),
+
+  files: (
+    id: string,
+    path: string,
+    serverId?: string,
+  ): Promise<ContainerFileList> =>
+    fetchApi<ContainerFileList>(
+      buildUrl(`${API_BASE}/containers/${id}/files`, { path, serverId }),
+    ),
+
+  downloadFile: async (
+    id: string,
+    path: string,
+    serverId?: string,
+  ): Promise<Blob> => {
+    const response = await fetch(
+      buildUrl(`${API_BASE}/containers/${id}/files/download`, {
+        path,
+        serverId,
+      }),
+      { credentials: "include" },
+    );
+    if (!response.ok) {
+      const envelope: ApiEnvelope<null> = await response.json();
+      throw ApiError.fromResponse(envelope, response.status);
+    }
+    return response.blob();
+  },
+
+  uploadFile: async (
+    id: string,
+    dir: string,
+    file: File,
+    serverId?: string,
+  ): Promise<ContainerFileUploadResult> => {
+    const body = new FormData();
+    body.append("file", file);
+    const response = await fetch(
+      buildUrl(`${API_BASE}/containers/${id}/files/upload`, {
+        path: dir,
+        serverId,
+      }),
+      { method: "POST", body, credentials: "include" },
+    );
+    const envelope: ApiEnvelope<ContainerFileUploadResult> =
+      await response.json();
+    if (!response.ok || isApiError(envelope)) {
+      throw ApiError.fromResponse(envelope, response.status);
+    }
+    return envelope.data as ContainerFileUploadResult;
+  },
+
   consoleUrl: (id: string, shell = "sh", serverId?: string): string => {
     const base = API_URL.replace(/^http/, "ws");
     const params = new URLSearchParams({ shell });
@@ -278,4 +367,47 @@ export const volumesApi = {
         serverId,
       }),
     ),
+
+  files: (
+    name: string,
+    path: string,
+    serverId?: string,
+  ): Promise<VolumeFileList> =>
+    fetchApi<VolumeFileList>(
+      buildUrl(`${API_BASE}/volumes/${encodeURIComponent(name)}/files`, {
+        path,
+        serverId,
+      }),
+    ),
+
+  stat: (
+    name: string,
+    path: string,
+    serverId?: string,
+  ): Promise<ContainerFile> =>
+    fetchApi<ContainerFile>(
+      buildUrl(`${API_BASE}/volumes/${encodeURIComponent(name)}/files/stat`, {
+        path,
+        serverId,
+      }),
+    ),
+
+  downloadFile: async (
+    name: string,
+    path: string,
+    serverId?: string,
+  ): Promise<Blob> => {
+    const response = await fetch(
+      buildUrl(
+        `${API_BASE}/volumes/${encodeURIComponent(name)}/files/download`,
+        { path, serverId },
+      ),
+      { credentials: "include" },
+    );
+    if (!response.ok) {
+      const envelope: ApiEnvelope<null> = await response.json();
+      throw ApiError.fromResponse(envelope, response.status);
+    }
+    return response.blob();
+  },
 };
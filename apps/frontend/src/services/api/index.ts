@@ -6,7 +6,7 @@ import {
   envVarsApi,
   webhooksApi,
 } from "./apps";
-import { authApi, githubApi } from "./auth";
+import { apiTokensApi, authApi, githubApi } from "./auth";
 import {
   cleanupApi,
   containersApi,
@@ -18,14 +18,20 @@ import {
   certificatesApi,
   cloudflareApi,
   containerSSLApi,
+  dnsConnectionsApi,
+  domainVerificationsApi,
   migrationApi,
   templatesApi,
 } from "./infrastructure";
 import { notificationsApi } from "./notifications";
-import { serversApi } from "./servers";
+import { searchApi } from "./search";
+import { cloudApi, serversApi } from "./servers";
 import { systemApi } from "./system";
 
 export type {
+  APIToken,
+  CreateAPITokenInput,
+  CreatedAPIToken,
   GitHubInstallation,
   GitHubRepository,
   LoginInput,
@@ -45,6 +51,7 @@ export { API_BASE, API_URL } from "./client";
 
 export const api = {
   auth: authApi,
+  apiTokens: apiTokensApi,
   github: githubApi,
   apps: appsApi,
   deployments: deploymentsApi,
@@ -57,12 +64,16 @@ export const api = {
   networks: networksApi,
   volumes: volumesApi,
   servers: serversApi,
+  cloud: cloudApi,
   notifications: notificationsApi,
   cloudflare: cloudflareApi,
+  dnsConnections: dnsConnectionsApi,
+  domainVerifications: domainVerificationsApi,
   certificates: certificatesApi,
   migration: migrationApi,
   templates: templatesApi,
   system: systemApi,
+  search: searchApi,
   cleanup: cleanupApi,
   containerSSL: containerSSLApi,
 } as const;
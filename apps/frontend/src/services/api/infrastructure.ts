@@ -1,15 +1,30 @@
 import type {
   BackupResult,
+  CertificateActionResult,
   CertificateStatus,
   CloudflareStatus,
   Container,
   DeployTemplateInput,
+  DnsConnection,
+  DnsProvider,
+  DomainVerification,
   MigrateResult,
+  MigrationPlan,
   MigrationStatus,
+  RollbackResult,
+  Server,
+  ServerCertificates,
   Template,
   TraefikPreview,
 } from "@/types";
-import { API_BASE, API_URL, fetchApi, fetchApiList } from "./client";
+import {
+  API_BASE,
+  API_URL,
+  buildUrl,
+  fetchApi,
+  fetchApiDelete,
+  fetchApiList,
+} from "./client";
 
 export const cloudflareApi = {
   status: (): Promise<CloudflareStatus> =>
@@ -28,6 +43,43 @@ export const cloudflareApi = {
   },
 };
 
+export const dnsConnectionsApi = {
+  list: (): Promise<readonly DnsConnection[]> =>
+    fetchApiList<DnsConnection>(`${API_BASE}/dns-connections`),
+
+  save: (
+    provider: DnsProvider,
+    credentials: string,
+  ): Promise<DnsConnection> =>
+    fetchApi<DnsConnection>(`${API_BASE}/dns-connections/${provider}`, {
+      method: "PUT",
+      body: JSON.stringify({ credentials }),
+    }),
+
+  remove: (provider: DnsProvider): Promise<void> =>
+    fetchApiDelete(`${API_BASE}/dns-connections/${provider}`),
+};
+
+export const domainVerificationsApi = {
+  list: (): Promise<readonly DomainVerification[]> =>
+    fetchApiList<DomainVerification>(`${API_BASE}/domain-verifications`),
+
+  create: (domain: string): Promise<DomainVerification> =>
+    fetchApi<DomainVerification>(`${API_BASE}/domain-verifications`, {
+      method: "POST",
+      body: JSON.stringify({ domain }),
+    }),
+
+  check: (id: string): Promise<DomainVerification> =>
+    fetchApi<DomainVerification>(
+      `${API_BASE}/domain-verifications/${id}/check`,
+      { method: "POST" },
+    ),
+
+  remove: (id: string): Promise<void> =>
+    fetchApiDelete(`${API_BASE}/domain-verifications/${id}`),
+};
+
 export const certificatesApi = {
   list: (): Promise<readonly CertificateStatus[]> =>
     fetchApiList<CertificateStatus>(`${API_URL}/api/certificates`),
@@ -36,51 +88,122 @@ export const certificatesApi = {
     fetchApi<CertificateStatus>(
       `${API_URL}/api/certificates/${encodeURIComponent(domain)}`,
     ),
+
+  listForServer: (serverId: string): Promise<ServerCertificates> =>
+    fetchApi<ServerCertificates>(
+      `${API_URL}/api/certificates/servers/${serverId}`,
+    ),
+
+  renew: (
+    serverId: string,
+    domain: string,
+  ): Promise<CertificateActionResult> =>
+    fetchApi<CertificateActionResult>(
+      `${API_URL}/api/certificates/servers/${serverId}/renew`,
+      { method: "POST", body: JSON.stringify({ domain }) },
+    ),
+
+  remove: (
+    serverId: string,
+    domain: string,
+  ): Promise<CertificateActionResult> =>
+    fetchApi<CertificateActionResult>(
+      `${API_URL}/api/certificates/servers/${serverId}/${encodeURIComponent(domain)}`,
+      { method: "DELETE" },
+    ),
+
+  pruneStale: (serverId: string): Promise<CertificateActionResult> =>
+    fetchApi<CertificateActionResult>(
+      `${API_URL}/api/certificates/servers/${serverId}/prune`,
+      { method: "POST" },
+    ),
+
+  setStaging: (serverId: string, enabled: boolean): Promise<Server> =>
+    fetchApi<Server>(
+      `${API_URL}/api/certificates/servers/${serverId}/staging`,
+      { method: "PUT", body: JSON.stringify({ enabled }) },
+    ),
 };
 
 export const migrationApi = {
-  status: (): Promise<MigrationStatus> =>
-    fetchApi<MigrationStatus>(`${API_BASE}/migration/status`),
+  status: (serverId?: string): Promise<MigrationStatus> =>
+    fetchApi<MigrationStatus>(
+      buildUrl(`${API_BASE}/migration/status`, { serverId }),
+    ),
 
-  backup: (): Promise<BackupResult> =>
-    fetchApi<BackupResult>(`${API_BASE}/migration/backup`, {
-      method: "POST",
-    }),
+  plan: (serverId?: string): Promise<MigrationPlan> =>
+    fetchApi<MigrationPlan>(
+      buildUrl(`${API_BASE}/migration/plan`, { serverId }),
+      { method: "POST" },
+    ),
+
+  planMarkdown: async (serverId?: string): Promise<Blob> => {
+    const response = await fetch(
+      buildUrl(`${API_BASE}/migration/plan`, { format: "markdown", serverId }),
+      { method: "POST", credentials: "include" },
+    );
+    if (!response.ok) {
+      throw new Error("Failed to generate migration report");
+    }
+    return response.blob();
+  },
+
+  backup: (serverId?: string): Promise<BackupResult> =>
+    fetchApi<BackupResult>(
+      buildUrl(`${API_BASE}/migration/backup`, { serverId }),
+      { method: "POST" },
+    ),
 
   stopContainers: (
     containerIds: readonly string[],
+    serverId?: string,
   ): Promise<{ message: string; stopped: readonly string[] }> =>
     fetchApi<{ message: string; stopped: readonly string[] }>(
-      `${API_BASE}/migration/containers/stop`,
+      buildUrl(`${API_BASE}/migration/containers/stop`, { serverId }),
       { method: "POST", body: JSON.stringify({ containerIds }) },
     ),
 
   startContainers: (
     containerIds: readonly string[],
+    serverId?: string,
   ): Promise<{ message: string; started: readonly string[] }> =>
     fetchApi<{ message: string; started: readonly string[] }>(
-      `${API_BASE}/migration/containers/start`,
+      buildUrl(`${API_BASE}/migration/containers/start`, { serverId }),
       { method: "POST", body: JSON.stringify({ containerIds }) },
     ),
 
-  stopNginx: (): Promise<{ message: string }> =>
-    fetchApi<{ message: string }>(`${API_BASE}/migration/proxy/stop-nginx`, {
-      method: "POST",
-    }),
+  stopNginx: (serverId?: string): Promise<{ message: string }> =>
+    fetchApi<{ message: string }>(
+      buildUrl(`${API_BASE}/migration/proxy/stop-nginx`, { serverId }),
+      { method: "POST" },
+    ),
 
-  getTraefikConfig: (siteIndex: number): Promise<TraefikPreview> =>
+  getTraefikConfig: (
+    siteIndex: number,
+    serverId?: string,
+  ): Promise<TraefikPreview> =>
     fetchApi<TraefikPreview>(
-      `${API_BASE}/migration/sites/${siteIndex}/traefik`,
+      buildUrl(`${API_BASE}/migration/sites/${siteIndex}/traefik`, {
+        serverId,
+      }),
     ),
 
   migrateSite: (
     siteIndex: number,
     containerId: string,
+    serverId?: string,
   ): Promise<MigrateResult> =>
     fetchApi<MigrateResult>(
-      `${API_BASE}/migration/sites/${siteIndex}/migrate`,
+      buildUrl(`${API_BASE}/migration/sites/${siteIndex}/migrate`, {
+        serverId,
+      }),
       { method: "POST", body: JSON.stringify({ containerId }) },
     ),
+
+  rollback: (): Promise<RollbackResult> =>
+    fetchApi<RollbackResult>(`${API_BASE}/migration/rollback`, {
+      method: "POST",
+    }),
 };
 
 export interface SSLStatusResult {
@@ -0,0 +1,9 @@
+import type { SearchResult } from "@/types";
+import { API_BASE, fetchApiList } from "./client";
+
+export const searchApi = {
+  search: (query: string): Promise<readonly SearchResult[]> =>
+    fetchApiList<SearchResult>(
+      `${API_BASE}/search?q=${encodeURIComponent(query)}`,
+    ),
+};
@@ -1,11 +1,31 @@
 import type {
+  AgentInstallMethod,
   AgentUpdateMode,
   App,
+  CloudCredential,
+  CloudProvider,
+  ComposeProject,
+  CreateCloudServerInput,
   CreateServerInput,
+  DiscoveredApp,
+  ImportAppInput,
+  ImportAppResult,
+  ImportPlatform,
+  ProvisionBatch,
   Server,
+  ServerEntrypoint,
   ServerStats,
+  ServerTask,
+  ServerTaskInput,
+  ServerTaskRun,
 } from "@/types";
-import { API_BASE, fetchApi, fetchApiDelete, fetchApiList } from "./client";
+import {
+  API_BASE,
+  API_URL,
+  fetchApi,
+  fetchApiDelete,
+  fetchApiList,
+} from "./client";
 
 export const serversApi = {
   list: (): Promise<readonly Server[]> =>
@@ -14,6 +34,46 @@ export const serversApi = {
   get: (id: string): Promise<Server> =>
     fetchApi<Server>(`${API_BASE}/servers/${id}`),
 
+  consoleUrl: (id: string): string => {
+    const base = API_URL.replace(/^http/, "ws");
+    return `${base}/paas-deploy/v1/servers/${id}/console`;
+  },
+
+  listTasks: (id: string): Promise<readonly ServerTask[]> =>
+    fetchApiList<ServerTask>(`${API_BASE}/servers/${id}/tasks`),
+
+  createTask: (id: string, input: ServerTaskInput): Promise<ServerTask> =>
+    fetchApi<ServerTask>(`${API_BASE}/servers/${id}/tasks`, {
+      method: "POST",
+      body: JSON.stringify(input),
+    }),
+
+  updateTask: (
+    id: string,
+    taskId: string,
+    input: ServerTaskInput,
+  ): Promise<ServerTask> =>
+    fetchApi<ServerTask>(`${API_BASE}/servers/${id}/tasks/${taskId}`, {
+      method: "PATCH",
+      body: JSON.stringify(input),
+    }),
+
+  deleteTask: (id: string, taskId: string): Promise<void> =>
+    fetchApiDelete(`${API_BASE}/servers/${id}/tasks/${taskId}`),
+
+  runTask: (id: string, taskId: string): Promise<ServerTaskRun> =>
+    fetchApi<ServerTaskRun>(`${API_BASE}/servers/${id}/tasks/${taskId}/run`, {
+      method: "POST",
+    }),
+
+  listTaskRuns: (
+    id: string,
+    taskId: string,
+  ): Promise<readonly ServerTaskRun[]> =>
+    fetchApiList<ServerTaskRun>(
+      `${API_BASE}/servers/${id}/tasks/${taskId}/runs`,
+    ),
+
   create: (input: CreateServerInput): Promise<Server> =>
     fetchApi<Server>(`${API_BASE}/servers`, {
       method: "POST",
@@ -31,6 +91,11 @@ export const serversApi = {
       sshPassword?: string;
       acmeEmail?: string;
       agentUpdateMode?: AgentUpdateMode;
+      agentInstallMethod?: AgentInstallMethod;
+      dockerRootless?: boolean;
+      firewallEnabled?: boolean;
+      sshHardening?: boolean;
+      bastionServerId?: string;
     },
   ): Promise<Server> =>
     fetchApi<Server>(`${API_BASE}/servers/${id}`, {
@@ -46,6 +111,45 @@ export const serversApi = {
       method: "POST",
     }),
 
+  bulkCreate: (
+    servers: readonly CreateServerInput[],
+    provision: boolean,
+  ): Promise<BulkCreateServersResponse> =>
+    fetchApi<BulkCreateServersResponse>(`${API_BASE}/servers/bulk`, {
+      method: "POST",
+      body: JSON.stringify({ servers, provision }),
+    }),
+
+  bulkCreateCsv: (
+    csv: string,
+    provision: boolean,
+  ): Promise<BulkCreateServersResponse> =>
+    fetchApi<BulkCreateServersResponse>(
+      `${API_BASE}/servers/bulk?provision=${provision}`,
+      {
+        method: "POST",
+        headers: { "Content-Type": "text/csv" },
+        body: csv,
+      },
+    ),
+
+  bulkProvision: (serverIds: readonly string[]): Promise<ProvisionBatch> =>
+    fetchApi<ProvisionBatch>(`${API_BASE}/servers/bulk-provision`, {
+      method: "POST",
+      body: JSON.stringify({ serverIds }),
+    }),
+
+  provisionBatch: (batchId: string): Promise<ProvisionBatch> =>
+    fetchApi<ProvisionBatch>(
+      `${API_BASE}/servers/provision-batches/${batchId}`,
+    ),
+
+  provisionPlan: (id: string): Promise<ProvisionPlan> =>
+    fetchApi<ProvisionPlan>(
+      `${API_BASE}/servers/${id}/provision?dryRun=true`,
+      { method: "POST" },
+    ),
+
   getStats: (id: string): Promise<ServerStats> =>
     fetchApi<ServerStats>(`${API_BASE}/servers/${id}/stats`),
 
@@ -62,8 +166,187 @@ export const serversApi = {
       method: "POST",
       body: JSON.stringify({ action }),
     }),
+
+  bootstrapScript: (id: string, ttl?: string): Promise<BootstrapScript> =>
+    fetchApi<BootstrapScript>(
+      `${API_BASE}/servers/${id}/bootstrap-script${ttl ? `?ttl=${encodeURIComponent(ttl)}` : ""}`,
+      { method: "POST" },
+    ),
+
+  firewall: (id: string): Promise<ServerFirewall> =>
+    fetchApi<ServerFirewall>(`${API_BASE}/servers/${id}/firewall`),
+
+  applyFirewall: (id: string): Promise<ServerFirewall> =>
+    fetchApi<ServerFirewall>(`${API_BASE}/servers/${id}/firewall/apply`, {
+      method: "POST",
+    }),
+
+  drift: (id: string): Promise<DriftReport> =>
+    fetchApi<DriftReport>(`${API_BASE}/servers/${id}/drift`),
+
+  tunnel: (id: string): Promise<ServerTunnel> =>
+    fetchApi<ServerTunnel>(`${API_BASE}/servers/${id}/tunnel`),
+
+  enableTunnel: (id: string): Promise<ServerTunnel> =>
+    fetchApi<ServerTunnel>(`${API_BASE}/servers/${id}/tunnel`, {
+      method: "POST",
+    }),
+
+  disableTunnel: (id: string): Promise<ServerTunnel> =>
+    fetchApi<ServerTunnel>(`${API_BASE}/servers/${id}/tunnel`, {
+      method: "DELETE",
+    }),
+
+  updateEntrypoints: (
+    id: string,
+    entrypoints: readonly ServerEntrypoint[],
+  ): Promise<Server> =>
+    fetchApi<Server>(`${API_BASE}/servers/${id}/entrypoints`, {
+      method: "PUT",
+      body: JSON.stringify({ entrypoints }),
+    }),
+
+  enableMesh: (id: string): Promise<Server> =>
+    fetchApi<Server>(`${API_BASE}/servers/${id}/mesh`, {
+      method: "POST",
+    }),
+
+  disableMesh: (id: string): Promise<Server> =>
+    fetchApi<Server>(`${API_BASE}/servers/${id}/mesh`, {
+      method: "DELETE",
+    }),
+
+  discoverApps: (
+    id: string,
+    platform: ImportPlatform,
+  ): Promise<readonly DiscoveredApp[]> =>
+    fetchApiList<DiscoveredApp>(
+      `${API_BASE}/servers/${id}/import/${platform}`,
+    ),
+
+  importApps: (
+    id: string,
+    platform: ImportPlatform,
+    apps: readonly ImportAppInput[],
+  ): Promise<readonly ImportAppResult[]> =>
+    fetchApiList<ImportAppResult>(
+      `${API_BASE}/servers/${id}/import/${platform}`,
+      {
+        method: "POST",
+        body: JSON.stringify({ apps }),
+      },
+    ),
+
+  getComposeProject: (id: string, path: string): Promise<ComposeProject> =>
+    fetchApi<ComposeProject>(
+      `${API_BASE}/servers/${id}/compose?path=${encodeURIComponent(path)}`,
+    ),
+
+  adoptComposeProject: (
+    id: string,
+    path: string,
+    apps: readonly ImportAppInput[],
+  ): Promise<readonly ImportAppResult[]> =>
+    fetchApiList<ImportAppResult>(`${API_BASE}/servers/${id}/compose/adopt`, {
+      method: "POST",
+      body: JSON.stringify({ path, apps }),
+    }),
+
+  generateSshKey: (id: string): Promise<{ publicKey: string }> =>
+    fetchApi<{ publicKey: string }>(`${API_BASE}/servers/${id}/ssh-key`, {
+      method: "POST",
+    }),
+};
+
+export const cloudApi = {
+  credentials: (): Promise<readonly CloudCredential[]> =>
+    fetchApiList<CloudCredential>(`${API_BASE}/cloud-credentials`),
+
+  saveCredentials: (
+    provider: CloudProvider,
+    credentials: string,
+  ): Promise<CloudCredential> =>
+    fetchApi<CloudCredential>(`${API_BASE}/cloud-credentials/${provider}`, {
+      method: "PUT",
+      body: JSON.stringify({ credentials }),
+    }),
+
+  deleteCredentials: (provider: CloudProvider): Promise<void> =>
+    fetchApiDelete(`${API_BASE}/cloud-credentials/${provider}`),
+
+  createServer: (input: CreateCloudServerInput): Promise<Server> =>
+    fetchApi<Server>(`${API_BASE}/servers/cloud`, {
+      method: "POST",
+      body: JSON.stringify(input),
+    }),
 };
 
+export interface BootstrapScript {
+  readonly script: string;
+  readonly expiresAt: string;
+}
+
+export interface FirewallRule {
+  readonly port: number;
+  readonly protocol: string;
+  readonly source?: string;
+}
+
+export interface ServerFirewall {
+  readonly serverId: string;
+  readonly backend: "ufw" | "nftables";
+  readonly rules: readonly FirewallRule[];
+  readonly appliedAt: string;
+}
+
+export interface DriftItem {
+  readonly component: string;
+  readonly desired: string;
+  readonly actual: string;
+}
+
+export interface DriftReport {
+  readonly serverId: string;
+  readonly inSync: boolean;
+  readonly drift: readonly DriftItem[];
+  readonly checkedAt: string;
+}
+
+export interface ServerTunnel {
+  readonly enabled: boolean;
+  readonly tunnelId?: string;
+  readonly target?: string;
+  readonly createdAt?: string;
+}
+
+export interface BulkServerResult {
+  readonly index: number;
+  readonly name: string;
+  readonly host: string;
+  readonly serverId?: string;
+  readonly error?: string;
+}
+
+export interface BulkCreateServersResponse {
+  readonly results: readonly BulkServerResult[];
+  readonly created: number;
+  readonly failed: number;
+  readonly batch?: ProvisionBatch;
+}
+
+export interface PlannedStep {
+  readonly step: string;
+  readonly description: string;
+  readonly commands?: readonly string[];
+  readonly diff?: string;
+}
+
+export interface ProvisionPlan {
+  readonly serverId: string;
+  readonly steps: readonly PlannedStep[];
+  readonly generatedAt: string;
+}
+
 export interface ManageServerResponse {
   readonly success: boolean;
   readonly output: string;
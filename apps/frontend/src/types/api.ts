@@ -3,14 +3,26 @@ export type ErrorCode =
   | "UNAUTHORIZED"
   | "FORBIDDEN"
   | "NOT_FOUND"
+  | "METHOD_NOT_ALLOWED"
   | "CONFLICT"
+  | "PAYLOAD_TOO_LARGE"
+  | "UNPROCESSABLE"
   | "RATE_LIMITED"
-  | "INTERNAL_ERROR";
+  | "INTERNAL_ERROR"
+  | "BAD_GATEWAY"
+  | "SERVICE_UNAVAILABLE"
+  | "TIMEOUT";
 
-export interface ApiErrorInfo {
+/** RFC 7807 problem details, the body of every error response. */
+export interface ApiProblem {
+  readonly type: string;
+  readonly title: string;
+  readonly status: number;
+  readonly detail?: string;
+  readonly instance?: string;
   readonly code: ErrorCode;
-  readonly message: string;
-  readonly details?: Record<string, unknown>;
+  readonly traceId?: string;
+  readonly errors?: Record<string, unknown>;
 }
 
 export interface ApiPagination {
@@ -22,13 +34,13 @@ export interface ApiPagination {
 export interface ApiMeta {
   readonly traceId?: string;
   readonly pagination?: ApiPagination;
+  readonly nextCursor?: string;
   readonly warnings?: readonly string[];
 }
 
 export interface ApiEnvelope<T> {
   readonly success: boolean;
   readonly data: T | null;
-  readonly error: ApiErrorInfo | null;
   readonly meta: ApiMeta;
 }
 
@@ -36,9 +48,9 @@ export type ApiResponse<T> = ApiEnvelope<T>;
 export type ApiListResponse<T> = ApiEnvelope<readonly T[]>;
 
 export function isApiError<T>(
-  response: ApiEnvelope<T>,
-): response is ApiEnvelope<T> & { readonly error: ApiErrorInfo } {
-  return !response.success && response.error !== null;
+  response: ApiEnvelope<T> | ApiProblem,
+): response is ApiProblem {
+  return "code" in response && "status" in response;
 }
 
 export function isApiSuccess<T>(
@@ -59,22 +71,24 @@ export class ApiError extends Error {
     this.name = "ApiError";
   }
 
-  static fromResponse<T>(response: ApiEnvelope<T>, status: number): ApiError {
-    const error = response.error;
-    if (!error) {
+  static fromResponse<T>(
+    response: ApiEnvelope<T> | ApiProblem,
+    status: number,
+  ): ApiError {
+    if (!isApiError(response)) {
       return new ApiError(
         "INTERNAL_ERROR",
         "Unknown error",
         status,
-        response.meta.traceId,
+        response.meta?.traceId,
       );
     }
     return new ApiError(
-      error.code,
-      error.message,
+      response.code,
+      response.detail ?? response.title,
       status,
-      response.meta.traceId,
-      error.details as Record<string, unknown>,
+      response.traceId,
+      response.errors,
     );
   }
 }
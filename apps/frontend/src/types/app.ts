@@ -16,8 +16,70 @@ export interface App {
   readonly serverId?: string;
   readonly lastDeployedAt: string | null;
   readonly lastDeployment?: DeploymentSummary | null;
+  readonly rateLimit?: AppRateLimit;
+  readonly redirects: readonly AppRedirect[] | null;
+  readonly securityHeaders?: AppSecurityHeaders;
+  readonly internal: boolean;
+  readonly maintenance?: AppMaintenance;
+  readonly autoHeal?: AppAutoHeal;
+  readonly linkedAppIds: readonly string[] | null;
   readonly createdAt: string;
   readonly updatedAt: string;
+  readonly deletedAt?: string;
+}
+
+export type RateLimitSource = "ip" | "forwarded" | "host" | "header";
+
+export interface AppRateLimit {
+  readonly average: number;
+  readonly burst: number;
+  readonly source: RateLimitSource;
+  readonly header?: string;
+}
+
+export interface AppMaintenance {
+  readonly html?: string;
+  readonly enabledAt: string;
+}
+
+export interface AppAutoHeal {
+  readonly unhealthySeconds: number;
+  readonly maxRestarts: number;
+}
+
+export type FrameOptions = "DENY" | "SAMEORIGIN";
+
+export interface AppSecurityHeaders {
+  readonly hstsMaxAge: number;
+  readonly hstsIncludeSubdomains: boolean;
+  readonly hstsPreload: boolean;
+  readonly frameOptions?: FrameOptions;
+  readonly contentSecurityPolicy?: string;
+  readonly referrerPolicy?: string;
+}
+
+export type AnalyticsPeriod = "1h" | "24h" | "7d";
+
+export interface DomainAnalytics {
+  readonly domain: string;
+  readonly requests: number;
+  readonly statusCodes: Record<string, number>;
+  readonly p95LatencyMs: number;
+}
+
+export interface AppAnalytics {
+  readonly period: AnalyticsPeriod;
+  readonly since: string;
+  readonly domains: readonly DomainAnalytics[];
+}
+
+export type RedirectStatusCode = 301 | 302 | 307 | 308;
+
+export interface AppRedirect {
+  readonly sourceHost: string;
+  readonly sourcePath?: string;
+  readonly target: string;
+  readonly statusCode: RedirectStatusCode;
 }
 
 export interface CreateAppInput {
@@ -47,6 +109,20 @@ export interface AppVolumeConfig {
   readonly readOnly?: boolean;
 }
 
+export interface AppStickySessions {
+  readonly cookieName: string;
+  readonly secure: boolean;
+  readonly httpOnly: boolean;
+  readonly sameSite: string;
+}
+
+export interface AppPortConfig {
+  readonly port: number;
+  readonly protocol: string;
+  readonly entrypoint?: string;
+  readonly hostPort?: number;
+}
+
 export interface AppConfig {
   readonly name: string;
   readonly port: number;
@@ -64,6 +140,9 @@ export interface AppConfig {
   };
   readonly domains: readonly string[];
   readonly volumes: readonly AppVolumeConfig[];
+  readonly compress: boolean;
+  readonly stickySessions: AppStickySessions | null;
+  readonly ports: readonly AppPortConfig[] | null;
 }
 
 export interface EnvVar {
@@ -86,6 +165,26 @@ export interface BulkEnvVarInput {
   readonly vars: readonly CreateEnvVarInput[];
 }
 
+export interface HerokuAddon {
+  readonly name: string;
+  readonly service: string;
+  readonly plan: string;
+  readonly configVars: readonly string[] | null;
+}
+
+export interface HerokuImportPreview {
+  readonly herokuApp: string;
+  readonly envVars: readonly CreateEnvVarInput[];
+  readonly config: Record<string, unknown>;
+  readonly addons: readonly HerokuAddon[];
+}
+
+export interface HerokuImportInput {
+  readonly apiKey: string;
+  readonly herokuApp: string;
+  readonly name?: string;
+}
+
 export interface CommitInfo {
   readonly sha: string;
   readonly message: string;
@@ -124,22 +223,82 @@ export interface CertificateStatus {
   readonly error?: string | null;
 }
 
+export interface ServerCertificate {
+  readonly domain: string;
+  readonly sans?: readonly string[];
+  readonly resolver: string;
+  readonly issuer?: string;
+  readonly expiresAt?: string;
+  readonly daysRemaining?: number;
+  readonly staging: boolean;
+  readonly stale: boolean;
+}
+
+export interface ServerCertificates {
+  readonly acmeStaging: boolean;
+  readonly certificates: readonly ServerCertificate[];
+}
+
+export interface CertificateActionResult {
+  readonly removed: number;
+  readonly message: string;
+}
+
 export interface CloudflareStatus {
   readonly connected: boolean;
   readonly email?: string;
   readonly accountId?: string;
 }
 
+export type DnsProvider = "cloudflare" | "route53" | "digitalocean" | "desec";
+
+export interface DnsConnection {
+  readonly provider: DnsProvider;
+  readonly createdAt: string;
+  readonly updatedAt: string;
+}
+
+export type WwwPairing = "" | "redirect-to-apex" | "redirect-to-www";
+
+export interface DomainVerification {
+  readonly id: string;
+  readonly domain: string;
+  readonly recordType: string;
+  readonly recordName: string;
+  readonly recordValue: string;
+  readonly verified: boolean;
+  readonly verifiedAt?: string;
+  readonly createdAt: string;
+}
+
 export interface CustomDomain {
   readonly id: string;
   readonly appId: string;
   readonly domain: string;
   readonly pathPrefix: string;
   readonly recordType: string;
+  readonly dnsProvider?: DnsProvider;
+  readonly redirectTo?: string;
   readonly status: string;
+  readonly basicAuth: boolean;
+  readonly basicAuthUsers: readonly string[];
+  readonly ipAllowlist: readonly string[];
+  readonly customCertificate: boolean;
+  readonly certificateExpiresAt?: string;
   readonly createdAt: string;
 }
 
+export interface UpdateDomainBasicAuthInput {
+  readonly enabled: boolean;
+  readonly username?: string;
+  readonly password?: string;
+}
+
+export interface UploadDomainCertificateInput {
+  readonly certificate: string;
+  readonly privateKey: string;
+}
+
 export type NotificationChannelType = "slack" | "discord" | "email";
 
 export interface NotificationChannel {
@@ -167,7 +326,12 @@ export type NotificationEventType =
   | "deploy_success"
   | "deploy_failed"
   | "container_down"
-  | "health_unhealthy";
+  | "container_oom"
+  | "container_crash_loop"
+  | "health_unhealthy"
+  | "server_offline"
+  | "certificate_expiring"
+  | "server_task_failed";
 
 export interface CreateNotificationChannelInput {
   readonly type: NotificationChannelType;
@@ -182,3 +346,48 @@ export interface CreateNotificationRuleInput {
   readonly appId?: string;
   readonly enabled: boolean;
 }
+
+export type BulkAppAction = "restart" | "stop" | "start" | "redeploy";
+
+export interface BulkAppActionInput {
+  readonly action: BulkAppAction;
+  readonly appIds?: readonly string[];
+  readonly serverId?: string;
+  readonly concurrency?: number;
+}
+
+export interface AppBulkResult {
+  readonly appId: string;
+  readonly appName: string;
+  readonly status: "succeeded" | "queued" | "failed";
+  readonly deploymentId?: string;
+  readonly error?: string;
+}
+
+export interface AppBatch {
+  readonly id: string;
+  readonly action: BulkAppAction;
+  readonly status: "running" | "completed";
+  readonly total: number;
+  readonly completed: number;
+  readonly failed: number;
+  readonly results: readonly AppBulkResult[];
+  readonly startedAt: string;
+  readonly finishedAt?: string;
+}
+
+export type SearchResultType =
+  | "app"
+  | "deployment"
+  | "server"
+  | "domain"
+  | "container";
+
+export interface SearchResult {
+  readonly type: SearchResultType;
+  readonly id: string;
+  readonly title: string;
+  readonly subtitle?: string;
+  readonly appId?: string;
+  readonly serverId?: string;
+}
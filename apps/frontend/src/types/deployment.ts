@@ -27,9 +27,79 @@ export interface Deployment {
   readonly finishedAt: string | null;
   readonly errorMessage: string | null;
   readonly logs: string | null;
+  readonly archivedLogChunks?: number;
+  readonly archivedLogBytes?: number;
   readonly previousImageTag: string | null;
   readonly currentImageTag: string | null;
   readonly appVersion?: string;
+  readonly traceId?: string;
   readonly durationMs?: number | null;
   readonly createdAt: string;
 }
+
+export interface DeploymentSBOM {
+  readonly deploymentId: string;
+  readonly format: string;
+  readonly packageCount: number;
+  readonly createdAt: string;
+}
+
+export interface SBOMPackage {
+  readonly name: string;
+  readonly version: string;
+  readonly type: string;
+  readonly purl?: string;
+}
+
+export interface SBOMPackageChange {
+  readonly name: string;
+  readonly type: string;
+  readonly from: string;
+  readonly to: string;
+}
+
+export interface SBOMDiff {
+  readonly from: DeploymentSBOM;
+  readonly to: DeploymentSBOM;
+  readonly added: readonly SBOMPackage[];
+  readonly removed: readonly SBOMPackage[];
+  readonly changed: readonly SBOMPackageChange[];
+}
+
+export interface DeploymentEnvChange {
+  readonly key: string;
+  readonly change: "added" | "removed" | "changed";
+}
+
+export interface DeploymentConfigChange {
+  readonly field: string;
+  readonly from: string;
+  readonly to: string;
+}
+
+export interface DeploymentDiffCommit {
+  readonly sha: string;
+  readonly message: string;
+  readonly author?: string;
+  readonly date: string;
+  readonly url?: string;
+}
+
+export interface DeploymentDiff {
+  readonly from: Deployment;
+  readonly to: Deployment;
+  readonly commits: {
+    readonly from: string;
+    readonly to: string;
+    readonly compareUrl?: string;
+    readonly commits: readonly DeploymentDiffCommit[];
+  };
+  readonly env: readonly DeploymentEnvChange[];
+  readonly config: readonly DeploymentConfigChange[];
+  readonly imageSize?: {
+    readonly from: number;
+    readonly to: number;
+    readonly delta: number;
+  };
+  readonly snapshotMissing?: boolean;
+}
@@ -35,6 +35,57 @@ export interface Container {
   readonly isFlowDeployManaged: boolean;
 }
 
+export interface ContainerInspectState {
+  readonly status: string;
+  readonly running: boolean;
+  readonly paused: boolean;
+  readonly restarting: boolean;
+  readonly oomKilled: boolean;
+  readonly dead: boolean;
+  readonly exitCode: number;
+  readonly error?: string;
+  readonly startedAt: string;
+  readonly finishedAt?: string;
+}
+
+export interface ContainerHealthCheck {
+  readonly start: string;
+  readonly end: string;
+  readonly exitCode: number;
+  readonly output: string;
+}
+
+export interface ContainerNetwork {
+  readonly name: string;
+  readonly ipAddress: string;
+  readonly gateway: string;
+  readonly macAddress: string;
+}
+
+export interface ContainerInspect {
+  readonly id: string;
+  readonly name: string;
+  readonly image: string;
+  readonly imageId: string;
+  readonly created: string;
+  readonly restartCount: number;
+  readonly restartPolicy: string;
+  readonly state: ContainerInspectState;
+  readonly health?: {
+    readonly status: string;
+    readonly failingStreak: number;
+    readonly log: readonly ContainerHealthCheck[];
+  };
+  readonly envNames: readonly string[];
+  readonly labels: Record<string, string>;
+  readonly mounts: readonly ContainerMount[];
+  readonly networks: readonly ContainerNetwork[];
+}
+
+export interface ContainerDetails extends Container {
+  readonly inspect: ContainerInspect;
+}
+
 export interface ContainerActionResult {
   readonly success: boolean;
   readonly message: string;
@@ -44,6 +95,54 @@ export interface ContainerLogs {
   readonly logs: string;
 }
 
+export type ContainerFileType = "file" | "dir" | "symlink" | "other";
+
+export interface ContainerFile {
+  readonly name: string;
+  readonly type: ContainerFileType;
+  readonly size: number;
+  readonly mode: string;
+  readonly owner: string;
+  readonly modified: string;
+  readonly linkTarget?: string;
+}
+
+export interface ContainerFileList {
+  readonly path: string;
+  readonly files: readonly ContainerFile[];
+}
+
+export interface VolumeFileList extends ContainerFileList {
+  readonly volume: string;
+}
+
+export interface ContainerFileUploadResult {
+  readonly path: string;
+  readonly size: number;
+}
+
+export interface ContainerProcess {
+  readonly pid: number;
+  readonly ppid: number;
+  readonly user: string;
+  readonly cpuPercent: number;
+  readonly memPercent: number;
+  readonly elapsed: string;
+  readonly command: string;
+}
+
+export interface CommitContainerInput {
+  readonly repository: string;
+  readonly tag?: string;
+  readonly message?: string;
+  readonly pause?: boolean;
+}
+
+export interface CommitContainerResult {
+  readonly imageId: string;
+  readonly image: string;
+}
+
 export interface ContainerStats {
   readonly cpuPercent: number;
   readonly memoryUsage: number;
@@ -122,13 +221,23 @@ export interface DeployTemplateInput {
   readonly restartPolicy?: string;
 }
 
+export interface DockerNetworkContainer {
+  readonly id: string;
+  readonly name: string;
+  readonly ipv4Address: string;
+  readonly ipv6Address?: string;
+  readonly macAddress: string;
+}
+
 export interface DockerNetwork {
   readonly name: string;
   readonly id: string;
   readonly driver: string;
   readonly scope: string;
   readonly internal: boolean;
-  readonly containers: readonly string[];
+  readonly subnet: string;
+  readonly gateway: string;
+  readonly containers: readonly DockerNetworkContainer[];
 }
 
 export interface DockerVolume {
@@ -1,3 +1,4 @@
+import type { App, AppBatch } from "./app";
 import type { ContainerStats } from "./docker";
 
 export interface HealthStatus {
@@ -12,6 +13,7 @@ export type SSEEventType =
   | "SUCCESS"
   | "FAILED"
   | "LOG"
+  | "PROGRESS"
   | "HEALTH"
   | "STATS"
   | "SYSTEM_STATS"
@@ -21,6 +23,10 @@ export type SSEEventType =
   | "PROVISION_LOG"
   | "PROVISION_COMPLETED"
   | "PROVISION_FAILED"
+  | "PROVISION_BATCH_PROGRESS"
+  | "PROVISION_BATCH_COMPLETED"
+  | "APP_BATCH_PROGRESS"
+  | "APP_BATCH_COMPLETED"
   | "AGENT_UPDATE_STEP";
 
 export type AgentUpdateStep = "enqueued" | "delivered" | "updated" | "error";
@@ -33,18 +39,36 @@ export interface AgentUpdateState {
   readonly startedAt: number;
 }
 
+export interface DeployProgress {
+  readonly stage: string;
+  readonly stageIndex: number;
+  readonly stageCount: number;
+  readonly percent: number;
+  readonly elapsedMs: number;
+  readonly etaMs: number;
+  readonly fromHistory: boolean;
+}
+
+export type DeployLogKind = "step" | "warning" | "error";
+
 export interface SSEEvent {
   readonly type: SSEEventType;
   readonly deployId?: string;
   readonly appId?: string;
+  readonly traceId?: string;
   readonly serverId?: string;
   readonly step?: string;
   readonly status?: string;
   readonly message?: string;
+  readonly kind?: DeployLogKind;
   readonly health?: HealthStatus;
   readonly stats?: ContainerStats;
+  readonly progress?: DeployProgress;
   readonly systemStats?: ServerStats;
   readonly resource?: string;
+  readonly batchId?: string;
+  readonly batch?: ProvisionBatch;
+  readonly appBatch?: AppBatch;
   readonly timestamp: string;
 }
 
@@ -60,6 +84,24 @@ export interface ProvisionProgressState {
   readonly status: "running" | "completed" | "failed";
 }
 
+export interface ProvisionFailure {
+  readonly serverId: string;
+  readonly name: string;
+  readonly host: string;
+  readonly error: string;
+}
+
+export interface ProvisionBatch {
+  readonly id: string;
+  readonly status: "running" | "completed";
+  readonly total: number;
+  readonly completed: number;
+  readonly failed: number;
+  readonly failures: readonly ProvisionFailure[];
+  readonly startedAt: string;
+  readonly finishedAt?: string;
+}
+
 export interface ProxyStatus {
   readonly type: string;
   readonly running: boolean;
@@ -157,6 +199,25 @@ export interface MigrationStatus {
   readonly warnings: readonly string[];
   readonly lastBackupPath?: string;
   readonly lastBackupTime?: string;
+  readonly changes: readonly MigrationChange[];
+}
+
+export type MigrationChangeType = "container_migrated" | "nginx_stopped";
+
+export interface MigrationChange {
+  readonly type: MigrationChangeType;
+  readonly at: string;
+  readonly domain?: string;
+  readonly containerName?: string;
+  readonly originalId?: string;
+  readonly backupName?: string;
+  readonly newId?: string;
+  readonly nginxEnabled?: boolean;
+}
+
+export interface RollbackResult {
+  readonly rolledBack: readonly MigrationChange[];
+  readonly errors: readonly string[];
 }
 
 export interface BackupResult {
@@ -184,6 +245,36 @@ export interface TraefikPreview {
   readonly yaml: string;
 }
 
+export type CertStrategyKind = "none" | "acme" | "acme-reissue" | "manual";
+
+export interface CertStrategy {
+  readonly strategy: CertStrategyKind;
+  readonly certificate?: SSLCertificate;
+  readonly description: string;
+}
+
+export interface BackendPlan extends TraefikConfig {
+  readonly container?: MigrationContainer;
+}
+
+export interface SitePlan {
+  readonly configFile: string;
+  readonly domains: readonly string[];
+  readonly sslEnabled: boolean;
+  readonly backends: readonly BackendPlan[];
+  readonly certStrategy: CertStrategy;
+  readonly warnings: readonly string[];
+}
+
+export interface MigrationPlan {
+  readonly generatedAt: string;
+  readonly proxy: ProxyStatus;
+  readonly traefikReady: boolean;
+  readonly migrationNeeded: boolean;
+  readonly sites: readonly SitePlan[];
+  readonly warnings: readonly string[];
+}
+
 export interface MigrateResult {
   readonly containerId: string;
   readonly containerName: string;
@@ -202,6 +293,15 @@ export type ServerStatus =
 
 export type AgentUpdateMode = "grpc" | "https";
 
+export type AgentInstallMethod =
+  | "auto"
+  | "systemd_user"
+  | "openrc"
+  | "nohup"
+  | "docker";
+
+export type CloudProvider = "hetzner" | "digitalocean" | "aws";
+
 export interface Server {
   readonly id: string;
   readonly name: string;
@@ -212,12 +312,73 @@ export interface Server {
   readonly status: ServerStatus;
   readonly agentVersion?: string;
   readonly agentUpdateMode: AgentUpdateMode;
+  readonly agentInstallMethod: AgentInstallMethod;
+  readonly dockerRootless: boolean;
+  readonly firewallEnabled: boolean;
+  readonly sshHardening: boolean;
+  readonly acmeStaging: boolean;
+  readonly entrypoints?: readonly ServerEntrypoint[] | null;
+  readonly meshIp?: string;
+  readonly bastionServerId?: string;
+  readonly sshPublicKey?: string;
+  readonly cloudProvider?: CloudProvider;
   readonly latestAgentVersion: string;
   readonly lastHeartbeatAt?: string;
   readonly createdAt: string;
   readonly updatedAt: string;
 }
 
+export type EntrypointProtocol = "tcp" | "udp";
+
+export interface ServerEntrypoint {
+  readonly name: string;
+  readonly port: number;
+  readonly protocol: EntrypointProtocol;
+}
+
+export type ImportPlatform = "dokku" | "caprover";
+
+export interface ImportedVolume {
+  readonly name?: string;
+  readonly source?: string;
+  readonly target: string;
+  readonly readOnly?: boolean;
+}
+
+export interface DiscoveredApp {
+  readonly name: string;
+  readonly envVarKeys: readonly string[];
+  readonly domains: readonly string[];
+  readonly volumes: readonly ImportedVolume[];
+  readonly port: number;
+}
+
+export interface ComposeService extends DiscoveredApp {
+  readonly image?: string;
+  readonly buildContext?: string;
+  readonly dockerfile?: string;
+}
+
+export interface ComposeProject {
+  readonly projectName: string;
+  readonly services: readonly ComposeService[];
+}
+
+export interface ImportAppInput {
+  readonly source: string;
+  readonly name?: string;
+  readonly repositoryUrl: string;
+  readonly branch: string;
+  readonly workdir?: string;
+}
+
+export interface ImportAppResult {
+  readonly source: string;
+  readonly app?: App;
+  readonly config?: Record<string, unknown>;
+  readonly error?: string;
+}
+
 export interface CreateServerInput {
   readonly name: string;
   readonly host: string;
@@ -226,6 +387,29 @@ export interface CreateServerInput {
   readonly sshKey?: string;
   readonly sshPassword?: string;
   readonly acmeEmail?: string;
+  readonly agentInstallMethod?: AgentInstallMethod;
+  readonly dockerRootless?: boolean;
+  readonly firewallEnabled?: boolean;
+  readonly sshHardening?: boolean;
+  readonly bastionServerId?: string;
+  readonly generateSshKey?: boolean;
+}
+
+export interface CloudCredential {
+  readonly provider: CloudProvider;
+  readonly createdAt: string;
+  readonly updatedAt: string;
+}
+
+export interface CreateCloudServerInput {
+  readonly provider: CloudProvider;
+  readonly name: string;
+  readonly size?: string;
+  readonly region?: string;
+  readonly image?: string;
+  readonly acmeEmail?: string;
+  readonly firewallEnabled?: boolean;
+  readonly sshHardening?: boolean;
 }
 
 export interface ServerSystemInfo {
@@ -256,3 +440,45 @@ export interface ServerStats {
   readonly systemInfo: ServerSystemInfo;
   readonly systemMetrics: ServerSystemMetrics;
 }
+
+export type ServerTaskRunStatus = "running" | "success" | "failed";
+
+export interface ServerTask {
+  readonly id: string;
+  readonly serverId: string;
+  readonly name: string;
+  readonly schedule: string;
+  readonly command: string;
+  readonly timeoutSeconds: number;
+  readonly enabled: boolean;
+  readonly notifyOnFailure: boolean;
+  readonly nextRunAt?: string;
+  readonly lastRunAt?: string;
+  readonly lastStatus?: ServerTaskRunStatus;
+  readonly createdAt: string;
+  readonly updatedAt: string;
+}
+
+export interface ServerTaskInput {
+  readonly name?: string;
+  readonly schedule?: string;
+  readonly command?: string;
+  readonly timeoutSeconds?: number;
+  readonly enabled?: boolean;
+  readonly notifyOnFailure?: boolean;
+}
+
+export interface ServerTaskRun {
+  readonly id: string;
+  readonly taskId: string;
+  readonly serverId: string;
+  readonly trigger: "schedule" | "manual";
+  readonly status: ServerTaskRunStatus;
+  readonly exitCode?: number;
+  readonly output: string;
+  readonly truncated: boolean;
+  readonly error?: string;
+  readonly startedAt: string;
+  readonly finishedAt?: string;
+  readonly durationMs: number;
+}
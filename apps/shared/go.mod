@@ -3,3 +3,5 @@ module github.com/paasdeploy/shared
 go 1.24.0
 
 toolchain go1.24.12
+
+require go.yaml.in/yaml/v3 v3.0.4
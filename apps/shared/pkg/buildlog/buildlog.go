@@ -0,0 +1,70 @@
+// Package buildlog cleans up the output of docker build and docker compose
+// and tells build steps, warnings and errors apart from plain output.
+package buildlog
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Kind classifies a line of builder output. Plain output has no kind.
+type Kind string
+
+const (
+	KindOutput  Kind = ""
+	KindStep    Kind = "step"
+	KindWarning Kind = "warning"
+	KindError   Kind = "error"
+)
+
+var (
+	// ansiSequence matches CSI sequences such as colors and cursor moves,
+	// and OSC sequences such as terminal titles and hyperlinks.
+	ansiSequence = regexp.MustCompile(`\x1b\[[0-?]*[ -/]*[@-~]|\x1b\][^\x07\x1b]*(\x07|\x1b\\)|\x1b[@-Z\\-_]`)
+
+	// stepLine matches the start of a Dockerfile instruction, as printed by
+	// BuildKit ("#5 [builder 2/6] RUN go build") and the legacy builder
+	// ("Step 2/6 : RUN go build").
+	stepLine = regexp.MustCompile(`^(#\d+ \[[^\]]+\] |Step \d+/\d+ : )`)
+
+	// buildkitPrefix is the vertex number and elapsed time BuildKit puts in
+	// front of the output of a step.
+	buildkitPrefix = regexp.MustCompile(`^#\d+ (\d+\.\d+ )?`)
+
+	errorLine   = regexp.MustCompile(`(?i)^(error\b|fatal\b|npm err!|failed to solve|the command .* returned a non-zero code)`)
+	warningLine = regexp.MustCompile(`(?i)^(warn(ing)?\b|npm warn\b|\S+warning:)`)
+)
+
+// StripANSI removes terminal escape sequences from s. Progress bars redraw
+// themselves with carriage returns, so only the text after the last one is
+// kept.
+func StripANSI(s string) string {
+	if i := strings.LastIndexByte(strings.TrimRight(s, "\r"), '\r'); i >= 0 {
+		s = s[i+1:]
+	}
+	s = ansiSequence.ReplaceAllString(s, "")
+	return strings.TrimRight(s, "\r")
+}
+
+// Classify returns the kind of a line that has already been stripped of
+// escape sequences.
+func Classify(line string) Kind {
+	if stepLine.MatchString(line) {
+		return KindStep
+	}
+	content := strings.TrimSpace(buildkitPrefix.ReplaceAllString(line, ""))
+	switch {
+	case errorLine.MatchString(content):
+		return KindError
+	case warningLine.MatchString(content):
+		return KindWarning
+	}
+	return KindOutput
+}
+
+// Parse strips escape sequences from a line of builder output and
+// classifies it.
+func Parse(line string) (string, Kind) {
+	text := StripANSI(line)
+	return text, Classify(text)
+}
@@ -0,0 +1,59 @@
+package buildlog
+
+import "testing"
+
+func TestStripANSI(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"plain", "Successfully built", "Successfully built"},
+		{"colors", "\x1b[1;31merror\x1b[0m: not found", "error: not found"},
+		{"cursor moves", "\x1b[2K\x1b[1Gdone", "done"},
+		{"hyperlink", "\x1b]8;;https://example.com\x07link\x1b]8;;\x07", "link"},
+		{"progress bar", "10%\r50%\r100%\r", "100%"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StripANSI(tt.in); got != tt.want {
+				t.Errorf("StripANSI(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		line string
+		want Kind
+	}{
+		{"#5 [builder 2/6] RUN go build ./...", KindStep},
+		{"#8 [3/4] COPY . .", KindStep},
+		{"Step 2/6 : RUN npm ci", KindStep},
+		{"#5 0.512 go: downloading github.com/gofiber/fiber/v2 v2.52.0", KindOutput},
+		{"#5 DONE 12.3s", KindOutput},
+		{"#9 4.103 npm WARN deprecated inflight@1.0.6", KindWarning},
+		{" 1 warning found (use docker --debug to expand):", KindOutput},
+		{"WARNING: The requested image's platform does not match", KindWarning},
+		{"#7 2.004 (node:1) DeprecationWarning: Buffer() is deprecated", KindOutput},
+		{"#7 2.004 DeprecationWarning: Buffer() is deprecated", KindWarning},
+		{"#12 ERROR: process \"/bin/sh -c npm run build\" did not complete successfully: exit code: 1", KindError},
+		{"ERROR: failed to solve: process did not complete successfully", KindError},
+		{"#6 1.220 npm ERR! code ERESOLVE", KindError},
+		{"The command '/bin/sh -c make' returned a non-zero code: 2", KindError},
+		{"Container api  Started", KindOutput},
+	}
+	for _, tt := range tests {
+		if got := Classify(tt.line); got != tt.want {
+			t.Errorf("Classify(%q) = %q, want %q", tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	text, kind := Parse("\x1b[33mWARNING\x1b[0m: no healthcheck")
+	if text != "WARNING: no healthcheck" || kind != KindWarning {
+		t.Errorf("Parse() = %q, %q", text, kind)
+	}
+}
@@ -5,7 +5,10 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
+
+	"go.yaml.in/yaml/v3"
 )
 
 const DefaultAppPort = 8080
@@ -46,39 +49,266 @@ type Config struct {
 		Memory string `json:"memory"`
 		CPU    string `json:"cpu"`
 	} `json:"resources"`
-	Domains []string       `json:"domains,omitempty"`
+	Domains []DomainConfig `json:"domains,omitempty"`
 	Volumes []VolumeConfig `json:"volumes,omitempty"`
+	// Compress enables gzip/brotli response compression at the proxy.
+	Compress bool            `json:"compress,omitempty"`
+	Sticky   *StickySessions `json:"stickySessions,omitempty"`
+	Ports    []PortConfig    `json:"ports,omitempty"`
+
+	// PortSource says where Port came from when the file does not set it,
+	// as detected by LoadConfig. It is empty when the file sets the port.
+	PortSource string `json:"-"`
+}
+
+const (
+	PortProtocolTCP = "tcp"
+	PortProtocolUDP = "udp"
+)
+
+// PortConfig exposes a non-HTTP container port, either through a TCP/UDP
+// entrypoint provisioned on the server's Traefik or by publishing it
+// directly on HostPort.
+type PortConfig struct {
+	Port       int    `json:"port"`
+	Protocol   string `json:"protocol,omitempty"`
+	Entrypoint string `json:"entrypoint,omitempty"`
+	HostPort   int    `json:"hostPort,omitempty"`
+	// Mesh binds HostPort to the server's private WireGuard address only, so
+	// apps on other servers in the mesh can reach it without a public port.
+	Mesh bool `json:"mesh,omitempty"`
+}
+
+// StickySessions pins each client to one replica with a cookie set by the
+// proxy. An empty CookieName lets Traefik pick one.
+type StickySessions struct {
+	CookieName string `json:"cookieName,omitempty"`
+	Secure     bool   `json:"secure,omitempty"`
+	HTTPOnly   bool   `json:"httpOnly,omitempty"`
+	// SameSite is none, lax or strict.
+	SameSite string `json:"sameSite,omitempty"`
 }
 
 type DomainRoute struct {
 	Domain     string
 	PathPrefix string
+	// BasicAuthUsers are htpasswd entries ("user:hash") that Traefik
+	// requires before forwarding requests for this route.
+	BasicAuthUsers []string
+	// IPAllowlist restricts the route to these IPs or CIDR ranges.
+	IPAllowlist []string
+	// CustomCertificate means an uploaded certificate is served for the
+	// domain from Traefik's file provider, so no ACME resolver is attached.
+	CustomCertificate bool
+	// Middlewares are Traefik middlewares defined outside the app, applied
+	// after the generated ones.
+	Middlewares []string
+	// CertResolver overrides DefaultCertResolver.
+	CertResolver string
 }
 
-func LoadConfig(appDir string) (*Config, error) {
-	configPath := filepath.Join(appDir, "paasdeploy.json")
+const (
+	RateLimitSourceIP        = "ip"
+	RateLimitSourceForwarded = "forwarded"
+	RateLimitSourceHost      = "host"
+	RateLimitSourceHeader    = "header"
+)
+
+// RateLimit is rendered as a Traefik ratelimit middleware shared by all of
+// the app's routers. Average is in requests per second.
+type RateLimit struct {
+	Average int
+	Burst   int
+	// Source selects how requests are grouped: by client IP, by the first
+	// X-Forwarded-For hop (for apps behind a proxy such as Cloudflare), by
+	// request host or by the value of Header.
+	Source string
+	Header string
+}
+
+// SecurityHeaders is rendered as a Traefik headers middleware shared by all
+// of the app's routers. Zero values leave the matching header unset.
+type SecurityHeaders struct {
+	// HSTSMaxAge is the Strict-Transport-Security max-age in seconds.
+	HSTSMaxAge            int
+	HSTSIncludeSubdomains bool
+	HSTSPreload           bool
+	// FrameOptions is the X-Frame-Options value, DENY or SAMEORIGIN.
+	FrameOptions          string
+	ContentSecurityPolicy string
+	ReferrerPolicy        string
+}
+
+// Enabled reports whether any header would be set.
+func (h *SecurityHeaders) Enabled() bool {
+	return h != nil && (h.HSTSMaxAge > 0 || h.FrameOptions != "" || h.ContentSecurityPolicy != "" || h.ReferrerPolicy != "")
+}
+
+// Redirect sends requests for SourceHost (optionally only below SourcePath)
+// to Target, keeping the rest of the path. Target is a URL such as
+// "https://example.com" or "https://example.com/blog".
+type Redirect struct {
+	SourceHost string
+	SourcePath string
+	Target     string
+	Permanent  bool
+}
 
-	if _, err := os.Stat(configPath); os.IsNotExist(err) {
-		return nil, fmt.Errorf("paasdeploy.json not found in %s - this file is required for deployment", appDir)
+// ConfigFileNames are the names the configuration of an app is read from,
+// in order of precedence.
+var ConfigFileNames = []string{"paasdeploy.json", "paasdeploy.yaml", "paasdeploy.yml"}
+
+// IsConfigFile reports whether name is one of ConfigFileNames.
+func IsConfigFile(name string) bool {
+	for _, n := range ConfigFileNames {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// FindConfigFile returns the path of the configuration file in appDir.
+func FindConfigFile(appDir string) (string, error) {
+	for _, name := range ConfigFileNames {
+		path := filepath.Join(appDir, name)
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("paasdeploy.json not found in %s - this file (or paasdeploy.yaml) is required for deployment", appDir)
+}
+
+func LoadConfig(appDir string) (*Config, error) {
+	configPath, err := FindConfigFile(appDir)
+	if err != nil {
+		return nil, err
 	}
 
+	name := filepath.Base(configPath)
 	data, err := os.ReadFile(configPath)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read paasdeploy.json: %w", err)
+		return nil, fmt.Errorf("failed to read %s: %w", name, err)
+	}
+
+	config, err := decodeConfig(name, data)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.Port == 0 {
+		config.Port, config.PortSource = DetectPort(appDir, config)
+		if config.Port == 0 {
+			config.PortSource = PortSourceDefault
+		}
+	}
+
+	ApplyDefaults(config)
+
+	return config, nil
+}
+
+// ParseConfig decodes and checks a configuration file and applies the
+// defaults. name is the file name, which selects between JSON and YAML.
+func ParseConfig(name string, data []byte) (*Config, error) {
+	config, err := decodeConfig(name, data)
+	if err != nil {
+		return nil, err
+	}
+
+	ApplyDefaults(config)
+
+	return config, nil
+}
+
+func decodeConfig(name string, data []byte) (*Config, error) {
+	normalized, err := ConfigJSON(name, data)
+	if err != nil {
+		return nil, err
 	}
 
 	var config Config
-	if err := json.Unmarshal(data, &config); err != nil {
-		return nil, fmt.Errorf("invalid paasdeploy.json: %w", err)
+	if err := json.Unmarshal(normalized, &config); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", name, err)
+	}
+
+	if err := validateConfig(&config); err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return &config, nil
+}
+
+// ConfigJSON returns the configuration file as JSON, converting it when
+// it is YAML so both formats share the JSON field names.
+func ConfigJSON(name string, data []byte) ([]byte, error) {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".yaml", ".yml":
+	default:
+		return data, nil
+	}
+
+	var document interface{}
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", name, err)
+	}
+	if document == nil {
+		document = map[string]interface{}{}
 	}
+	converted, err := json.Marshal(document)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", name, err)
+	}
+	return converted, nil
+}
 
+func validateConfig(config *Config) error {
 	if config.Name == "" {
-		return nil, fmt.Errorf("paasdeploy.json: 'name' field is required")
+		return ConfigError{Path: "name", Message: "field is required"}
 	}
 
-	ApplyDefaults(&config)
+	if err := validateDomains(config.Domains); err != nil {
+		return err
+	}
 
-	return &config, nil
+	if err := validatePorts(config.Ports); err != nil {
+		return err
+	}
+
+	if config.Sticky != nil {
+		switch config.Sticky.SameSite {
+		case "", "none", "lax", "strict":
+		default:
+			return ConfigError{Path: "stickySessions.sameSite", Message: "must be none, lax or strict"}
+		}
+	}
+	return nil
+}
+
+var entrypointNameRe = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+
+func validatePorts(ports []PortConfig) error {
+	for i, p := range ports {
+		path := fmt.Sprintf("ports[%d]", i)
+		if p.Port < 1 || p.Port > 65535 {
+			return ConfigError{Path: path + ".port", Message: "must be between 1 and 65535"}
+		}
+		if p.Protocol != "" && p.Protocol != PortProtocolTCP && p.Protocol != PortProtocolUDP {
+			return ConfigError{Path: path + ".protocol", Message: "must be tcp or udp"}
+		}
+		if p.Entrypoint != "" && !entrypointNameRe.MatchString(p.Entrypoint) {
+			return ConfigError{Path: path + ".entrypoint", Message: "must be lowercase letters, digits and hyphens"}
+		}
+		if (p.Entrypoint == "") == (p.HostPort == 0) {
+			return ConfigError{Path: path, Message: "needs exactly one of 'entrypoint' or 'hostPort'"}
+		}
+		if p.HostPort < 0 || p.HostPort > 65535 {
+			return ConfigError{Path: path + ".hostPort", Message: "must be between 1 and 65535"}
+		}
+		if p.Mesh && p.HostPort == 0 {
+			return ConfigError{Path: path + ".mesh", Message: "requires 'hostPort'"}
+		}
+	}
+	return nil
 }
 
 func ValidateDockerfile(appDir string, config *Config) error {
@@ -153,4 +383,9 @@ func ApplyDefaults(config *Config) {
 	if config.Resources.CPU == "" {
 		config.Resources.CPU = "0.5"
 	}
+	for i := range config.Ports {
+		if config.Ports[i].Protocol == "" {
+			config.Ports[i].Protocol = PortProtocolTCP
+		}
+	}
 }
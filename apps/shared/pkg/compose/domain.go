@@ -0,0 +1,164 @@
+package compose
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// DefaultCertResolver is the Traefik ACME resolver attached to routes that
+// do not name one.
+const DefaultCertResolver = "letsencrypt"
+
+// DomainConfig is an entry of "domains". It is written either as a plain
+// host name or as an object with per-domain options.
+type DomainConfig struct {
+	Domain     string `json:"domain"`
+	PathPrefix string `json:"pathPrefix,omitempty"`
+	// Redirect sends every request for the domain to this URL, keeping the
+	// rest of the path, instead of routing it to the app.
+	Redirect string `json:"redirect,omitempty"`
+	// Middlewares are extra Traefik middlewares, such as "auth@file",
+	// applied after the ones generated for the app.
+	Middlewares  []string `json:"middlewares,omitempty"`
+	CertResolver string   `json:"certResolver,omitempty"`
+}
+
+// Hosts turns plain host names into domain entries.
+func Hosts(names []string) []DomainConfig {
+	if names == nil {
+		return nil
+	}
+	domains := make([]DomainConfig, len(names))
+	for i, name := range names {
+		domains[i] = DomainConfig{Domain: name}
+	}
+	return domains
+}
+
+func (d *DomainConfig) UnmarshalJSON(data []byte) error {
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '"' {
+		*d = DomainConfig{}
+		return json.Unmarshal(trimmed, &d.Domain)
+	}
+	type plain DomainConfig
+	return json.Unmarshal(data, (*plain)(d))
+}
+
+// MarshalJSON writes entries without options as a plain host name, as they
+// are usually written by hand.
+func (d DomainConfig) MarshalJSON() ([]byte, error) {
+	if d.PathPrefix == "" && d.Redirect == "" && len(d.Middlewares) == 0 && d.CertResolver == "" {
+		return json.Marshal(d.Domain)
+	}
+	type plain DomainConfig
+	return json.Marshal(plain(d))
+}
+
+// DomainNames returns the host names of the configured domains.
+func (c *Config) DomainNames() []string {
+	names := make([]string, 0, len(c.Domains))
+	for _, d := range c.Domains {
+		names = append(names, d.Domain)
+	}
+	return names
+}
+
+// DomainRoutes splits the configured domains into the routes to the app and
+// the redirects.
+func (c *Config) DomainRoutes() ([]DomainRoute, []Redirect) {
+	var routes []DomainRoute
+	var redirects []Redirect
+	for _, d := range c.Domains {
+		if d.Redirect != "" {
+			redirects = append(redirects, Redirect{
+				SourceHost: d.Domain,
+				SourcePath: d.PathPrefix,
+				Target:     d.Redirect,
+				Permanent:  true,
+			})
+			continue
+		}
+		routes = append(routes, DomainRoute{
+			Domain:       d.Domain,
+			PathPrefix:   d.PathPrefix,
+			Middlewares:  d.Middlewares,
+			CertResolver: d.CertResolver,
+		})
+	}
+	return routes, redirects
+}
+
+// MergeDomainRoutes combines the routes of the configuration file with the
+// custom domains of the app. A custom domain for the same host and path
+// replaces the route of the file but keeps its middlewares and certificate
+// resolver unless it serves an uploaded certificate.
+func MergeDomainRoutes(configRoutes, customRoutes []DomainRoute) []DomainRoute {
+	type key struct{ domain, pathPrefix string }
+	custom := make(map[key]DomainRoute, len(customRoutes))
+	for _, r := range customRoutes {
+		custom[key{strings.ToLower(r.Domain), r.PathPrefix}] = r
+	}
+
+	merged := make([]DomainRoute, 0, len(configRoutes)+len(customRoutes))
+	replaced := make(map[key]bool)
+	for _, r := range configRoutes {
+		k := key{strings.ToLower(r.Domain), r.PathPrefix}
+		override, ok := custom[k]
+		if !ok {
+			merged = append(merged, r)
+			continue
+		}
+		if replaced[k] {
+			continue
+		}
+		replaced[k] = true
+		override.Middlewares = append(append([]string(nil), r.Middlewares...), override.Middlewares...)
+		if override.CertResolver == "" && !override.CustomCertificate {
+			override.CertResolver = r.CertResolver
+		}
+		merged = append(merged, override)
+	}
+	for _, r := range customRoutes {
+		if !replaced[key{strings.ToLower(r.Domain), r.PathPrefix}] {
+			merged = append(merged, r)
+		}
+	}
+	return merged
+}
+
+var (
+	middlewareNameRe   = regexp.MustCompile(`^[A-Za-z0-9_-]+(@[A-Za-z0-9_-]+)?$`)
+	certResolverNameRe = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+)
+
+func validateDomains(domains []DomainConfig) error {
+	for i, d := range domains {
+		path := fmt.Sprintf("domains[%d]", i)
+		if strings.TrimSpace(d.Domain) == "" {
+			return ConfigError{Path: path + ".domain", Message: "is required"}
+		}
+		if d.PathPrefix != "" && !strings.HasPrefix(d.PathPrefix, "/") {
+			return ConfigError{Path: path + ".pathPrefix", Message: "must start with /"}
+		}
+		if d.Redirect != "" {
+			if !strings.HasPrefix(d.Redirect, "http://") && !strings.HasPrefix(d.Redirect, "https://") {
+				return ConfigError{Path: path + ".redirect", Message: "must be an http or https URL"}
+			}
+			if len(d.Middlewares) > 0 || d.CertResolver != "" {
+				return ConfigError{Path: path, Message: "a redirect cannot have middlewares or a certResolver"}
+			}
+		}
+		for j, name := range d.Middlewares {
+			if !middlewareNameRe.MatchString(name) {
+				return ConfigError{Path: fmt.Sprintf("%s.middlewares[%d]", path, j), Message: "must be a Traefik middleware name such as auth@file"}
+			}
+		}
+		if d.CertResolver != "" && !certResolverNameRe.MatchString(d.CertResolver) {
+			return ConfigError{Path: path + ".certResolver", Message: "must be letters, digits, hyphens and underscores"}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package compose
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestDomainConfigJSON(t *testing.T) {
+	var domains []DomainConfig
+	data := `["example.com", {"domain": "example.com", "pathPrefix": "/admin", "middlewares": ["auth@file"]}]`
+	if err := json.Unmarshal([]byte(data), &domains); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	want := []DomainConfig{
+		{Domain: "example.com"},
+		{Domain: "example.com", PathPrefix: "/admin", Middlewares: []string{"auth@file"}},
+	}
+	if !reflect.DeepEqual(domains, want) {
+		t.Errorf("domains = %+v, want %+v", domains, want)
+	}
+
+	out, err := json.Marshal(domains)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if got := `["example.com",{"domain":"example.com","pathPrefix":"/admin","middlewares":["auth@file"]}]`; string(out) != got {
+		t.Errorf("Marshal = %s, want %s", out, got)
+	}
+}
+
+func TestConfigDomainRoutes(t *testing.T) {
+	cfg := &Config{Domains: []DomainConfig{
+		{Domain: "example.com", CertResolver: "staging"},
+		{Domain: "www.example.com", Redirect: "https://example.com"},
+	}}
+
+	routes, redirects := cfg.DomainRoutes()
+	if want := []DomainRoute{{Domain: "example.com", CertResolver: "staging"}}; !reflect.DeepEqual(routes, want) {
+		t.Errorf("routes = %+v, want %+v", routes, want)
+	}
+	if want := []Redirect{{SourceHost: "www.example.com", Target: "https://example.com", Permanent: true}}; !reflect.DeepEqual(redirects, want) {
+		t.Errorf("redirects = %+v, want %+v", redirects, want)
+	}
+}
+
+func TestMergeDomainRoutes(t *testing.T) {
+	configRoutes := []DomainRoute{
+		{Domain: "example.com", Middlewares: []string{"auth@file"}, CertResolver: "staging"},
+		{Domain: "api.example.com", PathPrefix: "/v1"},
+	}
+	customRoutes := []DomainRoute{
+		{Domain: "Example.com", IPAllowlist: []string{"10.0.0.0/8"}},
+		{Domain: "api.example.com"},
+		{Domain: "shop.example.com", CustomCertificate: true},
+	}
+
+	want := []DomainRoute{
+		{Domain: "Example.com", IPAllowlist: []string{"10.0.0.0/8"}, Middlewares: []string{"auth@file"}, CertResolver: "staging"},
+		{Domain: "api.example.com", PathPrefix: "/v1"},
+		{Domain: "api.example.com"},
+		{Domain: "shop.example.com", CustomCertificate: true},
+	}
+	if got := MergeDomainRoutes(configRoutes, customRoutes); !reflect.DeepEqual(got, want) {
+		t.Errorf("MergeDomainRoutes() = %+v, want %+v", got, want)
+	}
+}
@@ -26,11 +26,19 @@ func sanitizeHealthCheckPath(path string) string {
 }
 
 type GenerateParams struct {
-	AppName  string
-	ImageTag string
-	Config   *Config
-	Domains  []DomainRoute
-	EnvVars  map[string]string
+	AppName   string
+	ImageTag  string
+	Config    *Config
+	Domains   []DomainRoute
+	EnvVars   map[string]string
+	RateLimit *RateLimit
+	Redirects []Redirect
+	Headers   *SecurityHeaders
+	// Internal apps get no Traefik labels and are only reachable on the
+	// paasdeploy network by container name.
+	Internal bool
+	// MeshIP is the server's WireGuard address that mesh ports bind to.
+	MeshIP string
 }
 
 func GenerateContent(params GenerateParams) string {
@@ -40,7 +48,13 @@ func GenerateContent(params GenerateParams) string {
 		ApplyDefaults(cfg)
 	}
 	envYAML := BuildEnvVarsYAML(cfg, params.EnvVars)
-	labels := BuildLabelsYAML(params.AppName, params.Domains, cfg.Port)
+	var labels string
+	if params.Internal {
+		labels = BuildInternalLabelsYAML(params.AppName)
+	} else {
+		labels = BuildLabelsYAML(params.AppName, params.Domains, cfg.Port, params.RateLimit, params.Redirects, params.Headers, cfg.Compress, cfg.Sticky)
+		labels += BuildPortLabelsYAML(params.AppName, cfg.Ports)
+	}
 	portMapping := BuildPortMapping(cfg.HostPort, cfg.Port)
 	healthCmd := BuildHealthCheckCommandTLS(cfg.Runtime, cfg.Port, cfg.Healthcheck.Path, cfg.Healthcheck.TLS)
 	serviceVolumes, topLevelVolumes := BuildVolumesYAML(cfg.Volumes)
@@ -56,6 +70,7 @@ func GenerateContent(params GenerateParams) string {
 		"%s"+
 		"%s"+
 		"%s"+
+		"%s"+
 		"    healthcheck:\n"+
 		"      test:\n"+
 		"        - CMD-SHELL\n"+
@@ -72,6 +87,7 @@ func GenerateContent(params GenerateParams) string {
 		"    networks:\n"+
 		"      - paasdeploy\n\n",
 		params.AppName, params.ImageTag, params.AppName, portMapping,
+		BuildPublishedPortsYAML(cfg.Ports, params.MeshIP),
 		envYAML, labels, serviceVolumes,
 		healthCmd,
 		cfg.Healthcheck.Interval, cfg.Healthcheck.Timeout,
@@ -213,7 +229,7 @@ func buildCurlHealthCmd(url string, useTLS bool) string {
 	return fmt.Sprintf("curl -sf %s || wget -q --spider %s || exit 1", url, url)
 }
 
-func BuildLabelsYAML(appName string, domains []DomainRoute, port int) string {
+func BuildLabelsYAML(appName string, domains []DomainRoute, port int, rateLimit *RateLimit, redirects []Redirect, headers *SecurityHeaders, compress bool, sticky *StickySessions) string {
 	if len(domains) > 0 {
 		var labels strings.Builder
 		labels.WriteString("    labels:\n")
@@ -221,6 +237,27 @@ func BuildLabelsYAML(appName string, domains []DomainRoute, port int) string {
 		labels.WriteString("      - \"traefik.enable=true\"\n")
 		labels.WriteString("      - \"traefik.docker.network=paasdeploy\"\n")
 		labels.WriteString(fmt.Sprintf("      - \"traefik.http.services.%s.loadbalancer.server.port=%d\"\n", appName, port))
+		if sticky != nil {
+			writeStickyLabels(&labels, appName, sticky)
+		}
+
+		rateLimitName := ""
+		if rateLimit != nil && rateLimit.Average > 0 {
+			rateLimitName = appName + "-ratelimit"
+			writeRateLimitLabels(&labels, rateLimitName, rateLimit)
+		}
+
+		headersName := ""
+		if headers.Enabled() {
+			headersName = appName + "-headers"
+			writeSecurityHeadersLabels(&labels, headersName, headers)
+		}
+
+		compressName := ""
+		if compress {
+			compressName = appName + "-compress"
+			labels.WriteString(fmt.Sprintf("      - \"traefik.http.middlewares.%s.compress=true\"\n", compressName))
+		}
 
 		for i, d := range domains {
 			routerName := appName
@@ -241,8 +278,47 @@ func BuildLabelsYAML(appName string, domains []DomainRoute, port int) string {
 			labels.WriteString(fmt.Sprintf("      - \"traefik.http.routers.%s.rule=%s\"\n", routerName, rule))
 			labels.WriteString(fmt.Sprintf("      - \"traefik.http.routers.%s.priority=%d\"\n", routerName, priority))
 			labels.WriteString(fmt.Sprintf("      - \"traefik.http.routers.%s.tls=true\"\n", routerName))
-			labels.WriteString(fmt.Sprintf("      - \"traefik.http.routers.%s.tls.certresolver=letsencrypt\"\n", routerName))
+			if !d.CustomCertificate {
+				certResolver := d.CertResolver
+				if certResolver == "" {
+					certResolver = DefaultCertResolver
+				}
+				labels.WriteString(fmt.Sprintf("      - \"traefik.http.routers.%s.tls.certresolver=%s\"\n", routerName, certResolver))
+			}
 			labels.WriteString(fmt.Sprintf("      - \"traefik.http.routers.%s.service=%s\"\n", routerName, appName))
+
+			// Headers run first so responses rejected by the allowlist,
+			// rate limit or auth carry them too.
+			var middlewares []string
+			if headersName != "" {
+				middlewares = append(middlewares, headersName)
+			}
+			if len(d.IPAllowlist) > 0 {
+				name := routerName + "-ipallowlist"
+				labels.WriteString(fmt.Sprintf("      - \"traefik.http.middlewares.%s.ipallowlist.sourcerange=%s\"\n",
+					name, strings.Join(d.IPAllowlist, ",")))
+				middlewares = append(middlewares, name)
+			}
+			if rateLimitName != "" {
+				middlewares = append(middlewares, rateLimitName)
+			}
+			if len(d.BasicAuthUsers) > 0 {
+				name := routerName + "-auth"
+				labels.WriteString(fmt.Sprintf("      - \"traefik.http.middlewares.%s.basicauth.users=%s\"\n",
+					name, EscapeEnvValue(strings.Join(d.BasicAuthUsers, ","))))
+				middlewares = append(middlewares, name)
+			}
+			middlewares = append(middlewares, d.Middlewares...)
+			if compressName != "" {
+				middlewares = append(middlewares, compressName)
+			}
+			if len(middlewares) > 0 {
+				labels.WriteString(fmt.Sprintf("      - \"traefik.http.routers.%s.middlewares=%s\"\n", routerName, strings.Join(middlewares, ",")))
+			}
+		}
+
+		for i, r := range redirects {
+			writeRedirectLabels(&labels, fmt.Sprintf("%s-redirect-%d", appName, i), appName, r)
 		}
 
 		return labels.String()
@@ -257,6 +333,102 @@ func BuildLabelsYAML(appName string, domains []DomainRoute, port int) string {
 		appName, appName, appName, appName, port)
 }
 
+// BuildInternalLabelsYAML keeps the app label used to find the container but
+// turns Traefik off, so the app gets no public route.
+func BuildInternalLabelsYAML(appName string) string {
+	return fmt.Sprintf("    labels:\n"+
+		"      - \"%s=%s\"\n"+
+		"      - \"traefik.enable=false\"\n",
+		docker.LabelPaasDeployApp, appName)
+}
+
+// writeRedirectLabels adds a router for the redirect source whose only job is
+// to run a redirectregex middleware. Its priority is above the app routers so
+// path redirects win over a catch-all route on the same host.
+func writeRedirectLabels(labels *strings.Builder, name, service string, r Redirect) {
+	rule := fmt.Sprintf("Host(`%s`)", r.SourceHost)
+	if r.SourcePath != "" {
+		rule = fmt.Sprintf("Host(`%s`) && PathPrefix(`%s`)", r.SourceHost, r.SourcePath)
+	}
+	regex := "^https?://" + regexp.QuoteMeta(r.SourceHost) + regexp.QuoteMeta(r.SourcePath) + "(.*)"
+	replacement := strings.TrimSuffix(r.Target, "/") + "${1}"
+
+	labels.WriteString(fmt.Sprintf("      - \"traefik.http.routers.%s.rule=%s\"\n", name, rule))
+	labels.WriteString(fmt.Sprintf("      - \"traefik.http.routers.%s.priority=%d\"\n", name, 1000+len(r.SourcePath)))
+	labels.WriteString(fmt.Sprintf("      - \"traefik.http.routers.%s.tls=true\"\n", name))
+	labels.WriteString(fmt.Sprintf("      - \"traefik.http.routers.%s.tls.certresolver=letsencrypt\"\n", name))
+	labels.WriteString(fmt.Sprintf("      - \"traefik.http.routers.%s.service=%s\"\n", name, service))
+	labels.WriteString(fmt.Sprintf("      - \"traefik.http.routers.%s.middlewares=%s\"\n", name, name))
+	labels.WriteString(fmt.Sprintf("      - \"traefik.http.middlewares.%s.redirectregex.regex=%s\"\n", name, escapeLabelValue(regex)))
+	labels.WriteString(fmt.Sprintf("      - \"traefik.http.middlewares.%s.redirectregex.replacement=%s\"\n", name, escapeLabelValue(replacement)))
+	labels.WriteString(fmt.Sprintf("      - \"traefik.http.middlewares.%s.redirectregex.permanent=%t\"\n", name, r.Permanent))
+}
+
+// escapeLabelValue makes a value safe inside a double-quoted YAML label:
+// backslashes and quotes are escaped for YAML and "$" for compose.
+func escapeLabelValue(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, `"`, `\"`)
+	return EscapeEnvValue(value)
+}
+
+func writeSecurityHeadersLabels(labels *strings.Builder, name string, h *SecurityHeaders) {
+	prefix := "traefik.http.middlewares." + name + ".headers"
+	if h.HSTSMaxAge > 0 {
+		labels.WriteString(fmt.Sprintf("      - \"%s.stsSeconds=%d\"\n", prefix, h.HSTSMaxAge))
+		if h.HSTSIncludeSubdomains {
+			labels.WriteString(fmt.Sprintf("      - \"%s.stsIncludeSubdomains=true\"\n", prefix))
+		}
+		if h.HSTSPreload {
+			labels.WriteString(fmt.Sprintf("      - \"%s.stsPreload=true\"\n", prefix))
+		}
+	}
+	if h.FrameOptions != "" {
+		labels.WriteString(fmt.Sprintf("      - \"%s.customFrameOptionsValue=%s\"\n", prefix, h.FrameOptions))
+	}
+	if h.ContentSecurityPolicy != "" {
+		labels.WriteString(fmt.Sprintf("      - \"%s.contentSecurityPolicy=%s\"\n", prefix, escapeLabelValue(h.ContentSecurityPolicy)))
+	}
+	if h.ReferrerPolicy != "" {
+		labels.WriteString(fmt.Sprintf("      - \"%s.referrerPolicy=%s\"\n", prefix, h.ReferrerPolicy))
+	}
+}
+
+func writeStickyLabels(labels *strings.Builder, service string, s *StickySessions) {
+	prefix := "traefik.http.services." + service + ".loadbalancer.sticky.cookie"
+	labels.WriteString(fmt.Sprintf("      - \"%s=true\"\n", prefix))
+	if s.CookieName != "" {
+		labels.WriteString(fmt.Sprintf("      - \"%s.name=%s\"\n", prefix, escapeLabelValue(s.CookieName)))
+	}
+	if s.Secure {
+		labels.WriteString(fmt.Sprintf("      - \"%s.secure=true\"\n", prefix))
+	}
+	if s.HTTPOnly {
+		labels.WriteString(fmt.Sprintf("      - \"%s.httponly=true\"\n", prefix))
+	}
+	if s.SameSite != "" {
+		labels.WriteString(fmt.Sprintf("      - \"%s.samesite=%s\"\n", prefix, s.SameSite))
+	}
+}
+
+func writeRateLimitLabels(labels *strings.Builder, name string, rl *RateLimit) {
+	prefix := "traefik.http.middlewares." + name + ".ratelimit"
+	labels.WriteString(fmt.Sprintf("      - \"%s.average=%d\"\n", prefix, rl.Average))
+	if rl.Burst > 0 {
+		labels.WriteString(fmt.Sprintf("      - \"%s.burst=%d\"\n", prefix, rl.Burst))
+	}
+	switch rl.Source {
+	case RateLimitSourceForwarded:
+		labels.WriteString(fmt.Sprintf("      - \"%s.sourcecriterion.ipstrategy.depth=1\"\n", prefix))
+	case RateLimitSourceHost:
+		labels.WriteString(fmt.Sprintf("      - \"%s.sourcecriterion.requesthost=true\"\n", prefix))
+	case RateLimitSourceHeader:
+		if rl.Header != "" {
+			labels.WriteString(fmt.Sprintf("      - \"%s.sourcecriterion.requestheadername=%s\"\n", prefix, rl.Header))
+		}
+	}
+}
+
 func BuildVolumesYAML(volumes []VolumeConfig) (serviceLevel string, topLevel string) {
 	if len(volumes) == 0 {
 		return "", ""
@@ -292,6 +464,51 @@ func BuildVolumesYAML(volumes []VolumeConfig) (serviceLevel string, topLevel str
 	return svc.String(), ""
 }
 
+// BuildPortLabelsYAML adds a Traefik TCP or UDP router per port routed
+// through an entrypoint. TCP routers match any SNI, so plain TCP works.
+func BuildPortLabelsYAML(appName string, ports []PortConfig) string {
+	var labels strings.Builder
+	for _, p := range ports {
+		if p.Entrypoint == "" {
+			continue
+		}
+		name := fmt.Sprintf("%s-%s", appName, p.Entrypoint)
+		if p.Protocol == PortProtocolUDP {
+			labels.WriteString(fmt.Sprintf("      - \"traefik.udp.routers.%s.entrypoints=%s\"\n", name, p.Entrypoint))
+			labels.WriteString(fmt.Sprintf("      - \"traefik.udp.routers.%s.service=%s\"\n", name, name))
+			labels.WriteString(fmt.Sprintf("      - \"traefik.udp.services.%s.loadbalancer.server.port=%d\"\n", name, p.Port))
+			continue
+		}
+		labels.WriteString(fmt.Sprintf("      - \"traefik.tcp.routers.%s.entrypoints=%s\"\n", name, p.Entrypoint))
+		labels.WriteString(fmt.Sprintf("      - \"traefik.tcp.routers.%s.rule=HostSNI(`*`)\"\n", name))
+		labels.WriteString(fmt.Sprintf("      - \"traefik.tcp.routers.%s.service=%s\"\n", name, name))
+		labels.WriteString(fmt.Sprintf("      - \"traefik.tcp.services.%s.loadbalancer.server.port=%d\"\n", name, p.Port))
+	}
+	return labels.String()
+}
+
+// BuildPublishedPortsYAML lists the ports published directly on the host,
+// to be appended to the service's ports section. Mesh ports bind to meshIP
+// and are left out when the server has not joined the mesh.
+func BuildPublishedPortsYAML(ports []PortConfig, meshIP string) string {
+	var sb strings.Builder
+	for _, p := range ports {
+		if p.HostPort == 0 || (p.Mesh && meshIP == "") {
+			continue
+		}
+		protocol := p.Protocol
+		if protocol == "" {
+			protocol = PortProtocolTCP
+		}
+		if p.Mesh {
+			sb.WriteString(fmt.Sprintf("      - \"%s:%d:%d/%s\"\n", meshIP, p.HostPort, p.Port, protocol))
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("      - \"%d:%d/%s\"\n", p.HostPort, p.Port, protocol))
+	}
+	return sb.String()
+}
+
 func BuildPortMapping(hostPort, port int) string {
 	if hostPort > 0 {
 		return fmt.Sprintf("%d:%d", hostPort, port)
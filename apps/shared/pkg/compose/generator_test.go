@@ -157,3 +157,293 @@ func TestVolumeConfigIsNamedVolume(t *testing.T) {
 		t.Error("volume with only Source should not be identified as named")
 	}
 }
+
+func TestBuildLabelsYAMLBasicAuth(t *testing.T) {
+	labels := BuildLabelsYAML(testAppName, []DomainRoute{
+		{Domain: "staging.example.com", BasicAuthUsers: []string{"admin:$2a$10$abc", "qa:$2a$10$def"}},
+		{Domain: "example.com"},
+	}, 3000, nil, nil, nil, false, nil)
+
+	if !strings.Contains(labels, `traefik.http.middlewares.test-app-auth.basicauth.users=admin:$$2a$$10$$abc,qa:$$2a$$10$$def"`) {
+		t.Errorf("expected escaped basicauth users label, got:\n%s", labels)
+	}
+	if !strings.Contains(labels, "traefik.http.routers.test-app.middlewares=test-app-auth") {
+		t.Errorf("expected router to use auth middleware, got:\n%s", labels)
+	}
+	if strings.Contains(labels, "traefik.http.routers.test-app-1.middlewares") {
+		t.Errorf("unprotected domain should not have middlewares, got:\n%s", labels)
+	}
+}
+
+func TestBuildLabelsYAMLRateLimit(t *testing.T) {
+	labels := BuildLabelsYAML(testAppName, []DomainRoute{
+		{Domain: "example.com", BasicAuthUsers: []string{"admin:hash"}},
+		{Domain: "api.example.com"},
+	}, 3000, &RateLimit{Average: 50, Burst: 100, Source: RateLimitSourceForwarded}, nil, nil, false, nil)
+
+	for _, want := range []string{
+		"traefik.http.middlewares.test-app-ratelimit.ratelimit.average=50",
+		"traefik.http.middlewares.test-app-ratelimit.ratelimit.burst=100",
+		"traefik.http.middlewares.test-app-ratelimit.ratelimit.sourcecriterion.ipstrategy.depth=1",
+		"traefik.http.routers.test-app.middlewares=test-app-ratelimit,test-app-auth",
+		"traefik.http.routers.test-app-1.middlewares=test-app-ratelimit",
+	} {
+		if !strings.Contains(labels, want) {
+			t.Errorf("expected %q in labels, got:\n%s", want, labels)
+		}
+	}
+}
+
+func TestBuildLabelsYAMLRateLimitDisabled(t *testing.T) {
+	labels := BuildLabelsYAML(testAppName, []DomainRoute{{Domain: "example.com"}}, 3000, &RateLimit{}, nil, nil, false, nil)
+	if strings.Contains(labels, "ratelimit") || strings.Contains(labels, "middlewares") {
+		t.Errorf("zero rate limit should not render middlewares, got:\n%s", labels)
+	}
+}
+
+func TestBuildLabelsYAMLIPAllowlist(t *testing.T) {
+	labels := BuildLabelsYAML(testAppName, []DomainRoute{
+		{Domain: "admin.example.com", IPAllowlist: []string{"203.0.113.0/24", "198.51.100.7"}, BasicAuthUsers: []string{"admin:hash"}},
+	}, 3000, &RateLimit{Average: 10}, nil, nil, false, nil)
+
+	if !strings.Contains(labels, "traefik.http.middlewares.test-app-ipallowlist.ipallowlist.sourcerange=203.0.113.0/24,198.51.100.7") {
+		t.Errorf("expected ipallowlist label, got:\n%s", labels)
+	}
+	if !strings.Contains(labels, "traefik.http.routers.test-app.middlewares=test-app-ipallowlist,test-app-ratelimit,test-app-auth") {
+		t.Errorf("allowlist should run before rate limit and auth, got:\n%s", labels)
+	}
+}
+
+func TestBuildLabelsYAMLCustomCertificate(t *testing.T) {
+	labels := BuildLabelsYAML(testAppName, []DomainRoute{
+		{Domain: "example.com", CustomCertificate: true},
+		{Domain: "api.example.com"},
+	}, 3000, nil, nil, nil, false, nil)
+
+	if strings.Contains(labels, "traefik.http.routers.test-app.tls.certresolver") {
+		t.Errorf("custom certificate route should not use the ACME resolver, got:\n%s", labels)
+	}
+	if !strings.Contains(labels, "traefik.http.routers.test-app.tls=true") {
+		t.Errorf("custom certificate route should still enable TLS, got:\n%s", labels)
+	}
+	if !strings.Contains(labels, "traefik.http.routers.test-app-1.tls.certresolver=letsencrypt") {
+		t.Errorf("other routes should keep the ACME resolver, got:\n%s", labels)
+	}
+}
+
+func TestBuildLabelsYAMLDomainOptions(t *testing.T) {
+	labels := BuildLabelsYAML(testAppName, []DomainRoute{
+		{Domain: "example.com"},
+		{Domain: "example.com", PathPrefix: "/admin", Middlewares: []string{"auth@file"}, CertResolver: "staging"},
+	}, 3000, nil, nil, nil, true, nil)
+
+	if !strings.Contains(labels, "traefik.http.routers.test-app.tls.certresolver=letsencrypt") {
+		t.Errorf("expected default cert resolver, got:\n%s", labels)
+	}
+	if !strings.Contains(labels, "traefik.http.routers.test-app-1.tls.certresolver=staging") {
+		t.Errorf("expected staging cert resolver, got:\n%s", labels)
+	}
+	if !strings.Contains(labels, "traefik.http.routers.test-app-1.middlewares=auth@file,test-app-compress") {
+		t.Errorf("extra middlewares should run before compression, got:\n%s", labels)
+	}
+}
+
+func TestBuildLabelsYAMLRedirects(t *testing.T) {
+	labels := BuildLabelsYAML(testAppName, []DomainRoute{{Domain: "example.com"}}, 3000, nil, []Redirect{
+		{SourceHost: "www.example.com", Target: "https://example.com", Permanent: true},
+		{SourceHost: "example.com", SourcePath: "/old-blog", Target: "https://example.com/blog/"},
+	}, nil, false, nil)
+
+	for _, want := range []string{
+		"traefik.http.routers.test-app-redirect-0.rule=Host(`www.example.com`)\"",
+		`traefik.http.middlewares.test-app-redirect-0.redirectregex.regex=^https?://www\\.example\\.com(.*)"`,
+		"traefik.http.middlewares.test-app-redirect-0.redirectregex.replacement=https://example.com$${1}\"",
+		"traefik.http.middlewares.test-app-redirect-0.redirectregex.permanent=true",
+		"traefik.http.routers.test-app-redirect-1.rule=Host(`example.com`) && PathPrefix(`/old-blog`)",
+		"traefik.http.routers.test-app-redirect-1.priority=1009",
+		"traefik.http.middlewares.test-app-redirect-1.redirectregex.replacement=https://example.com/blog$${1}\"",
+		"traefik.http.middlewares.test-app-redirect-1.redirectregex.permanent=false",
+	} {
+		if !strings.Contains(labels, want) {
+			t.Errorf("expected %q in labels, got:\n%s", want, labels)
+		}
+	}
+}
+
+func TestBuildLabelsYAMLSecurityHeaders(t *testing.T) {
+	labels := BuildLabelsYAML(testAppName, []DomainRoute{
+		{Domain: "example.com", BasicAuthUsers: []string{"admin:hash"}},
+	}, 3000, &RateLimit{Average: 10}, []Redirect{
+		{SourceHost: "www.example.com", Target: "https://example.com", Permanent: true},
+	}, &SecurityHeaders{
+		HSTSMaxAge:            31536000,
+		HSTSIncludeSubdomains: true,
+		FrameOptions:          "DENY",
+		ContentSecurityPolicy: "default-src 'self'; script-src 'self' \"nonce-$x\"",
+		ReferrerPolicy:        "strict-origin-when-cross-origin",
+	}, false, nil)
+
+	for _, want := range []string{
+		"traefik.http.middlewares.test-app-headers.headers.stsSeconds=31536000",
+		"traefik.http.middlewares.test-app-headers.headers.stsIncludeSubdomains=true",
+		"traefik.http.middlewares.test-app-headers.headers.customFrameOptionsValue=DENY",
+		`traefik.http.middlewares.test-app-headers.headers.contentSecurityPolicy=default-src 'self'; script-src 'self' \"nonce-$$x\""`,
+		"traefik.http.middlewares.test-app-headers.headers.referrerPolicy=strict-origin-when-cross-origin",
+		"traefik.http.routers.test-app.middlewares=test-app-headers,test-app-ratelimit,test-app-auth",
+	} {
+		if !strings.Contains(labels, want) {
+			t.Errorf("expected %q in labels, got:\n%s", want, labels)
+		}
+	}
+	if strings.Contains(labels, "stsPreload") {
+		t.Errorf("preload should not be set, got:\n%s", labels)
+	}
+	if strings.Contains(labels, "test-app-redirect-0.middlewares=test-app-headers") {
+		t.Errorf("redirect routers should not use the headers middleware, got:\n%s", labels)
+	}
+}
+
+func TestBuildLabelsYAMLSecurityHeadersDisabled(t *testing.T) {
+	labels := BuildLabelsYAML(testAppName, []DomainRoute{{Domain: "example.com"}}, 3000, nil, nil, &SecurityHeaders{HSTSIncludeSubdomains: true}, false, nil)
+	if strings.Contains(labels, "headers") || strings.Contains(labels, "middlewares") {
+		t.Errorf("empty security headers should not render middlewares, got:\n%s", labels)
+	}
+}
+
+func TestBuildLabelsYAMLCompress(t *testing.T) {
+	labels := BuildLabelsYAML(testAppName, []DomainRoute{
+		{Domain: "example.com", BasicAuthUsers: []string{"admin:hash"}},
+		{Domain: "api.example.com"},
+	}, 3000, nil, nil, &SecurityHeaders{FrameOptions: "DENY"}, true, nil)
+
+	for _, want := range []string{
+		"traefik.http.middlewares.test-app-compress.compress=true",
+		"traefik.http.routers.test-app.middlewares=test-app-headers,test-app-auth,test-app-compress",
+		"traefik.http.routers.test-app-1.middlewares=test-app-headers,test-app-compress",
+	} {
+		if !strings.Contains(labels, want) {
+			t.Errorf("expected %q in labels, got:\n%s", want, labels)
+		}
+	}
+}
+
+func TestBuildLabelsYAMLStickySessions(t *testing.T) {
+	labels := BuildLabelsYAML(testAppName, []DomainRoute{{Domain: "example.com"}}, 3000, nil, nil, nil, false, &StickySessions{
+		CookieName: "app_affinity",
+		Secure:     true,
+		HTTPOnly:   true,
+		SameSite:   "lax",
+	})
+
+	for _, want := range []string{
+		"traefik.http.services.test-app.loadbalancer.sticky.cookie=true",
+		"traefik.http.services.test-app.loadbalancer.sticky.cookie.name=app_affinity",
+		"traefik.http.services.test-app.loadbalancer.sticky.cookie.secure=true",
+		"traefik.http.services.test-app.loadbalancer.sticky.cookie.httponly=true",
+		"traefik.http.services.test-app.loadbalancer.sticky.cookie.samesite=lax",
+	} {
+		if !strings.Contains(labels, want) {
+			t.Errorf("expected %q in labels, got:\n%s", want, labels)
+		}
+	}
+}
+
+func TestGenerateContentWithPorts(t *testing.T) {
+	cfg := &Config{
+		Name: testAppName,
+		Port: 3000,
+		Ports: []PortConfig{
+			{Port: 1883, Entrypoint: "mqtt"},
+			{Port: 27015, Protocol: PortProtocolUDP, Entrypoint: "game"},
+			{Port: 5432, HostPort: 15432},
+		},
+	}
+	ApplyDefaults(cfg)
+
+	content := GenerateContent(GenerateParams{
+		AppName:  testAppName,
+		ImageTag: testAppName + ":latest",
+		Config:   cfg,
+	})
+
+	for _, want := range []string{
+		"      - \"3000\"\n      - \"15432:5432/tcp\"\n",
+		"traefik.tcp.routers.test-app-mqtt.entrypoints=mqtt",
+		"traefik.tcp.routers.test-app-mqtt.rule=HostSNI(`*`)",
+		"traefik.tcp.services.test-app-mqtt.loadbalancer.server.port=1883",
+		"traefik.udp.routers.test-app-game.entrypoints=game",
+		"traefik.udp.services.test-app-game.loadbalancer.server.port=27015",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("expected %q in compose, got:\n%s", want, content)
+		}
+	}
+	if strings.Contains(content, "traefik.tcp.routers.test-app-5432") || strings.Contains(content, "\"1883:") {
+		t.Errorf("ports should be either routed or published, got:\n%s", content)
+	}
+}
+
+func TestBuildPublishedPortsYAMLMesh(t *testing.T) {
+	ports := []PortConfig{
+		{Port: 5432, HostPort: 5432, Mesh: true},
+		{Port: 6379, HostPort: 16379},
+	}
+
+	got := BuildPublishedPortsYAML(ports, "10.210.0.2")
+	want := "      - \"10.210.0.2:5432:5432/tcp\"\n      - \"16379:6379/tcp\"\n"
+	if got != want {
+		t.Errorf("BuildPublishedPortsYAML() = %q, want %q", got, want)
+	}
+
+	if got := BuildPublishedPortsYAML(ports, ""); got != "      - \"16379:6379/tcp\"\n" {
+		t.Errorf("expected mesh port to be skipped outside the mesh, got %q", got)
+	}
+}
+
+func TestValidatePorts(t *testing.T) {
+	tests := []struct {
+		name    string
+		ports   []PortConfig
+		wantErr bool
+	}{
+		{"entrypoint", []PortConfig{{Port: 1883, Entrypoint: "mqtt"}}, false},
+		{"host port", []PortConfig{{Port: 5432, Protocol: PortProtocolTCP, HostPort: 15432}}, false},
+		{"neither", []PortConfig{{Port: 1883}}, true},
+		{"both", []PortConfig{{Port: 1883, Entrypoint: "mqtt", HostPort: 1883}}, true},
+		{"invalid protocol", []PortConfig{{Port: 1883, Protocol: "sctp", HostPort: 1883}}, true},
+		{"invalid entrypoint", []PortConfig{{Port: 1883, Entrypoint: "mqtt\""}}, true},
+		{"port out of range", []PortConfig{{Port: 0, HostPort: 1883}}, true},
+		{"mesh", []PortConfig{{Port: 5432, HostPort: 5432, Mesh: true}}, false},
+		{"mesh without host port", []PortConfig{{Port: 5432, Entrypoint: "pg", Mesh: true}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validatePorts(tt.ports); (err != nil) != tt.wantErr {
+				t.Fatalf("validatePorts() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGenerateContentInternal(t *testing.T) {
+	cfg := &Config{Name: testAppName, Port: 3000, Ports: []PortConfig{{Port: 1883, Entrypoint: "mqtt"}}}
+	ApplyDefaults(cfg)
+
+	content := GenerateContent(GenerateParams{
+		AppName:  testAppName,
+		ImageTag: testAppName + ":latest",
+		Config:   cfg,
+		Domains:  []DomainRoute{{Domain: "example.com"}},
+		Internal: true,
+	})
+
+	if !strings.Contains(content, "traefik.enable=false") {
+		t.Errorf("internal app should disable Traefik, got:\n%s", content)
+	}
+	if strings.Contains(content, "traefik.http.routers") || strings.Contains(content, "traefik.tcp.routers") {
+		t.Errorf("internal app should not get routers, got:\n%s", content)
+	}
+	if !strings.Contains(content, "networks:\n      - paasdeploy") {
+		t.Errorf("internal app should stay on the paasdeploy network, got:\n%s", content)
+	}
+}
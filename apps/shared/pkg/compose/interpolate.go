@@ -0,0 +1,65 @@
+package compose
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// interpolationRe matches ${VAR} and ${VAR:-default}.
+var interpolationRe = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)(?::-([^}]*))?\}`)
+
+// Interpolate resolves ${VAR} references in the domains and their redirect
+// targets, build args and healthcheck path against env, the app's environment variables, falling
+// back to the env of the file itself. ${VAR:-default} supplies a value for
+// a variable that is unset or empty. It returns the names of the variables
+// that could not be resolved, which are replaced by an empty string; a
+// domain left empty is dropped.
+func (c *Config) Interpolate(env map[string]string) []string {
+	missing := make(map[string]bool)
+	expand := func(value string) string {
+		if !strings.Contains(value, "${") {
+			return value
+		}
+		return interpolationRe.ReplaceAllStringFunc(value, func(ref string) string {
+			match := interpolationRe.FindStringSubmatch(ref)
+			name, hasDefault := match[1], strings.Contains(ref, ":-")
+			if v := env[name]; v != "" {
+				return v
+			}
+			if v := c.Env[name]; v != "" {
+				return v
+			}
+			if hasDefault {
+				return match[2]
+			}
+			missing[name] = true
+			return ""
+		})
+	}
+
+	domains := c.Domains[:0]
+	for _, d := range c.Domains {
+		d.Domain = strings.TrimSpace(expand(d.Domain))
+		d.Redirect = expand(d.Redirect)
+		if d.Domain != "" {
+			domains = append(domains, d)
+		}
+	}
+	c.Domains = domains
+
+	for key, value := range c.Build.Args {
+		c.Build.Args[key] = expand(value)
+	}
+	c.Healthcheck.Path = expand(c.Healthcheck.Path)
+
+	if len(missing) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(missing))
+	for name := range missing {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,43 @@
+package compose
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestConfigInterpolate(t *testing.T) {
+	cfg := &Config{
+		Domains: append(Hosts([]string{"${APP_HOST}", "www.${APP_HOST}", "${PREVIEW_HOST}"}),
+			DomainConfig{Domain: "static.example.com", Redirect: "https://${APP_HOST}/static"}),
+		Env: map[string]string{"API_VERSION": "v2"},
+	}
+	cfg.Build.Args = map[string]string{
+		"API_URL": "https://${APP_HOST}/api/${API_VERSION}",
+		"MODE":    "${BUILD_MODE:-production}",
+		"TOKEN":   "${NPM_TOKEN}",
+	}
+	cfg.Healthcheck.Path = "/${API_VERSION}/health"
+
+	missing := cfg.Interpolate(map[string]string{"APP_HOST": "shop.example.com"})
+
+	if want := []string{"NPM_TOKEN", "PREVIEW_HOST"}; !reflect.DeepEqual(missing, want) {
+		t.Errorf("missing = %v, want %v", missing, want)
+	}
+	if want := []string{"shop.example.com", "www.shop.example.com", "static.example.com"}; !reflect.DeepEqual(cfg.DomainNames(), want) {
+		t.Errorf("domains = %v, want %v", cfg.DomainNames(), want)
+	}
+	if cfg.Domains[2].Redirect != "https://shop.example.com/static" {
+		t.Errorf("redirect = %q, want https://shop.example.com/static", cfg.Domains[2].Redirect)
+	}
+	wantArgs := map[string]string{
+		"API_URL": "https://shop.example.com/api/v2",
+		"MODE":    "production",
+		"TOKEN":   "",
+	}
+	if !reflect.DeepEqual(cfg.Build.Args, wantArgs) {
+		t.Errorf("build args = %v, want %v", cfg.Build.Args, wantArgs)
+	}
+	if cfg.Healthcheck.Path != "/v2/health" {
+		t.Errorf("healthcheck path = %q, want /v2/health", cfg.Healthcheck.Path)
+	}
+}
@@ -0,0 +1,99 @@
+package compose
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/paasdeploy/shared/pkg/docker"
+)
+
+const (
+	PortSourceDockerfile = "EXPOSE in Dockerfile"
+	PortSourceDefault    = "platform default"
+)
+
+// frameworkPort is the port a framework listens on unless told otherwise,
+// recognized from a dependency or a file of the app.
+type frameworkPort struct {
+	name string
+	port int
+}
+
+// nodeFrameworkPorts are checked in order, so frameworks that may pull in
+// express come before it.
+var nodeFrameworkPorts = []struct {
+	dependency string
+	frameworkPort
+}{
+	{"next", frameworkPort{"Next.js", 3000}},
+	{"nuxt", frameworkPort{"Nuxt", 3000}},
+	{"@remix-run/serve", frameworkPort{"Remix", 3000}},
+	{"@sveltejs/adapter-node", frameworkPort{"SvelteKit", 3000}},
+	{"@nestjs/core", frameworkPort{"NestJS", 3000}},
+	{"express", frameworkPort{"Express", 3000}},
+}
+
+// fileFrameworkPorts match a file of the app against a pattern, or its mere
+// presence when the pattern is nil.
+var fileFrameworkPorts = []struct {
+	file    string
+	pattern *regexp.Regexp
+	frameworkPort
+}{
+	{"mix.exs", regexp.MustCompile(`:phoenix\b`), frameworkPort{"Phoenix", 4000}},
+	{"Gemfile", regexp.MustCompile(`(?m)^\s*gem\s+["']rails["']`), frameworkPort{"Rails", 3000}},
+	{"manage.py", nil, frameworkPort{"Django", 8000}},
+	{"requirements.txt", regexp.MustCompile(`(?im)^\s*(fastapi|uvicorn)\b`), frameworkPort{"FastAPI", 8000}},
+	{"pyproject.toml", regexp.MustCompile(`(?i)["'\s](fastapi|uvicorn)\b`), frameworkPort{"FastAPI", 8000}},
+	{"requirements.txt", regexp.MustCompile(`(?im)^\s*flask\b`), frameworkPort{"Flask", 5000}},
+	{"pyproject.toml", regexp.MustCompile(`(?i)["'\s]flask\b`), frameworkPort{"Flask", 5000}},
+}
+
+// DetectPort guesses the port of an app whose configuration does not set
+// one: from the EXPOSE instructions of its Dockerfile, then from the
+// default of the framework it uses. It returns 0 and an empty source when
+// neither gives a port.
+func DetectPort(appDir string, config *Config) (int, string) {
+	dockerfile := config.Build.Dockerfile
+	if dockerfile == "" {
+		dockerfile = "./Dockerfile"
+	}
+	if path, err := SafeJoin(appDir, dockerfile); err == nil {
+		if port, err := docker.ExposedPort(path, config.Build.Target); err == nil && port > 0 {
+			return port, PortSourceDockerfile
+		}
+	}
+
+	if fw, ok := detectFrameworkPort(appDir); ok {
+		return fw.port, fw.name + " default"
+	}
+	return 0, ""
+}
+
+func detectFrameworkPort(appDir string) (frameworkPort, bool) {
+	if data, err := os.ReadFile(filepath.Join(appDir, "package.json")); err == nil {
+		var pkg struct {
+			Dependencies map[string]string `json:"dependencies"`
+		}
+		if json.Unmarshal(data, &pkg) == nil {
+			for _, fw := range nodeFrameworkPorts {
+				if _, ok := pkg.Dependencies[fw.dependency]; ok {
+					return fw.frameworkPort, true
+				}
+			}
+		}
+	}
+
+	for _, fw := range fileFrameworkPorts {
+		data, err := os.ReadFile(filepath.Join(appDir, fw.file))
+		if err != nil {
+			continue
+		}
+		if fw.pattern == nil || fw.pattern.Match(data) {
+			return fw.frameworkPort, true
+		}
+	}
+	return frameworkPort{}, false
+}
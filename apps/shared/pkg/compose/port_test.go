@@ -0,0 +1,60 @@
+package compose
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadConfigDetectsPort(t *testing.T) {
+	tests := []struct {
+		name       string
+		files      map[string]string
+		wantPort   int
+		wantSource string
+	}{
+		{"set in config", map[string]string{
+			"paasdeploy.json": `{"name": "api", "port": 9000}`,
+			"Dockerfile":      "FROM node:20\nEXPOSE 3000\n",
+		}, 9000, ""},
+		{"dockerfile", map[string]string{
+			"paasdeploy.json":   `{"name": "api", "build": {"dockerfile": "./docker/Dockerfile"}}`,
+			"docker/Dockerfile": "FROM golang:1.24 AS build\nFROM alpine\nEXPOSE 8081\n",
+		}, 8081, PortSourceDockerfile},
+		{"node framework", map[string]string{
+			"paasdeploy.json": `{"name": "web"}`,
+			"Dockerfile":      "FROM node:20\nCMD [\"npm\", \"start\"]\n",
+			"package.json":    `{"dependencies": {"express": "^4.19.0", "next": "14.2.0"}}`,
+		}, 3000, "Next.js default"},
+		{"python framework", map[string]string{
+			"paasdeploy.json":  `{"name": "api"}`,
+			"requirements.txt": "Flask==3.0.0\ngunicorn\n",
+		}, 5000, "Flask default"},
+		{"nothing detected", map[string]string{
+			"paasdeploy.json": `{"name": "api"}`,
+			"Dockerfile":      "FROM alpine\n",
+		}, DefaultAppPort, PortSourceDefault},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range tt.files {
+				path := filepath.Join(dir, name)
+				if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			cfg, err := LoadConfig(dir)
+			if err != nil {
+				t.Fatalf("LoadConfig: %v", err)
+			}
+			if cfg.Port != tt.wantPort || cfg.PortSource != tt.wantSource {
+				t.Errorf("port = %d (%q), want %d (%q)", cfg.Port, cfg.PortSource, tt.wantPort, tt.wantSource)
+			}
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package compose
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var hostRuleRe = regexp.MustCompile("Host\\(`([^`]+)`\\)")
+
+// ProjectService is a service of an existing compose project, as resolved by
+// `docker compose config --format json`. BuildContext is relative to the
+// project directory; named volumes are mapped to the data directory of the
+// Docker volume the project created, so adopted apps keep their data.
+type ProjectService struct {
+	Name         string
+	Image        string
+	BuildContext string
+	Dockerfile   string
+	Environment  map[string]string
+	Port         int
+	Volumes      []VolumeConfig
+	Domains      []string
+}
+
+type projectJSON struct {
+	Name     string `json:"name"`
+	Services map[string]struct {
+		Image string `json:"image"`
+		Build *struct {
+			Context    string `json:"context"`
+			Dockerfile string `json:"dockerfile"`
+		} `json:"build"`
+		Environment map[string]*string `json:"environment"`
+		Ports       []struct {
+			Target int `json:"target"`
+		} `json:"ports"`
+		Expose  []string          `json:"expose"`
+		Labels  map[string]string `json:"labels"`
+		Volumes []struct {
+			Type     string `json:"type"`
+			Source   string `json:"source"`
+			Target   string `json:"target"`
+			ReadOnly bool   `json:"read_only"`
+		} `json:"volumes"`
+	} `json:"services"`
+	Volumes map[string]struct {
+		Name string `json:"name"`
+	} `json:"volumes"`
+}
+
+// ParseProject reads the resolved config of the compose project in dir and
+// returns its name and services sorted by name.
+func ParseProject(data []byte, dir string) (string, []ProjectService, error) {
+	var p projectJSON
+	if err := json.Unmarshal(data, &p); err != nil {
+		return "", nil, fmt.Errorf("parse compose config: %w", err)
+	}
+
+	services := make([]ProjectService, 0, len(p.Services))
+	for name, svc := range p.Services {
+		s := ProjectService{
+			Name:        name,
+			Image:       svc.Image,
+			Environment: make(map[string]string, len(svc.Environment)),
+		}
+		if svc.Build != nil {
+			s.BuildContext = relativeToProject(dir, svc.Build.Context)
+			s.Dockerfile = svc.Build.Dockerfile
+		}
+		for k, v := range svc.Environment {
+			if v != nil {
+				s.Environment[k] = *v
+			}
+		}
+
+		if len(svc.Ports) > 0 {
+			s.Port = svc.Ports[0].Target
+		} else if len(svc.Expose) > 0 {
+			s.Port, _ = strconv.Atoi(strings.SplitN(svc.Expose[0], "/", 2)[0])
+		}
+
+		for _, v := range svc.Volumes {
+			switch v.Type {
+			case "bind":
+				s.Volumes = append(s.Volumes, VolumeConfig{Source: v.Source, Target: v.Target, ReadOnly: v.ReadOnly})
+			case "volume":
+				volumeName := v.Source
+				if top, ok := p.Volumes[v.Source]; ok && top.Name != "" {
+					volumeName = top.Name
+				}
+				if volumeName == "" {
+					continue
+				}
+				s.Volumes = append(s.Volumes, VolumeConfig{
+					Source:   "/var/lib/docker/volumes/" + volumeName + "/_data",
+					Target:   v.Target,
+					ReadOnly: v.ReadOnly,
+				})
+			}
+		}
+
+		s.Domains = routerDomains(svc.Labels)
+		services = append(services, s)
+	}
+	sort.Slice(services, func(i, j int) bool { return services[i].Name < services[j].Name })
+	return p.Name, services, nil
+}
+
+// routerDomains collects the hosts of Traefik router rules in labels.
+func routerDomains(labels map[string]string) []string {
+	seen := map[string]bool{}
+	var domains []string
+	for key, value := range labels {
+		if !strings.HasPrefix(key, "traefik.http.routers.") || !strings.HasSuffix(key, ".rule") {
+			continue
+		}
+		for _, m := range hostRuleRe.FindAllStringSubmatch(value, -1) {
+			if !seen[m[1]] {
+				seen[m[1]] = true
+				domains = append(domains, m[1])
+			}
+		}
+	}
+	sort.Strings(domains)
+	return domains
+}
+
+func relativeToProject(dir, path string) string {
+	if path == "" || !filepath.IsAbs(path) {
+		return path
+	}
+	rel, err := filepath.Rel(dir, path)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return path
+	}
+	if rel == "." {
+		return "."
+	}
+	return "./" + rel
+}
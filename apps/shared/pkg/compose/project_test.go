@@ -0,0 +1,81 @@
+package compose
+
+import (
+	"reflect"
+	"testing"
+)
+
+const testProjectJSON = `{
+  "name": "shop",
+  "services": {
+    "web": {
+      "build": {"context": "/srv/shop/web", "dockerfile": "Dockerfile.prod"},
+      "environment": {"API_URL": "http://api:3000", "UNSET": null},
+      "ports": [{"mode": "ingress", "target": 8080, "published": "80", "protocol": "tcp"}],
+      "labels": {
+        "traefik.enable": "true",
+        "traefik.http.routers.web.rule": "Host(` + "`shop.example.com`" + `) || Host(` + "`www.shop.example.com`" + `)"
+      },
+      "volumes": [{"type": "bind", "source": "/srv/shop/uploads", "target": "/app/uploads", "read_only": true}]
+    },
+    "db": {
+      "image": "postgres:16",
+      "expose": ["5432/tcp"],
+      "volumes": [{"type": "volume", "source": "dbdata", "target": "/var/lib/postgresql/data"}]
+    }
+  },
+  "volumes": {"dbdata": {"name": "shop_dbdata"}}
+}`
+
+func TestParseProject(t *testing.T) {
+	name, services, err := ParseProject([]byte(testProjectJSON), "/srv/shop")
+	if err != nil {
+		t.Fatalf("ParseProject: %v", err)
+	}
+	if name != "shop" || len(services) != 2 {
+		t.Fatalf("unexpected project %q with %d services", name, len(services))
+	}
+
+	db, web := services[0], services[1]
+	if db.Name != "db" || db.Image != "postgres:16" || db.Port != 5432 || db.BuildContext != "" {
+		t.Errorf("unexpected db service %+v", db)
+	}
+	wantDBVolumes := []VolumeConfig{{Source: "/var/lib/docker/volumes/shop_dbdata/_data", Target: "/var/lib/postgresql/data"}}
+	if !reflect.DeepEqual(db.Volumes, wantDBVolumes) {
+		t.Errorf("unexpected db volumes %+v", db.Volumes)
+	}
+
+	if web.BuildContext != "./web" || web.Dockerfile != "Dockerfile.prod" || web.Port != 8080 {
+		t.Errorf("unexpected web service %+v", web)
+	}
+	if !reflect.DeepEqual(web.Environment, map[string]string{"API_URL": "http://api:3000"}) {
+		t.Errorf("unset variables should be dropped, got %v", web.Environment)
+	}
+	if !reflect.DeepEqual(web.Domains, []string{"shop.example.com", "www.shop.example.com"}) {
+		t.Errorf("unexpected domains %v", web.Domains)
+	}
+	wantWebVolumes := []VolumeConfig{{Source: "/srv/shop/uploads", Target: "/app/uploads", ReadOnly: true}}
+	if !reflect.DeepEqual(web.Volumes, wantWebVolumes) {
+		t.Errorf("unexpected web volumes %+v", web.Volumes)
+	}
+}
+
+func TestParseProjectInvalid(t *testing.T) {
+	if _, _, err := ParseProject([]byte("services: {}"), "/srv"); err == nil {
+		t.Error("expected error for non-JSON input")
+	}
+}
+
+func TestRelativeToProject(t *testing.T) {
+	tests := []struct{ path, want string }{
+		{"/srv/app", "."},
+		{"/srv/app/api", "./api"},
+		{"/opt/other", "/opt/other"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := relativeToProject("/srv/app", tt.path); got != tt.want {
+			t.Errorf("relativeToProject(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
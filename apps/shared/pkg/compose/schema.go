@@ -0,0 +1,320 @@
+package compose
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// paasdeploy.schema.json is a copy of the schema published at the root of
+// the repository, which go:embed cannot reach.
+//
+//go:embed paasdeploy.schema.json
+var configSchemaJSON []byte
+
+// ConfigSchema returns the JSON Schema of the paasdeploy configuration.
+func ConfigSchema() []byte {
+	return configSchemaJSON
+}
+
+// ConfigError is a problem found in a configuration file. Path locates the
+// offending value, as in "ports[0].port", and is empty for problems with
+// the file as a whole.
+type ConfigError struct {
+	Path    string `json:"path,omitempty"`
+	Message string `json:"message"`
+}
+
+func (e ConfigError) Error() string {
+	if e.Path == "" {
+		return e.Message
+	}
+	return fmt.Sprintf("'%s' %s", e.Path, e.Message)
+}
+
+// ValidateConfig checks a configuration file against the schema and then
+// against the rules the schema cannot express, returning every problem
+// found. name is the file name, which selects between JSON and YAML.
+func ValidateConfig(name string, data []byte) []ConfigError {
+	normalized, err := ConfigJSON(name, data)
+	if err != nil {
+		return []ConfigError{{Message: err.Error()}}
+	}
+
+	var document interface{}
+	if err := json.Unmarshal(normalized, &document); err != nil {
+		return []ConfigError{{Message: fmt.Sprintf("invalid %s: %v", name, err)}}
+	}
+
+	var errs []ConfigError
+	configSchema.validate("", document, &errs)
+	if len(errs) > 0 {
+		return errs
+	}
+
+	if _, err := ParseConfig(name, data); err != nil {
+		var configErr ConfigError
+		if errors.As(err, &configErr) {
+			return []ConfigError{configErr}
+		}
+		return []ConfigError{{Message: err.Error()}}
+	}
+	return nil
+}
+
+// schemaNode is the subset of JSON Schema the configuration schema uses.
+// Annotations such as description, default and examples are ignored.
+type schemaNode struct {
+	Type                 string                 `json:"type"`
+	Properties           map[string]*schemaNode `json:"properties"`
+	Required             []string               `json:"required"`
+	AdditionalProperties json.RawMessage        `json:"additionalProperties"`
+	Items                *schemaNode            `json:"items"`
+	Enum                 []string               `json:"enum"`
+	Minimum              *float64               `json:"minimum"`
+	Maximum              *float64               `json:"maximum"`
+	MinLength            int                    `json:"minLength"`
+	MaxLength            *int                   `json:"maxLength"`
+	Pattern              string                 `json:"pattern"`
+	OneOf                []*schemaNode          `json:"oneOf"`
+	AnyOf                []*schemaNode          `json:"anyOf"`
+
+	closed     bool
+	additional *schemaNode
+	pattern    *regexp.Regexp
+}
+
+var configSchema = mustCompileSchema(configSchemaJSON)
+
+func mustCompileSchema(data []byte) *schemaNode {
+	var root schemaNode
+	if err := json.Unmarshal(data, &root); err != nil {
+		panic(fmt.Sprintf("compose: invalid config schema: %v", err))
+	}
+	root.compile()
+	return &root
+}
+
+func (s *schemaNode) compile() {
+	if s.Pattern != "" {
+		s.pattern = regexp.MustCompile(s.Pattern)
+	}
+	switch raw := strings.TrimSpace(string(s.AdditionalProperties)); {
+	case raw == "false":
+		s.closed = true
+	case strings.HasPrefix(raw, "{"):
+		s.additional = &schemaNode{}
+		if err := json.Unmarshal(s.AdditionalProperties, s.additional); err != nil {
+			panic(fmt.Sprintf("compose: invalid config schema: %v", err))
+		}
+		s.additional.compile()
+	}
+	for _, property := range s.Properties {
+		property.compile()
+	}
+	if s.Items != nil {
+		s.Items.compile()
+	}
+	for _, alternative := range s.OneOf {
+		alternative.compile()
+	}
+	for _, alternative := range s.AnyOf {
+		alternative.compile()
+	}
+}
+
+func (s *schemaNode) validate(path string, value interface{}, errs *[]ConfigError) {
+	fail := func(format string, args ...interface{}) {
+		*errs = append(*errs, ConfigError{Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	switch s.Type {
+	case "object":
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			fail("must be an object")
+			return
+		}
+		s.validateObject(path, object, errs)
+	case "array":
+		items, ok := value.([]interface{})
+		if !ok {
+			fail("must be an array")
+			return
+		}
+		if s.Items != nil {
+			for i, item := range items {
+				s.Items.validate(fmt.Sprintf("%s[%d]", path, i), item, errs)
+			}
+		}
+	case "string":
+		str, ok := value.(string)
+		if !ok {
+			fail("must be a string")
+			return
+		}
+		if len(str) < s.MinLength {
+			if s.MinLength == 1 {
+				fail("must not be empty")
+			} else {
+				fail("must be at least %d characters long", s.MinLength)
+			}
+		} else if s.MaxLength != nil && len(str) > *s.MaxLength {
+			fail("must be at most %d characters long", *s.MaxLength)
+		} else if s.pattern != nil && !s.pattern.MatchString(str) {
+			fail("must match %s", s.Pattern)
+		}
+		if len(s.Enum) > 0 && !contains(s.Enum, str) {
+			fail("must be one of %s", strings.Join(s.Enum, ", "))
+		}
+	case "integer":
+		number, ok := value.(float64)
+		if !ok || number != math.Trunc(number) {
+			fail("must be an integer")
+			return
+		}
+		if s.Minimum != nil && number < *s.Minimum {
+			fail("must be at least %v", *s.Minimum)
+		}
+		if s.Maximum != nil && number > *s.Maximum {
+			fail("must be at most %v", *s.Maximum)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			fail("must be true or false")
+		}
+	case "":
+		if object, ok := value.(map[string]interface{}); ok {
+			s.validateObject(path, object, errs)
+		}
+	}
+
+	if len(s.OneOf) > 0 {
+		s.validateOneOf(path, value, errs)
+	}
+	if len(s.AnyOf) > 0 {
+		s.validateAnyOf(path, value, errs)
+	}
+}
+
+// validateAnyOf accepts value when it matches one of the alternatives. The
+// schema only uses alternatives of different types, for settings written
+// either as a string or as an object, so the errors reported are those of
+// the alternative of the value's type.
+func (s *schemaNode) validateAnyOf(path string, value interface{}, errs *[]ConfigError) {
+	var types []string
+	for _, alternative := range s.AnyOf {
+		var altErrs []ConfigError
+		alternative.validate(path, value, &altErrs)
+		if len(altErrs) == 0 {
+			return
+		}
+		if alternative.Type == jsonType(value) {
+			*errs = append(*errs, altErrs...)
+			return
+		}
+		types = append(types, alternative.Type)
+	}
+	*errs = append(*errs, ConfigError{Path: path, Message: "must be " + articled(types)})
+}
+
+func jsonType(value interface{}) string {
+	switch value.(type) {
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case string:
+		return "string"
+	case float64:
+		return "integer"
+	case bool:
+		return "boolean"
+	}
+	return "null"
+}
+
+// articled joins types as in "a string or an object".
+func articled(types []string) string {
+	words := make([]string, len(types))
+	for i, t := range types {
+		if strings.ContainsRune("aeiou", rune(t[0])) {
+			words[i] = "an " + t
+		} else {
+			words[i] = "a " + t
+		}
+	}
+	return strings.Join(words, " or ")
+}
+
+// validateOneOf requires value to match exactly one alternative. The schema
+// only uses alternatives made of required keys, for settings that exclude
+// each other, so the error names those keys.
+func (s *schemaNode) validateOneOf(path string, value interface{}, errs *[]ConfigError) {
+	matches := 0
+	var keys []string
+	for _, alternative := range s.OneOf {
+		var altErrs []ConfigError
+		alternative.validate(path, value, &altErrs)
+		if len(altErrs) == 0 {
+			matches++
+		}
+		keys = append(keys, alternative.Required...)
+	}
+	if matches == 1 {
+		return
+	}
+
+	quoted := make([]string, len(keys))
+	for i, key := range keys {
+		quoted[i] = "'" + key + "'"
+	}
+	*errs = append(*errs, ConfigError{Path: path, Message: "needs exactly one of " + strings.Join(quoted, " or ")})
+}
+
+func (s *schemaNode) validateObject(path string, object map[string]interface{}, errs *[]ConfigError) {
+	for _, key := range s.Required {
+		if _, ok := object[key]; !ok {
+			*errs = append(*errs, ConfigError{Path: joinPath(path, key), Message: "is required"})
+		}
+	}
+
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		property := s.Properties[key]
+		switch {
+		case property != nil:
+			property.validate(joinPath(path, key), object[key], errs)
+		case s.additional != nil:
+			s.additional.validate(joinPath(path, key), object[key], errs)
+		case s.closed:
+			*errs = append(*errs, ConfigError{Path: joinPath(path, key), Message: "is not a known setting"})
+		}
+	}
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,118 @@
+package compose
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const testConfigYAML = `
+name: shop
+runtime: node
+build:
+  dockerfile: ./Dockerfile.prod
+healthcheck:
+  path: /ready
+  retries: 5
+port: 3000
+env:
+  NODE_ENV: production
+ports:
+  - port: 5432
+    hostPort: 15432
+    mesh: true
+`
+
+func TestLoadConfigReadsYAML(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "paasdeploy.yaml"), []byte(testConfigYAML), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadConfig(dir)
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	if cfg.Name != "shop" || cfg.Port != 3000 || cfg.Build.Dockerfile != "./Dockerfile.prod" || cfg.Healthcheck.Retries != 5 {
+		t.Errorf("unexpected config: %+v", cfg)
+	}
+	if cfg.Env["NODE_ENV"] != "production" || len(cfg.Ports) != 1 || cfg.Ports[0].Protocol != PortProtocolTCP {
+		t.Errorf("unexpected env or ports: %v %+v", cfg.Env, cfg.Ports)
+	}
+	if cfg.Healthcheck.Interval != "30s" {
+		t.Errorf("defaults not applied: interval = %q", cfg.Healthcheck.Interval)
+	}
+}
+
+func TestLoadConfigPrefersJSON(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "paasdeploy.yaml"), []byte("name: from-yaml\n"), 0o644)
+	os.WriteFile(filepath.Join(dir, "paasdeploy.json"), []byte(`{"name": "from-json"}`), 0o644)
+
+	cfg, err := LoadConfig(dir)
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	if cfg.Name != "from-json" {
+		t.Errorf("name = %q, want from-json", cfg.Name)
+	}
+}
+
+func TestConfigSchemaMatchesPublishedSchema(t *testing.T) {
+	published, err := os.ReadFile("../../../../paasdeploy.schema.json")
+	if err != nil {
+		t.Skipf("published schema not found: %v", err)
+	}
+	if string(published) != string(ConfigSchema()) {
+		t.Error("paasdeploy.schema.json differs from the schema at the repository root; copy it over")
+	}
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+		data string
+		want []ConfigError
+	}{
+		{"valid json", "paasdeploy.json", `{"name": "api", "port": 8080}`, nil},
+		{"valid yaml", "paasdeploy.yaml", testConfigYAML, nil},
+		{"syntax error", "paasdeploy.json", `{"name": `, []ConfigError{
+			{Message: "invalid paasdeploy.json: unexpected end of JSON input"},
+		}},
+		{"schema errors", "paasdeploy.yaml", "port: 70000\nbuidl: {}\nports:\n  - protocol: sctp\n", []ConfigError{
+			{Path: "name", Message: "is required"},
+			{Path: "buidl", Message: "is not a known setting"},
+			{Path: "port", Message: "must be at most 65535"},
+			{Path: "ports[0].port", Message: "is required"},
+			{Path: "ports[0].protocol", Message: "must be one of tcp, udp"},
+			{Path: "ports[0]", Message: "needs exactly one of 'entrypoint' or 'hostPort'"},
+		}},
+		{"wrong types", "paasdeploy.json", `{"name": "api", "env": {"PORT": 3000}, "compress": "yes"}`, []ConfigError{
+			{Path: "compress", Message: "must be true or false"},
+			{Path: "env.PORT", Message: "must be a string"},
+		}},
+		{"rule outside the schema", "paasdeploy.json", `{"name": "api", "ports": [{"port": 53, "entrypoint": "dns", "mesh": true}]}`, []ConfigError{
+			{Path: "ports[0].mesh", Message: "requires 'hostPort'"},
+		}},
+		{"domain objects", "paasdeploy.yaml", "name: api\ndomains:\n  - example.com\n  - domain: example.com\n    pathPrefix: /admin\n    middlewares: [auth@file]\n", nil},
+		{"invalid domains", "paasdeploy.json", `{"name": "api", "domains": [42, {"domain": "example.com", "pathPrefix": "admin", "extra": true}, {"pathPrefix": "/x"}]}`, []ConfigError{
+			{Path: "domains[0]", Message: "must be a string or an object"},
+			{Path: "domains[1].extra", Message: "is not a known setting"},
+			{Path: "domains[1].pathPrefix", Message: "must match ^/"},
+			{Path: "domains[2].domain", Message: "is required"},
+		}},
+		{"redirect domain with options", "paasdeploy.json", `{"name": "api", "domains": [{"domain": "old.example.com", "redirect": "https://example.com", "certResolver": "staging"}]}`, []ConfigError{
+			{Path: "domains[0]", Message: "a redirect cannot have middlewares or a certResolver"},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ValidateConfig(tt.file, []byte(tt.data))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ValidateConfig() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
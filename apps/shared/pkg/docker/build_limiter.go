@@ -0,0 +1,52 @@
+package docker
+
+import "context"
+
+// BuildMemoryPerSlot is the RAM a host needs for each build it runs at once
+// when no explicit limit is configured.
+const BuildMemoryPerSlot int64 = 2 << 30
+
+// BuildSlotsForMemory returns how many builds a host with memTotal bytes of
+// RAM runs at once by default, at least one.
+func BuildSlotsForMemory(memTotal int64) int {
+	return max(1, int(memTotal/BuildMemoryPerSlot))
+}
+
+// BuildLimiter is a semaphore capping the image builds running on a host,
+// since parallel builds easily exhaust the memory of a small server.
+type BuildLimiter struct {
+	slots chan struct{}
+}
+
+func NewBuildLimiter(n int) *BuildLimiter {
+	return &BuildLimiter{slots: make(chan struct{}, max(1, n))}
+}
+
+// Limit returns how many builds may run at once.
+func (l *BuildLimiter) Limit() int {
+	return cap(l.slots)
+}
+
+// TryAcquire takes a build slot if one is free.
+func (l *BuildLimiter) TryAcquire() bool {
+	select {
+	case l.slots <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+// Acquire waits for a free build slot until ctx is done.
+func (l *BuildLimiter) Acquire(ctx context.Context) error {
+	select {
+	case l.slots <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (l *BuildLimiter) Release() {
+	<-l.slots
+}
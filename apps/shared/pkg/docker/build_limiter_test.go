@@ -0,0 +1,42 @@
+package docker
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestBuildSlotsForMemory(t *testing.T) {
+	cases := map[int64]int{
+		0:        1,
+		1 << 30:  1,
+		4 << 30:  2,
+		16 << 30: 8,
+	}
+	for mem, want := range cases {
+		if got := BuildSlotsForMemory(mem); got != want {
+			t.Errorf("BuildSlotsForMemory(%d) = %d, want %d", mem, got, want)
+		}
+	}
+}
+
+func TestBuildLimiterQueuesPastLimit(t *testing.T) {
+	l := NewBuildLimiter(1)
+	if !l.TryAcquire() {
+		t.Fatal("first slot should be free")
+	}
+	if l.TryAcquire() {
+		t.Fatal("second build should not get a slot")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := l.Acquire(ctx); err == nil {
+		t.Fatal("Acquire should wait until ctx is done")
+	}
+
+	l.Release()
+	if err := l.Acquire(context.Background()); err != nil {
+		t.Fatalf("Acquire after release: %v", err)
+	}
+}
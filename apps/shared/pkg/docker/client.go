@@ -2,8 +2,10 @@ package docker
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"os"
 	"strconv"
 	"strings"
 	"time"
@@ -22,6 +24,7 @@ type Client struct {
 	logger          *slog.Logger
 	registry        string
 	buildxAvailable bool
+	stats           *statsStreamer
 }
 
 func NewClient(baseDir string, registry string, logger *slog.Logger) *Client {
@@ -30,6 +33,7 @@ func NewClient(baseDir string, registry string, logger *slog.Logger) *Client {
 		executor: exec,
 		logger:   logger,
 		registry: registry,
+		stats:    newStatsStreamer(os.Getenv("DOCKER_HOST"), logger),
 	}
 	client.initBuildx()
 	return client
@@ -101,6 +105,19 @@ func (d *Client) ImageExists(ctx context.Context, tag string) (bool, error) {
 	return true, nil
 }
 
+// ImageSize returns the size in bytes of a local image.
+func (d *Client) ImageSize(ctx context.Context, tag string) (int64, error) {
+	result, err := d.executor.RunQuiet(ctx, "docker", "image", "inspect", formatFlag, "{{.Size}}", tag)
+	if err != nil {
+		return 0, fmt.Errorf("failed to inspect image: %w", err)
+	}
+	size, err := strconv.ParseInt(strings.TrimSpace(result.Stdout), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid image size %q: %w", strings.TrimSpace(result.Stdout), err)
+	}
+	return size, nil
+}
+
 func (d *Client) RemoveImage(ctx context.Context, tag string) error {
 	if tag == "" {
 		return nil
@@ -148,6 +165,38 @@ func (d *Client) GetCurrentContainerID(ctx context.Context) (string, error) {
 	return "", fmt.Errorf("could not determine container ID")
 }
 
+// HasRegistry reports whether images are tagged for a registry and can be
+// shared between servers through it.
+func (d *Client) HasRegistry() bool {
+	return d.registry != ""
+}
+
+// ImageAvailable reports whether tag can be used without building it: it
+// exists locally or, with a registry configured, could be pulled from it.
+// With a publicKey, a pulled image must carry a valid cosign signature; one
+// that does not is removed again and reported with ErrImageSignature, so it
+// is never started.
+func (d *Client) ImageAvailable(ctx context.Context, tag, publicKey string) (bool, error) {
+	if exists, err := d.ImageExists(ctx, tag); err == nil && exists {
+		return true, nil
+	}
+	if d.registry == "" {
+		return false, nil
+	}
+	if err := d.Pull(ctx, tag); err != nil {
+		d.logger.Debug("Image not available in registry", "tag", tag, "error", err)
+		return false, nil
+	}
+	if publicKey == "" {
+		return true, nil
+	}
+	if err := d.VerifyImage(ctx, tag, publicKey); err != nil {
+		_ = d.RemoveImage(ctx, tag)
+		return false, err
+	}
+	return true, nil
+}
+
 func (d *Client) getImagePrefix() string {
 	if d.registry != "" {
 		return d.registry + "/paasdeploy"
@@ -174,6 +223,11 @@ type ContainerHealth struct {
 	StartedAt string
 	Uptime    string
 	Image     string
+	// RestartCount is how many times Docker restarted the container under
+	// its restart policy. OOMKilled and ExitCode describe its last exit.
+	RestartCount int
+	OOMKilled    bool
+	ExitCode     int
 }
 
 func (d *Client) ContainerExists(ctx context.Context, containerName string) (bool, error) {
@@ -186,7 +240,7 @@ func (d *Client) ContainerExists(ctx context.Context, containerName string) (boo
 }
 
 func (d *Client) InspectContainer(ctx context.Context, containerName string) (*ContainerHealth, error) {
-	format := "{{.State.Status}}|{{if .State.Health}}{{.State.Health.Status}}{{else}}none{{end}}|{{.State.StartedAt}}|{{.Config.Image}}"
+	format := "{{.State.Status}}|{{if .State.Health}}{{.State.Health.Status}}{{else}}none{{end}}|{{.State.StartedAt}}|{{.Config.Image}}|{{.RestartCount}}|{{.State.OOMKilled}}|{{.State.ExitCode}}"
 	result, err := d.executor.RunQuietWithTimeout(ctx, 30*time.Second, "docker", "inspect", formatFlag, format, containerName)
 	if err != nil {
 		stderrLower := strings.ToLower(result.Stderr)
@@ -200,9 +254,14 @@ func (d *Client) InspectContainer(ctx context.Context, containerName string) (*C
 		return nil, fmt.Errorf("failed to inspect container: %w", err)
 	}
 
-	parts := strings.Split(strings.TrimSpace(result.Stdout), "|")
-	if len(parts) < 4 {
-		return nil, fmt.Errorf("unexpected inspect output: %s", result.Stdout)
+	return parseContainerHealth(containerName, result.Stdout)
+}
+
+// parseContainerHealth reads the output of InspectContainer's format.
+func parseContainerHealth(containerName, output string) (*ContainerHealth, error) {
+	parts := strings.Split(strings.TrimSpace(output), "|")
+	if len(parts) < 7 {
+		return nil, fmt.Errorf("unexpected inspect output: %s", output)
 	}
 
 	health := &ContainerHealth{
@@ -211,7 +270,10 @@ func (d *Client) InspectContainer(ctx context.Context, containerName string) (*C
 		Health:    parts[1],
 		StartedAt: parts[2],
 		Image:     parts[3],
+		OOMKilled: parts[5] == "true",
 	}
+	health.RestartCount, _ = strconv.Atoi(parts[4])
+	health.ExitCode, _ = strconv.Atoi(parts[6])
 
 	if health.Status == "running" && health.StartedAt != "" {
 		startTime, err := time.Parse(time.RFC3339Nano, health.StartedAt)
@@ -286,6 +348,16 @@ func (d *Client) RestartContainer(ctx context.Context, containerName string) err
 	return nil
 }
 
+// DisableRestart sets the restart policy of the container to "no", so a
+// container that keeps crashing stays stopped instead of restarting. The
+// next deploy recreates it with its usual policy.
+func (d *Client) DisableRestart(ctx context.Context, containerName string) error {
+	if _, err := d.executor.RunWithTimeout(ctx, 30*time.Second, "docker", "update", "--restart=no", containerName); err != nil {
+		return fmt.Errorf("failed to disable container restart: %w", err)
+	}
+	return nil
+}
+
 func (d *Client) StopContainer(ctx context.Context, containerName string) error {
 	d.logger.Info("Stopping container", "containerName", containerName)
 
@@ -349,7 +421,20 @@ type ContainerStats struct {
 	PIDs          int     `json:"pids"`
 }
 
+// ContainerStats returns the current stats of a container, read from a
+// stream kept open on the Docker API. It falls back to `docker stats` when
+// the API cannot be reached directly.
 func (d *Client) ContainerStats(ctx context.Context, containerName string) (*ContainerStats, error) {
+	if d.stats != nil {
+		stats, err := d.stats.Latest(ctx, containerName)
+		if !errors.Is(err, errStatsAPIUnavailable) {
+			return stats, err
+		}
+	}
+	return d.containerStatsCLI(ctx, containerName)
+}
+
+func (d *Client) containerStatsCLI(ctx context.Context, containerName string) (*ContainerStats, error) {
 	format := "{{.CPUPerc}}|{{.MemUsage}}|{{.MemPerc}}|{{.NetIO}}|{{.PIDs}}"
 	result, err := d.executor.RunQuietWithTimeout(ctx, 30*time.Second, "docker", "stats", "--no-stream", formatFlag, format, containerName)
 	if err != nil {
@@ -0,0 +1,70 @@
+package docker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidImageReference = errors.New("invalid image repository or tag")
+
+var (
+	imageRepositoryPattern = regexp.MustCompile(`^([a-zA-Z0-9.-]+(:[0-9]+)?/)?[a-z0-9]+([._-][a-z0-9]+)*(/[a-z0-9]+([._-][a-z0-9]+)*)*$`)
+	imageTagPattern        = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9_.-]{0,127}$`)
+)
+
+type CommitOptions struct {
+	Repository string
+	Tag        string
+	Message    string
+	Author     string
+	// Pause freezes the container while the snapshot is taken. Disabling it
+	// avoids downtime at the cost of a possibly inconsistent filesystem.
+	Pause bool
+}
+
+// ImageReference validates the repository and tag and returns them joined.
+// An empty tag defaults to "latest".
+func (o CommitOptions) ImageReference() (string, error) {
+	tag := o.Tag
+	if tag == "" {
+		tag = "latest"
+	}
+	if !imageRepositoryPattern.MatchString(o.Repository) || !imageTagPattern.MatchString(tag) {
+		return "", ErrInvalidImageReference
+	}
+	return o.Repository + ":" + tag, nil
+}
+
+// CommitContainer snapshots a container's filesystem into a new image and
+// returns the image ID.
+func (d *Client) CommitContainer(ctx context.Context, containerID string, opts CommitOptions) (string, error) {
+	ref, err := opts.ImageReference()
+	if err != nil {
+		return "", err
+	}
+
+	d.logger.Info("Committing container", "id", containerID, "image", ref)
+
+	args := []string{"commit", "--pause=" + strconv.FormatBool(opts.Pause)}
+	if opts.Message != "" {
+		args = append(args, "--message", opts.Message)
+	}
+	if opts.Author != "" {
+		args = append(args, "--author", opts.Author)
+	}
+	args = append(args, containerID, ref)
+
+	result, err := d.executor.RunQuietWithTimeout(ctx, 10*time.Minute, "docker", args...)
+	if err != nil {
+		if strings.Contains(strings.ToLower(result.Stderr), errNoSuchContainer) {
+			return "", fmt.Errorf("container not found: %s", containerID)
+		}
+		return "", fmt.Errorf("docker commit failed: %w", err)
+	}
+	return strings.TrimSpace(result.Stdout), nil
+}
@@ -0,0 +1,36 @@
+package docker
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCommitOptionsImageReference(t *testing.T) {
+	tests := []struct {
+		repository string
+		tag        string
+		want       string
+	}{
+		{"myapp-debug", "", "myapp-debug:latest"},
+		{"registry.local:5000/team/api", "hotfix-1.2", "registry.local:5000/team/api:hotfix-1.2"},
+	}
+	for _, tt := range tests {
+		got, err := CommitOptions{Repository: tt.repository, Tag: tt.tag}.ImageReference()
+		if err != nil || got != tt.want {
+			t.Errorf("ImageReference(%q, %q) = %q, %v; want %q", tt.repository, tt.tag, got, err, tt.want)
+		}
+	}
+
+	invalid := []CommitOptions{
+		{Repository: ""},
+		{Repository: "--help"},
+		{Repository: "MyApp"},
+		{Repository: "app", Tag: "-x"},
+		{Repository: "app", Tag: "v1:2"},
+	}
+	for _, opts := range invalid {
+		if _, err := opts.ImageReference(); !errors.Is(err, ErrInvalidImageReference) {
+			t.Errorf("ImageReference(%+v) expected ErrInvalidImageReference, got %v", opts, err)
+		}
+	}
+}
@@ -0,0 +1,240 @@
+package docker
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/paasdeploy/shared/pkg/executor"
+)
+
+// MaxContainerFileBytes caps files copied in or out of a container. Files are
+// buffered in memory and relayed through the agent, so they must stay small.
+const MaxContainerFileBytes = 50 << 20
+
+const containerFileTimeout = 2 * time.Minute
+
+var (
+	ErrContainerFileTooLarge = errors.New("file exceeds the size limit")
+	ErrNotRegularFile        = errors.New("path is not a regular file")
+	ErrInvalidContainerPath  = errors.New("path must be absolute")
+)
+
+const (
+	ContainerFileTypeFile    = "file"
+	ContainerFileTypeDir     = "dir"
+	ContainerFileTypeSymlink = "symlink"
+	ContainerFileTypeOther   = "other"
+)
+
+type ContainerFile struct {
+	Name       string `json:"name"`
+	Type       string `json:"type"`
+	Size       int64  `json:"size"`
+	Mode       string `json:"mode"`
+	Owner      string `json:"owner"`
+	Modified   string `json:"modified"`
+	LinkTarget string `json:"linkTarget,omitempty"`
+}
+
+// CleanContainerPath validates and normalizes an absolute path inside a
+// container.
+func CleanContainerPath(p string) (string, error) {
+	if !strings.HasPrefix(p, "/") {
+		return "", ErrInvalidContainerPath
+	}
+	return path.Clean(p), nil
+}
+
+// ListContainerFiles lists a directory inside a running container. It runs ls
+// in the container, so images without a shell userland (distroless, scratch)
+// cannot be browsed.
+func (d *Client) ListContainerFiles(ctx context.Context, containerID, dir string) ([]ContainerFile, error) {
+	dir, err := CleanContainerPath(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := d.executor.RunQuietWithTimeout(ctx, 30*time.Second, "docker", "exec", containerID, "ls", "-lA", dir+"/")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s: %w", dir, err)
+	}
+	return ParseLsOutput(result.Stdout), nil
+}
+
+// ParseLsOutput parses `ls -lA` output as printed by GNU coreutils and
+// busybox.
+func ParseLsOutput(output string) []ContainerFile {
+	files := []ContainerFile{}
+	for _, line := range strings.Split(output, "\n") {
+		if file, ok := parseLsLine(line); ok {
+			files = append(files, file)
+		}
+	}
+	return files
+}
+
+func parseLsLine(line string) (ContainerFile, bool) {
+	fields := strings.Fields(line)
+	if len(fields) < 9 || fields[0] == "total" {
+		return ContainerFile{}, false
+	}
+
+	// Device files print "major, minor" instead of a size.
+	nameField := 8
+	if strings.HasSuffix(fields[4], ",") {
+		nameField = 9
+		if len(fields) < 10 {
+			return ContainerFile{}, false
+		}
+	}
+
+	file := ContainerFile{
+		Mode:     fields[0],
+		Owner:    fields[2],
+		Modified: strings.Join(fields[nameField-3:nameField], " "),
+		Type:     fileTypeFromMode(fields[0]),
+		Name:     restOfLine(line, nameField),
+	}
+	if nameField == 8 {
+		file.Size, _ = strconv.ParseInt(fields[4], 10, 64)
+	}
+	if file.Type == ContainerFileTypeSymlink {
+		if name, target, ok := strings.Cut(file.Name, " -> "); ok {
+			file.Name = name
+			file.LinkTarget = target
+		}
+	}
+	return file, file.Name != ""
+}
+
+func fileTypeFromMode(mode string) string {
+	switch mode[0] {
+	case '-':
+		return ContainerFileTypeFile
+	case 'd':
+		return ContainerFileTypeDir
+	case 'l':
+		return ContainerFileTypeSymlink
+	default:
+		return ContainerFileTypeOther
+	}
+}
+
+// restOfLine returns the line after skipping n whitespace-separated fields,
+// keeping any spaces inside the remainder.
+func restOfLine(line string, n int) string {
+	rest := strings.TrimLeft(line, " \t")
+	for i := 0; i < n; i++ {
+		idx := strings.IndexAny(rest, " \t")
+		if idx < 0 {
+			return ""
+		}
+		rest = strings.TrimLeft(rest[idx:], " \t")
+	}
+	return rest
+}
+
+// CopyFileFromContainer reads a single regular file out of a container, the
+// equivalent of `docker cp container:path -` limited to maxBytes.
+func (d *Client) CopyFileFromContainer(ctx context.Context, containerID, filePath string, maxBytes int64) (string, []byte, error) {
+	filePath, err := CleanContainerPath(filePath)
+	if err != nil {
+		return "", nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// The archive is read while docker cp writes it, so oversized files are
+	// rejected without buffering them.
+	pr, pw := io.Pipe()
+	done := make(chan *executor.Result, 1)
+	go func() {
+		result, err := d.executor.RunQuietWithIO(ctx, containerFileTimeout, nil, pw, "docker", "cp", containerID+":"+filePath, "-")
+		pw.CloseWithError(err)
+		done <- result
+	}()
+
+	name, data, readErr := readSingleFileTar(pr, maxBytes)
+	if readErr != nil {
+		cancel()
+		pr.CloseWithError(readErr)
+		if result := <-done; result != nil && strings.TrimSpace(result.Stderr) != "" {
+			return "", nil, fmt.Errorf("docker cp failed: %s", strings.TrimSpace(result.Stderr))
+		}
+		return "", nil, readErr
+	}
+	_, copyErr := io.Copy(io.Discard, pr)
+	result := <-done
+	if copyErr != nil {
+		return "", nil, fmt.Errorf("docker cp failed: %s", strings.TrimSpace(result.Stderr))
+	}
+	return name, data, nil
+}
+
+func readSingleFileTar(r io.Reader, maxBytes int64) (string, []byte, error) {
+	tr := tar.NewReader(r)
+	header, err := tr.Next()
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read archive: %w", err)
+	}
+	if header.Typeflag != tar.TypeReg {
+		return "", nil, ErrNotRegularFile
+	}
+	if header.Size > maxBytes {
+		return "", nil, ErrContainerFileTooLarge
+	}
+
+	data, err := io.ReadAll(io.LimitReader(tr, header.Size))
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	return path.Base(header.Name), data, nil
+}
+
+// CopyFileToContainer writes data as dir/name inside a container, the
+// equivalent of `docker cp - container:dir` with a single-file archive.
+func (d *Client) CopyFileToContainer(ctx context.Context, containerID, dir, name string, data []byte) error {
+	dir, err := CleanContainerPath(dir)
+	if err != nil {
+		return err
+	}
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, "/\x00") {
+		return fmt.Errorf("invalid file name %q", name)
+	}
+	if int64(len(data)) > MaxContainerFileBytes {
+		return ErrContainerFileTooLarge
+	}
+
+	var archive bytes.Buffer
+	tw := tar.NewWriter(&archive)
+	if err := tw.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0o644,
+		Size:    int64(len(data)),
+		ModTime: time.Now(),
+	}); err != nil {
+		return fmt.Errorf("failed to build archive: %w", err)
+	}
+	if _, err := tw.Write(data); err != nil {
+		return fmt.Errorf("failed to build archive: %w", err)
+	}
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("failed to build archive: %w", err)
+	}
+
+	d.logger.Info("Copying file into container", "id", containerID, "dir", dir, "name", name, "size", len(data))
+	result, err := d.executor.RunQuietWithIO(ctx, containerFileTimeout, &archive, nil, "docker", "cp", "-", containerID+":"+dir)
+	if err != nil {
+		return fmt.Errorf("docker cp failed: %s", strings.TrimSpace(result.Stderr+result.Stdout))
+	}
+	return nil
+}
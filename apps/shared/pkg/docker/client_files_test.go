@@ -0,0 +1,71 @@
+package docker
+
+import (
+	"archive/tar"
+	"bytes"
+	"errors"
+	"testing"
+)
+
+const testLsOutput = `total 24
+drwxr-xr-x    2 root     root          4096 Jan  5 10:12 bin
+-rw-r--r--    1 app      app            312 Mar 14  2024 my notes.txt
+lrwxrwxrwx    1 root     root             7 Jan  5 10:12 lib -> usr/lib
+crw-rw-rw-    1 root     root        1,   3 Jan  5 10:12 null
+`
+
+func TestParseLsOutput(t *testing.T) {
+	files := ParseLsOutput(testLsOutput)
+	if len(files) != 4 {
+		t.Fatalf("expected 4 files, got %d: %+v", len(files), files)
+	}
+
+	want := []ContainerFile{
+		{Name: "bin", Type: ContainerFileTypeDir, Size: 4096, Mode: "drwxr-xr-x", Owner: "root", Modified: "Jan 5 10:12"},
+		{Name: "my notes.txt", Type: ContainerFileTypeFile, Size: 312, Mode: "-rw-r--r--", Owner: "app", Modified: "Mar 14 2024"},
+		{Name: "lib", Type: ContainerFileTypeSymlink, Size: 7, Mode: "lrwxrwxrwx", Owner: "root", Modified: "Jan 5 10:12", LinkTarget: "usr/lib"},
+		{Name: "null", Type: ContainerFileTypeOther, Mode: "crw-rw-rw-", Owner: "root", Modified: "Jan 5 10:12"},
+	}
+	for i, file := range files {
+		if file != want[i] {
+			t.Errorf("file %d = %+v, want %+v", i, file, want[i])
+		}
+	}
+}
+
+func TestCleanContainerPath(t *testing.T) {
+	if _, err := CleanContainerPath("etc/passwd"); !errors.Is(err, ErrInvalidContainerPath) {
+		t.Errorf("expected relative path to be rejected, got %v", err)
+	}
+	got, err := CleanContainerPath("/app/../etc//hosts")
+	if err != nil || got != "/etc/hosts" {
+		t.Errorf("CleanContainerPath = %q, %v", got, err)
+	}
+}
+
+func TestReadSingleFileTar(t *testing.T) {
+	archive := func(header *tar.Header, data []byte) *bytes.Buffer {
+		var buf bytes.Buffer
+		tw := tar.NewWriter(&buf)
+		if err := tw.WriteHeader(header); err != nil {
+			t.Fatal(err)
+		}
+		tw.Write(data)
+		tw.Close()
+		return &buf
+	}
+
+	content := []byte("hello")
+	name, data, err := readSingleFileTar(archive(&tar.Header{Name: "app.log", Mode: 0o644, Size: 5}, content), 10)
+	if err != nil || name != "app.log" || !bytes.Equal(data, content) {
+		t.Fatalf("readSingleFileTar = %q, %q, %v", name, data, err)
+	}
+
+	if _, _, err := readSingleFileTar(archive(&tar.Header{Name: "app.log", Mode: 0o644, Size: 5}, content), 4); !errors.Is(err, ErrContainerFileTooLarge) {
+		t.Errorf("expected size limit error, got %v", err)
+	}
+
+	if _, _, err := readSingleFileTar(archive(&tar.Header{Name: "etc/", Typeflag: tar.TypeDir, Mode: 0o755}, nil), 10); !errors.Is(err, ErrNotRegularFile) {
+		t.Errorf("expected directory to be rejected, got %v", err)
+	}
+}
@@ -2,18 +2,30 @@ package docker
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 )
 
 type NetworkInfo struct {
-	Name       string   `json:"name"`
-	ID         string   `json:"id"`
-	Driver     string   `json:"driver"`
-	Scope      string   `json:"scope"`
-	Internal   bool     `json:"internal"`
-	Containers []string `json:"containers"`
+	Name       string             `json:"name"`
+	ID         string             `json:"id"`
+	Driver     string             `json:"driver"`
+	Scope      string             `json:"scope"`
+	Internal   bool               `json:"internal"`
+	Subnet     string             `json:"subnet"`
+	Gateway    string             `json:"gateway"`
+	Containers []NetworkContainer `json:"containers"`
+}
+
+type NetworkContainer struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	IPv4Address string `json:"ipv4Address"`
+	IPv6Address string `json:"ipv6Address,omitempty"`
+	MacAddress  string `json:"macAddress"`
 }
 
 type VolumeInfo struct {
@@ -77,9 +89,10 @@ func (d *Client) ListNetworks(ctx context.Context) ([]NetworkInfo, error) {
 			continue
 		}
 		net := NetworkInfo{
-			ID:     parts[0],
-			Name:   parts[1],
-			Driver: parts[2],
+			ID:         parts[0],
+			Name:       parts[1],
+			Driver:     parts[2],
+			Containers: []NetworkContainer{},
 		}
 		if len(parts) > 3 {
 			net.Scope = parts[3]
@@ -89,9 +102,86 @@ func (d *Client) ListNetworks(ctx context.Context) ([]NetworkInfo, error) {
 		}
 		networks = append(networks, net)
 	}
+
+	details, err := d.inspectNetworks(ctx, networks)
+	if err != nil {
+		d.logger.Warn("Failed to inspect networks", "error", err)
+		return networks, nil
+	}
+	for i := range networks {
+		if detail, ok := details[networks[i].Name]; ok {
+			networks[i].Subnet = detail.Subnet
+			networks[i].Gateway = detail.Gateway
+			networks[i].Containers = detail.Containers
+		}
+	}
 	return networks, nil
 }
 
+func (d *Client) inspectNetworks(ctx context.Context, networks []NetworkInfo) (map[string]NetworkInfo, error) {
+	if len(networks) == 0 {
+		return map[string]NetworkInfo{}, nil
+	}
+	args := []string{"network", "inspect"}
+	for _, n := range networks {
+		args = append(args, n.ID)
+	}
+	result, err := d.executor.RunQuietWithTimeout(ctx, 30*time.Second, "docker", args...)
+	if err != nil {
+		return nil, fmt.Errorf("docker network inspect failed: %w", err)
+	}
+	return ParseNetworkInspect([]byte(result.Stdout))
+}
+
+// ParseNetworkInspect extracts the IPv4 subnet, gateway and attached
+// containers from `docker network inspect` output, keyed by network name.
+func ParseNetworkInspect(output []byte) (map[string]NetworkInfo, error) {
+	var raws []struct {
+		Name string
+		IPAM struct {
+			Config []struct {
+				Subnet  string
+				Gateway string
+			}
+		}
+		Containers map[string]struct {
+			Name        string
+			IPv4Address string
+			IPv6Address string
+			MacAddress  string
+		}
+	}
+	if err := json.Unmarshal(output, &raws); err != nil {
+		return nil, fmt.Errorf("failed to parse network inspect output: %w", err)
+	}
+
+	details := make(map[string]NetworkInfo, len(raws))
+	for _, raw := range raws {
+		info := NetworkInfo{Name: raw.Name, Containers: make([]NetworkContainer, 0, len(raw.Containers))}
+		for _, cfg := range raw.IPAM.Config {
+			if strings.Contains(cfg.Subnet, ".") {
+				info.Subnet = cfg.Subnet
+				info.Gateway = cfg.Gateway
+				break
+			}
+		}
+		for id, c := range raw.Containers {
+			info.Containers = append(info.Containers, NetworkContainer{
+				ID:          id,
+				Name:        c.Name,
+				IPv4Address: c.IPv4Address,
+				IPv6Address: c.IPv6Address,
+				MacAddress:  c.MacAddress,
+			})
+		}
+		sort.Slice(info.Containers, func(i, j int) bool {
+			return info.Containers[i].Name < info.Containers[j].Name
+		})
+		details[raw.Name] = info
+	}
+	return details, nil
+}
+
 func (d *Client) RemoveNetwork(ctx context.Context, network string) error {
 	_, err := d.executor.RunWithTimeout(ctx, 30*time.Second, "docker", "network", "rm", network)
 	if err != nil {
@@ -0,0 +1,30 @@
+package docker
+
+import "testing"
+
+func TestParseNetworkInspect(t *testing.T) {
+	output := `[{
+		"Name": "paasdeploy",
+		"IPAM": {"Config": [{"Subnet": "fd00::/64"}, {"Subnet": "172.20.0.0/16", "Gateway": "172.20.0.1"}]},
+		"Containers": {
+			"b2c3": {"Name": "web", "IPv4Address": "172.20.0.3/16", "MacAddress": "02:42:ac:14:00:03"},
+			"a1b2": {"Name": "db", "IPv4Address": "172.20.0.2/16", "MacAddress": "02:42:ac:14:00:02"}
+		}
+	}, {"Name": "none", "IPAM": {"Config": []}, "Containers": {}}]`
+
+	details, err := ParseNetworkInspect([]byte(output))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	net := details["paasdeploy"]
+	if net.Subnet != "172.20.0.0/16" || net.Gateway != "172.20.0.1" {
+		t.Errorf("subnet = %q, gateway = %q", net.Subnet, net.Gateway)
+	}
+	if len(net.Containers) != 2 || net.Containers[0].Name != "db" || net.Containers[0].ID != "a1b2" || net.Containers[0].IPv4Address != "172.20.0.2/16" {
+		t.Errorf("containers = %+v", net.Containers)
+	}
+	if none, ok := details["none"]; !ok || none.Containers == nil {
+		t.Errorf("expected empty container list for network without containers, got %+v", none)
+	}
+}
@@ -0,0 +1,25 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// SyftImage generates the SBOMs. Running it as a container needs nothing
+// installed on the host besides Docker.
+const SyftImage = "anchore/syft:v1.18.1"
+
+const sbomTimeout = 10 * time.Minute
+
+// GenerateSBOM lists the packages of a local image with syft and returns
+// them as a CycloneDX JSON document.
+func (d *Client) GenerateSBOM(ctx context.Context, imageTag string) ([]byte, error) {
+	result, err := d.executor.RunQuietWithTimeout(ctx, sbomTimeout, "docker", "run", "--rm",
+		"-v", "/var/run/docker.sock:/var/run/docker.sock:ro",
+		SyftImage, "scan", "docker:"+imageTag, "-o", "cyclonedx-json", "-q")
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate SBOM: %w", err)
+	}
+	return []byte(result.Stdout), nil
+}
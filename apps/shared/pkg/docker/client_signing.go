@@ -0,0 +1,84 @@
+package docker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// ErrImageSignature is returned for images whose cosign signature is
+// missing or does not match the platform key.
+var ErrImageSignature = errors.New("image signature verification failed")
+
+const cosignTimeout = 2 * time.Minute
+
+// SignImage signs ref, preferably a digest reference, with the cosign
+// private key at keyPath. cosign reads the key password from
+// COSIGN_PASSWORD. Signatures are stored next to the image in the registry
+// and not uploaded to the public transparency log.
+func (d *Client) SignImage(ctx context.Context, ref, keyPath string) error {
+	_, err := d.executor.RunQuietWithTimeout(ctx, cosignTimeout, "cosign", "sign", "--yes",
+		"--key", keyPath, "--tlog-upload=false", ref)
+	if err != nil {
+		return fmt.Errorf("failed to sign image: %w", err)
+	}
+	return nil
+}
+
+// VerifyImage checks the cosign signature of ref in the registry against
+// publicKey, a PEM encoded public key.
+func (d *Client) VerifyImage(ctx context.Context, ref, publicKey string) error {
+	keyFile, err := os.CreateTemp("", "cosign-*.pub")
+	if err != nil {
+		return fmt.Errorf("failed to write public key: %w", err)
+	}
+	defer os.Remove(keyFile.Name())
+	if _, err := keyFile.WriteString(publicKey); err != nil {
+		keyFile.Close()
+		return fmt.Errorf("failed to write public key: %w", err)
+	}
+	if err := keyFile.Close(); err != nil {
+		return fmt.Errorf("failed to write public key: %w", err)
+	}
+
+	_, err = d.executor.RunQuietWithTimeout(ctx, cosignTimeout, "cosign", "verify",
+		"--key", keyFile.Name(), "--insecure-ignore-tlog=true", ref)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrImageSignature, err)
+	}
+	return nil
+}
+
+// RepoDigest returns the digest reference of a pushed image, as in
+// "registry/paasdeploy/app@sha256:...", so it can be signed by content.
+func (d *Client) RepoDigest(ctx context.Context, tag string) (string, error) {
+	result, err := d.executor.RunQuietWithTimeout(ctx, 30*time.Second, "docker", "image", "inspect",
+		formatFlag, "{{range .RepoDigests}}{{println .}}{{end}}", tag)
+	if err != nil {
+		return "", fmt.Errorf("failed to inspect image digest: %w", err)
+	}
+	digest := pickRepoDigest(result.Stdout, tag)
+	if digest == "" {
+		return "", fmt.Errorf("image %s has no registry digest", tag)
+	}
+	return digest, nil
+}
+
+// pickRepoDigest returns the digest of the repository of tag among the
+// lines of RepoDigests.
+func pickRepoDigest(output, tag string) string {
+	repository := tag
+	if i := strings.LastIndex(tag, ":"); i > strings.LastIndex(tag, "/") {
+		repository = tag[:i]
+	}
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, repository+"@") {
+			return line
+		}
+	}
+	return ""
+}
@@ -0,0 +1,21 @@
+package docker
+
+import "testing"
+
+func TestPickRepoDigest(t *testing.T) {
+	output := "ghcr.io/acme/paasdeploy/api@sha256:aaa\nregistry.local:5000/paasdeploy/api@sha256:bbb\n"
+
+	tests := []struct {
+		tag  string
+		want string
+	}{
+		{"registry.local:5000/paasdeploy/api:abc123", "registry.local:5000/paasdeploy/api@sha256:bbb"},
+		{"ghcr.io/acme/paasdeploy/api:abc123", "ghcr.io/acme/paasdeploy/api@sha256:aaa"},
+		{"ghcr.io/acme/paasdeploy/web:abc123", ""},
+	}
+	for _, tt := range tests {
+		if got := pickRepoDigest(output, tt.tag); got != tt.want {
+			t.Errorf("pickRepoDigest(%q) = %q, want %q", tt.tag, got, tt.want)
+		}
+	}
+}
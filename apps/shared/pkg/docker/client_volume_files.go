@@ -0,0 +1,114 @@
+package docker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Volumes are not reachable from the host without root access to the docker
+// data dir, so their content is read through a short-lived helper container
+// that mounts the volume read-only.
+const (
+	volumeHelperImage = "busybox:1.36"
+	volumeMountPoint  = "/volume"
+	// volumeHelperTimeout covers pulling the helper image on first use.
+	volumeHelperTimeout = 2 * time.Minute
+)
+
+var ErrInvalidVolumeName = errors.New("invalid volume name")
+
+var volumeNamePattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+
+func volumeMountArg(volume string) (string, error) {
+	if !volumeNamePattern.MatchString(volume) {
+		return "", ErrInvalidVolumeName
+	}
+	return volume + ":" + volumeMountPoint + ":ro", nil
+}
+
+// volumePath maps an absolute path inside a volume to the helper mount.
+func volumePath(p string) (string, error) {
+	p, err := CleanContainerPath(p)
+	if err != nil {
+		return "", err
+	}
+	return path.Join(volumeMountPoint, p), nil
+}
+
+func (d *Client) runVolumeHelper(ctx context.Context, volume string, command ...string) (string, error) {
+	mount, err := volumeMountArg(volume)
+	if err != nil {
+		return "", err
+	}
+	args := append([]string{"run", "--rm", "--network", "none", "-v", mount, volumeHelperImage}, command...)
+	result, err := d.executor.RunQuietWithTimeout(ctx, volumeHelperTimeout, "docker", args...)
+	if err != nil {
+		if msg := strings.TrimSpace(result.Stderr); msg != "" {
+			return "", errors.New(msg)
+		}
+		return "", err
+	}
+	return result.Stdout, nil
+}
+
+// ListVolumeFiles lists a directory inside a named volume.
+func (d *Client) ListVolumeFiles(ctx context.Context, volume, dir string) ([]ContainerFile, error) {
+	target, err := volumePath(dir)
+	if err != nil {
+		return nil, err
+	}
+	output, err := d.runVolumeHelper(ctx, volume, "ls", "-lA", target+"/")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s in volume %s: %w", dir, volume, err)
+	}
+	return ParseLsOutput(output), nil
+}
+
+// StatVolumeFile describes a single path inside a named volume.
+func (d *Client) StatVolumeFile(ctx context.Context, volume, filePath string) (*ContainerFile, error) {
+	target, err := volumePath(filePath)
+	if err != nil {
+		return nil, err
+	}
+	output, err := d.runVolumeHelper(ctx, volume, "ls", "-ld", target)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat %s in volume %s: %w", filePath, volume, err)
+	}
+	file, ok := parseLsLine(strings.TrimSpace(output))
+	if !ok {
+		return nil, fmt.Errorf("unexpected ls output for %s", filePath)
+	}
+	// ls -d prints the full path; report names relative to the volume.
+	file.Name = path.Base(strings.TrimPrefix(file.Name, volumeMountPoint))
+	return &file, nil
+}
+
+// CopyFileFromVolume reads a single regular file out of a named volume, up
+// to maxBytes. The helper container is created but never started; docker cp
+// reads the volume through its mount.
+func (d *Client) CopyFileFromVolume(ctx context.Context, volume, filePath string, maxBytes int64) (string, []byte, error) {
+	mount, err := volumeMountArg(volume)
+	if err != nil {
+		return "", nil, err
+	}
+	target, err := volumePath(filePath)
+	if err != nil {
+		return "", nil, err
+	}
+
+	result, err := d.executor.RunQuietWithTimeout(ctx, volumeHelperTimeout, "docker", "create", "--network", "none", "-v", mount, volumeHelperImage, "true")
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create volume helper: %s", strings.TrimSpace(result.Stderr))
+	}
+	helperID := strings.TrimSpace(result.Stdout)
+	defer func() {
+		_, _ = d.executor.RunQuietWithTimeout(context.Background(), 30*time.Second, "docker", "rm", "-f", helperID)
+	}()
+
+	return d.CopyFileFromContainer(ctx, helperID, target, maxBytes)
+}
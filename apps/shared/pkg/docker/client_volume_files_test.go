@@ -0,0 +1,24 @@
+package docker
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestVolumeHelperPaths(t *testing.T) {
+	if _, err := volumeMountArg("data:/etc"); !errors.Is(err, ErrInvalidVolumeName) {
+		t.Errorf("expected volume name with mount syntax to be rejected, got %v", err)
+	}
+	mount, err := volumeMountArg("pgdata_1")
+	if err != nil || mount != "pgdata_1:/volume:ro" {
+		t.Errorf("volumeMountArg = %q, %v", mount, err)
+	}
+
+	got, err := volumePath("/../../etc/passwd")
+	if err != nil || got != "/volume/etc/passwd" {
+		t.Errorf("volumePath = %q, %v; want path kept inside the mount", got, err)
+	}
+	if _, err := volumePath("backups"); !errors.Is(err, ErrInvalidContainerPath) {
+		t.Errorf("expected relative path to be rejected, got %v", err)
+	}
+}
@@ -3,6 +3,7 @@ package docker
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -431,3 +432,58 @@ func parseDockerPortOutput(output string) []ContainerPort {
 
 	return ports
 }
+
+type ContainerProcess struct {
+	PID        int     `json:"pid"`
+	PPID       int     `json:"ppid"`
+	User       string  `json:"user"`
+	CPUPercent float64 `json:"cpuPercent"`
+	MemPercent float64 `json:"memPercent"`
+	Elapsed    string  `json:"elapsed"`
+	Command    string  `json:"command"`
+}
+
+// topColumns are the ps columns requested from docker top. args must stay
+// last since it is the only column that can contain spaces.
+const topColumns = "pid,ppid,user,pcpu,pmem,etime,args"
+
+func (d *Client) ContainerTop(ctx context.Context, containerID string) ([]ContainerProcess, error) {
+	result, err := d.executor.RunQuietWithTimeout(ctx, 30*time.Second, "docker", "top", containerID, "-eo", topColumns)
+	if err != nil {
+		if strings.Contains(strings.ToLower(result.Stderr), errNoSuchContainer) {
+			return nil, fmt.Errorf("container not found: %s", containerID)
+		}
+		return nil, fmt.Errorf("failed to list container processes: %w", err)
+	}
+	return ParseTopOutput(result.Stdout), nil
+}
+
+// ParseTopOutput parses docker top output for topColumns, skipping the
+// header line.
+func ParseTopOutput(output string) []ContainerProcess {
+	processes := []ContainerProcess{}
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	for _, line := range lines[1:] {
+		fields := strings.Fields(line)
+		if len(fields) < 7 {
+			continue
+		}
+		pid, err := strconv.Atoi(fields[0])
+		if err != nil {
+			continue
+		}
+		ppid, _ := strconv.Atoi(fields[1])
+		cpu, _ := strconv.ParseFloat(fields[3], 64)
+		mem, _ := strconv.ParseFloat(fields[4], 64)
+		processes = append(processes, ContainerProcess{
+			PID:        pid,
+			PPID:       ppid,
+			User:       fields[2],
+			CPUPercent: cpu,
+			MemPercent: mem,
+			Elapsed:    fields[5],
+			Command:    restOfLine(line, 6),
+		})
+	}
+	return processes
+}
@@ -0,0 +1,22 @@
+package docker
+
+import "testing"
+
+func TestParseTopOutput(t *testing.T) {
+	output := `PID                 PPID                USER                %CPU                %MEM                ELAPSED             COMMAND
+4211                4190                root                0.0                 0.1                 2-03:04:05          /bin/sh -c node server.js
+4260                4211                node                87.5                12.3                01:02               node  server.js --port 3000
+`
+	processes := ParseTopOutput(output)
+	if len(processes) != 2 {
+		t.Fatalf("expected 2 processes, got %d", len(processes))
+	}
+
+	want := ContainerProcess{PID: 4260, PPID: 4211, User: "node", CPUPercent: 87.5, MemPercent: 12.3, Elapsed: "01:02", Command: "node  server.js --port 3000"}
+	if processes[1] != want {
+		t.Errorf("process = %+v, want %+v", processes[1], want)
+	}
+	if processes[0].Command != "/bin/sh -c node server.js" {
+		t.Errorf("command = %q", processes[0].Command)
+	}
+}
@@ -0,0 +1,182 @@
+package docker
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// BaseImages returns the external images the Dockerfile at path builds
+// from, in order and without duplicates.
+func BaseImages(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseBaseImages(string(data)), nil
+}
+
+// ParseBaseImages extracts the images of the FROM instructions of a
+// Dockerfile. References to earlier build stages, scratch and images
+// depending on build arguments are skipped since they cannot be pulled
+// ahead of the build.
+func ParseBaseImages(dockerfile string) []string {
+	stages := make(map[string]bool)
+	seen := make(map[string]bool)
+	var images []string
+
+	scanner := bufio.NewScanner(strings.NewReader(dockerfile))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 || !strings.EqualFold(fields[0], "FROM") {
+			continue
+		}
+		args := fields[1:]
+		for len(args) > 0 && strings.HasPrefix(args[0], "--") {
+			args = args[1:]
+		}
+		if len(args) == 0 {
+			continue
+		}
+		image := args[0]
+		fromStage := stages[strings.ToLower(image)]
+		if len(args) >= 3 && strings.EqualFold(args[1], "AS") {
+			stages[strings.ToLower(args[2])] = true
+		}
+
+		if fromStage || strings.EqualFold(image, "scratch") || strings.Contains(image, "$") || seen[image] {
+			continue
+		}
+		seen[image] = true
+		images = append(images, image)
+	}
+	return images
+}
+
+// ExposedPort returns the first TCP port the Dockerfile at path exposes in
+// the stage that runs, or 0 when it exposes none.
+func ExposedPort(path, target string) (int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	return ParseExposedPort(string(data), target), nil
+}
+
+// ParseExposedPort extracts the first TCP port of the EXPOSE instructions
+// of the build stage that runs: target when set, the final stage otherwise.
+// Ports given as $VAR or ${VAR} are resolved from the ENV and ARG defaults
+// of the stage.
+func ParseExposedPort(dockerfile, target string) int {
+	port := 0
+	stage := ""
+	vars := make(map[string]string)
+	for _, line := range dockerfileInstructions(dockerfile) {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		switch strings.ToUpper(fields[0]) {
+		case "FROM":
+			if target != "" && strings.EqualFold(stage, target) {
+				return port
+			}
+			port = 0
+			stage = ""
+			if len(fields) >= 4 && strings.EqualFold(fields[len(fields)-2], "AS") {
+				stage = fields[len(fields)-1]
+			}
+			vars = make(map[string]string)
+		case "ARG", "ENV":
+			setDockerfileVars(vars, fields[1:])
+		case "EXPOSE":
+			if port != 0 {
+				continue
+			}
+			for _, spec := range fields[1:] {
+				spec = os.Expand(spec, func(name string) string { return vars[name] })
+				number, protocol, _ := strings.Cut(spec, "/")
+				if protocol != "" && !strings.EqualFold(protocol, "tcp") {
+					continue
+				}
+				if n, err := strconv.Atoi(number); err == nil && n > 0 && n <= 65535 {
+					port = n
+					break
+				}
+			}
+		}
+	}
+	return port
+}
+
+// dockerfileInstructions joins continuation lines and drops comments.
+func dockerfileInstructions(dockerfile string) []string {
+	var instructions []string
+	var current strings.Builder
+	scanner := bufio.NewScanner(strings.NewReader(dockerfile))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.HasSuffix(line, `\`) {
+			current.WriteString(strings.TrimSuffix(line, `\`))
+			current.WriteString(" ")
+			continue
+		}
+		current.WriteString(line)
+		instructions = append(instructions, current.String())
+		current.Reset()
+	}
+	if current.Len() > 0 {
+		instructions = append(instructions, current.String())
+	}
+	return instructions
+}
+
+// setDockerfileVars records the defaults of an ARG or ENV instruction, in
+// either the KEY=value or the legacy "ENV KEY value" form. Values may refer
+// to variables set before.
+func setDockerfileVars(vars map[string]string, args []string) {
+	set := func(key, value string) {
+		vars[key] = os.Expand(strings.Trim(value, `"'`), func(name string) string { return vars[name] })
+	}
+	if len(args) == 2 && !strings.Contains(args[0], "=") {
+		set(args[0], args[1])
+		return
+	}
+	for _, arg := range args {
+		if key, value, ok := strings.Cut(arg, "="); ok {
+			set(key, value)
+		}
+	}
+}
+
+// EnsureImages pulls, in parallel, the images that are not present locally.
+func (d *Client) EnsureImages(ctx context.Context, images []string) error {
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+	for _, image := range images {
+		if exists, err := d.ImageExists(ctx, image); err == nil && exists {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := d.Pull(ctx, image); err != nil {
+				mu.Lock()
+				errs = append(errs, err)
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
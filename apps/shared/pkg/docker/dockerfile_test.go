@@ -0,0 +1,52 @@
+package docker
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseBaseImages(t *testing.T) {
+	dockerfile := `# syntax=docker/dockerfile:1
+ARG NODE_VERSION=20
+FROM node:${NODE_VERSION}-alpine AS deps
+FROM --platform=linux/amd64 golang:1.24-alpine AS build
+RUN go build ./...
+from build as test
+FROM deps AS runtime
+FROM gcr.io/distroless/static
+COPY --from=build /app /app
+FROM golang:1.24-alpine
+FROM scratch
+`
+	want := []string{"golang:1.24-alpine", "gcr.io/distroless/static"}
+	if got := ParseBaseImages(dockerfile); !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseBaseImages = %v, want %v", got, want)
+	}
+}
+
+func TestParseExposedPort(t *testing.T) {
+	tests := []struct {
+		name       string
+		dockerfile string
+		target     string
+		want       int
+	}{
+		{"none", "FROM nginx\nCOPY . /usr/share/nginx/html\n", "", 0},
+		{"single", "FROM node:20\nEXPOSE 3000\n", "", 3000},
+		{"protocol", "FROM coredns/coredns\nEXPOSE 53/udp 8080/tcp\n", "", 8080},
+		{"final stage", "FROM golang:1.24 AS build\nEXPOSE 9000\nFROM alpine\nexpose 8000\n", "", 8000},
+		{"build stage only", "FROM golang:1.24 AS build\nEXPOSE 9000\nFROM alpine\n", "", 0},
+		{"variable", "FROM python:3.12\nARG APP_PORT=5000\nENV PORT=${APP_PORT}\nEXPOSE $PORT\n", "", 5000},
+		{"legacy env", "FROM ruby:3.3\nENV PORT 4567\nEXPOSE ${PORT}\n", "", 4567},
+		{"continuation", "FROM node:20\n# ports\nEXPOSE \\\n  4000\n", "", 4000},
+		{"unresolved", "FROM node:20\nEXPOSE $PORT\n", "", 0},
+		{"target", "FROM node:20 AS dev\nEXPOSE 5173\nFROM node:20 AS prod\nEXPOSE 3000\nFROM nginx AS static\nEXPOSE 80\n", "prod", 3000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseExposedPort(tt.dockerfile, tt.target); got != tt.want {
+				t.Errorf("ParseExposedPort() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,218 @@
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ContainerInspect is the subset of `docker inspect` that is safe to show in
+// the dashboard. Environment values are dropped since they routinely hold
+// credentials; only the variable names are kept.
+type ContainerInspect struct {
+	ID            string                `json:"id"`
+	Name          string                `json:"name"`
+	Image         string                `json:"image"`
+	ImageID       string                `json:"imageId"`
+	Created       string                `json:"created"`
+	RestartCount  int                   `json:"restartCount"`
+	RestartPolicy string                `json:"restartPolicy"`
+	State         ContainerInspectState `json:"state"`
+	Health        *ContainerHealthLog   `json:"health,omitempty"`
+	EnvNames      []string              `json:"envNames"`
+	Labels        map[string]string     `json:"labels"`
+	Mounts        []ContainerMount      `json:"mounts"`
+	Networks      []ContainerNetwork    `json:"networks"`
+}
+
+type ContainerInspectState struct {
+	Status     string `json:"status"`
+	Running    bool   `json:"running"`
+	Paused     bool   `json:"paused"`
+	Restarting bool   `json:"restarting"`
+	OOMKilled  bool   `json:"oomKilled"`
+	Dead       bool   `json:"dead"`
+	ExitCode   int    `json:"exitCode"`
+	Error      string `json:"error,omitempty"`
+	StartedAt  string `json:"startedAt"`
+	FinishedAt string `json:"finishedAt,omitempty"`
+}
+
+type ContainerHealthLog struct {
+	Status        string                 `json:"status"`
+	FailingStreak int                    `json:"failingStreak"`
+	Log           []ContainerHealthCheck `json:"log"`
+}
+
+type ContainerHealthCheck struct {
+	Start    string `json:"start"`
+	End      string `json:"end"`
+	ExitCode int    `json:"exitCode"`
+	Output   string `json:"output"`
+}
+
+type ContainerNetwork struct {
+	Name       string `json:"name"`
+	IPAddress  string `json:"ipAddress"`
+	Gateway    string `json:"gateway"`
+	MacAddress string `json:"macAddress"`
+}
+
+type rawInspect struct {
+	ID      string `json:"Id"`
+	Name    string
+	Image   string
+	Created string
+	Config  struct {
+		Image  string
+		Env    []string
+		Labels map[string]string
+	}
+	State struct {
+		Status     string
+		Running    bool
+		Paused     bool
+		Restarting bool
+		OOMKilled  bool
+		Dead       bool
+		ExitCode   int
+		Error      string
+		StartedAt  string
+		FinishedAt string
+		Health     *struct {
+			Status        string
+			FailingStreak int
+			Log           []struct {
+				Start    string
+				End      string
+				ExitCode int
+				Output   string
+			}
+		}
+	}
+	RestartCount int
+	HostConfig   struct {
+		RestartPolicy struct {
+			Name string
+		}
+	}
+	Mounts []struct {
+		Type        string
+		Source      string
+		Destination string
+		RW          bool
+	}
+	NetworkSettings struct {
+		Networks map[string]struct {
+			IPAddress  string
+			Gateway    string
+			MacAddress string
+		}
+	}
+}
+
+// zeroTime is how docker reports timestamps that were never set.
+const zeroTime = "0001-01-01T00:00:00Z"
+
+func (d *Client) InspectContainerFull(ctx context.Context, containerID string) (*ContainerInspect, error) {
+	result, err := d.executor.RunQuietWithTimeout(ctx, 30*time.Second, "docker", "inspect", "--type", "container", containerID)
+	if err != nil {
+		if strings.Contains(strings.ToLower(result.Stderr), "no such") {
+			return nil, fmt.Errorf("container not found: %s", containerID)
+		}
+		return nil, fmt.Errorf("failed to inspect container: %w", err)
+	}
+	return ParseInspectOutput([]byte(result.Stdout))
+}
+
+// ParseInspectOutput sanitizes the JSON array printed by docker inspect for
+// a single container.
+func ParseInspectOutput(output []byte) (*ContainerInspect, error) {
+	var raws []rawInspect
+	if err := json.Unmarshal(output, &raws); err != nil {
+		return nil, fmt.Errorf("failed to parse inspect output: %w", err)
+	}
+	if len(raws) == 0 {
+		return nil, fmt.Errorf("empty inspect output")
+	}
+	raw := raws[0]
+
+	inspect := &ContainerInspect{
+		ID:            raw.ID,
+		Name:          strings.TrimPrefix(raw.Name, "/"),
+		Image:         raw.Config.Image,
+		ImageID:       raw.Image,
+		Created:       raw.Created,
+		RestartCount:  raw.RestartCount,
+		RestartPolicy: raw.HostConfig.RestartPolicy.Name,
+		State: ContainerInspectState{
+			Status:     raw.State.Status,
+			Running:    raw.State.Running,
+			Paused:     raw.State.Paused,
+			Restarting: raw.State.Restarting,
+			OOMKilled:  raw.State.OOMKilled,
+			Dead:       raw.State.Dead,
+			ExitCode:   raw.State.ExitCode,
+			Error:      raw.State.Error,
+			StartedAt:  raw.State.StartedAt,
+		},
+		EnvNames: make([]string, 0, len(raw.Config.Env)),
+		Labels:   raw.Config.Labels,
+		Mounts:   make([]ContainerMount, 0, len(raw.Mounts)),
+		Networks: make([]ContainerNetwork, 0, len(raw.NetworkSettings.Networks)),
+	}
+	if raw.State.FinishedAt != zeroTime {
+		inspect.State.FinishedAt = raw.State.FinishedAt
+	}
+	if inspect.Labels == nil {
+		inspect.Labels = map[string]string{}
+	}
+
+	for _, env := range raw.Config.Env {
+		name, _, _ := strings.Cut(env, "=")
+		inspect.EnvNames = append(inspect.EnvNames, name)
+	}
+	sort.Strings(inspect.EnvNames)
+
+	for _, m := range raw.Mounts {
+		inspect.Mounts = append(inspect.Mounts, ContainerMount{
+			Type:        m.Type,
+			Source:      m.Source,
+			Destination: m.Destination,
+			ReadOnly:    !m.RW,
+		})
+	}
+
+	for name, n := range raw.NetworkSettings.Networks {
+		inspect.Networks = append(inspect.Networks, ContainerNetwork{
+			Name:       name,
+			IPAddress:  n.IPAddress,
+			Gateway:    n.Gateway,
+			MacAddress: n.MacAddress,
+		})
+	}
+	sort.Slice(inspect.Networks, func(i, j int) bool {
+		return inspect.Networks[i].Name < inspect.Networks[j].Name
+	})
+
+	if h := raw.State.Health; h != nil {
+		inspect.Health = &ContainerHealthLog{
+			Status:        h.Status,
+			FailingStreak: h.FailingStreak,
+			Log:           make([]ContainerHealthCheck, 0, len(h.Log)),
+		}
+		for _, entry := range h.Log {
+			inspect.Health.Log = append(inspect.Health.Log, ContainerHealthCheck{
+				Start:    entry.Start,
+				End:      entry.End,
+				ExitCode: entry.ExitCode,
+				Output:   strings.TrimSpace(entry.Output),
+			})
+		}
+	}
+
+	return inspect, nil
+}
@@ -0,0 +1,90 @@
+package docker
+
+import (
+	"reflect"
+	"testing"
+)
+
+const testInspectJSON = `[{
+  "Id": "4f1c2a",
+  "Created": "2025-01-05T10:12:00Z",
+  "Name": "/shop-api",
+  "Image": "sha256:abc",
+  "RestartCount": 3,
+  "Config": {
+    "Image": "shop/api:1.2.0",
+    "Env": ["PATH=/usr/bin", "DATABASE_URL=postgres://user:secret@db/shop"],
+    "Labels": {"paasdeploy.app": "shop"}
+  },
+  "State": {
+    "Status": "exited",
+    "OOMKilled": true,
+    "ExitCode": 137,
+    "StartedAt": "2025-01-05T10:12:01Z",
+    "FinishedAt": "2025-01-05T11:00:00Z",
+    "Health": {
+      "Status": "unhealthy",
+      "FailingStreak": 2,
+      "Log": [{"Start": "s", "End": "e", "ExitCode": 1, "Output": "connection refused\n"}]
+    }
+  },
+  "HostConfig": {"RestartPolicy": {"Name": "unless-stopped"}},
+  "Mounts": [{"Type": "volume", "Source": "/var/lib/docker/volumes/data", "Destination": "/data", "RW": false}],
+  "NetworkSettings": {"Networks": {"paasdeploy": {"IPAddress": "172.18.0.5", "Gateway": "172.18.0.1", "MacAddress": "02:42"}}}
+}]`
+
+func TestParseInspectOutput(t *testing.T) {
+	inspect, err := ParseInspectOutput([]byte(testInspectJSON))
+	if err != nil {
+		t.Fatalf("ParseInspectOutput: %v", err)
+	}
+
+	if inspect.Name != "shop-api" || inspect.Image != "shop/api:1.2.0" || inspect.RestartCount != 3 || inspect.RestartPolicy != "unless-stopped" {
+		t.Errorf("unexpected container fields: %+v", inspect)
+	}
+	if !inspect.State.OOMKilled || inspect.State.ExitCode != 137 || inspect.State.FinishedAt == "" {
+		t.Errorf("unexpected state: %+v", inspect.State)
+	}
+	if want := []string{"DATABASE_URL", "PATH"}; !reflect.DeepEqual(inspect.EnvNames, want) {
+		t.Errorf("env names = %v, want %v", inspect.EnvNames, want)
+	}
+	if len(inspect.Mounts) != 1 || !inspect.Mounts[0].ReadOnly {
+		t.Errorf("unexpected mounts: %+v", inspect.Mounts)
+	}
+	if len(inspect.Networks) != 1 || inspect.Networks[0].IPAddress != "172.18.0.5" {
+		t.Errorf("unexpected networks: %+v", inspect.Networks)
+	}
+	if inspect.Health == nil || len(inspect.Health.Log) != 1 || inspect.Health.Log[0].Output != "connection refused" {
+		t.Errorf("unexpected health: %+v", inspect.Health)
+	}
+}
+
+func TestParseInspectOutputEmpty(t *testing.T) {
+	if _, err := ParseInspectOutput([]byte("[]")); err == nil {
+		t.Error("expected error for empty inspect output")
+	}
+}
+
+func TestParseContainerHealth(t *testing.T) {
+	health, err := parseContainerHealth("shop", "exited|none|2025-01-05T10:12:00Z|shop:latest|7|true|137\n")
+	if err != nil {
+		t.Fatalf("parseContainerHealth: %v", err)
+	}
+	want := &ContainerHealth{
+		Name:         "shop",
+		Status:       "exited",
+		Health:       "none",
+		StartedAt:    "2025-01-05T10:12:00Z",
+		Image:        "shop:latest",
+		RestartCount: 7,
+		OOMKilled:    true,
+		ExitCode:     137,
+	}
+	if !reflect.DeepEqual(health, want) {
+		t.Errorf("parseContainerHealth() = %+v, want %+v", health, want)
+	}
+
+	if _, err := parseContainerHealth("shop", "running|healthy"); err == nil {
+		t.Error("expected error for truncated inspect output")
+	}
+}
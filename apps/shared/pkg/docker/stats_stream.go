@@ -0,0 +1,278 @@
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultDockerSocket = "/var/run/docker.sock"
+
+	// statsFirstSampleTimeout bounds how long a caller waits for a new stream
+	// to produce its second sample, the first with a CPU reading. The daemon
+	// sends one per second; after the timeout the first sample is used.
+	statsFirstSampleTimeout = 5 * time.Second
+
+	// statsStreamIdleTimeout closes a stream nobody has read for that long,
+	// so removed or no longer monitored containers do not keep a connection.
+	statsStreamIdleTimeout = 2 * time.Minute
+)
+
+// errStatsAPIUnavailable means the Docker API cannot be reached directly
+// (unsupported DOCKER_HOST scheme, TLS, unreachable socket) and stats must
+// come from the CLI instead.
+var errStatsAPIUnavailable = errors.New("docker stats api unavailable")
+
+// statsStreamer keeps one streaming stats request to the Docker API per
+// container and serves the latest sample from memory. This replaces a
+// `docker stats --no-stream` fork per container per poll.
+type statsStreamer struct {
+	http    *http.Client
+	baseURL string
+	logger  *slog.Logger
+
+	mu      sync.Mutex
+	streams map[string]*statsStream
+}
+
+type statsStream struct {
+	cancel context.CancelFunc
+	ready  chan struct{}
+
+	mu       sync.Mutex
+	latest   *ContainerStats
+	err      error
+	lastRead time.Time
+}
+
+// newStatsStreamer returns a streamer for dockerHost, in DOCKER_HOST form.
+// It returns nil when the host cannot be reached without the CLI.
+func newStatsStreamer(dockerHost string, logger *slog.Logger) *statsStreamer {
+	if dockerHost == "" {
+		dockerHost = "unix://" + defaultDockerSocket
+	}
+	if os.Getenv("DOCKER_TLS_VERIFY") != "" {
+		return nil
+	}
+
+	u, err := url.Parse(dockerHost)
+	if err != nil {
+		return nil
+	}
+
+	s := &statsStreamer{
+		logger:  logger,
+		streams: make(map[string]*statsStream),
+	}
+	switch u.Scheme {
+	case "unix":
+		socket := u.Path
+		s.baseURL = "http://docker"
+		s.http = &http.Client{Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, "unix", socket)
+			},
+		}}
+	case "tcp", "http":
+		s.baseURL = "http://" + u.Host
+		s.http = &http.Client{}
+	default:
+		return nil
+	}
+	return s
+}
+
+// Latest returns the most recent sample for containerName, opening a stream
+// if none is running and waiting for its first sample.
+func (s *statsStreamer) Latest(ctx context.Context, containerName string) (*ContainerStats, error) {
+	s.mu.Lock()
+	stream, ok := s.streams[containerName]
+	if !ok {
+		stream = s.open(containerName)
+	}
+	s.mu.Unlock()
+
+	timer := time.NewTimer(statsFirstSampleTimeout)
+	defer timer.Stop()
+	select {
+	case <-stream.ready:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-timer.C:
+	}
+
+	stream.mu.Lock()
+	defer stream.mu.Unlock()
+	stream.lastRead = time.Now()
+	if stream.latest == nil {
+		if stream.err != nil {
+			return nil, stream.err
+		}
+		return nil, fmt.Errorf("no stats available for container: %s", containerName)
+	}
+	stats := *stream.latest
+	return &stats, nil
+}
+
+// open starts the stream for containerName. s.mu must be held.
+func (s *statsStreamer) open(containerName string) *statsStream {
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := &statsStream{
+		cancel:   cancel,
+		ready:    make(chan struct{}),
+		lastRead: time.Now(),
+	}
+	s.streams[containerName] = stream
+	go s.run(ctx, containerName, stream)
+	return stream
+}
+
+func (s *statsStreamer) run(ctx context.Context, containerName string, stream *statsStream) {
+	var readyOnce sync.Once
+	markReady := func() { readyOnce.Do(func() { close(stream.ready) }) }
+
+	err := s.consume(ctx, containerName, stream, markReady)
+
+	s.mu.Lock()
+	if s.streams[containerName] == stream {
+		delete(s.streams, containerName)
+	}
+	s.mu.Unlock()
+	stream.cancel()
+
+	stream.mu.Lock()
+	stream.err = err
+	stream.mu.Unlock()
+	markReady()
+
+	if err != nil && !errors.Is(err, context.Canceled) {
+		s.logger.Debug("Container stats stream ended", "container", containerName, "error", err)
+	}
+}
+
+func (s *statsStreamer) consume(ctx context.Context, containerName string, stream *statsStream, markReady func()) error {
+	endpoint := s.baseURL + "/containers/" + url.PathEscape(containerName) + "/stats?stream=true"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := s.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %v", errStatsAPIUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return fmt.Errorf("container not found: %s", containerName)
+	default:
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("docker stats api returned %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	decoder := json.NewDecoder(resp.Body)
+	for frames := 1; ; frames++ {
+		var frame apiStats
+		if err := decoder.Decode(&frame); err != nil {
+			if errors.Is(err, io.EOF) {
+				return fmt.Errorf("no stats available for container: %s", containerName)
+			}
+			return err
+		}
+
+		stream.mu.Lock()
+		stream.latest = frame.toContainerStats()
+		idle := time.Since(stream.lastRead) > statsStreamIdleTimeout
+		stream.mu.Unlock()
+
+		// The first frame usually has no previous CPU reading, which would
+		// show as zero CPU; callers wait for the second one in that case.
+		if frames > 1 || frame.PreCPUStats.SystemUsage > 0 {
+			markReady()
+		}
+		if idle {
+			return context.Canceled
+		}
+	}
+}
+
+// apiStats is the subset of the Docker API stats frame used here.
+type apiStats struct {
+	CPUStats    apiCPUStats `json:"cpu_stats"`
+	PreCPUStats apiCPUStats `json:"precpu_stats"`
+	MemoryStats struct {
+		Usage uint64            `json:"usage"`
+		Limit uint64            `json:"limit"`
+		Stats map[string]uint64 `json:"stats"`
+	} `json:"memory_stats"`
+	Networks map[string]struct {
+		RxBytes uint64 `json:"rx_bytes"`
+		TxBytes uint64 `json:"tx_bytes"`
+	} `json:"networks"`
+	PidsStats struct {
+		Current uint64 `json:"current"`
+	} `json:"pids_stats"`
+}
+
+type apiCPUStats struct {
+	CPUUsage struct {
+		TotalUsage  uint64   `json:"total_usage"`
+		PercpuUsage []uint64 `json:"percpu_usage"`
+	} `json:"cpu_usage"`
+	SystemUsage uint64 `json:"system_cpu_usage"`
+	OnlineCPUs  uint32 `json:"online_cpus"`
+}
+
+// toContainerStats computes the values `docker stats` shows from a raw
+// frame, using the same formulas as the CLI.
+func (f *apiStats) toContainerStats() *ContainerStats {
+	stats := &ContainerStats{
+		MemoryLimit: int64(f.MemoryStats.Limit),
+		PIDs:        int(f.PidsStats.Current),
+	}
+
+	cpuDelta := float64(f.CPUStats.CPUUsage.TotalUsage) - float64(f.PreCPUStats.CPUUsage.TotalUsage)
+	systemDelta := float64(f.CPUStats.SystemUsage) - float64(f.PreCPUStats.SystemUsage)
+	onlineCPUs := float64(f.CPUStats.OnlineCPUs)
+	if onlineCPUs == 0 {
+		onlineCPUs = float64(len(f.CPUStats.CPUUsage.PercpuUsage))
+	}
+	if cpuDelta > 0 && systemDelta > 0 {
+		stats.CPUPercent = cpuDelta / systemDelta * onlineCPUs * 100
+	}
+
+	// Page cache is reclaimable and not counted, as in the CLI: cgroup v1
+	// reports it as total_inactive_file, cgroup v2 as inactive_file.
+	usage := f.MemoryStats.Usage
+	cache, ok := f.MemoryStats.Stats["total_inactive_file"]
+	if !ok {
+		cache = f.MemoryStats.Stats["inactive_file"]
+	}
+	if cache < usage {
+		usage -= cache
+	}
+	stats.MemoryUsage = int64(usage)
+	if f.MemoryStats.Limit > 0 {
+		stats.MemoryPercent = float64(usage) / float64(f.MemoryStats.Limit) * 100
+	}
+
+	for _, network := range f.Networks {
+		stats.NetworkRx += int64(network.RxBytes)
+		stats.NetworkTx += int64(network.TxBytes)
+	}
+
+	return stats
+}
@@ -0,0 +1,81 @@
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const testStatsFrame = `{
+  "cpu_stats": {"cpu_usage": {"total_usage": 400000000}, "system_cpu_usage": 20000000000, "online_cpus": 4},
+  "precpu_stats": {"cpu_usage": {"total_usage": 300000000}, "system_cpu_usage": 19000000000, "online_cpus": 4},
+  "memory_stats": {"usage": 150000000, "limit": 1000000000, "stats": {"inactive_file": 50000000}},
+  "networks": {"eth0": {"rx_bytes": 1000, "tx_bytes": 200}, "eth1": {"rx_bytes": 24, "tx_bytes": 56}},
+  "pids_stats": {"current": 12}
+}`
+
+func TestAPIStatsToContainerStats(t *testing.T) {
+	var frame apiStats
+	if err := json.Unmarshal([]byte(testStatsFrame), &frame); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	stats := frame.toContainerStats()
+
+	if stats.CPUPercent != 40 {
+		t.Errorf("CPUPercent = %v, want 40", stats.CPUPercent)
+	}
+	if stats.MemoryUsage != 100000000 || stats.MemoryLimit != 1000000000 || stats.MemoryPercent != 10 {
+		t.Errorf("unexpected memory: %+v", stats)
+	}
+	if stats.NetworkRx != 1024 || stats.NetworkTx != 256 || stats.PIDs != 12 {
+		t.Errorf("unexpected network or pids: %+v", stats)
+	}
+}
+
+func TestStatsStreamerLatest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/containers/shop-api/stats" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprintln(w, strings.ReplaceAll(testStatsFrame, "\n", ""))
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	streamer := newStatsStreamer("tcp://"+strings.TrimPrefix(server.URL, "http://"), slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if streamer == nil {
+		t.Fatal("newStatsStreamer returned nil for a tcp host")
+	}
+
+	stats, err := streamer.Latest(context.Background(), "shop-api")
+	if err != nil {
+		t.Fatalf("Latest: %v", err)
+	}
+	if stats.CPUPercent != 40 || stats.PIDs != 12 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+
+	if _, err := streamer.Latest(context.Background(), "missing"); err == nil || !strings.Contains(err.Error(), "container not found") {
+		t.Errorf("Latest(missing) error = %v, want container not found", err)
+	}
+
+	streamer.mu.Lock()
+	for _, stream := range streamer.streams {
+		stream.cancel()
+	}
+	streamer.mu.Unlock()
+}
+
+func TestNewStatsStreamerUnsupportedHost(t *testing.T) {
+	if s := newStatsStreamer("ssh://user@host", slog.Default()); s != nil {
+		t.Error("expected nil streamer for an ssh host")
+	}
+}
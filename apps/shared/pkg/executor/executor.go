@@ -35,22 +35,29 @@ func New(workDir string, timeout time.Duration, logger *slog.Logger) *Executor {
 }
 
 func (e *Executor) Run(ctx context.Context, name string, args ...string) (*Result, error) {
-	return e.run(ctx, true, e.timeout, name, args...)
+	return e.run(ctx, true, e.timeout, nil, nil, name, args...)
 }
 
 func (e *Executor) RunQuiet(ctx context.Context, name string, args ...string) (*Result, error) {
-	return e.run(ctx, false, e.timeout, name, args...)
+	return e.run(ctx, false, e.timeout, nil, nil, name, args...)
 }
 
 func (e *Executor) RunWithTimeout(ctx context.Context, timeout time.Duration, name string, args ...string) (*Result, error) {
-	return e.run(ctx, true, timeout, name, args...)
+	return e.run(ctx, true, timeout, nil, nil, name, args...)
 }
 
 func (e *Executor) RunQuietWithTimeout(ctx context.Context, timeout time.Duration, name string, args ...string) (*Result, error) {
-	return e.run(ctx, false, timeout, name, args...)
+	return e.run(ctx, false, timeout, nil, nil, name, args...)
 }
 
-func (e *Executor) run(ctx context.Context, logErrors bool, timeout time.Duration, name string, args ...string) (*Result, error) {
+// RunQuietWithIO runs the command with stdin as its input and its output
+// written to stdout instead of Result.Stdout, for binary data such as
+// archives. Either may be nil.
+func (e *Executor) RunQuietWithIO(ctx context.Context, timeout time.Duration, stdin io.Reader, stdout io.Writer, name string, args ...string) (*Result, error) {
+	return e.run(ctx, false, timeout, stdin, stdout, name, args...)
+}
+
+func (e *Executor) run(ctx context.Context, logErrors bool, timeout time.Duration, stdin io.Reader, output io.Writer, name string, args ...string) (*Result, error) {
 	start := time.Now()
 
 	ctx, cancel := context.WithTimeout(ctx, timeout)
@@ -58,9 +65,13 @@ func (e *Executor) run(ctx context.Context, logErrors bool, timeout time.Duratio
 
 	cmd := exec.CommandContext(ctx, name, args...)
 	cmd.Dir = e.workDir
+	cmd.Stdin = stdin
 
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
+	if output != nil {
+		cmd.Stdout = output
+	}
 	cmd.Stderr = &stderr
 
 	e.logger.Debug("Executing command",
@@ -51,6 +51,29 @@ func (g *Client) CloneWithToken(ctx context.Context, repoURL, targetDir, token s
 	return nil
 }
 
+// CloneBranchWithToken makes a shallow clone of a single branch.
+func (g *Client) CloneBranchWithToken(ctx context.Context, repoURL, branch, targetDir, token string) error {
+	g.logger.Info("Cloning branch", "url", repoURL, "branch", branch, "target", targetDir, "authenticated", token != "")
+
+	cloneURL := repoURL
+	if token != "" {
+		authenticatedURL, err := InjectTokenIntoURL(repoURL, token)
+		if err != nil {
+			return fmt.Errorf("failed to create authenticated URL: %w", err)
+		}
+		cloneURL = authenticatedURL
+	}
+
+	g.executor.SetWorkDir(filepath.Dir(targetDir))
+
+	_, err := g.executor.Run(ctx, "git", "clone", "--depth", "1", "--single-branch", "--branch", branch, cloneURL, filepath.Base(targetDir))
+	if err != nil {
+		return fmt.Errorf("git clone failed: %w", err)
+	}
+
+	return nil
+}
+
 func InjectTokenIntoURL(repoURL, token string) (string, error) {
 	parsed, err := url.Parse(repoURL)
 	if err != nil {
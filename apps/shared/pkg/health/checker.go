@@ -11,6 +11,11 @@ import (
 	"time"
 )
 
+const (
+	readyPollInitial = 250 * time.Millisecond
+	readyPollMax     = 2 * time.Second
+)
+
 type Checker struct {
 	client    *http.Client
 	tlsClient *http.Client
@@ -146,6 +151,37 @@ func (h *Checker) CheckWithBackoff(ctx context.Context, url string) error {
 	}
 }
 
+// WaitReady polls url right away, at a quickly growing interval, and returns
+// as soon as it is healthy, so fast-starting apps don't sit out a fixed
+// delay. Failures within startPeriod are expected while the app boots; past
+// it, checking continues as CheckWithBackoff does.
+func (h *Checker) WaitReady(ctx context.Context, url string, startPeriod time.Duration) error {
+	h.logger.Info("Waiting for app readiness", "url", url, "startPeriod", startPeriod)
+
+	start := time.Now()
+	poll := readyPollInitial
+	for attempt := 1; time.Since(start) < startPeriod; attempt++ {
+		err := h.singleCheck(ctx, url)
+		if err == nil {
+			h.logger.Info("Health check passed", "url", url, "attempt", attempt, "elapsed", time.Since(start))
+			return nil
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		h.logger.Debug("App not ready yet", "url", url, "attempt", attempt, "error", err)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(max(0, min(poll, startPeriod-time.Since(start)))):
+		}
+		poll = min(poll*2, readyPollMax)
+	}
+
+	return h.CheckWithBackoff(ctx, url)
+}
+
 func newHealthCheckTLSConfig() *tls.Config {
 	rootCAs, _ := x509.SystemCertPool()
 	if rootCAs == nil {
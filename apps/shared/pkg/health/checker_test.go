@@ -0,0 +1,48 @@
+package health
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWaitReadyReturnsOnceHealthy(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	h := NewChecker(time.Minute, 10, time.Second, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	start := time.Now()
+	if err := h.WaitReady(context.Background(), srv.URL, 15*time.Second); err != nil {
+		t.Fatalf("WaitReady: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("WaitReady took %s, want well under the start period", elapsed)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("got %d checks, want 3", calls.Load())
+	}
+}
+
+func TestWaitReadyFallsBackAfterStartPeriod(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	h := NewChecker(50*time.Millisecond, 10, 10*time.Millisecond, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err := h.WaitReady(context.Background(), srv.URL, 100*time.Millisecond); err == nil {
+		t.Fatal("WaitReady succeeded against an unhealthy app")
+	}
+}
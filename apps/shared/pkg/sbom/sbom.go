@@ -0,0 +1,204 @@
+// Package sbom reads the CycloneDX software bills of materials generated
+// for deployed images and compares them.
+package sbom
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Format is the format of the documents generated by docker.GenerateSBOM.
+const Format = "cyclonedx-json"
+
+// Package is a component found in an image. Type is the ecosystem from its
+// package URL, such as npm, golang or deb.
+type Package struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Type    string `json:"type"`
+	PURL    string `json:"purl,omitempty"`
+}
+
+// Change is a package whose version differs between two documents.
+type Change struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// Diff lists the packages added, removed and upgraded or downgraded from
+// one document to another.
+type Diff struct {
+	Added   []Package `json:"added"`
+	Removed []Package `json:"removed"`
+	Changed []Change  `json:"changed"`
+}
+
+type document struct {
+	Components []struct {
+		Type    string `json:"type"`
+		Name    string `json:"name"`
+		Version string `json:"version"`
+		PURL    string `json:"purl"`
+	} `json:"components"`
+}
+
+// Packages returns the packages of a CycloneDX JSON document, sorted by
+// type, name and version. Files and duplicates are left out.
+func Packages(data []byte) ([]Package, error) {
+	var doc document
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("invalid SBOM: %w", err)
+	}
+
+	seen := make(map[Package]bool)
+	packages := []Package{}
+	for _, component := range doc.Components {
+		if component.Type == "file" || component.Name == "" {
+			continue
+		}
+		pkg := Package{
+			Name:    component.Name,
+			Version: component.Version,
+			Type:    purlType(component.PURL, component.Type),
+			PURL:    component.PURL,
+		}
+		if seen[pkg] {
+			continue
+		}
+		seen[pkg] = true
+		packages = append(packages, pkg)
+	}
+
+	sortPackages(packages)
+	return packages, nil
+}
+
+// purlType returns the type of a package URL such as "pkg:npm/left-pad@1.3.0",
+// or fallback when there is none.
+func purlType(purl, fallback string) string {
+	rest, ok := strings.CutPrefix(purl, "pkg:")
+	if !ok {
+		return fallback
+	}
+	typ, _, ok := strings.Cut(rest, "/")
+	if !ok {
+		return fallback
+	}
+	return typ
+}
+
+// Compare returns what changed from the packages in from to those in to.
+// A package present in a single version on both sides is reported as
+// changed; when several versions are installed, the versions missing on
+// either side are reported as added or removed.
+func Compare(from, to []Package) Diff {
+	before := groupVersions(from)
+	after := groupVersions(to)
+	diff := Diff{Added: []Package{}, Removed: []Package{}, Changed: []Change{}}
+
+	for key, old := range before {
+		current, ok := after[key]
+		if !ok {
+			diff.Removed = append(diff.Removed, old...)
+			continue
+		}
+		if len(old) == 1 && len(current) == 1 {
+			if old[0].Version != current[0].Version {
+				diff.Changed = append(diff.Changed, Change{
+					Name: old[0].Name,
+					Type: old[0].Type,
+					From: old[0].Version,
+					To:   current[0].Version,
+				})
+			}
+			continue
+		}
+		diff.Removed = append(diff.Removed, missingVersions(old, current)...)
+		diff.Added = append(diff.Added, missingVersions(current, old)...)
+	}
+	for key, current := range after {
+		if _, ok := before[key]; !ok {
+			diff.Added = append(diff.Added, current...)
+		}
+	}
+
+	sortPackages(diff.Added)
+	sortPackages(diff.Removed)
+	sort.Slice(diff.Changed, func(i, j int) bool {
+		if diff.Changed[i].Type != diff.Changed[j].Type {
+			return diff.Changed[i].Type < diff.Changed[j].Type
+		}
+		return diff.Changed[i].Name < diff.Changed[j].Name
+	})
+	return diff
+}
+
+func groupVersions(packages []Package) map[string][]Package {
+	groups := make(map[string][]Package)
+	for _, pkg := range packages {
+		key := pkg.Type + "/" + pkg.Name
+		groups[key] = append(groups[key], pkg)
+	}
+	return groups
+}
+
+// missingVersions returns the packages of a whose version is not in b.
+func missingVersions(a, b []Package) []Package {
+	var missing []Package
+	for _, pkg := range a {
+		found := false
+		for _, other := range b {
+			if other.Version == pkg.Version {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, pkg)
+		}
+	}
+	return missing
+}
+
+func sortPackages(packages []Package) {
+	sort.Slice(packages, func(i, j int) bool {
+		a, b := packages[i], packages[j]
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.Version < b.Version
+	})
+}
+
+// Compress gzips a document for transfer from the agent.
+func Compress(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Decompress reverses Compress.
+func Decompress(data []byte) ([]byte, error) {
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+	return io.ReadAll(zr)
+}
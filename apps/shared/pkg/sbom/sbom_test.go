@@ -0,0 +1,77 @@
+package sbom
+
+import (
+	"reflect"
+	"testing"
+)
+
+const testDocument = `{
+  "bomFormat": "CycloneDX",
+  "components": [
+    {"type": "library", "name": "left-pad", "version": "1.3.0", "purl": "pkg:npm/left-pad@1.3.0"},
+    {"type": "library", "name": "musl", "version": "1.2.5-r0", "purl": "pkg:apk/alpine/musl@1.2.5-r0"},
+    {"type": "library", "name": "left-pad", "version": "1.3.0", "purl": "pkg:npm/left-pad@1.3.0"},
+    {"type": "file", "name": "/usr/lib/libc.so"},
+    {"type": "operating-system", "name": "alpine", "version": "3.21.0"}
+  ]
+}`
+
+func TestPackages(t *testing.T) {
+	got, err := Packages([]byte(testDocument))
+	if err != nil {
+		t.Fatalf("Packages: %v", err)
+	}
+	want := []Package{
+		{Name: "musl", Version: "1.2.5-r0", Type: "apk", PURL: "pkg:apk/alpine/musl@1.2.5-r0"},
+		{Name: "left-pad", Version: "1.3.0", Type: "npm", PURL: "pkg:npm/left-pad@1.3.0"},
+		{Name: "alpine", Version: "3.21.0", Type: "operating-system"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Packages() = %+v, want %+v", got, want)
+	}
+
+	if _, err := Packages([]byte("not json")); err == nil {
+		t.Error("expected error for invalid document")
+	}
+}
+
+func TestCompare(t *testing.T) {
+	from := []Package{
+		{Name: "express", Version: "4.18.2", Type: "npm"},
+		{Name: "debug", Version: "2.6.9", Type: "npm"},
+		{Name: "debug", Version: "4.3.4", Type: "npm"},
+		{Name: "request", Version: "2.88.2", Type: "npm"},
+	}
+	to := []Package{
+		{Name: "express", Version: "4.19.2", Type: "npm"},
+		{Name: "debug", Version: "4.3.4", Type: "npm"},
+		{Name: "undici", Version: "6.0.0", Type: "npm"},
+	}
+
+	got := Compare(from, to)
+	want := Diff{
+		Added: []Package{{Name: "undici", Version: "6.0.0", Type: "npm"}},
+		Removed: []Package{
+			{Name: "debug", Version: "2.6.9", Type: "npm"},
+			{Name: "request", Version: "2.88.2", Type: "npm"},
+		},
+		Changed: []Change{{Name: "express", Type: "npm", From: "4.18.2", To: "4.19.2"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Compare() = %+v, want %+v", got, want)
+	}
+}
+
+func TestCompressRoundTrip(t *testing.T) {
+	compressed, err := Compress([]byte(testDocument))
+	if err != nil {
+		t.Fatalf("Compress: %v", err)
+	}
+	got, err := Decompress(compressed)
+	if err != nil {
+		t.Fatalf("Decompress: %v", err)
+	}
+	if string(got) != testDocument {
+		t.Error("round trip changed the document")
+	}
+}
@@ -0,0 +1,78 @@
+// Package tracing carries the ID of the request that started a piece of work
+// through contexts, log lines and calls between the backend and the agents.
+package tracing
+
+import (
+	"context"
+	"log/slog"
+)
+
+const (
+	// MetadataKey is the gRPC metadata key carrying the trace ID.
+	MetadataKey = "x-trace-id"
+	// LogKey is the log attribute holding the trace ID.
+	LogKey = "traceId"
+
+	maxIDLength = 128
+)
+
+// Key is the context key of the trace ID. It is exported so that request
+// values stored outside context.WithValue, like fasthttp user values, can
+// use it as well.
+type Key struct{}
+
+func WithID(ctx context.Context, id string) context.Context {
+	if id == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, Key{}, id)
+}
+
+func ID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(Key{}).(string)
+	return id
+}
+
+// ValidID reports whether an ID received from a client or a peer is safe to
+// reuse in logs and headers.
+func ValidID(id string) bool {
+	if id == "" || len(id) > maxIDLength {
+		return false
+	}
+	for _, r := range id {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == '-', r == '_', r == '.':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// Handler adds the trace ID of the record's context to every log line.
+type Handler struct {
+	slog.Handler
+}
+
+func NewHandler(h slog.Handler) *Handler {
+	return &Handler{Handler: h}
+}
+
+func (h *Handler) Handle(ctx context.Context, r slog.Record) error {
+	if id := ID(ctx); id != "" {
+		r.AddAttrs(slog.String(LogKey, id))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &Handler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h *Handler) WithGroup(name string) slog.Handler {
+	return &Handler{Handler: h.Handler.WithGroup(name)}
+}
@@ -0,0 +1,52 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestValidID(t *testing.T) {
+	tests := map[string]bool{
+		"":                                     false,
+		"3f1c2a9e-8d4b-4c1e-9f0a-1b2c3d4e5f60": true,
+		"req_1.2":                              true,
+		"bad id":                               false,
+		"bad\nid":                              false,
+		strings.Repeat("a", maxIDLength+1):     false,
+	}
+	for id, want := range tests {
+		if got := ValidID(id); got != want {
+			t.Errorf("ValidID(%q) = %v, want %v", id, got, want)
+		}
+	}
+}
+
+func TestHandlerAddsTraceID(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(NewHandler(slog.NewJSONHandler(&buf, nil))).With("component", "test")
+
+	logger.InfoContext(WithID(context.Background(), "trace-1"), "hello")
+	logger.InfoContext(context.Background(), "no trace")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d log lines, want 2", len(lines))
+	}
+	var first, second map[string]any
+	if err := json.Unmarshal([]byte(lines[0]), &first); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(lines[1]), &second); err != nil {
+		t.Fatal(err)
+	}
+	if first[LogKey] != "trace-1" {
+		t.Errorf("traceId = %v, want trace-1", first[LogKey])
+	}
+	if _, ok := second[LogKey]; ok {
+		t.Errorf("unexpected traceId in %s", lines[1])
+	}
+}
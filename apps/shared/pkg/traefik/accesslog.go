@@ -0,0 +1,99 @@
+package traefik
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"math"
+	"net"
+	"sort"
+	"strings"
+	"time"
+)
+
+// accessLogEntry is the subset of a Traefik JSON access log line used for
+// analytics. Duration is in nanoseconds.
+type accessLogEntry struct {
+	RequestHost      string    `json:"RequestHost"`
+	DownstreamStatus int       `json:"DownstreamStatus"`
+	Duration         int64     `json:"Duration"`
+	StartUTC         time.Time `json:"StartUTC"`
+}
+
+// DomainStats aggregates the requests Traefik served for one host.
+type DomainStats struct {
+	Domain      string
+	Requests    int64
+	StatusCodes map[int]int64
+	P95Latency  time.Duration
+}
+
+// AggregateAccessLog reads a JSON access log and returns per-host stats for
+// requests started at or after since, sorted by domain. Only hosts in domains
+// are kept unless it is empty. Lines that are not valid JSON are skipped.
+func AggregateAccessLog(r io.Reader, domains []string, since time.Time) ([]DomainStats, error) {
+	wanted := make(map[string]bool, len(domains))
+	for _, d := range domains {
+		wanted[strings.ToLower(d)] = true
+	}
+
+	stats := map[string]*DomainStats{}
+	latencies := map[string][]time.Duration{}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var entry accessLogEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			continue
+		}
+		if entry.StartUTC.Before(since) {
+			continue
+		}
+		host := normalizeRequestHost(entry.RequestHost)
+		if host == "" || (len(wanted) > 0 && !wanted[host]) {
+			continue
+		}
+
+		s, ok := stats[host]
+		if !ok {
+			s = &DomainStats{Domain: host, StatusCodes: map[int]int64{}}
+			stats[host] = s
+		}
+		s.Requests++
+		s.StatusCodes[entry.DownstreamStatus]++
+		latencies[host] = append(latencies[host], time.Duration(entry.Duration))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	result := make([]DomainStats, 0, len(stats))
+	for host, s := range stats {
+		s.P95Latency = percentile(latencies[host], 0.95)
+		result = append(result, *s)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Domain < result[j].Domain })
+	return result, nil
+}
+
+func normalizeRequestHost(host string) string {
+	host = strings.ToLower(strings.TrimSpace(host))
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		return h
+	}
+	return host
+}
+
+// percentile uses the nearest-rank method.
+func percentile(values []time.Duration, p float64) time.Duration {
+	if len(values) == 0 {
+		return 0
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+	rank := int(math.Ceil(p*float64(len(values)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	return values[rank]
+}
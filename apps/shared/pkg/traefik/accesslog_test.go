@@ -0,0 +1,59 @@
+package traefik
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func accessLogLine(host string, status int, duration time.Duration, start time.Time) string {
+	return fmt.Sprintf(`{"RequestHost":%q,"DownstreamStatus":%d,"Duration":%d,"StartUTC":%q}`,
+		host, status, duration.Nanoseconds(), start.UTC().Format(time.RFC3339Nano))
+}
+
+func TestAggregateAccessLog(t *testing.T) {
+	now := time.Now()
+	var lines []string
+	for i := 1; i <= 20; i++ {
+		lines = append(lines, accessLogLine("app.example.com", 200, time.Duration(i)*time.Millisecond, now))
+	}
+	lines = append(lines,
+		accessLogLine("APP.example.com:443", 502, 100*time.Millisecond, now),
+		accessLogLine("app.example.com", 200, time.Second, now.Add(-2*time.Hour)),
+		accessLogLine("other.example.com", 404, time.Millisecond, now),
+		"not json",
+	)
+
+	stats, err := AggregateAccessLog(strings.NewReader(strings.Join(lines, "\n")), []string{"app.example.com"}, now.Add(-time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stats) != 1 {
+		t.Fatalf("expected stats for one domain, got %+v", stats)
+	}
+	s := stats[0]
+	if s.Domain != "app.example.com" || s.Requests != 21 {
+		t.Errorf("unexpected totals %+v", s)
+	}
+	if s.StatusCodes[200] != 20 || s.StatusCodes[502] != 1 {
+		t.Errorf("unexpected status codes %v", s.StatusCodes)
+	}
+	if s.P95Latency != 20*time.Millisecond {
+		t.Errorf("P95Latency = %s, want 20ms", s.P95Latency)
+	}
+}
+
+func TestAggregateAccessLogAllDomains(t *testing.T) {
+	now := time.Now()
+	log := accessLogLine("b.example.com", 200, time.Millisecond, now) + "\n" +
+		accessLogLine("a.example.com", 200, time.Millisecond, now)
+
+	stats, err := AggregateAccessLog(strings.NewReader(log), nil, time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stats) != 2 || stats[0].Domain != "a.example.com" || stats[1].Domain != "b.example.com" {
+		t.Errorf("expected both domains sorted, got %+v", stats)
+	}
+}
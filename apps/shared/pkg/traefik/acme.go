@@ -0,0 +1,132 @@
+package traefik
+
+import (
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// AcmeCertificate is a certificate stored by a Traefik ACME resolver.
+type AcmeCertificate struct {
+	Resolver  string
+	Domain    string
+	Sans      []string
+	NotBefore time.Time
+	NotAfter  time.Time
+	Issuer    string
+}
+
+type acmeResolver struct {
+	Account      json.RawMessage   `json:"Account"`
+	Certificates []json.RawMessage `json:"Certificates"`
+}
+
+type acmeCertificateEntry struct {
+	Domain struct {
+		Main string   `json:"main"`
+		Sans []string `json:"sans"`
+	} `json:"domain"`
+	Certificate string `json:"certificate"`
+}
+
+func parseAcmeResolvers(data []byte) (map[string]*acmeResolver, error) {
+	resolvers := map[string]*acmeResolver{}
+	if len(strings.TrimSpace(string(data))) == 0 {
+		return resolvers, nil
+	}
+	if err := json.Unmarshal(data, &resolvers); err != nil {
+		return nil, fmt.Errorf("parse acme storage: %w", err)
+	}
+	return resolvers, nil
+}
+
+// ParseAcmeStore lists the certificates in a Traefik acme.json file. Entries
+// whose certificate cannot be decoded are returned without validity dates.
+func ParseAcmeStore(data []byte) ([]AcmeCertificate, error) {
+	resolvers, err := parseAcmeResolvers(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var certs []AcmeCertificate
+	for name, resolver := range resolvers {
+		if resolver == nil {
+			continue
+		}
+		for _, raw := range resolver.Certificates {
+			var entry acmeCertificateEntry
+			if err := json.Unmarshal(raw, &entry); err != nil {
+				continue
+			}
+			cert := AcmeCertificate{Resolver: name, Domain: entry.Domain.Main, Sans: entry.Domain.Sans}
+			if leaf := decodeAcmeCertificate(entry.Certificate); leaf != nil {
+				cert.NotBefore = leaf.NotBefore
+				cert.NotAfter = leaf.NotAfter
+				cert.Issuer = leaf.Issuer.CommonName
+			}
+			certs = append(certs, cert)
+		}
+	}
+	return certs, nil
+}
+
+// RemoveAcmeCertificates drops the certificates whose main domain is in
+// domains and returns the rewritten file. Accounts and unknown fields are
+// kept as they are so Traefik can keep using its ACME account.
+func RemoveAcmeCertificates(data []byte, domains []string) ([]byte, int, error) {
+	resolvers, err := parseAcmeResolvers(data)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	remove := make(map[string]bool, len(domains))
+	for _, d := range domains {
+		remove[strings.ToLower(d)] = true
+	}
+
+	removed := 0
+	for _, resolver := range resolvers {
+		if resolver == nil {
+			continue
+		}
+		kept := resolver.Certificates[:0]
+		for _, raw := range resolver.Certificates {
+			var entry acmeCertificateEntry
+			if err := json.Unmarshal(raw, &entry); err == nil && remove[strings.ToLower(entry.Domain.Main)] {
+				removed++
+				continue
+			}
+			kept = append(kept, raw)
+		}
+		resolver.Certificates = kept
+	}
+
+	if removed == 0 {
+		return data, 0, nil
+	}
+	out, err := json.MarshalIndent(resolvers, "", "  ")
+	if err != nil {
+		return nil, 0, err
+	}
+	return out, removed, nil
+}
+
+func decodeAcmeCertificate(encoded string) *x509.Certificate {
+	pemBytes, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil
+	}
+	block, _ := pem.Decode(pemBytes)
+	if block == nil {
+		return nil
+	}
+	leaf, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil
+	}
+	return leaf
+}
@@ -0,0 +1,131 @@
+package traefik
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func testAcmeCertificate(t *testing.T, domain string, notAfter time.Time) string {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: domain},
+		Issuer:       pkix.Name{CommonName: domain},
+		DNSNames:     []string{domain},
+		NotBefore:    notAfter.Add(-90 * 24 * time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return base64.StdEncoding.EncodeToString(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+func testAcmeStore(t *testing.T, notAfter time.Time) []byte {
+	t.Helper()
+	store := map[string]any{
+		"letsencrypt": map[string]any{
+			"Account": map[string]any{"Email": "ops@example.com"},
+			"Certificates": []any{
+				map[string]any{
+					"domain":      map[string]any{"main": "app.example.com"},
+					"certificate": testAcmeCertificate(t, "app.example.com", notAfter),
+					"key":         "a2V5",
+					"Store":       "default",
+				},
+				map[string]any{
+					"domain":      map[string]any{"main": "old.example.com", "sans": []string{"www.old.example.com"}},
+					"certificate": "not-base64",
+					"key":         "a2V5",
+					"Store":       "default",
+				},
+			},
+		},
+	}
+	data, err := json.Marshal(store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestParseAcmeStore(t *testing.T) {
+	notAfter := time.Now().Add(30 * 24 * time.Hour).Truncate(time.Second).UTC()
+	certs, err := ParseAcmeStore(testAcmeStore(t, notAfter))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(certs) != 2 {
+		t.Fatalf("expected 2 certificates, got %d", len(certs))
+	}
+
+	byDomain := map[string]AcmeCertificate{}
+	for _, c := range certs {
+		byDomain[c.Domain] = c
+	}
+	app := byDomain["app.example.com"]
+	if app.Resolver != "letsencrypt" || !app.NotAfter.Equal(notAfter) {
+		t.Errorf("unexpected certificate: %+v", app)
+	}
+	old := byDomain["old.example.com"]
+	if !old.NotAfter.IsZero() || len(old.Sans) != 1 {
+		t.Errorf("undecodable certificate should be listed without dates: %+v", old)
+	}
+}
+
+func TestParseAcmeStoreEmpty(t *testing.T) {
+	certs, err := ParseAcmeStore(nil)
+	if err != nil || len(certs) != 0 {
+		t.Fatalf("expected no certificates, got %v, %v", certs, err)
+	}
+}
+
+func TestRemoveAcmeCertificates(t *testing.T) {
+	data := testAcmeStore(t, time.Now().Add(time.Hour))
+
+	out, removed, err := RemoveAcmeCertificates(data, []string{"OLD.example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if removed != 1 {
+		t.Fatalf("expected 1 removed, got %d", removed)
+	}
+
+	certs, err := ParseAcmeStore(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(certs) != 1 || certs[0].Domain != "app.example.com" {
+		t.Errorf("unexpected remaining certificates: %+v", certs)
+	}
+
+	var store map[string]map[string]json.RawMessage
+	if err := json.Unmarshal(out, &store); err != nil {
+		t.Fatal(err)
+	}
+	if string(store["letsencrypt"]["Account"]) == "null" {
+		t.Error("account should be preserved")
+	}
+}
+
+func TestRemoveAcmeCertificatesNoMatch(t *testing.T) {
+	data := testAcmeStore(t, time.Now().Add(time.Hour))
+	out, removed, err := RemoveAcmeCertificates(data, []string{"missing.example.com"})
+	if err != nil || removed != 0 || string(out) != string(data) {
+		t.Errorf("expected unchanged store, got removed=%d err=%v", removed, err)
+	}
+}